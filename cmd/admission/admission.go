@@ -21,16 +21,21 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
 	admissionapi "k8s.io/api/admission/v1beta1"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	prowjobv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
 
 	prowjobscheme "sigs.k8s.io/prow/pkg/client/clientset/versioned/scheme"
 )
@@ -78,21 +83,25 @@ func readRequest(r io.Reader, contentType string) (*admissionapi.AdmissionReques
 	return ar.Request, nil
 }
 
-// handle reads the request and writes the response
-func handle(w http.ResponseWriter, r *http.Request) {
-	req, err := readRequest(r.Body, r.Header.Get("Content-Type"))
-	if err != nil {
-		logrus.WithError(err).Error("read")
-	}
+// handler reads the request and writes the response of the given decider
+func handler(decide decider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := readRequest(r.Body, r.Header.Get("Content-Type"))
+		if err != nil {
+			logrus.WithError(err).Error("read")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-	if err := writeResponse(*req, w, onlyUpdateStatus); err != nil {
-		logrus.WithError(err).Error("write")
+		if err := writeResponse(*req, w, decide); err != nil {
+			logrus.WithError(err).Error("write")
+		}
 	}
 }
 
 type decider func(admissionapi.AdmissionRequest) (*admissionapi.AdmissionResponse, error)
 
-// writeResponse gets the response from onlyUpdateStatus and writes it to w.
+// writeResponse gets the response from the decider and writes it to w.
 func writeResponse(ar admissionapi.AdmissionRequest, w io.Writer, decide decider) error {
 	response, err := decide(ar)
 	if err != nil {
@@ -160,3 +169,188 @@ func onlyUpdateStatus(req admissionapi.AdmissionRequest) (*admissionapi.Admissio
 	logger.Info("reject") // no
 	return &reject, nil
 }
+
+// enforceJobPolicies returns a decider that rejects the creation of ProwJobs
+// violating any of the configured job policies.
+func enforceJobPolicies(cfg config.Getter) decider {
+	return func(req admissionapi.AdmissionRequest) (*admissionapi.AdmissionResponse, error) {
+		if req.Operation != admissionapi.Create {
+			return &allow, nil
+		}
+		var pj prowjobv1.ProwJob
+		if _, _, err := codecs.UniversalDeserializer().Decode(req.Object.Raw, nil, &pj); err != nil {
+			return nil, fmt.Errorf("decode new: %w", err)
+		}
+		violations, err := cfg().EvaluateJobPolicies(&pj)
+		if err != nil {
+			return nil, fmt.Errorf("evaluate job policies: %w", err)
+		}
+		if len(violations) == 0 {
+			return &allow, nil
+		}
+		messages := make([]string, 0, len(violations))
+		for _, violation := range violations {
+			messages = append(messages, violation.String())
+		}
+		logrus.WithFields(logrus.Fields{
+			"name":       req.Name,
+			"namespace":  req.Namespace,
+			"job":        pj.Spec.Job,
+			"violations": messages,
+		}).Info("reject job violating policies")
+		return &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonForbidden,
+				Message: fmt.Sprintf("ProwJob %s violates job policies: %s", pj.Spec.Job, strings.Join(messages, "; ")),
+			},
+		}, nil
+	}
+}
+
+// validateJobConfigs returns a decider that rejects JobConfig resources whose
+// job configuration is invalid or configures jobs the namespace of the
+// resource may not configure.
+func validateJobConfigs(cfg config.Getter) decider {
+	return func(req admissionapi.AdmissionRequest) (*admissionapi.AdmissionResponse, error) {
+		if req.Operation != admissionapi.Create && req.Operation != admissionapi.Update {
+			return &allow, nil
+		}
+		var jc prowjobv1.JobConfig
+		if _, _, err := codecs.UniversalDeserializer().Decode(req.Object.Raw, nil, &jc); err != nil {
+			return nil, fmt.Errorf("decode new: %w", err)
+		}
+		if jc.Namespace == "" {
+			jc.Namespace = req.Namespace
+		}
+		if err := cfg().ValidateJobConfigResource(&jc); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"name":      req.Name,
+				"namespace": req.Namespace,
+			}).Info("reject invalid JobConfig")
+			return &admissionapi.AdmissionResponse{
+				Result: &meta.Status{
+					Reason:  meta.StatusReasonInvalid,
+					Message: fmt.Sprintf("invalid JobConfig: %v", err),
+				},
+			}, nil
+		}
+		return &allow, nil
+	}
+}
+
+// enforceSecurityPolicies returns a decider that rejects the creation of
+// ProwJobs and pods violating the security policy of their tenant and sets
+// the seccomp profile of the policy on those that set none. The tenant is
+// derived from the repo and cluster of the job, pods are in buildCluster.
+func enforceSecurityPolicies(cfg config.Getter, buildCluster string) decider {
+	return func(req admissionapi.AdmissionRequest) (*admissionapi.AdmissionResponse, error) {
+		if req.Operation != admissionapi.Create {
+			return &allow, nil
+		}
+		var org, repo, cluster, name, specPath string
+		var spec, checked *corev1.PodSpec
+		switch req.Kind.Kind {
+		case "ProwJob":
+			var pj prowjobv1.ProwJob
+			if _, _, err := codecs.UniversalDeserializer().Decode(req.Object.Raw, nil, &pj); err != nil {
+				return nil, fmt.Errorf("decode new: %w", err)
+			}
+			if refs := pj.Spec.Refs; refs != nil {
+				org, repo = refs.Org, refs.Repo
+			} else if len(pj.Spec.ExtraRefs) > 0 {
+				org, repo = pj.Spec.ExtraRefs[0].Org, pj.Spec.ExtraRefs[0].Repo
+			}
+			cluster, name, spec, specPath = pj.Spec.Cluster, "ProwJob "+pj.Spec.Job, pj.Spec.PodSpec, "/spec/pod_spec"
+			checked = spec
+		case "Pod":
+			var pod corev1.Pod
+			if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
+				return nil, fmt.Errorf("decode new: %w", err)
+			}
+			org, repo = pod.Labels[kube.OrgLabel], pod.Labels[kube.RepoLabel]
+			cluster, name, spec, specPath = buildCluster, "Pod "+pod.Name, &pod.Spec, "/spec"
+			checked = spec
+			if pod.Labels[kube.CreatedByProw] == "true" {
+				var orgRepo string
+				if org != "" {
+					orgRepo = org + "/" + repo
+				}
+				images := cfg().Plank.GuessDefaultDecorationConfig(orgRepo, cluster).UtilityImages
+				checked = withoutUtilityContainers(spec, decorate.PodUtilsContainerImages(images))
+			}
+		default:
+			return &allow, nil
+		}
+		tenant, policy := cfg().TenantSecurityPolicy(org, repo, cluster)
+		if policy == nil || spec == nil {
+			return &allow, nil
+		}
+
+		if violations := policy.Violations(org, repo, checked); len(violations) > 0 {
+			logrus.WithFields(logrus.Fields{
+				"name":       req.Name,
+				"namespace":  req.Namespace,
+				"tenant":     tenant,
+				"violations": violations,
+			}).Info("reject resource violating the security policy")
+			return &admissionapi.AdmissionResponse{
+				Result: &meta.Status{
+					Reason:  meta.StatusReasonForbidden,
+					Message: fmt.Sprintf("%s violates the security policy of tenant %s: %s", name, tenant, strings.Join(violations, "; ")),
+				},
+			}, nil
+		}
+		if !policy.NeedsSeccompProfile(spec) {
+			return &allow, nil
+		}
+		patch, err := seccompProfilePatch(specPath, spec, policy.SeccompProfile)
+		if err != nil {
+			return nil, err
+		}
+		patchType := admissionapi.PatchTypeJSONPatch
+		return &admissionapi.AdmissionResponse{Allowed: true, Patch: patch, PatchType: &patchType}, nil
+	}
+}
+
+// withoutUtilityContainers returns the pod spec without the containers Prow
+// adds to decorated jobs, which are not subject to the security policies.
+// Utility containers are only recognized when they run the configured utility
+// image for their name, since any container may take their names.
+func withoutUtilityContainers(spec *corev1.PodSpec, images map[string]string) *corev1.PodSpec {
+	isUtility := func(container corev1.Container) bool {
+		image, ok := images[container.Name]
+		return ok && image != "" && image == container.Image
+	}
+	filtered := *spec
+	filtered.InitContainers, filtered.Containers = nil, nil
+	for _, container := range spec.InitContainers {
+		if !isUtility(container) {
+			filtered.InitContainers = append(filtered.InitContainers, container)
+		}
+	}
+	for _, container := range spec.Containers {
+		if !isUtility(container) {
+			filtered.Containers = append(filtered.Containers, container)
+		}
+	}
+	return &filtered
+}
+
+// seccompProfilePatch returns the JSON patch setting the seccomp profile of
+// the pod spec at the given path.
+func seccompProfilePatch(specPath string, spec *corev1.PodSpec, profile *corev1.SeccompProfile) ([]byte, error) {
+	type operation struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}
+	op := operation{Op: "add", Path: specPath + "/securityContext/seccompProfile", Value: profile}
+	if spec.SecurityContext == nil {
+		op = operation{Op: "add", Path: specPath + "/securityContext", Value: corev1.PodSecurityContext{SeccompProfile: profile}}
+	}
+	patch, err := json.Marshal([]operation{op})
+	if err != nil {
+		return nil, fmt.Errorf("encode patch: %w", err)
+	}
+	return patch, nil
+}
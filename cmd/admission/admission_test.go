@@ -22,12 +22,16 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	admissionapi "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	prowjobv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
 )
 
 func TestOnlyUpdateStatus(t *testing.T) {
@@ -302,3 +306,334 @@ func TestReadRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestEnforceJobPolicies(t *testing.T) {
+	cfg := &config.Config{ProwConfig: config.ProwConfig{JobPolicies: []config.JobPolicy{{
+		Name:       "decorated",
+		Expression: `has(job.decoration_config)`,
+		Message:    "jobs must be decorated",
+	}}}}
+	cases := []struct {
+		name      string
+		operation admissionapi.Operation
+		pj        prowjobv1.ProwJob
+		expected  *admissionapi.AdmissionResponse
+	}{{
+		name:      "allow compliant jobs",
+		operation: admissionapi.Create,
+		pj: prowjobv1.ProwJob{Spec: prowjobv1.ProwJobSpec{
+			Job:              "decorated-job",
+			DecorationConfig: &prowjobv1.DecorationConfig{},
+		}},
+		expected: &allow,
+	}, {
+		name:      "reject jobs violating a policy",
+		operation: admissionapi.Create,
+		pj:        prowjobv1.ProwJob{Spec: prowjobv1.ProwJobSpec{Job: "undecorated-job"}},
+		expected: &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonForbidden,
+				Message: "ProwJob undecorated-job violates job policies: decorated: jobs must be decorated",
+			},
+		},
+	}, {
+		name:      "allow updates of existing jobs",
+		operation: admissionapi.Update,
+		pj:        prowjobv1.ProwJob{Spec: prowjobv1.ProwJobSpec{Job: "undecorated-job"}},
+		expected:  &allow,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var req admissionapi.AdmissionRequest
+			var err error
+			req.Operation = tc.operation
+			req.Object.Raw, err = json.Marshal(tc.pj)
+			if err != nil {
+				t.Fatalf("encode job: %v", err)
+			}
+			actual, err := enforceJobPolicies(func() *config.Config { return cfg })(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("actual %#v != expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestValidateJobConfigs(t *testing.T) {
+	cfg := &config.Config{ProwConfig: config.ProwConfig{
+		ProwJobNamespace:   "prowjobs",
+		PodNamespace:       "pods",
+		JobConfigResources: &config.JobConfigResources{Namespaces: map[string][]string{"team-a": {"org"}}},
+	}}
+	const jobs = `
+presubmits:
+  org/repo:
+  - name: pull-unit
+    always_run: true
+    spec:
+      containers:
+      - image: alpine
+`
+	cases := []struct {
+		name      string
+		namespace string
+		expected  *admissionapi.AdmissionResponse
+	}{{
+		name:      "allow valid job config",
+		namespace: "team-a",
+		expected:  &allow,
+	}, {
+		name:      "reject jobs for repos the namespace may not configure",
+		namespace: "team-b",
+		expected: &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonInvalid,
+				Message: "invalid JobConfig: JobConfig team-b/jobs: job pull-unit: namespace team-b may not configure jobs for org/repo",
+			},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var req admissionapi.AdmissionRequest
+			var err error
+			req.Operation = admissionapi.Create
+			req.Namespace = tc.namespace
+			req.Object.Raw, err = json.Marshal(prowjobv1.JobConfig{
+				ObjectMeta: meta.ObjectMeta{Name: "jobs"},
+				Spec:       prowjobv1.JobConfigSpec{Config: jobs},
+			})
+			if err != nil {
+				t.Fatalf("encode job config: %v", err)
+			}
+			actual, err := validateJobConfigs(func() *config.Config { return cfg })(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("actual %#v != expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestEnforceSecurityPolicies(t *testing.T) {
+	cfg := &config.Config{ProwConfig: config.ProwConfig{
+		ProwJobDefaultEntries: []*config.ProwJobDefaultEntry{
+			{OrgRepo: "org", Config: &prowjobv1.ProwJobDefault{TenantID: "team-a"}},
+			{OrgRepo: "org-b", Config: &prowjobv1.ProwJobDefault{TenantID: "team-b"}},
+		},
+		Tenants: map[string]config.Tenant{
+			"team-a": {SecurityPolicy: &config.TenantSecurityPolicy{
+				PrivilegedRepos: []string{"org/privileged"},
+				SeccompProfile:  &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			}},
+			"team-b":               {},
+			config.DefaultTenantID: {SecurityPolicy: &config.TenantSecurityPolicy{}},
+		},
+		Plank: config.Plank{DefaultDecorationConfigs: []*config.DefaultDecorationConfigEntry{{
+			OrgRepo: "*",
+			Config: &prowjobv1.DecorationConfig{UtilityImages: &prowjobv1.UtilityImages{
+				InitUpload: "initupload:v1",
+				Sidecar:    "sidecar:v1",
+			}},
+		}}},
+	}}
+	privileged := true
+	jsonPatch := admissionapi.PatchTypeJSONPatch
+	prowJob := func(tenant, orgRepo string, spec *corev1.PodSpec) interface{} {
+		org, repo, _ := strings.Cut(orgRepo, "/")
+		return prowjobv1.ProwJob{Spec: prowjobv1.ProwJobSpec{
+			Job:            "job",
+			Cluster:        "default",
+			Refs:           &prowjobv1.Refs{Org: org, Repo: repo},
+			PodSpec:        spec,
+			ProwJobDefault: &prowjobv1.ProwJobDefault{TenantID: tenant},
+		}}
+	}
+	pod := func(tenant, orgRepo string, spec corev1.PodSpec) interface{} {
+		org, repo, _ := strings.Cut(orgRepo, "/")
+		return corev1.Pod{
+			ObjectMeta: meta.ObjectMeta{Name: "pod", Labels: map[string]string{kube.TenantIDLabel: tenant, kube.OrgLabel: org, kube.RepoLabel: repo}},
+			Spec:       spec,
+		}
+	}
+	prowPod := func(orgRepo string, spec corev1.PodSpec) interface{} {
+		p := pod("", orgRepo, spec).(corev1.Pod)
+		p.Labels[kube.CreatedByProw] = "true"
+		return p
+	}
+	privilegedSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "test", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}}}}
+	privilegedSidecarSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "initupload", Image: "initupload:v1", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}}},
+		Containers: []corev1.Container{
+			{Name: "test"},
+			{Name: "sidecar", Image: "sidecar:v1", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+		},
+		SecurityContext: &corev1.PodSecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}},
+	}
+	profiledSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "test"}}, SecurityContext: &corev1.PodSecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}}}
+
+	cases := []struct {
+		name      string
+		kind      string
+		operation admissionapi.Operation
+		object    interface{}
+		expected  *admissionapi.AdmissionResponse
+	}{{
+		name:      "allow jobs of tenants without a policy",
+		kind:      "ProwJob",
+		operation: admissionapi.Create,
+		object:    prowJob("", "org-b/repo", &privilegedSpec),
+		expected:  &allow,
+	}, {
+		name:      "reject privileged jobs outside allowlisted repos",
+		kind:      "ProwJob",
+		operation: admissionapi.Create,
+		object:    prowJob("", "org/repo", &privilegedSpec),
+		expected: &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonForbidden,
+				Message: "ProwJob job violates the security policy of tenant team-a: container test may not be privileged",
+			},
+		},
+	}, {
+		name:      "set seccomp profile on jobs of allowlisted repos",
+		kind:      "ProwJob",
+		operation: admissionapi.Create,
+		object:    prowJob("", "org/privileged", &privilegedSpec),
+		expected: &admissionapi.AdmissionResponse{
+			Allowed:   true,
+			Patch:     []byte(`[{"op":"add","path":"/spec/pod_spec/securityContext","value":{"seccompProfile":{"type":"RuntimeDefault"}}}]`),
+			PatchType: &jsonPatch,
+		},
+	}, {
+		name:      "allow jobs setting a seccomp profile",
+		kind:      "ProwJob",
+		operation: admissionapi.Create,
+		object:    prowJob("", "org/repo", &profiledSpec),
+		expected:  &allow,
+	}, {
+		name:      "allow jobs without pod spec",
+		kind:      "ProwJob",
+		operation: admissionapi.Create,
+		object:    prowJob("", "org/repo", nil),
+		expected:  &allow,
+	}, {
+		name:      "reject privileged pods outside allowlisted repos",
+		kind:      "Pod",
+		operation: admissionapi.Create,
+		object:    pod("", "org/repo", privilegedSpec),
+		expected: &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonForbidden,
+				Message: "Pod pod violates the security policy of tenant team-a: container test may not be privileged",
+			},
+		},
+	}, {
+		name:      "set seccomp profile on pods with a security context",
+		kind:      "Pod",
+		operation: admissionapi.Create,
+		object:    pod("", "org/repo", corev1.PodSpec{Containers: []corev1.Container{{Name: "test"}}, SecurityContext: &corev1.PodSecurityContext{}}),
+		expected: &admissionapi.AdmissionResponse{
+			Allowed:   true,
+			Patch:     []byte(`[{"op":"add","path":"/spec/securityContext/seccompProfile","value":{"type":"RuntimeDefault"}}]`),
+			PatchType: &jsonPatch,
+		},
+	}, {
+		name:      "ignore the tenant jobs declare themselves",
+		kind:      "ProwJob",
+		operation: admissionapi.Create,
+		object:    prowJob("team-b", "org/repo", &privilegedSpec),
+		expected: &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonForbidden,
+				Message: "ProwJob job violates the security policy of tenant team-a: container test may not be privileged",
+			},
+		},
+	}, {
+		name:      "apply the default policy to jobs of no tenant",
+		kind:      "ProwJob",
+		operation: admissionapi.Create,
+		object:    prowJob("team-b", "other/repo", &privilegedSpec),
+		expected: &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonForbidden,
+				Message: "ProwJob job violates the security policy of tenant GlobalDefaultID: container test may not be privileged",
+			},
+		},
+	}, {
+		name:      "ignore the tenant pods declare themselves",
+		kind:      "Pod",
+		operation: admissionapi.Create,
+		object:    pod("team-b", "org/repo", privilegedSpec),
+		expected: &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonForbidden,
+				Message: "Pod pod violates the security policy of tenant team-a: container test may not be privileged",
+			},
+		},
+	}, {
+		name:      "allow privileged utility containers in pods",
+		kind:      "Pod",
+		operation: admissionapi.Create,
+		object:    prowPod("org/repo", privilegedSidecarSpec),
+		expected:  &allow,
+	}, {
+		name:      "reject privileged utility containers in pods not created by Prow",
+		kind:      "Pod",
+		operation: admissionapi.Create,
+		object:    pod("", "org/repo", privilegedSidecarSpec),
+		expected: &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonForbidden,
+				Message: "Pod pod violates the security policy of tenant team-a: container initupload may not be privileged; container sidecar may not be privileged",
+			},
+		},
+	}, {
+		name:      "reject privileged containers named like utility containers with other images",
+		kind:      "Pod",
+		operation: admissionapi.Create,
+		object: prowPod("org/repo", corev1.PodSpec{
+			Containers:      []corev1.Container{{Name: "sidecar", Image: "alpine", SecurityContext: &corev1.SecurityContext{Privileged: &privileged}}},
+			SecurityContext: &corev1.PodSecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}},
+		}),
+		expected: &admissionapi.AdmissionResponse{
+			Result: &meta.Status{
+				Reason:  meta.StatusReasonForbidden,
+				Message: "Pod pod violates the security policy of tenant team-a: container sidecar may not be privileged",
+			},
+		},
+	}, {
+		name:      "allow updates of existing pods",
+		kind:      "Pod",
+		operation: admissionapi.Update,
+		object:    pod("", "org/repo", privilegedSpec),
+		expected:  &allow,
+	}}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var req admissionapi.AdmissionRequest
+			var err error
+			req.Operation = tc.operation
+			req.Kind.Kind = tc.kind
+			req.Object.Raw, err = json.Marshal(tc.object)
+			if err != nil {
+				t.Fatalf("encode object: %v", err)
+			}
+			actual, err := enforceSecurityPolicies(func() *config.Config { return cfg }, "default")(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("actual %#v != expected %#v", actual, tc.expected)
+			}
+		})
+	}
+}
@@ -29,7 +29,9 @@ import (
 	"sigs.k8s.io/prow/pkg/pjutil/pprof"
 
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/pjutil"
 )
@@ -37,6 +39,8 @@ import (
 type options struct {
 	cert                   string
 	privateKey             string
+	buildCluster           string
+	config                 configflagutil.ConfigOptions
 	instrumentationOptions prowflagutil.InstrumentationOptions
 }
 
@@ -51,6 +55,8 @@ func parseOptions() options {
 func (o *options) parse(flags *flag.FlagSet, args []string) error {
 	flags.StringVar(&o.cert, "tls-cert-file", "", "Path to x509 certificate for HTTPS")
 	flags.StringVar(&o.privateKey, "tls-private-key-file", "", "Path to matching x509 private key.")
+	flags.StringVar(&o.buildCluster, "build-cluster", kube.DefaultClusterAlias, "Alias of the build cluster whose pods are admitted, used to find the tenant of the pods.")
+	o.config.AddFlags(flags)
 	o.instrumentationOptions.AddFlags(flags)
 	if err := flags.Parse(args); err != nil {
 		return fmt.Errorf("parse flags: %w", err)
@@ -58,7 +64,7 @@ func (o *options) parse(flags *flag.FlagSet, args []string) error {
 	if len(o.cert) == 0 || len(o.privateKey) == 0 {
 		return errors.New("Both --tls-cert-file and --tls-private-key-file are required for HTTPS")
 	}
-	return nil
+	return o.config.ValidateConfigOptional()
 }
 
 func main() {
@@ -72,7 +78,18 @@ func main() {
 	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort)
 
 	admissionMux := http.NewServeMux()
-	admissionMux.HandleFunc("/validate", handle)
+	admissionMux.HandleFunc("/validate", handler(onlyUpdateStatus))
+	// Job policies, JobConfig resources and tenant security policies are only
+	// enforced when a config is provided.
+	if o.config.ConfigPath != "" {
+		configAgent, err := o.config.ConfigAgent()
+		if err != nil {
+			logrus.WithError(err).Fatal("Error starting config agent.")
+		}
+		admissionMux.HandleFunc("/policy", handler(enforceJobPolicies(configAgent.Config)))
+		admissionMux.HandleFunc("/jobconfig", handler(validateJobConfigs(configAgent.Config)))
+		admissionMux.HandleFunc("/security", handler(enforceSecurityPolicies(configAgent.Config, o.buildCluster)))
+	}
 	s := http.Server{
 		Addr: ":8443",
 		TLSConfig: &tls.Config{
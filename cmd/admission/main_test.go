@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 )
 
 func TestOptions(t *testing.T) {
@@ -41,11 +42,22 @@ func TestOptions(t *testing.T) {
 			name: "works with both private/pub",
 			args: []string{"--tls-cert-file=c", "--tls-private-key-file=k"},
 			expected: &options{
-				cert:                   "c",
-				privateKey:             "k",
+				cert:         "c",
+				privateKey:   "k",
+				buildCluster: "default",
+				config: configflagutil.ConfigOptions{
+					ConfigPathFlagName:                    "config-path",
+					JobConfigPathFlagName:                 "job-config-path",
+					SupplementalProwConfigsFileNameSuffix: "_prowconfig.yaml",
+					InRepoConfigCacheSize:                 200,
+				},
 				instrumentationOptions: prowflagutil.DefaultInstrumentationOptions(),
 			},
 		},
+		{
+			name: "job config requires config",
+			args: []string{"--tls-cert-file=c", "--tls-private-key-file=k", "--job-config-path=j"},
+		},
 		{
 			name: "defaults error",
 		}}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// artifact-retention deletes the artifacts of old builds from the job
+// buckets or moves them to cold storage, as configured by the
+// artifact_retention section of the Prow config.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/artifactretention"
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+)
+
+type options struct {
+	config                 configflagutil.ConfigOptions
+	storage                prowflagutil.StorageClientOptions
+	instrumentationOptions prowflagutil.InstrumentationOptions
+	dryRun                 bool
+	runOnce                bool
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	o := options{config: configflagutil.ConfigOptions{ConfigPath: "/etc/config/config.yaml"}}
+	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to delete or move artifacts.")
+	fs.BoolVar(&o.runOnce, "run-once", false, "If true, run only once then quit.")
+	for _, group := range []prowflagutil.OptionGroup{&o.config, &o.storage, &o.instrumentationOptions} {
+		group.AddFlags(fs)
+	}
+	fs.Parse(args)
+	return o
+}
+
+func (o *options) Validate() error {
+	for _, group := range []prowflagutil.OptionGroup{&o.config, &o.storage, &o.instrumentationOptions} {
+		if err := group.Validate(o.dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	defer interrupts.WaitForGracefulShutdown()
+
+	pprof.Instrument(o.instrumentationOptions)
+
+	configAgent, err := o.config.ConfigAgent()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error starting config agent.")
+	}
+	cfg := configAgent.Config
+
+	metrics.ExposeMetrics("artifact-retention", cfg().PushGateway, o.instrumentationOptions.MetricsPort)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	opener, err := o.storage.StorageClient(ctx)
+	if err != nil {
+		logrus.WithError(err).Fatal("Cannot create opener")
+	}
+
+	c := artifactretention.NewController(cfg, opener, o.dryRun)
+	sync := func() {
+		start := time.Now()
+		if err := c.Sync(interrupts.Context()); err != nil {
+			logrus.WithError(err).Error("Error applying artifact retention policies.")
+		}
+		logrus.WithField("duration", time.Since(start).String()).Info("Applied artifact retention policies.")
+	}
+	if o.runOnce {
+		sync()
+		return
+	}
+	interrupts.Tick(sync, func() time.Duration {
+		if retention := cfg().ArtifactRetention; retention != nil {
+			return retention.ResyncPeriod.Duration
+		}
+		return time.Hour
+	})
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestGatherOptions(t *testing.T) {
+	cases := []struct {
+		name           string
+		args           []string
+		expectedDryRun bool
+		expectedCreds  string
+	}{
+		{
+			name:           "dry run by default",
+			expectedDryRun: true,
+		},
+		{
+			name:          "storage credentials and dry run can be set",
+			args:          []string{"--dry-run=false", "--gcs-credentials-file=/creds"},
+			expectedCreds: "/creds",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			o := gatherOptions(flag.NewFlagSet("artifact-retention", flag.ContinueOnError), tc.args...)
+			if o.dryRun != tc.expectedDryRun {
+				t.Errorf("expected dry run %t, got %t", tc.expectedDryRun, o.dryRun)
+			}
+			if o.storage.GCSCredentialsFile != tc.expectedCreds {
+				t.Errorf("expected GCS credentials %q, got %q", tc.expectedCreds, o.storage.GCSCredentialsFile)
+			}
+			if o.config.ConfigPath != "/etc/config/config.yaml" {
+				t.Errorf("expected default config path, got %q", o.config.ConfigPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// contextsCommentMarker identifies the comment listing the context changes of
+// a PR, so that it is edited rather than posted again.
+const contextsCommentMarker = "<!-- branchprotector: required contexts -->"
+
+var pullRequestRe = regexp.MustCompile(`^([^/]+)/([^/#]+)#(\d+)$`)
+
+// ContextChange is how the required status contexts of a branch change from
+// the base config to the config under review.
+type ContextChange struct {
+	Org     string
+	Repo    string
+	Branch  string
+	Added   []string
+	Removed []string
+}
+
+// contextsRecorder collects the changes to the required contexts of the
+// branches the protector walks, compared to a base config.
+type contextsRecorder struct {
+	base    *config.Config
+	lock    sync.Mutex
+	changes []ContextChange
+}
+
+func newContextsRecorder(base *config.Config) *contextsRecorder {
+	return &contextsRecorder{base: base}
+}
+
+// recordBranch records how the required contexts of the branch change from
+// the base config to cfg, if they do.
+func (c *contextsRecorder) recordBranch(cfg *config.Config, org, repo, branchName string, branch config.Branch, protected bool) error {
+	desired, err := requiredContexts(cfg, org, repo, branchName, branch, protected)
+	if err != nil {
+		return err
+	}
+	baseBranch, err := c.base.BranchProtection.GetOrg(org).GetRepo(repo).GetBranch(branchName)
+	if err != nil {
+		return fmt.Errorf("get %s in the base config: %w", branchName, err)
+	}
+	current, err := requiredContexts(c.base, org, repo, branchName, *baseBranch, protected)
+	if err != nil {
+		return fmt.Errorf("base config: %w", err)
+	}
+	added, removed := desired.Difference(current), current.Difference(desired)
+	if added.Len() == 0 && removed.Len() == 0 {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.changes = append(c.changes, ContextChange{
+		Org:     org,
+		Repo:    repo,
+		Branch:  branchName,
+		Added:   sets.List(added),
+		Removed: sets.List(removed),
+	})
+	return nil
+}
+
+// finish returns the recorded changes sorted by branch.
+func (c *contextsRecorder) finish() []ContextChange {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	sort.Slice(c.changes, func(i, j int) bool {
+		a, b := c.changes[i], c.changes[j]
+		if a.Org != b.Org {
+			return a.Org < b.Org
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Branch < b.Branch
+	})
+	return c.changes
+}
+
+// requiredContexts returns the contexts the config requires on the branch.
+// Unmanaged and unprotected branches require none.
+func requiredContexts(cfg *config.Config, org, repo, branchName string, branch config.Branch, protected bool) (sets.Set[string], error) {
+	if branch.Unmanaged != nil && *branch.Unmanaged {
+		return sets.New[string](), nil
+	}
+	bp, err := cfg.GetPolicy(org, repo, branchName, branch, cfg.GetPresubmitsStatic(org+"/"+repo), &protected)
+	if err != nil {
+		return nil, fmt.Errorf("get policy: %w", err)
+	}
+	if bp == nil || bp.Protect == nil || !*bp.Protect || bp.RequiredStatusChecks == nil {
+		return sets.New[string](), nil
+	}
+	return sets.New[string](bp.RequiredStatusChecks.Contexts...), nil
+}
+
+// formatContextChanges renders the changes as a Markdown comment.
+func formatContextChanges(changes []ContextChange) string {
+	var b strings.Builder
+	b.WriteString(contextsCommentMarker + "\n")
+	if len(changes) == 0 {
+		b.WriteString("This change does not change the status contexts required by branch protection.\n")
+		return b.String()
+	}
+	b.WriteString("Once applied by branchprotector, this change will update the status contexts required by branch protection:\n\n")
+	b.WriteString("| Branch | Added | Removed |\n| --- | --- | --- |\n")
+	for _, c := range changes {
+		fmt.Fprintf(&b, "| %s/%s=%s | %s | %s |\n", c.Org, c.Repo, c.Branch, formatContexts(c.Added), formatContexts(c.Removed))
+	}
+	return b.String()
+}
+
+func formatContexts(contexts []string) string {
+	var quoted []string
+	for _, context := range contexts {
+		quoted = append(quoted, "`"+strings.ReplaceAll(context, "|", `\|`)+"`")
+	}
+	return strings.Join(quoted, "<br>")
+}
+
+// parsePullRequest parses a pull request of the form org/repo#number.
+func parsePullRequest(pr string) (string, string, int, error) {
+	match := pullRequestRe.FindStringSubmatch(pr)
+	if match == nil {
+		return "", "", 0, fmt.Errorf("%q is not of the form org/repo#number", pr)
+	}
+	number, err := strconv.Atoi(match[3])
+	if err != nil {
+		return "", "", 0, err
+	}
+	return match[1], match[2], number, nil
+}
+
+type commentClient interface {
+	BotUserChecker() (func(candidate string) bool, error)
+	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
+	CreateComment(org, repo string, number int, comment string) error
+	EditComment(org, repo string, id int, comment string) error
+}
+
+// commentContextChanges comments the changes on the pull request, editing the
+// previous comment if there is one. No comment is posted if there are no
+// changes and there was no previous comment.
+func commentContextChanges(gc commentClient, org, repo string, number int, changes []ContextChange) error {
+	botUserChecker, err := gc.BotUserChecker()
+	if err != nil {
+		return fmt.Errorf("get bot user: %w", err)
+	}
+	comments, err := gc.ListIssueComments(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("list comments of %s/%s#%d: %w", org, repo, number, err)
+	}
+	body := formatContextChanges(changes)
+	for _, comment := range comments {
+		if !botUserChecker(comment.User.Login) || !strings.Contains(comment.Body, contextsCommentMarker) {
+			continue
+		}
+		if comment.Body == body {
+			return nil
+		}
+		return gc.EditComment(org, repo, comment.ID, body)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return gc.CreateComment(org, repo, number, body)
+}
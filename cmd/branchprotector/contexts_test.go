@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+)
+
+func loadConfig(t *testing.T, raw string) *config.Config {
+	t.Helper()
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(raw), &cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	for _, presubmits := range cfg.PresubmitsStatic {
+		if err := config.SetPresubmitRegexes(presubmits); err != nil {
+			t.Fatalf("failed to compile presubmit regexes: %v", err)
+		}
+	}
+	return &cfg
+}
+
+func TestContextChanges(t *testing.T) {
+	base := loadConfig(t, `
+branch-protection:
+  protect-tested-repos: true
+  orgs:
+    org:
+      protect: true
+      required_status_checks:
+        contexts:
+        - cla
+      repos:
+        unmanaged:
+          unmanaged: true
+presubmits:
+  org/repo:
+  - name: unit
+    always_run: true
+    context: unit
+  - name: e2e
+    always_run: true
+    context: e2e
+    branches:
+    - main
+`)
+	head := loadConfig(t, `
+branch-protection:
+  protect-tested-repos: true
+  orgs:
+    org:
+      protect: true
+      required_status_checks:
+        contexts:
+        - cla
+      repos:
+        unmanaged:
+          unmanaged: true
+presubmits:
+  org/repo:
+  - name: unit
+    always_run: true
+    context: unit
+    optional: true
+  - name: e2e
+    always_run: true
+    context: e2e
+  - name: lint
+    always_run: true
+    context: lint
+    skip_branches:
+    - release-.*
+  org/unmanaged:
+  - name: unit
+    always_run: true
+    context: unit
+`)
+	fc := fakeClient{
+		repos: map[string][]github.Repo{"org": {{Name: "repo", FullName: "org/repo"}, {Name: "unmanaged", FullName: "org/unmanaged"}}},
+		branches: map[string][]github.Branch{
+			"org/repo":      {{Name: "main", Protected: true}, {Name: "release-1.0", Protected: true}},
+			"org/unmanaged": {{Name: "main"}},
+		},
+	}
+	p := protector{
+		client:         &fc,
+		cfg:            head,
+		errors:         Errors{},
+		updates:        make(chan requirements),
+		done:           make(chan []error),
+		completedRepos: make(map[string]bool),
+		enabled:        func(org, repo string) bool { return true },
+		contexts:       newContextsRecorder(base),
+		reportOnly:     true,
+	}
+	go func() {
+		p.protect()
+		close(p.updates)
+	}()
+	var updates []requirements
+	for r := range p.updates {
+		updates = append(updates, r)
+	}
+	if len(p.errors.errs) != 0 {
+		t.Errorf("expected no errors, got %v", p.errors.errs)
+	}
+	if len(updates) != 0 || len(fc.updated) != 0 {
+		t.Errorf("expected no changes when reporting context changes, got updates %v", updates)
+	}
+
+	expected := []ContextChange{
+		{Org: "org", Repo: "repo", Branch: "main", Added: []string{"lint"}, Removed: []string{"unit"}},
+		{Org: "org", Repo: "repo", Branch: "release-1.0", Added: []string{"e2e"}, Removed: []string{"unit"}},
+	}
+	if diff := cmp.Diff(expected, p.contexts.finish()); diff != "" {
+		t.Errorf("unexpected context changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestCommentContextChanges(t *testing.T) {
+	changes := []ContextChange{{Org: "org", Repo: "repo", Branch: "main", Added: []string{"lint", "e2e|serial"}, Removed: []string{"unit"}}}
+	testCases := []struct {
+		name             string
+		existing         []github.IssueComment
+		changes          []ContextChange
+		expectedCreated  bool
+		expectedEdited   bool
+		expectedContains string
+	}{
+		{
+			name:             "changes are commented",
+			changes:          changes,
+			expectedCreated:  true,
+			expectedContains: "| org/repo=main | `lint`<br>`e2e\\|serial` | `unit` |",
+		},
+		{
+			name: "no changes without previous comment are not commented",
+		},
+		{
+			name:             "previous comment is edited",
+			existing:         []github.IssueComment{{ID: 1, User: github.User{Login: "k8s-ci-robot"}, Body: formatContextChanges(changes)}},
+			expectedEdited:   true,
+			expectedContains: "does not change the status contexts",
+		},
+		{
+			name:     "unchanged comment is not edited",
+			existing: []github.IssueComment{{ID: 1, User: github.User{Login: "k8s-ci-robot"}, Body: formatContextChanges(changes)}},
+			changes:  changes,
+		},
+		{
+			name:             "comments of other users are ignored",
+			existing:         []github.IssueComment{{ID: 1, User: github.User{Login: "someone"}, Body: contextsCommentMarker}},
+			changes:          changes,
+			expectedCreated:  true,
+			expectedContains: "will update the status contexts",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := fakegithub.NewFakeClient()
+			gc.IssueComments = map[int][]github.IssueComment{1: tc.existing}
+			if err := commentContextChanges(gc, "org", "repo", 1, tc.changes); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if created := len(gc.IssueCommentsAdded) > 0; created != tc.expectedCreated {
+				t.Errorf("expected a comment to be created: %t, got %v", tc.expectedCreated, gc.IssueCommentsAdded)
+			}
+			if edited := len(gc.IssueCommentsEdited) > 0; edited != tc.expectedEdited {
+				t.Errorf("expected a comment to be edited: %t, got %v", tc.expectedEdited, gc.IssueCommentsEdited)
+			}
+			if tc.expectedContains != "" {
+				written := append(gc.IssueCommentsAdded, gc.IssueCommentsEdited...)
+				if len(written) != 1 || !strings.Contains(written[0], tc.expectedContains) {
+					t.Errorf("expected a comment containing %q, got %v", tc.expectedContains, written)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePullRequest(t *testing.T) {
+	org, repo, number, err := parsePullRequest("kubernetes-sigs/prow#123")
+	if err != nil || org != "kubernetes-sigs" || repo != "prow" || number != 123 {
+		t.Errorf("expected kubernetes-sigs, prow, 123, got %s, %s, %d, %v", org, repo, number, err)
+	}
+	for _, invalid := range []string{"prow#123", "kubernetes-sigs/prow", "kubernetes-sigs/prow#", "a/b/c#1"} {
+		if _, _, _, err := parsePullRequest(invalid); err == nil {
+			t.Errorf("expected an error parsing %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+const (
+	driftKindBranchProtection = "branch_protection"
+	driftKindRuleset          = "ruleset"
+)
+
+var driftMetrics = struct {
+	protections *prometheus.GaugeVec
+}{
+	protections: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "branchprotector_protections",
+		Help: "Number of branch protections and rulesets managed by branchprotector, by whether they match the config.",
+	}, []string{
+		"org",
+		"kind",
+		"in_sync",
+	}),
+}
+
+func init() {
+	prometheus.MustRegister(driftMetrics.protections)
+}
+
+// DriftReport lists the differences between the desired protection from the
+// config and the actual protection on GitHub.
+type DriftReport struct {
+	Timestamp        time.Time      `json:"timestamp"`
+	BranchProtection []BranchDrift  `json:"branch_protection"`
+	Rulesets         []RulesetDrift `json:"rulesets"`
+}
+
+// BranchDrift is the state of the protection of a single branch.
+type BranchDrift struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"`
+	InSync bool   `json:"in_sync"`
+	// Current is the current protection expressed as a request, unset if the
+	// branch is not protected.
+	Current *github.BranchProtectionRequest `json:"current,omitempty"`
+	// Desired is the protection from the config, unset if the branch should
+	// not be protected.
+	Desired *github.BranchProtectionRequest `json:"desired,omitempty"`
+	// Diff is a unified diff from Current to Desired.
+	Diff string `json:"diff,omitempty"`
+}
+
+// RulesetDrift is the state of a single ruleset.
+type RulesetDrift struct {
+	Org string `json:"org"`
+	// Repo is unset for organization rulesets.
+	Repo   string `json:"repo,omitempty"`
+	Name   string `json:"name"`
+	InSync bool   `json:"in_sync"`
+	// Current is unset if the ruleset does not exist yet.
+	Current *github.Ruleset `json:"current,omitempty"`
+	Desired github.Ruleset  `json:"desired"`
+	// Diff is a unified diff from Current to Desired.
+	Diff string `json:"diff,omitempty"`
+}
+
+// driftRecorder collects a DriftReport while the protector runs.
+type driftRecorder struct {
+	lock   sync.Mutex
+	report DriftReport
+}
+
+func newDriftRecorder() *driftRecorder {
+	return &driftRecorder{report: DriftReport{
+		BranchProtection: []BranchDrift{},
+		Rulesets:         []RulesetDrift{},
+	}}
+}
+
+func (d *driftRecorder) recordBranch(drift BranchDrift) {
+	if d == nil {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.report.BranchProtection = append(d.report.BranchProtection, drift)
+}
+
+func (d *driftRecorder) recordRuleset(drift RulesetDrift) {
+	if d == nil {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.report.Rulesets = append(d.report.Rulesets, drift)
+}
+
+// finish sorts the report and updates the drift metrics from it.
+func (d *driftRecorder) finish(now time.Time) DriftReport {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.report.Timestamp = now
+	sort.Slice(d.report.BranchProtection, func(i, j int) bool {
+		a, b := d.report.BranchProtection[i], d.report.BranchProtection[j]
+		if a.Org != b.Org {
+			return a.Org < b.Org
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Branch < b.Branch
+	})
+	sort.Slice(d.report.Rulesets, func(i, j int) bool {
+		a, b := d.report.Rulesets[i], d.report.Rulesets[j]
+		if a.Org != b.Org {
+			return a.Org < b.Org
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Name < b.Name
+	})
+
+	driftMetrics.protections.Reset()
+	for _, b := range d.report.BranchProtection {
+		driftMetrics.protections.WithLabelValues(b.Org, driftKindBranchProtection, fmt.Sprint(b.InSync)).Inc()
+	}
+	for _, r := range d.report.Rulesets {
+		driftMetrics.protections.WithLabelValues(r.Org, driftKindRuleset, fmt.Sprint(r.InSync)).Inc()
+	}
+	return d.report
+}
+
+// writeDriftReport uploads the report as JSON to path, which can be a local
+// path or a bucket path such as gs://bucket/branchprotector/drift.json.
+func writeDriftReport(ctx context.Context, opener io.Opener, path string, report DriftReport) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal drift report: %w", err)
+	}
+	contentType := "application/json"
+	if err := io.WriteContent(ctx, logrus.WithField("path", path), opener, path, content, io.WriterOptions{ContentType: &contentType}); err != nil {
+		return fmt.Errorf("write drift report to %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+func TestDriftReport(t *testing.T) {
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(`
+branch-protection:
+  orgs:
+    org:
+      protect: true
+      required_status_checks:
+        contexts:
+        - unit
+      repos:
+        repo:
+          rulesets:
+          - name: signed
+            required_signatures: true
+`), &cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	fc := fakeClient{
+		repos: map[string][]github.Repo{"org": {{Name: "repo", FullName: "org/repo"}}},
+		branches: map[string][]github.Branch{"org/repo": {
+			{Name: "drifted", Protected: true},
+			{Name: "synced", Protected: true},
+		}},
+		branchProtections: map[string]github.BranchProtection{
+			"org/repo=drifted": {RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"e2e"}}},
+			"org/repo=synced":  {RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"unit"}}},
+		},
+	}
+	p := protector{
+		client:         &fc,
+		cfg:            &cfg,
+		errors:         Errors{},
+		updates:        make(chan requirements),
+		done:           make(chan []error),
+		completedRepos: make(map[string]bool),
+		enabled:        func(org, repo string) bool { return true },
+		drift:          newDriftRecorder(),
+		reportOnly:     true,
+	}
+	go func() {
+		p.protect()
+		close(p.updates)
+	}()
+	var updates []requirements
+	for r := range p.updates {
+		updates = append(updates, r)
+	}
+	if len(p.errors.errs) != 0 {
+		t.Errorf("expected no errors, got %v", p.errors.errs)
+	}
+	if len(updates) != 0 || len(fc.createdRulesets) != 0 {
+		t.Errorf("expected no changes in report only mode, got updates %v and rulesets %v", updates, fc.createdRulesets)
+	}
+
+	report := p.drift.finish(time.Unix(0, 0))
+	var branches []string
+	for _, b := range report.BranchProtection {
+		if b.InSync != (b.Diff == "") {
+			t.Errorf("%s: in_sync=%t does not match diff %q", b.Branch, b.InSync, b.Diff)
+		}
+		branches = append(branches, b.Branch+"="+map[bool]string{true: "in sync", false: "drifted"}[b.InSync])
+	}
+	if diff := cmp.Diff([]string{"drifted=drifted", "synced=in sync"}, branches); diff != "" {
+		t.Errorf("unexpected branches in report (-want +got):\n%s", diff)
+	}
+	if len(report.Rulesets) != 1 || report.Rulesets[0].InSync || report.Rulesets[0].Current != nil || report.Rulesets[0].Repo != "repo" {
+		t.Errorf("expected a single missing repo ruleset, got %+v", report.Rulesets)
+	}
+	if got := testutil.ToFloat64(driftMetrics.protections.WithLabelValues("org", driftKindBranchProtection, "false")); got != 1 {
+		t.Errorf("expected 1 drifted branch protection in metrics, got %v", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "drift.json")
+	opener, err := io.NewOpener(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("failed to create opener: %v", err)
+	}
+	if err := writeDriftReport(context.Background(), opener, path, report); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	var written DriftReport
+	if err := json.Unmarshal(raw, &written); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+	if diff := cmp.Diff(report, written); diff != "" {
+		t.Errorf("written report differs (-want +got):\n%s", diff)
+	}
+}
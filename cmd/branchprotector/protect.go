@@ -17,6 +17,8 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/url"
@@ -25,16 +27,21 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/audit"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
 )
 
 const (
@@ -47,9 +54,16 @@ type options struct {
 	confirm                bool
 	verifyRestrictions     bool
 	enableAppsRestrictions bool
+	driftReportPath        string
+	driftReportOnly        bool
+	baseConfigPath         string
+	baseJobConfigPath      string
+	pullRequest            string
 
 	github           flagutil.GitHubOptions
 	githubEnablement flagutil.GitHubEnablementOptions
+	storage          flagutil.StorageClientOptions
+	audit            flagutil.AuditOptions
 }
 
 func (o *options) Validate() error {
@@ -65,6 +79,33 @@ func (o *options) Validate() error {
 		return err
 	}
 
+	if err := o.storage.Validate(!o.confirm); err != nil {
+		return err
+	}
+
+	if err := o.audit.Validate(!o.confirm); err != nil {
+		return err
+	}
+
+	if o.driftReportOnly && o.driftReportPath == "" {
+		return errors.New("--drift-report-only requires --drift-report-path")
+	}
+
+	if o.baseJobConfigPath != "" && o.baseConfigPath == "" {
+		return errors.New("--base-job-config-path requires --base-config-path")
+	}
+	if o.pullRequest != "" {
+		if o.baseConfigPath == "" {
+			return errors.New("--pull-request requires --base-config-path")
+		}
+		if !o.confirm {
+			return errors.New("--pull-request requires --confirm to comment on the pull request")
+		}
+		if _, _, _, err := parsePullRequest(o.pullRequest); err != nil {
+			return fmt.Errorf("--pull-request: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -74,7 +115,14 @@ func gatherOptions() options {
 	fs.BoolVar(&o.confirm, "confirm", false, "Mutate github if set")
 	fs.BoolVar(&o.verifyRestrictions, "verify-restrictions", false, "Verify the restrictions section of the request for authorized apps/collaborators/teams")
 	fs.BoolVar(&o.enableAppsRestrictions, "enable-apps-restrictions", false, "Enable feature to enforce apps restrictions in branch protection rules")
+	fs.StringVar(&o.driftReportPath, "drift-report-path", "", "If set, write a JSON report of the drift between the config and GitHub to this local path or gs:// or s3:// path")
+	fs.BoolVar(&o.driftReportOnly, "drift-report-only", false, "Only report the drift without changing GitHub, even with --confirm")
+	fs.StringVar(&o.baseConfigPath, "base-config-path", "", "If set, only report how the required status contexts of every branch change from the config at this path to the one at --config-path, without changing branch protection, even with --confirm")
+	fs.StringVar(&o.baseJobConfigPath, "base-job-config-path", "", "Path to the job config of --base-config-path")
+	fs.StringVar(&o.pullRequest, "pull-request", "", "Pull request of the form org/repo#number to comment the changes reported with --base-config-path on")
 	o.config.AddFlags(fs)
+	o.storage.AddFlags(fs)
+	o.audit.AddFlags(fs)
 	o.github.AddCustomizedFlags(fs, flagutil.ThrottlerDefaults(defaultTokens, defaultBurst))
 	o.githubEnablement.AddFlags(fs)
 	fs.Parse(os.Args[1:])
@@ -131,18 +179,68 @@ func main() {
 		verifyRestrictions:     o.verifyRestrictions,
 		enableAppsRestrictions: o.enableAppsRestrictions,
 		enabled:                o.githubEnablement.EnablementChecker(),
+		reportOnly:             o.driftReportOnly,
+	}
+	if o.driftReportPath != "" {
+		p.drift = newDriftRecorder()
+	}
+	if o.confirm {
+		opener, err := o.storage.StorageClient(context.Background())
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating opener.")
+		}
+		if p.auditor, err = o.audit.Auditor(context.Background(), "branchprotector", opener); err != nil {
+			logrus.WithError(err).Fatal("Error creating auditor.")
+		}
+	}
+	if o.baseConfigPath != "" {
+		base, err := config.Load(o.baseConfigPath, o.baseJobConfigPath, nil, "")
+		if err != nil {
+			logrus.WithError(err).Fatalf("Failed to load --base-config-path=%s", o.baseConfigPath)
+		}
+		p.contexts = newContextsRecorder(base)
+		p.reportOnly = true
 	}
 
 	go p.configureBranches()
 	p.protect()
 	close(p.updates)
 	errors := <-p.done
+
+	if p.drift != nil {
+		report := p.drift.finish(time.Now())
+		opener, err := o.storage.StorageClient(context.Background())
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating opener.")
+		}
+		if err := writeDriftReport(context.Background(), opener, o.driftReportPath, report); err != nil {
+			logrus.WithError(err).Fatal("Error writing drift report.")
+		}
+		if cfg.PushGateway.Endpoint != "" {
+			if err := metrics.PushOnce("branchprotector", cfg.PushGateway.Endpoint); err != nil {
+				logrus.WithError(err).Error("Error pushing drift metrics.")
+			}
+		}
+	}
+
 	if n := len(errors); n > 0 {
 		for i, err := range errors {
 			logrus.WithError(err).Error(i)
 		}
 		logrus.Fatalf("Encountered %d errors protecting branches", n)
 	}
+
+	if p.contexts != nil {
+		changes := p.contexts.finish()
+		if o.pullRequest == "" {
+			fmt.Print(formatContextChanges(changes))
+			return
+		}
+		org, repo, number, _ := parsePullRequest(o.pullRequest)
+		if err := commentContextChanges(githubClient, org, repo, number, changes); err != nil {
+			logrus.WithError(err).Fatalf("Failed to comment on %s.", o.pullRequest)
+		}
+	}
 }
 
 type client interface {
@@ -155,6 +253,14 @@ type client interface {
 	ListAppInstallationsForOrg(org string) ([]github.AppInstallation, error)
 	ListCollaborators(org, repo string) ([]github.User, error)
 	ListRepoTeams(org, repo string) ([]github.Team, error)
+	ListOrgRulesets(org string) ([]github.Ruleset, error)
+	ListRepoRulesets(org, repo string) ([]github.Ruleset, error)
+	GetOrgRuleset(org string, id int) (*github.Ruleset, error)
+	GetRepoRuleset(org, repo string, id int) (*github.Ruleset, error)
+	CreateOrgRuleset(org string, ruleset github.Ruleset) (int, error)
+	CreateRepoRuleset(org, repo string, ruleset github.Ruleset) (int, error)
+	UpdateOrgRuleset(org string, id int, ruleset github.Ruleset) error
+	UpdateRepoRuleset(org, repo string, id int, ruleset github.Ruleset) error
 }
 
 type protector struct {
@@ -167,28 +273,53 @@ type protector struct {
 	verifyRestrictions     bool
 	enableAppsRestrictions bool
 	enabled                func(org, repo string) bool
+	// drift records the drift between config and GitHub, if set.
+	drift *driftRecorder
+	// reportOnly skips applying changes, only recording the drift.
+	reportOnly bool
+	// contexts records the changes to required contexts from a base config,
+	// if set, instead of comparing the branch protection with GitHub.
+	contexts *contextsRecorder
+	// auditor emits an audit event for every change to GitHub, if set.
+	auditor *audit.Auditor
 }
 
 func (p *protector) configureBranches() {
 	for u := range p.updates {
+		target := fmt.Sprintf("%s/%s=%s", u.Org, u.Repo, u.Branch)
 		if u.Request == nil {
-			if err := p.client.RemoveBranchProtection(u.Org, u.Repo, u.Branch); err != nil {
+			err := p.client.RemoveBranchProtection(u.Org, u.Repo, u.Branch)
+			p.audit(target, "remove", err)
+			if err != nil {
 				p.errors.add(fmt.Errorf("remove %s/%s=%s protection failed: %w", u.Org, u.Repo, u.Branch, err))
 			}
 			continue
 		}
 
-		if err := p.client.UpdateBranchProtection(u.Org, u.Repo, u.Branch, *u.Request); err != nil {
+		err := p.client.UpdateBranchProtection(u.Org, u.Repo, u.Branch, *u.Request)
+		p.audit(target, "update", err)
+		if err != nil {
 			p.errors.add(fmt.Errorf("update %s/%s=%s protection to %v failed: %w", u.Org, u.Repo, u.Branch, *u.Request, err))
 		}
 	}
 	p.done <- p.errors.errs
 }
 
+// audit emits an audit event for a change of the branch protection or
+// rulesets of the target.
+func (p *protector) audit(target, operation string, err error) {
+	p.auditor.Emit(context.Background(), audit.Event{
+		Action:  audit.ActionBranchProtection,
+		Target:  target,
+		Error:   audit.ErrorString(err),
+		Details: map[string]string{"operation": operation},
+	})
+}
+
 // protect protects branches specified in the presubmit and branch-protection config sections.
 func (p *protector) protect() {
 	bp := p.cfg.BranchProtection
-	if bp.Policy.Unmanaged != nil && *bp.Policy.Unmanaged && !bp.HasManagedOrgs() && !bp.HasManagedRepos() && !bp.HasManagedBranches() {
+	if bp.Policy.Unmanaged != nil && *bp.Policy.Unmanaged && !bp.HasManagedOrgs() && !bp.HasManagedRepos() && !bp.HasManagedBranches() && !bp.HasRulesets() {
 		logrus.Warn("Branchprotection has global unmanaged: true, will not do anything")
 		return
 	}
@@ -234,10 +365,17 @@ func (p *protector) protect() {
 	}
 }
 
-// UpdateOrg updates all repos in the org with the specified defaults
+// UpdateOrg updates the org rulesets and all repos in the org with the specified defaults
 func (p *protector) UpdateOrg(orgName string, org config.Org) error {
-	if org.Policy.Unmanaged != nil && *org.Policy.Unmanaged && !org.HasManagedRepos() && !org.HasManagedBranches() {
-		return nil
+	var errs []error
+	// Rulesets are opted into by name, so they are managed regardless of unmanaged.
+	if len(org.Rulesets) > 0 {
+		if err := p.UpdateOrgRulesets(orgName, org.Rulesets); err != nil {
+			errs = append(errs, fmt.Errorf("update rulesets: %w", err))
+		}
+	}
+	if org.Policy.Unmanaged != nil && *org.Policy.Unmanaged && !org.HasManagedRepos() && !org.HasManagedBranches() && !org.HasRepoRulesets() {
+		return utilerrors.NewAggregate(errs)
 	}
 
 	var repos []string
@@ -245,7 +383,7 @@ func (p *protector) UpdateOrg(orgName string, org config.Org) error {
 		// Strongly opinionated org, configure every repo in the org.
 		rs, err := p.client.GetRepos(orgName, false)
 		if err != nil {
-			return fmt.Errorf("list repos: %w", err)
+			return utilerrors.NewAggregate(append(errs, fmt.Errorf("list repos: %w", err)))
 		}
 		for _, r := range rs {
 			// Skip Archived repos as they can't be modified in this way
@@ -265,7 +403,6 @@ func (p *protector) UpdateOrg(orgName string, org config.Org) error {
 		}
 	}
 
-	var errs []error
 	for _, repoName := range repos {
 		if !p.enabled(orgName, repoName) {
 			continue
@@ -282,7 +419,8 @@ func (p *protector) UpdateOrg(orgName string, org config.Org) error {
 // UpdateRepo updates all branches in the repo with the specified defaults
 func (p *protector) UpdateRepo(orgName string, repoName string, repo config.Repo) error {
 	p.completedRepos[orgName+"/"+repoName] = true
-	if repo.Policy.Unmanaged != nil && *repo.Policy.Unmanaged && !repo.HasManagedBranches() {
+	unmanaged := repo.Policy.Unmanaged != nil && *repo.Policy.Unmanaged && !repo.HasManagedBranches()
+	if unmanaged && len(repo.Rulesets) == 0 {
 		return nil
 	}
 
@@ -299,6 +437,16 @@ func (p *protector) UpdateRepo(orgName string, repoName string, repo config.Repo
 		return nil
 	}
 
+	var errs []error
+	if len(repo.Rulesets) > 0 {
+		if err := p.UpdateRepoRulesets(orgName, repoName, repo.Rulesets); err != nil {
+			errs = append(errs, fmt.Errorf("update rulesets: %w", err))
+		}
+	}
+	if unmanaged {
+		return utilerrors.NewAggregate(errs)
+	}
+
 	var branchInclusions *regexp.Regexp
 	if len(repo.Policy.Include) > 0 {
 		branchInclusions, err = regexp.Compile(strings.Join(repo.Policy.Include, `|`))
@@ -357,7 +505,6 @@ func (p *protector) UpdateRepo(orgName string, repoName string, repo config.Repo
 		}
 	}
 
-	var errs []error
 	for bn, githubBranch := range branches {
 		if branch, err := repo.GetBranch(bn); err != nil {
 			errs = append(errs, fmt.Errorf("get %s: %w", bn, err))
@@ -446,6 +593,9 @@ func (p *protector) UpdateBranch(orgName, repo string, branchName string, branch
 	if branch.Unmanaged != nil && *branch.Unmanaged {
 		return nil
 	}
+	if p.contexts != nil {
+		return p.contexts.recordBranch(p.cfg, orgName, repo, branchName, branch, protected)
+	}
 	bp, err := p.cfg.GetPolicy(orgName, repo, branchName, branch, p.cfg.GetPresubmitsStatic(orgName+"/"+repo), &protected)
 	if err != nil {
 		return fmt.Errorf("get policy: %w", err)
@@ -455,6 +605,7 @@ func (p *protector) UpdateBranch(orgName, repo string, branchName string, branch
 	}
 	if !protected && !*bp.Protect {
 		logrus.Infof("%s/%s=%s: already unprotected", orgName, repo, branchName)
+		p.drift.recordBranch(BranchDrift{Org: orgName, Repo: repo, Branch: branchName, InSync: true})
 		return nil
 	}
 
@@ -492,10 +643,32 @@ func (p *protector) UpdateBranch(orgName, repo string, branchName string, branch
 		return fmt.Errorf("get current branch protection: %w", err)
 	}
 
-	if equalBranchProtections(currentBP, req) {
+	drift := BranchDrift{
+		Org:     orgName,
+		Repo:    repo,
+		Branch:  branchName,
+		InSync:  equalBranchProtections(currentBP, req),
+		Desired: req,
+	}
+	if currentBP != nil {
+		current := requestFromProtection(*currentBP)
+		drift.Current = &current
+	}
+	if !drift.InSync {
+		if drift.Diff, err = protectionDiff(currentBP, req); err != nil {
+			logrus.WithError(err).Warnf("%s/%s=%s: failed to diff branch protection", orgName, repo, branchName)
+		}
+	}
+	p.drift.recordBranch(drift)
+
+	if drift.InSync {
 		logrus.Debugf("%s/%s=%s: current branch protection matches policy, skipping", orgName, repo, branchName)
 		return nil
 	}
+	logrus.Infof("%s/%s=%s: branch protection differs from policy:\n%s", orgName, repo, branchName, drift.Diff)
+	if p.reportOnly {
+		return nil
+	}
 
 	p.updates <- requirements{
 		Org:     orgName,
@@ -506,6 +679,97 @@ func (p *protector) UpdateBranch(orgName, repo string, branchName string, branch
 	return nil
 }
 
+// protectionDiff returns a unified diff between the current branch protection
+// and the requested one.
+func protectionDiff(state *github.BranchProtection, request *github.BranchProtectionRequest) (string, error) {
+	var current, desired []byte
+	var err error
+	if state != nil {
+		stateRequest := requestFromProtection(*state)
+		if r := stateRequest.Restrictions; r != nil && request != nil && request.Restrictions != nil && request.Restrictions.Apps == nil {
+			// See equalApps, apps are not managed unless requested.
+			r.Apps = nil
+		}
+		if current, err = yaml.Marshal(stateRequest); err != nil {
+			return "", err
+		}
+	}
+	if request != nil {
+		r := *request
+		if r.EnforceAdmins == nil {
+			// See equalAdminEnforcement, no statement is the same as not enforcing.
+			no := false
+			r.EnforceAdmins = &no
+		}
+		if desired, err = yaml.Marshal(r); err != nil {
+			return "", err
+		}
+	}
+	return unifiedDiff(string(current), string(desired))
+}
+
+// requestFromProtection expresses the branch protection read from GitHub as
+// the request that would configure it, so that the two can be diffed.
+func requestFromProtection(state github.BranchProtection) github.BranchProtectionRequest {
+	request := github.BranchProtectionRequest{
+		RequiredStatusChecks:  state.RequiredStatusChecks,
+		EnforceAdmins:         &state.EnforceAdmins.Enabled,
+		RequiredLinearHistory: state.RequiredLinearHistory.Enabled,
+		AllowForcePushes:      state.AllowForcePushes.Enabled,
+		AllowDeletions:        state.AllowDeletions.Enabled,
+	}
+	if reviews := state.RequiredPullRequestReviews; reviews != nil {
+		request.RequiredPullRequestReviews = &github.RequiredPullRequestReviewsRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+		}
+		if r := reviews.DismissalRestrictions; r != nil {
+			request.RequiredPullRequestReviews.DismissalRestrictions = github.DismissalRestrictionsRequest{
+				Users: userLogins(r.Users),
+				Teams: teamSlugs(r.Teams),
+			}
+		}
+		if r := reviews.BypassRestrictions; r != nil {
+			request.RequiredPullRequestReviews.BypassRestrictions = github.BypassRestrictionsRequest{
+				Users: userLogins(r.Users),
+				Teams: teamSlugs(r.Teams),
+			}
+		}
+	}
+	if r := state.Restrictions; r != nil {
+		apps := []string{}
+		for _, app := range r.Apps {
+			apps = append(apps, app.Slug)
+		}
+		sort.Strings(apps)
+		request.Restrictions = &github.RestrictionsRequest{
+			Apps:  &apps,
+			Users: userLogins(r.Users),
+			Teams: teamSlugs(r.Teams),
+		}
+	}
+	return request
+}
+
+func userLogins(users []github.User) *[]string {
+	logins := []string{}
+	for _, user := range users {
+		logins = append(logins, github.NormLogin(user.Login))
+	}
+	sort.Strings(logins)
+	return &logins
+}
+
+func teamSlugs(teams []github.Team) *[]string {
+	slugs := []string{}
+	for _, team := range teams {
+		slugs = append(slugs, team.Slug)
+	}
+	sort.Strings(slugs)
+	return &slugs
+}
+
 func equalBranchProtections(state *github.BranchProtection, request *github.BranchProtectionRequest) bool {
 	switch {
 	case state == nil && request == nil:
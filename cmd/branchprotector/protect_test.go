@@ -67,6 +67,76 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			name: "drift report only without a path",
+			opt: options{
+				config: configflagutil.ConfigOptions{
+					ConfigPath: "dummy",
+				},
+				github:          flagutil.GitHubOptions{TokenPath: "fake", ThrottleHourlyTokens: defaultTokens, ThrottleAllowBurst: defaultBurst},
+				driftReportOnly: true,
+			},
+			expectedErr: true,
+		},
+		{
+			name: "drift report only",
+			opt: options{
+				config: configflagutil.ConfigOptions{
+					ConfigPath: "dummy",
+				},
+				github:          flagutil.GitHubOptions{TokenPath: "fake", ThrottleHourlyTokens: defaultTokens, ThrottleAllowBurst: defaultBurst},
+				driftReportPath: "gs://bucket/drift.json",
+				driftReportOnly: true,
+			},
+			expectedErr: false,
+		},
+		{
+			name: "base config",
+			opt: options{
+				config: configflagutil.ConfigOptions{
+					ConfigPath: "dummy",
+				},
+				github:            flagutil.GitHubOptions{TokenPath: "fake", ThrottleHourlyTokens: defaultTokens, ThrottleAllowBurst: defaultBurst},
+				baseConfigPath:    "base/config.yaml",
+				baseJobConfigPath: "base/jobs",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "base job config without a base config",
+			opt: options{
+				config: configflagutil.ConfigOptions{
+					ConfigPath: "dummy",
+				},
+				github:            flagutil.GitHubOptions{TokenPath: "fake", ThrottleHourlyTokens: defaultTokens, ThrottleAllowBurst: defaultBurst},
+				baseJobConfigPath: "base/jobs",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "pull request without a base config",
+			opt: options{
+				config: configflagutil.ConfigOptions{
+					ConfigPath: "dummy",
+				},
+				github:      flagutil.GitHubOptions{TokenPath: "fake", ThrottleHourlyTokens: defaultTokens, ThrottleAllowBurst: defaultBurst},
+				confirm:     true,
+				pullRequest: "org/repo#1",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "pull request without confirm",
+			opt: options{
+				config: configflagutil.ConfigOptions{
+					ConfigPath: "dummy",
+				},
+				github:         flagutil.GitHubOptions{TokenPath: "fake", ThrottleHourlyTokens: defaultTokens, ThrottleAllowBurst: defaultBurst},
+				baseConfigPath: "base/config.yaml",
+				pullRequest:    "org/repo#1",
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -89,6 +159,10 @@ type fakeClient struct {
 	appInstallations  []github.AppInstallation
 	collaborators     []github.User
 	teams             []github.Team
+	// rulesets are keyed by org or org/repo
+	rulesets        map[string][]github.Ruleset
+	createdRulesets map[string]github.Ruleset
+	updatedRulesets map[int]github.Ruleset
 }
 
 func (c fakeClient) GetRepo(org string, repo string) (github.FullRepo, error) {
@@ -177,6 +251,63 @@ func (c *fakeClient) ListRepoTeams(org, repo string) ([]github.Team, error) {
 	return c.teams, nil
 }
 
+func (c *fakeClient) ListOrgRulesets(org string) ([]github.Ruleset, error) {
+	return c.rulesets[org], nil
+}
+
+func (c *fakeClient) ListRepoRulesets(org, repo string) ([]github.Ruleset, error) {
+	return c.rulesets[org+"/"+repo], nil
+}
+
+func (c *fakeClient) getRuleset(scope string, id int) (*github.Ruleset, error) {
+	for _, r := range c.rulesets[scope] {
+		if r.ID == id {
+			return &r, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown ruleset %d in %s", id, scope)
+}
+
+func (c *fakeClient) GetOrgRuleset(org string, id int) (*github.Ruleset, error) {
+	return c.getRuleset(org, id)
+}
+
+func (c *fakeClient) GetRepoRuleset(org, repo string, id int) (*github.Ruleset, error) {
+	return c.getRuleset(org+"/"+repo, id)
+}
+
+func (c *fakeClient) createRuleset(scope string, ruleset github.Ruleset) (int, error) {
+	if c.createdRulesets == nil {
+		c.createdRulesets = map[string]github.Ruleset{}
+	}
+	c.createdRulesets[scope+":"+ruleset.Name] = ruleset
+	return len(c.createdRulesets), nil
+}
+
+func (c *fakeClient) CreateOrgRuleset(org string, ruleset github.Ruleset) (int, error) {
+	return c.createRuleset(org, ruleset)
+}
+
+func (c *fakeClient) CreateRepoRuleset(org, repo string, ruleset github.Ruleset) (int, error) {
+	return c.createRuleset(org+"/"+repo, ruleset)
+}
+
+func (c *fakeClient) updateRuleset(id int, ruleset github.Ruleset) error {
+	if c.updatedRulesets == nil {
+		c.updatedRulesets = map[int]github.Ruleset{}
+	}
+	c.updatedRulesets[id] = ruleset
+	return nil
+}
+
+func (c *fakeClient) UpdateOrgRuleset(org string, id int, ruleset github.Ruleset) error {
+	return c.updateRuleset(id, ruleset)
+}
+
+func (c *fakeClient) UpdateRepoRuleset(org, repo string, id int, ruleset github.Ruleset) error {
+	return c.updateRuleset(id, ruleset)
+}
+
 func TestConfigureBranches(t *testing.T) {
 	yes := true
 
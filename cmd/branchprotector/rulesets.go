@@ -0,0 +1,369 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+const (
+	defaultRefCondition  = "~DEFAULT_BRANCH"
+	defaultRepoCondition = "~ALL"
+)
+
+// rulesetScope abstracts over the org and repo rulesets APIs.
+type rulesetScope struct {
+	org    string
+	repo   string
+	list   func() ([]github.Ruleset, error)
+	get    func(id int) (*github.Ruleset, error)
+	create func(ruleset github.Ruleset) (int, error)
+	update func(id int, ruleset github.Ruleset) error
+}
+
+// UpdateOrgRulesets reconciles the org rulesets listed in the config.
+func (p *protector) UpdateOrgRulesets(orgName string, rulesets []config.Ruleset) error {
+	return p.updateRulesets(rulesetScope{
+		org:  orgName,
+		list: func() ([]github.Ruleset, error) { return p.client.ListOrgRulesets(orgName) },
+		get:  func(id int) (*github.Ruleset, error) { return p.client.GetOrgRuleset(orgName, id) },
+		create: func(ruleset github.Ruleset) (int, error) {
+			id, err := p.client.CreateOrgRuleset(orgName, ruleset)
+			p.audit(orgName, "create ruleset "+ruleset.Name, err)
+			return id, err
+		},
+		update: func(id int, ruleset github.Ruleset) error {
+			err := p.client.UpdateOrgRuleset(orgName, id, ruleset)
+			p.audit(orgName, "update ruleset "+ruleset.Name, err)
+			return err
+		},
+	}, rulesets, true)
+}
+
+// UpdateRepoRulesets reconciles the repo rulesets listed in the config.
+func (p *protector) UpdateRepoRulesets(orgName, repoName string, rulesets []config.Ruleset) error {
+	return p.updateRulesets(rulesetScope{
+		org:  orgName,
+		repo: repoName,
+		list: func() ([]github.Ruleset, error) { return p.client.ListRepoRulesets(orgName, repoName) },
+		get:  func(id int) (*github.Ruleset, error) { return p.client.GetRepoRuleset(orgName, repoName, id) },
+		create: func(ruleset github.Ruleset) (int, error) {
+			id, err := p.client.CreateRepoRuleset(orgName, repoName, ruleset)
+			p.audit(orgName+"/"+repoName, "create ruleset "+ruleset.Name, err)
+			return id, err
+		},
+		update: func(id int, ruleset github.Ruleset) error {
+			err := p.client.UpdateRepoRuleset(orgName, repoName, id, ruleset)
+			p.audit(orgName+"/"+repoName, "update ruleset "+ruleset.Name, err)
+			return err
+		},
+	}, rulesets, false)
+}
+
+// updateRulesets creates or updates the configured rulesets by name. Rulesets
+// that are not in the config are left alone, so that rulesets managed by hand
+// or by other tools can coexist with the ones managed here.
+func (p *protector) updateRulesets(scope rulesetScope, rulesets []config.Ruleset, orgLevel bool) error {
+	scopeName := scope.org
+	if scope.repo != "" {
+		scopeName += "/" + scope.repo
+	}
+	existing, err := scope.list()
+	if err != nil {
+		return fmt.Errorf("list rulesets: %w", err)
+	}
+	ids := map[string]int{}
+	for _, r := range existing {
+		ids[r.Name] = r.ID
+	}
+
+	var errs []error
+	for _, r := range rulesets {
+		desired := makeRuleset(r, orgLevel)
+		var current *github.Ruleset
+		id, exists := ids[r.Name]
+		if exists {
+			if current, err = scope.get(id); err != nil {
+				errs = append(errs, fmt.Errorf("get ruleset %q: %w", r.Name, err))
+				continue
+			}
+		}
+		d, err := rulesetDiff(current, desired)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("diff ruleset %q: %w", r.Name, err))
+			continue
+		}
+		p.drift.recordRuleset(RulesetDrift{
+			Org:     scope.org,
+			Repo:    scope.repo,
+			Name:    r.Name,
+			InSync:  d == "",
+			Current: current,
+			Desired: desired,
+			Diff:    d,
+		})
+		if d == "" {
+			logrus.Debugf("%s: ruleset %q matches policy, skipping", scopeName, r.Name)
+			continue
+		}
+		logrus.Infof("%s: ruleset %q differs from policy:\n%s", scopeName, r.Name, d)
+		if p.reportOnly {
+			continue
+		}
+		if !exists {
+			if _, err := scope.create(desired); err != nil {
+				errs = append(errs, fmt.Errorf("create ruleset %q: %w", r.Name, err))
+			}
+			continue
+		}
+		if err := scope.update(id, desired); err != nil {
+			errs = append(errs, fmt.Errorf("update ruleset %q: %w", r.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// makeRuleset converts the configured ruleset into a GitHub ruleset.
+func makeRuleset(r config.Ruleset, orgLevel bool) github.Ruleset {
+	ruleset := github.Ruleset{
+		Name:        r.Name,
+		Target:      r.Target,
+		Enforcement: r.Enforcement,
+		Conditions: &github.RulesetConditions{
+			RefName: &github.RulesetNameCondition{
+				Include: r.Include,
+				Exclude: r.Exclude,
+			},
+		},
+	}
+	if ruleset.Target == "" {
+		ruleset.Target = github.RulesetTargetBranch
+	}
+	if ruleset.Enforcement == "" {
+		ruleset.Enforcement = github.RulesetEnforcementActive
+	}
+	if len(r.Include) == 0 {
+		ruleset.Conditions.RefName.Include = []string{defaultRefCondition}
+	}
+	if orgLevel {
+		ruleset.Conditions.RepositoryName = &github.RulesetNameCondition{
+			Include: r.Repos,
+			Exclude: r.ExcludeRepos,
+		}
+		if len(r.Repos) == 0 {
+			ruleset.Conditions.RepositoryName.Include = []string{defaultRepoCondition}
+		}
+	}
+
+	for _, rule := range []struct {
+		enabled  bool
+		ruleType string
+	}{
+		{r.RestrictCreations, github.RulesetRuleCreation},
+		{r.RestrictUpdates, github.RulesetRuleUpdate},
+		{r.RestrictDeletions, github.RulesetRuleDeletion},
+		{r.RequiredLinearHistory, github.RulesetRuleRequiredLinearHistory},
+		{r.RequiredSignatures, github.RulesetRuleRequiredSignatures},
+		{r.BlockForcePushes, github.RulesetRuleNonFastForward},
+	} {
+		if rule.enabled {
+			ruleset.Rules = append(ruleset.Rules, github.RulesetRule{Type: rule.ruleType})
+		}
+	}
+	if reviews := r.RequiredPullRequestReviews; reviews != nil {
+		ruleset.Rules = append(ruleset.Rules, github.RulesetRule{
+			Type: github.RulesetRulePullRequest,
+			Parameters: mustMarshal(github.PullRequestRuleParameters{
+				DismissStaleReviewsOnPush:      reviews.DismissStale,
+				RequireCodeOwnerReview:         reviews.RequireOwners,
+				RequireLastPushApproval:        reviews.RequireLastPushApproval,
+				RequiredApprovingReviewCount:   reviews.Approvals,
+				RequiredReviewThreadResolution: reviews.RequireThreadResolution,
+			}),
+		})
+	}
+	if checks := r.RequiredStatusChecks; checks != nil {
+		params := github.RequiredStatusChecksRuleParameters{
+			RequiredStatusChecks:             []github.RulesetStatusCheck{},
+			StrictRequiredStatusChecksPolicy: checks.Strict != nil && *checks.Strict,
+		}
+		for _, context := range checks.Contexts {
+			params.RequiredStatusChecks = append(params.RequiredStatusChecks, github.RulesetStatusCheck{Context: context})
+		}
+		ruleset.Rules = append(ruleset.Rules, github.RulesetRule{
+			Type:       github.RulesetRuleRequiredStatusChecks,
+			Parameters: mustMarshal(params),
+		})
+	}
+
+	for _, actor := range r.BypassActors {
+		bypass := github.RulesetBypassActor{
+			ActorType:  actor.ActorType,
+			BypassMode: actor.BypassMode,
+		}
+		if actor.ActorID != 0 {
+			id := actor.ActorID
+			bypass.ActorID = &id
+		}
+		if bypass.BypassMode == "" {
+			bypass.BypassMode = "always"
+		}
+		ruleset.BypassActors = append(ruleset.BypassActors, bypass)
+	}
+	return ruleset
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		// Only called with the plain parameter structs above.
+		panic(fmt.Sprintf("failed to marshal %T: %v", v, err))
+	}
+	return b
+}
+
+// normalizeRuleset strips the fields of the ruleset that are not managed and
+// sorts the remaining ones, so that rulesets read from GitHub can be compared
+// with the ones built from the config.
+func normalizeRuleset(r github.Ruleset) (github.Ruleset, error) {
+	normalized := github.Ruleset{
+		Name:        r.Name,
+		Target:      r.Target,
+		Enforcement: r.Enforcement,
+	}
+	if r.Conditions != nil {
+		normalized.Conditions = &github.RulesetConditions{
+			RefName:        normalizeNameCondition(r.Conditions.RefName),
+			RepositoryName: normalizeNameCondition(r.Conditions.RepositoryName),
+		}
+	}
+
+	for _, rule := range r.Rules {
+		normalizedRule := github.RulesetRule{Type: rule.Type}
+		switch rule.Type {
+		case github.RulesetRulePullRequest:
+			var params github.PullRequestRuleParameters
+			if err := unmarshalParameters(rule, &params); err != nil {
+				return github.Ruleset{}, err
+			}
+			normalizedRule.Parameters = mustMarshal(params)
+		case github.RulesetRuleRequiredStatusChecks:
+			var params github.RequiredStatusChecksRuleParameters
+			if err := unmarshalParameters(rule, &params); err != nil {
+				return github.Ruleset{}, err
+			}
+			sort.Slice(params.RequiredStatusChecks, func(i, j int) bool {
+				return params.RequiredStatusChecks[i].Context < params.RequiredStatusChecks[j].Context
+			})
+			if params.RequiredStatusChecks == nil {
+				params.RequiredStatusChecks = []github.RulesetStatusCheck{}
+			}
+			normalizedRule.Parameters = mustMarshal(params)
+		case github.RulesetRuleCreation, github.RulesetRuleUpdate, github.RulesetRuleDeletion,
+			github.RulesetRuleRequiredLinearHistory, github.RulesetRuleRequiredSignatures, github.RulesetRuleNonFastForward:
+			// Parameters of these rules, if any, are not managed.
+		default:
+			normalizedRule.Parameters = rule.Parameters
+		}
+		normalized.Rules = append(normalized.Rules, normalizedRule)
+	}
+	sort.SliceStable(normalized.Rules, func(i, j int) bool {
+		return normalized.Rules[i].Type < normalized.Rules[j].Type
+	})
+
+	normalized.BypassActors = append(normalized.BypassActors, r.BypassActors...)
+	sort.SliceStable(normalized.BypassActors, func(i, j int) bool {
+		a, b := normalized.BypassActors[i], normalized.BypassActors[j]
+		if a.ActorType != b.ActorType {
+			return a.ActorType < b.ActorType
+		}
+		return a.ActorID != nil && (b.ActorID == nil || *a.ActorID < *b.ActorID)
+	})
+	return normalized, nil
+}
+
+func normalizeNameCondition(c *github.RulesetNameCondition) *github.RulesetNameCondition {
+	if c == nil {
+		return nil
+	}
+	normalized := &github.RulesetNameCondition{
+		Include: append([]string{}, c.Include...),
+		Exclude: append([]string{}, c.Exclude...),
+	}
+	sort.Strings(normalized.Include)
+	sort.Strings(normalized.Exclude)
+	return normalized
+}
+
+func unmarshalParameters(rule github.RulesetRule, params interface{}) error {
+	if len(rule.Parameters) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(rule.Parameters, params); err != nil {
+		return fmt.Errorf("failed to parse %s rule parameters: %w", rule.Type, err)
+	}
+	return nil
+}
+
+// rulesetDiff returns a unified diff between the current ruleset, nil if it
+// does not exist yet, and the desired one. It is empty if they match.
+func rulesetDiff(current *github.Ruleset, desired github.Ruleset) (string, error) {
+	var currentYAML []byte
+	if current != nil {
+		normalized, err := normalizeRuleset(*current)
+		if err != nil {
+			return "", err
+		}
+		if currentYAML, err = yaml.Marshal(normalized); err != nil {
+			return "", err
+		}
+	}
+	normalized, err := normalizeRuleset(desired)
+	if err != nil {
+		return "", err
+	}
+	desiredYAML, err := yaml.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	return unifiedDiff(string(currentYAML), string(desiredYAML))
+}
+
+// unifiedDiff returns the unified diff between the current and desired
+// serialized states, or an empty string if they are the same.
+func unifiedDiff(current, desired string) (string, error) {
+	if current == desired {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(current),
+		B:        difflib.SplitLines(desired),
+		FromFile: "current",
+		ToFile:   "desired",
+		Context:  3,
+	})
+}
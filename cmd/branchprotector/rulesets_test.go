@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+func TestUpdateRulesets(t *testing.T) {
+	one := 1
+	signedRuleset := github.Ruleset{
+		ID:          7,
+		Name:        "signed",
+		Target:      "branch",
+		SourceType:  "Repository",
+		Source:      "org/repo",
+		Enforcement: "active",
+		Conditions: &github.RulesetConditions{
+			RefName: &github.RulesetNameCondition{Include: []string{"refs/heads/release-*", "~DEFAULT_BRANCH"}},
+		},
+		Rules: []github.RulesetRule{
+			{Type: "required_status_checks", Parameters: json.RawMessage(`{"strict_required_status_checks_policy":true,"required_status_checks":[{"context":"unit"},{"context":"e2e"}]}`)},
+			{Type: "required_signatures"},
+			{Type: "update", Parameters: json.RawMessage(`{"update_allows_fetch_and_merge":false}`)},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		config   string
+		rulesets map[string][]github.Ruleset
+		created  map[string]github.Ruleset
+		updated  map[int]github.Ruleset
+	}{
+		{
+			name: "missing org ruleset is created",
+			config: `
+branch-protection:
+  orgs:
+    org:
+      rulesets:
+      - name: org-wide
+        exclude_repos:
+        - legacy
+        block_force_pushes: true
+        restrict_deletions: true
+        bypass_actors:
+        - actor_type: OrganizationAdmin
+          actor_id: 1
+`,
+			rulesets: map[string][]github.Ruleset{
+				"org": {{ID: 3, Name: "handmade"}},
+			},
+			created: map[string]github.Ruleset{
+				"org:org-wide": {
+					Name:        "org-wide",
+					Target:      "branch",
+					Enforcement: "active",
+					Conditions: &github.RulesetConditions{
+						RefName:        &github.RulesetNameCondition{Include: []string{"~DEFAULT_BRANCH"}},
+						RepositoryName: &github.RulesetNameCondition{Include: []string{"~ALL"}, Exclude: []string{"legacy"}},
+					},
+					Rules: []github.RulesetRule{
+						{Type: "deletion"},
+						{Type: "non_fast_forward"},
+					},
+					BypassActors: []github.RulesetBypassActor{{ActorID: &one, ActorType: "OrganizationAdmin", BypassMode: "always"}},
+				},
+			},
+		},
+		{
+			name: "matching repo ruleset is left alone even in an unmanaged org",
+			config: `
+branch-protection:
+  orgs:
+    org:
+      unmanaged: true
+      repos:
+        repo:
+          rulesets:
+          - name: signed
+            include:
+            - ~DEFAULT_BRANCH
+            - refs/heads/release-*
+            required_signatures: true
+            restrict_updates: true
+            required_status_checks:
+              contexts:
+              - e2e
+              - unit
+              strict: true
+`,
+			rulesets: map[string][]github.Ruleset{
+				"org/repo": {signedRuleset},
+			},
+		},
+		{
+			name: "differing repo ruleset is updated",
+			config: `
+branch-protection:
+  orgs:
+    org:
+      repos:
+        repo:
+          rulesets:
+          - name: signed
+            enforcement: evaluate
+            required_signatures: true
+            required_pull_request_reviews:
+              required_approving_review_count: 2
+`,
+			rulesets: map[string][]github.Ruleset{
+				"org/repo": {signedRuleset},
+			},
+			updated: map[int]github.Ruleset{
+				7: {
+					Name:        "signed",
+					Target:      "branch",
+					Enforcement: "evaluate",
+					Conditions: &github.RulesetConditions{
+						RefName: &github.RulesetNameCondition{Include: []string{"~DEFAULT_BRANCH"}},
+					},
+					Rules: []github.RulesetRule{
+						{Type: "required_signatures"},
+						{Type: "pull_request", Parameters: json.RawMessage(`{"dismiss_stale_reviews_on_push":false,"require_code_owner_review":false,"require_last_push_approval":false,"required_approving_review_count":2,"required_review_thread_resolution":false}`)},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakeClient{
+				repos:    map[string][]github.Repo{"org": {{Name: "repo", FullName: "org/repo"}}},
+				branches: map[string][]github.Branch{"org/repo": {{Name: "master"}}},
+				rulesets: tc.rulesets,
+			}
+			var cfg config.Config
+			if err := yaml.Unmarshal([]byte(tc.config), &cfg); err != nil {
+				t.Fatalf("failed to parse config: %v", err)
+			}
+			p := protector{
+				client:         &fc,
+				cfg:            &cfg,
+				errors:         Errors{},
+				updates:        make(chan requirements),
+				done:           make(chan []error),
+				completedRepos: make(map[string]bool),
+				enabled:        func(org, repo string) bool { return true },
+			}
+			go func() {
+				p.protect()
+				close(p.updates)
+			}()
+			for range p.updates {
+			}
+
+			if len(p.errors.errs) != 0 {
+				t.Errorf("expected no errors, got %v", p.errors.errs)
+			}
+			if diff := cmp.Diff(tc.created, fc.createdRulesets); diff != "" {
+				t.Errorf("created rulesets differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.updated, fc.updatedRulesets); diff != "" {
+				t.Errorf("updated rulesets differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProtectionDiff(t *testing.T) {
+	yes := true
+	state := &github.BranchProtection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"unit"}},
+		Restrictions: &github.Restrictions{
+			Users: []github.User{{Login: "Bob"}},
+			Teams: []github.Team{{Slug: "admins"}},
+		},
+	}
+	request := &github.BranchProtectionRequest{
+		RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"unit"}},
+		EnforceAdmins:        &yes,
+		Restrictions: &github.RestrictionsRequest{
+			Apps:  &[]string{},
+			Users: &[]string{"bob"},
+			Teams: &[]string{"admins"},
+		},
+	}
+
+	d, err := protectionDiff(state, request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var changes []string
+	for _, line := range strings.Split(d, "\n") {
+		if (strings.HasPrefix(line, "-") || strings.HasPrefix(line, "+")) && !strings.HasPrefix(line, "---") && !strings.HasPrefix(line, "+++") {
+			changes = append(changes, line)
+		}
+	}
+	if diff := cmp.Diff([]string{"-enforce_admins: false", "+enforce_admins: true"}, changes); diff != "" {
+		t.Errorf("unexpected changes (-want +got):\n%s\nfull diff:\n%s", diff, d)
+	}
+
+	if d, err := protectionDiff(state, &github.BranchProtectionRequest{
+		RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"unit"}},
+		Restrictions: &github.RestrictionsRequest{
+			Apps:  &[]string{},
+			Users: &[]string{"bob"},
+			Teams: &[]string{"admins"},
+		},
+	}); err != nil || d != "" {
+		t.Errorf("expected no diff, got %q (err: %v)", d, err)
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// canary-controller promotes or rolls back canary config revisions. A canary
+// revision lives in its own ConfigMap next to the stable one and is used by
+// hook for the repos listed in canary.repos of the stable config. Once the
+// canary ProwJobs have baked for canary.bake_period without exceeding the
+// error budget, the canary data is copied into the stable ConfigMap.
+// Otherwise the stable data is copied back into the canary ConfigMap. Direct
+// updates of the stable ConfigMap are copied into the canary ConfigMap as
+// well, dropping a baking canary, so that it is never promoted over them.
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+)
+
+const (
+	// canaryStartAnnotation records on the canary ConfigMap when the current
+	// canary revision was first seen.
+	canaryStartAnnotation = "prow.k8s.io/canary-start"
+	// canaryBaseAnnotation records on the canary ConfigMap the hash of the
+	// stable data it last matched, to tell whether the canary or the stable
+	// ConfigMap was updated since.
+	canaryBaseAnnotation = "prow.k8s.io/canary-base"
+)
+
+type options struct {
+	config configflagutil.ConfigOptions
+
+	kubernetes             prowflagutil.KubernetesOptions
+	instrumentationOptions prowflagutil.InstrumentationOptions
+	dryRun                 bool
+
+	stableConfigMap string
+	canaryConfigMap string
+	syncPeriod      time.Duration
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	var o options
+	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to make mutating API calls to Kubernetes.")
+	fs.StringVar(&o.stableConfigMap, "stable-configmap", "", "The ConfigMap holding the stable config, as namespace/name.")
+	fs.StringVar(&o.canaryConfigMap, "canary-configmap", "", "The ConfigMap holding the canary config, as namespace/name.")
+	fs.DurationVar(&o.syncPeriod, "sync-period", 5*time.Minute, "How often to evaluate the canary.")
+	o.config.AddFlags(fs)
+	o.kubernetes.AddFlags(fs)
+	o.instrumentationOptions.AddFlags(fs)
+	fs.Parse(args)
+	return o
+}
+
+func (o *options) Validate() error {
+	for _, group := range []prowflagutil.OptionGroup{&o.kubernetes, &o.config} {
+		if err := group.Validate(o.dryRun); err != nil {
+			return err
+		}
+	}
+	for flagName, value := range map[string]string{"--stable-configmap": o.stableConfigMap, "--canary-configmap": o.canaryConfigMap} {
+		if _, err := parseNamespacedName(value); err != nil {
+			return fmt.Errorf("invalid %s: %w", flagName, err)
+		}
+	}
+	if o.stableConfigMap == o.canaryConfigMap {
+		return errors.New("--stable-configmap and --canary-configmap must differ")
+	}
+	return nil
+}
+
+func parseNamespacedName(value string) (types.NamespacedName, error) {
+	namespace, name, found := strings.Cut(value, "/")
+	if !found || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("%q must be of the form namespace/name", value)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	defer interrupts.WaitForGracefulShutdown()
+
+	pprof.Instrument(o.instrumentationOptions)
+
+	configAgent, err := o.config.ConfigAgent()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error starting config agent.")
+	}
+
+	restCfg, err := o.kubernetes.InfrastructureClusterConfig(o.dryRun)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to get infrastructure cluster config")
+	}
+	client, err := ctrlruntimeclient.New(restCfg, ctrlruntimeclient.Options{})
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to construct client")
+	}
+
+	// Validated above.
+	stable, _ := parseNamespacedName(o.stableConfigMap)
+	canary, _ := parseNamespacedName(o.canaryConfigMap)
+	interrupts.TickLiteral(func() {
+		cfg := configAgent.Config()
+		if err := sync(interrupts.Context(), client, cfg.Canary, cfg.ProwJobNamespace, stable, canary, time.Now()); err != nil {
+			logrus.WithError(err).Error("Error syncing canary config.")
+		}
+	}, o.syncPeriod)
+}
+
+// sync evaluates the canary config and promotes or rolls it back if the
+// canary is done baking.
+func sync(ctx context.Context, client ctrlruntimeclient.Client, settings *config.Canary, prowJobNamespace string, stableName, canaryName types.NamespacedName, now time.Time) error {
+	if settings == nil {
+		logrus.Debug("No canary configured.")
+		return nil
+	}
+	stable, canary := &corev1.ConfigMap{}, &corev1.ConfigMap{}
+	if err := client.Get(ctx, stableName, stable); err != nil {
+		return fmt.Errorf("failed to get stable ConfigMap %s: %w", stableName, err)
+	}
+	if err := client.Get(ctx, canaryName, canary); err != nil {
+		return fmt.Errorf("failed to get canary ConfigMap %s: %w", canaryName, err)
+	}
+	logger := logrus.WithField("canary", canaryName.String())
+	stableHash, err := dataHash(stable)
+	if err != nil {
+		return err
+	}
+	canaryHash, err := dataHash(canary)
+	if err != nil {
+		return err
+	}
+
+	if stableHash == canaryHash {
+		_, started := canary.Annotations[canaryStartAnnotation]
+		if !started && canary.Annotations[canaryBaseAnnotation] == stableHash {
+			return nil
+		}
+		logger.Info("Canary matches the stable config, clearing its start time.")
+		return updateCanary(ctx, client, canary, stableHash)
+	}
+	if base, ok := canary.Annotations[canaryBaseAnnotation]; ok && base != stableHash {
+		if canaryHash == base {
+			logger.Info("The stable config was updated directly, copying it into the canary.")
+		} else {
+			logger.Warn("The stable config was updated directly while the canary was baking, dropping the canary.")
+		}
+		canary.Data, canary.BinaryData = stable.Data, stable.BinaryData
+		return updateCanary(ctx, client, canary, stableHash)
+	}
+
+	startValue, ok := canary.Annotations[canaryStartAnnotation]
+	if !ok {
+		logger.Info("Found a new canary, starting to bake.")
+		if canary.Annotations == nil {
+			canary.Annotations = map[string]string{}
+		}
+		canary.Annotations[canaryStartAnnotation] = now.Format(time.RFC3339)
+		return client.Update(ctx, canary)
+	}
+	start, err := time.Parse(time.RFC3339, startValue)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s annotation of %s: %w", canaryStartAnnotation, canaryName, err)
+	}
+
+	var jobs prowapi.ProwJobList
+	if err := client.List(ctx, &jobs, ctrlruntimeclient.InNamespace(prowJobNamespace), ctrlruntimeclient.MatchingLabels{kube.CanaryLabel: "true"}); err != nil {
+		return fmt.Errorf("failed to list canary ProwJobs: %w", err)
+	}
+	decision, reason := settings.Evaluate(jobs.Items, start, now)
+	logger = logger.WithFields(logrus.Fields{"decision": decision, "reason": reason})
+	switch decision {
+	case config.CanaryPromote:
+		logger.Info("Promoting canary.")
+		stable.Data, stable.BinaryData = canary.Data, canary.BinaryData
+		if err := client.Update(ctx, stable); err != nil {
+			return fmt.Errorf("failed to promote canary to %s: %w", stableName, err)
+		}
+		stableHash = canaryHash
+	case config.CanaryRollback:
+		logger.Warn("Rolling back canary.")
+		canary.Data, canary.BinaryData = stable.Data, stable.BinaryData
+	default:
+		logger.Debug("Canary is baking.")
+		return nil
+	}
+	if err := updateCanary(ctx, client, canary, stableHash); err != nil {
+		return fmt.Errorf("failed to update canary %s: %w", canaryName, err)
+	}
+	return nil
+}
+
+// updateCanary updates the canary ConfigMap once it matches the stable data
+// with the given hash.
+func updateCanary(ctx context.Context, client ctrlruntimeclient.Client, canary *corev1.ConfigMap, stableHash string) error {
+	if canary.Annotations == nil {
+		canary.Annotations = map[string]string{}
+	}
+	delete(canary.Annotations, canaryStartAnnotation)
+	canary.Annotations[canaryBaseAnnotation] = stableHash
+	return client.Update(ctx, canary)
+}
+
+// dataHash returns a hash of the data of the ConfigMap.
+func dataHash(cm *corev1.ConfigMap) (string, error) {
+	// Maps are marshalled with sorted keys. Empty and missing data are the
+	// same to the components reading the ConfigMap.
+	var data struct {
+		Data       map[string]string `json:"data,omitempty"`
+		BinaryData map[string][]byte `json:"binaryData,omitempty"`
+	}
+	data.Data, data.BinaryData = cm.Data, cm.BinaryData
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash the data of ConfigMap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
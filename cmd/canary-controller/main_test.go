@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func TestSync(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	stableName := types.NamespacedName{Namespace: "ns", Name: "config"}
+	canaryName := types.NamespacedName{Namespace: "ns", Name: "config-canary"}
+	settings := &config.Canary{BakePeriod: &metav1.Duration{Duration: time.Hour}, MaxErrorPercentage: utilpointer.Int(10)}
+
+	configMap := func(name types.NamespacedName, data string, start *time.Time) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name},
+			Data:       map[string]string{"config.yaml": data},
+		}
+		if start != nil {
+			cm.Annotations = map[string]string{canaryStartAnnotation: start.Format(time.RFC3339)}
+		}
+		return cm
+	}
+	prowJob := func(name string, state prowapi.ProwJobState) *prowapi.ProwJob {
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "prowjobs",
+				Name:              name,
+				Labels:            map[string]string{kube.CanaryLabel: "true"},
+				CreationTimestamp: metav1.NewTime(now.Add(-90 * time.Minute)),
+			},
+			Status: prowapi.ProwJobStatus{State: state, CompletionTime: &metav1.Time{Time: now}},
+		}
+	}
+	// basedOn records that the canary last matched the stable data.
+	basedOn := func(cm *corev1.ConfigMap, stableData string) *corev1.ConfigMap {
+		hash, err := dataHash(configMap(stableName, stableData, nil))
+		if err != nil {
+			t.Fatalf("failed to hash data: %v", err)
+		}
+		if cm.Annotations == nil {
+			cm.Annotations = map[string]string{}
+		}
+		cm.Annotations[canaryBaseAnnotation] = hash
+		return cm
+	}
+	longAgo := now.Add(-2 * time.Hour)
+	recently := now.Add(-10 * time.Minute)
+
+	testCases := []struct {
+		name           string
+		settings       *config.Canary
+		objects        []ctrlruntimeclient.Object
+		expectedStable string
+		expectedCanary string
+		expectedStart  *time.Time
+	}{
+		{
+			name:           "no canary configured",
+			objects:        []ctrlruntimeclient.Object{configMap(stableName, "stable", nil), configMap(canaryName, "canary", nil)},
+			expectedStable: "stable",
+			expectedCanary: "canary",
+		},
+		{
+			name:           "identical configs clear the start time",
+			settings:       settings,
+			objects:        []ctrlruntimeclient.Object{configMap(stableName, "stable", nil), configMap(canaryName, "stable", &longAgo)},
+			expectedStable: "stable",
+			expectedCanary: "stable",
+		},
+		{
+			name:           "new canary starts baking",
+			settings:       settings,
+			objects:        []ctrlruntimeclient.Object{configMap(stableName, "stable", nil), configMap(canaryName, "canary", nil)},
+			expectedStable: "stable",
+			expectedCanary: "canary",
+			expectedStart:  &now,
+		},
+		{
+			name:           "baking canary is left alone",
+			settings:       settings,
+			objects:        []ctrlruntimeclient.Object{configMap(stableName, "stable", nil), configMap(canaryName, "canary", &recently)},
+			expectedStable: "stable",
+			expectedCanary: "canary",
+			expectedStart:  &recently,
+		},
+		{
+			name:           "baking canary based on the stable config is left alone",
+			settings:       settings,
+			objects:        []ctrlruntimeclient.Object{configMap(stableName, "stable", nil), basedOn(configMap(canaryName, "canary", &recently), "stable")},
+			expectedStable: "stable",
+			expectedCanary: "canary",
+			expectedStart:  &recently,
+		},
+		{
+			name:           "direct update of the stable config is copied into the canary",
+			settings:       settings,
+			objects:        []ctrlruntimeclient.Object{configMap(stableName, "updated", nil), basedOn(configMap(canaryName, "stable", nil), "stable")},
+			expectedStable: "updated",
+			expectedCanary: "updated",
+		},
+		{
+			name:           "direct update of the stable config drops the baking canary",
+			settings:       settings,
+			objects:        []ctrlruntimeclient.Object{configMap(stableName, "updated", nil), basedOn(configMap(canaryName, "canary", &longAgo), "stable"), prowJob("a", prowapi.SuccessState)},
+			expectedStable: "updated",
+			expectedCanary: "updated",
+		},
+		{
+			name:     "baked canary is promoted",
+			settings: settings,
+			objects: []ctrlruntimeclient.Object{
+				configMap(stableName, "stable", nil), configMap(canaryName, "canary", &longAgo),
+				prowJob("a", prowapi.SuccessState),
+			},
+			expectedStable: "canary",
+			expectedCanary: "canary",
+		},
+		{
+			name:     "erroring canary is rolled back",
+			settings: settings,
+			objects: []ctrlruntimeclient.Object{
+				configMap(stableName, "stable", nil), configMap(canaryName, "canary", &longAgo),
+				prowJob("a", prowapi.SuccessState), prowJob("b", prowapi.ErrorState),
+			},
+			expectedStable: "stable",
+			expectedCanary: "stable",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.objects...).Build()
+			if err := sync(context.Background(), client, tc.settings, "prowjobs", stableName, canaryName, now); err != nil {
+				t.Fatalf("sync failed: %v", err)
+			}
+			stable, canary := &corev1.ConfigMap{}, &corev1.ConfigMap{}
+			if err := client.Get(context.Background(), stableName, stable); err != nil {
+				t.Fatalf("failed to get stable ConfigMap: %v", err)
+			}
+			if err := client.Get(context.Background(), canaryName, canary); err != nil {
+				t.Fatalf("failed to get canary ConfigMap: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedStable, stable.Data["config.yaml"]); diff != "" {
+				t.Errorf("stable data differs from expected: %s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedCanary, canary.Data["config.yaml"]); diff != "" {
+				t.Errorf("canary data differs from expected: %s", diff)
+			}
+			var expectedStart string
+			if tc.expectedStart != nil {
+				expectedStart = tc.expectedStart.Format(time.RFC3339)
+			}
+			if diff := cmp.Diff(expectedStart, canary.Annotations[canaryStartAnnotation]); diff != "" {
+				t.Errorf("canary start differs from expected: %s", diff)
+			}
+			if tc.settings != nil && tc.expectedStable == tc.expectedCanary {
+				expectedBase, err := dataHash(stable)
+				if err != nil {
+					t.Fatalf("failed to hash data: %v", err)
+				}
+				if diff := cmp.Diff(expectedBase, canary.Annotations[canaryBaseAnnotation]); diff != "" {
+					t.Errorf("canary base differs from expected: %s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestParseNamespacedName(t *testing.T) {
+	if actual, err := parseNamespacedName("ns/name"); err != nil || actual != (types.NamespacedName{Namespace: "ns", Name: "name"}) {
+		t.Errorf("unexpected result %v, %v", actual, err)
+	}
+	for _, invalid := range []string{"", "name", "/name", "ns/"} {
+		if _, err := parseNamespacedName(invalid); err == nil {
+			t.Errorf("expected %q to be invalid", invalid)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/prow/pkg/checkconfig"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// analyzers returns the built-in analyzers followed by the compiled-in and
+// exec analyzers. The built-in ones are closures over the options because
+// some of them need clients that are configured through flags.
+func (o *options) analyzers() []*checkconfig.Analyzer {
+	builtin := []*checkconfig.Analyzer{
+		{
+			Name:                 verifyOwnersFilePresence,
+			Doc:                  "Checks that repos using OWNERS based plugins contain OWNERS files.",
+			RequiresPluginConfig: true,
+			Run: func(pass *checkconfig.Pass) error {
+				if o.github.TokenPath == "" {
+					return errors.New("cannot verify OWNERS file presence without a GitHub token")
+				}
+				githubClient, err := o.github.GitHubClient(false)
+				if err != nil {
+					return fmt.Errorf("error loading GitHub client: %w", err)
+				}
+				// 404s are expected to happen, no point in retrying
+				githubClient.SetMax404Retries(0)
+				pass.Report(verifyOwnersPresence(pass.PluginConfig, githubClient))
+				return nil
+			},
+		},
+		{
+			Name:                 mismatchedTideWarning,
+			Doc:                  "Checks that Tide queries match the plugins enabled for the repos.",
+			RequiresPluginConfig: true,
+			Run: func(pass *checkconfig.Pass) error {
+				pass.Report(validateTideRequirements(pass.Config, pass.PluginConfig, true))
+				return nil
+			},
+		},
+		{
+			Name:                 mismatchedTideLenientWarning,
+			Doc:                  "Like mismatched-tide, but does not check forbidden labels.",
+			RequiresPluginConfig: true,
+			Run: func(pass *checkconfig.Pass) error {
+				// mismatched-tide is a superset, don't report twice.
+				if o.warningEnabled(mismatchedTideWarning) {
+					return nil
+				}
+				pass.Report(validateTideRequirements(pass.Config, pass.PluginConfig, false))
+				return nil
+			},
+		},
+		simpleAnalyzer(nonDecoratedJobsWarning, "Checks that jobs running in Kubernetes are decorated.", validateDecoratedJobs),
+		simpleAnalyzer(validDecorationConfigWarning, "Checks that the decoration config of jobs is valid.", validateDecorationConfig),
+		simpleAnalyzer(jobNameLengthWarning, "Checks that job names are short enough to be used as label values.", func(cfg *config.Config) error {
+			return validateJobRequirements(cfg.JobConfig)
+		}),
+		simpleAnalyzer(jobRefsDuplicationWarning, "Checks that jobs don't clone the same repository twice.", func(cfg *config.Config) error {
+			return validateJobExtraRefs(cfg.JobConfig)
+		}),
+		simpleAnalyzer(periodicDefaultCloneWarning, "Checks that periodics don't set clone options without extra_refs.", func(cfg *config.Config) error {
+			return validatePeriodicDefaultCloneConfig(cfg.JobConfig)
+		}),
+		simpleAnalyzer(needsOkToTestWarning, "Checks that presubmits always running are consistent with the needs-ok-to-test label.", validateNeedsOkToTestLabel),
+		simpleAnalyzer(managedWebhooksWarning, "Checks that managed webhooks don't overlap.", validateManagedWebhooks),
+		{
+			Name:                 validateOwnersWarning,
+			Doc:                  "Checks that the owners plugins are configured consistently.",
+			RequiresPluginConfig: true,
+			Run: func(pass *checkconfig.Pass) error {
+				pass.Report(verifyOwnersPlugin(pass.PluginConfig))
+				return nil
+			},
+		},
+		{
+			Name:                 missingTriggerWarning,
+			Doc:                  "Checks that repos with presubmits enable the trigger plugin.",
+			RequiresPluginConfig: true,
+			Run: func(pass *checkconfig.Pass) error {
+				pass.Report(validateTriggers(pass.Config, pass.PluginConfig))
+				return nil
+			},
+		},
+		{
+			Name:                 validateURLsWarning,
+			Doc:                  "Checks that the URLs in the config are valid.",
+			RequiresPluginConfig: true,
+			Run: func(pass *checkconfig.Pass) error {
+				pass.Report(validateURLs(pass.Config.ProwConfig))
+				return nil
+			},
+		},
+		{
+			Name: unknownFieldsWarning,
+			Doc:  "Checks that the Prow and plugin configs don't contain unknown fields.",
+			Run: func(pass *checkconfig.Pass) error {
+				// unknown-fields-all is a superset, don't report twice.
+				if o.warningEnabled(unknownFieldsAllWarning) {
+					return nil
+				}
+				cfgBytes, err := os.ReadFile(o.config.ConfigPath)
+				if err != nil {
+					return fmt.Errorf("error reading Prow config for validation: %w", err)
+				}
+				pass.Report(validateUnknownFields(&config.Config{}, cfgBytes, o.config.ConfigPath))
+				return o.validateUnknownPluginFields(pass)
+			},
+		},
+		{
+			Name: unknownFieldsAllWarning,
+			Doc:  "Like unknown-fields, but also checks the job configs.",
+			Run: func(pass *checkconfig.Pass) error {
+				if _, err := config.LoadStrict(o.config.ConfigPath, o.config.JobConfigPath, nil, ""); err != nil {
+					pass.Report(err)
+				}
+				return o.validateUnknownPluginFields(pass)
+			},
+		},
+		simpleAnalyzer(tideStrictBranchWarning, "Checks that Tide's strict branch protection is consistent with branch protection.", func(cfg *config.Config) error {
+			return validateStrictBranches(cfg.ProwConfig)
+		}),
+		simpleAnalyzer(tideContextPolicy, "Checks that Tide's context policy can be resolved for every repo.", validateTideContextPolicy),
+		{
+			Name: validateClusterFieldWarning,
+			Doc:  "Checks that jobs only use known and reachable build clusters.",
+			Run: func(pass *checkconfig.Pass) error {
+				opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile)
+				if err != nil {
+					return fmt.Errorf("error creating opener: %w", err)
+				}
+				pass.Report(validateCluster(pass.Config, opener))
+				return nil
+			},
+		},
+		{
+			Name: validateSupplementalProwConfigOrgRepoHirarchy,
+			Doc:  "Checks that supplemental configs follow the org/repo directory structure.",
+			Run: func(pass *checkconfig.Pass) error {
+				pass.Report(validateAdditionalProwConfigIsInOrgRepoDirectoryStructure(os.DirFS("./"), o.config.SupplementalProwConfigDirs.Strings(), o.pluginsConfig.SupplementalPluginsConfigDirs.Strings(), o.config.SupplementalProwConfigsFileNameSuffix, o.pluginsConfig.SupplementalPluginsConfigsFileNameSuffix))
+				return nil
+			},
+		},
+		simpleAnalyzer(validateUnmanagedBranchConfigHasNoSubconfig, "Checks that unmanaged branch protection configs have no sub-configs.", func(cfg *config.Config) error {
+			return validateUnmanagedBranchprotectionConfigDoesntHaveSubconfig(cfg.BranchProtection)
+		}),
+		{
+			Name: validateGitHubAppInstallationWarning,
+			Doc:  "Checks that the GitHub App is installed in every repo with jobs.",
+			Run: func(pass *checkconfig.Pass) error {
+				githubClient, err := o.github.GitHubClient(false)
+				if err != nil {
+					return fmt.Errorf("error loading GitHub client: %w", err)
+				}
+				pass.Report(validateGitHubAppIsInstalled(githubClient, pass.Config.AllRepos))
+				return nil
+			},
+		},
+		{
+			Name:                 validateLabelWarning,
+			Doc:                  "Checks that the label plugin config is valid.",
+			RequiresPluginConfig: true,
+			Run: func(pass *checkconfig.Pass) error {
+				pass.Report(verifyLabelPlugin(pass.PluginConfig.Label))
+				return nil
+			},
+		},
+		simpleAnalyzer(requiredJobAnnotationsWarning, "Checks that jobs set the annotations given by --required-job-annotations.", func(cfg *config.Config) error {
+			return validateRequiredJobAnnotations(o.requiredJobAnnotations.Strings(), cfg.JobConfig)
+		}),
+		simpleAnalyzer(jobPoliciesWarning, "Checks that jobs comply with the job policies.", validateJobPolicies),
+		{
+			Name: jobConfigSourceConflictsWarning,
+			Doc:  "Checks that jobs from job config sources and JobConfig resources don't conflict with jobs of higher precedence.",
+			Run: func(pass *checkconfig.Pass) error {
+				for _, conflict := range pass.Config.SourceConflicts {
+					pass.Report(errors.New(conflict))
+				}
+				return nil
+			},
+		},
+	}
+	return append(append(builtin, checkconfig.Registered()...), o.execAnalyzers...)
+}
+
+// simpleAnalyzer wraps a check that only looks at the Prow config.
+func simpleAnalyzer(name, doc string, check func(*config.Config) error) *checkconfig.Analyzer {
+	return &checkconfig.Analyzer{
+		Name: name,
+		Doc:  doc,
+		Run: func(pass *checkconfig.Pass) error {
+			pass.Report(check(pass.Config))
+			return nil
+		},
+	}
+}
+
+func (o *options) validateUnknownPluginFields(pass *checkconfig.Pass) error {
+	if pass.PluginConfig == nil {
+		return nil
+	}
+	pcfgBytes, err := os.ReadFile(o.pluginsConfig.PluginConfigPath)
+	if err != nil {
+		return fmt.Errorf("error reading Prow plugin config for validation: %w", err)
+	}
+	if err := validateUnknownFields(&plugins.Configuration{}, pcfgBytes, o.pluginsConfig.PluginConfigPath); err != nil {
+		pass.ReportFile(o.pluginsConfig.PluginConfigPath, err.Error())
+	}
+	return nil
+}
+
+func writeSARIF(path string, analyzers []*checkconfig.Analyzer, findings []checkconfig.Finding, strict bool) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := checkconfig.WriteSARIF(f, analyzers, findings, strict); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/prow/pkg/checkconfig"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// printJobDiff writes the jobs that differ between the base revision and the config
+// passed with --config-path and --job-config-path.
+func printJobDiff(o options, w io.Writer) error {
+	base, err := config.Load(o.diffBaseConfigPath, o.diffBaseJobConfigPath, nil, "")
+	if err != nil {
+		return fmt.Errorf("error loading base config: %w", err)
+	}
+	configAgent, err := o.config.ConfigAgent()
+	if err != nil {
+		return fmt.Errorf("error loading prow config: %w", err)
+	}
+	return checkconfig.WriteDiffMarkdown(w, checkconfig.DiffJobs(&base.JobConfig, &configAgent.Config().JobConfig))
+}
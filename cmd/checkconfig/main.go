@@ -41,6 +41,7 @@ import (
 
 	needsrebase "sigs.k8s.io/prow/cmd/external-plugins/needs-rebase/plugin"
 	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/checkconfig"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
@@ -51,17 +52,22 @@ import (
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/labels"
 	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/plank"
 	"sigs.k8s.io/prow/pkg/plugins"
 	"sigs.k8s.io/prow/pkg/plugins/approve"
+	"sigs.k8s.io/prow/pkg/plugins/backport"
 	"sigs.k8s.io/prow/pkg/plugins/blockade"
 	"sigs.k8s.io/prow/pkg/plugins/blunderbuss"
 	"sigs.k8s.io/prow/pkg/plugins/bugzilla"
 	"sigs.k8s.io/prow/pkg/plugins/cherrypickunapproved"
+	"sigs.k8s.io/prow/pkg/plugins/dependencyreview"
 	"sigs.k8s.io/prow/pkg/plugins/hold"
 	labelplugin "sigs.k8s.io/prow/pkg/plugins/label"
+	"sigs.k8s.io/prow/pkg/plugins/largefiles"
 	"sigs.k8s.io/prow/pkg/plugins/lgtm"
 	ownerslabel "sigs.k8s.io/prow/pkg/plugins/owners-label"
+	"sigs.k8s.io/prow/pkg/plugins/prdescription"
 	"sigs.k8s.io/prow/pkg/plugins/releasenote"
 	"sigs.k8s.io/prow/pkg/plugins/trigger"
 	verifyowners "sigs.k8s.io/prow/pkg/plugins/verify-owners"
@@ -84,6 +90,14 @@ type options struct {
 
 	github  flagutil.GitHubOptions
 	storage flagutil.StorageClientOptions
+
+	execAnalyzerFlags flagutil.Strings
+	execAnalyzers     []*checkconfig.Analyzer
+	sarifFile         string
+
+	diff                  bool
+	diffBaseConfigPath    string
+	diffBaseJobConfigPath string
 }
 
 func reportWarning(strict bool, errs utilerrors.Aggregate) {
@@ -123,6 +137,8 @@ const (
 	validateLabelWarning                          = "validate-label"
 	requiredJobAnnotationsWarning                 = "required-job-annotations"
 	periodicDefaultCloneWarning                   = "periodic-default-clone-config"
+	jobPoliciesWarning                            = "job-policies"
+	jobConfigSourceConflictsWarning               = "job-config-source-conflicts"
 
 	defaultHourlyTokens = 3000
 	defaultAllowedBurst = 100
@@ -148,6 +164,8 @@ var defaultWarnings = []string{
 	validateLabelWarning,
 	requiredJobAnnotationsWarning,
 	periodicDefaultCloneWarning,
+	jobPoliciesWarning,
+	jobConfigSourceConflictsWarning,
 }
 
 var expensiveWarnings = []string{
@@ -169,10 +187,29 @@ func getAllWarnings() []string {
 	all = append(all, defaultWarnings...)
 	all = append(all, expensiveWarnings...)
 	all = append(all, optionalWarnings...)
+	for _, a := range checkconfig.Registered() {
+		all = append(all, a.Name)
+	}
 
 	return all
 }
 
+// getDefaultWarnings returns the warnings that are enabled unless warnings
+// are selected explicitly.
+func (o *options) getDefaultWarnings() []string {
+	var defaults []string
+	defaults = append(defaults, defaultWarnings...)
+	for _, a := range checkconfig.Registered() {
+		if a.Default {
+			defaults = append(defaults, a.Name)
+		}
+	}
+	for _, a := range o.execAnalyzers {
+		defaults = append(defaults, a.Name)
+	}
+	return defaults
+}
+
 func (o *options) DefaultAndValidate() error {
 	allWarnings := getAllWarnings()
 	for _, validate := range []interface{ Validate(bool) error }{&o.config, &o.pluginsConfig, &o.storage} {
@@ -184,6 +221,23 @@ func (o *options) DefaultAndValidate() error {
 	if o.prowYAMLPath != "" && o.prowYAMLRepoName == "" {
 		return errors.New("--prow-yaml-repo-path requires --prow-yaml-repo-name to be set")
 	}
+	if o.diff && o.diffBaseConfigPath == "" {
+		return errors.New("--diff requires --diff-base-config-path to be set")
+	}
+	o.execAnalyzers = nil
+	for _, value := range o.execAnalyzerFlags.Strings() {
+		a, err := checkconfig.ParseExecAnalyzer(value)
+		if err != nil {
+			return err
+		}
+		for _, existing := range append(allWarnings, o.execAnalyzerNames()...) {
+			if existing == a.Name {
+				return fmt.Errorf("exec analyzer %s conflicts with an existing warning", a.Name)
+			}
+		}
+		o.execAnalyzers = append(o.execAnalyzers, a)
+	}
+	allWarnings = append(allWarnings, o.execAnalyzerNames()...)
 	for _, warning := range o.warnings.Strings() {
 		found := false
 		for _, registeredWarning := range allWarnings {
@@ -199,6 +253,14 @@ func (o *options) DefaultAndValidate() error {
 	return nil
 }
 
+func (o *options) execAnalyzerNames() []string {
+	var names []string
+	for _, a := range o.execAnalyzers {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
 func parseOptions() (options, error) {
 	o := options{}
 
@@ -218,6 +280,11 @@ func (o *options) gatherOptions(flag *flag.FlagSet, args []string) error {
 	flag.BoolVar(&o.expensive, "expensive-checks", false, "If set, additional expensive warnings will be enabled")
 	flag.BoolVar(&o.strict, "strict", false, "If set, consider all warnings as errors.")
 	flag.BoolVar(&o.includeDefaultWarnings, "include-default-warnings", false, "If set force inclusion of default warning set. Normally this is inferred based on a lack of '--warnings' flags.")
+	flag.Var(&o.execAnalyzerFlags, "exec-analyzer", "Additional analyzer to run, as name=path to an executable implementing the checkconfig exec protocol. Use repeatedly to provide a list of analyzers")
+	flag.StringVar(&o.sarifFile, "sarif-file", "", "If set, write the findings as a SARIF log to this file.")
+	flag.BoolVar(&o.diff, "diff", false, "If set, print the jobs affected by the changes to the config compared to --diff-base-config-path and --diff-base-job-config-path as Markdown instead of validating the config.")
+	flag.StringVar(&o.diffBaseConfigPath, "diff-base-config-path", "", "Path to the config.yaml of the base revision for --diff.")
+	flag.StringVar(&o.diffBaseJobConfigPath, "diff-base-job-config-path", "", "Path to the job config of the base revision for --diff.")
 	o.github.AddCustomizedFlags(flag, throttlerDefaults)
 	o.github.AllowAnonymous = true
 	o.config.AddFlags(flag)
@@ -240,6 +307,13 @@ func main() {
 		logrus.Fatalf("Error parsing options - %v", err)
 	}
 
+	if o.diff {
+		if err := printJobDiff(o, os.Stdout); err != nil {
+			logrus.WithError(err).Fatal("Failed to diff config")
+		}
+		return
+	}
+
 	if err := validate(o); err != nil {
 		switch e := err.(type) {
 		case utilerrors.Aggregate:
@@ -257,9 +331,9 @@ func validate(o options) error {
 	// use all warnings by default
 	if len(o.warnings.Strings()) == 0 || o.includeDefaultWarnings {
 		if o.expensive {
-			o.warnings = flagutil.NewStrings(append(o.warnings.Strings(), getAllWarnings()...)...)
+			o.warnings = flagutil.NewStrings(append(append(o.warnings.Strings(), getAllWarnings()...), o.execAnalyzerNames()...)...)
 		} else {
-			o.warnings = flagutil.NewStrings(append(o.warnings.Strings(), defaultWarnings...)...)
+			o.warnings = flagutil.NewStrings(append(o.warnings.Strings(), o.getDefaultWarnings()...)...)
 		}
 	}
 	if o.github.AppID != "" && o.github.AppPrivateKeyPath != "" {
@@ -292,159 +366,25 @@ func validate(o options) error {
 	// detect them here but don't necessarily want to stop config re-load
 	// in all components on their failure.
 	var errs []error
-	if pcfg != nil && o.warningEnabled(verifyOwnersFilePresence) {
-		if o.github.TokenPath == "" {
-			return errors.New("cannot verify OWNERS file presence without a GitHub token")
-		}
-
-		githubClient, err := o.github.GitHubClient(false)
-		if err != nil {
-			return fmt.Errorf("error loading GitHub client: %w", err)
-		}
-		// 404s are expected to happen, no point in retrying
-		githubClient.SetMax404Retries(0)
-
-		if err := verifyOwnersPresence(pcfg, githubClient); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if pcfg != nil && o.warningEnabled(mismatchedTideWarning) {
-		if err := validateTideRequirements(cfg, pcfg, true); err != nil {
-			errs = append(errs, err)
-		}
-	} else if pcfg != nil && o.warningEnabled(mismatchedTideLenientWarning) {
-		if err := validateTideRequirements(cfg, pcfg, false); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if o.warningEnabled(nonDecoratedJobsWarning) {
-		if err := validateDecoratedJobs(cfg); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if o.warningEnabled(validDecorationConfigWarning) {
-		if err := validateDecorationConfig(cfg); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if o.warningEnabled(jobNameLengthWarning) {
-		if err := validateJobRequirements(cfg.JobConfig); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if o.warningEnabled(jobRefsDuplicationWarning) {
-		if err := validateJobExtraRefs(cfg.JobConfig); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if o.warningEnabled(periodicDefaultCloneWarning) {
-		if err := validatePeriodicDefaultCloneConfig(cfg.JobConfig); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if o.warningEnabled(needsOkToTestWarning) {
-		if err := validateNeedsOkToTestLabel(cfg); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if o.warningEnabled(managedWebhooksWarning) {
-		if err := validateManagedWebhooks(cfg); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if pcfg != nil && o.warningEnabled(validateOwnersWarning) {
-		if err := verifyOwnersPlugin(pcfg); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if pcfg != nil && o.warningEnabled(missingTriggerWarning) {
-		if err := validateTriggers(cfg, pcfg); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if pcfg != nil && o.warningEnabled(validateURLsWarning) {
-		if err := validateURLs(cfg.ProwConfig); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	// If both "unknown-fields" and "unknown-fields-all" are enabled, just run "unknown-fields-all" validation
-	// since it is a superset. This will avoid duplicate warnings.
-	unknownAllEnabled := o.warningEnabled(unknownFieldsAllWarning)
-	unknownEnabled := o.warningEnabled(unknownFieldsWarning)
-	if unknownAllEnabled {
-		if _, err := config.LoadStrict(o.config.ConfigPath, o.config.JobConfigPath, nil, ""); err != nil {
-			errs = append(errs, err)
-		}
-	} else if unknownEnabled {
-		cfgBytes, err := os.ReadFile(o.config.ConfigPath)
-		if err != nil {
-			return fmt.Errorf("error reading Prow config for validation: %w", err)
-		}
-		if err := validateUnknownFields(&config.Config{}, cfgBytes, o.config.ConfigPath); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if pcfg != nil && (unknownEnabled || unknownAllEnabled) {
-		pcfgBytes, err := os.ReadFile(o.pluginsConfig.PluginConfigPath)
-		if err != nil {
-			return fmt.Errorf("error reading Prow plugin config for validation: %w", err)
-		}
-		if err := validateUnknownFields(&plugins.Configuration{}, pcfgBytes, o.pluginsConfig.PluginConfigPath); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if o.warningEnabled(tideStrictBranchWarning) {
-		if err := validateStrictBranches(cfg.ProwConfig); err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if o.warningEnabled(tideContextPolicy) {
-		if err := validateTideContextPolicy(cfg); err != nil {
-			errs = append(errs, err)
+	var ran []*checkconfig.Analyzer
+	var findings []checkconfig.Finding
+	for _, a := range o.analyzers() {
+		if !o.warningEnabled(a.Name) {
+			continue
 		}
-	}
-	if o.warningEnabled(validateClusterFieldWarning) {
-		opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile)
+		found, err := checkconfig.Run(a, cfg, pcfg, o.config.ConfigPath)
 		if err != nil {
-			logrus.WithError(err).Fatal("Error creating opener")
-		}
-		if err := validateCluster(cfg, opener); err != nil {
-			errs = append(errs, err)
-		}
-	}
-
-	if o.warningEnabled(validateSupplementalProwConfigOrgRepoHirarchy) {
-		if err := validateAdditionalProwConfigIsInOrgRepoDirectoryStructure(os.DirFS("./"), o.config.SupplementalProwConfigDirs.Strings(), o.pluginsConfig.SupplementalPluginsConfigDirs.Strings(), o.config.SupplementalProwConfigsFileNameSuffix, o.pluginsConfig.SupplementalPluginsConfigsFileNameSuffix); err != nil {
-			errs = append(errs, err)
+			return err
 		}
+		ran = append(ran, a)
+		findings = append(findings, found...)
 	}
-
-	if o.warningEnabled(validateUnmanagedBranchConfigHasNoSubconfig) {
-		if err := validateUnmanagedBranchprotectionConfigDoesntHaveSubconfig(cfg.BranchProtection); err != nil {
-			errs = append(errs, err)
-		}
+	for _, finding := range findings {
+		errs = append(errs, finding)
 	}
-
-	if o.warningEnabled(validateGitHubAppInstallationWarning) {
-		githubClient, err := o.github.GitHubClient(false)
-		if err != nil {
-			return fmt.Errorf("error loading GitHub client: %w", err)
-		}
-
-		if err := validateGitHubAppIsInstalled(githubClient, cfg.AllRepos); err != nil {
-			errs = append(errs, err)
-		}
-	}
-
-	if pcfg != nil && o.warningEnabled(validateLabelWarning) {
-		if err := verifyLabelPlugin(pcfg.Label); err != nil {
-			errs = append(errs, err)
-		}
-	}
-
-	if o.warningEnabled(requiredJobAnnotationsWarning) {
-		if err := validateRequiredJobAnnotations(o.requiredJobAnnotations.Strings(), cfg.JobConfig); err != nil {
-			errs = append(errs, err)
+	if o.sarifFile != "" {
+		if err := writeSARIF(o.sarifFile, ran, findings, o.strict); err != nil {
+			return err
 		}
 	}
 
@@ -685,6 +625,10 @@ func validateTideRequirements(cfg *config.Config, pcfg *plugins.Configuration, i
 			plugin{name: verifyowners.PluginName, label: labels.InvalidOwners, matcher: forbids},
 			plugin{name: releasenote.PluginName, label: labels.ReleaseNoteLabelNeeded, matcher: forbids},
 			plugin{name: cherrypickunapproved.PluginName, label: labels.CpUnapproved, matcher: forbids},
+			plugin{name: backport.PluginName, label: labels.NeedsBackportApproval, matcher: forbids},
+			plugin{name: prdescription.PluginName, label: labels.MissingDescription, matcher: forbids},
+			plugin{name: dependencyreview.PluginName, label: labels.NeedsDependencyReview, matcher: forbids},
+			plugin{name: largefiles.PluginName, label: labels.LargeFiles, matcher: forbids},
 			plugin{name: blockade.PluginName, label: labels.BlockedPaths, matcher: forbids},
 			plugin{name: needsrebase.PluginName, label: labels.NeedsRebase, external: true, matcher: forbids},
 		)
@@ -1553,3 +1497,49 @@ func validateRequiredJobAnnotations(a []string, c config.JobConfig) error {
 	}
 	return utilerrors.NewAggregate(errs)
 }
+
+func validateJobPolicies(cfg *config.Config) error {
+	if len(cfg.JobPolicies) == 0 {
+		return nil
+	}
+	validator := func(name string, pj v1.ProwJob) error {
+		violations, err := cfg.EvaluateJobPolicies(&pj)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate job policies for job '%s': %w", name, err)
+		}
+		var errs []error
+		for _, violation := range violations {
+			errs = append(errs, fmt.Errorf("job '%s' violates policy %s", name, violation))
+		}
+		return utilerrors.NewAggregate(errs)
+	}
+	var errs []error
+
+	for repo, presubmits := range cfg.PresubmitsStatic {
+		org, name, err := config.SplitRepoName(repo)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, presubmit := range presubmits {
+			pj := pjutil.NewProwJob(pjutil.PresubmitSpec(presubmit, v1.Refs{Org: org, Repo: name}), presubmit.Labels, presubmit.Annotations)
+			errs = append(errs, validator(presubmit.Name, pj))
+		}
+	}
+	for repo, postsubmits := range cfg.PostsubmitsStatic {
+		org, name, err := config.SplitRepoName(repo)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, postsubmit := range postsubmits {
+			pj := pjutil.NewProwJob(pjutil.PostsubmitSpec(postsubmit, v1.Refs{Org: org, Repo: name}), postsubmit.Labels, postsubmit.Annotations)
+			errs = append(errs, validator(postsubmit.Name, pj))
+		}
+	}
+	for _, periodic := range cfg.Periodics {
+		pj := pjutil.NewProwJob(pjutil.PeriodicSpec(periodic), periodic.Labels, periodic.Annotations)
+		errs = append(errs, validator(periodic.Name, pj))
+	}
+	return utilerrors.NewAggregate(errs)
+}
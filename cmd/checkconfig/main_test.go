@@ -2480,3 +2480,68 @@ func TestValidateRequiredJobAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateJobPolicies(t *testing.T) {
+	testCases := []struct {
+		name        string
+		policies    []config.JobPolicy
+		presubmits  []config.Presubmit
+		periodics   []config.Periodic
+		expectedErr string
+	}{
+		{
+			name:       "no policies, pass",
+			presubmits: []config.Presubmit{{JobBase: config.JobBase{Name: "pull-unit"}}},
+		},
+		{
+			name:       "jobs satisfy policies, pass",
+			policies:   []config.JobPolicy{{Name: "max-concurrency", Expression: `job.max_concurrency > 0`}},
+			presubmits: []config.Presubmit{{JobBase: config.JobBase{Name: "pull-unit", MaxConcurrency: 1}}},
+			periodics:  []config.Periodic{{JobBase: config.JobBase{Name: "ci-unit", MaxConcurrency: 2}}},
+		},
+		{
+			name: "jobs violate policies, fail",
+			policies: []config.JobPolicy{
+				{Name: "no-periodics", Expression: `job.type != "periodic"`, Message: "periodics are not allowed"},
+				{Name: "only-org", Expression: `job.type == "periodic" || org == "org"`},
+			},
+			presubmits:  []config.Presubmit{{JobBase: config.JobBase{Name: "pull-unit"}}},
+			periodics:   []config.Periodic{{JobBase: config.JobBase{Name: "ci-unit"}}},
+			expectedErr: "job 'ci-unit' violates policy no-periodics: periodics are not allowed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{
+				JobConfig: config.JobConfig{
+					PresubmitsStatic: map[string][]config.Presubmit{"org/repo": tc.presubmits},
+					Periodics:        tc.periodics,
+				},
+				ProwConfig: config.ProwConfig{JobPolicies: tc.policies},
+			}
+			err := validateJobPolicies(cfg)
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
+func TestAnalyzersCoverWarnings(t *testing.T) {
+	o := options{}
+	analyzers := sets.New[string]()
+	for _, a := range o.analyzers() {
+		if analyzers.Has(a.Name) {
+			t.Errorf("warning %s has more than one analyzer", a.Name)
+		}
+		analyzers.Insert(a.Name)
+	}
+	if diff := cmp.Diff(sets.List(sets.New[string](getAllWarnings()...)), sets.List(analyzers)); diff != "" {
+		t.Errorf("warnings and analyzers differ (-warnings +analyzers):\n%s", diff)
+	}
+}
@@ -20,7 +20,10 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -37,6 +40,7 @@ import (
 	k8sgcsreporter "sigs.k8s.io/prow/pkg/crier/reporters/gcs/kubernetes"
 	gerritreporter "sigs.k8s.io/prow/pkg/crier/reporters/gerrit"
 	githubreporter "sigs.k8s.io/prow/pkg/crier/reporters/github"
+	metricsreporter "sigs.k8s.io/prow/pkg/crier/reporters/metrics"
 	pubsubreporter "sigs.k8s.io/prow/pkg/crier/reporters/pubsub"
 	resultstorereporter "sigs.k8s.io/prow/pkg/crier/reporters/resultstore"
 	slackreporter "sigs.k8s.io/prow/pkg/crier/reporters/slack"
@@ -46,6 +50,7 @@ import (
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
 	slackclient "sigs.k8s.io/prow/pkg/slack"
+	"sigs.k8s.io/prow/pkg/tracing"
 )
 
 type options struct {
@@ -64,6 +69,14 @@ type options struct {
 	blobStorageWorkers    int
 	k8sBlobStorageWorkers int
 	resultStoreWorkers    int
+	metricsWorkers        int
+
+	metricsRemoteWriteURL string
+	metricsPushgatewayURL string
+
+	githubReportThrottle      githubreporter.ThrottleSettings
+	githubReportOrgThrottlers prowflagutil.Strings
+	parsedGitHubOrgThrottlers map[string]githubreporter.ThrottleSettings
 
 	slackTokenFile            string
 	additionalSlackTokenFiles slackclient.HostsFlag
@@ -81,7 +94,7 @@ type options struct {
 }
 
 func (o *options) validate() error {
-	if o.gerritWorkers+o.pubsubWorkers+o.githubWorkers+o.slackWorkers+o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers <= 0 {
+	if o.gerritWorkers+o.pubsubWorkers+o.githubWorkers+o.slackWorkers+o.blobStorageWorkers+o.k8sBlobStorageWorkers+o.resultStoreWorkers+o.metricsWorkers <= 0 {
 		return errors.New("crier need to have at least one report worker to start")
 	}
 
@@ -102,6 +115,9 @@ func (o *options) validate() error {
 		if err := o.github.Validate(o.dryrun); err != nil {
 			return err
 		}
+		if err := o.parseGitHubReportThrottle(); err != nil {
+			return err
+		}
 	}
 
 	if o.slackWorkers > 0 {
@@ -110,6 +126,10 @@ func (o *options) validate() error {
 		}
 	}
 
+	if o.metricsWorkers > 0 && (o.metricsRemoteWriteURL == "") == (o.metricsPushgatewayURL == "") {
+		return errors.New("exactly one of --metrics-remote-write-url or --metrics-pushgateway-url must be set")
+	}
+
 	for _, opt := range []interface{ Validate(bool) error }{&o.client, &o.githubEnablement, &o.config} {
 		if err := opt.Validate(o.dryrun); err != nil {
 			return err
@@ -119,11 +139,50 @@ func (o *options) validate() error {
 	return nil
 }
 
+// parseGitHubReportThrottle validates the throttle of the GitHub reporter
+// and parses its settings per org.
+func (o *options) parseGitHubReportThrottle() error {
+	if o.githubReportThrottle.HourlyReports < 0 {
+		return errors.New("--github-report-hourly-tokens must not be negative")
+	}
+	if o.githubReportThrottle.HourlyReports > 0 && (o.githubReportThrottle.Burst < 1 || o.githubReportThrottle.Burst > o.githubReportThrottle.HourlyReports) {
+		return errors.New("--github-report-allowed-burst must be positive and not greater than --github-report-hourly-tokens")
+	}
+
+	if len(o.githubReportOrgThrottlers.Strings()) == 0 {
+		return nil
+	}
+	o.parsedGitHubOrgThrottlers = map[string]githubreporter.ThrottleSettings{}
+	for _, orgThrottler := range o.githubReportOrgThrottlers.Strings() {
+		colonSplit := strings.Split(orgThrottler, ":")
+		if len(colonSplit) != 3 {
+			return fmt.Errorf("--github-report-throttle-org=%s is not in org:hourlyTokens:burst format", orgThrottler)
+		}
+		org := colonSplit[0]
+		hourlyTokens, err := strconv.Atoi(colonSplit[1])
+		if err != nil || hourlyTokens < 1 {
+			return fmt.Errorf("--github-report-throttle-org=%s: hourlyTokens must be a positive int", orgThrottler)
+		}
+		burst, err := strconv.Atoi(colonSplit[2])
+		if err != nil || burst < 1 || burst > hourlyTokens {
+			return fmt.Errorf("--github-report-throttle-org=%s: burst must be a positive int not greater than hourlyTokens", orgThrottler)
+		}
+		if _, alreadyExists := o.parsedGitHubOrgThrottlers[org]; alreadyExists {
+			return fmt.Errorf("got multiple --github-report-throttle-org for the %s org", org)
+		}
+		o.parsedGitHubOrgThrottlers[org] = githubreporter.ThrottleSettings{HourlyReports: hourlyTokens, Burst: burst}
+	}
+	return nil
+}
+
 func (o *options) parseArgs(fs *flag.FlagSet, args []string) error {
 	fs.StringVar(&o.cookiefilePath, "cookiefile", "", "Path to git http.cookiefile, leave empty for anonymous")
 	fs.IntVar(&o.gerritWorkers, "gerrit-workers", 0, "Number of gerrit report workers (0 means disabled)")
 	fs.IntVar(&o.pubsubWorkers, "pubsub-workers", 0, "Number of pubsub report workers (0 means disabled)")
 	fs.IntVar(&o.githubWorkers, "github-workers", 0, "Number of github report workers (0 means disabled)")
+	fs.IntVar(&o.githubReportThrottle.HourlyReports, "github-report-hourly-tokens", 0, "If set to a value larger than zero, limit how many jobs of every org the github reporter reports per hour. Reports beyond the limit are deferred, which leaves API quota of the org's installation to plugins.")
+	fs.IntVar(&o.githubReportThrottle.Burst, "github-report-allowed-burst", 0, "Number of jobs of an org the github reporter may report at once, if --github-report-hourly-tokens is set")
+	fs.Var(&o.githubReportOrgThrottlers, "github-report-throttle-org", "Report throttle settings for a specific org in org:hourlyTokens:burst format. Can be passed multiple times.")
 	fs.IntVar(&o.slackWorkers, "slack-workers", 0, "Number of Slack report workers (0 means disabled)")
 	fs.Var(&o.additionalSlackTokenFiles, "additional-slack-token-files", "Map of additional slack token files. example: --additional-slack-token-files=foo=/etc/foo-slack-tokens/token, repeat flag for each host")
 	fs.IntVar(&o.blobStorageWorkers, "blob-storage-workers", 0, "Number of blob storage report workers (0 means disabled)")
@@ -133,6 +192,9 @@ func (o *options) parseArgs(fs *flag.FlagSet, args []string) error {
 	fs.StringVar(&o.reportAgent, "report-agent", "", "Only report specified agent - empty means report to all agents (effective for github and Slack only)")
 	fs.IntVar(&o.resultStoreWorkers, "resultstore-workers", 0, "Number of ResultStore report workers (0 means disabled)")
 	fs.BoolVar(&o.resultstoreArtifactsDirOnly, "resultstore-artifacts-dir-only", false, "Report the artifacts/ dir instead of subtree files (testing)")
+	fs.IntVar(&o.metricsWorkers, "metrics-workers", 0, "Number of workers pushing job outcome metrics (0 means disabled)")
+	fs.StringVar(&o.metricsRemoteWriteURL, "metrics-remote-write-url", "", "Prometheus remote-write endpoint to send job outcome metrics to")
+	fs.StringVar(&o.metricsPushgatewayURL, "metrics-pushgateway-url", "", "Prometheus Pushgateway to push job outcome metrics to")
 
 	// TODO(krzyzacy): implement dryrun for gerrit/pubsub
 	fs.BoolVar(&o.dryrun, "dry-run", false, "Run in dry-run mode, not doing actual report (effective for github and Slack only)")
@@ -166,6 +228,9 @@ func main() {
 	o := parseOptions()
 
 	pprof.Instrument(o.instrumentationOptions)
+	if err := tracing.Init("crier", o.instrumentationOptions); err != nil {
+		logrus.WithError(err).Fatal("Error initializing tracing.")
+	}
 
 	configAgent, err := o.config.ConfigAgent()
 	if err != nil {
@@ -224,10 +289,7 @@ func main() {
 	}
 
 	if o.gerritWorkers > 0 {
-		orgRepoConfigGetter := func() *config.GerritOrgRepoConfigs {
-			return cfg().Gerrit.OrgReposConfig
-		}
-		gerritReporter, err := gerritreporter.NewReporter(orgRepoConfigGetter, o.cookiefilePath, mgr.GetClient(), o.gerrit.MaxQPS, o.gerrit.MaxBurst)
+		gerritReporter, err := gerritreporter.NewReporter(cfg, o.cookiefilePath, mgr.GetClient(), o.gerrit.MaxQPS, o.gerrit.MaxBurst)
 		if err != nil {
 			logrus.WithError(err).Fatal("Error starting gerrit reporter")
 		}
@@ -259,6 +321,9 @@ func main() {
 
 		hasReporter = true
 		githubReporter := githubreporter.NewReporter(githubClient, cfg, prowapi.ProwJobAgent(o.reportAgent), mgr.GetCache())
+		if o.githubReportThrottle.HourlyReports > 0 || len(o.parsedGitHubOrgThrottlers) > 0 {
+			githubReporter.Throttle(o.githubReportThrottle, o.parsedGitHubOrgThrottlers)
+		}
 		if err := crier.New(mgr, githubReporter, o.githubWorkers, o.githubEnablement.EnablementChecker()); err != nil {
 			logrus.WithError(err).Fatal("failed to construct github reporter controller")
 		}
@@ -305,6 +370,19 @@ func main() {
 		}
 	}
 
+	if o.metricsWorkers > 0 {
+		hasReporter = true
+		var metricsReporter crier.ReportClient
+		if o.metricsRemoteWriteURL != "" {
+			metricsReporter = metricsreporter.NewRemoteWrite(o.metricsRemoteWriteURL, o.dryrun)
+		} else {
+			metricsReporter = metricsreporter.NewPushgateway(o.metricsPushgatewayURL, o.dryrun)
+		}
+		if err := crier.New(mgr, metricsReporter, o.metricsWorkers, o.githubEnablement.EnablementChecker()); err != nil {
+			logrus.WithError(err).Fatal("failed to construct metrics reporter controller")
+		}
+	}
+
 	if !hasReporter {
 		logrus.Fatalf("should have at least one controller to start crier.")
 	}
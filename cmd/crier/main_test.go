@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
+	githubreporter "sigs.k8s.io/prow/pkg/crier/reporters/github"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 )
@@ -205,6 +206,32 @@ func TestOptions(t *testing.T) {
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			},
 		},
+		{
+			name: "metrics workers, sets workers and url",
+			args: []string{"--metrics-workers=2", "--metrics-remote-write-url=https://prometheus/api/v1/write", "--config-path=foo"},
+			expected: &options{
+				metricsWorkers:        2,
+				metricsRemoteWriteURL: "https://prometheus/api/v1/write",
+				config: configflagutil.ConfigOptions{
+					ConfigPathFlagName:                    "config-path",
+					JobConfigPathFlagName:                 "job-config-path",
+					ConfigPath:                            "foo",
+					SupplementalProwConfigsFileNameSuffix: "_prowconfig.yaml",
+					InRepoConfigCacheSize:                 200,
+				},
+				github:                 defaultGitHubOptions,
+				k8sReportFraction:      1.0,
+				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
+			},
+		},
+		{
+			name: "metrics workers without url rejects",
+			args: []string{"--metrics-workers=2", "--config-path=foo"},
+		},
+		{
+			name: "metrics workers with both urls rejects",
+			args: []string{"--metrics-workers=2", "--metrics-remote-write-url=https://prometheus/api/v1/write", "--metrics-pushgateway-url=pushgateway:9091", "--config-path=foo"},
+		},
 	}
 
 	for _, tc := range cases {
@@ -267,3 +294,70 @@ func TestGitHubOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestGitHubReportThrottleOptions(t *testing.T) {
+	cases := []struct {
+		name             string
+		args             []string
+		expectedDefaults githubreporter.ThrottleSettings
+		expectedOrgs     map[string]githubreporter.ThrottleSettings
+		expectErr        bool
+	}{
+		{
+			name: "not throttled by default",
+		},
+		{
+			name:             "throttle of all orgs",
+			args:             []string{"--github-report-hourly-tokens=1200", "--github-report-allowed-burst=100"},
+			expectedDefaults: githubreporter.ThrottleSettings{HourlyReports: 1200, Burst: 100},
+		},
+		{
+			name: "throttle per org",
+			args: []string{"--github-report-throttle-org=kubernetes:600:50", "--github-report-throttle-org=kubernetes-sigs:300:10"},
+			expectedOrgs: map[string]githubreporter.ThrottleSettings{
+				"kubernetes":      {HourlyReports: 600, Burst: 50},
+				"kubernetes-sigs": {HourlyReports: 300, Burst: 10},
+			},
+		},
+		{
+			name:      "burst is required",
+			args:      []string{"--github-report-hourly-tokens=1200"},
+			expectErr: true,
+		},
+		{
+			name:      "burst greater than hourly tokens",
+			args:      []string{"--github-report-throttle-org=kubernetes:10:50"},
+			expectErr: true,
+		},
+		{
+			name:      "malformed org throttle",
+			args:      []string{"--github-report-throttle-org=kubernetes:10"},
+			expectErr: true,
+		},
+		{
+			name:      "org throttled twice",
+			args:      []string{"--github-report-throttle-org=kubernetes:10:5", "--github-report-throttle-org=kubernetes:20:5"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			flags := flag.NewFlagSet(tc.name, flag.ContinueOnError)
+			actual := options{}
+			err := actual.parseArgs(flags, append([]string{"--github-workers=5", "--github-token-path=tkpath", "--config-path=foo"}, tc.args...))
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			if diff := cmp.Diff(tc.expectedDefaults, actual.githubReportThrottle); diff != "" {
+				t.Errorf("throttle mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedOrgs, actual.parsedGitHubOrgThrottlers); diff != "" {
+				t.Errorf("org throttles mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -27,12 +27,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/audit"
 	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/githuboauth"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
 
-func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *githuboauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
+func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *githuboauth.Agent, oidc *oidcAgent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, auditor *audit.Auditor, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.TODO()
 		name := r.URL.Query().Get("prowjob")
@@ -59,7 +60,7 @@ func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *
 			}
 			// Using same permission validation as rerun, could be future work to add validation
 			// unique to Abort
-			allowed, user, err, code := isAllowedToRerun(r, cfg, goa, ghc, *pj, cli, pluginAgent, l)
+			allowed, user, err, code := isAllowedToRerun(r, cfg, goa, oidc, ghc, *pj, cli, pluginAgent, l)
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Could not verify if allowed to abort: %v.", err), code)
 				l.WithError(err).Debug("Could not verify if allowed to abort.")
@@ -80,6 +81,7 @@ func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *
 			}
 			pj.Status.State = prowapi.AbortedState
 			pj.Status.Description = abortDescription
+			pj.Status.Cancellation = &prowapi.Cancellation{Reason: prowapi.CancelledByUser, Requester: user}
 			jsonPJ, err := json.Marshal(pj)
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Error marshal source job: %v.", err), http.StatusInternalServerError)
@@ -87,6 +89,7 @@ func handleAbort(prowJobClient prowv1.ProwJobInterface, cfg authCfgGetter, goa *
 				return
 			}
 			pj, err := prowJobClient.Patch(ctx, pj.Name, ktypes.MergePatchType, jsonPJ, metav1.PatchOptions{})
+			auditor.Emit(r.Context(), audit.Event{Action: audit.ActionAbort, Actor: user, Target: name, Error: audit.ErrorString(err)})
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Could not patch aborted job: %v.", err), http.StatusInternalServerError)
 				l.WithError(err).Errorf("Could not patch aborted job.")
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/audit"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// handleAuditEvents serves the audit events of a day (UTC) as JSON, ordered
+// by time. The day is given by the date query parameter (YYYY-MM-DD) and
+// defaults to today. The component, action, actor and target query parameters
+// filter the events.
+func handleAuditEvents(opener io.Opener, path string, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		if r.Method != http.MethodGet {
+			http.Error(w, fmt.Sprintf("bad verb %v", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		day := time.Now().UTC()
+		if date := query.Get("date"); date != "" {
+			var err error
+			if day, err = time.Parse("2006-01-02", date); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid date %q, expected YYYY-MM-DD.", date), http.StatusBadRequest)
+				return
+			}
+		}
+		filter := audit.Filter{
+			Component: query.Get("component"),
+			Action:    query.Get("action"),
+			Actor:     query.Get("actor"),
+			Target:    query.Get("target"),
+		}
+		events, err := audit.Query(r.Context(), opener, path, day, filter)
+		if err != nil {
+			log.WithError(err).Error("Failed to query audit events.")
+			http.Error(w, "Failed to query audit events.", http.StatusInternalServerError)
+			return
+		}
+		b, err := json.Marshal(events)
+		if err != nil {
+			log.WithError(err).Error("Failed to marshal audit events.")
+			http.Error(w, "Failed to marshal audit events.", http.StatusInternalServerError)
+			return
+		}
+		writeJSONResponse(w, r, b)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/audit"
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+)
+
+func TestHandleAuditEvents(t *testing.T) {
+	opener := &fakeopener.FakeOpener{}
+	sink := audit.NewStorageSink(opener, "gs://bucket/audit")
+	day := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	events := []audit.Event{
+		{Time: day.Add(time.Hour), Component: "deck", Action: audit.ActionRerun, Actor: "alice", Target: "job-1"},
+		{Time: day.Add(2 * time.Hour), Component: "deck", Action: audit.ActionAbort, Actor: "bob", Target: "job-2"},
+	}
+	for _, event := range events {
+		if err := sink.Write(context.Background(), event); err != nil {
+			t.Fatalf("failed to write event: %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		method       string
+		query        string
+		expectedCode int
+		expected     []audit.Event
+	}{
+		{
+			name:         "events of the day",
+			method:       http.MethodGet,
+			query:        "date=2026-10-15",
+			expectedCode: http.StatusOK,
+			expected:     events,
+		},
+		{
+			name:         "filtered by action",
+			method:       http.MethodGet,
+			query:        "date=2026-10-15&action=abort",
+			expectedCode: http.StatusOK,
+			expected:     events[1:],
+		},
+		{
+			name:         "invalid date",
+			method:       http.MethodGet,
+			query:        "date=yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "post",
+			method:       http.MethodPost,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/audit?"+tc.query, nil)
+			rr := httptest.NewRecorder()
+			handleAuditEvents(opener, "gs://bucket/audit", logrus.WithField("handler", "/audit")).ServeHTTP(rr, req)
+			if rr.Code != tc.expectedCode {
+				t.Fatalf("expected code %d, got %d: %s", tc.expectedCode, rr.Code, rr.Body.String())
+			}
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+			var actual []audit.Event
+			if err := json.Unmarshal(rr.Body.Bytes(), &actual); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected events (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/flaketracker"
+)
+
+type flakeLister interface {
+	Flakes(repo, job string) ([]flaketracker.Flake, error)
+}
+
+type flakesTemplate struct {
+	Repo   string
+	Job    string
+	Flakes []flakeRow
+}
+
+type flakeRow struct {
+	flaketracker.Flake
+	RatePercent string
+	Signature   string
+	LastFlake   string
+}
+
+// handleFlakes renders the flaky tests known to the flake-tracker.
+// The url may filter them by repo and job:
+//
+// /flakes?repo=<org/repo>&job=<job name>
+func handleFlakes(o options, cfg config.Getter, flakes flakeLister, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		tmpl := flakesTemplate{Repo: r.URL.Query().Get("repo"), Job: r.URL.Query().Get("job")}
+		all, err := flakes.Flakes(tmpl.Repo, tmpl.Job)
+		if err != nil {
+			msg := fmt.Sprintf("failed to get flakes: %v", err)
+			log.WithField("url", r.URL.String()).Info(msg)
+			http.Error(w, msg, http.StatusInternalServerError)
+			return
+		}
+		hiddenRepos := sets.New[string](cfg().Deck.HiddenRepos...)
+		for _, flake := range all {
+			org, _, _ := strings.Cut(flake.Repo, "/")
+			hidden := hiddenRepos.Has(flake.Repo) || hiddenRepos.Has(org)
+			if (hidden && !o.showHidden && !o.hiddenOnly) || (!hidden && o.hiddenOnly) {
+				continue
+			}
+			row := flakeRow{
+				Flake:       flake,
+				RatePercent: fmt.Sprintf("%.1f%%", 100*flake.Rate),
+				LastFlake:   flake.LastFlake.Format("2006-01-02 15:04 MST"),
+			}
+			if len(flake.Signatures) > 0 {
+				row.Signature = flake.Signatures[0].Message
+			}
+			tmpl.Flakes = append(tmpl.Flakes, row)
+		}
+		handleSimpleTemplate(o, cfg, "flakes.html", tmpl)(w, r)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/flaketracker"
+)
+
+type fakeFlakeLister struct {
+	flakes []flaketracker.Flake
+	err    error
+}
+
+func (f *fakeFlakeLister) Flakes(repo, job string) ([]flaketracker.Flake, error) {
+	return f.flakes, f.err
+}
+
+func TestHandleFlakes(t *testing.T) {
+	flakes := []flaketracker.Flake{
+		{Job: "unit", Test: "TestVisible", Repo: "org/repo", Commits: 4, Flakes: 1, Rate: 0.25, Signatures: []flaketracker.Signature{{Message: "timeout after <n>s", Count: 1}}},
+		{Job: "secret", Test: "TestHidden", Repo: "hidden/repo", Commits: 2, Flakes: 2, Rate: 1},
+	}
+	testCases := []struct {
+		name         string
+		lister       *fakeFlakeLister
+		showHidden   bool
+		expectedCode int
+		expected     []string
+		unexpected   []string
+	}{
+		{
+			name:         "hidden repos are not shown",
+			lister:       &fakeFlakeLister{flakes: flakes},
+			expectedCode: http.StatusOK,
+			expected:     []string{"TestVisible", "25.0%", "timeout after &lt;n&gt;s"},
+			unexpected:   []string{"TestHidden"},
+		},
+		{
+			name:         "hidden repos are shown with --show-hidden",
+			lister:       &fakeFlakeLister{flakes: flakes},
+			showHidden:   true,
+			expectedCode: http.StatusOK,
+			expected:     []string{"TestVisible", "TestHidden", "100.0%"},
+		},
+		{
+			name:         "flake-tracker errors are surfaced",
+			lister:       &fakeFlakeLister{err: errors.New("unavailable")},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+	cfg := func() *config.Config {
+		return &config.Config{ProwConfig: config.ProwConfig{Deck: config.Deck{HiddenRepos: []string{"hidden"}}}}
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			o := options{templateFilesLocation: "template", showHidden: tc.showHidden, flakeTrackerURL: "http://flake-tracker"}
+			rr := httptest.NewRecorder()
+			handleFlakes(o, cfg, tc.lister, logrus.WithField("handler", "/flakes")).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/flakes", nil))
+			if rr.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedCode, rr.Code, rr.Body.String())
+			}
+			body := rr.Body.String()
+			for _, s := range tc.expected {
+				if !strings.Contains(body, s) {
+					t.Errorf("expected body to contain %q", s)
+				}
+			}
+			for _, s := range tc.unexpected {
+				if strings.Contains(body, s) {
+					t.Errorf("expected body not to contain %q", s)
+				}
+			}
+		})
+	}
+}
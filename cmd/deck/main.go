@@ -58,12 +58,14 @@ import (
 	"sigs.k8s.io/yaml"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/audit"
 	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/deck/jobs"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	pluginsflagutil "sigs.k8s.io/prow/pkg/flagutil/plugins"
+	"sigs.k8s.io/prow/pkg/flaketracker"
 	"sigs.k8s.io/prow/pkg/git/v2"
 	prowgithub "sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/githuboauth"
@@ -84,8 +86,11 @@ import (
 	// Import standard spyglass viewers
 
 	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/benchmark"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/buildlog"
+	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/clusterdump"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/coverage"
+	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/gotest"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/html"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/junit"
 	_ "sigs.k8s.io/prow/pkg/spyglass/lenses/links"
@@ -119,10 +124,13 @@ type options struct {
 	kubernetes            prowflagutil.KubernetesOptions
 	github                prowflagutil.GitHubOptions
 	tideURL               string
+	flakeTrackerURL       string
 	hookURL               string
 	oauthURL              string
 	githubOAuthConfigFile string
 	cookieSecretFile      string
+	savedSearchesPath     string
+	notificationsPath     string
 	redirectHTTPTo        string
 	hiddenOnly            bool
 	pregeneratedData      string
@@ -138,10 +146,14 @@ type options struct {
 	controllerManager     prowflagutil.ControllerManagerOptions
 	dryRun                bool
 	tenantIDs             prowflagutil.Strings
+	oidc                  oidcOptions
+	audit                 prowflagutil.AuditOptions
+	serveAuditEvents      bool
+	adminAccess           prowflagutil.AdminAccessOptions
 }
 
 func (o *options) Validate() error {
-	for _, group := range []pkgFlagutil.OptionGroup{&o.kubernetes, &o.github, &o.config, &o.pluginsConfig, &o.controllerManager} {
+	for _, group := range []pkgFlagutil.OptionGroup{&o.kubernetes, &o.github, &o.config, &o.pluginsConfig, &o.controllerManager, &o.audit, &o.adminAccess} {
 		if err := group.Validate(o.dryRun); err != nil {
 			return err
 		}
@@ -155,6 +167,19 @@ func (o *options) Validate() error {
 			return errors.New("an OAuth URL was provided but required flag --cookie-secret was unset")
 		}
 	}
+	if o.savedSearchesPath != "" && o.oauthURL == "" {
+		return errors.New("--saved-searches-path requires --oauth-url to identify users")
+	}
+	if o.notificationsPath != "" && o.oauthURL == "" {
+		return errors.New("--notifications-path requires --oauth-url to identify users")
+	}
+
+	if err := o.oidc.validate(); err != nil {
+		return err
+	}
+	if o.serveAuditEvents && o.audit.StoragePath == "" {
+		return errors.New("--serve-audit-events requires --audit-storage-path")
+	}
 
 	if (o.hiddenOnly && o.showHidden) || (o.tenantIDs.Strings() != nil && (o.hiddenOnly || o.showHidden)) {
 		return errors.New("'--hidden-only', '--tenant-id', and '--show-hidden' are mutually exclusive, 'hidden-only' shows only hidden job, '--tenant-id' shows all jobs with matching ID and 'show-hidden' shows both hidden and non-hidden jobs")
@@ -165,10 +190,19 @@ func (o *options) Validate() error {
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	var o options
 	fs.StringVar(&o.tideURL, "tide-url", "", "Path to tide. If empty, do not serve tide data.")
+	fs.StringVar(&o.flakeTrackerURL, "flake-tracker-url", "", "URL of the flake-tracker API. If empty, do not serve the flake dashboard.")
 	fs.StringVar(&o.hookURL, "hook-url", "", "Path to hook plugin help endpoint.")
 	fs.StringVar(&o.oauthURL, "oauth-url", "", "Path to deck user dashboard endpoint.")
 	fs.StringVar(&o.githubOAuthConfigFile, "github-oauth-config-file", "/etc/github/secret", "Path to the file containing the GitHub App Client secret.")
 	fs.StringVar(&o.cookieSecretFile, "cookie-secret", "", "Path to the file containing the cookie secret key.")
+	fs.StringVar(&o.savedSearchesPath, "saved-searches-path", "", "Local, gs:// or s3:// path under which the saved searches of users are stored. If empty, searches cannot be saved. Requires --oauth-url.")
+	fs.StringVar(&o.notificationsPath, "notifications-path", "", "Local, gs:// or s3:// path under which the notification preferences of users are stored. If empty, the feed of the failures users follow is not served. Requires --oauth-url.")
+	// use when behind a proxy that authenticates users with an OIDC provider
+	fs.StringVar(&o.oidc.issuerURL, "oidc-issuer-url", "", "URL of the OIDC issuer whose ID tokens identify users for rerun and abort authorization by their oidc_groups. If empty, OIDC is not used.")
+	fs.StringVar(&o.oidc.clientID, "oidc-client-id", "", "OIDC client ID the ID tokens must be issued for.")
+	fs.StringVar(&o.oidc.groupsClaim, "oidc-groups-claim", defaultOIDCGroupsClaim, "Claim of the ID token holding the groups of the user.")
+	fs.StringVar(&o.oidc.usernameClaim, "oidc-username-claim", defaultOIDCUsernameClaim, "Claim of the ID token identifying the user.")
+	fs.StringVar(&o.oidc.tokenHeader, "oidc-token-header", defaultOIDCTokenHeader, "Request header the authenticating proxy passes the ID token in. A 'Bearer ' prefix is stripped from the Authorization header.")
 	// use when behind a load balancer
 	fs.StringVar(&o.redirectHTTPTo, "redirect-http-to", "", "Host to redirect http->https to based on x-forwarded-proto == http.")
 	// use when behind an oauth proxy
@@ -184,6 +218,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.BoolVar(&o.allowInsecure, "allow-insecure", false, "Allows insecure requests for CSRF and GitHub oauth.")
 	fs.BoolVar(&o.dryRun, "dry-run", false, "Whether or not to make mutating API calls to GitHub.")
 	fs.Var(&o.tenantIDs, "tenant-id", "The tenantID(s) used by the ProwJobs that should be displayed by this instance of Deck. This flag can be repeated.")
+	fs.BoolVar(&o.serveAuditEvents, "serve-audit-events", false, "Serve the audit events written below --audit-storage-path at /audit. **WARNING:** Only use this with non-public deck instances, the events name the users that acted.")
 	o.config.AddFlags(fs)
 	o.instrumentation.AddFlags(fs)
 	o.controllerManager.TimeoutListingProwJobsDefault = 30 * time.Second
@@ -194,6 +229,8 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	o.github.AllowDirectAccess = true
 	o.storage.AddFlags(fs)
 	o.pluginsConfig.AddFlags(fs)
+	o.audit.AddFlags(fs)
+	o.adminAccess.AddFlags(fs)
 	fs.Parse(args)
 
 	return o
@@ -223,6 +260,7 @@ var simplifier = simplifypath.NewSimplifier(l("", // shadow element mimicing the
 	l("config"),
 	l("data.js"),
 	l("favicon.ico"),
+	l("flakes"),
 	l("github-login",
 		l("redirect")),
 	l("github-link"),
@@ -313,6 +351,7 @@ func main() {
 
 	// Set up handlers for template pages.
 	mux.Handle("/pr", gziphandler.GzipHandler(handleSimpleTemplate(o, cfg, "pr.html", nil)))
+	mux.Handle("/pr/compact", gziphandler.GzipHandler(handleSimpleTemplate(o, cfg, "pr-compact.html", nil)))
 	mux.Handle("/command-help", gziphandler.GzipHandler(handleSimpleTemplate(o, cfg, "command-help.html", nil)))
 	mux.Handle("/plugin-help", http.RedirectHandler("/command-help", http.StatusMovedPermanently))
 	mux.Handle("/tide", gziphandler.GzipHandler(handleSimpleTemplate(o, cfg, "tide.html", nil)))
@@ -424,11 +463,13 @@ func main() {
 			return
 		}
 		indexHandler := handleSimpleTemplate(o, cfg, "index.html", struct {
-			SpyglassEnabled bool
-			ReRunCreatesJob bool
+			SpyglassEnabled      bool
+			ReRunCreatesJob      bool
+			SavedSearchesEnabled bool
 		}{
-			SpyglassEnabled: o.spyglass,
-			ReRunCreatesJob: o.rerunCreatesJob})
+			SpyglassEnabled:      o.spyglass,
+			ReRunCreatesJob:      o.rerunCreatesJob,
+			SavedSearchesEnabled: o.savedSearchesPath != ""})
 		indexHandler(w, r)
 	})
 
@@ -449,7 +490,7 @@ func main() {
 	if runLocal {
 		mux = localOnlyMain(cfg, o, mux)
 	} else {
-		mux = prodOnlyMain(cfg, pluginAgent, authCfgGetter, githubClient, o, mux)
+		mux = prodOnlyMain(cfg, pluginAgent, authCfgGetter, githubClient, ja, o, mux)
 	}
 
 	// signal to the world that we're ready
@@ -492,14 +533,16 @@ func main() {
 		return
 	}
 
+	handler := traceHandler(mux)
 	if csrfToken != nil {
 		CSRF := csrf.Protect(csrfToken, csrf.Path("/"), csrf.Secure(!o.allowInsecure))
-		logrus.WithError(http.ListenAndServe(":8080", CSRF(traceHandler(mux)))).Fatal("ListenAndServe returned.")
-		return
+		handler = CSRF(handler)
 	}
 	// setup done, actually start the server
-	server := &http.Server{Addr: ":8080", Handler: traceHandler(mux)}
-	interrupts.ListenAndServe(server, 5*time.Second)
+	server := &http.Server{Addr: ":8080", Handler: handler}
+	if err := o.adminAccess.ListenAndServe(server, 5*time.Second); err != nil {
+		logrus.WithError(err).Fatal("Error starting server.")
+	}
 }
 
 // localOnlyMain contains logic used only when running locally, and is mutually exclusive with
@@ -545,7 +588,7 @@ func (fjc *fakePjListingClientWrapper) List(ctx context.Context, pjl *prowapi.Pr
 }
 
 // prodOnlyMain contains logic only used when running deployed, not locally
-func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGetter authCfgGetter, githubClient deckGitHubClient, o options, mux *http.ServeMux) *http.ServeMux {
+func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGetter authCfgGetter, githubClient deckGitHubClient, ja *jobs.JobAgent, o options, mux *http.ServeMux) *http.ServeMux {
 	prowJobClient, err := o.kubernetes.ProwJobClient(cfg().ProwJobNamespace, false)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error getting ProwJob client for infrastructure cluster.")
@@ -582,6 +625,10 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 		}()
 	}
 
+	if o.flakeTrackerURL != "" {
+		mux.Handle("/flakes", gziphandler.GzipHandler(handleFlakes(o, cfg, flaketracker.NewClient(o.flakeTrackerURL), logrus.WithField("handler", "/flakes"))))
+	}
+
 	secure := !o.allowInsecure
 
 	// Handles link to github
@@ -644,10 +691,68 @@ func prodOnlyMain(cfg config.Getter, pluginAgent *plugins.ConfigAgent, authCfgGe
 		mux.Handle("/github-login", goa.HandleLogin(oauthClient, secure))
 		// Handles redirect from GitHub OAuth server.
 		mux.Handle("/github-login/redirect", goa.HandleRedirect(oauthClient, githuboauth.NewAuthenticatedUserIdentifier(&o.github), secure))
+
+		if o.savedSearchesPath != "" {
+			opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile)
+			if err != nil {
+				logrus.WithError(err).Fatal("Error creating opener for saved searches")
+			}
+			ghc := githuboauth.NewAuthenticatedUserIdentifier(&o.github)
+			searches := &savedSearches{
+				opener: opener,
+				path:   o.savedSearchesPath,
+				login:  func(r *http.Request) (string, error) { return goa.GetLogin(r, ghc) },
+				log:    logrus.WithField("handler", "/saved-searches"),
+			}
+			mux.Handle("/saved-searches", searches.handle())
+			mux.Handle("/s/", searches.handleShared())
+		}
+
+		if o.notificationsPath != "" {
+			opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile)
+			if err != nil {
+				logrus.WithError(err).Fatal("Error creating opener for notification preferences")
+			}
+			ghc := githuboauth.NewAuthenticatedUserIdentifier(&o.github)
+			notifications := &notifications{
+				opener:   opener,
+				path:     o.notificationsPath,
+				login:    func(r *http.Request) (string, error) { return goa.GetLogin(r, ghc) },
+				prowJobs: ja.ProwJobs,
+				log:      logrus.WithField("handler", "/notifications"),
+			}
+			mux.Handle("/notifications", gziphandler.GzipHandler(handleSimpleTemplate(o, cfg, "notifications.html", nil)))
+			mux.Handle("/notifications/preferences", notifications.handlePreferences())
+			mux.Handle("/notifications/feed", gziphandler.GzipHandler(notifications.handleFeed()))
+		}
+	}
+
+	var oidc *oidcAgent
+	if o.oidc.enabled() {
+		oidc = newOIDCAgent(o.oidc)
 	}
 
-	mux.Handle("/rerun", gziphandler.GzipHandler(handleRerun(cfg, prowJobClient, o.rerunCreatesJob, authCfgGetter, goa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/rerun"))))
-	mux.Handle("/abort", gziphandler.GzipHandler(handleAbort(prowJobClient, authCfgGetter, goa, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, logrus.WithField("handler", "/abort"))))
+	adminPolicy, err := o.adminAccess.Policy()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error creating admin access policy")
+	}
+
+	var auditor *audit.Auditor
+	if o.audit.Enabled() {
+		opener, err := io.NewOpener(context.Background(), o.storage.GCSCredentialsFile, o.storage.S3CredentialsFile)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating opener for audit events")
+		}
+		if auditor, err = o.audit.Auditor(context.Background(), "deck", opener); err != nil {
+			logrus.WithError(err).Fatal("Error creating auditor")
+		}
+		if o.serveAuditEvents {
+			mux.Handle("/audit", adminPolicy.Protect(gziphandler.GzipHandler(handleAuditEvents(opener, o.audit.StoragePath, logrus.WithField("handler", "/audit")))))
+		}
+	}
+
+	mux.Handle("/rerun", adminPolicy.Protect(gziphandler.GzipHandler(handleRerun(cfg, prowJobClient, o.rerunCreatesJob, authCfgGetter, goa, oidc, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, auditor, logrus.WithField("handler", "/rerun")))))
+	mux.Handle("/abort", adminPolicy.Protect(gziphandler.GzipHandler(handleAbort(prowJobClient, authCfgGetter, goa, oidc, githuboauth.NewAuthenticatedUserIdentifier(&o.github), githubClient, pluginAgent, auditor, logrus.WithField("handler", "/abort")))))
 
 	// optionally inject http->https redirect handler when behind loadbalancer
 	if o.redirectHTTPTo != "" {
@@ -687,6 +792,7 @@ func initSpyglass(cfg config.Getter, o options, mux *http.ServeMux, ja *jobs.Job
 	mux.Handle("/spyglass/static/", http.StripPrefix("/spyglass/static", staticHandlerFromDir(o.spyglassFilesLocation)))
 	mux.Handle("/spyglass/lens/", gziphandler.GzipHandler(http.StripPrefix("/spyglass/lens/", handleArtifactView(o, sg, cfg))))
 	mux.Handle("/view/", gziphandler.GzipHandler(handleRequestJobViews(sg, cfg, o, logrus.WithField("handler", "/view"))))
+	mux.Handle("/spyglass/search/", gziphandler.GzipHandler(handleArtifactSearch(sg, cfg, logrus.WithField("handler", "/spyglass/search"))))
 	mux.Handle("/job-history/", gziphandler.GzipHandler(handleJobHistory(o, cfg, opener, logrus.WithField("handler", "/job-history"))))
 	mux.Handle("/pr-history/", gziphandler.GzipHandler(handlePRHistory(o, cfg, opener, gitHubClient, gitClient, logrus.WithField("handler", "/pr-history"))))
 	if err := initLocalLensHandler(cfg, o, sg); err != nil {
@@ -875,6 +981,8 @@ func handleBadge(ja *jobs.JobAgent) http.HandlerFunc {
 // Example:
 // - /job-history/kubernetes-jenkins/logs/ci-kubernetes-e2e-prow-canary
 // - /job-history/gs/kubernetes-jenkins/logs/ci-kubernetes-e2e-prow-canary
+//
+// The builds are served as JSON instead of a page with ?format=json.
 func handleJobHistory(o options, cfg config.Getter, opener io.Opener, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		setHeadersNoCaching(w)
@@ -893,6 +1001,13 @@ func handleJobHistory(o options, cfg config.Getter, opener io.Opener, log *logru
 			tmpl.Builds[idx].Result = strings.ToUpper(build.Result)
 
 		}
+		if r.URL.Query().Get("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(tmpl.Builds); err != nil {
+				log.WithError(err).Error("Failed to write job history.")
+			}
+			return
+		}
 		handleSimpleTemplate(o, cfg, "job-history.html", tmpl)(w, r)
 	}
 }
@@ -955,6 +1070,43 @@ func handleRequestJobViews(sg *spyglass.Spyglass, cfg config.Getter, o options,
 	}
 }
 
+// handleArtifactSearch searches the text artifacts of a build for the lines
+// containing a string, so that users can find an error without downloading
+// every log:
+//
+// /spyglass/search/<source>?q=<text>
+func handleArtifactSearch(sg *spyglass.Spyglass, cfg config.Getter, log *logrus.Entry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		query := r.URL.Query().Get("q")
+		if query == "" {
+			http.Error(w, "Missing query parameter q.", http.StatusBadRequest)
+			return
+		}
+		src, err := sg.ResolveSymlink(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/spyglass/search/"), "/"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to resolve source: %v", err), http.StatusNotFound)
+			return
+		}
+		if err := validateStoragePath(cfg, src); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to process request: %v", err), httpStatusForError(err))
+			return
+		}
+		result, err := sg.SearchArtifacts(r.Context(), src, query, spyglass.DefaultSearchLimits)
+		if err != nil {
+			if shouldLogHTTPErrors(err) {
+				log.WithError(err).WithField("source", src).Warn("Failed to search artifacts.")
+			}
+			http.Error(w, fmt.Sprintf("Failed to search artifacts: %v", err), httpStatusForError(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			log.WithError(err).Error("Failed to write search result.")
+		}
+	}
+}
+
 // renderSpyglass returns a pre-rendered Spyglass page from the given source string
 func renderSpyglass(ctx context.Context, sg *spyglass.Spyglass, cfg config.Getter, src string, o options, csrfToken string, log *logrus.Entry) (string, error) {
 	renderStart := time.Now()
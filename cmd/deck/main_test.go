@@ -136,6 +136,28 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "ok with oidc",
+			input: options{
+				config: configflagutil.ConfigOptions{ConfigPath: "test"},
+				controllerManager: flagutil.ControllerManagerOptions{
+					TimeoutListingProwJobsDefault: 30 * time.Second,
+				},
+				oidc: oidcOptions{issuerURL: "https://accounts.example.com", clientID: "deck"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "missing client ID with oidc",
+			input: options{
+				config: configflagutil.ConfigOptions{ConfigPath: "test"},
+				controllerManager: flagutil.ControllerManagerOptions{
+					TimeoutListingProwJobsDefault: 30 * time.Second,
+				},
+				oidc: oidcOptions{issuerURL: "https://accounts.example.com"},
+			},
+			expectedErr: true,
+		},
 		{
 			name: "hidden only and show hidden are mutually exclusive",
 			input: options{
@@ -712,6 +734,11 @@ func Test_gatherOptions(t *testing.T) {
 				spyglassFilesLocation: "/lenses",
 				github:                ghoptions,
 				instrumentation:       flagutil.DefaultInstrumentationOptions(),
+				oidc: oidcOptions{
+					groupsClaim:   defaultOIDCGroupsClaim,
+					usernameClaim: defaultOIDCUsernameClaim,
+					tokenHeader:   defaultOIDCTokenHeader,
+				},
 			}
 			if tc.expected != nil {
 				tc.expected(expected)
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+const (
+	// defaultFailureFeedPeriod is how far back the failure feed goes unless
+	// asked otherwise.
+	defaultFailureFeedPeriod = 7 * 24 * time.Hour
+	maxFailureFeedLength     = 200
+	maxFollowed              = 100
+)
+
+var followedRepoRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)?$`)
+
+// notificationPreferences are what a user follows the failures of.
+type notificationPreferences struct {
+	// PullRequests follows the jobs testing the pull requests the user
+	// authored.
+	PullRequests bool `json:"pull_requests"`
+	// Jobs are the names of followed jobs, e.g. the ones the user owns.
+	Jobs []string `json:"jobs"`
+	// Repos are followed org/repos or whole orgs.
+	Repos []string `json:"repos"`
+}
+
+// defaultNotificationPreferences are the preferences of users who have not
+// set any: the failures of their own pull requests.
+var defaultNotificationPreferences = notificationPreferences{PullRequests: true}
+
+func validateNotificationPreferences(prefs notificationPreferences) error {
+	if len(prefs.Jobs) > maxFollowed || len(prefs.Repos) > maxFollowed {
+		return fmt.Errorf("at most %d jobs and %d repos can be followed", maxFollowed, maxFollowed)
+	}
+	for _, job := range prefs.Jobs {
+		if strings.TrimSpace(job) == "" {
+			return fmt.Errorf("job names must not be empty")
+		}
+	}
+	for _, repo := range prefs.Repos {
+		if !followedRepoRe.MatchString(repo) {
+			return fmt.Errorf("repo %q must be of the form org/repo or org", repo)
+		}
+	}
+	return nil
+}
+
+// failureNotification is a failed job in the feed of a user.
+type failureNotification struct {
+	Job      string               `json:"job"`
+	BuildID  string               `json:"build_id"`
+	State    prowapi.ProwJobState `json:"state"`
+	Repo     string               `json:"repo,omitempty"`
+	Pull     int                  `json:"pull,omitempty"`
+	Author   string               `json:"author,omitempty"`
+	URL      string               `json:"url"`
+	Finished time.Time            `json:"finished"`
+	// Reasons are why the user is notified of the failure: "pull_request",
+	// "job" and/or "repo".
+	Reasons []string `json:"reasons"`
+}
+
+// latestRuns keeps the most recently started run of every job for every
+// pull request, or for every branch for jobs that do not test a pull
+// request, so that failures that were fixed by a later run are not reported.
+func latestRuns(pjs []prowapi.ProwJob) []prowapi.ProwJob {
+	latest := map[string]prowapi.ProwJob{}
+	for _, pj := range pjs {
+		// Runs on different commits of a pull request or branch are not told apart.
+		key := pj.Spec.Job
+		if refs := pjRefs(pj); refs != nil {
+			key = fmt.Sprintf("%s|%s/%s@%s", pj.Spec.Job, refs.Org, refs.Repo, refs.BaseRef)
+			if len(refs.Pulls) > 0 {
+				key = fmt.Sprintf("%s|%s/%s#%d", pj.Spec.Job, refs.Org, refs.Repo, refs.Pulls[0].Number)
+			}
+		}
+		if current, ok := latest[key]; !ok || current.Status.StartTime.Before(&pj.Status.StartTime) {
+			latest[key] = pj
+		}
+	}
+	runs := make([]prowapi.ProwJob, 0, len(latest))
+	for _, pj := range latest {
+		runs = append(runs, pj)
+	}
+	return runs
+}
+
+func pjRefs(pj prowapi.ProwJob) *prowapi.Refs {
+	if pj.Spec.Refs != nil {
+		return pj.Spec.Refs
+	}
+	if len(pj.Spec.ExtraRefs) > 0 {
+		return &pj.Spec.ExtraRefs[0]
+	}
+	return nil
+}
+
+// failuresFor returns the failures the user follows that finished after
+// since, most recent first.
+func failuresFor(pjs []prowapi.ProwJob, login string, prefs notificationPreferences, since time.Time) []failureNotification {
+	jobs := map[string]bool{}
+	for _, job := range prefs.Jobs {
+		jobs[job] = true
+	}
+	repos := map[string]bool{}
+	for _, repo := range prefs.Repos {
+		repos[strings.ToLower(repo)] = true
+	}
+
+	failures := []failureNotification{}
+	for _, pj := range latestRuns(pjs) {
+		if pj.Status.State != prowapi.FailureState && pj.Status.State != prowapi.ErrorState {
+			continue
+		}
+		if pj.Status.CompletionTime == nil || !pj.Status.CompletionTime.After(since) {
+			continue
+		}
+		failure := failureNotification{
+			Job:      pj.Spec.Job,
+			BuildID:  pj.Status.BuildID,
+			State:    pj.Status.State,
+			URL:      pj.Status.URL,
+			Finished: pj.Status.CompletionTime.Time,
+		}
+		if refs := pjRefs(pj); refs != nil {
+			failure.Repo = refs.Org + "/" + refs.Repo
+			if len(refs.Pulls) > 0 {
+				failure.Pull = refs.Pulls[0].Number
+				failure.Author = refs.Pulls[0].Author
+			}
+			if prefs.PullRequests && pj.Spec.Type == prowapi.PresubmitJob && login != "" {
+				for _, pull := range refs.Pulls {
+					if strings.EqualFold(pull.Author, login) {
+						failure.Reasons = append(failure.Reasons, "pull_request")
+						break
+					}
+				}
+			}
+			if repos[strings.ToLower(failure.Repo)] || repos[strings.ToLower(refs.Org)] {
+				failure.Reasons = append(failure.Reasons, "repo")
+			}
+		}
+		if jobs[pj.Spec.Job] {
+			failure.Reasons = append(failure.Reasons, "job")
+		}
+		if len(failure.Reasons) == 0 {
+			continue
+		}
+		failures = append(failures, failure)
+	}
+	sort.SliceStable(failures, func(i, j int) bool {
+		if !failures[i].Finished.Equal(failures[j].Finished) {
+			return failures[i].Finished.After(failures[j].Finished)
+		}
+		return failures[i].Job < failures[j].Job
+	})
+	if len(failures) > maxFailureFeedLength {
+		failures = failures[:maxFailureFeedLength]
+	}
+	return failures
+}
+
+// notifications persists the notification preferences of every user as one
+// JSON object per GitHub login under path and serves their failure feeds.
+type notifications struct {
+	opener io.Opener
+	path   string
+	// login identifies the GitHub user making the request.
+	login func(r *http.Request) (string, error)
+	// prowJobs lists the ProwJobs known to Deck.
+	prowJobs func() []prowapi.ProwJob
+	log      *logrus.Entry
+
+	lock sync.Mutex
+}
+
+func (n *notifications) pathFor(login string) string {
+	return fmt.Sprintf("%s/%s.json", strings.TrimSuffix(n.path, "/"), strings.ToLower(login))
+}
+
+func (n *notifications) read(r *http.Request, login string) (notificationPreferences, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	raw, err := io.ReadContent(r.Context(), n.log, n.opener, n.pathFor(login))
+	if io.IsNotExist(err) {
+		return defaultNotificationPreferences, nil
+	}
+	if err != nil {
+		return notificationPreferences{}, err
+	}
+	var prefs notificationPreferences
+	if err := json.Unmarshal(raw, &prefs); err != nil {
+		return notificationPreferences{}, fmt.Errorf("unmarshal notification preferences of %s: %w", login, err)
+	}
+	return prefs, nil
+}
+
+func (n *notifications) write(r *http.Request, login string, prefs notificationPreferences) error {
+	raw, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return io.WriteContent(r.Context(), n.log, n.opener, n.pathFor(login), raw)
+}
+
+// handlePreferences serves the notification preferences of the logged in
+// user:
+//
+// GET /notifications/preferences returns them
+// PUT /notifications/preferences replaces them with the JSON encoded body
+func (n *notifications) handlePreferences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		login, err := n.login(r)
+		if err != nil {
+			http.Error(w, "Error retrieving GitHub login.", http.StatusUnauthorized)
+			return
+		}
+		log := n.log.WithField("user", login)
+
+		switch r.Method {
+		case http.MethodGet:
+			prefs, err := n.read(r, login)
+			if err != nil {
+				log.WithError(err).Error("Failed to read notification preferences.")
+				http.Error(w, "Failed to read notification preferences.", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(prefs); err != nil {
+				log.WithError(err).Error("Failed to write notification preferences.")
+			}
+		case http.MethodPut:
+			var prefs notificationPreferences
+			if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid notification preferences: %v", err), http.StatusBadRequest)
+				return
+			}
+			if err := validateNotificationPreferences(prefs); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid notification preferences: %v", err), http.StatusBadRequest)
+				return
+			}
+			if err := n.write(r, login, prefs); err != nil {
+				log.WithError(err).Error("Failed to write notification preferences.")
+				http.Error(w, "Failed to save notification preferences.", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, fmt.Sprintf("bad verb %v", r.Method), http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// handleFeed serves the failures the logged in user follows as JSON. They go
+// back a week, or to the RFC 3339 time of the since parameter:
+//
+// GET /notifications/feed?since=<time>
+func (n *notifications) handleFeed() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		if r.Method != http.MethodGet {
+			http.Error(w, fmt.Sprintf("bad verb %v", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		login, err := n.login(r)
+		if err != nil {
+			http.Error(w, "Error retrieving GitHub login.", http.StatusUnauthorized)
+			return
+		}
+		since := time.Now().Add(-defaultFailureFeedPeriod)
+		if raw := r.URL.Query().Get("since"); raw != "" {
+			if since, err = time.Parse(time.RFC3339, raw); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid since: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+		log := n.log.WithField("user", login)
+		prefs, err := n.read(r, login)
+		if err != nil {
+			log.WithError(err).Error("Failed to read notification preferences.")
+			http.Error(w, "Failed to read notification preferences.", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(failuresFor(n.prowJobs(), login, prefs, since)); err != nil {
+			log.WithError(err).Error("Failed to write failure feed.")
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+func TestValidateNotificationPreferences(t *testing.T) {
+	testCases := []struct {
+		name        string
+		prefs       notificationPreferences
+		expectedErr bool
+	}{
+		{
+			name:  "valid preferences",
+			prefs: notificationPreferences{PullRequests: true, Jobs: []string{"ci-unit"}, Repos: []string{"org/repo", "other-org"}},
+		},
+		{
+			name:        "empty job",
+			prefs:       notificationPreferences{Jobs: []string{" "}},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid repo",
+			prefs:       notificationPreferences{Repos: []string{"org/repo/path"}},
+			expectedErr: true,
+		},
+		{
+			name:        "too many jobs",
+			prefs:       notificationPreferences{Jobs: make([]string, maxFollowed+1)},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateNotificationPreferences(tc.prefs); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestFailuresFor(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	pj := func(job string, jobType prowapi.ProwJobType, state prowapi.ProwJobState, started, finished time.Duration, refs *prowapi.Refs) prowapi.ProwJob {
+		pj := prowapi.ProwJob{
+			Spec: prowapi.ProwJobSpec{Job: job, Type: jobType, Refs: refs},
+			Status: prowapi.ProwJobStatus{
+				State:     state,
+				BuildID:   job + "-" + started.String(),
+				URL:       "https://prow.example.com/view/" + job,
+				StartTime: metav1.NewTime(now.Add(-started)),
+			},
+		}
+		if finished > 0 {
+			completion := metav1.NewTime(now.Add(-finished))
+			pj.Status.CompletionTime = &completion
+		}
+		return pj
+	}
+	pull := func(number int, author string) *prowapi.Refs {
+		return &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", Pulls: []prowapi.Pull{{Number: number, Author: author}}}
+	}
+	branch := &prowapi.Refs{Org: "other-org", Repo: "tool", BaseRef: "main"}
+
+	pjs := []prowapi.ProwJob{
+		// The pull request of the user failed a job...
+		pj("pull-unit", prowapi.PresubmitJob, prowapi.FailureState, 3*time.Hour, 2*time.Hour, pull(1, "Alice")),
+		// ... and another one, which passed when retested.
+		pj("pull-lint", prowapi.PresubmitJob, prowapi.FailureState, 5*time.Hour, 4*time.Hour, pull(1, "alice")),
+		pj("pull-lint", prowapi.PresubmitJob, prowapi.SuccessState, 2*time.Hour, time.Hour, pull(1, "alice")),
+		// Someone else's pull request is only of interest through the repo.
+		pj("pull-unit", prowapi.PresubmitJob, prowapi.ErrorState, 3*time.Hour, 30*time.Minute, pull(2, "bob")),
+		// A followed periodic failed on a later commit.
+		pj("ci-tool", prowapi.PeriodicJob, prowapi.SuccessState, 26*time.Hour, 25*time.Hour, branch),
+		pj("ci-tool", prowapi.PeriodicJob, prowapi.FailureState, 2*time.Hour, 90*time.Minute, branch),
+		// Failures that are too old or still running are left out.
+		pj("ci-old", prowapi.PeriodicJob, prowapi.FailureState, 9*24*time.Hour, 8*24*time.Hour, nil),
+		pj("ci-running", prowapi.PeriodicJob, prowapi.PendingState, time.Hour, 0, nil),
+	}
+
+	prefs := notificationPreferences{PullRequests: true, Jobs: []string{"ci-tool", "ci-old", "ci-running"}, Repos: []string{"Org/Repo"}}
+	expected := []failureNotification{
+		{
+			Job: "pull-unit", BuildID: "pull-unit-3h0m0s", State: prowapi.ErrorState,
+			Repo: "org/repo", Pull: 2, Author: "bob",
+			URL: "https://prow.example.com/view/pull-unit", Finished: now.Add(-30 * time.Minute),
+			Reasons: []string{"repo"},
+		},
+		{
+			Job: "ci-tool", BuildID: "ci-tool-2h0m0s", State: prowapi.FailureState,
+			Repo: "other-org/tool",
+			URL:  "https://prow.example.com/view/ci-tool", Finished: now.Add(-90 * time.Minute),
+			Reasons: []string{"job"},
+		},
+		{
+			Job: "pull-unit", BuildID: "pull-unit-3h0m0s", State: prowapi.FailureState,
+			Repo: "org/repo", Pull: 1, Author: "Alice",
+			URL: "https://prow.example.com/view/pull-unit", Finished: now.Add(-2 * time.Hour),
+			Reasons: []string{"pull_request", "repo"},
+		},
+	}
+	if diff := cmp.Diff(expected, failuresFor(pjs, "alice", prefs, now.Add(-defaultFailureFeedPeriod))); diff != "" {
+		t.Errorf("failures differ from expected (-want +got):\n%s", diff)
+	}
+
+	if failures := failuresFor(pjs, "carol", defaultNotificationPreferences, now.Add(-defaultFailureFeedPeriod)); len(failures) != 0 {
+		t.Errorf("expected no failures for a user without pull requests, got %v", failures)
+	}
+}
+
+func newTestNotifications(t *testing.T, login string, pjs []prowapi.ProwJob) *notifications {
+	opener, err := io.NewOpener(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("failed to create opener: %v", err)
+	}
+	return &notifications{
+		opener: opener,
+		path:   t.TempDir(),
+		login: func(*http.Request) (string, error) {
+			if login == "" {
+				return "", errors.New("not logged in")
+			}
+			return login, nil
+		},
+		prowJobs: func() []prowapi.ProwJob { return pjs },
+		log:      logrus.WithField("handler", "/notifications"),
+	}
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	n := newTestNotifications(t, "Alice", nil)
+	handler := n.handlePreferences()
+
+	get := func() notificationPreferences {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/notifications/preferences", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		var prefs notificationPreferences
+		if err := json.Unmarshal(rr.Body.Bytes(), &prefs); err != nil {
+			t.Fatalf("failed to unmarshal preferences: %v", err)
+		}
+		return prefs
+	}
+
+	if diff := cmp.Diff(defaultNotificationPreferences, get()); diff != "" {
+		t.Errorf("expected the default preferences before any were saved (-want +got):\n%s", diff)
+	}
+
+	for _, tc := range []struct {
+		body         string
+		expectedCode int
+	}{
+		{body: `{"pull_requests": false, "jobs": ["ci-unit"], "repos": ["org/repo"]}`, expectedCode: http.StatusNoContent},
+		{body: `{"repos": ["not a repo"]}`, expectedCode: http.StatusBadRequest},
+		{body: `{`, expectedCode: http.StatusBadRequest},
+	} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/notifications/preferences", strings.NewReader(tc.body)))
+		if rr.Code != tc.expectedCode {
+			t.Errorf("saving %s: expected status %d, got %d: %s", tc.body, tc.expectedCode, rr.Code, rr.Body.String())
+		}
+	}
+
+	expected := notificationPreferences{Jobs: []string{"ci-unit"}, Repos: []string{"org/repo"}}
+	if diff := cmp.Diff(expected, get()); diff != "" {
+		t.Errorf("saved preferences differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestNotificationFeed(t *testing.T) {
+	completion := metav1.NewTime(time.Now().Add(-time.Hour))
+	pjs := []prowapi.ProwJob{{
+		Spec:   prowapi.ProwJobSpec{Job: "pull-unit", Type: prowapi.PresubmitJob, Refs: &prowapi.Refs{Org: "org", Repo: "repo", Pulls: []prowapi.Pull{{Number: 1, Author: "alice"}}}},
+		Status: prowapi.ProwJobStatus{State: prowapi.FailureState, BuildID: "1", StartTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)), CompletionTime: &completion},
+	}}
+
+	testCases := []struct {
+		name          string
+		login         string
+		query         string
+		expectedCode  int
+		expectedCount int
+	}{
+		{
+			name:          "failures of the user's pull requests by default",
+			login:         "alice",
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+		},
+		{
+			name:         "failures since later",
+			login:        "alice",
+			query:        "?since=" + time.Now().Format(time.RFC3339),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid since",
+			login:        "alice",
+			query:        "?since=yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "not logged in",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			newTestNotifications(t, tc.login, pjs).handleFeed().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/notifications/feed"+tc.query, nil))
+			if rr.Code != tc.expectedCode {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedCode, rr.Code, rr.Body.String())
+			}
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var failures []failureNotification
+			if err := json.Unmarshal(rr.Body.Bytes(), &failures); err != nil {
+				t.Fatalf("failed to unmarshal feed: %v", err)
+			}
+			if len(failures) != tc.expectedCount {
+				t.Errorf("expected %d failures, got %v", tc.expectedCount, failures)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go/v4"
+)
+
+const (
+	defaultOIDCGroupsClaim   = "groups"
+	defaultOIDCUsernameClaim = "email"
+	defaultOIDCTokenHeader   = "Authorization"
+
+	// oidcKeysRefreshInterval is how often the signing keys of the issuer are
+	// fetched again at most when a token is signed with an unknown key.
+	oidcKeysRefreshInterval = time.Minute
+)
+
+// oidcOptions configures how Deck identifies users by the OIDC ID token that
+// an authenticating proxy in front of it, e.g. oauth2-proxy, passes
+// along with every request.
+type oidcOptions struct {
+	issuerURL     string
+	clientID      string
+	groupsClaim   string
+	usernameClaim string
+	tokenHeader   string
+}
+
+func (o *oidcOptions) enabled() bool {
+	return o.issuerURL != ""
+}
+
+func (o *oidcOptions) validate() error {
+	if o.enabled() && o.clientID == "" {
+		return errors.New("an OIDC issuer URL was provided but required flag --oidc-client-id was unset")
+	}
+	return nil
+}
+
+// oidcIdentity is the identity of a user according to its verified ID token.
+type oidcIdentity struct {
+	user   string
+	groups []string
+}
+
+// oidcAgent verifies ID tokens against the signing keys of the issuer.
+type oidcAgent struct {
+	opts   oidcOptions
+	client *http.Client
+
+	lock      sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	lastFetch time.Time
+}
+
+func newOIDCAgent(opts oidcOptions) *oidcAgent {
+	return &oidcAgent{opts: opts, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// identify returns the identity of the user of the request according to the
+// ID token in the configured header.
+func (a *oidcAgent) identify(r *http.Request) (*oidcIdentity, error) {
+	raw := r.Header.Get(a.opts.tokenHeader)
+	if strings.EqualFold(a.opts.tokenHeader, "Authorization") {
+		raw = strings.TrimPrefix(raw, "Bearer ")
+	}
+	if raw == "" {
+		return nil, fmt.Errorf("no ID token in the %s header", a.opts.tokenHeader)
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(raw, claims, a.key, jwt.WithAudience(a.opts.clientID), jwt.WithIssuer(a.opts.issuerURL)); err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	// Unlike the OIDC spec, the JWT spec makes both claims optional.
+	if _, ok := claims["aud"]; !ok {
+		return nil, errors.New("invalid ID token: no aud claim")
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, errors.New("invalid ID token: no exp claim")
+	}
+
+	user, _ := claims[a.opts.usernameClaim].(string)
+	if user == "" {
+		return nil, fmt.Errorf("ID token has no %s claim", a.opts.usernameClaim)
+	}
+	identity := &oidcIdentity{user: user}
+	switch groups := claims[a.opts.groupsClaim].(type) {
+	case string:
+		identity.groups = []string{groups}
+	case []interface{}:
+		for _, group := range groups {
+			if group, ok := group.(string); ok {
+				identity.groups = append(identity.groups, group)
+			}
+		}
+	}
+	return identity, nil
+}
+
+// key returns the public key a token is signed with, fetching the keys of the
+// issuer if it is not known yet.
+func (a *oidcAgent) key(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unsupported signing method %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if key, ok := a.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(a.lastFetch) < oidcKeysRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	a.lastFetch = time.Now()
+	keys, err := a.fetchKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the signing keys of %s: %w", a.opts.issuerURL, err)
+	}
+	a.keys = keys
+	if key, ok := a.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// fetchKeys fetches the RSA signing keys of the issuer from the JWKS its
+// discovery document points to.
+func (a *oidcAgent) fetchKeys() (map[string]*rsa.PublicKey, error) {
+	var discovery struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := a.getJSON(strings.TrimSuffix(a.opts.issuerURL, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, err
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errors.New("discovery document has no jwks_uri")
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := a.getJSON(discovery.JWKSURI, &jwks); err != nil {
+		return nil, err
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, key := range jwks.Keys {
+		if key.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of key %q: %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of key %q: %w", key.Kid, err)
+		}
+		keys[key.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
+
+func (a *oidcAgent) getJSON(url string, into interface{}) error {
+	resp, err := a.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go/v4"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/sessions"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/githuboauth"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	testOIDCClientID = "deck"
+	testOIDCKeyID    = "key-1"
+)
+
+// newTestIssuer serves the discovery document and JWKS of an OIDC issuer
+// that signs with the given key.
+func newTestIssuer(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL, "jwks_uri": server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testOIDCKeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	return server
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testOIDCKeyID
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestOIDCAgentIdentify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	issuer := newTestIssuer(t, key)
+	claims := func(modify func(jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":    issuer.URL,
+			"aud":    testOIDCClientID,
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"email":  "jane@example.com",
+			"groups": []string{"everyone", "ci-admins"},
+		}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+
+	testCases := []struct {
+		name     string
+		header   string
+		token    string
+		expected *oidcIdentity
+		err      bool
+	}{
+		{
+			name:     "valid token",
+			header:   "Bearer " + signTestToken(t, key, claims(nil)),
+			expected: &oidcIdentity{user: "jane@example.com", groups: []string{"everyone", "ci-admins"}},
+		},
+		{
+			name:     "single group",
+			header:   "Bearer " + signTestToken(t, key, claims(func(c jwt.MapClaims) { c["groups"] = "ci-admins" })),
+			expected: &oidcIdentity{user: "jane@example.com", groups: []string{"ci-admins"}},
+		},
+		{
+			name:     "no groups",
+			header:   "Bearer " + signTestToken(t, key, claims(func(c jwt.MapClaims) { delete(c, "groups") })),
+			expected: &oidcIdentity{user: "jane@example.com"},
+		},
+		{
+			name: "no token",
+			err:  true,
+		},
+		{
+			name:   "malformed token",
+			header: "Bearer garbage",
+			err:    true,
+		},
+		{
+			name:   "signed with another key",
+			header: "Bearer " + signTestToken(t, otherKey, claims(nil)),
+			err:    true,
+		},
+		{
+			name:   "expired",
+			header: "Bearer " + signTestToken(t, key, claims(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() })),
+			err:    true,
+		},
+		{
+			name:   "no expiry",
+			header: "Bearer " + signTestToken(t, key, claims(func(c jwt.MapClaims) { delete(c, "exp") })),
+			err:    true,
+		},
+		{
+			name:   "issued for another client",
+			header: "Bearer " + signTestToken(t, key, claims(func(c jwt.MapClaims) { c["aud"] = "someone-else" })),
+			err:    true,
+		},
+		{
+			name:   "no audience",
+			header: "Bearer " + signTestToken(t, key, claims(func(c jwt.MapClaims) { delete(c, "aud") })),
+			err:    true,
+		},
+		{
+			name:   "issued by another issuer",
+			header: "Bearer " + signTestToken(t, key, claims(func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" })),
+			err:    true,
+		},
+		{
+			name:   "no username",
+			header: "Bearer " + signTestToken(t, key, claims(func(c jwt.MapClaims) { delete(c, "email") })),
+			err:    true,
+		},
+	}
+
+	agent := newOIDCAgent(oidcOptions{
+		issuerURL:     issuer.URL,
+		clientID:      testOIDCClientID,
+		groupsClaim:   defaultOIDCGroupsClaim,
+		usernameClaim: defaultOIDCUsernameClaim,
+		tokenHeader:   defaultOIDCTokenHeader,
+	})
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/rerun", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			identity, err := agent.identify(req)
+			if tc.err != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.err, err)
+			}
+			if diff := cmp.Diff(tc.expected, identity, cmp.AllowUnexported(oidcIdentity{})); diff != "" {
+				t.Errorf("identity differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsAllowedToRerunWithOIDC(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	issuer := newTestIssuer(t, key)
+	token := func(groups ...string) string {
+		return signTestToken(t, key, jwt.MapClaims{
+			"iss":    issuer.URL,
+			"aud":    testOIDCClientID,
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"email":  "jane@example.com",
+			"groups": groups,
+		})
+	}
+
+	testCases := []struct {
+		name          string
+		token         string
+		githubLogin   string
+		withGitHub    bool
+		configGroups  []string
+		jobGroups     []string
+		expected      bool
+		expectedUser  string
+		expectedCode  int
+		expectedError bool
+	}{
+		{
+			name:         "group allowed by the config",
+			token:        token("ci-admins"),
+			configGroups: []string{"ci-admins"},
+			expected:     true,
+			expectedUser: "jane@example.com",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "group allowed by the job",
+			token:        token("everyone", "release-managers"),
+			configGroups: []string{"ci-admins"},
+			jobGroups:    []string{"release-managers"},
+			expected:     true,
+			expectedUser: "jane@example.com",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "no group allowed",
+			token:        token("everyone"),
+			configGroups: []string{"ci-admins"},
+			expectedUser: "jane@example.com",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "no token and no GitHub OAuth",
+			configGroups:  []string{"ci-admins"},
+			expectedCode:  http.StatusUnauthorized,
+			expectedError: true,
+		},
+		{
+			name:         "no token falls back to GitHub OAuth",
+			githubLogin:  "authorized",
+			withGitHub:   true,
+			configGroups: []string{"ci-admins"},
+			expected:     true,
+			expectedUser: "authorized",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "no group allowed falls back to GitHub OAuth",
+			token:        token("everyone"),
+			githubLogin:  "authorized",
+			withGitHub:   true,
+			configGroups: []string{"ci-admins"},
+			expected:     true,
+			expectedUser: "authorized",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	agent := newOIDCAgent(oidcOptions{
+		issuerURL:     issuer.URL,
+		clientID:      testOIDCClientID,
+		groupsClaim:   defaultOIDCGroupsClaim,
+		usernameClaim: defaultOIDCUsernameClaim,
+		tokenHeader:   defaultOIDCTokenHeader,
+	})
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{Spec: prowapi.ProwJobSpec{
+				Job:             "whoa",
+				Type:            prowapi.PeriodicJob,
+				RerunAuthConfig: &prowapi.RerunAuthConfig{OIDCGroups: tc.jobGroups},
+			}}
+			authCfgGetter := func(*prowapi.ProwJobSpec) *prowapi.RerunAuthConfig {
+				return &prowapi.RerunAuthConfig{GitHubUsers: []string{"authorized"}, OIDCGroups: tc.configGroups}
+			}
+			req := httptest.NewRequest(http.MethodPost, "/rerun?prowjob=wowsuch", nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			var goa *githuboauth.Agent
+			if tc.withGitHub {
+				req.AddCookie(&http.Cookie{Name: "github_login", Value: tc.githubLogin})
+				cookieStore := sessions.NewCookieStore([]byte("secret-key"))
+				session, err := sessions.GetRegistry(req).Get(cookieStore, "access-token-session")
+				if err != nil {
+					t.Fatalf("Error making access token session: %v", err)
+				}
+				session.Values["access-token"] = &oauth2.Token{AccessToken: "validtoken"}
+				goa = githuboauth.NewAgent(&githuboauth.Config{CookieStore: cookieStore}, logrus.WithField("client", "githuboauth"))
+			}
+			pca := plugins.NewFakeConfigAgent()
+
+			allowed, user, err, code := isAllowedToRerun(req, authCfgGetter, goa, agent, &fakeAuthenticatedUserIdentifier{login: tc.githubLogin}, pj, fakegithub.NewFakeClient(), &pca, logrus.WithField("handler", "/rerun"))
+			if tc.expectedError != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedError, err)
+			}
+			if allowed != tc.expected {
+				t.Errorf("expected allowed %t, got %t", tc.expected, allowed)
+			}
+			if user != tc.expectedUser {
+				t.Errorf("expected user %q, got %q", tc.expectedUser, user)
+			}
+			if code != tc.expectedCode {
+				t.Errorf("expected code %d, got %d", tc.expectedCode, code)
+			}
+		})
+	}
+}
@@ -25,12 +25,11 @@ import (
 	"github.com/sirupsen/logrus"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/audit"
 	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
-	gerritsource "sigs.k8s.io/prow/pkg/gerrit/source"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/githuboauth"
 	"sigs.k8s.io/prow/pkg/kube"
@@ -55,112 +54,6 @@ var (
 	)
 )
 
-func verifyRerunRefs(refs *prowapi.Refs) error {
-	var errs []error
-	if refs == nil {
-		return errors.New("Refs must be supplied")
-	}
-	if len(refs.Org) == 0 {
-		errs = append(errs, errors.New("org must be supplied"))
-	}
-	if len(refs.Repo) == 0 {
-		errs = append(errs, errors.New("repo must be supplied"))
-	}
-	if len(refs.BaseRef) == 0 {
-		errs = append(errs, errors.New("baseRef must be supplied"))
-	}
-	return utilerrors.NewAggregate(errs)
-}
-
-func setRerunOrgRepo(refs *prowapi.Refs, labels map[string]string) string {
-	org, repo := refs.Org, refs.Repo
-	orgRepo := org + "/" + repo
-	// Normalize prefix to orgRepo if this is a gerrit job.
-	// (Unfortunately gerrit jobs use the full repo URL as the identifier.)
-	if labels[kube.GerritRevision] != "" && !gerritsource.IsGerritOrg(refs.Org) {
-		orgRepo = gerritsource.CloneURIFromOrgRepo(refs.Org, refs.Repo)
-	}
-	return orgRepo
-}
-
-type preOrPostsubmit interface {
-	GetName() string
-	CouldRun(string) bool
-	GetLabels() map[string]string
-	GetAnnotations() map[string]string
-}
-
-func getPreOrPostSpec[p preOrPostsubmit](jobGetter func(string) []p, creator func(p, prowapi.Refs) prowapi.ProwJobSpec, name string, refs *prowapi.Refs, labels map[string]string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
-	if err := verifyRerunRefs(refs); err != nil {
-		return nil, nil, nil, err
-	}
-	var result *p
-	branch := refs.BaseRef
-	orgRepo := setRerunOrgRepo(refs, labels)
-	nameFound := false
-	for _, job := range jobGetter(orgRepo) {
-		job := job
-		if job.GetName() != name {
-			continue
-		}
-		nameFound = true
-		if job.CouldRun(branch) { // filter out jobs that are not branch matching
-			if result != nil {
-				return nil, nil, nil, fmt.Errorf("%s matches multiple prow jobs from orgRepo %q", name, orgRepo)
-			}
-			result = &job
-		}
-	}
-	if result == nil {
-		if nameFound {
-			return nil, nil, nil, fmt.Errorf("found job %q, but not allowed to run for orgRepo %q", name, orgRepo)
-		} else {
-			return nil, nil, nil, fmt.Errorf("failed to find job %q for orgRepo %q", name, orgRepo)
-		}
-	}
-
-	prowJobSpec := creator(*result, *refs)
-	return &prowJobSpec, (*result).GetLabels(), (*result).GetAnnotations(), nil
-}
-
-func getPresubmitSpec(cfg config.Getter, name string, refs *prowapi.Refs, labels map[string]string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
-	return getPreOrPostSpec(cfg().GetPresubmitsStatic, pjutil.PresubmitSpec, name, refs, labels)
-}
-
-func getPostsubmitSpec(cfg config.Getter, name string, refs *prowapi.Refs, labels map[string]string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
-	return getPreOrPostSpec(cfg().GetPostsubmitsStatic, pjutil.PostsubmitSpec, name, refs, labels)
-}
-
-func getPeriodicSpec(cfg config.Getter, name string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
-	var periodicJob *config.Periodic
-	for _, job := range cfg().AllPeriodics() {
-		if job.Name == name {
-			// Directly followed by break, so this is ok
-			// nolint: exportloopref
-			periodicJob = &job
-			break
-		}
-	}
-	if periodicJob == nil {
-		return nil, nil, nil, fmt.Errorf("failed to find associated periodic job %q", name)
-	}
-	prowJobSpec := pjutil.PeriodicSpec(*periodicJob)
-	return &prowJobSpec, periodicJob.Labels, periodicJob.Annotations, nil
-}
-
-func getProwJobSpec(pjType prowapi.ProwJobType, cfg config.Getter, name string, refs *prowapi.Refs, labels map[string]string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
-	switch pjType {
-	case prowapi.PeriodicJob:
-		return getPeriodicSpec(cfg, name)
-	case prowapi.PresubmitJob:
-		return getPresubmitSpec(cfg, name, refs, labels)
-	case prowapi.PostsubmitJob:
-		return getPostsubmitSpec(cfg, name, refs, labels)
-	default:
-		return nil, nil, nil, fmt.Errorf("Could not create new prowjob: Invalid prowjob type: %q", pjType)
-	}
-}
-
 type pluginsCfg func() *plugins.Configuration
 
 // canTriggerJob determines whether the given user can trigger any job.
@@ -208,7 +101,7 @@ func canTriggerJob(user string, pj prowapi.ProwJob, cfg *prowapi.RerunAuthConfig
 	return false, nil
 }
 
-func isAllowedToRerun(r *http.Request, acfg authCfgGetter, goa *githuboauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, pj prowapi.ProwJob, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) (bool, string, error, int) {
+func isAllowedToRerun(r *http.Request, acfg authCfgGetter, goa *githuboauth.Agent, oidc *oidcAgent, ghc githuboauth.AuthenticatedUserIdentifier, pj prowapi.ProwJob, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) (bool, string, error, int) {
 	authConfig := acfg(&pj.Spec)
 	var allowed bool
 	var login string
@@ -217,6 +110,22 @@ func isAllowedToRerun(r *http.Request, acfg authCfgGetter, goa *githuboauth.Agen
 		// jobs so that GH oauth doesn't need to be set up for private Prows.
 		allowed = true
 	} else {
+		if oidc != nil {
+			// Users of the IdP are authorized by their groups. Users that are not
+			// can still be authorized by their GitHub login if GH oauth is set up.
+			identity, err := oidc.identify(r)
+			if err != nil && goa == nil {
+				return allowed, "", fmt.Errorf("Error verifying the OIDC ID token: %w", err), http.StatusUnauthorized
+			}
+			if identity != nil {
+				if authConfig.IsAuthorizedByGroups(identity.groups) || pj.Spec.RerunAuthConfig.IsAuthorizedByGroups(identity.groups) {
+					return true, identity.user, nil, http.StatusOK
+				}
+				if goa == nil {
+					return allowed, identity.user, nil, http.StatusOK
+				}
+			}
+		}
 		if goa == nil {
 			return allowed, "", errors.New("GitHub oauth must be configured to rerun jobs unless 'allow_anyone: true' is specified."), http.StatusInternalServerError
 		}
@@ -242,7 +151,7 @@ const (
 // handleRerun triggers a rerun of the given job if that features is enabled, it receives a
 // POST request, and the user has the necessary permissions. Otherwise, it writes the config
 // for a new job but does not trigger it.
-func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, createProwJob bool, acfg authCfgGetter, goa *githuboauth.Agent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, log *logrus.Entry) http.HandlerFunc {
+func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, createProwJob bool, acfg authCfgGetter, goa *githuboauth.Agent, oidc *oidcAgent, ghc githuboauth.AuthenticatedUserIdentifier, cli deckGitHubClient, pluginAgent *plugins.ConfigAgent, auditor *audit.Auditor, log *logrus.Entry) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		name := r.URL.Query().Get("prowjob")
 		mode := r.URL.Query().Get("mode")
@@ -263,7 +172,7 @@ func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, creat
 		enableScheduling := cfg().Scheduler.Enabled
 		var newPJ prowapi.ProwJob
 		if mode == LATEST {
-			prowJobSpec, labels, annotations, err := getProwJobSpec(pj.Spec.Type, cfg, pj.Spec.Job, pj.Spec.Refs, pj.Labels)
+			prowJobSpec, labels, annotations, err := pjutil.ProwJobSpecFromConfig(pj.Spec.Type, cfg, pj.Spec.Job, pj.Spec.Refs, pj.Labels)
 			if err != nil {
 				// These are user errors, i.e. missing fields, requested prowjob doesn't exist etc.
 				// These errors are already surfaced to user via pubsub two lines below.
@@ -303,7 +212,7 @@ func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, creat
 				http.Error(w, "Direct rerun feature is not enabled. Enable with the '--rerun-creates-job' flag.", http.StatusMethodNotAllowed)
 				return
 			}
-			allowed, user, err, code := isAllowedToRerun(r, acfg, goa, ghc, newPJ, cli, pluginAgent, l)
+			allowed, user, err, code := isAllowedToRerun(r, acfg, goa, oidc, ghc, newPJ, cli, pluginAgent, l)
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Could not verify if allowed to rerun: %v.", err), code)
 				l.WithError(err).Debug("Could not verify if allowed to rerun.")
@@ -324,6 +233,14 @@ func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, creat
 			}
 			newPJ.Status.Description = rerunDescription
 			created, err := prowJobClient.Create(context.TODO(), &newPJ, metav1.CreateOptions{})
+			event := audit.Event{Action: audit.ActionRerun, Actor: user, Target: name, Error: audit.ErrorString(err), Details: map[string]string{"job": newPJ.Spec.Job}}
+			if mode != "" {
+				event.Details["mode"] = mode
+			}
+			if err == nil {
+				event.Details["new_prowjob"] = created.Name
+			}
+			auditor.Emit(r.Context(), event)
 			if err != nil {
 				l.WithError(err).Error("Error creating job.")
 				http.Error(w, fmt.Sprintf("Error creating job: %v", err), http.StatusInternalServerError)
@@ -335,6 +252,8 @@ func handleRerun(cfg config.Getter, prowJobClient prowv1.ProwJobInterface, creat
 			} else {
 				l.Info(fmt.Sprintf("Successfully created a rerun of %v.", name))
 			}
+			// Point API clients to the new job.
+			w.Header().Set("Location", "/prowjob?prowjob="+created.Name)
 			if _, err = w.Write([]byte("Job successfully triggered. Wait 30 seconds and refresh the page for the job to show up.")); err != nil {
 				l.WithError(err).Error("Error writing to rerun response.")
 			}
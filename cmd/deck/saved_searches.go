@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+var (
+	savedSearchNameRe  = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+	savedSearchLoginRe = regexp.MustCompile(`^[A-Za-z0-9-]{1,39}$`)
+	// savedSearchParams are the query parameters of the job list filters.
+	savedSearchParams = map[string]bool{
+		"type": true, "repo": true, "pull": true, "author": true,
+		"job": true, "state": true, "cluster": true, "since": true,
+	}
+)
+
+// savedSearchList is the response listing the saved searches of a user.
+type savedSearchList struct {
+	// Login is the GitHub login of the user, which the shareable URLs of
+	// the searches start with.
+	Login    string        `json:"login"`
+	Searches []savedSearch `json:"searches"`
+}
+
+// savedSearch is a named filter over the job list.
+type savedSearch struct {
+	Name string `json:"name"`
+	// Query is the query string of the filtered job list, without the
+	// leading '?'.
+	Query string `json:"query"`
+}
+
+// savedSearches persists the saved searches of every user as one JSON
+// object per GitHub login under path.
+type savedSearches struct {
+	opener io.Opener
+	path   string
+	// login identifies the GitHub user making the request.
+	login func(r *http.Request) (string, error)
+	log   *logrus.Entry
+
+	lock sync.Mutex
+}
+
+func (s *savedSearches) pathFor(login string) string {
+	return fmt.Sprintf("%s/%s.json", strings.TrimSuffix(s.path, "/"), strings.ToLower(login))
+}
+
+func (s *savedSearches) read(r *http.Request, login string) (map[string]string, error) {
+	raw, err := io.ReadContent(r.Context(), s.log, s.opener, s.pathFor(login))
+	if io.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	searches := map[string]string{}
+	if err := json.Unmarshal(raw, &searches); err != nil {
+		return nil, fmt.Errorf("unmarshal saved searches of %s: %w", login, err)
+	}
+	return searches, nil
+}
+
+func (s *savedSearches) write(r *http.Request, login string, searches map[string]string) error {
+	raw, err := json.Marshal(searches)
+	if err != nil {
+		return err
+	}
+	return io.WriteContent(r.Context(), s.log, s.opener, s.pathFor(login), raw)
+}
+
+// validateSavedSearch checks that a search has a name that is stable in
+// URLs and only filters the job list.
+func validateSavedSearch(search savedSearch) error {
+	if !savedSearchNameRe.MatchString(search.Name) {
+		return fmt.Errorf("name %q must be 1-64 letters, digits, '.', '_' or '-'", search.Name)
+	}
+	params, err := url.ParseQuery(search.Query)
+	if err != nil {
+		return fmt.Errorf("invalid query %q: %w", search.Query, err)
+	}
+	if len(params) == 0 {
+		return fmt.Errorf("query %q does not filter anything", search.Query)
+	}
+	for param := range params {
+		if !savedSearchParams[param] {
+			return fmt.Errorf("query %q has unknown parameter %q", search.Query, param)
+		}
+	}
+	return nil
+}
+
+// handle serves the saved searches of the logged in user:
+//
+// GET /saved-searches lists them
+// POST /saved-searches saves the JSON encoded search of the body
+// DELETE /saved-searches?name=<name> deletes one
+func (s *savedSearches) handle() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setHeadersNoCaching(w)
+		login, err := s.login(r)
+		if err != nil {
+			http.Error(w, "Error retrieving GitHub login.", http.StatusUnauthorized)
+			return
+		}
+		log := s.log.WithField("user", login)
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		searches, err := s.read(r, login)
+		if err != nil {
+			log.WithError(err).Error("Failed to read saved searches.")
+			http.Error(w, "Failed to read saved searches.", http.StatusInternalServerError)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			list := savedSearchList{Login: login, Searches: make([]savedSearch, 0, len(searches))}
+			for name, query := range searches {
+				list.Searches = append(list.Searches, savedSearch{Name: name, Query: query})
+			}
+			sort.Slice(list.Searches, func(i, j int) bool { return list.Searches[i].Name < list.Searches[j].Name })
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(list); err != nil {
+				log.WithError(err).Error("Failed to write saved searches.")
+			}
+			return
+		case http.MethodPost:
+			var search savedSearch
+			if err := json.NewDecoder(r.Body).Decode(&search); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid saved search: %v", err), http.StatusBadRequest)
+				return
+			}
+			if err := validateSavedSearch(search); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid saved search: %v", err), http.StatusBadRequest)
+				return
+			}
+			searches[search.Name] = search.Query
+		case http.MethodDelete:
+			name := r.URL.Query().Get("name")
+			if _, ok := searches[name]; !ok {
+				http.Error(w, fmt.Sprintf("No saved search named %q.", name), http.StatusNotFound)
+				return
+			}
+			delete(searches, name)
+		default:
+			http.Error(w, fmt.Sprintf("bad verb %v", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err := s.write(r, login, searches); err != nil {
+			log.WithError(err).Error("Failed to write saved searches.")
+			http.Error(w, "Failed to save searches.", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleShared redirects the stable URL of a saved search to the job list
+// it filters. The URL does not require logging in, so that it can be
+// shared and bookmarked:
+//
+// /s/<login>/<name>
+func (s *savedSearches) handleShared() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		login, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/s/"), "/")
+		if !savedSearchLoginRe.MatchString(login) || !savedSearchNameRe.MatchString(name) {
+			http.NotFound(w, r)
+			return
+		}
+		s.lock.Lock()
+		searches, err := s.read(r, login)
+		s.lock.Unlock()
+		if err != nil {
+			s.log.WithError(err).WithField("user", login).Error("Failed to read saved searches.")
+			http.Error(w, "Failed to read saved searches.", http.StatusInternalServerError)
+			return
+		}
+		query, ok := searches[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.Redirect(w, r, "/?"+query, http.StatusFound)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+func newTestSavedSearches(t *testing.T, login string) *savedSearches {
+	opener, err := io.NewOpener(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("failed to create opener: %v", err)
+	}
+	return &savedSearches{
+		opener: opener,
+		path:   t.TempDir(),
+		login: func(*http.Request) (string, error) {
+			if login == "" {
+				return "", errors.New("not logged in")
+			}
+			return login, nil
+		},
+		log: logrus.WithField("handler", "/saved-searches"),
+	}
+}
+
+func TestValidateSavedSearch(t *testing.T) {
+	testCases := []struct {
+		name        string
+		search      savedSearch
+		expectedErr bool
+	}{
+		{
+			name:   "valid search",
+			search: savedSearch{Name: "failing-periodics", Query: "type=periodic&state=failure&since=24h"},
+		},
+		{
+			name:   "job wildcard",
+			search: savedSearch{Name: "e2e", Query: "job=*e2e*"},
+		},
+		{
+			name:        "name with slash",
+			search:      savedSearch{Name: "our/jobs", Query: "type=periodic"},
+			expectedErr: true,
+		},
+		{
+			name:        "empty name",
+			search:      savedSearch{Query: "type=periodic"},
+			expectedErr: true,
+		},
+		{
+			name:        "empty query",
+			search:      savedSearch{Name: "all"},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown parameter",
+			search:      savedSearch{Name: "rerun", Query: "rerun=gh_redirect"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSavedSearch(tc.search)
+			if err != nil && !tc.expectedErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err == nil && tc.expectedErr {
+				t.Error("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestSavedSearches(t *testing.T) {
+	s := newTestSavedSearches(t, "Alice")
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		if strings.HasPrefix(target, "/s/") {
+			s.handleShared()(rr, req)
+		} else {
+			s.handle()(rr, req)
+		}
+		return rr
+	}
+	expectStatus := func(rr *httptest.ResponseRecorder, code int) {
+		t.Helper()
+		if rr.Code != code {
+			t.Fatalf("expected status %d, got %d: %s", code, rr.Code, rr.Body.String())
+		}
+	}
+
+	expectStatus(serve(http.MethodPost, "/saved-searches", `{"name":"failing-periodics","query":"type=periodic&state=failure"}`), http.StatusNoContent)
+	expectStatus(serve(http.MethodPost, "/saved-searches", `{"name":"e2e","query":"job=*e2e*&since=24h"}`), http.StatusNoContent)
+	expectStatus(serve(http.MethodPost, "/saved-searches", `{"name":"bad name","query":"type=periodic"}`), http.StatusBadRequest)
+
+	rr := serve(http.MethodGet, "/saved-searches", "")
+	expectStatus(rr, http.StatusOK)
+	var list savedSearchList
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal saved searches: %v", err)
+	}
+	expected := savedSearchList{
+		Login: "Alice",
+		Searches: []savedSearch{
+			{Name: "e2e", Query: "job=*e2e*&since=24h"},
+			{Name: "failing-periodics", Query: "type=periodic&state=failure"},
+		},
+	}
+	if diff := cmp.Diff(expected, list); diff != "" {
+		t.Errorf("listed searches differ from expected (-want +got):\n%s", diff)
+	}
+
+	rr = serve(http.MethodGet, "/s/alice/failing-periodics", "")
+	expectStatus(rr, http.StatusFound)
+	if location := rr.Header().Get("Location"); location != "/?type=periodic&state=failure" {
+		t.Errorf("expected redirect to the filtered job list, got %q", location)
+	}
+	expectStatus(serve(http.MethodGet, "/s/alice/unknown", ""), http.StatusNotFound)
+	expectStatus(serve(http.MethodGet, "/s/bob/failing-periodics", ""), http.StatusNotFound)
+	expectStatus(serve(http.MethodGet, "/s/../failing-periodics", ""), http.StatusNotFound)
+
+	expectStatus(serve(http.MethodDelete, "/saved-searches?name=failing-periodics", ""), http.StatusNoContent)
+	expectStatus(serve(http.MethodDelete, "/saved-searches?name=failing-periodics", ""), http.StatusNotFound)
+	expectStatus(serve(http.MethodGet, "/s/alice/failing-periodics", ""), http.StatusNotFound)
+	expectStatus(serve(http.MethodGet, "/s/alice/e2e", ""), http.StatusFound)
+}
+
+func TestSavedSearchesRequireLogin(t *testing.T) {
+	s := newTestSavedSearches(t, "")
+	rr := httptest.NewRecorder()
+	s.handle()(rr, httptest.NewRequest(http.MethodGet, "/saved-searches", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
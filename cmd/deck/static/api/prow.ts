@@ -121,9 +121,59 @@ export interface ProwJobStatus {
   pod_name?: string;
   build_id?: string;
   jenkins_build_id?: string;
+  jenkins_stages?: JenkinsStage[];
+  tekton_task_runs?: TektonTaskRun[];
+  test_summary?: TestSummary;
+  cancellation?: Cancellation;
+  conditions?: Condition[];
   prev_report_states?: { [key: string]: ProwJobState };
 }
 
+// JenkinsStage is the result of a stage of a Jenkins Pipeline build.
+// JenkinsStage mirrors the JenkinsStage struct defined in prow/apis/prowjobs/v1/types.go.
+export interface JenkinsStage {
+  name: string;
+  state: ProwJobState;
+}
+
+// TektonTaskRun is the outcome of a TaskRun of a Tekton PipelineRun.
+// TektonTaskRun mirrors the TektonTaskRun struct defined in prow/apis/prowjobs/v1/types.go.
+export interface TektonTaskRun {
+  name: string;
+  pipeline_task: string;
+  state: ProwJobState;
+  description?: string;
+  results?: { [key: string]: string };
+}
+
+// Condition is a standard Kubernetes condition of a ProwJob.
+// Condition mirrors the Condition struct defined in k8s.io/apimachinery/pkg/apis/meta/v1/types.go.
+export interface Condition {
+  type: string;
+  status: "True" | "False" | "Unknown";
+  observedGeneration?: number;
+  lastTransitionTime: string;
+  reason: string;
+  message: string;
+}
+
+// Cancellation records who aborted a ProwJob and why.
+// Cancellation mirrors the Cancellation struct defined in prow/apis/prowjobs/v1/types.go.
+export interface Cancellation {
+  reason: string;
+  requester?: string;
+  superseded_by?: string;
+}
+
+// TestSummary counts the results of the tests a job ran.
+// TestSummary mirrors the TestSummary struct defined in prow/apis/prowjobs/v1/types.go.
+export interface TestSummary {
+  passed: number;
+  failed: number;
+  skipped: number;
+  failed_tests?: string[];
+}
+
 // PodSpec is a description of a pod.
 // PodSpec mirrors the PodSpec struct defined in k8s.io/api/core/v1
 // Podspec interface only holds containers right now since no other values are used
@@ -0,0 +1,177 @@
+import {showAlert, showToast} from "../common/common";
+import {relativeURL} from "../common/urls";
+
+declare const csrfToken: string;
+
+interface NotificationPreferences {
+  pull_requests: boolean;
+  jobs: string[];
+  repos: string[];
+}
+
+interface FailureNotification {
+  job: string;
+  build_id: string;
+  state: string;
+  repo?: string;
+  pull?: number;
+  author?: string;
+  url: string;
+  finished: string;
+  reasons: string[];
+}
+
+// The feed is refreshed every minute, which is also how often new failures
+// can be notified of.
+const refreshInterval = 60 * 1000;
+const browserNotificationsKey = "notifications-browser";
+
+function login(): void {
+  window.location.href = `${window.location.origin}/github-login?dest=${relativeURL()}`;
+}
+
+function lines(textarea: HTMLTextAreaElement): string[] {
+  return textarea.value.split("\n").map((l) => l.trim()).filter((l) => l !== "");
+}
+
+function describe(failure: FailureNotification): string {
+  const target = failure.pull ? `${failure.repo}#${failure.pull}` : failure.repo || "";
+  return target ? `${failure.job} on ${target}` : failure.job;
+}
+
+function createFailureRow(failure: FailureNotification): HTMLElement {
+  const row = document.createElement("li");
+  row.classList.add("failure", failure.state);
+
+  const link = document.createElement("a");
+  link.href = failure.url;
+  link.textContent = describe(failure);
+  row.appendChild(link);
+
+  const details = document.createElement("div");
+  details.classList.add("failure-details");
+  const reasons: {[reason: string]: string} = {
+    job: "a job you follow",
+    pull_request: "your pull request",
+    repo: "a repo you follow",
+  };
+  const why = failure.reasons.map((r) => reasons[r] || r).join(", ");
+  details.textContent = `${failure.state} · ${new Date(failure.finished).toLocaleString()} · ${why}`;
+  row.appendChild(details);
+  return row;
+}
+
+function redraw(failures: FailureNotification[]): void {
+  const feed = document.getElementById("failure-feed")!;
+  while (feed.firstChild) {
+    feed.removeChild(feed.firstChild);
+  }
+  if (failures.length === 0) {
+    const message = document.createElement("li");
+    message.classList.add("message");
+    message.textContent = "Nothing you follow failed in the last week.";
+    feed.appendChild(message);
+    return;
+  }
+  for (const failure of failures) {
+    feed.appendChild(createFailureRow(failure));
+  }
+}
+
+// notify shows a browser notification for each failure that was not in the
+// feed before, if the user opted in.
+function notify(failures: FailureNotification[], seen: Set<string>): void {
+  const enabled = localStorage.getItem(browserNotificationsKey) === "true" &&
+    "Notification" in window && Notification.permission === "granted";
+  for (const failure of failures) {
+    const key = `${failure.job}/${failure.build_id}`;
+    if (enabled && seen.size > 0 && !seen.has(key)) {
+      const notification = new Notification(`${describe(failure)} failed`, {tag: key});
+      notification.onclick = () => window.open(failure.url);
+    }
+    seen.add(key);
+  }
+}
+
+async function refresh(seen: Set<string>): Promise<void> {
+  const result = await fetch("/notifications/feed");
+  if (result.status === 401) {
+    login();
+    return;
+  }
+  if (!result.ok) {
+    showAlert(await result.text());
+    return;
+  }
+  const failures: FailureNotification[] = await result.json();
+  redraw(failures);
+  notify(failures, seen);
+}
+
+async function loadPreferences(): Promise<void> {
+  const result = await fetch("/notifications/preferences");
+  if (result.status === 401) {
+    login();
+    return;
+  }
+  if (!result.ok) {
+    showAlert(await result.text());
+    return;
+  }
+  const prefs: NotificationPreferences = await result.json();
+  (document.getElementById("follow-pull-requests") as HTMLInputElement).checked = prefs.pull_requests;
+  (document.getElementById("follow-jobs") as HTMLTextAreaElement).value = (prefs.jobs || []).join("\n");
+  (document.getElementById("follow-repos") as HTMLTextAreaElement).value = (prefs.repos || []).join("\n");
+}
+
+async function savePreferences(seen: Set<string>): Promise<void> {
+  const prefs: NotificationPreferences = {
+    jobs: lines(document.getElementById("follow-jobs") as HTMLTextAreaElement),
+    pull_requests: (document.getElementById("follow-pull-requests") as HTMLInputElement).checked,
+    repos: lines(document.getElementById("follow-repos") as HTMLTextAreaElement),
+  };
+  const result = await fetch("/notifications/preferences", {
+    body: JSON.stringify(prefs),
+    headers: {
+      "Content-type": "application/json",
+      "X-CSRF-Token": csrfToken,
+    },
+    method: "PUT",
+  });
+  if (!result.ok) {
+    showAlert(await result.text());
+    return;
+  }
+  showToast("Preferences saved.");
+  // Failures that are only in the feed because of the new preferences are
+  // not new, so they are not notified of.
+  seen.clear();
+  await refresh(seen);
+}
+
+function initBrowserNotifications(): void {
+  const checkbox = document.getElementById("browser-notifications") as HTMLInputElement;
+  if (!("Notification" in window)) {
+    checkbox.disabled = true;
+    return;
+  }
+  checkbox.checked = localStorage.getItem(browserNotificationsKey) === "true" && Notification.permission === "granted";
+  checkbox.onchange = async () => {
+    if (checkbox.checked && Notification.permission !== "granted") {
+      checkbox.checked = await Notification.requestPermission() === "granted";
+    }
+    localStorage.setItem(browserNotificationsKey, String(checkbox.checked));
+  };
+}
+
+window.onload = async () => {
+  const seen = new Set<string>();
+  initBrowserNotifications();
+  document.getElementById("save-preferences")!.onclick = () => savePreferences(seen);
+  const progress = document.querySelector("#loading-progress")!;
+  progress.classList.remove("hidden");
+  await loadPreferences();
+  await refresh(seen);
+  progress.classList.add("hidden");
+  setInterval(() => refresh(seen), refreshInterval);
+};
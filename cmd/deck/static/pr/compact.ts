@@ -0,0 +1,180 @@
+import {PullRequest, UserData} from '../api/pr';
+import {ProwJobList} from '../api/prow';
+import {TideData, TidePool} from '../api/tide';
+import {getCookieByName} from '../common/common';
+import {relativeURL} from "../common/urls";
+import {getFullPRContext, latestPresubmits, UnifiedContext} from "./contexts";
+
+declare const tideData: TideData;
+declare const allBuilds: ProwJobList;
+declare const csrfToken: string;
+
+type CompactState = "succeeded" | "failed" | "pending" | "unknown";
+
+/**
+ * Returns the overall state of the contexts of a PR: failed if any of them
+ * failed, pending if any of them is still running, succeeded otherwise.
+ */
+export function overallState(contexts: UnifiedContext[]): CompactState {
+  if (contexts.length === 0) {
+    return "unknown";
+  }
+  let pending = false;
+  for (const context of contexts) {
+    switch (context.state) {
+      case "failure":
+      case "error":
+        return "failed";
+      case "success":
+        break;
+      default:
+        pending = true;
+    }
+  }
+  return pending ? "pending" : "succeeded";
+}
+
+/**
+ * Returns the status of the PR in the Tide pool of its branch, if it is in
+ * one.
+ */
+export function tideStatus(pr: PullRequest, pools: TidePool[] = []): string {
+  const pool = pools.find((p) => `${p.Org}/${p.Repo}` === pr.Repository.NameWithOwner && p.Branch === pr.BaseRef.Name);
+  if (!pool) {
+    return "Not in a merge pool";
+  }
+  if (pool.Blockers && pool.Blockers.length > 0) {
+    return "Merge pool blocked";
+  }
+  const statuses: [{Number: number}[], string][] = [
+    [pool.Target, "Merging"],
+    [pool.BatchPending, "Testing in batch"],
+    [pool.SuccessPRs, "Ready to merge"],
+    [pool.PendingPRs, "Waiting for tests"],
+    [pool.MissingPRs, "Queued for retest"],
+  ];
+  for (const [prs, status] of statuses) {
+    if (prs && prs.some((p) => p.Number === pr.Number)) {
+      return status;
+    }
+  }
+  return "Not ready to merge";
+}
+
+function createStateIcon(state: CompactState): HTMLElement {
+  const icons: {[key in CompactState]: string} = {
+    failed: "error",
+    pending: "watch_later",
+    succeeded: "check_circle",
+    unknown: "help",
+  };
+  const icon = document.createElement("i");
+  icon.classList.add("material-icons", "compact-state", state);
+  icon.textContent = icons[state];
+  icon.title = `Jobs ${state}`;
+  return icon;
+}
+
+function createPRRow(pr: PullRequest, contexts: UnifiedContext[]): HTMLElement {
+  const state = overallState(contexts);
+  const row = document.createElement("li");
+  row.classList.add("compact-pr", state);
+  row.appendChild(createStateIcon(state));
+
+  const body = document.createElement("div");
+  body.classList.add("compact-pr-body");
+
+  const title = document.createElement("a");
+  title.classList.add("compact-pr-title");
+  title.href = `/github-link?dest=${pr.Repository.NameWithOwner}/pull/${pr.Number}`;
+  title.textContent = pr.Title;
+  body.appendChild(title);
+
+  const subtitle = document.createElement("div");
+  subtitle.classList.add("compact-pr-subtitle");
+  subtitle.textContent = `${pr.Repository.NameWithOwner}#${pr.Number} · ${tideStatus(pr, tideData.Pools)}`;
+  body.appendChild(subtitle);
+
+  // Only the jobs that need attention are listed, linking to their results.
+  const failed = contexts.filter((c) => c.state === "failure" || c.state === "error");
+  const pending = contexts.filter((c) => c.state !== "success" && c.state !== "failure" && c.state !== "error");
+  if (failed.length > 0) {
+    const jobs = document.createElement("ul");
+    jobs.classList.add("compact-pr-jobs");
+    for (const context of failed) {
+      const item = document.createElement("li");
+      if (context.url) {
+        const link = document.createElement("a");
+        link.href = context.url;
+        link.textContent = context.context;
+        item.appendChild(link);
+      } else {
+        item.textContent = context.context;
+      }
+      jobs.appendChild(item);
+    }
+    body.appendChild(jobs);
+  }
+  if (pending.length > 0) {
+    const summary = document.createElement("div");
+    summary.classList.add("compact-pr-subtitle");
+    summary.textContent = `${pending.length} job${pending.length === 1 ? "" : "s"} pending`;
+    body.appendChild(summary);
+  }
+
+  row.appendChild(body);
+  return row;
+}
+
+function createMessage(msg: string): HTMLElement {
+  const el = document.createElement("p");
+  el.classList.add("message");
+  el.textContent = msg;
+  return el;
+}
+
+function redraw(prData: UserData): void {
+  const container = document.querySelector("#pr-container")!;
+  while (container.firstChild) {
+    container.removeChild(container.firstChild);
+  }
+  if (!prData || !prData.Login) {
+    window.location.href = `${window.location.origin}/github-login?dest=${relativeURL()}`;
+    return;
+  }
+  if (!prData.PullRequestsWithContexts || prData.PullRequestsWithContexts.length === 0) {
+    container.appendChild(createMessage("You have no open PRs"));
+    return;
+  }
+  const list = document.createElement("ul");
+  list.classList.add("compact-prs");
+  for (const prWithContext of prData.PullRequestsWithContexts) {
+    const pr = prWithContext.PullRequest;
+    const contexts = getFullPRContext(latestPresubmits(pr, allBuilds.items), prWithContext.Contexts);
+    list.appendChild(createPRRow(pr, contexts));
+  }
+  container.appendChild(list);
+}
+
+window.onload = () => {
+  const progress = document.querySelector("#loading-progress")!;
+  progress.classList.remove("hidden");
+  const request = new XMLHttpRequest();
+  request.onreadystatechange = () => {
+    if (request.readyState === 4 && request.status === 200) {
+      progress.classList.add("hidden");
+      redraw(JSON.parse(request.responseText) as UserData);
+    }
+  };
+  request.onerror = () => {
+    progress.classList.add("hidden");
+    document.querySelector("#pr-container")!.appendChild(createMessage("Failed to load your PRs"));
+  };
+  request.withCredentials = true;
+  request.open("POST", "/pr-data.js", true);
+  request.setRequestHeader("Content-type", "application/x-www-form-urlencoded");
+  request.setRequestHeader("X-CSRF-Token", csrfToken);
+  // The authenticated user is only shown their own open PRs.
+  const query = `is:pr state:open author:${getCookieByName("github_login")}`;
+  request.send(`query=${encodeURIComponent(query)}`);
+};
@@ -0,0 +1,98 @@
+import {Context} from '../api/github';
+import {PullRequest} from '../api/pr';
+import {ProwJob, ProwJobState} from '../api/prow';
+
+export type UnifiedState = ProwJobState | "expected";
+
+export interface UnifiedContext {
+  context: string;
+  description: string;
+  state: UnifiedState;
+  discrepancy: string | null;
+  url?: string;
+}
+
+/**
+ * Returns the latest build of every presubmit that tested the head of the PR.
+ * allBuilds is sorted with the most recent builds first, so only the first
+ * build of each job is kept, as there might be multiple runs of a job.
+ */
+export function latestPresubmits(pr: PullRequest, allBuilds: ProwJob[]): ProwJob[] {
+  const seenJobs: {[key: string]: boolean} = {};
+  const builds: ProwJob[] = [];
+  for (const build of allBuilds) {
+    const {
+      spec: {
+        type = "",
+        job = "",
+        refs: {repo = "", pulls = [], base_ref = ""} = {},
+      },
+    } = build;
+
+    if (type === 'presubmit' &&
+              repo === pr.Repository.NameWithOwner &&
+              base_ref === pr.BaseRef.Name &&
+              pulls.length &&
+              pulls[0].number === pr.Number &&
+              pulls[0].sha === pr.HeadRefOID) {
+      if (!seenJobs[job]) {  // First (latest) build for job.
+        seenJobs[job] = true;
+        builds.push(build);
+      }
+    }
+  }
+  return builds;
+}
+
+/**
+ * GetFullPRContexts gathers build jobs and pr contexts. It firstly takes
+ * all pr contexts and only replaces contexts that have existing Prow Jobs. Tide
+ * context will be omitted from the list.
+ */
+export function getFullPRContext(builds: ProwJob[], contexts: Context[]): UnifiedContext[] {
+  const contextMap: Map<string, UnifiedContext> = new Map();
+  if (contexts) {
+    for (const context of contexts) {
+      if (context.Context === "tide") {
+        continue;
+      }
+      contextMap.set(context.Context, {
+        context: context.Context,
+        description: context.Description,
+        discrepancy: null,
+        state: context.State.toLowerCase() as UnifiedState,
+      });
+    }
+  }
+
+  for (const build of builds) {
+    const {
+      spec: {
+        context = "",
+      },
+      status: {
+        url = "", description = "", state = "",
+      },
+    } = build;
+
+    let discrepancy = null;
+    // If GitHub context exits, check if mismatch or not.
+    if (contextMap.has(context)) {
+      const githubContext = contextMap.get(context)!;
+      // TODO (qhuynh96): ProwJob's states and GitHub contexts states
+      // are not equivalent in some states.
+      if (githubContext.state !== state) {
+        discrepancy = "GitHub context and Prow Job states mismatch";
+      }
+    }
+    contextMap.set(context, {
+      context,
+      description,
+      discrepancy,
+      state,
+      url,
+    });
+  }
+
+  return Array.from(contextMap.values());
+}
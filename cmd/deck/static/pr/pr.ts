@@ -1,26 +1,16 @@
 import dialogPolyfill from "dialog-polyfill";
 
-import {Context} from '../api/github';
 import {Label, PullRequest, UserData} from '../api/pr';
-import {ProwJob, ProwJobList, ProwJobState} from '../api/prow';
+import {ProwJobList} from '../api/prow';
 import {Blocker, TideData, TidePool, TideQuery as ITideQuery} from '../api/tide';
 import {getCookieByName, tidehistory} from '../common/common';
 import {parseQuery, relativeURL} from "../common/urls";
+import {getFullPRContext, latestPresubmits, UnifiedContext} from "./contexts";
 
 declare const tideData: TideData;
 declare const allBuilds: ProwJobList;
 declare const csrfToken: string;
 
-type UnifiedState = ProwJobState | "expected";
-
-interface UnifiedContext {
-  context: string;
-  description: string;
-  state: UnifiedState;
-  discrepancy: string | null;
-  url?: string;
-}
-
 interface ProcessedLabel {
   name: string;
   own: boolean;
@@ -297,8 +287,14 @@ function createSearchCard(): HTMLElement {
     window.location.search = `?query=${  encodeURIComponent(searchQuery)}`;
   });
 
+  const compactBtn = createIcon("smartphone", "Show the compact view of my open pull requests", ["search-button"], true);
+  compactBtn.addEventListener("click", () => {
+    window.location.href = "/pr/compact";
+  });
+
   const actionCtn = document.createElement("div");
   actionCtn.id = "search-action";
+  actionCtn.appendChild(compactBtn);
   actionCtn.appendChild(userBtn);
   actionCtn.appendChild(refBtn);
   actionCtn.appendChild(tidehistory.authorIcon(getCookieByName("github_login")));
@@ -327,59 +323,6 @@ function createSearchCard(): HTMLElement {
   return searchCard;
 }
 
-/**
- * GetFullPRContexts gathers build jobs and pr contexts. It firstly takes
- * all pr contexts and only replaces contexts that have existing Prow Jobs. Tide
- * context will be omitted from the list.
- */
-function getFullPRContext(builds: ProwJob[], contexts: Context[]): UnifiedContext[] {
-  const contextMap: Map<string, UnifiedContext> = new Map();
-  if (contexts) {
-    for (const context of contexts) {
-      if (context.Context === "tide") {
-        continue;
-      }
-      contextMap.set(context.Context, {
-        context: context.Context,
-        description: context.Description,
-        discrepancy: null,
-        state: context.State.toLowerCase() as UnifiedState,
-      });
-    }
-  }
-
-  for (const build of builds) {
-    const {
-      spec: {
-        context = "",
-      },
-      status: {
-        url = "", description = "", state = "",
-      },
-    } = build;
-
-    let discrepancy = null;
-    // If GitHub context exits, check if mismatch or not.
-    if (contextMap.has(context)) {
-      const githubContext = contextMap.get(context)!;
-      // TODO (qhuynh96): ProwJob's states and GitHub contexts states
-      // are not equivalent in some states.
-      if (githubContext.state !== state) {
-        discrepancy = "GitHub context and Prow Job states mismatch";
-      }
-    }
-    contextMap.set(context, {
-      context,
-      description,
-      discrepancy,
-      state,
-      url,
-    });
-  }
-
-  return Array.from(contextMap.values());
-}
-
 /**
  * Loads Pr Status
  */
@@ -399,33 +342,8 @@ function loadPrStatus(prData: UserData): void {
     return;
   }
   for (const prWithContext of prData.PullRequestsWithContexts) {
-    // There might be multiple runs of jobs for a build.
-    // allBuilds is sorted with the most recent builds first, so
-    // we only need to keep the first build for each job name.
     const pr = prWithContext.PullRequest;
-    const seenJobs: {[key: string]: boolean} = {};
-    const builds: ProwJob[] = [];
-    for (const build of allBuilds.items) {
-      const {
-        spec: {
-          type = "",
-          job = "",
-          refs: {repo = "", pulls = [], base_ref = ""} = {},
-        },
-      } = build;
-
-      if (type === 'presubmit' &&
-                repo === pr.Repository.NameWithOwner &&
-                base_ref === pr.BaseRef.Name &&
-                pulls.length &&
-                pulls[0].number === pr.Number &&
-                pulls[0].sha === pr.HeadRefOID) {
-        if (!seenJobs[job]) {  // First (latest) build for job.
-          seenJobs[job] = true;
-          builds.push(build);
-        }
-      }
-    }
+    const builds = latestPresubmits(pr, allBuilds.items);
     const githubContexts = prWithContext.Contexts;
     const contexts = getFullPRContext(builds, githubContexts);
     const validQueries: TideQuery[] = [];
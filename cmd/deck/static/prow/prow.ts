@@ -1,16 +1,31 @@
 import moment from "moment";
-import {ProwJob, ProwJobList, ProwJobState, ProwJobType, Pull} from "../api/prow";
+import {Cancellation, ProwJob, ProwJobList, ProwJobState, ProwJobType, Pull, TestSummary} from "../api/prow";
 import {createAbortProwJobIcon} from "../common/abort";
 import {cell, formatDuration, icon} from "../common/common";
 import {createRerunProwJobIcon} from "../common/rerun";
 import {getParameterByName} from "../common/urls";
 import {FuzzySearch} from './fuzzy-search';
 import {JobHistogram, JobSample} from './histogram';
+import {initSavedSearches} from './saved-searches';
 
 declare const allBuilds: ProwJobList;
 declare const spyglass: boolean;
 declare const rerunCreatesJob: boolean;
 declare const csrfToken: string;
+declare const savedSearchesEnabled: boolean;
+
+// sinceSeconds are the time ranges of the "since" filter.
+const sinceSeconds: {[key: string]: number} = {
+  "1h": 3600,
+  "6h": 6 * 3600,
+  "24h": 24 * 3600,
+  "168h": 7 * 24 * 3600,
+};
+
+function selectSinceFromURL(): void {
+  const since = getParameterByName("since") || "";
+  (document.getElementById("since") as HTMLSelectElement).value = sinceSeconds[since] ? since : "";
+}
 
 function genShortRefKey(baseRef: string, pulls: Pull[] = []) {
   return [baseRef, ...pulls.map((p) => p.number)].filter((n) => n).join(",");
@@ -272,9 +287,11 @@ window.onload = (): void => {
       "job-list",
       Object.keys(optsPopped.jobs).sort());
     redrawOptions(fzPopped, optsPopped);
+    selectSinceFromURL();
     redraw(fzPopped, false);
   });
   // set dropdown based on options from query string
+  selectSinceFromURL();
   const opts = optionsForRepo("");
   const fz = initFuzzySearch(
     "job",
@@ -283,6 +300,9 @@ window.onload = (): void => {
     Object.keys(opts.jobs).sort());
   redrawOptions(fz, opts);
   redraw(fz);
+  if (savedSearchesEnabled) {
+    initSavedSearches(csrfToken);
+  }
 };
 
 function displayFuzzySearchResult(el: HTMLElement, inputContainer: ClientRect | DOMRect): void {
@@ -479,6 +499,10 @@ function redraw(fz: FuzzySearch, pushState = true): void {
   const jobSel = getSelectionFuzzySearch("job", "job-input");
   const stateSel = getSelection("state");
   const clusterSel = getSelection("cluster");
+  const sinceSel = (document.getElementById("since") as HTMLSelectElement).value;
+  if (sinceSel !== "") {
+    args.push(`since=${sinceSel}`);
+  }
 
   if (pushState && window.history && window.history.pushState !== undefined) {
     if (args.length > 0) {
@@ -546,6 +570,9 @@ function redraw(fz: FuzzySearch, pushState = true): void {
     if (!jobSel.test(job)) {
       continue;
     }
+    if (sinceSel !== "" && now - Date.parse(startTime) / 1000 > sinceSeconds[sinceSel]) {
+      continue;
+    }
 
     if (pullSel) {
       if (!pulls.length) {
@@ -609,7 +636,12 @@ function redraw(fz: FuzzySearch, pushState = true): void {
     displayedJob++;
     const r = document.createElement("tr");
     // State column
-    r.appendChild(cell.state(state));
+    const stateCell = cell.state(state);
+    const cancellation = build.status.cancellation;
+    if (state === "aborted" && cancellation) {
+      stateCell.title = describeCancellation(cancellation);
+    }
+    r.appendChild(stateCell);
     // Log column
     r.appendChild(createLogCell(build, buildUrl));
     // Rerun column
@@ -667,11 +699,12 @@ function redraw(fz: FuzzySearch, pushState = true): void {
       r.appendChild(cell.text(''));
     }
     // Results column
-    if (buildUrl === "") {
-      r.appendChild(cell.text(job));
-    } else {
-      r.appendChild(cell.link(job, buildUrl));
+    const resultsCell = buildUrl === "" ? cell.text(job) : cell.link(job, buildUrl);
+    const testSummary = build.status.test_summary;
+    if (testSummary && testSummary.failed > 0) {
+      resultsCell.title = describeTestSummary(testSummary);
     }
+    r.appendChild(resultsCell);
     // Started column
     r.appendChild(cell.time(i.toString(), moment.unix(started)));
     // Duration column
@@ -727,6 +760,45 @@ function redraw(fz: FuzzySearch, pushState = true): void {
   componentHandler.upgradeDom();
 }
 
+// describeTestSummary mirrors TestSummary.String in prow/apis/prowjobs/v1/types.go.
+function describeTestSummary(summary: TestSummary): string {
+  const noun = summary.failed === 1 ? "test" : "tests";
+  const names = summary.failed_tests || [];
+  if (names.length === 0) {
+    return `${summary.failed} ${noun} failed`;
+  }
+  const more = names.length < summary.failed ? ", …" : "";
+  return `${summary.failed} ${noun} failed: ${names.join(", ")}${more}`;
+}
+
+// describeCancellation mirrors Cancellation.String in prow/apis/prowjobs/v1/types.go.
+function describeCancellation(c: Cancellation): string {
+  if (c.reason === "user_action") {
+    return c.requester ? `aborted by ${c.requester}` : "aborted by a user";
+  }
+  let desc: string;
+  switch (c.reason) {
+    case "superseded":
+      desc = "superseded by a newer run";
+      break;
+    case "abandoned":
+      desc = "aborted as the pull request was closed or converted to a draft";
+      break;
+    case "timeout":
+      desc = "aborted after timing out";
+      break;
+    case "quota":
+      desc = "aborted to free up quota";
+      break;
+    case "freeze":
+      desc = "aborted because of a freeze";
+      break;
+    default:
+      desc = `aborted (${c.reason})`;
+  }
+  return c.requester ? `${desc} (requested by ${c.requester})` : desc;
+}
+
 function createAbortCell(modal: HTMLElement, modalContent: Element, job: string, state: ProwJobState, prowjob: string): HTMLTableCellElement {
   const c = document.createElement("td");
   c.appendChild(createAbortProwJobIcon(modal, modalContent, job, state, prowjob, csrfToken));
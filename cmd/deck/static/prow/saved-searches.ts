@@ -0,0 +1,125 @@
+import {copyToClipboard, showAlert, showToast} from "../common/common";
+import {relativeURL} from "../common/urls";
+
+interface SavedSearch {
+  name: string;
+  query: string;
+}
+
+interface SavedSearchList {
+  login: string;
+  searches: SavedSearch[];
+}
+
+function login(): void {
+  window.location.href = `${window.location.origin}/github-login?dest=${relativeURL()}`;
+}
+
+function shareURL(user: string, name: string): string {
+  return `${window.location.origin}/s/${user}/${name}`;
+}
+
+async function request(method: string, csrfToken: string, url: string, body?: string): Promise<Response | undefined> {
+  const result = await fetch(url, {
+    body,
+    headers: {
+      "Content-type": "application/json",
+      "X-CSRF-Token": csrfToken,
+    },
+    method,
+  });
+  if (result.status === 401) {
+    login();
+    return undefined;
+  }
+  if (result.status >= 400) {
+    showAlert(await result.text());
+    return undefined;
+  }
+  return result;
+}
+
+// initSavedSearches shows the saved searches of the logged in user above
+// the job list and lets them save the current filters as a new one.
+export async function initSavedSearches(csrfToken: string): Promise<void> {
+  const container = document.getElementById("saved-searches")!;
+  const select = document.getElementById("saved-search") as HTMLSelectElement;
+  container.classList.remove("hidden");
+
+  let user = "";
+  const result = await fetch("/saved-searches");
+  if (result.ok) {
+    const list: SavedSearchList = await result.json();
+    user = list.login;
+    const current = window.location.search.substr(1);
+    for (const search of list.searches) {
+      const o = document.createElement("option");
+      o.text = search.name;
+      o.value = search.query;
+      o.selected = current !== "" && search.query === current;
+      select.appendChild(o);
+    }
+  }
+
+  select.onchange = () => {
+    if (select.selectedIndex > 0) {
+      window.location.href = `/?${select.value}`;
+    }
+  };
+
+  document.getElementById("save-search")!.onclick = async () => {
+    const query = window.location.search.substr(1);
+    if (query === "") {
+      showAlert("Filter the job list before saving the search.");
+      return;
+    }
+    const name = prompt("Name of the search (letters, digits, '.', '_' and '-'):");
+    if (!name) {
+      return;
+    }
+    const saved = await request("POST", csrfToken, "/saved-searches", JSON.stringify({name, query}));
+    if (saved === undefined) {
+      return;
+    }
+    let option = Array.from(select.options).slice(1).find((o) => o.text === name);
+    if (option === undefined) {
+      option = document.createElement("option");
+      option.text = name;
+      select.appendChild(option);
+    }
+    option.value = query;
+    option.selected = true;
+    if (user !== "") {
+      copyToClipboard(shareURL(user, name));
+      showToast(`Saved "${name}" and copied its link to the clipboard`);
+    } else {
+      showToast(`Saved "${name}"`);
+    }
+  };
+
+  document.getElementById("share-search")!.onclick = () => {
+    if (select.selectedIndex === 0 || user === "") {
+      showAlert("Select a saved search to share.");
+      return;
+    }
+    copyToClipboard(shareURL(user, select.options[select.selectedIndex].text));
+    showToast("Copied to clipboard");
+  };
+
+  document.getElementById("delete-search")!.onclick = async () => {
+    if (select.selectedIndex === 0) {
+      showAlert("Select a saved search to delete.");
+      return;
+    }
+    const name = select.options[select.selectedIndex].text;
+    if (!confirm(`Delete the saved search "${name}"? Links to it will stop working.`)) {
+      return;
+    }
+    const deleted = await request("DELETE", csrfToken, `/saved-searches?name=${encodeURIComponent(name)}`);
+    if (deleted !== undefined) {
+      select.remove(select.selectedIndex);
+      select.selectedIndex = 0;
+      showToast(`Deleted "${name}"`);
+    }
+  };
+}
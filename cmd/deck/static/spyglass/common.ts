@@ -16,6 +16,12 @@ export interface RequestMessage extends BaseMessage {
   data: string;
 }
 
+export interface RequestForBuildMessage extends BaseMessage {
+  type: 'requestForBuild';
+  src: string;
+  data: string;
+}
+
 export interface RequestPageMessage extends BaseMessage {
   type: 'requestPage';
   data: string;
@@ -46,7 +52,7 @@ export function isResponse(data: any): data is Response {
   return isBaseMessage(data) && data.type === 'response';
 }
 
-export type Message = ContentUpdatedMessage | RequestMessage | RequestPageMessage | UpdatePageMessage | UpdateHash | ShowOffset | Response;
+export type Message = ContentUpdatedMessage | RequestMessage | RequestForBuildMessage | RequestPageMessage | UpdatePageMessage | UpdateHash | ShowOffset | Response;
 
 export interface TransitMessage {
   id: number;
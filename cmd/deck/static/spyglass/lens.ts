@@ -32,6 +32,15 @@ export interface Spyglass {
    * recommended, but not required.
    */
   request(data: string): Promise<string>;
+  /**
+   * Sends a request to the server-side lens backend like request(), but for
+   * the artifacts of another build, e.g. a previous run of the same job.
+   *
+   * @param src The source of the other build, i.e. the part of its Spyglass
+   * URL after /view/.
+   * @param data Some data to pass back to the server.
+   */
+  requestForBuild(src: string, data: string): Promise<string>;
   /**
    * Inform Spyglass that the lens content has updated. This should be called whenever
    * the visible content changes, so Spyglass can ensure that all content is visible.
@@ -97,6 +106,10 @@ class SpyglassImpl implements Spyglass {
     const result = await this.postMessage({type: 'request', data});
     return result.data;
   }
+  public async requestForBuild(src: string, data: string): Promise<string> {
+    const result = await this.postMessage({type: 'requestForBuild', src, data});
+    return result.data;
+  }
   public contentUpdated(): void {
     this.updateHeight();
     clearTimeout(this.pendingUpdateTimer);
@@ -28,17 +28,17 @@ function loadLenses(): void {
   }
 }
 
-function queryForLens(lens: string, index: number): string {
+function queryForLens(lens: string, index: number, buildSrc: string = src): string {
   const data = {
     artifacts: lensArtifacts[index],
     index,
-    src,
+    src: buildSrc,
   };
   return `req=${encodeURIComponent(JSON.stringify(data))}`;
 }
 
-function urlForLensRequest(lens: string, index: number, request: string): string {
-  return `/spyglass/lens/${lens}/${request}?${queryForLens(lens, index)}`;
+function urlForLensRequest(lens: string, index: number, request: string, buildSrc: string = src): string {
+  return `/spyglass/lens/${lens}/${request}?${queryForLens(lens, index, buildSrc)}`;
 }
 
 function frameForMessage(e: MessageEvent): HTMLIFrameElement {
@@ -105,6 +105,12 @@ window.addEventListener('message', async (e) => {
         respond(await req.text());
         break;
       }
+      case "requestForBuild": {
+        const req = await fetch(urlForLensRequest(lens, index, 'callback', message.src),
+          getLensRequestOptions(message.data));
+        respond(await req.text());
+        break;
+      }
       case "requestPage": {
         const req = await fetch(urlForLensRequest(lens, index, 'rerender'),
           getLensRequestOptions(message.data));
@@ -170,8 +176,87 @@ window.addEventListener('load', () => {
   loadLenses();
   handleRerunButton();
   handleAbortButton();
+  handleArtifactSearch();
 });
 
+interface SearchMatch {
+  artifact: string;
+  line: number;
+  text: string;
+  link: string;
+}
+
+interface SearchResult {
+  query: string;
+  matches: SearchMatch[];
+  truncated?: boolean;
+  skipped?: string[];
+}
+
+// lensForLine finds the build log lens that shows the given artifact, which
+// can jump to one of its lines.
+function lensForLine(artifact: string): number | undefined {
+  for (const index of lensIndexes) {
+    const frame = document.querySelector<HTMLIFrameElement>(`#iframe-${index}`);
+    if (frame && frame.dataset.lensName === "buildlog" && (lensArtifacts[index] || []).includes(artifact)) {
+      return index;
+    }
+  }
+  return undefined;
+}
+
+function handleArtifactSearch(): void {
+  const form = document.getElementById("artifact-search") as HTMLFormElement;
+  const input = document.getElementById("artifact-search-input") as HTMLInputElement;
+  const status = document.getElementById("artifact-search-status")!;
+  const results = document.getElementById("artifact-search-results")!;
+
+  form.addEventListener("submit", async (e) => {
+    e.preventDefault();
+    const query = input.value;
+    if (query === "") {
+      return;
+    }
+    results.innerHTML = "";
+    status.textContent = "Searching...";
+    const response = await fetch(`/spyglass/search/${src}?q=${encodeURIComponent(query)}`);
+    if (!response.ok) {
+      status.textContent = await response.text();
+      return;
+    }
+    const result: SearchResult = await response.json();
+    let summary = `${result.matches.length} matching lines`;
+    if (result.truncated) {
+      summary += ", stopped searching at the limit";
+    }
+    if (result.skipped && result.skipped.length > 0) {
+      summary += `, skipped ${result.skipped.length} large or binary artifacts`;
+    }
+    status.textContent = summary;
+
+    for (const match of result.matches) {
+      const li = document.createElement("li");
+      const a = document.createElement("a");
+      a.textContent = `${match.artifact}:${match.line}`;
+      const lensIndex = lensForLine(match.artifact);
+      if (lensIndex !== undefined) {
+        a.href = "#";
+        a.addEventListener("click", (event) => {
+          event.preventDefault();
+          updateHash(lensIndex, `#${match.artifact}:${match.line}`);
+          document.getElementById(`iframe-${lensIndex}`)!.scrollIntoView();
+        });
+      } else {
+        a.href = match.link;
+        a.target = "_blank";
+      }
+      li.appendChild(a);
+      li.appendChild(document.createTextNode(`  ${match.text}`));
+      results.appendChild(li);
+    }
+  });
+}
+
 function handleRerunButton() {
   // In case prowJob is unavailable, the rerun button shouldn't be shown
   if (!prowJobName) {
@@ -49,15 +49,19 @@ func getConcreteBrandingFunction(cfg config.Getter) func() config.Branding {
 }
 
 type baseTemplateSections struct {
-	PR   bool
-	Tide bool
+	PR            bool
+	Tide          bool
+	Flakes        bool
+	Notifications bool
 }
 
 func getConcreteSectionFunction(o options) func() baseTemplateSections {
 	return func() baseTemplateSections {
 		return baseTemplateSections{
-			PR:   o.oauthURL != "" || o.pregeneratedData != "",
-			Tide: o.tideURL != "" || o.pregeneratedData != "",
+			PR:            o.oauthURL != "" || o.pregeneratedData != "",
+			Tide:          o.tideURL != "" || o.pregeneratedData != "",
+			Flakes:        o.flakeTrackerURL != "",
+			Notifications: o.notificationsPath != "",
 		}
 	}
 }
@@ -94,6 +94,50 @@ func (pjc prowJobCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 }
 
+var concurrencyDesc = prometheus.NewDesc(
+	"prow_job_concurrency",
+	"Number of triggered and pending prow jobs by org and build cluster.",
+	[]string{"org", "cluster", "state"}, nil,
+)
+
+// prowJobConcurrencyCollector counts the jobs that are waiting to be scheduled
+// or running, so that the capacity consumed by each org can be told apart.
+type prowJobConcurrencyCollector struct {
+	lister lister
+}
+
+func (c prowJobConcurrencyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- concurrencyDesc
+}
+
+func (c prowJobConcurrencyCollector) Collect(ch chan<- prometheus.Metric) {
+	prowJobs, err := c.lister.List(labels.Everything())
+	if err != nil {
+		logrus.WithError(err).Error("Failed to list prow jobs")
+		return
+	}
+	type key struct {
+		org, cluster string
+		state        prowapi.ProwJobState
+	}
+	counts := map[key]int{}
+	for _, pj := range prowJobs {
+		if pj.Status.State != prowapi.TriggeredState && pj.Status.State != prowapi.PendingState {
+			continue
+		}
+		var org string
+		if pj.Spec.Refs != nil {
+			org = pj.Spec.Refs.Org
+		} else if len(pj.Spec.ExtraRefs) > 0 {
+			org = pj.Spec.ExtraRefs[0].Org
+		}
+		counts[key{org: org, cluster: pj.ClusterAlias(), state: pj.Status.State}]++
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(concurrencyDesc, prometheus.GaugeValue, float64(count), k.org, k.cluster, string(k.state))
+	}
+}
+
 func getLatest(jobs []*prowapi.ProwJob) map[string]*prowapi.ProwJob {
 	latest := map[string]time.Time{}
 	latestJobs := map[string]*prowapi.ProwJob{}
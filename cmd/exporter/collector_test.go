@@ -18,6 +18,7 @@ package main
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/sirupsen/logrus"
 
@@ -459,3 +461,41 @@ func TestGetLatest(t *testing.T) {
 		})
 	}
 }
+
+type stateLister []*prowapi.ProwJob
+
+func (l stateLister) List(selector labels.Selector) ([]*prowapi.ProwJob, error) {
+	return l, nil
+}
+
+func TestProwJobConcurrencyCollector(t *testing.T) {
+	job := func(org, cluster string, state prowapi.ProwJobState) *prowapi.ProwJob {
+		pj := &prowapi.ProwJob{
+			Spec:   prowapi.ProwJobSpec{Cluster: cluster},
+			Status: prowapi.ProwJobStatus{State: state},
+		}
+		if org != "" {
+			pj.Spec.ExtraRefs = []prowapi.Refs{{Org: org}}
+		}
+		return pj
+	}
+	c := prowJobConcurrencyCollector{lister: stateLister{
+		job("kubernetes", "", prowapi.PendingState),
+		job("kubernetes", "default", prowapi.PendingState),
+		job("kubernetes", "", prowapi.TriggeredState),
+		job("kubernetes", "", prowapi.SuccessState),
+		job("openshift", "build01", prowapi.PendingState),
+		job("", "", prowapi.PendingState),
+	}}
+	expected := `
+# HELP prow_job_concurrency Number of triggered and pending prow jobs by org and build cluster.
+# TYPE prow_job_concurrency gauge
+prow_job_concurrency{cluster="build01",org="openshift",state="pending"} 1
+prow_job_concurrency{cluster="default",org="",state="pending"} 1
+prow_job_concurrency{cluster="default",org="kubernetes",state="pending"} 2
+prow_job_concurrency{cluster="default",org="kubernetes",state="triggered"} 1
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected)); err != nil {
+		t.Errorf("unexpected metrics: %v", err)
+	}
+}
@@ -64,9 +64,14 @@ func (o *options) Validate() error {
 
 func mustRegister(component string, lister lister) *prometheus.Registry {
 	registry := prometheus.NewRegistry()
-	prometheus.WrapRegistererWith(prometheus.Labels{"collector_name": component}, registry).MustRegister(&prowJobCollector{
-		lister: lister,
-	})
+	prometheus.WrapRegistererWith(prometheus.Labels{"collector_name": component}, registry).MustRegister(
+		&prowJobCollector{
+			lister: lister,
+		},
+		&prowJobConcurrencyCollector{
+			lister: lister,
+		},
+	)
 	registry.MustRegister(
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 		collectors.NewGoCollector(),
@@ -102,7 +107,10 @@ func main() {
 	go informerFactory.Start(interrupts.Context().Done())
 
 	registry := mustRegister("exporter", pjLister)
-	registry.MustRegister(prowjobs.NewProwJobLifecycleHistogramVec(informerFactory.Prow().V1().ProwJobs().Informer()))
+	registry.MustRegister(
+		prowjobs.NewProwJobLifecycleHistogramVec(informerFactory.Prow().V1().ProwJobs().Informer()),
+		prowjobs.NewProwJobQueueTimeCollector(informerFactory.Prow().V1().ProwJobs().Informer()),
+	)
 
 	// Expose prometheus metrics
 	metrics.ExposeMetricsWithRegistry("exporter", cfg().PushGateway, o.instrumentationOptions.MetricsPort, registry, nil)
@@ -0,0 +1,353 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin implements the review-sla external plugin, which
+// periodically pings the reviewers of PRs that have been awaiting review for
+// longer than the SLA of their repository.
+package plugin
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/repoowners"
+)
+
+const (
+	// PluginName is the name of this plugin
+	PluginName = "review-sla"
+
+	levelNone      = 0
+	levelReviewers = 1
+	levelEscalated = 2
+)
+
+var (
+	// The marker records the level of a ping, so that every level is only
+	// pinged once while a PR awaits review.
+	markerRe      = regexp.MustCompile(`<!-- review-sla: level (\d) -->`)
+	pullURLRe     = regexp.MustCompile(`/([^/]+)/([^/]+)/pull/(\d+)$`)
+	skipLabels    = []string{labels.LGTM, labels.Hold, labels.WorkInProgress}
+	statusByLevel = map[int]string{levelNone: "within_sla", levelReviewers: "breached", levelEscalated: "escalated"}
+)
+
+var (
+	prsAwaitingReview = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "review_sla_prs_awaiting_review",
+		Help: "Number of open PRs awaiting review by whether they are within their review SLA, breached it or were escalated.",
+	}, []string{"org", "repo", "status"})
+	oldestWait = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "review_sla_oldest_wait_seconds",
+		Help: "How long the PR that has been awaiting review the longest has been waiting in seconds.",
+	}, []string{"org", "repo"})
+	pings = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_sla_pings_total",
+		Help: "Number of times reviewers were pinged because a PR breached its review SLA, by level.",
+	}, []string{"org", "repo", "level"})
+)
+
+func init() {
+	prometheus.MustRegister(prsAwaitingReview, oldestWait, pings)
+}
+
+type githubClient interface {
+	BotUserChecker() (func(candidate string) bool, error)
+	FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error)
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	ListReviews(org, repo string, number int) ([]github.Review, error)
+	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
+	CreateComment(org, repo string, number int, comment string) error
+}
+
+type ownersClient interface {
+	LoadRepoOwners(org, repo, base string) (repoowners.RepoOwner, error)
+}
+
+// HelpProvider constructs the PluginHelp for this plugin that takes into account enabled repositories.
+func HelpProvider(_ []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		ReviewSLA: []plugins.ReviewSLA{
+			{
+				Repos:         []string{"kubernetes/test-infra"},
+				SLA:           "48h",
+				EscalationSLA: "120h",
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: "The review-sla plugin periodically pings the requested reviewers and assignees of open PRs that have been awaiting review for longer than the review SLA of their repository. " +
+			"Once a PR has been waiting for longer than the escalation SLA, the approvers of the parent OWNERS files of its changes are pinged as well. " +
+			"A PR awaits review from its creation or the latest review by someone other than its author, unless it is labeled with `" + strings.Join(skipLabels, "`, `") + "`.",
+		Snippet: yamlSnippet,
+	}, nil
+}
+
+// HandleAll checks all open PRs of the repos that have a review SLA and pings
+// the reviewers of the ones that breached it.
+func HandleAll(log *logrus.Entry, ghc githubClient, oc ownersClient, config *plugins.Configuration, now time.Time) error {
+	isBot, err := ghc.BotUserChecker()
+	if err != nil {
+		return fmt.Errorf("failed to get the bot user checker: %w", err)
+	}
+
+	prsAwaitingReview.Reset()
+	oldestWait.Reset()
+	oldest := map[[2]string]time.Duration{}
+	seen := sets.New[string]()
+	var errs []error
+	for _, sla := range config.ReviewSLA {
+		for _, orgRepo := range sla.Repos {
+			org, query := orgRepo, fmt.Sprintf("is:pr is:open draft:false org:%s", orgRepo)
+			if strings.Contains(orgRepo, "/") {
+				org, query = strings.Split(orgRepo, "/")[0], fmt.Sprintf("is:pr is:open draft:false repo:%s", orgRepo)
+			}
+			issues, err := ghc.FindIssuesWithOrg(org, query, "created", true)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to search the PRs of %s: %w", orgRepo, err))
+				continue
+			}
+			for _, issue := range issues {
+				match := pullURLRe.FindStringSubmatch(issue.HTMLURL)
+				if match == nil || seen.Has(issue.HTMLURL) {
+					continue
+				}
+				seen.Insert(issue.HTMLURL)
+				org, repo := match[1], match[2]
+				number, _ := strconv.Atoi(match[3])
+				l := log.WithFields(logrus.Fields{"org": org, "repo": repo, "pr": number})
+				waiting, err := handle(l, ghc, oc, isBot, optionsForRepo(config, org, repo), org, repo, number, now)
+				if err != nil {
+					l.WithError(err).Error("Error handling PR.")
+				}
+				if key := [2]string{org, repo}; waiting > oldest[key] {
+					oldest[key] = waiting
+				}
+			}
+		}
+	}
+	for key, waiting := range oldest {
+		oldestWait.WithLabelValues(key[0], key[1]).Set(waiting.Seconds())
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// handle pings the reviewers of the PR if it breached its review SLA and
+// returns how long it has been awaiting review.
+func handle(log *logrus.Entry, ghc githubClient, oc ownersClient, isBot func(string) bool, sla *plugins.ReviewSLA, org, repo string, number int, now time.Time) (time.Duration, error) {
+	pr, err := ghc.GetPullRequest(org, repo, number)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the PR: %w", err)
+	}
+	if pr.State != github.PullRequestStateOpen || pr.Draft {
+		return 0, nil
+	}
+	for _, label := range skipLabels {
+		if github.HasLabel(label, pr.Labels) {
+			return 0, nil
+		}
+	}
+
+	reviews, err := ghc.ListReviews(org, repo, number)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list the reviews: %w", err)
+	}
+	since := awaitingReviewSince(pr, reviews)
+	waiting := now.Sub(since)
+	level := slaLevel(sla, waiting)
+
+	prsAwaitingReview.WithLabelValues(org, repo, statusByLevel[level]).Inc()
+	if level == levelNone {
+		return waiting, nil
+	}
+
+	comments, err := ghc.ListIssueComments(org, repo, number)
+	if err != nil {
+		return waiting, fmt.Errorf("failed to list the comments: %w", err)
+	}
+	if pingedLevel(comments, isBot, since) >= level {
+		return waiting, nil
+	}
+
+	reviewers := sets.New[string]()
+	for _, user := range append(pr.RequestedReviewers, pr.Assignees...) {
+		reviewers.Insert(github.NormLogin(user.Login))
+	}
+	reviewers.Delete(github.NormLogin(pr.User.Login))
+
+	var msg string
+	if level == levelEscalated {
+		escalation, err := parentApprovers(ghc, oc, pr)
+		if err != nil {
+			return waiting, err
+		}
+		escalation = escalation.Difference(reviewers)
+		escalation.Delete(github.NormLogin(pr.User.Login))
+		msg = fmt.Sprintf("This PR has been awaiting review for %s, which is longer than the escalation SLA of %s.", formatDuration(waiting), formatDuration(sla.EscalationSLADuration))
+		if reviewers.Len() > 0 {
+			msg += fmt.Sprintf(" It is still waiting for %s.", mentions(reviewers))
+		}
+		if escalation.Len() > 0 {
+			msg += fmt.Sprintf("\n\n%s: as approvers of the parent OWNERS files of its changes, could you help getting it reviewed?", mentions(escalation))
+		}
+	} else {
+		if reviewers.Len() == 0 {
+			msg = fmt.Sprintf("This PR has been awaiting review for %s, which is longer than the review SLA of %s, but it has no requested reviewers or assignees. Please request a review.", formatDuration(waiting), formatDuration(sla.SLADuration))
+		} else {
+			msg = fmt.Sprintf("%s: this PR has been awaiting review for %s, which is longer than the review SLA of %s. PTAL.", mentions(reviewers), formatDuration(waiting), formatDuration(sla.SLADuration))
+		}
+	}
+	msg += fmt.Sprintf("\n\n<!-- review-sla: level %d -->", level)
+
+	log.WithField("level", level).Info("Pinging reviewers of PR that breached its review SLA.")
+	if err := ghc.CreateComment(org, repo, number, plugins.FormatSimpleResponse(msg)); err != nil {
+		return waiting, fmt.Errorf("failed to comment: %w", err)
+	}
+	pings.WithLabelValues(org, repo, strconv.Itoa(level)).Inc()
+	return waiting, nil
+}
+
+// awaitingReviewSince returns when the PR started awaiting review, which is
+// when it was created or, if later, the latest review by someone other than
+// its author.
+func awaitingReviewSince(pr *github.PullRequest, reviews []github.Review) time.Time {
+	since := pr.CreatedAt
+	for _, review := range reviews {
+		if github.NormLogin(review.User.Login) == github.NormLogin(pr.User.Login) {
+			continue
+		}
+		if review.SubmittedAt.After(since) {
+			since = review.SubmittedAt
+		}
+	}
+	return since
+}
+
+func slaLevel(sla *plugins.ReviewSLA, waiting time.Duration) int {
+	switch {
+	case sla.EscalationSLADuration > 0 && waiting >= sla.EscalationSLADuration:
+		return levelEscalated
+	case sla.SLADuration > 0 && waiting >= sla.SLADuration:
+		return levelReviewers
+	default:
+		return levelNone
+	}
+}
+
+// pingedLevel returns the highest level the bot pinged at since the PR
+// started awaiting review.
+func pingedLevel(comments []github.IssueComment, isBot func(string) bool, since time.Time) int {
+	level := levelNone
+	for _, comment := range comments {
+		if !isBot(comment.User.Login) || comment.CreatedAt.Before(since) {
+			continue
+		}
+		if match := markerRe.FindStringSubmatch(comment.Body); match != nil {
+			if l, _ := strconv.Atoi(match[1]); l > level {
+				level = l
+			}
+		}
+	}
+	return level
+}
+
+// parentApprovers returns the approvers of the OWNERS files above the ones
+// owning the changes of the PR. OWNERS files with `no_parent_owners` are not
+// escalated.
+func parentApprovers(ghc githubClient, oc ownersClient, pr *github.PullRequest) (sets.Set[string], error) {
+	org, repo := pr.Base.Repo.Owner.Login, pr.Base.Repo.Name
+	owners, err := oc.LoadRepoOwners(org, repo, pr.Base.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the OWNERS of %s/%s: %w", org, repo, err)
+	}
+	changes, err := ghc.GetPullRequestChanges(org, repo, pr.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the changes of the PR: %w", err)
+	}
+	approvers := sets.New[string]()
+	for _, change := range changes {
+		ownersDir := owners.FindApproverOwnersForFile(change.Filename)
+		if ownersDir == "" || ownersDir == "." || owners.IsNoParentOwners(ownersDir) {
+			continue
+		}
+		approvers = approvers.Union(owners.LeafApprovers(path.Dir(ownersDir)))
+	}
+	return approvers, nil
+}
+
+func mentions(logins sets.Set[string]) string {
+	var ms []string
+	for _, login := range sets.List(logins) {
+		ms = append(ms, "@"+login)
+	}
+	sort.Strings(ms)
+	return strings.Join(ms, " ")
+}
+
+// formatDuration formats a duration in days and hours, e.g. 2d5h.
+func formatDuration(d time.Duration) string {
+	hours := int(d.Round(time.Hour).Hours())
+	switch {
+	case hours < 24:
+		return fmt.Sprintf("%dh", hours)
+	case hours%24 == 0:
+		return fmt.Sprintf("%dd", hours/24)
+	default:
+		return fmt.Sprintf("%dd%dh", hours/24, hours%24)
+	}
+}
+
+// optionsForRepo gets the plugins.ReviewSLA struct that is applicable to the
+// indicated repo.
+func optionsForRepo(config *plugins.Configuration, org, repo string) *plugins.ReviewSLA {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+
+	// First search for repo config
+	for _, c := range config.ReviewSLA {
+		if !sets.New[string](c.Repos...).Has(fullName) {
+			continue
+		}
+		return &c
+	}
+
+	// If you don't find anything, loop again looking for an org config
+	for _, c := range config.ReviewSLA {
+		if !sets.New[string](c.Repos...).Has(org) {
+			continue
+		}
+		return &c
+	}
+
+	return &plugins.ReviewSLA{}
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/repoowners"
+)
+
+type fakeRepoOwners struct {
+	repoowners.RepoOwner
+}
+
+func (fakeRepoOwners) FindApproverOwnersForFile(path string) string {
+	if strings.HasPrefix(path, "pkg/sub/") {
+		return "pkg/sub"
+	}
+	if strings.HasPrefix(path, "isolated/") {
+		return "isolated"
+	}
+	return ""
+}
+
+func (fakeRepoOwners) IsNoParentOwners(path string) bool {
+	return path == "isolated"
+}
+
+func (fakeRepoOwners) LeafApprovers(path string) sets.Set[string] {
+	if path == "pkg" {
+		return sets.New[string]("parent-approver", "reviewer")
+	}
+	return sets.New[string]()
+}
+
+type fakeOwnersClient struct{}
+
+func (fakeOwnersClient) LoadRepoOwners(org, repo, base string) (repoowners.RepoOwner, error) {
+	return fakeRepoOwners{}, nil
+}
+
+// fakeClient returns the PRs of the fake as search results.
+type fakeClient struct {
+	*fakegithub.FakeClient
+}
+
+func (f fakeClient) FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error) {
+	var issues []github.Issue
+	for _, pr := range f.PullRequests {
+		issues = append(issues, github.Issue{Number: pr.Number, HTMLURL: pr.HTMLURL, PullRequest: &struct{}{}})
+	}
+	return issues, nil
+}
+
+func TestHandle(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	sla := &plugins.ReviewSLA{SLADuration: 48 * time.Hour, EscalationSLADuration: 120 * time.Hour}
+
+	testCases := []struct {
+		name             string
+		age              time.Duration
+		labels           []string
+		reviewers        []string
+		reviews          []github.Review
+		comments         []github.IssueComment
+		changes          []string
+		expectedWaiting  time.Duration
+		expectedComment  []string
+		expectNoComments bool
+	}{
+		{
+			name:             "PR within its SLA",
+			age:              24 * time.Hour,
+			reviewers:        []string{"reviewer"},
+			expectedWaiting:  24 * time.Hour,
+			expectNoComments: true,
+		},
+		{
+			name:            "PR breaching its SLA pings the reviewers and assignees",
+			age:             50 * time.Hour,
+			reviewers:       []string{"reviewer"},
+			expectedWaiting: 50 * time.Hour,
+			expectedComment: []string{"@assignee @reviewer: this PR has been awaiting review for 2d2h, which is longer than the review SLA of 2d.", "<!-- review-sla: level 1 -->"},
+		},
+		{
+			name:             "reviewers are only pinged once",
+			age:              50 * time.Hour,
+			reviewers:        []string{"reviewer"},
+			comments:         []github.IssueComment{{User: github.User{Login: fakegithub.Bot}, Body: "<!-- review-sla: level 1 -->", CreatedAt: now.Add(-time.Hour)}},
+			expectedWaiting:  50 * time.Hour,
+			expectNoComments: true,
+		},
+		{
+			name:      "pings before the latest review do not count",
+			age:       100 * time.Hour,
+			reviewers: []string{"reviewer"},
+			reviews:   []github.Review{{User: github.User{Login: "reviewer"}, SubmittedAt: now.Add(-49 * time.Hour)}},
+			comments: []github.IssueComment{
+				{User: github.User{Login: fakegithub.Bot}, Body: "<!-- review-sla: level 1 -->", CreatedAt: now.Add(-52 * time.Hour)},
+				{User: github.User{Login: "someone"}, Body: "<!-- review-sla: level 1 -->", CreatedAt: now.Add(-time.Hour)},
+			},
+			expectedWaiting: 49 * time.Hour,
+			expectedComment: []string{"<!-- review-sla: level 1 -->"},
+		},
+		{
+			name:            "reviews by the author do not count",
+			age:             50 * time.Hour,
+			reviews:         []github.Review{{User: github.User{Login: "author"}, SubmittedAt: now.Add(-time.Hour)}},
+			expectedWaiting: 50 * time.Hour,
+			expectedComment: []string{"@assignee: this PR"},
+		},
+		{
+			name:             "PRs with the lgtm label are not awaiting review",
+			age:              200 * time.Hour,
+			labels:           []string{labels.LGTM},
+			expectNoComments: true,
+		},
+		{
+			name:            "PR breaching its escalation SLA pings the parent approvers",
+			age:             130 * time.Hour,
+			reviewers:       []string{"reviewer"},
+			comments:        []github.IssueComment{{User: github.User{Login: fakegithub.Bot}, Body: "<!-- review-sla: level 1 -->", CreatedAt: now.Add(-80 * time.Hour)}},
+			changes:         []string{"pkg/sub/file.go", "isolated/file.go", "README.md"},
+			expectedWaiting: 130 * time.Hour,
+			expectedComment: []string{
+				"longer than the escalation SLA of 5d. It is still waiting for @assignee @reviewer.",
+				"@parent-approver: as approvers of the parent OWNERS files",
+				"<!-- review-sla: level 2 -->",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			pr := &github.PullRequest{
+				Number:    1,
+				State:     github.PullRequestStateOpen,
+				User:      github.User{Login: "author"},
+				Assignees: []github.User{{Login: "assignee"}, {Login: "author"}},
+				CreatedAt: now.Add(-tc.age),
+				Base:      github.PullRequestBranch{Ref: "main", Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo"}},
+			}
+			for _, label := range tc.labels {
+				pr.Labels = append(pr.Labels, github.Label{Name: label})
+			}
+			for _, reviewer := range tc.reviewers {
+				pr.RequestedReviewers = append(pr.RequestedReviewers, github.User{Login: reviewer})
+			}
+			fc.PullRequests[1] = pr
+			fc.Reviews[1] = tc.reviews
+			fc.IssueComments[1] = tc.comments
+			for _, change := range tc.changes {
+				fc.PullRequestChanges[1] = append(fc.PullRequestChanges[1], github.PullRequestChange{Filename: change})
+			}
+			isBot, _ := fc.BotUserChecker()
+
+			waiting, err := handle(logrus.WithField("plugin", PluginName), fc, fakeOwnersClient{}, isBot, sla, "org", "repo", 1, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if waiting != tc.expectedWaiting {
+				t.Errorf("expected waiting %v, got %v", tc.expectedWaiting, waiting)
+			}
+			newComments := fc.IssueComments[1][len(tc.comments):]
+			if tc.expectNoComments {
+				if len(newComments) != 0 {
+					t.Errorf("expected no comment, got %v", newComments)
+				}
+				return
+			}
+			if len(newComments) != 1 {
+				t.Fatalf("expected one comment, got %v", newComments)
+			}
+			for _, s := range tc.expectedComment {
+				if !strings.Contains(newComments[0].Body, s) {
+					t.Errorf("expected comment to contain %q, got %s", s, newComments[0].Body)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleAll(t *testing.T) {
+	now := time.Now()
+	fc := fakegithub.NewFakeClient()
+	fc.PullRequests[1] = &github.PullRequest{
+		Number:             1,
+		HTMLURL:            "https://github.com/org/repo/pull/1",
+		State:              github.PullRequestStateOpen,
+		User:               github.User{Login: "author"},
+		RequestedReviewers: []github.User{{Login: "reviewer"}},
+		CreatedAt:          now.Add(-72 * time.Hour),
+	}
+	fc.PullRequests[2] = &github.PullRequest{
+		Number:    2,
+		HTMLURL:   "https://github.com/org/repo/pull/2",
+		State:     github.PullRequestStateOpen,
+		Draft:     true,
+		CreatedAt: now.Add(-72 * time.Hour),
+	}
+	config := &plugins.Configuration{ReviewSLA: []plugins.ReviewSLA{
+		{Repos: []string{"org"}, SLA: "96h", SLADuration: 96 * time.Hour},
+		{Repos: []string{"org/repo"}, SLA: "48h", SLADuration: 48 * time.Hour},
+	}}
+
+	pings.Reset()
+	if err := HandleAll(logrus.WithField("plugin", PluginName), fakeClient{fc}, fakeOwnersClient{}, config, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The PR is found by both configs, but only the repo config applies.
+	if n := len(fc.IssueComments[1]); n != 1 {
+		t.Errorf("expected one ping of PR 1, got %d", n)
+	}
+	if n := len(fc.IssueComments[2]); n != 0 {
+		t.Errorf("expected no ping of the draft PR, got %d", n)
+	}
+	if got := testutil.ToFloat64(prsAwaitingReview.WithLabelValues("org", "repo", "breached")); got != 1 {
+		t.Errorf("expected one breached PR, got %v", got)
+	}
+	if got := testutil.ToFloat64(pings.WithLabelValues("org", "repo", "1")); got != 1 {
+		t.Errorf("expected one ping, got %v", got)
+	}
+	if got := testutil.ToFloat64(oldestWait.WithLabelValues("org", "repo")); got < (72 * time.Hour).Seconds() {
+		t.Errorf("expected the oldest wait to be at least 72h, got %vs", got)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, expected := range map[time.Duration]string{
+		5 * time.Hour:                 "5h",
+		48 * time.Hour:                "2d",
+		50*time.Hour + 20*time.Minute: "2d2h",
+		23*time.Hour + 40*time.Minute: "1d",
+	} {
+		if got := formatDuration(d); got != expected {
+			t.Errorf("expected %v to be formatted as %s, got %s", d, expected, got)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// flake-tracker mines the junit results and outcomes of finished ProwJobs
+// into a database of flaky tests. It serves the database to Deck and the
+// trigger plugin and can comment on pull requests that failed because of
+// known flakes.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	"sigs.k8s.io/prow/pkg/flaketracker"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+)
+
+type options struct {
+	config                 configflagutil.ConfigOptions
+	kubernetes             prowflagutil.KubernetesOptions
+	github                 prowflagutil.GitHubOptions
+	storage                prowflagutil.StorageClientOptions
+	instrumentationOptions prowflagutil.InstrumentationOptions
+
+	port       int
+	dryRun     bool
+	dbPath     string
+	window     time.Duration
+	syncPeriod time.Duration
+	minFlakes  int
+	minRate    float64
+	report     bool
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	o := options{config: configflagutil.ConfigOptions{ConfigPath: "/etc/config/config.yaml"}}
+	fs.IntVar(&o.port, "port", 8888, "Port to serve the flake database on.")
+	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to comment on pull requests.")
+	fs.StringVar(&o.dbPath, "db-path", "", "Storage path the flake database is persisted to, e.g. gs://bucket/flake-tracker.json. Kept in memory only if unset.")
+	fs.DurationVar(&o.window, "window", 14*24*time.Hour, "How long the results of a build are considered.")
+	fs.DurationVar(&o.syncPeriod, "sync-period", 5*time.Minute, "How often finished ProwJobs are mined.")
+	fs.IntVar(&o.minFlakes, "min-flakes", 2, "Minimum number of commits a test must have flaked on to be a known flake.")
+	fs.Float64Var(&o.minRate, "min-flake-rate", 0, "Minimum flake rate of a test to be a known flake.")
+	fs.BoolVar(&o.report, "report", false, "Whether to maintain a flake-report comment on pull requests that failed because of known flakes.")
+	for _, group := range []prowflagutil.OptionGroup{&o.config, &o.kubernetes, &o.github, &o.storage, &o.instrumentationOptions} {
+		group.AddFlags(fs)
+	}
+	fs.Parse(args)
+	return o
+}
+
+func (o *options) Validate() error {
+	groups := []prowflagutil.OptionGroup{&o.config, &o.kubernetes, &o.storage, &o.instrumentationOptions}
+	if o.report {
+		groups = append(groups, &o.github)
+	}
+	for _, group := range groups {
+		if err := group.Validate(o.dryRun); err != nil {
+			return err
+		}
+	}
+	if o.window <= 0 || o.syncPeriod <= 0 {
+		return errors.New("--window and --sync-period must be positive")
+	}
+	if o.minFlakes < 1 {
+		return errors.New("--min-flakes must be at least 1")
+	}
+	if o.minRate < 0 || o.minRate > 1 {
+		return errors.New("--min-flake-rate must be between 0 and 1")
+	}
+	return nil
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options")
+	}
+
+	defer interrupts.WaitForGracefulShutdown()
+
+	pprof.Instrument(o.instrumentationOptions)
+
+	configAgent, err := o.config.ConfigAgent()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error starting config agent.")
+	}
+	cfg := configAgent.Config
+
+	metrics.ExposeMetrics("flake-tracker", cfg().PushGateway, o.instrumentationOptions.MetricsPort)
+
+	restCfg, err := o.kubernetes.InfrastructureClusterConfig(o.dryRun)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to get infrastructure cluster config")
+	}
+	client, err := ctrlruntimeclient.New(restCfg, ctrlruntimeclient.Options{})
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to construct client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	opener, err := o.storage.StorageClient(ctx)
+	if err != nil {
+		logrus.WithError(err).Fatal("Cannot create opener")
+	}
+
+	store := flaketracker.NewStore(o.window)
+	if o.dbPath != "" {
+		if err := store.Load(ctx, opener, o.dbPath); err != nil {
+			logrus.WithError(err).Fatal("Error loading the flake database.")
+		}
+	}
+	thresholds := flaketracker.Thresholds{MinFlakes: o.minFlakes, MinRate: o.minRate}
+
+	var reporter *flaketracker.Reporter
+	if o.report {
+		githubClient, err := o.github.GitHubClient(o.dryRun)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting GitHub client.")
+		}
+		reporter = flaketracker.NewReporter(githubClient, store, thresholds)
+	}
+
+	miner := flaketracker.NewMiner(client, cfg, opener, store)
+	lastSync := time.Now().Add(-o.syncPeriod)
+	interrupts.TickLiteral(func() {
+		start := time.Now()
+		if err := miner.Sync(interrupts.Context()); err != nil {
+			logrus.WithError(err).Error("Error mining finished ProwJobs.")
+		}
+		if o.dbPath != "" {
+			if err := store.Save(interrupts.Context(), opener, o.dbPath); err != nil {
+				logrus.WithError(err).Error("Error saving the flake database.")
+			}
+		}
+		if reporter != nil {
+			if err := reporter.Report(lastSync); err != nil {
+				logrus.WithError(err).Error("Error reporting known flakes.")
+			}
+		}
+		lastSync = start
+		logrus.WithField("duration", time.Since(start).String()).Info("Synced flake database.")
+	}, o.syncPeriod)
+
+	server := &http.Server{Addr: ":" + strconv.Itoa(o.port), Handler: flaketracker.NewServer(store, thresholds)}
+	interrupts.ListenAndServe(server, 5*time.Second)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	cases := []struct {
+		name      string
+		args      []string
+		expectErr bool
+	}{
+		{
+			name: "defaults are valid",
+		},
+		{
+			name: "thresholds can be set",
+			args: []string{"--min-flakes=3", "--min-flake-rate=0.1"},
+		},
+		{
+			name:      "min flakes must be positive",
+			args:      []string{"--min-flakes=0"},
+			expectErr: true,
+		},
+		{
+			name:      "min flake rate is a ratio",
+			args:      []string{"--min-flake-rate=2"},
+			expectErr: true,
+		},
+		{
+			name:      "window must be positive",
+			args:      []string{"--window=0"},
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			o := gatherOptions(flag.NewFlagSet("flake-tracker", flag.ContinueOnError), tc.args...)
+			if err := o.Validate(); (err != nil) != tc.expectErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
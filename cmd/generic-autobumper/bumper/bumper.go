@@ -38,7 +38,8 @@ import (
 const (
 	forkRemoteName = "bumper-fork-remote"
 
-	defaultHeadBranchName = "autobump"
+	// DefaultHeadBranchName is the branch of the bump PR if Options.HeadBranchName is unset.
+	DefaultHeadBranchName = "autobump"
 
 	gitCmd = "git"
 )
@@ -177,7 +178,7 @@ func validateOptions(o *Options) error {
 	}
 	if !o.SkipPullRequest {
 		if o.HeadBranchName == "" {
-			o.HeadBranchName = defaultHeadBranchName
+			o.HeadBranchName = DefaultHeadBranchName
 		}
 	}
 
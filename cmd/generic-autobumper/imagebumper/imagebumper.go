@@ -31,6 +31,9 @@ import (
 var (
 	imageRegexp = regexp.MustCompile(`\b((?:[a-z0-9]+\.)?gcr\.io|(?:[a-z0-9-]+)?docker\.pkg\.dev)/([a-z][a-z0-9-]{5,29}/[a-zA-Z0-9][a-zA-Z0-9_./-]+):([a-zA-Z0-9_.-]+)\b`)
 	tagRegexp   = regexp.MustCompile(`(v?\d{8}-(?:v\d(?:[.-]\d+)*-g)?[0-9a-f]{6,10}|latest)(-.+)?`)
+	// digestRegexp matches the digest an image reference is pinned to,
+	// following its tag.
+	digestRegexp = regexp.MustCompile(`^@sha256:[a-f0-9]{64}\b`)
 )
 
 const (
@@ -42,9 +45,16 @@ const (
 )
 
 type Client struct {
+	// PinDigests pins the updated image references to the digest of their
+	// tag, as <image>:<tag>@sha256:<digest>. References that are pinned
+	// already are always re-pinned when their tag changes.
+	PinDigests bool
+
 	// Keys are <imageHost>/<imageName>:<currentTag>. Values are corresponding tags.
-	tagCache   map[string]string
-	httpClient *http.Client
+	tagCache map[string]string
+	// Keys are <imageHost>/<imageName>:<tag>. Values are the digests of the tags.
+	digestCache map[string]string
+	httpClient  *http.Client
 }
 
 func NewClient(httpClient *http.Client) *Client {
@@ -53,11 +63,13 @@ func NewClient(httpClient *http.Client) *Client {
 	httpClientCopy.Timeout = 1 * time.Minute
 
 	return &Client{
-		tagCache:   map[string]string{},
-		httpClient: &httpClientCopy,
+		tagCache:    map[string]string{},
+		digestCache: map[string]string{},
+		httpClient:  &httpClientCopy,
 	}
 }
 
+// manifest maps the digests of an image to their creation time and tags.
 type manifest map[string]struct {
 	TimeCreatedMs string   `json:"timeCreatedMs"`
 	Tags          []string `json:"tag"`
@@ -172,6 +184,36 @@ func (cli *Client) TagExists(imageHost, imageName, currentTag string) (bool, err
 	return false, nil
 }
 
+// FindDigest returns the digest of the given tag of the image.
+func (cli *Client) FindDigest(imageHost, imageName, tag string) (string, error) {
+	k := imageHost + "/" + imageName + ":" + tag
+	if digest, ok := cli.digestCache[k]; ok {
+		return digest, nil
+	}
+
+	imageList, err := cli.getManifest(imageHost, imageName)
+	if err != nil {
+		return "", err
+	}
+	digest, err := pickDigest(tag, imageList)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", k, err)
+	}
+	cli.digestCache[k] = digest
+	return digest, nil
+}
+
+func pickDigest(tag string, manifest manifest) (string, error) {
+	for digest, v := range manifest {
+		for _, t := range v.Tags {
+			if t == tag {
+				return digest, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no digest found for tag %s", tag)
+}
+
 func pickBestTag(currentTagParts []string, manifest manifest) (string, error) {
 	// The approach is to find the most recently created image that has the same suffix as the
 	// current tag. However, if we find one called "latest" (with appropriate suffix), we assume
@@ -225,7 +267,10 @@ func (cli *Client) AddToCache(image, newTag string) {
 	cli.tagCache[image] = newTag
 }
 
-func updateAllTags(tagPicker func(host, image, tag string) (string, error), content []byte, imageFilter *regexp.Regexp) []byte {
+// updateAllTags updates the tags of the images matching the filter. The
+// updated references are pinned to the digest of their new tag if pinAll is
+// set or if they were pinned to a digest before.
+func updateAllTags(tagPicker, digestPicker func(host, image, tag string) (string, error), pinAll bool, content []byte, imageFilter *regexp.Regexp) []byte {
 	indexes := imageRegexp.FindAllSubmatchIndex(content, -1)
 	// Not finding any images is not an error.
 	if indexes == nil {
@@ -239,20 +284,36 @@ func updateAllTags(tagPicker func(host, image, tag string) (string, error), cont
 		host := string(content[m[imageHostPart*2]:m[imageHostPart*2+1]])
 		image := string(content[m[imageImagePart*2]:m[imageImagePart*2+1]])
 		tag := string(content[m[imageTagPart*2]:m[imageTagPart*2+1]])
-		lastIndex = m[1]
+		end := m[1]
+		pinned := digestRegexp.Find(content[end:])
+		end += len(pinned)
+		lastIndex = end
 
 		if tag == "" || (imageFilter != nil && !imageFilter.MatchString(host+"/"+image+":"+tag)) {
-			newContent = append(newContent, content[m[imageTagPart*2]:m[1]]...)
+			newContent = append(newContent, content[m[imageTagPart*2]:end]...)
 			continue
 		}
 
 		latest, err := tagPicker(host, image, tag)
 		if err != nil {
 			log.Printf("Failed to update %s/%s:%s: %v.\n", host, image, tag, err)
-			newContent = append(newContent, content[m[imageTagPart*2]:m[1]]...)
+			newContent = append(newContent, content[m[imageTagPart*2]:end]...)
+			continue
+		}
+		if !pinAll && (pinned == nil || latest == tag) {
+			// Unpinned references stay unpinned, and pinned ones whose tag
+			// did not change keep their digest.
+			newContent = append(newContent, []byte(latest)...)
+			newContent = append(newContent, pinned...)
+			continue
+		}
+		digest, err := digestPicker(host, image, latest)
+		if err != nil {
+			log.Printf("Failed to pin %s/%s:%s: %v.\n", host, image, latest, err)
+			newContent = append(newContent, content[m[imageTagPart*2]:end]...)
 			continue
 		}
-		newContent = append(newContent, []byte(latest)...)
+		newContent = append(newContent, []byte(latest+"@"+digest)...)
 	}
 	newContent = append(newContent, content[lastIndex:]...)
 
@@ -267,7 +328,7 @@ func (cli *Client) UpdateFile(tagPicker func(imageHost, imageName, currentTag st
 		return fmt.Errorf("failed to read %s: %w", path, err)
 	}
 
-	newContent := updateAllTags(tagPicker, content, imageFilter)
+	newContent := updateAllTags(tagPicker, cli.FindDigest, cli.PinDigests, content, imageFilter)
 
 	if err := os.WriteFile(path, newContent, 0644); err != nil {
 		return fmt.Errorf("failed to write %s: %w", path, err)
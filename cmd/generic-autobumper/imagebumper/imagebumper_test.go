@@ -19,6 +19,7 @@ package imagebumper
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -276,12 +277,16 @@ func TestPickBestTag(t *testing.T) {
 }
 
 func TestUpdateAllTags(t *testing.T) {
+	digest1 := strings.Repeat("1a", 32)
+	digest2 := strings.Repeat("2b", 32)
 	tests := []struct {
 		name           string
 		content        string
 		expectedResult string
 		imageFilter    *regexp.Regexp
 		newTags        map[string]string
+		pinDigests     bool
+		digests        map[string]string
 	}{
 		{
 			name:           "file with no images does nothing",
@@ -355,6 +360,40 @@ func TestUpdateAllTags(t *testing.T) {
 			},
 			imageFilter: regexp.MustCompile("gcr.io/k8s-testimages"),
 		},
+		{
+			name:           "images are pinned to the digest of their new tag",
+			content:        `{"images": ["gcr.io/k8s-testimages/some-image:v20190404-12345678", "gcr.io/k8s-testimages/other-image:v20190404-12345678"]}`,
+			expectedResult: `{"images": ["gcr.io/k8s-testimages/some-image:v20190405-123456789@sha256:` + digest1 + `", "gcr.io/k8s-testimages/other-image:v20190404-12345678@sha256:` + digest2 + `"]}`,
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678":  "v20190405-123456789",
+				"gcr.io/k8s-testimages/other-image:v20190404-12345678": "v20190404-12345678",
+			},
+			pinDigests: true,
+			digests: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190405-123456789": "sha256:" + digest1,
+				"gcr.io/k8s-testimages/other-image:v20190404-12345678": "sha256:" + digest2,
+			},
+		},
+		{
+			name:           "pinned images are re-pinned when their tag changes",
+			content:        `image: gcr.io/k8s-testimages/some-image:v20190404-12345678@sha256:` + digest2 + `\nimage: gcr.io/k8s-testimages/other-image:v20190404-12345678@sha256:` + digest2,
+			expectedResult: `image: gcr.io/k8s-testimages/some-image:v20190405-123456789@sha256:` + digest1 + `\nimage: gcr.io/k8s-testimages/other-image:v20190404-12345678@sha256:` + digest2,
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678":  "v20190405-123456789",
+				"gcr.io/k8s-testimages/other-image:v20190404-12345678": "v20190404-12345678",
+			},
+			digests: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190405-123456789": "sha256:" + digest1,
+			},
+		},
+		{
+			name:           "pinned images are left alone if the new digest is unknown",
+			content:        `image: gcr.io/k8s-testimages/some-image:v20190404-12345678@sha256:` + digest2,
+			expectedResult: `image: gcr.io/k8s-testimages/some-image:v20190404-12345678@sha256:` + digest2,
+			newTags: map[string]string{
+				"gcr.io/k8s-testimages/some-image:v20190404-12345678": "v20190405-123456789",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -367,10 +406,37 @@ func TestUpdateAllTags(t *testing.T) {
 				return result, nil
 			}
 
-			newContent := updateAllTags(tagPicker, []byte(test.content), test.imageFilter)
+			digestPicker := func(imageHost string, imageName string, imageTag string) (string, error) {
+				result, ok := test.digests[imageHost+"/"+imageName+":"+imageTag]
+				if !ok {
+					return "", fmt.Errorf("unknown image %s/%s:%s", imageHost, imageName, imageTag)
+				}
+				return result, nil
+			}
+
+			newContent := updateAllTags(tagPicker, digestPicker, test.pinDigests, []byte(test.content), test.imageFilter)
 			if test.expectedResult != string(newContent) {
 				t.Fatalf("Expected content:\n%s\n\nActual content:\n%s\n\n", test.expectedResult, string(newContent))
 			}
 		})
 	}
 }
+
+func TestPickDigest(t *testing.T) {
+	m := manifest{
+		"sha256:older": {Tags: []string{"v20190404-12345678"}},
+		"sha256:newer": {Tags: []string{"v20190405-123456789", "latest"}},
+	}
+	for tag, expected := range map[string]string{"v20190404-12345678": "sha256:older", "latest": "sha256:newer"} {
+		digest, err := pickDigest(tag, m)
+		if err != nil {
+			t.Errorf("unexpected error picking the digest of %s: %v", tag, err)
+		}
+		if digest != expected {
+			t.Errorf("expected digest %s for %s, got %s", expected, tag, digest)
+		}
+	}
+	if _, err := pickDigest("v20190406-12345678", m); err == nil {
+		t.Error("expected an error for an unknown tag, got none")
+	}
+}
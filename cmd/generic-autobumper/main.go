@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -34,6 +35,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/prow/cmd/generic-autobumper/bumper"
 	"sigs.k8s.io/prow/cmd/generic-autobumper/imagebumper"
@@ -86,7 +88,7 @@ func (c *client) Changes() []func(context.Context) (string, error) {
 			var prefixNames []string
 			for _, prefix := range c.o.Prefixes {
 				prefixNames = append(prefixNames, prefix.Name)
-				body = body + generateSummary(prefix.Name, prefix.Repo, prefix.Prefix, prefix.Summarise, c.images) + "\n\n"
+				body = body + generateSummary(prefix, c.images) + "\n\n"
 			}
 
 			return fmt.Sprintf("Bumping %s\n\n%s", strings.Join(prefixNames, " and "), body), nil
@@ -107,7 +109,7 @@ func (c *client) PRTitleBody() (string, string) {
 func generatePRBody(images map[string]string, prefixes []prefix) (body string) {
 	body = ""
 	for _, prefix := range prefixes {
-		body = body + generateSummary(prefix.Name, prefix.Repo, prefix.Prefix, prefix.Summarise, images) + "\n\n"
+		body = body + generateSummary(prefix, images) + "\n\n"
 	}
 	return body + "\n"
 }
@@ -147,6 +149,20 @@ type options struct {
 	ImageRegistryAuth string `yaml:"imageRegistryAuth"`
 	// AdditionalPRBody allows for generic, additional content in the body of the PR
 	AdditionalPRBody string `yaml:"additionalPRBody"`
+	// PinDigests pins the bumped images to the digest of their new tag, as image:tag@sha256:digest.
+	// Images that are pinned already are re-pinned when bumped regardless.
+	PinDigests bool `yaml:"pinDigests"`
+	// Groups splits the bump into one PR per group, e.g. one for all Prow components and one for all test images.
+	// When set, every prefix must be in exactly one group.
+	Groups []group `yaml:"groups"`
+}
+
+// group is a set of prefixes that are bumped together in their own PR.
+type group struct {
+	// Name of the group. It is appended to the head branch name of the PR, so it must be valid in a branch name.
+	Name string `yaml:"name"`
+	// The names of the prefixes bumped in the PR of the group.
+	Prefixes []string `yaml:"prefixes"`
 }
 
 // prefix is the information needed for each prefix being bumped.
@@ -167,6 +183,9 @@ type prefix struct {
 	ConsistentImages bool `yaml:"consistentImages"`
 	// A list of images whose tags are not required to be consistent after the bump. Requires `consistentImages: true`.
 	ConsistentImageExceptions []string `yaml:"consistentImageExceptions"`
+	// The URL of the changes between two versions linked in the PR summary, in which {old} and {new} are replaced by the old and new
+	// commits or tags. Defaults to the comparison of the two in Repo, i.e. <repo>/compare/{old}...{new}.
+	ChangelogURL string `yaml:"changelogURL"`
 }
 
 func parseOptions() (*options, *bumper.Options, error) {
@@ -220,6 +239,37 @@ func validateOptions(o *options) error {
 	if len(o.IncludedConfigPaths) == 0 {
 		return errors.New("includedConfigPaths is mandatory")
 	}
+	if len(o.Groups) > 0 {
+		grouped := map[string]string{}
+		groupNames := sets.NewString()
+		for _, g := range o.Groups {
+			if g.Name == "" {
+				return errors.New("groups must have a name")
+			}
+			if groupNames.Has(g.Name) {
+				return fmt.Errorf("group %q is defined more than once", g.Name)
+			}
+			groupNames.Insert(g.Name)
+			for _, name := range g.Prefixes {
+				if other, ok := grouped[name]; ok {
+					return fmt.Errorf("prefix %q is in both group %q and group %q", name, other, g.Name)
+				}
+				grouped[name] = g.Name
+			}
+		}
+		prefixNames := sets.NewString()
+		for _, prefix := range o.Prefixes {
+			prefixNames.Insert(prefix.Name)
+			if _, ok := grouped[prefix.Name]; !ok {
+				return fmt.Errorf("prefix %q is not in any group", prefix.Name)
+			}
+		}
+		for name, g := range grouped {
+			if !prefixNames.Has(name) {
+				return fmt.Errorf("group %q has unknown prefix %q", g, name)
+			}
+		}
+	}
 	if o.TargetVersion != latestVersion && o.TargetVersion != upstreamVersion &&
 		o.TargetVersion != upstreamStagingVersion && !tagRegexp.MatchString(o.TargetVersion) {
 		logrus.WithField("allowed", []string{latestVersion, upstreamVersion, upstreamStagingVersion, tagVersion}).Warn(
@@ -331,6 +381,7 @@ func updateReferencesWrapper(ctx context.Context, o *options) (map[string]string
 		}
 	}
 	imageBumperCli := imagebumper.NewClient(client)
+	imageBumperCli.PinDigests = o.PinDigests
 	return updateReferences(imageBumperCli, filterRegexp, o)
 }
 
@@ -542,7 +593,7 @@ func makeCommitSummary(prefixes []prefix, versions map[string][]string) string {
 }
 
 // Generate PR summary for github
-func generateSummary(name, repo, prefix string, summarise bool, images map[string]string) string {
+func generateSummary(p prefix, images map[string]string) string {
 	type delta struct {
 		oldCommit string
 		newCommit string
@@ -553,7 +604,7 @@ func generateSummary(name, repo, prefix string, summarise bool, images map[strin
 	}
 	versions := map[string][]delta{}
 	for image, newTag := range images {
-		if !strings.HasPrefix(image, prefix) {
+		if !strings.HasPrefix(image, p.Prefix) {
 			continue
 		}
 		if strings.HasSuffix(image, ":"+newTag) {
@@ -563,6 +614,13 @@ func generateSummary(name, repo, prefix string, summarise bool, images map[strin
 		newDate, newCommit, _ := imagebumper.DeconstructTag(newTag)
 		oldCommit = commitToRef(oldCommit)
 		newCommit = commitToRef(newCommit)
+		// Tags that are not vYYYYMMDD-deadbeef, e.g. v1.2.3, are refs themselves.
+		if oldCommit == "" {
+			oldCommit = tagFromName(image)
+		}
+		if newCommit == "" {
+			newCommit = newTag
+		}
 		k := oldCommit + ":" + newCommit
 		d := delta{
 			oldCommit: oldCommit,
@@ -577,11 +635,14 @@ func generateSummary(name, repo, prefix string, summarise bool, images map[strin
 
 	switch {
 	case len(versions) == 0:
-		return fmt.Sprintf("No %s changes.", prefix)
-	case len(versions) == 1 && summarise:
+		return fmt.Sprintf("No %s changes.", p.Prefix)
+	case len(versions) == 1 && p.Summarise:
 		for k, v := range versions {
 			s := strings.Split(k, ":")
-			return fmt.Sprintf("%s changes: %s/compare/%s...%s (%s → %s)", prefix, repo, s[0], s[1], formatTagDate(v[0].oldDate), formatTagDate(v[0].newDate))
+			if v[0].oldDate == "" && v[0].newDate == "" {
+				return fmt.Sprintf("%s changes: %s", p.Prefix, changelogLink(p, s[0], s[1]))
+			}
+			return fmt.Sprintf("%s changes: %s (%s → %s)", p.Prefix, changelogLink(p, s[0], s[1]), formatTagDate(v[0].oldDate), formatTagDate(v[0].newDate))
 		}
 	default:
 		changes := make([]string, 0, len(versions))
@@ -592,15 +653,75 @@ func generateSummary(name, repo, prefix string, summarise bool, images map[strin
 				names = append(names, d.component+d.variant)
 			}
 			sort.Strings(names)
-			changes = append(changes, fmt.Sprintf("%s/compare/%s...%s | %s&nbsp;&#x2192;&nbsp;%s | %s",
-				repo, s[0], s[1], formatTagDate(v[0].oldDate), formatTagDate(v[0].newDate), strings.Join(names, ", ")))
+			changes = append(changes, fmt.Sprintf("%s | %s&nbsp;&#x2192;&nbsp;%s | %s",
+				changelogLink(p, s[0], s[1]), formatTagDate(v[0].oldDate), formatTagDate(v[0].newDate), strings.Join(names, ", ")))
 		}
 		sort.Slice(changes, func(i, j int) bool { return strings.Split(changes[i], "|")[1] < strings.Split(changes[j], "|")[1] })
-		return fmt.Sprintf("Multiple distinct %s changes:\n\nCommits | Dates | Images\n--- | --- | ---\n%s\n", prefix, strings.Join(changes, "\n"))
+		return fmt.Sprintf("Multiple distinct %s changes:\n\nCommits | Dates | Images\n--- | --- | ---\n%s\n", p.Prefix, strings.Join(changes, "\n"))
 	}
 	panic("unreachable!")
 }
 
+// changelogLink returns the link to the changes between the old and new refs of the prefix.
+func changelogLink(p prefix, oldRef, newRef string) string {
+	if p.ChangelogURL == "" {
+		return fmt.Sprintf("%s/compare/%s...%s", p.Repo, oldRef, newRef)
+	}
+	return strings.NewReplacer("{old}", oldRef, "{new}", newRef).Replace(p.ChangelogURL)
+}
+
+// forGroup returns the options for bumping the prefixes of the group only.
+func (o *options) forGroup(g group) *options {
+	grouped := *o
+	grouped.Groups = nil
+	grouped.Prefixes = nil
+	names := sets.NewString(g.Prefixes...)
+	for _, prefix := range o.Prefixes {
+		if names.Has(prefix.Name) {
+			grouped.Prefixes = append(grouped.Prefixes, prefix)
+		}
+	}
+	return &grouped
+}
+
+// bumperOptionsForGroup returns the options for the PR of the group, which
+// gets its own head branch or, on Gerrit, its own change.
+func bumperOptionsForGroup(pro *bumper.Options, g group) *bumper.Options {
+	grouped := *pro
+	if grouped.HeadBranchName == "" {
+		grouped.HeadBranchName = bumper.DefaultHeadBranchName
+	}
+	grouped.HeadBranchName += "-" + g.Name
+	if pro.Gerrit != nil {
+		gerrit := *pro.Gerrit
+		gerrit.AutobumpPRIdentifier += "-" + g.Name
+		grouped.Gerrit = &gerrit
+	}
+	return &grouped
+}
+
+// runGroups creates a PR for every group. Each group starts from the
+// original tree, so that its PR only contains its own bumps.
+func runGroups(ctx context.Context, o *options, pro *bumper.Options) error {
+	var head bytes.Buffer
+	if err := bumper.Call(&head, os.Stderr, "git", []string{"rev-parse", "HEAD"}); err != nil {
+		return fmt.Errorf("get the current commit: %w", err)
+	}
+	start := strings.TrimSpace(head.String())
+
+	var errs []error
+	for _, g := range o.Groups {
+		logrus.WithField("group", g.Name).Info("Bumping group.")
+		if err := bumper.Run(ctx, bumperOptionsForGroup(pro, g), &client{o: o.forGroup(g)}); err != nil {
+			errs = append(errs, fmt.Errorf("group %s: %w", g.Name, err))
+		}
+		if err := bumper.Call(os.Stdout, os.Stderr, "git", []string{"reset", "--hard", start}); err != nil {
+			return fmt.Errorf("reset to %s after bumping group %s: %w", start, g.Name, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 func main() {
 	ctx := context.Background()
 	logrus.SetLevel(logrus.DebugLevel)
@@ -620,6 +741,12 @@ func main() {
 		logrus.WithError(err).Fatalf("Failed validating flags")
 	}
 
+	if len(o.Groups) > 0 {
+		if err := runGroups(ctx, o, pro); err != nil {
+			logrus.WithError(err).Fatalf("failed to run the bumper tool")
+		}
+		return
+	}
 	if err := bumper.Run(ctx, pro, &client{o: o}); err != nil {
 		logrus.WithError(err).Fatalf("failed to run the bumper tool")
 	}
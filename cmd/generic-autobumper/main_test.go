@@ -194,6 +194,7 @@ func TestValidateOptions(t *testing.T) {
 		targetVersion       *string
 		includeConfigPaths  *[]string
 		prefixes            *[]prefix
+		groups              []group
 		upstreamURLBase     *string
 		err                 bool
 		upstreamBaseChanged bool
@@ -263,6 +264,31 @@ func TestValidateOptions(t *testing.T) {
 			err:                 false,
 			upstreamBaseChanged: false,
 		},
+		{
+			name:   "every prefix in one group",
+			groups: []group{{Name: "test", Prefixes: []string{"test"}}},
+			err:    false,
+		},
+		{
+			name:   "groups need a name",
+			groups: []group{{Prefixes: []string{"test"}}},
+			err:    true,
+		},
+		{
+			name:   "group with an unknown prefix",
+			groups: []group{{Name: "test", Prefixes: []string{"test", "unknown"}}},
+			err:    true,
+		},
+		{
+			name:   "prefix in two groups",
+			groups: []group{{Name: "test", Prefixes: []string{"test"}}, {Name: "other", Prefixes: []string{"test"}}},
+			err:    true,
+		},
+		{
+			name:   "prefix in no group",
+			groups: []group{{Name: "test"}},
+			err:    true,
+		},
 		{
 			name:                "don't use default upstreamURLbase if not neededfor upstreamStaging",
 			upstreamURLBase:     &whateverStr,
@@ -293,6 +319,7 @@ func TestValidateOptions(t *testing.T) {
 			if tc.upstreamURLBase != nil {
 				defaultOption.UpstreamURLBase = *tc.upstreamURLBase
 			}
+			defaultOption.Groups = tc.groups
 
 			err := validateOptions(defaultOption)
 			t.Logf("err is: %v", err)
@@ -1007,15 +1034,17 @@ Commits | Dates | Images
 		fmt.Sprintf("gcr.io/variant/name:v%s-%s-second", beforeDate, beforeCommit):  fmt.Sprintf("v%s-%s", afterDate, afterCommit),
 		fmt.Sprintf("gcr.io/inconsistent/first:v%s-%s", beforeDate2, beforeCommit2): fmt.Sprintf("v%s-%s", afterDate2, afterCommit2),
 		fmt.Sprintf("gcr.io/inconsistent/second:v%s-%s", beforeDate, beforeCommit):  fmt.Sprintf("v%s-%s", afterDate, afterCommit),
+		"gcr.io/semver/tool:v1.2.3": "v1.3.0",
 	}
 	testCases := []struct {
-		testName  string
-		name      string
-		repo      string
-		prefix    string
-		summarize bool
-		images    map[string]string
-		expected  string
+		testName     string
+		name         string
+		repo         string
+		prefix       string
+		summarize    bool
+		changelogURL string
+		images       map[string]string
+		expected     string
 	}{
 		{
 			testName:  "Image not bumped unsummarized",
@@ -1071,11 +1100,31 @@ Commits | Dates | Images
 			images:    sampleImages,
 			expected:  fmt.Sprintf("%s\n%s\n%s\n", fmt.Sprintf(unsummarizedOutHeader, "gcr.io/inconsistent"), fmt.Sprintf(unsummarizedOutLine, beforeCommit2, afterCommit2, formatTagDate(beforeDate2), formatTagDate(afterDate2), "first"), fmt.Sprintf(unsummarizedOutLine, beforeCommit, afterCommit, formatTagDate(beforeDate), formatTagDate(afterDate), "second")),
 		},
+		{
+			testName:  "Image bumped with custom changelog: summarized",
+			name:      "Test",
+			repo:      "github.com/test/repo",
+			prefix:    "gcr.io/bumped",
+			summarize: true,
+			// The old and new refs are replaced as often as they occur.
+			changelogURL: "https://example.com/changelog?from={old}&to={new}#{new}",
+			images:       sampleImages,
+			expected:     fmt.Sprintf("gcr.io/bumped changes: https://example.com/changelog?from=%s&to=%s#%s (%s → %s)", beforeCommit, afterCommit, afterCommit, formatTagDate(beforeDate), formatTagDate(afterDate)),
+		},
+		{
+			testName:  "Image with version tags bumped: summarized",
+			name:      "Test",
+			repo:      "github.com/test/tool",
+			prefix:    "gcr.io/semver",
+			summarize: true,
+			images:    sampleImages,
+			expected:  "gcr.io/semver changes: github.com/test/tool/compare/v1.2.3...v1.3.0",
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.testName, func(t *testing.T) {
-			want, got := tc.expected, generateSummary(tc.name, tc.repo, tc.prefix, tc.summarize, tc.images)
+			want, got := tc.expected, generateSummary(prefix{Name: tc.name, Repo: tc.repo, Prefix: tc.prefix, Summarise: tc.summarize, ChangelogURL: tc.changelogURL}, tc.images)
 			if diff := cmp.Diff(want, got); diff != "" {
 				t.Errorf("generateSummary returned unexpected value (-want +got):\n%s", diff)
 			}
@@ -21,8 +21,10 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -39,6 +41,8 @@ import (
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/gerrit/adapter"
+	"sigs.k8s.io/prow/pkg/gerrit/client"
+	"sigs.k8s.io/prow/pkg/gerrit/source"
 	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 )
@@ -92,8 +96,16 @@ type options struct {
 	instrumentationOptions   prowflagutil.InstrumentationOptions
 	gerrit                   prowflagutil.GerritOptions
 	changeWorkerPoolSize     int
+	hostSyncConcurrency      int
 	pushGatewayInterval      time.Duration
 	instanceConcurrencyLimit uint
+	// streamEvents enables consuming Gerrit's stream-events over SSH in
+	// addition to polling.
+	streamEvents      bool
+	sshUser           string
+	sshPrivateKeyPath string
+	sshKnownHostsPath string
+	sshPort           int
 }
 
 func (o *options) validate() error {
@@ -123,17 +135,31 @@ func (o *options) validate() error {
 	if o.changeWorkerPoolSize < 1 {
 		return errors.New("change-worker-pool-size must be at least 1")
 	}
+	if o.hostSyncConcurrency < 0 {
+		return errors.New("host-sync-concurrency must not be negative")
+	}
+	if o.streamEvents {
+		if o.sshUser == "" || o.sshPrivateKeyPath == "" || o.sshKnownHostsPath == "" {
+			return errors.New("--ssh-user, --ssh-private-key-path and --ssh-known-hosts-path are required with --stream-events")
+		}
+	}
 	return nil
 }
 
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	var o options
 	fs.StringVar(&o.cookiefilePath, "cookiefile", "", "Path to git http.cookiefile, leave empty for anonymous")
-	fs.StringVar(&o.lastSyncFallback, "last-sync-fallback", "", "The /local/path, gs://path/to/object or s3://path/to/object to sync the latest timestamp")
+	fs.StringVar(&o.lastSyncFallback, "last-sync-fallback", "", "The /local/path, gs://path/to/object or s3://path/to/object to sync the latest timestamp. Each instance is synced to its own object suffixed with the instance name.")
 	fs.BoolVar(&o.dryRun, "dry-run", false, "Run in dry-run mode, performing no modifying actions.")
 	fs.StringVar(&o.tokenPathOverride, "token-path", "", "Force the use of the token in this path, use with gcloud auth print-access-token")
 	fs.IntVar(&o.changeWorkerPoolSize, "change-worker-pool-size", 1, "Number of workers processing changes for each instance.")
+	fs.IntVar(&o.hostSyncConcurrency, "host-sync-concurrency", 0, "Maximum number of projects of a single Gerrit instance synced concurrently, 0 means unlimited. Each instance has its own limit.")
 	fs.DurationVar(&o.pushGatewayInterval, "push-gateway-interval", time.Minute, "Interval at which prometheus metrics for disk space are pushed.")
+	fs.BoolVar(&o.streamEvents, "stream-events", false, "Consume Gerrit's stream-events over SSH to trigger jobs within seconds, polling keeps running as a fallback.")
+	fs.StringVar(&o.sshUser, "ssh-user", "", "User to authenticate as against Gerrit's SSH daemon, requires the 'Stream Events' capability.")
+	fs.StringVar(&o.sshPrivateKeyPath, "ssh-private-key-path", "", "Path to the SSH private key used with --stream-events.")
+	fs.StringVar(&o.sshKnownHostsPath, "ssh-known-hosts-path", "", "Path to a known_hosts file used to verify Gerrit SSH host keys with --stream-events.")
+	fs.IntVar(&o.sshPort, "ssh-port", client.DefaultSSHPort, "Port of Gerrit's SSH daemon.")
 	// TODO(cjwagner): remove deprecated flag.
 	fs.UintVar(&o.instanceConcurrencyLimit, "instance-concurrency-limit", 5, "[DEPRECATED] Number of concurrent calls that can be made to any single Gerrit host instance simultaneously.")
 	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.storage, &o.instrumentationOptions, &o.config, &o.gerrit} {
@@ -202,7 +228,21 @@ func main() {
 		}
 		ircg = ircc
 	}
-	c := adapter.NewController(ctx, prowJobClient, op, ca, o.cookiefilePath, o.tokenPathOverride, o.lastSyncFallback, o.changeWorkerPoolSize, o.gerrit.MaxQPS, o.gerrit.MaxBurst, ircg)
+	c := adapter.NewController(ctx, prowJobClient, op, ca, o.cookiefilePath, o.tokenPathOverride, o.lastSyncFallback, o.changeWorkerPoolSize, o.hostSyncConcurrency, o.gerrit.MaxQPS, o.gerrit.MaxBurst, ircg)
+
+	if o.streamEvents {
+		sshConfig, err := client.NewSSHClientConfig(o.sshUser, o.sshPrivateKeyPath, o.sshKnownHostsPath)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating SSH client config.")
+		}
+		for instance := range cfg().Gerrit.OrgReposConfig.AllRepos() {
+			instance := instance
+			addr := net.JoinHostPort(source.TrimHTTPSPrefix(instance), strconv.Itoa(o.sshPort))
+			interrupts.Run(func(ctx context.Context) {
+				c.StreamEvents(ctx, instance, client.SSHStreamDialer(addr, sshConfig))
+			})
+		}
+	}
 
 	logrus.Infof("Starting gerrit fetcher")
 
@@ -80,6 +80,54 @@ func TestFlags(t *testing.T) {
 				o.storage.S3CredentialsFile = "/creds"
 			},
 		},
+		{
+			name: "host sync concurrency is set",
+			args: map[string]string{
+				"--host-sync-concurrency": "4",
+			},
+			expected: func(o *options) {
+				o.hostSyncConcurrency = 4
+			},
+		},
+		{
+			name: "negative host sync concurrency is rejected",
+			args: map[string]string{
+				"--host-sync-concurrency": "-1",
+			},
+			err: true,
+		},
+		{
+			name: "stream events over SSH",
+			args: map[string]string{
+				"--stream-events":        "true",
+				"--ssh-user":             "prow",
+				"--ssh-private-key-path": "/etc/ssh-key/id_ed25519",
+				"--ssh-known-hosts-path": "/etc/ssh-key/known_hosts",
+			},
+			expected: func(o *options) {
+				o.streamEvents = true
+				o.sshUser = "prow"
+				o.sshPrivateKeyPath = "/etc/ssh-key/id_ed25519"
+				o.sshKnownHostsPath = "/etc/ssh-key/known_hosts"
+			},
+		},
+		{
+			name: "stream events requires known hosts",
+			args: map[string]string{
+				"--stream-events":        "true",
+				"--ssh-user":             "prow",
+				"--ssh-private-key-path": "/etc/ssh-key/id_ed25519",
+			},
+			err: true,
+		},
+		{
+			name: "stream events requires an SSH key",
+			args: map[string]string{
+				"--stream-events": "true",
+				"--ssh-user":      "prow",
+			},
+			err: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -98,6 +146,7 @@ func TestFlags(t *testing.T) {
 				changeWorkerPoolSize:     1,
 				pushGatewayInterval:      time.Minute,
 				instanceConcurrencyLimit: 5,
+				sshPort:                  29418,
 			}
 			if tc.expected != nil {
 				tc.expected(expected)
@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/prow/pkg/diskutil"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	"sigs.k8s.io/prow/pkg/ghcache"
+	"sigs.k8s.io/prow/pkg/github/fixtures"
 	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
@@ -81,6 +82,8 @@ func init() {
 // GitHub reverse proxy HTTP cache RoundTripper stack:
 //  v -   <Client(s)>
 //  v ^ reverse proxy
+//  v ^ fixtures: Recorder (only with --record-fixtures-dir)
+//  v ^ ghcache: Warmer (only with --cache-warming-interval)
 //  v ^ ghcache: downstreamTransport (coalescing, instrumentation)
 //  v ^ ghcache: httpcache layer
 //  v ^ ghcache: upstreamTransport (cache-control, instrumentation)
@@ -94,6 +97,10 @@ type options struct {
 	diskCacheDisableAuthHeaderPartitioning bool
 
 	redisAddress string
+	redisTTL     time.Duration
+
+	warmingInterval time.Duration
+	warmingKeys     int
 
 	port           int
 	upstream       string
@@ -117,6 +124,8 @@ type options struct {
 	instrumentationOptions flagutil.InstrumentationOptions
 
 	timeout uint
+
+	recordFixturesDir string
 }
 
 func (o *options) validate() error {
@@ -129,6 +138,20 @@ func (o *options) validate() error {
 	if (o.dir == "") != (o.sizeGB == 0) {
 		return errors.New("--cache-dir and --cache-sizeGB must be specified together to enable the disk cache (otherwise a memory cache is used)")
 	}
+	if o.redisTTL < 0 || (o.redisTTL > 0 && o.redisAddress == "") {
+		return errors.New("--redis-ttl must not be negative and requires --redis-address")
+	}
+	if o.warmingInterval > 0 {
+		if o.redisTTL == 0 {
+			return errors.New("--cache-warming-interval requires --redis-ttl, entries that never expire don't need to be warmed")
+		}
+		if o.warmingInterval >= o.redisTTL {
+			return fmt.Errorf("--cache-warming-interval (%v) must be shorter than --redis-ttl (%v)", o.warmingInterval, o.redisTTL)
+		}
+		if o.warmingKeys <= 0 {
+			return errors.New("--cache-warming-keys must be positive")
+		}
+	}
 	upstreamURL, err := url.Parse(o.upstream)
 	if err != nil {
 		return fmt.Errorf("failed to parse upstream URL: %w", err)
@@ -142,7 +165,10 @@ func flagOptions() *options {
 	flag.StringVar(&o.dir, "cache-dir", "", "Directory to cache to if using a disk cache.")
 	flag.IntVar(&o.sizeGB, "cache-sizeGB", 0, "Cache size in GB per unique token if using a disk cache.")
 	flag.BoolVar(&o.diskCacheDisableAuthHeaderPartitioning, "legacy-disable-disk-cache-partitions-by-auth-header", true, "Whether to disable partitioning a disk cache by auth header. Disabling this will start a new cache at $cache_dir/$sha256sum_of_authorization_header for each unique authorization header. Bigger setups are advise to manually warm this up from an existing cache. This option will be removed and set to `false` in the future")
-	flag.StringVar(&o.redisAddress, "redis-address", "", "Redis address if using a redis cache e.g. localhost:6379.")
+	flag.StringVar(&o.redisAddress, "redis-address", "", "Redis address if using a redis cache e.g. localhost:6379. Several ghproxy replicas can share a redis cache.")
+	flag.DurationVar(&o.redisTTL, "redis-ttl", 0, "Lifetime of redis cache entries after they were last written. Entries never expire if zero.")
+	flag.DurationVar(&o.warmingInterval, "cache-warming-interval", 0, "If set, the most requested resources are revalidated at this interval so that their cache entries don't expire. Must be shorter than --redis-ttl.")
+	flag.IntVar(&o.warmingKeys, "cache-warming-keys", 100, "Number of the most requested resources that are revalidated per --cache-warming-interval.")
 	flag.IntVar(&o.port, "port", 8888, "Port to listen on.")
 	flag.StringVar(&o.upstream, "upstream", "https://api.github.com", "Scheme, host, and base path of reverse proxy upstream.")
 	flag.IntVar(&o.maxConcurrency, "concurrency", 25, "Maximum number of concurrent in-flight requests to GitHub.")
@@ -156,6 +182,7 @@ func flagOptions() *options {
 	flag.StringVar(&o.logLevel, "log-level", "debug", fmt.Sprintf("Log level is one of %v.", logrus.AllLevels))
 	flag.BoolVar(&o.serveMetrics, "serve-metrics", false, "If true, it serves prometheus metrics")
 	flag.UintVar(&o.timeout, "request-timeout", 30, "Request timeout which applies also to paged requests. Default is 30 seconds.")
+	flag.StringVar(&o.recordFixturesDir, "record-fixtures-dir", "", "If set, all requests and responses are recorded as sanitized fixtures into this directory. Meant for capturing test fixtures, not for production use.")
 	o.instrumentationOptions.AddFlags(flag.CommandLine)
 	return o
 }
@@ -202,7 +229,7 @@ func proxy(o *options, upstreamTransport http.RoundTripper, diskCachePruneInterv
 	var cache http.RoundTripper
 	throttlingTimes := ghcache.NewRequestThrottlingTimes(o.requestThrottlingTime, o.requestThrottlingTimeV4, o.requestThrottlingTimeForGET, o.requestThrottlingMaxDelayTime, o.requestThrottlingMaxDelayTimeV4)
 	if o.redisAddress != "" {
-		cache = ghcache.NewRedisCache(apptokenequalizer.New(upstreamTransport), o.redisAddress, o.maxConcurrency, throttlingTimes)
+		cache = ghcache.NewRedisCache(apptokenequalizer.New(upstreamTransport), o.redisAddress, o.redisTTL, o.maxConcurrency, throttlingTimes)
 	} else if o.dir == "" {
 		cache = ghcache.NewMemCache(apptokenequalizer.New(upstreamTransport), o.maxConcurrency, throttlingTimes)
 	} else {
@@ -210,6 +237,20 @@ func proxy(o *options, upstreamTransport http.RoundTripper, diskCachePruneInterv
 		go diskMonitor(o.pushGatewayInterval, o.dir)
 	}
 
+	if o.warmingInterval > 0 {
+		warmer := ghcache.NewWarmer(cache, o.warmingKeys)
+		interrupts.TickLiteral(warmer.Warm, o.warmingInterval)
+		cache = warmer
+	}
+
+	if o.recordFixturesDir != "" {
+		recorder, err := fixtures.NewRecorder(cache, o.recordFixturesDir)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to set up fixture recording.")
+		}
+		cache = recorder
+	}
+
 	return newReverseProxy(o.upstreamParsed, cache, time.Duration(o.timeout)*time.Second)
 }
 
@@ -42,6 +42,7 @@ import (
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
 	"sigs.k8s.io/prow/pkg/ghhook"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 )
 
@@ -57,6 +58,8 @@ type options struct {
 	hmacTokenSecretNamespace string
 	hmacTokenSecretName      string
 	hmacTokenKey             string
+
+	interval time.Duration
 }
 
 func (o *options) validate() error {
@@ -78,6 +81,9 @@ func (o *options) validate() error {
 	if o.hmacTokenKey == "" {
 		return errors.New("required flag --hmac-token-key was unset")
 	}
+	if o.interval < 0 {
+		return errors.New("--interval must not be negative")
+	}
 
 	return nil
 }
@@ -96,6 +102,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.StringVar(&o.hmacTokenSecretNamespace, "hmac-token-secret-namespace", "default", "Name of the namespace on the cluster where the hmac-token secret is in.")
 	fs.StringVar(&o.hmacTokenSecretName, "hmac-token-secret-name", "", "Name of the secret on the cluster containing the GitHub HMAC secret.")
 	fs.StringVar(&o.hmacTokenKey, "hmac-token-key", "", "Key of the hmac token in the secret.")
+	fs.DurationVar(&o.interval, "interval", 0, "Interval at which the tokens, secret and webhooks are reconciled. Zero reconciles once and exits.")
 	fs.Parse(args)
 	return o
 }
@@ -108,6 +115,10 @@ type client struct {
 
 	currentHMACMap map[string]github.HMACsForRepo
 	newHMACConfig  config.ManagedWebhooks
+	// secretResourceVersion is the resource version of the hmac secret the
+	// current hmac map was read from. Updates are conditional on it, so that
+	// tokens written by a concurrent run are never overwritten.
+	secretResourceVersion string
 
 	hmacMapForBatchUpdate map[string]string
 	hmacMapForRecovery    map[string]github.HMACsForRepo
@@ -130,16 +141,33 @@ func main() {
 	if err != nil {
 		logrus.WithError(err).Fatal("Error starting config agent.")
 	}
-	newHMACConfig := configAgent.Config().ManagedWebhooks
 
 	gc, err := o.github.GitHubClient(o.dryRun)
 	if err != nil {
 		logrus.WithError(err).Fatal("Error creating github client")
 	}
 
-	currentHMACYaml, err := getCurrentHMACTokens(kc, o.hmacTokenSecretNamespace, o.hmacTokenSecretName, o.hmacTokenKey)
+	if o.interval == 0 {
+		if err := reconcile(o, kc, gc, configAgent.Config().ManagedWebhooks); err != nil {
+			logrus.WithError(err).Fatal("Error reconciling hmac tokens.")
+		}
+		return
+	}
+
+	interrupts.TickLiteral(func() {
+		if err := reconcile(o, kc, gc, configAgent.Config().ManagedWebhooks); err != nil {
+			logrus.WithError(err).Error("Error reconciling hmac tokens.")
+		}
+	}, o.interval)
+	interrupts.WaitForGracefulShutdown()
+}
+
+// reconcile brings the hmac tokens in the secret and the webhooks in line
+// with the managed webhooks config.
+func reconcile(o options, kc kubernetes.Interface, gc github.HookClient, newHMACConfig config.ManagedWebhooks) error {
+	currentHMACYaml, resourceVersion, err := getCurrentHMACTokens(kc, o.hmacTokenSecretNamespace, o.hmacTokenSecretName, o.hmacTokenKey)
 	if err != nil {
-		logrus.WithError(err).Fatal("Error getting the current hmac yaml.")
+		return fmt.Errorf("error getting the current hmac yaml: %w", err)
 	}
 
 	currentHMACMap := map[string]github.HMACsForRepo{}
@@ -147,7 +175,7 @@ func main() {
 		// When the token is still a single global token, respect_legacy_global_token must be set to true before running this tool.
 		// This can prevent the global token from being deleted by mistake before users migrate all repos/orgs to use auto-generated private tokens.
 		if !newHMACConfig.RespectLegacyGlobalToken {
-			logrus.Fatal("respect_legacy_global_token must be set to true before the hmac tool is run for the first time.")
+			return errors.New("respect_legacy_global_token must be set to true before the hmac tool is run for the first time")
 		}
 
 		logrus.WithError(err).Error("Couldn't unmarshal the hmac secret as hierarchical file. Parsing as a single global token and writing it back to the secret.")
@@ -165,17 +193,19 @@ func main() {
 
 		currentHMACMap:        currentHMACMap,
 		newHMACConfig:         newHMACConfig,
+		secretResourceVersion: resourceVersion,
 		hmacMapForBatchUpdate: map[string]string{},
 		hmacMapForRecovery:    map[string]github.HMACsForRepo{},
 	}
 
 	if err := c.handleInvitation(); err != nil {
-		logrus.WithError(err).Fatal("Error accepting invitations.")
+		return fmt.Errorf("error accepting invitations: %w", err)
 	}
 
 	if err := c.handleConfigUpdate(); err != nil {
-		logrus.WithError(err).Fatal("Error handling hmac config update.")
+		return fmt.Errorf("error handling hmac config update: %w", err)
 	}
+	return nil
 }
 
 func (c *client) handleInvitation() error {
@@ -275,10 +305,13 @@ func (c *client) handleConfigUpdate() error {
 	if err := c.updateHMACTokenSecret(); err != nil {
 		return fmt.Errorf("error updating hmac tokens: %w", err)
 	}
-	// HACK: waiting for the hmac k8s secret update to propagate to the pods that are using the secret,
-	// so that components like hook can start respecting the new hmac values.
-	time.Sleep(20 * time.Second)
-	errs := c.batchOnboardNewTokenForRepos()
+	var errs []error
+	if len(c.hmacMapForBatchUpdate) > 0 {
+		// HACK: waiting for the hmac k8s secret update to propagate to the pods that are using the secret,
+		// so that components like hook can start respecting the new hmac values.
+		time.Sleep(20 * time.Second)
+		errs = c.batchOnboardNewTokenForRepos()
+	}
 
 	// Do necessary cleanups after the token and webhook updates are done.
 	if err := c.cleanup(); err != nil {
@@ -341,12 +374,19 @@ func (c *client) handleAddedRepo(added map[string]config.ManagedWebhookInfo) err
 }
 
 func (c *client) handledRotatedRepo(rotated map[string]config.ManagedWebhookInfo) error {
-	// For each rotated repo, we only onboard a new token when none of the existing tokens is created after user specified time.
+	// For each rotated repo, we only onboard a new token when none of the existing tokens is created after user specified time,
+	// or after the rotation interval ago if rotations are scheduled.
 	for repo, hmacConfig := range rotated {
+		createdAfter := hmacConfig.TokenCreatedAfter
+		if interval := c.newHMACConfig.RotationInterval; interval != nil {
+			if scheduled := time.Now().Add(-interval.Duration); scheduled.After(createdAfter) {
+				createdAfter = scheduled
+			}
+		}
 		needsRotation := true
 		for _, token := range c.currentHMACMap[repo] {
 			// If the existing token is created after the user specified time, we do not need to rotate it.
-			if token.CreatedAt.After(hmacConfig.TokenCreatedAfter) {
+			if token.CreatedAt.After(createdAfter) {
 				needsRotation = false
 				break
 			}
@@ -452,14 +492,18 @@ func (c *client) updateHMACTokenSecret() error {
 	sec := &corev1.Secret{}
 	sec.Name = c.options.hmacTokenSecretName
 	sec.Namespace = c.options.hmacTokenSecretNamespace
+	sec.ResourceVersion = c.secretResourceVersion
 	sec.StringData = map[string]string{c.options.hmacTokenKey: string(secretContent)}
-	if _, err = c.kubernetesClient.CoreV1().Secrets(c.options.hmacTokenSecretNamespace).Update(context.TODO(), sec, metav1.UpdateOptions{}); err != nil {
+	updated, err := c.kubernetesClient.CoreV1().Secrets(c.options.hmacTokenSecretNamespace).Update(context.TODO(), sec, metav1.UpdateOptions{})
+	if err != nil {
 		return fmt.Errorf("error updating the secret: %w", err)
 	}
+	c.secretResourceVersion = updated.ResourceVersion
 	return nil
 }
 
 // pruneOldTokens removes all but most recent token from token config.
+// With a grace period, the older tokens are kept until they expire instead.
 func (c *client) pruneOldTokens(repo string) {
 	tokens := c.currentHMACMap[repo]
 	if len(tokens) <= 1 {
@@ -467,11 +511,29 @@ func (c *client) pruneOldTokens(repo string) {
 		return
 	}
 
-	logrus.WithField("repo", repo).Debugf("Token size is %d, prune to 1", len(tokens))
 	sort.SliceStable(tokens, func(i, j int) bool {
 		return tokens[i].CreatedAt.After(tokens[j].CreatedAt)
 	})
-	c.currentHMACMap[repo] = tokens[:1]
+	if c.newHMACConfig.GracePeriod == nil || c.newHMACConfig.GracePeriod.Duration == 0 {
+		logrus.WithField("repo", repo).Debugf("Token size is %d, prune to 1", len(tokens))
+		c.currentHMACMap[repo] = tokens[:1]
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(c.newHMACConfig.GracePeriod.Duration)
+	pruned := tokens[:1]
+	for _, token := range tokens[1:] {
+		if token.Expired(now) {
+			continue
+		}
+		if token.ExpiresAt == nil {
+			token.ExpiresAt = &expiresAt
+		}
+		pruned = append(pruned, token)
+	}
+	logrus.WithField("repo", repo).Debugf("Token size is %d, prune to %d", len(tokens), len(pruned))
+	c.currentHMACMap[repo] = pruned
 }
 
 // generateNewHMACToken generates a hex encoded crypto random string of length 40.
@@ -483,18 +545,19 @@ func generateNewHMACToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-// getCurrentHMACTokens returns the hmac tokens currently configured in the cluster.
-func getCurrentHMACTokens(kc kubernetes.Interface, ns, secName, key string) ([]byte, error) {
+// getCurrentHMACTokens returns the hmac tokens currently configured in the cluster, along with
+// the resource version of the secret.
+func getCurrentHMACTokens(kc kubernetes.Interface, ns, secName, key string) ([]byte, string, error) {
 	sec, err := kc.CoreV1().Secrets(ns).Get(context.TODO(), secName, metav1.GetOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
-		return nil, fmt.Errorf("error getting hmac secret %q: %w", secName, err)
+		return nil, "", fmt.Errorf("error getting hmac secret %q: %w", secName, err)
 	}
 	if err == nil {
 		buf, ok := sec.Data[key]
 		if ok {
-			return buf, nil
+			return buf, sec.ResourceVersion, nil
 		}
-		return nil, fmt.Errorf("error getting key %q from the hmac secret %q", key, secName)
+		return nil, "", fmt.Errorf("error getting key %q from the hmac secret %q", key, secName)
 	}
-	return nil, fmt.Errorf("error getting hmac token values: %w", err)
+	return nil, "", fmt.Errorf("error getting hmac token values: %w", err)
 }
@@ -18,13 +18,19 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"sigs.k8s.io/prow/cmd/hmac/fakeghhook"
 	"sigs.k8s.io/prow/pkg/config"
@@ -219,6 +225,80 @@ func TestPruneOldTokens(t *testing.T) {
 	}
 }
 
+func TestPruneOldTokensWithGracePeriod(t *testing.T) {
+	time1, _ := time.Parse(time.RFC3339, "2020-01-05T19:07:08+00:00")
+	time2, _ := time.Parse(time.RFC3339, "2020-02-05T19:07:08+00:00")
+	time3, _ := time.Parse(time.RFC3339, "2020-03-05T19:07:08+00:00")
+	expired := time.Now().Add(-time.Minute)
+	valid := time.Now().Add(time.Minute)
+
+	c := &client{
+		newHMACConfig: config.ManagedWebhooks{GracePeriod: &metav1.Duration{Duration: time.Hour}},
+		currentHMACMap: map[string]github.HMACsForRepo{
+			"org1/repo1": []github.HMACToken{
+				{Value: "rand-val1", CreatedAt: time1, ExpiresAt: &expired},
+				{Value: "rand-val2", CreatedAt: time2},
+				{Value: "rand-val3", CreatedAt: time3},
+			},
+			"org1/repo2": []github.HMACToken{
+				{Value: "rand-val1", CreatedAt: time1, ExpiresAt: &valid},
+				{Value: "rand-val2", CreatedAt: time2},
+			},
+		},
+	}
+	before := time.Now()
+	c.pruneOldTokens("org1/repo1")
+	c.pruneOldTokens("org1/repo2")
+
+	repo1 := c.currentHMACMap["org1/repo1"]
+	if len(repo1) != 2 || repo1[0].Value != "rand-val3" || repo1[1].Value != "rand-val2" {
+		t.Fatalf("expected the expired token to be pruned and the replaced one to be kept, got %#v", repo1)
+	}
+	if repo1[0].ExpiresAt != nil {
+		t.Errorf("expected the current token not to expire, but it expires at %s", repo1[0].ExpiresAt)
+	}
+	if repo1[1].ExpiresAt == nil || repo1[1].ExpiresAt.Before(before.Add(time.Hour)) {
+		t.Errorf("expected the replaced token to expire after the grace period, got %v", repo1[1].ExpiresAt)
+	}
+
+	expectedRepo2 := github.HMACsForRepo{
+		{Value: "rand-val2", CreatedAt: time2},
+		{Value: "rand-val1", CreatedAt: time1, ExpiresAt: &valid},
+	}
+	if diff := cmp.Diff(expectedRepo2, c.currentHMACMap["org1/repo2"]); diff != "" {
+		t.Errorf("expected the expiry of replaced tokens to be kept (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateHMACTokenSecret(t *testing.T) {
+	kc := fake.NewSimpleClientset()
+	var updatedAt []string
+	kc.PrependReactor("update", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sec := action.(k8stesting.UpdateAction).GetObject().(*corev1.Secret)
+		updatedAt = append(updatedAt, sec.ResourceVersion)
+		updated := sec.DeepCopy()
+		updated.ResourceVersion = fmt.Sprintf("%s+", sec.ResourceVersion)
+		return true, updated, nil
+	})
+
+	c := &client{
+		options:               options{hmacTokenSecretNamespace: "default", hmacTokenSecretName: "hmac-token", hmacTokenKey: "hmac"},
+		kubernetesClient:      kc,
+		currentHMACMap:        map[string]github.HMACsForRepo{"org": {{Value: "current"}}},
+		secretResourceVersion: "1",
+	}
+	// The updates of a run are conditional on the version the tokens were
+	// read at, and then on the version of the previous update.
+	for i := 0; i < 2; i++ {
+		if err := c.updateHMACTokenSecret(); err != nil {
+			t.Fatalf("unexpected error updating the secret: %v", err)
+		}
+	}
+	if diff := cmp.Diff([]string{"1", "1+"}, updatedAt); diff != "" {
+		t.Errorf("secret updates were not conditional on the expected resource versions (-want +got):\n%s", diff)
+	}
+}
+
 func TestGenerateNewHMACToken(t *testing.T) {
 	token1, err := generateNewHMACToken()
 	if err != nil {
@@ -435,6 +515,7 @@ func TestHandleRotatedRepo(t *testing.T) {
 	cases := []struct {
 		name                         string
 		toRotate                     map[string]config.ManagedWebhookInfo
+		rotationInterval             *metav1.Duration
 		currentHMACs                 map[string]github.HMACsForRepo
 		currentHMACMapForBatchUpdate map[string]string
 		expectedHMACsSize            map[string]int
@@ -510,12 +591,46 @@ func TestHandleRotatedRepo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "test a repo whose token is older than the rotation interval",
+			toRotate: map[string]config.ManagedWebhookInfo{
+				"repo1": {TokenCreatedAfter: pastTime},
+				"repo2": {TokenCreatedAfter: pastTime},
+			},
+			rotationInterval: &metav1.Duration{Duration: 24 * time.Hour},
+			currentHMACs: map[string]github.HMACsForRepo{
+				"repo1": []github.HMACToken{
+					{
+						Value:     "rand-val1",
+						CreatedAt: pastTime.Add(1 * time.Hour),
+					},
+				},
+				"repo2": []github.HMACToken{
+					{
+						Value:     "rand-val2",
+						CreatedAt: time.Now().Add(-1 * time.Hour),
+					},
+				},
+			},
+			currentHMACMapForBatchUpdate: map[string]string{},
+			expectedHMACsSize:            map[string]int{"repo1": 2, "repo2": 1},
+			expectedReposForBatchUpdate:  []string{"repo1"},
+			expectedHMACMapForRecovery: map[string]github.HMACsForRepo{
+				"repo1": []github.HMACToken{
+					{
+						Value:     "rand-val1",
+						CreatedAt: pastTime.Add(1 * time.Hour),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			c := &client{
 				currentHMACMap:        tc.currentHMACs,
+				newHMACConfig:         config.ManagedWebhooks{RotationInterval: tc.rotationInterval},
 				hmacMapForBatchUpdate: tc.currentHMACMapForBatchUpdate,
 				hmacMapForRecovery:    map[string]github.HMACsForRepo{},
 			}
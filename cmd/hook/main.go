@@ -17,7 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"strconv"
@@ -27,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/prow/pkg/pjutil/pprof"
 
+	"sigs.k8s.io/prow/pkg/audit"
 	"sigs.k8s.io/prow/pkg/bugzilla"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/config/secret"
@@ -49,6 +53,7 @@ import (
 	"sigs.k8s.io/prow/pkg/repoowners"
 	"sigs.k8s.io/prow/pkg/slack"
 
+	"sigs.k8s.io/prow/pkg/tracing"
 	_ "sigs.k8s.io/prow/pkg/version"
 )
 
@@ -71,17 +76,25 @@ type options struct {
 	bugzilla               prowflagutil.BugzillaOptions
 	instrumentationOptions prowflagutil.InstrumentationOptions
 	jira                   prowflagutil.JiraOptions
+	audit                  prowflagutil.AuditOptions
+	adminAccess            prowflagutil.AdminAccessOptions
 
 	webhookSecretFile string
 	slackTokenFile    string
+
+	canaryConfigPath    string
+	canaryJobConfigPath string
 }
 
 func (o *options) Validate() error {
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig, &o.audit, &o.adminAccess} {
 		if err := group.Validate(o.dryRun); err != nil {
 			return err
 		}
 	}
+	if o.canaryJobConfigPath != "" && o.canaryConfigPath == "" {
+		return errors.New("--canary-job-config-path requires --canary-config-path")
+	}
 
 	return nil
 }
@@ -94,12 +107,14 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate.")
 	fs.DurationVar(&o.gracePeriod, "grace-period", 180*time.Second, "On shutdown, try to handle remaining events for the specified duration. ")
 	o.pluginsConfig.PluginConfigPathDefault = "/etc/plugins/plugins.yaml"
-	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.instrumentationOptions, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig} {
+	for _, group := range []flagutil.OptionGroup{&o.kubernetes, &o.github, &o.bugzilla, &o.instrumentationOptions, &o.jira, &o.githubEnablement, &o.config, &o.pluginsConfig, &o.audit, &o.adminAccess} {
 		group.AddFlags(fs)
 	}
 
 	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret.")
 	fs.StringVar(&o.slackTokenFile, "slack-token-file", "", "Path to the file containing the Slack token to use.")
+	fs.StringVar(&o.canaryConfigPath, "canary-config-path", "", "Path to the canary revision of the Prow config. If set, it is used for the repos listed in canary.repos of the stable config.")
+	fs.StringVar(&o.canaryJobConfigPath, "canary-job-config-path", "", "Path to the canary revision of the job config.")
 	fs.Parse(args)
 	return o
 }
@@ -118,6 +133,14 @@ func main() {
 	}
 	o.kubernetes.SetDisabledClusters(sets.New[string](configAgent.Config().DisabledClusters...))
 
+	var canaryConfigAgent *config.Agent
+	if o.canaryConfigPath != "" {
+		canaryConfigAgent = &config.Agent{}
+		if err := canaryConfigAgent.Start(o.canaryConfigPath, o.canaryJobConfigPath, nil, o.config.SupplementalProwConfigsFileNameSuffix, config.MarkCanary); err != nil {
+			logrus.WithError(err).Fatal("Error starting canary config agent.")
+		}
+	}
+
 	var tokens []string
 
 	// Append the path of hmac and github secrets.
@@ -223,6 +246,14 @@ func main() {
 	}
 	ownersClient := repoowners.NewClient(gitClient, githubClient, mdYAMLEnabled, skipCollaborators, ownersDirDenylist, resolver)
 
+	auditor, err := o.audit.Auditor(context.Background(), "hook", nil)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error creating auditor.")
+	}
+	if auditor != nil {
+		auditConfigReloads(configAgent, auditor)
+	}
+
 	clientAgent := &plugins.ClientAgent{
 		GitHubClient:              githubClient,
 		ProwJobClient:             prowJobClient,
@@ -233,6 +264,7 @@ func main() {
 		OwnersClient:              ownersClient,
 		BugzillaClient:            bugzillaClient,
 		JiraClient:                jiraClient,
+		Auditor:                   auditor,
 	}
 
 	promMetrics := githubeventserver.NewMetrics()
@@ -242,14 +274,18 @@ func main() {
 	// Expose prometheus metrics
 	metrics.ExposeMetrics("hook", configAgent.Config().PushGateway, o.instrumentationOptions.MetricsPort)
 	pprof.Instrument(o.instrumentationOptions)
+	if err := tracing.Init("hook", o.instrumentationOptions); err != nil {
+		logrus.WithError(err).Fatal("Error initializing tracing.")
+	}
 
 	server := &hook.Server{
-		ClientAgent:    clientAgent,
-		ConfigAgent:    configAgent,
-		Plugins:        pluginAgent,
-		Metrics:        promMetrics,
-		RepoEnabled:    o.githubEnablement.EnablementChecker(),
-		TokenGenerator: secret.GetTokenGenerator(o.webhookSecretFile),
+		ClientAgent:       clientAgent,
+		ConfigAgent:       configAgent,
+		CanaryConfigAgent: canaryConfigAgent,
+		Plugins:           pluginAgent,
+		Metrics:           promMetrics,
+		RepoEnabled:       o.githubEnablement.EnablementChecker(),
+		TokenGenerator:    secret.GetTokenGenerator(o.webhookSecretFile),
 	}
 	interrupts.OnInterrupt(func() {
 		server.GracefulShutdown()
@@ -266,7 +302,11 @@ func main() {
 	hookMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
 
 	// For /hook, handle a webhook normally.
-	hookMux.Handle(o.webhookPath, server)
+	adminPolicy, err := o.adminAccess.Policy()
+	if err != nil {
+		logrus.WithError(err).Fatal("Error creating admin access policy.")
+	}
+	hookMux.Handle(o.webhookPath, adminPolicy.Protect(server))
 	// Serve plugin help information from /plugin-help.
 	hookMux.Handle("/plugin-help", pluginhelp.NewHelpAgent(pluginAgent, githubClient))
 
@@ -274,5 +314,41 @@ func main() {
 
 	health.ServeReady()
 
-	interrupts.ListenAndServe(httpServer, o.gracePeriod)
+	if err := o.adminAccess.ListenAndServe(httpServer, o.gracePeriod); err != nil {
+		logrus.WithError(err).Fatal("Error starting server.")
+	}
+}
+
+// auditConfigReloads emits an audit event whenever the config is reloaded.
+func auditConfigReloads(configAgent *config.Agent, auditor *audit.Auditor) {
+	deltas := make(chan config.Delta)
+	configAgent.Subscribe(deltas)
+	go func() {
+		for delta := range deltas {
+			auditor.Emit(context.Background(), audit.Event{
+				Action:  audit.ActionConfigReload,
+				Details: configReloadDetails(delta),
+			})
+		}
+	}()
+}
+
+// configReloadDetails summarizes how the jobs changed with a config reload.
+func configReloadDetails(delta config.Delta) map[string]string {
+	count := func(jc config.JobConfig) (presubmits, postsubmits int) {
+		for _, jobs := range jc.PresubmitsStatic {
+			presubmits += len(jobs)
+		}
+		for _, jobs := range jc.PostsubmitsStatic {
+			postsubmits += len(jobs)
+		}
+		return presubmits, postsubmits
+	}
+	presubmitsBefore, postsubmitsBefore := count(delta.Before.JobConfig)
+	presubmitsAfter, postsubmitsAfter := count(delta.After.JobConfig)
+	return map[string]string{
+		"presubmits":  fmt.Sprintf("%d -> %d", presubmitsBefore, presubmitsAfter),
+		"postsubmits": fmt.Sprintf("%d -> %d", postsubmitsBefore, postsubmitsAfter),
+		"periodics":   fmt.Sprintf("%d -> %d", len(delta.Before.Periodics), len(delta.After.Periodics)),
+	}
 }
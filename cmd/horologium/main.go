@@ -37,6 +37,7 @@ import (
 	"sigs.k8s.io/prow/pkg/interrupts"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/moonraker"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/pjutil/pprof"
 )
@@ -49,17 +50,21 @@ type options struct {
 	config configflagutil.ConfigOptions
 
 	kubernetes             prowflagutil.KubernetesOptions
+	github                 prowflagutil.GitHubOptions
 	instrumentationOptions prowflagutil.InstrumentationOptions
 	controllerManager      prowflagutil.ControllerManagerOptions
 	dryRun                 bool
+	inRepoPeriodics        bool
 }
 
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	var o options
 
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether or not to make mutating API calls to Kubernetes.")
+	fs.BoolVar(&o.inRepoPeriodics, "in-repo-periodics", false, "Whether to also trigger the periodics defined in-repo by the repositories listed in in_repo_config.periodics.")
 	o.config.AddFlags(fs)
 	o.kubernetes.AddFlags(fs)
+	o.github.AddFlags(fs)
 	o.instrumentationOptions.AddFlags(fs)
 	o.controllerManager.TimeoutListingProwJobsDefault = 60 * time.Second
 	o.controllerManager.AddFlags(fs)
@@ -69,7 +74,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 }
 
 func (o *options) Validate() error {
-	for _, group := range []pkgflagutil.OptionGroup{&o.kubernetes, &o.config, &o.controllerManager} {
+	for _, group := range []pkgflagutil.OptionGroup{&o.kubernetes, &o.github, &o.config, &o.controllerManager} {
 		if err := group.Validate(o.dryRun); err != nil {
 			return err
 		}
@@ -121,6 +126,30 @@ func main() {
 		logrus.Fatal("Timed out waiting for cache sync")
 	}
 
+	var ircg config.InRepoConfigGetter
+	var githubClient refClient
+	if o.inRepoPeriodics {
+		githubClient, err = o.github.GitHubClient(o.dryRun)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error getting GitHub client.")
+		}
+		if o.config.MoonrakerAddress != "" {
+			ircg, err = moonraker.NewClient(o.config.MoonrakerAddress, configAgent)
+			if err != nil {
+				logrus.WithError(err).Fatal("Error getting Moonraker client.")
+			}
+		} else {
+			gitClient, err := o.github.GitClientFactory("", &o.config.InRepoConfigCacheDirBase, o.dryRun, false)
+			if err != nil {
+				logrus.WithError(err).Fatal("Error getting Git client.")
+			}
+			ircg, err = config.NewInRepoConfigCache(o.config.InRepoConfigCacheSize, configAgent, gitClient)
+			if err != nil {
+				logrus.WithError(err).Fatal("Error creating InRepoConfigCache.")
+			}
+		}
+	}
+
 	// start a cron
 	cr := cron.New()
 	cr.Start()
@@ -133,13 +162,66 @@ func main() {
 	}
 	interrupts.TickLiteral(func() {
 		start := time.Now()
-		if err := sync(cluster.GetClient(), configAgent.Config(), cr, start); err != nil {
+		cfg := configAgent.Config()
+		if ircg != nil {
+			cfg = withInRepoPeriodics(cfg, ircg, githubClient)
+		}
+		if err := sync(cluster.GetClient(), cfg, cr, start); err != nil {
 			logrus.WithError(err).Error("Error syncing periodic jobs.")
 		}
 		logrus.WithField("duration", time.Since(start)).Info("Synced periodic jobs")
 	}, tickInterval)
 }
 
+type refClient interface {
+	GetRef(org, repo, ref string) (string, error)
+}
+
+// withInRepoPeriodics returns a copy of cfg whose periodics also include the
+// ones defined in-repo by the repositories listed in in_repo_config.periodics.
+// Repositories whose config cannot be loaded are skipped, so that they cannot
+// block the periodics of the central config. Periodics whose name is defined
+// by more than one repository are skipped as well, as horologium tells the
+// runs of periodics apart by name only.
+func withInRepoPeriodics(cfg *config.Config, ircg config.InRepoConfigGetter, ghc refClient) *config.Config {
+	if len(cfg.InRepoConfig.Periodics) == 0 {
+		return cfg
+	}
+	var inRepo []config.Periodic
+	repos := map[string]sets.Set[string]{}
+	for _, identifier := range sets.List(sets.KeySet(cfg.InRepoConfig.Periodics)) {
+		branch := cfg.InRepoConfig.Periodics[identifier].Branch
+		logger := logrus.WithFields(logrus.Fields{"repo": identifier, "branch": branch})
+		orgRepo := config.NewOrgRepo(identifier)
+		baseSHAGetter := func() (string, error) {
+			return ghc.GetRef(orgRepo.Org, orgRepo.Repo, "heads/"+branch)
+		}
+		prowYAML, err := ircg.GetInRepoConfig(identifier, branch, baseSHAGetter)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get in-repo periodics.")
+			continue
+		}
+		for _, periodic := range prowYAML.Periodics {
+			if repos[periodic.Name] == nil {
+				repos[periodic.Name] = sets.New[string]()
+			}
+			repos[periodic.Name].Insert(identifier)
+		}
+		inRepo = append(inRepo, prowYAML.Periodics...)
+	}
+	periodics := append([]config.Periodic(nil), cfg.Periodics...)
+	for _, periodic := range inRepo {
+		if repos[periodic.Name].Len() > 1 {
+			logrus.WithFields(logrus.Fields{"job": periodic.Name, "repos": sets.List(repos[periodic.Name])}).Error("In-repo periodic is defined by more than one repository, skipping it.")
+			continue
+		}
+		periodics = append(periodics, periodic)
+	}
+	withPeriodics := &config.Config{JobConfig: cfg.JobConfig, ProwConfig: cfg.ProwConfig}
+	withPeriodics.Periodics = periodics
+	return withPeriodics
+}
+
 type cronClient interface {
 	SyncConfig(cfg *config.Config) error
 	QueuedJobs() []string
@@ -171,6 +253,23 @@ func sync(prowJobClient ctrlruntimeclient.Client, cfg *config.Config, cr cronCli
 
 		var shouldTrigger = false
 		switch {
+		case p.RunAt != nil: // one-shot run at a fixed time
+			runAt := p.RunAt.Time
+			if now.Before(runAt) {
+				logger.WithField("run-at", runAt).Debug("Scheduled run time has not yet been reached.")
+				continue
+			}
+			if previousFound && !j.Status.StartTime.Time.Before(runAt) {
+				// The scheduled run has already been triggered.
+				continue
+			}
+			if !previousFound && cfg.Sinker.MaxProwJobAge != nil && now.Sub(runAt) > cfg.Sinker.MaxProwJobAge.Duration {
+				// Any run we triggered could have been garbage collected since, so
+				// don't risk running the job a second time.
+				logger.WithField("run-at", runAt).Debug("Scheduled run time is older than max_prowjob_age, skipping.")
+				continue
+			}
+			shouldTrigger = !previousFound || j.Complete()
 		case p.Cron == "": // no cron expression is set, we use interval to trigger
 			if j.Complete() {
 				intervalRef := j.Status.StartTime.Time
@@ -19,10 +19,12 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -356,6 +358,108 @@ func TestSyncCron(t *testing.T) {
 	}
 }
 
+// Test sync periodic job scheduled to run once with run_at.
+func TestSyncRunAt(t *testing.T) {
+	testcases := []struct {
+		testName        string
+		runAtAgo        time.Duration
+		jobName         string
+		jobComplete     bool
+		jobStartTimeAgo time.Duration
+		shouldStart     bool
+	}{
+		{
+			testName:    "run_at in the future",
+			runAtAgo:    -time.Hour,
+			shouldStart: false,
+		},
+		{
+			testName:    "run_at in the future, no previous job",
+			runAtAgo:    -time.Minute,
+			jobName:     "not-j",
+			jobComplete: true,
+			shouldStart: false,
+		},
+		{
+			testName:    "run_at passed, no job",
+			runAtAgo:    time.Minute,
+			shouldStart: true,
+		},
+		{
+			testName:        "run_at passed, job from before run_at finished",
+			runAtAgo:        time.Minute,
+			jobName:         "j",
+			jobComplete:     true,
+			jobStartTimeAgo: time.Hour,
+			shouldStart:     true,
+		},
+		{
+			testName:        "run_at passed, job from before run_at still running",
+			runAtAgo:        time.Minute,
+			jobName:         "j",
+			jobComplete:     false,
+			jobStartTimeAgo: time.Hour,
+			shouldStart:     false,
+		},
+		{
+			testName:        "run_at passed, scheduled run already triggered",
+			runAtAgo:        time.Minute,
+			jobName:         "j",
+			jobComplete:     true,
+			jobStartTimeAgo: time.Second,
+			shouldStart:     false,
+		},
+		{
+			testName:    "run_at older than max_prowjob_age, no job",
+			runAtAgo:    48 * time.Hour,
+			shouldStart: false,
+		},
+	}
+	for _, tc := range testcases {
+		now := time.Now()
+		cfg := config.Config{
+			ProwConfig: config.ProwConfig{
+				ProwJobNamespace: "prowjobs",
+				Sinker:           config.Sinker{MaxProwJobAge: &metav1.Duration{Duration: 24 * time.Hour}},
+			},
+			JobConfig: config.JobConfig{
+				Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "j"}, RunAt: &metav1.Time{Time: now.Add(-tc.runAtAgo)}}},
+			},
+		}
+
+		var jobs []client.Object
+		if tc.jobName != "" {
+			job := &prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "with-run-at",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Type: prowapi.PeriodicJob,
+					Job:  tc.jobName,
+				},
+				Status: prowapi.ProwJobStatus{
+					StartTime: metav1.NewTime(now.Add(-tc.jobStartTimeAgo)),
+				},
+			}
+			complete := metav1.NewTime(now.Add(-time.Millisecond))
+			if tc.jobComplete {
+				job.Status.CompletionTime = &complete
+			}
+			jobs = append(jobs, job)
+		}
+		fakeProwJobClient := newCreateTrackingClient(jobs)
+		fc := &fakeCron{}
+		if err := sync(fakeProwJobClient, &cfg, fc, now); err != nil {
+			t.Fatalf("For case %s, didn't expect error: %v", tc.testName, err)
+		}
+
+		if sawCreation := fakeProwJobClient.sawCreate; tc.shouldStart != sawCreation {
+			t.Errorf("For case %s, expected creation %t, got %t", tc.testName, tc.shouldStart, sawCreation)
+		}
+	}
+}
+
 func TestFlags(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -427,6 +531,7 @@ func TestFlags(t *testing.T) {
 				dryRun:                 true,
 				instrumentationOptions: flagutil.DefaultInstrumentationOptions(),
 			}
+			expected.github.AddFlags(flag.NewFlagSet("fake-flags", flag.PanicOnError))
 			if tc.expected != nil {
 				tc.expected(expected)
 			}
@@ -479,3 +584,56 @@ func newCreateTrackingClient(objs []client.Object) *createTrackingClient {
 		created: make([]ctrlruntimeclient.Object, 0),
 	}
 }
+
+type fakeInRepoConfigGetter struct {
+	config.InRepoConfigGetter
+	prowYAMLs map[string]*config.ProwYAML
+}
+
+func (f *fakeInRepoConfigGetter) GetInRepoConfig(identifier, baseBranch string, baseSHAGetter config.RefGetter, _ ...config.RefGetter) (*config.ProwYAML, error) {
+	sha, err := baseSHAGetter()
+	if err != nil {
+		return nil, err
+	}
+	prowYAML, ok := f.prowYAMLs[identifier+"@"+baseBranch+":"+sha]
+	if !ok {
+		return nil, fmt.Errorf("no config for %s", identifier)
+	}
+	return prowYAML, nil
+}
+
+type fakeRefClient struct{}
+
+func (fakeRefClient) GetRef(org, repo, ref string) (string, error) {
+	return org + "/" + repo + "/" + ref, nil
+}
+
+func TestWithInRepoPeriodics(t *testing.T) {
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "central"}}}},
+		ProwConfig: config.ProwConfig{InRepoConfig: config.InRepoConfig{Periodics: map[string]config.InRepoPeriodics{
+			"org/repo":   {Branch: "main"},
+			"org/other":  {Branch: "main"},
+			"org/broken": {Branch: "main"},
+		}}},
+	}
+	ircg := &fakeInRepoConfigGetter{prowYAMLs: map[string]*config.ProwYAML{
+		"org/repo@main:org/repo/heads/main": {Periodics: []config.Periodic{
+			{JobBase: config.JobBase{Name: "in-repo"}},
+			{JobBase: config.JobBase{Name: "same-name"}},
+		}},
+		"org/other@main:org/other/heads/main": {Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "same-name"}}}},
+	}}
+
+	actual := withInRepoPeriodics(cfg, ircg, fakeRefClient{})
+	var names []string
+	for _, p := range actual.Periodics {
+		names = append(names, p.Name)
+	}
+	if diff := cmp.Diff([]string{"central", "in-repo"}, names); diff != "" {
+		t.Errorf("unexpected periodics (-want +got):\n%s", diff)
+	}
+	if len(cfg.Periodics) != 1 {
+		t.Errorf("expected the original config to be left untouched, got %d periodics", len(cfg.Periodics))
+	}
+}
@@ -19,6 +19,7 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
 
@@ -27,7 +28,10 @@ import (
 	"sigs.k8s.io/prow/pkg/jenkins"
 )
 
-var reJenkinsJobURL = regexp.MustCompile(`^(/?job)/([A-Za-z0-9-._]([A-Za-z0-9-._/]*[A-Za-z0-9-_])?)/(\d+)/consoleText$`)
+var (
+	reJenkinsJobURL      = regexp.MustCompile(`^(/?job)/([A-Za-z0-9-._]([A-Za-z0-9-._/]*[A-Za-z0-9-_])?)/(\d+)/consoleText$`)
+	reJenkinsArtifactURL = regexp.MustCompile(`^(/?job)/([A-Za-z0-9-._]([A-Za-z0-9-._/]*?[A-Za-z0-9-_])?)/(\d+)/(api/json|artifact/(.+))$`)
+)
 
 func handleLog(jc *jenkins.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -41,22 +45,27 @@ func handleLog(jc *jenkins.Client) http.HandlerFunc {
 			return
 		}
 
-		// Needs to get Jenkins logs.
-		if !strings.HasSuffix(r.URL.Path, "consoleText") {
-			http.Error(w, "403 Forbidden: Request may only access raw Jenkins logs", http.StatusForbidden)
+		// Needs to get Jenkins logs or artifacts.
+		var realPath string
+		var err error
+		switch {
+		case strings.HasSuffix(r.URL.Path, "consoleText"):
+			realPath, err = getRealJenkinsLogPath(r.URL.Path)
+		case reJenkinsArtifactURL.MatchString(r.URL.Path):
+			realPath, err = getRealJenkinsArtifactPath(r.URL.Path)
+		default:
+			http.Error(w, "403 Forbidden: Request may only access raw Jenkins logs and artifacts", http.StatusForbidden)
 			return
 		}
-
-		realPath, err := getRealJenkinsLogPath(r.URL.Path)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Log not found: %v", err), http.StatusNotFound)
+			http.Error(w, fmt.Sprintf("Not found: %v", err), http.StatusNotFound)
 			return
 		}
 
 		log, err := jc.GetSkipMetrics(realPath)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Log not found: %v", err), http.StatusNotFound)
-			logrus.WithError(err).Warning(fmt.Sprintf("Cannot get logs from Jenkins (GET %s).", realPath))
+			http.Error(w, fmt.Sprintf("Not found: %v", err), http.StatusNotFound)
+			logrus.WithError(err).Warning(fmt.Sprintf("Cannot get logs or artifacts from Jenkins (GET %s).", realPath))
 			return
 		}
 
@@ -80,3 +89,30 @@ func getRealJenkinsLogPath(path string) (string, error) {
 
 	return realPath, nil
 }
+
+// getRealJenkinsArtifactPath maps the path of the list of artifacts of a build,
+// which is limited to their names, or of one of them to its path on Jenkins.
+func getRealJenkinsArtifactPath(path string) (string, error) {
+	jobMatches := reJenkinsArtifactURL.FindStringSubmatch(path)
+	if len(jobMatches) != 7 {
+		return "", fmt.Errorf("artifact URL path not match regexp pattern: ^%s$", reJenkinsArtifactURL)
+	}
+
+	jobPath := fmt.Sprintf("%s/%s/%s",
+		jobMatches[1],
+		strings.Join(strings.Split(jobMatches[2], "/"), "/job/"),
+		jobMatches[4],
+	)
+	if jobMatches[5] == "api/json" {
+		return jobPath + "/api/json?tree=artifacts%5BrelativePath%5D", nil
+	}
+
+	var artifactPath []string
+	for _, element := range strings.Split(jobMatches[6], "/") {
+		if element == "" || element == "." || element == ".." {
+			return "", fmt.Errorf("invalid artifact path %q", jobMatches[6])
+		}
+		artifactPath = append(artifactPath, url.PathEscape(element))
+	}
+	return fmt.Sprintf("%s/artifact/%s", jobPath, strings.Join(artifactPath, "/")), nil
+}
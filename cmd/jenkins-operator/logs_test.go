@@ -59,3 +59,50 @@ func Test_getRealJenkinsLogPath(t *testing.T) {
 		})
 	}
 }
+
+func Test_getRealJenkinsArtifactPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "list of artifacts",
+			path: "/job/abc/1/api/json",
+			want: "/job/abc/1/api/json?tree=artifacts%5BrelativePath%5D",
+		},
+		{
+			name: "artifact of nested job",
+			path: "/job/folder-l1/the-job/1/artifact/results/junit 01.xml",
+			want: "/job/folder-l1/job/the-job/1/artifact/results/junit%2001.xml",
+		},
+		{
+			name: "artifact in a directory named like a build",
+			path: "/job/abc/1/artifact/logs/2/artifact/out.txt",
+			want: "/job/abc/1/artifact/logs/2/artifact/out.txt",
+		},
+		{
+			name:    "artifact outside of the build",
+			path:    "/job/abc/1/artifact/../../2/consoleText",
+			wantErr: true,
+		},
+		{
+			name:    "other API",
+			path:    "/job/abc/1/api/xml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getRealJenkinsArtifactPath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getRealJenkinsArtifactPath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getRealJenkinsArtifactPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/gerrit/adapter"
+	gerrit "sigs.k8s.io/prow/pkg/gerrit/client"
+	"sigs.k8s.io/prow/pkg/gerrit/source"
+)
+
+// changeURL identifies a GitHub pull request or a Gerrit change.
+type changeURL struct {
+	// org is the GitHub org, or the Gerrit instance such as
+	// https://android-review.googlesource.com.
+	org    string
+	repo   string
+	number int
+	gerrit bool
+}
+
+// parseChangeURL parses the URL of a GitHub pull request, like
+// https://github.com/org/repo/pull/123, or of a Gerrit change, like
+// https://android-review.googlesource.com/c/platform/build/+/123.
+func parseChangeURL(raw string) (*changeURL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", raw, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" || u.Host == "" {
+		return nil, fmt.Errorf("URL %q is not an http(s) URL", raw)
+	}
+	path := strings.Trim(u.Path, "/")
+
+	// Gerrit change URLs are /c/<project>/+/<number>[/<patchset>].
+	if strings.HasPrefix(path, "c/") {
+		project, rest, found := strings.Cut(strings.TrimPrefix(path, "c/"), "/+/")
+		if !found || project == "" {
+			return nil, fmt.Errorf("URL %q is not a Gerrit change URL like https://host/c/project/+/123", raw)
+		}
+		number, err := strconv.Atoi(strings.Split(rest, "/")[0])
+		if err != nil || number <= 0 {
+			return nil, fmt.Errorf("URL %q has no valid change number", raw)
+		}
+		return &changeURL{org: source.NormalizeOrg(u.Scheme + "://" + u.Host), repo: project, number: number, gerrit: true}, nil
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 || parts[2] != "pull" {
+		return nil, fmt.Errorf("URL %q is neither a GitHub pull request URL like https://github.com/org/repo/pull/123 nor a Gerrit change URL", raw)
+	}
+	number, err := strconv.Atoi(parts[3])
+	if err != nil || number <= 0 {
+		return nil, fmt.Errorf("URL %q has no valid pull request number", raw)
+	}
+	return &changeURL{org: parts[0], repo: parts[1], number: number}, nil
+}
+
+type gerritClient interface {
+	GetChange(instance, id string, additionalFields ...string) (*gerrit.ChangeInfo, error)
+	GetBranchRevision(instance, project, branch string) (string, error)
+}
+
+// resolveChange fills the options from the change, so that the refs of the
+// job are not prompted for.
+func (o *options) resolveChange(change *changeURL) error {
+	o.org, o.repo, o.pullNumber = change.org, change.repo, change.number
+	if change.gerrit {
+		info, err := o.gerritClient.GetChange(change.org, strconv.Itoa(change.number), "CURRENT_REVISION", "CURRENT_COMMIT")
+		if err != nil {
+			return fmt.Errorf("failed to fetch change from Gerrit: %w", err)
+		}
+		baseSHA, err := o.gerritClient.GetBranchRevision(change.org, change.repo, info.Branch)
+		if err != nil {
+			return fmt.Errorf("failed to get base sha of branch %s: %w", info.Branch, err)
+		}
+		refs, err := adapter.CreateRefs(change.org, change.repo, info.Branch, baseSHA, *info)
+		if err != nil {
+			return err
+		}
+		o.refs = &refs
+		o.baseRef, o.baseSha = refs.BaseRef, refs.BaseSHA
+		return nil
+	}
+
+	pr, err := o.getPullRequest()
+	if err != nil {
+		return err
+	}
+	o.baseRef, o.baseSha = pr.Base.Ref, pr.Base.SHA
+	o.pullSha, o.pullAuthor, o.pullHeadRef = pr.Head.SHA, pr.User.Login, pr.Head.Ref
+	return nil
+}
+
+// matchingPresubmits returns the names of the static presubmits of the repo
+// that can run against the base ref, sorted.
+func matchingPresubmits(conf *config.Config, org, repo, baseRef string) []string {
+	var names []string
+	for fullRepoName, ps := range conf.PresubmitsStatic {
+		o, r, err := config.SplitRepoName(fullRepoName)
+		if err != nil || o != org || r != repo {
+			continue
+		}
+		for _, p := range ps {
+			if p.CouldRun(baseRef) {
+				names = append(names, p.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// chooseJob prompts for one of the jobs, by its number or name.
+func chooseJob(in io.Reader, out io.Writer, jobs []string) (string, error) {
+	if len(jobs) == 0 {
+		return "", fmt.Errorf("no presubmits run for the change")
+	}
+	for i, job := range jobs {
+		fmt.Fprintf(out, "%3d) %s\n", i+1, job)
+	}
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(out, "Job [1-%d]: ", len(jobs))
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.ErrUnexpectedEOF
+		}
+		answer := strings.TrimSpace(scanner.Text())
+		if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(jobs) {
+			return jobs[i-1], nil
+		}
+		for _, job := range jobs {
+			if job == answer {
+				return job, nil
+			}
+		}
+		fmt.Fprintf(out, "%q is not one of the listed jobs.\n", answer)
+	}
+}
+
+// presubmitRefs returns the refs of a presubmit for the change.
+func (o *options) presubmitRefs(org, repo string) prowapi.Refs {
+	if o.refs != nil {
+		return *o.refs
+	}
+	return prowapi.Refs{
+		Org:     org,
+		Repo:    repo,
+		BaseRef: o.baseRef,
+		BaseSHA: o.baseSha,
+		Pulls: []prowapi.Pull{{
+			Author:  o.pullAuthor,
+			Number:  o.pullNumber,
+			SHA:     o.pullSha,
+			HeadRef: o.pullHeadRef,
+		}},
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	gerrit "sigs.k8s.io/prow/pkg/gerrit/client"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/pjutil"
 )
@@ -47,10 +48,16 @@ type options struct {
 	pullHeadRef string
 	org         string
 	repo        string
+	url         string
 
 	github       prowflagutil.GitHubOptions
 	githubClient githubClient
 	pullRequest  *github.PullRequest
+
+	gerritCookiefile string
+	gerritClient     gerritClient
+	// refs are the refs of a Gerrit change given by --url.
+	refs *prowapi.Refs
 }
 
 func (o *options) genJobSpec(conf *config.Config) (config.JobBase, prowapi.ProwJobSpec) {
@@ -60,20 +67,12 @@ func (o *options) genJobSpec(conf *config.Config) (config.JobBase, prowapi.ProwJ
 			logrus.WithError(err).Warnf("Invalid repo name %s.", fullRepoName)
 			continue
 		}
+		if o.url != "" && (org != o.org || repo != o.repo) {
+			continue
+		}
 		for _, p := range ps {
 			if p.Name == o.jobName {
-				return p.JobBase, pjutil.PresubmitSpec(p, prowapi.Refs{
-					Org:     org,
-					Repo:    repo,
-					BaseRef: o.baseRef,
-					BaseSHA: o.baseSha,
-					Pulls: []prowapi.Pull{{
-						Author:  o.pullAuthor,
-						Number:  o.pullNumber,
-						SHA:     o.pullSha,
-						HeadRef: o.pullHeadRef,
-					}},
-				})
+				return p.JobBase, pjutil.PresubmitSpec(p, o.presubmitRefs(org, repo))
 			}
 		}
 	}
@@ -177,10 +176,16 @@ type githubClient interface {
 }
 
 func (o *options) Validate() error {
-	if o.jobName == "" {
+	if o.jobName == "" && o.url == "" {
 		return errors.New("required flag --job was unset")
 	}
 
+	if o.url != "" {
+		if _, err := parseChangeURL(o.url); err != nil {
+			return err
+		}
+	}
+
 	if err := o.config.Validate(false); err != nil {
 		return err
 	}
@@ -201,7 +206,9 @@ func (o *options) Validate() error {
 func gatherOptions() options {
 	var o options
 	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
-	fs.StringVar(&o.jobName, "job", "", "Job to run.")
+	fs.StringVar(&o.jobName, "job", "", "Job to run. May be omitted with --url to choose from the presubmits of the change.")
+	fs.StringVar(&o.url, "url", "", "URL of a GitHub pull request or Gerrit change to run a presubmit against. Its refs are fetched rather than prompted for.")
+	fs.StringVar(&o.gerritCookiefile, "gerrit-cookiefile", "", "Path to a git http.cookiefile for Gerrit changes of a --url that are not public.")
 	fs.StringVar(&o.baseRef, "base-ref", "", "Git base ref under test")
 	fs.StringVar(&o.baseSha, "base-sha", "", "Git base SHA under test")
 	fs.IntVar(&o.pullNumber, "pull-number", 0, "Git pull number under test")
@@ -235,6 +242,29 @@ func main() {
 	if err != nil {
 		logrus.WithError(err).Fatal("Failed to get GitHub client")
 	}
+	if o.url != "" {
+		change, err := parseChangeURL(o.url)
+		if err != nil {
+			logrus.WithError(err).Fatal("Bad --url")
+		}
+		if change.gerrit {
+			gc, err := gerrit.NewClient(map[string]map[string]*config.GerritQueryFilter{change.org: {change.repo: nil}}, 1, 10)
+			if err != nil {
+				logrus.WithError(err).Fatal("Failed to create Gerrit client")
+			}
+			gc.Authenticate(o.gerritCookiefile, "")
+			o.gerritClient = gc
+		}
+		if err := o.resolveChange(change); err != nil {
+			logrus.WithError(err).Fatal("Failed to fetch the refs of the change")
+		}
+		if o.jobName == "" {
+			o.jobName, err = chooseJob(os.Stdin, os.Stderr, matchingPresubmits(conf, o.org, o.repo, o.baseRef))
+			if err != nil {
+				logrus.WithError(err).Fatal("Failed to choose a job")
+			}
+		}
+	}
 	job, pjs := o.genJobSpec(conf)
 	if job.Name == "" {
 		logrus.Fatalf("Job %s not found.", o.jobName)
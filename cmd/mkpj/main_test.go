@@ -17,10 +17,18 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 
+	gerritapi "github.com/andygrunwald/go-gerrit"
+	"github.com/google/go-cmp/cmp"
+
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
+	gerrit "sigs.k8s.io/prow/pkg/gerrit/client"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
 )
@@ -53,6 +61,22 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "job chosen from url",
+			input: options{
+				url:    "https://github.com/org/repo/pull/1",
+				config: configflagutil.ConfigOptions{ConfigPath: "somewhere"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "invalid url",
+			input: options{
+				url:    "https://github.com/org/repo/issues/1",
+				config: configflagutil.ConfigOptions{ConfigPath: "somewhere"},
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -126,3 +150,195 @@ func TestDefaultBaseRef(t *testing.T) {
 		})
 	}
 }
+
+func TestParseChangeURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		url         string
+		expected    *changeURL
+		expectedErr bool
+	}{
+		{
+			name:     "GitHub pull request",
+			url:      "https://github.com/kubernetes/test-infra/pull/123",
+			expected: &changeURL{org: "kubernetes", repo: "test-infra", number: 123},
+		},
+		{
+			name:     "GitHub pull request files tab",
+			url:      "https://github.com/kubernetes/test-infra/pull/123/files",
+			expected: &changeURL{org: "kubernetes", repo: "test-infra", number: 123},
+		},
+		{
+			name:     "Gerrit change",
+			url:      "https://android-review.googlesource.com/c/platform/build/+/456",
+			expected: &changeURL{org: "https://android-review.googlesource.com", repo: "platform/build", number: 456, gerrit: true},
+		},
+		{
+			name:     "Gerrit change with patchset",
+			url:      "https://android-review.googlesource.com/c/platform/build/+/456/3",
+			expected: &changeURL{org: "https://android-review.googlesource.com", repo: "platform/build", number: 456, gerrit: true},
+		},
+		{
+			name:        "GitHub issue",
+			url:         "https://github.com/kubernetes/test-infra/issues/123",
+			expectedErr: true,
+		},
+		{
+			name:        "Gerrit change without project",
+			url:         "https://android-review.googlesource.com/c/456",
+			expectedErr: true,
+		},
+		{
+			name:        "no number",
+			url:         "https://github.com/kubernetes/test-infra/pull/latest",
+			expectedErr: true,
+		},
+		{
+			name:        "not a URL",
+			url:         "kubernetes/test-infra#123",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseChangeURL(tc.url)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if diff := cmp.Diff(tc.expected, actual, cmp.AllowUnexported(changeURL{})); diff != "" {
+				t.Errorf("parsed change differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeGerritClient struct {
+	changes  map[string]*gerrit.ChangeInfo
+	branches map[string]string
+}
+
+func (f *fakeGerritClient) GetChange(instance, id string, additionalFields ...string) (*gerrit.ChangeInfo, error) {
+	change, ok := f.changes[id]
+	if !ok {
+		return nil, fmt.Errorf("change %s not found", id)
+	}
+	return change, nil
+}
+
+func (f *fakeGerritClient) GetBranchRevision(instance, project, branch string) (string, error) {
+	return f.branches[branch], nil
+}
+
+func TestResolveChange(t *testing.T) {
+	fakeGitHubClient := fakegithub.NewFakeClient()
+	fakeGitHubClient.PullRequests = map[int]*github.PullRequest{2: {
+		User: github.User{Login: "author"},
+		Base: github.PullRequestBranch{Ref: "main", SHA: "base-sha"},
+		Head: github.PullRequestBranch{Ref: "feature", SHA: "head-sha"},
+	}}
+	o := &options{githubClient: fakeGitHubClient}
+	if err := o.resolveChange(&changeURL{org: "org", repo: "repo", number: 2}); err != nil {
+		t.Fatalf("unexpected error resolving pull request: %v", err)
+	}
+	if diff := cmp.Diff(prowapi.Refs{
+		Org:     "org",
+		Repo:    "repo",
+		BaseRef: "main",
+		BaseSHA: "base-sha",
+		Pulls:   []prowapi.Pull{{Number: 2, Author: "author", SHA: "head-sha", HeadRef: "feature"}},
+	}, o.presubmitRefs("org", "repo")); diff != "" {
+		t.Errorf("pull request refs differ from expected (-want +got):\n%s", diff)
+	}
+
+	o = &options{gerritClient: &fakeGerritClient{
+		changes: map[string]*gerrit.ChangeInfo{"3": {
+			ID:              "platform%2Fbuild~main~I0",
+			Project:         "platform/build",
+			Branch:          "main",
+			Number:          3,
+			CurrentRevision: "change-sha",
+			Revisions: map[string]gerrit.RevisionInfo{"change-sha": {
+				Ref:    "refs/changes/03/3/1",
+				Commit: gerritapi.CommitInfo{Author: gerritapi.GitPersonInfo{Name: "author", Email: "author@example.com"}},
+			}},
+		}},
+		branches: map[string]string{"main": "base-sha"},
+	}}
+	if err := o.resolveChange(&changeURL{org: "https://review.example.com", repo: "platform/build", number: 3, gerrit: true}); err != nil {
+		t.Fatalf("unexpected error resolving change: %v", err)
+	}
+	refs := o.presubmitRefs("https://review.example.com", "platform/build")
+	if refs.BaseRef != "main" || refs.BaseSHA != "base-sha" || o.baseRef != "main" {
+		t.Errorf("expected base main at base-sha, got %s at %s", refs.BaseRef, refs.BaseSHA)
+	}
+	if len(refs.Pulls) != 1 || refs.Pulls[0].SHA != "change-sha" || refs.Pulls[0].Ref != "refs/changes/03/3/1" || refs.Pulls[0].Author != "author" {
+		t.Errorf("unexpected pulls: %+v", refs.Pulls)
+	}
+	if refs.CloneURI != "https://review.example.com/platform/build" {
+		t.Errorf("unexpected clone URI %s", refs.CloneURI)
+	}
+}
+
+func TestChooseJob(t *testing.T) {
+	conf := &config.Config{JobConfig: config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{
+		"org/repo": {
+			{JobBase: config.JobBase{Name: "pull-unit"}},
+			{JobBase: config.JobBase{Name: "pull-e2e"}},
+			{JobBase: config.JobBase{Name: "pull-release"}, Brancher: config.Brancher{Branches: []string{"release"}}},
+		},
+		"org/other": {
+			{JobBase: config.JobBase{Name: "pull-other"}},
+		},
+	}}}
+	if err := conf.SetPresubmits(conf.PresubmitsStatic); err != nil {
+		t.Fatalf("failed to set presubmits: %v", err)
+	}
+	jobs := matchingPresubmits(conf, "org", "repo", "main")
+	if diff := cmp.Diff([]string{"pull-e2e", "pull-unit"}, jobs); diff != "" {
+		t.Fatalf("matching presubmits differ from expected (-want +got):\n%s", diff)
+	}
+
+	testCases := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "by number",
+			input:    "2\n",
+			expected: "pull-unit",
+		},
+		{
+			name:     "by name",
+			input:    "pull-e2e\n",
+			expected: "pull-e2e",
+		},
+		{
+			name:     "reprompted after invalid answer",
+			input:    "3\npull-unit\n",
+			expected: "pull-unit",
+		},
+		{
+			name:        "no answer",
+			input:       "",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job, err := chooseJob(strings.NewReader(tc.input), io.Discard, jobs)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if job != tc.expected {
+				t.Errorf("expected job %q, got %q", tc.expected, job)
+			}
+		})
+	}
+
+	if _, err := chooseJob(strings.NewReader("1\n"), io.Discard, nil); err == nil {
+		t.Error("expected an error without jobs, got none")
+	}
+}
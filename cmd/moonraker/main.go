@@ -36,6 +36,7 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/config/secret"
 	"sigs.k8s.io/prow/pkg/diskutil"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
@@ -95,6 +96,10 @@ type options struct {
 	gracePeriod            time.Duration
 	instrumentationOptions prowflagutil.InstrumentationOptions
 	pushGatewayInterval    time.Duration
+
+	webhookSecretFile string
+	storeDir          string
+	storeMaxAge       time.Duration
 }
 
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
@@ -106,6 +111,9 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.DurationVar(&o.gracePeriod, "grace-period", 25*time.Second, "On shutdown, try to handle remaining events for the specified duration. Cannot be larger than 30s.")
 	fs.StringVar(&o.cookiefilePath, "cookiefile", "", "Path to git http.cookiefile, leave empty for github or anonymous")
 	fs.DurationVar(&o.pushGatewayInterval, "push-gateway-interval", time.Minute, "Interval at which prometheus metrics for disk space are pushed.")
+	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "", "Path to the file containing the GitHub HMAC secret. If set, push events received on /prefetch warm up the cache.")
+	fs.StringVar(&o.storeDir, "persistent-cache-dir", "", "Directory in which retrieved ProwYAMLs are persisted across restarts. Persistence is disabled if empty.")
+	fs.DurationVar(&o.storeMaxAge, "persistent-cache-max-age", 7*24*time.Hour, "Duration after which unused ProwYAMLs are removed from --persistent-cache-dir.")
 	for _, group := range []flagutil.OptionGroup{&o.github, &o.instrumentationOptions, &o.config} {
 		group.AddFlags(fs)
 	}
@@ -165,10 +173,29 @@ func main() {
 		logrus.WithError(err).Fatal("Error creating InRepoConfigCacheGetter.")
 	}
 
+	if o.storeDir != "" {
+		store, err := config.NewDiskProwYAMLStore(o.storeDir)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error creating persistent ProwYAML store.")
+		}
+		cacheGetter.SetPersistentStore(store)
+		interrupts.TickLiteral(func() {
+			if err := store.Prune(o.storeMaxAge); err != nil {
+				logrus.WithError(err).Error("Error pruning persistent ProwYAML store.")
+			}
+		}, time.Hour)
+	}
+
 	mr := moonraker.Moonraker{
 		ConfigAgent:       configAgent,
 		InRepoConfigCache: cacheGetter,
 	}
+	if o.webhookSecretFile != "" {
+		if err := secret.Add(o.webhookSecretFile); err != nil {
+			logrus.WithError(err).Fatal("Error starting secrets agent.")
+		}
+		mr.TokenGenerator = secret.GetTokenGenerator(o.webhookSecretFile)
+	}
 
 	// If the main config changes (an update to the ConfigMap holding the main
 	// config), we have to reload it because the "in_repo_config" setting which
@@ -185,6 +212,9 @@ func main() {
 	mux := http.NewServeMux()
 	mux.HandleFunc(fmt.Sprintf("/%s", moonraker.PathPing), mr.ServePing)
 	mux.HandleFunc(fmt.Sprintf("/%s", moonraker.PathGetInrepoconfig), mr.ServeGetInrepoconfig)
+	if mr.TokenGenerator != nil {
+		mux.HandleFunc(fmt.Sprintf("/%s", moonraker.PathPrefetch), mr.ServePrefetch)
+	}
 	server := &http.Server{
 		Addr:    ":" + strconv.Itoa(o.port),
 		Handler: mux,
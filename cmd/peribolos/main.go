@@ -17,10 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -29,10 +31,12 @@ import (
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
+	"sigs.k8s.io/prow/pkg/audit"
 	"sigs.k8s.io/prow/pkg/config/org"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/teamsync"
 )
 
 const (
@@ -61,7 +65,10 @@ type options struct {
 	ignoreSecretTeams bool
 	allowRepoArchival bool
 	allowRepoPublish  bool
+	teamSyncConfig    string
+	teamSyncOutput    string
 	github            flagutil.GitHubOptions
+	audit             flagutil.AuditOptions
 
 	logLevel string
 }
@@ -94,8 +101,11 @@ func (o *options) parseArgs(flags *flag.FlagSet, args []string) error {
 	flags.BoolVar(&o.fixRepos, "fix-repos", false, "Create/update repositories if set")
 	flags.BoolVar(&o.allowRepoArchival, "allow-repo-archival", false, "If set, archiving repos is allowed while updating repos")
 	flags.BoolVar(&o.allowRepoPublish, "allow-repo-publish", false, "If set, making private repos public is allowed while updating repos")
+	flags.StringVar(&o.teamSyncConfig, "team-sync-config", "", "Path to the config of the identity providers to sync team members from, for teams with a sync stanza")
+	flags.StringVar(&o.teamSyncOutput, "team-sync-output", "", "Write the org config with synced teams to this path instead of configuring GitHub, requires --team-sync-config")
 	flags.StringVar(&o.logLevel, "log-level", logrus.InfoLevel.String(), fmt.Sprintf("Logging level, one of %v", logrus.AllLevels))
 	o.github.AddCustomizedFlags(flags, flagutil.ThrottlerDefaults(defaultTokens, defaultBurst))
+	o.audit.AddFlags(flags)
 	if err := flags.Parse(args); err != nil {
 		return err
 	}
@@ -110,6 +120,9 @@ func (o *options) parseArgs(flags *flag.FlagSet, args []string) error {
 	if err := o.github.Validate(!o.confirm); err != nil {
 		return err
 	}
+	if err := o.audit.Validate(!o.confirm); err != nil {
+		return err
+	}
 
 	if o.minAdmins < 2 {
 		return fmt.Errorf("--min-admins=%d must be at least 2", o.minAdmins)
@@ -137,6 +150,13 @@ func (o *options) parseArgs(flags *flag.FlagSet, args []string) error {
 		return errors.New("--dump-full can't be used without --dump")
 	}
 
+	if o.teamSyncConfig != "" && o.config == "" {
+		return errors.New("--team-sync-config requires --config-path")
+	}
+	if o.teamSyncOutput != "" && o.teamSyncConfig == "" {
+		return errors.New("--team-sync-output requires --team-sync-config")
+	}
+
 	if o.fixTeamMembers && !o.fixTeams {
 		return fmt.Errorf("--fix-team-members requires --fix-teams")
 	}
@@ -190,14 +210,90 @@ func main() {
 		logrus.WithError(err).Fatal("Failed to load configuration")
 	}
 
+	if o.teamSyncConfig != "" {
+		synced, err := syncTeams(o.teamSyncConfig, cfg)
+		if err != nil {
+			logrus.WithError(err).Fatal("Failed to sync teams")
+		}
+		if o.teamSyncOutput != "" {
+			out, err := yaml.Marshal(synced)
+			if err != nil {
+				logrus.WithError(err).Fatal("Failed to marshal synced configuration")
+			}
+			if err := os.WriteFile(o.teamSyncOutput, out, 0644); err != nil {
+				logrus.WithError(err).Fatal("Could not write --team-sync-output file")
+			}
+			logrus.Infof("Wrote synced configuration to %s.", o.teamSyncOutput)
+			return
+		}
+		cfg = *synced
+	}
+
+	var auditor *audit.Auditor
+	if o.confirm {
+		if auditor, err = o.audit.Auditor(context.Background(), "peribolos", nil); err != nil {
+			logrus.WithError(err).Fatal("Error creating auditor.")
+		}
+	}
+
 	for name, orgcfg := range cfg.Orgs {
-		if err := configureOrg(o, githubClient, name, orgcfg); err != nil {
+		err := configureOrg(o, githubClient, name, orgcfg)
+		auditor.Emit(context.Background(), audit.Event{
+			Action:  audit.ActionPeribolosApply,
+			Target:  name,
+			Error:   audit.ErrorString(err),
+			Details: map[string]string{"fixes": strings.Join(o.fixes(), ",")},
+		})
+		if err != nil {
 			logrus.Fatalf("Configuration failed: %v", err)
 		}
 	}
 	logrus.Info("Finished syncing configuration.")
 }
 
+// fixes returns the flags of the parts of the org that peribolos changes.
+func (o *options) fixes() []string {
+	var fixes []string
+	for flag, enabled := range map[string]bool{
+		"fix-org":          o.fixOrg,
+		"fix-org-members":  o.fixOrgMembers,
+		"fix-teams":        o.fixTeams,
+		"fix-team-members": o.fixTeamMembers,
+		"fix-team-repos":   o.fixTeamRepos,
+		"fix-repos":        o.fixRepos,
+	} {
+		if enabled {
+			fixes = append(fixes, flag)
+		}
+	}
+	sort.Strings(fixes)
+	return fixes
+}
+
+// syncTeams fills the members of teams from their identity provider groups,
+// logging the resulting changes to the config.
+func syncTeams(path string, cfg org.FullConfig) (*org.FullConfig, error) {
+	syncConfig, err := teamsync.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	sources, err := teamsync.NewSources(ctx, *syncConfig)
+	if err != nil {
+		return nil, err
+	}
+	synced, diff, err := teamsync.Sync(ctx, *syncConfig, sources, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if diff == "" {
+		logrus.Info("Teams are in sync with their identity provider groups.")
+	} else {
+		logrus.Infof("Syncing teams with their identity provider groups changes the config:\n%s", diff)
+	}
+	return synced, nil
+}
+
 type dumpClient interface {
 	GetOrg(name string) (*github.Organization, error)
 	ListOrgMembers(org, role string) ([]github.TeamMember, error)
@@ -358,17 +454,20 @@ func dumpOrgConfig(client dumpClient, orgName string, ignoreSecretTeams bool, ap
 		}
 		logrus.WithField("repo", full.FullName).Debug("Recording repo.")
 		out.Repos[full.Name] = org.PruneRepoDefaults(org.Repo{
-			Description:      &full.Description,
-			HomePage:         &full.Homepage,
-			Private:          &full.Private,
-			HasIssues:        &full.HasIssues,
-			HasProjects:      &full.HasProjects,
-			HasWiki:          &full.HasWiki,
-			AllowMergeCommit: &full.AllowMergeCommit,
-			AllowSquashMerge: &full.AllowSquashMerge,
-			AllowRebaseMerge: &full.AllowRebaseMerge,
-			Archived:         &full.Archived,
-			DefaultBranch:    &full.DefaultBranch,
+			Description:         &full.Description,
+			HomePage:            &full.Homepage,
+			Private:             &full.Private,
+			HasIssues:           &full.HasIssues,
+			HasProjects:         &full.HasProjects,
+			HasWiki:             &full.HasWiki,
+			AllowMergeCommit:    &full.AllowMergeCommit,
+			AllowSquashMerge:    &full.AllowSquashMerge,
+			AllowRebaseMerge:    &full.AllowRebaseMerge,
+			AllowAutoMerge:      &full.AllowAutoMerge,
+			DeleteBranchOnMerge: &full.DeleteBranchOnMerge,
+			Archived:            &full.Archived,
+			DefaultBranch:       &full.DefaultBranch,
+			Topics:              full.Topics,
 		})
 	}
 
@@ -863,6 +962,15 @@ type repoClient interface {
 	GetRepos(orgName string, isUser bool) ([]github.Repo, error)
 	CreateRepo(owner string, isUser bool, repo github.RepoCreateRequest) (*github.FullRepo, error)
 	UpdateRepo(owner, name string, repo github.RepoUpdateRequest) (*github.FullRepo, error)
+	ReplaceRepoTopics(org, repo string, topics []string) error
+	GetVulnerabilityAlerts(org, repo string) (bool, error)
+	SetVulnerabilityAlerts(org, repo string, enabled bool) error
+	ListRepoHooks(org, repo string) ([]github.Hook, error)
+	CreateRepoHook(org, repo string, req github.HookRequest) (int, error)
+	EditRepoHook(org, repo string, id int, req github.HookRequest) error
+	ListAutolinks(org, repo string) ([]github.Autolink, error)
+	CreateAutolink(org, repo string, autolink github.Autolink) (int, error)
+	DeleteAutolink(org, repo string, id int) error
 }
 
 func newRepoCreateRequest(name string, definition org.Repo) github.RepoCreateRequest {
@@ -880,6 +988,8 @@ func newRepoCreateRequest(name string, definition org.Repo) github.RepoCreateReq
 			AllowRebaseMerge:         definition.AllowRebaseMerge,
 			SquashMergeCommitTitle:   definition.SquashMergeCommitTitle,
 			SquashMergeCommitMessage: definition.SquashMergeCommitMessage,
+			AllowAutoMerge:           definition.AllowAutoMerge,
+			DeleteBranchOnMerge:      definition.DeleteBranchOnMerge,
 		},
 	}
 
@@ -915,7 +1025,37 @@ func validateRepos(repos map[string]org.Repo) error {
 		return fmt.Errorf("found duplicate repo names (GitHub repo names are case-insensitive): %s", strings.Join(dups, ", "))
 	}
 
-	return nil
+	var errs []error
+	for name, repo := range repos {
+		hooks := sets.New[string]()
+		for _, hook := range repo.Webhooks {
+			switch {
+			case hook.URL == "":
+				errs = append(errs, fmt.Errorf("repo %s: webhook url must be set", name))
+			case hooks.Has(hook.URL):
+				errs = append(errs, fmt.Errorf("repo %s: duplicate webhook %s", name, hook.URL))
+			}
+			if hook.ContentType != nil && *hook.ContentType != "json" && *hook.ContentType != "form" {
+				errs = append(errs, fmt.Errorf("repo %s: webhook %s content_type must be json or form, not %q", name, hook.URL, *hook.ContentType))
+			}
+			hooks.Insert(hook.URL)
+		}
+		prefixes := sets.New[string]()
+		for _, autolink := range repo.Autolinks {
+			switch {
+			case autolink.KeyPrefix == "":
+				errs = append(errs, fmt.Errorf("repo %s: autolink key_prefix must be set", name))
+			case prefixes.Has(autolink.KeyPrefix):
+				errs = append(errs, fmt.Errorf("repo %s: duplicate autolink key_prefix %s", name, autolink.KeyPrefix))
+			}
+			if !strings.Contains(autolink.URLTemplate, "<num>") {
+				errs = append(errs, fmt.Errorf("repo %s: autolink %s url_template must contain <num>", name, autolink.KeyPrefix))
+			}
+			prefixes.Insert(autolink.KeyPrefix)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
 }
 
 // newRepoUpdateRequest creates a minimal github.RepoUpdateRequest instance
@@ -947,6 +1087,8 @@ func newRepoUpdateRequest(current github.FullRepo, name string, repo org.Repo) g
 			AllowRebaseMerge:         setBool(current.AllowRebaseMerge, repo.AllowRebaseMerge),
 			SquashMergeCommitTitle:   setString(current.SquashMergeCommitTitle, repo.SquashMergeCommitTitle),
 			SquashMergeCommitMessage: setString(current.SquashMergeCommitMessage, repo.SquashMergeCommitMessage),
+			AllowAutoMerge:           setBool(current.AllowAutoMerge, repo.AllowAutoMerge),
+			DeleteBranchOnMerge:      setBool(current.DeleteBranchOnMerge, repo.DeleteBranchOnMerge),
 		},
 		DefaultBranch: setString(current.DefaultBranch, repo.DefaultBranch),
 		Archived:      setBool(current.Archived, repo.Archived),
@@ -1039,6 +1181,13 @@ func configureRepos(opt options, client repoClient, orgName string, orgConfig or
 					continue
 				}
 			}
+			// Settings are configured before the update, which might rename or archive the repo.
+			if !existing.Archived {
+				if err := configureRepoSettings(client, orgName, *existing, wantRepo); err != nil {
+					repoLogger.WithError(err).Error("failed to configure repository settings")
+					allErrors = append(allErrors, err)
+				}
+			}
 			repoLogger.Info("repo exists, considering an update")
 			delta := newRepoUpdateRequest(*existing, wantName, wantRepo)
 			if deltaErrors := sanitizeRepoDelta(opt, &delta); len(deltaErrors) > 0 {
@@ -1060,6 +1209,148 @@ func configureRepos(opt options, client repoClient, orgName string, orgConfig or
 	return utilerrors.NewAggregate(allErrors)
 }
 
+// configureRepoSettings reconciles the settings of a repo that are not part
+// of the repository object: topics, vulnerability alerts, webhooks and
+// autolinks.
+func configureRepoSettings(client repoClient, orgName string, current github.FullRepo, want org.Repo) error {
+	repoName := current.Name
+	var errs []error
+
+	if want.Topics != nil {
+		// GitHub stores topics in lowercase.
+		have, wantTopics := sets.New[string](), sets.New[string]()
+		for _, topic := range current.Topics {
+			have.Insert(strings.ToLower(topic))
+		}
+		for _, topic := range want.Topics {
+			wantTopics.Insert(strings.ToLower(topic))
+		}
+		if !have.Equal(wantTopics) {
+			logrus.Infof("Replacing %s topics with %v", repoName, sets.List(wantTopics))
+			if err := client.ReplaceRepoTopics(orgName, repoName, sets.List(wantTopics)); err != nil {
+				errs = append(errs, fmt.Errorf("failed to replace topics: %w", err))
+			}
+		}
+	}
+
+	if want.VulnerabilityAlerts != nil {
+		enabled, err := client.GetVulnerabilityAlerts(orgName, repoName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get vulnerability alerts: %w", err))
+		} else if enabled != *want.VulnerabilityAlerts {
+			logrus.Infof("Setting %s vulnerability alerts to %t", repoName, *want.VulnerabilityAlerts)
+			if err := client.SetVulnerabilityAlerts(orgName, repoName, *want.VulnerabilityAlerts); err != nil {
+				errs = append(errs, fmt.Errorf("failed to set vulnerability alerts: %w", err))
+			}
+		}
+	}
+
+	if len(want.Webhooks) > 0 {
+		if err := configureRepoWebhooks(client, orgName, repoName, want.Webhooks); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if want.Autolinks != nil {
+		if err := configureRepoAutolinks(client, orgName, repoName, want.Autolinks); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// configureRepoWebhooks creates the configured webhooks that are missing and
+// updates the events and active state of existing ones. Webhooks are matched
+// by their URL and unconfigured webhooks are left alone.
+func configureRepoWebhooks(client repoClient, orgName, repoName string, want []org.RepoWebhook) error {
+	hooks, err := client.ListRepoHooks(orgName, repoName)
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	byURL := make(map[string]github.Hook, len(hooks))
+	for _, hook := range hooks {
+		byURL[hook.Config.URL] = hook
+	}
+
+	var errs []error
+	for _, hook := range want {
+		active := hook.Active == nil || *hook.Active
+		events := hook.Events
+		if len(events) == 0 {
+			// This is what GitHub defaults to.
+			events = []string{"push"}
+		}
+		have, exists := byURL[hook.URL]
+		if !exists {
+			contentType := "json"
+			if hook.ContentType != nil {
+				contentType = *hook.ContentType
+			}
+			logrus.Infof("Creating %s webhook %s", repoName, hook.URL)
+			if _, err := client.CreateRepoHook(orgName, repoName, github.HookRequest{
+				Name:   "web",
+				Active: &active,
+				Config: &github.HookConfig{URL: hook.URL, ContentType: &contentType},
+				Events: events,
+			}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to create webhook %s: %w", hook.URL, err))
+			}
+			continue
+		}
+		if have.Active == active && sets.New[string](have.Events...).Equal(sets.New[string](events...)) {
+			continue
+		}
+		// The config is not sent, as that would drop the secret of the webhook.
+		logrus.Infof("Updating %s webhook %s", repoName, hook.URL)
+		if err := client.EditRepoHook(orgName, repoName, have.ID, github.HookRequest{
+			Active: &active,
+			Events: events,
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update webhook %s: %w", hook.URL, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// configureRepoAutolinks makes the autolinks of the repo match the config.
+// Autolinks cannot be edited, so changed ones are deleted and recreated.
+func configureRepoAutolinks(client repoClient, orgName, repoName string, want []org.Autolink) error {
+	autolinks, err := client.ListAutolinks(orgName, repoName)
+	if err != nil {
+		return fmt.Errorf("failed to list autolinks: %w", err)
+	}
+	wanted := make(map[string]github.Autolink, len(want))
+	for _, autolink := range want {
+		wanted[autolink.KeyPrefix] = github.Autolink{
+			KeyPrefix:      autolink.KeyPrefix,
+			URLTemplate:    autolink.URLTemplate,
+			IsAlphanumeric: autolink.IsAlphanumeric == nil || *autolink.IsAlphanumeric,
+		}
+	}
+
+	var errs []error
+	for _, have := range autolinks {
+		desired, ok := wanted[have.KeyPrefix]
+		if ok && desired.URLTemplate == have.URLTemplate && desired.IsAlphanumeric == have.IsAlphanumeric {
+			delete(wanted, have.KeyPrefix)
+			continue
+		}
+		logrus.Infof("Deleting %s autolink %s", repoName, have.KeyPrefix)
+		if err := client.DeleteAutolink(orgName, repoName, have.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete autolink %s: %w", have.KeyPrefix, err))
+			delete(wanted, have.KeyPrefix)
+		}
+	}
+	for _, prefix := range sets.List(sets.KeySet(wanted)) {
+		logrus.Infof("Creating %s autolink %s", repoName, prefix)
+		if _, err := client.CreateAutolink(orgName, repoName, wanted[prefix]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create autolink %s: %w", prefix, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 func configureTeamAndMembers(opt options, client github.Client, githubTeams map[string]github.Team, name, orgName string, team org.Team, parent *int) error {
 	gt, ok := githubTeams[name]
 	if !ok { // configureTeams is buggy if this is the case
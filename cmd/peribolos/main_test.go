@@ -107,6 +107,27 @@ func TestOptions(t *testing.T) {
 			name: "reject --fix-team-members without --fix-teams",
 			args: []string{"--config-path=foo", "--fix-team-members"},
 		},
+		{
+			name: "reject --team-sync-config without --config-path",
+			args: []string{"--dump=frogger", "--team-sync-config=sync.yaml"},
+		},
+		{
+			name: "reject --team-sync-output without --team-sync-config",
+			args: []string{"--config-path=foo", "--team-sync-output=out.yaml"},
+		},
+		{
+			name: "team sync",
+			args: []string{"--config-path=foo", "--team-sync-config=sync.yaml", "--team-sync-output=out.yaml"},
+			expected: &options{
+				config:         "foo",
+				minAdmins:      defaultMinAdmins,
+				requireSelf:    true,
+				maximumDelta:   defaultDelta,
+				teamSyncConfig: "sync.yaml",
+				teamSyncOutput: "out.yaml",
+				logLevel:       "info",
+			},
+		},
 		{
 			name: "allow dump without config",
 			args: []string{"--dump=frogger"},
@@ -2531,8 +2552,11 @@ func TestConfigureTeamRepos(t *testing.T) {
 }
 
 type fakeRepoClient struct {
-	t     *testing.T
-	repos map[string]github.FullRepo
+	t         *testing.T
+	repos     map[string]github.FullRepo
+	alerts    map[string]bool
+	hooks     map[string][]github.Hook
+	autolinks map[string][]github.Autolink
 }
 
 func (f fakeRepoClient) GetRepo(owner, name string) (github.FullRepo, error) {
@@ -2622,15 +2646,89 @@ func (f fakeRepoClient) UpdateRepo(owner, name string, want github.RepoUpdateReq
 	updateBool(&have.AllowRebaseMerge, want.AllowRebaseMerge)
 	updateString(&have.SquashMergeCommitTitle, want.SquashMergeCommitTitle)
 	updateString(&have.SquashMergeCommitMessage, want.SquashMergeCommitMessage)
+	updateBool(&have.AllowAutoMerge, want.AllowAutoMerge)
+	updateBool(&have.DeleteBranchOnMerge, want.DeleteBranchOnMerge)
 
 	f.repos[name] = have
 	return &have, nil
 }
 
+func (f fakeRepoClient) ReplaceRepoTopics(org, repo string, topics []string) error {
+	have, exists := f.repos[repo]
+	if !exists {
+		return fmt.Errorf("repo not found")
+	}
+	have.Topics = topics
+	f.repos[repo] = have
+	return nil
+}
+
+func (f fakeRepoClient) GetVulnerabilityAlerts(org, repo string) (bool, error) {
+	return f.alerts[repo], nil
+}
+
+func (f fakeRepoClient) SetVulnerabilityAlerts(org, repo string, enabled bool) error {
+	f.alerts[repo] = enabled
+	return nil
+}
+
+func (f fakeRepoClient) ListRepoHooks(org, repo string) ([]github.Hook, error) {
+	return f.hooks[repo], nil
+}
+
+func (f fakeRepoClient) CreateRepoHook(org, repo string, req github.HookRequest) (int, error) {
+	id := len(f.hooks[repo]) + 1
+	f.hooks[repo] = append(f.hooks[repo], github.Hook{
+		ID:     id,
+		Name:   req.Name,
+		Events: req.Events,
+		Active: *req.Active,
+		Config: *req.Config,
+	})
+	return id, nil
+}
+
+func (f fakeRepoClient) EditRepoHook(org, repo string, id int, req github.HookRequest) error {
+	if req.Config != nil {
+		f.t.Errorf("EditRepoHook() called with a config, which drops the secret")
+	}
+	for i, hook := range f.hooks[repo] {
+		if hook.ID == id {
+			f.hooks[repo][i].Events = req.Events
+			f.hooks[repo][i].Active = *req.Active
+			return nil
+		}
+	}
+	return fmt.Errorf("hook %d not found", id)
+}
+
+func (f fakeRepoClient) ListAutolinks(org, repo string) ([]github.Autolink, error) {
+	return append([]github.Autolink(nil), f.autolinks[repo]...), nil
+}
+
+func (f fakeRepoClient) CreateAutolink(org, repo string, autolink github.Autolink) (int, error) {
+	autolink.ID = 100 + len(f.autolinks[repo])
+	f.autolinks[repo] = append(f.autolinks[repo], autolink)
+	return autolink.ID, nil
+}
+
+func (f fakeRepoClient) DeleteAutolink(org, repo string, id int) error {
+	for i, autolink := range f.autolinks[repo] {
+		if autolink.ID == id {
+			f.autolinks[repo] = append(f.autolinks[repo][:i], f.autolinks[repo][i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("autolink %d not found", id)
+}
+
 func makeFakeRepoClient(t *testing.T, repos ...github.FullRepo) fakeRepoClient {
 	fc := fakeRepoClient{
-		repos: make(map[string]github.FullRepo, len(repos)),
-		t:     t,
+		repos:     make(map[string]github.FullRepo, len(repos)),
+		alerts:    map[string]bool{},
+		hooks:     map[string][]github.Hook{},
+		autolinks: map[string][]github.Autolink{},
+		t:         t,
 	}
 	for _, repo := range repos {
 		fc.repos[repo.Name] = repo
@@ -2977,6 +3075,107 @@ func TestConfigureRepos(t *testing.T) {
 	}
 }
 
+func TestConfigureRepoSettings(t *testing.T) {
+	yes := true
+	no := false
+	form := "form"
+	repo := github.FullRepo{Repo: github.Repo{Name: "repo"}, Topics: []string{"old"}}
+
+	testCases := []struct {
+		description string
+		want        org.Repo
+		hooks       []github.Hook
+		autolinks   []github.Autolink
+		alerts      bool
+
+		expectedTopics    []string
+		expectedAlerts    bool
+		expectedHooks     []github.Hook
+		expectedAutolinks []github.Autolink
+	}{
+		{
+			description:    "unset settings are left alone",
+			hooks:          []github.Hook{{ID: 1, Events: []string{"push"}, Active: true, Config: github.HookConfig{URL: "https://other"}}},
+			autolinks:      []github.Autolink{{ID: 1, KeyPrefix: "JIRA-", URLTemplate: "https://jira/<num>"}},
+			alerts:         true,
+			expectedTopics: []string{"old"},
+			expectedAlerts: true,
+			expectedHooks:  []github.Hook{{ID: 1, Events: []string{"push"}, Active: true, Config: github.HookConfig{URL: "https://other"}}},
+			expectedAutolinks: []github.Autolink{
+				{ID: 1, KeyPrefix: "JIRA-", URLTemplate: "https://jira/<num>"},
+			},
+		},
+		{
+			description: "settings are reconciled",
+			want: org.Repo{
+				Topics:              []string{"Kubernetes", "ci"},
+				VulnerabilityAlerts: &yes,
+				Webhooks: []org.RepoWebhook{
+					{URL: "https://hook", Events: []string{"pull_request", "push"}},
+					{URL: "https://new", ContentType: &form, Active: &no},
+				},
+				Autolinks: []org.Autolink{
+					{KeyPrefix: "JIRA-", URLTemplate: "https://jira/<num>", IsAlphanumeric: &no},
+					{KeyPrefix: "TICKET-", URLTemplate: "https://tickets/<num>"},
+				},
+			},
+			hooks: []github.Hook{
+				{ID: 1, Events: []string{"push"}, Active: false, Config: github.HookConfig{URL: "https://hook"}},
+				{ID: 2, Events: []string{"push"}, Active: true, Config: github.HookConfig{URL: "https://other"}},
+			},
+			autolinks: []github.Autolink{
+				{ID: 1, KeyPrefix: "JIRA-", URLTemplate: "https://jira/<num>", IsAlphanumeric: true},
+				{ID: 2, KeyPrefix: "TICKET-", URLTemplate: "https://tickets/<num>", IsAlphanumeric: true},
+				{ID: 3, KeyPrefix: "OLD-", URLTemplate: "https://old/<num>", IsAlphanumeric: true},
+			},
+			expectedTopics: []string{"ci", "kubernetes"},
+			expectedAlerts: true,
+			expectedHooks: []github.Hook{
+				{ID: 1, Events: []string{"pull_request", "push"}, Active: true, Config: github.HookConfig{URL: "https://hook"}},
+				{ID: 2, Events: []string{"push"}, Active: true, Config: github.HookConfig{URL: "https://other"}},
+				{ID: 3, Name: "web", Events: []string{"push"}, Active: false, Config: github.HookConfig{URL: "https://new", ContentType: &form}},
+			},
+			expectedAutolinks: []github.Autolink{
+				{ID: 2, KeyPrefix: "TICKET-", URLTemplate: "https://tickets/<num>", IsAlphanumeric: true},
+				{ID: 101, KeyPrefix: "JIRA-", URLTemplate: "https://jira/<num>", IsAlphanumeric: false},
+			},
+		},
+		{
+			description:       "empty lists clear topics and autolinks",
+			want:              org.Repo{Topics: []string{}, Autolinks: []org.Autolink{}, VulnerabilityAlerts: &no},
+			autolinks:         []github.Autolink{{ID: 1, KeyPrefix: "JIRA-", URLTemplate: "https://jira/<num>"}},
+			alerts:            true,
+			expectedTopics:    []string{},
+			expectedAutolinks: []github.Autolink{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			fc := makeFakeRepoClient(t, repo)
+			fc.alerts["repo"] = tc.alerts
+			fc.hooks["repo"] = tc.hooks
+			fc.autolinks["repo"] = tc.autolinks
+
+			if err := configureRepoSettings(fc, "org", repo, tc.want); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedTopics, fc.repos["repo"].Topics); diff != "" {
+				t.Errorf("unexpected topics (-want +got):\n%s", diff)
+			}
+			if fc.alerts["repo"] != tc.expectedAlerts {
+				t.Errorf("expected vulnerability alerts to be %t", tc.expectedAlerts)
+			}
+			if diff := cmp.Diff(tc.expectedHooks, fc.hooks["repo"]); diff != "" {
+				t.Errorf("unexpected webhooks (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedAutolinks, fc.autolinks["repo"]); diff != "" {
+				t.Errorf("unexpected autolinks (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateRepos(t *testing.T) {
 	description := "cool repo"
 	testCases := []struct {
@@ -3021,6 +3220,20 @@ func TestValidateRepos(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			description: "finds duplicate webhooks",
+			config: map[string]org.Repo{
+				"repo": {Webhooks: []org.RepoWebhook{{URL: "https://hook"}, {URL: "https://hook"}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "finds autolinks without a number placeholder",
+			config: map[string]org.Repo{
+				"repo": {Autolinks: []org.Autolink{{KeyPrefix: "JIRA-", URLTemplate: "https://jira/"}}},
+			},
+			expectError: true,
+		},
 		{
 			description: "allows case-duplicate name between former and current name",
 			config: map[string]org.Repo{
@@ -18,8 +18,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,6 +33,7 @@ import (
 	prowjobinfov1 "sigs.k8s.io/prow/pkg/client/informers/externalversions/prowjobs/v1"
 	prowjoblisters "sigs.k8s.io/prow/pkg/client/listers/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/gcsupload"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
@@ -39,6 +42,7 @@ import (
 	"github.com/sirupsen/logrus"
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	untypedcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -112,7 +116,7 @@ func (c *controller) hasSynced() bool {
 		c.pipelinesDone = map[string]bool{}
 	}
 	for n, cfg := range c.pipelines {
-		if !cfg.informer.Informer().HasSynced() {
+		if !cfg.informer.Informer().HasSynced() || !cfg.taskRuns.Informer().HasSynced() {
 			if c.wait != n {
 				c.wait = n
 				logrus.Infof("Waiting on %s pipelines...", n)
@@ -274,6 +278,7 @@ type reconciler interface {
 	listProwJobs(namespace string) ([]*prowjobv1.ProwJob, error)
 	patchProwJob(pj *prowjobv1.ProwJob, newpj *prowjobv1.ProwJob) (*prowjobv1.ProwJob, error)
 	getPipelineRun(context, namespace, name string) (*pipelinev1beta1.PipelineRun, error)
+	getTaskRun(context, namespace, name string) (*pipelinev1beta1.TaskRun, error)
 	cancelPipelineRun(context string, pr *pipelinev1beta1.PipelineRun) error
 	deletePipelineRun(context, namespace, name string) error
 	createPipelineRun(context, namespace string, b *pipelinev1beta1.PipelineRun) (*pipelinev1beta1.PipelineRun, error)
@@ -316,6 +321,14 @@ func (c *controller) getPipelineRun(context, namespace, name string) (*pipelinev
 	return p.informer.Lister().PipelineRuns(namespace).Get(name)
 }
 
+func (c *controller) getTaskRun(context, namespace, name string) (*pipelinev1beta1.TaskRun, error) {
+	p, err := c.getPipelineConfig(context)
+	if err != nil {
+		return nil, err
+	}
+	return p.taskRuns.Lister().TaskRuns(namespace).Get(name)
+}
+
 func (c *controller) deletePipelineRun(pContext, namespace, name string) error {
 	logrus.Debugf("deletePipeline(%s,%s,%s)", pContext, namespace, name)
 	p, err := c.getPipelineConfig(pContext)
@@ -421,6 +434,10 @@ func abortDuplicatedProwJobs(c reconciler, pj *prowjobv1.ProwJob) (*prowjobv1.Pr
 		newpj.Status.State = prowjobv1.AbortedState
 		newpj.Status.Description = descAborted
 		newpj.Status.CompletionTime = &now
+		newpj.Status.Cancellation = &prowjobv1.Cancellation{
+			Reason:       prowjobv1.CancelledSuperseded,
+			SupersededBy: pjs[len(pjs)-1].Name,
+		}
 		newpj, err = c.patchProwJob(pjs[i], newpj)
 		if err != nil {
 			logrus.WithError(err).Error("failed to abort prowJob")
@@ -537,6 +554,7 @@ func reconcile(c reconciler, key string) error {
 	if p == nil {
 		return fmt.Errorf("no pipelinerun found or created for %q, wantPipelineRun was %t", key, wantPipelineRun)
 	}
+	newpj.Status.TektonTaskRuns = taskRunStatuses(c, ctx, p, pj.Status.TektonTaskRuns)
 	wantState, wantMsg := prowJobStatus(p.Status)
 	return updateProwJobState(c, key, newPipelineRun, pj, newpj, wantState, wantMsg)
 }
@@ -544,7 +562,8 @@ func reconcile(c reconciler, key string) error {
 func updateProwJobState(c reconciler, key string, newPipelineRun bool, pj *prowjobv1.ProwJob, newpj *prowjobv1.ProwJob, state prowjobv1.ProwJobState, msg string) error {
 	haveState := newpj.Status.State
 	haveMsg := newpj.Status.Description
-	if newPipelineRun || haveState != state || haveMsg != msg {
+	taskRunsChanged := !equality.Semantic.DeepEqual(pj.Status.TektonTaskRuns, newpj.Status.TektonTaskRuns)
+	if newPipelineRun || haveState != state || haveMsg != msg || taskRunsChanged {
 		if haveState != state && state == prowjobv1.PendingState {
 			now := c.now()
 			newpj.Status.PendingTime = &now
@@ -639,6 +658,67 @@ func prowJobStatus(ps pipelinev1beta1.PipelineRunStatus) (prowjobv1.ProwJobState
 	return prowjobv1.ErrorState, description(cond, descUnknown) // shouldn't happen
 }
 
+// taskRunStatuses returns the state and results of the TaskRuns of the
+// pipeline run. TaskRuns that cannot be read keep their previous status.
+func taskRunStatuses(c reconciler, ctx string, p *pipelinev1beta1.PipelineRun, previous []prowjobv1.TektonTaskRun) []prowjobv1.TektonTaskRun {
+	known := map[string]prowjobv1.TektonTaskRun{}
+	for _, tr := range previous {
+		known[tr.Name] = tr
+	}
+	var statuses []prowjobv1.TektonTaskRun
+	for _, child := range p.Status.ChildReferences {
+		if child.Kind != "TaskRun" {
+			continue
+		}
+		tr, err := c.getTaskRun(ctx, p.Namespace, child.Name)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				logrus.WithError(err).Warnf("Failed to get TaskRun %s/%s", p.Namespace, child.Name)
+			}
+			if status, ok := known[child.Name]; ok {
+				statuses = append(statuses, status)
+			}
+			continue
+		}
+		statuses = append(statuses, taskRunStatus(child.PipelineTaskName, tr))
+	}
+	return statuses
+}
+
+// taskRunStatus summarizes a TaskRun for the ProwJob status.
+func taskRunStatus(pipelineTask string, tr *pipelinev1beta1.TaskRun) prowjobv1.TektonTaskRun {
+	status := prowjobv1.TektonTaskRun{
+		Name:         tr.Name,
+		PipelineTask: pipelineTask,
+		State:        prowjobv1.PendingState,
+	}
+	if cond := tr.Status.GetCondition(apis.ConditionSucceeded); cond != nil {
+		switch cond.Status {
+		case untypedcorev1.ConditionTrue:
+			status.State = prowjobv1.SuccessState
+		case untypedcorev1.ConditionFalse:
+			status.State = prowjobv1.FailureState
+		}
+		status.Description = description(*cond, "")
+	}
+	for _, result := range tr.Status.TaskRunResults {
+		if status.Results == nil {
+			status.Results = map[string]string{}
+		}
+		if result.Value.Type == pipelinev1beta1.ParamTypeString || result.Value.Type == "" {
+			status.Results[result.Name] = result.Value.StringVal
+			continue
+		}
+		raw, err := json.Marshal(result.Value)
+		if err != nil {
+			logrus.WithError(err).Warnf("Failed to encode result %s of TaskRun %s", result.Name, tr.Name)
+			continue
+		}
+		status.Results[result.Name] = string(raw)
+	}
+	return status
+}
+
 // pipelineMeta builds the pipeline metadata from prow job definition
 func pipelineMeta(name string, pj prowjobv1.ProwJob) metav1.ObjectMeta {
 	labels, annotations := decorate.LabelsAndAnnotationsForJob(pj)
@@ -723,17 +803,32 @@ func makePipelineRun(pj prowjobv1.ProwJob) (*pipelinev1beta1.PipelineRun, error)
 	if err != nil {
 		return nil, err
 	}
+	for i, refs := range pj.Spec.ExtraRefs {
+		for key, val := range extraRefParams(i, refs) {
+			env[key] = val
+		}
+	}
+	for key, val := range artifactParams(pj, buildID) {
+		env[key] = val
+	}
+	declared := sets.New[string]()
+	for _, param := range p.Spec.Params {
+		declared.Insert(param.Name)
+	}
 	for _, key := range sets.List(sets.KeySet[string](env)) {
-		val := env[key]
-		// TODO: make this handle existing values/substitutions.
+		if declared.Has(key) {
+			// Values set in the job config take precedence.
+			continue
+		}
 		p.Spec.Params = append(p.Spec.Params, pipelinev1beta1.Param{
 			Name: key,
 			Value: pipelinev1beta1.ParamValue{
 				Type:      pipelinev1beta1.ParamTypeString,
-				StringVal: val,
+				StringVal: env[key],
 			},
 		})
 	}
+	bindWorkspaces(&p, pj)
 
 	if p.Spec.PipelineSpec != nil {
 		for i, task := range p.Spec.PipelineSpec.Tasks {
@@ -761,3 +856,65 @@ func makePipelineRun(pj prowjobv1.ProwJob) (*pipelinev1beta1.PipelineRun, error)
 
 	return &p, nil
 }
+
+// extraRefParams returns the parameters describing the extra ref at the given
+// index, named after the downward API variables of the primary ref.
+func extraRefParams(index int, refs prowjobv1.Refs) map[string]string {
+	prefix := fmt.Sprintf("EXTRA_REF_%d_", index)
+	return map[string]string{
+		prefix + downwardapi.RepoOwnerEnv:   refs.Org,
+		prefix + downwardapi.RepoNameEnv:    refs.Repo,
+		prefix + downwardapi.PullBaseRefEnv: refs.BaseRef,
+		prefix + downwardapi.PullBaseShaEnv: refs.BaseSHA,
+		prefix + downwardapi.PullRefsEnv:    refs.String(),
+	}
+}
+
+// artifactParams returns the parameters describing where the job's results
+// are uploaded, or nothing when the job has no GCS configuration.
+func artifactParams(pj prowjobv1.ProwJob, buildID string) map[string]string {
+	dc := pj.Spec.DecorationConfig
+	if dc == nil || dc.GCSConfiguration == nil || dc.GCSConfiguration.Bucket == "" {
+		return nil
+	}
+	spec := downwardapi.NewJobSpec(pj.Spec, buildID, pj.Name)
+	_, dir, _ := gcsupload.PathsForJob(dc.GCSConfiguration, &spec, "")
+	return map[string]string{
+		"PROW_ARTIFACTS_BUCKET": dc.GCSConfiguration.Bucket,
+		"PROW_JOB_PATH":         dir,
+		"PROW_ARTIFACTS_PATH":   path.Join(dir, "artifacts"),
+	}
+}
+
+// bindWorkspaces binds the workspaces prow provides to the pipeline when the
+// pipeline declares them and the job config does not bind them itself.
+func bindWorkspaces(p *pipelinev1beta1.PipelineRun, pj prowjobv1.ProwJob) {
+	if p.Spec.PipelineSpec == nil {
+		return
+	}
+	bound := sets.New[string]()
+	for _, ws := range p.Spec.Workspaces {
+		bound.Insert(ws.Name)
+	}
+	for _, ws := range p.Spec.PipelineSpec.Workspaces {
+		if bound.Has(ws.Name) {
+			continue
+		}
+		switch ws.Name {
+		case config.ProwArtifactsWorkspace:
+			p.Spec.Workspaces = append(p.Spec.Workspaces, pipelinev1beta1.WorkspaceBinding{
+				Name:     ws.Name,
+				EmptyDir: &untypedcorev1.EmptyDirVolumeSource{},
+			})
+		case config.ProwGCSCredentialsWorkspace:
+			dc := pj.Spec.DecorationConfig
+			if dc == nil || dc.GCSCredentialsSecret == nil || *dc.GCSCredentialsSecret == "" {
+				continue
+			}
+			p.Spec.Workspaces = append(p.Spec.Workspaces, pipelinev1beta1.WorkspaceBinding{
+				Name:   ws.Name,
+				Secret: &untypedcorev1.SecretVolumeSource{SecretName: *dc.GCSCredentialsSecret},
+			})
+		}
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/diff"
 	"k8s.io/client-go/util/workqueue"
 	"knative.dev/pkg/apis"
@@ -56,6 +57,7 @@ const (
 type fakeReconciler struct {
 	jobs      map[string]prowjobv1.ProwJob
 	pipelines map[string]pipelinev1beta1.PipelineRun
+	taskRuns  map[string]pipelinev1beta1.TaskRun
 	nows      metav1.Time
 }
 
@@ -109,6 +111,16 @@ func (r *fakeReconciler) getPipelineRun(context, namespace, name string) (*pipel
 	return &p, nil
 }
 
+func (r *fakeReconciler) getTaskRun(context, namespace, name string) (*pipelinev1beta1.TaskRun, error) {
+	logrus.Debugf("getTaskRun: ctx=%s, ns=%s, name=%s", context, namespace, name)
+	k := toKey(context, namespace, name)
+	tr, present := r.taskRuns[k]
+	if !present {
+		return nil, apierrors.NewNotFound(pipelinev1beta1.Resource("TaskRun"), name)
+	}
+	return &tr, nil
+}
+
 func (r *fakeReconciler) listProwJobs(namespace string) ([]*prowjobv1.ProwJob, error) {
 	logrus.Debugf("listProwJobs: namespace=%s", namespace)
 	pjs := []*prowjobv1.ProwJob{}
@@ -259,6 +271,7 @@ func TestReconcile(t *testing.T) {
 		context             string
 		observedJob         *prowjobv1.ProwJob
 		observedPipelineRun *pipelinev1beta1.PipelineRun
+		observedTaskRuns    []pipelinev1beta1.TaskRun
 		expectedJob         func(prowjobv1.ProwJob, pipelinev1beta1.PipelineRun) prowjobv1.ProwJob
 		expectedPipelineRun func(prowjobv1.ProwJob, pipelinev1beta1.PipelineRun) pipelinev1beta1.PipelineRun
 		duplicateStartTime  *metav1.Time
@@ -317,6 +330,10 @@ func TestReconcile(t *testing.T) {
 						Description:    descAborted,
 						BuildID:        pipelineID,
 						CompletionTime: &now,
+						Cancellation: &prowjobv1.Cancellation{
+							Reason:       prowjobv1.CancelledSuperseded,
+							SupersededBy: pj.Name + duplicateAppendix,
+						},
 					}
 					return pj
 				}
@@ -359,6 +376,10 @@ func TestReconcile(t *testing.T) {
 					BuildID:        pipelineID + duplicateAppendix,
 					CompletionTime: &now,
 					Description:    descAborted,
+					Cancellation: &prowjobv1.Cancellation{
+						Reason:       prowjobv1.CancelledSuperseded,
+						SupersededBy: strings.TrimSuffix(pj.Name, duplicateAppendix),
+					},
 				}
 				return pj
 			},
@@ -636,6 +657,84 @@ func TestReconcile(t *testing.T) {
 			},
 			expectedPipelineRun: noPipelineRunChange,
 		},
+		{
+			name: "prowjob tracks task runs of the pipeline run",
+			observedJob: &prowjobv1.ProwJob{
+				Spec: prowjobv1.ProwJobSpec{
+					Agent:           prowjobv1.TektonAgent,
+					PipelineRunSpec: &pipelineSpec,
+				},
+				Status: prowjobv1.ProwJobStatus{
+					State:       prowjobv1.PendingState,
+					Description: descRunning,
+					TektonTaskRuns: []prowjobv1.TektonTaskRun{
+						{Name: "deleted", PipelineTask: "lint", State: prowjobv1.SuccessState},
+					},
+				},
+			},
+			observedPipelineRun: func() *pipelinev1beta1.PipelineRun {
+				pj := prowjobv1.ProwJob{}
+				pj.Spec.Type = prowjobv1.PeriodicJob
+				pj.Spec.Agent = prowjobv1.TektonAgent
+				pj.Spec.PipelineRunSpec = &pipelineSpec
+				pj.Status.BuildID = pipelineID
+				p, err := makePipelineRun(pj)
+				if err != nil {
+					panic(err)
+				}
+				p.Status.StartTime = &now
+				p.Status.SetCondition(&apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+				})
+				p.Status.ChildReferences = []pipelinev1beta1.ChildStatusReference{
+					{TypeMeta: runtime.TypeMeta{Kind: "TaskRun"}, Name: "deleted", PipelineTaskName: "lint"},
+					{TypeMeta: runtime.TypeMeta{Kind: "TaskRun"}, Name: "build-tr", PipelineTaskName: "build"},
+					{TypeMeta: runtime.TypeMeta{Kind: "CustomRun"}, Name: "approval", PipelineTaskName: "approve"},
+					{TypeMeta: runtime.TypeMeta{Kind: "TaskRun"}, Name: "test-tr", PipelineTaskName: "test"},
+				}
+				return p
+			}(),
+			observedTaskRuns: []pipelinev1beta1.TaskRun{
+				func() pipelinev1beta1.TaskRun {
+					tr := pipelinev1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "build-tr"}}
+					tr.Status.SetCondition(&apis.Condition{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+						Reason: "Succeeded",
+					})
+					tr.Status.TaskRunResults = []pipelinev1beta1.TaskRunResult{
+						{Name: "image", Type: pipelinev1beta1.ResultsTypeString, Value: *pipelinev1beta1.NewStructuredValues("gcr.io/foo/bar@sha256:123")},
+						{Name: "tags", Type: pipelinev1beta1.ResultsTypeArray, Value: *pipelinev1beta1.NewStructuredValues("v1", "latest")},
+					}
+					return tr
+				}(),
+				{ObjectMeta: metav1.ObjectMeta{Name: "test-tr"}},
+			},
+			expectedJob: func(pj prowjobv1.ProwJob, _ pipelinev1beta1.PipelineRun) prowjobv1.ProwJob {
+				pj.Status = prowjobv1.ProwJobStatus{
+					StartTime:   now,
+					State:       prowjobv1.PendingState,
+					Description: descRunning,
+					TektonTaskRuns: []prowjobv1.TektonTaskRun{
+						{Name: "deleted", PipelineTask: "lint", State: prowjobv1.SuccessState},
+						{
+							Name:         "build-tr",
+							PipelineTask: "build",
+							State:        prowjobv1.SuccessState,
+							Description:  "Succeeded",
+							Results: map[string]string{
+								"image": "gcr.io/foo/bar@sha256:123",
+								"tags":  `["v1","latest"]`,
+							},
+						},
+						{Name: "test-tr", PipelineTask: "test", State: prowjobv1.PendingState},
+					},
+				}
+				return pj
+			},
+			expectedPipelineRun: noPipelineRunChange,
+		},
 		{
 			name: "prowjob fails when pipeline run fails",
 			observedJob: &prowjobv1.ProwJob{
@@ -811,8 +910,12 @@ func TestReconcile(t *testing.T) {
 			r := &fakeReconciler{
 				jobs:      map[string]prowjobv1.ProwJob{},
 				pipelines: map[string]pipelinev1beta1.PipelineRun{},
+				taskRuns:  map[string]pipelinev1beta1.TaskRun{},
 				nows:      now,
 			}
+			for _, tr := range tc.observedTaskRuns {
+				r.taskRuns[toKey(tc.context, tc.namespace, tr.Name)] = tr
+			}
 
 			jk := toKey(fakePJCtx, fakePJNS, name)
 			jkDuplicate := toKey(fakePJCtx, fakePJNS, name+duplicateAppendix)
@@ -977,6 +1080,22 @@ func TestMakeResourcesBeta1(t *testing.T) {
 				return pj
 			},
 			pipelineRun: func(pr pipelinev1beta1.PipelineRun) pipelinev1beta1.PipelineRun {
+				var params []pipelinev1beta1.Param
+				params = append(params, pr.Spec.Params[:2]...)
+				for _, org := range []string{"org0", "org1"} {
+					prefix := "EXTRA_REF_0_"
+					if org == "org1" {
+						prefix = "EXTRA_REF_1_"
+					}
+					params = append(params,
+						pipelinev1beta1.Param{Name: prefix + "PULL_BASE_REF", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString}},
+						pipelinev1beta1.Param{Name: prefix + "PULL_BASE_SHA", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString}},
+						pipelinev1beta1.Param{Name: prefix + "PULL_REFS", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString}},
+						pipelinev1beta1.Param{Name: prefix + "REPO_NAME", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString}},
+						pipelinev1beta1.Param{Name: prefix + "REPO_OWNER", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString, StringVal: org}},
+					)
+				}
+				pr.Spec.Params = append(params, pr.Spec.Params[2:]...)
 				pr.Spec.PipelineSpec.Tasks = []pipelinev1beta1.PipelineTask{
 					{
 						TaskRef: &pipelinev1beta1.TaskRef{Name: "git-clone"},
@@ -996,6 +1115,78 @@ func TestMakeResourcesBeta1(t *testing.T) {
 				return pr
 			},
 		},
+		{
+			name: "expose the artifact upload location",
+			job: func(pj prowjobv1.ProwJob) prowjobv1.ProwJob {
+				pj.Spec.DecorationConfig = &prowjobv1.DecorationConfig{
+					GCSConfiguration: &prowjobv1.GCSConfiguration{
+						Bucket:       "gs://bucket",
+						PathStrategy: prowjobv1.PathStrategyExplicit,
+					},
+				}
+				return pj
+			},
+			pipelineRun: func(pr pipelinev1beta1.PipelineRun) pipelinev1beta1.PipelineRun {
+				params := append([]pipelinev1beta1.Param{}, pr.Spec.Params[:5]...)
+				params = append(params,
+					pipelinev1beta1.Param{Name: "PROW_ARTIFACTS_BUCKET", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString, StringVal: "gs://bucket"}},
+					pipelinev1beta1.Param{Name: "PROW_ARTIFACTS_PATH", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString, StringVal: "logs/ci-job/so-many-pipelines/artifacts"}},
+					pr.Spec.Params[5],
+					pipelinev1beta1.Param{Name: "PROW_JOB_PATH", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString, StringVal: "logs/ci-job/so-many-pipelines"}},
+				)
+				pr.Spec.Params = params
+				return pr
+			},
+		},
+		{
+			name: "bind declared prow workspaces",
+			job: func(pj prowjobv1.ProwJob) prowjobv1.ProwJob {
+				secret := "gcs-creds"
+				pj.Spec.DecorationConfig = &prowjobv1.DecorationConfig{GCSCredentialsSecret: &secret}
+				pj.Spec.TektonPipelineRunSpec.V1Beta1.PipelineSpec = &pipelinev1beta1.PipelineSpec{
+					Workspaces: []pipelinev1beta1.PipelineWorkspaceDeclaration{
+						{Name: config.ProwArtifactsWorkspace},
+						{Name: config.ProwGCSCredentialsWorkspace},
+						{Name: "cache"},
+					},
+				}
+				return pj
+			},
+			pipelineRun: func(pr pipelinev1beta1.PipelineRun) pipelinev1beta1.PipelineRun {
+				pr.Spec.Workspaces = []pipelinev1beta1.WorkspaceBinding{
+					{Name: config.ProwArtifactsWorkspace, EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					{Name: config.ProwGCSCredentialsWorkspace, Secret: &corev1.SecretVolumeSource{SecretName: "gcs-creds"}},
+				}
+				return pr
+			},
+		},
+		{
+			name: "params and workspaces from the job config take precedence",
+			job: func(pj prowjobv1.ProwJob) prowjobv1.ProwJob {
+				pj.Spec.TektonPipelineRunSpec.V1Beta1.Params = []pipelinev1beta1.Param{
+					{Name: "JOB_NAME", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString, StringVal: "custom"}},
+				}
+				pj.Spec.TektonPipelineRunSpec.V1Beta1.Workspaces = []pipelinev1beta1.WorkspaceBinding{
+					{Name: config.ProwArtifactsWorkspace, PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "artifacts"}},
+				}
+				pj.Spec.TektonPipelineRunSpec.V1Beta1.PipelineSpec = &pipelinev1beta1.PipelineSpec{
+					Workspaces: []pipelinev1beta1.PipelineWorkspaceDeclaration{
+						{Name: config.ProwArtifactsWorkspace},
+						{Name: config.ProwGCSCredentialsWorkspace, Optional: true},
+					},
+				}
+				return pj
+			},
+			pipelineRun: func(pr pipelinev1beta1.PipelineRun) pipelinev1beta1.PipelineRun {
+				params := []pipelinev1beta1.Param{
+					{Name: "JOB_NAME", Value: pipelinev1beta1.ParamValue{Type: pipelinev1beta1.ParamTypeString, StringVal: "custom"}},
+				}
+				params = append(params, pr.Spec.Params[:2]...)
+				params = append(params, pr.Spec.Params[3:]...)
+				pr.Spec.Params = params
+				return pr
+			},
+		},
 		{
 			name: "do not override unrelated git resources",
 			job: func(pj prowjobv1.ProwJob) prowjobv1.ProwJob {
@@ -78,6 +78,7 @@ func (o *options) parse(flags *flag.FlagSet, args []string) error {
 type pipelineConfig struct {
 	client   pipelineset.Interface
 	informer pipelineinfov1beta1.PipelineRunInformer
+	taskRuns pipelineinfov1beta1.TaskRunInformer
 }
 
 // newPipelineConfig returns a client and informer capable of mutating and monitoring the specified config.
@@ -96,10 +97,12 @@ func newPipelineConfig(cfg rest.Config, stop <-chan struct{}) (*pipelineConfig,
 	// Assume watches receive updates, but resync every 30m in case something wonky happens
 	bif := pipelineinfo.NewSharedInformerFactory(bc, 30*time.Minute)
 	bif.Tekton().V1beta1().PipelineRuns().Lister()
+	bif.Tekton().V1beta1().TaskRuns().Lister()
 	go bif.Start(stop)
 	return &pipelineConfig{
 		client:   bc,
 		informer: bif.Tekton().V1beta1().PipelineRuns(),
+		taskRuns: bif.Tekton().V1beta1().TaskRuns(),
 	}, nil
 }
 
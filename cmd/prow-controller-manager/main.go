@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/prow/pkg/pjutil/pprof"
 	"sigs.k8s.io/prow/pkg/scheduler"
 
+	"sigs.k8s.io/prow/pkg/buildcluster"
 	"sigs.k8s.io/prow/pkg/flagutil"
 	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
 	configflagutil "sigs.k8s.io/prow/pkg/flagutil/config"
@@ -44,10 +45,11 @@ import (
 	"sigs.k8s.io/prow/pkg/metrics"
 	"sigs.k8s.io/prow/pkg/plank"
 
+	"sigs.k8s.io/prow/pkg/tracing"
 	_ "sigs.k8s.io/prow/pkg/version"
 )
 
-var allControllers = sets.New(plank.ControllerName, scheduler.ControllerName)
+var allControllers = sets.New(plank.ControllerName, scheduler.ControllerName, buildcluster.ControllerName)
 
 type options struct {
 	totURL string
@@ -119,6 +121,9 @@ func main() {
 
 	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort) // Start liveness endpoint
 	pprof.Instrument(o.instrumentationOptions)
+	if err := tracing.Init("prow-controller-manager", o.instrumentationOptions); err != nil {
+		logrus.WithError(err).Fatal("Error initializing tracing.")
+	}
 
 	configAgent, err := o.config.ConfigAgent()
 	if err != nil {
@@ -210,6 +215,12 @@ func main() {
 		}
 	}
 
+	if enabledControllersSet.Has(buildcluster.ControllerName) {
+		if err := buildcluster.Add(mgr); err != nil {
+			logrus.WithError(err).Fatal("Failed to add build cluster controller to manager")
+		}
+	}
+
 	// Expose prometheus metrics
 	metrics.ExposeMetrics("plank", cfg().PushGateway, o.instrumentationOptions.MetricsPort)
 	// Serve readiness endpoint
@@ -345,7 +345,6 @@ func (c *controller) clean() {
 	pjMap := map[string]*prowapi.ProwJob{}
 	isFinished := sets.New[string]()
 
-	maxProwJobAge := c.config().Sinker.MaxProwJobAge.Duration
 	for i, prowJob := range prowJobs.Items {
 		pjMap[prowJob.ObjectMeta.Name] = &prowJobs.Items[i]
 		// Handle periodics separately.
@@ -356,7 +355,7 @@ func (c *controller) clean() {
 			continue
 		}
 		isFinished.Insert(prowJob.ObjectMeta.Name)
-		if time.Since(prowJob.Status.StartTime.Time) <= maxProwJobAge {
+		if time.Since(prowJob.Status.StartTime.Time) <= c.config().MaxProwJobAgeFor(&prowJob) {
 			continue
 		}
 		if err := c.prowJobClient.Delete(c.ctx, &prowJob); err == nil {
@@ -392,7 +391,7 @@ func (c *controller) clean() {
 			// Ignore deleting this one.
 			continue
 		}
-		if time.Since(prowJob.Status.StartTime.Time) <= maxProwJobAge {
+		if time.Since(prowJob.Status.StartTime.Time) <= c.config().MaxProwJobAgeFor(&prowJob) {
 			continue
 		}
 		if err := c.prowJobClient.Delete(c.ctx, &prowJob); err == nil {
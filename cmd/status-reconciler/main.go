@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"os"
 	"time"
@@ -59,6 +60,11 @@ type options struct {
 	// a) the gcs credentials can write to this bucket
 	// b) the default acls do not expose any private info
 	statusURI string
+
+	// planURI is where Status-reconciler writes the status changes it plans
+	// per repo, a /local/path, gs://path or s3://path prefix.
+	planURI  string
+	planOnly bool
 }
 
 func gatherOptions(fs *flag.FlagSet, args ...string) options {
@@ -66,6 +72,8 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 
 	fs.StringVar(&o.statusURI, "status-path", "", "The /local/path, gs://path/to/object or s3://path/to/object to store status controller state. GCS writes will use the default object ACL for the bucket.")
 
+	fs.StringVar(&o.planURI, "plan-path", "", "The /local/path, gs://path or s3://path prefix to write the planned status changes of every repo to for review.")
+	fs.BoolVar(&o.planOnly, "plan-only", false, "Only write the planned status changes to --plan-path without making them. The state is not saved, so the changes are made once this is unset.")
 	fs.BoolVar(&o.continueOnError, "continue-on-error", false, "Indicates that the migration should continue if context migration fails for an individual PR.")
 	fs.Var(&o.addedPresubmitDenylist, "denylist", "Org or org/repo to ignore new added presubmits for, set more than once to add more.")
 	fs.Var(&o.addedPresubmitDenylistAll, "denylist-all", "Org or org/repo to ignore reconciling, set more than once to add more.")
@@ -86,6 +94,10 @@ func (o *options) Validate() error {
 		}
 	}
 
+	if o.planOnly && o.planURI == "" {
+		return errors.New("--plan-only requires --plan-path")
+	}
+
 	return nil
 }
 
@@ -139,7 +151,7 @@ func main() {
 		logrus.WithError(err).Fatal("Cannot create opener")
 	}
 
-	c := statusreconciler.NewController(o.continueOnError, o.getDenyList(), o.getDenyListAll(), opener, o.config, o.statusURI, prowJobClient, githubClient, pluginAgent)
+	c := statusreconciler.NewController(o.continueOnError, o.getDenyList(), o.getDenyListAll(), opener, o.config, o.statusURI, o.planURI, o.planOnly, prowJobClient, githubClient, pluginAgent)
 	interrupts.Run(func(ctx context.Context) {
 		c.Run(ctx)
 	})
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"flag"
 	"reflect"
 	"testing"
@@ -78,6 +79,27 @@ func TestGatherOptions(t *testing.T) {
 				o.addedPresubmitDenylist = newSetStringsFlagForTest("a", "b")
 			},
 		},
+		{
+			name: "plan only",
+			args: []string{
+				"-plan-path=gs://bucket/plans",
+				"-plan-only",
+			},
+			expected: func(o *options) {
+				o.planURI = "gs://bucket/plans"
+				o.planOnly = true
+			},
+		},
+		{
+			name: "plan only requires a plan path",
+			args: []string{
+				"-plan-only",
+			},
+			expected: func(o *options) {
+				o.planOnly = true
+			},
+			expectedErr: errors.New("--plan-only requires --plan-path"),
+		},
 	}
 
 	for _, tc := range cases {
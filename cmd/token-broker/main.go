@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// token-broker mints GitHub App installation tokens and serves them to the
+// configured clients, so that only the broker needs the private key of the app.
+package main
+
+import (
+	"errors"
+	"flag"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/config/secret"
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+	"sigs.k8s.io/prow/pkg/tokenbroker"
+)
+
+type options struct {
+	port          int
+	clientsConfig string
+	tlsCertFile   string
+	tlsKeyFile    string
+	unixSocket    string
+
+	github                 prowflagutil.GitHubOptions
+	instrumentationOptions prowflagutil.InstrumentationOptions
+}
+
+func gatherOptions(fs *flag.FlagSet, args ...string) options {
+	o := options{}
+	fs.IntVar(&o.port, "port", 8888, "Port to listen on.")
+	fs.StringVar(&o.clientsConfig, "clients-config", "", "Path to the file that configures the clients that may request tokens.")
+	fs.StringVar(&o.tlsCertFile, "tls-cert-file", "", "Path to the x509 certificate to serve tokens over HTTPS on --port with.")
+	fs.StringVar(&o.tlsKeyFile, "tls-private-key-file", "", "Path to the private key matching --tls-cert-file.")
+	fs.StringVar(&o.unixSocket, "unix-socket", "", "Path of the unix socket to serve tokens on instead of HTTPS.")
+	o.github.AddFlags(fs)
+	o.instrumentationOptions.AddFlags(fs)
+	fs.Parse(args)
+	return o
+}
+
+func (o *options) Validate() error {
+	if err := o.github.Validate(false); err != nil {
+		return err
+	}
+	if o.github.AppID == "" {
+		return errors.New("--github-app-id and --github-app-private-key-path are required")
+	}
+	if o.clientsConfig == "" {
+		return errors.New("--clients-config is required")
+	}
+	if (o.tlsCertFile == "") != (o.tlsKeyFile == "") {
+		return errors.New("--tls-cert-file and --tls-private-key-file must be set together")
+	}
+	if (o.tlsCertFile == "") == (o.unixSocket == "") {
+		return errors.New("exactly one of --tls-cert-file or --unix-socket is required, tokens are not served over plain HTTP")
+	}
+	return nil
+}
+
+// unixSocketServer serves on a unix socket.
+type unixSocketServer struct {
+	*http.Server
+	path string
+}
+
+func (s *unixSocketServer) ListenAndServe() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+func main() {
+	logrusutil.ComponentInit()
+
+	o := gatherOptions(flag.NewFlagSet(os.Args[0], flag.ExitOnError), os.Args[1:]...)
+	if err := o.Validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options.")
+	}
+
+	defer interrupts.WaitForGracefulShutdown()
+
+	pprof.Instrument(o.instrumentationOptions)
+	metrics.ExposeMetrics("token-broker", config.PushGateway{}, o.instrumentationOptions.MetricsPort)
+	health := pjutil.NewHealthOnPort(o.instrumentationOptions.HealthPort)
+
+	brokerConfig, err := tokenbroker.LoadConfig(o.clientsConfig)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load the clients config.")
+	}
+	var tokenPaths []string
+	for _, client := range brokerConfig.Clients {
+		tokenPaths = append(tokenPaths, client.TokenPath)
+	}
+	if err := secret.Add(tokenPaths...); err != nil {
+		logrus.WithError(err).Fatal("Failed to start secret agent.")
+	}
+
+	githubClient, err := o.github.GitHubClient(false)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to construct GitHub client.")
+	}
+	tokens, ok := githubClient.(github.AppTokenSource)
+	if !ok {
+		logrus.Fatalf("GitHub client %T can't mint installation tokens.", githubClient)
+	}
+
+	mux := http.NewServeMux()
+	broker := tokenbroker.NewServer(tokens, brokerConfig.Clients, secret.GetSecret)
+	mux.Handle(tokenbroker.TokenPath, broker)
+	mux.Handle(tokenbroker.AppPath, broker)
+	server := &http.Server{Addr: ":" + strconv.Itoa(o.port), Handler: mux}
+	health.ServeReady()
+	if o.unixSocket != "" {
+		interrupts.ListenAndServe(&unixSocketServer{Server: server, path: o.unixSocket}, 5*time.Second)
+	} else {
+		interrupts.ListenAndServeTLS(server, o.tlsCertFile, o.tlsKeyFile, 5*time.Second)
+	}
+}
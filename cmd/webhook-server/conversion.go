@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowv2 "sigs.k8s.io/prow/pkg/apis/prowjobs/v2"
+)
+
+// newConversionWebhook returns the handler converting ProwJobs between the
+// versions the API server serves, through the v1 hub.
+func newConversionWebhook() (*conversion.Webhook, error) {
+	scheme := runtime.NewScheme()
+	if err := prowv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("unable to add the v1 ProwJob API to the scheme: %w", err)
+	}
+	if err := prowv2.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("unable to add the v2 ProwJob API to the scheme: %w", err)
+	}
+	converter := &conversion.Webhook{}
+	if err := converter.InjectScheme(scheme); err != nil {
+		return nil, fmt.Errorf("unable to inject the scheme into the conversion webhook: %w", err)
+	}
+	return converter, nil
+}
+
+// patchCRDConversion makes the API server call the conversion webhook on the
+// given service and trust the current CA when doing so. The CRD is left alone
+// when it does not exist or does not convert through the webhook.
+func patchCRDConversion(ctx context.Context, caPem string, service types.NamespacedName, client ctrlruntimeclient.Client) error {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"})
+	if err := client.Get(ctx, types.NamespacedName{Name: prowJobCRDName}, crd); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get the ProwJob CRD: %w", err)
+	}
+	if strategy, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "strategy"); strategy != "Webhook" {
+		return nil
+	}
+	original := crd.DeepCopy()
+	caBundle := base64.StdEncoding.EncodeToString([]byte(caPem))
+	if err := unstructured.SetNestedField(crd.Object, caBundle, "spec", "conversion", "webhook", "clientConfig", "caBundle"); err != nil {
+		return fmt.Errorf("failed to set the CA bundle of the conversion webhook: %w", err)
+	}
+	for field, value := range map[string]string{"namespace": service.Namespace, "name": service.Name, "path": convertPath} {
+		if err := unstructured.SetNestedField(crd.Object, value, "spec", "conversion", "webhook", "clientConfig", "service", field); err != nil {
+			return fmt.Errorf("failed to set the service of the conversion webhook: %w", err)
+		}
+	}
+	patchOptions := &ctrlruntimeclient.PatchOptions{
+		FieldManager: "webhook-server",
+	}
+	if err := client.Patch(ctx, crd, ctrlruntimeclient.MergeFrom(original), patchOptions); err != nil {
+		return fmt.Errorf("failed to patch the ProwJob CRD: %w", err)
+	}
+	return nil
+}
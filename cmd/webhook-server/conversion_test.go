@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apix "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowv2 "sigs.k8s.io/prow/pkg/apis/prowjobs/v2"
+)
+
+func TestConversionWebhook(t *testing.T) {
+	converter, err := newConversionWebhook()
+	if err != nil {
+		t.Fatalf("failed to create the conversion webhook: %v", err)
+	}
+	v1PJ := prowv1.ProwJob{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "prow.k8s.io/v1", Kind: "ProwJob"},
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "prowjobs"},
+		Spec:       prowv1.ProwJobSpec{Job: "job", MaxConcurrency: 1},
+	}
+	raw, err := json.Marshal(v1PJ)
+	if err != nil {
+		t.Fatalf("failed to marshal the ProwJob: %v", err)
+	}
+	review := apix.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Request: &apix.ConversionRequest{
+			UID:               types.UID("uid"),
+			DesiredAPIVersion: "prow.k8s.io/v2",
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		},
+	}
+	body, err := json.Marshal(review)
+	if err != nil {
+		t.Fatalf("failed to marshal the review: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	converter.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, convertPath, bytes.NewReader(body)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	var response apix.ConversionReview
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal the response: %v", err)
+	}
+	if response.Response == nil || response.Response.Result.Status != metav1.StatusSuccess || len(response.Response.ConvertedObjects) != 1 {
+		t.Fatalf("conversion failed: %+v", response.Response)
+	}
+	var v2PJ prowv2.ProwJob
+	if err := json.Unmarshal(response.Response.ConvertedObjects[0].Raw, &v2PJ); err != nil {
+		t.Fatalf("failed to unmarshal the converted ProwJob: %v", err)
+	}
+	expected := prowv2.ProwJobSpec{Job: "job", Scheduling: &prowv2.Scheduling{MaxConcurrency: 1}}
+	if diff := cmp.Diff(expected, v2PJ.Spec); diff != "" {
+		t.Errorf("unexpected v2 spec (-want +got):\n%s", diff)
+	}
+}
+
+func TestPatchCRDConversion(t *testing.T) {
+	crd := func(strategy string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata":   map[string]interface{}{"name": prowJobCRDName},
+			"spec": map[string]interface{}{
+				"conversion": map[string]interface{}{"strategy": strategy},
+			},
+		}}
+		return u
+	}
+	var testCases = []struct {
+		name             string
+		crd              *unstructured.Unstructured
+		expectedCABundle string
+	}{
+		{
+			name:             "webhook conversion gets the CA",
+			crd:              crd("Webhook"),
+			expectedCABundle: base64.StdEncoding.EncodeToString([]byte("ca")),
+		},
+		{
+			name: "conversion without webhook is left alone",
+			crd:  crd("None"),
+		},
+		{
+			name: "missing CRD is not an error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fakectrlruntimeclient.NewClientBuilder()
+			if tc.crd != nil {
+				builder.WithObjects(tc.crd)
+			}
+			client := builder.Build()
+			service := types.NamespacedName{Namespace: "prow", Name: "webhook-server"}
+			if err := patchCRDConversion(context.Background(), "ca", service, client); err != nil {
+				t.Fatalf("failed to patch the CRD: %v", err)
+			}
+			if tc.crd == nil {
+				return
+			}
+			actual := crd("")
+			if err := client.Get(context.Background(), types.NamespacedName{Name: prowJobCRDName}, actual); err != nil {
+				t.Fatalf("failed to get the CRD: %v", err)
+			}
+			caBundle, _, _ := unstructured.NestedString(actual.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+			if caBundle != tc.expectedCABundle {
+				t.Errorf("expected CA bundle %q, got %q", tc.expectedCABundle, caBundle)
+			}
+			actualService, _, _ := unstructured.NestedStringMap(actual.Object, "spec", "conversion", "webhook", "clientConfig", "service")
+			var expectedService map[string]string
+			if tc.expectedCABundle != "" {
+				expectedService = map[string]string{"namespace": "prow", "name": "webhook-server", "path": convertPath}
+			}
+			if diff := cmp.Diff(expectedService, actualService); diff != "" {
+				t.Errorf("unexpected conversion service (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -47,8 +47,10 @@ const (
 	prowjobAdmissionServiceName  = "prowjob-admission-webhook"
 	prowJobMutatingWebhookName   = "prow-job-mutating-webhook-config.prow.k8s.io"
 	prowJobValidatingWebhookName = "prow-job-validating-webhook-config.prow.k8s.io"
+	prowJobCRDName               = "prowjobs.prow.k8s.io"
 	mutatePath                   = "/mutate"
 	validatePath                 = "/validate"
+	convertPath                  = "/convert"
 )
 
 // for unit testing purposes
@@ -409,7 +411,7 @@ func checkWebhooksExist(ctx context.Context, client ctrlruntimeclient.Client) (s
 	return "", "", false, nil
 }
 
-func reconcileWebhooks(ctx context.Context, caPem string, cl ctrlruntimeclient.Client) error {
+func reconcileWebhooks(ctx context.Context, caPem string, conversionService types.NamespacedName, cl ctrlruntimeclient.Client) error {
 	mutatingCAPem, validatingCAPem, exist, err := checkWebhooksExist(ctx, cl)
 	if err != nil {
 		return err
@@ -429,6 +431,11 @@ func reconcileWebhooks(ctx context.Context, caPem string, cl ctrlruntimeclient.C
 			return fmt.Errorf("unable to generate MutatingWebhookConfig %v", err)
 		}
 	}
+	if caPem != "" {
+		if err := patchCRDConversion(ctx, caPem, conversionService, cl); err != nil {
+			return fmt.Errorf("unable to patch the conversion webhook of the ProwJob CRD %v", err)
+		}
+	}
 	return nil
 }
 
@@ -25,10 +25,12 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/prow/cmd/webhook-server/secretmanager"
 	"sigs.k8s.io/prow/pkg/config"
@@ -65,12 +67,17 @@ type options struct {
 	storage        prowflagutil.StorageClientOptions
 	time           int
 	dryRun         bool
+
+	conversionService string
 }
 
 type clientOptions struct {
 	secretID      string
 	expiryInYears int
 	dnsNames      prowflagutil.Strings
+	// conversionService is the service the API server calls to convert
+	// ProwJobs between API versions.
+	conversionService types.NamespacedName
 }
 
 type webhookAgent struct {
@@ -78,6 +85,7 @@ type webhookAgent struct {
 	statuses map[string]plank.ClusterStatus
 	mu       sync.Mutex
 	plank    config.Plank
+	config   config.Getter
 }
 
 func (o *options) DefaultAndValidate() error {
@@ -97,6 +105,9 @@ func (o *options) DefaultAndValidate() error {
 	if o.projectId != "" && o.secretID == "" {
 		return fmt.Errorf("secretID must be specified if choosing to use a GCP project")
 	}
+	if namespace, name, _ := strings.Cut(o.conversionService, "/"); namespace == "" || name == "" {
+		return fmt.Errorf("conversion service must be given as namespace/name, got %q", o.conversionService)
+	}
 	if o.dnsNames.StringSet().Len() == 0 {
 		o.dnsNames.Add(prowjobAdmissionServiceName + ".default.svc")
 	}
@@ -112,6 +123,7 @@ func gatherOptions(fs *flag.FlagSet, args ...string) options {
 	fs.BoolVar(&o.dryRun, "dry-run", true, "Whether to mutate any real-world state")
 	fs.IntVar(&o.time, "time", 1, "duration in minutes to fetch build clusters")
 	fs.Var(&o.dnsNames, "dns", "DNS Names CA-Cert config")
+	fs.StringVar(&o.conversionService, "conversion-service", defaultNamespace+"/"+prowjobAdmissionServiceName, "Namespace/name of the service serving this webhook-server, which the API server calls to convert ProwJobs between API versions. Its DNS name must be covered by --dns.")
 	optionGroups := []flagutil.OptionGroup{&o.kubernetes, &o.config}
 	for _, optionGroup := range optionGroups {
 		optionGroup.AddFlags(fs)
@@ -147,6 +159,8 @@ func main() {
 		dnsNames:      o.dnsNames,
 		expiryInYears: o.expiryInYears,
 	}
+	namespace, name, _ := strings.Cut(o.conversionService, "/")
+	clientoptions.conversionService = types.NamespacedName{Namespace: namespace, Name: name}
 	if o.projectId != "" {
 		secretManagerClient, err := secretmanager.NewClient(o.projectId, false)
 		if err != nil {
@@ -177,14 +191,21 @@ func main() {
 		storage:  o.storage,
 		statuses: statuses,
 		plank:    cfg.Plank,
+		config:   configAgent.Config,
 	}
 	interrupts.Run(func(ctx context.Context) {
 		wa.fetchClusters(time.Duration(o.time*int(time.Minute)), ctx, &wa.statuses, configAgent)
 	})
 
+	converter, err := newConversionWebhook()
+	if err != nil {
+		logrus.WithError(err).Fatal("could not create the conversion webhook")
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc(validatePath, wa.serveValidate)
 	mux.HandleFunc(mutatePath, wa.serveMutate)
+	mux.Handle(convertPath, converter)
 	s := http.Server{
 		Addr: ":8008",
 		TLSConfig: &tls.Config{
@@ -231,7 +252,7 @@ func handleSecrets(client ClientInterface, ctx context.Context, clientoptions cl
 			}
 		}
 	}
-	if err = reconcileWebhooks(ctx, caPem, cl); err != nil {
+	if err = reconcileWebhooks(ctx, caPem, clientoptions.conversionService, cl); err != nil {
 		return "", "", err
 	}
 	tempDir, err := os.MkdirTemp("", "cert")
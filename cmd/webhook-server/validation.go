@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/plank"
 )
@@ -66,6 +67,8 @@ func (wa *webhookAgent) serveValidate(w http.ResponseWriter, r *http.Request) {
 	if admissionRequest.Operation == "CREATE" {
 		if err := validateProwJobClusterOnCreate(prowJob, wa.statuses); err != nil {
 			admissionResponse = createValidatingAdmissionResponse(admissionRequest.UID, err)
+		} else if err := validateProwJobSecretsOnCreate(prowJob, wa.config().Plank); err != nil {
+			admissionResponse = createValidatingAdmissionResponse(admissionRequest.UID, err)
 		} else {
 			admissionResponse = createValidatingAdmissionResponse(admissionRequest.UID, nil)
 		}
@@ -97,6 +100,21 @@ func validateProwJobClusterOnCreate(prowJob v1.ProwJob, statuses map[string]plan
 	return nil
 }
 
+// validateProwJobSecretsOnCreate denies ProwJobs that reference secret bundles
+// they are not allowed to use, by name or through the secrets of their pod
+// spec, and logs the ones they are allowed to use so that their use can be
+// audited.
+func validateProwJobSecretsOnCreate(prowJob v1.ProwJob, plank config.Plank) error {
+	if err := plank.CheckSecretBundles(prowJob.Spec); err != nil {
+		return fmt.Errorf("%s: %w", prowJob.Name, err)
+	}
+	if len(prowJob.Spec.Secrets) == 0 {
+		return nil
+	}
+	logrus.WithFields(logrus.Fields{"prowjob": prowJob.Name, "job": prowJob.Spec.Job, "secrets": prowJob.Spec.Secrets}).Info("Admitted ProwJob with secret bundles.")
+	return nil
+}
+
 func createValidatingAdmissionResponse(uid types.UID, err error) *v1beta1.AdmissionResponse {
 	var ar *v1beta1.AdmissionResponse
 	var result *apiv1.Status
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestValidateProwJobSecretsOnCreate(t *testing.T) {
+	plank := config.Plank{SecretBundles: map[string]config.SecretBundle{
+		"gcp": {Secret: "gcp-service-account", AllowedRepos: []string{"kubernetes"}},
+	}}
+	secretRef := corev1.LocalObjectReference{Name: "gcp-service-account"}
+	podSpec := func(container corev1.Container, volumes ...corev1.Volume) *corev1.PodSpec {
+		return &corev1.PodSpec{Containers: []corev1.Container{container}, Volumes: volumes}
+	}
+	testCases := []struct {
+		name        string
+		spec        v1.ProwJobSpec
+		expectedErr bool
+	}{
+		{
+			name: "no secrets",
+			spec: v1.ProwJobSpec{Job: "job", Refs: &v1.Refs{Org: "other", Repo: "repo"}},
+		},
+		{
+			name: "allowed secret bundle",
+			spec: v1.ProwJobSpec{Job: "job", Secrets: []string{"gcp"}, Refs: &v1.Refs{Org: "kubernetes", Repo: "kubernetes"}},
+		},
+		{
+			name:        "secret bundle of another org",
+			spec:        v1.ProwJobSpec{Job: "job", Secrets: []string{"gcp"}, Refs: &v1.Refs{Org: "other", Repo: "repo"}},
+			expectedErr: true,
+		},
+		{
+			name: "allowed secret of a bundle in the pod spec",
+			spec: v1.ProwJobSpec{Job: "job", Refs: &v1.Refs{Org: "kubernetes", Repo: "kubernetes"}, PodSpec: podSpec(corev1.Container{}, corev1.Volume{
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "gcp-service-account"}},
+			})},
+		},
+		{
+			name: "secret of a bundle in a volume of another org",
+			spec: v1.ProwJobSpec{Job: "job", Refs: &v1.Refs{Org: "other", Repo: "repo"}, PodSpec: podSpec(corev1.Container{}, corev1.Volume{
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "gcp-service-account"}},
+			})},
+			expectedErr: true,
+		},
+		{
+			name: "secret of a bundle in env of another org",
+			spec: v1.ProwJobSpec{Job: "job", Refs: &v1.Refs{Org: "other", Repo: "repo"}, PodSpec: podSpec(corev1.Container{Env: []corev1.EnvVar{{
+				Name:      "KEY",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: "key"}},
+			}}})},
+			expectedErr: true,
+		},
+		{
+			name: "secret of a bundle in envFrom of another org",
+			spec: v1.ProwJobSpec{Job: "job", Refs: &v1.Refs{Org: "other", Repo: "repo"}, PodSpec: podSpec(corev1.Container{EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: secretRef},
+			}}})},
+			expectedErr: true,
+		},
+		{
+			name: "secret outside of bundles",
+			spec: v1.ProwJobSpec{Job: "job", Refs: &v1.Refs{Org: "other", Repo: "repo"}, PodSpec: podSpec(corev1.Container{EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "other-secret"}},
+			}}})},
+		},
+		{
+			name:        "unknown secret bundle",
+			spec:        v1.ProwJobSpec{Job: "job", Secrets: []string{"aws"}, Refs: &v1.Refs{Org: "kubernetes", Repo: "kubernetes"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateProwJobSecretsOnCreate(v1.ProwJob{Spec: tc.spec}, plank)
+			if (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
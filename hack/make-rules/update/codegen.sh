@@ -159,7 +159,7 @@ gen-deepcopy() {
   echo "Generating DeepCopy() methods..." >&2
   "$deepcopygen" \
     --go-header-file hack/boilerplate/boilerplate.generated.go.txt \
-    --input-dirs sigs.k8s.io/prow/pkg/apis/prowjobs/v1 \
+    --input-dirs sigs.k8s.io/prow/pkg/apis/prowjobs/v1,sigs.k8s.io/prow/pkg/apis/prowjobs/v2 \
     --output-file-base zz_generated.deepcopy \
     --bounding-dirs sigs.k8s.io/prow/pkg/apis
   copyfiles "pkg/apis" "zz_generated.deepcopy.go"
@@ -246,9 +246,23 @@ gen-prowjob-crd(){
   clean "./config/prow/cluster" "prowjob_customresourcedefinition.yaml"
   echo "Generating prowjob crd..." >&2
   if [[ -z ${HOME:-} ]]; then export HOME=$PWD; fi
-  $controller_gen crd:preserveUnknownFields=false,crdVersions=v1 paths=./pkg/apis/prowjobs/v1 output:stdout \
+  $controller_gen crd:preserveUnknownFields=false,crdVersions=v1 paths=./pkg/apis/prowjobs/... output:stdout \
     | $SED '/^$/d' \
     | $SED '/^spec:.*/a  \  preserveUnknownFields: false' \
+    | $SED -e '/^  name: prowjobs.prow.k8s.io/,/^spec:/{/^spec:/r'<(cat<<EOF
+  conversion:
+    strategy: Webhook
+    webhook:
+      clientConfig:
+        service:
+          name: prowjob-admission-webhook
+          namespace: default
+          path: /convert
+          port: 443
+      conversionReviewVersions:
+      - v1
+EOF
+    ) -e '}' \
     | $SED '/^  annotations.*/a  \    api-approved.kubernetes.io: https://github.com/kubernetes/test-infra/pull/8669' \
     | $SED '/^          status:/r'<(cat<<EOF
             anyOf:
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package adminaccess restricts access to sensitive HTTP endpoints by the
+// address of the client and by TLS client certificates, for deployments that
+// do not have a service mesh to do so.
+package adminaccess
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Policy decides which requests may reach protected endpoints. The zero
+// value and a nil Policy admit every request.
+type Policy struct {
+	allowedNets []*net.IPNet
+	// requireClientCert requires a client certificate that was verified
+	// against the client CA of the server.
+	requireClientCert bool
+	// allowedNames are the common names and DNS names of the client
+	// certificates that are admitted. If empty, every verified certificate
+	// is admitted.
+	allowedNames sets.Set[string]
+}
+
+// NewPolicy returns a Policy that admits requests from the CIDRs, or from
+// everywhere if there are none. If requireClientCert is set, requests must
+// also present a verified client certificate whose common name or one of
+// whose DNS names is in allowedNames, unless allowedNames is empty.
+func NewPolicy(cidrs []string, requireClientCert bool, allowedNames []string) (*Policy, error) {
+	if len(allowedNames) > 0 && !requireClientCert {
+		return nil, fmt.Errorf("allowed client certificate names require client certificates")
+	}
+	policy := &Policy{requireClientCert: requireClientCert, allowedNames: sets.New[string](allowedNames...)}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		policy.allowedNets = append(policy.allowedNets, ipNet)
+	}
+	return policy, nil
+}
+
+// Admit determines whether the request may reach a protected endpoint and
+// explains why not if it may not.
+func (p *Policy) Admit(r *http.Request) (bool, string) {
+	if p == nil {
+		return true, ""
+	}
+	if len(p.allowedNets) > 0 {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return false, fmt.Sprintf("unparseable remote address %q", r.RemoteAddr)
+		}
+		if !p.allowed(ip) {
+			return false, fmt.Sprintf("remote address %s is not in an allowed CIDR", ip)
+		}
+	}
+	if p.requireClientCert {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+			return false, "no verified client certificate"
+		}
+		if p.allowedNames.Len() > 0 {
+			leaf := r.TLS.VerifiedChains[0][0]
+			if !p.allowedNames.Has(leaf.Subject.CommonName) && !p.allowedNames.HasAny(leaf.DNSNames...) {
+				return false, fmt.Sprintf("client certificate %q is not allowed", leaf.Subject.CommonName)
+			}
+		}
+	}
+	return true, ""
+}
+
+func (p *Policy) allowed(ip net.IP) bool {
+	for _, ipNet := range p.allowedNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Protect wraps the handler so that requests the policy does not admit are
+// rejected with 403 Forbidden. A nil Policy returns the handler unchanged.
+func (p *Policy) Protect(handler http.Handler) http.Handler {
+	if p == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, reason := p.Admit(r); !ok {
+			logrus.WithFields(logrus.Fields{"path": r.URL.Path, "remote": r.RemoteAddr}).Infof("Rejected request to protected endpoint: %s.", reason)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// ServerTLSConfig returns the TLS configuration of a server that verifies the
+// client certificates it is given against the CAs in clientCAFile. Clients
+// without a certificate are still served, so that unprotected endpoints stay
+// reachable; Policy rejects them on the protected ones.
+func ServerTLSConfig(clientCAFile string) (*tls.Config, error) {
+	raw, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(raw) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", clientCAFile)
+	}
+	return &tls.Config{
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		ClientCAs:  pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
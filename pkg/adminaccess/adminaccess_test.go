@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adminaccess
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func verified(commonName string, dnsNames ...string) *tls.ConnectionState {
+	return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: commonName}, DNSNames: dnsNames}}}}
+}
+
+func TestAdmit(t *testing.T) {
+	testCases := []struct {
+		name              string
+		cidrs             []string
+		requireClientCert bool
+		allowedNames      []string
+		remoteAddr        string
+		tls               *tls.ConnectionState
+		expected          bool
+	}{
+		{
+			name:       "no restrictions admit everything",
+			remoteAddr: "203.0.113.7:1234",
+			expected:   true,
+		},
+		{
+			name:       "address in an allowed CIDR is admitted",
+			cidrs:      []string{"192.0.2.0/24", "203.0.113.0/24"},
+			remoteAddr: "203.0.113.7:1234",
+			expected:   true,
+		},
+		{
+			name:       "IPv6 address in an allowed CIDR is admitted",
+			cidrs:      []string{"2001:db8::/32"},
+			remoteAddr: "[2001:db8::1]:1234",
+			expected:   true,
+		},
+		{
+			name:       "address outside of the allowed CIDRs is rejected",
+			cidrs:      []string{"192.0.2.0/24"},
+			remoteAddr: "203.0.113.7:1234",
+		},
+		{
+			name:              "request without client certificate is rejected",
+			requireClientCert: true,
+			remoteAddr:        "203.0.113.7:1234",
+		},
+		{
+			name:              "request with an unverified client certificate is rejected",
+			requireClientCert: true,
+			remoteAddr:        "203.0.113.7:1234",
+			tls:               &tls.ConnectionState{},
+		},
+		{
+			name:              "request with a verified client certificate is admitted",
+			requireClientCert: true,
+			remoteAddr:        "203.0.113.7:1234",
+			tls:               verified("anyone"),
+			expected:          true,
+		},
+		{
+			name:              "client certificate with an allowed common name is admitted",
+			requireClientCert: true,
+			allowedNames:      []string{"github-gateway"},
+			remoteAddr:        "203.0.113.7:1234",
+			tls:               verified("github-gateway"),
+			expected:          true,
+		},
+		{
+			name:              "client certificate with an allowed DNS name is admitted",
+			requireClientCert: true,
+			allowedNames:      []string{"gateway.example.com"},
+			remoteAddr:        "203.0.113.7:1234",
+			tls:               verified("gateway", "other.example.com", "gateway.example.com"),
+			expected:          true,
+		},
+		{
+			name:              "client certificate without an allowed name is rejected",
+			requireClientCert: true,
+			allowedNames:      []string{"github-gateway"},
+			remoteAddr:        "203.0.113.7:1234",
+			tls:               verified("someone-else"),
+		},
+		{
+			name:              "verified client certificate from outside of the allowed CIDRs is rejected",
+			cidrs:             []string{"192.0.2.0/24"},
+			requireClientCert: true,
+			remoteAddr:        "203.0.113.7:1234",
+			tls:               verified("anyone"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewPolicy(tc.cidrs, tc.requireClientCert, tc.allowedNames)
+			if err != nil {
+				t.Fatalf("failed to create policy: %v", err)
+			}
+			request := httptest.NewRequest(http.MethodPost, "/hook", nil)
+			request.RemoteAddr = tc.remoteAddr
+			request.TLS = tc.tls
+			if actual, reason := policy.Admit(request); actual != tc.expected {
+				t.Errorf("expected admitted to be %t, got %t (%s)", tc.expected, actual, reason)
+			}
+		})
+	}
+}
+
+func TestNewPolicyErrors(t *testing.T) {
+	if _, err := NewPolicy([]string{"10.0.0.1"}, false, nil); err == nil {
+		t.Error("expected an error for an address that is not a CIDR")
+	}
+	if _, err := NewPolicy(nil, false, []string{"gateway"}); err == nil {
+		t.Error("expected an error for allowed names without client certificates")
+	}
+}
+
+func TestProtect(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	var nilPolicy *Policy
+	recorder := httptest.NewRecorder()
+	nilPolicy.Protect(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/rerun", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected a nil policy to admit the request, got status %d", recorder.Code)
+	}
+
+	policy, err := NewPolicy([]string{"192.0.2.0/24"}, false, nil)
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	request := httptest.NewRequest(http.MethodGet, "/rerun", nil)
+	request.RemoteAddr = "203.0.113.7:1234"
+	recorder = httptest.NewRecorder()
+	policy.Protect(handler).ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+}
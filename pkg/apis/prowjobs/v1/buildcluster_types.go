@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultBuildClusterTokenKey is the key of the token in the secret
+	// referenced by a BuildCluster if none is configured.
+	DefaultBuildClusterTokenKey = "token"
+	// DefaultTokenRotationInterval is how often the token of a BuildCluster
+	// is rotated if no interval is configured.
+	DefaultTokenRotationInterval = 24 * time.Hour
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+
+// BuildCluster registers a build cluster with the components running in the
+// infrastructure cluster, as an alternative to kubeconfig secrets. The name
+// of the resource is the name of the cluster that jobs refer to, and its
+// namespace must be the ProwJob namespace.
+type BuildCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BuildClusterSpec   `json:"spec,omitempty"`
+	Status BuildClusterStatus `json:"status,omitempty"`
+}
+
+// BuildClusterSpec describes how to connect to a build cluster.
+type BuildClusterSpec struct {
+	// Server is the address of the API server of the cluster, e.g.
+	// https://10.0.0.1:443.
+	// +kubebuilder:validation:Required
+	Server string `json:"server"`
+	// CertificateAuthorityData holds the PEM encoded certificate authority
+	// that the serving certificate of the API server is verified against.
+	// The system roots are used if it is empty.
+	CertificateAuthorityData []byte `json:"certificate_authority_data,omitempty"`
+	// Auth configures how to authenticate with the cluster.
+	// +kubebuilder:validation:Required
+	Auth BuildClusterAuth `json:"auth"`
+}
+
+// BuildClusterAuth configures how to authenticate with a build cluster.
+// Exactly one of its fields must be set.
+type BuildClusterAuth struct {
+	// Token authenticates with a bearer token stored in a secret.
+	Token *BuildClusterToken `json:"token,omitempty"`
+	// WorkloadIdentity authenticates with the identity of the component
+	// itself, i.e. with a projected service account token that the build
+	// cluster is configured to trust.
+	WorkloadIdentity *WorkloadIdentity `json:"workload_identity,omitempty"`
+}
+
+// BuildClusterToken references a bearer token stored in a secret.
+type BuildClusterToken struct {
+	// SecretName is the name of the secret holding the token. The secret
+	// must be in the namespace of the BuildCluster.
+	// +kubebuilder:validation:Required
+	SecretName string `json:"secret_name"`
+	// Key is the key of the token in the secret. Defaults to "token".
+	Key string `json:"key,omitempty"`
+	// Rotation configures the automated rotation of the token. The token is
+	// not rotated if it is unset.
+	Rotation *TokenRotation `json:"rotation,omitempty"`
+}
+
+// KeyOrDefault returns the key of the token in the secret.
+func (t *BuildClusterToken) KeyOrDefault() string {
+	if t.Key == "" {
+		return DefaultBuildClusterTokenKey
+	}
+	return t.Key
+}
+
+// TokenRotation configures the rotation of the token of a build cluster.
+// New tokens are requested for a service account of the build cluster with
+// the current token and are valid for twice the interval, so that components
+// that have not picked up the new token yet keep working.
+type TokenRotation struct {
+	// ServiceAccount is the name of the service account in the build cluster
+	// that tokens are requested for.
+	// +kubebuilder:validation:Required
+	ServiceAccount string `json:"service_account"`
+	// Namespace is the namespace of the service account in the build cluster.
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+	// Interval is how often the token is rotated. Defaults to 24h.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// IntervalOrDefault returns how often the token is rotated.
+func (r *TokenRotation) IntervalOrDefault() time.Duration {
+	if r.Interval == nil || r.Interval.Duration <= 0 {
+		return DefaultTokenRotationInterval
+	}
+	return r.Interval.Duration
+}
+
+// WorkloadIdentity authenticates with a projected service account token of
+// the component.
+type WorkloadIdentity struct {
+	// TokenFile is the path of the projected service account token in the
+	// pods of the components, whose audience the build cluster accepts.
+	// +kubebuilder:validation:Required
+	TokenFile string `json:"token_file"`
+}
+
+// BuildClusterStatus holds the health of a build cluster as last probed by
+// the build cluster controller.
+type BuildClusterStatus struct {
+	// Healthy is true if the API server of the cluster could be reached with
+	// the configured credentials during the last probe.
+	Healthy bool `json:"healthy"`
+	// Message explains why the cluster is not healthy.
+	Message string `json:"message,omitempty"`
+	// ServerVersion is the version of the API server of the cluster.
+	ServerVersion string `json:"server_version,omitempty"`
+	// LastProbeTime is when the cluster was last probed.
+	LastProbeTime metav1.Time `json:"last_probe_time,omitempty"`
+	// LastRotationTime is when the token of the cluster was last rotated.
+	LastRotationTime *metav1.Time `json:"last_rotation_time,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BuildClusterList is a list of BuildCluster resources
+type BuildClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []BuildCluster `json:"items"`
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JobConfig holds a part of the job configuration as a custom resource, as
+// an alternative to sharding the job configuration into ConfigMaps. The
+// namespace of the resource determines which repositories it may configure
+// jobs for, see the job_config_resources section of the Prow config.
+type JobConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec JobConfigSpec `json:"spec,omitempty"`
+}
+
+// JobConfigSpec holds the job configuration.
+type JobConfigSpec struct {
+	// Config is the job configuration in the same YAML format as the job
+	// config files, e.g. holding presubmits, postsubmits, periodics and presets.
+	// +kubebuilder:validation:Required
+	Config string `json:"config"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JobConfigList is a list of JobConfig resources
+type JobConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []JobConfig `json:"items"`
+}
@@ -58,6 +58,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ProwJob{},
 		&ProwJobList{},
+		&JobConfig{},
+		&JobConfigList{},
+		&BuildCluster{},
+		&BuildClusterList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -22,11 +22,15 @@ import (
 	"fmt"
 	"mime"
 	"net/url"
+	"path"
+	"regexp"
 	"strings"
+	"text/template"
 	"time"
 
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -69,6 +73,23 @@ const (
 	ErrorState ProwJobState = "error"
 )
 
+// Types of the conditions of a ProwJob.
+const (
+	// ProwJobScheduled is true once the job left the scheduling state.
+	ProwJobScheduled = "Scheduled"
+	// ProwJobPodCreated is true once the agent started to run the job, be it
+	// as a pod, a PipelineRun or a Jenkins build.
+	ProwJobPodCreated = "PodCreated"
+	// ProwJobCompleted is true once the job completed, whatever its result.
+	ProwJobCompleted = "Completed"
+	// ProwJobReported is true once a reporter reported the current state of
+	// the job.
+	ProwJobReported = "Reported"
+	// ProwJobArtifactsUploaded tells whether the sidecar of a decorated job
+	// uploaded its artifacts.
+	ProwJobArtifactsUploaded = "ArtifactsUploaded"
+)
+
 // GetAllProwJobStates returns all possible job states.
 func GetAllProwJobStates() []ProwJobState {
 	return []ProwJobState{
@@ -111,12 +132,17 @@ const (
 
 	// CloneRecordFile is the JSON file that stores clone records of a prowjob.
 	CloneRecordFile = "clone-records.json"
+
+	// FailureFile is the JSON file that classifies why a build failed, see
+	// Failure.
+	FailureFile = "failure.json"
 )
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ProwJob contains the spec as well as runtime metadata.
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Job",type=string,JSONPath=`.spec.job`,description="The name of the job being run"
 // +kubebuilder:printcolumn:name="BuildId",type=string,JSONPath=`.status.build_id`,description="The ID of the job being run."
 // +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`,description="The type of job being run."
@@ -134,6 +160,9 @@ type ProwJob struct {
 	Status ProwJobStatus `json:"status,omitempty"`
 }
 
+// Hub marks v1 as the version other versions of ProwJob convert through.
+func (*ProwJob) Hub() {}
+
 // ProwJobSpec configures the details of the prow job.
 //
 // Details include the podspec, code to clone, the cluster it runs
@@ -229,6 +258,49 @@ type ProwJobSpec struct {
 	// This behaviour may be superseded by MaxConcurrency field, if it
 	// is set to a constraining value.
 	JobQueueName string `json:"job_queue_name,omitempty"`
+
+	// ArtifactRetention is how long the artifacts of the job are kept. It is
+	// recorded in the finished.json of the build for the artifact retention
+	// controller and bucket lifecycle tooling.
+	ArtifactRetention *JobArtifactRetention `json:"artifact_retention,omitempty"`
+
+	// Secrets are the names of the secret bundles of the Prow config that
+	// are injected into the pod of the job. They are checked against the
+	// allowlists of the bundles when the ProwJob is created.
+	Secrets []string `json:"secrets,omitempty"`
+}
+
+// ArtifactRetentionMetadataKey is the key of the ArtifactRetention of a
+// job in the metadata of the finished.json of its builds.
+const ArtifactRetentionMetadataKey = "artifact-retention"
+
+// JobArtifactRetention is the retention policy of the artifacts of a job. It
+// takes precedence over the artifact_retention policies of the Prow config.
+type JobArtifactRetention struct {
+	// Duration is how long the artifacts of a build are kept after it
+	// started. They are kept forever if unset.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// KeepLast is how many of the latest builds of the job are kept
+	// regardless of their age.
+	// +kubebuilder:validation:Minimum=0
+	KeepLast int `json:"keep_last,omitempty"`
+	// StorageClass is the storage class the artifacts of a build are moved
+	// to once it finished, e.g. NEARLINE. Only GCS buckets support it.
+	StorageClass string `json:"storage_class,omitempty"`
+}
+
+// Validate checks the retention policy for errors.
+func (ar *JobArtifactRetention) Validate() error {
+	if ar == nil {
+		return nil
+	}
+	if ar.Duration != nil && ar.Duration.Duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %s", ar.Duration.Duration)
+	}
+	if ar.KeepLast < 0 {
+		return fmt.Errorf("keep_last must not be negative, got %d", ar.KeepLast)
+	}
+	return nil
 }
 
 func (pjs ProwJobSpec) HasPipelineRunSpec() bool {
@@ -276,6 +348,10 @@ type RerunAuthConfig struct {
 	GitHubUsers []string `json:"github_users,omitempty"`
 	// GitHubOrgs contains names of GitHub organizations whose members can rerun the job
 	GitHubOrgs []string `json:"github_orgs,omitempty"`
+	// OIDCGroups contains names of groups of the OIDC identity provider of Deck
+	// whose members can rerun the job. The groups are read from the groups claim
+	// of the ID token of the user, see the --oidc-* flags of Deck.
+	OIDCGroups []string `json:"oidc_groups,omitempty"`
 }
 
 // IsSpecifiedUser returns true if AllowAnyone is set to true or if the given user is
@@ -326,13 +402,29 @@ func (rac *RerunAuthConfig) IsAuthorized(org, user string, cli prowgithub.RerunC
 	return false, nil
 }
 
+// IsAuthorizedByGroups returns true if one of the given OIDC groups of a user
+// is permitted to rerun the job.
+func (rac *RerunAuthConfig) IsAuthorizedByGroups(groups []string) bool {
+	if rac == nil {
+		return false
+	}
+	for _, allowed := range rac.OIDCGroups {
+		for _, group := range groups {
+			if allowed == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Validate validates the RerunAuthConfig fields.
 func (rac *RerunAuthConfig) Validate() error {
 	if rac == nil {
 		return nil
 	}
 
-	hasAllowList := len(rac.GitHubUsers) > 0 || len(rac.GitHubTeamIDs) > 0 || len(rac.GitHubTeamSlugs) > 0 || len(rac.GitHubOrgs) > 0
+	hasAllowList := len(rac.GitHubUsers) > 0 || len(rac.GitHubTeamIDs) > 0 || len(rac.GitHubTeamSlugs) > 0 || len(rac.GitHubOrgs) > 0 || len(rac.OIDCGroups) > 0
 
 	// If an allowlist is specified, the user probably does not intend for anyone to be able to rerun any job.
 	if rac.AllowAnyone && hasAllowList {
@@ -498,6 +590,9 @@ type DecorationConfig struct {
 	// BloblessFetch tells Prow to avoid fetching objects when cloning using
 	// the --filter=blob:none flag.
 	BloblessFetch *bool `json:"blobless_fetch,omitempty"`
+	// TreelessFetch tells Prow to avoid fetching trees and blobs when cloning
+	// using the --filter=tree:0 flag. Takes precedence over BloblessFetch.
+	TreelessFetch *bool `json:"treeless_fetch,omitempty"`
 	// SkipCloning determines if we should clone source code in the
 	// initcontainers for jobs that specify refs
 	SkipCloning *bool `json:"skip_cloning,omitempty"`
@@ -526,6 +621,11 @@ type DecorationConfig struct {
 	// hope that the test process exits cleanly before starting an upload.
 	UploadIgnoresInterrupts *bool `json:"upload_ignores_interrupts,omitempty"`
 
+	// UploadInterval is how often sidecar uploads the build logs while the
+	// test is still running. If unset, logs are only uploaded once the test
+	// finished.
+	UploadInterval *Duration `json:"upload_interval,omitempty"`
+
 	// SetLimitEqualsMemoryRequest sets memory limit equal to request.
 	SetLimitEqualsMemoryRequest *bool `json:"set_limit_equals_memory_request,omitempty"`
 	// DefaultMemoryRequest is the default requested memory on a test container.
@@ -557,6 +657,40 @@ type DecorationConfig struct {
 	// This field will not override the existing ProwJob's PodSecurityContext.
 	// Equivalent to PodSecurityContext's FsGroup
 	FsGroup *int64 `json:"fs_group,omitempty"`
+
+	// Caches are directories of the test containers that are restored from
+	// blob storage before the test starts and saved there after it passed.
+	Caches []Cache `json:"caches,omitempty"`
+
+	// StartedMetadata are extra key/values that initupload adds to the
+	// metadata of started.json, e.g. the purpose of the build or the cost
+	// center it is billed to. Keys set on the job take precedence over the
+	// defaults, and job annotations prefixed with
+	// prow.k8s.io/started-metadata. take precedence over both.
+	StartedMetadata map[string]string `json:"started_metadata,omitempty"`
+}
+
+// StartedMetadataAnnotationPrefix prefixes the annotations of a ProwJob that
+// are added to the metadata of the started.json of its build, without the
+// prefix, e.g. prow.k8s.io/started-metadata.tenant: foo adds tenant: foo.
+const StartedMetadataAnnotationPrefix = "prow.k8s.io/started-metadata."
+
+// Cache is a set of directories of the test containers that is kept in the
+// GCS bucket of the job between runs.
+type Cache struct {
+	// Key names the cache in the bucket. It is a Go template that is executed
+	// with the name of the job as {{.Job}} and its main refs as {{.Org}},
+	// {{.Repo}} and {{.BaseRef}}. Runs of the same job type and repo whose
+	// keys render the same share the cache. The rendered key must not contain
+	// "..".
+	Key string `json:"key"`
+	// Paths are the absolute paths of the directories to cache.
+	Paths []string `json:"paths"`
+	// SaveFromPresubmits saves the cache after presubmit and batch runs too.
+	// They run untrusted code, so by default they only restore the cache
+	// saved by postsubmits. Their caches are kept apart from the ones of
+	// postsubmits and periodics in any case.
+	SaveFromPresubmits bool `json:"save_from_presubmits,omitempty"`
 }
 
 type CensoringOptions struct {
@@ -762,6 +896,10 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 		merged.UploadIgnoresInterrupts = def.UploadIgnoresInterrupts
 	}
 
+	if merged.UploadInterval == nil {
+		merged.UploadInterval = def.UploadInterval
+	}
+
 	if merged.SetLimitEqualsMemoryRequest == nil {
 		merged.SetLimitEqualsMemoryRequest = def.SetLimitEqualsMemoryRequest
 	}
@@ -797,6 +935,27 @@ func (d *DecorationConfig) ApplyDefault(def *DecorationConfig) *DecorationConfig
 	if merged.BloblessFetch == nil {
 		merged.BloblessFetch = def.BloblessFetch
 	}
+
+	if merged.TreelessFetch == nil {
+		merged.TreelessFetch = def.TreelessFetch
+	}
+
+	if merged.Caches == nil {
+		merged.Caches = def.Caches
+	}
+
+	// Merge into a fresh map so that neither the job's nor the default's
+	// map is written to, as the result may be defaulted again.
+	if len(def.StartedMetadata) > 0 {
+		startedMetadata := make(map[string]string, len(merged.StartedMetadata)+len(def.StartedMetadata))
+		for key, value := range def.StartedMetadata {
+			startedMetadata[key] = value
+		}
+		for key, value := range merged.StartedMetadata {
+			startedMetadata[key] = value
+		}
+		merged.StartedMetadata = startedMetadata
+	}
 	return &merged
 }
 
@@ -835,6 +994,31 @@ func (d *DecorationConfig) Validate() error {
 	if d.OauthTokenSecret != nil && len(d.SSHKeySecrets) > 0 {
 		return errors.New("both OAuth token and SSH key secrets are specified")
 	}
+	for i, cache := range d.Caches {
+		if err := cache.Validate(); err != nil {
+			return fmt.Errorf("cache %d is invalid: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate ensures that the key of the cache is a valid template and that
+// it has absolute paths.
+func (c *Cache) Validate() error {
+	if c.Key == "" {
+		return errors.New("key is not specified")
+	}
+	if _, err := template.New("key").Option("missingkey=error").Parse(c.Key); err != nil {
+		return fmt.Errorf("key is not a valid template: %w", err)
+	}
+	if len(c.Paths) == 0 {
+		return errors.New("paths are not specified")
+	}
+	for _, p := range c.Paths {
+		if !path.IsAbs(p) {
+			return fmt.Errorf("path %q is not absolute", p)
+		}
+	}
 	return nil
 }
 
@@ -928,6 +1112,11 @@ type GCSConfiguration struct {
 	// Example: "txt", "json"
 	// Use "*" for all
 	CompressFileTypes []string `json:"compress_file_types,omitempty"`
+	// ChecksumManifest makes the pod utilities upload a manifest of the path,
+	// size and SHA256 checksum of every file they upload for a job to
+	// checksum-manifest.json in the job's directory. Checksums are of the
+	// content before it is compressed.
+	ChecksumManifest *bool `json:"checksum_manifest,omitempty"`
 }
 
 // ApplyDefault applies the defaults for GCSConfiguration decorations. If a field has a zero value,
@@ -980,9 +1169,18 @@ func (g *GCSConfiguration) ApplyDefault(def *GCSConfiguration) *GCSConfiguration
 	if merged.CompressFileTypes == nil {
 		merged.CompressFileTypes = def.CompressFileTypes
 	}
+	if merged.ChecksumManifest == nil {
+		merged.ChecksumManifest = def.ChecksumManifest
+	}
 	return &merged
 }
 
+// ChecksumManifestEnabled determines whether a checksum manifest of the
+// uploaded files is uploaded along with them.
+func (g *GCSConfiguration) ChecksumManifestEnabled() bool {
+	return g != nil && g.ChecksumManifest != nil && *g.ChecksumManifest
+}
+
 // Validate ensures all the values set in the GCSConfiguration are valid.
 func (g *GCSConfiguration) Validate() error {
 	if _, err := ParsePath(g.Bucket); err != nil {
@@ -1073,6 +1271,35 @@ type ProwJobStatus struct {
 	// ProwJob.
 	JenkinsBuildID string `json:"jenkins_build_id,omitempty"`
 
+	// JenkinsStages applies only to ProwJobs fulfilled by the
+	// jenkins-operator for Pipeline jobs. It holds the results
+	// of the stages of the build, in order.
+	JenkinsStages []JenkinsStage `json:"jenkins_stages,omitempty"`
+
+	// TektonTaskRuns applies only to ProwJobs fulfilled by the
+	// tekton-pipeline agent. It holds the state and results of the
+	// TaskRuns of the PipelineRun, in the order Tekton reports them.
+	TektonTaskRuns []TektonTaskRun `json:"tekton_task_runs,omitempty"`
+
+	// TestSummary summarizes the junit results the job wrote to its
+	// artifacts, when the job is decorated and wrote any.
+	TestSummary *TestSummary `json:"test_summary,omitempty"`
+
+	// Failure classifies why the job failed, when the job is decorated and
+	// failed.
+	Failure *Failure `json:"failure,omitempty"`
+
+	// Cancellation records who aborted the job and why, when it was
+	// aborted before it completed.
+	Cancellation *Cancellation `json:"cancellation,omitempty"`
+
+	// Conditions mirror the progress of the job as standard Kubernetes
+	// conditions, so that `kubectl wait` and other generic tooling can
+	// follow it.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
 	// PrevReportStates stores the previous reported prowjob state per reporter
 	// So crier won't make duplicated report attempt
 	PrevReportStates map[string]ProwJobState `json:"prev_report_states,omitempty"`
@@ -1090,6 +1317,56 @@ func (j *ProwJob) SetComplete() {
 	*j.Status.CompletionTime = metav1.Now()
 }
 
+// SetCondition adds or updates the condition of the given type. The
+// transition time only changes along with the status of the condition.
+func (j *ProwJob) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&j.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: j.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// UpdateConditions derives the Scheduled, PodCreated and Completed conditions
+// from the state of the job. Agents call it before they persist a change.
+func (j *ProwJob) UpdateConditions() {
+	if j.Status.State == SchedulingState {
+		j.SetCondition(ProwJobScheduled, metav1.ConditionFalse, "Scheduling", "The job is waiting to be scheduled.")
+	} else if j.Status.State != "" {
+		j.SetCondition(ProwJobScheduled, metav1.ConditionTrue, "Scheduled", "The job was scheduled.")
+	}
+
+	switch j.Status.State {
+	case SchedulingState, TriggeredState:
+		j.SetCondition(ProwJobPodCreated, metav1.ConditionFalse, "Waiting", "The job did not start yet.")
+	case PendingState:
+		j.SetCondition(ProwJobPodCreated, metav1.ConditionTrue, "Created", "The job started.")
+	}
+
+	if !j.Complete() {
+		if j.Status.State != "" {
+			j.SetCondition(ProwJobCompleted, metav1.ConditionFalse, "Running", "The job did not complete yet.")
+		}
+		return
+	}
+	var reason string
+	switch j.Status.State {
+	case SuccessState:
+		reason = "Succeeded"
+	case FailureState:
+		reason = "Failed"
+	case AbortedState:
+		reason = "Aborted"
+	case ErrorState:
+		reason = "Errored"
+	default:
+		reason = "Completed"
+	}
+	j.SetCondition(ProwJobCompleted, metav1.ConditionTrue, reason, j.Status.Description)
+}
+
 // ClusterAlias specifies the key in the clusters map to use.
 //
 // This allows scheduling a prow job somewhere aside from the default build cluster.
@@ -1169,6 +1446,15 @@ type Refs struct {
 	// using the --filter=blob:none flag. If unspecified, defaults to
 	// DecorationConfig.BloblessFetch.
 	BloblessFetch *bool `json:"blobless_fetch,omitempty"`
+	// TreelessFetch tells prow to avoid fetching trees and blobs when
+	// cloning using the --filter=tree:0 flag. Takes precedence over
+	// BloblessFetch. If unspecified, defaults to
+	// DecorationConfig.TreelessFetch.
+	TreelessFetch *bool `json:"treeless_fetch,omitempty"`
+	// Filter is the partial clone filter that the repository is fetched
+	// with, e.g. blob:none or tree:0, see the --filter option of
+	// git rev-list. Takes precedence over TreelessFetch and BloblessFetch.
+	Filter string `json:"filter,omitempty"`
 }
 
 func (r Refs) String() string {
@@ -1198,6 +1484,27 @@ func (r Refs) OrgRepoString() string {
 	return r.Org
 }
 
+var cloneFilterRe = regexp.MustCompile(`^(blob:none|blob:limit=[0-9]+[kmg]?|tree:[0-9]+|object:type=(tag|commit|tree|blob)|sparse:oid=[^\s+]+)$`)
+
+// ValidateCloneFilter checks that the filter is a partial clone filter that
+// git understands, see the --filter option of git rev-list. An empty filter
+// is valid.
+func ValidateCloneFilter(filter string) error {
+	if filter == "" {
+		return nil
+	}
+	filters := []string{filter}
+	if combined, ok := strings.CutPrefix(filter, "combine:"); ok {
+		filters = strings.Split(combined, "+")
+	}
+	for _, f := range filters {
+		if !cloneFilterRe.MatchString(f) {
+			return fmt.Errorf("invalid partial clone filter %q", f)
+		}
+	}
+	return nil
+}
+
 // JenkinsSpec is optional parameters for Jenkins jobs.
 // Currently, the only parameter supported is for telling
 // jenkins-operator that the job is generated by the https://go.cloudbees.com/docs/plugins/github-branch-source/#github-branch-source plugin
@@ -1205,6 +1512,169 @@ type JenkinsSpec struct {
 	GitHubBranchSourceJob bool `json:"github_branch_source_job,omitempty"`
 }
 
+// JenkinsStage is the result of a stage of a Jenkins Pipeline build.
+type JenkinsStage struct {
+	Name string `json:"name"`
+	// State is the state of the stage: pending while it runs, then
+	// success, failure or aborted.
+	State ProwJobState `json:"state"`
+}
+
+// CancellationReason is why a ProwJob was aborted.
+type CancellationReason string
+
+const (
+	// CancelledByUser means a user aborted the job, e.g. from Deck.
+	CancelledByUser CancellationReason = "user_action"
+	// CancelledSuperseded means a newer run of the job replaced it, e.g.
+	// after new commits were pushed to the pull request.
+	CancelledSuperseded CancellationReason = "superseded"
+	// CancelledAbandoned means the pull request the job tests was closed
+	// or converted to a draft.
+	CancelledAbandoned CancellationReason = "abandoned"
+	// CancelledTimeout means the job ran for longer than it is allowed to.
+	CancelledTimeout CancellationReason = "timeout"
+	// CancelledQuota means the job was aborted to free up a quota.
+	CancelledQuota CancellationReason = "quota"
+	// CancelledFreeze means the job was aborted because of a freeze.
+	CancelledFreeze CancellationReason = "freeze"
+)
+
+// Cancellation records who aborted a ProwJob and why.
+type Cancellation struct {
+	Reason CancellationReason `json:"reason"`
+	// Requester is the user who aborted the job, or the component that
+	// aborted it on its own.
+	Requester string `json:"requester,omitempty"`
+	// SupersededBy is the name of the ProwJob that superseded this one.
+	SupersededBy string `json:"superseded_by,omitempty"`
+}
+
+// String describes the cancellation, e.g. "aborted by alice".
+func (c Cancellation) String() string {
+	if c.Reason == CancelledByUser {
+		if c.Requester == "" {
+			return "aborted by a user"
+		}
+		return fmt.Sprintf("aborted by %s", c.Requester)
+	}
+	var desc string
+	switch c.Reason {
+	case CancelledSuperseded:
+		desc = "superseded by a newer run"
+	case CancelledAbandoned:
+		desc = "aborted as the pull request was closed or converted to a draft"
+	case CancelledTimeout:
+		desc = "aborted after timing out"
+	case CancelledQuota:
+		desc = "aborted to free up quota"
+	case CancelledFreeze:
+		desc = "aborted because of a freeze"
+	default:
+		desc = fmt.Sprintf("aborted (%s)", c.Reason)
+	}
+	if c.Requester != "" {
+		desc = fmt.Sprintf("%s (requested by %s)", desc, c.Requester)
+	}
+	return desc
+}
+
+// MaxSummaryFailedTests is the number of failed tests a TestSummary names.
+const MaxSummaryFailedTests = 10
+
+// TestSummary counts the results of the tests a job ran.
+type TestSummary struct {
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	// FailedTests are the names of the first MaxSummaryFailedTests failed
+	// tests, in the order the junit results list them.
+	FailedTests []string `json:"failed_tests,omitempty"`
+}
+
+// String describes the failed tests, e.g. "3 tests failed: TestFoo, TestBar, TestBaz".
+func (s TestSummary) String() string {
+	if s.Failed == 0 {
+		return fmt.Sprintf("%d tests passed", s.Passed)
+	}
+	noun := "tests"
+	if s.Failed == 1 {
+		noun = "test"
+	}
+	if len(s.FailedTests) == 0 {
+		return fmt.Sprintf("%d %s failed", s.Failed, noun)
+	}
+	names := strings.Join(s.FailedTests, ", ")
+	if len(s.FailedTests) < s.Failed {
+		names += ", …"
+	}
+	return fmt.Sprintf("%d %s failed: %s", s.Failed, noun, names)
+}
+
+// FailureCategory is the kind of cause of the failure of a job.
+type FailureCategory string
+
+const (
+	// FailureCategoryInfra means that the job failed because of the
+	// infrastructure it ran on, not because of the tested code.
+	FailureCategoryInfra FailureCategory = "infra"
+	// FailureCategoryTest means that the tests of the job failed.
+	FailureCategoryTest FailureCategory = "test"
+	// FailureCategoryTimeout means that the job did not finish before its
+	// timeout.
+	FailureCategoryTimeout FailureCategory = "timeout"
+)
+
+// MaxFailureMessageLength is the length in bytes a Failure message is
+// truncated to.
+const MaxFailureMessageLength = 512
+
+// Failure classifies why a job failed. The pod utilities write it to the
+// FailureFile of the build, and tests can write it to their artifacts to
+// classify their own failures.
+type Failure struct {
+	// Category is the kind of cause of the failure.
+	Category FailureCategory `json:"category"`
+	// Message explains the failure.
+	Message string `json:"message,omitempty"`
+	// Owner hints at who should look into the failure, e.g. a team or
+	// the oncall of the infrastructure.
+	Owner string `json:"owner,omitempty"`
+}
+
+// Validate ensures that the category of the failure is known.
+func (f Failure) Validate() error {
+	switch f.Category {
+	case FailureCategoryInfra, FailureCategoryTest, FailureCategoryTimeout:
+		return nil
+	}
+	return fmt.Errorf("unknown failure category %q", f.Category)
+}
+
+// String describes the failure, e.g. "infra failure: node went away".
+func (f Failure) String() string {
+	if f.Message == "" {
+		return fmt.Sprintf("%s failure", f.Category)
+	}
+	return fmt.Sprintf("%s failure: %s", f.Category, f.Message)
+}
+
+// TektonTaskRun is the outcome of a TaskRun of a Tekton PipelineRun.
+type TektonTaskRun struct {
+	// Name is the name of the TaskRun.
+	Name string `json:"name"`
+	// PipelineTask is the name of the pipeline task the TaskRun fulfils.
+	PipelineTask string `json:"pipeline_task"`
+	// State is the state of the TaskRun: pending while it runs, then
+	// success or failure.
+	State ProwJobState `json:"state"`
+	// Description is the message of the TaskRun's Succeeded condition.
+	Description string `json:"description,omitempty"`
+	// Results are the results the TaskRun's steps wrote out. Array and
+	// object results are JSON encoded.
+	Results map[string]string `json:"results,omitempty"`
+}
+
 // TektonPipelineRunSpec is optional parameters for Tekton pipeline jobs.
 type TektonPipelineRunSpec struct {
 	V1Beta1 *pipelinev1beta1.PipelineRunSpec `json:"v1beta1,omitempty"`
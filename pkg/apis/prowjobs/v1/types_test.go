@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	fuzz "github.com/google/gofuzz"
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func pStr(str string) *string {
@@ -225,6 +226,7 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 					DefaultOrg:        "org2",
 					DefaultRepo:       "repo2",
 					CompressFileTypes: []string{"txt", "json"},
+					ChecksumManifest:  &lies,
 				},
 			},
 			expected: func(orig, def *DecorationConfig) *DecorationConfig {
@@ -359,6 +361,36 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 				return def
 			},
 		},
+		{
+			name: "upload interval provided",
+			provided: &DecorationConfig{
+				UploadInterval: &Duration{Duration: 30 * time.Second},
+			},
+			expected: func(orig, def *DecorationConfig) *DecorationConfig {
+				def.UploadInterval = orig.UploadInterval
+				return def
+			},
+		},
+		{
+			name: "caches provided",
+			provided: &DecorationConfig{
+				Caches: []Cache{{Key: "{{.Repo}}-bazel", Paths: []string{"/root/.cache/bazel"}}},
+			},
+			expected: func(orig, def *DecorationConfig) *DecorationConfig {
+				def.Caches = orig.Caches
+				return def
+			},
+		},
+		{
+			name: "started metadata merged with the defaults",
+			provided: &DecorationConfig{
+				StartedMetadata: map[string]string{"tenant": "sig-node", "purpose": "release"},
+			},
+			expected: func(orig, def *DecorationConfig) *DecorationConfig {
+				def.StartedMetadata = map[string]string{"tenant": "sig-node", "purpose": "release", "cost-center": "ci"}
+				return def
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -366,8 +398,9 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			defaults := &DecorationConfig{
-				Timeout:     &Duration{Duration: 1 * time.Minute},
-				GracePeriod: &Duration{Duration: 10 * time.Second},
+				Timeout:        &Duration{Duration: 1 * time.Minute},
+				GracePeriod:    &Duration{Duration: 10 * time.Second},
+				UploadInterval: &Duration{Duration: 1 * time.Minute},
 				UtilityImages: &UtilityImages{
 					CloneRefs:  "clonerefs",
 					InitUpload: "initupload",
@@ -375,17 +408,19 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 					Sidecar:    "sidecar",
 				},
 				GCSConfiguration: &GCSConfiguration{
-					Bucket:       "bucket",
-					PathPrefix:   "prefix",
-					PathStrategy: PathStrategyLegacy,
-					DefaultOrg:   "org",
-					DefaultRepo:  "repo",
+					Bucket:           "bucket",
+					PathPrefix:       "prefix",
+					PathStrategy:     PathStrategyLegacy,
+					DefaultOrg:       "org",
+					DefaultRepo:      "repo",
+					ChecksumManifest: &truth,
 				},
 				GCSCredentialsSecret: pStr("secretName"),
 				S3CredentialsSecret:  pStr("s3-secret"),
 				SSHKeySecrets:        []string{"first", "second"},
 				SSHHostFingerprints:  []string{"primero", "segundo"},
 				SkipCloning:          &truth,
+				StartedMetadata:      map[string]string{"tenant": "default", "cost-center": "ci"},
 			}
 
 			expected := tc.expected(tc.provided, defaults)
@@ -397,6 +432,33 @@ func TestDecorationDefaultingDoesntOverwrite(t *testing.T) {
 	}
 }
 
+func TestDecorationDefaultingCopiesStartedMetadata(t *testing.T) {
+	t.Parallel()
+	provided := &DecorationConfig{StartedMetadata: map[string]string{"tenant": "sig-node"}}
+	repoDefault := &DecorationConfig{StartedMetadata: map[string]string{"purpose": "release"}}
+	globalDefault := &DecorationConfig{StartedMetadata: map[string]string{"tenant": "default", "cost-center": "ci"}}
+
+	actual := provided.ApplyDefault(repoDefault).ApplyDefault(globalDefault)
+
+	expected := map[string]string{"tenant": "sig-node", "purpose": "release", "cost-center": "ci"}
+	if diff := cmp.Diff(expected, actual.StartedMetadata); diff != "" {
+		t.Errorf("unexpected started metadata: %s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"tenant": "sig-node"}, provided.StartedMetadata); diff != "" {
+		t.Errorf("provided started metadata was modified: %s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"purpose": "release"}, repoDefault.StartedMetadata); diff != "" {
+		t.Errorf("default started metadata was modified: %s", diff)
+	}
+
+	// A job without metadata of its own must not end up sharing the map of
+	// the default, or writes to the result leak into the default.
+	(&DecorationConfig{}).ApplyDefault(repoDefault).StartedMetadata["tenant"] = "sig-node"
+	if diff := cmp.Diff(map[string]string{"purpose": "release"}, repoDefault.StartedMetadata); diff != "" {
+		t.Errorf("default started metadata was modified: %s", diff)
+	}
+}
+
 func TestApplyDefaultsAppliesDefaultsForAllFields(t *testing.T) {
 	t.Parallel()
 	seed := time.Now().UnixNano()
@@ -528,6 +590,195 @@ func TestRefsToString(t *testing.T) {
 	}
 }
 
+func TestTestSummaryString(t *testing.T) {
+	var tests = []struct {
+		name     string
+		summary  TestSummary
+		expected string
+	}{
+		{
+			name:     "all tests passed",
+			summary:  TestSummary{Passed: 12, Skipped: 1},
+			expected: "12 tests passed",
+		},
+		{
+			name:     "one test failed",
+			summary:  TestSummary{Passed: 11, Failed: 1, FailedTests: []string{"TestFoo"}},
+			expected: "1 test failed: TestFoo",
+		},
+		{
+			name:     "all failed tests are named",
+			summary:  TestSummary{Failed: 2, FailedTests: []string{"TestFoo", "TestBar"}},
+			expected: "2 tests failed: TestFoo, TestBar",
+		},
+		{
+			name:     "some failed tests are named",
+			summary:  TestSummary{Failed: 3, FailedTests: []string{"TestFoo", "TestBar"}},
+			expected: "3 tests failed: TestFoo, TestBar, …",
+		},
+		{
+			name:     "no failed tests are named",
+			summary:  TestSummary{Failed: 3},
+			expected: "3 tests failed",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := test.summary.String(); actual != test.expected {
+			t.Errorf("%s: got summary %q, but expected %q", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestCancellationString(t *testing.T) {
+	var tests = []struct {
+		name         string
+		cancellation Cancellation
+		expected     string
+	}{
+		{
+			name:         "user action",
+			cancellation: Cancellation{Reason: CancelledByUser, Requester: "alice"},
+			expected:     "aborted by alice",
+		},
+		{
+			name:         "anonymous user action",
+			cancellation: Cancellation{Reason: CancelledByUser},
+			expected:     "aborted by a user",
+		},
+		{
+			name:         "superseded",
+			cancellation: Cancellation{Reason: CancelledSuperseded, SupersededBy: "newer"},
+			expected:     "superseded by a newer run",
+		},
+		{
+			name:         "requester of a freeze",
+			cancellation: Cancellation{Reason: CancelledFreeze, Requester: "release-bot"},
+			expected:     "aborted because of a freeze (requested by release-bot)",
+		},
+		{
+			name:         "unknown reason",
+			cancellation: Cancellation{Reason: "maintenance"},
+			expected:     "aborted (maintenance)",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := test.cancellation.String(); actual != test.expected {
+			t.Errorf("%s: got %q, but expected %q", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestFailureString(t *testing.T) {
+	var tests = []struct {
+		name     string
+		failure  Failure
+		expected string
+	}{
+		{
+			name:     "with a message",
+			failure:  Failure{Category: FailureCategoryInfra, Message: "node went away"},
+			expected: "infra failure: node went away",
+		},
+		{
+			name:     "without a message",
+			failure:  Failure{Category: FailureCategoryTimeout, Owner: "sig-testing"},
+			expected: "timeout failure",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := test.failure.String(); actual != test.expected {
+			t.Errorf("%s: got %q, but expected %q", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestFailureValidate(t *testing.T) {
+	for _, category := range []FailureCategory{FailureCategoryInfra, FailureCategoryTest, FailureCategoryTimeout} {
+		if err := (Failure{Category: category}).Validate(); err != nil {
+			t.Errorf("%s: unexpected error: %v", category, err)
+		}
+	}
+	for _, category := range []FailureCategory{"", "flake"} {
+		if err := (Failure{Category: category}).Validate(); err == nil {
+			t.Errorf("%q: expected an error", category)
+		}
+	}
+}
+
+func TestUpdateConditions(t *testing.T) {
+	type condition struct {
+		Status metav1.ConditionStatus
+		Reason string
+	}
+	var tests = []struct {
+		name     string
+		status   ProwJobStatus
+		expected map[string]condition
+	}{
+		{
+			name:     "no state yet",
+			status:   ProwJobStatus{},
+			expected: map[string]condition{},
+		},
+		{
+			name:   "scheduling",
+			status: ProwJobStatus{State: SchedulingState},
+			expected: map[string]condition{
+				ProwJobScheduled:  {metav1.ConditionFalse, "Scheduling"},
+				ProwJobPodCreated: {metav1.ConditionFalse, "Waiting"},
+				ProwJobCompleted:  {metav1.ConditionFalse, "Running"},
+			},
+		},
+		{
+			name:   "pending",
+			status: ProwJobStatus{State: PendingState},
+			expected: map[string]condition{
+				ProwJobScheduled:  {metav1.ConditionTrue, "Scheduled"},
+				ProwJobPodCreated: {metav1.ConditionTrue, "Created"},
+				ProwJobCompleted:  {metav1.ConditionFalse, "Running"},
+			},
+		},
+		{
+			name:   "failed",
+			status: ProwJobStatus{State: FailureState, CompletionTime: &metav1.Time{}},
+			expected: map[string]condition{
+				ProwJobScheduled: {metav1.ConditionTrue, "Scheduled"},
+				ProwJobCompleted: {metav1.ConditionTrue, "Failed"},
+			},
+		},
+		{
+			name: "started before it was aborted",
+			status: ProwJobStatus{
+				State:          AbortedState,
+				CompletionTime: &metav1.Time{},
+				Conditions:     []metav1.Condition{{Type: ProwJobPodCreated, Status: metav1.ConditionTrue, Reason: "Created"}},
+			},
+			expected: map[string]condition{
+				ProwJobScheduled:  {metav1.ConditionTrue, "Scheduled"},
+				ProwJobPodCreated: {metav1.ConditionTrue, "Created"},
+				ProwJobCompleted:  {metav1.ConditionTrue, "Aborted"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pj := ProwJob{Status: test.status}
+			pj.UpdateConditions()
+			actual := map[string]condition{}
+			for _, c := range pj.Status.Conditions {
+				actual[c.Type] = condition{c.Status, c.Reason}
+			}
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("unexpected conditions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestRerunAuthConfigValidate(t *testing.T) {
 	var testCases = []struct {
 		name        string
@@ -564,6 +815,11 @@ func TestRerunAuthConfigValidate(t *testing.T) {
 			config:      &RerunAuthConfig{AllowAnyone: true, GitHubOrgs: []string{"istio"}},
 			errExpected: true,
 		},
+		{
+			name:        "allow any and has OIDC groups",
+			config:      &RerunAuthConfig{AllowAnyone: true, OIDCGroups: []string{"ci-admins"}},
+			errExpected: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -576,6 +832,47 @@ func TestRerunAuthConfigValidate(t *testing.T) {
 	}
 }
 
+func TestCacheValidate(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		cache       Cache
+		errExpected bool
+	}{
+		{
+			name:  "valid cache",
+			cache: Cache{Key: "{{.Org}}-{{.Repo}}-{{.BaseRef}}-gomod", Paths: []string{"/go/pkg/mod", "/root/.cache/go-build"}},
+		},
+		{
+			name:        "no key",
+			cache:       Cache{Paths: []string{"/go/pkg/mod"}},
+			errExpected: true,
+		},
+		{
+			name:        "invalid key template",
+			cache:       Cache{Key: "{{.Repo", Paths: []string{"/go/pkg/mod"}},
+			errExpected: true,
+		},
+		{
+			name:        "no paths",
+			cache:       Cache{Key: "gomod"},
+			errExpected: true,
+		},
+		{
+			name:        "relative path",
+			cache:       Cache{Key: "gomod", Paths: []string{"go/pkg/mod"}},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.cache.Validate(); (err != nil) != tc.errExpected {
+				t.Errorf("Expected error %v, got %v", tc.errExpected, err)
+			}
+		})
+	}
+}
+
 func TestRerunAuthConfigIsAuthorized(t *testing.T) {
 	var testCases = []struct {
 		name       string
@@ -619,6 +916,53 @@ func TestRerunAuthConfigIsAuthorized(t *testing.T) {
 	}
 }
 
+func TestRerunAuthConfigIsAuthorizedByGroups(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		groups     []string
+		config     *RerunAuthConfig
+		authorized bool
+	}{
+		{
+			name:       "authorized - one of the groups is allowed",
+			groups:     []string{"everyone", "ci-admins"},
+			config:     &RerunAuthConfig{OIDCGroups: []string{"ci-admins"}},
+			authorized: true,
+		},
+		{
+			name:       "unauthorized - none of the groups is allowed",
+			groups:     []string{"everyone"},
+			config:     &RerunAuthConfig{OIDCGroups: []string{"ci-admins"}},
+			authorized: false,
+		},
+		{
+			name:       "unauthorized - user without groups",
+			config:     &RerunAuthConfig{OIDCGroups: []string{"ci-admins"}},
+			authorized: false,
+		},
+		{
+			name:       "unauthorized - only GitHub users are allowed",
+			groups:     []string{"gumby"},
+			config:     &RerunAuthConfig{GitHubUsers: []string{"gumby"}},
+			authorized: false,
+		},
+		{
+			name:       "unauthorized - RerunAuthConfig is nil",
+			groups:     []string{"ci-admins"},
+			config:     nil,
+			authorized: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.config.IsAuthorizedByGroups(tc.groups); actual != tc.authorized {
+				t.Errorf("Expected %v, got %v", tc.authorized, actual)
+			}
+		})
+	}
+}
+
 func TestRerunAuthConfigIsAllowAnyone(t *testing.T) {
 	var testCases = []struct {
 		name     string
@@ -893,3 +1237,31 @@ func TestProwJobSpec_GetPipelineRunSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCloneFilter(t *testing.T) {
+	testCases := []struct {
+		filter  string
+		isValid bool
+	}{
+		{filter: "", isValid: true},
+		{filter: "blob:none", isValid: true},
+		{filter: "blob:limit=1m", isValid: true},
+		{filter: "blob:limit=1024", isValid: true},
+		{filter: "tree:0", isValid: true},
+		{filter: "object:type=commit", isValid: true},
+		{filter: "sparse:oid=main:.gitsparse", isValid: true},
+		{filter: "combine:blob:none+tree:3", isValid: true},
+		{filter: "blob:all"},
+		{filter: "tree:deep"},
+		{filter: "combine:blob:none+"},
+		{filter: "blob:none --upload-pack=evil"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			if err := ValidateCloneFilter(tc.filter); (err == nil) != tc.isValid {
+				t.Errorf("expected valid to be %t, got error %v", tc.isValid, err)
+			}
+		})
+	}
+}
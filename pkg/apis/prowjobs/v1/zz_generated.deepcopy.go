@@ -30,6 +30,194 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildCluster) DeepCopyInto(out *BuildCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildCluster.
+func (in *BuildCluster) DeepCopy() *BuildCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildClusterAuth) DeepCopyInto(out *BuildClusterAuth) {
+	*out = *in
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(BuildClusterToken)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(WorkloadIdentity)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildClusterAuth.
+func (in *BuildClusterAuth) DeepCopy() *BuildClusterAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildClusterAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildClusterList) DeepCopyInto(out *BuildClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BuildCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildClusterList.
+func (in *BuildClusterList) DeepCopy() *BuildClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildClusterSpec) DeepCopyInto(out *BuildClusterSpec) {
+	*out = *in
+	if in.CertificateAuthorityData != nil {
+		in, out := &in.CertificateAuthorityData, &out.CertificateAuthorityData
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	in.Auth.DeepCopyInto(&out.Auth)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildClusterSpec.
+func (in *BuildClusterSpec) DeepCopy() *BuildClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildClusterStatus) DeepCopyInto(out *BuildClusterStatus) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildClusterStatus.
+func (in *BuildClusterStatus) DeepCopy() *BuildClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildClusterToken) DeepCopyInto(out *BuildClusterToken) {
+	*out = *in
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(TokenRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildClusterToken.
+func (in *BuildClusterToken) DeepCopy() *BuildClusterToken {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildClusterToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cache) DeepCopyInto(out *Cache) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cache.
+func (in *Cache) DeepCopy() *Cache {
+	if in == nil {
+		return nil
+	}
+	out := new(Cache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cancellation) DeepCopyInto(out *Cancellation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cancellation.
+func (in *Cancellation) DeepCopy() *Cancellation {
+	if in == nil {
+		return nil
+	}
+	out := new(Cancellation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CensoringOptions) DeepCopyInto(out *CensoringOptions) {
 	*out = *in
@@ -124,6 +312,11 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TreelessFetch != nil {
+		in, out := &in.TreelessFetch, &out.TreelessFetch
+		*out = new(bool)
+		**out = **in
+	}
 	if in.SkipCloning != nil {
 		in, out := &in.SkipCloning, &out.SkipCloning
 		*out = new(bool)
@@ -164,6 +357,11 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UploadInterval != nil {
+		in, out := &in.UploadInterval, &out.UploadInterval
+		*out = new(Duration)
+		**out = **in
+	}
 	if in.SetLimitEqualsMemoryRequest != nil {
 		in, out := &in.SetLimitEqualsMemoryRequest, &out.SetLimitEqualsMemoryRequest
 		*out = new(bool)
@@ -204,6 +402,20 @@ func (in *DecorationConfig) DeepCopyInto(out *DecorationConfig) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Caches != nil {
+		in, out := &in.Caches, &out.Caches
+		*out = make([]Cache, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartedMetadata != nil {
+		in, out := &in.StartedMetadata, &out.StartedMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -233,6 +445,22 @@ func (in *Duration) DeepCopy() *Duration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Failure) DeepCopyInto(out *Failure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Failure.
+func (in *Failure) DeepCopy() *Failure {
+	if in == nil {
+		return nil
+	}
+	out := new(Failure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCSConfiguration) DeepCopyInto(out *GCSConfiguration) {
 	*out = *in
@@ -248,6 +476,11 @@ func (in *GCSConfiguration) DeepCopyInto(out *GCSConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ChecksumManifest != nil {
+		in, out := &in.ChecksumManifest, &out.ChecksumManifest
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -309,6 +542,119 @@ func (in *JenkinsSpec) DeepCopy() *JenkinsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsStage) DeepCopyInto(out *JenkinsStage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsStage.
+func (in *JenkinsStage) DeepCopy() *JenkinsStage {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobArtifactRetention) DeepCopyInto(out *JobArtifactRetention) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobArtifactRetention.
+func (in *JobArtifactRetention) DeepCopy() *JobArtifactRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(JobArtifactRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConfig) DeepCopyInto(out *JobConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfig.
+func (in *JobConfig) DeepCopy() *JobConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConfigList) DeepCopyInto(out *JobConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JobConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigList.
+func (in *JobConfigList) DeepCopy() *JobConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConfigSpec) DeepCopyInto(out *JobConfigSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigSpec.
+func (in *JobConfigSpec) DeepCopy() *JobConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OauthTokenSecret) DeepCopyInto(out *OauthTokenSecret) {
 	*out = *in
@@ -462,6 +808,16 @@ func (in *ProwJobSpec) DeepCopyInto(out *ProwJobSpec) {
 		*out = new(ProwJobDefault)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ArtifactRetention != nil {
+		in, out := &in.ArtifactRetention, &out.ArtifactRetention
+		*out = new(JobArtifactRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -487,6 +843,40 @@ func (in *ProwJobStatus) DeepCopyInto(out *ProwJobStatus) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.JenkinsStages != nil {
+		in, out := &in.JenkinsStages, &out.JenkinsStages
+		*out = make([]JenkinsStage, len(*in))
+		copy(*out, *in)
+	}
+	if in.TektonTaskRuns != nil {
+		in, out := &in.TektonTaskRuns, &out.TektonTaskRuns
+		*out = make([]TektonTaskRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TestSummary != nil {
+		in, out := &in.TestSummary, &out.TestSummary
+		*out = new(TestSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Failure != nil {
+		in, out := &in.Failure, &out.Failure
+		*out = new(Failure)
+		**out = **in
+	}
+	if in.Cancellation != nil {
+		in, out := &in.Cancellation, &out.Cancellation
+		*out = new(Cancellation)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PrevReportStates != nil {
 		in, out := &in.PrevReportStates, &out.PrevReportStates
 		*out = make(map[string]ProwJobState, len(*in))
@@ -557,6 +947,11 @@ func (in *Refs) DeepCopyInto(out *Refs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TreelessFetch != nil {
+		in, out := &in.TreelessFetch, &out.TreelessFetch
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -614,6 +1009,11 @@ func (in *RerunAuthConfig) DeepCopyInto(out *RerunAuthConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.OIDCGroups != nil {
+		in, out := &in.OIDCGroups, &out.OIDCGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -726,6 +1126,71 @@ func (in *TektonPipelineRunSpec) DeepCopy() *TektonPipelineRunSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TektonTaskRun) DeepCopyInto(out *TektonTaskRun) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TektonTaskRun.
+func (in *TektonTaskRun) DeepCopy() *TektonTaskRun {
+	if in == nil {
+		return nil
+	}
+	out := new(TektonTaskRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestSummary) DeepCopyInto(out *TestSummary) {
+	*out = *in
+	if in.FailedTests != nil {
+		in, out := &in.FailedTests, &out.FailedTests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestSummary.
+func (in *TestSummary) DeepCopy() *TestSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(TestSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenRotation) DeepCopyInto(out *TokenRotation) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenRotation.
+func (in *TokenRotation) DeepCopy() *TokenRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UtilityImages) DeepCopyInto(out *UtilityImages) {
 	*out = *in
@@ -741,3 +1206,19 @@ func (in *UtilityImages) DeepCopy() *UtilityImages {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentity) DeepCopyInto(out *WorkloadIdentity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentity.
+func (in *WorkloadIdentity) DeepCopy() *WorkloadIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+var _ conversion.Convertible = &ProwJob{}
+
+// ConvertTo converts the ProwJob to the v1 hub version.
+func (j *ProwJob) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*prowv1.ProwJob)
+	if !ok {
+		return fmt.Errorf("cannot convert a ProwJob to %T", hub)
+	}
+	src := j.DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = prowv1.ProwJobSpec{
+		Type:                  src.Spec.Type,
+		Agent:                 src.Spec.Agent,
+		Cluster:               src.Spec.Cluster,
+		Namespace:             src.Spec.Namespace,
+		Job:                   src.Spec.Job,
+		Refs:                  src.Spec.Refs,
+		ExtraRefs:             src.Spec.ExtraRefs,
+		Report:                src.Spec.Report,
+		Context:               src.Spec.Context,
+		RerunCommand:          src.Spec.RerunCommand,
+		ErrorOnEviction:       src.Spec.ErrorOnEviction,
+		PodSpec:               src.Spec.PodSpec,
+		JenkinsSpec:           src.Spec.JenkinsSpec,
+		TektonPipelineRunSpec: src.Spec.TektonPipelineRunSpec,
+		DecorationConfig:      src.Spec.Decoration.toV1(),
+		ReporterConfig:        src.Spec.ReporterConfig,
+		RerunAuthConfig:       src.Spec.RerunAuthConfig,
+		Hidden:                src.Spec.Hidden,
+		ProwJobDefault:        src.Spec.ProwJobDefault,
+		ArtifactRetention:     src.Spec.ArtifactRetention,
+		Secrets:               src.Spec.Secrets,
+	}
+	if scheduling := src.Spec.Scheduling; scheduling != nil {
+		dst.Spec.MaxConcurrency = scheduling.MaxConcurrency
+		dst.Spec.JobQueueName = scheduling.JobQueueName
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts the v1 hub version into the ProwJob. The deprecated
+// pipeline_run_spec becomes the tekton_pipeline_run_spec it stands for.
+func (j *ProwJob) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*prowv1.ProwJob)
+	if !ok {
+		return fmt.Errorf("cannot convert %T to a ProwJob", hub)
+	}
+	src = src.DeepCopy()
+	j.ObjectMeta = src.ObjectMeta
+	j.Spec = ProwJobSpec{
+		Type:                  src.Spec.Type,
+		Agent:                 src.Spec.Agent,
+		Cluster:               src.Spec.Cluster,
+		Namespace:             src.Spec.Namespace,
+		Job:                   src.Spec.Job,
+		Refs:                  src.Spec.Refs,
+		ExtraRefs:             src.Spec.ExtraRefs,
+		Report:                src.Spec.Report,
+		Context:               src.Spec.Context,
+		RerunCommand:          src.Spec.RerunCommand,
+		ErrorOnEviction:       src.Spec.ErrorOnEviction,
+		PodSpec:               src.Spec.PodSpec,
+		JenkinsSpec:           src.Spec.JenkinsSpec,
+		TektonPipelineRunSpec: src.Spec.TektonPipelineRunSpec,
+		Decoration:            decorationFromV1(src.Spec.DecorationConfig),
+		ReporterConfig:        src.Spec.ReporterConfig,
+		RerunAuthConfig:       src.Spec.RerunAuthConfig,
+		Hidden:                src.Spec.Hidden,
+		ProwJobDefault:        src.Spec.ProwJobDefault,
+		ArtifactRetention:     src.Spec.ArtifactRetention,
+		Secrets:               src.Spec.Secrets,
+	}
+	if src.Spec.PipelineRunSpec != nil && (j.Spec.TektonPipelineRunSpec == nil || j.Spec.TektonPipelineRunSpec.V1Beta1 == nil) {
+		j.Spec.TektonPipelineRunSpec = &prowv1.TektonPipelineRunSpec{V1Beta1: src.Spec.PipelineRunSpec}
+	}
+
+	if src.Spec.MaxConcurrency != 0 || src.Spec.JobQueueName != "" {
+		j.Spec.Scheduling = &Scheduling{
+			MaxConcurrency: src.Spec.MaxConcurrency,
+			JobQueueName:   src.Spec.JobQueueName,
+		}
+	}
+	j.Status = src.Status
+	return nil
+}
+
+func (d *Decoration) toV1() *prowv1.DecorationConfig {
+	if d == nil {
+		return nil
+	}
+	dc := &prowv1.DecorationConfig{
+		UtilityImages:   d.UtilityImages,
+		Resources:       d.Resources,
+		Caches:          d.Caches,
+		StartedMetadata: d.StartedMetadata,
+	}
+	if t := d.Timeouts; t != nil {
+		dc.Timeout = t.Timeout
+		dc.GracePeriod = t.GracePeriod
+		dc.PodPendingTimeout = t.PodPending
+		dc.PodRunningTimeout = t.PodRunning
+		dc.PodUnscheduledTimeout = t.PodUnscheduled
+	}
+	if u := d.Upload; u != nil {
+		dc.GCSConfiguration = u.GCSConfiguration
+		dc.GCSCredentialsSecret = u.GCSCredentialsSecret
+		dc.S3CredentialsSecret = u.S3CredentialsSecret
+		dc.UploadIgnoresInterrupts = u.IgnoresInterrupts
+		dc.UploadInterval = u.Interval
+	}
+	if c := d.Cloning; c != nil {
+		dc.SkipCloning = c.Skip
+		dc.BloblessFetch = c.BloblessFetch
+		dc.TreelessFetch = c.TreelessFetch
+		dc.SSHKeySecrets = c.SSHKeySecrets
+		dc.SSHHostFingerprints = c.SSHHostFingerprints
+		dc.CookiefileSecret = c.CookiefileSecret
+		dc.OauthTokenSecret = c.OauthTokenSecret
+		dc.GitHubAPIEndpoints = c.GitHubAPIEndpoints
+		dc.GitHubAppID = c.GitHubAppID
+		dc.GitHubAppPrivateKeySecret = c.GitHubAppPrivateKeySecret
+	}
+	if c := d.Censoring; c != nil {
+		dc.CensorSecrets = c.Enabled
+		dc.CensoringOptions = c.Options
+	}
+	if p := d.Pod; p != nil {
+		dc.DefaultServiceAccountName = p.DefaultServiceAccountName
+		dc.SetLimitEqualsMemoryRequest = p.SetLimitEqualsMemoryRequest
+		dc.DefaultMemoryRequest = p.DefaultMemoryRequest
+		dc.RunAsUser = p.RunAsUser
+		dc.RunAsGroup = p.RunAsGroup
+		dc.FsGroup = p.FsGroup
+	}
+	return dc
+}
+
+func decorationFromV1(dc *prowv1.DecorationConfig) *Decoration {
+	if dc == nil {
+		return nil
+	}
+	d := &Decoration{
+		UtilityImages:   dc.UtilityImages,
+		Resources:       dc.Resources,
+		Caches:          dc.Caches,
+		StartedMetadata: dc.StartedMetadata,
+	}
+	timeouts := Timeouts{
+		Timeout:        dc.Timeout,
+		GracePeriod:    dc.GracePeriod,
+		PodPending:     dc.PodPendingTimeout,
+		PodRunning:     dc.PodRunningTimeout,
+		PodUnscheduled: dc.PodUnscheduledTimeout,
+	}
+	if timeouts != (Timeouts{}) {
+		d.Timeouts = &timeouts
+	}
+	upload := Upload{
+		GCSConfiguration:     dc.GCSConfiguration,
+		GCSCredentialsSecret: dc.GCSCredentialsSecret,
+		S3CredentialsSecret:  dc.S3CredentialsSecret,
+		IgnoresInterrupts:    dc.UploadIgnoresInterrupts,
+		Interval:             dc.UploadInterval,
+	}
+	if upload != (Upload{}) {
+		d.Upload = &upload
+	}
+	cloning := &Cloning{
+		Skip:                      dc.SkipCloning,
+		BloblessFetch:             dc.BloblessFetch,
+		TreelessFetch:             dc.TreelessFetch,
+		SSHKeySecrets:             dc.SSHKeySecrets,
+		SSHHostFingerprints:       dc.SSHHostFingerprints,
+		CookiefileSecret:          dc.CookiefileSecret,
+		OauthTokenSecret:          dc.OauthTokenSecret,
+		GitHubAPIEndpoints:        dc.GitHubAPIEndpoints,
+		GitHubAppID:               dc.GitHubAppID,
+		GitHubAppPrivateKeySecret: dc.GitHubAppPrivateKeySecret,
+	}
+	if !cloning.isEmpty() {
+		d.Cloning = cloning
+	}
+	if dc.CensorSecrets != nil || dc.CensoringOptions != nil {
+		d.Censoring = &Censoring{Enabled: dc.CensorSecrets, Options: dc.CensoringOptions}
+	}
+	pod := Pod{
+		DefaultServiceAccountName:   dc.DefaultServiceAccountName,
+		SetLimitEqualsMemoryRequest: dc.SetLimitEqualsMemoryRequest,
+		DefaultMemoryRequest:        dc.DefaultMemoryRequest,
+		RunAsUser:                   dc.RunAsUser,
+		RunAsGroup:                  dc.RunAsGroup,
+		FsGroup:                     dc.FsGroup,
+	}
+	if pod != (Pod{}) {
+		d.Pod = &pod
+	}
+	return d
+}
+
+func (c *Cloning) isEmpty() bool {
+	return c.Skip == nil && c.BloblessFetch == nil && c.TreelessFetch == nil &&
+		c.SSHKeySecrets == nil && c.SSHHostFingerprints == nil && c.CookiefileSecret == nil &&
+		c.OauthTokenSecret == nil && c.GitHubAPIEndpoints == nil && c.GitHubAppID == "" &&
+		c.GitHubAppPrivateKeySecret == nil
+}
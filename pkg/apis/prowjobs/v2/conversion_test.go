@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestDecorationRoundTrip(t *testing.T) {
+	t.Parallel()
+	seed := time.Now().UnixNano()
+	// Print the seed so failures can easily be reproduced
+	t.Logf("Seed: %d", seed)
+	fuzzer := fuzz.NewWithSeed(seed)
+	for i := 0; i < 100; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			original := &prowv1.ProwJob{}
+			fuzzer.Fuzz(&original.Spec.DecorationConfig)
+			fuzzer.Fuzz(&original.Spec.MaxConcurrency)
+			fuzzer.Fuzz(&original.Spec.JobQueueName)
+
+			converted := &ProwJob{}
+			if err := converted.ConvertFrom(original); err != nil {
+				t.Fatalf("failed to convert from v1: %v", err)
+			}
+			roundTripped := &prowv1.ProwJob{}
+			if err := converted.ConvertTo(roundTripped); err != nil {
+				t.Fatalf("failed to convert to v1: %v", err)
+			}
+			if diff := cmp.Diff(original, roundTripped); diff != "" {
+				t.Errorf("ProwJob changed on its round trip through v2 (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConvertFrom(t *testing.T) {
+	pipelineRunSpec := &pipelinev1beta1.PipelineRunSpec{ServiceAccountName: "tekton"}
+	var testCases = []struct {
+		name     string
+		v1       prowv1.ProwJob
+		expected ProwJob
+	}{
+		{
+			name: "deprecated pipeline run spec is moved",
+			v1: prowv1.ProwJob{
+				Spec: prowv1.ProwJobSpec{Job: "tekton", PipelineRunSpec: pipelineRunSpec},
+			},
+			expected: ProwJob{
+				Spec: ProwJobSpec{Job: "tekton", TektonPipelineRunSpec: &prowv1.TektonPipelineRunSpec{V1Beta1: pipelineRunSpec}},
+			},
+		},
+		{
+			name: "scheduling fields are grouped",
+			v1: prowv1.ProwJob{
+				Spec: prowv1.ProwJobSpec{Job: "queued", MaxConcurrency: 2, JobQueueName: "gpus"},
+			},
+			expected: ProwJob{
+				Spec: ProwJobSpec{Job: "queued", Scheduling: &Scheduling{MaxConcurrency: 2, JobQueueName: "gpus"}},
+			},
+		},
+		{
+			name: "decoration is grouped by concern",
+			v1: prowv1.ProwJob{
+				Spec: prowv1.ProwJobSpec{Job: "decorated", DecorationConfig: &prowv1.DecorationConfig{
+					Timeout:       &prowv1.Duration{Duration: time.Hour},
+					SkipCloning:   boolPtr(true),
+					CensorSecrets: boolPtr(true),
+				}},
+			},
+			expected: ProwJob{
+				Spec: ProwJobSpec{Job: "decorated", Decoration: &Decoration{
+					Timeouts:  &Timeouts{Timeout: &prowv1.Duration{Duration: time.Hour}},
+					Cloning:   &Cloning{Skip: boolPtr(true)},
+					Censoring: &Censoring{Enabled: boolPtr(true)},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var actual ProwJob
+			if err := actual.ConvertFrom(&tc.v1); err != nil {
+				t.Fatalf("failed to convert: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected v2 ProwJob (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConvertToUngroupsScheduling(t *testing.T) {
+	pj := ProwJob{Spec: ProwJobSpec{Job: "queued", Scheduling: &Scheduling{MaxConcurrency: 1, JobQueueName: "gpus"}}}
+	var actual prowv1.ProwJob
+	if err := pj.ConvertTo(&actual); err != nil {
+		t.Fatalf("failed to convert: %v", err)
+	}
+	expected := prowv1.ProwJob{
+		Spec: prowv1.ProwJobSpec{Job: "queued", MaxConcurrency: 1, JobQueueName: "gpus"},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected v1 ProwJob (-want +got):\n%s", diff)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProwJob contains the spec as well as runtime metadata.
+// +kubebuilder:printcolumn:name="Job",type=string,JSONPath=`.spec.job`,description="The name of the job being run"
+// +kubebuilder:printcolumn:name="BuildId",type=string,JSONPath=`.status.build_id`,description="The ID of the job being run."
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`,description="The type of job being run."
+// +kubebuilder:printcolumn:name="StartTime",type=date,JSONPath=`.status.startTime`,description="When the job started running."
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,description="The state of the job."
+type ProwJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProwJobSpec          `json:"spec,omitempty"`
+	Status prowv1.ProwJobStatus `json:"status,omitempty"`
+}
+
+// ProwJobSpec configures the details of the prow job.
+//
+// Compared to v1, the deprecated pipeline_run_spec is gone in favour of
+// tekton_pipeline_run_spec, the decoration config is grouped by concern and
+// the fields that decide when the job runs moved into the scheduling section.
+type ProwJobSpec struct {
+	// Type is the type of job and informs how
+	// the jobs is triggered
+	// +kubebuilder:validation:Enum=presubmit;postsubmit;periodic;batch
+	// +kubebuilder:validation:Required
+	Type prowv1.ProwJobType `json:"type,omitempty"`
+	// Agent determines which controller fulfills
+	// this specific ProwJobSpec and runs the job
+	Agent prowv1.ProwJobAgent `json:"agent,omitempty"`
+	// Cluster is which Kubernetes cluster is used
+	// to run the job, only applicable for that
+	// specific agent
+	Cluster string `json:"cluster,omitempty"`
+	// Namespace defines where to create pods/resources.
+	Namespace string `json:"namespace,omitempty"`
+	// Job is the name of the job
+	// +kubebuilder:validation:Required
+	Job string `json:"job,omitempty"`
+	// Refs is the code under test, determined at
+	// runtime by Prow itself
+	Refs *prowv1.Refs `json:"refs,omitempty"`
+	// ExtraRefs are auxiliary repositories that
+	// need to be cloned, determined from config
+	ExtraRefs []prowv1.Refs `json:"extra_refs,omitempty"`
+	// Report determines if the result of this job should
+	// be reported (e.g. status on GitHub, message in Slack, etc.)
+	Report bool `json:"report,omitempty"`
+	// Context is the name of the status context used to
+	// report back to GitHub
+	Context string `json:"context,omitempty"`
+	// RerunCommand is the command a user would write to
+	// trigger this job on their pull request
+	RerunCommand string `json:"rerun_command,omitempty"`
+	// ErrorOnEviction indicates that the ProwJob should be completed and given
+	// the ErrorState status if the pod that is executing the job is evicted.
+	ErrorOnEviction bool `json:"error_on_eviction,omitempty"`
+
+	// Scheduling decides when the job may run.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// PodSpec provides the basis for running the test under
+	// a Kubernetes agent
+	PodSpec *corev1.PodSpec `json:"pod_spec,omitempty"`
+	// JenkinsSpec holds configuration specific to Jenkins jobs
+	JenkinsSpec *prowv1.JenkinsSpec `json:"jenkins_spec,omitempty"`
+	// TektonPipelineRunSpec provides the basis for running the test as
+	// a pipeline-crd resource
+	// https://github.com/tektoncd/pipeline
+	TektonPipelineRunSpec *prowv1.TektonPipelineRunSpec `json:"tekton_pipeline_run_spec,omitempty"`
+
+	// Decoration holds configuration options for
+	// decorating PodSpecs that users provide
+	Decoration *Decoration `json:"decoration,omitempty"`
+
+	// ReporterConfig holds reporter-specific configuration
+	ReporterConfig *prowv1.ReporterConfig `json:"reporter_config,omitempty"`
+	// RerunAuthConfig holds information about which users can rerun the job
+	RerunAuthConfig *prowv1.RerunAuthConfig `json:"rerun_auth_config,omitempty"`
+	// Hidden specifies if the Job is considered hidden.
+	Hidden bool `json:"hidden,omitempty"`
+	// ProwJobDefault holds configuration options provided as defaults
+	// in the Prow config
+	ProwJobDefault *prowv1.ProwJobDefault `json:"prowjob_defaults,omitempty"`
+	// ArtifactRetention is how long the artifacts of the job are kept.
+	ArtifactRetention *prowv1.JobArtifactRetention `json:"artifact_retention,omitempty"`
+	// Secrets are the names of the secret bundles that are injected into
+	// the pod of the job.
+	Secrets []string `json:"secrets,omitempty"`
+}
+
+// Scheduling decides when a job may run.
+type Scheduling struct {
+	// MaxConcurrency restricts the total number of instances
+	// of this job that can run in parallel at once.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrency int `json:"max_concurrency,omitempty"`
+	// JobQueueName is the name of a queue defining max concurrency, see
+	// JobQueueCapacities in the plank config.
+	JobQueueName string `json:"job_queue_name,omitempty"`
+}
+
+// Decoration holds configuration options for decorating PodSpecs that users
+// provide, grouped by concern.
+type Decoration struct {
+	// Timeouts bounds how long the job and its pod may take.
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+	// UtilityImages holds pull specs for utility container
+	// images used to decorate a PodSpec.
+	UtilityImages *prowv1.UtilityImages `json:"utility_images,omitempty"`
+	// Resources holds resource requests and limits for utility
+	// containers used to decorate a PodSpec.
+	Resources *prowv1.Resources `json:"resources,omitempty"`
+	// Upload configures where and how the artifacts are uploaded.
+	Upload *Upload `json:"upload,omitempty"`
+	// Cloning configures how the refs are cloned.
+	Cloning *Cloning `json:"cloning,omitempty"`
+	// Censoring configures the censoring of secrets in logs and artifacts.
+	Censoring *Censoring `json:"censoring,omitempty"`
+	// Pod configures the pod of the job.
+	Pod *Pod `json:"pod,omitempty"`
+	// Caches are directories of the test containers that are restored from
+	// blob storage before the test starts and saved there after it passed.
+	Caches []prowv1.Cache `json:"caches,omitempty"`
+	// StartedMetadata are extra key/values that initupload adds to the
+	// metadata of started.json.
+	StartedMetadata map[string]string `json:"started_metadata,omitempty"`
+}
+
+// Timeouts bounds how long a job and its pod may take.
+type Timeouts struct {
+	// Timeout is how long the pod utilities will wait
+	// before aborting a job with SIGINT.
+	Timeout *prowv1.Duration `json:"timeout,omitempty"`
+	// GracePeriod is how long the pod utilities will wait
+	// after sending SIGINT to send SIGKILL when aborting
+	// a job. Only applicable if decorating the PodSpec.
+	GracePeriod *prowv1.Duration `json:"grace_period,omitempty"`
+	// PodPending is how long the controller waits before it garbage
+	// collects a pending pod.
+	PodPending *metav1.Duration `json:"pod_pending,omitempty"`
+	// PodRunning is how long the controller waits before it aborts a job
+	// whose pod is stuck running.
+	PodRunning *metav1.Duration `json:"pod_running,omitempty"`
+	// PodUnscheduled is how long the controller waits before it aborts a
+	// job whose pod is stuck unscheduled.
+	PodUnscheduled *metav1.Duration `json:"pod_unscheduled,omitempty"`
+}
+
+// Upload configures where and how the artifacts of a job are uploaded.
+type Upload struct {
+	// GCSConfiguration holds options for pushing logs and
+	// artifacts to GCS from a job.
+	GCSConfiguration *prowv1.GCSConfiguration `json:"gcs_configuration,omitempty"`
+	// GCSCredentialsSecret is the name of the Kubernetes secret
+	// that holds GCS push credentials.
+	GCSCredentialsSecret *string `json:"gcs_credentials_secret,omitempty"`
+	// S3CredentialsSecret is the name of the Kubernetes secret
+	// that holds blob storage push credentials.
+	S3CredentialsSecret *string `json:"s3_credentials_secret,omitempty"`
+	// IgnoresInterrupts causes sidecar to ignore interrupts for the upload
+	// process in hope that the test process exits cleanly before starting
+	// an upload.
+	IgnoresInterrupts *bool `json:"ignores_interrupts,omitempty"`
+	// Interval is how often sidecar uploads the build logs while the test is
+	// still running. If unset, logs are only uploaded once the test finished.
+	Interval *prowv1.Duration `json:"interval,omitempty"`
+}
+
+// Cloning configures how the refs of a job are cloned.
+type Cloning struct {
+	// Skip determines if we should clone the source code in the
+	// initcontainers for jobs that specify refs
+	Skip *bool `json:"skip,omitempty"`
+	// BloblessFetch tells Prow to avoid fetching objects when cloning using
+	// the --filter=blob:none flag.
+	BloblessFetch *bool `json:"blobless_fetch,omitempty"`
+	// TreelessFetch tells Prow to avoid fetching trees and blobs when cloning
+	// using the --filter=tree:0 flag. Takes precedence over BloblessFetch.
+	TreelessFetch *bool `json:"treeless_fetch,omitempty"`
+	// SSHKeySecrets are the names of Kubernetes secrets that contain
+	// SSK keys which should be used during the cloning process.
+	SSHKeySecrets []string `json:"ssh_key_secrets,omitempty"`
+	// SSHHostFingerprints are the fingerprints of known SSH hosts
+	// that the cloning process can trust.
+	SSHHostFingerprints []string `json:"ssh_host_fingerprints,omitempty"`
+	// CookiefileSecret is the name of a kubernetes secret that contains
+	// a git http.cookiefile, which should be used during the cloning process.
+	CookiefileSecret *string `json:"cookiefile_secret,omitempty"`
+	// OauthTokenSecret is a Kubernetes secret that contains the OAuth token,
+	// which is going to be used for fetching a private repository.
+	OauthTokenSecret *prowv1.OauthTokenSecret `json:"oauth_token_secret,omitempty"`
+	// GitHubAPIEndpoints are the endpoints of GitHub APIs.
+	GitHubAPIEndpoints []string `json:"github_api_endpoints,omitempty"`
+	// GitHubAppID is the ID of GitHub App, which is going to be used for
+	// fetching a private repository.
+	GitHubAppID string `json:"github_app_id,omitempty"`
+	// GitHubAppPrivateKeySecret is a Kubernetes secret that contains the
+	// GitHub App private key, which is going to be used for fetching a
+	// private repository.
+	GitHubAppPrivateKeySecret *prowv1.GitHubAppPrivateKeySecret `json:"github_app_private_key_secret,omitempty"`
+}
+
+// Censoring configures the censoring of secrets in the logs and artifacts of
+// a job.
+type Censoring struct {
+	// Enabled enables censoring output logs and artifacts.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Options exposes options for censoring output logs and artifacts.
+	Options *prowv1.CensoringOptions `json:"options,omitempty"`
+}
+
+// Pod configures the pod of a job.
+type Pod struct {
+	// DefaultServiceAccountName is the name of the Kubernetes service account
+	// that should be used by the pod if one is not specified in the podspec.
+	DefaultServiceAccountName *string `json:"default_service_account_name,omitempty"`
+	// SetLimitEqualsMemoryRequest sets memory limit equal to request.
+	SetLimitEqualsMemoryRequest *bool `json:"set_limit_equals_memory_request,omitempty"`
+	// DefaultMemoryRequest is the default requested memory on a test container.
+	DefaultMemoryRequest *resource.Quantity `json:"default_memory_request,omitempty"`
+	// RunAsUser defines UID for process in all containers running in a Pod.
+	RunAsUser *int64 `json:"run_as_user,omitempty"`
+	// RunAsGroup defines GID of process in all containers running in a Pod.
+	RunAsGroup *int64 `json:"run_as_group,omitempty"`
+	// FsGroup defines special supplemental group ID used in all containers in a Pod.
+	FsGroup *int64 `json:"fs_group,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProwJobList is a list of ProwJob resources
+type ProwJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ProwJob `json:"items"`
+}
@@ -0,0 +1,455 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package artifactretention deletes or archives the artifacts of job builds
+// according to the artifact_retention policies of the Prow config.
+package artifactretention
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdio "io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/gcsupload"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/io/providers"
+	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
+)
+
+const (
+	actionDelete      = "delete"
+	actionColdStorage = "cold_storage"
+)
+
+var retainedBuilds = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "artifact_retention_builds",
+	Help: "Number of builds whose artifacts were deleted or moved to cold storage.",
+}, []string{"action"})
+
+func init() {
+	prometheus.MustRegister(retainedBuilds)
+}
+
+// Controller applies the retention policies to the artifacts of the jobs of
+// the Prow config.
+type Controller struct {
+	config config.Getter
+	opener io.Opener
+	dryRun bool
+	now    func() time.Time
+	logger *logrus.Entry
+}
+
+// NewController creates a Controller. In dry-run mode it only logs what it
+// would delete or archive.
+func NewController(cfg config.Getter, opener io.Opener, dryRun bool) *Controller {
+	return &Controller{
+		config: cfg,
+		opener: opener,
+		dryRun: dryRun,
+		now:    time.Now,
+		logger: logrus.WithField("component", "artifact-retention"),
+	}
+}
+
+// jobDir is a directory holding the build directories of a job.
+type jobDir struct {
+	job        string
+	path       string
+	postsubmit bool
+	// aliases is the directory of the build aliases of presubmits.
+	aliases string
+	// policy is the policy of the Prow config, if any.
+	policy *config.RetentionPolicy
+}
+
+// build is a build directory of a job.
+type build struct {
+	id      string
+	path    string
+	started time.Time
+	passed  bool
+	// retention is the artifact retention of the job recorded in the
+	// finished.json of the build, if any.
+	retention *prowapi.JobArtifactRetention
+}
+
+// Sync applies the retention policies once.
+func (c *Controller) Sync(ctx context.Context) error {
+	cfg := c.config()
+	if cfg.ArtifactRetention == nil {
+		return nil
+	}
+	dirs, err := c.jobDirs(ctx, cfg)
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, dir := range dirs {
+		if err := c.syncJobDir(ctx, dir); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", dir.job, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// jobDirs returns the directories of the builds of all decorated jobs that
+// have a retention policy in the Prow config or in their job config.
+func (c *Controller) jobDirs(ctx context.Context, cfg *config.Config) ([]jobDir, error) {
+	var dirs []jobDir
+	var errs []error
+	seen := map[string]bool{}
+	add := func(dir jobDir) {
+		if !seen[dir.path] {
+			seen[dir.path] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	gcsConfig := func(base config.JobBase) *prowapi.GCSConfiguration {
+		if base.DecorationConfig == nil || base.DecorationConfig.GCSConfiguration == nil || base.DecorationConfig.GCSConfiguration.Bucket == "" {
+			return nil
+		}
+		return base.DecorationConfig.GCSConfiguration
+	}
+
+	for orgRepo, presubmits := range cfg.PresubmitsStatic {
+		org, repo, err := config.SplitRepoName(orgRepo)
+		if err != nil {
+			continue
+		}
+		for _, presubmit := range presubmits {
+			policy, gcsConf := cfg.ArtifactRetention.RetentionPolicyFor(org, repo, presubmit.Name), gcsConfig(presubmit.JobBase)
+			if (policy == nil && presubmit.ArtifactRetention == nil) || gcsConf == nil {
+				continue
+			}
+			builder := gcsupload.BuilderForStrategy(gcsConf.PathStrategy, gcsConf.DefaultOrg, gcsConf.DefaultRepo)
+			pulls, err := storagePath(gcsConf, path.Join(gcs.PRLogs, "pull", builder(org, repo)))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("job %s: %w", presubmit.Name, err))
+				continue
+			}
+			aliases, _ := storagePath(gcsConf, path.Join(gcs.PRLogs, "directory", presubmit.Name))
+			// The builds of presubmits are stored per pull request.
+			pullDirs, err := c.list(ctx, pulls)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("job %s: %w", presubmit.Name, err))
+				continue
+			}
+			for _, pullDir := range pullDirs {
+				if _, err := strconv.Atoi(path.Base(pullDir)); err != nil {
+					continue
+				}
+				add(jobDir{job: presubmit.Name, path: pullDir + "/" + presubmit.Name, aliases: aliases, policy: policy})
+			}
+		}
+	}
+	nonPRDir := func(job string, base config.JobBase, org, repo string, postsubmit bool) {
+		policy, gcsConf := cfg.ArtifactRetention.RetentionPolicyFor(org, repo, job), gcsConfig(base)
+		if (policy == nil && base.ArtifactRetention == nil) || gcsConf == nil {
+			return
+		}
+		dir, err := storagePath(gcsConf, path.Join(gcs.NonPRLogs, job))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job, err))
+			return
+		}
+		add(jobDir{job: job, path: dir, postsubmit: postsubmit, policy: policy})
+	}
+	for orgRepo, postsubmits := range cfg.PostsubmitsStatic {
+		org, repo, err := config.SplitRepoName(orgRepo)
+		if err != nil {
+			continue
+		}
+		for _, postsubmit := range postsubmits {
+			nonPRDir(postsubmit.Name, postsubmit.JobBase, org, repo, true)
+		}
+	}
+	for _, periodic := range cfg.Periodics {
+		var org, repo string
+		if len(periodic.ExtraRefs) > 0 {
+			org, repo = periodic.ExtraRefs[0].Org, periodic.ExtraRefs[0].Repo
+		}
+		nonPRDir(periodic.Name, periodic.JobBase, org, repo, false)
+	}
+	return dirs, utilerrors.NewAggregate(errs)
+}
+
+// storagePath returns the path of dir in the bucket of the job.
+func storagePath(gcsConf *prowapi.GCSConfiguration, dir string) (string, error) {
+	if gcsConf.PathPrefix != "" {
+		dir = path.Join(gcsConf.PathPrefix, dir)
+	}
+	return providers.StoragePath(gcsConf.Bucket, dir)
+}
+
+// list returns the paths of the directories in dir, without trailing slash.
+func (c *Controller) list(ctx context.Context, dir string) ([]string, error) {
+	root, err := bucketRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	it, err := c.opener.Iterator(ctx, strings.TrimSuffix(dir, "/")+"/", "/")
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", dir, err)
+	}
+	var dirs []string
+	for {
+		attrs, err := it.Next(ctx)
+		if errors.Is(err, stdio.EOF) {
+			return dirs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", dir, err)
+		}
+		if attrs.IsDir {
+			dirs = append(dirs, root+strings.TrimSuffix(attrs.Name, "/"))
+		}
+	}
+}
+
+// bucketRoot returns the <provider>://<bucket>/ prefix of path.
+func bucketRoot(p string) (string, error) {
+	provider, bucket, _, err := providers.ParseStoragePath(p)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s://%s/", provider, bucket), nil
+}
+
+func (c *Controller) syncJobDir(ctx context.Context, dir jobDir) error {
+	buildDirs, err := c.list(ctx, dir.path)
+	if err != nil {
+		return err
+	}
+	var builds []build
+	for _, buildDir := range buildDirs {
+		b, ok, err := c.readBuild(ctx, buildDir)
+		if err != nil {
+			c.logger.WithError(err).WithField("build", buildDir).Warn("Failed to read build metadata.")
+			continue
+		}
+		if ok {
+			builds = append(builds, b)
+		}
+	}
+
+	now := c.now()
+	var errs []error
+	for _, b := range applicableBuilds(builds, dir) {
+		policy := buildPolicy(dir.policy, b.retention)
+		if policy == nil {
+			continue
+		}
+		age := now.Sub(b.started)
+		log := c.logger.WithFields(logrus.Fields{"job": dir.job, "build": b.path, "age": age.Round(time.Second)})
+		switch {
+		case policy.DeleteAfter != nil && age > policy.DeleteAfter.Duration:
+			if err := c.deleteBuild(ctx, dir, b, log); err != nil {
+				errs = append(errs, err)
+			}
+		case policy.ColdStorageAfter != nil && age > policy.ColdStorageAfter.Duration:
+			if err := c.archiveBuild(ctx, b, policy.ColdStorageClass, log); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// buildPolicy returns the policy of a build, which is the policy of the Prow
+// config overridden by the artifact retention recorded by the build. A storage
+// class recorded by the build applies as soon as the build finished.
+func buildPolicy(policy *config.RetentionPolicy, retention *prowapi.JobArtifactRetention) *config.RetentionPolicy {
+	if retention == nil {
+		return policy
+	}
+	effective := config.RetentionPolicy{}
+	if policy != nil {
+		effective = *policy
+	}
+	if retention.Duration != nil {
+		effective.DeleteAfter = retention.Duration
+	}
+	if retention.StorageClass != "" {
+		effective.ColdStorageAfter = &metav1.Duration{}
+		effective.ColdStorageClass = retention.StorageClass
+	}
+	return &effective
+}
+
+// applicableBuilds returns the builds the policies apply to, which are all
+// builds but the latest passing ones of postsubmits and the latest ones
+// builds record to keep.
+func applicableBuilds(builds []build, dir jobDir) []build {
+	sort.Slice(builds, func(i, j int) bool { return builds[i].started.After(builds[j].started) })
+	keepGreen := 0
+	if dir.postsubmit && dir.policy != nil {
+		keepGreen = dir.policy.KeepGreenPostsubmits
+	}
+	var applicable []build
+	kept := 0
+	for i, b := range builds {
+		if b.retention != nil && i < b.retention.KeepLast {
+			continue
+		}
+		if b.passed && kept < keepGreen {
+			kept++
+			continue
+		}
+		applicable = append(applicable, b)
+	}
+	return applicable
+}
+
+// readBuild reads when the build started and whether it passed. Builds
+// without started.json are skipped.
+func (c *Controller) readBuild(ctx context.Context, dir string) (build, bool, error) {
+	b := build{id: path.Base(dir), path: dir}
+	var started metadata.Started
+	if ok, err := c.readJSON(ctx, dir+"/started.json", &started); err != nil || !ok {
+		return b, false, err
+	}
+	b.started = time.Unix(started.Timestamp, 0)
+	var finished metadata.Finished
+	ok, err := c.readJSON(ctx, dir+"/finished.json", &finished)
+	if err != nil {
+		return b, false, err
+	}
+	b.passed = ok && finished.Passed != nil && *finished.Passed
+	if raw, ok := finished.Metadata[prowapi.ArtifactRetentionMetadataKey]; ok {
+		// The metadata is decoded as generic JSON, so round-trip it.
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return b, false, err
+		}
+		b.retention = &prowapi.JobArtifactRetention{}
+		if err := json.Unmarshal(data, b.retention); err != nil {
+			return b, false, fmt.Errorf("parse the %s metadata of %s: %w", prowapi.ArtifactRetentionMetadataKey, dir, err)
+		}
+	}
+	return b, true, nil
+}
+
+func (c *Controller) readJSON(ctx context.Context, p string, into interface{}) (bool, error) {
+	raw, err := io.ReadContent(ctx, c.logger, c.opener, p)
+	if io.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", p, err)
+	}
+	if err := json.Unmarshal(raw, into); err != nil {
+		return false, fmt.Errorf("parse %s: %w", p, err)
+	}
+	return true, nil
+}
+
+// objects returns all objects below the build directory.
+func (c *Controller) objects(ctx context.Context, dir string) ([]io.ObjectAttributes, error) {
+	root, err := bucketRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	it, err := c.opener.Iterator(ctx, dir+"/", "")
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", dir, err)
+	}
+	var objects []io.ObjectAttributes
+	for {
+		attrs, err := it.Next(ctx)
+		if errors.Is(err, stdio.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", dir, err)
+		}
+		if !attrs.IsDir {
+			attrs.Name = root + attrs.Name
+			objects = append(objects, attrs)
+		}
+	}
+}
+
+func (c *Controller) deleteBuild(ctx context.Context, dir jobDir, b build, log *logrus.Entry) error {
+	objects, err := c.objects(ctx, b.path)
+	if err != nil {
+		return err
+	}
+	if dir.aliases != "" {
+		objects = append(objects, io.ObjectAttributes{Name: fmt.Sprintf("%s/%s.txt", dir.aliases, b.id)})
+	}
+	if c.dryRun {
+		log.WithField("objects", len(objects)).Info("Would delete the artifacts of the build.")
+		return nil
+	}
+	var errs []error
+	for _, object := range objects {
+		if err := c.opener.Delete(ctx, object.Name); err != nil && !io.IsNotExist(err) {
+			errs = append(errs, fmt.Errorf("delete %s: %w", object.Name, err))
+		}
+	}
+	if len(errs) == 0 {
+		log.WithField("objects", len(objects)).Info("Deleted the artifacts of the build.")
+		retainedBuilds.WithLabelValues(actionDelete).Inc()
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *Controller) archiveBuild(ctx context.Context, b build, storageClass string, log *logrus.Entry) error {
+	objects, err := c.objects(ctx, b.path)
+	if err != nil {
+		return err
+	}
+	var pending []string
+	for _, object := range objects {
+		if object.StorageClass != storageClass {
+			pending = append(pending, object.Name)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if c.dryRun {
+		log.WithField("objects", len(pending)).Infof("Would move the artifacts of the build to %s.", storageClass)
+		return nil
+	}
+	var errs []error
+	for _, object := range pending {
+		if err := c.opener.SetStorageClass(ctx, object, storageClass); err != nil {
+			errs = append(errs, fmt.Errorf("set storage class of %s: %w", object, err))
+		}
+	}
+	if len(errs) == 0 {
+		log.WithField("objects", len(pending)).Infof("Moved the artifacts of the build to %s.", storageClass)
+		retainedBuilds.WithLabelValues(actionColdStorage).Inc()
+	}
+	return utilerrors.NewAggregate(errs)
+}
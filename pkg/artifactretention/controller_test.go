@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactretention
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	stdio "io"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// fakeBucket stores the storage classes of objects by their path.
+type fakeBucket struct {
+	io.Opener
+	objects  map[string]string
+	contents map[string]string
+}
+
+func (f *fakeBucket) Reader(_ context.Context, path string) (io.ReadCloser, error) {
+	content, ok := f.contents[path]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return stdio.NopCloser(strings.NewReader(content)), nil
+}
+
+func (f *fakeBucket) Delete(_ context.Context, path string) error {
+	if _, ok := f.objects[path]; !ok {
+		return os.ErrNotExist
+	}
+	delete(f.objects, path)
+	return nil
+}
+
+func (f *fakeBucket) SetStorageClass(_ context.Context, path, storageClass string) error {
+	f.objects[path] = storageClass
+	return nil
+}
+
+type fakeIterator []io.ObjectAttributes
+
+func (f *fakeIterator) Next(_ context.Context) (io.ObjectAttributes, error) {
+	if len(*f) == 0 {
+		return io.ObjectAttributes{}, stdio.EOF
+	}
+	next := (*f)[0]
+	*f = (*f)[1:]
+	return next, nil
+}
+
+func (f *fakeBucket) Iterator(_ context.Context, prefix, delimiter string) (io.ObjectIterator, error) {
+	const root = "gs://bucket/"
+	seen := map[string]bool{}
+	var it fakeIterator
+	for path, class := range f.objects {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(path, root)
+		if delimiter != "" {
+			if i := strings.Index(strings.TrimPrefix(path, prefix), delimiter); i >= 0 {
+				dir := strings.TrimPrefix(prefix, root) + strings.TrimPrefix(path, prefix)[:i+1]
+				if !seen[dir] {
+					seen[dir] = true
+					it = append(it, io.ObjectAttributes{Name: dir, IsDir: true})
+				}
+				continue
+			}
+		}
+		it = append(it, io.ObjectAttributes{Name: name, StorageClass: class})
+	}
+	sort.Slice(it, func(i, j int) bool { return it[i].Name < it[j].Name })
+	return &it, nil
+}
+
+func TestSync(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) int64 { return now.Add(-time.Duration(n) * 24 * time.Hour).Unix() }
+	days := func(n int) *metav1.Duration { return &metav1.Duration{Duration: time.Duration(n) * 24 * time.Hour} }
+	decoration := &prowapi.DecorationConfig{GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "bucket", PathStrategy: prowapi.PathStrategyExplicit}}
+
+	bucket := &fakeBucket{objects: map[string]string{}, contents: map[string]string{}}
+	addBuild := func(dir string, started int64, passed *bool) {
+		bucket.objects[dir+"/started.json"] = "STANDARD"
+		bucket.contents[dir+"/started.json"] = fmt.Sprintf(`{"timestamp": %d}`, started)
+		bucket.objects[dir+"/artifacts/junit.xml"] = "STANDARD"
+		if passed != nil {
+			bucket.objects[dir+"/finished.json"] = "STANDARD"
+			bucket.contents[dir+"/finished.json"] = fmt.Sprintf(`{"timestamp": %d, "passed": %t}`, started+60, *passed)
+		}
+	}
+	yes, no := true, false
+	addBuild("gs://bucket/logs/post/1", daysAgo(100), &yes)
+	addBuild("gs://bucket/logs/post/2", daysAgo(90), &no)
+	addBuild("gs://bucket/logs/post/3", daysAgo(80), &yes)
+	addBuild("gs://bucket/logs/post/4", daysAgo(1), &no)
+	addBuild("gs://bucket/logs/periodic/10", daysAgo(40), &yes)
+	addBuild("gs://bucket/logs/periodic/11", daysAgo(20), nil)
+	addBuild("gs://bucket/pr-logs/pull/org_repo/5/pull/20", daysAgo(40), &no)
+	bucket.objects["gs://bucket/pr-logs/directory/pull/20.txt"] = "STANDARD"
+	addBuild("gs://bucket/pr-logs/pull/org_repo/6/pull/21", daysAgo(2), &yes)
+	addBuild("gs://bucket/logs/unmanaged/30", daysAgo(400), &yes)
+
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			PresubmitsStatic: map[string][]config.Presubmit{
+				"org/repo": {{JobBase: config.JobBase{Name: "pull", UtilityConfig: config.UtilityConfig{DecorationConfig: decoration}}}},
+			},
+			PostsubmitsStatic: map[string][]config.Postsubmit{
+				"org/repo":  {{JobBase: config.JobBase{Name: "post", UtilityConfig: config.UtilityConfig{DecorationConfig: decoration}}}},
+				"org/other": {{JobBase: config.JobBase{Name: "unmanaged", UtilityConfig: config.UtilityConfig{DecorationConfig: decoration}}}},
+			},
+			Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic", UtilityConfig: config.UtilityConfig{DecorationConfig: decoration}}}},
+		},
+		ProwConfig: config.ProwConfig{ArtifactRetention: &config.ArtifactRetention{
+			Default: &config.RetentionPolicy{ColdStorageAfter: days(30), ColdStorageClass: "ARCHIVE"},
+			Repos: map[string]config.RetentionPolicy{
+				"org/repo":  {DeleteAfter: days(30), KeepGreenPostsubmits: 1},
+				"org/other": {},
+			},
+		}},
+	}
+
+	c := NewController(func() *config.Config { return cfg }, bucket, false)
+	c.now = func() time.Time { return now }
+	c.logger = logrus.NewEntry(logrus.New())
+	c.logger.Logger.SetOutput(&bytes.Buffer{})
+	if err := c.Sync(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		// The latest green postsubmit is kept.
+		"gs://bucket/logs/post/3/started.json":        "STANDARD",
+		"gs://bucket/logs/post/3/finished.json":       "STANDARD",
+		"gs://bucket/logs/post/3/artifacts/junit.xml": "STANDARD",
+		"gs://bucket/logs/post/4/started.json":        "STANDARD",
+		"gs://bucket/logs/post/4/finished.json":       "STANDARD",
+		"gs://bucket/logs/post/4/artifacts/junit.xml": "STANDARD",
+		// Periodics fall back to the default policy.
+		"gs://bucket/logs/periodic/10/started.json":        "ARCHIVE",
+		"gs://bucket/logs/periodic/10/finished.json":       "ARCHIVE",
+		"gs://bucket/logs/periodic/10/artifacts/junit.xml": "ARCHIVE",
+		"gs://bucket/logs/periodic/11/started.json":        "STANDARD",
+		"gs://bucket/logs/periodic/11/artifacts/junit.xml": "STANDARD",
+		// Presubmit builds and their aliases are deleted once too old.
+		"gs://bucket/pr-logs/pull/org_repo/6/pull/21/started.json":        "STANDARD",
+		"gs://bucket/pr-logs/pull/org_repo/6/pull/21/finished.json":       "STANDARD",
+		"gs://bucket/pr-logs/pull/org_repo/6/pull/21/artifacts/junit.xml": "STANDARD",
+		// Repos with an empty policy are kept forever.
+		"gs://bucket/logs/unmanaged/30/started.json":        "STANDARD",
+		"gs://bucket/logs/unmanaged/30/finished.json":       "STANDARD",
+		"gs://bucket/logs/unmanaged/30/artifacts/junit.xml": "STANDARD",
+	}
+	if diff := cmp.Diff(expected, bucket.objects); diff != "" {
+		t.Errorf("objects differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestSyncJobArtifactRetention(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) int64 { return now.Add(-time.Duration(n) * 24 * time.Hour).Unix() }
+	bucket := &fakeBucket{objects: map[string]string{}, contents: map[string]string{}}
+	addBuild := func(dir string, started int64, metadata string) {
+		bucket.objects[dir+"/started.json"] = "STANDARD"
+		bucket.contents[dir+"/started.json"] = fmt.Sprintf(`{"timestamp": %d}`, started)
+		bucket.objects[dir+"/finished.json"] = "STANDARD"
+		bucket.contents[dir+"/finished.json"] = fmt.Sprintf(`{"timestamp": %d, "passed": true, "metadata": {%s}}`, started+60, metadata)
+	}
+	retention := `"artifact-retention": {"duration": "240h", "keep_last": 1, "storage_class": "NEARLINE"}`
+	addBuild("gs://bucket/logs/job/1", daysAgo(100), "")
+	addBuild("gs://bucket/logs/job/2", daysAgo(50), retention)
+	addBuild("gs://bucket/logs/job/3", daysAgo(5), retention)
+	addBuild("gs://bucket/logs/job/4", daysAgo(2), retention)
+
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{Periodics: []config.Periodic{{JobBase: config.JobBase{
+			Name:              "job",
+			ArtifactRetention: &prowapi.JobArtifactRetention{KeepLast: 1},
+			UtilityConfig:     config.UtilityConfig{DecorationConfig: &prowapi.DecorationConfig{GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "bucket"}}},
+		}}}},
+		ProwConfig: config.ProwConfig{ArtifactRetention: &config.ArtifactRetention{}},
+	}
+	c := NewController(func() *config.Config { return cfg }, bucket, false)
+	c.now = func() time.Time { return now }
+	c.logger = logrus.NewEntry(logrus.New())
+	c.logger.Logger.SetOutput(&bytes.Buffer{})
+	if err := c.Sync(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		// Builds without a recorded retention and no policy are kept forever.
+		"gs://bucket/logs/job/1/started.json":  "STANDARD",
+		"gs://bucket/logs/job/1/finished.json": "STANDARD",
+		// Builds that are too old are deleted, the others move to their
+		// storage class but the latest one.
+		"gs://bucket/logs/job/3/started.json":  "NEARLINE",
+		"gs://bucket/logs/job/3/finished.json": "NEARLINE",
+		"gs://bucket/logs/job/4/started.json":  "STANDARD",
+		"gs://bucket/logs/job/4/finished.json": "STANDARD",
+	}
+	if diff := cmp.Diff(expected, bucket.objects); diff != "" {
+		t.Errorf("objects differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestSyncDryRun(t *testing.T) {
+	bucket := &fakeBucket{
+		objects:  map[string]string{"gs://bucket/logs/job/1/started.json": "STANDARD"},
+		contents: map[string]string{"gs://bucket/logs/job/1/started.json": `{"timestamp": 0}`},
+	}
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{Periodics: []config.Periodic{{JobBase: config.JobBase{
+			Name:          "job",
+			UtilityConfig: config.UtilityConfig{DecorationConfig: &prowapi.DecorationConfig{GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "gs://bucket"}}},
+		}}}},
+		ProwConfig: config.ProwConfig{ArtifactRetention: &config.ArtifactRetention{
+			Default: &config.RetentionPolicy{DeleteAfter: &metav1.Duration{Duration: time.Hour}},
+		}},
+	}
+	if err := NewController(func() *config.Config { return cfg }, bucket, true).Sync(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bucket.objects) != 1 {
+		t.Errorf("expected dry run to keep all objects, got %v", bucket.objects)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit emits structured events for privileged actions, e.g. reruns,
+// aborts and overrides of jobs or changes of branch protection, to object
+// storage or a webhook of a SIEM.
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Actions of the privileged operations that are audited.
+const (
+	ActionRerun            = "rerun"
+	ActionAbort            = "abort"
+	ActionOverride         = "override"
+	ActionConfigReload     = "config-reload"
+	ActionBranchProtection = "branch-protection"
+	ActionPeribolosApply   = "peribolos-apply"
+)
+
+// Event records a privileged action.
+type Event struct {
+	// Time is when the action happened.
+	Time time.Time `json:"time"`
+	// Component is the Prow component that performed the action.
+	Component string `json:"component"`
+	// Action is what was done, one of the Action constants.
+	Action string `json:"action"`
+	// Actor is who requested the action, e.g. a GitHub login. It is empty for
+	// actions that are not requested by a user, like config reloads.
+	Actor string `json:"actor,omitempty"`
+	// Target is what the action was done to, e.g. a ProwJob, a pull request
+	// (org/repo#number), a branch (org/repo=branch) or a GitHub org.
+	Target string `json:"target,omitempty"`
+	// Error is set if the action failed.
+	Error string `json:"error,omitempty"`
+	// Details holds further information that depends on the action.
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Sink stores audit events.
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+}
+
+// Auditor emits the audit events of a component to its sinks. A nil Auditor
+// discards all events, so that components do not need to check whether they
+// are configured to audit.
+type Auditor struct {
+	component string
+	sinks     []Sink
+	now       func() time.Time
+}
+
+// NewAuditor returns an Auditor emitting the events of the component to the
+// sinks.
+func NewAuditor(component string, sinks ...Sink) *Auditor {
+	return &Auditor{component: component, sinks: sinks, now: time.Now}
+}
+
+// Emit writes the event to all sinks, setting its time and component.
+// Failing to write it is logged but does not fail the audited action.
+func (a *Auditor) Emit(ctx context.Context, event Event) {
+	if a == nil {
+		return
+	}
+	event.Time = a.now().UTC()
+	event.Component = a.component
+	var errs []error
+	for _, sink := range a.sinks {
+		if err := sink.Write(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{"action": event.Action, "actor": event.Actor, "target": event.Target}).Error("Failed to emit audit event.")
+	}
+}
+
+// ErrorString returns the message of the error, or an empty string if it is
+// nil, for use as the Error of an Event.
+func ErrorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
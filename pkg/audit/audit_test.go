@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+)
+
+type fakeSink struct {
+	events []Event
+	err    error
+}
+
+func (s *fakeSink) Write(_ context.Context, event Event) error {
+	s.events = append(s.events, event)
+	return s.err
+}
+
+func TestEmit(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	failing := &fakeSink{err: errors.New("unavailable")}
+	working := &fakeSink{}
+	auditor := NewAuditor("deck", failing, working)
+	auditor.now = func() time.Time { return now }
+
+	auditor.Emit(context.Background(), Event{Action: ActionRerun, Actor: "alice", Target: "job-1", Component: "ignored"})
+
+	expected := []Event{{Time: now, Component: "deck", Action: ActionRerun, Actor: "alice", Target: "job-1"}}
+	if diff := cmp.Diff(expected, working.events); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+	if len(failing.events) != 1 {
+		t.Errorf("expected the event to be written to the failing sink too, got %d events", len(failing.events))
+	}
+
+	var nilAuditor *Auditor
+	nilAuditor.Emit(context.Background(), Event{Action: ActionAbort})
+}
+
+func TestStorageSinkAndQuery(t *testing.T) {
+	ctx := context.Background()
+	opener := &fakeopener.FakeOpener{}
+	dir := "gs://bucket/audit"
+	sink := NewStorageSink(opener, dir+"/")
+	day := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	events := []Event{
+		{Time: day.Add(2 * time.Hour), Component: "deck", Action: ActionAbort, Actor: "bob", Target: "job-2"},
+		{Time: day.Add(time.Hour), Component: "deck", Action: ActionRerun, Actor: "alice", Target: "job-1"},
+		{Time: day.Add(3 * time.Hour), Component: "hook", Action: ActionOverride, Actor: "alice", Target: "org/repo#1", Details: map[string]string{"contexts": "ci/test"}},
+		{Time: day.Add(25 * time.Hour), Component: "deck", Action: ActionRerun, Actor: "alice", Target: "job-3"},
+	}
+	for _, event := range events {
+		if err := sink.Write(ctx, event); err != nil {
+			t.Fatalf("failed to write event: %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		filter   Filter
+		expected []Event
+	}{
+		{
+			name:     "all events of the day ordered by time",
+			expected: []Event{events[1], events[0], events[2]},
+		},
+		{
+			name:     "by component and action",
+			filter:   Filter{Component: "deck", Action: ActionRerun},
+			expected: []Event{events[1]},
+		},
+		{
+			name:     "by actor",
+			filter:   Filter{Actor: "Alice"},
+			expected: []Event{events[1], events[2]},
+		},
+		{
+			name:     "by target",
+			filter:   Filter{Target: "org/repo"},
+			expected: []Event{events[2]},
+		},
+		{
+			name:     "nothing matches",
+			filter:   Filter{Actor: "carol"},
+			expected: []Event{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Query(ctx, opener, dir, day.Add(12*time.Hour), tc.filter)
+			if err != nil {
+				t.Fatalf("failed to query events: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected events (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received Event
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if received.Action == ActionAbort {
+			http.Error(w, "rejected", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, func() []byte { return []byte("secret\n") }, nil)
+	event := Event{Time: time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC), Component: "deck", Action: ActionRerun, Actor: "alice"}
+	if err := sink.Write(context.Background(), event); err != nil {
+		t.Fatalf("failed to write event: %v", err)
+	}
+	if diff := cmp.Diff(event, received); diff != "" {
+		t.Errorf("unexpected event (-want +got):\n%s", diff)
+	}
+	if authorization != "Bearer secret" {
+		t.Errorf("expected bearer token, got %q", authorization)
+	}
+	if err := sink.Write(context.Background(), Event{Action: ActionAbort}); err == nil {
+		t.Error("expected an error when the webhook rejects the event")
+	}
+	if sink.client.Timeout == 0 {
+		t.Error("expected the default client to time out")
+	}
+}
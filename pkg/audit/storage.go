@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	pkgio "sigs.k8s.io/prow/pkg/io"
+)
+
+// dateLayout is the layout of the directories that group the events of a day.
+const dateLayout = "2006-01-02"
+
+// StorageSink writes every event as a JSON object to object storage, below a
+// directory per day (UTC) so that the events of a day can be listed.
+type StorageSink struct {
+	opener pkgio.Opener
+	path   string
+}
+
+// NewStorageSink returns a sink writing events below the path, e.g.
+// gs://bucket/audit.
+func NewStorageSink(opener pkgio.Opener, path string) *StorageSink {
+	return &StorageSink{opener: opener, path: strings.TrimSuffix(path, "/")}
+}
+
+func (s *StorageSink) Write(ctx context.Context, event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+	name := fmt.Sprintf("%s/%s/%d-%s-%s-%s.json", s.path, event.Time.Format(dateLayout), event.Time.UnixNano(), event.Component, event.Action, uuid.NewString())
+	if err := pkgio.WriteContent(ctx, logrus.WithField("action", event.Action), s.opener, name, b); err != nil {
+		return fmt.Errorf("failed to write audit event to %s: %w", name, err)
+	}
+	return nil
+}
+
+// Filter selects audit events. Empty fields match all events.
+type Filter struct {
+	Component string
+	Action    string
+	Actor     string
+	Target    string
+}
+
+func (f Filter) matches(event Event) bool {
+	return (f.Component == "" || f.Component == event.Component) &&
+		(f.Action == "" || f.Action == event.Action) &&
+		(f.Actor == "" || strings.EqualFold(f.Actor, event.Actor)) &&
+		(f.Target == "" || strings.Contains(event.Target, f.Target))
+}
+
+// Query reads the events of the day that a StorageSink wrote below the path
+// and returns the ones matching the filter, ordered by time.
+func Query(ctx context.Context, opener pkgio.Opener, path string, day time.Time, filter Filter) ([]Event, error) {
+	dir := fmt.Sprintf("%s/%s/", strings.TrimSuffix(path, "/"), day.UTC().Format(dateLayout))
+	it, err := opener.Iterator(ctx, dir, "/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events in %s: %w", dir, err)
+	}
+	events := []Event{}
+	for {
+		attrs, err := it.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audit events in %s: %w", dir, err)
+		}
+		if attrs.IsDir || !strings.HasSuffix(attrs.Name, ".json") {
+			continue
+		}
+		name := dir + attrs.ObjName
+		b, err := pkgio.ReadContent(ctx, logrus.NewEntry(logrus.StandardLogger()), opener, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit event %s: %w", name, err)
+		}
+		var event Event
+		if err := json.Unmarshal(b, &event); err != nil {
+			return nil, fmt.Errorf("failed to parse audit event %s: %w", name, err)
+		}
+		if filter.matches(event) {
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// webhookTimeout bounds how long posting an event may block the audited
+// action when no client is given.
+const webhookTimeout = 10 * time.Second
+
+// WebhookSink posts every event as a JSON object to a webhook, e.g. the HTTP
+// event collector of a SIEM.
+type WebhookSink struct {
+	url    string
+	token  func() []byte
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting events to the URL. If token is not
+// nil, its value is sent as bearer token. If client is nil, a client that
+// times out after 10 seconds is used.
+func NewWebhookSink(url string, token func() []byte, client *http.Client) *WebhookSink {
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	return &WebhookSink{url: url, token: token, client: client}
+}
+
+func (s *WebhookSink) Write(ctx context.Context, event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != nil {
+		req.Header.Set("Authorization", "Bearer "+string(bytes.TrimSpace(s.token())))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post audit event: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to post audit event: status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
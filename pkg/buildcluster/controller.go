@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package buildcluster implements a controller that probes the health of the
+// build clusters registered as BuildCluster resources and rotates their
+// tokens.
+package buildcluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	coreapi "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+const (
+	ControllerName = "build-cluster"
+
+	// probeInterval is how often the build clusters are probed.
+	probeInterval = time.Minute
+)
+
+// ClientFactory creates a client for a build cluster.
+type ClientFactory func(cfg *rest.Config) (kubernetes.Interface, error)
+
+func Add(mgr controllerruntime.Manager) error {
+	reconciler := NewReconciler(mgr.GetClient(), func(cfg *rest.Config) (kubernetes.Interface, error) {
+		return kubernetes.NewForConfig(cfg)
+	}, clock.RealClock{})
+	if err := controllerruntime.NewControllerManagedBy(mgr).
+		Named(ControllerName).
+		// The status updates of the controller itself do not change the
+		// generation, build clusters are probed again by requeueing them.
+		For(&prowv1.BuildCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(reconciler); err != nil {
+		return fmt.Errorf("failed to construct controller: %w", err)
+	}
+	return nil
+}
+
+type Reconciler struct {
+	client    ctrlruntimeclient.Client
+	newClient ClientFactory
+	clock     clock.PassiveClock
+	log       *logrus.Entry
+}
+
+func NewReconciler(client ctrlruntimeclient.Client, newClient ClientFactory, clock clock.PassiveClock) *Reconciler {
+	return &Reconciler{
+		client:    client,
+		newClient: newClient,
+		clock:     clock,
+		log:       logrus.NewEntry(logrus.StandardLogger()).WithField("controller", ControllerName),
+	}
+}
+
+// Reconcile probes the build cluster, rotates its token if it is due and
+// records the outcome in the status of the BuildCluster. Build clusters are
+// probed again after probeInterval.
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithField("build-cluster", request.Name)
+
+	bc := &prowv1.BuildCluster{}
+	if err := r.client.Get(ctx, request.NamespacedName, bc); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("get build cluster %s: %w", request.Name, err)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	updated := bc.DeepCopy()
+	now := metav1.NewTime(r.clock.Now())
+	updated.Status.LastProbeTime = now
+	if err := r.probe(ctx, updated, now); err != nil {
+		log.WithError(err).Warn("Build cluster is unhealthy")
+		updated.Status.Healthy = false
+		updated.Status.Message = err.Error()
+	} else {
+		updated.Status.Healthy = true
+		updated.Status.Message = ""
+	}
+
+	if err := r.client.Status().Patch(ctx, updated, ctrlruntimeclient.MergeFrom(bc)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("patch status of build cluster %s: %w", request.Name, err)
+	}
+	return reconcile.Result{RequeueAfter: probeInterval}, nil
+}
+
+// probe connects to the build cluster and rotates its token if it is due.
+func (r *Reconciler) probe(ctx context.Context, bc *prowv1.BuildCluster, now metav1.Time) error {
+	token, err := kube.BuildClusterToken(ctx, r.client, bc)
+	if err != nil {
+		return err
+	}
+	cfg, err := kube.BuildClusterConfig(bc, token)
+	if err != nil {
+		return err
+	}
+	client, err := r.newClient(&cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	serverVersion, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get server version: %w", err)
+	}
+	bc.Status.ServerVersion = serverVersion.GitVersion
+
+	auth := bc.Spec.Auth.Token
+	if auth == nil || auth.Rotation == nil {
+		return nil
+	}
+	interval := auth.Rotation.IntervalOrDefault()
+	if last := bc.Status.LastRotationTime; last != nil && now.Sub(last.Time) < interval {
+		return nil
+	}
+	if err := r.rotate(ctx, client, bc, interval); err != nil {
+		return fmt.Errorf("failed to rotate token: %w", err)
+	}
+	bc.Status.LastRotationTime = &now
+	return nil
+}
+
+// rotate requests a new token for the service account of the build cluster
+// and stores it in the token secret. The new token is valid for twice the
+// rotation interval, so that components restarting with it do not race with
+// its expiration.
+func (r *Reconciler) rotate(ctx context.Context, client kubernetes.Interface, bc *prowv1.BuildCluster, interval time.Duration) error {
+	token := bc.Spec.Auth.Token
+	expirationSeconds := int64(2 * interval / time.Second)
+	request, err := client.CoreV1().ServiceAccounts(token.Rotation.Namespace).CreateToken(ctx, token.Rotation.ServiceAccount, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to request token for service account %s/%s: %w", token.Rotation.Namespace, token.Rotation.ServiceAccount, err)
+	}
+
+	secret := &coreapi.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: bc.Namespace, Name: token.SecretName}, secret); err != nil {
+		return fmt.Errorf("failed to get secret %s: %w", token.SecretName, err)
+	}
+	rotated := secret.DeepCopy()
+	if rotated.Data == nil {
+		rotated.Data = map[string][]byte{}
+	}
+	rotated.Data[token.KeyOrDefault()] = []byte(request.Status.Token)
+	if err := r.client.Patch(ctx, rotated, ctrlruntimeclient.MergeFrom(secret)); err != nil {
+		return fmt.Errorf("failed to update secret %s: %w", token.SecretName, err)
+	}
+	r.log.WithField("build-cluster", bc.Name).Info("Rotated token")
+	return nil
+}
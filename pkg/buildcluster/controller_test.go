@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildcluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+type unreachableClient struct {
+	*fake.Clientset
+}
+
+func (c *unreachableClient) Discovery() discovery.DiscoveryInterface {
+	return &unreachableDiscovery{FakeDiscovery: c.Clientset.Discovery().(*fakediscovery.FakeDiscovery)}
+}
+
+type unreachableDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (d *unreachableDiscovery) ServerVersion() (*version.Info, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	recently := metav1.NewTime(now.Add(-time.Hour))
+	longAgo := metav1.NewTime(now.Add(-48 * time.Hour))
+	nowTime := metav1.NewTime(now)
+
+	buildCluster := func(rotation *prowv1.TokenRotation, lastRotation *metav1.Time) *prowv1.BuildCluster {
+		return &prowv1.BuildCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "build01", Namespace: "prow"},
+			Spec: prowv1.BuildClusterSpec{
+				Server: "https://build01",
+				Auth: prowv1.BuildClusterAuth{Token: &prowv1.BuildClusterToken{
+					SecretName: "build01-token",
+					Rotation:   rotation,
+				}},
+			},
+			Status: prowv1.BuildClusterStatus{LastRotationTime: lastRotation},
+		}
+	}
+	rotation := &prowv1.TokenRotation{ServiceAccount: "prow", Namespace: "test-pods"}
+
+	testCases := []struct {
+		name           string
+		buildCluster   *prowv1.BuildCluster
+		unreachable    bool
+		expectedStatus prowv1.BuildClusterStatus
+		expectedToken  string
+	}{
+		{
+			name:         "healthy cluster without rotation",
+			buildCluster: buildCluster(nil, nil),
+			expectedStatus: prowv1.BuildClusterStatus{
+				Healthy:       true,
+				ServerVersion: "v1.30.0",
+				LastProbeTime: nowTime,
+			},
+			expectedToken: "old",
+		},
+		{
+			name:         "unreachable cluster",
+			buildCluster: buildCluster(rotation, nil),
+			unreachable:  true,
+			expectedStatus: prowv1.BuildClusterStatus{
+				Message:       "failed to get server version: connection refused",
+				LastProbeTime: nowTime,
+			},
+			expectedToken: "old",
+		},
+		{
+			name:         "token is rotated for the first time",
+			buildCluster: buildCluster(rotation, nil),
+			expectedStatus: prowv1.BuildClusterStatus{
+				Healthy:          true,
+				ServerVersion:    "v1.30.0",
+				LastProbeTime:    nowTime,
+				LastRotationTime: &nowTime,
+			},
+			expectedToken: "new",
+		},
+		{
+			name:         "token is not rotated before the interval passed",
+			buildCluster: buildCluster(rotation, &recently),
+			expectedStatus: prowv1.BuildClusterStatus{
+				Healthy:          true,
+				ServerVersion:    "v1.30.0",
+				LastProbeTime:    nowTime,
+				LastRotationTime: &recently,
+			},
+			expectedToken: "old",
+		},
+		{
+			name:         "token is rotated after the interval passed",
+			buildCluster: buildCluster(rotation, &longAgo),
+			expectedStatus: prowv1.BuildClusterStatus{
+				Healthy:          true,
+				ServerVersion:    "v1.30.0",
+				LastProbeTime:    nowTime,
+				LastRotationTime: &nowTime,
+			},
+			expectedToken: "new",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret := &coreapi.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "build01-token", Namespace: "prow"},
+				Data:       map[string][]byte{"token": []byte("old")},
+			}
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.buildCluster, secret).Build()
+
+			buildClusterClient := fake.NewSimpleClientset()
+			discovery := buildClusterClient.Discovery().(*fakediscovery.FakeDiscovery)
+			discovery.FakedServerVersion = &version.Info{GitVersion: "v1.30.0"}
+			var requestedExpiration int64
+			buildClusterClient.PrependReactor("create", "serviceaccounts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				request := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+				requestedExpiration = *request.Spec.ExpirationSeconds
+				return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{Token: "new"}}, nil
+			})
+			var usedToken string
+			newClient := func(cfg *rest.Config) (kubernetes.Interface, error) {
+				usedToken = cfg.BearerToken
+				if tc.unreachable {
+					return &unreachableClient{Clientset: buildClusterClient}, nil
+				}
+				return buildClusterClient, nil
+			}
+
+			r := NewReconciler(client, newClient, clocktesting.NewFakePassiveClock(now))
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "prow", Name: "build01"}}
+			result, err := r.Reconcile(context.Background(), request)
+			if err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+			if result.RequeueAfter != probeInterval {
+				t.Errorf("expected to be requeued after %s, got %s", probeInterval, result.RequeueAfter)
+			}
+			if usedToken != "old" {
+				t.Errorf("expected the build cluster to be probed with token old, got %q", usedToken)
+			}
+
+			var bc prowv1.BuildCluster
+			if err := client.Get(context.Background(), request.NamespacedName, &bc); err != nil {
+				t.Fatalf("failed to get build cluster: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedStatus, bc.Status); diff != "" {
+				t.Errorf("unexpected status (-want +got):\n%s", diff)
+			}
+			if err := client.Get(context.Background(), types.NamespacedName{Namespace: "prow", Name: "build01-token"}, secret); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if token := string(secret.Data["token"]); token != tc.expectedToken {
+				t.Errorf("expected token %q, got %q", tc.expectedToken, token)
+			}
+			if tc.expectedToken == "new" && requestedExpiration != int64(2*prowv1.DefaultTokenRotationInterval/time.Second) {
+				t.Errorf("expected token to be requested for twice the rotation interval, got %ds", requestedExpiration)
+			}
+		})
+	}
+}
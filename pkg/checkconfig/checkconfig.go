@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package checkconfig contains the framework behind the checkconfig lint
+// rules. Every rule is an Analyzer that can be toggled individually. Analyzers
+// other than the built-in ones are either compiled in by calling Register from
+// an init function, or run as an external executable (see ExecAnalyzer).
+package checkconfig
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// Analyzer is a single, individually toggleable lint rule.
+type Analyzer struct {
+	// Name identifies the analyzer in --warnings and --exclude-warning.
+	Name string
+	// Doc is a short description of what the analyzer checks.
+	Doc string
+	// Default makes the analyzer run unless warnings are selected
+	// explicitly.
+	Default bool
+	// RequiresPluginConfig skips the analyzer when no plugin config was
+	// provided.
+	RequiresPluginConfig bool
+	// Run reports the problems it finds through the pass. A returned error
+	// means that the analyzer itself failed and aborts checkconfig.
+	Run func(pass *Pass) error
+}
+
+// Pass is the input of a single analyzer run.
+type Pass struct {
+	Config *config.Config
+	// PluginConfig is nil unless a plugin config was provided.
+	PluginConfig *plugins.Configuration
+
+	analyzer    *Analyzer
+	defaultFile string
+	findings    []Finding
+}
+
+// Finding is a single problem reported by an analyzer.
+type Finding struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	// File is the config file the problem was found in, if known.
+	File string `json:"file,omitempty"`
+}
+
+func (f Finding) Error() string {
+	return f.Message
+}
+
+// Report records err as a finding. Aggregated errors are recorded as one
+// finding each.
+func (p *Pass) Report(err error) {
+	if err == nil {
+		return
+	}
+	var agg utilerrors.Aggregate
+	if errors.As(err, &agg) {
+		for _, err := range agg.Errors() {
+			p.Report(err)
+		}
+		return
+	}
+	p.ReportFile(p.defaultFile, err.Error())
+}
+
+// ReportFile records a finding in the given file.
+func (p *Pass) ReportFile(file, message string) {
+	p.findings = append(p.findings, Finding{Rule: p.analyzer.Name, Message: message, File: file})
+}
+
+// Run runs the analyzer. Findings that don't name a file are attributed to
+// defaultFile.
+func Run(a *Analyzer, cfg *config.Config, pcfg *plugins.Configuration, defaultFile string) ([]Finding, error) {
+	if a.RequiresPluginConfig && pcfg == nil {
+		return nil, nil
+	}
+	pass := &Pass{Config: cfg, PluginConfig: pcfg, analyzer: a, defaultFile: defaultFile}
+	if err := a.Run(pass); err != nil {
+		return nil, fmt.Errorf("analyzer %s failed: %w", a.Name, err)
+	}
+	return pass.findings, nil
+}
+
+var (
+	registryLock sync.Mutex
+	registry     = map[string]*Analyzer{}
+)
+
+// Register makes a compiled-in analyzer available to checkconfig. It is
+// meant to be called from init functions and panics on duplicate names.
+func Register(a *Analyzer) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if a.Name == "" || a.Run == nil {
+		panic("checkconfig: analyzer must have a name and a Run function")
+	}
+	if _, exists := registry[a.Name]; exists {
+		panic(fmt.Sprintf("checkconfig: analyzer %s registered twice", a.Name))
+	}
+	registry[a.Name] = a
+}
+
+// Registered returns the registered analyzers, sorted by name.
+func Registered() []*Analyzer {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	var analyzers []*Analyzer
+	for _, a := range registry {
+		analyzers = append(analyzers, a)
+	}
+	sort.Slice(analyzers, func(i, j int) bool { return analyzers[i].Name < analyzers[j].Name })
+	return analyzers
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkconfig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name             string
+		analyzer         *Analyzer
+		expectedFindings []Finding
+		expectedErr      string
+	}{
+		{
+			name: "aggregated errors are reported individually",
+			analyzer: &Analyzer{Name: "rule", Run: func(pass *Pass) error {
+				pass.Report(utilerrors.NewAggregate([]error{errors.New("first"), errors.New("second")}))
+				pass.Report(nil)
+				pass.ReportFile("jobs.yaml", "third")
+				return nil
+			}},
+			expectedFindings: []Finding{
+				{Rule: "rule", Message: "first", File: "config.yaml"},
+				{Rule: "rule", Message: "second", File: "config.yaml"},
+				{Rule: "rule", Message: "third", File: "jobs.yaml"},
+			},
+		},
+		{
+			name: "analyzer failure",
+			analyzer: &Analyzer{Name: "rule", Run: func(pass *Pass) error {
+				return errors.New("no token")
+			}},
+			expectedErr: "analyzer rule failed: no token",
+		},
+		{
+			name: "analyzer requiring plugin config is skipped without one",
+			analyzer: &Analyzer{Name: "rule", RequiresPluginConfig: true, Run: func(pass *Pass) error {
+				return errors.New("should not run")
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			findings, err := Run(tc.analyzer, &config.Config{}, nil, "config.yaml")
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Fatalf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+			if diff := cmp.Diff(tc.expectedFindings, findings); diff != "" {
+				t.Errorf("unexpected findings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	defer func() { registry = map[string]*Analyzer{} }()
+	Register(&Analyzer{Name: "b", Run: func(*Pass) error { return nil }})
+	Register(&Analyzer{Name: "a", Run: func(*Pass) error { return nil }})
+
+	var names []string
+	for _, a := range Registered() {
+		names = append(names, a.Name)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, names); diff != "" {
+		t.Errorf("unexpected analyzers (-want +got):\n%s", diff)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a duplicate analyzer to panic")
+		}
+	}()
+	Register(&Analyzer{Name: "a", Run: func(*Pass) error { return nil }})
+}
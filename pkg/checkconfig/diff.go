@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// The kinds of JobChanges.
+const (
+	JobAdded   = "added"
+	JobRemoved = "removed"
+	JobChanged = "changed"
+)
+
+// The aspects of a job that a JobChange reports separately because they
+// decide when, where and with how much capacity the job runs.
+const (
+	AspectTriggers  = "triggers"
+	AspectResources = "resources"
+	AspectCluster   = "cluster"
+	AspectOther     = "other fields"
+)
+
+// JobChange describes how a job differs between two config revisions.
+type JobChange struct {
+	// Kind is presubmit, postsubmit or periodic.
+	Kind string
+	Name string
+	// Repo is empty for periodics.
+	Repo string
+	// Change is one of JobAdded, JobRemoved and JobChanged.
+	Change string
+	// Aspects are the changed aspects of a changed job.
+	Aspects []string
+}
+
+// jobFacets holds the aspects of a job in comparable form.
+type jobFacets struct {
+	triggers, resources, cluster, all string
+}
+
+func facetsFor(triggers interface{}, base config.JobBase, job interface{}) jobFacets {
+	resources := map[string]v1.ResourceRequirements{}
+	if base.Spec != nil {
+		for _, c := range append(base.Spec.InitContainers, base.Spec.Containers...) {
+			resources[c.Name] = c.Resources
+		}
+	}
+	return jobFacets{
+		triggers:  marshal(triggers),
+		resources: marshal(resources),
+		cluster:   base.Cluster,
+		all:       marshal(job),
+	}
+}
+
+func marshal(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		// The config was loaded from YAML, so it always serializes.
+		return fmt.Sprintf("%#v", v)
+	}
+	return string(raw)
+}
+
+func presubmitFacets(p config.Presubmit) jobFacets {
+	return facetsFor(struct {
+		AlwaysRun bool
+		Optional  bool
+		Trigger   string
+		config.Brancher
+		config.RegexpChangeMatcher
+	}{p.AlwaysRun, p.Optional, p.Trigger, p.Brancher, p.RegexpChangeMatcher}, p.JobBase, p)
+}
+
+func postsubmitFacets(p config.Postsubmit) jobFacets {
+	return facetsFor(struct {
+		AlwaysRun *bool
+		config.Brancher
+		config.RegexpChangeMatcher
+	}{p.AlwaysRun, p.Brancher, p.RegexpChangeMatcher}, p.JobBase, p)
+}
+
+func periodicFacets(p config.Periodic) jobFacets {
+	return facetsFor(struct {
+		Cron, Interval, MinimumInterval string
+	}{p.Cron, p.Interval, p.MinimumInterval}, p.JobBase, p)
+}
+
+// changedAspects returns the aspects that differ, or nil if the jobs are
+// identical.
+func changedAspects(base, head jobFacets) []string {
+	var aspects []string
+	if base.triggers != head.triggers {
+		aspects = append(aspects, AspectTriggers)
+	}
+	if base.resources != head.resources {
+		aspects = append(aspects, AspectResources)
+	}
+	if base.cluster != head.cluster {
+		aspects = append(aspects, AspectCluster)
+	}
+	if len(aspects) == 0 && base.all != head.all {
+		aspects = append(aspects, AspectOther)
+	}
+	return aspects
+}
+
+type jobKey struct {
+	kind, repo, name string
+}
+
+// DiffJobs compares the jobs of two config revisions. The changes are sorted
+// by repo, kind and name, periodics come first.
+func DiffJobs(base, head *config.JobConfig) []JobChange {
+	collect := func(jc *config.JobConfig) map[jobKey]jobFacets {
+		jobs := map[jobKey]jobFacets{}
+		for repo, presubmits := range jc.PresubmitsStatic {
+			for _, p := range presubmits {
+				jobs[jobKey{"presubmit", repo, p.Name}] = presubmitFacets(p)
+			}
+		}
+		for repo, postsubmits := range jc.PostsubmitsStatic {
+			for _, p := range postsubmits {
+				jobs[jobKey{"postsubmit", repo, p.Name}] = postsubmitFacets(p)
+			}
+		}
+		for _, p := range jc.Periodics {
+			jobs[jobKey{"periodic", "", p.Name}] = periodicFacets(p)
+		}
+		return jobs
+	}
+	baseJobs, headJobs := collect(base), collect(head)
+
+	var changes []JobChange
+	for key, headFacets := range headJobs {
+		change := JobChange{Kind: key.kind, Name: key.name, Repo: key.repo}
+		if baseFacets, existed := baseJobs[key]; !existed {
+			change.Change = JobAdded
+		} else if change.Aspects = changedAspects(baseFacets, headFacets); len(change.Aspects) > 0 {
+			change.Change = JobChanged
+		} else {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	for key := range baseJobs {
+		if _, exists := headJobs[key]; !exists {
+			changes = append(changes, JobChange{Kind: key.kind, Name: key.name, Repo: key.repo, Change: JobRemoved})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return changes
+}
+
+// WriteDiffMarkdown writes the changes as Markdown that is suitable for a
+// pull request comment.
+func WriteDiffMarkdown(w io.Writer, changes []JobChange) error {
+	if len(changes) == 0 {
+		_, err := io.WriteString(w, "This change does not affect any jobs.\n")
+		return err
+	}
+	var b strings.Builder
+	repos := sets.New[string]()
+	counts := map[string]int{}
+	for _, change := range changes {
+		if change.Repo != "" {
+			repos.Insert(change.Repo)
+		}
+		counts[change.Change]++
+	}
+	fmt.Fprintf(&b, "This change adds %d, removes %d and changes %d jobs", counts[JobAdded], counts[JobRemoved], counts[JobChanged])
+	if repos.Len() > 0 {
+		fmt.Fprintf(&b, " of %d repos", repos.Len())
+	}
+	b.WriteString(".\n\n| Repo | Job | Type | Change |\n| --- | --- | --- | --- |\n")
+	for _, change := range changes {
+		repo := change.Repo
+		if repo == "" {
+			repo = "-"
+		}
+		description := change.Change
+		if len(change.Aspects) > 0 {
+			description += ": " + strings.Join(change.Aspects, ", ")
+		}
+		fmt.Fprintf(&b, "| %s | `%s` | %s | %s |\n", repo, change.Name, change.Kind, description)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
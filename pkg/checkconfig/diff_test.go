@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkconfig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestDiffJobs(t *testing.T) {
+	spec := func(cpu string) *v1.PodSpec {
+		return &v1.PodSpec{Containers: []v1.Container{{
+			Name:      "test",
+			Image:     "alpine",
+			Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)}},
+		}}}
+	}
+	presubmit := func(name string, alwaysRun bool, cpu string) config.Presubmit {
+		return config.Presubmit{JobBase: config.JobBase{Name: name, Spec: spec(cpu)}, AlwaysRun: alwaysRun}
+	}
+	base := &config.JobConfig{
+		PresubmitsStatic: map[string][]config.Presubmit{
+			"org/repo": {
+				presubmit("unchanged", true, "1"),
+				presubmit("trigger", true, "1"),
+				presubmit("resources", true, "1"),
+				presubmit("removed", true, "1"),
+			},
+		},
+		Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic", Cluster: "default"}, Interval: "1h"}},
+	}
+	head := &config.JobConfig{
+		PresubmitsStatic: map[string][]config.Presubmit{
+			"org/repo": {
+				presubmit("unchanged", true, "1"),
+				presubmit("trigger", false, "1"),
+				presubmit("resources", true, "2"),
+			},
+			"org/other": {presubmit("added", true, "1")},
+		},
+		Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "periodic", Cluster: "build01", MaxConcurrency: 1}, Interval: "1h"}},
+	}
+	head.PresubmitsStatic["org/repo"][0].Annotations = map[string]string{"owner": "me"}
+
+	expected := []JobChange{
+		{Kind: "periodic", Name: "periodic", Change: JobChanged, Aspects: []string{AspectCluster}},
+		{Kind: "presubmit", Name: "added", Repo: "org/other", Change: JobAdded},
+		{Kind: "presubmit", Name: "removed", Repo: "org/repo", Change: JobRemoved},
+		{Kind: "presubmit", Name: "resources", Repo: "org/repo", Change: JobChanged, Aspects: []string{AspectResources}},
+		{Kind: "presubmit", Name: "trigger", Repo: "org/repo", Change: JobChanged, Aspects: []string{AspectTriggers}},
+		{Kind: "presubmit", Name: "unchanged", Repo: "org/repo", Change: JobChanged, Aspects: []string{AspectOther}},
+	}
+	changes := DiffJobs(base, head)
+	if diff := cmp.Diff(expected, changes); diff != "" {
+		t.Errorf("unexpected changes (-want +got):\n%s", diff)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDiffMarkdown(&buf, changes[:2]); err != nil {
+		t.Fatalf("failed to write markdown: %v", err)
+	}
+	expectedMarkdown := "This change adds 1, removes 0 and changes 1 jobs of 1 repos.\n\n" +
+		"| Repo | Job | Type | Change |\n| --- | --- | --- | --- |\n" +
+		"| - | `periodic` | periodic | changed: cluster |\n" +
+		"| org/other | `added` | presubmit | added |\n"
+	if diff := cmp.Diff(expectedMarkdown, buf.String()); diff != "" {
+		t.Errorf("unexpected markdown (-want +got):\n%s", diff)
+	}
+
+	if changes := DiffJobs(base, base); len(changes) != 0 {
+		t.Errorf("expected no changes when diffing a config against itself, got %v", changes)
+	}
+}
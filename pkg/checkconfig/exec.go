@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// ExecInput is written as JSON to the stdin of exec analyzers.
+type ExecInput struct {
+	Config       *config.Config         `json:"config"`
+	PluginConfig *plugins.Configuration `json:"plugin_config,omitempty"`
+}
+
+// ExecOutput is read as JSON from the stdout of exec analyzers.
+type ExecOutput struct {
+	Findings []ExecFinding `json:"findings"`
+}
+
+// ExecFinding is a finding reported by an exec analyzer.
+type ExecFinding struct {
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+}
+
+// ExecAnalyzer returns an analyzer that runs an external executable. The
+// executable receives an ExecInput on stdin and must print an ExecOutput on
+// stdout. A non-zero exit code means that the analyzer failed.
+func ExecAnalyzer(name, path string) *Analyzer {
+	return &Analyzer{
+		Name:    name,
+		Doc:     fmt.Sprintf("Runs %s.", path),
+		Default: true,
+		Run: func(pass *Pass) error {
+			input, err := json.Marshal(ExecInput{Config: pass.Config, PluginConfig: pass.PluginConfig})
+			if err != nil {
+				return fmt.Errorf("failed to marshal input: %w", err)
+			}
+			var stdout, stderr bytes.Buffer
+			cmd := exec.Command(path)
+			cmd.Stdin = bytes.NewReader(input)
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("%s: %w: %s", path, err, strings.TrimSpace(stderr.String()))
+			}
+			var output ExecOutput
+			if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+				return fmt.Errorf("failed to unmarshal output of %s: %w", path, err)
+			}
+			for _, finding := range output.Findings {
+				file := finding.File
+				if file == "" {
+					file = pass.defaultFile
+				}
+				pass.ReportFile(file, finding.Message)
+			}
+			return nil
+		},
+	}
+}
+
+// ParseExecAnalyzer parses a name=path flag value into an exec analyzer.
+func ParseExecAnalyzer(value string) (*Analyzer, error) {
+	name, path, found := strings.Cut(value, "=")
+	if !found || name == "" || path == "" {
+		return nil, fmt.Errorf("exec analyzer %q must be of the form name=path", value)
+	}
+	return ExecAnalyzer(name, path), nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func TestExecAnalyzer(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "analyzer")
+	// The analyzer only succeeds if it received the config on stdin.
+	if err := os.WriteFile(script, []byte(`#!/bin/sh
+if grep -q '"name":"nightly"' ; then
+  echo '{"findings": [{"message": "nightly is not allowed", "file": "jobs.yaml"}, {"message": "no file"}]}'
+else
+  echo 'input did not contain the config' >&2
+  exit 1
+fi
+`), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{JobConfig: config.JobConfig{Periodics: []config.Periodic{{JobBase: config.JobBase{Name: "nightly"}}}}}
+	a, err := ParseExecAnalyzer("custom=" + script)
+	if err != nil {
+		t.Fatalf("failed to parse exec analyzer: %v", err)
+	}
+	findings, err := Run(a, cfg, nil, "config.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Finding{
+		{Rule: "custom", Message: "nightly is not allowed", File: "jobs.yaml"},
+		{Rule: "custom", Message: "no file", File: "config.yaml"},
+	}
+	if diff := cmp.Diff(expected, findings); diff != "" {
+		t.Errorf("unexpected findings (-want +got):\n%s", diff)
+	}
+
+	if _, err := Run(a, &config.Config{}, nil, "config.yaml"); err == nil {
+		t.Error("expected failing exec analyzer to return an error")
+	}
+	if _, err := ParseExecAnalyzer("custom"); err == nil {
+		t.Error("expected an error for an exec analyzer without path")
+	}
+}
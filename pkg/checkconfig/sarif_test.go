@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	analyzers := []*Analyzer{{Name: "rule", Doc: "Checks things."}}
+	findings := []Finding{{Rule: "rule", Message: "broken", File: "config.yaml"}}
+	if err := WriteSARIF(&buf, analyzers, findings, true); err != nil {
+		t.Fatalf("failed to write SARIF: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("failed to unmarshal SARIF: %v", err)
+	}
+	expected := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "checkconfig",
+			InformationURI: "https://docs.prow.k8s.io/docs/components/cli-tools/checkconfig/",
+			Rules:          []sarifRule{{ID: "rule", ShortDescription: sarifMessage{Text: "Checks things."}}},
+		}},
+		Results: []sarifResult{{
+			RuleID:    "rule",
+			Level:     "error",
+			Message:   sarifMessage{Text: "broken"},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "config.yaml"}}}},
+		}},
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("expected a single SARIF 2.1.0 run, got %+v", log)
+	}
+	if diff := cmp.Diff(expected, log.Runs[0]); diff != "" {
+		t.Errorf("unexpected run (-want +got):\n%s", diff)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/sirupsen/logrus"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/pod-utils/clone"
+	"sigs.k8s.io/prow/pkg/tokenbroker"
 )
 
 // Options configures the clonerefs tool
@@ -73,6 +74,13 @@ type Options struct {
 	GitHubAppID             string   `json:"github_app_id,omitempty"`
 	GitHubAppPrivateKeyFile string   `json:"github_app_private_key_file,omitempty"`
 
+	// GitHubTokenBrokerURL is the URL of a token broker to get GitHub App
+	// installation tokens from instead of minting them with the private key.
+	GitHubTokenBrokerURL string `json:"github_token_broker_url,omitempty"`
+	// GitHubTokenBrokerTokenFile is the path of a file that contains the
+	// secret to authenticate with at the token broker.
+	GitHubTokenBrokerTokenFile string `json:"github_token_broker_token_file,omitempty"`
+
 	// used to hold flag values
 	refs      gitRefs
 	clonePath orgRepoFormat
@@ -123,6 +131,20 @@ func (o *Options) Validate() error {
 	if o.GitHubAppID == "" && o.GitHubAppPrivateKeyFile != "" {
 		return errors.New("no GitHub App ID specified")
 	}
+	if o.GitHubTokenBrokerURL != "" || o.GitHubTokenBrokerTokenFile != "" {
+		if o.OauthTokenFile != "" || o.GitHubAppID != "" || o.GitHubAppPrivateKeyFile != "" {
+			return errors.New("multiple authentication methods specified")
+		}
+		if o.GitHubTokenBrokerURL == "" {
+			return errors.New("no token broker URL specified")
+		}
+		if o.GitHubTokenBrokerTokenFile == "" {
+			return errors.New("no token broker token file specified")
+		}
+		if err := tokenbroker.ValidateURL(o.GitHubTokenBrokerURL); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -211,6 +211,88 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "specify token broker",
+			input: Options{
+				SrcRoot: "test",
+				Log:     "thing",
+				GitRefs: []prowapi.Refs{
+					{
+						Repo: "repo",
+						Org:  "org",
+					},
+				},
+				GitHubTokenBrokerURL:       "https://token-broker",
+				GitHubTokenBrokerTokenFile: "/tmp/broker-token",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "specify token broker and GitHub App authentication",
+			input: Options{
+				SrcRoot: "test",
+				Log:     "thing",
+				GitRefs: []prowapi.Refs{
+					{
+						Repo: "repo",
+						Org:  "org",
+					},
+				},
+				GitHubAPIEndpoints:         []string{github.DefaultAPIEndpoint},
+				GitHubAppID:                "123456",
+				GitHubAppPrivateKeyFile:    "/tmp/private-key.pem",
+				GitHubTokenBrokerURL:       "https://token-broker",
+				GitHubTokenBrokerTokenFile: "/tmp/broker-token",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "specify token broker but no token file",
+			input: Options{
+				SrcRoot: "test",
+				Log:     "thing",
+				GitRefs: []prowapi.Refs{
+					{
+						Repo: "repo",
+						Org:  "org",
+					},
+				},
+				GitHubTokenBrokerURL: "https://token-broker",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "specify token broker on a unix socket",
+			input: Options{
+				SrcRoot: "test",
+				Log:     "thing",
+				GitRefs: []prowapi.Refs{
+					{
+						Repo: "repo",
+						Org:  "org",
+					},
+				},
+				GitHubTokenBrokerURL:       "unix:///var/run/token-broker/broker.sock",
+				GitHubTokenBrokerTokenFile: "/tmp/broker-token",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "specify token broker over plain HTTP",
+			input: Options{
+				SrcRoot: "test",
+				Log:     "thing",
+				GitRefs: []prowapi.Refs{
+					{
+						Repo: "repo",
+						Org:  "org",
+					},
+				},
+				GitHubTokenBrokerURL:       "http://token-broker",
+				GitHubTokenBrokerTokenFile: "/tmp/broker-token",
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
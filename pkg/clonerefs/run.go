@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config/secret"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/pod-utils/clone"
+	"sigs.k8s.io/prow/pkg/tokenbroker"
 )
 
 var cloneFunc = clone.Run
@@ -102,6 +103,26 @@ func (o *Options) createRecords() []clone.Record {
 		}
 	}
 
+	if o.GitHubTokenBrokerURL != "" {
+		if err := secret.Add(o.GitHubTokenBrokerTokenFile); err != nil {
+			logrus.WithError(err).Error("Failed to read token broker token file.")
+			rec.Failed = true
+			return []clone.Record{rec}
+		}
+		generator, err := tokenbroker.NewTokenGenerator(o.GitHubTokenBrokerURL, func() []byte {
+			return secret.GetSecret(o.GitHubTokenBrokerTokenFile)
+		})
+		if err != nil {
+			logrus.WithError(err).Error("Failed to construct token broker client.")
+			rec.Failed = true
+			return []clone.Record{rec}
+		}
+		tokenGenerator = generator
+		userGenerator = func() (string, error) {
+			return "x-access-token", nil
+		}
+	}
+
 	// Print md5 sum of cookiefile for debugging purpose
 	if len(o.CookiePath) > 0 {
 		l := logrus.WithField("http-cookiefile", o.CookiePath)
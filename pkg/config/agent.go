@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -44,6 +45,12 @@ type Agent struct {
 	mut           sync.RWMutex // do not export Lock, etc methods
 	c             *Config
 	subscriptions []DeltaChan
+	// reloadRequested makes Start load the config at its next tick, even
+	// if the config files did not change.
+	reloadRequested atomic.Bool
+	// watchedPaths are polled for modifications by Start next to the config
+	// files, see WatchPaths.
+	watchedPaths []string
 }
 
 // IsConfigMapMount determines whether the provided directory is a configmap mounted directory
@@ -276,6 +283,7 @@ func watchConfigs(ca *Agent, prowConfig, jobConfig string, supplementalProwConfi
 	} else {
 		dirs.Insert(prowConfig)
 	}
+	dirs.Insert(ca.watchedPaths...)
 	var runFuncs []func(context.Context)
 	for cm := range cms {
 		runFunc, err := GetCMMountWatcher(cmEventFunc, errFunc, cm)
@@ -311,7 +319,7 @@ func (ca *Agent) StartWatch(prowConfig, jobConfig string, supplementalProwConfig
 	return nil
 }
 
-func lastConfigModTime(prowConfig, jobConfig string) (time.Time, error) {
+func lastConfigModTime(prowConfig, jobConfig string, watchedPaths []string) (time.Time, error) {
 	// Check if the file changed to see if it needs to be re-read.
 	// os.Stat follows symbolic links, which is how ConfigMaps work.
 	prowStat, err := os.Stat(prowConfig)
@@ -332,14 +340,46 @@ func lastConfigModTime(prowConfig, jobConfig string) (time.Time, error) {
 			recentModTime = jobConfigStat.ModTime()
 		}
 	}
+	for _, path := range watchedPaths {
+		modTime, err := lastModTime(path)
+		if err != nil {
+			logrus.WithField("path", path).WithError(err).Error("Error loading watched path.")
+			return time.Time{}, err
+		}
+		if modTime.After(recentModTime) {
+			recentModTime = modTime
+		}
+	}
 	return recentModTime, nil
 }
 
+// lastModTime returns the modification time of the file at path or, for a
+// directory, the most recent one of the directory and everything in it.
+func lastModTime(path string) (time.Time, error) {
+	var recentModTime time.Time
+	err := filepath.WalkDir(path, func(p string, _ os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Stat the path rather than using the entry, to follow the symbolic
+		// links of ConfigMap mounts.
+		stat, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		if stat.ModTime().After(recentModTime) {
+			recentModTime = stat.ModTime()
+		}
+		return nil
+	})
+	return recentModTime, err
+}
+
 // Start will begin polling the config file at the path. If the first load
 // fails, Start will return the error and abort. Future load failures will log
 // the failure message but continue attempting to load.
 func (ca *Agent) Start(prowConfig, jobConfig string, additionalProwConfigDirs []string, supplementalProwConfigsFileNameSuffix string, additionals ...func(*Config) error) error {
-	lastModTime, err := lastConfigModTime(prowConfig, jobConfig)
+	lastModTime, err := lastConfigModTime(prowConfig, jobConfig, ca.watchedPaths)
 	if err != nil {
 		lastModTime = time.Time{}
 	}
@@ -354,8 +394,8 @@ func (ca *Agent) Start(prowConfig, jobConfig string, additionalProwConfigDirs []
 		// fail. Reload periodically just in case.
 		skips := 0
 		for range time.Tick(1 * time.Second) {
-			if skips < 600 {
-				recentModTime, err := lastConfigModTime(prowConfig, jobConfig)
+			if skips < 600 && !ca.reloadRequested.Swap(false) {
+				recentModTime, err := lastConfigModTime(prowConfig, jobConfig, ca.watchedPaths)
 				if err != nil {
 					continue
 				}
@@ -378,6 +418,20 @@ func (ca *Agent) Start(prowConfig, jobConfig string, additionalProwConfigDirs []
 	return nil
 }
 
+// WatchPaths makes the agent started with Start reload the config when a file
+// at or below one of the paths is modified, e.g. because one of its additional
+// load functions reads them. It must be called before Start.
+func (ca *Agent) WatchPaths(paths ...string) {
+	ca.watchedPaths = append(ca.watchedPaths, paths...)
+}
+
+// Reload makes the agent started with Start load the config again within a
+// second, e.g. because a source read by one of its additional load functions
+// changed.
+func (ca *Agent) Reload() {
+	ca.reloadRequested.Store(true)
+}
+
 // Subscribe registers the channel for messages on config reload.
 // The caller can expect a copy of the previous and current config
 // to be sent down the subscribed channel when a new configuration
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultArtifactRetentionResyncPeriod = 6 * time.Hour
+	defaultColdStorageClass              = "ARCHIVE"
+)
+
+// ArtifactRetention configures the artifact-retention controller, which
+// deletes or archives the artifacts that jobs upload to their bucket.
+type ArtifactRetention struct {
+	// ResyncPeriod is how often the artifacts are checked. Defaults to 6h.
+	ResyncPeriod *metav1.Duration `json:"resync_period,omitempty"`
+	// Default is the policy of jobs no other policy applies to. Artifacts
+	// of such jobs are kept forever if unset.
+	Default *RetentionPolicy `json:"default,omitempty"`
+	// Repos maps orgs and org/repos to the policy of their jobs. The
+	// policy of a repo takes precedence over the one of its org.
+	Repos map[string]RetentionPolicy `json:"repos,omitempty"`
+	// Jobs maps job names to their policy, which takes precedence over the
+	// policy of their repo.
+	Jobs map[string]RetentionPolicy `json:"jobs,omitempty"`
+}
+
+// RetentionPolicy is how long the artifacts of the builds of a job are kept.
+// The age of a build is measured from when it started.
+type RetentionPolicy struct {
+	// DeleteAfter is the age after which the artifacts of a build are
+	// deleted. They are never deleted if unset.
+	DeleteAfter *metav1.Duration `json:"delete_after,omitempty"`
+	// ColdStorageAfter is the age after which the artifacts of a build are
+	// moved to ColdStorageClass. They are never moved if unset. Only GCS
+	// buckets support it.
+	ColdStorageAfter *metav1.Duration `json:"cold_storage_after,omitempty"`
+	// ColdStorageClass is the storage class artifacts are moved to.
+	// Defaults to ARCHIVE.
+	ColdStorageClass string `json:"cold_storage_class,omitempty"`
+	// KeepGreenPostsubmits is how many of the latest passing builds of a
+	// postsubmit are kept regardless of their age.
+	KeepGreenPostsubmits int `json:"keep_green_postsubmits,omitempty"`
+}
+
+func (ar *ArtifactRetention) defaultAndValidate() error {
+	if ar == nil {
+		return nil
+	}
+	if ar.ResyncPeriod == nil {
+		ar.ResyncPeriod = &metav1.Duration{Duration: defaultArtifactRetentionResyncPeriod}
+	}
+	var errs []error
+	if ar.ResyncPeriod.Duration <= 0 {
+		errs = append(errs, errors.New("resync_period must be positive"))
+	}
+	if ar.Default != nil {
+		if err := ar.Default.defaultAndValidate(); err != nil {
+			errs = append(errs, fmt.Errorf("default: %w", err))
+		}
+	}
+	for _, policies := range []map[string]RetentionPolicy{ar.Repos, ar.Jobs} {
+		for key, policy := range policies {
+			if err := policy.defaultAndValidate(); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			}
+			policies[key] = policy
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *RetentionPolicy) defaultAndValidate() error {
+	if p.ColdStorageClass == "" {
+		p.ColdStorageClass = defaultColdStorageClass
+	}
+	var errs []error
+	if p.DeleteAfter != nil && p.DeleteAfter.Duration <= 0 {
+		errs = append(errs, errors.New("delete_after must be positive"))
+	}
+	if p.ColdStorageAfter != nil && p.ColdStorageAfter.Duration <= 0 {
+		errs = append(errs, errors.New("cold_storage_after must be positive"))
+	}
+	if p.DeleteAfter != nil && p.ColdStorageAfter != nil && p.ColdStorageAfter.Duration >= p.DeleteAfter.Duration {
+		errs = append(errs, errors.New("cold_storage_after must be less than delete_after"))
+	}
+	if p.KeepGreenPostsubmits < 0 {
+		errs = append(errs, fmt.Errorf("keep_green_postsubmits must not be negative, got %d", p.KeepGreenPostsubmits))
+	}
+	return errors.Join(errs...)
+}
+
+// RetentionPolicyFor returns the retention policy of the artifacts of a job,
+// or nil if they are kept forever. org and repo are empty for periodics
+// without refs.
+func (ar *ArtifactRetention) RetentionPolicyFor(org, repo, job string) *RetentionPolicy {
+	if ar == nil {
+		return nil
+	}
+	if policy, ok := ar.Jobs[job]; ok {
+		return &policy
+	}
+	if org != "" {
+		if policy, ok := ar.Repos[org+"/"+repo]; ok {
+			return &policy
+		}
+		if policy, ok := ar.Repos[org]; ok {
+			return &policy
+		}
+	}
+	return ar.Default
+}
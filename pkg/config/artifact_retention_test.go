@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestArtifactRetentionDefaultAndValidate(t *testing.T) {
+	days := func(n int) *metav1.Duration { return &metav1.Duration{Duration: time.Duration(n) * 24 * time.Hour} }
+	testCases := []struct {
+		name      string
+		retention *ArtifactRetention
+		expectErr bool
+	}{
+		{
+			name: "nil is valid",
+		},
+		{
+			name:      "valid policies",
+			retention: &ArtifactRetention{Default: &RetentionPolicy{DeleteAfter: days(90), ColdStorageAfter: days(30)}, Repos: map[string]RetentionPolicy{"org": {KeepGreenPostsubmits: 5}}},
+		},
+		{
+			name:      "negative resync period is rejected",
+			retention: &ArtifactRetention{ResyncPeriod: &metav1.Duration{Duration: -time.Hour}},
+			expectErr: true,
+		},
+		{
+			name:      "cold storage after deletion is rejected",
+			retention: &ArtifactRetention{Jobs: map[string]RetentionPolicy{"job": {DeleteAfter: days(30), ColdStorageAfter: days(30)}}},
+			expectErr: true,
+		},
+		{
+			name:      "negative number of kept builds is rejected",
+			retention: &ArtifactRetention{Default: &RetentionPolicy{KeepGreenPostsubmits: -1}},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.retention.defaultAndValidate()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if tc.retention == nil || tc.expectErr {
+				return
+			}
+			if tc.retention.ResyncPeriod.Duration != defaultArtifactRetentionResyncPeriod {
+				t.Errorf("expected resync period %s, got %s", defaultArtifactRetentionResyncPeriod, tc.retention.ResyncPeriod.Duration)
+			}
+			for key, policy := range tc.retention.Repos {
+				if policy.ColdStorageClass != defaultColdStorageClass {
+					t.Errorf("%s: expected cold storage class %s, got %q", key, defaultColdStorageClass, policy.ColdStorageClass)
+				}
+			}
+		})
+	}
+}
+
+func TestRetentionPolicyFor(t *testing.T) {
+	ar := &ArtifactRetention{
+		Default: &RetentionPolicy{KeepGreenPostsubmits: 1},
+		Repos: map[string]RetentionPolicy{
+			"org":      {KeepGreenPostsubmits: 2},
+			"org/repo": {KeepGreenPostsubmits: 3},
+		},
+		Jobs: map[string]RetentionPolicy{"job": {KeepGreenPostsubmits: 4}},
+	}
+	testCases := []struct {
+		org, repo, job string
+		expected       int
+	}{
+		{org: "org", repo: "repo", job: "job", expected: 4},
+		{org: "org", repo: "repo", job: "other", expected: 3},
+		{org: "org", repo: "other", job: "other", expected: 2},
+		{org: "other", repo: "repo", job: "other", expected: 1},
+		{job: "periodic", expected: 1},
+	}
+	for _, tc := range testCases {
+		policy := ar.RetentionPolicyFor(tc.org, tc.repo, tc.job)
+		if policy == nil || policy.KeepGreenPostsubmits != tc.expected {
+			t.Errorf("%s/%s %s: expected policy %d, got %+v", tc.org, tc.repo, tc.job, tc.expected, policy)
+		}
+	}
+	if policy := (&ArtifactRetention{}).RetentionPolicyFor("org", "repo", "job"); policy != nil {
+		t.Errorf("expected no policy, got %+v", policy)
+	}
+}
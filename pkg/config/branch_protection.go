@@ -289,6 +289,16 @@ func (bp BranchProtection) HasManagedRepos() bool {
 	return false
 }
 
+// HasRulesets returns true if the global branch protector's config has rulesets
+func (bp BranchProtection) HasRulesets() bool {
+	for _, org := range bp.Orgs {
+		if len(org.Rulesets) > 0 || org.HasRepoRulesets() {
+			return true
+		}
+	}
+	return false
+}
+
 func (bp *BranchProtection) merge(additional *BranchProtection) error {
 	var errs []error
 	if isPolicySet(bp.Policy) && isPolicySet(additional.Policy) {
@@ -327,6 +337,11 @@ func (bp *BranchProtection) merge(additional *BranchProtection) error {
 			orgSettings.Policy = additional.Orgs[org].Policy
 			bp.Orgs[org] = orgSettings
 		}
+		if rulesets := additional.Orgs[org].Rulesets; len(rulesets) > 0 {
+			orgSettings := bp.Orgs[org]
+			orgSettings.Rulesets = append(orgSettings.Rulesets, rulesets...)
+			bp.Orgs[org] = orgSettings
+		}
 
 		for repo := range additional.Orgs[org].Repos {
 			if bp.Orgs[org].Repos == nil {
@@ -341,6 +356,11 @@ func (bp *BranchProtection) merge(additional *BranchProtection) error {
 				repoSettings.Policy = additional.Orgs[org].Repos[repo].Policy
 				bp.Orgs[org].Repos[repo] = repoSettings
 			}
+			if rulesets := additional.Orgs[org].Repos[repo].Rulesets; len(rulesets) > 0 {
+				repoSettings := bp.Orgs[org].Repos[repo]
+				repoSettings.Rulesets = append(repoSettings.Rulesets, rulesets...)
+				bp.Orgs[org].Repos[repo] = repoSettings
+			}
 
 			for branch := range additional.Orgs[org].Repos[repo].Branches {
 				if bp.Orgs[org].Repos[repo].Branches == nil {
@@ -378,6 +398,8 @@ func (bp BranchProtection) GetOrg(name string) *Org {
 type Org struct {
 	Policy `json:",inline"`
 	Repos  map[string]Repo `json:"repos,omitempty"`
+	// Rulesets are organization rulesets, applying to the repos they select.
+	Rulesets []Ruleset `json:"rulesets,omitempty"`
 }
 
 // HasManagedRepos returns true if the org has managed repos
@@ -400,6 +422,16 @@ func (o Org) HasManagedBranches() bool {
 	return false
 }
 
+// HasRepoRulesets returns true if any repo of the org has rulesets
+func (o Org) HasRepoRulesets() bool {
+	for _, repo := range o.Repos {
+		if len(repo.Rulesets) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // GetRepo returns the repo config after merging in any org policies.
 func (o Org) GetRepo(name string) *Repo {
 	r, ok := o.Repos[name]
@@ -415,6 +447,8 @@ func (o Org) GetRepo(name string) *Repo {
 type Repo struct {
 	Policy   `json:",inline"`
 	Branches map[string]Branch `json:"branches,omitempty"`
+	// Rulesets are repository rulesets, applying to the refs they select.
+	Rulesets []Ruleset `json:"rulesets,omitempty"`
 }
 
 // HasManagedBranches returns true if the repo has managed branches
@@ -446,6 +480,128 @@ type Branch struct {
 	Policy `json:",inline"`
 }
 
+// Ruleset configures a GitHub ruleset. Rulesets are managed by name: the ones
+// listed are created or updated to match, any other ruleset is left alone.
+type Ruleset struct {
+	// Name identifies the ruleset on GitHub.
+	Name string `json:"name"`
+	// Target is either branch or tag, defaults to branch.
+	Target string `json:"target,omitempty"`
+	// Enforcement is one of active, evaluate or disabled, defaults to active.
+	Enforcement string `json:"enforcement,omitempty"`
+	// Include lists the fnmatch patterns of refs the ruleset applies to, e.g.
+	// refs/heads/release-*, ~DEFAULT_BRANCH or ~ALL. Defaults to ~DEFAULT_BRANCH.
+	Include []string `json:"include,omitempty"`
+	// Exclude lists the fnmatch patterns of refs excluded from the ruleset.
+	Exclude []string `json:"exclude,omitempty"`
+	// Repos lists the fnmatch patterns of repos an organization ruleset
+	// applies to. Defaults to ~ALL. Only valid for organization rulesets.
+	Repos []string `json:"repos,omitempty"`
+	// ExcludeRepos lists the fnmatch patterns of repos excluded from an
+	// organization ruleset. Only valid for organization rulesets.
+	ExcludeRepos []string `json:"exclude_repos,omitempty"`
+	// RequiredStatusChecks lists the contexts that must pass before a ref is updated.
+	RequiredStatusChecks *ContextPolicy `json:"required_status_checks,omitempty"`
+	// RequiredPullRequestReviews requires changes to be made through pull requests.
+	RequiredPullRequestReviews *RulesetReviewPolicy `json:"required_pull_request_reviews,omitempty"`
+	// RequiredSignatures requires commits to have verified signatures.
+	RequiredSignatures bool `json:"required_signatures,omitempty"`
+	// RequiredLinearHistory prevents merge commits from being pushed.
+	RequiredLinearHistory bool `json:"required_linear_history,omitempty"`
+	// RestrictCreations only allows users with bypass permissions to create matching refs.
+	RestrictCreations bool `json:"restrict_creations,omitempty"`
+	// RestrictUpdates only allows users with bypass permissions to push to matching refs.
+	RestrictUpdates bool `json:"restrict_updates,omitempty"`
+	// RestrictDeletions only allows users with bypass permissions to delete matching refs.
+	RestrictDeletions bool `json:"restrict_deletions,omitempty"`
+	// BlockForcePushes prevents anyone from force pushing to matching refs.
+	BlockForcePushes bool `json:"block_force_pushes,omitempty"`
+	// BypassActors are allowed to bypass the rules.
+	BypassActors []RulesetBypassActor `json:"bypass_actors,omitempty"`
+}
+
+// RulesetReviewPolicy configures the pull request rule of a ruleset.
+type RulesetReviewPolicy struct {
+	// Approvals is the number of approving reviews required.
+	Approvals int `json:"required_approving_review_count,omitempty"`
+	// DismissStale dismisses approving reviews when new commits are pushed.
+	DismissStale bool `json:"dismiss_stale_reviews,omitempty"`
+	// RequireOwners requires an approving review from CODEOWNERS.
+	RequireOwners bool `json:"require_code_owner_reviews,omitempty"`
+	// RequireLastPushApproval requires the last push to be approved by someone else.
+	RequireLastPushApproval bool `json:"require_last_push_approval,omitempty"`
+	// RequireThreadResolution requires all review threads to be resolved.
+	RequireThreadResolution bool `json:"require_review_thread_resolution,omitempty"`
+}
+
+// RulesetBypassActor is allowed to bypass the rules of a ruleset.
+type RulesetBypassActor struct {
+	// ActorType is one of Integration, OrganizationAdmin, RepositoryRole, Team or DeployKey.
+	ActorType string `json:"actor_type"`
+	// ActorID is the ID of the app, repository role or team. Use 1 for OrganizationAdmin.
+	ActorID int `json:"actor_id,omitempty"`
+	// BypassMode is either always or pull_request, defaults to always.
+	BypassMode string `json:"bypass_mode,omitempty"`
+}
+
+var (
+	rulesetTargets      = sets.New[string]("", "branch", "tag")
+	rulesetEnforcements = sets.New[string]("", "active", "evaluate", "disabled")
+	rulesetActorTypes   = sets.New[string]("Integration", "OrganizationAdmin", "RepositoryRole", "Team", "DeployKey")
+	rulesetBypassModes  = sets.New[string]("", "always", "pull_request")
+)
+
+func validateRulesets(rulesets []Ruleset, orgLevel bool) error {
+	var errs []error
+	names := sets.New[string]()
+	for _, r := range rulesets {
+		if r.Name == "" {
+			errs = append(errs, errors.New("rulesets must have a name"))
+			continue
+		}
+		if names.Has(r.Name) {
+			errs = append(errs, fmt.Errorf("ruleset %q is defined more than once", r.Name))
+		}
+		names.Insert(r.Name)
+		if !rulesetTargets.Has(r.Target) {
+			errs = append(errs, fmt.Errorf("ruleset %q: invalid target %q, must be one of %v", r.Name, r.Target, sets.List(rulesetTargets.Clone().Delete(""))))
+		}
+		if !rulesetEnforcements.Has(r.Enforcement) {
+			errs = append(errs, fmt.Errorf("ruleset %q: invalid enforcement %q, must be one of %v", r.Name, r.Enforcement, sets.List(rulesetEnforcements.Clone().Delete(""))))
+		}
+		if !orgLevel && (len(r.Repos) > 0 || len(r.ExcludeRepos) > 0) {
+			errs = append(errs, fmt.Errorf("ruleset %q: repos and exclude_repos are only valid for organization rulesets", r.Name))
+		}
+		if r.RequiredPullRequestReviews != nil && r.RequiredPullRequestReviews.Approvals < 0 {
+			errs = append(errs, fmt.Errorf("ruleset %q: required_approving_review_count must not be negative", r.Name))
+		}
+		for _, actor := range r.BypassActors {
+			if !rulesetActorTypes.Has(actor.ActorType) {
+				errs = append(errs, fmt.Errorf("ruleset %q: invalid bypass actor_type %q, must be one of %v", r.Name, actor.ActorType, sets.List(rulesetActorTypes)))
+			}
+			if !rulesetBypassModes.Has(actor.BypassMode) {
+				errs = append(errs, fmt.Errorf("ruleset %q: invalid bypass_mode %q, must be one of %v", r.Name, actor.BypassMode, sets.List(rulesetBypassModes.Clone().Delete(""))))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (bp BranchProtection) validateRulesets() error {
+	var errs []error
+	for orgName, org := range bp.Orgs {
+		if err := validateRulesets(org.Rulesets, true); err != nil {
+			errs = append(errs, fmt.Errorf("org %s: %w", orgName, err))
+		}
+		for repoName, repo := range org.Repos {
+			if err := validateRulesets(repo.Rulesets, false); err != nil {
+				errs = append(errs, fmt.Errorf("repo %s/%s: %w", orgName, repoName, err))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 // GetBranchProtection returns the policy for a given branch.
 //
 // Handles merging any policies defined at repo/org/global levels into the branch policy.
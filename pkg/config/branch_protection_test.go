@@ -1338,3 +1338,74 @@ func TestUnprotectedBranches(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRulesets(t *testing.T) {
+	testCases := []struct {
+		name        string
+		bp          BranchProtection
+		expectedErr string
+	}{
+		{
+			name: "valid org and repo rulesets",
+			bp: BranchProtection{
+				Orgs: map[string]Org{
+					"org": {
+						Rulesets: []Ruleset{{Name: "signed", Repos: []string{"~ALL"}, RequiredSignatures: true}},
+						Repos: map[string]Repo{
+							"repo": {Rulesets: []Ruleset{{
+								Name:         "signed",
+								Enforcement:  "evaluate",
+								BypassActors: []RulesetBypassActor{{ActorType: "Team", ActorID: 42, BypassMode: "pull_request"}},
+							}}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "duplicate names in the same scope",
+			bp: BranchProtection{
+				Orgs: map[string]Org{
+					"org": {Rulesets: []Ruleset{{Name: "signed"}, {Name: "signed"}}},
+				},
+			},
+			expectedErr: `org org: ruleset "signed" is defined more than once`,
+		},
+		{
+			name: "repo ruleset selecting repos",
+			bp: BranchProtection{
+				Orgs: map[string]Org{
+					"org": {Repos: map[string]Repo{
+						"repo": {Rulesets: []Ruleset{{Name: "signed", ExcludeRepos: []string{"other"}}}},
+					}},
+				},
+			},
+			expectedErr: `repo org/repo: ruleset "signed": repos and exclude_repos are only valid for organization rulesets`,
+		},
+		{
+			name: "invalid enforcement and bypass actor",
+			bp: BranchProtection{
+				Orgs: map[string]Org{
+					"org": {Rulesets: []Ruleset{{
+						Name:         "signed",
+						Enforcement:  "enforced",
+						BypassActors: []RulesetBypassActor{{ActorType: "User"}},
+					}}},
+				},
+			},
+			expectedErr: `org org: [ruleset "signed": invalid enforcement "enforced", must be one of [active disabled evaluate], ruleset "signed": invalid bypass actor_type "User", must be one of [DeployKey Integration OrganizationAdmin RepositoryRole Team]]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := tc.bp.validateRulesets(); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
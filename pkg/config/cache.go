@@ -148,6 +148,23 @@ type InRepoConfigCache struct {
 	*cache.LRUCache
 	configAgent prowConfigAgentClient
 	gitClient   git.ClientFactory
+	// store optionally persists ProwYAMLs beyond the lifetime of the LRUCache.
+	store ProwYAMLStore
+}
+
+// ProwYAMLStore persists the ProwYAMLs constructed by an InRepoConfigCache. It
+// is consulted on cache misses before falling back to Git, which spares cold
+// starts from having to clone every repository again. ProwYAMLs are keyed by
+// commits and therefore never go stale.
+type ProwYAMLStore interface {
+	Get(key CacheKey) (*ProwYAML, bool)
+	Put(key CacheKey, prowYAML *ProwYAML) error
+}
+
+// SetPersistentStore makes the cache fall back to the given store on cache
+// misses. It must be called before the cache is used.
+func (cache *InRepoConfigCache) SetPersistentStore(store ProwYAMLStore) {
+	cache.store = store
 }
 
 // NewInRepoConfigCache creates a new LRU cache for ProwYAML values, where the keys
@@ -230,12 +247,12 @@ func NewInRepoConfigCache(
 	}()
 
 	cache := &InRepoConfigCache{
-		lruCache,
+		LRUCache: lruCache,
 		// Know how to default the retrieved ProwYAML values against the latest Config.
-		configAgent,
+		configAgent: configAgent,
 		// Make the cache be able to handle cache misses (by calling out to Git
 		// to construct the ProwYAML value).
-		gitClientFactory,
+		gitClient: gitClientFactory,
 	}
 
 	return cache, nil
@@ -414,11 +431,29 @@ func (cache *InRepoConfigCache) getProwYAML(
 		return nil, err
 	}
 
+	keyParts := CacheKeyParts{Identifier: identifier, BaseSHA: baseSHA, HeadSHAs: headSHAs}
 	valConstructor := func() (interface{}, error) {
-		return valConstructorHelper(cache.gitClient, identifier, baseBranch, baseSHAGetter, headSHAGetters...)
+		if cache.store == nil {
+			return valConstructorHelper(cache.gitClient, identifier, baseBranch, baseSHAGetter, headSHAGetters...)
+		}
+		key, err := keyParts.CacheKey()
+		if err != nil {
+			return nil, fmt.Errorf("converting CacheKeyParts to CacheKey: %v", err)
+		}
+		if prowYAML, ok := cache.store.Get(key); ok {
+			return prowYAML, nil
+		}
+		prowYAML, err := valConstructorHelper(cache.gitClient, identifier, baseBranch, baseSHAGetter, headSHAGetters...)
+		if err != nil {
+			return nil, err
+		}
+		if err := cache.store.Put(key, prowYAML); err != nil {
+			logrus.WithError(err).WithField("identifier", identifier).Warn("Failed to persist ProwYAML.")
+		}
+		return prowYAML, nil
 	}
 
-	got, err := cache.get(CacheKeyParts{Identifier: identifier, BaseSHA: baseSHA, HeadSHAs: headSHAs}, valConstructor)
+	got, err := cache.get(keyParts, valConstructor)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DiskProwYAMLStore is a ProwYAMLStore that keeps every ProwYAML in its own
+// JSON file, named after the SHA256 of its cache key.
+type DiskProwYAMLStore struct {
+	dir string
+}
+
+var _ ProwYAMLStore = (*DiskProwYAMLStore)(nil)
+
+// NewDiskProwYAMLStore creates a DiskProwYAMLStore in dir, creating the
+// directory if needed.
+func NewDiskProwYAMLStore(dir string) (*DiskProwYAMLStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	return &DiskProwYAMLStore{dir: dir}, nil
+}
+
+func (s *DiskProwYAMLStore) path(key CacheKey) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the ProwYAML stored for key. Entries that cannot be read are
+// treated as missing.
+func (s *DiskProwYAMLStore) Get(key CacheKey) (*ProwYAML, bool) {
+	p := s.path(key)
+	raw, err := os.ReadFile(p)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.WithError(err).WithField("path", p).Warn("Failed to read persisted ProwYAML.")
+		}
+		return nil, false
+	}
+	prowYAML := &ProwYAML{}
+	if err := json.Unmarshal(raw, prowYAML); err != nil {
+		logrus.WithError(err).WithField("path", p).Warn("Failed to unmarshal persisted ProwYAML.")
+		return nil, false
+	}
+	// Record the access so that Prune keeps entries that are still in use.
+	now := time.Now()
+	if err := os.Chtimes(p, now, now); err != nil {
+		logrus.WithError(err).WithField("path", p).Debug("Failed to update access time of persisted ProwYAML.")
+	}
+	return prowYAML, true
+}
+
+// Put stores prowYAML for key. The file is written atomically so that
+// concurrent readers never see partial content.
+func (s *DiskProwYAMLStore) Put(key CacheKey, prowYAML *ProwYAML) error {
+	raw, err := json.Marshal(prowYAML)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ProwYAML: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", tmp.Name(), err)
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Prune removes the entries that have not been used for longer than maxAge.
+func (s *DiskProwYAMLStore) Prune(maxAge time.Duration) error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", s.dir, err)
+	}
+	var pruned int
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) <= maxAge {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+		}
+		pruned++
+	}
+	logrus.WithField("pruned", pruned).Debug("Pruned persisted ProwYAMLs.")
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	utilpointer "k8s.io/utils/pointer"
+
+	"sigs.k8s.io/prow/pkg/git/v2"
+)
+
+func TestDiskProwYAMLStore(t *testing.T) {
+	store, err := NewDiskProwYAMLStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	key, err := (&CacheKeyParts{Identifier: "foo/bar", BaseSHA: "ba5e"}).CacheKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := store.Get(key); ok {
+		t.Fatal("expected empty store to miss")
+	}
+	if err := store.Put(key, &ProwYAML{Presubmits: []Presubmit{{JobBase: JobBase{Name: "hans"}}}}); err != nil {
+		t.Fatalf("failed to put: %v", err)
+	}
+	prowYAML, ok := store.Get(key)
+	if !ok {
+		t.Fatal("expected stored ProwYAML to be found")
+	}
+	if len(prowYAML.Presubmits) != 1 || prowYAML.Presubmits[0].Name != "hans" {
+		t.Errorf("expected presubmit hans, got %v", prowYAML.Presubmits)
+	}
+
+	if err := store.Prune(time.Hour); err != nil {
+		t.Fatalf("failed to prune: %v", err)
+	}
+	if _, ok := store.Get(key); !ok {
+		t.Error("expected recently used entry to survive pruning")
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(store.path(key), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Prune(time.Hour); err != nil {
+		t.Fatalf("failed to prune: %v", err)
+	}
+	if _, ok := store.Get(key); ok {
+		t.Error("expected stale entry to be pruned")
+	}
+}
+
+func TestGetProwYAMLPersisted(t *testing.T) {
+	store, err := NewDiskProwYAMLStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	fca := &fakeConfigAgent{c: &Config{ProwConfig: ProwConfig{InRepoConfig: InRepoConfig{
+		Enabled: map[string]*bool{"*": utilpointer.Bool(true)},
+	}}}}
+
+	var constructed int
+	valConstructor := func(git.ClientFactory, string, string, RefGetter, ...RefGetter) (*ProwYAML, error) {
+		constructed++
+		if constructed > 1 {
+			return nil, errors.New("should have been served from the store")
+		}
+		return &ProwYAML{Presubmits: []Presubmit{{JobBase: JobBase{Name: "hans"}}}}, nil
+	}
+
+	// Every cache simulates a restart, with an empty LRUCache.
+	for i := 0; i < 2; i++ {
+		cache, err := NewInRepoConfigCache(1, fca, &testClientFactory{})
+		if err != nil {
+			t.Fatal("could not initialize cache")
+		}
+		cache.SetPersistentStore(store)
+		prowYAML, err := cache.getProwYAML(valConstructor, "foo/bar", "main", goodSHAGetter("ba5e"), goodSHAGetter("abcd"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(prowYAML.Presubmits) != 1 || prowYAML.Presubmits[0].Name != "hans" {
+			t.Errorf("expected presubmit hans, got %v", prowYAML.Presubmits)
+		}
+	}
+	if constructed != 1 {
+		t.Errorf("expected the ProwYAML to be constructed once, got %d", constructed)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+const (
+	defaultCanaryBakePeriod         = 2 * time.Hour
+	defaultCanaryMaxErrorPercentage = 10
+)
+
+// Canary configures the rollout of canary config revisions. A canary revision
+// is deployed next to the stable config and is only used for the allowlisted
+// repos until it is promoted or rolled back by the canary-controller. These
+// settings are always read from the stable config. Only hook uses the canary
+// revision, all other components use the stable config.
+type Canary struct {
+	// Repos lists the repos that use the canary config, as 'org' or
+	// 'org/repo'.
+	Repos []string `json:"repos,omitempty"`
+	// Jobs lists the presubmits and postsubmits that use their definition of
+	// the canary config in all repos. The rest of the stable config is still
+	// used for them.
+	Jobs []string `json:"jobs,omitempty"`
+	// BakePeriod is how long a canary is observed before it is promoted.
+	// Defaults to 2h.
+	BakePeriod *metav1.Duration `json:"bake_period,omitempty"`
+	// MaxErrorPercentage is the percentage of canary ProwJobs ending in the
+	// error state above which the canary is rolled back. 0 rolls back on
+	// any error. Defaults to 10.
+	MaxErrorPercentage *int `json:"max_error_percentage,omitempty"`
+	// MinJobs is the number of canary ProwJobs that must have finished before
+	// the canary is promoted or rolled back. Defaults to 0.
+	MinJobs int `json:"min_jobs,omitempty"`
+}
+
+func (c *Canary) defaultAndValidate() error {
+	if c == nil {
+		return nil
+	}
+	if c.BakePeriod == nil {
+		c.BakePeriod = &metav1.Duration{Duration: defaultCanaryBakePeriod}
+	}
+	if c.MaxErrorPercentage == nil {
+		maxErrorPercentage := defaultCanaryMaxErrorPercentage
+		c.MaxErrorPercentage = &maxErrorPercentage
+	}
+	var errs []error
+	if c.BakePeriod.Duration <= 0 {
+		errs = append(errs, errors.New("bake_period must be positive"))
+	}
+	if *c.MaxErrorPercentage < 0 || *c.MaxErrorPercentage > 100 {
+		errs = append(errs, fmt.Errorf("max_error_percentage must be between 0 and 100, got %d", *c.MaxErrorPercentage))
+	}
+	if c.MinJobs < 0 {
+		errs = append(errs, fmt.Errorf("min_jobs must not be negative, got %d", c.MinJobs))
+	}
+	return errors.Join(errs...)
+}
+
+// CanaryApplies returns whether the canary config is used for the given repo.
+func (c *Config) CanaryApplies(org, repo string) bool {
+	if c.Canary == nil {
+		return false
+	}
+	for _, allowed := range c.Canary.Repos {
+		if allowed == org || allowed == org+"/"+repo {
+			return true
+		}
+	}
+	return false
+}
+
+// WithCanaryJobs returns a copy of the config in which the presubmits and
+// postsubmits allowlisted in canary.jobs are replaced by their definitions in the canary config.
+// Allowlisted jobs that only one of the configs defines are added or removed
+// accordingly. The config itself is returned if no jobs are allowlisted.
+func (c *Config) WithCanaryJobs(canary *Config) *Config {
+	if c.Canary == nil || len(c.Canary.Jobs) == 0 {
+		return c
+	}
+	allowed := sets.New[string](c.Canary.Jobs...)
+	merged := *c
+	merged.PresubmitsStatic = map[string][]Presubmit{}
+	for _, repo := range sets.List(sets.KeySet(c.PresubmitsStatic).Union(sets.KeySet(canary.PresubmitsStatic))) {
+		presubmits := mergeCanaryJobs(c.PresubmitsStatic[repo], canary.PresubmitsStatic[repo], allowed, func(p Presubmit) string { return p.Name })
+		if len(presubmits) > 0 {
+			merged.PresubmitsStatic[repo] = presubmits
+		}
+	}
+	merged.PostsubmitsStatic = map[string][]Postsubmit{}
+	for _, repo := range sets.List(sets.KeySet(c.PostsubmitsStatic).Union(sets.KeySet(canary.PostsubmitsStatic))) {
+		postsubmits := mergeCanaryJobs(c.PostsubmitsStatic[repo], canary.PostsubmitsStatic[repo], allowed, func(p Postsubmit) string { return p.Name })
+		if len(postsubmits) > 0 {
+			merged.PostsubmitsStatic[repo] = postsubmits
+		}
+	}
+	merged.AllRepos = c.AllRepos.Union(sets.KeySet(merged.PresubmitsStatic)).Union(sets.KeySet(merged.PostsubmitsStatic))
+	return &merged
+}
+
+// mergeCanaryJobs returns the stable jobs that are not allowlisted, followed
+// by the canary jobs that are.
+func mergeCanaryJobs[T any](stable, canary []T, allowed sets.Set[string], name func(T) string) []T {
+	var merged []T
+	for _, job := range stable {
+		if !allowed.Has(name(job)) {
+			merged = append(merged, job)
+		}
+	}
+	for _, job := range canary {
+		if allowed.Has(name(job)) {
+			merged = append(merged, job)
+		}
+	}
+	return merged
+}
+
+// MarkCanary labels all jobs of a canary config revision, so that the
+// ProwJobs created from them can be told apart. It is meant to be passed as
+// an additional load function to the Agent loading the canary revision.
+func MarkCanary(c *Config) error {
+	mark := func(base *JobBase) {
+		if base.Labels == nil {
+			base.Labels = map[string]string{}
+		}
+		base.Labels[kube.CanaryLabel] = "true"
+	}
+	for _, presubmits := range c.PresubmitsStatic {
+		for i := range presubmits {
+			mark(&presubmits[i].JobBase)
+		}
+	}
+	for _, postsubmits := range c.PostsubmitsStatic {
+		for i := range postsubmits {
+			mark(&postsubmits[i].JobBase)
+		}
+	}
+	for i := range c.Periodics {
+		mark(&c.Periodics[i].JobBase)
+	}
+	return nil
+}
+
+// CanaryDecision is the outcome of evaluating a canary.
+type CanaryDecision string
+
+const (
+	// CanaryBaking means that the canary is still being observed.
+	CanaryBaking CanaryDecision = "baking"
+	// CanaryPromote means that the canary should replace the stable config.
+	CanaryPromote CanaryDecision = "promote"
+	// CanaryRollback means that the canary should be reverted to the stable
+	// config.
+	CanaryRollback CanaryDecision = "rollback"
+)
+
+// Evaluate decides what to do with a canary that started at start, based on
+// the canary ProwJobs created since. Only ProwJobs ending in the error state
+// count against the canary, as failures are usually caused by the code under
+// test rather than by the config.
+func (c *Canary) Evaluate(jobs []prowapi.ProwJob, start, now time.Time) (CanaryDecision, string) {
+	var finished, errored int
+	for _, pj := range jobs {
+		if pj.CreationTimestamp.Time.Before(start) || pj.Labels[kube.CanaryLabel] != "true" || !pj.Complete() {
+			continue
+		}
+		finished++
+		if pj.Status.State == prowapi.ErrorState {
+			errored++
+		}
+	}
+	if finished < c.MinJobs {
+		return CanaryBaking, fmt.Sprintf("%d/%d canary jobs finished", finished, c.MinJobs)
+	}
+	if finished > 0 && errored*100 > *c.MaxErrorPercentage*finished {
+		return CanaryRollback, fmt.Sprintf("%d/%d canary jobs errored, exceeding %d%%", errored, finished, *c.MaxErrorPercentage)
+	}
+	if now.Sub(start) < c.BakePeriod.Duration {
+		return CanaryBaking, fmt.Sprintf("baking until %s", start.Add(c.BakePeriod.Duration).Format(time.RFC3339))
+	}
+	return CanaryPromote, fmt.Sprintf("%d/%d canary jobs errored after %s", errored, finished, c.BakePeriod.Duration)
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilpointer "k8s.io/utils/pointer"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func TestCanaryDefaultAndValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		canary      *Canary
+		expectErr   bool
+		expectedMax *int
+	}{
+		{
+			name: "nil is valid",
+		},
+		{
+			name:        "defaults are applied",
+			canary:      &Canary{Repos: []string{"org"}},
+			expectedMax: utilpointer.Int(defaultCanaryMaxErrorPercentage),
+		},
+		{
+			name:        "zero error percentage is kept",
+			canary:      &Canary{MaxErrorPercentage: utilpointer.Int(0)},
+			expectedMax: utilpointer.Int(0),
+		},
+		{
+			name:      "negative bake period is rejected",
+			canary:    &Canary{BakePeriod: &metav1.Duration{Duration: -time.Hour}},
+			expectErr: true,
+		},
+		{
+			name:      "error percentage above 100 is rejected",
+			canary:    &Canary{MaxErrorPercentage: utilpointer.Int(101)},
+			expectErr: true,
+		},
+		{
+			name:      "negative min jobs is rejected",
+			canary:    &Canary{MinJobs: -1},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.canary.defaultAndValidate()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if tc.expectedMax != nil {
+				if *tc.canary.MaxErrorPercentage != *tc.expectedMax {
+					t.Errorf("expected max error percentage %d, got %d", *tc.expectedMax, *tc.canary.MaxErrorPercentage)
+				}
+				if tc.canary.BakePeriod.Duration != defaultCanaryBakePeriod {
+					t.Errorf("expected bake period %s, got %s", defaultCanaryBakePeriod, tc.canary.BakePeriod.Duration)
+				}
+			}
+		})
+	}
+}
+
+func TestCanaryApplies(t *testing.T) {
+	c := &Config{ProwConfig: ProwConfig{Canary: &Canary{Repos: []string{"org", "other/repo"}}}}
+	testCases := []struct {
+		org, repo string
+		expected  bool
+	}{
+		{org: "org", repo: "any", expected: true},
+		{org: "other", repo: "repo", expected: true},
+		{org: "other", repo: "different", expected: false},
+		{org: "third", repo: "repo", expected: false},
+	}
+	for _, tc := range testCases {
+		if actual := c.CanaryApplies(tc.org, tc.repo); actual != tc.expected {
+			t.Errorf("%s/%s: expected %t, got %t", tc.org, tc.repo, tc.expected, actual)
+		}
+	}
+	if (&Config{}).CanaryApplies("org", "repo") {
+		t.Error("expected canary not to apply without canary config")
+	}
+}
+
+func TestWithCanaryJobs(t *testing.T) {
+	stable := &Config{
+		JobConfig: JobConfig{
+			PresubmitsStatic: map[string][]Presubmit{
+				"org/repo":  {{JobBase: JobBase{Name: "pre", Cluster: "stable"}}, {JobBase: JobBase{Name: "other", Cluster: "stable"}}},
+				"org/third": {{JobBase: JobBase{Name: "removed", Cluster: "stable"}}},
+			},
+			PostsubmitsStatic: map[string][]Postsubmit{"org/repo": {{JobBase: JobBase{Name: "post", Cluster: "stable"}}}},
+			AllRepos:          sets.New[string]("org/repo", "org/third", "org/tide"),
+		},
+		ProwConfig: ProwConfig{Canary: &Canary{Jobs: []string{"pre", "removed", "added"}}},
+	}
+	canary := &Config{JobConfig: JobConfig{
+		PresubmitsStatic: map[string][]Presubmit{
+			"org/repo":  {{JobBase: JobBase{Name: "pre", Cluster: "canary"}}, {JobBase: JobBase{Name: "other", Cluster: "canary"}}},
+			"org/added": {{JobBase: JobBase{Name: "added", Cluster: "canary"}}},
+		},
+		PostsubmitsStatic: map[string][]Postsubmit{"org/repo": {{JobBase: JobBase{Name: "post", Cluster: "canary"}}}},
+	}}
+
+	merged := stable.WithCanaryJobs(canary)
+	clusters := map[string]string{}
+	for repo, presubmits := range merged.PresubmitsStatic {
+		for _, p := range presubmits {
+			clusters[repo+"/"+p.Name] = p.Cluster
+		}
+	}
+	for repo, postsubmits := range merged.PostsubmitsStatic {
+		for _, p := range postsubmits {
+			clusters[repo+"/"+p.Name] = p.Cluster
+		}
+	}
+	expected := map[string]string{
+		"org/repo/pre":    "canary",
+		"org/repo/other":  "stable",
+		"org/added/added": "canary",
+		"org/repo/post":   "stable",
+	}
+	if diff := cmp.Diff(expected, clusters); diff != "" {
+		t.Errorf("unexpected jobs (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"org/added", "org/repo", "org/third", "org/tide"}, sets.List(merged.AllRepos)); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+	if stable.PresubmitsStatic["org/repo"][0].Cluster != "stable" || len(stable.PresubmitsStatic["org/third"]) != 1 {
+		t.Error("expected the stable config to be left untouched")
+	}
+	if noJobs := (&Config{}); noJobs.WithCanaryJobs(canary) != noJobs {
+		t.Error("expected the stable config itself without allowlisted jobs")
+	}
+}
+
+func TestMarkCanary(t *testing.T) {
+	c := &Config{JobConfig: JobConfig{
+		PresubmitsStatic:  map[string][]Presubmit{"org/repo": {{JobBase: JobBase{Name: "pre", Labels: map[string]string{"a": "b"}}}}},
+		PostsubmitsStatic: map[string][]Postsubmit{"org/repo": {{JobBase: JobBase{Name: "post"}}}},
+		Periodics:         []Periodic{{JobBase: JobBase{Name: "periodic"}}},
+	}}
+	if err := MarkCanary(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, base := range []JobBase{c.PresubmitsStatic["org/repo"][0].JobBase, c.PostsubmitsStatic["org/repo"][0].JobBase, c.Periodics[0].JobBase} {
+		if base.Labels[kube.CanaryLabel] != "true" {
+			t.Errorf("job %s is not labelled as canary: %v", base.Name, base.Labels)
+		}
+	}
+	if c.PresubmitsStatic["org/repo"][0].Labels["a"] != "b" {
+		t.Error("existing labels were not preserved")
+	}
+}
+
+func TestCanaryEvaluate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(state prowapi.ProwJobState, created time.Time, canary bool) prowapi.ProwJob {
+		pj := prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Status:     prowapi.ProwJobStatus{State: state},
+		}
+		if canary {
+			pj.Labels = map[string]string{kube.CanaryLabel: "true"}
+		}
+		if state != prowapi.PendingState && state != prowapi.TriggeredState {
+			pj.Status.CompletionTime = &metav1.Time{Time: created.Add(time.Minute)}
+		}
+		return pj
+	}
+	after := start.Add(time.Minute)
+	canary := &Canary{BakePeriod: &metav1.Duration{Duration: time.Hour}, MaxErrorPercentage: utilpointer.Int(20), MinJobs: 2}
+
+	testCases := []struct {
+		name     string
+		jobs     []prowapi.ProwJob
+		now      time.Time
+		expected CanaryDecision
+	}{
+		{
+			name:     "not enough jobs finished",
+			jobs:     []prowapi.ProwJob{job(prowapi.SuccessState, after, true), job(prowapi.PendingState, after, true)},
+			now:      start.Add(2 * time.Hour),
+			expected: CanaryBaking,
+		},
+		{
+			name:     "bake period not over",
+			jobs:     []prowapi.ProwJob{job(prowapi.SuccessState, after, true), job(prowapi.FailureState, after, true)},
+			now:      start.Add(30 * time.Minute),
+			expected: CanaryBaking,
+		},
+		{
+			name:     "failures don't count against the canary",
+			jobs:     []prowapi.ProwJob{job(prowapi.FailureState, after, true), job(prowapi.FailureState, after, true)},
+			now:      start.Add(2 * time.Hour),
+			expected: CanaryPromote,
+		},
+		{
+			name:     "errors above the budget roll back before the bake period is over",
+			jobs:     []prowapi.ProwJob{job(prowapi.ErrorState, after, true), job(prowapi.SuccessState, after, true)},
+			now:      start.Add(30 * time.Minute),
+			expected: CanaryRollback,
+		},
+		{
+			name: "errors of older or non-canary jobs are ignored",
+			jobs: []prowapi.ProwJob{
+				job(prowapi.ErrorState, start.Add(-time.Minute), true),
+				job(prowapi.ErrorState, after, false),
+				job(prowapi.SuccessState, after, true),
+				job(prowapi.SuccessState, after, true),
+			},
+			now:      start.Add(2 * time.Hour),
+			expected: CanaryPromote,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual, reason := canary.Evaluate(tc.jobs, start, tc.now); actual != tc.expected {
+				t.Errorf("expected %s, got %s (%s)", tc.expected, actual, reason)
+			}
+		})
+	}
+}
@@ -73,6 +73,13 @@ const (
 
 	ProwImplicitGitResource = "PROW_IMPLICIT_GIT_REF"
 
+	// ProwArtifactsWorkspace and ProwGCSCredentialsWorkspace are Tekton
+	// workspaces the tekton-pipeline agent binds for pipelines that declare
+	// them: an emptyDir for artifacts and the decoration config's GCS
+	// credentials secret, respectively.
+	ProwArtifactsWorkspace      = "prow-artifacts"
+	ProwGCSCredentialsWorkspace = "prow-gcs-credentials"
+
 	// ConfigVersionFileName is the name of a file that will be added to
 	// all configmaps by the configupdater and contain the git sha that
 	// triggered said configupdate. The configloading in turn will pick
@@ -94,6 +101,11 @@ var (
 type Config struct {
 	JobConfig
 	ProwConfig
+
+	// SourceConflicts lists the jobs of job config sources and JobConfig
+	// resources that were ignored because they conflict with a job of higher
+	// precedence.
+	SourceConflicts []string `json:"-"`
 }
 
 // JobConfig is config for all prow jobs.
@@ -114,6 +126,16 @@ type JobConfig struct {
 	// Periodics are not associated with any repo.
 	Periodics []Periodic `json:"periodics,omitempty"`
 
+	// JobTemplates are reusable job definitions with parameters.
+	JobTemplates []JobTemplate `json:"job_templates,omitempty"`
+	// TemplatedJobs instantiate JobTemplates into presubmits, postsubmits
+	// or periodics when the config is loaded.
+	TemplatedJobs []TemplatedJob `json:"templated_jobs,omitempty"`
+
+	// Includes references job configuration hosted outside of the config
+	// repository, e.g. shared job libraries.
+	Includes []JobConfigInclude `json:"include,omitempty"`
+
 	// AllRepos contains all Repos that have one or more jobs configured or
 	// for which a tide query is configured.
 	AllRepos sets.Set[string] `json:"-"`
@@ -204,6 +226,13 @@ type ProwConfig struct {
 	// can be used to restrict build cluster on a topic.
 	PubSubTriggers PubSubTriggers `json:"pubsub_triggers,omitempty"`
 
+	// OverrideRestrictions is how gangway and sub treat envs and labels that
+	// externally triggered runs set on jobs without trigger_overrides, unless
+	// the API client or Pub/Sub trigger allows unrestricted overrides.
+	// "warn", the default for now, accepts them and logs a warning, "enforce"
+	// rejects them. The default will change to "enforce" in a future release.
+	OverrideRestrictions OverrideRestrictions `json:"override_restrictions,omitempty"`
+
 	// GitHubOptions allows users to control how prow applications display GitHub website links.
 	GitHubOptions GitHubOptions `json:"github,omitempty"`
 
@@ -230,6 +259,27 @@ type ProwConfig struct {
 	// DisabledClusters holds a list of disabled build cluster names. The same context names will be ignored while
 	// Prow components load the kubeconfig files.
 	DisabledClusters []string `json:"disabled_clusters,omitempty"`
+
+	// JobPolicies are rules every job has to satisfy. They are enforced by
+	// checkconfig and, if deployed, by the ProwJob admission webhook.
+	JobPolicies []JobPolicy `json:"job_policies,omitempty"`
+
+	// JobConfigResources configures which JobConfig custom resources may
+	// contribute to the job configuration.
+	JobConfigResources *JobConfigResources `json:"job_config_resources,omitempty"`
+
+	// Tenants maps tenant IDs, as set in prowjob_default, to the restrictions
+	// that apply to the jobs of the tenant.
+	Tenants map[string]Tenant `json:"tenants,omitempty"`
+
+	// Canary configures the rollout of canary config revisions.
+	Canary *Canary `json:"canary,omitempty"`
+
+	// StatusReconciler contains configuration for the status-reconciler.
+	StatusReconciler *StatusReconciler `json:"status_reconciler,omitempty"`
+
+	// ArtifactRetention configures how long the artifacts of jobs are kept.
+	ArtifactRetention *ArtifactRetention `json:"artifact_retention,omitempty"`
 }
 
 type InRepoConfig struct {
@@ -241,6 +291,19 @@ type InRepoConfig struct {
 	// a given repo. All clusters that are allowed for the specific repo, its org or
 	// globally can be used.
 	AllowedClusters map[string][]string `json:"allowed_clusters,omitempty"`
+	// Periodics lists the repositories that may define periodics in-repo, keyed
+	// by 'org/repo'. In-repo periodics of any other repository are rejected.
+	Periodics map[string]InRepoPeriodics `json:"periodics,omitempty"`
+}
+
+// InRepoPeriodics holds the guardrails for periodics defined in-repo.
+type InRepoPeriodics struct {
+	// Branch is the branch the periodics are loaded from.
+	Branch string `json:"branch"`
+	// MaxResources caps the resource requests and limits of every container
+	// of the periodics, including the ones added by decoration. Resources
+	// that are not listed are not capped.
+	MaxResources v1.ResourceList `json:"max_resources,omitempty"`
 }
 
 func SplitRepoName(fullRepoName string) (string, string, error) {
@@ -280,6 +343,17 @@ func (c *Config) InRepoConfigAllowsCluster(clusterName, identifier string) bool
 	return false
 }
 
+// InRepoConfigPeriodics returns the guardrails for periodics defined in-repo
+// by a given repository, or nil if the repository may not define periodics.
+func (c *Config) InRepoConfigPeriodics(identifier string) *InRepoPeriodics {
+	for _, key := range keysForIdentifier(identifier)[:2] {
+		if periodics, ok := c.InRepoConfig.Periodics[key]; ok {
+			return &periodics
+		}
+	}
+	return nil
+}
+
 // keysForIdentifier returns all possible identifiers for given keys. In
 // consideration of Gerrit identifiers that contain `https://` prefix, it
 // returns keys contain both `https://foo/bar` and `foo/bar` for identifier
@@ -708,6 +782,77 @@ type Plank struct {
 	// limit. An example use case would be easier scheduling of jobs using boskos resources.
 	// This mechanism is separate from ProwJob's MaxConcurrency setting.
 	JobQueueCapacities map[string]int `json:"job_queue_capacities,omitempty"`
+
+	// PodTemplates maps the alias of a build cluster to named pod templates
+	// that jobs of the cluster can reference with pod_template. They hold
+	// placement settings, like node selectors and tolerations, that would
+	// otherwise be repeated in the pod specs of many jobs.
+	PodTemplates map[string]map[string]PodTemplate `json:"pod_templates,omitempty"`
+
+	// WarmPools keep pods ready for latency sensitive jobs. Every pool holds
+	// placeholder pods that reserve the resources of the job on a node of its
+	// build cluster and, for decorated jobs only, pull its images there. A
+	// pod of the job prefers the node of a placeholder pod, which is deleted
+	// to make room for it once the pod was created.
+	WarmPools []WarmPool `json:"warm_pools,omitempty"`
+
+	// ImagePrePull configures DaemonSets that pull the images of the jobs
+	// of a build cluster on all of its nodes, so that pods of jobs do not
+	// wait for their images to be pulled, for example during retest storms.
+	ImagePrePull *ImagePrePull `json:"image_prepull,omitempty"`
+
+	// SecretBundles are secrets that jobs can reference by name with secrets.
+	// Every bundle lists the repos and jobs that may use it, which is
+	// enforced when the config is loaded and when ProwJobs are created.
+	SecretBundles map[string]SecretBundle `json:"secret_bundles,omitempty"`
+}
+
+// ImagePrePull configures the DaemonSets that pull the images of jobs.
+// Only images of decorated jobs are pulled, as pulling them relies on the
+// entrypoint utility.
+type ImagePrePull struct {
+	// Clusters are the aliases of the build clusters to pull images in.
+	// "*" stands for all build clusters.
+	Clusters []string `json:"clusters"`
+	// MinJobs is how many jobs of a cluster must use an image for it to be
+	// pulled. Defaults to 1.
+	MinJobs int `json:"min_jobs,omitempty"`
+	// PauseImage is the image of the container that keeps the pods of the
+	// DaemonSet running. Defaults to registry.k8s.io/pause:3.9.
+	PauseImage string `json:"pause_image,omitempty"`
+	// NodeSelector restricts the nodes that images are pulled on.
+	NodeSelector map[string]string `json:"node_selector,omitempty"`
+	// Tolerations let images be pulled on tainted nodes.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+}
+
+// EnabledFor tells whether images are pulled in the given build cluster.
+func (p *ImagePrePull) EnabledFor(cluster string) bool {
+	if p == nil {
+		return false
+	}
+	for _, c := range p.Clusters {
+		if c == "*" || c == cluster {
+			return true
+		}
+	}
+	return false
+}
+
+// WarmPool configures the placeholder pods of a job.
+type WarmPool struct {
+	// Job is the name of the job. It must be a static job that runs on the
+	// kubernetes agent, and the only static job of that name.
+	Job string `json:"job"`
+	// Size is how many placeholder pods are kept.
+	Size int `json:"size"`
+	// PriorityClassName is the priority class of the placeholder pods. A
+	// class with a lower priority than the one of jobs lets the scheduler
+	// preempt placeholder pods when the cluster is full.
+	PriorityClassName string `json:"priority_class_name,omitempty"`
+	// PauseImage is the image of the container that keeps placeholder pods
+	// running. Defaults to registry.k8s.io/pause:3.9.
+	PauseImage string `json:"pause_image,omitempty"`
 }
 
 type ProwJobDefaultEntry struct {
@@ -955,6 +1100,53 @@ type Gerrit struct {
 	// AllowedPresubmitTriggerRe is used to match presubmit test related commands in comments
 	AllowedPresubmitTriggerRe          *CopyableRegexp `json:"-"`
 	AllowedPresubmitTriggerReRawString string          `json:"allowed_presubmit_trigger_re,omitempty"`
+	// ReportLabels configures the votes cast on Gerrit labels. Jobs pick the
+	// label they vote on with the `prow.k8s.io/gerrit-report-label` label,
+	// e.g. lint jobs can vote on Code-Style while e2e jobs vote on Verified.
+	// Labels not configured here vote +1 when all of their jobs passed and -1
+	// otherwise.
+	ReportLabels []GerritReportLabel `json:"report_labels,omitempty"`
+}
+
+// GerritReportLabel configures the votes cast on a Gerrit label by the jobs
+// reporting to it.
+type GerritReportLabel struct {
+	// Label is the name of the Gerrit label, e.g. Verified.
+	Label string `json:"label"`
+	// PassVote is cast when all jobs reporting to the label passed. Defaults
+	// to +1.
+	PassVote string `json:"pass_vote,omitempty"`
+	// FailVote is cast on presubmits when at least FailThreshold jobs
+	// reporting to the label failed. Defaults to -1.
+	FailVote string `json:"fail_vote,omitempty"`
+	// FailThreshold is the number of failed jobs from which FailVote is cast,
+	// fewer failures vote 0. Defaults to 1.
+	FailThreshold int `json:"fail_threshold,omitempty"`
+}
+
+// ReportLabel returns the configuration of a Gerrit label, with defaults
+// applied when the label isn't configured.
+func (g *Gerrit) ReportLabel(label string) GerritReportLabel {
+	for _, reportLabel := range g.ReportLabels {
+		if reportLabel.Label == label {
+			return reportLabel
+		}
+	}
+	reportLabel := GerritReportLabel{Label: label}
+	reportLabel.defaultVotes()
+	return reportLabel
+}
+
+func (l *GerritReportLabel) defaultVotes() {
+	if l.PassVote == "" {
+		l.PassVote = "+1"
+	}
+	if l.FailVote == "" {
+		l.FailVote = "-1"
+	}
+	if l.FailThreshold == 0 {
+		l.FailThreshold = 1
+	}
 }
 
 func (g *Gerrit) DefaultAndValidate() error {
@@ -971,6 +1163,27 @@ func (g *Gerrit) DefaultAndValidate() error {
 		return fmt.Errorf("failed to compile regex for allowed presubmit triggers: %s", err.Error())
 	}
 	g.AllowedPresubmitTriggerRe = &CopyableRegexp{re}
+
+	seen := sets.New[string]()
+	for i := range g.ReportLabels {
+		reportLabel := &g.ReportLabels[i]
+		if reportLabel.Label == "" {
+			return errors.New("gerrit report labels must have a label")
+		}
+		if seen.Has(reportLabel.Label) {
+			return fmt.Errorf("gerrit report label %q is configured more than once", reportLabel.Label)
+		}
+		seen.Insert(reportLabel.Label)
+		if reportLabel.FailThreshold < 0 {
+			return fmt.Errorf("fail_threshold of gerrit report label %q must not be negative", reportLabel.Label)
+		}
+		reportLabel.defaultVotes()
+		for _, vote := range []string{reportLabel.PassVote, reportLabel.FailVote} {
+			if _, err := strconv.Atoi(vote); err != nil {
+				return fmt.Errorf("vote %q of gerrit report label %q is not a number", vote, reportLabel.Label)
+			}
+		}
+	}
 	return nil
 }
 
@@ -993,6 +1206,12 @@ type GerritOrgRepoConfig struct {
 	// the help message with comments like `/test ?`, `/retest ?`, `/test
 	// job-not-exist`, `/test job-only-available-from-another-prow`.
 	OptOutHelp bool `json:"opt_out_help,omitempty"`
+	// TriggerOnWorkInProgress makes Prow run presubmits automatically on work
+	// in progress changes of the repos defined under here. By default they
+	// only run once a change is marked ready for review, like draft pull
+	// requests on GitHub. Jobs can always be triggered explicitly with
+	// `/test`.
+	TriggerOnWorkInProgress bool `json:"trigger_on_work_in_progress,omitempty"`
 	// Filters are used for limiting the scope of querying the Gerrit server.
 	// Currently supports branches and excluded branches.
 	Filters *GerritQueryFilter `json:"filters,omitempty"`
@@ -1036,6 +1255,25 @@ func (goc *GerritOrgRepoConfigs) OptOutHelpRepos() map[string]sets.Set[string] {
 	return res
 }
 
+// TriggerOnWorkInProgress tells whether presubmits run automatically on work
+// in progress changes of the repo.
+func (goc *GerritOrgRepoConfigs) TriggerOnWorkInProgress(org, repo string) bool {
+	if goc == nil {
+		return false
+	}
+	for _, orgConfig := range *goc {
+		if orgConfig.Org != org || !orgConfig.TriggerOnWorkInProgress {
+			continue
+		}
+		for _, r := range orgConfig.Repos {
+			if r == repo {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Horologium is config for the Horologium.
 type Horologium struct {
 	// TickInterval is the interval in which we check if new jobs need to be
@@ -1073,6 +1311,12 @@ type GitHubReporter struct {
 	// comments is only sent when all jobs from current SHA are finished. Status
 	// contexts will still be written.
 	SummaryCommentRepos []string `json:"summary_comment_repos,omitempty"`
+	// StickyCommentRepos is a list of orgs and org/repos for which a single
+	// comment summarizing the results of all jobs on the latest commit of a PR
+	// is maintained and updated as jobs finish, instead of failure report
+	// comments. Status contexts will still be written. NoCommentRepos takes
+	// precedence.
+	StickyCommentRepos []string `json:"sticky_comment_repos,omitempty"`
 }
 
 // Sinker is config for the sinker controller.
@@ -1385,6 +1629,14 @@ type ExternalAgentLog struct {
 	// will be passed a prowapi.ProwJob and the generated URL should provide
 	// logs for the ProwJob.
 	URLTemplate *template.Template `json:"-"`
+	// ArtifactsURLTemplateString compiles into ArtifactsURLTemplate at load time.
+	ArtifactsURLTemplateString string `json:"artifacts_url_template,omitempty"`
+	// ArtifactsURLTemplate is compiled at load time from ArtifactsURLTemplateString.
+	// It will be passed a prowapi.ProwJob and the generated URL should point at
+	// the artifacts the agent keeps for the ProwJob, e.g. the Jenkins build as
+	// served by jenkins-operator. Spyglass shows these artifacts alongside the
+	// uploaded ones, if it knows how to read them for the agent.
+	ArtifactsURLTemplate *template.Template `json:"-"`
 }
 
 // Branding holds branding configuration for deck.
@@ -1528,11 +1780,84 @@ type PubSubTriggers []PubSubTrigger
 
 // PubSubTrigger contain pubsub configuration for a single project.
 type PubSubTrigger struct {
-	Project         string   `json:"project"`
+	// Project is the GCP project of the Pub/Sub subscriptions. It must be
+	// empty if SQS or NATS is set.
+	Project string `json:"project"`
+	// Topics are the Pub/Sub subscription IDs to listen to, or the queue URLs
+	// if SQS is set, or the subjects if NATS is set.
 	Topics          []string `json:"topics"`
 	AllowedClusters []string `json:"allowed_clusters"`
 	// MaxOutstandingMessages is the max number of messaged being processed, default is 10.
 	MaxOutstandingMessages int `json:"max_outstanding_messages"`
+	// SQS listens to AWS SQS queues instead of GCP Pub/Sub. SNS topics are
+	// supported by subscribing an SQS queue to them.
+	SQS *SQSTrigger `json:"sqs,omitempty"`
+	// NATS listens to NATS subjects instead of GCP Pub/Sub.
+	NATS *NATSTrigger `json:"nats,omitempty"`
+	// AllowUnrestrictedOverrides lets messages override any envs and labels
+	// of jobs without trigger_overrides. By default, such messages are
+	// rejected.
+	AllowUnrestrictedOverrides bool `json:"allow_unrestricted_overrides,omitempty"`
+}
+
+// SQSTrigger configures the AWS SQS source of a PubSubTrigger. Credentials
+// are taken from the default AWS credential chain unless configured here.
+type SQSTrigger struct {
+	// Region is the AWS region of the queues.
+	Region string `json:"region"`
+	// Endpoint overrides the SQS endpoint, e.g. for SQS compatible services.
+	Endpoint string `json:"endpoint,omitempty"`
+	// CredentialsFile is the path to an AWS shared credentials file.
+	CredentialsFile string `json:"credentials_file,omitempty"`
+	// Profile is the profile to use from the credentials file.
+	Profile string `json:"profile,omitempty"`
+	// RoleARN is a role that is assumed to access the queues.
+	RoleARN string `json:"role_arn,omitempty"`
+}
+
+// NATSTrigger configures the NATS source of a PubSubTrigger.
+type NATSTrigger struct {
+	// URL is the comma separated list of NATS servers, e.g. nats://nats:4222.
+	URL string `json:"url"`
+	// QueueGroup is the queue group the subscriptions join, so that every
+	// message is only handled by one replica of sub. Defaults to prow-sub.
+	QueueGroup string `json:"queue_group,omitempty"`
+	// CredentialsFile is the path to a NATS user credentials (JWT and NKey)
+	// file.
+	CredentialsFile string `json:"credentials_file,omitempty"`
+	// TokenFile is the path to a file holding a NATS authentication token.
+	TokenFile string `json:"token_file,omitempty"`
+}
+
+const defaultNATSQueueGroup = "prow-sub"
+
+func (t *PubSubTrigger) validate() error {
+	switch {
+	case t.SQS != nil && t.NATS != nil:
+		return errors.New("only one of sqs and nats can be set")
+	case t.SQS != nil:
+		if t.Project != "" {
+			return errors.New("project must not be set for sqs")
+		}
+		if t.SQS.Region == "" {
+			return errors.New("sqs.region must be set")
+		}
+	case t.NATS != nil:
+		if t.Project != "" {
+			return errors.New("project must not be set for nats")
+		}
+		if t.NATS.URL == "" {
+			return errors.New("nats.url must be set")
+		}
+		if t.NATS.CredentialsFile != "" && t.NATS.TokenFile != "" {
+			return errors.New("only one of nats.credentials_file and nats.token_file can be set")
+		}
+	default:
+		if t.Project == "" {
+			return errors.New("project must be set")
+		}
+	}
+	return nil
 }
 
 // GitHubOptions allows users to control how prow applications display GitHub website links.
@@ -1561,6 +1886,15 @@ type ManagedWebhooks struct {
 	// will be left pending.
 	AutoAcceptInvitation bool                          `json:"auto_accept_invitation"`
 	OrgRepoConfig        map[string]ManagedWebhookInfo `json:"org_repo_config,omitempty"`
+	// RotationInterval is the maximum age of the tokens of the orgs and
+	// repos. Older tokens are rotated by the hmac controller as if their
+	// token_created_after was bumped. Unset disables scheduled rotations.
+	RotationInterval *metav1.Duration `json:"rotation_interval,omitempty"`
+	// GracePeriod is how long hook keeps accepting a token after it was
+	// replaced, so that webhooks that were already sent or are redelivered
+	// with the old token still validate. Unset removes replaced tokens as
+	// soon as the webhooks use the new token.
+	GracePeriod *metav1.Duration `json:"grace_period,omitempty"`
 }
 
 // SlackReporter represents the config for the Slack reporter. The channel can be overridden
@@ -1656,6 +1990,15 @@ func loadWithYamlOpts(yamlOpts []yaml.JSONOpt, prowConfig, jobConfig string, sup
 	if err != nil {
 		return nil, err
 	}
+	if err := c.resolveIncludes(yamlOpts...); err != nil {
+		return nil, err
+	}
+	if err := c.expandJobTemplates(); err != nil {
+		return nil, err
+	}
+	if err := c.expandMatrices(); err != nil {
+		return nil, err
+	}
 	if err := c.finalizeJobConfig(); err != nil {
 		return nil, err
 	}
@@ -1717,6 +2060,13 @@ func ReadJobConfig(jobConfig string, yamlOpts ...yaml.JSONOpt) (JobConfig, error
 			}
 			return nil
 		}
+		if info.IsDir() {
+			// Every directory may hold a ConfigMap sharded by config-updater.
+			if err := VerifyShards(path); err != nil {
+				errs = append(errs, fmt.Errorf("incomplete sharded job config in %s: %w", path, err))
+			}
+			return nil
+		}
 		if filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml" {
 			return nil
 		}
@@ -1870,11 +2220,14 @@ func loadConfig(prowConfig, jobConfig string, additionalProwConfigDirs []string,
 		if nc.PubSubTriggers != nil {
 			return nil, errors.New("pubsub_subscriptions and pubsub_triggers are mutually exclusive")
 		}
+		// The deprecated pubsub_subscriptions keep accepting any overrides,
+		// pubsub_triggers are needed to restrict them.
 		for proj, topics := range nc.PubSubSubscriptions {
 			nc.PubSubTriggers = append(nc.PubSubTriggers, PubSubTrigger{
-				Project:         proj,
-				Topics:          topics,
-				AllowedClusters: []string{"*"},
+				Project:                    proj,
+				Topics:                     topics,
+				AllowedClusters:            []string{"*"},
+				AllowUnrestrictedOverrides: true,
 			})
 		}
 	}
@@ -1882,6 +2235,9 @@ func loadConfig(prowConfig, jobConfig string, additionalProwConfigDirs []string,
 		if trigger.MaxOutstandingMessages == 0 {
 			nc.PubSubTriggers[i].MaxOutstandingMessages = defaultMaxOutstandingMessages
 		}
+		if trigger.NATS != nil && trigger.NATS.QueueGroup == "" {
+			nc.PubSubTriggers[i].NATS.QueueGroup = defaultNATSQueueGroup
+		}
 	}
 
 	// TODO(krzyzacy): temporary allow empty jobconfig
@@ -1920,7 +2276,12 @@ func yamlToConfig(path string, nc interface{}, opts ...yaml.JSONOpt) error {
 		// No job config, skip inserting filepaths into the jobs.
 		return nil
 	}
+	setSourcePath(jc, path)
+	return nil
+}
 
+// setSourcePath records the file the jobs were loaded from.
+func setSourcePath(jc *JobConfig, path string) {
 	for rep := range jc.PresubmitsStatic {
 		fix := func(job *Presubmit) {
 			job.SourcePath = path
@@ -1944,7 +2305,6 @@ func yamlToConfig(path string, nc interface{}, opts ...yaml.JSONOpt) error {
 	for i := range jc.Periodics {
 		fix(&jc.Periodics[i])
 	}
-	return nil
 }
 
 // ReadFileMaybeGZIP wraps os.ReadFile, returning the decompressed contents
@@ -1973,6 +2333,9 @@ func (c *Config) mergeJobConfig(jc JobConfig) error {
 		PresubmitsStatic:  c.PresubmitsStatic,
 		Periodics:         c.Periodics,
 		PostsubmitsStatic: c.PostsubmitsStatic,
+		JobTemplates:      c.JobTemplates,
+		TemplatedJobs:     c.TemplatedJobs,
+		Includes:          c.Includes,
 	}, jc)
 	if err != nil {
 		return err
@@ -1981,6 +2344,9 @@ func (c *Config) mergeJobConfig(jc JobConfig) error {
 	c.PresubmitsStatic = m.PresubmitsStatic
 	c.Periodics = m.Periodics
 	c.PostsubmitsStatic = m.PostsubmitsStatic
+	c.JobTemplates = m.JobTemplates
+	c.TemplatedJobs = m.TemplatedJobs
+	c.Includes = m.Includes
 	return nil
 }
 
@@ -1990,6 +2356,7 @@ func (c *Config) mergeJobConfig(jc JobConfig) error {
 //   - Postsubmits
 //   - Periodics
 //   - Presets
+//   - JobTemplates and TemplatedJobs
 func mergeJobConfigs(a, b JobConfig) (JobConfig, error) {
 	// Merge everything.
 	// *** Presets ***
@@ -2011,6 +2378,13 @@ func mergeJobConfigs(a, b JobConfig) (JobConfig, error) {
 	// *** Periodics ***
 	c.Periodics = append(a.Periodics, b.Periodics...)
 
+	// *** Job templates ***
+	c.JobTemplates = append(a.JobTemplates, b.JobTemplates...)
+	c.TemplatedJobs = append(a.TemplatedJobs, b.TemplatedJobs...)
+
+	// *** Includes ***
+	c.Includes = append(a.Includes, b.Includes...)
+
 	// *** Presubmits ***
 	c.PresubmitsStatic = make(map[string][]Presubmit)
 	for repo, jobs := range a.PresubmitsStatic {
@@ -2071,12 +2445,7 @@ func setPostsubmitDecorationDefaults(c *Config, ps *Postsubmit, repo string) {
 
 func setPeriodicDecorationDefaults(c *Config, ps *Periodic) {
 	if shouldDecorate(&c.JobConfig, &ps.JobBase.UtilityConfig) {
-		var repo string
-		if len(ps.UtilityConfig.ExtraRefs) > 0 {
-			repo = fmt.Sprintf("%s/%s", ps.UtilityConfig.ExtraRefs[0].Org, ps.UtilityConfig.ExtraRefs[0].Repo)
-		}
-
-		ps.DecorationConfig = c.Plank.mergeDefaultDecorationConfig(repo, ps.Cluster, ps.DecorationConfig)
+		ps.DecorationConfig = c.Plank.mergeDefaultDecorationConfig(periodicRepo(ps), ps.Cluster, ps.DecorationConfig)
 	}
 }
 
@@ -2090,6 +2459,12 @@ func defaultPresubmits(presubmits []Presubmit, additionalPresets []Preset, c *Co
 		if err := resolvePresets(ps.Name, ps.Labels, ps.Spec, append(c.Presets, additionalPresets...)); err != nil {
 			errs = append(errs, err)
 		}
+		if err := mergePodTemplate(&presubmits[idx].JobBase, c.Plank.PodTemplates); err != nil {
+			errs = append(errs, err)
+		}
+		if err := mergeSecretBundles(&presubmits[idx].JobBase, repo, c.Plank.SecretBundles); err != nil {
+			errs = append(errs, err)
+		}
 	}
 	if err := SetPresubmitRegexes(presubmits); err != nil {
 		errs = append(errs, fmt.Errorf("could not set regex: %w", err))
@@ -2108,6 +2483,12 @@ func defaultPostsubmits(postsubmits []Postsubmit, additionalPresets []Preset, c
 		if err := resolvePresets(ps.Name, ps.Labels, ps.Spec, append(c.Presets, additionalPresets...)); err != nil {
 			errs = append(errs, err)
 		}
+		if err := mergePodTemplate(&postsubmits[idx].JobBase, c.Plank.PodTemplates); err != nil {
+			errs = append(errs, err)
+		}
+		if err := mergeSecretBundles(&postsubmits[idx].JobBase, repo, c.Plank.SecretBundles); err != nil {
+			errs = append(errs, err)
+		}
 	}
 	if err := SetPostsubmitRegexes(postsubmits); err != nil {
 		errs = append(errs, fmt.Errorf("could not set regex: %w", err))
@@ -2117,10 +2498,30 @@ func defaultPostsubmits(postsubmits []Postsubmit, additionalPresets []Preset, c
 
 // DefaultPeriodic defaults (mutates) a single Periodic.
 func (c *Config) DefaultPeriodic(periodic *Periodic) error {
+	return c.defaultPeriodic(periodic, nil)
+}
+
+// defaultPeriodic defaults the periodic with the additional presets on top of
+// the ones of the config.
+func (c *Config) defaultPeriodic(periodic *Periodic, additionalPresets []Preset) error {
 	c.defaultPeriodicFields(periodic)
 	setPeriodicDecorationDefaults(c, periodic)
 	setPeriodicProwJobDefaults(c, periodic)
-	return resolvePresets(periodic.Name, periodic.Labels, periodic.Spec, c.Presets)
+	if err := resolvePresets(periodic.Name, periodic.Labels, periodic.Spec, append(c.Presets, additionalPresets...)); err != nil {
+		return err
+	}
+	if err := mergePodTemplate(&periodic.JobBase, c.Plank.PodTemplates); err != nil {
+		return err
+	}
+	return mergeSecretBundles(&periodic.JobBase, periodicRepo(periodic), c.Plank.SecretBundles)
+}
+
+// periodicRepo returns the org/repo of the first extra ref of the periodic.
+func periodicRepo(periodic *Periodic) string {
+	if len(periodic.ExtraRefs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", periodic.ExtraRefs[0].Org, periodic.ExtraRefs[0].Repo)
 }
 
 // defaultPeriodics defaults c.Periodics.
@@ -2159,6 +2560,106 @@ func (c *Config) finalizeJobConfig() error {
 	return nil
 }
 
+// validatePodTemplates validates the settings of the pod templates that
+// the API server would otherwise reject when plank creates a pod.
+func validatePodTemplates(templates map[string]map[string]PodTemplate) error {
+	var errs []error
+	for cluster, named := range templates {
+		for name, template := range named {
+			switch template.DNSPolicy {
+			case "", v1.DNSClusterFirst, v1.DNSClusterFirstWithHostNet, v1.DNSDefault, v1.DNSNone:
+			default:
+				errs = append(errs, fmt.Errorf("plank.pod_templates[%s][%s]: invalid dns_policy %q", cluster, name, template.DNSPolicy))
+			}
+			for _, toleration := range template.Tolerations {
+				switch toleration.Operator {
+				case "", v1.TolerationOpEqual:
+				case v1.TolerationOpExists:
+					if toleration.Value != "" {
+						errs = append(errs, fmt.Errorf("plank.pod_templates[%s][%s]: toleration of %q may not have a value with operator Exists", cluster, name, toleration.Key))
+					}
+				default:
+					errs = append(errs, fmt.Errorf("plank.pod_templates[%s][%s]: toleration of %q has invalid operator %q", cluster, name, toleration.Key, toleration.Operator))
+				}
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateWarmPools validates that every warm pool is for a distinct job
+// that plank runs.
+func (c *Config) validateWarmPools() error {
+	var errs []error
+	seen := sets.New[string]()
+	for i, pool := range c.Plank.WarmPools {
+		if pool.Job == "" {
+			errs = append(errs, fmt.Errorf("plank.warm_pools[%d]: job must be set", i))
+			continue
+		}
+		if seen.Has(pool.Job) {
+			errs = append(errs, fmt.Errorf("plank.warm_pools[%d]: job %s has more than one warm pool", i, pool.Job))
+		}
+		seen.Insert(pool.Job)
+		if pool.Size < 1 {
+			errs = append(errs, fmt.Errorf("plank.warm_pools[%d]: size must be positive, got %d", i, pool.Size))
+		}
+		jobs := c.StaticJobBases(pool.Job)
+		switch {
+		case len(jobs) == 0:
+			errs = append(errs, fmt.Errorf("plank.warm_pools[%d]: job %s is not a static job", i, pool.Job))
+		case len(jobs) > 1:
+			errs = append(errs, fmt.Errorf("plank.warm_pools[%d]: there are %d static jobs named %s", i, len(jobs), pool.Job))
+		case jobs[0].Agent != string(prowapi.KubernetesAgent) || jobs[0].Spec == nil:
+			errs = append(errs, fmt.Errorf("plank.warm_pools[%d]: job %s does not run on the kubernetes agent", i, pool.Job))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateImagePrePull validates the settings of the image pre-pull
+// DaemonSets.
+func validateImagePrePull(p *ImagePrePull) error {
+	if p == nil {
+		return nil
+	}
+	var errs []error
+	if len(p.Clusters) == 0 {
+		errs = append(errs, errors.New("plank.image_prepull: clusters must be set"))
+	}
+	if p.MinJobs < 0 {
+		errs = append(errs, fmt.Errorf("plank.image_prepull: min_jobs must not be negative, got %d", p.MinJobs))
+	}
+	for _, toleration := range p.Tolerations {
+		switch toleration.Operator {
+		case "", v1.TolerationOpEqual, v1.TolerationOpExists:
+		default:
+			errs = append(errs, fmt.Errorf("plank.image_prepull: toleration of %q has invalid operator %q", toleration.Key, toleration.Operator))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateSecretBundles validates that the secret bundles can be mounted.
+func validateSecretBundles(bundles map[string]SecretBundle) error {
+	var errs []error
+	for name, bundle := range bundles {
+		if msgs := validation.IsDNS1123Label("secret-bundle-" + name); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("plank.secret_bundles[%s]: name is not valid: %s", name, strings.Join(msgs, ", ")))
+		}
+		if bundle.Secret == "" {
+			errs = append(errs, fmt.Errorf("plank.secret_bundles[%s]: secret must be set", name))
+		}
+		if bundle.MountPath != "" && !path.IsAbs(bundle.MountPath) {
+			errs = append(errs, fmt.Errorf("plank.secret_bundles[%s]: mount_path %q is not absolute", name, bundle.MountPath))
+		}
+		if len(bundle.AllowedRepos) == 0 && len(bundle.AllowedJobs) == 0 {
+			errs = append(errs, fmt.Errorf("plank.secret_bundles[%s]: no repos or jobs are allowed to use it", name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 // validateComponentConfig validates the various infrastructure components' configurations.
 func (c *Config) validateComponentConfig() error {
 	for k, v := range c.Plank.JobURLPrefixConfig {
@@ -2166,6 +2667,18 @@ func (c *Config) validateComponentConfig() error {
 			return fmt.Errorf(`invalid value for Planks job_url_prefix_config["%s"]: %v`, k, err)
 		}
 	}
+	if err := validatePodTemplates(c.Plank.PodTemplates); err != nil {
+		return err
+	}
+	if err := c.validateWarmPools(); err != nil {
+		return err
+	}
+	if err := validateImagePrePull(c.Plank.ImagePrePull); err != nil {
+		return err
+	}
+	if err := validateSecretBundles(c.Plank.SecretBundles); err != nil {
+		return err
+	}
 	if c.Gerrit.DeckURL != "" {
 		if _, err := url.Parse(c.Gerrit.DeckURL); err != nil {
 			return fmt.Errorf("invalid value for gerrit.deck_url: %v", err)
@@ -2179,6 +2692,12 @@ func (c *Config) validateComponentConfig() error {
 				validationErrs = append(validationErrs, fmt.Errorf("token_created_after %s can be no later than current time for repo/org %s", repoValue.TokenCreatedAfter, repoName))
 			}
 		}
+		if c.ManagedWebhooks.RotationInterval != nil && c.ManagedWebhooks.RotationInterval.Duration <= 0 {
+			validationErrs = append(validationErrs, fmt.Errorf("managed_webhooks.rotation_interval must be positive, got %s", c.ManagedWebhooks.RotationInterval.Duration))
+		}
+		if c.ManagedWebhooks.GracePeriod != nil && c.ManagedWebhooks.GracePeriod.Duration < 0 {
+			validationErrs = append(validationErrs, fmt.Errorf("managed_webhooks.grace_period must not be negative, got %s", c.ManagedWebhooks.GracePeriod.Duration))
+		}
 		if len(validationErrs) > 0 {
 			return utilerrors.NewAggregate(validationErrs)
 		}
@@ -2209,6 +2728,19 @@ func (c *Config) validateComponentConfig() error {
 		return err
 	}
 
+	for i := range c.PubSubTriggers {
+		if err := c.PubSubTriggers[i].validate(); err != nil {
+			return fmt.Errorf("pubsub_triggers[%d]: %w", i, err)
+		}
+	}
+	if err := c.OverrideRestrictions.validate(); err != nil {
+		return err
+	}
+
+	if err := c.StatusReconciler.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -2264,6 +2796,12 @@ func (c Config) validateJobBase(v JobBase, jobType prowapi.ProwJobType) error {
 	if err := validateJobQueueName(v.JobQueueName, validJobQueueNames); err != nil {
 		return err
 	}
+	if err := v.TriggerOverrides.validate(); err != nil {
+		return err
+	}
+	if err := v.ArtifactRetention.Validate(); err != nil {
+		return fmt.Errorf("artifact_retention: %w", err)
+	}
 	if v.Spec == nil || len(v.Spec.Containers) == 0 {
 		return nil // jenkins jobs have no spec.
 	}
@@ -2371,6 +2909,9 @@ func (c Config) validatePostsubmits(postsubmits []Postsubmit) error {
 		if err := validateAlwaysRun(ps); err != nil {
 			errs = append(errs, err)
 		}
+		if err := validateGerritChangeMatcher(ps.Name, ps.GerritChangeMatcher); err != nil {
+			errs = append(errs, err)
+		}
 		if err := validateReporting(ps.JobBase, ps.Reporter); err != nil {
 			errs = append(errs, fmt.Errorf("invalid postsubmit job %s: %w", ps.Name, err))
 		}
@@ -2410,12 +2951,15 @@ func (c Config) validatePeriodics(periodics []Periodic) error {
 		if p.MinimumInterval != "" {
 			seen += 1
 		}
+		if p.RunAt != nil {
+			seen += 1
+		}
 		if seen > 1 {
-			errs = append(errs, fmt.Errorf("cron, interval, and minimum_interval are mutually exclusive in periodic %s", p.Name))
+			errs = append(errs, fmt.Errorf("cron, interval, minimum_interval, and run_at are mutually exclusive in periodic %s", p.Name))
 			continue
 		}
 		if seen == 0 {
-			errs = append(errs, fmt.Errorf("at least one of cron, interval, or minimum_interval must be set in periodic %s", p.Name))
+			errs = append(errs, fmt.Errorf("at least one of cron, interval, minimum_interval, or run_at must be set in periodic %s", p.Name))
 			continue
 		}
 
@@ -2473,6 +3017,10 @@ func (c *Config) ValidateJobConfig() error {
 		errs = append(errs, err)
 	}
 
+	if err := c.validateTenantRestrictions(); err != nil {
+		errs = append(errs, err)
+	}
+
 	c.Deck.AllKnownStorageBuckets = calculateStorageBuckets(c)
 
 	return utilerrors.NewAggregate(errs)
@@ -2553,6 +3101,13 @@ func parseProwConfig(c *Config) error {
 			return fmt.Errorf("parsing template for agent %q: %w", agentToTmpl.Agent, err)
 		}
 		c.Deck.ExternalAgentLogs[i].URLTemplate = urlTemplate
+		if agentToTmpl.ArtifactsURLTemplateString != "" {
+			artifactsURLTemplate, err := template.New(agentToTmpl.Agent).Parse(agentToTmpl.ArtifactsURLTemplateString)
+			if err != nil {
+				return fmt.Errorf("parsing artifacts template for agent %q: %w", agentToTmpl.Agent, err)
+			}
+			c.Deck.ExternalAgentLogs[i].ArtifactsURLTemplate = artifactsURLTemplate
+		}
 		// we need to validate selectors used by deck since these are not
 		// sent to the api server.
 		s, err := labels.Parse(c.Deck.ExternalAgentLogs[i].SelectorString)
@@ -2791,12 +3346,36 @@ func parseProwConfig(c *Config) error {
 		return fmt.Errorf("Forbidden to set both Policy.Include and Policy.Exclude, Please use either Include or Exclude!")
 	}
 
+	if err := c.BranchProtection.validateRulesets(); err != nil {
+		return fmt.Errorf("validating branch-protection rulesets: %w", err)
+	}
+
 	// Avoid using a Moonraker client timeout of infinity (default behavior of
 	// https://pkg.go.dev/net/http#Client) by setting a default value.
 	if c.Moonraker.ClientTimeout == nil {
 		c.Moonraker.ClientTimeout = &metav1.Duration{Duration: DefaultMoonrakerClientTimeout}
 	}
 
+	if err := validateJobPolicies(c.JobPolicies); err != nil {
+		return fmt.Errorf("validating job policies: %w", err)
+	}
+
+	if err := validateTenants(c.Tenants); err != nil {
+		return fmt.Errorf("validating tenants: %w", err)
+	}
+
+	if err := c.Canary.defaultAndValidate(); err != nil {
+		return fmt.Errorf("validating canary: %w", err)
+	}
+
+	if err := c.ArtifactRetention.defaultAndValidate(); err != nil {
+		return fmt.Errorf("validating artifact_retention: %w", err)
+	}
+
+	if err := validateInRepoPeriodics(c.InRepoConfig.Periodics); err != nil {
+		return fmt.Errorf("validating in_repo_config.periodics: %w", err)
+	}
+
 	return nil
 }
 
@@ -3093,6 +3672,20 @@ func validateTriggering(job Presubmit) error {
 		return fmt.Errorf("either both of job.Trigger and job.RerunCommand must be set, wasnt the case for job %q", job.Name)
 	}
 
+	return validateGerritChangeMatcher(job.Name, job.GerritChangeMatcher)
+}
+
+func validateGerritChangeMatcher(name string, cm GerritChangeMatcher) error {
+	for _, hashtag := range cm.GerritHashtags {
+		if strings.TrimSpace(hashtag) == "" {
+			return fmt.Errorf("job %s declares an empty gerrit_hashtags entry", name)
+		}
+	}
+	for _, topic := range cm.GerritTopics {
+		if strings.TrimSpace(topic) == "" {
+			return fmt.Errorf("job %s declares an empty gerrit_topics entry", name)
+		}
+	}
 	return nil
 }
 
@@ -3431,7 +4024,7 @@ func (pc *ProwConfig) HasConfigFor() (global bool, orgs sets.Set[string], repos
 	repos = sets.Set[string]{}
 
 	for org, orgConfig := range pc.BranchProtection.Orgs {
-		if isPolicySet(orgConfig.Policy) {
+		if isPolicySet(orgConfig.Policy) || len(orgConfig.Rulesets) > 0 {
 			orgs.Insert(org)
 		}
 		for repo := range orgConfig.Repos {
@@ -1217,6 +1217,46 @@ gerrit:
 				RateLimit:                          10,
 			},
 		},
+		{
+			name:        "report-labels",
+			expectError: false,
+			rawConfig: `
+gerrit:
+  report_labels:
+  - label: Verified
+    pass_vote: "+2"
+    fail_threshold: 2
+  - label: Code-Style
+`,
+			expected: Gerrit{
+				TickInterval: &metav1.Duration{Duration: time.Minute},
+				RateLimit:    5,
+				ReportLabels: []GerritReportLabel{
+					{Label: "Verified", PassVote: "+2", FailVote: "-1", FailThreshold: 2},
+					{Label: "Code-Style", PassVote: "+1", FailVote: "-1", FailThreshold: 1},
+				},
+			},
+		},
+		{
+			name:        "report-label-with-invalid-vote",
+			expectError: true,
+			rawConfig: `
+gerrit:
+  report_labels:
+  - label: Verified
+    fail_vote: nope
+`,
+		},
+		{
+			name:        "duplicate-report-label",
+			expectError: true,
+			rawConfig: `
+gerrit:
+  report_labels:
+  - label: Verified
+  - label: Verified
+`,
+		},
 		{
 			name:        "simple-org-repo",
 			expectError: false,
@@ -1284,6 +1324,9 @@ gerrit:
 			} else if !tc.expectError && err != nil {
 				t.Fatalf("tc %s: Expect no error, but got error %v", tc.name, err)
 			}
+			if tc.expectError {
+				return
+			}
 
 			if d := cmp.Diff(tc.expected, cfg.Gerrit, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(Gerrit{}, "AllowedPresubmitTriggerRe")); d != "" {
 				t.Errorf("got d: %s", d)
@@ -2095,6 +2138,27 @@ func TestValidateJobBase(t *testing.T) {
 				Namespace:      &cfg.PodNamespace,
 			},
 		},
+		{
+			name: "valid artifact retention",
+			base: JobBase{
+				Name:              "name",
+				Agent:             ka,
+				Spec:              &goodSpec,
+				Namespace:         &cfg.PodNamespace,
+				ArtifactRetention: &prowapi.JobArtifactRetention{Duration: &metav1.Duration{Duration: time.Hour}, KeepLast: 3},
+			},
+			pass: true,
+		},
+		{
+			name: "invalid artifact retention",
+			base: JobBase{
+				Name:              "name",
+				Agent:             ka,
+				Spec:              &goodSpec,
+				Namespace:         &cfg.PodNamespace,
+				ArtifactRetention: &prowapi.JobArtifactRetention{KeepLast: -1},
+			},
+		},
 		{
 			name: "invalid pod spec",
 			base: JobBase{
@@ -2510,6 +2574,60 @@ func TestGerritOptOutHelpRepos(t *testing.T) {
 	}
 }
 
+func TestGerritTriggerOnWorkInProgress(t *testing.T) {
+	configs := &GerritOrgRepoConfigs{
+		{
+			Org:   "org-1",
+			Repos: []string{"repo-1"},
+		},
+		{
+			Org:                     "org-1",
+			Repos:                   []string{"repo-2"},
+			TriggerOnWorkInProgress: true,
+		},
+	}
+	tests := []struct {
+		name string
+		in   *GerritOrgRepoConfigs
+		org  string
+		repo string
+		want bool
+	}{
+		{
+			name: "opted-in",
+			in:   configs,
+			org:  "org-1",
+			repo: "repo-2",
+			want: true,
+		},
+		{
+			name: "not-opted-in",
+			in:   configs,
+			org:  "org-1",
+			repo: "repo-1",
+		},
+		{
+			name: "other-org",
+			in:   configs,
+			org:  "org-2",
+			repo: "repo-2",
+		},
+		{
+			name: "nil",
+			org:  "org-1",
+			repo: "repo-2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.TriggerOnWorkInProgress(tc.org, tc.repo); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
 // integration test for fake config loading
 func TestValidConfigLoading(t *testing.T) {
 	ptrOrBool := func(p *bool) string {
@@ -3179,10 +3297,36 @@ pubsub_subscriptions:
 			verify: func(c *Config) error {
 				if diff := cmp.Diff(c.PubSubTriggers, PubSubTriggers([]PubSubTrigger{
 					{
-						Project:                "projA",
-						Topics:                 []string{"topicB", "topicC"},
+						Project:                    "projA",
+						Topics:                     []string{"topicB", "topicC"},
+						AllowedClusters:            []string{"*"},
+						MaxOutstandingMessages:     10,
+						AllowUnrestrictedOverrides: true,
+					},
+				})); diff != "" {
+					return fmt.Errorf("want(-), got(+): \n%s", diff)
+				}
+				return nil
+			},
+		},
+		{
+			name: "NATS PubSubTriggers get the default queue group",
+			prowConfig: `
+pubsub_triggers:
+- topics:
+  - prow.jobs
+  allowed_clusters:
+  - "*"
+  nats:
+    url: nats://nats:4222
+`,
+			verify: func(c *Config) error {
+				if diff := cmp.Diff(c.PubSubTriggers, PubSubTriggers([]PubSubTrigger{
+					{
+						Topics:                 []string{"prow.jobs"},
 						AllowedClusters:        []string{"*"},
 						MaxOutstandingMessages: 10,
+						NATS:                   &NATSTrigger{URL: "nats://nats:4222", QueueGroup: "prow-sub"},
 					},
 				})); diff != "" {
 					return fmt.Errorf("want(-), got(+): \n%s", diff)
@@ -3190,6 +3334,18 @@ pubsub_subscriptions:
 				return nil
 			},
 		},
+		{
+			name: "SQS PubSubTriggers must not set a project",
+			prowConfig: `
+pubsub_triggers:
+- project: projA
+  topics:
+  - https://sqs.us-east-1.amazonaws.com/123456789012/prow
+  sqs:
+    region: us-east-1
+`,
+			expectError: true,
+		},
 		{
 			name:               "Version file sets the version",
 			versionFileContent: "some-git-sha",
@@ -4722,6 +4878,151 @@ func TestValidateComponentConfig(t *testing.T) {
 			}}},
 			errExpected: false,
 		},
+		{
+			name: "Valid pod templates, no err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				PodTemplates: map[string]map[string]PodTemplate{
+					"build01": {"gpu": {
+						DNSPolicy:   v1.DNSClusterFirst,
+						Tolerations: []v1.Toleration{{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists}},
+					}},
+				},
+			}}},
+			errExpected: false,
+		},
+		{
+			name: "Pod template with invalid DNS policy, err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				PodTemplates: map[string]map[string]PodTemplate{
+					"build01": {"gpu": {DNSPolicy: "ClusterLast"}},
+				},
+			}}},
+			errExpected: true,
+		},
+		{
+			name: "Pod template with invalid toleration, err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				PodTemplates: map[string]map[string]PodTemplate{
+					"build01": {"gpu": {Tolerations: []v1.Toleration{{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists, Value: "true"}}}},
+				},
+			}}},
+			errExpected: true,
+		},
+		{
+			name: "Valid warm pool, no err",
+			config: &Config{
+				JobConfig: JobConfig{Periodics: []Periodic{{JobBase: JobBase{Name: "lint", Agent: "kubernetes", Spec: &v1.PodSpec{}}}}},
+				ProwConfig: ProwConfig{Plank: Plank{
+					WarmPools: []WarmPool{{Job: "lint", Size: 2}},
+				}},
+			},
+			errExpected: false,
+		},
+		{
+			name: "Warm pool of unknown job, err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				WarmPools: []WarmPool{{Job: "lint", Size: 2}},
+			}}},
+			errExpected: true,
+		},
+		{
+			name: "Warm pool of job that is not unique, err",
+			config: &Config{
+				JobConfig: JobConfig{
+					Periodics: []Periodic{{JobBase: JobBase{Name: "lint", Agent: "kubernetes", Spec: &v1.PodSpec{}}}},
+					PresubmitsStatic: map[string][]Presubmit{
+						"org/repo": {{JobBase: JobBase{Name: "lint", Agent: "kubernetes", Spec: &v1.PodSpec{}}}},
+					},
+				},
+				ProwConfig: ProwConfig{Plank: Plank{
+					WarmPools: []WarmPool{{Job: "lint", Size: 2}},
+				}},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Warm pool of job of another agent, err",
+			config: &Config{
+				JobConfig: JobConfig{Periodics: []Periodic{{JobBase: JobBase{Name: "lint", Agent: "jenkins"}}}},
+				ProwConfig: ProwConfig{Plank: Plank{
+					WarmPools: []WarmPool{{Job: "lint", Size: 2}},
+				}},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Empty warm pool, err",
+			config: &Config{
+				JobConfig: JobConfig{Periodics: []Periodic{{JobBase: JobBase{Name: "lint", Agent: "kubernetes", Spec: &v1.PodSpec{}}}}},
+				ProwConfig: ProwConfig{Plank: Plank{
+					WarmPools: []WarmPool{{Job: "lint"}},
+				}},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Two warm pools of a job, err",
+			config: &Config{
+				JobConfig: JobConfig{Periodics: []Periodic{{JobBase: JobBase{Name: "lint", Agent: "kubernetes", Spec: &v1.PodSpec{}}}}},
+				ProwConfig: ProwConfig{Plank: Plank{
+					WarmPools: []WarmPool{{Job: "lint", Size: 1}, {Job: "lint", Size: 2}},
+				}},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Valid image pre-pull, no err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				ImagePrePull: &ImagePrePull{Clusters: []string{"*"}, MinJobs: 3, Tolerations: []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}}},
+			}}},
+		},
+		{
+			name: "Image pre-pull without clusters, err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				ImagePrePull: &ImagePrePull{MinJobs: 3},
+			}}},
+			errExpected: true,
+		},
+		{
+			name: "Valid secret bundle, no err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				SecretBundles: map[string]SecretBundle{"gcp": {Secret: "gcp-service-account", MountPath: "/etc/gcp", AllowedRepos: []string{"kubernetes"}}},
+			}}},
+		},
+		{
+			name: "Secret bundle without allowlist, err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				SecretBundles: map[string]SecretBundle{"gcp": {Secret: "gcp-service-account"}},
+			}}},
+			errExpected: true,
+		},
+		{
+			name: "Secret bundle with invalid name and relative mount path, err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				SecretBundles: map[string]SecretBundle{"GCP_SA": {Secret: "gcp-service-account", MountPath: "etc/gcp", AllowedJobs: []string{"job"}}},
+			}}},
+			errExpected: true,
+		},
+		{
+			name: "Invalid override restrictions, err",
+			config: &Config{ProwConfig: ProwConfig{
+				OverrideRestrictions: "reject",
+			}},
+			errExpected: true,
+		},
+		{
+			name: "Enforced override restrictions",
+			config: &Config{ProwConfig: ProwConfig{
+				OverrideRestrictions: OverrideRestrictionsEnforce,
+			}},
+		},
+		{
+			name: "Image pre-pull with negative min_jobs, err",
+			config: &Config{ProwConfig: ProwConfig{Plank: Plank{
+				ImagePrePull: &ImagePrePull{Clusters: []string{"default"}, MinJobs: -1},
+			}}},
+			errExpected: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4964,6 +5265,24 @@ func TestValidateTriggering(t *testing.T) {
 			},
 			errExpected: false,
 		},
+		{
+			name: "Gerrit hashtags set, no err",
+			presubmit: Presubmit{
+				GerritChangeMatcher: GerritChangeMatcher{
+					GerritHashtags: []string{"perf"},
+				},
+			},
+			errExpected: false,
+		},
+		{
+			name: "Empty Gerrit topic, err",
+			presubmit: Presubmit{
+				GerritChangeMatcher: GerritChangeMatcher{
+					GerritTopics: []string{" "},
+				},
+			},
+			errExpected: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8182,14 +8501,21 @@ func TestValidatePeriodics(t *testing.T) {
 			periodics: []Periodic{
 				{JobBase: JobBase{Name: "a"}, Interval: "6h", MinimumInterval: "6h"},
 			},
-			expectedError: "cron, interval, and minimum_interval are mutually exclusive in periodic a",
+			expectedError: "cron, interval, minimum_interval, and run_at are mutually exclusive in periodic a",
+		},
+		{
+			name: "Mutually exclusive settings: cron and run_at",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "a"}, Cron: "@daily", RunAt: &metav1.Time{Time: time.Date(2024, 8, 1, 16, 0, 0, 0, time.UTC)}},
+			},
+			expectedError: "cron, interval, minimum_interval, and run_at are mutually exclusive in periodic a",
 		},
 		{
 			name: "Required settings: cron, interval, or minimal_interval",
 			periodics: []Periodic{
 				{JobBase: JobBase{Name: "a"}},
 			},
-			expectedError: "at least one of cron, interval, or minimum_interval must be set in periodic a",
+			expectedError: "at least one of cron, interval, minimum_interval, or run_at must be set in periodic a",
 		},
 		{
 			name: "Invalid cron string",
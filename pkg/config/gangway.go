@@ -41,6 +41,11 @@ type AllowedApiClient struct {
 	// AllowedJobsFilters contains information about what kinds of Prow jobs this
 	// API client is authorized to trigger.
 	AllowedJobsFilters []AllowedJobsFilter `json:"allowed_jobs_filters,omitempty"`
+
+	// AllowUnrestrictedOverrides lets this API client override any envs and
+	// labels of jobs without trigger_overrides. By default, such requests
+	// are rejected.
+	AllowUnrestrictedOverrides bool `json:"allow_unrestricted_overrides,omitempty"`
 }
 
 // ApiClientGcp encodes GCP Cloud Endpoints-specific HTTP metadata header
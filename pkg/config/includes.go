@@ -0,0 +1,401 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// JobConfigMediaType is the media type of the OCI artifact layer that
+	// holds the job configuration.
+	JobConfigMediaType = "application/vnd.prow.jobconfig.v1+yaml"
+
+	ociManifestMediaType    = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// maxIncludeSize limits the size of the fetched content.
+	maxIncludeSize = 10 << 20
+)
+
+// JobConfigInclude references job configuration hosted outside of the
+// config repository. The content is pinned by its digest, so it can be
+// cached indefinitely and a compromised host cannot change the jobs.
+type JobConfigInclude struct {
+	// URL is either an HTTPS URL of a job config file, e.g.
+	// https://example.com/jobs/go.yaml, or an OCI artifact reference without
+	// tag or digest, e.g. oci://gcr.io/my-project/prow-jobs. The OCI artifact
+	// must have a single layer, or a layer with the media type
+	// application/vnd.prow.jobconfig.v1+yaml, holding the job config.
+	URL string `json:"url"`
+	// Digest is the sha256 digest of the content in the form
+	// sha256:<hex>. For HTTPS URLs it is the digest of the file, for
+	// OCI artifacts the digest of the manifest.
+	Digest string `json:"digest"`
+}
+
+var (
+	// includeClient fetches included job configuration. It is a variable so
+	// that tests can replace it.
+	includeClient = &http.Client{Timeout: time.Minute}
+	// IncludeCacheDir is where fetched includes are stored across restarts,
+	// by their digest. The in-memory cache is used alone if it is empty.
+	IncludeCacheDir = filepath.Join(os.TempDir(), "prow-config-includes")
+
+	// includeCacheLock only guards includeCache, includes are fetched
+	// without holding it.
+	includeCacheLock sync.Mutex
+	includeCache     = map[string][]byte{}
+)
+
+// resolveIncludes fetches and merges all included job configuration.
+func (c *Config) resolveIncludes(yamlOpts ...yaml.JSONOpt) error {
+	var errs []error
+	for _, include := range c.Includes {
+		jc, err := loadInclude(include, yamlOpts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to include %s: %w", include.URL, err))
+			continue
+		}
+		if err := c.mergeJobConfig(jc); err != nil {
+			errs = append(errs, fmt.Errorf("failed to merge %s: %w", include.URL, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func loadInclude(include JobConfigInclude, yamlOpts ...yaml.JSONOpt) (JobConfig, error) {
+	raw, err := fetchInclude(include)
+	if err != nil {
+		return JobConfig{}, err
+	}
+	var jc JobConfig
+	if err := yaml.Unmarshal(raw, &jc, yamlOpts...); err != nil {
+		return JobConfig{}, fmt.Errorf("error unmarshaling: %w", err)
+	}
+	if len(jc.Includes) > 0 {
+		return JobConfig{}, errors.New("included job config must not include other job config")
+	}
+	setSourcePath(&jc, include.URL)
+	return jc, nil
+}
+
+// fetchInclude returns the job config referenced by the include, using the
+// cache if possible.
+func fetchInclude(include JobConfigInclude) ([]byte, error) {
+	if err := verifyDigestFormat(include.Digest); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(include.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "https" && u.Scheme != "oci" {
+		return nil, fmt.Errorf("unsupported scheme %q, must be https or oci", u.Scheme)
+	}
+	key := include.URL + "@" + include.Digest
+
+	includeCacheLock.Lock()
+	raw, ok := includeCache[key]
+	includeCacheLock.Unlock()
+	if ok {
+		return raw, nil
+	}
+
+	if u.Scheme == "https" {
+		raw, err = fetchHTTPSInclude(u.String(), include.Digest)
+	} else {
+		raw, err = fetchOCIInclude(u, include.Digest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	includeCacheLock.Lock()
+	includeCache[key] = raw
+	includeCacheLock.Unlock()
+	return raw, nil
+}
+
+// readCachedBlob returns the content with the digest from the persistent
+// cache. Content that does not match its digest is ignored, so that a
+// corrupted or tampered cache cannot change the jobs.
+func readCachedBlob(digest string) ([]byte, bool) {
+	if IncludeCacheDir == "" {
+		return nil, false
+	}
+	raw, err := os.ReadFile(includeCachePath(digest))
+	if err != nil {
+		return nil, false
+	}
+	if err := verifyDigest(raw, digest); err != nil {
+		logrus.WithError(err).Warn("Ignoring corrupted cached job config.")
+		return nil, false
+	}
+	return raw, true
+}
+
+// writeCachedBlob stores content that matched its digest in the persistent
+// cache.
+func writeCachedBlob(digest string, raw []byte) {
+	if IncludeCacheDir == "" {
+		return
+	}
+	if err := writeIncludeCache(includeCachePath(digest), raw); err != nil {
+		logrus.WithError(err).WithField("digest", digest).Warn("Failed to cache included job config.")
+	}
+}
+
+func includeCachePath(digest string) string {
+	return filepath.Join(IncludeCacheDir, strings.Replace(digest, ":", "-", 1))
+}
+
+func writeIncludeCache(path string, raw []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so that concurrent readers never see
+	// partial content.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".include-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func verifyDigestFormat(digest string) error {
+	hexDigest, ok := strings.CutPrefix(digest, "sha256:")
+	if !ok {
+		return fmt.Errorf("digest %q must be of the form sha256:<hex>", digest)
+	}
+	if decoded, err := hex.DecodeString(hexDigest); err != nil || len(decoded) != sha256.Size {
+		return fmt.Errorf("digest %q must be of the form sha256:<hex>", digest)
+	}
+	return nil
+}
+
+func verifyDigest(raw []byte, digest string) error {
+	sum := sha256.Sum256(raw)
+	if actual := "sha256:" + hex.EncodeToString(sum[:]); actual != digest {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", digest, actual)
+	}
+	return nil
+}
+
+func fetchHTTPSInclude(u, digest string) ([]byte, error) {
+	if raw, ok := readCachedBlob(digest); ok {
+		return raw, nil
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := doIncludeRequest(req, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyDigest(raw, digest); err != nil {
+		return nil, err
+	}
+	writeCachedBlob(digest, raw)
+	return raw, nil
+}
+
+// fetchOCIInclude fetches the job config layer of an OCI artifact using the
+// registry HTTP API. Only anonymous access is supported.
+func fetchOCIInclude(u *url.URL, digest string) ([]byte, error) {
+	repository := strings.Trim(u.Path, "/")
+	if repository == "" || strings.ContainsAny(repository, "@:") {
+		return nil, fmt.Errorf("invalid OCI reference %s, must be oci://<registry>/<repository>", u)
+	}
+	base := fmt.Sprintf("https://%s/v2/%s", u.Host, repository)
+
+	// The manifest and the layer are cached separately, each by its own
+	// digest.
+	var token string
+	raw, ok := readCachedBlob(digest)
+	if !ok {
+		req, err := http.NewRequest(http.MethodGet, base+"/manifests/"+digest, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", ociManifestMediaType+", "+dockerManifestMediaType)
+		if token, raw, err = doRegistryRequest(req, ""); err != nil {
+			return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+		}
+		if err := verifyDigest(raw, digest); err != nil {
+			return nil, fmt.Errorf("manifest %w", err)
+		}
+		writeCachedBlob(digest, raw)
+	}
+
+	var manifest struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	var layerDigest string
+	for _, layer := range manifest.Layers {
+		if layer.MediaType == JobConfigMediaType || len(manifest.Layers) == 1 {
+			layerDigest = layer.Digest
+			break
+		}
+	}
+	if layerDigest == "" {
+		return nil, fmt.Errorf("manifest has no layer of type %s", JobConfigMediaType)
+	}
+	if err := verifyDigestFormat(layerDigest); err != nil {
+		return nil, fmt.Errorf("invalid layer: %w", err)
+	}
+	if raw, ok := readCachedBlob(layerDigest); ok {
+		return raw, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, base+"/blobs/"+layerDigest, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, raw, err = doRegistryRequest(req, token); err != nil {
+		return nil, fmt.Errorf("failed to fetch layer: %w", err)
+	}
+	if err := verifyDigest(raw, layerDigest); err != nil {
+		return nil, fmt.Errorf("layer %w", err)
+	}
+	writeCachedBlob(layerDigest, raw)
+	return raw, nil
+}
+
+// doRegistryRequest executes the request, requesting an anonymous bearer
+// token if the registry asks for one. It returns the token for reuse.
+func doRegistryRequest(req *http.Request, token string) (string, []byte, error) {
+	raw, err := doIncludeRequest(req, token)
+	var challenge *authChallengeError
+	if !errors.As(err, &challenge) || token != "" {
+		return token, raw, err
+	}
+	if token, err = anonymousRegistryToken(challenge.header); err != nil {
+		return "", nil, err
+	}
+	raw, err = doIncludeRequest(req, token)
+	return token, raw, err
+}
+
+type authChallengeError struct {
+	header string
+}
+
+func (e *authChallengeError) Error() string {
+	return "unauthorized: " + e.header
+}
+
+func doIncludeRequest(req *http.Request, token string) ([]byte, error) {
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := includeClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") != "" {
+		return nil, &authChallengeError{header: resp.Header.Get("WWW-Authenticate")}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, req.URL)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxIncludeSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > maxIncludeSize {
+		return nil, fmt.Errorf("content of %s exceeds %d bytes", req.URL, maxIncludeSize)
+	}
+	return raw, nil
+}
+
+// anonymousRegistryToken requests a token as described by the
+// WWW-Authenticate header of a registry, e.g.
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/alpine:pull"
+func anonymousRegistryToken(header string) (string, error) {
+	params, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return "", fmt.Errorf("unsupported authentication challenge %q", header)
+	}
+	values := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		values[key] = strings.Trim(value, `"`)
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Scheme != "https" {
+		return "", fmt.Errorf("invalid token realm %q", values["realm"])
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if values[key] != "" {
+			query.Set(key, values[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	raw, err := doIncludeRequest(req, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry token: %w", err)
+	}
+	var response struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return "", fmt.Errorf("failed to parse registry token: %w", err)
+	}
+	if response.Token != "" {
+		return response.Token, nil
+	}
+	return response.AccessToken, nil
+}
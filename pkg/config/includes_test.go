@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sha256Digest(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestResolveIncludes(t *testing.T) {
+	jobs := []byte(`
+periodics:
+- name: shared-nightly
+  interval: 24h
+`)
+	jobsDigest := sha256Digest(jobs)
+	manifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"layers":[{"mediaType":%q,"digest":%q}]}`, JobConfigMediaType, jobsDigest))
+	manifestDigest := sha256Digest(manifest)
+
+	var requests int
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case r.URL.Path == "/jobs.yaml":
+			w.Write(jobs)
+		case r.URL.Path == "/token":
+			if r.URL.Query().Get("scope") != "repository:prow/jobs:pull" {
+				http.Error(w, "bad scope", http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"token":"anonymous"}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/prow/jobs/"):
+			if r.Header.Get("Authorization") != "Bearer anonymous" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",scope="repository:prow/jobs:pull"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/v2/prow/jobs/manifests/" + manifestDigest:
+				w.Write(manifest)
+			case "/v2/prow/jobs/blobs/" + jobsDigest:
+				w.Write(jobs)
+			default:
+				http.NotFound(w, r)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	oldClient := includeClient
+	defer func() { includeClient = oldClient }()
+	includeClient = server.Client()
+	oldCacheDir := IncludeCacheDir
+	defer func() { IncludeCacheDir = oldCacheDir }()
+
+	testCases := []struct {
+		name        string
+		include     JobConfigInclude
+		expectedErr string
+	}{
+		{
+			name:    "https include",
+			include: JobConfigInclude{URL: server.URL + "/jobs.yaml", Digest: jobsDigest},
+		},
+		{
+			name:    "oci include",
+			include: JobConfigInclude{URL: "oci://" + host + "/prow/jobs", Digest: manifestDigest},
+		},
+		{
+			name:        "digest mismatch",
+			include:     JobConfigInclude{URL: server.URL + "/jobs.yaml", Digest: manifestDigest},
+			expectedErr: fmt.Sprintf("failed to include %s/jobs.yaml: digest mismatch: expected %s, got %s", server.URL, manifestDigest, jobsDigest),
+		},
+		{
+			name:        "invalid digest",
+			include:     JobConfigInclude{URL: server.URL + "/jobs.yaml", Digest: "latest"},
+			expectedErr: fmt.Sprintf(`failed to include %s/jobs.yaml: digest "latest" must be of the form sha256:<hex>`, server.URL),
+		},
+		{
+			name:        "plain http is rejected",
+			include:     JobConfigInclude{URL: "http://example.com/jobs.yaml", Digest: jobsDigest},
+			expectedErr: `failed to include http://example.com/jobs.yaml: unsupported scheme "http", must be https or oci`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			IncludeCacheDir = t.TempDir()
+			includeCache = map[string][]byte{}
+
+			load := func() {
+				c := &Config{JobConfig: JobConfig{Includes: []JobConfigInclude{tc.include}}}
+				err := c.resolveIncludes()
+				var errMsg string
+				if err != nil {
+					errMsg = err.Error()
+				}
+				if errMsg != tc.expectedErr {
+					t.Fatalf("expected error %q, got %q", tc.expectedErr, errMsg)
+				}
+				if tc.expectedErr != "" {
+					return
+				}
+				if len(c.Periodics) != 1 || c.Periodics[0].Name != "shared-nightly" {
+					t.Fatalf("expected the included periodic, got %v", c.Periodics)
+				}
+				if c.Periodics[0].SourcePath != tc.include.URL {
+					t.Errorf("expected source path %s, got %s", tc.include.URL, c.Periodics[0].SourcePath)
+				}
+			}
+
+			load()
+			if tc.expectedErr != "" {
+				return
+			}
+			// Subsequent loads must be served from the in-memory and the
+			// persistent cache.
+			requests = 0
+			load()
+			includeCache = map[string][]byte{}
+			load()
+			if requests != 0 {
+				t.Errorf("expected cached include, got %d requests", requests)
+			}
+
+			// A tampered persistent cache must not be trusted.
+			cached, err := os.ReadDir(IncludeCacheDir)
+			if err != nil {
+				t.Fatalf("failed to list the cache: %v", err)
+			}
+			if len(cached) == 0 {
+				t.Fatal("expected the include to be cached on disk")
+			}
+			for _, entry := range cached {
+				if err := os.WriteFile(filepath.Join(IncludeCacheDir, entry.Name()), []byte("presubmits: {}"), 0644); err != nil {
+					t.Fatalf("failed to tamper with the cache: %v", err)
+				}
+			}
+			includeCache = map[string][]byte{}
+			load()
+			if requests == 0 {
+				t.Error("expected the tampered include to be fetched again")
+			}
+		})
+	}
+}
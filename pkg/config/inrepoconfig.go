@@ -29,6 +29,7 @@ import (
 	gitignore "github.com/denormal/go-gitignore"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	gerritsource "sigs.k8s.io/prow/pkg/gerrit/source"
@@ -83,11 +84,14 @@ func init() {
 // +k8s:deepcopy-gen=true
 
 // ProwYAML represents the content of a .prow.yaml file
-// used to version Presubmits and Postsubmits inside the tested repo.
+// used to version Presubmits, Postsubmits and Periodics inside the tested repo.
 type ProwYAML struct {
 	Presets     []Preset     `json:"presets"`
 	Presubmits  []Presubmit  `json:"presubmits"`
 	Postsubmits []Postsubmit `json:"postsubmits"`
+	// Periodics are only accepted for repositories listed in
+	// in_repo_config.periodics.
+	Periodics []Periodic `json:"periodics,omitempty"`
 
 	// ProwIgnored is a well known, unparsed field where non-Prow fields can
 	// be defined without conflicting with unknown field validation.
@@ -207,6 +211,7 @@ func ReadProwYAML(log *logrus.Entry, dir string, strict bool) (*ProwYAML, error)
 			c.Presets = append(a.Presets, b.Presets...)
 			c.Presubmits = append(a.Presubmits, b.Presubmits...)
 			c.Postsubmits = append(a.Postsubmits, b.Postsubmits...)
+			c.Periodics = append(a.Periodics, b.Periodics...)
 
 			return c
 		}
@@ -260,9 +265,20 @@ func ReadProwYAML(log *logrus.Entry, dir string, strict bool) (*ProwYAML, error)
 			}
 		}
 	}
+	if err := prowYAML.expandMatrices(); err != nil {
+		return nil, fmt.Errorf("failed to expand matrix jobs: %w", err)
+	}
 	return prowYAML, nil
 }
 
+func (p *ProwYAML) expandMatrices() error {
+	presubmits, presubmitsErr := expandMatrixJobs(p.Presubmits, func(p *Presubmit) *JobBase { return &p.JobBase })
+	postsubmits, postsubmitsErr := expandMatrixJobs(p.Postsubmits, func(p *Postsubmit) *JobBase { return &p.JobBase })
+	periodics, periodicsErr := expandMatrixJobs(p.Periodics, func(p *Periodic) *JobBase { return &p.JobBase })
+	p.Presubmits, p.Postsubmits, p.Periodics = presubmits, postsubmits, periodics
+	return utilerrors.NewAggregate([]error{presubmitsErr, postsubmitsErr, periodicsErr})
+}
+
 // prowYAMLGetterWithDefaults is like prowYAMLGetter, but additionally sets
 // defaults by calling DefaultAndValidateProwYAML.
 func prowYAMLGetterWithDefaults(
@@ -300,26 +316,139 @@ func DefaultAndValidateProwYAML(c *Config, p *ProwYAML, identifier string) error
 		return err
 	}
 
+	if err := defaultAndValidateInRepoPeriodics(c, p, identifier); err != nil {
+		return err
+	}
+
 	var errs []error
-	for _, pre := range p.Presubmits {
+	for i, pre := range p.Presubmits {
 		if !c.InRepoConfigAllowsCluster(pre.Cluster, identifier) {
 			errs = append(errs, fmt.Errorf("cluster %q is not allowed for repository %q", pre.Cluster, identifier))
 		}
+		if err := refuseSecretBundles(&p.Presubmits[i].JobBase, c.Plank.SecretBundles); err != nil {
+			errs = append(errs, err)
+		}
 	}
-	for _, post := range p.Postsubmits {
+	for i, post := range p.Postsubmits {
 		if !c.InRepoConfigAllowsCluster(post.Cluster, identifier) {
 			errs = append(errs, fmt.Errorf("cluster %q is not allowed for repository %q", post.Cluster, identifier))
 		}
+		if err := refuseSecretBundles(&p.Postsubmits[i].JobBase, c.Plank.SecretBundles); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i, periodic := range p.Periodics {
+		if !c.InRepoConfigAllowsCluster(periodic.Cluster, identifier) {
+			errs = append(errs, fmt.Errorf("cluster %q is not allowed for repository %q", periodic.Cluster, identifier))
+		}
+		if err := refuseSecretBundles(&p.Periodics[i].JobBase, c.Plank.SecretBundles); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
 	if len(errs) == 0 {
 		log := logrus.WithField("repo", identifier)
-		log.Debugf("Successfully got %d presubmits and %d postsubmits.", len(p.Presubmits), len(p.Postsubmits))
+		log.Debugf("Successfully got %d presubmits, %d postsubmits and %d periodics.", len(p.Presubmits), len(p.Postsubmits), len(p.Periodics))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateInRepoPeriodics(periodics map[string]InRepoPeriodics) error {
+	var errs []error
+	for identifier, settings := range periodics {
+		if _, _, err := SplitRepoName(identifier); err != nil {
+			errs = append(errs, err)
+		}
+		if settings.Branch == "" {
+			errs = append(errs, fmt.Errorf("%s: branch must be set", identifier))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// defaultAndValidateInRepoPeriodics defaults the periodics of a ProwYAML and
+// enforces the in_repo_config.periodics guardrails on them.
+func defaultAndValidateInRepoPeriodics(c *Config, p *ProwYAML, identifier string) error {
+	if len(p.Periodics) == 0 {
+		return nil
+	}
+	settings := c.InRepoConfigPeriodics(identifier)
+	if settings == nil {
+		return fmt.Errorf("repository %q may not define periodics in-repo", identifier)
+	}
+
+	var errs []error
+	for i := range p.Periodics {
+		periodic := &p.Periodics[i]
+		c.defaultPeriodicFields(periodic)
+		setPeriodicDecorationDefaults(c, periodic)
+		setPeriodicProwJobDefaults(c, periodic)
+		if err := resolvePresets(periodic.Name, periodic.Labels, periodic.Spec, append(c.Presets, p.Presets...)); err != nil {
+			errs = append(errs, err)
+		}
+		if err := mergePodTemplate(&periodic.JobBase, c.Plank.PodTemplates); err != nil {
+			errs = append(errs, err)
+		}
+		if err := mergeSecretBundles(&periodic.JobBase, periodicRepo(periodic), c.Plank.SecretBundles); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	if err := c.validatePeriodics(p.Periodics); err != nil {
+		return err
 	}
 
+	central := sets.New[string]()
+	for _, periodic := range c.Periodics {
+		central.Insert(periodic.Name)
+	}
+	for _, periodic := range p.Periodics {
+		if central.Has(periodic.Name) {
+			errs = append(errs, fmt.Errorf("duplicated periodic job (consider both inrepo and central config): %s", periodic.Name))
+		}
+		containerResources := periodicResources(&periodic)
+		for _, container := range sets.List(sets.KeySet(containerResources)) {
+			for name, limit := range settings.MaxResources {
+				for _, resources := range []v1.ResourceList{containerResources[container].Requests, containerResources[container].Limits} {
+					if quantity, ok := resources[name]; ok && quantity.Cmp(limit) > 0 {
+						errs = append(errs, fmt.Errorf("periodic %s: container %q exceeds the maximum %s of %s for repository %q", periodic.Name, container, name, limit.String(), identifier))
+						break
+					}
+				}
+			}
+		}
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
+// periodicResources returns the resources of every container the pod of the
+// defaulted periodic runs, including the ones decoration adds, by container
+// name.
+func periodicResources(periodic *Periodic) map[string]v1.ResourceRequirements {
+	resources := map[string]v1.ResourceRequirements{}
+	if periodic.Spec != nil {
+		for _, container := range append(append([]v1.Container{}, periodic.Spec.InitContainers...), periodic.Spec.Containers...) {
+			resources[container.Name] = container.Resources
+		}
+	}
+	if periodic.Decorate != nil && *periodic.Decorate && periodic.DecorationConfig != nil && periodic.DecorationConfig.Resources != nil {
+		for name, requirements := range map[string]*v1.ResourceRequirements{
+			"clonerefs":        periodic.DecorationConfig.Resources.CloneRefs,
+			"initupload":       periodic.DecorationConfig.Resources.InitUpload,
+			"place-entrypoint": periodic.DecorationConfig.Resources.PlaceEntrypoint,
+			"sidecar":          periodic.DecorationConfig.Resources.Sidecar,
+		} {
+			if requirements != nil {
+				resources[name] = *requirements
+			}
+		}
+	}
+	return resources
+}
+
 // ContainsInRepoConfigPath indicates whether the specified list of changed
 // files (repo relative paths) includes a file that might be an inrepo config file.
 //
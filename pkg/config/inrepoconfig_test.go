@@ -22,8 +22,11 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/prow/pkg/git/localgit"
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/kube"
@@ -258,6 +261,128 @@ func testDefaultProwYAMLGetter(clients localgit.Clients, t *testing.T) {
 				return nil
 			},
 		},
+		// periodics
+		{
+			name: "Basic happy path (periodics)",
+			baseContent: map[string][]byte{
+				".prow.yaml": []byte(`periodics: [{"name": "hans", "interval": "24h", "spec": {"containers": [{}]}}]`),
+			},
+			config: &Config{ProwConfig: ProwConfig{InRepoConfig: InRepoConfig{
+				AllowedClusters: map[string][]string{"*": {kube.DefaultClusterAlias}},
+				Periodics:       map[string]InRepoPeriodics{org + "/" + defaultRepo: {Branch: "main"}},
+			}}},
+			validate: func(p *ProwYAML, err error) error {
+				if err != nil {
+					return fmt.Errorf("unexpected error: %w", err)
+				}
+				if n := len(p.Periodics); n != 1 || p.Periodics[0].Name != "hans" {
+					return fmt.Errorf(`expected exactly one periodic with name "hans", got %v`, p.Periodics)
+				}
+				if p.Periodics[0].GetInterval() != 24*time.Hour {
+					return fmt.Errorf("expected validation to set the interval to 24h, was %v", p.Periodics[0].GetInterval())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Periodics are rejected unless the repo is allowed to define them",
+			baseContent: map[string][]byte{
+				".prow.yaml": []byte(`periodics: [{"name": "hans", "interval": "24h", "spec": {"containers": [{}]}}]`),
+			},
+			validate: func(_ *ProwYAML, err error) error {
+				if err == nil {
+					return errors.New("error is nil")
+				}
+				expectedErrMsg := `repository "org/repo" may not define periodics in-repo`
+				if err.Error() != expectedErrMsg {
+					return fmt.Errorf("expected error message to be %q, was %q", expectedErrMsg, err.Error())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Periodic validation includes central periodics",
+			baseContent: map[string][]byte{
+				".prow.yaml": []byte(`periodics: [{"name": "hans", "interval": "24h", "spec": {"containers": [{}]}}]`),
+			},
+			config: &Config{
+				JobConfig: JobConfig{Periodics: []Periodic{{JobBase: JobBase{Name: "hans"}}}},
+				ProwConfig: ProwConfig{InRepoConfig: InRepoConfig{
+					AllowedClusters: map[string][]string{"*": {kube.DefaultClusterAlias}},
+					Periodics:       map[string]InRepoPeriodics{org + "/" + defaultRepo: {Branch: "main"}},
+				}},
+			},
+			validate: func(_ *ProwYAML, err error) error {
+				if err == nil {
+					return errors.New("error is nil")
+				}
+				expectedErrMsg := "duplicated periodic job (consider both inrepo and central config): hans"
+				if err.Error() != expectedErrMsg {
+					return fmt.Errorf("expected error message to be %q, was %q", expectedErrMsg, err.Error())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Periodic exceeding the maximum resources is rejected",
+			baseContent: map[string][]byte{
+				".prow.yaml": []byte(`periodics: [{"name": "hans", "interval": "24h", "spec": {"containers": [{"name": "test", "resources": {"requests": {"cpu": "2"}, "limits": {"memory": "1Gi"}}}]}}]`),
+			},
+			config: &Config{ProwConfig: ProwConfig{InRepoConfig: InRepoConfig{
+				AllowedClusters: map[string][]string{"*": {kube.DefaultClusterAlias}},
+				Periodics:       map[string]InRepoPeriodics{org + "/" + defaultRepo: {Branch: "main", MaxResources: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("512Mi")}}},
+			}}},
+			validate: func(_ *ProwYAML, err error) error {
+				if err == nil {
+					return errors.New("error is nil")
+				}
+				expectedErrMsg := `periodic hans: container "test" exceeds the maximum memory of 512Mi for repository "org/repo"`
+				if err.Error() != expectedErrMsg {
+					return fmt.Errorf("expected error message to be %q, was %q", expectedErrMsg, err.Error())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Periodic exceeding the maximum resources through its decoration is rejected",
+			baseContent: map[string][]byte{
+				".prow.yaml": []byte(`periodics: [{"name": "hans", "interval": "24h", "decorate": true, "decoration_config": {"utility_images": {"clonerefs": "clonerefs", "initupload": "initupload", "entrypoint": "entrypoint", "sidecar": "sidecar"}, "gcs_configuration": {"bucket": "bucket", "path_strategy": "explicit"}, "resources": {"sidecar": {"requests": {"cpu": "8"}}}}, "spec": {"containers": [{"name": "test", "command": ["test"]}]}}]`),
+			},
+			config: &Config{ProwConfig: ProwConfig{InRepoConfig: InRepoConfig{
+				AllowedClusters: map[string][]string{"*": {kube.DefaultClusterAlias}},
+				Periodics:       map[string]InRepoPeriodics{org + "/" + defaultRepo: {Branch: "main", MaxResources: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")}}},
+			}}},
+			validate: func(_ *ProwYAML, err error) error {
+				if err == nil {
+					return errors.New("error is nil")
+				}
+				expectedErrMsg := `periodic hans: container "sidecar" exceeds the maximum cpu of 4 for repository "org/repo"`
+				if err.Error() != expectedErrMsg {
+					return fmt.Errorf("expected error message to be %q, was %q", expectedErrMsg, err.Error())
+				}
+				return nil
+			},
+		},
+		{
+			name: "Not allowed cluster is rejected (periodics)",
+			baseContent: map[string][]byte{
+				".prow.yaml": []byte(`periodics: [{"name": "hans", "interval": "24h", "cluster": "privileged", "spec": {"containers": [{}]}}]`),
+			},
+			config: &Config{ProwConfig: ProwConfig{InRepoConfig: InRepoConfig{
+				AllowedClusters: map[string][]string{"*": {kube.DefaultClusterAlias}},
+				Periodics:       map[string]InRepoPeriodics{org + "/" + defaultRepo: {Branch: "main"}},
+			}}},
+			validate: func(_ *ProwYAML, err error) error {
+				if err == nil {
+					return errors.New("error is nil")
+				}
+				expectedErrMsg := "cluster \"privileged\" is not allowed for repository \"org/repo\""
+				if err.Error() != expectedErrMsg {
+					return fmt.Errorf("expected error message to be %q, was %q", expectedErrMsg, err.Error())
+				}
+				return nil
+			},
+		},
 		// prowyaml
 		{
 			name: "Not allowed cluster is rejected",
@@ -420,6 +545,26 @@ func testDefaultProwYAMLGetter(clients localgit.Clients, t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "Merge periodics under .prow directory",
+			baseContent: map[string][]byte{
+				".prow/nightly/one.yaml": []byte(`periodics: [{"name": "hans", "interval": "24h", "spec": {"containers": [{}]}}]`),
+				".prow/two.yaml":         []byte(`postsubmits: [{"name": "kurt", "spec": {"containers": [{}]}}]`),
+			},
+			config: &Config{ProwConfig: ProwConfig{InRepoConfig: InRepoConfig{
+				AllowedClusters: map[string][]string{"*": {kube.DefaultClusterAlias}},
+				Periodics:       map[string]InRepoPeriodics{org + "/" + defaultRepo: {Branch: "main"}},
+			}}},
+			validate: func(p *ProwYAML, err error) error {
+				if err != nil {
+					return fmt.Errorf("unexpected error: %w", err)
+				}
+				if len(p.Periodics) != 1 || p.Periodics[0].Name != "hans" || len(p.Postsubmits) != 1 || p.Postsubmits[0].Name != "kurt" {
+					return fmt.Errorf(`expected periodic "hans" and postsubmit "kurt", got %v and %v`, p.Periodics, p.Postsubmits)
+				}
+				return nil
+			},
+		},
 		{
 			name: "Merge presets under .prow directory",
 			baseContent: map[string][]byte{
@@ -543,6 +688,28 @@ postsubmits: [{"name": "oli", "spec": {"containers": [{}]}}]`),
 			},
 			repo: "repo/name",
 		},
+		{
+			name: "Secret bundles of the repo are refused to in-repo jobs",
+			baseContent: map[string][]byte{
+				".prow.yaml": []byte(`presubmits: [{"name": "hans", "secrets": ["gcp"], "spec": {"containers": [{}]}}]`),
+			},
+			config: &Config{ProwConfig: ProwConfig{
+				InRepoConfig: InRepoConfig{AllowedClusters: map[string][]string{"*": {kube.DefaultClusterAlias}}},
+				Plank: Plank{SecretBundles: map[string]SecretBundle{
+					"gcp": {Secret: "gcp-service-account", AllowedRepos: []string{org + "/" + defaultRepo}, AllowedJobs: []string{org + "/" + defaultRepo + "/hans"}},
+				}},
+			}},
+			validate: func(_ *ProwYAML, err error) error {
+				if err == nil {
+					return errors.New("error is nil")
+				}
+				expectedErrMsg := "job hans is defined in-repo and may not use secret bundles"
+				if err.Error() != expectedErrMsg {
+					return fmt.Errorf("expected error message to be %q, was %q", expectedErrMsg, err.Error())
+				}
+				return nil
+			},
+		},
 	}
 
 	for idx := range testCases {
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// JobConfigResources restricts what JobConfig custom resources may configure.
+type JobConfigResources struct {
+	// Namespaces maps a namespace to the orgs and repos (`org` or `org/repo`)
+	// that JobConfig resources in that namespace may configure jobs for.
+	// Resources in the ProwJob namespace may configure any job, including
+	// periodics without extra refs. Resources in other namespaces that are not
+	// listed here are rejected.
+	Namespaces map[string][]string `json:"namespaces,omitempty"`
+}
+
+// mayConfigure determines whether JobConfig resources in the namespace may
+// configure jobs for the repo. An empty repo means a job that is not tied to
+// any repository.
+func (pc *ProwConfig) mayConfigure(namespace, repo string) bool {
+	if namespace == pc.ProwJobNamespace {
+		return true
+	}
+	if repo == "" || pc.JobConfigResources == nil {
+		return false
+	}
+	allowed := sets.New[string](pc.JobConfigResources.Namespaces[namespace]...)
+	org, _, _ := SplitRepoName(repo)
+	return allowed.Has(repo) || allowed.Has(org)
+}
+
+// JobConfigFromResource parses the job configuration of a JobConfig resource
+// and verifies that the namespace of the resource may configure its jobs.
+// Job templates and matrix jobs defined in the resource are expanded, jobs
+// are not defaulted.
+func (pc *ProwConfig) JobConfigFromResource(r *prowapi.JobConfig) (JobConfig, error) {
+	var jc JobConfig
+	if err := yaml.UnmarshalStrict([]byte(r.Spec.Config), &jc); err != nil {
+		return JobConfig{}, fmt.Errorf("failed to parse job config: %w", err)
+	}
+	return pc.namespacedJobConfig(r.Namespace, jc)
+}
+
+// namespacedJobConfig expands the job config owned by the namespace and
+// verifies that the namespace may configure its jobs.
+func (pc *ProwConfig) namespacedJobConfig(namespace string, jc JobConfig) (JobConfig, error) {
+	if len(jc.Includes) > 0 {
+		return JobConfig{}, fmt.Errorf("include is not supported in job config owned by namespace %s", namespace)
+	}
+	if err := jc.expandJobTemplates(); err != nil {
+		return JobConfig{}, err
+	}
+	if err := jc.expandMatrices(); err != nil {
+		return JobConfig{}, err
+	}
+	jc.JobTemplates, jc.TemplatedJobs = nil, nil
+
+	var errs []error
+	forbidden := func(job, repo string) {
+		if repo == "" {
+			errs = append(errs, fmt.Errorf("job %s: namespace %s may not configure jobs without a repository", job, namespace))
+		} else {
+			errs = append(errs, fmt.Errorf("job %s: namespace %s may not configure jobs for %s", job, namespace, repo))
+		}
+	}
+	for repo, presubmits := range jc.PresubmitsStatic {
+		for _, presubmit := range presubmits {
+			if !pc.mayConfigure(namespace, repo) {
+				forbidden(presubmit.Name, repo)
+			}
+		}
+	}
+	for repo, postsubmits := range jc.PostsubmitsStatic {
+		for _, postsubmit := range postsubmits {
+			if !pc.mayConfigure(namespace, repo) {
+				forbidden(postsubmit.Name, repo)
+			}
+		}
+	}
+	for _, periodic := range jc.Periodics {
+		if len(periodic.ExtraRefs) == 0 && !pc.mayConfigure(namespace, "") {
+			forbidden(periodic.Name, "")
+		}
+		for _, ref := range periodic.ExtraRefs {
+			if repo := ref.OrgRepoString(); !pc.mayConfigure(namespace, repo) {
+				forbidden(periodic.Name, repo)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return JobConfig{}, utilerrors.NewAggregate(errs)
+	}
+	return jc, nil
+}
+
+// AddJobConfigResources defaults the jobs configured by the JobConfig resources
+// and merges them into the config, then validates the resulting job config.
+// Resources in the ProwJob namespace take precedence over the ones in other
+// namespaces, which are ordered by namespace and name. Jobs conflicting with
+// jobs that are already configured or that come from a resource of higher
+// precedence are dropped and recorded in SourceConflicts.
+// It mutates the config and so must be called before the config is shared,
+// e.g. as one of the additionals passed to the config agent.
+func (c *Config) AddJobConfigResources(resources []prowapi.JobConfig) error {
+	resources = append([]prowapi.JobConfig(nil), resources...)
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if (a.Namespace == c.ProwJobNamespace) != (b.Namespace == c.ProwJobNamespace) {
+			return a.Namespace == c.ProwJobNamespace
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	for i := range resources {
+		r := &resources[i]
+		source := fmt.Sprintf("JobConfig %s/%s", r.Namespace, r.Name)
+		jc, err := c.JobConfigFromResource(r)
+		if err == nil {
+			err = c.addNamespacedJobConfig(source, jc)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+	}
+	return c.ValidateJobConfig()
+}
+
+// AddJobConfigResourcesFrom returns a load function that merges the JobConfig
+// resources returned by list into the config, see AddJobConfigResources.
+func AddJobConfigResourcesFrom(list func() ([]prowapi.JobConfig, error)) func(*Config) error {
+	return func(c *Config) error {
+		resources, err := list()
+		if err != nil {
+			return fmt.Errorf("failed to list JobConfig resources: %w", err)
+		}
+		return c.AddJobConfigResources(resources)
+	}
+}
+
+// ValidateJobConfigResource validates the JobConfig resource on its own, i.e.
+// without the jobs that are already configured.
+func (c *Config) ValidateJobConfigResource(r *prowapi.JobConfig) error {
+	nc := &Config{
+		ProwConfig: c.ProwConfig,
+		JobConfig: JobConfig{
+			Presets:         c.Presets,
+			DecorateAllJobs: c.DecorateAllJobs,
+		},
+	}
+	return nc.AddJobConfigResources([]prowapi.JobConfig{*r})
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestAddJobConfigResources(t *testing.T) {
+	const podSpec = `
+    spec:
+      containers:
+      - image: alpine`
+	const periodicPodSpec = `
+  spec:
+    containers:
+    - image: alpine`
+	resource := func(namespace, config string) prowapi.JobConfig {
+		return prowapi.JobConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "jobs"},
+			Spec:       prowapi.JobConfigSpec{Config: config},
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		resources          []prowapi.JobConfig
+		expectedPresubmits map[string][]string
+		expectedPeriodics  []string
+		expectedConflicts  []string
+		expectedErr        string
+	}{
+		{
+			name: "jobs from the ProwJob namespace are unrestricted",
+			resources: []prowapi.JobConfig{resource("prowjobs", `
+presubmits:
+  other/repo:
+  - name: pull-unit
+    always_run: true`+podSpec+`
+periodics:
+- name: ci-nightly
+  interval: 24h`+periodicPodSpec)},
+			expectedPresubmits: map[string][]string{"other/repo": {"pull-unit"}},
+			expectedPeriodics:  []string{"ci-nightly"},
+		},
+		{
+			name: "tenant namespace configures allowed org",
+			resources: []prowapi.JobConfig{resource("team-a", `
+presubmits:
+  org/repo:
+  - name: pull-unit
+    always_run: true`+podSpec)},
+			expectedPresubmits: map[string][]string{"org/repo": {"pull-unit"}},
+		},
+		{
+			name: "tenant namespace may not configure other repos",
+			resources: []prowapi.JobConfig{resource("team-a", `
+presubmits:
+  other/repo:
+  - name: pull-unit
+    always_run: true`+podSpec)},
+			expectedErr: "JobConfig team-a/jobs: job pull-unit: namespace team-a may not configure jobs for other/repo",
+		},
+		{
+			name: "tenant namespace may not configure periodics without refs",
+			resources: []prowapi.JobConfig{resource("team-a", `
+periodics:
+- name: ci-nightly
+  interval: 24h`+periodicPodSpec)},
+			expectedErr: "JobConfig team-a/jobs: job ci-nightly: namespace team-a may not configure jobs without a repository",
+		},
+		{
+			name: "unknown namespace may not configure anything",
+			resources: []prowapi.JobConfig{resource("team-b", `
+presubmits:
+  org/repo:
+  - name: pull-unit
+    always_run: true`+podSpec)},
+			expectedErr: "JobConfig team-b/jobs: job pull-unit: namespace team-b may not configure jobs for org/repo",
+		},
+		{
+			name:        "unknown fields are rejected",
+			resources:   []prowapi.JobConfig{resource("team-a", `presubmit: {}`)},
+			expectedErr: `JobConfig team-a/jobs: failed to parse job config: error unmarshaling JSON: while decoding JSON: json: unknown field "presubmit"`,
+		},
+		{
+			name: "jobs are validated",
+			resources: []prowapi.JobConfig{resource("team-a", `
+presubmits:
+  org/repo:
+  - name: pull-unit
+    always_run: true`+podSpec+`
+  - name: pull-unit
+    always_run: true`+podSpec)},
+			expectedErr: "duplicated presubmit jobs (consider both inrepo and central config): [pull-unit]",
+		},
+		{
+			name: "resources in the ProwJob namespace take precedence",
+			resources: []prowapi.JobConfig{
+				resource("team-a", `
+presubmits:
+  org/repo:
+  - name: pull-unit
+    always_run: true`+podSpec+`
+  - name: pull-lint
+    always_run: true`+podSpec),
+				resource("prowjobs", `
+presubmits:
+  org/repo:
+  - name: pull-unit
+    always_run: true`+podSpec)},
+			expectedPresubmits: map[string][]string{"org/repo": {"pull-unit", "pull-lint"}},
+			expectedConflicts:  []string{"JobConfig team-a/jobs: presubmit pull-unit for org/repo conflicts with a job of higher precedence and was ignored"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Config{ProwConfig: ProwConfig{
+				ProwJobNamespace:   "prowjobs",
+				PodNamespace:       "pods",
+				JobConfigResources: &JobConfigResources{Namespaces: map[string][]string{"team-a": {"org"}}},
+			}}
+			err := c.AddJobConfigResources(tc.resources)
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Fatalf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+			if tc.expectedErr != "" {
+				return
+			}
+			for repo, expected := range tc.expectedPresubmits {
+				var actual []string
+				for _, presubmit := range c.PresubmitsStatic[repo] {
+					actual = append(actual, presubmit.Name)
+					if presubmit.re == nil {
+						t.Errorf("presubmit %s was not defaulted", presubmit.Name)
+					}
+				}
+				if !sets.New(expected...).Equal(sets.New(actual...)) {
+					t.Errorf("expected presubmits %v for %s, got %v", expected, repo, actual)
+				}
+				if !c.AllRepos.Has(repo) {
+					t.Errorf("expected %s in all repos", repo)
+				}
+			}
+			var actualPeriodics []string
+			for _, periodic := range c.Periodics {
+				actualPeriodics = append(actualPeriodics, periodic.Name)
+			}
+			if !sets.New(tc.expectedPeriodics...).Equal(sets.New(actualPeriodics...)) {
+				t.Errorf("expected periodics %v, got %v", tc.expectedPeriodics, actualPeriodics)
+			}
+			if diff := cmp.Diff(tc.expectedConflicts, c.SourceConflicts); diff != "" {
+				t.Errorf("conflicts differ from expected: %s", diff)
+			}
+		})
+	}
+}
+
+func TestJobConfigResourcePresetsAreScoped(t *testing.T) {
+	c := &Config{ProwConfig: ProwConfig{
+		ProwJobNamespace:   "prowjobs",
+		PodNamespace:       "pods",
+		JobConfigResources: &JobConfigResources{Namespaces: map[string][]string{"team-a": {"org-a"}, "team-b": {"org-b"}}},
+	}}
+	resources := []prowapi.JobConfig{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "jobs"},
+			Spec: prowapi.JobConfigSpec{Config: `
+presets:
+- labels:
+    preset-token: "true"
+  env:
+  - name: TOKEN
+    value: team-a
+presubmits:
+  org-a/repo:
+  - name: pull-unit
+    always_run: true
+    labels:
+      preset-token: "true"
+    spec:
+      containers:
+      - image: alpine`},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "jobs"},
+			Spec: prowapi.JobConfigSpec{Config: `
+presubmits:
+  org-b/repo:
+  - name: pull-unit
+    always_run: true
+    labels:
+      preset-token: "true"
+    spec:
+      containers:
+      - image: alpine`},
+		},
+	}
+	if err := c.AddJobConfigResources(resources); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.Presets) != 0 {
+		t.Errorf("expected the presets of the resources not to be merged into the config, got %v", c.Presets)
+	}
+	if env := c.PresubmitsStatic["org-a/repo"][0].Spec.Containers[0].Env; len(env) != 1 || env[0].Value != "team-a" {
+		t.Errorf("expected the preset of team-a to apply to its own job, got env %v", env)
+	}
+	if env := c.PresubmitsStatic["org-b/repo"][0].Spec.Containers[0].Env; len(env) != 0 {
+		t.Errorf("expected the preset of team-a not to apply to the job of team-b, got env %v", env)
+	}
+}
+
+func TestLoadWithJobConfigResources(t *testing.T) {
+	prowConfig := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(prowConfig, []byte("prowjob_namespace: prowjobs\npod_namespace: pods\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	resources := []prowapi.JobConfig{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prowjobs", Name: "jobs"},
+		Spec: prowapi.JobConfigSpec{Config: `
+periodics:
+- name: ci-unit
+  interval: 1h
+  spec:
+    containers:
+    - image: alpine`},
+	}}
+
+	c, err := Load(prowConfig, "", nil, "", AddJobConfigResourcesFrom(func() ([]prowapi.JobConfig, error) { return resources, nil }))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if len(c.Periodics) != 1 || c.Periodics[0].Name != "ci-unit" {
+		t.Errorf("expected the periodic of the resource, got %v", c.Periodics)
+	}
+
+	_, err = Load(prowConfig, "", nil, "", AddJobConfigResourcesFrom(func() ([]prowapi.JobConfig, error) { return nil, errors.New("forbidden") }))
+	if err == nil {
+		t.Error("expected failing to list the resources to fail loading")
+	}
+}
@@ -20,13 +20,17 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/github"
@@ -86,6 +90,258 @@ func mergePreset(preset Preset, labels map[string]string, containers []v1.Contai
 
 // +k8s:deepcopy-gen=true
 
+// PodTemplate holds pod placement settings that jobs can reference by name
+// instead of repeating them in their pod specs.
+type PodTemplate struct {
+	// NodeSelector is merged into the node selector of the pod.
+	NodeSelector map[string]string `json:"node_selector,omitempty"`
+	// Tolerations are added to the tolerations of the pod.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// RuntimeClassName is the runtime class of the pod.
+	RuntimeClassName *string `json:"runtime_class_name,omitempty"`
+	// DNSPolicy is the DNS policy of the pod.
+	DNSPolicy v1.DNSPolicy `json:"dns_policy,omitempty"`
+}
+
+// mergePodTemplate merges the pod template that the job references into its
+// pod spec. Settings of the pod spec may not conflict with the template.
+func mergePodTemplate(jb *JobBase, templates map[string]map[string]PodTemplate) error {
+	if jb.PodTemplate == "" {
+		return nil
+	}
+	if jb.Spec == nil {
+		return fmt.Errorf("job %s references pod template %q but has no pod spec", jb.Name, jb.PodTemplate)
+	}
+	template, ok := templates[jb.Cluster][jb.PodTemplate]
+	if !ok {
+		return fmt.Errorf("job %s references pod template %q which is not defined for cluster %q", jb.Name, jb.PodTemplate, jb.Cluster)
+	}
+
+	spec := jb.Spec
+	for key, value := range template.NodeSelector {
+		if existing, ok := spec.NodeSelector[key]; ok && existing != value {
+			return fmt.Errorf("job %s: node selector %s=%s conflicts with %s=%s of pod template %q", jb.Name, key, existing, key, value, jb.PodTemplate)
+		}
+		if spec.NodeSelector == nil {
+			spec.NodeSelector = map[string]string{}
+		}
+		spec.NodeSelector[key] = value
+	}
+	for _, toleration := range template.Tolerations {
+		duplicate := false
+		for _, existing := range spec.Tolerations {
+			if existing.MatchToleration(&toleration) && existing.TolerationSeconds == toleration.TolerationSeconds {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			spec.Tolerations = append(spec.Tolerations, toleration)
+		}
+	}
+	if template.RuntimeClassName != nil {
+		if spec.RuntimeClassName != nil && *spec.RuntimeClassName != *template.RuntimeClassName {
+			return fmt.Errorf("job %s: runtime class %s conflicts with %s of pod template %q", jb.Name, *spec.RuntimeClassName, *template.RuntimeClassName, jb.PodTemplate)
+		}
+		runtimeClassName := *template.RuntimeClassName
+		spec.RuntimeClassName = &runtimeClassName
+	}
+	if template.DNSPolicy != "" {
+		if spec.DNSPolicy != "" && spec.DNSPolicy != template.DNSPolicy {
+			return fmt.Errorf("job %s: DNS policy %s conflicts with %s of pod template %q", jb.Name, spec.DNSPolicy, template.DNSPolicy, jb.PodTemplate)
+		}
+		spec.DNSPolicy = template.DNSPolicy
+	}
+	return nil
+}
+
+// +k8s:deepcopy-gen=true
+
+// SecretBundle is a Kubernetes secret that jobs can reference by name with
+// secrets, instead of mounting it themselves.
+type SecretBundle struct {
+	// Secret is the name of the secret in the pod namespace of the build
+	// clusters.
+	Secret string `json:"secret"`
+	// MountPath is where the keys of the secret are mounted as files in the
+	// containers of jobs. Defaults to /etc/secret-bundles/<name of the bundle>.
+	MountPath string `json:"mount_path,omitempty"`
+	// Env maps names of environment variables of the containers of jobs to
+	// keys of the secret.
+	Env map[string]string `json:"env,omitempty"`
+	// AllowedRepos are the orgs or org/repos whose jobs may use the bundle.
+	// Periodics belong to the repo of their first extra ref.
+	AllowedRepos []string `json:"allowed_repos,omitempty"`
+	// AllowedJobs are the jobs that may use the bundle, as org/repo/name.
+	// Periodics without extra refs are listed by their bare name.
+	AllowedJobs []string `json:"allowed_jobs,omitempty"`
+}
+
+// Allows tells whether the job of the given name and org/repo may use the
+// bundle.
+func (b SecretBundle) Allows(job, repo string) bool {
+	qualified := job
+	if repo != "" {
+		qualified = repo + "/" + job
+	}
+	for _, allowed := range b.AllowedJobs {
+		if allowed == qualified {
+			return true
+		}
+	}
+	if repo == "" {
+		return false
+	}
+	org, _, _ := strings.Cut(repo, "/")
+	for _, allowed := range b.AllowedRepos {
+		if allowed == repo || allowed == org {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSecretBundles returns an error if a job of the given name and
+// org/repo references secret bundles that do not exist or that it is not
+// allowed to use, either by name or through the secrets its pod spec uses.
+func checkSecretBundles(job, repo string, names []string, spec *v1.PodSpec, bundles map[string]SecretBundle) error {
+	var errs []error
+	for _, name := range names {
+		bundle, ok := bundles[name]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("job %s references secret bundle %q which is not defined", job, name))
+		case !bundle.Allows(job, repo):
+			errs = append(errs, fmt.Errorf("job %s of %q is not allowed to use secret bundle %q", job, repo, name))
+		}
+	}
+	if spec == nil {
+		return utilerrors.NewAggregate(errs)
+	}
+	allowed, forbidden := sets.New[string](), sets.New[string]()
+	for _, bundle := range bundles {
+		if bundle.Allows(job, repo) {
+			allowed.Insert(bundle.Secret)
+		} else {
+			forbidden.Insert(bundle.Secret)
+		}
+	}
+	forbidden = forbidden.Difference(allowed).Intersection(podSpecSecrets(spec))
+	for _, secret := range sets.List(forbidden) {
+		errs = append(errs, fmt.Errorf("job %s of %q is not allowed to use secret %q of a secret bundle", job, repo, secret))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// CheckSecretBundles returns an error if the ProwJob references secret
+// bundles that do not exist or that it is not allowed to use, by name or in
+// its pod spec. The ProwJob belongs to the repo of its refs, or of its first
+// extra refs if it has none.
+func (p *Plank) CheckSecretBundles(spec prowapi.ProwJobSpec) error {
+	return checkSecretBundles(spec.Job, jobRepo(&spec), spec.Secrets, spec.PodSpec, p.SecretBundles)
+}
+
+// mergeSecretBundles mounts the secret bundles that the job references into
+// the containers of its pod spec, after checking that the job of the given
+// org/repo may use them.
+func mergeSecretBundles(jb *JobBase, repo string, bundles map[string]SecretBundle) error {
+	if len(jb.Secrets) > 0 && jb.Spec == nil {
+		return fmt.Errorf("job %s references secret bundles but has no pod spec", jb.Name)
+	}
+	if err := checkSecretBundles(jb.Name, repo, jb.Secrets, jb.Spec, bundles); err != nil {
+		return err
+	}
+	if len(jb.Secrets) == 0 {
+		return nil
+	}
+
+	spec := jb.Spec
+	for _, name := range jb.Secrets {
+		bundle := bundles[name]
+		volumeName := "secret-bundle-" + name
+		if !hasVolume(spec.Volumes, volumeName) {
+			spec.Volumes = append(spec.Volumes, v1.Volume{
+				Name:         volumeName,
+				VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: bundle.Secret}},
+			})
+		}
+		mountPath := bundle.MountPath
+		if mountPath == "" {
+			mountPath = path.Join("/etc/secret-bundles", name)
+		}
+		envNames := sets.KeySet(bundle.Env).UnsortedList()
+		sort.Strings(envNames)
+		for i := range spec.Containers {
+			container := &spec.Containers[i]
+			if !hasVolumeMount(container.VolumeMounts, volumeName) {
+				container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{Name: volumeName, MountPath: mountPath, ReadOnly: true})
+			}
+			for _, envName := range envNames {
+				if hasEnv(container.Env, envName) {
+					continue
+				}
+				container.Env = append(container.Env, v1.EnvVar{
+					Name: envName,
+					ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: bundle.Secret},
+						Key:                  bundle.Env[envName],
+					}},
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// refuseSecretBundles returns an error if the job, which was loaded from
+// in-repo config, references secret bundles or uses their secrets in its pod
+// spec. Repos could otherwise name their jobs after allowed jobs.
+func refuseSecretBundles(jb *JobBase, bundles map[string]SecretBundle) error {
+	if len(jb.Secrets) > 0 {
+		return fmt.Errorf("job %s is defined in-repo and may not use secret bundles", jb.Name)
+	}
+	if jb.Spec == nil {
+		return nil
+	}
+	secrets := sets.New[string]()
+	for _, bundle := range bundles {
+		secrets.Insert(bundle.Secret)
+	}
+	if used := sets.List(secrets.Intersection(podSpecSecrets(jb.Spec))); len(used) > 0 {
+		return fmt.Errorf("job %s is defined in-repo and may not use secrets %q of secret bundles", jb.Name, used)
+	}
+	return nil
+}
+
+func hasVolume(volumes []v1.Volume, name string) bool {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeMount(mounts []v1.VolumeMount, name string) bool {
+	for _, mount := range mounts {
+		if mount.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnv(env []v1.EnvVar, name string) bool {
+	for _, e := range env {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// +k8s:deepcopy-gen=true
+
 // JobBase contains attributes common to all job types
 type JobBase struct {
 	// The name of the job. Must match regex [A-Za-z0-9-._]+
@@ -135,6 +391,27 @@ type JobBase struct {
 	// Works in parallel with MaxConcurrency and the limit is selected from the
 	// minimal setting of those two fields.
 	JobQueueName string `json:"job_queue_name,omitempty"`
+	// ArtifactRetention is how long the artifacts of the job are kept. It
+	// takes precedence over the artifact_retention policies of the Prow
+	// config.
+	ArtifactRetention *prowapi.JobArtifactRetention `json:"artifact_retention,omitempty"`
+	// Secrets are the names of secret bundles of the plank config whose
+	// secrets are mounted into the containers of the job. The job must be
+	// allowed to use them by the allowlists of the bundles.
+	Secrets []string `json:"secrets,omitempty"`
+	// Matrix expands the job into one job per combination of the axis values
+	// when the config is loaded. Like in job templates, every string of the
+	// job, e.g. its name, args or env values, is a Go template that can
+	// reference the axes, e.g. `e2e-{{.provider}}`.
+	Matrix map[string][]string `json:"matrix,omitempty"`
+	// TriggerOverrides lists what messages triggering the job through sub
+	// may override. Messages may override all envs and labels of jobs
+	// without it, unless their pubsub trigger restricts overrides.
+	TriggerOverrides *TriggerOverrides `json:"trigger_overrides,omitempty"`
+	// PodTemplate is the name of one of the plank.pod_templates of the
+	// cluster of the job. Its settings are merged into the pod spec when the
+	// config is loaded.
+	PodTemplate string `json:"pod_template,omitempty"`
 
 	UtilityConfig
 }
@@ -208,6 +485,8 @@ type Presubmit struct {
 
 	RegexpChangeMatcher
 
+	GerritChangeMatcher
+
 	Reporter
 
 	JenkinsSpec *JenkinsSpec `json:"jenkins_spec,omitempty"`
@@ -258,6 +537,8 @@ type Postsubmit struct {
 
 	RegexpChangeMatcher
 
+	GerritChangeMatcher
+
 	Brancher
 
 	// TODO(krzyzacy): Move existing `Report` into `Skip_Report` once this is deployed
@@ -266,6 +547,8 @@ type Postsubmit struct {
 	JenkinsSpec *JenkinsSpec `json:"jenkins_spec,omitempty"`
 }
 
+// +k8s:deepcopy-gen=true
+
 // Periodic runs on a timer.
 type Periodic struct {
 	JobBase
@@ -279,6 +562,10 @@ type Periodic struct {
 	MinimumInterval string `json:"minimum_interval,omitempty"`
 	// Cron representation of job trigger time
 	Cron string `json:"cron,omitempty"`
+	// RunAt schedules a single run of the job at the given time, e.g.
+	// `2024-08-01T16:00:00Z`. The job is triggered once as soon as this time
+	// has passed and is never triggered again unless run_at is changed.
+	RunAt *metav1.Time `json:"run_at,omitempty"`
 	// Tags for config entries
 	Tags []string `json:"tags,omitempty"`
 
@@ -344,6 +631,20 @@ type RegexpChangeMatcher struct {
 	reChanges         *CopyableRegexp // from RunIfChanged xor SkipIfOnlyChanged
 }
 
+// +k8s:deepcopy-gen=true
+
+// GerritChangeMatcher is for jobs that run only on Gerrit changes with certain
+// hashtags or topics. It is ignored for GitHub.
+type GerritChangeMatcher struct {
+	// GerritHashtags makes the job only run on changes that have any of
+	// these hashtags. Adding one of them to a change triggers the job.
+	GerritHashtags []string `json:"gerrit_hashtags,omitempty"`
+	// GerritTopics makes the job only run on changes whose topic is one of
+	// these. Setting the topic of a change to one of them triggers the job.
+	// Changes match if they match either GerritHashtags or GerritTopics.
+	GerritTopics []string `json:"gerrit_topics,omitempty"`
+}
+
 type Reporter struct {
 	// Context is the name of the GitHub status context for the job.
 	// Defaults: the same as the name of the job.
@@ -435,6 +736,38 @@ func (cm RegexpChangeMatcher) RunsAgainstChanges(changes []string) bool {
 	return false
 }
 
+// CouldRun determines if the job runs only on some changes.
+func (cm GerritChangeMatcher) CouldRun() bool {
+	return len(cm.GerritHashtags) > 0 || len(cm.GerritTopics) > 0
+}
+
+// Matches determines if the job should run on a change with the hashtags and
+// topic, and returns the hashtags and topic it matched. All changes match if
+// neither hashtags nor topics are configured. Gerrit compares hashtags and
+// topics case-insensitively, so this does as well.
+func (cm GerritChangeMatcher) Matches(hashtags []string, topic string) (matches bool, matchedHashtags []string, matchedTopic string) {
+	if !cm.CouldRun() {
+		return true, nil, ""
+	}
+	for _, hashtag := range hashtags {
+		for _, wanted := range cm.GerritHashtags {
+			if strings.EqualFold(hashtag, wanted) {
+				matchedHashtags = append(matchedHashtags, hashtag)
+				break
+			}
+		}
+	}
+	if topic != "" {
+		for _, wanted := range cm.GerritTopics {
+			if strings.EqualFold(topic, wanted) {
+				matchedTopic = topic
+				break
+			}
+		}
+	}
+	return len(matchedHashtags) > 0 || matchedTopic != "", matchedHashtags, matchedTopic
+}
+
 // CouldRun determines if the postsubmit could run against a specific
 // base ref
 func (ps Postsubmit) CouldRun(baseRef string) bool {
@@ -515,6 +848,12 @@ func (ps Presubmit) ContextRequired() bool {
 	return !ps.Optional && !ps.SkipReport
 }
 
+// RequiredForMerge checks whether Tide needs the presubmit to pass before
+// merging, i.e. whether /test-required and /retest-required run it.
+func (ps Presubmit) RequiredForMerge() bool {
+	return ps.ContextRequired() || ps.RunBeforeMerge
+}
+
 // ChangedFilesProvider returns a slice of modified files.
 type ChangedFilesProvider func() ([]string, error)
 
@@ -567,6 +906,9 @@ type UtilityConfig struct {
 	// SkipFetchHead tells prow to avoid a git fetch <remote> call.
 	// The git fetch <remote> <BaseRef> call occurs regardless.
 	SkipFetchHead bool `json:"skip_fetch_head,omitempty"`
+	// CloneFilter is the partial clone filter that the repository is
+	// fetched with, e.g. blob:none.
+	CloneFilter string `json:"clone_filter,omitempty"`
 
 	// ExtraRefs are auxiliary repositories that
 	// need to be cloned, determined from config
@@ -603,10 +945,17 @@ func (u *UtilityConfig) Validate() error {
 		return err
 	}
 
+	if err := prowapi.ValidateCloneFilter(u.CloneFilter); err != nil {
+		return fmt.Errorf("clone_filter: %w", err)
+	}
+
 	for i, ref := range u.ExtraRefs {
 		if err := cloneURIValidate(ref.CloneURI); err != nil {
 			return fmt.Errorf("extra_ref[%d]: %w", i, err)
 		}
+		if err := prowapi.ValidateCloneFilter(ref.Filter); err != nil {
+			return fmt.Errorf("extra_ref[%d]: filter: %w", i, err)
+		}
 	}
 
 	return nil
@@ -699,6 +1048,28 @@ func (c *JobConfig) AllPeriodics() []Periodic {
 	return listPeriodic(c.Periodics)
 }
 
+// StaticJobBases returns the JobBase of every static presubmit, postsubmit
+// and periodic with the given name.
+func (c *JobConfig) StaticJobBases(name string) []JobBase {
+	var res []JobBase
+	for _, ps := range c.AllStaticPresubmits(nil) {
+		if ps.Name == name {
+			res = append(res, ps.JobBase)
+		}
+	}
+	for _, ps := range c.AllStaticPostsubmits(nil) {
+		if ps.Name == name {
+			res = append(res, ps.JobBase)
+		}
+	}
+	for _, p := range c.AllPeriodics() {
+		if p.Name == name {
+			res = append(res, p.JobBase)
+		}
+	}
+	return res
+}
+
 // ClearCompiledRegexes removes compiled regexes from the presubmits,
 // useful for testing when deep equality is needed between presubmits
 func ClearCompiledRegexes(presubmits []Presubmit) {
@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	coreapi "k8s.io/api/core/v1"
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
@@ -170,6 +171,64 @@ func TestSkipIfOnlyChangedPostsubmits(t *testing.T) {
 	}
 }
 
+func TestGerritChangeMatcherMatches(t *testing.T) {
+	var testcases = []struct {
+		name             string
+		matcher          GerritChangeMatcher
+		hashtags         []string
+		topic            string
+		expected         bool
+		expectedHashtags []string
+		expectedTopic    string
+	}{
+		{
+			name:     "unconfigured matcher matches all changes",
+			hashtags: []string{"perf"},
+			expected: true,
+		},
+		{
+			name:             "hashtag matches case-insensitively",
+			matcher:          GerritChangeMatcher{GerritHashtags: []string{"perf", "gpu"}},
+			hashtags:         []string{"Perf", "other"},
+			expected:         true,
+			expectedHashtags: []string{"Perf"},
+		},
+		{
+			name:          "topic matches",
+			matcher:       GerritChangeMatcher{GerritHashtags: []string{"perf"}, GerritTopics: []string{"release"}},
+			topic:         "release",
+			expected:      true,
+			expectedTopic: "release",
+		},
+		{
+			name:     "neither hashtags nor topic match",
+			matcher:  GerritChangeMatcher{GerritHashtags: []string{"perf"}, GerritTopics: []string{"release"}},
+			hashtags: []string{"other"},
+			topic:    "feature",
+			expected: false,
+		},
+		{
+			name:     "change without hashtags and topic doesn't match",
+			matcher:  GerritChangeMatcher{GerritTopics: []string{"release"}},
+			expected: false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, hashtags, topic := tc.matcher.Matches(tc.hashtags, tc.topic)
+			if matches != tc.expected {
+				t.Errorf("expected matches to be %v, got %v", tc.expected, matches)
+			}
+			if !reflect.DeepEqual(hashtags, tc.expectedHashtags) {
+				t.Errorf("expected matched hashtags %v, got %v", tc.expectedHashtags, hashtags)
+			}
+			if topic != tc.expectedTopic {
+				t.Errorf("expected matched topic %q, got %q", tc.expectedTopic, topic)
+			}
+		})
+	}
+}
+
 func TestListPresubmit(t *testing.T) {
 	c := &Config{
 		JobConfig: JobConfig{
@@ -614,6 +673,280 @@ func TestMergePreset(t *testing.T) {
 	}
 }
 
+func TestMergePodTemplate(t *testing.T) {
+	gvisor, kata := "gvisor", "kata"
+	templates := map[string]map[string]PodTemplate{
+		"build01": {
+			"gpu": {
+				NodeSelector: map[string]string{"accelerator": "nvidia-tesla-t4"},
+				Tolerations: []coreapi.Toleration{
+					{Key: "nvidia.com/gpu", Operator: coreapi.TolerationOpExists, Effect: coreapi.TaintEffectNoSchedule},
+				},
+				RuntimeClassName: &gvisor,
+				DNSPolicy:        coreapi.DNSDefault,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		job         JobBase
+		expected    *coreapi.PodSpec
+		expectedErr bool
+	}{
+		{
+			name:     "no template",
+			job:      JobBase{Name: "job", Cluster: "build01", Spec: &coreapi.PodSpec{}},
+			expected: &coreapi.PodSpec{},
+		},
+		{
+			name: "template is merged",
+			job: JobBase{Name: "job", Cluster: "build01", PodTemplate: "gpu", Spec: &coreapi.PodSpec{
+				NodeSelector: map[string]string{"disk": "ssd"},
+				Tolerations:  []coreapi.Toleration{{Key: "dedicated", Operator: coreapi.TolerationOpEqual, Value: "ci"}},
+			}},
+			expected: &coreapi.PodSpec{
+				NodeSelector: map[string]string{"disk": "ssd", "accelerator": "nvidia-tesla-t4"},
+				Tolerations: []coreapi.Toleration{
+					{Key: "dedicated", Operator: coreapi.TolerationOpEqual, Value: "ci"},
+					{Key: "nvidia.com/gpu", Operator: coreapi.TolerationOpExists, Effect: coreapi.TaintEffectNoSchedule},
+				},
+				RuntimeClassName: &gvisor,
+				DNSPolicy:        coreapi.DNSDefault,
+			},
+		},
+		{
+			name: "merging again changes nothing",
+			job: JobBase{Name: "job", Cluster: "build01", PodTemplate: "gpu", Spec: &coreapi.PodSpec{
+				NodeSelector: map[string]string{"accelerator": "nvidia-tesla-t4"},
+				Tolerations: []coreapi.Toleration{
+					{Key: "nvidia.com/gpu", Operator: coreapi.TolerationOpExists, Effect: coreapi.TaintEffectNoSchedule},
+				},
+				RuntimeClassName: &gvisor,
+				DNSPolicy:        coreapi.DNSDefault,
+			}},
+			expected: &coreapi.PodSpec{
+				NodeSelector: map[string]string{"accelerator": "nvidia-tesla-t4"},
+				Tolerations: []coreapi.Toleration{
+					{Key: "nvidia.com/gpu", Operator: coreapi.TolerationOpExists, Effect: coreapi.TaintEffectNoSchedule},
+				},
+				RuntimeClassName: &gvisor,
+				DNSPolicy:        coreapi.DNSDefault,
+			},
+		},
+		{
+			name:        "template of another cluster",
+			job:         JobBase{Name: "job", Cluster: "default", PodTemplate: "gpu", Spec: &coreapi.PodSpec{}},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown template",
+			job:         JobBase{Name: "job", Cluster: "build01", PodTemplate: "arm64", Spec: &coreapi.PodSpec{}},
+			expectedErr: true,
+		},
+		{
+			name:        "job without pod spec",
+			job:         JobBase{Name: "job", Cluster: "build01", PodTemplate: "gpu"},
+			expectedErr: true,
+		},
+		{
+			name: "conflicting node selector",
+			job: JobBase{Name: "job", Cluster: "build01", PodTemplate: "gpu", Spec: &coreapi.PodSpec{
+				NodeSelector: map[string]string{"accelerator": "nvidia-tesla-a100"},
+			}},
+			expectedErr: true,
+		},
+		{
+			name:        "conflicting runtime class",
+			job:         JobBase{Name: "job", Cluster: "build01", PodTemplate: "gpu", Spec: &coreapi.PodSpec{RuntimeClassName: &kata}},
+			expectedErr: true,
+		},
+		{
+			name:        "conflicting DNS policy",
+			job:         JobBase{Name: "job", Cluster: "build01", PodTemplate: "gpu", Spec: &coreapi.PodSpec{DNSPolicy: coreapi.DNSClusterFirst}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := mergePodTemplate(&tc.job, templates)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr {
+				return
+			}
+			if !reflect.DeepEqual(tc.expected, tc.job.Spec) {
+				t.Errorf("expected pod spec %#v, got %#v", tc.expected, tc.job.Spec)
+			}
+		})
+	}
+}
+
+func TestMergeSecretBundles(t *testing.T) {
+	bundles := map[string]SecretBundle{
+		"gcp": {
+			Secret:       "gcp-service-account",
+			Env:          map[string]string{"GOOGLE_PROJECT": "project"},
+			AllowedRepos: []string{"kubernetes"},
+		},
+		"release": {
+			Secret:       "release-token",
+			MountPath:    "/etc/release",
+			AllowedRepos: []string{"kubernetes-sigs/release-utils"},
+			AllowedJobs:  []string{"kubernetes/release/ci-release-nightly", "ci-unowned"},
+		},
+	}
+	gcpMount := coreapi.VolumeMount{Name: "secret-bundle-gcp", MountPath: "/etc/secret-bundles/gcp", ReadOnly: true}
+	gcpVolume := coreapi.Volume{Name: "secret-bundle-gcp", VolumeSource: coreapi.VolumeSource{Secret: &coreapi.SecretVolumeSource{SecretName: "gcp-service-account"}}}
+	gcpEnv := coreapi.EnvVar{Name: "GOOGLE_PROJECT", ValueFrom: &coreapi.EnvVarSource{SecretKeyRef: &coreapi.SecretKeySelector{
+		LocalObjectReference: coreapi.LocalObjectReference{Name: "gcp-service-account"},
+		Key:                  "project",
+	}}}
+	releaseMount := coreapi.VolumeMount{Name: "secret-bundle-release", MountPath: "/etc/release", ReadOnly: true}
+	releaseVolume := coreapi.Volume{Name: "secret-bundle-release", VolumeSource: coreapi.VolumeSource{Secret: &coreapi.SecretVolumeSource{SecretName: "release-token"}}}
+
+	testCases := []struct {
+		name        string
+		job         JobBase
+		repo        string
+		expected    *coreapi.PodSpec
+		expectedErr bool
+	}{
+		{
+			name:     "no secrets",
+			job:      JobBase{Name: "job", Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}}},
+			repo:     "kubernetes/kubernetes",
+			expected: &coreapi.PodSpec{Containers: []coreapi.Container{{}}},
+		},
+		{
+			name: "bundles allowed for the org and for the job are mounted into all containers",
+			job:  JobBase{Name: "ci-release-nightly", Secrets: []string{"gcp", "release"}, Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{Name: "a"}, {Name: "b"}}}},
+			repo: "kubernetes/release",
+			expected: &coreapi.PodSpec{
+				Containers: []coreapi.Container{
+					{Name: "a", Env: []coreapi.EnvVar{gcpEnv}, VolumeMounts: []coreapi.VolumeMount{gcpMount, releaseMount}},
+					{Name: "b", Env: []coreapi.EnvVar{gcpEnv}, VolumeMounts: []coreapi.VolumeMount{gcpMount, releaseMount}},
+				},
+				Volumes: []coreapi.Volume{gcpVolume, releaseVolume},
+			},
+		},
+		{
+			name: "merging is idempotent",
+			job: JobBase{Name: "job", Secrets: []string{"gcp"}, Spec: &coreapi.PodSpec{
+				Containers: []coreapi.Container{{Env: []coreapi.EnvVar{gcpEnv}, VolumeMounts: []coreapi.VolumeMount{gcpMount}}},
+				Volumes:    []coreapi.Volume{gcpVolume},
+			}},
+			repo: "kubernetes/kubernetes",
+			expected: &coreapi.PodSpec{
+				Containers: []coreapi.Container{{Env: []coreapi.EnvVar{gcpEnv}, VolumeMounts: []coreapi.VolumeMount{gcpMount}}},
+				Volumes:    []coreapi.Volume{gcpVolume},
+			},
+		},
+		{
+			name:        "repo is not allowed",
+			job:         JobBase{Name: "job", Secrets: []string{"release"}, Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}}},
+			repo:        "kubernetes/kubernetes",
+			expectedErr: true,
+		},
+		{
+			name:        "job of the same name in another repo is not allowed",
+			job:         JobBase{Name: "ci-release-nightly", Secrets: []string{"release"}, Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}}},
+			repo:        "other/repo",
+			expectedErr: true,
+		},
+		{
+			name:     "job without repo is allowed by its bare name",
+			job:      JobBase{Name: "ci-unowned", Secrets: []string{"release"}, Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}}},
+			expected: &coreapi.PodSpec{Containers: []coreapi.Container{{VolumeMounts: []coreapi.VolumeMount{releaseMount}}}, Volumes: []coreapi.Volume{releaseVolume}},
+		},
+		{
+			name:        "job with a repo is not allowed by its bare name",
+			job:         JobBase{Name: "ci-unowned", Secrets: []string{"release"}, Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}}},
+			repo:        "other/repo",
+			expectedErr: true,
+		},
+		{
+			name:        "job without repo is not allowed",
+			job:         JobBase{Name: "job", Secrets: []string{"gcp"}, Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}}},
+			expectedErr: true,
+		},
+		{
+			name:        "bundle is not defined",
+			job:         JobBase{Name: "job", Secrets: []string{"aws"}, Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}}},
+			repo:        "kubernetes/kubernetes",
+			expectedErr: true,
+		},
+		{
+			name:     "secret of an allowed bundle may be used directly",
+			job:      JobBase{Name: "job", Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}, Volumes: []coreapi.Volume{gcpVolume}}},
+			repo:     "kubernetes/kubernetes",
+			expected: &coreapi.PodSpec{Containers: []coreapi.Container{{}}, Volumes: []coreapi.Volume{gcpVolume}},
+		},
+		{
+			name:        "secret of a bundle that is not allowed may not be used directly",
+			job:         JobBase{Name: "job", Spec: &coreapi.PodSpec{Containers: []coreapi.Container{{}}, Volumes: []coreapi.Volume{releaseVolume}}},
+			repo:        "kubernetes/kubernetes",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := mergeSecretBundles(&tc.job, tc.repo, bundles)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr {
+				return
+			}
+			if diff := cmp.Diff(tc.expected, tc.job.Spec); diff != "" {
+				t.Errorf("pod spec differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckSecretBundles(t *testing.T) {
+	plank := Plank{SecretBundles: map[string]SecretBundle{
+		"gcp": {Secret: "gcp-service-account", AllowedRepos: []string{"kubernetes/test-infra"}},
+	}}
+	testCases := []struct {
+		name        string
+		spec        prowapi.ProwJobSpec
+		expectedErr bool
+	}{
+		{
+			name: "no secrets",
+			spec: prowapi.ProwJobSpec{Job: "job"},
+		},
+		{
+			name: "refs of an allowed repo",
+			spec: prowapi.ProwJobSpec{Job: "job", Secrets: []string{"gcp"}, Refs: &prowapi.Refs{Org: "kubernetes", Repo: "test-infra"}},
+		},
+		{
+			name: "extra refs of an allowed repo",
+			spec: prowapi.ProwJobSpec{Job: "job", Secrets: []string{"gcp"}, ExtraRefs: []prowapi.Refs{{Org: "kubernetes", Repo: "test-infra"}}},
+		},
+		{
+			name:        "refs of another repo",
+			spec:        prowapi.ProwJobSpec{Job: "job", Secrets: []string{"gcp"}, Refs: &prowapi.Refs{Org: "kubernetes", Repo: "kubernetes"}},
+			expectedErr: true,
+		},
+		{
+			name:        "no refs",
+			spec:        prowapi.ProwJobSpec{Job: "job", Secrets: []string{"gcp"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := plank.CheckSecretBundles(tc.spec); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
 func TestPresubmitShouldRun(t *testing.T) {
 	var testCases = []struct {
 		name        string
@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// JobTemplate is a reusable job definition. Any string field of the job may
+// reference the template parameters using Go template syntax, e.g.
+// `name: "{{.component}}-unit"`. Exactly one of Presubmit, Postsubmit or
+// Periodic must be set.
+type JobTemplate struct {
+	// Name is used by TemplatedJobs to refer to this template.
+	Name string `json:"name"`
+	// Parameters declares the parameters accepted by the template.
+	Parameters []JobTemplateParameter `json:"parameters,omitempty"`
+
+	Presubmit  *Presubmit  `json:"presubmit,omitempty"`
+	Postsubmit *Postsubmit `json:"postsubmit,omitempty"`
+	Periodic   *Periodic   `json:"periodic,omitempty"`
+}
+
+// JobTemplateParameter declares a parameter of a JobTemplate.
+type JobTemplateParameter struct {
+	Name string `json:"name"`
+	// Default is used when the instance does not provide a value. Parameters
+	// without a default are required.
+	Default *string `json:"default,omitempty"`
+}
+
+// TemplatedJob instantiates a JobTemplate.
+type TemplatedJob struct {
+	// Template is the name of the JobTemplate to instantiate.
+	Template string `json:"template"`
+	// Repo is the org/repo the resulting job is configured for. It is required
+	// for presubmit and postsubmit templates and forbidden for periodics.
+	Repo string `json:"repo,omitempty"`
+	// Parameters holds the values for the template parameters.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+func (t *JobTemplate) validate() error {
+	if t.Name == "" {
+		return errors.New("job template must have a name")
+	}
+	set := 0
+	for _, job := range []bool{t.Presubmit != nil, t.Postsubmit != nil, t.Periodic != nil} {
+		if job {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("job template %s must define exactly one of presubmit, postsubmit, or periodic", t.Name)
+	}
+	seen := sets.New[string]()
+	for _, p := range t.Parameters {
+		if p.Name == "" {
+			return fmt.Errorf("job template %s has a parameter without a name", t.Name)
+		}
+		if seen.Has(p.Name) {
+			return fmt.Errorf("job template %s declares parameter %s more than once", t.Name, p.Name)
+		}
+		seen.Insert(p.Name)
+	}
+	return nil
+}
+
+// resolveParameters merges the instance values with the template defaults.
+func (t *JobTemplate) resolveParameters(values map[string]string) (map[string]string, error) {
+	resolved := map[string]string{}
+	declared := sets.New[string]()
+	var errs []error
+	for _, p := range t.Parameters {
+		declared.Insert(p.Name)
+		if value, ok := values[p.Name]; ok {
+			resolved[p.Name] = value
+		} else if p.Default != nil {
+			resolved[p.Name] = *p.Default
+		} else {
+			errs = append(errs, fmt.Errorf("missing value for required parameter %s", p.Name))
+		}
+	}
+	for name := range values {
+		if !declared.Has(name) {
+			errs = append(errs, fmt.Errorf("unknown parameter %s", name))
+		}
+	}
+	return resolved, utilerrors.NewAggregate(errs)
+}
+
+// expandJobTemplates instantiates all TemplatedJobs into concrete jobs.
+func (c *JobConfig) expandJobTemplates() error {
+	templates := map[string]JobTemplate{}
+	var errs []error
+	for _, t := range c.JobTemplates {
+		if err := t.validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, exists := templates[t.Name]; exists {
+			errs = append(errs, fmt.Errorf("duplicated job template: %s", t.Name))
+			continue
+		}
+		templates[t.Name] = t
+	}
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	for i, instance := range c.TemplatedJobs {
+		if err := c.expandTemplatedJob(templates, instance); err != nil {
+			errs = append(errs, fmt.Errorf("templated job %d (template %q): %w", i, instance.Template, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *JobConfig) expandTemplatedJob(templates map[string]JobTemplate, instance TemplatedJob) error {
+	t, ok := templates[instance.Template]
+	if !ok {
+		return errors.New("no such job template")
+	}
+	params, err := t.resolveParameters(instance.Parameters)
+	if err != nil {
+		return err
+	}
+	if t.Periodic != nil {
+		if instance.Repo != "" {
+			return errors.New("repo must not be set for periodic templates")
+		}
+		var periodic Periodic
+		if err := instantiateJobTemplate(t.Periodic, params, &periodic); err != nil {
+			return err
+		}
+		c.Periodics = append(c.Periodics, periodic)
+		return nil
+	}
+
+	if instance.Repo == "" {
+		return errors.New("repo must be set for presubmit and postsubmit templates")
+	}
+	if _, _, err := SplitRepoName(instance.Repo); err != nil {
+		return fmt.Errorf("invalid repo: %w", err)
+	}
+	if t.Presubmit != nil {
+		var presubmit Presubmit
+		if err := instantiateJobTemplate(t.Presubmit, params, &presubmit); err != nil {
+			return err
+		}
+		if c.PresubmitsStatic == nil {
+			c.PresubmitsStatic = map[string][]Presubmit{}
+		}
+		c.PresubmitsStatic[instance.Repo] = append(c.PresubmitsStatic[instance.Repo], presubmit)
+		return nil
+	}
+	var postsubmit Postsubmit
+	if err := instantiateJobTemplate(t.Postsubmit, params, &postsubmit); err != nil {
+		return err
+	}
+	if c.PostsubmitsStatic == nil {
+		c.PostsubmitsStatic = map[string][]Postsubmit{}
+	}
+	c.PostsubmitsStatic[instance.Repo] = append(c.PostsubmitsStatic[instance.Repo], postsubmit)
+	return nil
+}
+
+// instantiateJobTemplate substitutes the parameters into every string of the
+// job and stores the result in out. The template job itself is not mutated.
+func instantiateJobTemplate(job interface{}, params map[string]string, out interface{}) error {
+	raw, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job template: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return fmt.Errorf("failed to unmarshal job template: %w", err)
+	}
+	substituted, err := substituteParameters(generic, params)
+	if err != nil {
+		return err
+	}
+	if raw, err = json.Marshal(substituted); err != nil {
+		return fmt.Errorf("failed to marshal instantiated job: %w", err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("failed to unmarshal instantiated job: %w", err)
+	}
+	return nil
+}
+
+func substituteParameters(in interface{}, params map[string]string) (interface{}, error) {
+	switch v := in.(type) {
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		tmpl, err := template.New("job").Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %w", v, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, params); err != nil {
+			return nil, fmt.Errorf("failed to substitute parameters in %q: %w", v, err)
+		}
+		return buf.String(), nil
+	case map[string]interface{}:
+		for key, value := range v {
+			substituted, err := substituteParameters(value, params)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = substituted
+		}
+		return v, nil
+	case []interface{}:
+		for i, value := range v {
+			substituted, err := substituteParameters(value, params)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = substituted
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestExpandJobTemplates(t *testing.T) {
+	defaultGoVersion := "1.22"
+	unitTemplate := JobTemplate{
+		Name: "go-unit",
+		Parameters: []JobTemplateParameter{
+			{Name: "component"},
+			{Name: "go_version", Default: &defaultGoVersion},
+		},
+		Presubmit: &Presubmit{
+			JobBase: JobBase{
+				Name: "pull-{{.component}}-unit",
+				Spec: &v1.PodSpec{Containers: []v1.Container{{
+					Image:   "golang:{{.go_version}}",
+					Command: []string{"go", "test", "./{{.component}}/..."},
+				}}},
+			},
+			AlwaysRun: true,
+		},
+	}
+	nightlyTemplate := JobTemplate{
+		Name:       "nightly",
+		Parameters: []JobTemplateParameter{{Name: "component"}},
+		Periodic: &Periodic{
+			JobBase: JobBase{Name: "ci-{{.component}}-nightly"},
+			Cron:    "0 3 * * *",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		config        JobConfig
+		expected      JobConfig
+		expectedError string
+	}{
+		{
+			name: "presubmit template is instantiated with defaults",
+			config: JobConfig{
+				JobTemplates: []JobTemplate{unitTemplate},
+				TemplatedJobs: []TemplatedJob{
+					{Template: "go-unit", Repo: "org/repo", Parameters: map[string]string{"component": "api"}},
+					{Template: "go-unit", Repo: "org/repo", Parameters: map[string]string{"component": "cli", "go_version": "1.21"}},
+				},
+			},
+			expected: JobConfig{
+				PresubmitsStatic: map[string][]Presubmit{"org/repo": {
+					{
+						JobBase: JobBase{
+							Name: "pull-api-unit",
+							Spec: &v1.PodSpec{Containers: []v1.Container{{
+								Image:   "golang:1.22",
+								Command: []string{"go", "test", "./api/..."},
+							}}},
+						},
+						AlwaysRun: true,
+					},
+					{
+						JobBase: JobBase{
+							Name: "pull-cli-unit",
+							Spec: &v1.PodSpec{Containers: []v1.Container{{
+								Image:   "golang:1.21",
+								Command: []string{"go", "test", "./cli/..."},
+							}}},
+						},
+						AlwaysRun: true,
+					},
+				}},
+			},
+		},
+		{
+			name: "periodic template is instantiated",
+			config: JobConfig{
+				JobTemplates:  []JobTemplate{nightlyTemplate},
+				TemplatedJobs: []TemplatedJob{{Template: "nightly", Parameters: map[string]string{"component": "api"}}},
+			},
+			expected: JobConfig{
+				Periodics: []Periodic{{JobBase: JobBase{Name: "ci-api-nightly"}, Cron: "0 3 * * *"}},
+			},
+		},
+		{
+			name: "missing required parameter",
+			config: JobConfig{
+				JobTemplates:  []JobTemplate{unitTemplate},
+				TemplatedJobs: []TemplatedJob{{Template: "go-unit", Repo: "org/repo"}},
+			},
+			expectedError: `templated job 0 (template "go-unit"): missing value for required parameter component`,
+		},
+		{
+			name: "unknown parameter",
+			config: JobConfig{
+				JobTemplates:  []JobTemplate{nightlyTemplate},
+				TemplatedJobs: []TemplatedJob{{Template: "nightly", Parameters: map[string]string{"component": "api", "arch": "arm64"}}},
+			},
+			expectedError: `templated job 0 (template "nightly"): unknown parameter arch`,
+		},
+		{
+			name: "unknown template",
+			config: JobConfig{
+				TemplatedJobs: []TemplatedJob{{Template: "nope", Repo: "org/repo"}},
+			},
+			expectedError: `templated job 0 (template "nope"): no such job template`,
+		},
+		{
+			name: "presubmit template requires a repo",
+			config: JobConfig{
+				JobTemplates:  []JobTemplate{unitTemplate},
+				TemplatedJobs: []TemplatedJob{{Template: "go-unit", Parameters: map[string]string{"component": "api"}}},
+			},
+			expectedError: `templated job 0 (template "go-unit"): repo must be set for presubmit and postsubmit templates`,
+		},
+		{
+			name: "periodic template forbids a repo",
+			config: JobConfig{
+				JobTemplates:  []JobTemplate{nightlyTemplate},
+				TemplatedJobs: []TemplatedJob{{Template: "nightly", Repo: "org/repo", Parameters: map[string]string{"component": "api"}}},
+			},
+			expectedError: `templated job 0 (template "nightly"): repo must not be set for periodic templates`,
+		},
+		{
+			name: "template must define exactly one job",
+			config: JobConfig{
+				JobTemplates: []JobTemplate{{Name: "empty"}},
+			},
+			expectedError: "job template empty must define exactly one of presubmit, postsubmit, or periodic",
+		},
+		{
+			name: "duplicated template",
+			config: JobConfig{
+				JobTemplates: []JobTemplate{nightlyTemplate, nightlyTemplate},
+			},
+			expectedError: "duplicated job template: nightly",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.expandJobTemplates()
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedError {
+				t.Fatalf("expected error %q, got %q", tc.expectedError, errMsg)
+			}
+			if tc.expectedError != "" {
+				return
+			}
+			if diff := cmp.Diff(tc.expected, tc.config, cmpopts.IgnoreFields(JobConfig{}, "JobTemplates", "TemplatedJobs"), cmpopts.IgnoreUnexported(Presubmit{}, Periodic{}, Brancher{}, RegexpChangeMatcher{})); diff != "" {
+				t.Errorf("expanded config differs from expected: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var matrixAxisRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// expandMatrices replaces all matrix jobs of the job config by the jobs they
+// expand to.
+func (c *JobConfig) expandMatrices() error {
+	var errs []error
+	for repo, presubmits := range c.PresubmitsStatic {
+		expanded, err := expandMatrixJobs(presubmits, func(p *Presubmit) *JobBase { return &p.JobBase })
+		errs = append(errs, err)
+		c.PresubmitsStatic[repo] = expanded
+	}
+	for repo, postsubmits := range c.PostsubmitsStatic {
+		expanded, err := expandMatrixJobs(postsubmits, func(p *Postsubmit) *JobBase { return &p.JobBase })
+		errs = append(errs, err)
+		c.PostsubmitsStatic[repo] = expanded
+	}
+	expanded, err := expandMatrixJobs(c.Periodics, func(p *Periodic) *JobBase { return &p.JobBase })
+	errs = append(errs, err)
+	c.Periodics = expanded
+	return utilerrors.NewAggregate(errs)
+}
+
+// expandMatrixJobs expands the matrix jobs in jobs, keeping other jobs as
+// they are. Like for job templates, the axes are substituted into every
+// string of the job.
+func expandMatrixJobs[T any, PT interface{ *T }](jobs []T, base func(PT) *JobBase) ([]T, error) {
+	if !slices.ContainsFunc(jobs, func(job T) bool { return len(base(&job).Matrix) > 0 }) {
+		return jobs, nil
+	}
+	var result []T
+	var errs []error
+	for i := range jobs {
+		job := base(&jobs[i])
+		if len(job.Matrix) == 0 {
+			result = append(result, jobs[i])
+			continue
+		}
+		combinations, err := matrixCombinations(job.Matrix)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("matrix job %s: %w", job.Name, err))
+			continue
+		}
+		names := map[string]map[string]string{}
+		for _, values := range combinations {
+			var expanded T
+			if err := instantiateJobTemplate(&jobs[i], values, &expanded); err != nil {
+				errs = append(errs, fmt.Errorf("matrix job %s: %w", job.Name, err))
+				break
+			}
+			expandedBase := base(&expanded)
+			expandedBase.Matrix = nil
+			expandedBase.SourcePath = job.SourcePath
+			if other, ok := names[expandedBase.Name]; ok {
+				errs = append(errs, fmt.Errorf("matrix job %s: combinations %v and %v both expand to %s, reference every axis in the name", job.Name, other, values, expandedBase.Name))
+				break
+			}
+			names[expandedBase.Name] = values
+			result = append(result, expanded)
+		}
+	}
+	return result, utilerrors.NewAggregate(errs)
+}
+
+// matrixCombinations returns the cartesian product of the axes. Axes are
+// iterated in alphabetical order and values in the order they were given.
+func matrixCombinations(matrix map[string][]string) ([]map[string]string, error) {
+	combinations := []map[string]string{{}}
+	for _, axis := range sets.List(sets.KeySet(matrix)) {
+		if !matrixAxisRegex.MatchString(axis) {
+			return nil, fmt.Errorf("axis %q must match %s", axis, matrixAxisRegex.String())
+		}
+		values := matrix[axis]
+		if len(values) == 0 {
+			return nil, fmt.Errorf("axis %s has no values", axis)
+		}
+		if sets.New(values...).Len() != len(values) {
+			return nil, fmt.Errorf("axis %s has duplicate values", axis)
+		}
+		var next []map[string]string
+		for _, combination := range combinations {
+			for _, value := range values {
+				extended := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					extended[k] = v
+				}
+				extended[axis] = value
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+	return combinations, nil
+}
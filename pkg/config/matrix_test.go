@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestExpandMatrixJobs(t *testing.T) {
+	spec := func(env string) *v1.PodSpec {
+		return &v1.PodSpec{Containers: []v1.Container{{Name: "test", Env: []v1.EnvVar{{Name: "TARGET", Value: env}}}}}
+	}
+	testCases := []struct {
+		name          string
+		periodics     []Periodic
+		expectedNames []string
+		expectedEnv   []string
+		expectErr     bool
+	}{
+		{
+			name:          "jobs without matrix are kept",
+			periodics:     []Periodic{{JobBase: JobBase{Name: "plain", Spec: spec("x")}}},
+			expectedNames: []string{"plain"},
+			expectedEnv:   []string{"x"},
+		},
+		{
+			name: "matrix is expanded in axis order",
+			periodics: []Periodic{
+				{JobBase: JobBase{Name: "before", Spec: spec("x")}},
+				{JobBase: JobBase{
+					Name:   "e2e-{{.provider}}-{{.k8s}}",
+					Matrix: map[string][]string{"provider": {"aws", "gcp"}, "k8s": {"1.29", "1.30"}},
+					Spec:   spec("{{.provider}}/{{.k8s}}"),
+				}},
+			},
+			expectedNames: []string{"before", "e2e-aws-1.29", "e2e-gcp-1.29", "e2e-aws-1.30", "e2e-gcp-1.30"},
+			expectedEnv:   []string{"x", "aws/1.29", "gcp/1.29", "aws/1.30", "gcp/1.30"},
+		},
+		{
+			name: "name not referencing every axis collides",
+			periodics: []Periodic{{JobBase: JobBase{
+				Name:   "e2e-{{.provider}}",
+				Matrix: map[string][]string{"provider": {"aws", "gcp"}, "k8s": {"1.29", "1.30"}},
+			}}},
+			expectErr: true,
+		},
+		{
+			name: "unknown axis in template",
+			periodics: []Periodic{{JobBase: JobBase{
+				Name:   "e2e-{{.arch}}",
+				Matrix: map[string][]string{"provider": {"aws"}},
+			}}},
+			expectErr: true,
+		},
+		{
+			name: "empty axis",
+			periodics: []Periodic{{JobBase: JobBase{
+				Name:   "e2e-{{.provider}}",
+				Matrix: map[string][]string{"provider": {}},
+			}}},
+			expectErr: true,
+		},
+		{
+			name: "invalid axis name",
+			periodics: []Periodic{{JobBase: JobBase{
+				Name:   "e2e",
+				Matrix: map[string][]string{"k8s-version": {"1.30"}},
+			}}},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jc := &JobConfig{Periodics: tc.periodics}
+			err := jc.expandMatrices()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			var names, env []string
+			for _, periodic := range jc.Periodics {
+				if periodic.Matrix != nil {
+					t.Errorf("job %s still has a matrix", periodic.Name)
+				}
+				names = append(names, periodic.Name)
+				env = append(env, periodic.Spec.Containers[0].Env[0].Value)
+			}
+			if diff := cmp.Diff(tc.expectedNames, names); diff != "" {
+				t.Errorf("names differ from expected: %s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedEnv, env); diff != "" {
+				t.Errorf("env differs from expected: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadMatrixJobs(t *testing.T) {
+	dir := t.TempDir()
+	jobConfig := `presubmits:
+  org/repo:
+  - name: "unit-{{.arch}}"
+    matrix:
+      arch: [amd64, arm64]
+    always_run: true
+    spec:
+      containers:
+      - image: alpine
+        env:
+        - name: GOARCH
+          value: "{{.arch}}"
+`
+	if err := os.WriteFile(filepath.Join(dir, "jobs.yaml"), []byte(jobConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prowConfig := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(prowConfig, []byte(""), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(prowConfig, dir, nil, "")
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	presubmits := c.PresubmitsStatic["org/repo"]
+	if len(presubmits) != 2 {
+		t.Fatalf("expected 2 presubmits, got %d", len(presubmits))
+	}
+	for i, arch := range []string{"amd64", "arm64"} {
+		if presubmits[i].Name != "unit-"+arch || presubmits[i].Context != "unit-"+arch {
+			t.Errorf("expected name and context unit-%s, got %s and %s", arch, presubmits[i].Name, presubmits[i].Context)
+		}
+		if env := presubmits[i].Spec.Containers[0].Env[0].Value; env != arch {
+			t.Errorf("expected GOARCH %s, got %s", arch, env)
+		}
+	}
+}
@@ -67,15 +67,51 @@ type Repo struct {
 	AllowRebaseMerge         *bool   `json:"allow_rebase_merge,omitempty"`
 	SquashMergeCommitTitle   *string `json:"squash_merge_commit_title,omitempty"`
 	SquashMergeCommitMessage *string `json:"squash_merge_commit_message,omitempty"`
+	AllowAutoMerge           *bool   `json:"allow_auto_merge,omitempty"`
+	DeleteBranchOnMerge      *bool   `json:"delete_branch_on_merge,omitempty"`
 
 	DefaultBranch *string `json:"default_branch,omitempty"`
 	Archived      *bool   `json:"archived,omitempty"`
 
+	// Topics replaces the repository topics when set. An empty list
+	// removes all topics, while leaving it unset leaves them alone.
+	Topics []string `json:"topics,omitempty"`
+	// VulnerabilityAlerts enables or disables dependency vulnerability alerts.
+	VulnerabilityAlerts *bool `json:"vulnerability_alerts,omitempty"`
+	// Webhooks are matched to existing repository webhooks by URL. Webhooks
+	// that are not configured here are left alone.
+	Webhooks []RepoWebhook `json:"webhooks,omitempty"`
+	// Autolinks replaces all autolink references of the repository when set.
+	Autolinks []Autolink `json:"autolinks,omitempty"`
+
 	Previously []string `json:"previously,omitempty"`
 
 	OnCreate *RepoCreateOptions `json:"on_create,omitempty"`
 }
 
+// RepoWebhook declares a webhook of a repository. The webhook secret is not
+// managed, use the hmac tool to rotate secrets.
+//
+// See https://docs.github.com/en/rest/repos/webhooks
+type RepoWebhook struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+	// ContentType is either json or form, defaults to json.
+	ContentType *string `json:"content_type,omitempty"`
+	// Active defaults to true.
+	Active *bool `json:"active,omitempty"`
+}
+
+// Autolink declares an autolink reference of a repository.
+//
+// See https://docs.github.com/en/rest/repos/autolinks
+type Autolink struct {
+	KeyPrefix string `json:"key_prefix"`
+	// URLTemplate must contain <num> for the reference number.
+	URLTemplate    string `json:"url_template"`
+	IsAlphanumeric *bool  `json:"is_alphanumeric,omitempty"`
+}
+
 // Config declares org metadata as well as its people and teams.
 type Config struct {
 	Metadata
@@ -108,6 +144,24 @@ type Team struct {
 	// https://developer.github.com/v3/teams/#list-team-repos
 	// https://developer.github.com/v3/teams/#add-or-update-team-repository
 	Repos map[string]github.RepoPermissionLevel `json:"repos,omitempty"`
+
+	// Sync fills the members and maintainers of the team from the groups of
+	// an external identity provider, see peribolos --team-sync-config.
+	Sync *TeamSync `json:"sync,omitempty"`
+}
+
+// TeamSync declares the identity provider groups a team is synced from.
+type TeamSync struct {
+	// Source is the name of the identity provider in the team sync config.
+	Source string `json:"source"`
+	// MembersGroup replaces the members of the team when set.
+	MembersGroup string `json:"members_group,omitempty"`
+	// MaintainersGroup replaces the maintainers of the team when set.
+	MaintainersGroup string `json:"maintainers_group,omitempty"`
+	// AllowEmpty allows the groups to have no members. Otherwise an empty
+	// group fails the sync, as it more likely is a misconfigured or broken
+	// lookup than a team that should lose all of its members.
+	AllowEmpty bool `json:"allow_empty,omitempty"`
 }
 
 // Privacy is secret or closed.
@@ -168,6 +222,8 @@ func PruneRepoDefaults(repo Repo) Repo {
 	pruneBool(&repo.AllowRebaseMerge, true)
 	pruneBool(&repo.AllowSquashMerge, true)
 	pruneBool(&repo.AllowMergeCommit, true)
+	pruneBool(&repo.AllowAutoMerge, false)
+	pruneBool(&repo.DeleteBranchOnMerge, false)
 
 	pruneBool(&repo.Archived, false)
 	pruneString(&repo.DefaultBranch, "master")
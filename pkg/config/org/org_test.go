@@ -78,46 +78,52 @@ func TestPruneRepoDefaults(t *testing.T) {
 		{
 			description: "default values are pruned",
 			repo: Repo{
-				Description:      &empty,
-				HomePage:         &empty,
-				Private:          &no,
-				HasIssues:        &yes,
-				HasProjects:      &yes,
-				HasWiki:          &yes,
-				AllowSquashMerge: &yes,
-				AllowMergeCommit: &yes,
-				AllowRebaseMerge: &yes,
-				DefaultBranch:    &master,
-				Archived:         &no,
+				Description:         &empty,
+				HomePage:            &empty,
+				Private:             &no,
+				HasIssues:           &yes,
+				HasProjects:         &yes,
+				HasWiki:             &yes,
+				AllowSquashMerge:    &yes,
+				AllowMergeCommit:    &yes,
+				AllowRebaseMerge:    &yes,
+				AllowAutoMerge:      &no,
+				DeleteBranchOnMerge: &no,
+				DefaultBranch:       &master,
+				Archived:            &no,
 			},
 			expected: Repo{HasProjects: &yes},
 		},
 		{
 			description: "non-default values are not pruned",
 			repo: Repo{
-				Description:      &nonEmpty,
-				HomePage:         &nonEmpty,
-				Private:          &yes,
-				HasIssues:        &no,
-				HasProjects:      &no,
-				HasWiki:          &no,
-				AllowSquashMerge: &no,
-				AllowMergeCommit: &no,
-				AllowRebaseMerge: &no,
-				DefaultBranch:    &notMaster,
-				Archived:         &yes,
+				Description:         &nonEmpty,
+				HomePage:            &nonEmpty,
+				Private:             &yes,
+				HasIssues:           &no,
+				HasProjects:         &no,
+				HasWiki:             &no,
+				AllowSquashMerge:    &no,
+				AllowMergeCommit:    &no,
+				AllowRebaseMerge:    &no,
+				AllowAutoMerge:      &yes,
+				DeleteBranchOnMerge: &yes,
+				DefaultBranch:       &notMaster,
+				Archived:            &yes,
 			},
 			expected: Repo{Description: &nonEmpty,
-				HomePage:         &nonEmpty,
-				Private:          &yes,
-				HasIssues:        &no,
-				HasProjects:      &no,
-				HasWiki:          &no,
-				AllowSquashMerge: &no,
-				AllowMergeCommit: &no,
-				AllowRebaseMerge: &no,
-				DefaultBranch:    &notMaster,
-				Archived:         &yes,
+				HomePage:            &nonEmpty,
+				Private:             &yes,
+				HasIssues:           &no,
+				HasProjects:         &no,
+				HasWiki:             &no,
+				AllowSquashMerge:    &no,
+				AllowMergeCommit:    &no,
+				AllowRebaseMerge:    &no,
+				AllowAutoMerge:      &yes,
+				DeleteBranchOnMerge: &yes,
+				DefaultBranch:       &notMaster,
+				Archived:            &yes,
 			},
 		},
 	}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/policy"
+)
+
+// JobPolicy is an organization-wide rule that every job has to satisfy.
+type JobPolicy struct {
+	// Name identifies the policy in violation reports.
+	Name string `json:"name"`
+	// Expression is a policy expression that must evaluate to true for every
+	// compliant job. The following variables are available:
+	//
+	//   job:         the ProwJob spec, using the field names of its JSON
+	//                representation (e.g. job.type, job.decoration_config).
+	//   org, repo:   the org and repo the job runs for, empty if unknown.
+	//   labels:      the labels of the job.
+	//   annotations: the annotations of the job.
+	//
+	// For example:
+	//
+	//   org != "my-org" || has(job.decoration_config)
+	//   !has(job.pod_spec.volumes) || job.pod_spec.volumes.all(v, !has(v.secret) || v.secret.secretName != "admin-token")
+	//
+	// The syntax follows CEL, but expressions are not type checked and only
+	// the functions and macros listed in pkg/policy are supported.
+	Expression string `json:"expression"`
+	// Message is shown to users when a job violates the policy.
+	Message string `json:"message,omitempty"`
+
+	compiled *policy.Expression
+}
+
+// JobPolicyViolation describes a job that does not satisfy a JobPolicy.
+type JobPolicyViolation struct {
+	Policy  string
+	Message string
+}
+
+func (v JobPolicyViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Policy, v.Message)
+}
+
+func validateJobPolicies(policies []JobPolicy) error {
+	var errs []error
+	names := sets.New[string]()
+	for i := range policies {
+		p := &policies[i]
+		if p.Name == "" {
+			errs = append(errs, fmt.Errorf("job policy %d has no name", i))
+			continue
+		}
+		if names.Has(p.Name) {
+			errs = append(errs, fmt.Errorf("duplicated job policy: %s", p.Name))
+		}
+		names.Insert(p.Name)
+		compiled, err := policy.Compile(p.Expression)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid expression for job policy %s: %w", p.Name, err))
+			continue
+		}
+		p.compiled = compiled
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// EvaluateJobPolicies returns all job policies the ProwJob violates. A policy
+// whose expression fails to evaluate for the job is considered violated.
+func (pc *ProwConfig) EvaluateJobPolicies(pj *prowapi.ProwJob) ([]JobPolicyViolation, error) {
+	if len(pc.JobPolicies) == 0 {
+		return nil, nil
+	}
+	vars, err := jobPolicyVariables(pj)
+	if err != nil {
+		return nil, err
+	}
+	var violations []JobPolicyViolation
+	for _, p := range pc.JobPolicies {
+		compiled := p.compiled
+		if compiled == nil {
+			if compiled, err = policy.Compile(p.Expression); err != nil {
+				return nil, fmt.Errorf("invalid expression for job policy %s: %w", p.Name, err)
+			}
+		}
+		message := p.Message
+		if message == "" {
+			message = fmt.Sprintf("job does not satisfy %q", p.Expression)
+		}
+		ok, err := compiled.EvalBool(vars)
+		if err != nil {
+			violations = append(violations, JobPolicyViolation{Policy: p.Name, Message: fmt.Sprintf("failed to evaluate policy: %v", err)})
+		} else if !ok {
+			violations = append(violations, JobPolicyViolation{Policy: p.Name, Message: message})
+		}
+	}
+	return violations, nil
+}
+
+func jobPolicyVariables(pj *prowapi.ProwJob) (map[string]interface{}, error) {
+	raw, err := json.Marshal(pj.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ProwJob spec: %w", err)
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ProwJob spec: %w", err)
+	}
+	var org, repo string
+	if refs := pj.Spec.Refs; refs != nil {
+		org, repo = refs.Org, refs.Repo
+	} else if len(pj.Spec.ExtraRefs) > 0 {
+		org, repo = pj.Spec.ExtraRefs[0].Org, pj.Spec.ExtraRefs[0].Repo
+	}
+	return map[string]interface{}{
+		"job":         spec,
+		"org":         org,
+		"repo":        repo,
+		"labels":      stringMapToInterface(pj.Labels),
+		"annotations": stringMapToInterface(pj.Annotations),
+	}, nil
+}
+
+func stringMapToInterface(in map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
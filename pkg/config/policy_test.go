@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestValidateJobPolicies(t *testing.T) {
+	testCases := []struct {
+		name        string
+		policies    []JobPolicy
+		expectedErr string
+	}{
+		{
+			name:     "valid policies",
+			policies: []JobPolicy{{Name: "decorated", Expression: "has(job.decoration_config)"}},
+		},
+		{
+			name:        "missing name",
+			policies:    []JobPolicy{{Expression: "true"}},
+			expectedErr: "job policy 0 has no name",
+		},
+		{
+			name:        "duplicated name",
+			policies:    []JobPolicy{{Name: "a", Expression: "true"}, {Name: "a", Expression: "true"}},
+			expectedErr: "duplicated job policy: a",
+		},
+		{
+			name:        "invalid expression",
+			policies:    []JobPolicy{{Name: "a", Expression: "job.type =="}},
+			expectedErr: "invalid expression for job policy a: unexpected end of expression",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var errMsg string
+			if err := validateJobPolicies(tc.policies); err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
+func TestEvaluateJobPolicies(t *testing.T) {
+	policies := []JobPolicy{
+		{
+			Name:       "decorate-my-org",
+			Expression: `org != "my-org" || has(job.decoration_config)`,
+			Message:    "all jobs in my-org must set decorate: true",
+		},
+		{
+			Name:       "no-admin-token",
+			Expression: `!has(job.pod_spec) || !has(job.pod_spec.volumes) || job.pod_spec.volumes.all(v, !has(v.secret) || v.secret.secretName != "admin-token")`,
+		},
+		{
+			Name:       "labelled",
+			Expression: `labels.team != ""`,
+		},
+	}
+	if err := validateJobPolicies(policies); err != nil {
+		t.Fatalf("failed to validate policies: %v", err)
+	}
+	pc := ProwConfig{JobPolicies: policies}
+
+	testCases := []struct {
+		name     string
+		pj       prowapi.ProwJob
+		expected []JobPolicyViolation
+	}{
+		{
+			name: "compliant job",
+			pj: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "infra"}},
+				Spec: prowapi.ProwJobSpec{
+					Refs:             &prowapi.Refs{Org: "my-org", Repo: "repo"},
+					DecorationConfig: &prowapi.DecorationConfig{},
+					PodSpec:          &v1.PodSpec{},
+				},
+			},
+		},
+		{
+			name: "undecorated job in other org with extra refs",
+			pj: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "infra"}},
+				Spec: prowapi.ProwJobSpec{
+					ExtraRefs: []prowapi.Refs{{Org: "other-org", Repo: "repo"}},
+				},
+			},
+		},
+		{
+			name: "violating job",
+			pj: prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					ExtraRefs: []prowapi.Refs{{Org: "my-org", Repo: "repo"}},
+					PodSpec: &v1.PodSpec{Volumes: []v1.Volume{{
+						Name:         "token",
+						VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "admin-token"}},
+					}}},
+				},
+			},
+			expected: []JobPolicyViolation{
+				{Policy: "decorate-my-org", Message: "all jobs in my-org must set decorate: true"},
+				{Policy: "no-admin-token", Message: `job does not satisfy "!has(job.pod_spec) || !has(job.pod_spec.volumes) || job.pod_spec.volumes.all(v, !has(v.secret) || v.secret.secretName != \"admin-token\")"`},
+				{Policy: "labelled", Message: "failed to evaluate policy: no such key: team"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			violations, err := pc.EvaluateJobPolicies(&tc.pj)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, violations); diff != "" {
+				t.Errorf("violations differ from expected: %s", diff)
+			}
+		})
+	}
+}
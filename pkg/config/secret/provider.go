@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProviderRefreshInterval is how often secrets referenced through a provider
+// are fetched again to pick up rotations.
+var ProviderRefreshInterval = 5 * time.Minute
+
+// providerFetchTimeout bounds a single fetch from a provider.
+const providerFetchTimeout = 30 * time.Second
+
+// Provider fetches secrets from an external secret manager. Secrets are
+// referenced as <scheme>://<ref> wherever a secret path is accepted, and the
+// provider registered for the scheme is passed the ref. Only components
+// resolve references, job pods cannot use them.
+type Provider interface {
+	Fetch(ctx context.Context, ref string) ([]byte, error)
+}
+
+var (
+	providersLock sync.RWMutex
+	providers     = map[string]Provider{}
+)
+
+func init() {
+	RegisterProvider("vault", &VaultProvider{})
+	RegisterProvider("gcpsm", &GCPSecretManagerProvider{})
+	RegisterProvider("awssm", &AWSSecretsManagerProvider{})
+}
+
+// RegisterProvider makes a provider available under the given scheme,
+// replacing any provider previously registered for it.
+func RegisterProvider(scheme string, provider Provider) {
+	providersLock.Lock()
+	defer providersLock.Unlock()
+	providers[scheme] = provider
+}
+
+// providerFor returns the provider and ref of a secret reference, if path is
+// one. Paths with unknown schemes are treated as file paths.
+func providerFor(path string) (Provider, string, bool) {
+	scheme, ref, found := strings.Cut(path, "://")
+	if !found {
+		return nil, "", false
+	}
+	providersLock.RLock()
+	defer providersLock.RUnlock()
+	provider, ok := providers[scheme]
+	return provider, ref, ok
+}
+
+// IsProviderRef returns whether path references a secret in an external
+// secret manager rather than a file.
+func IsProviderRef(path string) bool {
+	_, _, ok := providerFor(path)
+	return ok
+}
+
+func fetchFromProvider(provider Provider, path, ref string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), providerFetchTimeout)
+	defer cancel()
+	value, err := provider.Fetch(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %w", path, err)
+	}
+	return value, nil
+}
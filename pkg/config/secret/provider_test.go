@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeProvider map[string]string
+
+func (f fakeProvider) Fetch(_ context.Context, ref string) ([]byte, error) {
+	value, ok := f[ref]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(value), nil
+}
+
+func TestLoadSingleSecretFromProvider(t *testing.T) {
+	RegisterProvider("fake", fakeProvider{"token": " value\n"})
+
+	if !IsProviderRef("fake://token") {
+		t.Error("expected fake://token to be a provider reference")
+	}
+	if IsProviderRef("unknown://token") || IsProviderRef("/etc/github/token") {
+		t.Error("expected paths with unknown or no scheme not to be provider references")
+	}
+
+	value, err := loadSingleSecret("fake://token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(value) != "value" {
+		t.Errorf("expected trimmed value, got %q", value)
+	}
+	if _, err := loadSingleSecret("fake://missing"); err == nil {
+		t.Error("expected an error for a missing secret")
+	}
+
+	a := &agent{secretsMap: map[string]secretReloader{}}
+	if err := a.Start([]string{"fake://token"}); err != nil {
+		t.Fatalf("failed to start agent: %v", err)
+	}
+	if actual := string(a.GetSecret("fake://token")); actual != "value" {
+		t.Errorf("expected agent to return value, got %q", actual)
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/prow":
+			w.Write([]byte(`{"data": {"data": {"token": "v2-token", "other": "x"}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/prow":
+			w.Write([]byte(`{"data": {"token": "v1-token"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name      string
+		token     string
+		ref       string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "KV v2 with key",
+			token:    "root",
+			ref:      "secret/data/prow#token",
+			expected: "v2-token",
+		},
+		{
+			name:      "KV v2 with several fields needs a key",
+			token:     "root",
+			ref:       "secret/data/prow",
+			expectErr: true,
+		},
+		{
+			name:     "KV v1 with a single field",
+			token:    "root",
+			ref:      "kv/prow",
+			expected: "v1-token",
+		},
+		{
+			name:      "unknown key",
+			token:     "root",
+			ref:       "kv/prow#missing",
+			expectErr: true,
+		},
+		{
+			name:      "forbidden",
+			token:     "wrong",
+			ref:       "kv/prow",
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &VaultProvider{Address: server.URL, Token: tc.token}
+			value, err := provider.Fetch(context.Background(), tc.ref)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if string(value) != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestGCPSecretVersion(t *testing.T) {
+	for ref, expected := range map[string]string{
+		"projects/p/secrets/s":            "projects/p/secrets/s/versions/latest",
+		"projects/p/secrets/s/":           "projects/p/secrets/s/versions/latest",
+		"projects/p/secrets/s/versions/3": "projects/p/secrets/s/versions/3",
+	} {
+		if actual := gcpSecretVersion(ref); actual != expected {
+			t.Errorf("%s: expected %s, got %s", ref, expected, actual)
+		}
+	}
+}
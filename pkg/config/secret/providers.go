@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// splitKey splits a ref of the form <name>#<key>.
+func splitKey(ref string) (string, string) {
+	name, key, _ := strings.Cut(ref, "#")
+	return name, key
+}
+
+// selectKey returns the value of key in a JSON object. Without a key, the
+// object must have exactly one field.
+func selectKey(fields map[string]interface{}, key string) ([]byte, error) {
+	if key == "" {
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("secret has %d fields, select one with #<key>", len(fields))
+		}
+		for k := range fields {
+			key = k
+		}
+	}
+	value, ok := fields[key]
+	if !ok {
+		return nil, fmt.Errorf("secret has no field %q", key)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("field %q of secret is not a string", key)
+	}
+	return []byte(str), nil
+}
+
+// VaultProvider reads secrets from the HTTP API of HashiCorp Vault. Refs are
+// API paths below /v1/ with an optional #<key>, e.g.
+// vault://secret/data/prow/github#token. Both KV v1 and v2 responses are
+// supported.
+type VaultProvider struct {
+	// Address of the Vault server. Defaults to $VAULT_ADDR.
+	Address string
+	// Token to authenticate with. Defaults to $VAULT_TOKEN.
+	Token string
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+func (v *VaultProvider) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	address, token, client := v.Address, v.Token, v.Client
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if address == "" {
+		return nil, errors.New("no Vault address configured, set $VAULT_ADDR")
+	}
+
+	path, key := splitKey(ref)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(address, "/")+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	fields := response.Data
+	// KV v2 nests the secret below data.data, next to data.metadata.
+	if nested, ok := fields["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := fields["metadata"]; hasMetadata {
+			fields = nested
+		}
+	}
+	return selectKey(fields, key)
+}
+
+// GCPSecretManagerProvider reads secrets from Google Cloud Secret Manager
+// using the application default credentials. Refs are secret or secret
+// version resource names, e.g. gcpsm://projects/my-project/secrets/github-token.
+// The latest version is used unless one is given.
+type GCPSecretManagerProvider struct {
+	lock   sync.Mutex
+	client *secretmanager.Client
+}
+
+func (g *GCPSecretManagerProvider) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	client, err := g.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: gcpSecretVersion(ref)})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Payload.Data, nil
+}
+
+func (g *GCPSecretManagerProvider) getClient(ctx context.Context) (*secretmanager.Client, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.client == nil {
+		client, err := secretmanager.NewClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Secret Manager client: %w", err)
+		}
+		g.client = client
+	}
+	return g.client, nil
+}
+
+func gcpSecretVersion(ref string) string {
+	if strings.Contains(ref, "/versions/") {
+		return ref
+	}
+	return strings.TrimSuffix(ref, "/") + "/versions/latest"
+}
+
+// AWSSecretsManagerProvider reads secrets from AWS Secrets Manager using the
+// default credential chain. Refs are secret names or ARNs with an optional
+// #<key> selecting a field of a JSON secret, e.g. awssm://prow/github#token.
+type AWSSecretsManagerProvider struct {
+	lock   sync.Mutex
+	client *secretsmanager.SecretsManager
+}
+
+func (a *AWSSecretsManagerProvider) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	client, err := a.getClient()
+	if err != nil {
+		return nil, err
+	}
+	name, key := splitKey(ref)
+	output, err := client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+	if err != nil {
+		return nil, err
+	}
+	if output.SecretString == nil {
+		if key != "" {
+			return nil, fmt.Errorf("cannot select field %q of a binary secret", key)
+		}
+		return output.SecretBinary, nil
+	}
+	if key == "" {
+		return []byte(*output.SecretString), nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(*output.SecretString), &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secret to select field %q: %w", key, err)
+	}
+	return selectKey(fields, key)
+}
+
+func (a *AWSSecretsManagerProvider) getClient() (*secretsmanager.SecretsManager, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.client == nil {
+		sess, err := session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create AWS session: %w", err)
+		}
+		a.client = secretsmanager.New(sess)
+	}
+	return a.client, nil
+}
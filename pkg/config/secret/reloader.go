@@ -43,10 +43,32 @@ func (p *parsingSecretReloader[T]) start(reloadCensor func()) error {
 	p.lock.Unlock()
 	reloadCensor()
 
-	go p.reloadSecret(reloadCensor)
+	if IsProviderRef(p.path) {
+		go p.refreshSecret(reloadCensor)
+	} else {
+		go p.reloadSecret(reloadCensor)
+	}
 	return nil
 }
 
+// refreshSecret periodically fetches a secret from its provider, as there is
+// no modification time to watch.
+func (p *parsingSecretReloader[T]) refreshSecret(reloadCensor func()) {
+	logger := logrus.WithField("secret-path", p.path)
+	for range time.Tick(ProviderRefreshInterval) {
+		raw, parsed, err := loadSingleSecretWithParser(p.path, p.parsingFN)
+		if err != nil {
+			logger.WithError(err).Error("Error refreshing secret.")
+			continue
+		}
+		p.lock.Lock()
+		p.rawValue = raw
+		p.parsed = parsed
+		p.lock.Unlock()
+		reloadCensor()
+	}
+}
+
 func (p *parsingSecretReloader[T]) reloadSecret(reloadCensor func()) {
 	var lastModTime time.Time
 	logger := logrus.NewEntry(logrus.StandardLogger())
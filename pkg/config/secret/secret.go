@@ -22,8 +22,16 @@ import (
 	"os"
 )
 
-// loadSingleSecret reads and returns the value of a single file.
+// loadSingleSecret reads and returns the value of a single file, or fetches it
+// from a provider if path is a provider reference.
 func loadSingleSecret(path string) ([]byte, error) {
+	if provider, ref, ok := providerFor(path); ok {
+		b, err := fetchFromProvider(provider, path, ref)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.TrimSpace(b), nil
+	}
 	b, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("error reading %s: %w", path, err)
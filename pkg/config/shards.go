@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ShardManifestFileName is the key under which config-updater stores the
+// ShardManifest of a ConfigMap that it had to shard.
+const ShardManifestFileName = "prow-shard-manifest.json"
+
+// ShardManifest describes a ConfigMap that was split into several ConfigMaps
+// because it exceeded the size limit of ConfigMaps. The shards are meant to be
+// mounted into the same directory, e.g. with a projected volume.
+type ShardManifest struct {
+	// Shards are the names of the additional ConfigMaps.
+	Shards []string `json:"shards"`
+	// Files maps every key of the sharded ConfigMap to the sha256 of its
+	// content.
+	Files map[string]string `json:"files"`
+}
+
+// ShardHash returns the hash recorded in a ShardManifest for the content.
+func ShardHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyShards checks that a directory holding a sharded ConfigMap contains
+// every file of its manifest with the recorded content. This catches shards
+// that are missing or that were not updated yet, so that a partial config is
+// never loaded. Directories without a manifest are not sharded and always
+// pass.
+func VerifyShards(dir string) error {
+	raw, err := os.ReadFile(filepath.Join(dir, ShardManifestFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read shard manifest: %w", err)
+	}
+	var manifest ShardManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return fmt.Errorf("failed to parse shard manifest: %w", err)
+	}
+	var errs []error
+	for _, file := range sets.List(sets.KeySet(manifest.Files)) {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sharded file %s: %w", file, err))
+			continue
+		}
+		if hash := ShardHash(content); hash != manifest.Files[file] {
+			errs = append(errs, fmt.Errorf("sharded file %s has hash %s, expected %s", file, hash, manifest.Files[file]))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
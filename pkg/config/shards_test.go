@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyShards(t *testing.T) {
+	const jobs = "periodics:\n- name: p\n  interval: 1h\n  spec:\n    containers:\n    - image: alpine\n"
+	testCases := []struct {
+		name      string
+		files     map[string]string
+		manifest  *ShardManifest
+		expectErr bool
+	}{
+		{
+			name:  "no manifest",
+			files: map[string]string{"jobs.yaml": jobs},
+		},
+		{
+			name:     "complete",
+			files:    map[string]string{"jobs.yaml": jobs},
+			manifest: &ShardManifest{Shards: []string{"jobs-shard-1"}, Files: map[string]string{"jobs.yaml": ShardHash([]byte(jobs))}},
+		},
+		{
+			name:      "missing file",
+			manifest:  &ShardManifest{Shards: []string{"jobs-shard-1"}, Files: map[string]string{"jobs.yaml": ShardHash([]byte(jobs))}},
+			expectErr: true,
+		},
+		{
+			name:      "stale file",
+			files:     map[string]string{"jobs.yaml": jobs},
+			manifest:  &ShardManifest{Shards: []string{"jobs-shard-1"}, Files: map[string]string{"jobs.yaml": ShardHash([]byte("old"))}},
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.manifest != nil {
+				raw, err := json.Marshal(tc.manifest)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, ShardManifestFileName), raw, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := VerifyShards(dir); (err != nil) != tc.expectErr {
+				t.Errorf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if _, err := ReadJobConfig(dir); (err != nil) != tc.expectErr {
+				t.Errorf("expected error reading job config: %t, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// JobConfigSource is job config owned by a namespace, e.g. a ConfigMap of a
+// team that is mounted next to the central job config. Like for JobConfig
+// resources, the namespace may only configure the jobs allowed by
+// job_config_resources.
+type JobConfigSource struct {
+	Namespace string
+	// Path is a file or a directory that is searched recursively.
+	Path string
+}
+
+func (s JobConfigSource) String() string {
+	return s.Namespace + "=" + s.Path
+}
+
+// ParseJobConfigSource parses a namespace=path flag value.
+func ParseJobConfigSource(value string) (JobConfigSource, error) {
+	namespace, path, found := strings.Cut(value, "=")
+	if !found || namespace == "" || path == "" {
+		return JobConfigSource{}, fmt.Errorf("job config source %q must be of the form namespace=path", value)
+	}
+	return JobConfigSource{Namespace: namespace, Path: path}, nil
+}
+
+// AddJobConfigSources returns a load function that merges the job config
+// sources into the config. The central job config takes precedence over the
+// sources, which take precedence over each other in the given order. Jobs
+// conflicting with a job of higher precedence are dropped and recorded in
+// SourceConflicts.
+func AddJobConfigSources(sources []JobConfigSource) func(*Config) error {
+	return func(c *Config) error {
+		for _, source := range sources {
+			jc, err := ReadJobConfig(source.Path)
+			if err == nil {
+				jc, err = c.namespacedJobConfig(source.Namespace, jc)
+			}
+			if err == nil {
+				err = c.addNamespacedJobConfig(source.String(), jc)
+			}
+			if err != nil {
+				return fmt.Errorf("job config source %s: %w", source, err)
+			}
+		}
+		return c.ValidateJobConfig()
+	}
+}
+
+// addNamespacedJobConfig defaults the jobs of a namespaced job config and
+// merges them into the config, dropping the jobs that conflict with jobs that
+// are already configured. The presets of the namespaced job config only apply
+// to its own jobs and are not merged into the config.
+func (c *Config) addNamespacedJobConfig(source string, jc JobConfig) error {
+	if c.AllRepos == nil {
+		c.AllRepos = sets.New[string]()
+	}
+	c.dropConflictingJobs(source, &jc)
+	// The presets may not match the same labels as the central ones.
+	if _, err := mergeJobConfigs(JobConfig{Presets: c.Presets}, JobConfig{Presets: jc.Presets}); err != nil {
+		return err
+	}
+	var errs []error
+	for repo, jobs := range jc.PresubmitsStatic {
+		errs = append(errs, defaultPresubmits(jobs, jc.Presets, c, repo))
+		c.AllRepos.Insert(repo)
+	}
+	for repo, jobs := range jc.PostsubmitsStatic {
+		errs = append(errs, defaultPostsubmits(jobs, jc.Presets, c, repo))
+		c.AllRepos.Insert(repo)
+	}
+	for i := range jc.Periodics {
+		errs = append(errs, c.defaultPeriodic(&jc.Periodics[i], jc.Presets))
+	}
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return err
+	}
+	jc.Presets = nil
+	return c.mergeJobConfig(jc)
+}
+
+// dropConflictingJobs removes the jobs from jc that are already configured.
+// Presubmits and postsubmits conflict when they have the same name and repo,
+// periodics when they have the same name.
+func (c *Config) dropConflictingJobs(source string, jc *JobConfig) {
+	conflict := func(kind, name, repo string) {
+		msg := fmt.Sprintf("%s: %s %s conflicts with a job of higher precedence and was ignored", source, kind, name)
+		if repo != "" {
+			msg = fmt.Sprintf("%s: %s %s for %s conflicts with a job of higher precedence and was ignored", source, kind, name, repo)
+		}
+		logrus.Warn(msg)
+		c.SourceConflicts = append(c.SourceConflicts, msg)
+	}
+	for repo, presubmits := range jc.PresubmitsStatic {
+		existing := sets.New[string]()
+		for _, presubmit := range c.PresubmitsStatic[repo] {
+			existing.Insert(presubmit.Name)
+		}
+		var kept []Presubmit
+		for _, presubmit := range presubmits {
+			if existing.Has(presubmit.Name) {
+				conflict("presubmit", presubmit.Name, repo)
+				continue
+			}
+			kept = append(kept, presubmit)
+		}
+		jc.PresubmitsStatic[repo] = kept
+	}
+	for repo, postsubmits := range jc.PostsubmitsStatic {
+		existing := sets.New[string]()
+		for _, postsubmit := range c.PostsubmitsStatic[repo] {
+			existing.Insert(postsubmit.Name)
+		}
+		var kept []Postsubmit
+		for _, postsubmit := range postsubmits {
+			if existing.Has(postsubmit.Name) {
+				conflict("postsubmit", postsubmit.Name, repo)
+				continue
+			}
+			kept = append(kept, postsubmit)
+		}
+		jc.PostsubmitsStatic[repo] = kept
+	}
+	existing := sets.New[string]()
+	for _, periodic := range c.Periodics {
+		existing.Insert(periodic.Name)
+	}
+	var kept []Periodic
+	for _, periodic := range jc.Periodics {
+		if existing.Has(periodic.Name) {
+			conflict("periodic", periodic.Name, "")
+			continue
+		}
+		kept = append(kept, periodic)
+	}
+	jc.Periodics = kept
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseJobConfigSource(t *testing.T) {
+	source, err := ParseJobConfigSource("team-a=/etc/team-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(JobConfigSource{Namespace: "team-a", Path: "/etc/team-a"}, source); diff != "" {
+		t.Errorf("source differs from expected: %s", diff)
+	}
+	for _, invalid := range []string{"", "team-a", "=/etc/team-a", "team-a="} {
+		if _, err := ParseJobConfigSource(invalid); err == nil {
+			t.Errorf("expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestAddJobConfigSources(t *testing.T) {
+	const presubmit = `
+  - name: %s
+    always_run: true
+    spec:
+      containers:
+      - image: alpine
+`
+	job := func(name string) string { return strings.ReplaceAll(presubmit, "%s", name) }
+	write := func(t *testing.T, dir, name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	testCases := []struct {
+		name               string
+		sources            map[string]string
+		order              []string
+		expectedPresubmits []string
+		expectedConflicts  []string
+		expectedErr        string
+	}{
+		{
+			name:               "team jobs are added",
+			sources:            map[string]string{"team-a": "presubmits:\n  org/repo:" + job("pull-team-a")},
+			order:              []string{"team-a"},
+			expectedPresubmits: []string{"pull-central", "pull-team-a"},
+		},
+		{
+			name: "central config and earlier sources take precedence",
+			sources: map[string]string{
+				"team-a": "presubmits:\n  org/repo:" + job("pull-central") + job("pull-shared"),
+				"team-b": "presubmits:\n  org/repo:" + job("pull-shared") + job("pull-team-b"),
+			},
+			order:              []string{"team-a", "team-b"},
+			expectedPresubmits: []string{"pull-central", "pull-shared", "pull-team-b"},
+			expectedConflicts: []string{
+				"team-a=SOURCE: presubmit pull-central for org/repo conflicts with a job of higher precedence and was ignored",
+				"team-b=SOURCE: presubmit pull-shared for org/repo conflicts with a job of higher precedence and was ignored",
+			},
+		},
+		{
+			name:        "namespaces may only configure allowed repos",
+			sources:     map[string]string{"team-b": "presubmits:\n  other/repo:" + job("pull-team-b")},
+			order:       []string{"team-b"},
+			expectedErr: "job pull-team-b: namespace team-b may not configure jobs for other/repo",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			prowConfig := write(t, dir, "config.yaml", `
+prowjob_namespace: prowjobs
+pod_namespace: pods
+job_config_resources:
+  namespaces:
+    team-a: [org]
+    team-b: [org/repo]
+`)
+			jobConfig := write(t, dir, "jobs.yaml", "presubmits:\n  org/repo:"+job("pull-central"))
+			var sources []JobConfigSource
+			paths := map[string]string{}
+			for _, namespace := range tc.order {
+				paths[namespace] = write(t, dir, namespace+".yaml", tc.sources[namespace])
+				sources = append(sources, JobConfigSource{Namespace: namespace, Path: paths[namespace]})
+			}
+
+			c, err := Load(prowConfig, jobConfig, nil, "", AddJobConfigSources(sources))
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load config: %v", err)
+			}
+			var names []string
+			for _, presubmit := range c.PresubmitsStatic["org/repo"] {
+				names = append(names, presubmit.Name)
+			}
+			if diff := cmp.Diff(tc.expectedPresubmits, names); diff != "" {
+				t.Errorf("presubmits differ from expected: %s", diff)
+			}
+			var expectedConflicts []string
+			for i, conflict := range tc.expectedConflicts {
+				expectedConflicts = append(expectedConflicts, strings.Replace(conflict, "SOURCE", paths[tc.order[i]], 1))
+			}
+			if diff := cmp.Diff(expectedConflicts, c.SourceConflicts); diff != "" {
+				t.Errorf("conflicts differ from expected: %s", diff)
+			}
+		})
+	}
+}
+
+func TestLastConfigModTimeOfWatchedPaths(t *testing.T) {
+	dir := t.TempDir()
+	prowConfig := filepath.Join(dir, "config.yaml")
+	source := filepath.Join(dir, "team-a")
+	nested := filepath.Join(source, "nested", "jobs.yaml")
+	if err := os.MkdirAll(filepath.Dir(nested), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	old := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	modified := old.Add(time.Hour)
+	for _, path := range []string{prowConfig, nested} {
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	for _, path := range []string{prowConfig, nested, filepath.Dir(nested), source} {
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatalf("failed to set the times of %s: %v", path, err)
+		}
+	}
+	if err := os.Chtimes(nested, modified, modified); err != nil {
+		t.Fatalf("failed to set the times of %s: %v", nested, err)
+	}
+
+	if actual, err := lastConfigModTime(prowConfig, "", nil); err != nil || !actual.Equal(old) {
+		t.Errorf("expected %s without watched paths, got %s, %v", old, actual, err)
+	}
+	if actual, err := lastConfigModTime(prowConfig, "", []string{source}); err != nil || !actual.Equal(modified) {
+		t.Errorf("expected %s from the nested file of the watched path, got %s, %v", modified, actual, err)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// StatusReconciler contains configuration for the status-reconciler.
+type StatusReconciler struct {
+	// ContextMigrations map the contexts of removed presubmits to the
+	// contexts of added presubmits. Instead of retiring the old context and
+	// triggering the new job on every open pull request, status-reconciler
+	// moves the existing statuses to the new context, which makes renaming
+	// many jobs at once cheap.
+	ContextMigrations []ContextMigration `json:"context_migrations,omitempty"`
+}
+
+// ContextMigration maps the contexts matching a glob pattern to new contexts.
+type ContextMigration struct {
+	// Repos limits the migration to these orgs or org/repos. The migration
+	// applies to all repos if empty.
+	Repos []string `json:"repos,omitempty"`
+	// From is a glob pattern matched against the whole context, in which *
+	// matches any sequence of characters.
+	From string `json:"from"`
+	// To is the new context. Every * in it is replaced by the text matched
+	// by the * at the same position in From.
+	To string `json:"to"`
+}
+
+// Validate validates the status-reconciler config.
+func (s *StatusReconciler) Validate() error {
+	if s == nil {
+		return nil
+	}
+	var errs []error
+	for i, migration := range s.ContextMigrations {
+		if migration.From == "" || migration.To == "" {
+			errs = append(errs, fmt.Errorf("status_reconciler.context_migrations[%d]: from and to must be set", i))
+			continue
+		}
+		if strings.Count(migration.To, "*") > strings.Count(migration.From, "*") {
+			errs = append(errs, fmt.Errorf("status_reconciler.context_migrations[%d]: to %q has more wildcards than from %q", i, migration.To, migration.From))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// AppliesTo determines whether the migration applies to the org/repo.
+func (m ContextMigration) AppliesTo(org, repo string) bool {
+	if len(m.Repos) == 0 {
+		return true
+	}
+	for _, r := range m.Repos {
+		if r == org || r == org+"/"+repo {
+			return true
+		}
+	}
+	return false
+}
+
+// Migrate returns the new context for the given context and whether the
+// context matches the migration at all.
+func (m ContextMigration) Migrate(context string) (string, bool) {
+	parts := strings.Split(m.From, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	matches := regexp.MustCompile("^" + strings.Join(parts, "(.*)") + "$").FindStringSubmatch(context)
+	if matches == nil {
+		return "", false
+	}
+	var migrated strings.Builder
+	for i, part := range strings.Split(m.To, "*") {
+		if i > 0 {
+			migrated.WriteString(matches[i])
+		}
+		migrated.WriteString(part)
+	}
+	return migrated.String(), true
+}
+
+// MigrateContext returns the context the given context of a presubmit in
+// org/repo migrates to according to the first matching migration.
+func (s *StatusReconciler) MigrateContext(org, repo, context string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	for _, migration := range s.ContextMigrations {
+		if !migration.AppliesTo(org, repo) {
+			continue
+		}
+		if migrated, ok := migration.Migrate(context); ok {
+			return migrated, true
+		}
+	}
+	return "", false
+}
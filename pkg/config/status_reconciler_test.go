@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "testing"
+
+func TestStatusReconcilerMigrateContext(t *testing.T) {
+	cfg := StatusReconciler{ContextMigrations: []ContextMigration{
+		{Repos: []string{"other"}, From: "ci/*", To: "other/*"},
+		{From: "pull-kubernetes-*", To: "pull-k8s-*"},
+		{From: "ci/prow/*-e2e-*", To: "e2e/*/*"},
+		{From: "legacy", To: "modern"},
+	}}
+	cases := []struct {
+		name     string
+		org      string
+		context  string
+		expected string
+		matches  bool
+	}{
+		{name: "prefix glob", org: "org", context: "pull-kubernetes-unit", expected: "pull-k8s-unit", matches: true},
+		{name: "wildcards match slashes", org: "org", context: "ci/prow/aws-e2e-serial/slow", expected: "e2e/aws/serial/slow", matches: true},
+		{name: "literal", org: "org", context: "legacy", expected: "modern", matches: true},
+		{name: "literal must match the whole context", org: "org", context: "legacy-unit"},
+		{name: "first matching migration for the repo wins", org: "other", context: "ci/prow/aws-e2e-serial", expected: "other/prow/aws-e2e-serial", matches: true},
+		{name: "no match", org: "org", context: "unit"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			migrated, matches := cfg.MigrateContext(tc.org, "repo", tc.context)
+			if matches != tc.matches || migrated != tc.expected {
+				t.Errorf("expected (%q, %t), got (%q, %t)", tc.expected, tc.matches, migrated, matches)
+			}
+		})
+	}
+}
+
+func TestStatusReconcilerValidate(t *testing.T) {
+	cases := []struct {
+		name       string
+		migrations []ContextMigration
+		expectErr  bool
+	}{
+		{name: "valid", migrations: []ContextMigration{{From: "a-*-*", To: "b-*"}}},
+		{name: "missing to", migrations: []ContextMigration{{From: "a-*"}}, expectErr: true},
+		{name: "more wildcards in to than from", migrations: []ContextMigration{{From: "a-*", To: "b-*-*"}}, expectErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&StatusReconciler{ContextMigrations: tc.migrations}).Validate()
+			if (err != nil) != tc.expectErr {
+				t.Errorf("expected error %t, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
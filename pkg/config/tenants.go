@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// Tenant restricts what the jobs of a tenant may do, so that a single Prow
+// instance can serve independent organizations. A job belongs to the tenant
+// that the prowjob_default_entries assign to its repo and cluster. The
+// tenant_id a job sets itself is not considered, as jobs of in-repo config
+// could set any. Jobs of tenants that are not configured, including the ones
+// of no tenant, belong to the GlobalDefaultID tenant if it is configured.
+// Tenants share the pod namespace and the job config, they do not get
+// their own.
+type Tenant struct {
+	// AllowedClusters are the build clusters the jobs of the tenant may run
+	// in. All clusters are allowed if empty.
+	AllowedClusters []string `json:"allowed_clusters,omitempty"`
+	// AllowedSecrets are the secrets the pods of the tenant may reference in
+	// volumes or environment variables. All secrets are allowed if unset, set
+	// it to an empty list to forbid all secrets.
+	AllowedSecrets []string `json:"allowed_secrets,omitempty"`
+	// MaxProwJobAge overrides sinker.max_prowjob_age for the ProwJobs of the
+	// tenant.
+	MaxProwJobAge *metav1.Duration `json:"max_prowjob_age,omitempty"`
+	// SecurityPolicy is enforced by the admission webhook on the ProwJobs
+	// of the tenant and the pods created for them.
+	SecurityPolicy *TenantSecurityPolicy `json:"security_policy,omitempty"`
+}
+
+// TenantSecurityPolicy restricts the pods of a tenant.
+type TenantSecurityPolicy struct {
+	// PrivilegedRepos are the orgs and org/repos whose jobs may run
+	// privileged containers. No job may if empty.
+	PrivilegedRepos []string `json:"privileged_repos,omitempty"`
+	// RequiredResourceLimits are the resources, e.g. cpu and memory, every
+	// container must set a limit for.
+	RequiredResourceLimits []v1.ResourceName `json:"required_resource_limits,omitempty"`
+	// SeccompProfile is set on pods that set no seccomp profile themselves,
+	// neither for the pod nor for any of its containers.
+	SeccompProfile *v1.SeccompProfile `json:"seccomp_profile,omitempty"`
+}
+
+func validateTenants(tenants map[string]Tenant) error {
+	var errs []error
+	for id, tenant := range tenants {
+		if id == "" {
+			errs = append(errs, fmt.Errorf("tenant id must not be empty"))
+		}
+		if tenant.MaxProwJobAge != nil && tenant.MaxProwJobAge.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("tenant %s: max_prowjob_age must be positive", id))
+		}
+		if policy := tenant.SecurityPolicy; policy != nil && policy.SeccompProfile != nil {
+			switch policy.SeccompProfile.Type {
+			case v1.SeccompProfileTypeLocalhost:
+				if policy.SeccompProfile.LocalhostProfile == nil || *policy.SeccompProfile.LocalhostProfile == "" {
+					errs = append(errs, fmt.Errorf("tenant %s: seccomp_profile of type Localhost needs a localhostProfile", id))
+				}
+			case v1.SeccompProfileTypeRuntimeDefault, v1.SeccompProfileTypeUnconfined:
+			default:
+				errs = append(errs, fmt.Errorf("tenant %s: unknown seccomp_profile type %q", id, policy.SeccompProfile.Type))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// jobRepo returns the org/repo of the refs of the job, or of its first extra
+// refs if it has none.
+func jobRepo(spec *prowapi.ProwJobSpec) string {
+	refs := spec.Refs
+	if refs == nil && len(spec.ExtraRefs) > 0 {
+		refs = &spec.ExtraRefs[0]
+	}
+	if refs == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", refs.Org, refs.Repo)
+}
+
+// tenantFor returns the tenant of jobs of the org/repo that run in the
+// cluster, if they have one.
+func (pc *ProwConfig) tenantFor(repo, cluster string) (string, *Tenant) {
+	id := pc.mergeProwJobDefault(repo, cluster, nil).TenantID
+	if tenant, ok := pc.Tenants[id]; ok {
+		return id, &tenant
+	}
+	if tenant, ok := pc.Tenants[DefaultTenantID]; ok {
+		return DefaultTenantID, &tenant
+	}
+	return id, nil
+}
+
+// ValidateTenantRestrictions verifies that the job does not exceed the
+// restrictions of its tenant. Only the pod spec of the job is considered,
+// not the decoration that Prow adds to it.
+func (pc *ProwConfig) ValidateTenantRestrictions(spec prowapi.ProwJobSpec) error {
+	return pc.checkTenantRestrictions(jobRepo(&spec), spec)
+}
+
+func (pc *ProwConfig) checkTenantRestrictions(repo string, spec prowapi.ProwJobSpec) error {
+	id, tenant := pc.tenantFor(repo, spec.Cluster)
+	if tenant == nil {
+		return nil
+	}
+	var errs []error
+	if len(tenant.AllowedClusters) > 0 && !sets.New[string](tenant.AllowedClusters...).Has(spec.Cluster) {
+		errs = append(errs, fmt.Errorf("tenant %s may not use cluster %q", id, spec.Cluster))
+	}
+	if tenant.AllowedSecrets != nil && spec.PodSpec != nil {
+		forbidden := podSpecSecrets(spec.PodSpec).Difference(sets.New[string](tenant.AllowedSecrets...))
+		if forbidden.Len() > 0 {
+			errs = append(errs, fmt.Errorf("tenant %s may not use secrets %v", id, sets.List(forbidden)))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// TenantSecurityPolicy returns the tenant of jobs of the org/repo that run in
+// the cluster and its security policy, if it has one.
+func (pc *ProwConfig) TenantSecurityPolicy(org, repo, cluster string) (string, *TenantSecurityPolicy) {
+	var orgRepo string
+	if org != "" {
+		orgRepo = fmt.Sprintf("%s/%s", org, repo)
+	}
+	id, tenant := pc.tenantFor(orgRepo, cluster)
+	if tenant == nil {
+		return id, nil
+	}
+	return id, tenant.SecurityPolicy
+}
+
+// Violations returns how the pod spec of a job for org/repo violates the
+// policy.
+func (p *TenantSecurityPolicy) Violations(org, repo string, spec *v1.PodSpec) []string {
+	var violations []string
+	privilegedAllowed := sets.New[string](p.PrivilegedRepos...)
+	for _, container := range append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...) {
+		if sc := container.SecurityContext; sc != nil && sc.Privileged != nil && *sc.Privileged &&
+			!privilegedAllowed.Has(org) && !privilegedAllowed.Has(org+"/"+repo) {
+			violations = append(violations, fmt.Sprintf("container %s may not be privileged", container.Name))
+		}
+		for _, resource := range p.RequiredResourceLimits {
+			if _, ok := container.Resources.Limits[resource]; !ok {
+				violations = append(violations, fmt.Sprintf("container %s must set a %s limit", container.Name, resource))
+			}
+		}
+	}
+	return violations
+}
+
+// NeedsSeccompProfile returns whether the policy sets a seccomp profile on
+// the pod spec, because it sets none itself.
+func (p *TenantSecurityPolicy) NeedsSeccompProfile(spec *v1.PodSpec) bool {
+	if p.SeccompProfile == nil {
+		return false
+	}
+	if spec.SecurityContext != nil && spec.SecurityContext.SeccompProfile != nil {
+		return false
+	}
+	for _, container := range append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...) {
+		if container.SecurityContext != nil && container.SecurityContext.SeccompProfile != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// MaxProwJobAgeFor returns how old the ProwJob can be before sinker deletes it.
+func (pc *ProwConfig) MaxProwJobAgeFor(pj *prowapi.ProwJob) time.Duration {
+	if _, tenant := pc.tenantFor(jobRepo(&pj.Spec), pj.Spec.Cluster); tenant != nil && tenant.MaxProwJobAge != nil {
+		return tenant.MaxProwJobAge.Duration
+	}
+	return pc.Sinker.MaxProwJobAge.Duration
+}
+
+// podSpecSecrets returns the names of all secrets referenced by the pod spec.
+func podSpecSecrets(spec *v1.PodSpec) sets.Set[string] {
+	secrets := sets.New[string]()
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil {
+			secrets.Insert(volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					secrets.Insert(source.Secret.Name)
+				}
+			}
+		}
+	}
+	for _, container := range append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...) {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				secrets.Insert(env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				secrets.Insert(envFrom.SecretRef.Name)
+			}
+		}
+	}
+	for _, secret := range spec.ImagePullSecrets {
+		secrets.Insert(secret.Name)
+	}
+	return secrets
+}
+
+// validateTenantRestrictions verifies the configured jobs against the
+// restrictions of their tenants.
+func (c *Config) validateTenantRestrictions() error {
+	if len(c.Tenants) == 0 {
+		return nil
+	}
+	var errs []error
+	validate := func(name, repo string, base JobBase) {
+		spec := prowapi.ProwJobSpec{Cluster: base.Cluster, PodSpec: base.Spec}
+		if err := c.checkTenantRestrictions(repo, spec); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", name, err))
+		}
+	}
+	for repo, jobs := range c.PresubmitsStatic {
+		for _, job := range jobs {
+			validate(job.Name, repo, job.JobBase)
+		}
+	}
+	for repo, jobs := range c.PostsubmitsStatic {
+		for _, job := range jobs {
+			validate(job.Name, repo, job.JobBase)
+		}
+	}
+	for _, job := range c.Periodics {
+		validate(job.Name, periodicRepo(&job), job.JobBase)
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestValidateTenantRestrictions(t *testing.T) {
+	pc := &ProwConfig{
+		ProwJobDefaultEntries: []*ProwJobDefaultEntry{
+			{OrgRepo: "team-a", Config: &prowapi.ProwJobDefault{TenantID: "team-a"}},
+			{OrgRepo: "team-b", Config: &prowapi.ProwJobDefault{TenantID: "team-b"}},
+			{OrgRepo: "team-c", Config: &prowapi.ProwJobDefault{TenantID: "team-c"}},
+		},
+		Tenants: map[string]Tenant{
+			"team-a": {AllowedClusters: []string{"team-a-cluster"}, AllowedSecrets: []string{"team-a-token"}},
+			"team-b": {AllowedSecrets: []string{}},
+		},
+	}
+	withDefault := &ProwConfig{
+		ProwJobDefaultEntries: pc.ProwJobDefaultEntries,
+		Tenants: map[string]Tenant{
+			"team-a":        pc.Tenants["team-a"],
+			DefaultTenantID: {AllowedClusters: []string{"default"}},
+		},
+	}
+	refs := func(org string) *prowapi.Refs {
+		return &prowapi.Refs{Org: org, Repo: "repo"}
+	}
+	podSpec := func(secrets ...string) *v1.PodSpec {
+		spec := &v1.PodSpec{Containers: []v1.Container{{}}}
+		for i, secret := range secrets {
+			switch i % 3 {
+			case 0:
+				spec.Volumes = append(spec.Volumes, v1.Volume{VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: secret}}})
+			case 1:
+				spec.Containers[0].Env = append(spec.Containers[0].Env, v1.EnvVar{ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: secret}}}})
+			case 2:
+				spec.Containers[0].EnvFrom = append(spec.Containers[0].EnvFrom, v1.EnvFromSource{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: secret}}})
+			}
+		}
+		return spec
+	}
+
+	testCases := []struct {
+		name        string
+		config      *ProwConfig
+		spec        prowapi.ProwJobSpec
+		expectedErr string
+	}{
+		{
+			name: "job without tenant is unrestricted",
+			spec: prowapi.ProwJobSpec{Cluster: "any", Refs: refs("other"), PodSpec: podSpec("admin-token")},
+		},
+		{
+			name: "job of unknown tenant is unrestricted",
+			spec: prowapi.ProwJobSpec{Cluster: "any", Refs: refs("team-c")},
+		},
+		{
+			name: "job within restrictions",
+			spec: prowapi.ProwJobSpec{Cluster: "team-a-cluster", Refs: refs("team-a"), PodSpec: podSpec("team-a-token")},
+		},
+		{
+			name:        "job in forbidden cluster",
+			spec:        prowapi.ProwJobSpec{Cluster: "default", Refs: refs("team-a")},
+			expectedErr: `tenant team-a may not use cluster "default"`,
+		},
+		{
+			name:        "job using forbidden secrets",
+			spec:        prowapi.ProwJobSpec{Cluster: "team-a-cluster", Refs: refs("team-a"), PodSpec: podSpec("team-a-token", "admin-token", "other-token")},
+			expectedErr: "tenant team-a may not use secrets [admin-token other-token]",
+		},
+		{
+			name:        "tenant without any allowed secrets",
+			spec:        prowapi.ProwJobSpec{Cluster: "default", Refs: refs("team-b"), PodSpec: podSpec("team-a-token")},
+			expectedErr: "tenant team-b may not use secrets [team-a-token]",
+		},
+		{
+			name:        "tenant declared by the job is ignored",
+			spec:        prowapi.ProwJobSpec{Cluster: "default", Refs: refs("team-a"), ProwJobDefault: &prowapi.ProwJobDefault{TenantID: "team-c"}},
+			expectedErr: `tenant team-a may not use cluster "default"`,
+		},
+		{
+			name:        "tenant is derived from extra refs",
+			spec:        prowapi.ProwJobSpec{Cluster: "default", ExtraRefs: []prowapi.Refs{*refs("team-b")}, PodSpec: podSpec("admin-token")},
+			expectedErr: "tenant team-b may not use secrets [admin-token]",
+		},
+		{
+			name:        "job without tenant is restricted by the default tenant",
+			config:      withDefault,
+			spec:        prowapi.ProwJobSpec{Cluster: "any", Refs: refs("other")},
+			expectedErr: `tenant GlobalDefaultID may not use cluster "any"`,
+		},
+		{
+			name:        "job of unknown tenant is restricted by the default tenant",
+			config:      withDefault,
+			spec:        prowapi.ProwJobSpec{Cluster: "any", Refs: refs("team-c")},
+			expectedErr: `tenant GlobalDefaultID may not use cluster "any"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := pc
+			if tc.config != nil {
+				config = tc.config
+			}
+			err := config.ValidateTenantRestrictions(tc.spec)
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+		})
+	}
+}
+
+func TestMaxProwJobAgeFor(t *testing.T) {
+	pc := &ProwConfig{
+		Sinker: Sinker{MaxProwJobAge: &metav1.Duration{Duration: 7 * 24 * time.Hour}},
+		ProwJobDefaultEntries: []*ProwJobDefaultEntry{
+			{OrgRepo: "team-a", Config: &prowapi.ProwJobDefault{TenantID: "team-a"}},
+			{OrgRepo: "team-b", Config: &prowapi.ProwJobDefault{TenantID: "team-b"}},
+			{Cluster: "short-lived", Config: &prowapi.ProwJobDefault{TenantID: "team-a"}},
+		},
+		Tenants: map[string]Tenant{"team-a": {MaxProwJobAge: &metav1.Duration{Duration: time.Hour}}, "team-b": {}},
+	}
+	testCases := []struct {
+		org      string
+		cluster  string
+		tenant   string
+		expected time.Duration
+	}{
+		{org: "other", expected: 7 * 24 * time.Hour},
+		{org: "team-a", expected: time.Hour},
+		{org: "team-b", expected: 7 * 24 * time.Hour},
+		{org: "other", cluster: "short-lived", expected: time.Hour},
+		{org: "other", tenant: "team-a", expected: 7 * 24 * time.Hour},
+	}
+	for _, tc := range testCases {
+		pj := &prowapi.ProwJob{Spec: prowapi.ProwJobSpec{
+			Cluster:        tc.cluster,
+			Refs:           &prowapi.Refs{Org: tc.org, Repo: "repo"},
+			ProwJobDefault: &prowapi.ProwJobDefault{TenantID: tc.tenant},
+		}}
+		if actual := pc.MaxProwJobAgeFor(pj); actual != tc.expected {
+			t.Errorf("org %q, cluster %q, tenant %q: expected %s, got %s", tc.org, tc.cluster, tc.tenant, tc.expected, actual)
+		}
+	}
+}
+
+func TestTenantSecurityPolicyViolations(t *testing.T) {
+	policy := &TenantSecurityPolicy{
+		PrivilegedRepos:        []string{"org", "other/repo"},
+		RequiredResourceLimits: []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory},
+	}
+	privileged := true
+	limits := v1.ResourceRequirements{Limits: v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("1"),
+		v1.ResourceMemory: resource.MustParse("1Gi"),
+	}}
+	testCases := []struct {
+		name     string
+		org      string
+		repo     string
+		spec     *v1.PodSpec
+		expected []string
+	}{
+		{
+			name: "compliant pod",
+			org:  "another",
+			repo: "repo",
+			spec: &v1.PodSpec{Containers: []v1.Container{{Name: "test", Resources: limits}}},
+		},
+		{
+			name: "privileged container of allowlisted org",
+			org:  "org",
+			repo: "repo",
+			spec: &v1.PodSpec{Containers: []v1.Container{{Name: "test", Resources: limits, SecurityContext: &v1.SecurityContext{Privileged: &privileged}}}},
+		},
+		{
+			name: "privileged container of allowlisted repo",
+			org:  "other",
+			repo: "repo",
+			spec: &v1.PodSpec{Containers: []v1.Container{{Name: "test", Resources: limits, SecurityContext: &v1.SecurityContext{Privileged: &privileged}}}},
+		},
+		{
+			name:     "privileged init container of other repo",
+			org:      "other",
+			repo:     "other",
+			spec:     &v1.PodSpec{InitContainers: []v1.Container{{Name: "setup", Resources: limits, SecurityContext: &v1.SecurityContext{Privileged: &privileged}}}},
+			expected: []string{"container setup may not be privileged"},
+		},
+		{
+			name: "missing limits",
+			org:  "org",
+			repo: "repo",
+			spec: &v1.PodSpec{Containers: []v1.Container{
+				{Name: "test", Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}}},
+				{Name: "sidecar"},
+			}},
+			expected: []string{"container test must set a memory limit", "container sidecar must set a cpu limit", "container sidecar must set a memory limit"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, policy.Violations(tc.org, tc.repo, tc.spec)); diff != "" {
+				t.Errorf("violations differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNeedsSeccompProfile(t *testing.T) {
+	profile := &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}
+	testCases := []struct {
+		name     string
+		policy   *TenantSecurityPolicy
+		spec     *v1.PodSpec
+		expected bool
+	}{
+		{
+			name:   "policy without profile",
+			policy: &TenantSecurityPolicy{},
+			spec:   &v1.PodSpec{Containers: []v1.Container{{}}},
+		},
+		{
+			name:     "pod without profile",
+			policy:   &TenantSecurityPolicy{SeccompProfile: profile},
+			spec:     &v1.PodSpec{Containers: []v1.Container{{}}, SecurityContext: &v1.PodSecurityContext{}},
+			expected: true,
+		},
+		{
+			name:   "pod with profile",
+			policy: &TenantSecurityPolicy{SeccompProfile: profile},
+			spec:   &v1.PodSpec{Containers: []v1.Container{{}}, SecurityContext: &v1.PodSecurityContext{SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeUnconfined}}},
+		},
+		{
+			name:   "container with profile",
+			policy: &TenantSecurityPolicy{SeccompProfile: profile},
+			spec:   &v1.PodSpec{Containers: []v1.Container{{}, {SecurityContext: &v1.SecurityContext{SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeUnconfined}}}}},
+		},
+	}
+	for _, tc := range testCases {
+		if actual := tc.policy.NeedsSeccompProfile(tc.spec); actual != tc.expected {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// OverrideRestrictions is how gangway and sub treat envs and labels that
+// externally triggered runs set on jobs without trigger_overrides.
+type OverrideRestrictions string
+
+const (
+	// OverrideRestrictionsWarn accepts the overrides and logs a warning. It
+	// is the default while users migrate to trigger_overrides.
+	OverrideRestrictionsWarn OverrideRestrictions = "warn"
+	// OverrideRestrictionsEnforce rejects the overrides.
+	OverrideRestrictionsEnforce OverrideRestrictions = "enforce"
+)
+
+func (r OverrideRestrictions) validate() error {
+	switch r {
+	case "", OverrideRestrictionsWarn, OverrideRestrictionsEnforce:
+		return nil
+	}
+	return fmt.Errorf("override_restrictions must be %q or %q, not %q", OverrideRestrictionsWarn, OverrideRestrictionsEnforce, r)
+}
+
+// +k8s:deepcopy-gen=true
+
+// TriggerOverrides lists the fields of a job that externally triggered runs
+// may override.
+type TriggerOverrides struct {
+	// Envs are the names of the environment variables that can be set. "*"
+	// allows any.
+	Envs []string `json:"envs,omitempty"`
+	// Labels are the keys of the labels that can be set. "*" allows any.
+	Labels []string `json:"labels,omitempty"`
+	// Refs allows periodics to replace their extra_refs of the same repo,
+	// e.g. to run against a given commit.
+	Refs bool `json:"refs,omitempty"`
+}
+
+// Check returns an error listing the requested overrides that are not
+// allowed. A nil TriggerOverrides allows none.
+func (o *TriggerOverrides) Check(envs, labels map[string]string, refs bool) error {
+	if o == nil {
+		o = &TriggerOverrides{}
+	}
+	var denied []string
+	if names := disallowed(envs, o.Envs); len(names) > 0 {
+		denied = append(denied, fmt.Sprintf("envs %s", strings.Join(names, ", ")))
+	}
+	if names := disallowed(labels, o.Labels); len(names) > 0 {
+		denied = append(denied, fmt.Sprintf("labels %s", strings.Join(names, ", ")))
+	}
+	if refs && !o.Refs {
+		denied = append(denied, "refs")
+	}
+	if len(denied) > 0 {
+		return fmt.Errorf("the job does not allow overriding %s", strings.Join(denied, "; "))
+	}
+	return nil
+}
+
+func disallowed(requested map[string]string, allowed []string) []string {
+	var names []string
+	for name := range requested {
+		var ok bool
+		for _, a := range allowed {
+			if a == "*" || a == name {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (o *TriggerOverrides) validate() error {
+	if o == nil {
+		return nil
+	}
+	for _, name := range append(append([]string{}, o.Envs...), o.Labels...) {
+		if name == "" {
+			return errors.New("trigger_overrides: envs and labels must not be empty")
+		}
+	}
+	return nil
+}
@@ -73,6 +73,32 @@ func (in *CopyableRegexp) DeepCopy() *CopyableRegexp {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GerritChangeMatcher) DeepCopyInto(out *GerritChangeMatcher) {
+	*out = *in
+	if in.GerritHashtags != nil {
+		in, out := &in.GerritHashtags, &out.GerritHashtags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GerritTopics != nil {
+		in, out := &in.GerritTopics, &out.GerritTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GerritChangeMatcher.
+func (in *GerritChangeMatcher) DeepCopy() *GerritChangeMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(GerritChangeMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobBase) DeepCopyInto(out *JobBase) {
 	*out = *in
@@ -125,6 +151,36 @@ func (in *JobBase) DeepCopyInto(out *JobBase) {
 		*out = new(prowjobsv1.ProwJobDefault)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ArtifactRetention != nil {
+		in, out := &in.ArtifactRetention, &out.ArtifactRetention
+		*out = new(prowjobsv1.JobArtifactRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.TriggerOverrides != nil {
+		in, out := &in.TriggerOverrides, &out.TriggerOverrides
+		*out = new(TriggerOverrides)
+		(*in).DeepCopyInto(*out)
+	}
 	in.UtilityConfig.DeepCopyInto(&out.UtilityConfig)
 	return
 }
@@ -139,6 +195,67 @@ func (in *JobBase) DeepCopy() *JobBase {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Periodic) DeepCopyInto(out *Periodic) {
+	*out = *in
+	in.JobBase.DeepCopyInto(&out.JobBase)
+	if in.RunAt != nil {
+		in, out := &in.RunAt, &out.RunAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Periodic.
+func (in *Periodic) DeepCopy() *Periodic {
+	if in == nil {
+		return nil
+	}
+	out := new(Periodic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Postsubmit) DeepCopyInto(out *Postsubmit) {
 	*out = *in
@@ -149,6 +266,7 @@ func (in *Postsubmit) DeepCopyInto(out *Postsubmit) {
 		**out = **in
 	}
 	in.RegexpChangeMatcher.DeepCopyInto(&out.RegexpChangeMatcher)
+	in.GerritChangeMatcher.DeepCopyInto(&out.GerritChangeMatcher)
 	in.Brancher.DeepCopyInto(&out.Brancher)
 	out.Reporter = in.Reporter
 	if in.JenkinsSpec != nil {
@@ -219,6 +337,7 @@ func (in *Presubmit) DeepCopyInto(out *Presubmit) {
 	in.JobBase.DeepCopyInto(&out.JobBase)
 	in.Brancher.DeepCopyInto(&out.Brancher)
 	in.RegexpChangeMatcher.DeepCopyInto(&out.RegexpChangeMatcher)
+	in.GerritChangeMatcher.DeepCopyInto(&out.GerritChangeMatcher)
 	out.Reporter = in.Reporter
 	if in.JenkinsSpec != nil {
 		in, out := &in.JenkinsSpec, &out.JenkinsSpec
@@ -266,6 +385,13 @@ func (in *ProwYAML) DeepCopyInto(out *ProwYAML) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Periodics != nil {
+		in, out := &in.Periodics, &out.Periodics
+		*out = make([]Periodic, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ProwIgnored != nil {
 		in, out := &in.ProwIgnored, &out.ProwIgnored
 		*out = new(json.RawMessage)
@@ -308,6 +434,65 @@ func (in *RegexpChangeMatcher) DeepCopy() *RegexpChangeMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretBundle) DeepCopyInto(out *SecretBundle) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AllowedRepos != nil {
+		in, out := &in.AllowedRepos, &out.AllowedRepos
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedJobs != nil {
+		in, out := &in.AllowedJobs, &out.AllowedJobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretBundle.
+func (in *SecretBundle) DeepCopy() *SecretBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerOverrides) DeepCopyInto(out *TriggerOverrides) {
+	*out = *in
+	if in.Envs != nil {
+		in, out := &in.Envs, &out.Envs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerOverrides.
+func (in *TriggerOverrides) DeepCopy() *TriggerOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UtilityConfig) DeepCopyInto(out *UtilityConfig) {
 	*out = *in
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -33,6 +35,7 @@ import (
 
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/crier/reporters/criercommonlib"
+	"sigs.k8s.io/prow/pkg/tracing"
 )
 
 type ReportClient interface {
@@ -139,8 +142,15 @@ func (r *reconciler) reconcile(ctx context.Context, log *logrus.Entry, req recon
 
 	log = log.WithField("jobStatus", pj.Status.State)
 	log.Info("Will report state")
+	ctx, span := tracing.StartProwJobSpan(ctx, &pj, "crier.report")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("reporter", r.reporter.GetName()),
+		attribute.String("state", string(pj.Status.State)),
+	)
 	pjs, requeue, err := r.reporter.Report(ctx, log, &pj)
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		if criercommonlib.IsUserError(err) {
 			log.WithError(err).Debug("Failed to report job.")
 		} else {
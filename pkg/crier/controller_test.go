@@ -26,6 +26,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
@@ -315,6 +316,15 @@ func TestReconcile(t *testing.T) {
 					t.Error("got unexpected patch")
 				}
 			}
+			if test.expectPatch {
+				var pj prowv1.ProwJob
+				if err := cs.Get(context.Background(), types.NamespacedName{Name: toReconcile}, &pj); err != nil {
+					t.Fatalf("failed to get the prowjob: %v", err)
+				}
+				if !meta.IsStatusConditionTrue(pj.Status.Conditions, prowv1.ProwJobReported) {
+					t.Errorf("expected the %s condition to be true, got %+v", prowv1.ProwJobReported, pj.Status.Conditions)
+				}
+			}
 		})
 	}
 }
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
@@ -40,6 +41,9 @@ func updateReportState(ctx context.Context, pj *prowv1.ProwJob, log *logrus.Entr
 		newpj.Status.PrevReportStates = map[string]prowv1.ProwJobState{}
 	}
 	newpj.Status.PrevReportStates[reporterName] = reportedState
+	if newpj.Status.State == reportedState {
+		newpj.SetCondition(prowv1.ProwJobReported, metav1.ConditionTrue, "Reported", fmt.Sprintf("The %s reporter reported the %s state.", reporterName, reportedState))
+	}
 
 	if err := pjclientset.Patch(ctx, newpj, ctrlruntimeclient.MergeFrom(pj)); err != nil {
 		return fmt.Errorf("failed to patch: %w", err)
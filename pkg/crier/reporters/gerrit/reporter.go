@@ -112,6 +112,7 @@ type Client struct {
 	gc          gerritClient
 	pjclientset ctrlruntimeclient.Client
 	prLocks     *criercommonlib.ShardedLock
+	config      config.Getter
 }
 
 // Job is the view of a prowjob scoped for a report
@@ -132,7 +133,11 @@ type JobReport struct {
 }
 
 // NewReporter returns a reporter client
-func NewReporter(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, cookiefilePath string, pjclientset ctrlruntimeclient.Client, maxQPS, maxBurst int) (*Client, error) {
+func NewReporter(cfg config.Getter, cookiefilePath string, pjclientset ctrlruntimeclient.Client, maxQPS, maxBurst int) (*Client, error) {
+	orgRepoConfigGetter := func() *config.GerritOrgRepoConfigs {
+		return cfg().Gerrit.OrgReposConfig
+	}
+
 	// Initialize an empty client, the orgs/repos will be filled in by
 	// ApplyGlobalConfig later.
 	gc, err := client.NewClient(nil, maxQPS, maxBurst)
@@ -155,6 +160,7 @@ func NewReporter(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, cookie
 		gc:          gc,
 		pjclientset: pjclientset,
 		prLocks:     criercommonlib.NewShardedLock(),
+		config:      cfg,
 	}
 
 	c.prLocks.RunCleanup()
@@ -381,16 +387,17 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 	var change *gerrit.ChangeInfo
 	var err error
 	if reportLabel != "" {
+		votes := c.reportLabel(reportLabel)
 		var vote string
 		// Can only vote below zero before merge
 		// TODO(fejta): cannot vote below previous vote after merge
 		switch {
 		case report.Success == report.Total:
-			vote = lgtm
-		case pj.Spec.Type == v1.PresubmitJob:
+			vote = votes.PassVote
+		case pj.Spec.Type == v1.PresubmitJob && report.Total-report.Success >= votes.FailThreshold:
 			//https://gerrit-documentation.storage.googleapis.com/Documentation/3.1.4/config-labels.html#label_allowPostSubmit
-			// If presubmit and failure vote -1...
-			vote = lbtm
+			// If presubmit and enough failures vote -1...
+			vote = votes.FailVote
 
 			change, err = c.gc.GetChange(gerritInstance, gerritID)
 			if err != nil {
@@ -491,6 +498,15 @@ func (c *Client) Report(ctx context.Context, logger *logrus.Entry, pj *v1.ProwJo
 	return nil, nil, err
 }
 
+// reportLabel returns the votes to cast on a Gerrit label.
+func (c *Client) reportLabel(label string) config.GerritReportLabel {
+	if c.config == nil {
+		return config.GerritReportLabel{Label: label, PassVote: lgtm, FailVote: lbtm, FailThreshold: 1}
+	}
+	gerritConfig := c.config().Gerrit
+	return gerritConfig.ReportLabel(label)
+}
+
 func jobNames(jobs []*v1.ProwJob) []string {
 	names := make([]string, len(jobs))
 	for i, job := range jobs {
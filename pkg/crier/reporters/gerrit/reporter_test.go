@@ -36,6 +36,7 @@ import (
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/crier/reporters/criercommonlib"
 	"sigs.k8s.io/prow/pkg/kube"
 )
@@ -127,6 +128,15 @@ func TestReport(t *testing.T) {
 			{ID: "merged", Status: "MERGED", Revisions: map[string]gerrit.RevisionInfo{"abc": {}}},
 		},
 	}
+	gerritConfig := config.Gerrit{
+		ReportLabels: []config.GerritReportLabel{
+			{Label: "Verified", PassVote: "+2", FailVote: "-2", FailThreshold: 2},
+		},
+	}
+	if err := gerritConfig.DefaultAndValidate(); err != nil {
+		t.Fatalf("Failed to default and validate the gerrit config: %v", err)
+	}
+	cfg := func() *config.Config { return &config.Config{ProwConfig: config.ProwConfig{Gerrit: gerritConfig}} }
 	var testcases = []struct {
 		name              string
 		pj                *v1.ProwJob
@@ -546,6 +556,84 @@ func TestReport(t *testing.T) {
 			expectLabel:       map[string]string{codeReview: lbtm},
 			numExpectedReport: 0,
 		},
+		{
+			name: "1 job, failed, below the fail threshold of its label, should vote 0",
+			pj: &v1.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						kube.GerritRevision:    "abc",
+						kube.ProwJobTypeLabel:  presubmit,
+						kube.GerritReportLabel: "Verified",
+					},
+					Annotations: map[string]string{
+						kube.GerritID:       "123-abc",
+						kube.GerritInstance: "gerrit",
+					},
+					Name:      "ci-foo",
+					Namespace: "test-pods",
+				},
+				Status: v1.ProwJobStatus{
+					State: v1.FailureState,
+					URL:   "guber/foo",
+				},
+				Spec: v1.ProwJobSpec{
+					Type: v1.PresubmitJob,
+					Refs: &v1.Refs{
+						Repo: "foo",
+						Pulls: []v1.Pull{
+							{
+								Number: 0,
+							},
+						},
+					},
+					Job:    "ci-foo",
+					Report: true,
+				},
+			},
+			expectReport:      true,
+			reportInclude:     []string{"0 out of 1", "ci-foo", "FAILURE", "guber/foo"},
+			expectLabel:       map[string]string{"Verified": lztm},
+			numExpectedReport: 0,
+		},
+		{
+			name: "1 job, passed, should cast the configured pass vote of its label",
+			pj: &v1.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						kube.GerritRevision:    "abc",
+						kube.ProwJobTypeLabel:  presubmit,
+						kube.GerritReportLabel: "Verified",
+					},
+					Annotations: map[string]string{
+						kube.GerritID:       "123-abc",
+						kube.GerritInstance: "gerrit",
+					},
+					Name:      "ci-foo",
+					Namespace: "test-pods",
+				},
+				Status: v1.ProwJobStatus{
+					State: v1.SuccessState,
+					URL:   "guber/foo",
+				},
+				Spec: v1.ProwJobSpec{
+					Type: v1.PresubmitJob,
+					Refs: &v1.Refs{
+						Repo: "foo",
+						Pulls: []v1.Pull{
+							{
+								Number: 0,
+							},
+						},
+					},
+					Job:    "ci-foo",
+					Report: true,
+				},
+			},
+			expectReport:      true,
+			reportInclude:     []string{"1 out of 1", "ci-foo", "SUCCESS", "guber/foo"},
+			expectLabel:       map[string]string{"Verified": "+2"},
+			numExpectedReport: 0,
+		},
 		{
 			name: "1 job, passed, has slash in repo name, should report and handle slash properly",
 			pj: &v1.ProwJob{
@@ -2022,6 +2110,7 @@ func TestReport(t *testing.T) {
 				gc:          fgc,
 				pjclientset: builder.Build(),
 				prLocks:     criercommonlib.NewShardedLock(),
+				config:      cfg,
 			}
 
 			shouldReport := reporter.ShouldReport(context.Background(), logrus.NewEntry(logrus.StandardLogger()), tc.pj)
@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -48,6 +49,7 @@ type Client struct {
 	reportAgent v1.ProwJobAgent
 	prLocks     *criercommonlib.ShardedLock
 	lister      ctrlruntimeclient.Reader
+	throttle    *orgThrottle
 }
 
 // NewReporter returns a reporter client
@@ -91,6 +93,24 @@ func (c *Client) Report(ctx context.Context, log *logrus.Entry, pj *v1.ProwJob)
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
+	if pj.Spec.Refs == nil {
+		// There is no commit to report the job on.
+		return []*v1.ProwJob{pj}, nil, nil
+	}
+	if delay := c.throttle.delay(pj.Spec.Refs.Org); delay > 0 {
+		log.WithField("delay", delay).Debug("Org has reached its report limit, deferring report.")
+		return nil, &reconcile.Result{RequeueAfter: delay}, nil
+	}
+	pjs, result, err := c.report(ctx, log, pj)
+	if err == nil {
+		// A failed report is retried, so only count the ones that went through.
+		c.throttle.take(pj.Spec.Refs.Org)
+	}
+	return pjs, result, err
+}
+
+// report reports the status context of the job and comments on its PR.
+func (c *Client) report(ctx context.Context, log *logrus.Entry, pj *v1.ProwJob) ([]*v1.ProwJob, *reconcile.Result, error) {
 	// TODO(krzyzacy): ditch ReportTemplate, and we can drop reference to config.Getter
 	err := report.ReportStatusContext(ctx, c.gc, *pj, c.config().GitHubReporter)
 	if err != nil {
@@ -135,6 +155,16 @@ func (c *Client) Report(ctx context.Context, log *logrus.Entry, pj *v1.ProwJob)
 			return []*v1.ProwJob{pj}, nil, nil
 		}
 	}
+
+	if pj.Spec.Type == v1.PresubmitJob && matchesRepo(c.config().GitHubReporter.StickyCommentRepos, pj.Spec.Refs) {
+		toSummarize, err := pjsToSummarize(ctx, c.lister, pj)
+		if err != nil {
+			return []*v1.ProwJob{pj}, nil, err
+		}
+		err = report.ReportStickyComment(ctx, c.gc, c.config().Plank.ReportTemplateForRepo(pj.Spec.Refs), toSummarize, c.config().GitHubReporter)
+		return []*v1.ProwJob{pj}, nil, err
+	}
+
 	// Check if this org or repo has opted out of failure report comments
 	toReport := []v1.ProwJob{*pj}
 	var mustCreateComment bool
@@ -191,6 +221,59 @@ func pjsToReport(ctx context.Context, log *logrus.Entry, lister ctrlruntimeclien
 	return toReport, nil
 }
 
+// pjsToSummarize returns the latest run of every reported job on the
+// commit of the PR that the given ProwJob tests, including the ones that
+// are still running.
+func pjsToSummarize(ctx context.Context, lister ctrlruntimeclient.Reader, pj *v1.ProwJob) ([]v1.ProwJob, error) {
+	if len(pj.Spec.Refs.Pulls) != 1 {
+		return nil, nil
+	}
+	selector := map[string]string{}
+	for _, l := range []string{kube.OrgLabel, kube.RepoLabel, kube.PullLabel} {
+		selector[l] = pj.ObjectMeta.Labels[l]
+	}
+	var pjs v1.ProwJobList
+	if err := lister.List(ctx, &pjs, ctrlruntimeclient.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("Cannot list prowjob with selector %v", selector)
+	}
+
+	sha := pj.Spec.Refs.Pulls[0].SHA
+	latest := map[string]v1.ProwJob{pj.Spec.Job: *pj}
+	for _, pjob := range pjs.Items {
+		if !pjob.Spec.Report || pjob.Spec.Refs == nil || len(pjob.Spec.Refs.Pulls) != 1 || pjob.Spec.Refs.Pulls[0].SHA != sha {
+			continue
+		}
+		// The lister may not have caught up with the ProwJob being reported yet.
+		if pjob.Name == pj.Name {
+			continue
+		}
+		if existing, ok := latest[pjob.Spec.Job]; !ok || pjob.CreationTimestamp.After(existing.CreationTimestamp.Time) {
+			latest[pjob.Spec.Job] = pjob
+		}
+	}
+
+	toSummarize := make([]v1.ProwJob, 0, len(latest))
+	for _, pjob := range latest {
+		toSummarize = append(toSummarize, pjob)
+	}
+	sort.Slice(toSummarize, func(i, j int) bool { return toSummarize[i].Spec.Job < toSummarize[j].Spec.Job })
+	return toSummarize, nil
+}
+
+// matchesRepo tells whether the refs are of one of the given orgs or org/repos.
+func matchesRepo(idents []string, refs *v1.Refs) bool {
+	if refs == nil {
+		return false
+	}
+	fullRepo := fmt.Sprintf("%s/%s", refs.Org, refs.Repo)
+	for _, ident := range idents {
+		if refs.Org == ident || fullRepo == ident {
+			return true
+		}
+	}
+	return false
+}
+
 func lockKeyForPJ(pj *v1.ProwJob) (*criercommonlib.SimplePull, error) {
 	if pj.Spec.Type != v1.PresubmitJob {
 		return nil, fmt.Errorf("can only get lock key for presubmit jobs, was %q", pj.Spec.Type)
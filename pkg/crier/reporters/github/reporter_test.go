@@ -19,6 +19,8 @@ package github
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -1016,3 +1018,172 @@ func TestPjsToReport(t *testing.T) {
 		})
 	}
 }
+
+func TestPjsToSummarize(t *testing.T) {
+	timeNow := time.Now().Truncate(time.Second)
+	newPJ := func(name, job, sha string, created time.Time, report bool) *v1.ProwJob {
+		return &v1.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					kube.OrgLabel:  "org",
+					kube.RepoLabel: "repo",
+					kube.PullLabel: "123",
+				},
+				CreationTimestamp: metav1.Time{Time: created},
+			},
+			Spec: v1.ProwJobSpec{
+				Type:   v1.PresubmitJob,
+				Job:    job,
+				Report: report,
+				Refs: &v1.Refs{
+					Org:   "org",
+					Repo:  "repo",
+					Pulls: []v1.Pull{{Number: 123, SHA: sha}},
+				},
+			},
+		}
+	}
+
+	var testcases = []struct {
+		name        string
+		pj          *v1.ProwJob
+		existingPJs []*v1.ProwJob
+		wantJobs    []string
+	}{
+		{
+			name:     "only the reported job",
+			pj:       newPJ("0", "ci-foo", "abc", timeNow, true),
+			wantJobs: []string{"0"},
+		},
+		{
+			name: "latest run of every job on the same commit",
+			pj:   newPJ("0", "ci-foo", "abc", timeNow, true),
+			existingPJs: []*v1.ProwJob{
+				newPJ("1", "ci-bar", "abc", timeNow.Add(-time.Hour), true),
+				newPJ("2", "ci-bar", "abc", timeNow.Add(-time.Minute), true),
+				newPJ("3", "ci-foo", "abc", timeNow.Add(-time.Hour), true),
+			},
+			wantJobs: []string{"2", "0"},
+		},
+		{
+			name: "jobs of other commits and unreported jobs are ignored",
+			pj:   newPJ("0", "ci-foo", "abc", timeNow, true),
+			existingPJs: []*v1.ProwJob{
+				newPJ("1", "ci-bar", "def", timeNow, true),
+				newPJ("2", "ci-baz", "abc", timeNow, false),
+			},
+			wantJobs: []string{"0"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.pj)
+			for _, pj := range tc.existingPJs {
+				builder.WithObjects(pj)
+			}
+
+			gotPjs, err := pjsToSummarize(context.Background(), builder.Build(), tc.pj)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var gotJobs []string
+			for _, pj := range gotPjs {
+				gotJobs = append(gotJobs, pj.Name)
+			}
+			if diff := cmp.Diff(tc.wantJobs, gotJobs); diff != "" {
+				t.Fatalf("pjs mismatch. got(+), want(-):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReportStickyComment(t *testing.T) {
+	newPJ := func(name string, optional bool) *v1.ProwJob {
+		return &v1.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					kube.OrgLabel:        "org",
+					kube.RepoLabel:       "repo",
+					kube.PullLabel:       "123",
+					kube.IsOptionalLabel: strconv.FormatBool(optional),
+				},
+			},
+			Spec: v1.ProwJobSpec{
+				Type:    v1.PresubmitJob,
+				Job:     name,
+				Context: name,
+				Report:  true,
+				Refs: &v1.Refs{
+					Org:   "org",
+					Repo:  "repo",
+					Pulls: []v1.Pull{{Number: 123, SHA: "abc"}},
+				},
+			},
+			Status: v1.ProwJobStatus{
+				State:          v1.FailureState,
+				CompletionTime: &metav1.Time{},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		stickyRepos      []string
+		optional         bool
+		expectedStatuses int
+		expectedSticky   bool
+	}{
+		{
+			name:             "repo without a sticky comment",
+			expectedStatuses: 1,
+		},
+		{
+			name:             "required job keeps its status context",
+			stickyRepos:      []string{"org/repo"},
+			expectedStatuses: 1,
+			expectedSticky:   true,
+		},
+		{
+			name:             "optional job keeps its status context",
+			stickyRepos:      []string{"org"},
+			optional:         true,
+			expectedStatuses: 1,
+			expectedSticky:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := newPJ("ci-foo", tc.optional)
+			other := newPJ("ci-bar", false)
+			fghc := fakegithub.NewFakeClient()
+			c := NewReporter(fghc, func() *config.Config {
+				return &config.Config{
+					ProwConfig: config.ProwConfig{
+						GitHubReporter: config.GitHubReporter{
+							JobTypesToReport:   []v1.ProwJobType{v1.PresubmitJob},
+							StickyCommentRepos: tc.stickyRepos,
+						},
+					},
+				}
+			}, "", fakectrlruntimeclient.NewClientBuilder().WithObjects(pj, other).Build())
+
+			if _, _, err := c.Report(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(fghc.CreatedStatuses["abc"]); got != tc.expectedStatuses {
+				t.Errorf("expected %d statuses, got %d", tc.expectedStatuses, got)
+			}
+			comments := fghc.IssueComments[123]
+			if len(comments) != 1 {
+				t.Fatalf("expected one comment, got %d", len(comments))
+			}
+			if sticky := strings.Contains(comments[0].Body, "ci-bar"); sticky != tc.expectedSticky {
+				t.Errorf("expected the comment to summarize all jobs: %t, got: %t\n%s", tc.expectedSticky, sticky, comments[0].Body)
+			}
+		})
+	}
+}
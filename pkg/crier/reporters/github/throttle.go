@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ThrottleSettings limit how many ProwJobs of an org are reported per hour.
+type ThrottleSettings struct {
+	HourlyReports int
+	Burst         int
+}
+
+// orgThrottle keeps a token bucket per org, which maps to a GitHub App
+// installation. Unlike the throttle of the GitHub client, it does not
+// block: a report that would exceed the limit is deferred instead, so that
+// a mass re-report after an outage does not use up the API quota of an
+// installation that plugins need to respond to users.
+type orgThrottle struct {
+	defaults ThrottleSettings
+	orgs     map[string]ThrottleSettings
+
+	lock     sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// Throttle limits the reports of every org to the given settings, with
+// overrides per org. An org with zero hourly reports is not throttled.
+func (c *Client) Throttle(defaults ThrottleSettings, orgs map[string]ThrottleSettings) {
+	lowered := make(map[string]ThrottleSettings, len(orgs))
+	for org, settings := range orgs {
+		lowered[strings.ToLower(org)] = settings
+	}
+	c.throttle = &orgThrottle{
+		defaults: defaults,
+		orgs:     lowered,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// delay returns how long to wait until a report of the org may be made.
+func (t *orgThrottle) delay(org string) time.Duration {
+	limiter := t.limiter(org)
+	if limiter == nil {
+		return 0
+	}
+	if limiter.Burst() < 1 {
+		return time.Hour
+	}
+	missing := 1 - limiter.Tokens()
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(missing / float64(limiter.Limit()) * float64(time.Second))
+}
+
+// take takes a token for a report of the org that went through. Concurrent
+// reports may take more tokens than are left, which delays later reports.
+func (t *orgThrottle) take(org string) {
+	if limiter := t.limiter(org); limiter != nil {
+		limiter.Reserve()
+	}
+}
+
+// limiter returns the limiter of the org, or nil if it is not throttled.
+func (t *orgThrottle) limiter(org string) *rate.Limiter {
+	if t == nil {
+		return nil
+	}
+	org = strings.ToLower(org)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	limiter, ok := t.limiters[org]
+	if !ok {
+		settings, ok := t.orgs[org]
+		if !ok {
+			settings = t.defaults
+		}
+		if settings.HourlyReports > 0 {
+			limiter = rate.NewLimiter(rate.Limit(float64(settings.HourlyReports)/time.Hour.Seconds()), settings.Burst)
+		}
+		t.limiters[org] = limiter
+	}
+	return limiter
+}
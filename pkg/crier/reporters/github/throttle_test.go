@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+)
+
+func TestOrgThrottleDelay(t *testing.T) {
+	c := &Client{}
+	c.Throttle(ThrottleSettings{HourlyReports: 3600, Burst: 2}, map[string]ThrottleSettings{
+		"Limited":   {HourlyReports: 1, Burst: 1},
+		"unlimited": {},
+	})
+
+	for i := 0; i < 2; i++ {
+		if delay := c.throttle.delay("org"); delay != 0 {
+			t.Fatalf("report %d: expected no delay within the burst, got %v", i, delay)
+		}
+		c.throttle.take("org")
+	}
+	if delay := c.throttle.delay("org"); delay <= 0 || delay > time.Second {
+		t.Errorf("expected a delay of up to a second after the burst, got %v", delay)
+	}
+
+	if delay := c.throttle.delay("other-org"); delay != 0 {
+		t.Errorf("expected other orgs to have their own limit, got delay %v", delay)
+	}
+
+	// Only reports that went through take a token.
+	for i := 0; i < 2; i++ {
+		if delay := c.throttle.delay("limited"); delay != 0 {
+			t.Fatalf("report %d: expected no delay before a report went through, got %v", i, delay)
+		}
+	}
+	c.throttle.take("limited")
+	if delay := c.throttle.delay("limited"); delay < 59*time.Minute {
+		t.Errorf("expected the override of the org to apply, got delay %v", delay)
+	}
+
+	for i := 0; i < 10; i++ {
+		if delay := c.throttle.delay("unlimited"); delay != 0 {
+			t.Fatalf("report %d: expected an org without hourly reports not to be throttled, got %v", i, delay)
+		}
+		c.throttle.take("unlimited")
+	}
+
+	var unthrottled *orgThrottle
+	unthrottled.take("org")
+	if delay := unthrottled.delay("org"); delay != 0 {
+		t.Errorf("expected no delay without a throttle, got %v", delay)
+	}
+}
+
+func TestReportDeferredByThrottle(t *testing.T) {
+	fghc := fakegithub.NewFakeClient()
+	c := Client{
+		gc: fghc,
+		config: func() *config.Config {
+			return &config.Config{
+				ProwConfig: config.ProwConfig{
+					GitHubReporter: config.GitHubReporter{
+						JobTypesToReport: []v1.ProwJobType{v1.PostsubmitJob},
+					},
+				},
+			}
+		},
+	}
+	c.Throttle(ThrottleSettings{HourlyReports: 1, Burst: 1}, nil)
+	pj := &v1.ProwJob{
+		Spec: v1.ProwJobSpec{
+			Type:   v1.PostsubmitJob,
+			Report: true,
+			Refs:   &v1.Refs{Org: "org", Repo: "repo", BaseSHA: "abc"},
+		},
+		Status: v1.ProwJobStatus{
+			State:          v1.SuccessState,
+			CompletionTime: &metav1.Time{},
+		},
+	}
+
+	log := logrus.NewEntry(logrus.StandardLogger())
+	fghc.Error = errors.New("injected error")
+	if _, result, err := c.Report(context.Background(), log, pj); err == nil || result != nil {
+		t.Fatalf("expected the failing report to return its error, got result %v and error %v", result, err)
+	}
+	fghc.Error = nil
+	// The failed report did not take the token.
+	if _, result, err := c.Report(context.Background(), log, pj); err != nil || result != nil {
+		t.Fatalf("expected the first report to go through, got result %v and error %v", result, err)
+	}
+	pjs, result, err := c.Report(context.Background(), log, pj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.RequeueAfter <= 0 || len(pjs) != 0 {
+		t.Errorf("expected the second report to be deferred, got result %v and jobs %v", result, pjs)
+	}
+	if statuses := len(fghc.CreatedStatuses["abc"]); statuses != 1 {
+		t.Errorf("expected one status, got %d", statuses)
+	}
+}
+
+func TestReportWithoutRefs(t *testing.T) {
+	c := Client{gc: fakegithub.NewFakeClient()}
+	c.Throttle(ThrottleSettings{HourlyReports: 1, Burst: 1}, nil)
+	pj := &v1.ProwJob{
+		Spec: v1.ProwJobSpec{
+			Type:   v1.PeriodicJob,
+			Report: true,
+		},
+	}
+
+	pjs, result, err := c.Report(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj)
+	if err != nil || result != nil || len(pjs) != 1 {
+		t.Errorf("expected a job without refs to be reported as is, got jobs %v, result %v and error %v", pjs, result, err)
+	}
+}
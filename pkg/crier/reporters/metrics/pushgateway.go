@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/proto"
+)
+
+// pushgatewayJob is the value of the job label of all pushed metrics.
+const pushgatewayJob = "prow-crier"
+
+// groupingLabels identify a job. The metrics of the last run of every job
+// replace the ones of its previous run.
+var groupingLabels = []string{"job_name", "type", "org", "repo"}
+
+// pushgateway pushes samples to a Prometheus Pushgateway, see
+// https://github.com/prometheus/pushgateway.
+type pushgateway struct {
+	url    string
+	client *http.Client
+}
+
+func newPushgateway(url string) *pushgateway {
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	return &pushgateway{url: strings.TrimSuffix(url, "/"), client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// push replaces the metrics in the group of the job. The Pushgateway does
+// not keep timestamps, which is why the completion time is also a sample.
+func (pg *pushgateway) push(ctx context.Context, labels map[string]string, _ time.Time, samples []sample) error {
+	// Label values are base64 encoded as they may contain slashes.
+	components := []string{"job", pushgatewayJob}
+	grouping := map[string]bool{}
+	for _, name := range groupingLabels {
+		components = append(components, name+"@base64", encodeGroupingValue(labels[name]))
+		grouping[name] = true
+	}
+	pushURL := fmt.Sprintf("%s/metrics/%s", pg.url, strings.Join(components, "/"))
+
+	var metricLabels []*dto.LabelPair
+	for _, name := range sortedNames(labels) {
+		if !grouping[name] {
+			metricLabels = append(metricLabels, &dto.LabelPair{Name: proto.String(name), Value: proto.String(labels[name])})
+		}
+	}
+	var buf bytes.Buffer
+	for _, s := range samples {
+		mf := &dto.MetricFamily{
+			Name: proto.String(s.name),
+			Type: dto.MetricType_GAUGE.Enum(),
+			Metric: []*dto.Metric{{
+				Label: metricLabels,
+				Gauge: &dto.Gauge{Value: proto.Float64(s.value)},
+			}},
+		}
+		if _, err := expfmt.MetricFamilyToText(&buf, mf); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", s.name, err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, pushURL, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", string(expfmt.FmtText))
+	resp, err := pg.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return responseError(resp, pushURL)
+	}
+	return nil
+}
+
+// encodeGroupingValue encodes a label value for the URL of a group. An empty
+// value is encoded as a single "=", as the path segment may not be empty.
+func encodeGroupingValue(value string) string {
+	if value == "" {
+		return "="
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// remoteWrite sends samples with the Prometheus remote-write protocol, see
+// https://prometheus.io/docs/concepts/remote_write_spec/.
+type remoteWrite struct {
+	url    string
+	client *http.Client
+}
+
+func newRemoteWrite(url string) *remoteWrite {
+	return &remoteWrite{url: url, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (rw *remoteWrite) push(ctx context.Context, labels map[string]string, timestamp time.Time, samples []sample) error {
+	body := snappy.Encode(nil, encodeWriteRequest(labels, timestamp, samples))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rw.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	resp, err := rw.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return responseError(resp, rw.url)
+	}
+	return nil
+}
+
+// encodeWriteRequest returns a WriteRequest with one time series per sample.
+// It is encoded by hand to not depend on Prometheus for its protobuf types:
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label { string name = 1; string value = 2; }
+//	message Sample { double value = 1; int64 timestamp = 2; }
+func encodeWriteRequest(labels map[string]string, timestamp time.Time, samples []sample) []byte {
+	names := sortedNames(labels)
+	var req []byte
+	for _, s := range samples {
+		var series []byte
+		// __name__ sorts before all other label names.
+		series = appendLabel(series, "__name__", s.name)
+		for _, name := range names {
+			// Labels without a value are the same as missing ones.
+			if labels[name] != "" {
+				series = appendLabel(series, name, labels[name])
+			}
+		}
+		var value []byte
+		value = protowire.AppendTag(value, 1, protowire.Fixed64Type)
+		value = protowire.AppendFixed64(value, math.Float64bits(s.value))
+		value = protowire.AppendTag(value, 2, protowire.VarintType)
+		value = protowire.AppendVarint(value, uint64(timestamp.UnixMilli()))
+		series = protowire.AppendTag(series, 2, protowire.BytesType)
+		series = protowire.AppendBytes(series, value)
+
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, series)
+	}
+	return req
+}
+
+func appendLabel(b []byte, name, value string) []byte {
+	var label []byte
+	label = protowire.AppendTag(label, 1, protowire.BytesType)
+	label = protowire.AppendString(label, name)
+	label = protowire.AppendTag(label, 2, protowire.BytesType)
+	label = protowire.AppendString(label, value)
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	return protowire.AppendBytes(b, label)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains a crier reporter that pushes the outcome and
+// duration of finished ProwJobs to a Prometheus compatible monitoring stack,
+// for Prow instances whose metrics are not scraped.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+const reporterName = "metricsreporter"
+
+const (
+	// stateMetric is always 1, it tells which state a job finished in.
+	stateMetric = "prowjob_state"
+	// durationMetric is the time from the start to the completion of a job.
+	durationMetric = "prowjob_duration_seconds"
+	// completionMetric is the time a job completed at, as a Unix timestamp.
+	completionMetric = "prowjob_completion_timestamp_seconds"
+)
+
+// sample is a single value of a metric at the time a job completed.
+type sample struct {
+	name  string
+	value float64
+}
+
+// rejectedError is returned by a sink if the endpoint rejected the samples
+// for good, e.g. because they are out of order, so retrying is pointless.
+type rejectedError struct {
+	err error
+}
+
+func (e *rejectedError) Error() string {
+	return e.err.Error()
+}
+
+// responseError returns an error for an unsuccessful response. Client errors
+// other than 429 (Too Many Requests) are rejections, see
+// https://prometheus.io/docs/concepts/remote_write_spec/#retries-backoff.
+func responseError(resp *http.Response, url string) error {
+	body, _ := io.ReadAll(resp.Body) // Ignore any further error as this is for an error message only.
+	err := fmt.Errorf("unexpected status code %d while writing to %s: %s", resp.StatusCode, url, body)
+	if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusTooManyRequests {
+		return &rejectedError{err: err}
+	}
+	return err
+}
+
+// sink pushes the samples of a finished ProwJob to a monitoring stack.
+type sink interface {
+	push(ctx context.Context, labels map[string]string, timestamp time.Time, samples []sample) error
+}
+
+type metricsReporter struct {
+	sink   sink
+	dryRun bool
+}
+
+// NewRemoteWrite returns a reporter that sends samples to the given Prometheus
+// remote-write endpoint.
+func NewRemoteWrite(url string, dryRun bool) *metricsReporter {
+	return &metricsReporter{sink: newRemoteWrite(url), dryRun: dryRun}
+}
+
+// NewPushgateway returns a reporter that pushes samples to the given
+// Prometheus Pushgateway.
+func NewPushgateway(url string, dryRun bool) *metricsReporter {
+	return &metricsReporter{sink: newPushgateway(url), dryRun: dryRun}
+}
+
+func (mr *metricsReporter) GetName() string {
+	return reporterName
+}
+
+func (mr *metricsReporter) ShouldReport(_ context.Context, _ *logrus.Entry, pj *prowapi.ProwJob) bool {
+	return pj.Complete()
+}
+
+func (mr *metricsReporter) Report(ctx context.Context, log *logrus.Entry, pj *prowapi.ProwJob) ([]*prowapi.ProwJob, *reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	labels := labelsFor(pj)
+	completion := pj.Status.CompletionTime.Time
+	samples := []sample{
+		{name: stateMetric, value: 1},
+		{name: durationMetric, value: completion.Sub(pj.Status.StartTime.Time).Seconds()},
+		{name: completionMetric, value: float64(completion.Unix())},
+	}
+	if mr.dryRun {
+		log.WithField("labels", labels).Info("Would push job metrics.")
+		return []*prowapi.ProwJob{pj}, nil, nil
+	}
+	if err := mr.sink.push(ctx, labels, completion, samples); err != nil {
+		var rejected *rejectedError
+		if errors.As(err, &rejected) {
+			// Pushing the same samples again would be rejected as well.
+			log.WithError(err).Warn("Job metrics were rejected, dropping them.")
+			return []*prowapi.ProwJob{pj}, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to push job metrics: %w", err)
+	}
+	return []*prowapi.ProwJob{pj}, nil, nil
+}
+
+// labelsFor returns the labels of the samples of a ProwJob. They match the
+// ones of the metrics that Prow exposes for scraping where they overlap.
+func labelsFor(pj *prowapi.ProwJob) map[string]string {
+	refs := pj.Spec.Refs
+	if refs == nil && len(pj.Spec.ExtraRefs) > 0 {
+		refs = &pj.Spec.ExtraRefs[0]
+	}
+	labels := map[string]string{
+		"job_name": pj.Spec.Job,
+		"type":     string(pj.Spec.Type),
+		"state":    string(pj.Status.State),
+		"cluster":  pj.ClusterAlias(),
+		"org":      "",
+		"repo":     "",
+		"base_ref": "",
+	}
+	if refs != nil {
+		labels["org"] = refs.Org
+		labels["repo"] = refs.Repo
+		labels["base_ref"] = refs.BaseRef
+	}
+	return labels
+}
+
+// sortedNames returns the names of the labels in lexicographic order, which
+// is the order both remote-write and the exposition format expect.
+func sortedNames(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/snappy"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protowire"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+var (
+	started   = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	completed = started.Add(90 * time.Second)
+)
+
+func finishedJob() *prowapi.ProwJob {
+	return &prowapi.ProwJob{
+		Spec: prowapi.ProwJobSpec{
+			Type:    prowapi.PresubmitJob,
+			Job:     "pull-unit",
+			Cluster: "build01",
+			Refs: &prowapi.Refs{
+				Org:     "org",
+				Repo:    "repo",
+				BaseRef: "main",
+			},
+		},
+		Status: prowapi.ProwJobStatus{
+			State:          prowapi.FailureState,
+			StartTime:      metav1.Time{Time: started},
+			CompletionTime: &metav1.Time{Time: completed},
+		},
+	}
+}
+
+func TestShouldReport(t *testing.T) {
+	pj := finishedJob()
+	if !(&metricsReporter{}).ShouldReport(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj) {
+		t.Error("expected a finished job to be reported")
+	}
+	pj.Status.CompletionTime = nil
+	if (&metricsReporter{}).ShouldReport(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj) {
+		t.Error("expected a running job not to be reported")
+	}
+}
+
+func TestLabelsFor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pj       *prowapi.ProwJob
+		expected map[string]string
+	}{
+		{
+			name: "presubmit",
+			pj:   finishedJob(),
+			expected: map[string]string{
+				"job_name": "pull-unit",
+				"type":     "presubmit",
+				"state":    "failure",
+				"cluster":  "build01",
+				"org":      "org",
+				"repo":     "repo",
+				"base_ref": "main",
+			},
+		},
+		{
+			name: "periodic without refs",
+			pj: &prowapi.ProwJob{
+				Spec:   prowapi.ProwJobSpec{Type: prowapi.PeriodicJob, Job: "ci-cleanup"},
+				Status: prowapi.ProwJobStatus{State: prowapi.SuccessState},
+			},
+			expected: map[string]string{
+				"job_name": "ci-cleanup",
+				"type":     "periodic",
+				"state":    "success",
+				"cluster":  "default",
+				"org":      "",
+				"repo":     "",
+				"base_ref": "",
+			},
+		},
+		{
+			name: "periodic with extra refs",
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					Type:      prowapi.PeriodicJob,
+					Job:       "ci-e2e",
+					ExtraRefs: []prowapi.Refs{{Org: "org", Repo: "other", BaseRef: "main"}},
+				},
+				Status: prowapi.ProwJobStatus{State: prowapi.SuccessState},
+			},
+			expected: map[string]string{
+				"job_name": "ci-e2e",
+				"type":     "periodic",
+				"state":    "success",
+				"cluster":  "default",
+				"org":      "org",
+				"repo":     "other",
+				"base_ref": "main",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, labelsFor(tc.pj)); diff != "" {
+				t.Errorf("labels mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type timeSeries struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// decodeWriteRequest decodes the fields of a WriteRequest that
+// encodeWriteRequest sets.
+func decodeWriteRequest(t *testing.T, b []byte) []timeSeries {
+	t.Helper()
+	var result []timeSeries
+	forEachField(t, b, func(_ protowire.Number, series []byte) {
+		ts := timeSeries{labels: map[string]string{}}
+		forEachField(t, series, func(num protowire.Number, v []byte) {
+			switch num {
+			case 1:
+				var name string
+				forEachField(t, v, func(num protowire.Number, v []byte) {
+					if num == 1 {
+						name = string(v)
+					} else {
+						ts.labels[name] = string(v)
+					}
+				})
+			case 2:
+				bits, n := protowire.ConsumeFixed64(v[1:])
+				if n < 0 {
+					t.Fatalf("invalid sample value: %v", protowire.ParseError(n))
+				}
+				ts.value = math.Float64frombits(bits)
+				timestamp, n := protowire.ConsumeVarint(v[1+n+1:])
+				if n < 0 {
+					t.Fatalf("invalid sample timestamp: %v", protowire.ParseError(n))
+				}
+				ts.timestamp = int64(timestamp)
+			}
+		})
+		result = append(result, ts)
+	})
+	return result
+}
+
+// forEachField calls f with the content of every length delimited field.
+func forEachField(t *testing.T, b []byte, f func(protowire.Number, []byte)) {
+	t.Helper()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 || typ != protowire.BytesType {
+			t.Fatalf("unexpected tag of type %v: %v", typ, protowire.ParseError(n))
+		}
+		b = b[n:]
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			t.Fatalf("invalid field: %v", protowire.ParseError(n))
+		}
+		f(num, v)
+		b = b[n:]
+	}
+}
+
+func TestReportRemoteWrite(t *testing.T) {
+	var got []timeSeries
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "snappy" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("failed to read body: %v", err)
+		}
+		decoded, err := snappy.Decode(nil, body)
+		if err != nil {
+			t.Fatalf("failed to decompress body: %v", err)
+		}
+		got = decodeWriteRequest(t, decoded)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	reporter := NewRemoteWrite(server.URL, false)
+	if _, _, err := reporter.Report(context.Background(), logrus.NewEntry(logrus.StandardLogger()), finishedJob()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	labels := func(name string) map[string]string {
+		return map[string]string{
+			"__name__": name,
+			"job_name": "pull-unit",
+			"type":     "presubmit",
+			"state":    "failure",
+			"cluster":  "build01",
+			"org":      "org",
+			"repo":     "repo",
+			"base_ref": "main",
+		}
+	}
+	expected := []timeSeries{
+		{labels: labels(stateMetric), value: 1, timestamp: completed.UnixMilli()},
+		{labels: labels(durationMetric), value: 90, timestamp: completed.UnixMilli()},
+		{labels: labels(completionMetric), value: float64(completed.Unix()), timestamp: completed.UnixMilli()},
+	}
+	if diff := cmp.Diff(expected, got, cmp.AllowUnexported(timeSeries{})); diff != "" {
+		t.Errorf("time series mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReportPushgateway(t *testing.T) {
+	var gotMethod, gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("failed to read body: %v", err)
+		}
+		gotMethod, gotPath, gotBody = r.Method, r.URL.Path, string(body)
+	}))
+	defer server.Close()
+
+	pj := finishedJob()
+	pj.Spec.Type = prowapi.PeriodicJob
+	pj.Spec.Refs = nil
+	reporter := NewPushgateway(server.URL, false)
+	if _, _, err := reporter.Report(context.Background(), logrus.NewEntry(logrus.StandardLogger()), pj); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotMethod != http.MethodPut {
+		t.Errorf("expected a PUT, got %s", gotMethod)
+	}
+	encoded := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	expectedPath := "/metrics/job/prow-crier/job_name@base64/" + encoded("pull-unit") + "/type@base64/" + encoded("periodic") + "/org@base64/=/repo@base64/="
+	if gotPath != expectedPath {
+		t.Errorf("expected path %s, got %s", expectedPath, gotPath)
+	}
+	for _, line := range []string{
+		"# TYPE prowjob_state gauge",
+		`prowjob_state{base_ref="",cluster="build01",state="failure"} 1`,
+		`prowjob_duration_seconds{base_ref="",cluster="build01",state="failure"} 90`,
+	} {
+		if !strings.Contains(gotBody, line) {
+			t.Errorf("expected body to contain %q, got:\n%s", line, gotBody)
+		}
+	}
+}
+
+func TestReportError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      int
+		expectedErr bool
+	}{
+		{name: "rejected samples are dropped", status: http.StatusBadRequest},
+		{name: "rate limiting is retried", status: http.StatusTooManyRequests, expectedErr: true},
+		{name: "server errors are retried", status: http.StatusServiceUnavailable, expectedErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "out of order sample", tc.status)
+			}))
+			defer server.Close()
+
+			reported, _, err := NewRemoteWrite(server.URL, false).Report(context.Background(), logrus.NewEntry(logrus.StandardLogger()), finishedJob())
+			if !tc.expectedErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(reported) != 1 {
+					t.Errorf("expected the job to be reported, got %v", reported)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "out of order sample") {
+				t.Errorf("expected the error of the endpoint, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// classifyFailure classifies the outcome of the process when the entrypoint
+// rather than the process knows why it failed: the process timed out or
+// could not run. It returns nil otherwise, leaving the classification to the
+// job and the sidecar.
+func (o Options) classifyFailure(code int, err error) *prowapi.Failure {
+	switch {
+	case err == nil || code == 0 || errors.Is(err, errAborted) || errors.Is(err, errProcessFailed):
+		return nil
+	case errors.Is(err, errTimedOut):
+		timeout := optionOrDefault(o.Timeout, DefaultTimeout)
+		return &prowapi.Failure{
+			Category: prowapi.FailureCategoryTimeout,
+			Message:  fmt.Sprintf("The process did not finish before the %s timeout.", timeout),
+		}
+	default:
+		return &prowapi.Failure{
+			Category: prowapi.FailureCategoryInfra,
+			Message:  err.Error(),
+		}
+	}
+}
+
+// writeFailure writes the failure to the failure file, unless the job
+// already classified its failure there.
+func (o Options) writeFailure(failure prowapi.Failure) error {
+	if o.FailureFile == "" {
+		return nil
+	}
+	if _, err := os.Stat(o.FailureFile); err == nil {
+		return nil
+	}
+	raw, err := json.Marshal(failure)
+	if err != nil {
+		return fmt.Errorf("marshal failure: %w", err)
+	}
+	if err := os.WriteFile(o.FailureFile, raw, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", o.FailureFile, err)
+	}
+	return nil
+}
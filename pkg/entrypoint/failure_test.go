@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		code     int
+		err      error
+		expected *prowapi.Failure
+	}{
+		{
+			name: "process passed",
+		},
+		{
+			name: "process failed",
+			code: 1,
+			err:  fmt.Errorf("%w: exit status 1", errProcessFailed),
+		},
+		{
+			name: "process aborted",
+			code: AbortedErrorCode,
+			err:  errAborted,
+		},
+		{
+			name: "previous step failed",
+			code: PreviousErrorCode,
+		},
+		{
+			name:     "process timed out",
+			code:     InternalErrorCode,
+			err:      errTimedOut,
+			expected: &prowapi.Failure{Category: prowapi.FailureCategoryTimeout, Message: "The process did not finish before the 1m0s timeout."},
+		},
+		{
+			name:     "process could not start",
+			code:     InternalErrorCode,
+			err:      errors.New("could not start the process: not found"),
+			expected: &prowapi.Failure{Category: prowapi.FailureCategoryInfra, Message: "could not start the process: not found"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			options := Options{Timeout: time.Minute}
+			if actual := options.classifyFailure(testCase.code, testCase.err); !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected failure %v, got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWriteFailure(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		existing string
+		expected prowapi.Failure
+	}{
+		{
+			name:     "failure written",
+			expected: prowapi.Failure{Category: prowapi.FailureCategoryTimeout, Message: "timed out"},
+		},
+		{
+			name:     "failure of the job kept",
+			existing: `{"category":"infra","message":"cluster setup failed","owner":"sig-testing"}`,
+			expected: prowapi.Failure{Category: prowapi.FailureCategoryInfra, Message: "cluster setup failed", Owner: "sig-testing"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			failureFile := filepath.Join(t.TempDir(), prowapi.FailureFile)
+			if testCase.existing != "" {
+				if err := os.WriteFile(failureFile, []byte(testCase.existing), 0644); err != nil {
+					t.Fatalf("failed to write the existing failure: %v", err)
+				}
+			}
+			options := Options{Options: &wrapper.Options{FailureFile: failureFile}}
+			if err := options.writeFailure(prowapi.Failure{Category: prowapi.FailureCategoryTimeout, Message: "timed out"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			raw, err := os.ReadFile(failureFile)
+			if err != nil {
+				t.Fatalf("failed to read the failure: %v", err)
+			}
+			var actual prowapi.Failure
+			if err := json.Unmarshal(raw, &actual); err != nil {
+				t.Fatalf("failed to unmarshal the failure: %v", err)
+			}
+			if actual != testCase.expected {
+				t.Errorf("expected failure %v, got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
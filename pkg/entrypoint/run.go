@@ -69,6 +69,9 @@ var (
 	// errAborted is used as the command's error when the command
 	// is shut down by an external signal
 	errAborted = errors.New("process aborted")
+	// errProcessFailed is used as the command's error when the
+	// command exits with a non-zero code on its own
+	errProcessFailed = errors.New("wrapped process failed")
 )
 
 // Run executes the test process then writes the exit code to the marker file.
@@ -83,6 +86,11 @@ func (o Options) internalRun(interrupt chan os.Signal) int {
 	if err != nil {
 		logrus.WithError(err).Error("Error executing test process")
 	}
+	if failure := o.classifyFailure(code, err); failure != nil {
+		if err := o.writeFailure(*failure); err != nil {
+			logrus.WithError(err).Warn("Error writing the failure file")
+		}
+	}
 	if err := o.Mark(code); err != nil {
 		logrus.WithError(err).Error("Error writing exit code to marker file")
 		return InternalErrorCode // we need to mark the real error code to safely return AlwaysZero
@@ -203,7 +211,7 @@ func (o Options) ExecuteProcess(signaledInterrupt chan os.Signal) (int, error) {
 		}
 
 		if returnCode != 0 {
-			commandErr = fmt.Errorf("wrapped process failed: %w", commandErr)
+			commandErr = fmt.Errorf("%w: %w", errProcessFailed, commandErr)
 		}
 	}
 	return returnCode, commandErr
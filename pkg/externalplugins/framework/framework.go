@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package framework provides everything an external plugin needs besides its
+// event handlers: the flags, HMAC validation of the webhooks, the demuxing of
+// events to the handlers, the GitHub client honoring --dry-run, the plugin
+// config, the help endpoint and the graceful shutdown. A plugin boils down to:
+//
+//	o := framework.Options{}
+//	o.AddFlags(flag.CommandLine)
+//	flag.Parse()
+//	if err := o.Validate(); err != nil {
+//		logrus.WithError(err).Fatal("Invalid options")
+//	}
+//	p, err := framework.New("my-plugin", o)
+//	if err != nil {
+//		logrus.WithError(err).Fatal("Error setting up the plugin")
+//	}
+//	p.RegisterHelpProvider(helpProvider)
+//	p.RegisterHandlePullRequestEvent(func(l *logrus.Entry, pre github.PullRequestEvent) {
+//		handle(l, p.GitHubClient, pre)
+//	})
+//	p.Run()
+package framework
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/config/secret"
+	"sigs.k8s.io/prow/pkg/flagutil"
+	pluginsflagutil "sigs.k8s.io/prow/pkg/flagutil/plugins"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/githubeventserver"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/metrics"
+	"sigs.k8s.io/prow/pkg/pjutil"
+	"sigs.k8s.io/prow/pkg/pjutil/pprof"
+	"sigs.k8s.io/prow/pkg/pluginhelp/externalplugins"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// Options holds the flags of an external plugin. Fields can be defaulted, e.g.
+// GitHub throttling or PluginsConfig.PluginConfigPathDefault, before AddFlags
+// is called.
+type Options struct {
+	DryRun         bool
+	HMACSecretFile string
+	LogLevel       string
+	GracePeriod    time.Duration
+
+	GitHub          flagutil.GitHubOptions
+	Instrumentation flagutil.InstrumentationOptions
+	// PluginsConfig is only loaded if --plugin-config is set.
+	PluginsConfig pluginsflagutil.PluginOptions
+	Server        githubeventserver.Options
+}
+
+// AddFlags adds the flags of the options to the given FlagSet.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&o.DryRun, "dry-run", true, "Dry run for testing. Uses API tokens but does not mutate.")
+	fs.StringVar(&o.HMACSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret.")
+	fs.StringVar(&o.LogLevel, "log-level", "info", fmt.Sprintf("Log level is one of %v.", logrus.AllLevels))
+	fs.DurationVar(&o.GracePeriod, "grace-period", 180*time.Second, "On shutdown, try to handle remaining events for the specified duration.")
+	// Hook sends events to http://<plugin name> unless an endpoint is configured.
+	if o.Server.EndpointDefault == "" {
+		o.Server.EndpointDefault = "/"
+	}
+	o.Server.Bind(fs)
+	for _, group := range []flagutil.OptionGroup{&o.GitHub, &o.Instrumentation, &o.PluginsConfig} {
+		group.AddFlags(fs)
+	}
+}
+
+// Validate validates the options and defaults the server options.
+func (o *Options) Validate() error {
+	for _, group := range []flagutil.OptionGroup{&o.GitHub, &o.Instrumentation, &o.PluginsConfig} {
+		if err := group.Validate(o.DryRun); err != nil {
+			return err
+		}
+	}
+	if _, err := logrus.ParseLevel(o.LogLevel); err != nil {
+		return fmt.Errorf("invalid --log-level: %w", err)
+	}
+	return o.Server.DefaultAndValidate()
+}
+
+// Plugin is an external plugin. Event handlers and additional endpoints are
+// registered on the embedded GitHubEventServer before calling Run.
+type Plugin struct {
+	*githubeventserver.GitHubEventServer
+
+	Name string
+	Log  *logrus.Entry
+	// GitHubClient does not mutate anything in dry-run mode.
+	GitHubClient github.Client
+	// PluginAgent holds the plugin config, which is reloaded when it
+	// changes. It is nil if --plugin-config is not set.
+	PluginAgent *plugins.ConfigAgent
+
+	options Options
+}
+
+// New sets up the plugin with the given name from validated options.
+func New(name string, o Options) (*Plugin, error) {
+	level, err := logrus.ParseLevel(o.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --log-level: %w", err)
+	}
+	logrus.SetLevel(level)
+	log := logrus.WithField("plugin", name)
+
+	if err := secret.Add(o.HMACSecretFile); err != nil {
+		return nil, fmt.Errorf("error starting secrets agent: %w", err)
+	}
+
+	githubClient, err := o.GitHub.GitHubClient(o.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("error getting GitHub client: %w", err)
+	}
+
+	var pluginAgent *plugins.ConfigAgent
+	if o.PluginsConfig.PluginConfigPath != "" {
+		if pluginAgent, err = o.PluginsConfig.PluginAgent(); err != nil {
+			return nil, fmt.Errorf("error loading plugin config: %w", err)
+		}
+	}
+
+	return &Plugin{
+		GitHubEventServer: githubeventserver.New(o.Server, secret.GetTokenGenerator(o.HMACSecretFile), log),
+		Name:              name,
+		Log:               log,
+		GitHubClient:      githubClient,
+		PluginAgent:       pluginAgent,
+		options:           o,
+	}, nil
+}
+
+// RegisterHelpProvider serves the help of the plugin to hook on /help.
+func (p *Plugin) RegisterHelpProvider(provider externalplugins.ExternalPluginHelpProvider) {
+	p.GitHubEventServer.RegisterHelpProvider(provider, p.Log)
+}
+
+// Run serves the plugin until it is interrupted, and then waits for the
+// events being handled before returning.
+func (p *Plugin) Run() {
+	defer interrupts.WaitForGracefulShutdown()
+
+	metrics.ExposeMetrics(p.Name, config.PushGateway{}, p.options.Instrumentation.MetricsPort)
+	pprof.Instrument(p.options.Instrumentation)
+	interrupts.OnInterrupt(p.GracefulShutdown)
+
+	health := pjutil.NewHealthOnPort(p.options.Instrumentation.HealthPort)
+	health.ServeReady()
+
+	interrupts.ListenAndServe(p.GitHubEventServer, p.options.GracePeriod)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestOptions(t *testing.T) {
+	dir := t.TempDir()
+	testCases := []struct {
+		name             string
+		args             []string
+		expectedEndpoint string
+		expectedErr      bool
+	}{
+		{
+			name:             "defaults",
+			expectedEndpoint: "/",
+		},
+		{
+			name:             "custom endpoint",
+			args:             []string{"--endpoint=/hook"},
+			expectedEndpoint: "/hook",
+		},
+		{
+			name:        "invalid endpoint",
+			args:        []string{"--endpoint=hook"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid log level",
+			args:        []string{"--log-level=loud"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid GitHub options",
+			args:        []string{"--github-app-id=123"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			o := Options{}
+			fs := flag.NewFlagSet(tc.name, flag.ContinueOnError)
+			o.AddFlags(fs)
+			if err := fs.Parse(append([]string{"--github-token-path=" + writeFile(t, dir, "token", "token")}, tc.args...)); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+			err := o.Validate()
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if !o.DryRun {
+				t.Error("expected dry-run to be the default")
+			}
+			if endpoint := fs.Lookup("endpoint").Value.String(); endpoint != tc.expectedEndpoint {
+				t.Errorf("expected endpoint %q, got %q", tc.expectedEndpoint, endpoint)
+			}
+			if o.Server.Metrics == nil {
+				t.Error("expected the server options to be defaulted")
+			}
+		})
+	}
+}
+
+func TestPlugin(t *testing.T) {
+	dir := t.TempDir()
+	o := Options{}
+	fs := flag.NewFlagSet("plugin", flag.ContinueOnError)
+	o.AddFlags(fs)
+	if err := fs.Parse([]string{
+		"--hmac-secret-file=" + writeFile(t, dir, "hmac", "abc"),
+		"--github-token-path=" + writeFile(t, dir, "token", "token"),
+		"--plugin-config=" + writeFile(t, dir, "plugins.yaml", "plugins:\n  org/repo:\n    plugins:\n    - size\n"),
+	}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if err := o.Validate(); err != nil {
+		t.Fatalf("invalid options: %v", err)
+	}
+
+	p, err := New("my-plugin", o)
+	if err != nil {
+		t.Fatalf("failed to set up the plugin: %v", err)
+	}
+	if p.GitHubClient == nil {
+		t.Error("expected a GitHub client")
+	}
+	if p.PluginAgent == nil || len(p.PluginAgent.Config().Plugins) != 1 {
+		t.Error("expected the plugin config to be loaded")
+	}
+
+	var lock sync.Mutex
+	var handled []int
+	p.RegisterHandlePullRequestEvent(func(l *logrus.Entry, pre github.PullRequestEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+		handled = append(handled, pre.Number)
+	})
+	p.RegisterHelpProvider(func(enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+		return &pluginhelp.PluginHelp{Description: "My plugin."}, nil
+	})
+
+	payload := []byte(`{"action":"opened","number":42,"repo":{"full_name":"org/repo"}}`)
+	for _, sig := range []string{github.PayloadSignature(payload, []byte("abc")), github.PayloadSignature(payload, []byte("wrong"))} {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(payload))
+		req.Header.Set("X-GitHub-Event", "pull_request")
+		req.Header.Set("X-GitHub-Delivery", "guid")
+		req.Header.Set("X-Hub-Signature", sig)
+		req.Header.Set("content-type", "application/json")
+		p.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	p.GracefulShutdown()
+	if len(handled) != 1 || handled[0] != 42 {
+		t.Errorf("expected only the signed event to be handled, got %v", handled)
+	}
+
+	rr := httptest.NewRecorder()
+	p.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/help", strings.NewReader("[]")))
+	var help pluginhelp.PluginHelp
+	if err := json.Unmarshal(rr.Body.Bytes(), &help); err != nil {
+		t.Fatalf("failed to unmarshal help %q: %v", rr.Body.String(), err)
+	}
+	if help.Description != "My plugin." {
+		t.Errorf("expected the help of the plugin, got %+v", help)
+	}
+}
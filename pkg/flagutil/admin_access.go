@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flagutil
+
+import (
+	"errors"
+	"flag"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/adminaccess"
+	"sigs.k8s.io/prow/pkg/interrupts"
+)
+
+// AdminAccessOptions configures TLS for a server and who may reach its
+// sensitive endpoints.
+type AdminAccessOptions struct {
+	// AllowedCIDRs are the networks that may reach sensitive endpoints.
+	AllowedCIDRs Strings
+	// TLSCertFile and TLSKeyFile are the serving certificate and key. If
+	// unset, the server serves plain HTTP.
+	TLSCertFile string
+	TLSKeyFile  string
+	// ClientCAFile holds the CAs that client certificates are verified
+	// against. If set, sensitive endpoints require a verified client
+	// certificate.
+	ClientCAFile string
+	// ClientCertNames are the common names or DNS names of the client
+	// certificates that may reach sensitive endpoints. If empty, any
+	// verified client certificate may.
+	ClientCertNames Strings
+}
+
+// AddFlags injects admin access options into the given FlagSet.
+func (o *AdminAccessOptions) AddFlags(fs *flag.FlagSet) {
+	fs.Var(&o.AllowedCIDRs, "admin-allowed-cidr", "CIDR that may reach sensitive endpoints, can be passed multiple times. If unset, every address may.")
+	fs.StringVar(&o.TLSCertFile, "tls-cert-file", "", "Path to the TLS certificate to serve with. If unset, plain HTTP is served.")
+	fs.StringVar(&o.TLSKeyFile, "tls-key-file", "", "Path to the private key of --tls-cert-file.")
+	fs.StringVar(&o.ClientCAFile, "admin-client-ca-file", "", "Path to the CAs that client certificates are verified against. If set, sensitive endpoints require a verified client certificate. Requires --tls-cert-file.")
+	fs.Var(&o.ClientCertNames, "admin-client-cert-name", "Common name or DNS name of a client certificate that may reach sensitive endpoints, can be passed multiple times. If unset, any verified client certificate may. Requires --admin-client-ca-file.")
+}
+
+// Validate validates admin access options.
+func (o *AdminAccessOptions) Validate(_ bool) error {
+	if (o.TLSCertFile == "") != (o.TLSKeyFile == "") {
+		return errors.New("--tls-cert-file and --tls-key-file must be set together")
+	}
+	if o.ClientCAFile != "" && o.TLSCertFile == "" {
+		return errors.New("--admin-client-ca-file requires --tls-cert-file")
+	}
+	if len(o.ClientCertNames.Strings()) > 0 && o.ClientCAFile == "" {
+		return errors.New("--admin-client-cert-name requires --admin-client-ca-file")
+	}
+	_, err := o.Policy()
+	return err
+}
+
+// Policy returns the policy protecting sensitive endpoints, or nil if every
+// request may reach them.
+func (o *AdminAccessOptions) Policy() (*adminaccess.Policy, error) {
+	if len(o.AllowedCIDRs.Strings()) == 0 && o.ClientCAFile == "" {
+		return nil, nil
+	}
+	return adminaccess.NewPolicy(o.AllowedCIDRs.Strings(), o.ClientCAFile != "", o.ClientCertNames.Strings())
+}
+
+// ListenAndServe runs the server with TLS if a serving certificate is
+// configured and with plain HTTP otherwise, see interrupts.ListenAndServe.
+func (o *AdminAccessOptions) ListenAndServe(server *http.Server, gracePeriod time.Duration) error {
+	if o.TLSCertFile == "" {
+		interrupts.ListenAndServe(server, gracePeriod)
+		return nil
+	}
+	if o.ClientCAFile != "" {
+		tlsConfig, err := adminaccess.ServerTLSConfig(o.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		server.TLSConfig = tlsConfig
+	}
+	interrupts.ListenAndServeTLS(server, o.TLSCertFile, o.TLSKeyFile, gracePeriod)
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flagutil
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+
+	"sigs.k8s.io/prow/pkg/audit"
+	"sigs.k8s.io/prow/pkg/config/secret"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/io/providers"
+)
+
+// AuditOptions configures where components emit audit events of privileged
+// actions to.
+type AuditOptions struct {
+	// StoragePath is the path in object storage that audit events are
+	// written below, e.g. gs://bucket/audit.
+	StoragePath string
+	// WebhookURL is the URL that audit events are posted to.
+	WebhookURL string
+	// WebhookTokenPath is the path to the file containing the bearer token
+	// sent to the webhook.
+	WebhookTokenPath string
+}
+
+// AddFlags injects audit options into the given FlagSet.
+func (o *AuditOptions) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.StoragePath, "audit-storage-path", "", "Path in object storage to write audit events of privileged actions below, e.g. gs://bucket/audit.")
+	fs.StringVar(&o.WebhookURL, "audit-webhook-url", "", "URL to post audit events of privileged actions to, e.g. the event collector of a SIEM.")
+	fs.StringVar(&o.WebhookTokenPath, "audit-webhook-token-path", "", "Path to the file containing the bearer token sent to --audit-webhook-url.")
+}
+
+// Validate validates audit options.
+func (o *AuditOptions) Validate(_ bool) error {
+	if o.StoragePath != "" {
+		if _, _, _, err := providers.ParseStoragePath(o.StoragePath); err != nil {
+			return fmt.Errorf("invalid --audit-storage-path: %w", err)
+		}
+	}
+	if o.WebhookURL != "" {
+		if _, err := url.ParseRequestURI(o.WebhookURL); err != nil {
+			return fmt.Errorf("invalid --audit-webhook-url: %w", err)
+		}
+	}
+	if o.WebhookTokenPath != "" && o.WebhookURL == "" {
+		return errors.New("--audit-webhook-token-path requires --audit-webhook-url")
+	}
+	return nil
+}
+
+// Enabled determines whether audit events are emitted anywhere.
+func (o *AuditOptions) Enabled() bool {
+	return o.StoragePath != "" || o.WebhookURL != ""
+}
+
+// Auditor returns an Auditor emitting the audit events of the component, or
+// nil if no sink is configured. The opener is used to write to object storage;
+// if it is nil, an opener using credential auto-discovery is created.
+func (o *AuditOptions) Auditor(ctx context.Context, component string, opener io.Opener) (*audit.Auditor, error) {
+	if !o.Enabled() {
+		return nil, nil
+	}
+	var sinks []audit.Sink
+	if o.StoragePath != "" {
+		if opener == nil {
+			var err error
+			if opener, err = io.NewOpener(ctx, "", ""); err != nil {
+				return nil, fmt.Errorf("failed to create opener for audit events: %w", err)
+			}
+		}
+		sinks = append(sinks, audit.NewStorageSink(opener, o.StoragePath))
+	}
+	if o.WebhookURL != "" {
+		var token func() []byte
+		if o.WebhookTokenPath != "" {
+			if err := secret.Add(o.WebhookTokenPath); err != nil {
+				return nil, fmt.Errorf("failed to load audit webhook token: %w", err)
+			}
+			token = secret.GetTokenGenerator(o.WebhookTokenPath)
+		}
+		sinks = append(sinks, audit.NewWebhookSink(o.WebhookURL, token, nil))
+	}
+	return audit.NewAuditor(component, sinks...), nil
+}
@@ -17,11 +17,21 @@ limitations under the License.
 package flagutil
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"time"
 
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/interrupts"
 )
 
 const (
@@ -40,6 +50,12 @@ type ConfigOptions struct {
 	JobConfigPathFlagName                 string
 	SupplementalProwConfigDirs            flagutil.Strings
 	SupplementalProwConfigsFileNameSuffix string
+	// JobConfigSources are namespace=path pairs of job config owned by
+	// namespaces, e.g. team ConfigMaps.
+	JobConfigSources flagutil.Strings
+	// JobConfigResources merges the JobConfig resources of the cluster the
+	// component runs in into the job config.
+	JobConfigResources bool
 	// Inrepoconfig related flags
 	InRepoConfigCacheSize    int
 	InRepoConfigCacheDirBase string
@@ -60,6 +76,8 @@ func (o *ConfigOptions) AddFlags(fs *flag.FlagSet) {
 	fs.Var(&o.SupplementalProwConfigDirs, "supplemental-prow-config-dir", "An additional directory from which to load prow configs. Can be used for config sharding but only supports a subset of the config. The flag can be passed multiple times.")
 	fs.StringVar(&o.SupplementalProwConfigsFileNameSuffix, "supplemental-prow-configs-filename", "_prowconfig.yaml", "Suffix for additional prow configs. Only files with this name will be considered. Deprecated and mutually exclusive with --supplemental-prow-configs-filename-suffix")
 	fs.StringVar(&o.SupplementalProwConfigsFileNameSuffix, "supplemental-prow-configs-filename-suffix", "_prowconfig.yaml", "Suffix for additional prow configs. Only files with this name will be considered")
+	fs.Var(&o.JobConfigSources, "job-config-source", "A namespace=path pair of job config owned by the namespace, e.g. a mounted team ConfigMap. The namespace may only configure the jobs allowed by job_config_resources. Jobs conflicting with the central job config or an earlier source are ignored. The flag can be passed multiple times.")
+	fs.BoolVar(&o.JobConfigResources, "job-config-resources", false, "Merge the JobConfig resources of the cluster the component runs in, or of the cluster of $KUBECONFIG, into the job config. Requires permission to list and watch jobconfigs in all namespaces.")
 	fs.IntVar(&o.InRepoConfigCacheSize, "in-repo-config-cache-size", 200, "Cache size for ProwYAMLs read from in-repo configs.")
 	fs.StringVar(&o.InRepoConfigCacheDirBase, "cache-dir-base", "", "Directory where the repo cache should be mounted.")
 	fs.StringVar(&o.MoonrakerAddress, "moonraker-address", "", "full HTTP address (domain and port) of moonraker service")
@@ -69,9 +87,24 @@ func (o *ConfigOptions) Validate(_ bool) error {
 	if o.ConfigPath == "" {
 		return fmt.Errorf("--%s is mandatory", o.ConfigPathFlagName)
 	}
+	if _, err := o.jobConfigSources(); err != nil {
+		return err
+	}
 	return nil
 }
 
+func (o *ConfigOptions) jobConfigSources() ([]config.JobConfigSource, error) {
+	var sources []config.JobConfigSource
+	for _, value := range o.JobConfigSources.Strings() {
+		source, err := config.ParseJobConfigSource(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --job-config-source: %w", err)
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
 func (o *ConfigOptions) ValidateConfigOptional() error {
 	if o.JobConfigPath != "" && o.ConfigPath == "" {
 		return fmt.Errorf("if --%s is given, --%s must be given as well", o.JobConfigPathFlagName, o.ConfigPathFlagName)
@@ -92,5 +125,71 @@ func (o *ConfigOptions) ConfigAgent(reuse ...*config.Agent) (*config.Agent, erro
 }
 
 func (o *ConfigOptions) ConfigAgentWithAdditionals(ca *config.Agent, additionals []func(*config.Config) error) (*config.Agent, error) {
+	sources, err := o.jobConfigSources()
+	if err != nil {
+		return nil, err
+	}
+	// Sources and JobConfig resources are merged before any other
+	// additionals, in the order of their precedence.
+	var loads []func(*config.Config) error
+	if len(sources) > 0 {
+		loads = append(loads, config.AddJobConfigSources(sources))
+		for _, source := range sources {
+			ca.WatchPaths(source.Path)
+		}
+	}
+	if o.JobConfigResources {
+		list, err := watchJobConfigResources(ca)
+		if err != nil {
+			return nil, err
+		}
+		loads = append(loads, config.AddJobConfigResourcesFrom(list))
+	}
+	additionals = append(loads, additionals...)
 	return ca, ca.Start(o.ConfigPath, o.JobConfigPath, o.SupplementalProwConfigDirs.Strings(), o.SupplementalProwConfigsFileNameSuffix, additionals...)
 }
+
+// watchJobConfigResources starts an informer for the JobConfig resources and
+// makes the agent reload the config whenever one of them changes. It returns
+// a function listing the resources from the informer's cache.
+func watchJobConfigResources(ca *config.Agent) (func() ([]prowapi.JobConfig, error), error) {
+	// The in-cluster config is used if $KUBECONFIG is not set.
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the client config for JobConfig resources: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	if err := prowapi.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to register the ProwJob API: %w", err)
+	}
+	informerCache, err := cache.New(restConfig, cache.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the cache for JobConfig resources: %w", err)
+	}
+	informer, err := informerCache.GetInformer(context.Background(), &prowapi.JobConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the informer for JobConfig resources: %w", err)
+	}
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { ca.Reload() },
+		UpdateFunc: func(interface{}, interface{}) { ca.Reload() },
+		DeleteFunc: func(interface{}) { ca.Reload() },
+	})
+	interrupts.Run(func(ctx context.Context) {
+		if err := informerCache.Start(ctx); err != nil {
+			logrus.WithError(err).Error("JobConfig resource informer failed.")
+		}
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if !informerCache.WaitForCacheSync(ctx) {
+		return nil, fmt.Errorf("timed out waiting for the JobConfig resources to sync")
+	}
+	return func() ([]prowapi.JobConfig, error) {
+		var resources prowapi.JobConfigList
+		if err := informerCache.List(context.Background(), &resources); err != nil {
+			return nil, err
+		}
+		return resources.Items, nil
+	}, nil
+}
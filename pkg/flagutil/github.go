@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config/secret"
 	gitv2 "sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/tokenbroker"
 )
 
 // GitHubOptions holds options for interacting with GitHub.
@@ -48,6 +49,10 @@ type GitHubOptions struct {
 	AllowDirectAccess bool
 	AppID             string
 	AppPrivateKeyPath string
+	// TokenBrokerURL and TokenBrokerTokenPath authenticate as the GitHub App
+	// of a token broker instead of with AppID and AppPrivateKeyPath.
+	TokenBrokerURL       string
+	TokenBrokerTokenPath string
 
 	ThrottleHourlyTokens int
 	ThrottleAllowBurst   int
@@ -65,6 +70,20 @@ type GitHubOptions struct {
 	max404Retries  int
 	initialDelay   time.Duration
 	maxSleepTime   time.Duration
+
+	graphQLReads bool
+
+	cacheMode   string
+	cacheDir    string
+	cacheSizeGB int
+
+	hostConfigPath string
+	hosts          []gitHubHost
+
+	gitSigningKeyPath string
+	gitSigningFormat  string
+	gitWorktrees      bool
+	gitCloneFilter    string
 }
 
 type throttlerSettings struct {
@@ -135,6 +154,8 @@ func (o *GitHubOptions) addFlags(fs *flag.FlagSet, paramFuncs ...FlagParameter)
 	fs.StringVar(&o.TokenPath, "github-token-path", defaults.TokenPath, "Path to the file containing the GitHub OAuth secret.")
 	fs.StringVar(&o.AppID, "github-app-id", defaults.AppID, "ID of the GitHub app. If set, requires --github-app-private-key-path to be set and --github-token-path to be unset.")
 	fs.StringVar(&o.AppPrivateKeyPath, "github-app-private-key-path", defaults.AppPrivateKeyPath, "Path to the private key of the github app. If set, requires --github-app-id to bet set and --github-token-path to be unset")
+	fs.StringVar(&o.TokenBrokerURL, "github-token-broker-url", defaults.TokenBrokerURL, "URL of a token broker to authenticate as its GitHub app with, https:// or unix:// followed by the path of its socket. If set, requires --github-token-broker-token-path to be set and the other authentication flags to be unset.")
+	fs.StringVar(&o.TokenBrokerTokenPath, "github-token-broker-token-path", defaults.TokenBrokerTokenPath, "Path to the file containing the secret to authenticate with at the token broker.")
 
 	if !params.disableThrottlerOptions {
 		fs.IntVar(&o.ThrottleHourlyTokens, "github-hourly-tokens", defaults.ThrottleHourlyTokens, "If set to a value larger than zero, enable client-side throttling to limit hourly token consumption. If set, --github-allowed-burst must be positive too.")
@@ -147,6 +168,15 @@ func (o *GitHubOptions) addFlags(fs *flag.FlagSet, paramFuncs ...FlagParameter)
 	fs.IntVar(&o.max404Retries, "github-client.max-404-retries", github.DefaultMax404Retries, "Maximum number of retries that will be used for a 404-ing request to the GitHub API.")
 	fs.DurationVar(&o.maxSleepTime, "github-client.backoff-timeout", github.DefaultMaxSleepTime, "Largest allowable Retry-After time for requests to the GitHub API.")
 	fs.DurationVar(&o.initialDelay, "github-client.initial-delay", github.DefaultInitialDelay, "Initial delay before retries begin for requests to the GitHub API.")
+	fs.StringVar(&o.cacheMode, "github-client.cache", github.CacheModeNone, fmt.Sprintf("Cache GET requests to the GitHub API in the client and revalidate them with conditional requests. One of %q or %q. Not needed when using ghproxy.", github.CacheModeMemory, github.CacheModeDisk))
+	fs.StringVar(&o.cacheDir, "github-client.cache-dir", "", "Directory of the disk cache, required for --github-client.cache=disk.")
+	fs.IntVar(&o.cacheSizeGB, "github-client.cache-sizeGB", 1, "Maximum size of the disk cache in GB.")
+	fs.StringVar(&o.hostConfigPath, "github-host-config", "", "Path to a YAML file listing additional GitHub hosts, e.g. GitHub Enterprise instances, with their orgs and credentials.")
+	fs.BoolVar(&o.graphQLReads, "github-client.graphql-reads", false, "Use the GraphQL API for reading pull requests, labels, reviews and team members, falling back to the REST API on errors.")
+	fs.StringVar(&o.gitSigningKeyPath, "git-signing-key-path", "", "Path to the private key that commits and tags created by the git client are signed with.")
+	fs.BoolVar(&o.gitWorktrees, "git-worktrees", false, "Check out repos as worktrees of one bare clone per repo instead of as clones of a mirror. Saves disk space and time for repos that are checked out often.")
+	fs.StringVar(&o.gitCloneFilter, "git-clone-filter", "", fmt.Sprintf("Make partial clones that download the objects excluded by this filter only when they are needed. One of %q (blobless) or %q (treeless).", gitv2.CloneFilterBlobless, gitv2.CloneFilterTreeless))
+	fs.StringVar(&o.gitSigningFormat, "git-signing-format", string(gitv2.SigningFormatOpenPGP), fmt.Sprintf("Format of the key passed with --git-signing-key-path. One of %q or %q.", gitv2.SigningFormatOpenPGP, gitv2.SigningFormatSSH))
 }
 
 func (o *GitHubOptions) parseOrgThrottlers() error {
@@ -154,7 +184,7 @@ func (o *GitHubOptions) parseOrgThrottlers() error {
 		return nil
 	}
 
-	if o.AppID == "" {
+	if o.AppID == "" && o.TokenBrokerURL == "" {
 		return errors.New("--github-throttle-org was passed, but client doesn't use apps auth")
 	}
 
@@ -217,8 +247,29 @@ func (o *GitHubOptions) Validate(bool) error {
 	if o.AppID == "" != (o.AppPrivateKeyPath == "") {
 		return errors.New("--app-id and --app-private-key-path must be set together")
 	}
+	if o.TokenBrokerURL != "" || o.TokenBrokerTokenPath != "" {
+		if o.TokenPath != "" || o.AppID != "" {
+			return errors.New("--github-token-broker-url is mutually exclusive with --github-token-path and --github-app-id")
+		}
+		if o.TokenBrokerURL == "" || o.TokenBrokerTokenPath == "" {
+			return errors.New("--github-token-broker-url and --github-token-broker-token-path must be set together")
+		}
+		if err := tokenbroker.ValidateURL(o.TokenBrokerURL); err != nil {
+			return err
+		}
+	}
 
-	if o.TokenPath != "" && len(endpoints) == 1 && endpoints[0] == github.DefaultAPIEndpoint && !o.AllowDirectAccess {
+	switch o.cacheMode {
+	case github.CacheModeNone, github.CacheModeMemory:
+	case github.CacheModeDisk:
+		if o.cacheDir == "" {
+			return errors.New("--github-client.cache=disk requires --github-client.cache-dir to be set")
+		}
+	default:
+		return fmt.Errorf("invalid --github-client.cache %q, must be %q or %q", o.cacheMode, github.CacheModeMemory, github.CacheModeDisk)
+	}
+
+	if o.TokenPath != "" && len(endpoints) == 1 && endpoints[0] == github.DefaultAPIEndpoint && !o.AllowDirectAccess && o.cacheMode == github.CacheModeNone {
 		logrus.Warn("It doesn't look like you are using ghproxy to cache API calls to GitHub! This has become a required component of Prow and other components will soon be allowed to add features that may rapidly consume API ratelimit without caching. Starting May 1, 2020 use Prow components without ghproxy at your own risk! https://github.com/kubernetes/test-infra/tree/master/ghproxy#ghproxy")
 	}
 
@@ -240,6 +291,22 @@ func (o *GitHubOptions) Validate(bool) error {
 		return errors.New("--github-allowed-burst must not be larger than --github-hourly-tokens")
 	}
 
+	switch gitv2.SigningFormat(o.gitSigningFormat) {
+	case "", gitv2.SigningFormatOpenPGP, gitv2.SigningFormatSSH:
+	default:
+		return fmt.Errorf("invalid --git-signing-format %q, must be %q or %q", o.gitSigningFormat, gitv2.SigningFormatOpenPGP, gitv2.SigningFormatSSH)
+	}
+
+	switch o.gitCloneFilter {
+	case "", gitv2.CloneFilterBlobless, gitv2.CloneFilterTreeless:
+	default:
+		return fmt.Errorf("invalid --git-clone-filter %q, must be %q or %q", o.gitCloneFilter, gitv2.CloneFilterBlobless, gitv2.CloneFilterTreeless)
+	}
+
+	if err := o.parseHostConfig(); err != nil {
+		return err
+	}
+
 	return o.parseOrgThrottlers()
 }
 
@@ -257,7 +324,7 @@ func (o *GitHubOptions) githubClient(dryRun bool) (github.Client, error) {
 	options := o.baseClientOptions()
 	options.DryRun = dryRun
 
-	if o.TokenPath == "" && o.AppPrivateKeyPath == "" {
+	if o.TokenPath == "" && o.AppPrivateKeyPath == "" && o.TokenBrokerURL == "" {
 		logrus.Warn("empty -github-token-path, will use anonymous github client")
 	}
 
@@ -280,6 +347,23 @@ func (o *GitHubOptions) githubClient(dryRun bool) (github.Client, error) {
 		options.AppPrivateKey = apk
 	}
 
+	if o.TokenBrokerURL != "" {
+		if err := secret.Add(o.TokenBrokerTokenPath); err != nil {
+			return nil, fmt.Errorf("failed to add token broker secret to secret agent: %w", err)
+		}
+		source, err := tokenbroker.NewAppTokenSource(o.TokenBrokerURL, secret.GetTokenGenerator(o.TokenBrokerTokenPath))
+		if err != nil {
+			return nil, err
+		}
+		options.AppTokenSource = source
+	}
+
+	hosts, err := o.hostOptions()
+	if err != nil {
+		return nil, err
+	}
+	options.AdditionalHosts = hosts
+
 	optionallyThrottled := func(c github.Client) (github.Client, error) {
 		// Throttle handles zeros as "disable throttling" so we do not need to call it conditionally
 		if err := c.Throttle(o.ThrottleHourlyTokens, o.ThrottleAllowBurst); err != nil {
@@ -314,6 +398,10 @@ func (o *GitHubOptions) baseClientOptions() github.ClientOptions {
 		MaxSleepTime:    o.maxSleepTime,
 		MaxRetries:      o.maxRetries,
 		Max404Retries:   o.max404Retries,
+		GraphQLReads:    o.graphQLReads,
+		CacheMode:       o.cacheMode,
+		CacheDir:        o.cacheDir,
+		CacheSizeGB:     o.cacheSizeGB,
 	}
 }
 
@@ -340,13 +428,16 @@ func (o *GitHubOptions) GitClientFactory(cookieFilePath string, cacheDir *string
 		Censor:         secret.Censor,
 		CookieFilePath: cookieFilePath,
 		Host:           o.Host,
+		OrgHosts:       o.gitHosts(),
 		Persist:        &persistCache,
+		UseWorktrees:   &o.gitWorktrees,
+		CloneFilter:    o.gitCloneFilter,
 	}
 	if cacheDir != nil && *cacheDir != "" {
 		opts.CacheDirBase = cacheDir
 	}
 
-	if cookieFilePath == "" && (o.TokenPath != "" || o.AppPrivateKeyPath != "") {
+	if cookieFilePath == "" && (o.TokenPath != "" || o.AppPrivateKeyPath != "" || o.TokenBrokerURL != "") {
 		// Make a client with auth suitable for GitHub
 		user, generator, err := o.getGitHubAuthentication(dryRun)
 		if err != nil {
@@ -357,6 +448,14 @@ func (o *GitHubOptions) GitClientFactory(cookieFilePath string, cacheDir *string
 	}
 	// If the client is for Gerrit we're already set with the cookie filepath.
 
+	if o.gitSigningKeyPath != "" {
+		if err := secret.Add(o.gitSigningKeyPath); err != nil {
+			return nil, fmt.Errorf("failed to add git signing key to secret agent: %w", err)
+		}
+		opts.SigningKey = secret.GetTokenGenerator(o.gitSigningKeyPath)
+		opts.SigningFormat = gitv2.SigningFormat(o.gitSigningFormat)
+	}
+
 	gitClientFactory, err := gitv2.NewClientFactory(opts.Apply)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create git client factory: %w", err)
@@ -380,8 +479,12 @@ func (o *GitHubOptions) getGitHubAuthentication(dryRun bool) (string, gitv2.Toke
 }
 
 func (o *GitHubOptions) appPrivateKeyGenerator() (func() *rsa.PrivateKey, error) {
+	return privateKeyGenerator(o.AppPrivateKeyPath)
+}
+
+func privateKeyGenerator(path string) (func() *rsa.PrivateKey, error) {
 	generator, err := secret.AddWithParser(
-		o.AppPrivateKeyPath,
+		path,
 		func(raw []byte) (*rsa.PrivateKey, error) {
 			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(raw)
 			if err != nil {
@@ -391,7 +494,7 @@ func (o *GitHubOptions) appPrivateKeyGenerator() (func() *rsa.PrivateKey, error)
 		},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add the key from --app-private-key-path to secret agent: %w", err)
+		return nil, fmt.Errorf("failed to add the key from %s to secret agent: %w", path, err)
 	}
 
 	return generator, nil
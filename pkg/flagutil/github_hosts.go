@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flagutil
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config/secret"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// gitHubHost is an entry of the file passed with --github-host-config.
+type gitHubHost struct {
+	// Orgs are the orgs and users that live on the host.
+	Orgs            []string `json:"orgs"`
+	Endpoint        string   `json:"endpoint"`
+	GraphQLEndpoint string   `json:"graphql_endpoint"`
+	// Host is the host that the repos of the orgs are cloned from, the host
+	// of the endpoint by default.
+	Host string `json:"host,omitempty"`
+	// Either TokenPath or AppID and AppPrivateKeyPath must be set.
+	TokenPath         string `json:"token_path,omitempty"`
+	AppID             string `json:"app_id,omitempty"`
+	AppPrivateKeyPath string `json:"app_private_key_path,omitempty"`
+	// HourlyTokens and AllowedBurst configure the throttling of the host.
+	HourlyTokens int `json:"hourly_tokens,omitempty"`
+	AllowedBurst int `json:"allowed_burst,omitempty"`
+}
+
+func (o *GitHubOptions) parseHostConfig() error {
+	o.hosts = nil
+	if o.hostConfigPath == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(o.hostConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read --github-host-config: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(raw, &o.hosts); err != nil {
+		return fmt.Errorf("failed to parse --github-host-config: %w", err)
+	}
+	for _, host := range o.hosts {
+		if len(host.Orgs) == 0 {
+			return fmt.Errorf("host %s in --github-host-config has no orgs", host.Endpoint)
+		}
+		if _, err := url.ParseRequestURI(host.Endpoint); err != nil {
+			return fmt.Errorf("invalid endpoint %q in --github-host-config: %w", host.Endpoint, err)
+		}
+		if _, err := url.ParseRequestURI(host.GraphQLEndpoint); err != nil {
+			return fmt.Errorf("invalid graphql_endpoint %q in --github-host-config: %w", host.GraphQLEndpoint, err)
+		}
+		if (host.TokenPath == "") == (host.AppID == "" || host.AppPrivateKeyPath == "") {
+			return fmt.Errorf("host %s in --github-host-config must have either token_path or app_id and app_private_key_path", host.Endpoint)
+		}
+		if (host.HourlyTokens > 0) != (host.AllowedBurst > 0) || host.AllowedBurst > host.HourlyTokens {
+			return errors.New("hourly_tokens and allowed_burst in --github-host-config must both be zero or positive with allowed_burst not larger than hourly_tokens")
+		}
+	}
+	return nil
+}
+
+// gitHosts maps the orgs on the additional hosts to the hosts that their repos
+// are cloned from.
+func (o *GitHubOptions) gitHosts() map[string]string {
+	if len(o.hosts) == 0 {
+		return nil
+	}
+	gitHosts := map[string]string{}
+	for _, host := range o.hosts {
+		gitHost := host.Host
+		if gitHost == "" {
+			// The endpoint was validated when the config was parsed.
+			endpoint, _ := url.Parse(host.Endpoint)
+			gitHost = endpoint.Host
+		}
+		for _, org := range host.Orgs {
+			gitHosts[strings.ToLower(org)] = gitHost
+		}
+	}
+	return gitHosts
+}
+
+// hostOptions loads the credentials of the additional hosts.
+func (o *GitHubOptions) hostOptions() ([]github.HostOptions, error) {
+	var hosts []github.HostOptions
+	for _, host := range o.hosts {
+		options := github.HostOptions{
+			Orgs:                 host.Orgs,
+			AppID:                host.AppID,
+			GraphqlEndpoint:      host.GraphQLEndpoint,
+			Bases:                []string{host.Endpoint},
+			ThrottleHourlyTokens: host.HourlyTokens,
+			ThrottleAllowBurst:   host.AllowedBurst,
+		}
+		if host.TokenPath != "" {
+			if err := secret.Add(host.TokenPath); err != nil {
+				return nil, fmt.Errorf("failed to add GitHub token of %s to secret agent: %w", host.Endpoint, err)
+			}
+			options.GetToken = secret.GetTokenGenerator(host.TokenPath)
+		} else {
+			options.GetToken = func() []byte { return nil }
+			apk, err := privateKeyGenerator(host.AppPrivateKeyPath)
+			if err != nil {
+				return nil, err
+			}
+			options.AppPrivateKey = apk
+		}
+		hosts = append(hosts, options)
+	}
+	return hosts, nil
+}
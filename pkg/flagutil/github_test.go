@@ -19,6 +19,8 @@ package flagutil
 import (
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"testing"
@@ -98,6 +100,69 @@ func TestGitHubOptions_Validate(t *testing.T) {
 			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
 			expectedErr:             false,
 		},
+		{
+			name: "ssh signing format: no error",
+			in: &GitHubOptions{
+				gitSigningFormat: "ssh",
+			},
+			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
+		},
+		{
+			name: "unknown signing format: error",
+			in: &GitHubOptions{
+				gitSigningFormat: "x509",
+			},
+			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
+			expectedErr:             true,
+		},
+		{
+			name: "treeless clone filter: no error",
+			in: &GitHubOptions{
+				gitCloneFilter: "tree:0",
+			},
+			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
+		},
+		{
+			name: "unknown clone filter: error",
+			in: &GitHubOptions{
+				gitCloneFilter: "blob:limit=1m",
+			},
+			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
+			expectedErr:             true,
+		},
+		{
+			name: "token broker on a unix socket: no error",
+			in: &GitHubOptions{
+				TokenBrokerURL:       "unix:///var/run/token-broker/broker.sock",
+				TokenBrokerTokenPath: "/etc/token-broker/hook",
+			},
+			expectedGraphqlEndpoint: github.DefaultGraphQLEndpoint,
+		},
+		{
+			name: "token broker over plain HTTP: error",
+			in: &GitHubOptions{
+				TokenBrokerURL:       "http://token-broker",
+				TokenBrokerTokenPath: "/etc/token-broker/hook",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "token broker without token path: error",
+			in: &GitHubOptions{
+				TokenBrokerURL: "https://token-broker",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "token broker and app private key: error",
+			in: &GitHubOptions{
+				AppID:                "123",
+				AppPrivateKeyPath:    "/etc/github/key",
+				TokenBrokerURL:       "https://token-broker",
+				TokenBrokerTokenPath: "/etc/token-broker/hook",
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -289,3 +354,89 @@ func TestOrgThottlerOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestParseHostConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      string
+		expectedErr bool
+	}{
+		{
+			name: "token host",
+			config: `- orgs: [corp]
+  endpoint: https://ghe.example.com/api/v3
+  graphql_endpoint: https://ghe.example.com/api/graphql
+  token_path: /etc/ghe/token
+`,
+		},
+		{
+			name: "app host with throttling",
+			config: `- orgs: [corp]
+  endpoint: https://ghe.example.com/api/v3
+  graphql_endpoint: https://ghe.example.com/api/graphql
+  app_id: "1"
+  app_private_key_path: /etc/ghe/key
+  hourly_tokens: 100
+  allowed_burst: 10
+`,
+		},
+		{
+			name: "no orgs",
+			config: `- endpoint: https://ghe.example.com/api/v3
+  graphql_endpoint: https://ghe.example.com/api/graphql
+  token_path: /etc/ghe/token
+`,
+			expectedErr: true,
+		},
+		{
+			name: "token and app",
+			config: `- orgs: [corp]
+  endpoint: https://ghe.example.com/api/v3
+  graphql_endpoint: https://ghe.example.com/api/graphql
+  token_path: /etc/ghe/token
+  app_id: "1"
+  app_private_key_path: /etc/ghe/key
+`,
+			expectedErr: true,
+		},
+		{
+			name:        "unknown field",
+			config:      `- orgs: [corp]\n  tokenpath: /etc/ghe/token\n`,
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "hosts.yaml")
+			if err := os.WriteFile(path, []byte(tc.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			o := GitHubOptions{hostConfigPath: path}
+			err := o.parseHostConfig()
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if err == nil && (len(o.hosts) != 1 || o.hosts[0].Orgs[0] != "corp") {
+				t.Errorf("unexpected hosts: %+v", o.hosts)
+			}
+		})
+	}
+}
+
+func TestGitHosts(t *testing.T) {
+	o := GitHubOptions{hosts: []gitHubHost{
+		{Orgs: []string{"Corp", "infra"}, Endpoint: "https://ghe.example.com/api/v3"},
+		{Orgs: []string{"other"}, Endpoint: "https://api.other.example.com", Host: "git.other.example.com"},
+	}}
+	expected := map[string]string{
+		"corp":  "ghe.example.com",
+		"infra": "ghe.example.com",
+		"other": "git.other.example.com",
+	}
+	if diff := cmp.Diff(expected, o.gitHosts()); diff != "" {
+		t.Errorf("unexpected git hosts (-want +got):\n%s", diff)
+	}
+	if hosts := (&GitHubOptions{}).gitHosts(); hosts != nil {
+		t.Errorf("expected no git hosts without additional hosts, got %v", hosts)
+	}
+}
@@ -18,6 +18,7 @@ package flagutil
 
 import (
 	"flag"
+	"fmt"
 	"time"
 )
 
@@ -27,6 +28,8 @@ const (
 	DefaultHealthPort  = 8081
 
 	DefaultMemoryProfileInterval = 30 * time.Second
+
+	DefaultTracingSampleRate = 1.0
 )
 
 // InstrumentationOptions holds common options which are used across Prow components
@@ -42,6 +45,12 @@ type InstrumentationOptions struct {
 	ProfileMemory bool
 	// MemoryProfileInterval is the interval at which memory profiles should be dumped
 	MemoryProfileInterval time.Duration
+
+	// TracingEndpoint is the address of the OTLP gRPC receiver spans are
+	// exported to. Tracing is off if unset.
+	TracingEndpoint string
+	// TracingSampleRate is the fraction of the traces that are sampled.
+	TracingSampleRate float64
 }
 
 // DefaultInstrumentationOptions returns an initialized options struct, mostly for use in tests.
@@ -52,6 +61,7 @@ func DefaultInstrumentationOptions() InstrumentationOptions {
 		HealthPort:            DefaultHealthPort,
 		ProfileMemory:         false,
 		MemoryProfileInterval: DefaultMemoryProfileInterval,
+		TracingSampleRate:     DefaultTracingSampleRate,
 	}
 }
 
@@ -62,8 +72,13 @@ func (o *InstrumentationOptions) AddFlags(fs *flag.FlagSet) {
 	fs.IntVar(&o.HealthPort, "health-port", DefaultHealthPort, "port to serve liveness and readiness")
 	fs.BoolVar(&o.ProfileMemory, "profile-memory-usage", false, "profile memory usage for analysis")
 	fs.DurationVar(&o.MemoryProfileInterval, "memory-profile-interval", DefaultMemoryProfileInterval, "duration at which memory profiles should be dumped")
+	fs.StringVar(&o.TracingEndpoint, "tracing-endpoint", "", "address of the OTLP gRPC receiver, e.g. an OpenTelemetry Collector, to export spans to")
+	fs.Float64Var(&o.TracingSampleRate, "tracing-sample-rate", DefaultTracingSampleRate, "fraction of the traces to sample")
 }
 
 func (o *InstrumentationOptions) Validate(_ bool) error {
+	if o.TracingSampleRate < 0 || o.TracingSampleRate > 1 {
+		return fmt.Errorf("--tracing-sample-rate must be between 0 and 1, got %v", o.TracingSampleRate)
+	}
 	return nil
 }
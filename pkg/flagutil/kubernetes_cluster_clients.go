@@ -63,6 +63,7 @@ type KubernetesOptions struct {
 	projectedTokenFile       string
 	noInClusterConfig        bool
 	NOInClusterConfigDefault bool
+	buildClusterNamespace    string
 
 	// from the setter SetDisabledClusters
 	disabledClusters sets.Set[string]
@@ -76,11 +77,18 @@ type KubernetesOptions struct {
 	infrastructureClusterConfig *rest.Config
 	kubeconfigWach              *sync.Once
 	kubeconfigWatchEvents       <-chan fsnotify.Event
+	buildClusterReader          ctrlruntimeclient.Reader
+	buildClusterFingerprint     string
 }
 
+// buildClusterPollInterval is how often BuildCluster resources are checked
+// for changes.
+const buildClusterPollInterval = time.Minute
+
 var MissingPermissions = errors.New("missing permissions")
 
-// AddKubeconfigChangeCallback adds a callback that gets called whenever the kubeconfig changes.
+// AddKubeconfigChangeCallback adds a callback that gets called whenever the kubeconfig changes,
+// or, if --build-cluster-namespace is set, whenever the BuildCluster resources or their tokens change.
 // The main usecase for this is to exit components that can not reload a kubeconfig at runtime
 // so the kubelet restarts them
 func (o *KubernetesOptions) AddKubeconfigChangeCallback(callback func()) error {
@@ -147,9 +155,35 @@ func (o *KubernetesOptions) AddKubeconfigChangeCallback(callback func()) error {
 		}
 	}()
 
+	if o.buildClusterReader != nil {
+		go func() {
+			for range time.Tick(buildClusterPollInterval) {
+				if o.buildClustersChanged() {
+					callback()
+					return
+				}
+			}
+		}()
+	}
+
 	return nil
 }
 
+// buildClustersChanged determines whether the BuildCluster resources changed
+// since the options were resolved.
+func (o *KubernetesOptions) buildClustersChanged() bool {
+	_, fingerprint, err := kube.LoadBuildClusterConfigs(context.Background(), o.buildClusterReader, o.buildClusterNamespace, o.disabledClusters)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to check build clusters for changes")
+		return false
+	}
+	if fingerprint == o.buildClusterFingerprint {
+		return false
+	}
+	logrus.WithField("namespace", o.buildClusterNamespace).Info("Build clusters changed")
+	return true
+}
+
 // LoadClusterConfigs returns the resolved rest.Configs and each callback function will be executed if
 // the underlying kubeconfig files are modified. This function is for the case where the rest.Configs are
 // needed without interests of the clients.
@@ -188,6 +222,7 @@ func (o *KubernetesOptions) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&o.kubeconfigSuffix, "kubeconfig-suffix", "", "The files without the suffix will be ignored when loading kubeconfig files from --kubeconfig-dir. It must be used together with --kubeconfig-dir.")
 	fs.StringVar(&o.projectedTokenFile, "projected-token-file", "", "A projected serviceaccount token file. If set, this will be configured as token file in the in-cluster config.")
 	fs.BoolVar(&o.noInClusterConfig, "no-in-cluster-config", o.NOInClusterConfigDefault, "Not resolving InCluster Config if set.")
+	fs.StringVar(&o.buildClusterNamespace, "build-cluster-namespace", "", "If set, the BuildCluster resources in this namespace of the infrastructure cluster are used as build clusters in addition to the kubeconfig contexts.")
 }
 
 // Validate validates Kubernetes options.
@@ -228,6 +263,11 @@ func (o *KubernetesOptions) resolve(dryRun bool) error {
 	if err != nil {
 		return fmt.Errorf("load --kubeconfig=%q configs: %w", o.kubeconfig, err)
 	}
+	if o.buildClusterNamespace != "" {
+		if err := o.addBuildClusterConfigs(clusterConfigs); err != nil {
+			return err
+		}
+	}
 	o.clusterConfigs = clusterConfigs
 
 	clients := map[string]kubernetes.Interface{}
@@ -258,6 +298,32 @@ func (o *KubernetesOptions) resolve(dryRun bool) error {
 	return nil
 }
 
+// addBuildClusterConfigs adds the configs of the BuildCluster resources to the
+// configs loaded from kubeconfigs.
+func (o *KubernetesOptions) addBuildClusterConfigs(clusterConfigs map[string]rest.Config) error {
+	localCfg, ok := clusterConfigs[kube.InClusterContext]
+	if !ok {
+		return errors.New("--build-cluster-namespace requires access to the infrastructure cluster")
+	}
+	reader, err := ctrlruntimeclient.New(&localCfg, ctrlruntimeclient.Options{})
+	if err != nil {
+		return fmt.Errorf("create client to load build clusters: %w", err)
+	}
+	buildClusterConfigs, fingerprint, err := kube.LoadBuildClusterConfigs(context.Background(), reader, o.buildClusterNamespace, o.disabledClusters)
+	if err != nil {
+		return fmt.Errorf("load build clusters from namespace %s: %w", o.buildClusterNamespace, err)
+	}
+	for name, cfg := range buildClusterConfigs {
+		if _, ok := clusterConfigs[name]; ok {
+			return fmt.Errorf("build cluster %s is registered both in a kubeconfig and as BuildCluster resource", name)
+		}
+		clusterConfigs[name] = cfg
+	}
+	o.buildClusterReader = reader
+	o.buildClusterFingerprint = fingerprint
+	return nil
+}
+
 // ProwJobClientset returns a ProwJob clientset for use in informer factories.
 func (o *KubernetesOptions) ProwJobClientset(dryRun bool) (prowJobClientset prow.Interface, err error) {
 	if err := o.resolve(dryRun); err != nil {
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flaketracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client queries the API of a flake-tracker.
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// NewClient creates a Client for the flake-tracker serving at url.
+func NewClient(url string) *Client {
+	return &Client{url: strings.TrimSuffix(url, "/"), client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Flakes lists the flaky tests, optionally only those of a repo or job.
+func (c *Client) Flakes(repo, job string) ([]Flake, error) {
+	query := url.Values{}
+	if repo != "" {
+		query.Set("repo", repo)
+	}
+	if job != "" {
+		query.Set("job", job)
+	}
+	var flakes []Flake
+	return flakes, c.get("/flakes", query, &flakes)
+}
+
+// PullReport reports the failed jobs of a commit of a pull request.
+func (c *Client) PullReport(org, repo string, pull int, sha string) (*PullReport, error) {
+	query := url.Values{}
+	query.Set("repo", org+"/"+repo)
+	query.Set("pull", strconv.Itoa(pull))
+	query.Set("sha", sha)
+	report := &PullReport{}
+	return report, c.get("/pull", query, report)
+}
+
+func (c *Client) get(path string, query url.Values, into interface{}) error {
+	resp, err := c.client.Get(c.url + path + "?" + query.Encode())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", path, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
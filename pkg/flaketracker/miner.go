@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flaketracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdio "io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/crier/reporters/gcs/util"
+	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/io/providers"
+)
+
+const maxFailureMessageLength = 1000
+
+var junitRe = regexp.MustCompile(`(^|/)junit.*\.xml$`)
+
+// Miner records the outcome and junit results of finished ProwJobs in a
+// Store.
+type Miner struct {
+	client ctrlruntimeclient.Client
+	config config.Getter
+	opener io.Opener
+	store  *Store
+	now    func() time.Time
+	logger *logrus.Entry
+}
+
+// NewMiner creates a Miner.
+func NewMiner(client ctrlruntimeclient.Client, cfg config.Getter, opener io.Opener, store *Store) *Miner {
+	return &Miner{
+		client: client,
+		config: cfg,
+		opener: opener,
+		store:  store,
+		now:    time.Now,
+		logger: logrus.WithField("component", "flake-tracker"),
+	}
+}
+
+// Sync records the ProwJobs that finished since the last sync and prunes the
+// runs that left the window of the store.
+func (m *Miner) Sync(ctx context.Context) error {
+	cfg := m.config()
+	pjs := &prowapi.ProwJobList{}
+	if err := m.client.List(ctx, pjs, ctrlruntimeclient.InNamespace(cfg.ProwJobNamespace)); err != nil {
+		return fmt.Errorf("list ProwJobs: %w", err)
+	}
+	now := m.now()
+	m.store.Prune(now)
+
+	var errs []error
+	var added int
+	for i := range pjs.Items {
+		pj := &pjs.Items[i]
+		if !mineable(pj) || now.Sub(pj.Status.CompletionTime.Time) > m.store.window || m.store.Has(pj.Spec.Job, pj.Status.BuildID) {
+			continue
+		}
+		run, err := m.mine(ctx, pj)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s build %s: %w", pj.Spec.Job, pj.Status.BuildID, err))
+			continue
+		}
+		m.store.Add(run)
+		added++
+	}
+	m.logger.WithField("added", added).Info("Mined finished ProwJobs.")
+	return utilerrors.NewAggregate(errs)
+}
+
+// mineable returns whether the ProwJob finished with an outcome of the tested
+// code on a single commit. Batches, aborted and errored jobs are ignored.
+func mineable(pj *prowapi.ProwJob) bool {
+	if pj.Status.CompletionTime == nil || pj.Status.BuildID == "" || pj.Spec.Type == prowapi.BatchJob {
+		return false
+	}
+	return pj.Status.State == prowapi.SuccessState || pj.Status.State == prowapi.FailureState
+}
+
+func (m *Miner) mine(ctx context.Context, pj *prowapi.ProwJob) (Run, error) {
+	run := Run{
+		Job:      pj.Spec.Job,
+		BuildID:  pj.Status.BuildID,
+		Finished: pj.Status.CompletionTime.Time,
+		Passed:   pj.Status.State == prowapi.SuccessState,
+		URL:      pj.Status.URL,
+	}
+	if pj.Status.Failure != nil {
+		run.FailureCategory = pj.Status.Failure.Category
+	}
+	if refs := pj.Spec.Refs; refs != nil {
+		run.Repo = refs.Org + "/" + refs.Repo
+		run.SHA = refs.BaseSHA
+		if pj.Spec.Type == prowapi.PresubmitJob && len(refs.Pulls) > 0 {
+			run.Pull = refs.Pulls[0].Number
+			run.SHA = refs.Pulls[0].SHA
+		}
+	}
+	bucket, dir, err := util.GetJobDestination(m.config, pj)
+	if err != nil {
+		// Jobs without a storage location have no results to look at.
+		return run, nil
+	}
+	artifacts, err := providers.StoragePath(bucket, dir+"/artifacts")
+	if err != nil {
+		return run, err
+	}
+	run.HasResults, run.Failures, err = m.results(ctx, artifacts)
+	return run, err
+}
+
+// results reads the junit files in dir and returns the failed tests.
+func (m *Miner) results(ctx context.Context, dir string) (bool, map[string]string, error) {
+	provider, bucket, _, err := providers.ParseStoragePath(dir)
+	if err != nil {
+		return false, nil, err
+	}
+	root := fmt.Sprintf("%s://%s/", provider, bucket)
+	it, err := m.opener.Iterator(ctx, dir+"/", "")
+	if err != nil {
+		return false, nil, fmt.Errorf("list %s: %w", dir, err)
+	}
+	var hasResults bool
+	var failures map[string]string
+	for {
+		attrs, err := it.Next(ctx)
+		if errors.Is(err, stdio.EOF) {
+			return hasResults, failures, nil
+		}
+		if err != nil {
+			return false, nil, fmt.Errorf("list %s: %w", dir, err)
+		}
+		if attrs.IsDir || !junitRe.MatchString(attrs.Name) {
+			continue
+		}
+		content, err := io.ReadContent(ctx, m.logger, m.opener, root+attrs.Name)
+		if err != nil {
+			return false, nil, fmt.Errorf("read %s: %w", attrs.Name, err)
+		}
+		suites, err := junit.Parse(content)
+		if err != nil {
+			m.logger.WithError(err).WithField("path", attrs.Name).Debug("Ignoring invalid junit file.")
+			continue
+		}
+		hasResults = true
+		var record func(suite junit.Suite)
+		record = func(suite junit.Suite) {
+			for _, s := range suite.Suites {
+				record(s)
+			}
+			for _, result := range suite.Results {
+				if result.Failure == nil && result.Errored == nil {
+					continue
+				}
+				if failures == nil {
+					failures = map[string]string{}
+				}
+				failures[testName(result)] = result.Message(maxFailureMessageLength)
+			}
+		}
+		for _, suite := range suites.Suites {
+			record(suite)
+		}
+	}
+}
+
+func testName(result junit.Result) string {
+	if result.ClassName == "" {
+		return result.Name
+	}
+	return strings.Join([]string{result.ClassName, result.Name}, ".")
+}
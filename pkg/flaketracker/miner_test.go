@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flaketracker
+
+import (
+	"context"
+	stdio "io"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// fakeBucket serves the contents of objects by their path.
+type fakeBucket struct {
+	io.Opener
+	contents map[string]string
+}
+
+func (f *fakeBucket) Reader(_ context.Context, path string) (io.ReadCloser, error) {
+	content, ok := f.contents[path]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return stdio.NopCloser(strings.NewReader(content)), nil
+}
+
+type fakeIterator []io.ObjectAttributes
+
+func (f *fakeIterator) Next(_ context.Context) (io.ObjectAttributes, error) {
+	if len(*f) == 0 {
+		return io.ObjectAttributes{}, stdio.EOF
+	}
+	next := (*f)[0]
+	*f = (*f)[1:]
+	return next, nil
+}
+
+func (f *fakeBucket) Iterator(_ context.Context, prefix, _ string) (io.ObjectIterator, error) {
+	var it fakeIterator
+	for path := range f.contents {
+		if strings.HasPrefix(path, prefix) {
+			it = append(it, io.ObjectAttributes{Name: strings.TrimPrefix(path, "gs://bucket/")})
+		}
+	}
+	sort.Slice(it, func(i, j int) bool { return it[i].Name < it[j].Name })
+	return &it, nil
+}
+
+func TestMinerSync(t *testing.T) {
+	decoration := &prowapi.DecorationConfig{GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "bucket", PathStrategy: prowapi.PathStrategyExplicit}}
+	pj := func(name, buildID string, state prowapi.ProwJobState, completed time.Time) *prowapi.ProwJob {
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prowjobs"},
+			Spec: prowapi.ProwJobSpec{
+				Type: prowapi.PresubmitJob,
+				Job:  "unit",
+				Refs: &prowapi.Refs{
+					Org:     "org",
+					Repo:    "repo",
+					BaseSHA: "base",
+					Pulls:   []prowapi.Pull{{Number: 1, SHA: "head"}},
+				},
+				DecorationConfig: decoration,
+			},
+			Status: prowapi.ProwJobStatus{
+				State:          state,
+				BuildID:        buildID,
+				CompletionTime: &metav1.Time{Time: completed},
+				URL:            "https://prow/" + buildID,
+			},
+		}
+	}
+	failed := pj("failed", "1", prowapi.FailureState, now)
+	failed.Status.Failure = &prowapi.Failure{Category: prowapi.FailureCategoryTest, Message: "The test exited with code 1."}
+	aborted := pj("aborted", "3", prowapi.AbortedState, now)
+	running := pj("running", "4", prowapi.PendingState, now)
+	running.Status.CompletionTime = nil
+	client := fakectrlruntimeclient.NewClientBuilder().WithObjects(
+		failed,
+		pj("passed", "2", prowapi.SuccessState, now),
+		aborted,
+		running,
+		pj("old", "5", prowapi.FailureState, now.Add(-30*24*time.Hour)),
+	).Build()
+	bucket := &fakeBucket{contents: map[string]string{
+		"gs://bucket/pr-logs/pull/org_repo/1/unit/1/artifacts/junit_01.xml": `<testsuites><testsuite name="suite">
+<testcase classname="pkg" name="TestA"><failure message="timeout">stack</failure></testcase>
+<testcase classname="pkg" name="TestB"></testcase>
+</testsuite></testsuites>`,
+		"gs://bucket/pr-logs/pull/org_repo/1/unit/1/artifacts/build-log.txt": "not junit",
+		"gs://bucket/pr-logs/pull/org_repo/1/unit/2/artifacts/junit.xml":     `<testsuite name="suite"><testcase classname="pkg" name="TestA"></testcase></testsuite>`,
+	}}
+	cfg := &config.Config{ProwConfig: config.ProwConfig{ProwJobNamespace: "prowjobs"}}
+
+	store := NewStore(7 * 24 * time.Hour)
+	m := NewMiner(client, func() *config.Config { return cfg }, bucket, store)
+	m.now = func() time.Time { return now }
+	if err := m.Sync(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Run{
+		{Job: "unit", BuildID: "1", Repo: "org/repo", Pull: 1, SHA: "head", URL: "https://prow/1", HasResults: true, Failures: map[string]string{"pkg.TestA": "timeout\nstack"}, FailureCategory: prowapi.FailureCategoryTest},
+		{Job: "unit", BuildID: "2", Repo: "org/repo", Pull: 1, SHA: "head", URL: "https://prow/2", Passed: true, HasResults: true},
+	}
+	runs := store.Runs(func(Run) bool { return true })
+	sort.Slice(runs, func(i, j int) bool { return runs[i].BuildID < runs[j].BuildID })
+	for i := range runs {
+		runs[i].Finished = time.Time{}
+	}
+	if diff := cmp.Diff(expected, runs); diff != "" {
+		t.Errorf("runs differ from expected (-want +got):\n%s", diff)
+	}
+}
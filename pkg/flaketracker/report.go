@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flaketracker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// reportMarker identifies the flake-report comment of a pull request.
+const reportMarker = "<!-- flake-report -->"
+
+type githubClient interface {
+	BotUserChecker() (func(candidate string) bool, error)
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
+	ListIssueComments(org, repo string, number int) ([]github.IssueComment, error)
+	CreateComment(org, repo string, number int, comment string) error
+	EditComment(org, repo string, id int, comment string) error
+	DeleteComment(org, repo string, id int) error
+}
+
+// Reporter maintains a flake-report comment on the open pull requests whose
+// latest commit failed tests that are known flakes.
+type Reporter struct {
+	github     githubClient
+	store      *Store
+	thresholds Thresholds
+	logger     *logrus.Entry
+}
+
+// NewReporter creates a Reporter.
+func NewReporter(gc githubClient, store *Store, thresholds Thresholds) *Reporter {
+	return &Reporter{
+		github:     gc,
+		store:      store,
+		thresholds: thresholds,
+		logger:     logrus.WithField("component", "flake-tracker"),
+	}
+}
+
+// Report updates the comments of the pull requests with presubmits that
+// failed since the given time.
+func (r *Reporter) Report(since time.Time) error {
+	type pull struct {
+		repo   string
+		number int
+	}
+	pulls := map[pull]bool{}
+	for _, run := range r.store.Runs(func(run Run) bool { return run.Pull != 0 && !run.Passed && run.Finished.After(since) }) {
+		pulls[pull{run.Repo, run.Pull}] = true
+	}
+	var errs []error
+	for p := range pulls {
+		if err := r.reportPull(p.repo, p.number); err != nil {
+			errs = append(errs, fmt.Errorf("%s#%d: %w", p.repo, p.number, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (r *Reporter) reportPull(orgRepo string, number int) error {
+	org, repo, err := config.SplitRepoName(orgRepo)
+	if err != nil {
+		return err
+	}
+	pr, err := r.github.GetPullRequest(org, repo, number)
+	if err != nil {
+		return err
+	}
+	if pr.State != github.PullRequestStateOpen {
+		return nil
+	}
+	body := reportBody(r.store.PullReport(orgRepo, number, pr.Head.SHA, r.thresholds))
+
+	isBot, err := r.github.BotUserChecker()
+	if err != nil {
+		return err
+	}
+	comments, err := r.github.ListIssueComments(org, repo, number)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if !isBot(comment.User.Login) || !strings.Contains(comment.Body, reportMarker) {
+			continue
+		}
+		switch {
+		case body == "":
+			return r.github.DeleteComment(org, repo, comment.ID)
+		case body != comment.Body:
+			return r.github.EditComment(org, repo, comment.ID, body)
+		default:
+			return nil
+		}
+	}
+	if body == "" {
+		return nil
+	}
+	r.logger.WithField("pull", fmt.Sprintf("%s#%d", orgRepo, number)).Info("Reporting known flakes.")
+	return r.github.CreateComment(org, repo, number, body)
+}
+
+// reportBody renders the flake-report comment, or returns an empty string if
+// no known flake failed.
+func reportBody(report *PullReport) string {
+	var jobs []JobReport
+	for _, job := range report.Jobs {
+		if len(job.KnownFlakes) > 0 {
+			jobs = append(jobs, job)
+		}
+	}
+	if len(jobs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\nThe following tests failed on commit %s and are known to be flaky:\n\n", reportMarker, report.SHA)
+	b.WriteString("Job | Known flakes | Other failures\n--- | --- | ---\n")
+	rerunnable := false
+	for _, job := range jobs {
+		name := job.Job
+		if job.URL != "" {
+			name = fmt.Sprintf("[%s](%s)", job.Job, job.URL)
+		}
+		known := map[string]bool{}
+		for _, test := range job.KnownFlakes {
+			known[test] = true
+		}
+		var others []string
+		for _, test := range job.FailedTests {
+			if !known[test] {
+				others = append(others, test)
+			}
+		}
+		fmt.Fprintf(&b, "%s | %s | %s\n", name, codeList(job.KnownFlakes), codeList(others))
+		rerunnable = rerunnable || job.OnlyFlakes
+	}
+	if rerunnable {
+		b.WriteString("\nComment `/retest-flakes` to rerun the jobs that failed because of known flakes only.\n")
+	}
+	return b.String()
+}
+
+func codeList(tests []string) string {
+	if len(tests) == 0 {
+		return "none"
+	}
+	quoted := make([]string, 0, len(tests))
+	for _, test := range tests {
+		quoted = append(quoted, "`"+test+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flaketracker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+)
+
+func TestReport(t *testing.T) {
+	testCases := []struct {
+		name          string
+		state         string
+		existing      string
+		flaky         bool
+		expectCreated bool
+		expectEdited  bool
+		expectDeleted bool
+	}{
+		{
+			name:          "known flake creates the comment",
+			state:         github.PullRequestStateOpen,
+			flaky:         true,
+			expectCreated: true,
+		},
+		{
+			name:  "closed pull requests are skipped",
+			state: github.PullRequestStateClosed,
+			flaky: true,
+		},
+		{
+			name:         "outdated comment is edited",
+			state:        github.PullRequestStateOpen,
+			existing:     reportMarker + "\nold",
+			flaky:        true,
+			expectEdited: true,
+		},
+		{
+			name:          "comment is deleted once no known flake failed",
+			state:         github.PullRequestStateOpen,
+			existing:      reportMarker + "\nold",
+			expectDeleted: true,
+		},
+		{
+			name:  "nothing to report",
+			state: github.PullRequestStateOpen,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := testStore()
+			failures := map[string]string{"other": "boom"}
+			if tc.flaky {
+				failures = map[string]string{"test-a": "timeout"}
+			}
+			s.Add(Run{Job: "unit", BuildID: "10", Repo: "org/repo", Pull: 1, SHA: "head", Finished: now, HasResults: true, Failures: failures, URL: "https://prow/10"})
+
+			gc := fakegithub.NewFakeClient()
+			gc.PullRequests[1] = &github.PullRequest{Number: 1, State: tc.state, Head: github.PullRequestBranch{SHA: "head"}}
+			if tc.existing != "" {
+				gc.IssueComments[1] = []github.IssueComment{{ID: 5, Body: tc.existing, User: github.User{Login: "k8s-ci-robot"}}}
+			}
+			if err := NewReporter(gc, s, Thresholds{MinFlakes: 2}).Report(now.Add(-time.Minute)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if created := len(gc.IssueCommentsAdded) > 0; created != tc.expectCreated {
+				t.Errorf("expected comment created: %t, got %v", tc.expectCreated, gc.IssueCommentsAdded)
+			}
+			if edited := len(gc.IssueCommentsEdited) > 0; edited != tc.expectEdited {
+				t.Errorf("expected comment edited: %t, got %v", tc.expectEdited, gc.IssueCommentsEdited)
+			}
+			if deleted := len(gc.IssueCommentsDeleted) > 0; deleted != tc.expectDeleted {
+				t.Errorf("expected comment deleted: %t, got %v", tc.expectDeleted, gc.IssueCommentsDeleted)
+			}
+			if tc.expectCreated && !strings.Contains(gc.IssueCommentsAdded[0], "/retest-flakes") {
+				t.Errorf("expected the comment to suggest /retest-flakes, got %s", gc.IssueCommentsAdded[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flaketracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Thresholds decide which flaky tests are known flakes, whose failures
+// /retest-flakes reruns and the flake-report comments.
+type Thresholds struct {
+	// MinFlakes is the minimum number of commits a test flaked on.
+	MinFlakes int
+	// MinRate is the minimum flake rate of a test.
+	MinRate float64
+}
+
+// Known returns whether the flaky test is a known flake.
+func (t Thresholds) Known(f Flake) bool {
+	return f.Flakes >= t.MinFlakes && f.Rate >= t.MinRate
+}
+
+// PullReport lists the failed jobs of a commit of a pull request.
+type PullReport struct {
+	Repo string      `json:"repo"`
+	Pull int         `json:"pull"`
+	SHA  string      `json:"sha"`
+	Jobs []JobReport `json:"jobs,omitempty"`
+}
+
+// JobReport is the latest failed build of a job.
+type JobReport struct {
+	Job     string `json:"job"`
+	BuildID string `json:"build_id"`
+	URL     string `json:"url,omitempty"`
+	// FailedTests are the tests that failed in the build.
+	FailedTests []string `json:"failed_tests,omitempty"`
+	// KnownFlakes are the failed tests that are known flakes.
+	KnownFlakes []string `json:"known_flakes,omitempty"`
+	// OnlyFlakes is whether the build failed because of known flakes only.
+	OnlyFlakes bool `json:"only_flakes"`
+}
+
+// FlakyJobs returns the failed jobs of the report that failed because of
+// known flakes only.
+func (r *PullReport) FlakyJobs() []string {
+	var jobs []string
+	for _, job := range r.Jobs {
+		if job.OnlyFlakes {
+			jobs = append(jobs, job.Job)
+		}
+	}
+	return jobs
+}
+
+// PullReport builds the report of a commit of a pull request.
+func (s *Store) PullReport(repo string, pull int, sha string, thresholds Thresholds) *PullReport {
+	known := map[string]bool{}
+	for _, flake := range s.Flakes() {
+		if thresholds.Known(flake) {
+			known[flake.Job+"/"+flake.Test] = true
+		}
+	}
+	report := &PullReport{Repo: repo, Pull: pull, SHA: sha}
+	seen := map[string]bool{}
+	for _, run := range s.Runs(func(r Run) bool { return r.Repo == repo && r.Pull == pull && r.SHA == sha }) {
+		if seen[run.Job] {
+			// Only the latest build of a job matters.
+			continue
+		}
+		seen[run.Job] = true
+		if run.Passed {
+			continue
+		}
+		job := JobReport{Job: run.Job, BuildID: run.BuildID, URL: run.URL}
+		for test := range run.Failures {
+			job.FailedTests = append(job.FailedTests, test)
+			if known[run.Job+"/"+test] {
+				job.KnownFlakes = append(job.KnownFlakes, test)
+			}
+		}
+		sort.Strings(job.FailedTests)
+		sort.Strings(job.KnownFlakes)
+		job.OnlyFlakes = run.HasResults && len(job.FailedTests) > 0 && len(job.FailedTests) == len(job.KnownFlakes)
+		report.Jobs = append(report.Jobs, job)
+	}
+	sort.Slice(report.Jobs, func(i, j int) bool { return report.Jobs[i].Job < report.Jobs[j].Job })
+	return report
+}
+
+// NewServer serves the flake database:
+//
+//	GET /flakes?repo=org/repo&job=name lists the flaky tests, most flaky first.
+//	GET /pull?repo=org/repo&pull=1&sha=abc reports the failed jobs of a commit
+//	of a pull request.
+func NewServer(store *Store, thresholds Thresholds) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/flakes", func(w http.ResponseWriter, r *http.Request) {
+		repo, job := r.URL.Query().Get("repo"), r.URL.Query().Get("job")
+		flakes := []Flake{}
+		for _, flake := range store.Flakes() {
+			if (repo == "" || flake.Repo == repo) && (job == "" || flake.Job == job) {
+				flakes = append(flakes, flake)
+			}
+		}
+		writeJSON(w, flakes)
+	})
+	mux.HandleFunc("/pull", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		pull, err := strconv.Atoi(query.Get("pull"))
+		if err != nil || query.Get("repo") == "" || query.Get("sha") == "" {
+			http.Error(w, "repo, pull and sha are required", http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, store.PullReport(query.Get("repo"), pull, query.Get("sha"), thresholds))
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to marshal response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(raw); err != nil {
+		logrus.WithError(err).Debug("Failed to write response.")
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flaketracker mines the junit results of finished jobs into a
+// database of flaky tests. A test flakes on a commit when it fails in one
+// build of a job and does not fail in another build of the same job on the
+// same commit.
+package flaketracker
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// Run is the outcome of one build of a job.
+type Run struct {
+	Job     string `json:"job"`
+	BuildID string `json:"build_id"`
+	// Repo is the org/repo the job tested, if any.
+	Repo string `json:"repo,omitempty"`
+	// Pull is the number of the tested pull request, if any.
+	Pull int `json:"pull,omitempty"`
+	// SHA is the tested commit, the head of the pull request for presubmits.
+	SHA      string    `json:"sha,omitempty"`
+	Finished time.Time `json:"finished"`
+	Passed   bool      `json:"passed"`
+	URL      string    `json:"url,omitempty"`
+	// HasResults is whether the build uploaded junit results.
+	HasResults bool `json:"has_results,omitempty"`
+	// Failures maps the failed tests to their failure message.
+	Failures map[string]string `json:"failures,omitempty"`
+	// FailureCategory is the category the pod utilities classified the
+	// failure of the build as, if it failed.
+	FailureCategory prowapi.FailureCategory `json:"failure_category,omitempty"`
+}
+
+func (r Run) key() string {
+	return r.Job + "/" + r.BuildID
+}
+
+// Flake summarizes how flaky a test of a job is.
+type Flake struct {
+	Job  string `json:"job"`
+	Test string `json:"test"`
+	Repo string `json:"repo,omitempty"`
+	// Commits is the number of commits the job has results for.
+	Commits int `json:"commits"`
+	// Flakes is the number of commits the test flaked on.
+	Flakes int `json:"flakes"`
+	// Rate is Flakes / Commits.
+	Rate float64 `json:"rate"`
+	// Signatures are the normalized failure messages of the flakes, most
+	// common first.
+	Signatures []Signature `json:"signatures,omitempty"`
+	LastFlake  time.Time   `json:"last_flake"`
+}
+
+// Signature is a normalized failure message.
+type Signature struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// Store holds the runs of a sliding window.
+type Store struct {
+	lock   sync.RWMutex
+	window time.Duration
+	runs   map[string]Run
+}
+
+// NewStore creates a Store keeping runs that finished within the window.
+func NewStore(window time.Duration) *Store {
+	return &Store{window: window, runs: map[string]Run{}}
+}
+
+// Has returns whether the build of the job was recorded.
+func (s *Store) Has(job, buildID string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.runs[job+"/"+buildID]
+	return ok
+}
+
+// Add records a run.
+func (s *Store) Add(run Run) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.runs[run.key()] = run
+}
+
+// Prune drops the runs that finished before the window.
+func (s *Store) Prune(now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for key, run := range s.runs {
+		if now.Sub(run.Finished) > s.window {
+			delete(s.runs, key)
+		}
+	}
+}
+
+// Runs returns the runs matching the filter, latest first.
+func (s *Store) Runs(match func(Run) bool) []Run {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var runs []Run
+	for _, run := range s.runs {
+		if match(run) {
+			runs = append(runs, run)
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Finished.After(runs[j].Finished) })
+	return runs
+}
+
+// MarshalJSON serializes the runs of the store.
+func (s *Store) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Runs(func(Run) bool { return true }))
+}
+
+// UnmarshalJSON replaces the runs of the store.
+func (s *Store) UnmarshalJSON(raw []byte) error {
+	var runs []Run
+	if err := json.Unmarshal(raw, &runs); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.runs = make(map[string]Run, len(runs))
+	for _, run := range runs {
+		s.runs[run.key()] = run
+	}
+	return nil
+}
+
+// Load replaces the runs of the store with the ones saved at path, if any.
+func (s *Store) Load(ctx context.Context, opener io.Opener, path string) error {
+	raw, err := io.ReadContent(ctx, logrus.WithField("path", path), opener, path)
+	if io.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, s)
+}
+
+// Save writes the runs of the store to path.
+func (s *Store) Save(ctx context.Context, opener io.Opener, path string) error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return io.WriteContent(ctx, logrus.WithField("path", path), opener, path, raw)
+}
+
+// Flakes computes the tests that flaked at least once, most flaky first.
+func (s *Store) Flakes() []Flake {
+	type commitKey struct{ job, sha string }
+	commits := map[commitKey][]Run{}
+	// Runs that failed because of the infrastructure say nothing about the
+	// tests, which may not even have run.
+	for _, run := range s.Runs(func(r Run) bool {
+		return r.SHA != "" && r.HasResults && r.FailureCategory != prowapi.FailureCategoryInfra
+	}) {
+		key := commitKey{run.Job, run.SHA}
+		commits[key] = append(commits[key], run)
+	}
+	commitsPerJob := map[string]int{}
+	flakes := map[commitKey]*Flake{}
+	signatures := map[commitKey]map[string]int{}
+	for key, runs := range commits {
+		commitsPerJob[key.job]++
+		failed := map[string][]Run{}
+		for _, run := range runs {
+			for test := range run.Failures {
+				failed[test] = append(failed[test], run)
+			}
+		}
+		for test, failedRuns := range failed {
+			if len(failedRuns) == len(runs) {
+				// Failed consistently, which is not a flake.
+				continue
+			}
+			flakeKey := commitKey{key.job, test}
+			flake, ok := flakes[flakeKey]
+			if !ok {
+				flake = &Flake{Job: key.job, Test: test, Repo: failedRuns[0].Repo}
+				flakes[flakeKey] = flake
+				signatures[flakeKey] = map[string]int{}
+			}
+			flake.Flakes++
+			for _, run := range failedRuns {
+				signatures[flakeKey][normalize(run.Failures[test])]++
+				if run.Finished.After(flake.LastFlake) {
+					flake.LastFlake = run.Finished
+				}
+			}
+		}
+	}
+
+	result := make([]Flake, 0, len(flakes))
+	for key, flake := range flakes {
+		flake.Commits = commitsPerJob[flake.Job]
+		flake.Rate = float64(flake.Flakes) / float64(flake.Commits)
+		for message, count := range signatures[key] {
+			flake.Signatures = append(flake.Signatures, Signature{Message: message, Count: count})
+		}
+		sort.Slice(flake.Signatures, func(i, j int) bool {
+			if flake.Signatures[i].Count != flake.Signatures[j].Count {
+				return flake.Signatures[i].Count > flake.Signatures[j].Count
+			}
+			return flake.Signatures[i].Message < flake.Signatures[j].Message
+		})
+		result = append(result, *flake)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Rate != result[j].Rate {
+			return result[i].Rate > result[j].Rate
+		}
+		if result[i].Job != result[j].Job {
+			return result[i].Job < result[j].Job
+		}
+		return result[i].Test < result[j].Test
+	})
+	return result
+}
+
+const maxSignatureLength = 200
+
+var (
+	hexRe    = regexp.MustCompile(`\b0x[0-9a-fA-F]+\b|\b[0-9a-f]{8,}\b`)
+	numberRe = regexp.MustCompile(`\d+`)
+)
+
+// normalize reduces a failure message to a signature that is the same for
+// failures with the same cause, by keeping the first line and masking
+// addresses, hashes and numbers.
+func normalize(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	line = hexRe.ReplaceAllString(line, "<hex>")
+	line = numberRe.ReplaceAllString(line, "<n>")
+	if len(line) > maxSignatureLength {
+		line = line[:maxSignatureLength]
+	}
+	return line
+}
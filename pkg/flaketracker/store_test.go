@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flaketracker
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+var now = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func testStore() *Store {
+	s := NewStore(7 * 24 * time.Hour)
+	for i, run := range []Run{
+		// test-a flakes on both commits, test-b fails consistently on abc.
+		{Job: "unit", SHA: "abc", Passed: false, Failures: map[string]string{"test-a": "timeout after 30s", "test-b": "boom"}},
+		{Job: "unit", SHA: "abc", Passed: false, Failures: map[string]string{"test-b": "boom"}},
+		{Job: "unit", SHA: "def", Passed: false, Failures: map[string]string{"test-a": "timeout after 31s"}},
+		{Job: "unit", SHA: "def", Passed: true},
+		// A single run per commit cannot show flakes.
+		{Job: "e2e", SHA: "abc", Passed: false, Failures: map[string]string{"test-c": "boom"}},
+		// Runs without results are not considered.
+		{Job: "unit", SHA: "ghi", Passed: false, HasResults: false},
+		// Runs that failed because of the infrastructure are not considered.
+		{Job: "unit", SHA: "abc", Passed: false, FailureCategory: prowapi.FailureCategoryInfra, Failures: map[string]string{"test-d": "connection refused"}},
+	} {
+		run.BuildID = string(rune('0' + i))
+		run.Repo = "org/repo"
+		run.Finished = now.Add(-time.Duration(i) * time.Hour)
+		if run.Job != "unit" || run.SHA != "ghi" {
+			run.HasResults = true
+		}
+		s.Add(run)
+	}
+	return s
+}
+
+func TestFlakes(t *testing.T) {
+	expected := []Flake{{
+		Job:        "unit",
+		Test:       "test-a",
+		Repo:       "org/repo",
+		Commits:    2,
+		Flakes:     2,
+		Rate:       1,
+		Signatures: []Signature{{Message: "timeout after <n>s", Count: 2}},
+		LastFlake:  now,
+	}}
+	if diff := cmp.Diff(expected, testStore().Flakes()); diff != "" {
+		t.Errorf("flakes differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	s := testStore()
+	s.Prune(now.Add(7*24*time.Hour - 90*time.Minute))
+	if runs := s.Runs(func(Run) bool { return true }); len(runs) != 2 {
+		t.Errorf("expected 2 runs to be kept, got %d", len(runs))
+	}
+	if !s.Has("unit", "0") || s.Has("unit", "2") {
+		t.Error("expected only the oldest runs to be pruned")
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	s := testStore()
+	raw, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	restored := NewStore(time.Hour)
+	if err := json.Unmarshal(raw, restored); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if diff := cmp.Diff(s.Runs(func(Run) bool { return true }), restored.Runs(func(Run) bool { return true })); diff != "" {
+		t.Errorf("runs differ after round trip (-want +got):\n%s", diff)
+	}
+}
+
+func TestPullReport(t *testing.T) {
+	s := testStore()
+	s.Add(Run{Job: "unit", BuildID: "10", Repo: "org/repo", Pull: 1, SHA: "abc", Finished: now, HasResults: true, Failures: map[string]string{"test-a": "timeout"}})
+	s.Add(Run{Job: "lint", BuildID: "11", Repo: "org/repo", Pull: 1, SHA: "abc", Finished: now, HasResults: true, Failures: map[string]string{"test-a": "timeout"}})
+	s.Add(Run{Job: "e2e", BuildID: "12", Repo: "org/repo", Pull: 1, SHA: "abc", Finished: now.Add(-time.Minute), HasResults: true, Failures: map[string]string{"test-c": "boom"}})
+	s.Add(Run{Job: "e2e", BuildID: "13", Repo: "org/repo", Pull: 1, SHA: "abc", Finished: now, Passed: true, HasResults: true})
+
+	expected := &PullReport{
+		Repo: "org/repo",
+		Pull: 1,
+		SHA:  "abc",
+		Jobs: []JobReport{
+			{Job: "lint", BuildID: "11", FailedTests: []string{"test-a"}},
+			{Job: "unit", BuildID: "10", FailedTests: []string{"test-a"}, KnownFlakes: []string{"test-a"}, OnlyFlakes: true},
+		},
+	}
+	report := s.PullReport("org/repo", 1, "abc", Thresholds{MinFlakes: 2})
+	if diff := cmp.Diff(expected, report); diff != "" {
+		t.Errorf("report differs from expected (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"unit"}, report.FlakyJobs()); diff != "" {
+		t.Errorf("flaky jobs differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		message, expected string
+	}{
+		{message: "  panic at 0xdeadbeef\nstack", expected: "panic at <hex>"},
+		{message: "pod abcdef0123 failed 3 times", expected: "pod <hex> failed <n> times"},
+	}
+	for _, tc := range testCases {
+		if got := normalize(tc.message); got != tc.expected {
+			t.Errorf("normalize(%q): expected %q, got %q", tc.message, tc.expected, got)
+		}
+	}
+}
@@ -95,7 +95,9 @@ func (gw *Gangway) CreateJobExecution(ctx context.Context, cjer *CreateJobExecut
 	var reporterFunc ReporterFunc = nil
 	requireTenantID := true
 
-	jobExec, err := HandleProwJob(l, reporterFunc, cjer, gw.ProwJobClient, &mainConfig, gw.InRepoConfigGetter, allowedApiClient, requireTenantID, allowedClusters)
+	restrictOverrides := !allowedApiClient.AllowUnrestrictedOverrides
+
+	jobExec, err := HandleProwJob(l, reporterFunc, cjer, gw.ProwJobClient, &mainConfig, gw.InRepoConfigGetter, allowedApiClient, requireTenantID, allowedClusters, restrictOverrides)
 	if err != nil {
 		logrus.WithError(err).Debugf("failed to create job %q", cjer.GetJobName())
 		return nil, err
@@ -397,6 +399,7 @@ type prowCfgClient interface {
 	GetPostsubmitsStatic(identifier string) []config.Postsubmit
 	GetProwJobDefault(repo, cluster string) *prowcrd.ProwJobDefault
 	GetScheduler() config.Scheduler
+	GetOverrideRestrictions() config.OverrideRestrictions
 }
 
 type ProwCfgAdapter struct {
@@ -405,6 +408,10 @@ type ProwCfgAdapter struct {
 
 func (c *ProwCfgAdapter) GetScheduler() config.Scheduler { return c.Scheduler }
 
+func (c *ProwCfgAdapter) GetOverrideRestrictions() config.OverrideRestrictions {
+	return c.OverrideRestrictions
+}
+
 type ReporterFunc func(pj *prowcrd.ProwJob, state prowcrd.ProwJobState, err error)
 
 func (cjer *CreateJobExecutionRequest) getJobHandler() (jobHandler, error) {
@@ -460,7 +467,8 @@ func HandleProwJob(l *logrus.Entry,
 	ircg config.InRepoConfigGetter,
 	allowedApiClient *config.AllowedApiClient,
 	requireTenantID bool,
-	allowedClusters []string) (*JobExecution, error) {
+	allowedClusters []string,
+	restrictOverrides bool) (*JobExecution, error) {
 
 	var prowJobCR prowcrd.ProwJob
 
@@ -470,7 +478,10 @@ func HandleProwJob(l *logrus.Entry,
 	if err != nil {
 		return nil, err
 	}
-	prowJobSpec, labels, annotations, err := jh.getProwJobSpec(mainConfig, ircg, cjer)
+	prowJobSpec, job, err := jh.getProwJobSpec(mainConfig, ircg, cjer)
+	if err == nil {
+		err = applyOverrides(l, cjer, job, prowJobSpec, restrictOverrides, mainConfig.GetOverrideRestrictions())
+	}
 	if err != nil {
 		// These are user errors, i.e. missing fields, requested prowjob doesn't exist etc.
 		// These errors are already surfaced to user via pubsub two lines below.
@@ -487,7 +498,7 @@ func HandleProwJob(l *logrus.Entry,
 		return nil, fmt.Errorf("failed getting prowjob spec") // This should not happen
 	}
 
-	combinedLabels, combinedAnnotations := mergeMapFields(cjer, labels, annotations)
+	combinedLabels, combinedAnnotations := mergeMapFields(cjer, job.Labels, job.Annotations)
 	prowJobCR = pjutil.NewProwJob(*prowJobSpec, combinedLabels, combinedAnnotations,
 		pjutil.RequireScheduling(mainConfig.GetScheduler().Enabled))
 	// Adds / Updates Environments to containers
@@ -594,23 +605,67 @@ func HandleProwJob(l *logrus.Entry,
 	return jobExec, nil
 }
 
+// applyOverrides validates the envs, labels and refs the request overrides
+// against the trigger_overrides of the job, and replaces the extra_refs of
+// periodics that allow overriding refs. Jobs without trigger_overrides only
+// accept envs and labels if overrides are not restricted, or if restrictions
+// are not enforced yet, in which case a warning is logged. Annotations are
+// never restricted, as they carry where to report the job to.
+func applyOverrides(l *logrus.Entry, cjer *CreateJobExecutionRequest, job config.JobBase, spec *prowcrd.ProwJobSpec, restrict bool, restrictions config.OverrideRestrictions) error {
+	if job.TriggerOverrides == nil && (!restrict || restrictions != config.OverrideRestrictionsEnforce) {
+		if !restrict {
+			return nil
+		}
+		pso := cjer.GetPodSpecOptions()
+		if err := job.TriggerOverrides.Check(pso.GetEnvs(), pso.GetLabels(), false); err != nil {
+			l.WithError(err).WithField("name", cjer.GetJobName()).Warn("Accepting overrides of a job without trigger_overrides. They will be rejected once override_restrictions is \"enforce\".")
+		}
+		return nil
+	}
+	// The refs of presubmits and postsubmits select what they run against
+	// instead of overriding anything.
+	overridesRefs := cjer.GetJobExecutionType() == JobExecutionType_PERIODIC && cjer.GetRefs() != nil
+	pso := cjer.GetPodSpecOptions()
+	if err := job.TriggerOverrides.Check(pso.GetEnvs(), pso.GetLabels(), overridesRefs); err != nil {
+		return err
+	}
+	if !overridesRefs {
+		return nil
+	}
+	refs, err := ToCrdRefs(cjer.GetRefs())
+	if err != nil {
+		return err
+	}
+	// The extra_refs are shared with the config, copy them before changing.
+	spec.ExtraRefs = append([]prowcrd.Refs(nil), spec.ExtraRefs...)
+	for i, extraRefs := range spec.ExtraRefs {
+		if extraRefs.Org == refs.Org && extraRefs.Repo == refs.Repo {
+			spec.ExtraRefs[i].BaseRef = refs.BaseRef
+			spec.ExtraRefs[i].BaseSHA = refs.BaseSHA
+			spec.ExtraRefs[i].Pulls = refs.Pulls
+			return nil
+		}
+	}
+	return fmt.Errorf("the job has no extra_refs for %s/%s to override", refs.Org, refs.Repo)
+}
+
 // jobHandler handles job type specific logic
 type jobHandler interface {
-	getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, err error)
+	getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, job config.JobBase, err error)
 }
 
 // periodicJobHandler implements jobHandler
 type periodicJobHandler struct{}
 
-func (peh *periodicJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, err error) {
+func (peh *periodicJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, job config.JobBase, err error) {
 	var periodicJob *config.Periodic
 	// TODO(chaodaiG): do we want to support inrepoconfig when
 	// https://github.com/kubernetes/test-infra/issues/21729 is done?
-	for _, job := range mainConfig.AllPeriodics() {
-		if job.Name == cjer.GetJobName() {
+	for _, periodic := range mainConfig.AllPeriodics() {
+		if periodic.Name == cjer.GetJobName() {
 			// Directly followed by break, so this is ok
 			// nolint: exportloopref
-			periodicJob = &job
+			periodicJob = &periodic
 			break
 		}
 	}
@@ -621,7 +676,7 @@ func (peh *periodicJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg con
 
 	spec := pjutil.PeriodicSpec(*periodicJob)
 	prowJobSpec = &spec
-	labels, annotations = periodicJob.Labels, periodicJob.Annotations
+	job = periodicJob.JobBase
 	return
 }
 
@@ -664,7 +719,7 @@ func validateRefs(jobType JobExecutionType, refs *prowcrd.Refs) error {
 	return nil
 }
 
-func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, err error) {
+func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, job config.JobBase, err error) {
 	// presubmit jobs require Refs and Refs.Pulls to be set
 	refs, err := ToCrdRefs(cjer.GetRefs())
 	if err != nil {
@@ -706,17 +761,17 @@ func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg co
 		}
 	}
 
-	for _, job := range presubmits {
-		job := job
-		if !job.CouldRun(branch) { // filter out jobs that are not branch matching
+	for _, presubmit := range presubmits {
+		presubmit := presubmit
+		if !presubmit.CouldRun(branch) { // filter out jobs that are not branch matching
 			continue
 		}
-		if job.Name == cjer.GetJobName() {
+		if presubmit.Name == cjer.GetJobName() {
 			if presubmitJob != nil {
 				err = fmt.Errorf("%s matches multiple prow jobs from orgRepo %q", cjer.GetJobName(), orgRepo)
 				return
 			}
-			presubmitJob = &job
+			presubmitJob = &presubmit
 		}
 	}
 	// This also captures the case where fetching jobs from inrepoconfig failed.
@@ -727,7 +782,7 @@ func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg co
 	}
 
 	spec := pjutil.PresubmitSpec(*presubmitJob, *refs)
-	prowJobSpec, labels, annotations = &spec, presubmitJob.Labels, presubmitJob.Annotations
+	prowJobSpec, job = &spec, presubmitJob.JobBase
 	return
 }
 
@@ -735,7 +790,7 @@ func (prh *presubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg co
 type postsubmitJobHandler struct {
 }
 
-func (poh *postsubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, labels map[string]string, annotations map[string]string, err error) {
+func (poh *postsubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg config.InRepoConfigGetter, cjer *CreateJobExecutionRequest) (prowJobSpec *prowcrd.ProwJobSpec, job config.JobBase, err error) {
 	// postsubmit jobs require Refs to be set
 	refs, err := ToCrdRefs(cjer.GetRefs())
 	if err != nil {
@@ -774,16 +829,17 @@ func (poh *postsubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg c
 		}
 	}
 
-	for _, job := range postsubmits {
-		job := job
-		if !job.CouldRun(branch) { // filter out jobs that are not branch matching
+	for _, postsubmit := range postsubmits {
+		postsubmit := postsubmit
+		if !postsubmit.CouldRun(branch) { // filter out jobs that are not branch matching
 			continue
 		}
-		if job.Name == cjer.GetJobName() {
+		if postsubmit.Name == cjer.GetJobName() {
 			if postsubmitJob != nil {
-				return nil, nil, nil, fmt.Errorf("%s matches multiple prow jobs from orgRepo %q", cjer.GetJobName(), orgRepo)
+				err = fmt.Errorf("%s matches multiple prow jobs from orgRepo %q", cjer.GetJobName(), orgRepo)
+				return
 			}
-			postsubmitJob = &job
+			postsubmitJob = &postsubmit
 		}
 	}
 	// This also captures the case where fetching jobs from inrepoconfig failed.
@@ -794,6 +850,6 @@ func (poh *postsubmitJobHandler) getProwJobSpec(mainConfig prowCfgClient, ircg c
 	}
 
 	spec := pjutil.PostsubmitSpec(*postsubmitJob, *refs)
-	prowJobSpec, labels, annotations = &spec, postsubmitJob.Labels, postsubmitJob.Annotations
+	prowJobSpec, job = &spec, postsubmitJob.JobBase
 	return
 }
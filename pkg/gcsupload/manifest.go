@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcsupload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	pkgio "sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/io/providers"
+	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
+)
+
+// ManifestName is the name of the checksum manifest in the job's directory.
+const ManifestName = "checksum-manifest.json"
+
+// Manifest lists the files uploaded for a job.
+type Manifest struct {
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a file uploaded for a job.
+type ManifestFile struct {
+	// Path is relative to the job's directory.
+	Path string `json:"path"`
+	// Size is the size in bytes of the content before compression.
+	Size int64 `json:"size"`
+	// SHA256 is the hex encoded checksum of the content before compression.
+	SHA256 string `json:"sha256"`
+}
+
+// manifestRecorder records the files uploaded below the job's directory.
+type manifestRecorder struct {
+	jobPath string
+
+	lock  sync.Mutex
+	files map[string]ManifestFile
+}
+
+func newManifestRecorder(jobPath string) *manifestRecorder {
+	return &manifestRecorder{jobPath: jobPath, files: map[string]ManifestFile{}}
+}
+
+// record wraps the uploads of the targets below the job's directory so that
+// they are recorded once they succeeded.
+func (m *manifestRecorder) record(uploadTargets map[string]gcs.UploadFunc) {
+	for destination, upload := range uploadTargets {
+		relative, ok := m.relative(destination)
+		if !ok {
+			continue
+		}
+		uploadTargets[destination] = gcs.ChecksumUpload(upload, func(size int64, checksum string) {
+			m.lock.Lock()
+			defer m.lock.Unlock()
+			m.files[relative] = ManifestFile{Path: relative, Size: size, SHA256: checksum}
+		})
+	}
+}
+
+func (m *manifestRecorder) relative(destination string) (string, bool) {
+	if m.jobPath == "" {
+		return destination, true
+	}
+	return strings.CutPrefix(destination, m.jobPath+"/")
+}
+
+// upload merges the recorded files into the manifest of the job, which may
+// already list the files uploaded by an earlier utility, and uploads it.
+func (m *manifestRecorder) upload(ctx context.Context, o Options) error {
+	manifestPath := path.Join(m.jobPath, ManifestName)
+	existing, err := o.readManifest(ctx, manifestPath)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to read the existing checksum manifest, replacing it.")
+	}
+
+	files := map[string]ManifestFile{}
+	for _, file := range existing.Files {
+		files[file.Path] = file
+	}
+	m.lock.Lock()
+	for relative, file := range m.files {
+		files[relative] = file
+	}
+	m.lock.Unlock()
+
+	manifest := Manifest{Files: make([]ManifestFile, 0, len(files))}
+	for _, file := range files {
+		manifest.Files = append(manifest.Files, file)
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checksum manifest: %w", err)
+	}
+	return completeUpload(ctx, o, map[string]gcs.UploadFunc{manifestPath: gcs.DataUpload(newStringReadCloser(string(raw)))})
+}
+
+// readManifest reads the manifest at the path in the bucket or the local
+// output dir. A manifest that does not exist yet is empty.
+func (o Options) readManifest(ctx context.Context, manifestPath string) (Manifest, error) {
+	var manifest Manifest
+	fullPath := filepath.Join(o.LocalOutputDir, manifestPath)
+	if o.LocalOutputDir == "" {
+		parsedBucket, err := url.Parse(o.Bucket)
+		if err != nil {
+			return manifest, fmt.Errorf("cannot parse bucket name %s: %w", o.Bucket, err)
+		}
+		if parsedBucket.Scheme == "" {
+			parsedBucket.Scheme = providers.GS
+		}
+		fullPath = fmt.Sprintf("%s/%s", parsedBucket.String(), manifestPath)
+	}
+	opener, err := pkgio.NewOpener(ctx, o.StorageClientOptions.GCSCredentialsFile, o.StorageClientOptions.S3CredentialsFile)
+	if err != nil {
+		return manifest, fmt.Errorf("new opener: %w", err)
+	}
+	raw, err := pkgio.ReadContent(ctx, logrus.WithField("path", fullPath), opener, fullPath)
+	if pkgio.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to unmarshal %s: %w", fullPath, err)
+	}
+	return manifest, nil
+}
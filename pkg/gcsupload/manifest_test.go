@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcsupload
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
+)
+
+func TestChecksumManifest(t *testing.T) {
+	dir := t.TempDir()
+	artifacts := filepath.Join(dir, "artifacts")
+	if err := os.MkdirAll(filepath.Join(artifacts, "nested"), 0755); err != nil {
+		t.Fatalf("failed to create artifacts: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(artifacts, "nested", "junit.xml"), []byte("<testsuites/>"), 0644); err != nil {
+		t.Fatalf("failed to write artifact: %v", err)
+	}
+	outputDir := filepath.Join(dir, "output")
+	enabled := true
+	spec := &downwardapi.JobSpec{Type: prowapi.PeriodicJob, Job: "job", BuildID: "1"}
+
+	// initupload uploads first, sidecar later merges its files into the
+	// manifest.
+	initupload := Options{GCSConfiguration: &prowapi.GCSConfiguration{LocalOutputDir: outputDir, ChecksumManifest: &enabled}}
+	if err := initupload.Run(context.Background(), spec, map[string]gcs.UploadFunc{
+		prowapi.StartedStatusFile: gcs.DataUpload(newStringReadCloser("started")),
+	}); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+	sidecar := Options{Items: []string{artifacts}, GCSConfiguration: &prowapi.GCSConfiguration{LocalOutputDir: outputDir, ChecksumManifest: &enabled}}
+	if err := sidecar.Run(context.Background(), spec, map[string]gcs.UploadFunc{
+		"build-log.txt": gcs.DataUpload(newStringReadCloser("")),
+	}); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(outputDir, ManifestName))
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	var actual Manifest
+	if err := json.Unmarshal(raw, &actual); err != nil {
+		t.Fatalf("failed to unmarshal manifest: %v", err)
+	}
+	expected := Manifest{Files: []ManifestFile{
+		{Path: "artifacts/nested/junit.xml", Size: 13, SHA256: "14971007a6c99471a0dd7d408b2769fa55dfa8534c3d21e761a2d9f6f1ef7467"},
+		{Path: "build-log.txt", Size: 0, SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{Path: "started.json", Size: 7, SHA256: "03494afd4248c42f5fa1237bf2eeebe751ab8d9c977d55405fcb17469dbd91f8"},
+	}}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected manifest (-want +got):\n%s", diff)
+	}
+}
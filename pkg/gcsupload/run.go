@@ -30,6 +30,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/io/providers"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
 )
@@ -51,6 +52,13 @@ func (o Options) Run(ctx context.Context, spec *downwardapi.JobSpec, extra map[s
 		return fmt.Errorf("assembleTargets: %w", err)
 	}
 
+	var manifest *manifestRecorder
+	if o.GCSConfiguration.ChecksumManifestEnabled() && !o.DryRun {
+		manifest = newManifestRecorder(o.jobPath(spec))
+		manifest.record(uploadTargets)
+		manifest.record(extraTargets)
+	}
+
 	err = completeUpload(ctx, o, uploadTargets)
 
 	if extraErr := completeUpload(ctx, o, extraTargets); extraErr != nil {
@@ -61,9 +69,80 @@ func (o Options) Run(ctx context.Context, spec *downwardapi.JobSpec, extra map[s
 		}
 	}
 
+	if manifest != nil {
+		if manifestErr := manifest.upload(ctx, o); manifestErr != nil {
+			if err == nil {
+				err = manifestErr
+			} else {
+				logrus.WithError(manifestErr).Info("Also failed to upload checksum manifest")
+			}
+		}
+	}
+
 	return err
 }
 
+// jobPath is the path that the files of the job are uploaded below, relative
+// to the bucket or the local output dir.
+func (o Options) jobPath(spec *downwardapi.JobSpec) string {
+	if o.LocalOutputDir != "" {
+		return ""
+	}
+	_, blobStoragePath, _ := PathsForJob(o.GCSConfiguration, spec, o.SubDir)
+	return blobStoragePath
+}
+
+// UploadFiles uploads only the given files, relative to the base of the GCS
+// dir of the job, without the items, alias and latest build markers that Run
+// uploads along with them.
+func (o Options) UploadFiles(ctx context.Context, spec *downwardapi.JobSpec, files map[string]gcs.UploadFunc) error {
+	jobPath := o.jobPath(spec)
+	uploadTargets := make(map[string]gcs.UploadFunc, len(files))
+	for destination, upload := range files {
+		uploadTargets[path.Join(jobPath, destination)] = upload
+	}
+	return completeUpload(ctx, o, uploadTargets)
+}
+
+// CanAppend returns whether AppendFiles is supported, which is the case for
+// GCS buckets and local output dirs.
+func (o Options) CanAppend() bool {
+	if o.LocalOutputDir != "" {
+		return true
+	}
+	parsedBucket, err := url.Parse(o.Bucket)
+	return err == nil && (parsedBucket.Scheme == "" || parsedBucket.Scheme == providers.GS)
+}
+
+// AppendFiles appends the data to the given files, relative to the base of
+// the GCS dir of the job. The files have to exist already, e.g. from an
+// earlier call to UploadFiles without compression.
+func (o Options) AppendFiles(ctx context.Context, spec *downwardapi.JobSpec, files map[string]gcs.UploadFunc) error {
+	jobPath := o.jobPath(spec)
+	appendTargets := make(map[string]gcs.UploadFunc, len(files))
+	for destination, upload := range files {
+		appendTargets[path.Join(jobPath, destination)] = upload
+	}
+
+	if o.DryRun {
+		for destination := range appendTargets {
+			logrus.WithField("dest", destination).Info("Would append")
+		}
+		return nil
+	}
+
+	if o.LocalOutputDir == "" {
+		if err := gcs.Append(ctx, o.Bucket, o.StorageClientOptions.GCSCredentialsFile, o.StorageClientOptions.S3CredentialsFile, appendTargets); err != nil {
+			return fmt.Errorf("failed to append to blob storage: %w", err)
+		}
+	} else {
+		if err := gcs.LocalAppend(ctx, o.LocalOutputDir, appendTargets); err != nil {
+			return fmt.Errorf("failed to append to files in %q: %w", o.LocalOutputDir, err)
+		}
+	}
+	return nil
+}
+
 func completeUpload(ctx context.Context, o Options, uploadTargets map[string]gcs.UploadFunc) error {
 	if o.DryRun {
 		for destination := range uploadTargets {
@@ -165,7 +244,7 @@ func (o Options) assembleTargets(spec *downwardapi.JobSpec, extra map[string]gcs
 //
 // The builder for the job is also returned for use in other path resolution.
 func PathsForJob(options *prowapi.GCSConfiguration, spec *downwardapi.JobSpec, subdir string) (string, string, gcs.RepoPathBuilder) {
-	builder := builderForStrategy(options.PathStrategy, options.DefaultOrg, options.DefaultRepo)
+	builder := BuilderForStrategy(options.PathStrategy, options.DefaultOrg, options.DefaultRepo)
 	jobBasePath := gcs.PathForSpec(spec, builder)
 	if options.PathPrefix != "" {
 		jobBasePath = path.Join(options.PathPrefix, jobBasePath)
@@ -180,7 +259,9 @@ func PathsForJob(options *prowapi.GCSConfiguration, spec *downwardapi.JobSpec, s
 	return jobBasePath, blobStoragePath, builder
 }
 
-func builderForStrategy(strategy, defaultOrg, defaultRepo string) gcs.RepoPathBuilder {
+// BuilderForStrategy returns the builder of the org/repo path segment of
+// presubmits for the gcs_path_strategy.
+func BuilderForStrategy(strategy, defaultOrg, defaultRepo string) gcs.RepoPathBuilder {
 	var builder gcs.RepoPathBuilder
 	switch strategy {
 	case prowapi.PathStrategyExplicit:
@@ -303,7 +303,7 @@ func TestBuilderForStrategy(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		builder := builderForStrategy(testCase.strategy, testCase.defaultOrg, testCase.defaultRepo)
+		builder := BuilderForStrategy(testCase.strategy, testCase.defaultOrg, testCase.defaultRepo)
 		for sampleInfo, expectedPath := range testCase.expectedPaths {
 			if actual, expected := builder(sampleInfo.org, sampleInfo.repo), expectedPath; actual != expected {
 				t.Errorf("%s: expected (%s,%s) -> %s, got %s", testCase.name, sampleInfo.org, sampleInfo.repo, expected, actual)
@@ -311,3 +311,40 @@ func TestBuilderForStrategy(t *testing.T) {
 		}
 	}
 }
+
+func TestCanAppend(t *testing.T) {
+	testCases := []struct {
+		name     string
+		options  Options
+		expected bool
+	}{
+		{
+			name:     "bucket without scheme is on GCS",
+			options:  Options{GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "bucket"}},
+			expected: true,
+		},
+		{
+			name:     "GCS bucket",
+			options:  Options{GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "gs://bucket"}},
+			expected: true,
+		},
+		{
+			name:     "S3 bucket",
+			options:  Options{GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "s3://bucket"}},
+			expected: false,
+		},
+		{
+			name:     "local output dir",
+			options:  Options{GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "s3://bucket", LocalOutputDir: "/output"}},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.options.CanAppend(); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
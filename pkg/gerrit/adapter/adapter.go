@@ -59,6 +59,8 @@ var gerritMetrics = struct {
 	gerritRepoQueryDuration     *prometheus.HistogramVec
 	pickupChangeLatency         *prometheus.HistogramVec
 	jobCreationDuration         *prometheus.HistogramVec
+	hostActiveSyncs             *prometheus.GaugeVec
+	hostSyncQueueDuration       *prometheus.HistogramVec
 }{
 	processingResults: prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "gerrit_processing_results",
@@ -131,6 +133,15 @@ var gerritMetrics = struct {
 		"org",
 		"repo",
 	}),
+	hostActiveSyncs: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gerrit_host_active_syncs",
+		Help: "Number of projects currently being synced, by instance.",
+	}, []string{"org"}),
+	hostSyncQueueDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gerrit_host_sync_queue_duration",
+		Help:    "Histogram of seconds a project sync waited for one of the concurrency slots of its instance.",
+		Buckets: []float64{0.01, 0.1, 0.5, 1, 2, 5, 10, 20, 30, 60, 120, 300},
+	}, []string{"org"}),
 }
 
 func init() {
@@ -144,6 +155,8 @@ func init() {
 	prometheus.MustRegister(gerritMetrics.gerritRepoQueryDuration)
 	prometheus.MustRegister(gerritMetrics.pickupChangeLatency)
 	prometheus.MustRegister(gerritMetrics.jobCreationDuration)
+	prometheus.MustRegister(gerritMetrics.hostActiveSyncs)
+	prometheus.MustRegister(gerritMetrics.hostSyncQueueDuration)
 }
 
 type prowJobClient interface {
@@ -151,7 +164,7 @@ type prowJobClient interface {
 }
 
 type gerritClient interface {
-	ApplyGlobalConfig(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, lastSyncTracker *client.SyncTime, cookiefilePath, tokenPathOverride string, additionalFunc func())
+	ApplyGlobalConfig(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, lastSyncTracker *client.ShardedSyncTime, cookiefilePath, tokenPathOverride string, additionalFunc func())
 	Authenticate(cookiefilePath, tokenPath string)
 	QueryChangesForProject(instance, project string, lastUpdate time.Time, rateLimit int, additionalFilters ...string) ([]gerrit.ChangeInfo, error)
 	GetBranchRevision(instance, project, branch string) (string, error)
@@ -165,16 +178,60 @@ type Controller struct {
 	config                      config.Getter
 	prowJobClient               prowJobClient
 	gc                          gerritClient
-	tracker                     LastSyncTracker
+	trackerForHost              func(instance string) LastSyncTracker
 	projectsOptOutHelp          map[string]sets.Set[string]
 	lock                        sync.RWMutex
 	cookieFilePath              string
 	configAgent                 *config.Agent
 	inRepoConfigGetter          config.InRepoConfigGetter
 	inRepoConfigFailuresTracker map[string]bool
-	projectsWithWorker          map[string]bool
-	latestMux                   sync.Mutex
-	workerPoolSize              int
+	// shards holds the sync state of every Gerrit host, keyed by instance.
+	shards              map[string]*hostShard
+	workerMux           sync.Mutex
+	latestMux           sync.Mutex
+	workerPoolSize      int
+	hostSyncConcurrency int
+}
+
+// hostShard holds everything a single Gerrit host needs to sync on its own,
+// so that a slow or unavailable host never delays the others.
+type hostShard struct {
+	instance string
+	tracker  LastSyncTracker
+	// slots bounds how many projects of the host sync concurrently, nil
+	// means unbounded.
+	slots chan struct{}
+	// workers holds the wakeup channel of every project worker.
+	workers map[string]chan struct{}
+}
+
+func newHostShard(instance string, tracker LastSyncTracker, concurrency int) *hostShard {
+	shard := &hostShard{
+		instance: instance,
+		tracker:  tracker,
+		workers:  map[string]chan struct{}{},
+	}
+	if concurrency > 0 {
+		shard.slots = make(chan struct{}, concurrency)
+	}
+	return shard
+}
+
+// acquire blocks until the shard has capacity to sync one more project and
+// returns the function releasing it.
+func (s *hostShard) acquire() func() {
+	start := time.Now()
+	if s.slots != nil {
+		s.slots <- struct{}{}
+	}
+	gerritMetrics.hostSyncQueueDuration.WithLabelValues(s.instance).Observe(time.Since(start).Seconds())
+	gerritMetrics.hostActiveSyncs.WithLabelValues(s.instance).Inc()
+	return func() {
+		gerritMetrics.hostActiveSyncs.WithLabelValues(s.instance).Dec()
+		if s.slots != nil {
+			<-s.slots
+		}
+	}
 }
 
 type LastSyncTracker interface {
@@ -184,14 +241,14 @@ type LastSyncTracker interface {
 
 // NewController returns a new gerrit controller client
 func NewController(ctx context.Context, prowJobClient prowv1.ProwJobInterface, op io.Opener,
-	ca *config.Agent, cookiefilePath, tokenPathOverride, lastSyncFallback string, workerPoolSize int, maxQPS, maxBurst int, ircg config.InRepoConfigGetter) *Controller {
+	ca *config.Agent, cookiefilePath, tokenPathOverride, lastSyncFallback string, workerPoolSize, hostSyncConcurrency int, maxQPS, maxBurst int, ircg config.InRepoConfigGetter) *Controller {
 
 	cfg := ca.Config
 	projectsOptOutHelpMap := map[string]sets.Set[string]{}
 	if cfg().Gerrit.OrgReposConfig != nil {
 		projectsOptOutHelpMap = cfg().Gerrit.OrgReposConfig.OptOutHelpRepos()
 	}
-	lastSyncTracker := client.NewSyncTime(lastSyncFallback, op, ctx)
+	lastSyncTracker := client.NewShardedSyncTime(lastSyncFallback, op, ctx)
 
 	if err := lastSyncTracker.Init(cfg().Gerrit.OrgReposConfig.AllRepos()); err != nil {
 		logrus.WithError(err).Fatal("Error initializing lastSyncFallback.")
@@ -204,14 +261,15 @@ func NewController(ctx context.Context, prowJobClient prowv1.ProwJobInterface, o
 		prowJobClient:               prowJobClient,
 		config:                      cfg,
 		gc:                          gerritClient,
-		tracker:                     lastSyncTracker,
+		trackerForHost:              func(instance string) LastSyncTracker { return lastSyncTracker.Shard(instance) },
 		projectsOptOutHelp:          projectsOptOutHelpMap,
 		cookieFilePath:              cookiefilePath,
 		configAgent:                 ca,
 		inRepoConfigGetter:          ircg,
 		inRepoConfigFailuresTracker: map[string]bool{},
-		projectsWithWorker:          make(map[string]bool),
+		shards:                      make(map[string]*hostShard),
 		workerPoolSize:              workerPoolSize,
+		hostSyncConcurrency:         hostSyncConcurrency,
 	}
 
 	// applyGlobalConfig reads gerrit configurations from global gerrit config,
@@ -286,10 +344,13 @@ func (c *Controller) processChange(latest client.LastSyncState, changeChan <-cha
 	}
 }
 
-func (c *Controller) processSingleProject(instance, project string) {
-	// Assumes the passed in instance was already normalized with https:// prefix.
+func (c *Controller) processSingleProject(shard *hostShard, project string) {
+	defer shard.acquire()()
+
+	// Assumes the shard instance was already normalized with https:// prefix.
+	instance := shard.instance
 	log := logrus.WithFields(logrus.Fields{"host": instance, "repo": project})
-	tracker := c.tracker.Current()
+	tracker := shard.tracker.Current()
 	syncTime := time.Now()
 	if projects, ok := tracker[instance]; ok {
 		if t, ok := projects[project]; ok {
@@ -349,7 +410,9 @@ func (c *Controller) processSingleProject(instance, project string) {
 	wg.Wait()
 	gerritMetrics.changeProcessDuration.WithLabelValues(instance, project).Observe((float64(time.Since(timeProcessChangesForProject).Seconds())))
 	close(changeChan)
-	c.tracker.Update(latest)
+	if err := shard.tracker.Update(latest); err != nil {
+		log.WithError(err).Error("Failed to update last sync time.")
+	}
 }
 
 func checkAndLogQuery(log *logrus.Entry, changes []gerrit.ChangeInfo) {
@@ -366,49 +429,114 @@ func checkAndLogQuery(log *logrus.Entry, changes []gerrit.ChangeInfo) {
 // Sync looks for newly made gerrit changes
 // and creates prowjobs according to specs
 func (c *Controller) Sync() {
-	// Identify projects without worker threads
-	id := func(instance, project string) string { return fmt.Sprintf("%s/%s", instance, project) }
-	needsWorker := map[string][]string{}
-	needsWorkerCount := map[string]int{}
+	c.workerMux.Lock()
+	defer c.workerMux.Unlock()
+
 	for instance, projects := range c.config().Gerrit.OrgReposConfig.AllRepos() {
-		for project := range projects {
-			if _, ok := c.projectsWithWorker[id(instance, project)]; ok {
-				// The worker thread is already up for this project, nothing needs
-				// to be done.
-				continue
+		shard, ok := c.shards[instance]
+		if !ok {
+			// First time seeing this host, every host syncs independently
+			// with its own last sync state and concurrency limit.
+			logrus.WithField("instance", instance).Info("Starting shard for host.")
+			shard = newHostShard(instance, c.trackerForHost(instance), c.hostSyncConcurrency)
+			c.shards[instance] = shard
+		}
+		c.startWorkers(shard, projects)
+	}
+}
+
+// startWorkers spins up worker threads for the projects of a host that don't
+// have one yet. Must be called with workerMux held.
+func (c *Controller) startWorkers(shard *hostShard, projects map[string]*config.GerritQueryFilter) {
+	var needsWorker []string
+	for project := range projects {
+		if _, ok := shard.workers[project]; ok {
+			// The worker thread is already up for this project, nothing needs
+			// to be done.
+			continue
+		}
+		needsWorker = append(needsWorker, project)
+	}
+	if len(needsWorker) == 0 {
+		return
+	}
+	// Stagger new worker threads across the loop period to reduce load on the Gerrit API and Git server.
+	staggerIncrement := c.config().Gerrit.TickInterval.Duration / time.Duration(len(needsWorker))
+	for staggerPosition, project := range needsWorker {
+		wakeup := make(chan struct{}, 1)
+		shard.workers[project] = wakeup
+		logrus.WithFields(logrus.Fields{"instance": shard.instance, "repo": project}).Info("Starting worker for project.")
+		go c.runWorker(shard, project, staggerIncrement*time.Duration(staggerPosition), wakeup)
+	}
+}
+
+func (c *Controller) runWorker(shard *hostShard, project string, napTime time.Duration, wakeup <-chan struct{}) {
+	time.Sleep(napTime)
+
+	// Now start the repo worker thread.
+	previousRun := time.Now()
+	for {
+		timeDiff := time.Until(previousRun.Add(c.config().Gerrit.TickInterval.Duration))
+		if timeDiff > 0 {
+			select {
+			case <-time.After(timeDiff):
+			case <-wakeup:
 			}
-			needsWorker[instance] = append(needsWorker[instance], project)
-			needsWorkerCount[instance]++
-		}
-	}
-	// First time seeing these projects, spin up worker threads for them.
-	staggerPosition := 0
-	for instance, projects := range needsWorker {
-		staggerIncement := c.config().Gerrit.TickInterval.Duration / time.Duration(needsWorkerCount[instance])
-		for _, project := range projects {
-			c.projectsWithWorker[id(instance, project)] = true
-			logrus.WithFields(logrus.Fields{"instance": instance, "repo": project}).Info("Starting worker for project.")
-			go func(instance, project string, staggerPosition int) {
-				// Stagger new worker threads across the loop period to reduce load on the Gerrit API and Git server.
-				napTime := staggerIncement * time.Duration(staggerPosition)
-				time.Sleep(napTime)
-
-				// Now start the repo worker thread.
-				previousRun := time.Now()
-				for {
-					timeDiff := time.Until(previousRun.Add(c.config().Gerrit.TickInterval.Duration))
-					if timeDiff > 0 {
-						time.Sleep(timeDiff)
-					}
-					previousRun = time.Now()
-					c.processSingleProject(instance, project)
-				}
-			}(instance, project, staggerPosition)
-			staggerPosition++
 		}
+		previousRun = time.Now()
+		c.processSingleProject(shard, project)
 	}
 }
 
+// wakeProject makes the worker of a project sync right away instead of
+// waiting for the next tick. Wakeups received while a sync is already
+// pending are coalesced. Returns false if the project has no worker.
+func (c *Controller) wakeProject(instance, project string) bool {
+	c.workerMux.Lock()
+	var wakeup chan struct{}
+	if shard, ok := c.shards[instance]; ok {
+		wakeup = shard.workers[project]
+	}
+	c.workerMux.Unlock()
+	if wakeup == nil {
+		return false
+	}
+	select {
+	case wakeup <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// StreamEvents consumes the event stream of a Gerrit instance until the
+// context is cancelled, syncing a project as soon as an event about one of
+// its changes arrives. Every project of the instance is synced whenever the
+// stream (re-)connects, which replays events missed while disconnected as the
+// sync queries everything updated since the last one. Regular polling keeps
+// running as a safety net.
+func (c *Controller) StreamEvents(ctx context.Context, instance string, dial client.StreamDialer) {
+	streamer := &client.EventStreamer{
+		Instance: instance,
+		Dial:     dial,
+		OnConnect: func() {
+			for project := range c.config().Gerrit.OrgReposConfig.AllRepos()[instance] {
+				c.wakeProject(instance, project)
+			}
+		},
+		OnEvent: func(event client.StreamEvent) {
+			if event.Change == nil {
+				return
+			}
+			if !c.wakeProject(instance, event.Change.Project) {
+				logrus.WithFields(logrus.Fields{"host": instance, "repo": event.Change.Project, "type": event.Type}).Debug("Ignoring event for a project without a worker.")
+			}
+		},
+		MinBackoff: time.Second,
+		MaxBackoff: 5 * time.Minute,
+	}
+	streamer.Run(ctx)
+}
+
 // CreateRefs creates refs for a presubmit job from given changes.
 //
 // Passed in instance must contain https:// prefix.
@@ -560,6 +688,9 @@ func (c *Controller) shouldTriggerJobs(change client.ChangeInfo, lastProjectSync
 		if indicatesChangeFromDraftToActiveState(message.Message) {
 			return true
 		}
+		if indicatesTagging(message.Message) {
+			return true
+		}
 	}
 
 	return false
@@ -595,6 +726,7 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 		spec        prowapi.ProwJobSpec
 		labels      map[string]string
 		annotations map[string]string
+		matcher     config.GerritChangeMatcher
 	}
 	var jobSpecs []jobSpec
 	baseSHAGetter := func() (string, error) { return baseSHA, nil }
@@ -663,6 +795,9 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 		}
 
 		for _, postsubmit := range postsubmits {
+			if matches, _, _ := postsubmit.GerritChangeMatcher.Matches(change.Hashtags, change.Topic); !matches {
+				continue
+			}
 			if shouldRun, err := postsubmit.ShouldRun(change.Branch, client.ChangedFilesProvider(&change)); err != nil {
 				return fmt.Errorf("failed to determine if postsubmit %q should run: %w", postsubmit.Name, err)
 			} else if shouldRun {
@@ -673,6 +808,7 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 					spec:        pjutil.PostsubmitSpec(postsubmit, refs),
 					labels:      postsubmit.Labels,
 					annotations: postsubmit.Annotations,
+					matcher:     postsubmit.GerritChangeMatcher,
 				})
 			}
 		}
@@ -709,6 +845,9 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 			// kicking off nothing.
 			return err
 		}
+		// Jobs restricted to hashtags or topics the change doesn't have are
+		// treated like jobs for other branches.
+		presubmits = presubmitsMatchingTags(presubmits, change.Hashtags, change.Topic)
 
 		account, err := c.gc.Account(instance)
 		if err != nil {
@@ -716,7 +855,7 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 			return fmt.Errorf("account not found for %q: %w", instance, err)
 		}
 
-		lastUpdate, ok := c.tracker.Current()[instance][change.Project]
+		lastUpdate, ok := c.trackerForHost(instance).Current()[instance][change.Project]
 		if !ok {
 			lastUpdate = time.Now()
 			logger.WithField("lastUpdate", lastUpdate).Warnf("lastUpdate not found, falling back to now")
@@ -727,12 +866,15 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 		failed, all := presubmitContexts(failedJobs, presubmits, logger)
 		messages := currentMessages(change, lastUpdate)
 		logger.WithField("failed", len(failed)).Debug("Failed jobs parsed from previous comments.")
+		// Work in progress changes only run jobs when explicitly asked to,
+		// unless the repo opts in. Marking the change ready for review
+		// triggers them.
+		autoTrigger := !change.WorkInProgress || c.config().Gerrit.OrgReposConfig.TriggerOnWorkInProgress(instance, change.Project)
 		filters := []pjutil.Filter{
-			messageFilter(messages, failed, all, triggerTimes, logger),
+			messageFilter(messages, failed, all, triggerTimes, autoTrigger, logger),
 		}
-		// Automatically trigger the Prow jobs if the revision is new and the
-		// change is not in WorkInProgress.
-		if revision.Created.Time.After(lastUpdate) && !change.WorkInProgress {
+		// Automatically trigger the Prow jobs if the revision is new.
+		if revision.Created.Time.After(lastUpdate) && autoTrigger {
 			filters = append(filters, &timeAnnotationFilter{
 				Filter:       pjutil.NewTestAllFilter(),
 				eventTime:    revision.Created.Time,
@@ -772,6 +914,7 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 				spec:        pjutil.PresubmitSpec(presubmit, refs),
 				labels:      presubmit.Labels,
 				annotations: presubmit.Annotations,
+				matcher:     presubmit.GerritChangeMatcher,
 			})
 		}
 	}
@@ -780,6 +923,14 @@ func (c *Controller) triggerJobs(logger logrus.FieldLogger, instance string, cha
 
 	for _, jSpec := range jobSpecs {
 		labels, annotations := LabelsAndAnnotations(instance, jSpec.labels, jSpec.annotations, change)
+		// Record which hashtags and topic triggered jobs restricted to them.
+		_, hashtags, topic := jSpec.matcher.Matches(change.Hashtags, change.Topic)
+		if len(hashtags) > 0 {
+			annotations[kube.GerritHashtags] = strings.Join(hashtags, ",")
+		}
+		if topic != "" {
+			annotations[kube.GerritTopic] = topic
+		}
 
 		pj := pjutil.NewProwJob(jSpec.spec, labels, annotations, pjutil.RequireScheduling(schedulerEnabled))
 
@@ -77,7 +77,7 @@ func (f *fgc) HasRelatedChanges(instance, id, revision string) (bool, error) {
 	return false, nil
 }
 
-func (f *fgc) ApplyGlobalConfig(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, lastSyncTracker *client.SyncTime, cookiefilePath, tokenPathOverride string, additionalFunc func()) {
+func (f *fgc) ApplyGlobalConfig(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, lastSyncTracker *client.ShardedSyncTime, cookiefilePath, tokenPathOverride string, additionalFunc func()) {
 
 }
 
@@ -1066,6 +1066,67 @@ func TestTriggerJobs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "work in progress change triggers jobs when the repo opts in",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "other-repo",
+				Status:          "NEW",
+				WorkInProgress:  true,
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Ref:     "refs/changes/00/1/1",
+						Created: stampNow,
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+			wantPjs: []*prowapi.ProwJob{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"prow.k8s.io/context":             "other-test",
+							"prow.k8s.io/refs.pull":           "0",
+							"created-by-prow":                 "true",
+							"prow.k8s.io/gerrit-revision":     "1",
+							"prow.k8s.io/type":                "presubmit",
+							"prow.k8s.io/refs.base_ref":       "",
+							"prow.k8s.io/gerrit-patchset":     "0",
+							"prow.k8s.io/gerrit-report-label": "Code-Review",
+							"prow.k8s.io/refs.repo":           "other-repo",
+							"prow.k8s.io/job":                 "other-test",
+							"prow.k8s.io/refs.org":            "gerrit",
+						},
+						Annotations: map[string]string{
+							"prow.k8s.io/job":             "other-test",
+							"prow.k8s.io/context":         "other-test",
+							"prow.k8s.io/gerrit-instance": "https://gerrit",
+							"prow.k8s.io/gerrit-id":       "",
+						},
+					},
+					Spec: prowapi.ProwJobSpec{
+						Refs: &prowapi.Refs{
+							Org:      "https://gerrit",
+							Repo:     "other-repo",
+							RepoLink: "https://gerrit/other-repo",
+							BaseSHA:  "abc",
+							BaseLink: "https://gerrit/other-repo/+/abc",
+							CloneURI: "https://gerrit/other-repo",
+							Pulls: []prowapi.Pull{
+								{
+									Ref:        "refs/changes/00/1/1",
+									SHA:        "1",
+									Link:       "https://gerrit/c/other-repo/+/0",
+									CommitLink: "https://gerrit/other-repo/+/1",
+									AuthorLink: "https://gerrit/q/",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "merged change should trigger postsubmit",
 			change: client.ChangeInfo{
@@ -1834,6 +1895,326 @@ func TestTriggerJobs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "new revision of a change with a hashtag triggers the jobs restricted to it",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "tagged-project",
+				Status:          "NEW",
+				Hashtags:        []string{"Perf", "other"},
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Created: stampNow,
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+			wantPjs: []*prowapi.ProwJob{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"prow.k8s.io/refs.pull":           "0",
+							"prow.k8s.io/gerrit-report-label": "Code-Review",
+							"prow.k8s.io/job":                 "always-runs",
+							"prow.k8s.io/refs.base_ref":       "",
+							"prow.k8s.io/gerrit-revision":     "1",
+							"created-by-prow":                 "true",
+							"prow.k8s.io/type":                "presubmit",
+							"prow.k8s.io/refs.org":            "gerrit",
+							"prow.k8s.io/gerrit-patchset":     "1",
+							"prow.k8s.io/context":             "always-runs",
+							"prow.k8s.io/refs.repo":           "tagged-project",
+						},
+						Annotations: map[string]string{
+							"prow.k8s.io/job":             "always-runs",
+							"prow.k8s.io/context":         "always-runs",
+							"prow.k8s.io/gerrit-instance": "https://gerrit",
+							"prow.k8s.io/gerrit-id":       "",
+						},
+					},
+					Spec: prowapi.ProwJobSpec{
+						Refs: &prowapi.Refs{
+							Org:      "https://gerrit",
+							Repo:     "tagged-project",
+							RepoLink: "https://gerrit/tagged-project",
+							BaseSHA:  "abc",
+							BaseLink: "https://gerrit/tagged-project/+/abc",
+							CloneURI: "https://gerrit/tagged-project",
+							Pulls: []prowapi.Pull{
+								{
+									SHA:        "1",
+									Link:       "https://gerrit/c/tagged-project/+/0",
+									CommitLink: "https://gerrit/tagged-project/+/1",
+									AuthorLink: "https://gerrit/q/",
+								},
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"prow.k8s.io/refs.pull":           "0",
+							"prow.k8s.io/gerrit-report-label": "Code-Review",
+							"prow.k8s.io/job":                 "runs-on-perf-hashtag",
+							"prow.k8s.io/refs.base_ref":       "",
+							"prow.k8s.io/gerrit-revision":     "1",
+							"created-by-prow":                 "true",
+							"prow.k8s.io/type":                "presubmit",
+							"prow.k8s.io/refs.org":            "gerrit",
+							"prow.k8s.io/gerrit-patchset":     "1",
+							"prow.k8s.io/context":             "runs-on-perf-hashtag",
+							"prow.k8s.io/refs.repo":           "tagged-project",
+						},
+						Annotations: map[string]string{
+							"prow.k8s.io/job":             "runs-on-perf-hashtag",
+							"prow.k8s.io/context":         "runs-on-perf-hashtag",
+							"prow.k8s.io/gerrit-instance": "https://gerrit",
+							"prow.k8s.io/gerrit-id":       "",
+							"prow.k8s.io/gerrit-hashtags": "Perf",
+						},
+					},
+					Spec: prowapi.ProwJobSpec{
+						Refs: &prowapi.Refs{
+							Org:      "https://gerrit",
+							Repo:     "tagged-project",
+							RepoLink: "https://gerrit/tagged-project",
+							BaseSHA:  "abc",
+							BaseLink: "https://gerrit/tagged-project/+/abc",
+							CloneURI: "https://gerrit/tagged-project",
+							Pulls: []prowapi.Pull{
+								{
+									SHA:        "1",
+									Link:       "https://gerrit/c/tagged-project/+/0",
+									CommitLink: "https://gerrit/tagged-project/+/1",
+									AuthorLink: "https://gerrit/q/",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "new revision of a change without hashtags skips the jobs restricted to them",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "tagged-project",
+				Status:          "NEW",
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Created: stampNow,
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+			wantPjs: []*prowapi.ProwJob{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"prow.k8s.io/refs.pull":           "0",
+							"prow.k8s.io/gerrit-report-label": "Code-Review",
+							"prow.k8s.io/job":                 "always-runs",
+							"prow.k8s.io/refs.base_ref":       "",
+							"prow.k8s.io/gerrit-revision":     "1",
+							"created-by-prow":                 "true",
+							"prow.k8s.io/type":                "presubmit",
+							"prow.k8s.io/refs.org":            "gerrit",
+							"prow.k8s.io/gerrit-patchset":     "1",
+							"prow.k8s.io/context":             "always-runs",
+							"prow.k8s.io/refs.repo":           "tagged-project",
+						},
+						Annotations: map[string]string{
+							"prow.k8s.io/job":             "always-runs",
+							"prow.k8s.io/context":         "always-runs",
+							"prow.k8s.io/gerrit-instance": "https://gerrit",
+							"prow.k8s.io/gerrit-id":       "",
+						},
+					},
+					Spec: prowapi.ProwJobSpec{
+						Refs: &prowapi.Refs{
+							Org:      "https://gerrit",
+							Repo:     "tagged-project",
+							RepoLink: "https://gerrit/tagged-project",
+							BaseSHA:  "abc",
+							BaseLink: "https://gerrit/tagged-project/+/abc",
+							CloneURI: "https://gerrit/tagged-project",
+							Pulls: []prowapi.Pull{
+								{
+									SHA:        "1",
+									Link:       "https://gerrit/c/tagged-project/+/0",
+									CommitLink: "https://gerrit/tagged-project/+/1",
+									AuthorLink: "https://gerrit/q/",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "adding a hashtag triggers the jobs restricted to it",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "tagged-project",
+				Status:          "NEW",
+				Hashtags:        []string{"Perf"},
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Created: makeStamp(timeNow.Add(-time.Hour)),
+					},
+				},
+				Messages: []gerrit.ChangeMessageInfo{
+					{
+						Message:        "Hashtag added: Perf",
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(time.Hour)),
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+			wantPjs: []*prowapi.ProwJob{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"prow.k8s.io/refs.pull":           "0",
+							"prow.k8s.io/gerrit-report-label": "Code-Review",
+							"prow.k8s.io/job":                 "runs-on-perf-hashtag",
+							"prow.k8s.io/refs.base_ref":       "",
+							"prow.k8s.io/gerrit-revision":     "1",
+							"created-by-prow":                 "true",
+							"prow.k8s.io/type":                "presubmit",
+							"prow.k8s.io/refs.org":            "gerrit",
+							"prow.k8s.io/gerrit-patchset":     "1",
+							"prow.k8s.io/context":             "runs-on-perf-hashtag",
+							"prow.k8s.io/refs.repo":           "tagged-project",
+						},
+						Annotations: map[string]string{
+							"prow.k8s.io/job":             "runs-on-perf-hashtag",
+							"prow.k8s.io/context":         "runs-on-perf-hashtag",
+							"prow.k8s.io/gerrit-instance": "https://gerrit",
+							"prow.k8s.io/gerrit-id":       "",
+							"prow.k8s.io/gerrit-hashtags": "Perf",
+						},
+					},
+					Spec: prowapi.ProwJobSpec{
+						Refs: &prowapi.Refs{
+							Org:      "https://gerrit",
+							Repo:     "tagged-project",
+							RepoLink: "https://gerrit/tagged-project",
+							BaseSHA:  "abc",
+							BaseLink: "https://gerrit/tagged-project/+/abc",
+							CloneURI: "https://gerrit/tagged-project",
+							Pulls: []prowapi.Pull{
+								{
+									SHA:        "1",
+									Link:       "https://gerrit/c/tagged-project/+/0",
+									CommitLink: "https://gerrit/tagged-project/+/1",
+									AuthorLink: "https://gerrit/q/",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "setting the topic triggers the jobs restricted to it",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "tagged-project",
+				Status:          "NEW",
+				Topic:           "release",
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Created: makeStamp(timeNow.Add(-time.Hour)),
+					},
+				},
+				Messages: []gerrit.ChangeMessageInfo{
+					{
+						Message:        "Topic set to release",
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(time.Hour)),
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+			wantPjs: []*prowapi.ProwJob{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"prow.k8s.io/refs.pull":           "0",
+							"prow.k8s.io/gerrit-report-label": "Code-Review",
+							"prow.k8s.io/job":                 "runs-on-release-topic",
+							"prow.k8s.io/refs.base_ref":       "",
+							"prow.k8s.io/gerrit-revision":     "1",
+							"created-by-prow":                 "true",
+							"prow.k8s.io/type":                "presubmit",
+							"prow.k8s.io/refs.org":            "gerrit",
+							"prow.k8s.io/gerrit-patchset":     "1",
+							"prow.k8s.io/context":             "runs-on-release-topic",
+							"prow.k8s.io/refs.repo":           "tagged-project",
+						},
+						Annotations: map[string]string{
+							"prow.k8s.io/job":             "runs-on-release-topic",
+							"prow.k8s.io/context":         "runs-on-release-topic",
+							"prow.k8s.io/gerrit-instance": "https://gerrit",
+							"prow.k8s.io/gerrit-id":       "",
+							"prow.k8s.io/gerrit-topic":    "release",
+						},
+					},
+					Spec: prowapi.ProwJobSpec{
+						Refs: &prowapi.Refs{
+							Org:      "https://gerrit",
+							Repo:     "tagged-project",
+							RepoLink: "https://gerrit/tagged-project",
+							BaseSHA:  "abc",
+							BaseLink: "https://gerrit/tagged-project/+/abc",
+							CloneURI: "https://gerrit/tagged-project",
+							Pulls: []prowapi.Pull{
+								{
+									SHA:        "1",
+									Link:       "https://gerrit/c/tagged-project/+/0",
+									CommitLink: "https://gerrit/tagged-project/+/1",
+									AuthorLink: "https://gerrit/q/",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "explicitly requested job restricted to a hashtag doesn't run without it",
+			change: client.ChangeInfo{
+				CurrentRevision: "1",
+				Project:         "tagged-project",
+				Status:          "NEW",
+				Revisions: map[string]client.RevisionInfo{
+					"1": {
+						Number:  1,
+						Created: makeStamp(timeNow.Add(-time.Hour)),
+					},
+				},
+				Messages: []gerrit.ChangeMessageInfo{
+					{
+						Message:        "/test runs-on-perf-hashtag",
+						RevisionNumber: 1,
+						Date:           makeStamp(timeNow.Add(time.Hour)),
+					},
+				},
+			},
+			instancesMap: map[string]*gerrit.AccountInfo{testInstance: {AccountID: 42}},
+			instance:     testInstance,
+		},
 		{
 			name: "unrelated comment shouldn't trigger anything",
 			change: client.ChangeInfo{
@@ -2801,6 +3182,7 @@ func TestTriggerJobs(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Labels: map[string]string{
 							"prow.k8s.io/job":                 "always-runs-inRepoConfig",
+							"prow.k8s.io/tenant-id":           "GlobalDefaultID",
 							"prow.k8s.io/refs.org":            "gerrit",
 							"prow.k8s.io/gerrit-report-label": "Code-Review",
 							"prow.k8s.io/gerrit-revision":     "1",
@@ -2912,6 +3294,7 @@ func TestTriggerJobs(t *testing.T) {
 							"prow.k8s.io/refs.base_ref":       "inRepoConfig",
 							"prow.k8s.io/gerrit-revision":     "1",
 							"prow.k8s.io/job":                 "always-runs-inRepoConfig-Post",
+							"prow.k8s.io/tenant-id":           "GlobalDefaultID",
 							"prow.k8s.io/refs.repo":           "postsubmits-project",
 							"prow.k8s.io/refs.pull":           "0",
 							"prow.k8s.io/gerrit-report-label": "Code-Review",
@@ -2970,6 +3353,7 @@ func TestTriggerJobs(t *testing.T) {
 							"prow.k8s.io/refs.pull":           "0",
 							"prow.k8s.io/gerrit-patchset":     "0",
 							"prow.k8s.io/job":                 "always-runs-inRepoConfig",
+							"prow.k8s.io/tenant-id":           "GlobalDefaultID",
 							"prow.k8s.io/refs.repo":           "test-infra",
 							"prow.k8s.io/context":             "always-runs-inRepoConfig",
 							"prow.k8s.io/refs.org":            "gerrit",
@@ -3149,6 +3533,50 @@ func TestTriggerJobs(t *testing.T) {
 		t.Fatalf("could not set regexes: %v", err)
 	}
 
+	taggedProjectPresubmits := []config.Presubmit{
+		{
+			JobBase: config.JobBase{
+				Name: "always-runs",
+			},
+			AlwaysRun: true,
+			Reporter: config.Reporter{
+				Context:    "always-runs",
+				SkipReport: true,
+			},
+		},
+		{
+			JobBase: config.JobBase{
+				Name: "runs-on-perf-hashtag",
+			},
+			AlwaysRun:    true,
+			Trigger:      `(?m)^/test runs-on-perf-hashtag`,
+			RerunCommand: "/test runs-on-perf-hashtag",
+			GerritChangeMatcher: config.GerritChangeMatcher{
+				GerritHashtags: []string{"perf"},
+			},
+			Reporter: config.Reporter{
+				Context:    "runs-on-perf-hashtag",
+				SkipReport: true,
+			},
+		},
+		{
+			JobBase: config.JobBase{
+				Name: "runs-on-release-topic",
+			},
+			AlwaysRun: true,
+			GerritChangeMatcher: config.GerritChangeMatcher{
+				GerritTopics: []string{"release"},
+			},
+			Reporter: config.Reporter{
+				Context:    "runs-on-release-topic",
+				SkipReport: true,
+			},
+		},
+	}
+	if err := config.SetPresubmitRegexes(taggedProjectPresubmits); err != nil {
+		t.Fatalf("could not set regexes: %v", err)
+	}
+
 	cfg := &config.Config{
 		JobConfig: config.JobConfig{
 			ProwYAMLGetterWithDefaults: fakeProwYAMLGetter,
@@ -3167,6 +3595,7 @@ func TestTriggerJobs(t *testing.T) {
 						},
 					},
 				},
+				"https://gerrit/tagged-project": taggedProjectPresubmits,
 				"https://gerrit/other-repo": {
 					{
 						JobBase: config.JobBase{
@@ -3200,6 +3629,15 @@ func TestTriggerJobs(t *testing.T) {
 				Enabled:         map[string]*bool{"*": &trueBool},
 				AllowedClusters: map[string][]string{"*": {kube.DefaultClusterAlias}},
 			},
+			Gerrit: config.Gerrit{
+				OrgReposConfig: &config.GerritOrgRepoConfigs{
+					{
+						Org:                     "https://gerrit",
+						Repos:                   []string{"other-repo"},
+						TriggerOnWorkInProgress: true,
+					},
+				},
+			},
 		},
 	}
 	fca := &fca{
@@ -3225,7 +3663,7 @@ func TestTriggerJobs(t *testing.T) {
 				config:                      fca.Config,
 				prowJobClient:               fakeProwJobClient.ProwV1().ProwJobs("prowjobs"),
 				gc:                          &gc,
-				tracker:                     &fakeSync{val: fakeLastSync},
+				trackerForHost:              func(string) LastSyncTracker { return &fakeSync{val: fakeLastSync} },
 				inRepoConfigGetter:          cache,
 				inRepoConfigFailuresTracker: make(map[string]bool),
 			}
@@ -3340,6 +3778,52 @@ func TestIsProjectExemptFromHelp(t *testing.T) {
 	}
 }
 
+func TestWakeProject(t *testing.T) {
+	wakeup := make(chan struct{}, 1)
+	c := &Controller{
+		shards: map[string]*hostShard{
+			"https://gerrit": {
+				instance: "https://gerrit",
+				workers:  map[string]chan struct{}{"foo": wakeup},
+			},
+		},
+	}
+
+	if c.wakeProject("https://gerrit", "bar") {
+		t.Error("Expected a project without worker not to be woken up.")
+	}
+	// Wakeups are coalesced while the worker is busy.
+	for i := 0; i < 3; i++ {
+		if !c.wakeProject("https://gerrit", "foo") {
+			t.Fatal("Expected the project worker to be woken up.")
+		}
+	}
+	if len(wakeup) != 1 {
+		t.Errorf("Expected a single pending wakeup, got %d.", len(wakeup))
+	}
+}
+
+func TestHostShardAcquire(t *testing.T) {
+	shard := newHostShard("https://gerrit", &fakeSync{}, 1)
+	release := shard.acquire()
+
+	acquired := make(chan func())
+	go func() { acquired <- shard.acquire() }()
+	select {
+	case <-acquired:
+		t.Fatal("Expected the second sync to wait for a free slot.")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case release := <-acquired:
+		release()
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the second sync to start.")
+	}
+}
+
 func TestDeckLinkForPR(t *testing.T) {
 	tcs := []struct {
 		name         string
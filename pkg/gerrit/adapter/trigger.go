@@ -17,6 +17,7 @@ limitations under the License.
 package adapter
 
 import (
+	"regexp"
 	"strings"
 	"time"
 
@@ -60,10 +61,44 @@ func indicatesChangeFromDraftToActiveState(s string) bool {
 		strings.HasSuffix(s, client.ReadyForReviewMessageCustomizable)
 }
 
+// taggingRe matches the messages Gerrit adds when hashtags are added to a
+// change or its topic is set.
+var taggingRe = regexp.MustCompile(`(?m)^(Hashtags? added: |Topic (set to|changed from) )`)
+
+func indicatesTagging(s string) bool {
+	return taggingRe.MatchString(s)
+}
+
+// tagFilter triggers the jobs that run automatically on changes with certain
+// hashtags or topics. Whether the change has them is checked by the caller.
+type tagFilter struct{}
+
+func (tf *tagFilter) ShouldRun(p config.Presubmit) (bool, bool, bool) {
+	return p.GerritChangeMatcher.CouldRun() && !p.NeedsExplicitTrigger(), false, false
+}
+
+func (tf *tagFilter) Name() string {
+	return "gerrit-tag-filter"
+}
+
+// presubmitsMatchingTags returns the presubmits that may run on a change with
+// the hashtags and topic.
+func presubmitsMatchingTags(presubmits []config.Presubmit, hashtags []string, topic string) []config.Presubmit {
+	var matching []config.Presubmit
+	for _, presubmit := range presubmits {
+		if matches, _, _ := presubmit.GerritChangeMatcher.Matches(hashtags, topic); matches {
+			matching = append(matching, presubmit)
+		}
+	}
+	return matching
+}
+
 // messageFilter returns filter that matches all /test all, /test foo, /retest comments since lastUpdate.
 //
 // The behavior of each message matches the behavior of pjutil.PresubmitFilter.
-func messageFilter(messages []gerrit.ChangeMessageInfo, failingContexts, allContexts sets.Set[string], triggerTimes map[string]time.Time, logger logrus.FieldLogger) pjutil.Filter {
+// Events that trigger jobs automatically, like the change becoming ready for
+// review or getting tagged, are ignored unless autoTrigger is set.
+func messageFilter(messages []gerrit.ChangeMessageInfo, failingContexts, allContexts sets.Set[string], triggerTimes map[string]time.Time, autoTrigger bool, logger logrus.FieldLogger) pjutil.Filter {
 	var filters []pjutil.Filter
 	contextGetter := func() (sets.Set[string], sets.Set[string], error) {
 		return failingContexts, allContexts, nil
@@ -80,6 +115,9 @@ func messageFilter(messages []gerrit.ChangeMessageInfo, failingContexts, allCont
 			eventTime:    message.Date.Time,
 			triggerTimes: triggerTimes,
 		})
+		if !autoTrigger {
+			continue
+		}
 		// If the Gerrit Change changed from draft to active state, trigger all
 		// presubmit Prow jobs.
 		if indicatesChangeFromDraftToActiveState(message.Message) {
@@ -90,6 +128,15 @@ func messageFilter(messages []gerrit.ChangeMessageInfo, failingContexts, allCont
 			})
 			continue
 		}
+		// If hashtags were added to the change or its topic was set, trigger
+		// the jobs that are restricted to them.
+		if indicatesTagging(message.Message) {
+			filters = append(filters, &timeAnnotationFilter{
+				Filter:       &tagFilter{},
+				eventTime:    message.Date.Time,
+				triggerTimes: triggerTimes,
+			})
+		}
 	}
 
 	return pjutil.NewAggregateFilter(filters)
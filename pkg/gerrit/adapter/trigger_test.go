@@ -204,7 +204,9 @@ func TestMessageFilter(t *testing.T) {
 		messages []gerrit.ChangeMessageInfo
 		failed   sets.Set[string]
 		all      sets.Set[string]
-		checks   []check
+		// workInProgress is whether the change is currently work in progress.
+		workInProgress bool
+		checks         []check
 	}{
 		{
 			name: "basically works",
@@ -305,6 +307,35 @@ func TestMessageFilter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "draft->active is ignored when the change is back to work in progress",
+			messages:       []gerrit.ChangeMessageInfo{msg(client.ReadyForReviewMessageFixed, old)},
+			all:            sets.New[string]("foo"),
+			workInProgress: true,
+			checks: []check{
+				{
+					job:             job("foo", nil),
+					shouldRun:       false,
+					forcedToRun:     false,
+					defaultBehavior: false,
+				},
+			},
+		},
+		{
+			name:           "/test foo works on work in progress changes",
+			messages:       []gerrit.ChangeMessageInfo{msg("/test foo", old)},
+			all:            sets.New[string]("foo"),
+			workInProgress: true,
+			checks: []check{
+				{
+					job:             job("foo", nil),
+					shouldRun:       true,
+					forcedToRun:     true,
+					defaultBehavior: true,
+					triggered:       old,
+				},
+			},
+		},
 		{
 			name: "draft->active by clicking `SEND AND START REVIEW` triggers multiple",
 			messages: []gerrit.ChangeMessageInfo{msg(`Patch Set 1:
@@ -336,7 +367,7 @@ func TestMessageFilter(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			logger := logrus.WithField("case", tc.name)
 			triggerTimes := map[string]time.Time{}
-			filt := messageFilter(tc.messages, tc.failed, tc.all, triggerTimes, logger)
+			filt := messageFilter(tc.messages, tc.failed, tc.all, triggerTimes, !tc.workInProgress, logger)
 			for _, check := range tc.checks {
 				t.Run(check.job.Name, func(t *testing.T) {
 					fixed := []config.Presubmit{check.job}
@@ -62,7 +62,9 @@ const (
 )
 
 var clientMetrics = struct {
-	queryResults *prometheus.CounterVec
+	queryResults     *prometheus.CounterVec
+	streamEvents     *prometheus.CounterVec
+	streamReconnects *prometheus.CounterVec
 }{
 	queryResults: prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "gerrit_query_results",
@@ -72,10 +74,25 @@ var clientMetrics = struct {
 		"repo",
 		"result",
 	}),
+	streamEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gerrit_stream_events",
+		Help: "Count of events received from Gerrit's event stream by instance and event type.",
+	}, []string{
+		"org",
+		"type",
+	}),
+	streamReconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gerrit_stream_reconnects",
+		Help: "Count of reconnections to Gerrit's event stream by instance.",
+	}, []string{
+		"org",
+	}),
 }
 
 func init() {
 	prometheus.MustRegister(clientMetrics.queryResults)
+	prometheus.MustRegister(clientMetrics.streamEvents)
+	prometheus.MustRegister(clientMetrics.streamReconnects)
 }
 
 type gerritAuthentication interface {
@@ -194,7 +211,7 @@ func NewClient(instances map[string]map[string]*config.GerritQueryFilter, maxQPS
 	return c, nil
 }
 
-func (c *Client) ApplyGlobalConfig(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, lastSyncTracker *SyncTime, cookiefilePath, tokenPathOverride string, additionalFunc func()) {
+func (c *Client) ApplyGlobalConfig(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, lastSyncTracker *ShardedSyncTime, cookiefilePath, tokenPathOverride string, additionalFunc func()) {
 	c.applyGlobalConfigOnce(orgRepoConfigGetter, lastSyncTracker, cookiefilePath, tokenPathOverride, additionalFunc)
 
 	go func() {
@@ -206,7 +223,7 @@ func (c *Client) ApplyGlobalConfig(orgRepoConfigGetter func() *config.GerritOrgR
 	}()
 }
 
-func (c *Client) applyGlobalConfigOnce(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, lastSyncTracker *SyncTime, cookiefilePath, tokenPathOverride string, additionalFunc func()) {
+func (c *Client) applyGlobalConfigOnce(orgRepoConfigGetter func() *config.GerritOrgRepoConfigs, lastSyncTracker *ShardedSyncTime, cookiefilePath, tokenPathOverride string, additionalFunc func()) {
 	orgReposConfig := orgRepoConfigGetter()
 	if orgReposConfig == nil {
 		return
@@ -102,7 +102,7 @@ func TestApplyGlobalConfigOnce(t *testing.T) {
 	tests := []struct {
 		name                string
 		orgRepoConfigGetter func() *config.GerritOrgRepoConfigs
-		lastSyncTracker     *SyncTime
+		lastSyncTracker     *ShardedSyncTime
 		additionalFunc      func()
 		expect              func(t *testing.T)
 	}{
@@ -111,7 +111,7 @@ func TestApplyGlobalConfigOnce(t *testing.T) {
 			orgRepoConfigGetter: func() *config.GerritOrgRepoConfigs {
 				return cfg.Gerrit.OrgReposConfig
 			},
-			lastSyncTracker: NewSyncTime(path, opener, context.Background()),
+			lastSyncTracker: NewShardedSyncTime(path, opener, context.Background()),
 			additionalFunc: func() {
 				setRecond("base", "base")
 			},
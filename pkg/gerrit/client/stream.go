@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// DefaultSSHPort is the port Gerrit's SSH daemon listens on by default.
+	DefaultSSHPort = 29418
+
+	streamEventsCommand = "gerrit stream-events"
+	sshKeepAlivePeriod  = 30 * time.Second
+	// maxStreamEventSize bounds a single event line, events carrying large
+	// comments can be well over the default bufio.Scanner limit.
+	maxStreamEventSize = 4 * 1024 * 1024
+)
+
+// StreamEvent is a single event emitted by Gerrit's stream-events command.
+// Only the fields needed to decide which project to sync are decoded, see
+// https://gerrit-review.googlesource.com/Documentation/cmd-stream-events.html.
+type StreamEvent struct {
+	Type           string             `json:"type"`
+	EventCreatedOn int64              `json:"eventCreatedOn"`
+	Change         *StreamEventChange `json:"change,omitempty"`
+}
+
+// StreamEventChange is the change attribute of a stream event.
+type StreamEventChange struct {
+	Project string `json:"project"`
+	Branch  string `json:"branch"`
+	Number  int    `json:"number"`
+}
+
+// StreamDialer opens a new stream of newline delimited Gerrit events.
+type StreamDialer func(ctx context.Context) (io.ReadCloser, error)
+
+// EventStreamer consumes the event stream of a single Gerrit instance and
+// reconnects with exponential backoff whenever the stream breaks.
+type EventStreamer struct {
+	Instance string
+	Dial     StreamDialer
+	// OnConnect is called every time the stream is (re-)established, before
+	// any event is delivered, so that events missed while disconnected can
+	// be replayed by querying the instance.
+	OnConnect func()
+	// OnEvent is called for every event read from the stream.
+	OnEvent func(StreamEvent)
+
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Run streams events until the context is cancelled.
+func (s *EventStreamer) Run(ctx context.Context) {
+	log := logrus.WithField("host", s.Instance)
+	backoff := s.MinBackoff
+	for {
+		connected := time.Now()
+		err := s.stream(ctx, log)
+		if ctx.Err() != nil {
+			return
+		}
+		// Only reset the backoff once a connection proved to be stable, an
+		// instance that drops us right after the handshake must not be hammered.
+		if time.Since(connected) > s.MaxBackoff {
+			backoff = s.MinBackoff
+		}
+		log.WithError(err).WithField("backoff", backoff.String()).Warn("Gerrit event stream disconnected, reconnecting.")
+		clientMetrics.streamReconnects.WithLabelValues(s.Instance).Inc()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > s.MaxBackoff {
+			backoff = s.MaxBackoff
+		}
+	}
+}
+
+func (s *EventStreamer) stream(ctx context.Context, log *logrus.Entry) error {
+	rc, err := s.Dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// Unblock the scanner below when asked to stop.
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		rc.Close()
+	}()
+
+	log.Info("Connected to Gerrit event stream.")
+	if s.OnConnect != nil {
+		s.OnConnect()
+	}
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamEventSize)
+	for scanner.Scan() {
+		var event StreamEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			log.WithError(err).Warn("Failed to decode Gerrit stream event.")
+			continue
+		}
+		clientMetrics.streamEvents.WithLabelValues(s.Instance, event.Type).Inc()
+		s.OnEvent(event)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+// NewSSHClientConfig builds the client configuration used to connect to
+// Gerrit's SSH daemon. Host keys are verified against the known_hosts file at
+// knownHostsPath, which is required.
+func NewSSHClientConfig(user, privateKeyPath, knownHostsPath string) (*ssh.ClientConfig, error) {
+	key, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH private key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH private key: %w", err)
+	}
+	if knownHostsPath == "" {
+		return nil, errors.New("a known_hosts file is required to verify SSH host keys")
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SSH known hosts: %w", err)
+	}
+	return &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
+	}, nil
+}
+
+// SSHStreamDialer returns a StreamDialer that runs `gerrit stream-events`
+// over SSH against addr (host:port).
+func SSHStreamDialer(addr string, config *ssh.ClientConfig) StreamDialer {
+	return func(ctx context.Context) (io.ReadCloser, error) {
+		dialer := net.Dialer{Timeout: config.Timeout}
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		client := ssh.NewClient(c, chans, reqs)
+		session, err := client.NewSession()
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+		stdout, err := session.StdoutPipe()
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+		if err := session.Start(streamEventsCommand); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to run %q: %w", streamEventsCommand, err)
+		}
+		stream := &sshStream{Reader: stdout, client: client, done: make(chan struct{})}
+		go stream.keepAlive()
+		return stream, nil
+	}
+}
+
+// sshStream is the stdout of a stream-events session, closing it tears down
+// the whole connection.
+type sshStream struct {
+	io.Reader
+	client *ssh.Client
+	done   chan struct{}
+}
+
+// keepAlive detects half-open connections, which would otherwise leave the
+// reader blocked forever on a quiet instance.
+func (s *sshStream) keepAlive() {
+	ticker := time.NewTicker(sshKeepAlivePeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if _, _, err := s.client.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+				s.client.Close()
+				return
+			}
+		}
+	}
+}
+
+func (s *sshStream) Close() error {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+	return s.client.Close()
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// blockingReader returns no data until closed.
+type blockingReader struct {
+	closed chan struct{}
+}
+
+func (r *blockingReader) Read([]byte) (int, error) {
+	<-r.closed
+	return 0, io.EOF
+}
+
+func (r *blockingReader) Close() error {
+	close(r.closed)
+	return nil
+}
+
+func TestEventStreamer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lastStream := &blockingReader{closed: make(chan struct{})}
+	dials := []func() (io.ReadCloser, error){
+		func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(`{"type":"patchset-created","change":{"project":"foo","branch":"main","number":1}}
+not json
+{"type":"ref-updated"}
+`)), nil
+		},
+		func() (io.ReadCloser, error) {
+			return nil, errors.New("connection refused")
+		},
+		func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(`{"type":"hashtags-changed","change":{"project":"bar","branch":"main","number":2}}
+`)), nil
+		},
+		func() (io.ReadCloser, error) {
+			// Everything has been delivered, stop once the stream is up.
+			defer cancel()
+			return lastStream, nil
+		},
+	}
+
+	var connects int
+	var events []StreamEvent
+	streamer := &EventStreamer{
+		Instance: "https://gerrit",
+		Dial: func(context.Context) (io.ReadCloser, error) {
+			if len(dials) == 0 {
+				t.Fatal("Unexpected dial after the stream was cancelled.")
+			}
+			dial := dials[0]
+			dials = dials[1:]
+			return dial()
+		},
+		OnConnect: func() { connects++ },
+		OnEvent:   func(event StreamEvent) { events = append(events, event) },
+
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 2 * time.Millisecond,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		streamer.Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the streamer to stop.")
+	}
+
+	if connects != 3 {
+		t.Errorf("Expected 3 connections, got %d.", connects)
+	}
+	expected := []StreamEvent{
+		{Type: "patchset-created", Change: &StreamEventChange{Project: "foo", Branch: "main", Number: 1}},
+		{Type: "ref-updated"},
+		{Type: "hashtags-changed", Change: &StreamEventChange{Project: "bar", Branch: "main", Number: 2}},
+	}
+	if diff := cmp.Diff(expected, events); diff != "" {
+		t.Errorf("Unexpected events (-want +got):\n%s", diff)
+	}
+	select {
+	case <-lastStream.closed:
+	default:
+		t.Error("Expected the stream to be closed on cancellation.")
+	}
+}
@@ -22,10 +22,12 @@ import (
 	"encoding/json"
 	"fmt"
 	stdio "io"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/io"
 )
@@ -42,6 +44,11 @@ type SyncTime struct {
 	path   string
 	opener opener
 	ctx    context.Context
+
+	// host restricts the tracked state to a single host when set.
+	host string
+	// legacyPath is read when nothing was persisted at path yet.
+	legacyPath string
 }
 
 func NewSyncTime(path string, opener opener, ctx context.Context) *SyncTime {
@@ -98,9 +105,17 @@ func (st *SyncTime) update(hostProjects map[string]map[string]*config.GerritQuer
 }
 
 func (st *SyncTime) currentState() (LastSyncState, error) {
-	r, err := st.opener.Reader(st.ctx, st.path)
+	state, err := st.readState(st.path)
+	if err != nil || state != nil || st.legacyPath == "" {
+		return state, err
+	}
+	return st.readState(st.legacyPath)
+}
+
+func (st *SyncTime) readState(path string) (LastSyncState, error) {
+	r, err := st.opener.Reader(st.ctx, path)
 	if io.IsNotExist(err) {
-		logrus.Warnf("lastSyncFallback not found at %q", st.path)
+		logrus.Warnf("lastSyncFallback not found at %q", path)
 		return nil, nil
 	} else if err != nil {
 		return nil, fmt.Errorf("open: %w", err)
@@ -116,6 +131,12 @@ func (st *SyncTime) currentState() (LastSyncState, error) {
 		logrus.WithField("lastSync", st.val).Warnln("Failed to unmarshal lastSyncFallback, resetting all last update times to current.")
 		return nil, nil
 	}
+	if st.host != "" {
+		if _, ok := state[st.host]; !ok {
+			return nil, nil
+		}
+		state = LastSyncState{st.host: state[st.host]}
+	}
 	return state, nil
 }
 
@@ -169,3 +190,66 @@ func (st *SyncTime) Update(newState LastSyncState) error {
 	st.val = targetState
 	return nil
 }
+
+// ShardedSyncTime tracks the last sync times of every Gerrit host in a
+// separate SyncTime, each persisted to its own file next to path, so that
+// hosts checkpoint independently and a slow write for one host never blocks
+// the others. A host without a checkpoint of its own starts from its entry in
+// the state shared by all hosts at path, if any.
+type ShardedSyncTime struct {
+	path   string
+	opener opener
+	ctx    context.Context
+
+	lock   sync.Mutex
+	shards map[string]*SyncTime
+}
+
+func NewShardedSyncTime(path string, opener opener, ctx context.Context) *ShardedSyncTime {
+	return &ShardedSyncTime{
+		path:   path,
+		opener: opener,
+		ctx:    ctx,
+		shards: map[string]*SyncTime{},
+	}
+}
+
+// ShardPath returns where the last sync times of a host are persisted.
+func ShardPath(path, host string) string {
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+	return path + "." + strings.ReplaceAll(strings.Trim(host, "/"), "/", "_")
+}
+
+// Shard returns the tracker of a host, creating it on first use.
+func (s *ShardedSyncTime) Shard(host string) *SyncTime {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if st, ok := s.shards[host]; ok {
+		return st
+	}
+	st := NewSyncTime(ShardPath(s.path, host), s.opener, s.ctx)
+	st.host = host
+	st.legacyPath = s.path
+	s.shards[host] = st
+	return st
+}
+
+func (s *ShardedSyncTime) Init(hostProjects map[string]map[string]*config.GerritQueryFilter) error {
+	var errs []error
+	for host, projects := range hostProjects {
+		if err := s.Shard(host).Init(map[string]map[string]*config.GerritQueryFilter{host: projects}); err != nil {
+			errs = append(errs, fmt.Errorf("host %s: %w", host, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (s *ShardedSyncTime) update(hostProjects map[string]map[string]*config.GerritQueryFilter) error {
+	var errs []error
+	for host, projects := range hostProjects {
+		if err := s.Shard(host).update(map[string]map[string]*config.GerritQueryFilter{host: projects}); err != nil {
+			errs = append(errs, fmt.Errorf("host %s: %w", host, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
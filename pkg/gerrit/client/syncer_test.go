@@ -242,3 +242,77 @@ func TestNewProjectAddition(t *testing.T) {
 		t.Error("expected tracker to initialize a new entry for qwe/qux, but did not")
 	}
 }
+
+func TestShardedSyncTime(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "value.txt")
+	ctx := context.Background()
+	open, err := io.NewOpener(ctx, "", "")
+	if err != nil {
+		t.Fatalf("Failed to create opener: %v", err)
+	}
+
+	// State previously shared by all hosts.
+	legacy := time.Now().Add(-time.Hour).Truncate(time.Second)
+	buf, err := json.Marshal(LastSyncState{
+		"https://foo": {"foo-project": legacy},
+		"https://bar": {"bar-project": legacy},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal state: %v", err)
+	}
+	if err := os.WriteFile(path, buf, 0644); err != nil {
+		t.Fatalf("Failed to write state: %v", err)
+	}
+
+	hostProjects := map[string]map[string]*config.GerritQueryFilter{
+		"https://foo": {"foo-project": nil},
+		"https://bar": {"bar-project": nil},
+	}
+	st := NewShardedSyncTime(path, open, ctx)
+	if err := st.Init(hostProjects); err != nil {
+		t.Fatalf("Failed init: %v", err)
+	}
+	for host, project := range map[string]string{"https://foo": "foo-project", "https://bar": "bar-project"} {
+		got := st.Shard(host).Current()
+		if diff := cmp.Diff(LastSyncState{host: {project: legacy}}, got); diff != "" {
+			t.Errorf("Shard %s wasn't initialized from the shared state (-want +got):\n%s", host, diff)
+		}
+	}
+
+	later := legacy.Add(time.Hour)
+	if err := st.Shard("https://foo").Update(LastSyncState{"https://foo": {"foo-project": later}}); err != nil {
+		t.Fatalf("Failed update: %v", err)
+	}
+	if _, err := os.Stat(ShardPath(path, "https://foo")); err != nil {
+		t.Errorf("Expected the shard to be persisted on its own: %v", err)
+	}
+	if _, err := os.Stat(ShardPath(path, "https://bar")); !os.IsNotExist(err) {
+		t.Errorf("Expected the untouched shard not to be persisted, got: %v", err)
+	}
+
+	// A restart picks up the shard's own checkpoint over the shared state.
+	st = NewShardedSyncTime(path, open, ctx)
+	if err := st.Init(hostProjects); err != nil {
+		t.Fatalf("Failed init: %v", err)
+	}
+	if got := st.Shard("https://foo").Current()["https://foo"]["foo-project"]; !got.Equal(later) {
+		t.Errorf("Expected %v from the shard checkpoint, got %v", later, got)
+	}
+	if got := st.Shard("https://bar").Current()["https://bar"]["bar-project"]; !got.Equal(legacy) {
+		t.Errorf("Expected %v from the shared state, got %v", legacy, got)
+	}
+}
+
+func TestShardPath(t *testing.T) {
+	for host, expected := range map[string]string{
+		"https://foo-review.example.com": "gs://bucket/last-sync.foo-review.example.com",
+		"http://foo-review.example.com/": "gs://bucket/last-sync.foo-review.example.com",
+		"https://example.com/gerrit":     "gs://bucket/last-sync.example.com_gerrit",
+		"plain-host.example.com":         "gs://bucket/last-sync.plain-host.example.com",
+	} {
+		if got := ShardPath("gs://bucket/last-sync", host); got != expected {
+			t.Errorf("ShardPath(%q) = %q, expected %q", host, got, expected)
+		}
+	}
+}
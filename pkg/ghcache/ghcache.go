@@ -43,8 +43,6 @@ import (
 
 	"github.com/cjwagner/httpcache"
 	"github.com/cjwagner/httpcache/diskcache"
-	rediscache "github.com/cjwagner/httpcache/redis"
-	"github.com/gomodule/redigo/redis"
 	"github.com/peterbourgon/diskv"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
@@ -525,18 +523,21 @@ func NewFromCache(roundTripper http.RoundTripper, cache CachePartitionCreator, m
 }
 
 // NewRedisCache creates a GitHub cache RoundTripper that is backed by a Redis
-// cache.
-// Important note: The redis implementation does not support partitioning the cache
-// which means that requests to the same path from different tokens will invalidate
-// each other.
-func NewRedisCache(roundTripper http.RoundTripper, redisAddress string, maxConcurrency int, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
-	conn, err := redis.Dial("tcp", redisAddress)
+// cache, which several ghproxy replicas can share.
+// It supports a partitioned cache. Entries expire ttl after they were last
+// written, unless ttl is zero.
+func NewRedisCache(roundTripper http.RoundTripper, redisAddress string, ttl time.Duration, maxConcurrency int, throttlingTimes RequestThrottlingTimes) http.RoundTripper {
+	pool := newRedisPool(redisAddress, maxConcurrency)
+	conn := pool.Get()
+	_, err := conn.Do("PING")
+	conn.Close()
 	if err != nil {
 		logrus.WithError(err).Fatal("Error connecting to Redis")
 	}
-	redisCache := rediscache.NewWithClient(conn)
 	return NewFromCache(roundTripper,
-		func(_ string, _ *time.Time) httpcache.Cache { return redisCache },
+		func(partitionKey string, _ *time.Time) httpcache.Cache {
+			return &redisCache{pool: pool, prefix: "ghcache:" + partitionKey + ":", ttl: ttl}
+		},
 		maxConcurrency,
 		throttlingTimes)
 }
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghcache
+
+import (
+	"time"
+
+	"github.com/cjwagner/httpcache"
+	"github.com/gomodule/redigo/redis"
+	"github.com/sirupsen/logrus"
+)
+
+// redisCache is a httpcache.Cache that stores the entries of a cache
+// partition in redis. Several ghproxy replicas can share it.
+type redisCache struct {
+	pool *redis.Pool
+	// prefix separates the partitions from each other and from other data
+	// in redis.
+	prefix string
+	// ttl is the lifetime of an entry after it was last written. Entries
+	// don't expire if it is zero.
+	ttl time.Duration
+}
+
+var _ httpcache.Cache = &redisCache{}
+
+func (c *redisCache) key(key string) string {
+	return c.prefix + key
+}
+
+// Get returns the response corresponding to key if present.
+func (c *redisCache) Get(key string) ([]byte, bool) {
+	conn := c.pool.Get()
+	defer conn.Close()
+	item, err := redis.Bytes(conn.Do("GET", c.key(key)))
+	if err != nil {
+		if err != redis.ErrNil {
+			logrus.WithError(err).Warn("Failed to read from redis cache.")
+		}
+		return nil, false
+	}
+	return item, true
+}
+
+// Set saves a response to the cache as key.
+func (c *redisCache) Set(key string, resp []byte) {
+	conn := c.pool.Get()
+	defer conn.Close()
+	args := []interface{}{c.key(key), resp}
+	if c.ttl > 0 {
+		args = append(args, "PX", c.ttl.Milliseconds())
+	}
+	if _, err := conn.Do("SET", args...); err != nil {
+		logrus.WithError(err).Warn("Failed to write to redis cache.")
+	}
+}
+
+// Delete removes the response with key from the cache.
+func (c *redisCache) Delete(key string) {
+	conn := c.pool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("DEL", c.key(key)); err != nil {
+		logrus.WithError(err).Warn("Failed to delete from redis cache.")
+	}
+}
+
+func newRedisPool(redisAddress string, maxConcurrency int) *redis.Pool {
+	return &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", redisAddress)
+		},
+		TestOnBorrow: func(conn redis.Conn, lastUsed time.Time) error {
+			if time.Since(lastUsed) < time.Minute {
+				return nil
+			}
+			_, err := conn.Do("PING")
+			return err
+		},
+		// Every in-flight request uses at most one connection at a time.
+		MaxIdle:     maxConcurrency,
+		IdleTimeout: 5 * time.Minute,
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghcache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// fakeRedis implements the redis commands used by redisCache. All
+// connections share its data like the connections to a real server.
+type fakeRedis struct {
+	lock sync.Mutex
+	data map[string][]byte
+	ttls map[string]int64
+}
+
+type fakeRedisConn struct {
+	*fakeRedis
+}
+
+func (c fakeRedisConn) Close() error                      { return nil }
+func (c fakeRedisConn) Err() error                        { return nil }
+func (c fakeRedisConn) Send(string, ...interface{}) error { return nil }
+func (c fakeRedisConn) Flush() error                      { return nil }
+func (c fakeRedisConn) Receive() (interface{}, error)     { return nil, nil }
+func (c fakeRedisConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	if cmd == "" {
+		// The pool flushes connections when they are returned.
+		return nil, nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key, _ := args[0].(string)
+	switch cmd {
+	case "GET":
+		value, ok := c.data[key]
+		if !ok {
+			return nil, nil
+		}
+		return value, nil
+	case "SET":
+		c.data[key] = args[1].([]byte)
+		delete(c.ttls, key)
+		if len(args) == 4 && args[2] == "PX" {
+			c.ttls[key] = args[3].(int64)
+		}
+		return "OK", nil
+	case "DEL":
+		delete(c.data, key)
+		return int64(1), nil
+	}
+	return nil, fmt.Errorf("unexpected command %s", cmd)
+}
+
+func TestRedisCache(t *testing.T) {
+	server := &fakeRedis{data: map[string][]byte{}, ttls: map[string]int64{}}
+	pool := &redis.Pool{Dial: func() (redis.Conn, error) { return fakeRedisConn{server}, nil }}
+	first := &redisCache{pool: pool, prefix: "ghcache:first:", ttl: time.Minute}
+	second := &redisCache{pool: pool, prefix: "ghcache:second:"}
+
+	first.Set("/repos/org/repo", []byte("response"))
+	if value, ok := first.Get("/repos/org/repo"); !ok || string(value) != "response" {
+		t.Errorf("expected the stored response, got %q, %t", value, ok)
+	}
+	if _, ok := second.Get("/repos/org/repo"); ok {
+		t.Error("expected partitions to be separate")
+	}
+	if ttl := server.ttls["ghcache:first:/repos/org/repo"]; ttl != time.Minute.Milliseconds() {
+		t.Errorf("expected a ttl of one minute, got %dms", ttl)
+	}
+
+	second.Set("/repos/org/repo", []byte("other"))
+	if _, hasTTL := server.ttls["ghcache:second:/repos/org/repo"]; hasTTL {
+		t.Error("expected no ttl without configured ttl")
+	}
+	first.Delete("/repos/org/repo")
+	if _, ok := first.Get("/repos/org/repo"); ok {
+		t.Error("expected deleted entry to be gone")
+	}
+	if value, ok := second.Get("/repos/org/repo"); !ok || string(value) != "other" {
+		t.Errorf("expected the other partition to be unaffected, got %q, %t", value, ok)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghcache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+var warmingRequestsCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "ghcache_warming_requests",
+		Help: "How many requests were sent to keep hot cache entries from expiring, by cache mode.",
+	},
+	[]string{"mode"},
+)
+
+func init() {
+	prometheus.MustRegister(warmingRequestsCounter)
+}
+
+type hotRequest struct {
+	req  *http.Request
+	hits int
+}
+
+// Warmer is a http.RoundTripper that tracks which GET requests are made
+// most often and periodically repeats them, so that their cache entries are
+// revalidated before they expire. Revalidating an unchanged resource doesn't
+// cost any API tokens, whereas a request for an expired entry does.
+type Warmer struct {
+	delegate http.RoundTripper
+	// keys is the number of requests that are repeated per interval.
+	keys int
+
+	lock sync.Mutex
+	hot  map[string]*hotRequest
+}
+
+// NewWarmer returns a Warmer that sends requests to the cache delegate.
+func NewWarmer(delegate http.RoundTripper, keys int) *Warmer {
+	return &Warmer{delegate: delegate, keys: keys, hot: map[string]*hotRequest{}}
+}
+
+func warmingKey(req *http.Request) string {
+	return getCachePartition(req) + " " + req.Header.Get("Accept") + " " + req.URL.String()
+}
+
+func (w *Warmer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet {
+		key := warmingKey(req)
+		w.lock.Lock()
+		if hot, ok := w.hot[key]; ok {
+			hot.hits++
+		} else {
+			w.hot[key] = &hotRequest{req: req.Clone(context.Background()), hits: 1}
+		}
+		w.lock.Unlock()
+	}
+	return w.delegate.RoundTrip(req)
+}
+
+// hottest returns the most often made requests since the last call.
+func (w *Warmer) hottest() []*http.Request {
+	w.lock.Lock()
+	hot := w.hot
+	w.hot = map[string]*hotRequest{}
+	w.lock.Unlock()
+
+	var candidates []*hotRequest
+	for _, candidate := range hot {
+		if expiresAt := getExpiry(candidate.req); expiresAt != nil && expiresAt.Before(time.Now()) {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].hits != candidates[j].hits {
+			return candidates[i].hits > candidates[j].hits
+		}
+		return candidates[i].req.URL.String() < candidates[j].req.URL.String()
+	})
+	if len(candidates) > w.keys {
+		candidates = candidates[:w.keys]
+	}
+	var requests []*http.Request
+	for _, candidate := range candidates {
+		requests = append(requests, candidate.req)
+	}
+	return requests
+}
+
+// Warm repeats the requests that were made most often since the last
+// round. It must be called more often than cache entries expire.
+func (w *Warmer) Warm() {
+	for _, req := range w.hottest() {
+		resp, err := w.delegate.RoundTrip(req.Clone(context.Background()))
+		if err != nil {
+			warmingRequestsCounter.WithLabelValues(string(ModeError)).Inc()
+			logrus.WithError(err).WithField("path", req.URL.Path).Debug("Failed to warm cache entry.")
+			continue
+		}
+		// The cache is only updated once the body is read.
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		warmingRequestsCounter.WithLabelValues(resp.Header.Get(CacheModeHeader)).Inc()
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ghcache
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type recordingRoundTripper struct {
+	lock     sync.Mutex
+	requests []string
+}
+
+func (rt *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	rt.requests = append(rt.requests, req.Method+" "+req.URL.Path+" "+req.Header.Get("Authorization"))
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{CacheModeHeader: []string{string(ModeRevalidated)}}, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func TestWarmer(t *testing.T) {
+	delegate := &recordingRoundTripper{}
+	warmer := NewWarmer(delegate, 2)
+	request := func(method, path, token string, expiresAt *time.Time) {
+		req, err := http.NewRequest(method, "https://api.github.com"+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", token)
+		if expiresAt != nil {
+			req.Header.Set(TokenExpiryAtHeader, expiresAt.Format(time.RFC3339))
+		}
+		if _, err := warmer.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expired := time.Now().Add(-time.Minute)
+	for i := 0; i < 3; i++ {
+		request(http.MethodGet, "/a", "token", nil)
+		request(http.MethodPost, "/post", "token", nil)
+		request(http.MethodGet, "/expired", "old-token", &expired)
+	}
+	request(http.MethodGet, "/b", "token", nil)
+	request(http.MethodGet, "/c", "token", nil)
+	request(http.MethodGet, "/c", "other-token", nil)
+	request(http.MethodGet, "/c", "other-token", nil)
+
+	delegate.requests = nil
+	warmer.Warm()
+	expected := []string{"GET /a token", "GET /c other-token"}
+	if diff := cmp.Diff(expected, delegate.requests); diff != "" {
+		t.Errorf("unexpected warming requests (-want +got):\n%s", diff)
+	}
+
+	delegate.requests = nil
+	warmer.Warm()
+	if len(delegate.requests) != 0 {
+		t.Errorf("expected no warming requests without new requests, got %v", delegate.requests)
+	}
+}
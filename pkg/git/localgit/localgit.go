@@ -98,6 +98,10 @@ func (lg *LocalGit) MakeFakeRepo(org, repo string) error {
 	if err := runCmd(lg.Git, rdir, "config", "commit.gpgsign", "false"); err != nil {
 		return err
 	}
+	// Allows partial clones of the repo.
+	if err := runCmd(lg.Git, rdir, "config", "uploadpack.allowFilter", "true"); err != nil {
+		return err
+	}
 	if err := lg.AddCommit(org, repo, map[string][]byte{"initial": {}}); err != nil {
 		return err
 	}
@@ -166,6 +170,22 @@ func (lg *LocalGit) Rebase(org, repo, commitlike string) (string, error) {
 
 // NewV2 creates a LocalGit and a v2 client factory pointing at it.
 func NewV2() (*LocalGit, v2.ClientFactory, error) {
+	return newV2()
+}
+
+// NewV2WithWorktrees is like NewV2, but the clients are worktrees of a bare
+// clone.
+func NewV2WithWorktrees() (*LocalGit, v2.ClientFactory, error) {
+	useWorktrees := true
+	return newV2(func(o *v2.ClientFactoryOpts) { o.UseWorktrees = &useWorktrees })
+}
+
+// NewV2Blobless is like NewV2, but the clients are blobless partial clones.
+func NewV2Blobless() (*LocalGit, v2.ClientFactory, error) {
+	return newV2(func(o *v2.ClientFactoryOpts) { o.CloneFilter = v2.CloneFilterBlobless })
+}
+
+func newV2(opts ...v2.ClientFactoryOpt) (*LocalGit, v2.ClientFactory, error) {
 	g, err := exec.LookPath("git")
 	if err != nil {
 		return nil, nil, err
@@ -176,7 +196,8 @@ func NewV2() (*LocalGit, v2.ClientFactory, error) {
 	}
 	c, err := v2.NewLocalClientFactory(t,
 		func() (name, email string, err error) { return "robot", "robot@beep.boop", nil },
-		func(content []byte) []byte { return content })
+		func(content []byte) []byte { return content },
+		opts...)
 	if err != nil {
 		return nil, nil, err
 	}
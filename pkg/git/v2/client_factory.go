@@ -99,9 +99,24 @@ type repoClient struct {
 	interactor
 }
 
+const (
+	// CloneFilterBlobless clones all commits and trees, but only downloads
+	// the blobs that are checked out.
+	CloneFilterBlobless = "blob:none"
+	// CloneFilterTreeless clones all commits, but only downloads the trees
+	// and blobs that are checked out. History is cheap to walk, but
+	// commands that diff old commits download their trees on demand.
+	CloneFilterTreeless = "tree:0"
+)
+
 type ClientFactoryOpts struct {
 	// Host, defaults to "github.com" if unset
 	Host string
+	// OrgHosts maps orgs to the hosts serving them if they differ from Host,
+	// e.g. orgs on a GitHub Enterprise instance. The Token getter has to
+	// return tokens for the host of the org. Publishing to forks is only
+	// supported for repos on Host.
+	OrgHosts map[string]string
 	// Whether to use HTTP. By default, HTTPS is used (overrides UseSSH).
 	//
 	// TODO (listx): Combine HTTPS, HTTP, and SSH schemes into a single enum.
@@ -125,6 +140,18 @@ type ClientFactoryOpts struct {
 	CookieFilePath string
 	// If set, cacheDir persist. Otherwise temp dir will be used for CacheDir
 	Persist *bool
+	// If set, all commits and tags are signed with this key
+	SigningKey SigningKeyGetter
+	// The format of the SigningKey, defaults to SigningFormatOpenPGP
+	SigningFormat SigningFormat
+	// If set, clients are worktrees of one bare clone per repo instead of
+	// clones of a mirror. Worktrees are faster to create and share all
+	// objects, but also the local branches, so consumers must use unique
+	// branch names.
+	UseWorktrees *bool
+	// If set, repos are partial clones that only download the objects that
+	// are excluded by this filter when they are needed, e.g. CloneFilterBlobless.
+	CloneFilter string
 }
 
 // These options are scoped to the repo, not the ClientFactory level. The reason
@@ -159,6 +186,9 @@ func (cfo *ClientFactoryOpts) Apply(target *ClientFactoryOpts) {
 	if cfo.Host != "" {
 		target.Host = cfo.Host
 	}
+	if cfo.OrgHosts != nil {
+		target.OrgHosts = cfo.OrgHosts
+	}
 	if cfo.UseInsecureHTTP != nil {
 		target.UseInsecureHTTP = cfo.UseInsecureHTTP
 	}
@@ -186,6 +216,18 @@ func (cfo *ClientFactoryOpts) Apply(target *ClientFactoryOpts) {
 	if cfo.Persist != nil {
 		target.Persist = cfo.Persist
 	}
+	if cfo.SigningKey != nil {
+		target.SigningKey = cfo.SigningKey
+	}
+	if cfo.SigningFormat != "" {
+		target.SigningFormat = cfo.SigningFormat
+	}
+	if cfo.UseWorktrees != nil {
+		target.UseWorktrees = cfo.UseWorktrees
+	}
+	if cfo.CloneFilter != "" {
+		target.CloneFilter = cfo.CloneFilter
+	}
 }
 
 func defaultTempDir() *string {
@@ -241,10 +283,19 @@ func NewClientFactory(opts ...ClientFactoryOpt) (ClientFactory, error) {
 		return nil, err
 	}
 
+	var signer *signer
+	if o.SigningKey != nil {
+		// The key lives next to the cache so that it is removed with it.
+		if signer, err = newSigner(o.SigningFormat, o.SigningKey, path.Join(cacheDir, ".signing")); err != nil {
+			return nil, err
+		}
+	}
+
 	var remote RemoteResolverFactory
 	if o.UseSSH != nil && *o.UseSSH {
 		remote = &sshRemoteResolverFactory{
 			host:     o.Host,
+			orgHosts: o.OrgHosts,
 			username: o.Username,
 		}
 	} else if o.CookieFilePath != "" {
@@ -252,6 +303,7 @@ func NewClientFactory(opts ...ClientFactoryOpt) (ClientFactory, error) {
 	} else {
 		remote = &httpResolverFactory{
 			host:     o.Host,
+			orgHosts: o.OrgHosts,
 			http:     o.UseInsecureHTTP != nil && *o.UseInsecureHTTP,
 			username: o.Username,
 			token:    o.Token,
@@ -267,24 +319,34 @@ func NewClientFactory(opts ...ClientFactoryOpt) (ClientFactory, error) {
 		repoLocks:      map[string]*sync.Mutex{},
 		logger:         logrus.WithField("client", "git"),
 		cookieFilePath: o.CookieFilePath,
+		signer:         signer,
+		useWorktrees:   o.UseWorktrees != nil && *o.UseWorktrees,
+		cloneFilter:    o.CloneFilter,
 	}, nil
 }
 
 // NewLocalClientFactory allows for the creation of repository clients
-// based on a local filepath remote for testing
-func NewLocalClientFactory(baseDir string, gitUser GitUserGetter, censor Censor) (ClientFactory, error) {
+// based on a local filepath remote for testing. Of the opts, only
+// UseWorktrees and CloneFilter are honored.
+func NewLocalClientFactory(baseDir string, gitUser GitUserGetter, censor Censor, opts ...ClientFactoryOpt) (ClientFactory, error) {
+	o := ClientFactoryOpts{}
+	for _, opt := range opts {
+		opt(&o)
+	}
 	cacheDir, err := os.MkdirTemp("", "gitcache")
 	if err != nil {
 		return nil, err
 	}
 	return &clientFactory{
-		cacheDir:   cacheDir,
-		remote:     &pathResolverFactory{baseDir: baseDir},
-		gitUser:    gitUser,
-		censor:     censor,
-		masterLock: &sync.Mutex{},
-		repoLocks:  map[string]*sync.Mutex{},
-		logger:     logrus.WithField("client", "git"),
+		cacheDir:     cacheDir,
+		remote:       &pathResolverFactory{baseDir: baseDir, fileURLs: o.CloneFilter != ""},
+		gitUser:      gitUser,
+		censor:       censor,
+		masterLock:   &sync.Mutex{},
+		repoLocks:    map[string]*sync.Mutex{},
+		logger:       logrus.WithField("client", "git"),
+		useWorktrees: o.UseWorktrees != nil && *o.UseWorktrees,
+		cloneFilter:  o.CloneFilter,
 	}, nil
 }
 
@@ -294,6 +356,12 @@ type clientFactory struct {
 	censor         Censor
 	logger         *logrus.Entry
 	cookieFilePath string
+	// signer is set if commits and tags are signed
+	signer *signer
+	// useWorktrees is set if clients are worktrees of a bare clone
+	useWorktrees bool
+	// cloneFilter is the --filter of partial clones
+	cloneFilter string
 
 	// cacheDir is the root under which cached clones of repos are created
 	cacheDir string
@@ -320,6 +388,9 @@ func (c *clientFactory) bootstrapClients(org, repo, dir string) (cacher, cloner,
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	if c.signer != nil {
+		executor = &signingExecutor{executor: executor, signer: c.signer}
+	}
 	client := &repoClient{
 		publisher: publisher{
 			remotes: remotes{
@@ -331,10 +402,12 @@ func (c *clientFactory) bootstrapClients(org, repo, dir string) (cacher, cloner,
 			logger:   logger,
 		},
 		interactor: interactor{
-			dir:      dir,
-			remote:   c.remote.CentralRemote(org, repo),
-			executor: executor,
-			logger:   logger,
+			dir:       dir,
+			remote:    c.remote.CentralRemote(org, repo),
+			executor:  executor,
+			logger:    logger,
+			worktrees: c.useWorktrees,
+			filter:    c.cloneFilter,
 		},
 	}
 	return client, client, client, nil
@@ -370,7 +443,7 @@ func (c *clientFactory) ClientForWithRepoOpts(org, repo string, repoOpts RepoOpt
 		return nil, fmt.Errorf("programmer error: cannot share objects between primary and secondary without targeted fetches (NeededCommits)")
 	}
 
-	cacheDir := path.Join(c.cacheDir, org, repo)
+	cacheDir := c.primaryDir(org, repo)
 	c.logger.WithFields(logrus.Fields{"org": org, "repo": repo, "dir": cacheDir}).Debug("Creating a client from the cache.")
 	cacheClientCacher, _, _, err := c.bootstrapClients(org, repo, cacheDir)
 	if err != nil {
@@ -399,7 +472,17 @@ func (c *clientFactory) ClientForWithRepoOpts(org, repo string, repoOpts RepoOpt
 	// Initialize the new derivative repo (secondary clone) from the primary
 	// clone. This is a local clone operation.
 	timeBeforeSecondaryClone := time.Now()
-	if err = repoClientCloner.CloneWithRepoOpts(cacheDir, repoOpts); err != nil {
+	if c.useWorktrees {
+		// Adding a worktree writes to the primary, which must not happen while
+		// it is pruned by an update.
+		repoLock := c.repoLock(cacheDir)
+		repoLock.Lock()
+		err = cacheClientCacher.AddWorktree(repoDir, repoOpts)
+		repoLock.Unlock()
+	} else {
+		err = repoClientCloner.CloneWithRepoOpts(cacheDir, repoOpts)
+	}
+	if err != nil {
 		return nil, err
 	}
 	gitMetrics.secondaryCloneDuration.WithLabelValues(org, repo).Observe(time.Since(timeBeforeSecondaryClone).Seconds())
@@ -452,19 +535,9 @@ func (c *clientFactory) ensureFreshPrimary(
 // operations in this function are protected by a lock so that only one thread
 // can run at a given time for the same cacheDir (primary clone path).
 func (c *clientFactory) maybeCloneAndUpdatePrimary(cacheDir string, cacheClientCacher cacher, repoOpts RepoOpts) error {
-	// Protect access to the shared repoLocks map. The main point of all this
-	// locking is to ensure that we only try to create the primary clone (if it
-	// doesn't exist) in a serial manner.
-	var repoLock *sync.Mutex
-	c.masterLock.Lock()
-	if _, exists := c.repoLocks[cacheDir]; exists {
-		repoLock = c.repoLocks[cacheDir]
-	} else {
-		repoLock = &sync.Mutex{}
-		c.repoLocks[cacheDir] = repoLock
-	}
-	c.masterLock.Unlock()
-
+	// The main point of all this locking is to ensure that we only try to
+	// create the primary clone (if it doesn't exist) in a serial manner.
+	repoLock := c.repoLock(cacheDir)
 	repoLock.Lock()
 	defer repoLock.Unlock()
 	if _, err := os.Stat(path.Join(cacheDir, "HEAD")); os.IsNotExist(err) {
@@ -472,7 +545,11 @@ func (c *clientFactory) maybeCloneAndUpdatePrimary(cacheDir string, cacheClientC
 		if err := os.MkdirAll(cacheDir, os.ModePerm); err != nil && !os.IsExist(err) {
 			return err
 		}
-		if err := cacheClientCacher.MirrorClone(); err != nil {
+		clone := cacheClientCacher.MirrorClone
+		if c.useWorktrees {
+			clone = cacheClientCacher.BareClone
+		}
+		if err := clone(); err != nil {
 			return err
 		}
 	} else if err != nil {
@@ -496,6 +573,26 @@ func (c *clientFactory) maybeCloneAndUpdatePrimary(cacheDir string, cacheClientC
 	return nil
 }
 
+// primaryDir is the directory of the primary clone of a repo. Bare clones
+// for worktrees are kept apart from mirrors, as their refs differ.
+func (c *clientFactory) primaryDir(org, repo string) string {
+	if c.useWorktrees {
+		return path.Join(c.cacheDir, ".worktrees", org, repo)
+	}
+	return path.Join(c.cacheDir, org, repo)
+}
+
+// repoLock returns the lock that guards the primary clone in cacheDir.
+func (c *clientFactory) repoLock(cacheDir string) *sync.Mutex {
+	// Protect access to the shared repoLocks map.
+	c.masterLock.Lock()
+	defer c.masterLock.Unlock()
+	if _, exists := c.repoLocks[cacheDir]; !exists {
+		c.repoLocks[cacheDir] = &sync.Mutex{}
+	}
+	return c.repoLocks[cacheDir]
+}
+
 // Clean removes the caches used to generate clients
 func (c *clientFactory) Clean() error {
 	return os.RemoveAll(c.cacheDir)
@@ -82,6 +82,11 @@ type Interactor interface {
 type cacher interface {
 	// MirrorClone sets up a mirror of the source repository.
 	MirrorClone() error
+	// BareClone sets up a bare clone of the source repository that worktrees
+	// can be added to.
+	BareClone() error
+	// AddWorktree adds a worktree of the bare clone at dir.
+	AddWorktree(dir string, repoOpts RepoOpts) error
 	// RemoteUpdate fetches all updates from the remote.
 	RemoteUpdate() error
 	// FetchCommits fetches only the given commits.
@@ -108,6 +113,16 @@ type interactor struct {
 	remote   RemoteResolver
 	dir      string
 	logger   *logrus.Entry
+	// worktrees is set if the repo is a bare clone or one of its worktrees.
+	// The branches of the remote are then only available as origin/<branch>,
+	// local branches are shared by all worktrees.
+	worktrees bool
+	// branches are the local branches created in a worktree. They are
+	// deleted when it is cleaned up, as they would outlive it otherwise.
+	branches []string
+	// filter is the --filter of partial clones, e.g. blob:none. Missing
+	// objects are fetched from the remote when they are needed.
+	filter string
 }
 
 // Directory exposes the directory in which this repository has been cloned
@@ -115,8 +130,19 @@ func (i *interactor) Directory() string {
 	return i.dir
 }
 
-// Clean cleans up the repository from the on-disk cache
+// Clean cleans up the repository from the on-disk cache. The bare clone
+// forgets about removed worktrees when the next one is added.
 func (i *interactor) Clean() error {
+	if len(i.branches) > 0 {
+		// The current branch cannot be deleted.
+		if out, err := i.executor.Run("checkout", "--detach"); err != nil {
+			i.logger.WithError(err).Warnf("Failed to detach HEAD: %v", string(out))
+		}
+		if out, err := i.executor.Run(append([]string{"branch", "-D"}, i.branches...)...); err != nil {
+			i.logger.WithError(err).Warnf("Failed to delete branches %v: %v", i.branches, string(out))
+		}
+		i.branches = nil
+	}
 	return os.RemoveAll(i.dir)
 }
 
@@ -164,22 +190,31 @@ func (i *interactor) CloneWithRepoOpts(from string, repoOpts RepoOpts) error {
 		cloneArgs = append(cloneArgs, "--sparse")
 	}
 
+	// The primary of a partial clone can't serve the objects it is missing,
+	// so the clone is only checked out once it fetches them from the remote.
+	if i.filter != "" {
+		cloneArgs = append(cloneArgs, "--no-checkout")
+	}
+
 	cloneArgs = append(cloneArgs, from, i.dir)
 
 	if out, err := i.executor.Run(cloneArgs...); err != nil {
 		return fmt.Errorf("error creating a clone: %w %v", err, string(out))
 	}
 
+	if i.filter != "" {
+		if err := i.configurePromisor(); err != nil {
+			return err
+		}
+	}
+
 	// For sparse checkouts, we have to do some additional housekeeping after
 	// the clone is completed. We use Git's global "-C <directory>" flag to
 	// switch to that directory before running the "sparse-checkout" command,
 	// because otherwise the command will fail (because it will try to run the
 	// command in the $PWD, which is not the same as the just-created clone
 	// directory (i.dir)).
-	if repoOpts.SparseCheckoutDirs != nil {
-		if len(repoOpts.SparseCheckoutDirs) == 0 {
-			return nil
-		}
+	if len(repoOpts.SparseCheckoutDirs) > 0 {
 		sparseCheckoutArgs := []string{"-C", i.dir, "sparse-checkout", "set"}
 		sparseCheckoutArgs = append(sparseCheckoutArgs, repoOpts.SparseCheckoutDirs...)
 
@@ -189,6 +224,36 @@ func (i *interactor) CloneWithRepoOpts(from string, repoOpts RepoOpts) error {
 		}
 		gitMetrics.sparseCheckoutDuration.Observe(time.Since(timeBeforeSparseCheckout).Seconds())
 	}
+
+	// Checking out after the sparse checkout is set up only fetches the
+	// objects that are needed.
+	if i.filter != "" {
+		if out, err := i.executor.Run("-C", i.dir, "reset", "--hard"); err != nil {
+			return fmt.Errorf("error checking out the partial clone: %w %v", err, string(out))
+		}
+	}
+	return nil
+}
+
+// configurePromisor makes the clone fetch the objects it is missing from the
+// remote.
+func (i *interactor) configurePromisor() error {
+	remote, err := i.remote()
+	if err != nil {
+		return fmt.Errorf("could not resolve remote for missing objects: %w", err)
+	}
+	for _, config := range [][]string{
+		// Extensions are only honored in version 1 repositories.
+		{"core.repositoryformatversion", "1"},
+		{"remote.promisor.url", remote},
+		{"remote.promisor.promisor", "true"},
+		{"remote.promisor.partialclonefilter", i.filter},
+		{"extensions.partialClone", "promisor"},
+	} {
+		if out, err := i.executor.Run(append([]string{"-C", i.dir, "config"}, config...)...); err != nil {
+			return fmt.Errorf("error configuring the partial clone: %w %v", err, string(out))
+		}
+	}
 	return nil
 }
 
@@ -199,16 +264,90 @@ func (i *interactor) MirrorClone() error {
 	if err != nil {
 		return fmt.Errorf("could not resolve remote for cloning: %w", err)
 	}
-	if out, err := i.executor.Run("clone", "--mirror", remote, i.dir); err != nil {
+	args := []string{"clone", "--mirror"}
+	if i.filter != "" {
+		args = append(args, "--filter="+i.filter)
+	}
+	if out, err := i.executor.Run(append(args, remote, i.dir)...); err != nil {
 		return fmt.Errorf("error creating a mirror clone: %w %v", err, string(out))
 	}
 	return nil
 }
 
-// Checkout runs git checkout.
+// BareClone sets up a bare clone of the source repository. Unlike a mirror,
+// it keeps the branches of the remote as origin/<branch>, so that branches
+// created in its worktrees are not pruned by updates.
+func (i *interactor) BareClone() error {
+	i.logger.Infof("Creating a bare clone of the repo at %s", i.dir)
+	remote, err := i.remote()
+	if err != nil {
+		return fmt.Errorf("could not resolve remote for cloning: %w", err)
+	}
+	commands := [][]string{{"init", "--bare", i.dir}, {"remote", "add", "origin", remote}}
+	if i.filter != "" {
+		commands = append(commands,
+			[]string{"config", "core.repositoryformatversion", "1"},
+			[]string{"config", "remote.origin.promisor", "true"},
+			[]string{"config", "remote.origin.partialclonefilter", i.filter},
+			[]string{"config", "extensions.partialClone", "origin"},
+		)
+	}
+	for _, args := range commands {
+		if out, err := i.executor.Run(args...); err != nil {
+			return fmt.Errorf("error creating a bare clone: %w %v", err, string(out))
+		}
+	}
+	if err := i.RemoteUpdate(); err != nil {
+		return err
+	}
+	if out, err := i.executor.Run("remote", "set-head", "origin", "--auto"); err != nil {
+		return fmt.Errorf("error setting the default branch: %w %v", err, string(out))
+	}
+	return nil
+}
+
+// AddWorktree adds a worktree at dir with a detached HEAD at the default
+// branch. Worktrees share the objects and local branches of the bare clone.
+func (i *interactor) AddWorktree(dir string, repoOpts RepoOpts) error {
+	i.logger.Infof("Adding a worktree of the repo at %s to %s", i.dir, dir)
+	// Clean up the worktrees whose directories were removed.
+	if out, err := i.executor.Run("worktree", "prune"); err != nil {
+		return fmt.Errorf("error pruning worktrees: %w %v", err, string(out))
+	}
+	args := []string{"worktree", "add", "--detach"}
+	if repoOpts.SparseCheckoutDirs != nil {
+		args = append(args, "--no-checkout")
+	}
+	if out, err := i.executor.Run(append(args, dir, "origin/HEAD")...); err != nil {
+		return fmt.Errorf("error adding a worktree: %w %v", err, string(out))
+	}
+	if repoOpts.SparseCheckoutDirs == nil {
+		return nil
+	}
+	timeBeforeSparseCheckout := time.Now()
+	sparseCheckoutArgs := append([]string{"-C", dir, "sparse-checkout", "set"}, repoOpts.SparseCheckoutDirs...)
+	if out, err := i.executor.Run(sparseCheckoutArgs...); err != nil {
+		return fmt.Errorf("error setting it to a sparse checkout: %w %v", err, string(out))
+	}
+	if out, err := i.executor.Run("-C", dir, "checkout", "--detach"); err != nil {
+		return fmt.Errorf("error populating the sparse checkout: %w %v", err, string(out))
+	}
+	gitMetrics.sparseCheckoutDuration.Observe(time.Since(timeBeforeSparseCheckout).Seconds())
+	return nil
+}
+
+// Checkout runs git checkout. In a worktree, branches of the remote are
+// checked out as detached HEAD, as a branch can only be checked out in one
+// worktree at a time.
 func (i *interactor) Checkout(commitlike string) error {
 	i.logger.Infof("Checking out %q", commitlike)
-	if out, err := i.executor.Run("checkout", commitlike); err != nil {
+	args := []string{"checkout", commitlike}
+	if i.worktrees {
+		if _, err := i.executor.Run("rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+commitlike); err == nil {
+			args = []string{"checkout", "--detach", "origin/" + commitlike}
+		}
+	}
+	if out, err := i.executor.Run(args...); err != nil {
 		return fmt.Errorf("error checking out %q: %w %v", commitlike, err, string(out))
 	}
 	return nil
@@ -279,12 +418,21 @@ func (i *interactor) ObjectExists(sha string) (bool, error) {
 	return true, nil
 }
 
-// CheckoutNewBranch creates a new branch and checks it out.
+// CheckoutNewBranch creates a new branch and checks it out. In a worktree,
+// a branch that another worktree left behind is reset instead, and the
+// branch is deleted again by Clean.
 func (i *interactor) CheckoutNewBranch(branch string) error {
 	i.logger.Infof("Checking out new branch %q", branch)
-	if out, err := i.executor.Run("checkout", "-b", branch); err != nil {
+	flag := "-b"
+	if i.worktrees {
+		flag = "-B"
+	}
+	if out, err := i.executor.Run("checkout", flag, branch); err != nil {
 		return fmt.Errorf("error checking out new branch %q: %w %v", branch, err, string(out))
 	}
+	if i.worktrees {
+		i.branches = append(i.branches, branch)
+	}
 	return nil
 }
 
@@ -436,6 +584,9 @@ func (i *interactor) Am(path string) error {
 // missing.
 func (i *interactor) FetchCommits(commitSHAs []string) error {
 	fetchArgs := []string{"--no-write-fetch-head", "--no-tags"}
+	if i.filter != "" {
+		fetchArgs = append(fetchArgs, "--filter="+i.filter)
+	}
 
 	// For each commit SHA, check if it already exists. If so, don't bother
 	// fetching it.
@@ -465,6 +616,9 @@ func (i *interactor) FetchCommits(commitSHAs []string) error {
 // RetargetBranch moves the given branch to an already-existing commit.
 func (i *interactor) RetargetBranch(branch, sha string) error {
 	args := []string{"branch", "-f", branch, sha}
+	if i.worktrees {
+		args = []string{"update-ref", "refs/remotes/origin/" + branch, sha}
+	}
 	if out, err := i.executor.Run(args...); err != nil {
 		return fmt.Errorf("error retargeting branch: %w %v", err, string(out))
 	}
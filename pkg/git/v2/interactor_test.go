@@ -19,6 +19,7 @@ package git
 import (
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"testing"
 
@@ -100,6 +101,7 @@ func TestInteractor_CloneWithRepoOpts(t *testing.T) {
 		from          string
 		remote        RemoteResolver
 		repoOpts      RepoOpts
+		filter        string
 		responses     map[string]execResponse
 		expectedCalls [][]string
 		expectedErr   bool
@@ -177,6 +179,113 @@ func TestInteractor_CloneWithRepoOpts(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			name: "partial clone",
+			dir:  "/secondaryclone",
+			from: "/mirrorclone",
+			remote: func() (string, error) {
+				return "someone.com", nil
+			},
+			filter: "blob:none",
+			responses: map[string]execResponse{
+				"clone --no-checkout /mirrorclone /secondaryclone": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config core.repositoryformatversion 1": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config remote.promisor.url someone.com": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config remote.promisor.promisor true": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config remote.promisor.partialclonefilter blob:none": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config extensions.partialClone promisor": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone reset --hard": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"clone", "--no-checkout", "/mirrorclone", "/secondaryclone"},
+				{"-C", "/secondaryclone", "config", "core.repositoryformatversion", "1"},
+				{"-C", "/secondaryclone", "config", "remote.promisor.url", "someone.com"},
+				{"-C", "/secondaryclone", "config", "remote.promisor.promisor", "true"},
+				{"-C", "/secondaryclone", "config", "remote.promisor.partialclonefilter", "blob:none"},
+				{"-C", "/secondaryclone", "config", "extensions.partialClone", "promisor"},
+				{"-C", "/secondaryclone", "reset", "--hard"},
+			},
+		},
+		{
+			name: "partial clone with sparse checkout checks out after setting up the sparse checkout",
+			dir:  "/secondaryclone",
+			from: "/mirrorclone",
+			remote: func() (string, error) {
+				return "someone.com", nil
+			},
+			repoOpts: RepoOpts{
+				SparseCheckoutDirs: []string{"a"},
+			},
+			filter: "blob:none",
+			responses: map[string]execResponse{
+				"clone --sparse --no-checkout /mirrorclone /secondaryclone": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config core.repositoryformatversion 1": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config remote.promisor.url someone.com": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config remote.promisor.promisor true": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config remote.promisor.partialclonefilter blob:none": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone config extensions.partialClone promisor": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone sparse-checkout set a": {
+					out: []byte(`ok`),
+				},
+				"-C /secondaryclone reset --hard": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"clone", "--sparse", "--no-checkout", "/mirrorclone", "/secondaryclone"},
+				{"-C", "/secondaryclone", "config", "core.repositoryformatversion", "1"},
+				{"-C", "/secondaryclone", "config", "remote.promisor.url", "someone.com"},
+				{"-C", "/secondaryclone", "config", "remote.promisor.promisor", "true"},
+				{"-C", "/secondaryclone", "config", "remote.promisor.partialclonefilter", "blob:none"},
+				{"-C", "/secondaryclone", "config", "extensions.partialClone", "promisor"},
+				{"-C", "/secondaryclone", "sparse-checkout", "set", "a"},
+				{"-C", "/secondaryclone", "reset", "--hard"},
+			},
+		},
+		{
+			name: "partial clone with unresolvable remote",
+			dir:  "/secondaryclone",
+			from: "/mirrorclone",
+			remote: func() (string, error) {
+				return "", errors.New("oops")
+			},
+			filter: "blob:none",
+			responses: map[string]execResponse{
+				"clone --no-checkout /mirrorclone /secondaryclone": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"clone", "--no-checkout", "/mirrorclone", "/secondaryclone"},
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -190,6 +299,7 @@ func TestInteractor_CloneWithRepoOpts(t *testing.T) {
 				remote:   testCase.remote,
 				dir:      testCase.dir,
 				logger:   logrus.WithField("test", testCase.name),
+				filter:   testCase.filter,
 			}
 			actualErr := i.CloneWithRepoOpts(testCase.from, testCase.repoOpts)
 			if testCase.expectedErr && actualErr == nil {
@@ -210,6 +320,7 @@ func TestInteractor_MirrorClone(t *testing.T) {
 		name          string
 		dir           string
 		remote        RemoteResolver
+		filter        string
 		responses     map[string]execResponse
 		expectedCalls [][]string
 		expectedErr   bool
@@ -256,6 +367,22 @@ func TestInteractor_MirrorClone(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "partial clone",
+			dir:  "/else",
+			remote: func() (string, error) {
+				return "someone.com", nil
+			},
+			filter: "tree:0",
+			responses: map[string]execResponse{
+				"clone --mirror --filter=tree:0 someone.com /else": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"clone", "--mirror", "--filter=tree:0", "someone.com", "/else"},
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -269,6 +396,7 @@ func TestInteractor_MirrorClone(t *testing.T) {
 				remote:   testCase.remote,
 				dir:      testCase.dir,
 				logger:   logrus.WithField("test", testCase.name),
+				filter:   testCase.filter,
 			}
 			actualErr := i.MirrorClone()
 			if testCase.expectedErr && actualErr == nil {
@@ -288,6 +416,7 @@ func TestInteractor_Checkout(t *testing.T) {
 	var testCases = []struct {
 		name          string
 		commitlike    string
+		worktrees     bool
 		remote        RemoteResolver
 		responses     map[string]execResponse
 		expectedCalls [][]string
@@ -319,6 +448,42 @@ func TestInteractor_Checkout(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name:       "worktree checks out remote branch detached",
+			commitlike: "main",
+			worktrees:  true,
+			responses: map[string]execResponse{
+				"rev-parse --verify --quiet refs/remotes/origin/main": {
+					out: []byte("shasum"),
+				},
+				"checkout --detach origin/main": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"rev-parse", "--verify", "--quiet", "refs/remotes/origin/main"},
+				{"checkout", "--detach", "origin/main"},
+			},
+			expectedErr: false,
+		},
+		{
+			name:       "worktree checks out other commitlikes as is",
+			commitlike: "shasum",
+			worktrees:  true,
+			responses: map[string]execResponse{
+				"rev-parse --verify --quiet refs/remotes/origin/shasum": {
+					err: errors.New("not a branch"),
+				},
+				"checkout shasum": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"rev-parse", "--verify", "--quiet", "refs/remotes/origin/shasum"},
+				{"checkout", "shasum"},
+			},
+			expectedErr: false,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -328,9 +493,10 @@ func TestInteractor_Checkout(t *testing.T) {
 				responses: testCase.responses,
 			}
 			i := interactor{
-				executor: &e,
-				remote:   testCase.remote,
-				logger:   logrus.WithField("test", testCase.name),
+				executor:  &e,
+				remote:    testCase.remote,
+				logger:    logrus.WithField("test", testCase.name),
+				worktrees: testCase.worktrees,
 			}
 			actualErr := i.Checkout(testCase.commitlike)
 			if testCase.expectedErr && actualErr == nil {
@@ -528,12 +694,14 @@ func TestInteractor_ObjectExists(t *testing.T) {
 
 func TestInteractor_CheckoutNewBranch(t *testing.T) {
 	var testCases = []struct {
-		name          string
-		branch        string
-		remote        RemoteResolver
-		responses     map[string]execResponse
-		expectedCalls [][]string
-		expectedErr   bool
+		name             string
+		branch           string
+		worktrees        bool
+		remote           RemoteResolver
+		responses        map[string]execResponse
+		expectedCalls    [][]string
+		expectedErr      bool
+		expectedBranches []string
 	}{
 		{
 			name:   "happy case",
@@ -561,6 +729,20 @@ func TestInteractor_CheckoutNewBranch(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name:      "worktree resets the branch",
+			branch:    "new-branch",
+			worktrees: true,
+			responses: map[string]execResponse{
+				"checkout -B new-branch": {
+					out: []byte(`ok`),
+				},
+			},
+			expectedCalls: [][]string{
+				{"checkout", "-B", "new-branch"},
+			},
+			expectedBranches: []string{"new-branch"},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -570,11 +752,15 @@ func TestInteractor_CheckoutNewBranch(t *testing.T) {
 				responses: testCase.responses,
 			}
 			i := interactor{
-				executor: &e,
-				remote:   testCase.remote,
-				logger:   logrus.WithField("test", testCase.name),
+				executor:  &e,
+				remote:    testCase.remote,
+				logger:    logrus.WithField("test", testCase.name),
+				worktrees: testCase.worktrees,
 			}
 			actualErr := i.CheckoutNewBranch(testCase.branch)
+			if !reflect.DeepEqual(i.branches, testCase.expectedBranches) {
+				t.Errorf("%s: expected created branches %v, got %v", testCase.name, testCase.expectedBranches, i.branches)
+			}
 			if testCase.expectedErr && actualErr == nil {
 				t.Errorf("%s: expected an error but got none", testCase.name)
 			}
@@ -1964,3 +2150,146 @@ func TestInteractor_ShowRef(t *testing.T) {
 		})
 	}
 }
+
+func TestInteractor_AddWorktree(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		repoOpts      RepoOpts
+		responses     map[string]execResponse
+		expectedCalls [][]string
+		expectedErr   bool
+	}{
+		{
+			name: "full checkout",
+			responses: map[string]execResponse{
+				"worktree prune": {},
+				"worktree add --detach /worktree origin/HEAD": {},
+			},
+			expectedCalls: [][]string{
+				{"worktree", "prune"},
+				{"worktree", "add", "--detach", "/worktree", "origin/HEAD"},
+			},
+		},
+		{
+			name:     "sparse checkout",
+			repoOpts: RepoOpts{SparseCheckoutDirs: []string{"docs"}},
+			responses: map[string]execResponse{
+				"worktree prune": {},
+				"worktree add --detach --no-checkout /worktree origin/HEAD": {},
+				"-C /worktree sparse-checkout set docs":                     {},
+				"-C /worktree checkout --detach":                            {},
+			},
+			expectedCalls: [][]string{
+				{"worktree", "prune"},
+				{"worktree", "add", "--detach", "--no-checkout", "/worktree", "origin/HEAD"},
+				{"-C", "/worktree", "sparse-checkout", "set", "docs"},
+				{"-C", "/worktree", "checkout", "--detach"},
+			},
+		},
+		{
+			name: "adding fails",
+			responses: map[string]execResponse{
+				"worktree prune": {},
+				"worktree add --detach /worktree origin/HEAD": {
+					err: errors.New("oops"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"worktree", "prune"},
+				{"worktree", "add", "--detach", "/worktree", "origin/HEAD"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := fakeExecutor{
+				records:   [][]string{},
+				responses: testCase.responses,
+			}
+			i := interactor{
+				dir:       "/primary",
+				executor:  &e,
+				logger:    logrus.WithField("test", testCase.name),
+				worktrees: true,
+			}
+			actualErr := i.AddWorktree("/worktree", testCase.repoOpts)
+			if testCase.expectedErr && actualErr == nil {
+				t.Errorf("%s: expected an error but got none", testCase.name)
+			}
+			if !testCase.expectedErr && actualErr != nil {
+				t.Errorf("%s: expected no error but got one: %v", testCase.name, actualErr)
+			}
+			if actual, expected := e.records, testCase.expectedCalls; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: got incorrect git calls: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
+			}
+		})
+	}
+}
+
+func TestInteractor_Clean(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		branches      []string
+		responses     map[string]execResponse
+		expectedCalls [][]string
+	}{
+		{
+			name:          "no branches were created",
+			expectedCalls: [][]string{},
+		},
+		{
+			name:     "created branches are deleted",
+			branches: []string{"pull1", "cherry-pick"},
+			responses: map[string]execResponse{
+				"checkout --detach":           {},
+				"branch -D pull1 cherry-pick": {},
+			},
+			expectedCalls: [][]string{
+				{"checkout", "--detach"},
+				{"branch", "-D", "pull1", "cherry-pick"},
+			},
+		},
+		{
+			name:     "failing to delete branches does not prevent the cleanup",
+			branches: []string{"pull1"},
+			responses: map[string]execResponse{
+				"checkout --detach": {},
+				"branch -D pull1": {
+					err: errors.New("oops"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"checkout", "--detach"},
+				{"branch", "-D", "pull1"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dir := t.TempDir()
+			e := fakeExecutor{
+				records:   [][]string{},
+				responses: testCase.responses,
+			}
+			i := interactor{
+				dir:       dir,
+				executor:  &e,
+				logger:    logrus.WithField("test", testCase.name),
+				worktrees: true,
+				branches:  testCase.branches,
+			}
+			if err := i.Clean(); err != nil {
+				t.Fatalf("%s: expected no error but got one: %v", testCase.name, err)
+			}
+			if _, err := os.Stat(dir); !os.IsNotExist(err) {
+				t.Errorf("%s: expected the worktree to be removed, got: %v", testCase.name, err)
+			}
+			if actual, expected := e.records, testCase.expectedCalls; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: got incorrect git calls: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
+			}
+		})
+	}
+}
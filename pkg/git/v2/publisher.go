@@ -17,7 +17,9 @@ limitations under the License.
 package git
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -32,6 +34,36 @@ type Publisher interface {
 	PushToNamedFork(forkName, branch string, force bool) error
 	// PushToCentral pushes the local state to the central remote
 	PushToCentral(branch string, force bool) error
+	// Tag creates an annotated tag of the current HEAD
+	Tag(name, message string) error
+	// LeaseNamedFork records the SHA the branch has in the fork, which
+	// PushToNamedForkWithLease expects it to still have
+	LeaseNamedFork(forkName, branch string) (string, error)
+	// PushToNamedForkWithLease force-pushes the local state to the fork
+	// unless the branch changed since it was leased
+	PushToNamedForkWithLease(forkName, branch string, opts LeaseOptions) error
+	// LeaseCentral records the SHA the branch has in the central remote,
+	// which PushToCentralWithLease expects it to still have
+	LeaseCentral(branch string) (string, error)
+	// PushToCentralWithLease force-pushes the local state to the central
+	// remote unless the branch changed since it was leased
+	PushToCentralWithLease(branch string, opts LeaseOptions) error
+}
+
+// ErrStaleLease is returned when a push with lease was rejected because the
+// branch changed since it was leased.
+var ErrStaleLease = errors.New("the remote branch changed since it was leased")
+
+// LeaseOptions configure how pushes with lease handle concurrent updates of
+// the remote branch.
+type LeaseOptions struct {
+	// Retries is how often a rejected push is retried. Before every retry,
+	// the new state of the branch is fetched, leased and passed to Reapply.
+	Retries int
+	// Reapply redoes the local changes on top of the SHA the remote branch
+	// has now, e.g. by rebasing onto it. The SHA is empty if the branch has
+	// been deleted. Rejected pushes are not retried if it is unset.
+	Reapply func(remoteSHA string) error
 }
 
 // GitUserGetter fetches a name and email for us in git commits on-demand
@@ -47,6 +79,14 @@ type publisher struct {
 	remotes  remotes
 	info     GitUserGetter
 	logger   *logrus.Entry
+	// leases are the SHAs the remote branches are expected to have, by
+	// remote and branch. Remotes are identified by name rather than URL,
+	// as URLs may contain credentials that rotate.
+	leases map[lease]string
+}
+
+type lease struct {
+	remote, branch string
 }
 
 // Commit adds all of the current content to the index and creates a commit
@@ -68,6 +108,16 @@ func (p *publisher) Commit(title, body string) error {
 	return nil
 }
 
+// Tag creates an annotated tag of the current HEAD, which is signed if the
+// client signs commits
+func (p *publisher) Tag(name, message string) error {
+	p.logger.Infof("Tagging HEAD as %q", name)
+	if out, err := p.executor.Run("tag", "--annotate", "--message", message, name); err != nil {
+		return fmt.Errorf("error tagging %q: %w %v", name, err, string(out))
+	}
+	return nil
+}
+
 func (p *publisher) PushToNamedFork(forkName, branch string, force bool) error {
 	remote, err := p.remotes.publishRemote(forkName)
 	if err != nil {
@@ -111,3 +161,103 @@ func (p *publisher) PushToCentral(branch string, force bool) error {
 	}
 	return nil
 }
+
+// LeaseNamedFork records the SHA the branch has in the fork
+func (p *publisher) LeaseNamedFork(forkName, branch string) (string, error) {
+	return p.lease(lease{remote: "fork/" + forkName, branch: branch}, func() (string, error) {
+		return p.remotes.publishRemote(forkName)
+	})
+}
+
+// PushToNamedForkWithLease force-pushes the local state to the fork unless
+// the branch changed since it was leased
+func (p *publisher) PushToNamedForkWithLease(forkName, branch string, opts LeaseOptions) error {
+	return p.pushWithLease(lease{remote: "fork/" + forkName, branch: branch}, func() (string, error) {
+		return p.remotes.publishRemote(forkName)
+	}, opts)
+}
+
+// LeaseCentral records the SHA the branch has in the central remote
+func (p *publisher) LeaseCentral(branch string) (string, error) {
+	return p.lease(lease{remote: "central", branch: branch}, p.remotes.centralRemote)
+}
+
+// PushToCentralWithLease force-pushes the local state to the central remote
+// unless the branch changed since it was leased
+func (p *publisher) PushToCentralWithLease(branch string, opts LeaseOptions) error {
+	return p.pushWithLease(lease{remote: "central", branch: branch}, p.remotes.centralRemote, opts)
+}
+
+func (p *publisher) lease(l lease, resolve RemoteResolver) (string, error) {
+	remote, err := resolve()
+	if err != nil {
+		return "", err
+	}
+	sha, err := p.remoteSHA(remote, l.branch)
+	if err != nil {
+		return "", err
+	}
+	if p.leases == nil {
+		p.leases = map[lease]string{}
+	}
+	p.leases[l] = sha
+	return sha, nil
+}
+
+// remoteSHA returns the SHA of the branch in the remote, or an empty string
+// if the branch doesn't exist.
+func (p *publisher) remoteSHA(remote, branch string) (string, error) {
+	out, err := p.executor.Run("ls-remote", remote, "refs/heads/"+branch)
+	if err != nil {
+		return "", fmt.Errorf("error listing remote branch %q: %w %v", branch, err, string(out))
+	}
+	if fields := strings.Fields(string(out)); len(fields) > 0 {
+		return fields[0], nil
+	}
+	return "", nil
+}
+
+func (p *publisher) pushWithLease(l lease, resolve RemoteResolver, opts LeaseOptions) error {
+	expected, leased := p.leases[l]
+	if !leased {
+		return fmt.Errorf("branch %q has not been leased", l.branch)
+	}
+	for attempt := 0; ; attempt++ {
+		remote, err := resolve()
+		if err != nil {
+			return err
+		}
+		p.logger.Infof("Pushing branch %q with lease on %q", l.branch, expected)
+		// An empty SHA expects the branch not to exist.
+		out, err := p.executor.Run("push", fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", l.branch, expected), remote, l.branch)
+		if err == nil {
+			pushed, err := p.executor.Run("rev-parse", l.branch)
+			if err != nil {
+				return fmt.Errorf("error resolving pushed branch %q: %w %v", l.branch, err, string(pushed))
+			}
+			p.leases[l] = strings.TrimSpace(string(pushed))
+			return nil
+		}
+		if !strings.Contains(string(out), "stale info") {
+			return fmt.Errorf("error pushing %q: %w %v", l.branch, err, string(out))
+		}
+		if opts.Reapply == nil || attempt >= opts.Retries {
+			return fmt.Errorf("error pushing %q: %w", l.branch, ErrStaleLease)
+		}
+
+		p.logger.Infof("Branch %q changed since it was leased, reapplying changes", l.branch)
+		current, err := p.remoteSHA(remote, l.branch)
+		if err != nil {
+			return err
+		}
+		if current != "" {
+			if out, err := p.executor.Run("fetch", remote, current); err != nil {
+				return fmt.Errorf("error fetching %q: %w %v", l.branch, err, string(out))
+			}
+		}
+		p.leases[l], expected = current, current
+		if err := opts.Reapply(current); err != nil {
+			return fmt.Errorf("error reapplying changes onto %q: %w", l.branch, err)
+		}
+	}
+}
@@ -346,3 +346,365 @@ func TestPublisher_PushToCentral(t *testing.T) {
 		})
 	}
 }
+
+func TestPublisher_Tag(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		responses     map[string]execResponse
+		expectedCalls [][]string
+		expectedErr   bool
+	}{
+		{
+			name: "no errors works fine",
+			responses: map[string]execResponse{
+				"tag --annotate --message release v1.0.0": {
+					out: []byte("ok"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"tag", "--annotate", "--message", "release", "v1.0.0"},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "tag fails",
+			responses: map[string]execResponse{
+				"tag --annotate --message release v1.0.0": {
+					err: errors.New("oops"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"tag", "--annotate", "--message", "release", "v1.0.0"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := fakeExecutor{
+				records:   [][]string{},
+				responses: testCase.responses,
+			}
+			p := publisher{
+				executor: &e,
+				logger:   logrus.WithField("test", testCase.name),
+			}
+			actualErr := p.Tag("v1.0.0", "release")
+			if testCase.expectedErr && actualErr == nil {
+				t.Errorf("%s: expected an error but got none", testCase.name)
+			}
+			if !testCase.expectedErr && actualErr != nil {
+				t.Errorf("%s: expected no error but got one: %v", testCase.name, actualErr)
+			}
+			if actual, expected := e.records, testCase.expectedCalls; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: got incorrect git calls: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
+			}
+		})
+	}
+}
+
+func TestPublisher_LeaseCentral(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		responses      map[string]execResponse
+		expectedLease  string
+		expectedLeases map[lease]string
+		expectedErr    bool
+	}{
+		{
+			name: "existing branch is leased at its SHA",
+			responses: map[string]execResponse{
+				"ls-remote http.com refs/heads/master": {
+					out: []byte("abcdef\trefs/heads/master\n"),
+				},
+			},
+			expectedLease:  "abcdef",
+			expectedLeases: map[lease]string{{remote: "central", branch: "master"}: "abcdef"},
+		},
+		{
+			name: "missing branch is leased as missing",
+			responses: map[string]execResponse{
+				"ls-remote http.com refs/heads/master": {
+					out: []byte(""),
+				},
+			},
+			expectedLeases: map[lease]string{{remote: "central", branch: "master"}: ""},
+		},
+		{
+			name: "ls-remote fails",
+			responses: map[string]execResponse{
+				"ls-remote http.com refs/heads/master": {
+					err: errors.New("oops"),
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := fakeExecutor{
+				records:   [][]string{},
+				responses: testCase.responses,
+			}
+			r := fakeResolver{out: "http.com"}
+			p := publisher{
+				executor: &e,
+				remotes:  remotes{centralRemote: r.Resolve},
+				logger:   logrus.WithField("test", testCase.name),
+			}
+			actualLease, actualErr := p.LeaseCentral("master")
+			if testCase.expectedErr && actualErr == nil {
+				t.Errorf("%s: expected an error but got none", testCase.name)
+			}
+			if !testCase.expectedErr && actualErr != nil {
+				t.Errorf("%s: expected no error but got one: %v", testCase.name, actualErr)
+			}
+			if actualLease != testCase.expectedLease {
+				t.Errorf("%s: expected lease %q, got %q", testCase.name, testCase.expectedLease, actualLease)
+			}
+			if actual, expected := p.leases, testCase.expectedLeases; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: got incorrect leases: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
+			}
+		})
+	}
+}
+
+func TestPublisher_PushToNamedForkWithLease(t *testing.T) {
+	stale := execResponse{
+		out: []byte(" ! [rejected]        branch -> branch (stale info)"),
+		err: errors.New("exit status 1"),
+	}
+	var testCases = []struct {
+		name              string
+		leases            map[lease]string
+		retries           int
+		reapply           bool
+		reapplyErr        error
+		responses         map[string]execResponse
+		expectedCalls     [][]string
+		expectedReapplies []string
+		expectedLeases    map[lease]string
+		expectedErr       error
+	}{
+		{
+			name:           "branch that wasn't leased isn't pushed",
+			expectedCalls:  [][]string{},
+			expectedErr:    errors.New(`branch "branch" has not been leased`),
+			expectedLeases: nil,
+		},
+		{
+			name:    "push with lease updates the lease",
+			leases:  map[lease]string{{remote: "fork/fork", branch: "branch"}: "old"},
+			reapply: true,
+			responses: map[string]execResponse{
+				"push --force-with-lease=refs/heads/branch:old http.com branch": {
+					out: []byte("ok"),
+				},
+				"rev-parse branch": {
+					out: []byte("new\n"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"push", "--force-with-lease=refs/heads/branch:old", "http.com", "branch"},
+				{"rev-parse", "branch"},
+			},
+			expectedLeases: map[lease]string{{remote: "fork/fork", branch: "branch"}: "new"},
+		},
+		{
+			name:   "push of a new branch expects it not to exist",
+			leases: map[lease]string{{remote: "fork/fork", branch: "branch"}: ""},
+			responses: map[string]execResponse{
+				"push --force-with-lease=refs/heads/branch: http.com branch": {
+					out: []byte("ok"),
+				},
+				"rev-parse branch": {
+					out: []byte("new\n"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"push", "--force-with-lease=refs/heads/branch:", "http.com", "branch"},
+				{"rev-parse", "branch"},
+			},
+			expectedLeases: map[lease]string{{remote: "fork/fork", branch: "branch"}: "new"},
+		},
+		{
+			name:    "rejected push is not retried without reapply",
+			leases:  map[lease]string{{remote: "fork/fork", branch: "branch"}: "old"},
+			retries: 3,
+			responses: map[string]execResponse{
+				"push --force-with-lease=refs/heads/branch:old http.com branch": stale,
+			},
+			expectedCalls: [][]string{
+				{"push", "--force-with-lease=refs/heads/branch:old", "http.com", "branch"},
+			},
+			expectedLeases: map[lease]string{{remote: "fork/fork", branch: "branch"}: "old"},
+			expectedErr:    ErrStaleLease,
+		},
+		{
+			name:    "rejected push is retried after reapplying onto the new remote SHA",
+			leases:  map[lease]string{{remote: "fork/fork", branch: "branch"}: "old"},
+			retries: 1,
+			reapply: true,
+			responses: map[string]execResponse{
+				"push --force-with-lease=refs/heads/branch:old http.com branch": stale,
+				"ls-remote http.com refs/heads/branch": {
+					out: []byte("concurrent\trefs/heads/branch\n"),
+				},
+				"fetch http.com concurrent": {
+					out: []byte("ok"),
+				},
+				"push --force-with-lease=refs/heads/branch:concurrent http.com branch": {
+					out: []byte("ok"),
+				},
+				"rev-parse branch": {
+					out: []byte("new\n"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"push", "--force-with-lease=refs/heads/branch:old", "http.com", "branch"},
+				{"ls-remote", "http.com", "refs/heads/branch"},
+				{"fetch", "http.com", "concurrent"},
+				{"push", "--force-with-lease=refs/heads/branch:concurrent", "http.com", "branch"},
+				{"rev-parse", "branch"},
+			},
+			expectedReapplies: []string{"concurrent"},
+			expectedLeases:    map[lease]string{{remote: "fork/fork", branch: "branch"}: "new"},
+		},
+		{
+			name:    "deleted branch isn't fetched",
+			leases:  map[lease]string{{remote: "fork/fork", branch: "branch"}: "old"},
+			retries: 1,
+			reapply: true,
+			responses: map[string]execResponse{
+				"push --force-with-lease=refs/heads/branch:old http.com branch": stale,
+				"ls-remote http.com refs/heads/branch": {
+					out: []byte(""),
+				},
+				"push --force-with-lease=refs/heads/branch: http.com branch": {
+					out: []byte("ok"),
+				},
+				"rev-parse branch": {
+					out: []byte("new\n"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"push", "--force-with-lease=refs/heads/branch:old", "http.com", "branch"},
+				{"ls-remote", "http.com", "refs/heads/branch"},
+				{"push", "--force-with-lease=refs/heads/branch:", "http.com", "branch"},
+				{"rev-parse", "branch"},
+			},
+			expectedReapplies: []string{""},
+			expectedLeases:    map[lease]string{{remote: "fork/fork", branch: "branch"}: "new"},
+		},
+		{
+			name:    "retries are exhausted",
+			leases:  map[lease]string{{remote: "fork/fork", branch: "branch"}: "old"},
+			retries: 1,
+			reapply: true,
+			responses: map[string]execResponse{
+				"push --force-with-lease=refs/heads/branch:old http.com branch": stale,
+				"ls-remote http.com refs/heads/branch": {
+					out: []byte("concurrent\trefs/heads/branch\n"),
+				},
+				"fetch http.com concurrent": {
+					out: []byte("ok"),
+				},
+				"push --force-with-lease=refs/heads/branch:concurrent http.com branch": stale,
+			},
+			expectedCalls: [][]string{
+				{"push", "--force-with-lease=refs/heads/branch:old", "http.com", "branch"},
+				{"ls-remote", "http.com", "refs/heads/branch"},
+				{"fetch", "http.com", "concurrent"},
+				{"push", "--force-with-lease=refs/heads/branch:concurrent", "http.com", "branch"},
+			},
+			expectedReapplies: []string{"concurrent"},
+			expectedLeases:    map[lease]string{{remote: "fork/fork", branch: "branch"}: "concurrent"},
+			expectedErr:       ErrStaleLease,
+		},
+		{
+			name:       "reapply fails",
+			leases:     map[lease]string{{remote: "fork/fork", branch: "branch"}: "old"},
+			retries:    1,
+			reapply:    true,
+			reapplyErr: errors.New("conflict"),
+			responses: map[string]execResponse{
+				"push --force-with-lease=refs/heads/branch:old http.com branch": stale,
+				"ls-remote http.com refs/heads/branch": {
+					out: []byte("concurrent\trefs/heads/branch\n"),
+				},
+				"fetch http.com concurrent": {
+					out: []byte("ok"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"push", "--force-with-lease=refs/heads/branch:old", "http.com", "branch"},
+				{"ls-remote", "http.com", "refs/heads/branch"},
+				{"fetch", "http.com", "concurrent"},
+			},
+			expectedReapplies: []string{"concurrent"},
+			expectedLeases:    map[lease]string{{remote: "fork/fork", branch: "branch"}: "concurrent"},
+			expectedErr:       errors.New(`error reapplying changes onto "branch": conflict`),
+		},
+		{
+			name:    "other push errors are not retried",
+			leases:  map[lease]string{{remote: "fork/fork", branch: "branch"}: "old"},
+			retries: 1,
+			reapply: true,
+			responses: map[string]execResponse{
+				"push --force-with-lease=refs/heads/branch:old http.com branch": {
+					out: []byte("permission denied"),
+					err: errors.New("exit status 128"),
+				},
+			},
+			expectedCalls: [][]string{
+				{"push", "--force-with-lease=refs/heads/branch:old", "http.com", "branch"},
+			},
+			expectedLeases: map[lease]string{{remote: "fork/fork", branch: "branch"}: "old"},
+			expectedErr:    errors.New(`error pushing "branch": exit status 128 permission denied`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			e := fakeExecutor{
+				records:   [][]string{},
+				responses: testCase.responses,
+			}
+			r := fakeResolver{out: "http.com"}
+			p := publisher{
+				executor: &e,
+				remotes:  remotes{publishRemote: r.ForkResolver},
+				logger:   logrus.WithField("test", testCase.name),
+				leases:   testCase.leases,
+			}
+			var reapplied []string
+			opts := LeaseOptions{Retries: testCase.retries}
+			if testCase.reapply {
+				opts.Reapply = func(remoteSHA string) error {
+					reapplied = append(reapplied, remoteSHA)
+					return testCase.reapplyErr
+				}
+			}
+			actualErr := p.PushToNamedForkWithLease("fork", "branch", opts)
+			switch {
+			case testCase.expectedErr == nil && actualErr != nil:
+				t.Errorf("%s: expected no error but got one: %v", testCase.name, actualErr)
+			case testCase.expectedErr == ErrStaleLease && !errors.Is(actualErr, ErrStaleLease):
+				t.Errorf("%s: expected a stale lease error, got %v", testCase.name, actualErr)
+			case testCase.expectedErr != nil && testCase.expectedErr != ErrStaleLease && (actualErr == nil || actualErr.Error() != testCase.expectedErr.Error()):
+				t.Errorf("%s: expected error %v, got %v", testCase.name, testCase.expectedErr, actualErr)
+			}
+			if actual, expected := e.records, testCase.expectedCalls; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: got incorrect git calls: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
+			}
+			if actual, expected := reapplied, testCase.expectedReapplies; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: expected reapplies onto %v, got %v", testCase.name, expected, actual)
+			}
+			if actual, expected := p.leases, testCase.expectedLeases; !reflect.DeepEqual(actual, expected) {
+				t.Errorf("%s: got incorrect leases: %v", testCase.name, diff.ObjectReflectDiff(actual, expected))
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
 
 	gerritsource "sigs.k8s.io/prow/pkg/gerrit/source"
 )
@@ -54,15 +55,36 @@ type LoginGetter func() (login string, err error)
 // TokenGetter fetches a GitHub OAuth token on-demand
 type TokenGetter func(org string) (string, error)
 
+// hostFor returns the host serving the org, which is either listed in the
+// orgHosts or the default host.
+func hostFor(host string, orgHosts map[string]string, org string) string {
+	if orgHost, ok := orgHosts[strings.ToLower(org)]; ok {
+		return orgHost
+	}
+	return host
+}
+
+// checkPublishable returns an error if the org is served by another host
+// than the default one. Forks are resolved with the login of the default
+// host, which doesn't identify the fork on other hosts.
+func checkPublishable(orgHosts map[string]string, org string) error {
+	if _, ok := orgHosts[strings.ToLower(org)]; ok {
+		return fmt.Errorf("publishing forks of repos in %s is not supported, it is served by another host", org)
+	}
+	return nil
+}
+
 type sshRemoteResolverFactory struct {
-	host     string
+	host string
+	// orgHosts maps orgs to the hosts serving them if they differ from host.
+	orgHosts map[string]string
 	username LoginGetter
 }
 
 // CentralRemote creates a remote resolver that refers to an authoritative remote
 // for the repository.
 func (f *sshRemoteResolverFactory) CentralRemote(org, repo string) RemoteResolver {
-	remote := fmt.Sprintf("git@%s:%s/%s.git", f.host, org, repo)
+	remote := fmt.Sprintf("git@%s:%s/%s.git", hostFor(f.host, f.orgHosts, org), org, repo)
 	return func() (string, error) {
 		return remote, nil
 	}
@@ -70,8 +92,11 @@ func (f *sshRemoteResolverFactory) CentralRemote(org, repo string) RemoteResolve
 
 // PublishRemote creates a remote resolver that refers to a user's remote
 // for the repository that can be published to.
-func (f *sshRemoteResolverFactory) PublishRemote(_, centralRepo string) ForkRemoteResolver {
+func (f *sshRemoteResolverFactory) PublishRemote(centralOrg, centralRepo string) ForkRemoteResolver {
 	return func(forkName string) (string, error) {
+		if err := checkPublishable(f.orgHosts, centralOrg); err != nil {
+			return "", err
+		}
 		repo := centralRepo
 		if forkName != "" {
 			repo = forkName
@@ -88,6 +113,8 @@ type httpResolverFactory struct {
 	// Whether to use HTTP.
 	http bool
 	host string
+	// orgHosts maps orgs to the hosts serving them if they differ from host.
+	orgHosts map[string]string
 	// Optional, either both or none must be set
 	username LoginGetter
 	token    TokenGetter
@@ -103,8 +130,11 @@ func (f *httpResolverFactory) CentralRemote(org, repo string) RemoteResolver {
 
 // PublishRemote creates a remote resolver that refers to a user's remote
 // for the repository that can be published to.
-func (f *httpResolverFactory) PublishRemote(_, centralRepo string) ForkRemoteResolver {
+func (f *httpResolverFactory) PublishRemote(centralOrg, centralRepo string) ForkRemoteResolver {
 	return func(forkName string) (string, error) {
+		if err := checkPublishable(f.orgHosts, centralOrg); err != nil {
+			return "", err
+		}
 		// For the publsh remote we use:
 		// - the user login rather than the central org
 		// - the forkName rather than the central repo name, if specified.
@@ -134,7 +164,7 @@ func (f *httpResolverFactory) resolve(org, repo string) (string, error) {
 	if f.http {
 		scheme = "http"
 	}
-	remote := &url.URL{Scheme: scheme, Host: f.host, Path: fmt.Sprintf("%s/%s", org, repo)}
+	remote := &url.URL{Scheme: scheme, Host: hostFor(f.host, f.orgHosts, org), Path: fmt.Sprintf("%s/%s", org, repo)}
 
 	if f.username != nil {
 		name, err := f.username()
@@ -155,13 +185,23 @@ func (f *httpResolverFactory) resolve(org, repo string) (string, error) {
 // used in local integration testing only
 type pathResolverFactory struct {
 	baseDir string
+	// fileURLs is set to resolve file:// URLs instead of paths, as git
+	// ignores the filter of partial clones from local paths.
+	fileURLs bool
+}
+
+func (f *pathResolverFactory) resolve(org, repo string) string {
+	if f.fileURLs {
+		return "file://" + path.Join(f.baseDir, org, repo)
+	}
+	return path.Join(f.baseDir, org, repo)
 }
 
 // CentralRemote creates a remote resolver that refers to an authoritative remote
 // for the repository.
 func (f *pathResolverFactory) CentralRemote(org, repo string) RemoteResolver {
 	return func() (string, error) {
-		return path.Join(f.baseDir, org, repo), nil
+		return f.resolve(org, repo), nil
 	}
 }
 
@@ -169,7 +209,7 @@ func (f *pathResolverFactory) CentralRemote(org, repo string) RemoteResolver {
 // for the repository that can be published to.
 func (f *pathResolverFactory) PublishRemote(org, centralRepo string) ForkRemoteResolver {
 	return func(_ string) (string, error) {
-		return path.Join(f.baseDir, org, centralRepo), nil
+		return f.resolve(org, centralRepo), nil
 	}
 }
 
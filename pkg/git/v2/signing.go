@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SigningFormat is the format of the key that commits and tags are signed
+// with, see the gpg.format git config.
+type SigningFormat string
+
+const (
+	// SigningFormatOpenPGP signs with an armored GPG private key.
+	SigningFormatOpenPGP SigningFormat = "openpgp"
+	// SigningFormatSSH signs with an OpenSSH private key.
+	SigningFormatSSH SigningFormat = "ssh"
+)
+
+// SigningKeyGetter returns the private key that commits and tags are signed
+// with. The key must not be protected by a passphrase.
+type SigningKeyGetter func() []byte
+
+// signer makes git sign all commits and tags it creates. The key is written
+// to dir whenever it changes, so that rotated keys are picked up.
+type signer struct {
+	format SigningFormat
+	key    SigningKeyGetter
+	dir    string
+
+	lock sync.Mutex
+	// current is the key the config was generated for.
+	current []byte
+	config  []string
+}
+
+func newSigner(format SigningFormat, key SigningKeyGetter, dir string) (*signer, error) {
+	switch format {
+	case "":
+		format = SigningFormatOpenPGP
+	case SigningFormatOpenPGP, SigningFormatSSH:
+	default:
+		return nil, fmt.Errorf("unknown signing format %q, must be %q or %q", format, SigningFormatOpenPGP, SigningFormatSSH)
+	}
+	return &signer{format: format, key: key, dir: dir}, nil
+}
+
+// configArgs returns the git arguments that enable signing with the current
+// key.
+func (s *signer) configArgs() ([]string, error) {
+	key := s.key()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.config != nil && bytes.Equal(key, s.current) {
+		return s.config, nil
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("no %s signing key", s.format)
+	}
+	if err := os.RemoveAll(s.dir); err != nil {
+		return nil, fmt.Errorf("failed to remove old signing key: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create signing key dir: %w", err)
+	}
+	var config map[string]string
+	var err error
+	switch s.format {
+	case SigningFormatSSH:
+		config, err = s.writeSSHKey(key)
+	default:
+		config, err = s.importOpenPGPKey(key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	config["commit.gpgSign"] = "true"
+	config["tag.gpgSign"] = "true"
+	config["gpg.format"] = string(s.format)
+
+	var args []string
+	for _, name := range []string{"commit.gpgSign", "tag.gpgSign", "gpg.format", "gpg.program", "user.signingKey"} {
+		if value, ok := config[name]; ok {
+			args = append(args, "-c", name+"="+value)
+		}
+	}
+	s.current, s.config = key, args
+	return args, nil
+}
+
+func (s *signer) writeSSHKey(key []byte) (map[string]string, error) {
+	path := filepath.Join(s.dir, "signing-key")
+	// ssh-keygen rejects keys without a trailing newline, which secrets are
+	// often stripped of.
+	if err := os.WriteFile(path, append(bytes.TrimSpace(key), '\n'), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write ssh signing key: %w", err)
+	}
+	return map[string]string{"user.signingKey": path}, nil
+}
+
+// importOpenPGPKey imports the key into a keyring of its own and returns the
+// config that makes git use it.
+func (s *signer) importOpenPGPKey(key []byte) (map[string]string, error) {
+	home := filepath.Join(s.dir, "gnupg")
+	if err := os.MkdirAll(home, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create keyring dir: %w", err)
+	}
+	gpg := func(stdin []byte, args ...string) ([]byte, error) {
+		cmd := exec.Command("gpg", append([]string{"--homedir", home, "--batch"}, args...)...)
+		cmd.Stdin = bytes.NewReader(stdin)
+		out, err := cmd.Output()
+		if err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				return nil, fmt.Errorf("gpg %s failed: %w: %s", args[0], err, exitErr.Stderr)
+			}
+			return nil, fmt.Errorf("gpg %s failed: %w", args[0], err)
+		}
+		return out, nil
+	}
+	if _, err := gpg(key, "--import"); err != nil {
+		return nil, err
+	}
+	out, err := gpg(nil, "--with-colons", "--list-secret-keys")
+	if err != nil {
+		return nil, err
+	}
+	var fingerprint string
+	for _, line := range strings.Split(string(out), "\n") {
+		// The first fpr record belongs to the primary key.
+		if fields := strings.Split(line, ":"); len(fields) > 9 && fields[0] == "fpr" {
+			fingerprint = fields[9]
+			break
+		}
+	}
+	if fingerprint == "" {
+		return nil, fmt.Errorf("no secret key found in the openpgp signing key")
+	}
+
+	// git has no setting for the keyring, so it calls gpg through a wrapper
+	// that selects it.
+	program := filepath.Join(s.dir, "gpg")
+	script := fmt.Sprintf("#!/bin/sh\nexec gpg --homedir '%s' \"$@\"\n", home)
+	if err := os.WriteFile(program, []byte(script), 0700); err != nil {
+		return nil, fmt.Errorf("failed to write gpg wrapper: %w", err)
+	}
+	return map[string]string{"gpg.program": program, "user.signingKey": fingerprint}, nil
+}
+
+// signingExecutor passes the signing config to every git command, so that
+// all commits and tags are signed, including those created by merges,
+// rebases and git am.
+type signingExecutor struct {
+	executor
+	signer *signer
+}
+
+func (e *signingExecutor) Run(args ...string) ([]byte, error) {
+	config, err := e.signer.configArgs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up commit signing: %w", err)
+	}
+	return e.executor.Run(append(append([]string{}, config...), args...)...)
+}
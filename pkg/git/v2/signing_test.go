@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestSigningExecutor_Run(t *testing.T) {
+	dir := t.TempDir()
+	key := []byte("first-key")
+	s, err := newSigner(SigningFormatSSH, func() []byte { return key }, filepath.Join(dir, "signing"))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	keyPath := filepath.Join(dir, "signing", "signing-key")
+	e := &fakeExecutor{responses: map[string]execResponse{}}
+	executor := &signingExecutor{executor: e, signer: s}
+
+	expectedConfig := []string{"-c", "commit.gpgSign=true", "-c", "tag.gpgSign=true", "-c", "gpg.format=ssh", "-c", "user.signingKey=" + keyPath}
+	e.responses[strings.Join(append(expectedConfig, "commit", "--message", "title"), " ")] = execResponse{out: []byte("ok")}
+	for _, content := range []string{"first-key", "rotated-key"} {
+		key = []byte(content)
+		if _, err := executor.Run("commit", "--message", "title"); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+		written, err := os.ReadFile(keyPath)
+		if err != nil {
+			t.Fatalf("failed to read key: %v", err)
+		}
+		if string(written) != content+"\n" {
+			t.Errorf("expected key %q, got %q", content+"\n", string(written))
+		}
+		info, err := os.Stat(keyPath)
+		if err != nil {
+			t.Fatalf("failed to stat key: %v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("expected key to be only readable by the owner, got %v", info.Mode().Perm())
+		}
+	}
+	if expected := [][]string{append(expectedConfig, "commit", "--message", "title"), append(expectedConfig, "commit", "--message", "title")}; !reflect.DeepEqual(e.records, expected) {
+		t.Errorf("expected calls %v, got %v", expected, e.records)
+	}
+
+	key = nil
+	if _, err := executor.Run("commit", "--message", "title"); err == nil {
+		t.Error("expected an error without signing key")
+	}
+	if len(e.records) != 2 {
+		t.Errorf("expected git not to run without signing key, got %v", e.records)
+	}
+}
+
+func TestNewSigner(t *testing.T) {
+	if _, err := newSigner("x509", func() []byte { return nil }, t.TempDir()); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	s, err := newSigner("", func() []byte { return nil }, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	if s.format != SigningFormatOpenPGP {
+		t.Errorf("expected format to default to %q, got %q", SigningFormatOpenPGP, s.format)
+	}
+}
+
+// TestSigning signs with the real tools if they are available.
+func TestSigning(t *testing.T) {
+	testCases := []struct {
+		name              string
+		format            SigningFormat
+		tool              string
+		generateKey       func(t *testing.T, dir string) []byte
+		expectedSignature string
+	}{
+		{
+			name:   "ssh",
+			format: SigningFormatSSH,
+			tool:   "ssh-keygen",
+			generateKey: func(t *testing.T, dir string) []byte {
+				path := filepath.Join(dir, "id_ed25519")
+				if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", path).CombinedOutput(); err != nil {
+					t.Fatalf("failed to generate key: %v: %s", err, out)
+				}
+				key, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("failed to read key: %v", err)
+				}
+				return key
+			},
+			expectedSignature: "-----BEGIN SSH SIGNATURE-----",
+		},
+		{
+			name:   "openpgp",
+			format: SigningFormatOpenPGP,
+			tool:   "gpg",
+			generateKey: func(t *testing.T, dir string) []byte {
+				home := filepath.Join(dir, "gnupg")
+				if err := os.MkdirAll(home, 0700); err != nil {
+					t.Fatalf("failed to create keyring: %v", err)
+				}
+				if out, err := exec.Command("gpg", "--homedir", home, "--batch", "--passphrase", "", "--quick-gen-key", "robot <boop@beep.zoop>", "ed25519", "sign", "never").CombinedOutput(); err != nil {
+					t.Fatalf("failed to generate key: %v: %s", err, out)
+				}
+				key, err := exec.Command("gpg", "--homedir", home, "--batch", "--armor", "--export-secret-keys").Output()
+				if err != nil {
+					t.Fatalf("failed to export key: %v", err)
+				}
+				// The agent of the generating keyring isn't needed anymore.
+				exec.Command("gpgconf", "--homedir", home, "--kill", "gpg-agent").Run()
+				return key
+			},
+			expectedSignature: "-----BEGIN PGP SIGNATURE-----",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := exec.LookPath(tc.tool); err != nil {
+				t.Skipf("%s is not available", tc.tool)
+			}
+			dir := t.TempDir()
+			key := tc.generateKey(t, dir)
+			s, err := newSigner(tc.format, func() []byte { return key }, filepath.Join(dir, "signing"))
+			if err != nil {
+				t.Fatalf("failed to create signer: %v", err)
+			}
+			if tc.format == SigningFormatOpenPGP {
+				defer exec.Command("gpgconf", "--homedir", filepath.Join(dir, "signing", "gnupg"), "--kill", "gpg-agent").Run()
+			}
+			repo := filepath.Join(dir, "repo")
+			if err := os.MkdirAll(repo, 0755); err != nil {
+				t.Fatalf("failed to create repo dir: %v", err)
+			}
+			censoringExecutor, err := NewCensoringExecutor(repo, func(in []byte) []byte { return in }, logrus.WithField("test", tc.name))
+			if err != nil {
+				t.Fatalf("failed to create executor: %v", err)
+			}
+			executor := &signingExecutor{executor: censoringExecutor, signer: s}
+			p := publisher{
+				executor: executor,
+				info:     func() (string, string, error) { return "robot", "boop@beep.zoop", nil },
+				logger:   logrus.WithField("test", tc.name),
+			}
+			for _, args := range [][]string{{"init"}, {"config", "user.name", "robot"}, {"config", "user.email", "boop@beep.zoop"}} {
+				if out, err := executor.Run(args...); err != nil {
+					t.Fatalf("git %v failed: %v: %s", args, err, out)
+				}
+			}
+			if err := os.WriteFile(filepath.Join(repo, "file"), []byte("content"), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			if err := p.Commit("title", "body"); err != nil {
+				t.Fatalf("failed to commit: %v", err)
+			}
+			if err := p.Tag("v1.0.0", "release"); err != nil {
+				t.Fatalf("failed to tag: %v", err)
+			}
+			for _, object := range [][]string{{"cat-file", "commit", "HEAD"}, {"cat-file", "tag", "v1.0.0"}} {
+				out, err := censoringExecutor.Run(object...)
+				if err != nil {
+					t.Fatalf("git %v failed: %v: %s", object, err, out)
+				}
+				if !strings.Contains(string(out), tc.expectedSignature) {
+					t.Errorf("expected %s to be signed, got:\n%s", object[1], out)
+				}
+			}
+		})
+	}
+}
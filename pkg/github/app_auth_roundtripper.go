@@ -19,6 +19,7 @@ package github
 import (
 	"context"
 	"crypto/rsa"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -71,6 +72,8 @@ type appsRoundTripper struct {
 	upstream          http.RoundTripper
 	githubClient      appGitHubClient
 	hostPrefixMapping map[string]string
+	// tokenSource replaces the private key if set, see ClientOptions.
+	tokenSource AppTokenSource
 }
 
 // appsAuthError is returned by the appsRoundTripper if any issues were encountered
@@ -112,6 +115,9 @@ var TimeNow = func() time.Time {
 }
 
 func (arr *appsRoundTripper) addAppAuth(r *http.Request) *appsAuthError {
+	if arr.privateKey == nil {
+		return &appsAuthError{fmt.Errorf("%s needs the private key of the GitHub App", arr.canonicalizedPath(r.URL))}
+	}
 	now := TimeNow()
 	// GitHub's clock may lag a few seconds, so we do not use 10min here.
 	expiresAt := now.Add(9 * time.Minute)
@@ -174,6 +180,9 @@ func (arr *appsRoundTripper) addAppInstallationAuth(r *http.Request) *appsAuthEr
 }
 
 func (arr *appsRoundTripper) installationTokenFor(org string) (string, time.Time, error) {
+	if arr.tokenSource != nil {
+		return arr.tokenSource.InstallationToken(org)
+	}
 	installationID, err := arr.installationIDFor(org)
 	if err != nil {
 		return "", time.Time{}, fmt.Errorf("failed to get installation id for org %s: %w", org, err)
@@ -187,6 +196,33 @@ func (arr *appsRoundTripper) installationTokenFor(org string) (string, time.Time
 	return token, expiresAt, nil
 }
 
+// InstallationTokenSource mints GitHub App installation tokens.
+type InstallationTokenSource interface {
+	// InstallationToken returns an installation token for the org and the
+	// time it expires at. Tokens are cached until shortly before they expire.
+	InstallationToken(org string) (string, time.Time, error)
+}
+
+// AppTokenSource is a GitHub App whose installation tokens are minted
+// elsewhere, e.g. by a token broker.
+type AppTokenSource interface {
+	InstallationTokenSource
+	// GetApp returns the app the tokens are minted for.
+	GetApp() (*App, error)
+}
+
+// InstallationToken implements InstallationTokenSource. It fails unless the
+// client authenticates as a GitHub App.
+func (c *client) InstallationToken(org string) (string, time.Time, error) {
+	if host := c.hostFor(org); host != nil {
+		return host.InstallationToken(org)
+	}
+	if c.installationToken == nil {
+		return "", time.Time{}, errors.New("installation tokens require GitHub App authentication")
+	}
+	return c.installationToken(org)
+}
+
 // installationIDFor returns the installation id for the given org. Unfortunately,
 // GitHub does not expose what repos in that org the app is installed in, it
 // only tells us if its all repos or a subset via the repository_selection
@@ -270,7 +306,11 @@ func (arr *appsRoundTripper) getSlug() (string, error) {
 	if arr.appSlug != "" {
 		return arr.appSlug, nil
 	}
-	response, err := arr.githubClient.GetApp()
+	getApp := arr.githubClient.GetApp
+	if arr.tokenSource != nil {
+		getApp = arr.tokenSource.GetApp
+	}
+	response, err := getApp()
 	if err != nil {
 		return "", err
 	}
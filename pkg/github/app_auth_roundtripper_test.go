@@ -518,3 +518,86 @@ func serializeOrDie(in interface{}) io.ReadCloser {
 	}
 	return io.NopCloser(bytes.NewBuffer(rawData))
 }
+
+func TestInstallationToken(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	_, _, ghClient, err := NewAppsAuthClientWithFields(logrus.Fields{}, nil, "13", func() *rsa.PrivateKey { return rsaKey }, "", "https://api.github.com")
+	if err != nil {
+		t.Fatalf("failed to construct github client: %v", err)
+	}
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	appsRoundTripper := validateAppsRoundTripper(t, ghClient)
+	appsRoundTripper.installations = map[string]AppInstallation{"org": {ID: 1}}
+	appsRoundTripper.tokens = map[int64]*AppInstallationToken{1: {Token: "the-token", ExpiresAt: expiresAt}}
+
+	token, actualExpiresAt, err := ghClient.(InstallationTokenSource).InstallationToken("org")
+	if err != nil {
+		t.Fatalf("failed to get installation token: %v", err)
+	}
+	if token != "the-token" {
+		t.Errorf("expected token %q, got %q", "the-token", token)
+	}
+	if !actualExpiresAt.Equal(expiresAt) {
+		t.Errorf("expected expiry %v, got %v", expiresAt, actualExpiresAt)
+	}
+
+	if _, _, err := getClient("https://api.github.com").InstallationToken("org"); err == nil {
+		t.Error("expected an error for a client that doesn't use apps auth")
+	}
+}
+
+type fakeAppTokenSource struct{}
+
+func (fakeAppTokenSource) InstallationToken(org string) (string, time.Time, error) {
+	return "token-for-" + org, time.Now().Add(time.Hour), nil
+}
+
+func (fakeAppTokenSource) GetApp() (*App, error) {
+	return &App{Slug: "prow-bot", Name: "Prow Bot"}, nil
+}
+
+func TestAppTokenSource(t *testing.T) {
+	upstream := &fakeRoundTripper{responses: map[string]*http.Response{
+		"/repos/org/repo/issues/1/comments": {StatusCode: 201, Body: io.NopCloser(bytes.NewBufferString("{}"))},
+	}}
+	tokenGenerator, userGenerator, ghClient, err := NewClientFromOptions(logrus.Fields{}, ClientOptions{
+		AppTokenSource:   fakeAppTokenSource{},
+		Bases:            []string{"https://api.github.com"},
+		BaseRoundTripper: upstream,
+		Censor:           func(content []byte) []byte { return content },
+	})
+	if err != nil {
+		t.Fatalf("failed to construct github client: %v", err)
+	}
+	if !ghClient.UsesAppAuth() {
+		t.Error("expected the client to use apps auth")
+	}
+	if err := ghClient.CreateComment("org", "repo", 1, "hello"); err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+	if len(upstream.requests) != 1 {
+		t.Fatalf("expected only the comment to be requested, got %d requests", len(upstream.requests))
+	}
+	if authorization := upstream.requests[0].Header.Get("Authorization"); authorization != "Bearer token-for-org" {
+		t.Errorf("expected the installation token of the source, got %q", authorization)
+	}
+	botUser, err := ghClient.BotUser()
+	if err != nil {
+		t.Fatalf("failed to get bot user: %v", err)
+	}
+	if botUser.Login != "prow-bot" {
+		t.Errorf("expected bot user prow-bot, got %q", botUser.Login)
+	}
+	if token, err := tokenGenerator("org"); err != nil || token != "token-for-org" {
+		t.Errorf("expected git token %q, got %q and error %v", "token-for-org", token, err)
+	}
+	if user, err := userGenerator(); err != nil || user != "x-access-token" {
+		t.Errorf("expected git user x-access-token, got %q and error %v", user, err)
+	}
+	if _, err := ghClient.ListAppInstallations(); err == nil {
+		t.Error("expected requests that need the private key of the app to fail")
+	}
+}
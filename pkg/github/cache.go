@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/ghcache"
+)
+
+// Cache modes for ClientOptions.CacheMode.
+const (
+	CacheModeNone   = ""
+	CacheModeMemory = "memory"
+	CacheModeDisk   = "disk"
+)
+
+const (
+	// cacheMaxConcurrency matches the default of ghproxy.
+	cacheMaxConcurrency = 25
+	cachePruneInterval  = time.Hour
+)
+
+// cachingRoundTripper wraps the round tripper with the conditional request
+// cache used by ghproxy, so that clients that don't talk to GitHub through
+// ghproxy still get revalidated responses for free. Cache hits are reported
+// in the ghcache_responses metric.
+func cachingRoundTripper(rt http.RoundTripper, options ClientOptions) (http.RoundTripper, error) {
+	switch options.CacheMode {
+	case CacheModeNone:
+		return rt, nil
+	case CacheModeMemory:
+		return ghcache.NewMemCache(rt, cacheMaxConcurrency, ghcache.RequestThrottlingTimes{}), nil
+	case CacheModeDisk:
+		if options.CacheDir == "" {
+			return nil, fmt.Errorf("cache mode %s requires a cache directory", CacheModeDisk)
+		}
+		sizeGB := options.CacheSizeGB
+		if sizeGB <= 0 {
+			sizeGB = 1
+		}
+		return ghcache.NewDiskCache(rt, options.CacheDir, sizeGB, cacheMaxConcurrency, false, cachePruneInterval, ghcache.RequestThrottlingTimes{}), nil
+	default:
+		return nil, fmt.Errorf("unknown cache mode %q, valid modes are %q and %q", options.CacheMode, CacheModeMemory, CacheModeDisk)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestClientCache(t *testing.T) {
+	var requests, revalidations int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidations++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `[{"name": "lgtm"}]`)
+	}))
+	defer ts.Close()
+
+	for _, mode := range []string{CacheModeMemory, CacheModeDisk} {
+		t.Run(mode, func(t *testing.T) {
+			requests, revalidations = 0, 0
+			_, _, c, err := NewClientFromOptions(logrus.Fields{}, ClientOptions{
+				GetToken:  func() []byte { return []byte("token") },
+				Bases:     []string{ts.URL},
+				CacheMode: mode,
+				CacheDir:  t.TempDir(),
+			})
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			for i := 0; i < 2; i++ {
+				labels, err := c.GetIssueLabels("org", "repo", 1)
+				if err != nil {
+					t.Fatalf("failed to get labels: %v", err)
+				}
+				if len(labels) != 1 || labels[0].Name != "lgtm" {
+					t.Errorf("expected the cached labels, got %v", labels)
+				}
+			}
+			if requests != 2 || revalidations != 1 {
+				t.Errorf("expected the second of 2 requests to be revalidated, got %d requests and %d revalidations", requests, revalidations)
+			}
+		})
+	}
+}
+
+func TestClientCacheInvalidMode(t *testing.T) {
+	if _, _, _, err := NewClientFromOptions(logrus.Fields{}, ClientOptions{CacheMode: "redis"}); err == nil {
+		t.Error("expected an error for an unknown cache mode")
+	}
+	if _, _, _, err := NewClientFromOptions(logrus.Fields{}, ClientOptions{CacheMode: CacheModeDisk}); err == nil {
+		t.Error("expected an error for a disk cache without directory")
+	}
+}
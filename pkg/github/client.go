@@ -89,6 +89,18 @@ type HookClient interface {
 	AcceptUserOrgInvitation(org string) error
 }
 
+// RulesetClient interface for ruleset related API actions
+type RulesetClient interface {
+	ListOrgRulesets(org string) ([]Ruleset, error)
+	ListRepoRulesets(org, repo string) ([]Ruleset, error)
+	GetOrgRuleset(org string, id int) (*Ruleset, error)
+	GetRepoRuleset(org, repo string, id int) (*Ruleset, error)
+	CreateOrgRuleset(org string, ruleset Ruleset) (int, error)
+	CreateRepoRuleset(org, repo string, ruleset Ruleset) (int, error)
+	UpdateOrgRuleset(org string, id int, ruleset Ruleset) error
+	UpdateRepoRuleset(org, repo string, id int, ruleset Ruleset) error
+}
+
 // CommentClient interface for comment related API actions
 type CommentClient interface {
 	CreateComment(org, repo string, number int, comment string) error
@@ -100,6 +112,7 @@ type CommentClient interface {
 	CreateCommentReaction(org, repo string, id int, reaction string) error
 	DeleteStaleComments(org, repo string, number int, comments []IssueComment, isStale func(IssueComment) bool) error
 	DeleteStaleCommentsWithContext(ctx context.Context, org, repo string, number int, comments []IssueComment, isStale func(IssueComment) bool) error
+	CreateDiscussionComment(org, discussionNodeID, replyToNodeID, body string) (string, error)
 }
 
 // IssueClient interface for issue related API actions
@@ -144,6 +157,7 @@ type PullRequestClient interface {
 	IsMergeable(org, repo string, number int, SHA string) (bool, error)
 	ListPullRequestCommits(org, repo string, number int) ([]RepositoryCommit, error)
 	UpdatePullRequestBranch(org, repo string, number int, expectedHeadSha *string) error
+	EnqueuePullRequest(org, pullRequestNodeID string) error
 }
 
 // CommitClient interface for commit related API actions
@@ -152,12 +166,16 @@ type CommitClient interface {
 	CreateStatusWithContext(ctx context.Context, org, repo, SHA string, s Status) error
 	ListStatuses(org, repo, ref string) ([]Status, error)
 	GetSingleCommit(org, repo, SHA string) (RepositoryCommit, error)
+	CompareCommits(org, repo, base, head string) ([]CommitFile, error)
 	GetCombinedStatus(org, repo, ref string) (*CombinedStatus, error)
 	ListCheckRuns(org, repo, ref string) (*CheckRunList, error)
 	GetRef(org, repo, ref string) (string, error)
 	DeleteRef(org, repo, ref string) error
+	UpdateRef(org, repo, ref, sha string, force bool) error
 	ListFileCommits(org, repo, path string) ([]RepositoryCommit, error)
 	CreateCheckRun(org, repo string, checkRun CheckRun) error
+	UpdateCheckRun(org, repo string, checkRunId int64, checkRun CheckRun) error
+	ListCheckSuites(org, repo, ref string) (*CheckSuiteList, error)
 }
 
 // RepositoryClient interface for repository related API actions
@@ -188,6 +206,12 @@ type RepositoryClient interface {
 	ListRepoTeams(org, repo string) ([]Team, error)
 	CreateRepo(owner string, isUser bool, repo RepoCreateRequest) (*FullRepo, error)
 	UpdateRepo(owner, name string, repo RepoUpdateRequest) (*FullRepo, error)
+	ReplaceRepoTopics(org, repo string, topics []string) error
+	GetVulnerabilityAlerts(org, repo string) (bool, error)
+	SetVulnerabilityAlerts(org, repo string, enabled bool) error
+	ListAutolinks(org, repo string) ([]Autolink, error)
+	CreateAutolink(org, repo string, autolink Autolink) (int, error)
+	DeleteAutolink(org, repo string, id int) error
 }
 
 // TeamClient interface for team related API actions
@@ -231,6 +255,11 @@ type ProjectClient interface {
 	GetColumnProjectCard(org string, columnID int, issueURL string) (*ProjectCard, error)
 	MoveProjectCard(org string, projectCardID int, newColumnID int) error
 	DeleteProjectCard(org string, projectCardID int) error
+	AddProjectV2Item(org, projectNodeID, contentNodeID string) (string, error)
+	DeleteProjectV2Item(org, projectNodeID, itemNodeID string) error
+	SetProjectV2ItemSingleSelectValue(org, projectNodeID, itemNodeID, fieldNodeID, optionID string) error
+	GetOrgProjectsV2(org string) ([]ProjectV2, error)
+	GetIssueProjectV2Items(org, repo string, number int) (string, []ProjectV2ContentItem, error)
 }
 
 // MilestoneClient interface for milestone related API actions
@@ -262,6 +291,7 @@ type Client interface {
 	MilestoneClient
 	UserClient
 	HookClient
+	RulesetClient
 	ListAppInstallations() ([]AppInstallation, error)
 	IsAppInstalled(org, repo string) (bool, error)
 	UsesAppAuth() bool
@@ -271,6 +301,7 @@ type Client interface {
 	GetFailedActionRunsByHeadBranch(org, repo, branchName, headSHA string) ([]WorkflowRun, error)
 
 	Throttle(hourlyTokens, burst int, org ...string) error
+	Paginator
 	QueryWithGitHubAppsSupport(ctx context.Context, q interface{}, vars map[string]interface{}, org string) error
 	MutateWithGitHubAppsSupport(ctx context.Context, m interface{}, input githubql.Input, vars map[string]interface{}, org string) error
 
@@ -312,12 +343,25 @@ type delegate struct {
 	dry          bool
 	fake         bool
 	usesAppsAuth bool
+	graphQLReads bool
 	throttle     ghThrottler
 	getToken     func() []byte
 	censor       func([]byte) []byte
 
+	// installationToken mints GitHub App installation tokens, it is nil
+	// unless the client uses apps auth.
+	installationToken func(org string) (string, time.Time, error)
+	// appTokenSource provides the installation tokens and the app of a
+	// client that authenticates without the private key of the app.
+	appTokenSource AppTokenSource
+
 	mut      sync.Mutex // protects botName and email
 	userData *UserData
+
+	secondaryRateLimiter secondaryRateLimiter
+
+	// hosts holds the clients for the orgs on additional hosts.
+	hosts map[string]*client
 }
 
 type UserData struct {
@@ -476,6 +520,10 @@ func (t *ghThrottler) forUserAgent(userAgent string) gqlClient {
 // allowing burst tokens.
 func (c *client) Throttle(hourlyTokens, burst int, orgs ...string) error {
 	if len(orgs) > 0 {
+		var err error
+		if orgs, err = c.throttleHosts(hourlyTokens, burst, orgs); err != nil || len(orgs) == 0 {
+			return err
+		}
 		if !c.usesAppsAuth {
 			return errors.New("passing an org to the throttler is only allowed when using github apps auth")
 		}
@@ -497,6 +545,10 @@ type ClientOptions struct {
 	GetToken      func() []byte
 	AppID         string
 	AppPrivateKey func() *rsa.PrivateKey
+	// AppTokenSource authenticates the client as a GitHub App with the
+	// installation tokens of the source, e.g. a token broker, instead of
+	// minting them with AppID and AppPrivateKey.
+	AppTokenSource AppTokenSource
 
 	// the following fields determine which server we talk to
 	GraphqlEndpoint string
@@ -507,6 +559,17 @@ type ClientOptions struct {
 	MaxRetries, Max404Retries                  int
 
 	DryRun bool
+	// GraphQLReads makes the client use GraphQL for some frequent reads,
+	// falling back to REST if a GraphQL request fails.
+	GraphQLReads bool
+	// CacheMode enables a conditional request cache in the client, see the
+	// CacheMode constants. CacheDir and CacheSizeGB configure the disk cache.
+	CacheMode   string
+	CacheDir    string
+	CacheSizeGB int
+	// AdditionalHosts are GitHub hosts besides the one configured above that
+	// serve some of the orgs.
+	AdditionalHosts []HostOptions
 	// BaseRoundTripper is the last RoundTripper to be called. Used for testing, gets defaulted to http.DefaultTransport
 	BaseRoundTripper http.RoundTripper
 }
@@ -585,6 +648,11 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 	if options.BaseRoundTripper == nil {
 		options.BaseRoundTripper = http.DefaultTransport
 	}
+	cachingTransport, err := cachingRoundTripper(options.BaseRoundTripper, options)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	options.BaseRoundTripper = cachingTransport
 
 	httpClient := &http.Client{
 		Transport: options.BaseRoundTripper,
@@ -610,7 +678,8 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 			getToken:      options.GetToken,
 			censor:        options.Censor,
 			dry:           options.DryRun,
-			usesAppsAuth:  options.AppID != "",
+			usesAppsAuth:  options.AppID != "" || options.AppTokenSource != nil,
+			graphQLReads:  options.GraphQLReads,
 			maxRetries:    options.MaxRetries,
 			max404Retries: options.Max404Retries,
 			initialDelay:  options.InitialDelay,
@@ -624,13 +693,16 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 
 	var tokenGenerator func(_ string) (string, error)
 	var userGenerator func() (string, error)
-	if options.AppID != "" {
+	if c.usesAppsAuth {
 		appsTransport, err := newAppsRoundTripper(options.AppID, options.AppPrivateKey, options.BaseRoundTripper, c, options.Bases)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to construct apps auth roundtripper: %w", err)
 		}
+		appsTransport.tokenSource = options.AppTokenSource
+		c.appTokenSource = options.AppTokenSource
 		httpClient.Transport = appsTransport
 		graphQLTransport.upstream = appsTransport
+		c.installationToken = appsTransport.installationTokenFor
 
 		// Use github apps auth for git actions
 		// https://docs.github.com/en/free-pro-team@latest/developers/apps/authenticating-with-github-apps#http-based-git-access-by-an-installation=
@@ -655,6 +727,21 @@ func NewClientFromOptions(fields logrus.Fields, options ClientOptions) (TokenGen
 		}
 	}
 
+	if len(options.AdditionalHosts) > 0 {
+		hosts, hostTokenGenerators, err := newHostClients(fields, options)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.hosts = hosts
+		defaultTokenGenerator := tokenGenerator
+		tokenGenerator = func(org string) (string, error) {
+			if hostTokenGenerator, ok := hostTokenGenerators[strings.ToLower(org)]; ok {
+				return hostTokenGenerator(org)
+			}
+			return defaultTokenGenerator(org)
+		}
+	}
+
 	return tokenGenerator, userGenerator, c, nil
 }
 
@@ -932,6 +1019,9 @@ func (c *client) requestRetry(method, path, accept, org string, body interface{}
 }
 
 func (c *client) requestRetryWithContext(ctx context.Context, method, path, accept, org string, body interface{}) (*http.Response, error) {
+	if host := c.hostFor(org); host != nil {
+		return host.requestRetryWithContext(ctx, method, path, accept, org, body)
+	}
 	var hostIndex int
 	var resp *http.Response
 	var err error
@@ -940,6 +1030,7 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 		if retries > 0 && resp != nil {
 			resp.Body.Close()
 		}
+		c.secondaryRateLimiter.wait(c.secondaryRateLimitKey(org), c.time)
 		resp, err = c.doRequest(ctx, method, c.bases[hostIndex]+path, accept, org, body)
 		if err == nil {
 			if resp.StatusCode == 404 && retries < c.max404Retries {
@@ -952,7 +1043,7 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 				c.logger.WithField("backoff", backoff.String()).Debug("Retrying 404")
 				c.time.Sleep(backoff)
 				backoff *= 2
-			} else if resp.StatusCode == 403 {
+			} else if resp.StatusCode == 403 || resp.StatusCode == 429 {
 				if resp.Header.Get("X-RateLimit-Remaining") == "0" {
 					// If we are out of API tokens, sleep first. The X-RateLimit-Reset
 					// header tells us the time at which we can request again.
@@ -974,27 +1065,23 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 						resp.Body.Close()
 						break
 					}
-				} else if rawTime := resp.Header.Get("Retry-After"); rawTime != "" && rawTime != "0" {
-					// If we are getting abuse rate limited, we need to wait or
-					// else we risk continuing to make the situation worse
-					var t int
-					if t, err = strconv.Atoi(rawTime); err == nil {
-						// Sleep an extra second plus how long GitHub wants us to
-						// sleep. If it's going to take too long, then break.
-						sleepTime := time.Duration(t+1) * time.Second
-						if sleepTime < c.maxSleepTime {
-							c.logger.WithField("backoff", sleepTime.String()).WithField("path", path).Debug("Retrying after abuse ratelimit reset")
-							c.time.Sleep(sleepTime)
-						} else {
-							err = fmt.Errorf("sleep time for abuse rate limit exceeds max sleep time (%v > %v)", sleepTime, c.maxSleepTime)
-							resp.Body.Close()
-							break
-						}
-					} else {
-						err = fmt.Errorf("failed to parse abuse rate limit wait time %q: %w", rawTime, err)
+				} else if secondary, retryAfter, parseErr := secondaryRateLimit(resp); secondary {
+					// If we are getting secondary rate limited, all requests of
+					// the installation need to wait or else we risk continuing
+					// to make the situation worse. The wait happens before the
+					// next attempt.
+					if parseErr != nil {
+						err = parseErr
 						resp.Body.Close()
 						break
 					}
+					if retryAfter >= c.maxSleepTime {
+						err = fmt.Errorf("sleep time for abuse rate limit exceeds max sleep time (%v > %v)", retryAfter, c.maxSleepTime)
+						resp.Body.Close()
+						break
+					}
+					sleepTime := c.secondaryRateLimiter.limited(c.secondaryRateLimitKey(org), retryAfter, c.maxSleepTime)
+					c.logger.WithField("backoff", sleepTime.String()).WithField("path", path).Debug("Retrying after secondary rate limit")
 				} else {
 					acceptedScopes := resp.Header.Get("X-Accepted-OAuth-Scopes")
 					authorizedScopes := resp.Header.Get("X-OAuth-Scopes")
@@ -1021,6 +1108,7 @@ func (c *client) requestRetryWithContext(ctx context.Context, method, path, acce
 				}
 			} else if resp.StatusCode < 500 {
 				// Normal, happy case.
+				c.secondaryRateLimiter.succeeded(c.secondaryRateLimitKey(org))
 				break
 			} else {
 				// Retry 500 after a break.
@@ -1162,7 +1250,13 @@ func init() {
 // Not thread-safe - callers need to hold c.mut.
 func (c *client) getUserData(ctx context.Context) error {
 	if c.delegate.usesAppsAuth {
-		resp, err := c.GetAppWithContext(ctx)
+		var resp *App
+		var err error
+		if c.appTokenSource != nil {
+			resp, err = c.appTokenSource.GetApp()
+		} else {
+			resp, err = c.GetAppWithContext(ctx)
+		}
 		if err != nil {
 			return err
 		}
@@ -1876,11 +1970,27 @@ func (c *client) readPaginatedResultsWithValues(path string, values url.Values,
 }
 
 func (c *client) readPaginatedResultsWithValuesWithContext(ctx context.Context, path string, values url.Values, accept, org string, newObj func() interface{}, accumulate func(interface{})) error {
+	return c.forEachPage(ctx, path, values, accept, org, func(page []byte) (bool, error) {
+		obj := newObj()
+		if err := json.Unmarshal(page, obj); err != nil {
+			return false, err
+		}
+		accumulate(obj)
+		return true, nil
+	})
+}
+
+// forEachPage requests the pages of a paginated list and passes their bodies
+// to handlePage until it returns false or an error, or there are no more pages.
+func (c *client) forEachPage(ctx context.Context, path string, values url.Values, accept, org string, handlePage func(page []byte) (bool, error)) error {
 	pagedPath := path
 	if len(values) > 0 {
 		pagedPath += "?" + values.Encode()
 	}
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		resp, err := c.requestRetryWithContext(ctx, http.MethodGet, pagedPath, accept, org, nil)
 		if err != nil {
 			return err
@@ -1895,13 +2005,10 @@ func (c *client) readPaginatedResultsWithValuesWithContext(ctx context.Context,
 			return err
 		}
 
-		obj := newObj()
-		if err := json.Unmarshal(b, obj); err != nil {
+		if more, err := handlePage(b); err != nil || !more {
 			return err
 		}
 
-		accumulate(obj)
-
 		link := parseLinks(resp.Header.Get("Link"))["next"]
 		if link == "" {
 			break
@@ -2029,6 +2136,13 @@ func (c *client) GetPullRequest(org, repo string, number int) (*PullRequest, err
 	durationLogger := c.log("GetPullRequest", org, repo, number)
 	defer durationLogger()
 
+	var pullRequest *PullRequest
+	if c.readWithGraphQL("GetPullRequest", func() (err error) {
+		pullRequest, err = c.getPullRequestGraphQL(org, repo, number)
+		return err
+	}) {
+		return pullRequest, nil
+	}
 	var pr PullRequest
 	_, err := c.request(&request{
 		// allow the description and draft fields
@@ -2387,8 +2501,14 @@ func (c *client) ListReviews(org, repo string, number int) ([]Review, error) {
 	if c.fake {
 		return nil, nil
 	}
-	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews", org, repo, number)
 	var reviews []Review
+	if c.readWithGraphQL("ListReviews", func() (err error) {
+		reviews, err = c.listReviewsGraphQL(org, repo, number)
+		return err
+	}) {
+		return reviews, nil
+	}
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews", org, repo, number)
 	err := c.readPaginatedResults(
 		path,
 		acceptNone,
@@ -2520,6 +2640,134 @@ func (c *client) UpdateRepo(owner, name string, repo RepoUpdateRequest) (*FullRe
 	return &retRepo, err
 }
 
+// ReplaceRepoTopics replaces all topics of org/repo. An empty list removes
+// all topics.
+//
+// See https://docs.github.com/en/rest/repos/repos#replace-all-repository-topics
+func (c *client) ReplaceRepoTopics(org, repo string, topics []string) error {
+	durationLogger := c.log("ReplaceRepoTopics", org, repo, topics)
+	defer durationLogger()
+
+	if topics == nil {
+		topics = []string{}
+	}
+	_, err := c.request(&request{
+		method:      http.MethodPut,
+		path:        fmt.Sprintf("/repos/%s/%s/topics", org, repo),
+		org:         org,
+		requestBody: map[string][]string{"names": topics},
+		exitCodes:   []int{200},
+	}, nil)
+	return err
+}
+
+// GetVulnerabilityAlerts returns whether dependency vulnerability alerts are
+// enabled for org/repo.
+//
+// See https://docs.github.com/en/rest/repos/repos#check-if-vulnerability-alerts-are-enabled-for-a-repository
+func (c *client) GetVulnerabilityAlerts(org, repo string) (bool, error) {
+	durationLogger := c.log("GetVulnerabilityAlerts", org, repo)
+	defer durationLogger()
+
+	code, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/repos/%s/%s/vulnerability-alerts", org, repo),
+		org:       org,
+		exitCodes: []int{204, 404},
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+	return code == 204, nil
+}
+
+// SetVulnerabilityAlerts enables or disables dependency vulnerability alerts
+// for org/repo.
+//
+// See https://docs.github.com/en/rest/repos/repos#enable-vulnerability-alerts
+func (c *client) SetVulnerabilityAlerts(org, repo string, enabled bool) error {
+	durationLogger := c.log("SetVulnerabilityAlerts", org, repo, enabled)
+	defer durationLogger()
+
+	method := http.MethodPut
+	if !enabled {
+		method = http.MethodDelete
+	}
+	_, err := c.request(&request{
+		method:    method,
+		path:      fmt.Sprintf("/repos/%s/%s/vulnerability-alerts", org, repo),
+		org:       org,
+		exitCodes: []int{204},
+	}, nil)
+	return err
+}
+
+// ListAutolinks returns the autolink references of org/repo.
+//
+// See https://docs.github.com/en/rest/repos/autolinks#get-all-autolinks-of-a-repository
+func (c *client) ListAutolinks(org, repo string) ([]Autolink, error) {
+	durationLogger := c.log("ListAutolinks", org, repo)
+	defer durationLogger()
+
+	var ret []Autolink
+	err := c.readPaginatedResults(
+		fmt.Sprintf("/repos/%s/%s/autolinks", org, repo),
+		acceptNone,
+		org,
+		func() interface{} {
+			return &[]Autolink{}
+		},
+		func(obj interface{}) {
+			ret = append(ret, *(obj.(*[]Autolink))...)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// CreateAutolink creates an autolink reference for org/repo and returns its ID.
+//
+// See https://docs.github.com/en/rest/repos/autolinks#create-an-autolink-reference-for-a-repository
+func (c *client) CreateAutolink(org, repo string, autolink Autolink) (int, error) {
+	durationLogger := c.log("CreateAutolink", org, repo, autolink.KeyPrefix)
+	defer durationLogger()
+
+	if c.dry {
+		return -1, nil
+	}
+	autolink.ID = 0
+	var ret Autolink
+	_, err := c.request(&request{
+		method:      http.MethodPost,
+		path:        fmt.Sprintf("/repos/%s/%s/autolinks", org, repo),
+		org:         org,
+		requestBody: &autolink,
+		exitCodes:   []int{201},
+	}, &ret)
+	if err != nil {
+		return 0, err
+	}
+	return ret.ID, nil
+}
+
+// DeleteAutolink deletes the autolink reference with the given ID from org/repo.
+//
+// See https://docs.github.com/en/rest/repos/autolinks#delete-an-autolink-reference-from-a-repository
+func (c *client) DeleteAutolink(org, repo string, id int) error {
+	durationLogger := c.log("DeleteAutolink", org, repo, id)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:    http.MethodDelete,
+		path:      fmt.Sprintf("/repos/%s/%s/autolinks/%d", org, repo, id),
+		org:       org,
+		exitCodes: []int{204},
+	}, nil)
+	return err
+}
+
 // GetRepos returns all repos in an org.
 //
 // This call uses multiple API tokens when results are paginated.
@@ -2575,6 +2823,26 @@ func (c *client) GetSingleCommit(org, repo, SHA string) (RepositoryCommit, error
 	return commit, err
 }
 
+// CompareCommits returns the files changed between the merge base of the base
+// and head commits and the head commit. GitHub lists at most 300 files.
+//
+// See https://docs.github.com/en/rest/commits/commits#compare-two-commits
+func (c *client) CompareCommits(org, repo, base, head string) ([]CommitFile, error) {
+	durationLogger := c.log("CompareCommits", org, repo, base, head)
+	defer durationLogger()
+
+	var comparison struct {
+		Files []CommitFile `json:"files"`
+	}
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("/repos/%s/%s/compare/%s...%s", org, repo, base, head),
+		org:       org,
+		exitCodes: []int{200},
+	}, &comparison)
+	return comparison.Files, err
+}
+
 // GetBranches returns all branches in the repo.
 //
 // If onlyProtected is true it will only return repos with protection enabled,
@@ -2690,6 +2958,156 @@ func (c *client) UpdateBranchProtection(org, repo, branch string, config BranchP
 	return err
 }
 
+func rulesetsPath(org string, repo *string) string {
+	if repo != nil {
+		return fmt.Sprintf("/repos/%s/%s/rulesets", org, *repo)
+	}
+	return fmt.Sprintf("/orgs/%s/rulesets", org)
+}
+
+func (c *client) listRulesets(org string, repo *string) ([]Ruleset, error) {
+	var ret []Ruleset
+	values := url.Values{
+		"per_page": []string{"100"},
+	}
+	if repo != nil {
+		// Organization rulesets apply to repos too, but are managed at the org level.
+		values.Set("includes_parents", "false")
+	}
+	err := c.readPaginatedResultsWithValues(
+		rulesetsPath(org, repo),
+		values,
+		acceptNone,
+		org,
+		func() interface{} {
+			return &[]Ruleset{}
+		},
+		func(obj interface{}) {
+			ret = append(ret, *(obj.(*[]Ruleset))...)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ListOrgRulesets returns the rulesets of the org, without their rules and conditions.
+//
+// See https://docs.github.com/en/rest/orgs/rules#get-all-organization-repository-rulesets
+func (c *client) ListOrgRulesets(org string) ([]Ruleset, error) {
+	durationLogger := c.log("ListOrgRulesets", org)
+	defer durationLogger()
+	return c.listRulesets(org, nil)
+}
+
+// ListRepoRulesets returns the rulesets defined on org/repo, without their
+// rules and conditions. Rulesets inherited from the org are not listed.
+//
+// See https://docs.github.com/en/rest/repos/rules#get-all-repository-rulesets
+func (c *client) ListRepoRulesets(org, repo string) ([]Ruleset, error) {
+	durationLogger := c.log("ListRepoRulesets", org, repo)
+	defer durationLogger()
+	return c.listRulesets(org, &repo)
+}
+
+func (c *client) getRuleset(org string, repo *string, id int) (*Ruleset, error) {
+	var ret Ruleset
+	_, err := c.request(&request{
+		method:    http.MethodGet,
+		path:      fmt.Sprintf("%s/%d", rulesetsPath(org, repo), id),
+		org:       org,
+		exitCodes: []int{200},
+	}, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// GetOrgRuleset returns the org ruleset with the given ID.
+//
+// See https://docs.github.com/en/rest/orgs/rules#get-an-organization-repository-ruleset
+func (c *client) GetOrgRuleset(org string, id int) (*Ruleset, error) {
+	durationLogger := c.log("GetOrgRuleset", org, id)
+	defer durationLogger()
+	return c.getRuleset(org, nil, id)
+}
+
+// GetRepoRuleset returns the org/repo ruleset with the given ID.
+//
+// See https://docs.github.com/en/rest/repos/rules#get-a-repository-ruleset
+func (c *client) GetRepoRuleset(org, repo string, id int) (*Ruleset, error) {
+	durationLogger := c.log("GetRepoRuleset", org, repo, id)
+	defer durationLogger()
+	return c.getRuleset(org, &repo, id)
+}
+
+func (c *client) createRuleset(org string, repo *string, ruleset Ruleset) (int, error) {
+	if c.dry {
+		return -1, nil
+	}
+	var ret Ruleset
+	_, err := c.request(&request{
+		method:      http.MethodPost,
+		path:        rulesetsPath(org, repo),
+		org:         org,
+		requestBody: &ruleset,
+		exitCodes:   []int{201},
+	}, &ret)
+	if err != nil {
+		return 0, err
+	}
+	return ret.ID, nil
+}
+
+// CreateOrgRuleset creates a new ruleset for the org and returns its ID.
+//
+// See https://docs.github.com/en/rest/orgs/rules#create-an-organization-repository-ruleset
+func (c *client) CreateOrgRuleset(org string, ruleset Ruleset) (int, error) {
+	durationLogger := c.log("CreateOrgRuleset", org, ruleset.Name)
+	defer durationLogger()
+	return c.createRuleset(org, nil, ruleset)
+}
+
+// CreateRepoRuleset creates a new ruleset for org/repo and returns its ID.
+//
+// See https://docs.github.com/en/rest/repos/rules#create-a-repository-ruleset
+func (c *client) CreateRepoRuleset(org, repo string, ruleset Ruleset) (int, error) {
+	durationLogger := c.log("CreateRepoRuleset", org, repo, ruleset.Name)
+	defer durationLogger()
+	return c.createRuleset(org, &repo, ruleset)
+}
+
+func (c *client) updateRuleset(org string, repo *string, id int, ruleset Ruleset) error {
+	_, err := c.request(&request{
+		method:      http.MethodPut,
+		path:        fmt.Sprintf("%s/%d", rulesetsPath(org, repo), id),
+		org:         org,
+		requestBody: &ruleset,
+		exitCodes:   []int{200},
+	}, nil)
+	return err
+}
+
+// UpdateOrgRuleset replaces the org ruleset with the given ID.
+//
+// See https://docs.github.com/en/rest/orgs/rules#update-an-organization-repository-ruleset
+func (c *client) UpdateOrgRuleset(org string, id int, ruleset Ruleset) error {
+	durationLogger := c.log("UpdateOrgRuleset", org, id)
+	defer durationLogger()
+	return c.updateRuleset(org, nil, id, ruleset)
+}
+
+// UpdateRepoRuleset replaces the org/repo ruleset with the given ID.
+//
+// See https://docs.github.com/en/rest/repos/rules#update-a-repository-ruleset
+func (c *client) UpdateRepoRuleset(org, repo string, id int, ruleset Ruleset) error {
+	durationLogger := c.log("UpdateRepoRuleset", org, repo, id)
+	defer durationLogger()
+	return c.updateRuleset(org, &repo, id, ruleset)
+}
+
 // AddRepoLabel adds a defined label given org/repo
 //
 // See https://developer.github.com/v3/issues/labels/#create-a-label
@@ -2808,6 +3226,13 @@ func (c *client) GetIssueLabels(org, repo string, number int) ([]Label, error) {
 	durationLogger := c.log("GetIssueLabels", org, repo, number)
 	defer durationLogger()
 
+	var labels []Label
+	if c.readWithGraphQL("GetIssueLabels", func() (err error) {
+		labels, err = c.getIssueLabelsGraphQL(org, repo, number)
+		return err
+	}) {
+		return labels, nil
+	}
 	return c.getLabels(fmt.Sprintf("/repos/%s/%s/issues/%d/labels", org, repo, number), org)
 }
 
@@ -3376,6 +3801,25 @@ func (c *client) DeleteRef(org, repo, ref string) error {
 	return err
 }
 
+// UpdateRef points the given ref, such as "heads/release-1.30", to the given
+// SHA. Unless force is set, GitHub rejects the update if it is not a
+// fast-forward.
+//
+// See https://docs.github.com/en/rest/git/refs#update-a-reference
+func (c *client) UpdateRef(org, repo, ref, sha string, force bool) error {
+	durationLogger := c.log("UpdateRef", org, repo, ref, sha, force)
+	defer durationLogger()
+
+	_, err := c.request(&request{
+		method:      http.MethodPatch,
+		path:        fmt.Sprintf("/repos/%s/%s/git/refs/%s", org, repo, ref),
+		org:         org,
+		requestBody: map[string]interface{}{"sha": sha, "force": force},
+		exitCodes:   []int{200},
+	}, nil)
+	return err
+}
+
 // ListFileCommits returns the commits for this file path.
 //
 // See https://developer.github.com/v3/repos/#list-commits
@@ -3517,6 +3961,9 @@ func (c *client) GetFile(org, repo, filepath, commit string) ([]byte, error) {
 
 // QueryWithGitHubAppsSupport runs a GraphQL query using shurcooL/githubql's client.
 func (c *client) QueryWithGitHubAppsSupport(ctx context.Context, q interface{}, vars map[string]interface{}, org string) error {
+	if host := c.hostFor(org); host != nil {
+		return host.QueryWithGitHubAppsSupport(ctx, q, vars, org)
+	}
 	// Don't log query here because Query is typically called multiple times to get all pages.
 	// Instead log once per search and include total search cost.
 	return c.gqlc.QueryWithGitHubAppsSupport(ctx, q, vars, org)
@@ -3524,6 +3971,9 @@ func (c *client) QueryWithGitHubAppsSupport(ctx context.Context, q interface{},
 
 // MutateWithGitHubAppsSupport runs a GraphQL mutation using shurcooL/githubql's client.
 func (c *client) MutateWithGitHubAppsSupport(ctx context.Context, m interface{}, input githubql.Input, vars map[string]interface{}, org string) error {
+	if host := c.hostFor(org); host != nil {
+		return host.MutateWithGitHubAppsSupport(ctx, m, input, vars, org)
+	}
 	return c.gqlc.MutateWithGitHubAppsSupport(ctx, m, input, vars, org)
 }
 
@@ -3740,8 +4190,14 @@ func (c *client) ListTeamMembersBySlug(org, teamSlug, role string) ([]TeamMember
 	if c.fake {
 		return nil, nil
 	}
-	path := fmt.Sprintf("/orgs/%s/teams/%s/members", org, teamSlug)
 	var teamMembers []TeamMember
+	if c.readWithGraphQL("ListTeamMembersBySlug", func() (err error) {
+		teamMembers, err = c.listTeamMembersBySlugGraphQL(org, teamSlug, role)
+		return err
+	}) {
+		return teamMembers, nil
+	}
+	path := fmt.Sprintf("/orgs/%s/teams/%s/members", org, teamSlug)
 	err := c.readPaginatedResultsWithValues(
 		path,
 		url.Values{
@@ -4660,6 +5116,49 @@ func (c *client) CreateCheckRun(org, repo string, checkRun CheckRun) error {
 	return nil
 }
 
+// UpdateCheckRun updates a check run, e.g. to complete it or to add more
+// annotations.
+//
+// See https://docs.github.com/en/rest/checks/runs#update-a-check-run
+func (c *client) UpdateCheckRun(org, repo string, checkRunId int64, checkRun CheckRun) error {
+	durationLogger := c.log("UpdateCheckRun", org, repo, checkRunId, checkRun)
+	defer durationLogger()
+	_, err := c.request(&request{
+		method:      http.MethodPatch,
+		path:        fmt.Sprintf("/repos/%s/%s/check-runs/%d", org, repo, checkRunId),
+		org:         org,
+		requestBody: &checkRun,
+		exitCodes:   []int{200},
+	}, nil)
+	return err
+}
+
+// ListCheckSuites lists all check suites for the given ref
+//
+// See https://docs.github.com/en/rest/checks/suites#list-check-suites-for-a-git-reference
+func (c *client) ListCheckSuites(org, repo, ref string) (*CheckSuiteList, error) {
+	durationLogger := c.log("ListCheckSuites", org, repo, ref)
+	defer durationLogger()
+
+	var checkSuiteList CheckSuiteList
+	if err := c.readPaginatedResults(
+		fmt.Sprintf("/repos/%s/%s/commits/%s/check-suites", org, repo, ref),
+		"",
+		org,
+		func() interface{} {
+			return &CheckSuiteList{}
+		},
+		func(obj interface{}) {
+			cs := *(obj.(*CheckSuiteList))
+			cs.CheckSuites = append(checkSuiteList.CheckSuites, cs.CheckSuites...)
+			checkSuiteList = cs
+		},
+	); err != nil {
+		return nil, err
+	}
+	return &checkSuiteList, nil
+}
+
 // Simple function to check if GitHub App Authentication is being used
 func (c *client) UsesAppAuth() bool {
 	return c.delegate.usesAppsAuth
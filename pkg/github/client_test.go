@@ -132,6 +132,84 @@ func TestAbuseRateLimit(t *testing.T) {
 	}
 }
 
+func TestSecondaryRateLimit(t *testing.T) {
+	testCases := []struct {
+		name          string
+		status        int
+		header        string
+		body          string
+		expectedSleep time.Duration
+	}{
+		{
+			name:          "403 with secondary rate limit message",
+			status:        http.StatusForbidden,
+			body:          `{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`,
+			expectedSleep: secondaryRateLimitBackoff,
+		},
+		{
+			name:          "429 without Retry-After",
+			status:        http.StatusTooManyRequests,
+			expectedSleep: secondaryRateLimitBackoff,
+		},
+		{
+			name:          "429 with Retry-After",
+			status:        http.StatusTooManyRequests,
+			header:        "5",
+			expectedSleep: 6 * time.Second,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := &testTime{now: time.Now()}
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.slept == 0 {
+					if tc.header != "" {
+						w.Header().Set("Retry-After", tc.header)
+					}
+					http.Error(w, tc.body, tc.status)
+				}
+			}))
+			defer ts.Close()
+			c := getClient(ts.URL)
+			c.time = tt
+			resp, err := c.requestRetry(http.MethodGet, "/", "", "", nil)
+			if err != nil {
+				t.Fatalf("Error from request: %v", err)
+			}
+			if resp.StatusCode != 200 {
+				t.Errorf("Expected status code 200, got %d", resp.StatusCode)
+			}
+			// The sleep includes the time since the test started and up to 10% jitter.
+			if tt.slept < tc.expectedSleep || tt.slept > tc.expectedSleep*12/10 {
+				t.Errorf("Expected to sleep for about %v, got %v", tc.expectedSleep, tt.slept)
+			}
+		})
+	}
+}
+
+func TestSecondaryRateLimiter(t *testing.T) {
+	var l secondaryRateLimiter
+	for i, expected := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		if wait := l.limited("org", 0, 3*time.Minute); wait != expected {
+			t.Errorf("limited response %d: expected a wait of %v, got %v", i, expected, wait)
+		}
+	}
+	l.succeeded("org")
+	if wait := l.limited("org", 0, 3*time.Minute); wait != time.Minute {
+		t.Errorf("expected the backoff to be reset after a success, got %v", wait)
+	}
+
+	tt := &testTime{now: time.Now()}
+	l.wait("other-org", tt)
+	if tt.slept != 0 {
+		t.Errorf("expected other installations not to wait, slept %v", tt.slept)
+	}
+	l.wait("org", tt)
+	if tt.slept < 2*time.Minute {
+		t.Errorf("expected to wait for the blocked installation, slept %v", tt.slept)
+	}
+}
+
 func TestRetry404(t *testing.T) {
 	tc := &testTime{now: time.Now()}
 	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -541,6 +619,37 @@ func TestDeleteRef(t *testing.T) {
 	}
 }
 
+func TestUpdateRef(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/k8s/kuber/git/refs/heads/release-1.30" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("Could not read request body: %v", err)
+		}
+		var body struct {
+			SHA   string `json:"sha"`
+			Force bool   `json:"force"`
+		}
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Errorf("Could not unmarshal request: %v", err)
+		}
+		if body.SHA != "abcdef" || body.Force {
+			t.Errorf("Unexpected request body: %s", b)
+		}
+		fmt.Fprint(w, `{"ref": "refs/heads/release-1.30", "object": {"sha": "abcdef"}}`)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	if err := c.UpdateRef("k8s", "kuber", "heads/release-1.30", "abcdef", false); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+}
+
 func TestListFileCommits(t *testing.T) {
 	githubResponse := []byte(`
 [
@@ -762,6 +871,33 @@ func TestGetSingleCommit(t *testing.T) {
 	}
 }
 
+func TestCompareCommits(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/octocat/Hello-World/compare/abc...def" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{
+			"files": [
+			  {"filename": "docs/README.md", "status": "modified"},
+			  {"filename": "main.go", "status": "added"}
+			]
+		  }`)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	files, err := c.CompareCommits("octocat", "Hello-World", "abc", "def")
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	expected := []CommitFile{{Filename: "docs/README.md", Status: "modified"}, {Filename: "main.go", Status: "added"}}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Wrong files: %v", files)
+	}
+}
+
 func TestCreateStatus(t *testing.T) {
 	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -2441,6 +2577,118 @@ func TestUpdateBranchProtection(t *testing.T) {
 	}
 }
 
+func TestListRepoRulesets(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/org/repo/rulesets" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("includes_parents"); got != "false" {
+			t.Errorf("Expected includes_parents=false, got %q", got)
+		}
+		b, err := json.Marshal([]Ruleset{{ID: 1, Name: "main", Enforcement: RulesetEnforcementActive}})
+		if err != nil {
+			t.Fatalf("Didn't expect error: %v", err)
+		}
+		fmt.Fprint(w, string(b))
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	rulesets, err := c.ListRepoRulesets("org", "repo")
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	if len(rulesets) != 1 || rulesets[0].ID != 1 || rulesets[0].Name != "main" {
+		t.Errorf("Unexpected rulesets: %+v", rulesets)
+	}
+}
+
+func TestUpdateOrgRuleset(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/orgs/org/rulesets/42" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("Could not read request body: %v", err)
+		}
+		var ruleset Ruleset
+		if err := json.Unmarshal(b, &ruleset); err != nil {
+			t.Errorf("Could not unmarshal request: %v", err)
+		}
+		if len(ruleset.Rules) != 1 || ruleset.Rules[0].Type != RulesetRuleRequiredSignatures {
+			t.Errorf("Unexpected rules: %+v", ruleset.Rules)
+		}
+		http.Error(w, "200 OK", http.StatusOK)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	err := c.UpdateOrgRuleset("org", 42, Ruleset{
+		Name:        "signed",
+		Enforcement: RulesetEnforcementActive,
+		Rules:       []RulesetRule{{Type: RulesetRuleRequiredSignatures}},
+	})
+	if err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+}
+
+func TestReplaceRepoTopics(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/org/repo/topics" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("Could not read request body: %v", err)
+		}
+		if string(b) != `{"names":[]}` {
+			t.Errorf("Unexpected request body: %s", b)
+		}
+		fmt.Fprint(w, `{"names":[]}`)
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	if err := c.ReplaceRepoTopics("org", "repo", nil); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+}
+
+func TestGetVulnerabilityAlerts(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/repos/org/enabled/vulnerability-alerts":
+			w.WriteHeader(http.StatusNoContent)
+		case "/repos/org/disabled/vulnerability-alerts":
+			http.Error(w, "404 Not Found", http.StatusNotFound)
+		default:
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	for repo, expected := range map[string]bool{"enabled": true, "disabled": false} {
+		enabled, err := c.GetVulnerabilityAlerts("org", repo)
+		if err != nil {
+			t.Errorf("Didn't expect error for %s: %v", repo, err)
+		}
+		if enabled != expected {
+			t.Errorf("Expected alerts enabled for %s to be %t, got %t", repo, expected, enabled)
+		}
+	}
+}
+
 func TestClearMilestone(t *testing.T) {
 	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPatch {
@@ -3448,6 +3696,65 @@ func TestCreateCheckRun(t *testing.T) {
 	}
 }
 
+func TestUpdateCheckRun(t *testing.T) {
+	checkRun := CheckRun{
+		Status:     CheckRunStatusCompleted,
+		Conclusion: CheckRunConclusionFailure,
+		Output: CheckRunOutput{
+			Title:       "lint",
+			Summary:     "1 issue",
+			Annotations: []CheckRunAnnotation{{Path: "main.go", StartLine: 1, EndLine: 1, AnnotationLevel: CheckRunAnnotationLevelFailure, Message: "unused"}},
+		},
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		if r.URL.Path != "/repos/k8s/kuber/check-runs/42" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		var cr CheckRun
+		if err := json.NewDecoder(r.Body).Decode(&cr); err != nil {
+			t.Errorf("Could not unmarshal request: %v", err)
+		} else if diff := cmp.Diff(checkRun, cr); diff != "" {
+			t.Errorf("expected checkrun differs from actual: %s", diff)
+		}
+		fmt.Fprint(w, "{}")
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	if err := c.UpdateCheckRun("k8s", "kuber", 42, checkRun); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+}
+
+func TestListCheckSuites(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Bad method: %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/repos/k8s/kuber/commits/someref/check-suites":
+			w.Header().Set("Link", fmt.Sprintf(`<blorp>; rel="first", <https://%s/someotherpath>; rel="next"`, r.Host))
+			fmt.Fprint(w, `{"total_count": 2, "check_suites": [{"id": 1, "status": "completed"}]}`)
+		case "/someotherpath":
+			fmt.Fprint(w, `{"total_count": 2, "check_suites": [{"id": 2, "status": "queued"}]}`)
+		default:
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	c := getClient(ts.URL)
+	suites, err := c.ListCheckSuites("k8s", "kuber", "someref")
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	expected := &CheckSuiteList{Total: 2, CheckSuites: []CheckSuite{{ID: 1, Status: "completed"}, {ID: 2, Status: "queued"}}}
+	if diff := cmp.Diff(expected, suites); diff != "" {
+		t.Errorf("unexpected check suites (-want +got):\n%s", diff)
+	}
+}
+
 func TestIsAppInstalled(t *testing.T) {
 	testCases := []struct {
 		name     string
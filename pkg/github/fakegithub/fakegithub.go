@@ -24,12 +24,15 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	githubql "github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fixtures"
 )
 
 const botName = "k8s-ci-robot"
@@ -60,6 +63,8 @@ type FakeClient struct {
 	CreatedStatuses            map[string][]github.Status
 	IssueEvents                map[int][]github.ListedIssueEvent
 	Commits                    map[string]github.RepositoryCommit
+	// base...head:files
+	Comparisons map[string][]github.CommitFile
 
 	// All Labels That Exist In The Repo
 	RepoLabelsExisting []string
@@ -103,6 +108,8 @@ type FakeClient struct {
 
 	// A list of refs that got deleted via DeleteRef
 	RefsDeleted []struct{ Org, Repo, Ref string }
+	// RefsUpdated maps org/repo/ref to the SHA it was last updated to
+	RefsUpdated map[string]string
 
 	// A map of repo names to projects
 	RepoProjects map[string][]github.Project
@@ -122,6 +129,12 @@ type FakeClient struct {
 	OrgRepoIssueLabels map[string][]github.Label
 	OrgProjects        map[string][]github.Project
 
+	// Maps org name to its projects (beta)
+	OrgProjectsV2 map[string][]github.ProjectV2
+	// Maps org/repo#number to the items of the issue or PR in projects (beta).
+	// The node ID of the issue or PR is its org/repo#number.
+	IssueProjectV2Items map[string][]github.ProjectV2ContentItem
+
 	// Maps org name to the list of hooks
 	OrgHooks map[string][]github.Hook
 	// Maps repo name to the list of hooks
@@ -198,6 +211,8 @@ func NewFakeClient() *FakeClient {
 		ColumnIDMap:         make(map[string]map[int]string),
 		OrgRepoIssueLabels:  make(map[string][]github.Label),
 		OrgProjects:         make(map[string][]github.Project),
+		OrgProjectsV2:       make(map[string][]github.ProjectV2),
+		IssueProjectV2Items: make(map[string][]github.ProjectV2ContentItem),
 		OrgHooks:            make(map[string][]github.Hook),
 		RepoHooks:           make(map[string][]github.Hook),
 		UserRepoInvitations: make(map[int]github.UserRepoInvitation),
@@ -497,6 +512,20 @@ func (f *FakeClient) DeleteRef(owner, repo, ref string) error {
 	return nil
 }
 
+// UpdateRef records the SHA the given ref was updated to.
+func (f *FakeClient) UpdateRef(owner, repo, ref, sha string, force bool) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.Error != nil {
+		return f.Error
+	}
+	if f.RefsUpdated == nil {
+		f.RefsUpdated = map[string]string{}
+	}
+	f.RefsUpdated[fmt.Sprintf("%s/%s/%s", owner, repo, ref)] = sha
+	return nil
+}
+
 // GetSingleCommit returns a single commit.
 func (f *FakeClient) GetSingleCommit(org, repo, SHA string) (github.RepositoryCommit, error) {
 	f.lock.RLock()
@@ -504,6 +533,13 @@ func (f *FakeClient) GetSingleCommit(org, repo, SHA string) (github.RepositoryCo
 	return f.Commits[SHA], nil
 }
 
+// CompareCommits returns the files changed between two commits.
+func (f *FakeClient) CompareCommits(org, repo, base, head string) ([]github.CommitFile, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.Comparisons[base+"..."+head], nil
+}
+
 // CreateStatus adds a status context to a commit.
 func (f *FakeClient) CreateStatus(owner, repo, SHA string, s github.Status) error {
 	return f.CreateStatusWithContext(context.Background(), owner, repo, SHA, s)
@@ -1036,6 +1072,89 @@ func (f *FakeClient) MoveProjectCard(org string, projectCardID int, newColumnID
 	return nil
 }
 
+// GetOrgProjectsV2 returns the projects (beta) of an org.
+func (f *FakeClient) GetOrgProjectsV2(org string) ([]github.ProjectV2, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.OrgProjectsV2[org], nil
+}
+
+// GetIssueProjectV2Items returns the node ID of an issue or PR and its items
+// in projects (beta).
+func (f *FakeClient) GetIssueProjectV2Items(org, repo string, number int) (string, []github.ProjectV2ContentItem, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	key := fmt.Sprintf("%s/%s#%d", org, repo, number)
+	return key, f.IssueProjectV2Items[key], nil
+}
+
+// AddProjectV2Item adds an issue or PR to a project (beta).
+func (f *FakeClient) AddProjectV2Item(org, projectNodeID, contentNodeID string) (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, item := range f.IssueProjectV2Items[contentNodeID] {
+		if item.ProjectID == projectNodeID {
+			return item.ID, nil
+		}
+	}
+	id := fmt.Sprintf("%s:%s", projectNodeID, contentNodeID)
+	f.IssueProjectV2Items[contentNodeID] = append(f.IssueProjectV2Items[contentNodeID], github.ProjectV2ContentItem{ID: id, ProjectID: projectNodeID, FieldValues: map[string]string{}})
+	return id, nil
+}
+
+// DeleteProjectV2Item removes an item from a project (beta).
+func (f *FakeClient) DeleteProjectV2Item(org, projectNodeID, itemNodeID string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for content, items := range f.IssueProjectV2Items {
+		for i, item := range items {
+			if item.ID == itemNodeID && item.ProjectID == projectNodeID {
+				f.IssueProjectV2Items[content] = append(items[:i:i], items[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("project item %s doesn't exist", itemNodeID)
+}
+
+// SetProjectV2ItemSingleSelectValue selects an option of a single-select field
+// of an item of a project (beta).
+func (f *FakeClient) SetProjectV2ItemSingleSelectValue(org, projectNodeID, itemNodeID, fieldNodeID, optionID string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var fieldName, optionName string
+	for _, project := range f.OrgProjectsV2[org] {
+		if project.ID != projectNodeID {
+			continue
+		}
+		for _, field := range project.Fields {
+			if field.ID != fieldNodeID {
+				continue
+			}
+			for _, option := range field.Options {
+				if option.ID == optionID {
+					fieldName, optionName = field.Name, option.Name
+				}
+			}
+		}
+	}
+	if fieldName == "" {
+		return fmt.Errorf("option %s of field %s doesn't exist in project %s", optionID, fieldNodeID, projectNodeID)
+	}
+	for _, items := range f.IssueProjectV2Items {
+		for i := range items {
+			if items[i].ID == itemNodeID {
+				if items[i].FieldValues == nil {
+					items[i].FieldValues = map[string]string{}
+				}
+				items[i].FieldValues[fieldName] = optionName
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("project item %s doesn't exist", itemNodeID)
+}
+
 // TeamHasMember checks if a user belongs to a team
 func (f *FakeClient) TeamHasMember(org string, teamID int, memberLogin string) (bool, error) {
 	teamMembers, _ := f.ListTeamMembers(org, teamID, github.RoleAll)
@@ -1201,3 +1320,25 @@ func (f *FakeClient) RequestReview(org, repo string, number int, logins []string
 	f.ReviewersRequested = logins
 	return nil
 }
+
+// NewReplayClient returns a real GitHub client that is served by the
+// fixtures in dir, which were recorded with ghproxy's --record-fixtures-dir.
+// This allows tests to exercise the client and its callers with real API
+// payloads. Requests without fixture fail with a 404 response.
+func NewReplayClient(dir string) (github.Client, error) {
+	interactions, err := fixtures.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixtures: %w", err)
+	}
+	_, _, client, err := github.NewClientFromOptions(logrus.Fields{}, github.ClientOptions{
+		Censor:           func(content []byte) []byte { return content },
+		GetToken:         func() []byte { return []byte("replay") },
+		GraphqlEndpoint:  "https://api.github.com/graphql",
+		Bases:            []string{"https://api.github.com"},
+		InitialDelay:     time.Millisecond,
+		MaxRetries:       1,
+		Max404Retries:    1,
+		BaseRoundTripper: fixtures.NewReplayer(interactions),
+	})
+	return client, err
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fixtures records interactions with the GitHub API into sanitized
+// fixtures and replays them, so that tests can use real API payloads.
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Redacted replaces the values of sensitive fields.
+const Redacted = "REDACTED"
+
+// sensitiveFields are the JSON fields whose values are redacted in
+// fixtures.
+var sensitiveFields = map[string]bool{
+	"token":       true,
+	"email":       true,
+	"key":         true,
+	"private_key": true,
+	"secret":      true,
+	"password":    true,
+}
+
+// preservedHeaders are the only response headers stored in fixtures. All
+// other headers, in particular all request headers, are dropped because they
+// may hold credentials.
+var preservedHeaders = []string{"Content-Type", "Link"}
+
+// Interaction is a single request to the GitHub API and its response.
+type Interaction struct {
+	Method string `json:"method"`
+	// Path is the path of the request relative to the API endpoint.
+	Path  string `json:"path"`
+	Query string `json:"query,omitempty"`
+	// RequestBody is the JSON body of the request. It distinguishes GraphQL
+	// queries, which all use the same path.
+	RequestBody json.RawMessage `json:"request_body,omitempty"`
+
+	StatusCode     int               `json:"status_code"`
+	ResponseHeader map[string]string `json:"response_header,omitempty"`
+	ResponseBody   json.RawMessage   `json:"response_body,omitempty"`
+}
+
+func (i Interaction) String() string {
+	s := i.Method + " " + i.Path
+	if i.Query != "" {
+		s += "?" + i.Query
+	}
+	return s
+}
+
+// Load reads the fixtures in dir in the order they were recorded.
+func Load(dir string) ([]Interaction, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	var interactions []Interaction
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(raw, &interaction); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", file, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	return interactions, nil
+}
+
+// Recorder is a http.RoundTripper that stores every interaction as a
+// sanitized fixture in a directory.
+type Recorder struct {
+	delegate http.RoundTripper
+	dir      string
+
+	lock  sync.Mutex
+	count int
+}
+
+// NewRecorder returns a Recorder that sends requests to delegate and writes
+// the fixtures to dir. Recording continues after the fixtures that already
+// exist in dir.
+func NewRecorder(delegate http.RoundTripper, dir string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	existing, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{delegate: delegate, dir: dir, count: len(existing)}, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	interaction := Interaction{
+		Method: req.Method,
+		Path:   normalizePath(req.URL.Path),
+		Query:  req.URL.RawQuery,
+	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		interaction.RequestBody = sanitize(body)
+	}
+
+	resp, err := r.delegate.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	interaction.StatusCode = resp.StatusCode
+	interaction.ResponseBody = sanitize(body)
+	for _, header := range preservedHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			if interaction.ResponseHeader == nil {
+				interaction.ResponseHeader = map[string]string{}
+			}
+			interaction.ResponseHeader[header] = value
+		}
+	}
+	if err := r.write(interaction); err != nil {
+		// Recording must not break the client.
+		logrus.WithError(err).WithField("request", interaction.String()).Warn("Failed to record fixture.")
+	}
+	return resp, nil
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+func (r *Recorder) write(interaction Interaction) error {
+	raw, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return err
+	}
+	r.lock.Lock()
+	r.count++
+	name := fmt.Sprintf("%05d-%s-%s.json", r.count, interaction.Method, strings.Trim(unsafeFileNameChars.ReplaceAllString(interaction.Path, "_"), "_"))
+	r.lock.Unlock()
+	return os.WriteFile(filepath.Join(r.dir, name), raw, 0644)
+}
+
+// sanitize redacts sensitive fields of a JSON document. Non-JSON bodies are
+// not stored at all.
+func sanitize(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil
+	}
+	raw, err := json.Marshal(redact(doc))
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+func redact(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, isString := value.(string); isString && sensitiveFields[strings.ToLower(key)] {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redact(value)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return doc
+}
+
+// Replayer is a http.RoundTripper that answers requests with fixtures.
+// Requests are matched by method, path, query and body. If a request was
+// recorded several times, the recorded responses are returned in order and
+// the last one is repeated. Requests without fixture get a 404 response.
+type Replayer struct {
+	lock         sync.Mutex
+	interactions map[string][]Interaction
+}
+
+// NewReplayer returns a Replayer for the interactions.
+func NewReplayer(interactions []Interaction) *Replayer {
+	r := &Replayer{interactions: map[string][]Interaction{}}
+	for _, interaction := range interactions {
+		key := matchKey(interaction.Method, interaction.Path, interaction.Query, interaction.RequestBody)
+		r.interactions[key] = append(r.interactions[key], interaction)
+	}
+	return r
+}
+
+func matchKey(method, path, query string, body json.RawMessage) string {
+	return strings.Join([]string{method, path, query, string(body)}, " ")
+}
+
+// normalizePath strips the path prefix of GitHub Enterprise endpoints, so
+// that fixtures recorded against either can be replayed against both.
+func normalizePath(path string) string {
+	if path == "/api/graphql" {
+		return "/graphql"
+	}
+	return strings.TrimPrefix(path, "/api/v3")
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body json.RawMessage
+	if req.Body != nil {
+		raw, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = sanitize(raw)
+	}
+	path := normalizePath(req.URL.Path)
+	r.lock.Lock()
+	key := matchKey(req.Method, path, req.URL.RawQuery, body)
+	candidates := r.interactions[key]
+	if len(candidates) == 0 {
+		r.lock.Unlock()
+		// Answer like GitHub does for unknown resources, so that the client
+		// surfaces the error instead of retrying.
+		message, _ := json.Marshal(map[string]string{"message": fmt.Sprintf("no fixture for %s %s?%s", req.Method, path, req.URL.RawQuery)})
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Status:     "404 Not Found",
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(message)),
+			Request:    req,
+		}, nil
+	}
+	interaction := candidates[0]
+	if len(candidates) > 1 {
+		r.interactions[key] = candidates[1:]
+	}
+	r.lock.Unlock()
+
+	resp := &http.Response{
+		StatusCode: interaction.StatusCode,
+		Status:     fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(interaction.ResponseBody)),
+		Request:    req,
+	}
+	for header, value := range interaction.ResponseHeader {
+		resp.Header.Set(header, value)
+	}
+	return resp, nil
+}
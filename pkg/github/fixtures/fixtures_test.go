@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+func newClient(t *testing.T, endpoint string, transport http.RoundTripper) github.Client {
+	_, _, client, err := github.NewClientFromOptions(logrus.Fields{}, github.ClientOptions{
+		Censor:           func(content []byte) []byte { return content },
+		GetToken:         func() []byte { return []byte("secret-token") },
+		GraphqlEndpoint:  endpoint + "/graphql",
+		Bases:            []string{endpoint},
+		InitialDelay:     time.Millisecond,
+		MaxRetries:       1,
+		Max404Retries:    1,
+		BaseRoundTripper: transport,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			t.Errorf("request was not authenticated: %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-GitHub-Request-Id", "1234")
+		switch r.URL.Path {
+		case "/repos/org/repo/pulls/1/reviews":
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprint(w, `[{"id": 2, "user": {"login": "bob"}, "state": "CHANGES_REQUESTED"}]`)
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/repos/org/repo/pulls/1/reviews?per_page=100&page=2>; rel="next"`, r.Host))
+			fmt.Fprint(w, `[{"id": 1, "user": {"login": "alice", "email": "alice@example.com"}, "state": "APPROVED"}]`)
+		case "/repos/org/repo/commits/abc/check-suites":
+			fmt.Fprint(w, `{"total_count": 1, "check_suites": [{"id": 5, "head_sha": "abc", "status": "completed", "conclusion": "success"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer upstream.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(http.DefaultTransport, dir)
+	if err != nil {
+		t.Fatalf("failed to create recorder: %v", err)
+	}
+	recording := newClient(t, upstream.URL, recorder)
+	recordedReviews, err := recording.ListReviews("org", "repo", 1)
+	if err != nil {
+		t.Fatalf("failed to list reviews: %v", err)
+	}
+	recordedSuites, err := recording.ListCheckSuites("org", "repo", "abc")
+	if err != nil {
+		t.Fatalf("failed to list check suites: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 fixtures, got %v", files)
+	}
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, secret := range []string{"secret-token", "alice@example.com", "X-GitHub-Request-Id"} {
+			if strings.Contains(string(raw), secret) {
+				t.Errorf("fixture %s contains %q:\n%s", file, secret, raw)
+			}
+		}
+	}
+
+	interactions, err := Load(dir)
+	if err != nil {
+		t.Fatalf("failed to load fixtures: %v", err)
+	}
+	// The upstream is gone, replaying must not depend on it.
+	upstream.Close()
+	replaying := newClient(t, "https://ghe.example.com/api/v3", NewReplayer(interactions))
+	reviews, err := replaying.ListReviews("org", "repo", 1)
+	if err != nil {
+		t.Fatalf("failed to list replayed reviews: %v", err)
+	}
+	recordedReviews[0].User.Email = Redacted
+	if diff := cmp.Diff(recordedReviews, reviews); diff != "" {
+		t.Errorf("replayed reviews differ from recorded ones: %s", diff)
+	}
+	suites, err := replaying.ListCheckSuites("org", "repo", "abc")
+	if err != nil {
+		t.Fatalf("failed to list replayed check suites: %v", err)
+	}
+	if diff := cmp.Diff(recordedSuites, suites); diff != "" {
+		t.Errorf("replayed check suites differ from recorded ones: %s", diff)
+	}
+
+	if _, err := replaying.ListReviews("org", "repo", 2); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a 404 error for a request without fixture, got %v", err)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name: "empty body",
+		},
+		{
+			name: "not JSON",
+			body: "<html></html>",
+		},
+		{
+			name:     "nested sensitive fields are redacted",
+			body:     `{"token": "abc", "owner": {"login": "alice", "Email": "alice@example.com"}, "keys": [{"key": "ssh-rsa AAA"}]}`,
+			expected: `{"keys":[{"key":"REDACTED"}],"owner":{"Email":"REDACTED","login":"alice"},"token":"REDACTED"}`,
+		},
+		{
+			name:     "non-string fields are kept",
+			body:     `{"email": null, "secret": {"name": "x"}}`,
+			expected: `{"email":null,"secret":{"name":"x"}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := string(sanitize([]byte(tc.body))); actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
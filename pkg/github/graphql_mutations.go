@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// Projects (beta), the merge queue and discussions are only available in the
+// GraphQL API. The input types below are missing from the vendored githubv4
+// version; their names must match the GraphQL schema because the mutation is
+// derived from them.
+
+// AddProjectV2ItemByIdInput is the input of the addProjectV2ItemById mutation.
+type AddProjectV2ItemByIdInput struct {
+	ProjectID githubql.ID `json:"projectId"`
+	ContentID githubql.ID `json:"contentId"`
+}
+
+// DeleteProjectV2ItemInput is the input of the deleteProjectV2Item mutation.
+type DeleteProjectV2ItemInput struct {
+	ProjectID githubql.ID `json:"projectId"`
+	ItemID    githubql.ID `json:"itemId"`
+}
+
+// UpdateProjectV2ItemFieldValueInput is the input of the
+// updateProjectV2ItemFieldValue mutation.
+type UpdateProjectV2ItemFieldValueInput struct {
+	ProjectID githubql.ID         `json:"projectId"`
+	ItemID    githubql.ID         `json:"itemId"`
+	FieldID   githubql.ID         `json:"fieldId"`
+	Value     ProjectV2FieldValue `json:"value"`
+}
+
+// ProjectV2FieldValue is the value of a field of a project item. Only
+// single-select values are supported.
+type ProjectV2FieldValue struct {
+	SingleSelectOptionID githubql.String `json:"singleSelectOptionId,omitempty"`
+}
+
+// EnqueuePullRequestInput is the input of the enqueuePullRequest mutation.
+type EnqueuePullRequestInput struct {
+	PullRequestID githubql.ID `json:"pullRequestId"`
+}
+
+// AddProjectV2Item adds an issue or pull request to a project (beta) and
+// returns the node ID of the item. Adding content that is already in the
+// project returns the existing item.
+//
+// See https://docs.github.com/en/graphql/reference/mutations#addprojectv2itembyid
+func (c *client) AddProjectV2Item(org, projectNodeID, contentNodeID string) (string, error) {
+	durationLogger := c.log("AddProjectV2Item", org, projectNodeID, contentNodeID)
+	defer durationLogger()
+	if c.dry {
+		return "", nil
+	}
+	var m struct {
+		AddProjectV2ItemById struct {
+			Item struct {
+				ID githubql.ID
+			}
+		} `graphql:"addProjectV2ItemById(input: $input)"`
+	}
+	input := AddProjectV2ItemByIdInput{ProjectID: projectNodeID, ContentID: contentNodeID}
+	if err := c.MutateWithGitHubAppsSupport(context.Background(), &m, input, nil, org); err != nil {
+		return "", err
+	}
+	id, _ := m.AddProjectV2ItemById.Item.ID.(string)
+	return id, nil
+}
+
+// DeleteProjectV2Item removes an item from a project (beta).
+//
+// See https://docs.github.com/en/graphql/reference/mutations#deleteprojectv2item
+func (c *client) DeleteProjectV2Item(org, projectNodeID, itemNodeID string) error {
+	durationLogger := c.log("DeleteProjectV2Item", org, projectNodeID, itemNodeID)
+	defer durationLogger()
+	if c.dry {
+		return nil
+	}
+	var m struct {
+		DeleteProjectV2Item struct {
+			DeletedItemID githubql.ID `graphql:"deletedItemId"`
+		} `graphql:"deleteProjectV2Item(input: $input)"`
+	}
+	return c.MutateWithGitHubAppsSupport(context.Background(), &m, DeleteProjectV2ItemInput{ProjectID: projectNodeID, ItemID: itemNodeID}, nil, org)
+}
+
+// SetProjectV2ItemSingleSelectValue selects an option of a single-select
+// field, e.g. the Status, of an item of a project (beta).
+//
+// See https://docs.github.com/en/graphql/reference/mutations#updateprojectv2itemfieldvalue
+func (c *client) SetProjectV2ItemSingleSelectValue(org, projectNodeID, itemNodeID, fieldNodeID, optionID string) error {
+	durationLogger := c.log("SetProjectV2ItemSingleSelectValue", org, projectNodeID, itemNodeID, fieldNodeID, optionID)
+	defer durationLogger()
+	if c.dry {
+		return nil
+	}
+	var m struct {
+		UpdateProjectV2ItemFieldValue struct {
+			ProjectV2Item struct {
+				ID githubql.ID
+			} `graphql:"projectV2Item"`
+		} `graphql:"updateProjectV2ItemFieldValue(input: $input)"`
+	}
+	input := UpdateProjectV2ItemFieldValueInput{
+		ProjectID: projectNodeID,
+		ItemID:    itemNodeID,
+		FieldID:   fieldNodeID,
+		Value:     ProjectV2FieldValue{SingleSelectOptionID: githubql.String(optionID)},
+	}
+	return c.MutateWithGitHubAppsSupport(context.Background(), &m, input, nil, org)
+}
+
+// EnqueuePullRequest adds a pull request to the merge queue of its base
+// branch.
+//
+// See https://docs.github.com/en/graphql/reference/mutations#enqueuepullrequest
+func (c *client) EnqueuePullRequest(org, pullRequestNodeID string) error {
+	durationLogger := c.log("EnqueuePullRequest", org, pullRequestNodeID)
+	defer durationLogger()
+	if c.dry {
+		return nil
+	}
+	var m struct {
+		EnqueuePullRequest struct {
+			MergeQueueEntry struct {
+				ID githubql.ID
+			}
+		} `graphql:"enqueuePullRequest(input: $input)"`
+	}
+	return c.MutateWithGitHubAppsSupport(context.Background(), &m, EnqueuePullRequestInput{PullRequestID: pullRequestNodeID}, nil, org)
+}
+
+// CreateDiscussionComment comments on a discussion, or replies to a comment
+// of the discussion if replyToNodeID is set, and returns the node ID of the
+// new comment.
+//
+// See https://docs.github.com/en/graphql/reference/mutations#adddiscussioncomment
+func (c *client) CreateDiscussionComment(org, discussionNodeID, replyToNodeID, body string) (string, error) {
+	durationLogger := c.log("CreateDiscussionComment", org, discussionNodeID, replyToNodeID, body)
+	defer durationLogger()
+	if c.dry {
+		return "", nil
+	}
+	var m struct {
+		AddDiscussionComment struct {
+			Comment struct {
+				ID githubql.ID
+			}
+		} `graphql:"addDiscussionComment(input: $input)"`
+	}
+	input := githubql.AddDiscussionCommentInput{DiscussionID: discussionNodeID, Body: githubql.String(body)}
+	if replyToNodeID != "" {
+		replyTo := githubql.ID(replyToNodeID)
+		input.ReplyToID = &replyTo
+	}
+	if err := c.MutateWithGitHubAppsSupport(context.Background(), &m, input, nil, org); err != nil {
+		return "", err
+	}
+	id, _ := m.AddDiscussionComment.Comment.ID.(string)
+	return id, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGraphQLMutations(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mutate        func(c *client) (string, error)
+		expectedQuery string
+		expectedInput map[string]interface{}
+		response      string
+		expectedID    string
+	}{
+		{
+			name: "add project item",
+			mutate: func(c *client) (string, error) {
+				return c.AddProjectV2Item("org", "PVT_1", "I_1")
+			},
+			expectedQuery: "mutation($input:AddProjectV2ItemByIdInput!){addProjectV2ItemById(input: $input){item{id}}}",
+			expectedInput: map[string]interface{}{"projectId": "PVT_1", "contentId": "I_1"},
+			response:      `{"data": {"addProjectV2ItemById": {"item": {"id": "PVTI_1"}}}}`,
+			expectedID:    "PVTI_1",
+		},
+		{
+			name: "delete project item",
+			mutate: func(c *client) (string, error) {
+				return "", c.DeleteProjectV2Item("org", "PVT_1", "PVTI_1")
+			},
+			expectedQuery: "mutation($input:DeleteProjectV2ItemInput!){deleteProjectV2Item(input: $input){deletedItemId}}",
+			expectedInput: map[string]interface{}{"projectId": "PVT_1", "itemId": "PVTI_1"},
+			response:      `{"data": {"deleteProjectV2Item": {"deletedItemId": "PVTI_1"}}}`,
+		},
+		{
+			name: "set project item status",
+			mutate: func(c *client) (string, error) {
+				return "", c.SetProjectV2ItemSingleSelectValue("org", "PVT_1", "PVTI_1", "PVTSSF_1", "f75ad846")
+			},
+			expectedQuery: "mutation($input:UpdateProjectV2ItemFieldValueInput!){updateProjectV2ItemFieldValue(input: $input){projectV2Item{id}}}",
+			expectedInput: map[string]interface{}{"projectId": "PVT_1", "itemId": "PVTI_1", "fieldId": "PVTSSF_1", "value": map[string]interface{}{"singleSelectOptionId": "f75ad846"}},
+			response:      `{"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "PVTI_1"}}}}`,
+		},
+		{
+			name: "enqueue pull request",
+			mutate: func(c *client) (string, error) {
+				return "", c.EnqueuePullRequest("org", "PR_1")
+			},
+			expectedQuery: "mutation($input:EnqueuePullRequestInput!){enqueuePullRequest(input: $input){mergeQueueEntry{id}}}",
+			expectedInput: map[string]interface{}{"pullRequestId": "PR_1"},
+			response:      `{"data": {"enqueuePullRequest": {"mergeQueueEntry": {"id": "MQE_1"}}}}`,
+		},
+		{
+			name: "reply to discussion comment",
+			mutate: func(c *client) (string, error) {
+				return c.CreateDiscussionComment("org", "D_1", "DC_1", "thanks")
+			},
+			expectedQuery: "mutation($input:AddDiscussionCommentInput!){addDiscussionComment(input: $input){comment{id}}}",
+			expectedInput: map[string]interface{}{"discussionId": "D_1", "replyToId": "DC_1", "body": "thanks"},
+			response:      `{"data": {"addDiscussionComment": {"comment": {"id": "DC_2"}}}}`,
+			expectedID:    "DC_2",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := getGraphQLReadsClient(t, func(query string, vars map[string]interface{}) (string, int) {
+				if query != tc.expectedQuery {
+					t.Errorf("expected query %s, got %s", tc.expectedQuery, query)
+				}
+				if diff := cmp.Diff(tc.expectedInput, vars["input"]); diff != "" {
+					t.Errorf("unexpected input (-want +got):\n%s", diff)
+				}
+				return tc.response, http.StatusOK
+			}, func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected REST request to %s", r.URL.Path)
+			})
+			id, err := tc.mutate(c)
+			if err != nil {
+				t.Fatalf("Didn't expect error: %v", err)
+			}
+			if id != tc.expectedID {
+				t.Errorf("expected id %q, got %q", tc.expectedID, id)
+			}
+		})
+	}
+}
+
+func TestGraphQLMutationsDryRun(t *testing.T) {
+	c := getGraphQLReadsClient(t, func(query string, vars map[string]interface{}) (string, int) {
+		t.Errorf("unexpected GraphQL request %s", query)
+		return "", http.StatusInternalServerError
+	}, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected REST request to %s", r.URL.Path)
+	})
+	c.dry = true
+	if err := c.EnqueuePullRequest("org", "PR_1"); err != nil {
+		t.Errorf("Didn't expect error: %v", err)
+	}
+}
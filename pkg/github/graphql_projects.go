@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// Projects (beta) are only available in the GraphQL API. Only their
+// single-select fields are read, as these are the only fields whose values
+// Prow sets.
+
+type singleSelectField struct {
+	ID      githubql.ID
+	Name    githubql.String
+	Options []struct {
+		ID   githubql.String
+		Name githubql.String
+	}
+}
+
+type orgProjectsV2Query struct {
+	Organization struct {
+		ProjectsV2 struct {
+			Nodes []struct {
+				ID     githubql.ID
+				Number githubql.Int
+				Title  githubql.String
+				Closed githubql.Boolean
+				Fields struct {
+					Nodes []struct {
+						SingleSelect singleSelectField `graphql:"... on ProjectV2SingleSelectField"`
+					}
+				} `graphql:"fields(first: 50)"`
+			}
+			PageInfo graphQLPageInfo
+		} `graphql:"projectsV2(first: 50, after: $cursor)"`
+	} `graphql:"organization(login: $org)"`
+}
+
+// GetOrgProjectsV2 returns the open projects (beta) of an org along with
+// their single-select fields.
+//
+// See https://docs.github.com/en/graphql/reference/objects#projectv2
+func (c *client) GetOrgProjectsV2(org string) ([]ProjectV2, error) {
+	durationLogger := c.log("GetOrgProjectsV2", org)
+	defer durationLogger()
+	var projects []ProjectV2
+	vars := map[string]interface{}{
+		"org":    githubql.String(org),
+		"cursor": (*githubql.String)(nil),
+	}
+	for {
+		var q orgProjectsV2Query
+		if err := c.QueryWithGitHubAppsSupport(context.Background(), &q, vars, org); err != nil {
+			return nil, err
+		}
+		connection := q.Organization.ProjectsV2
+		for _, node := range connection.Nodes {
+			if node.Closed {
+				continue
+			}
+			id, _ := node.ID.(string)
+			project := ProjectV2{ID: id, Number: int(node.Number), Title: string(node.Title)}
+			for _, field := range node.Fields.Nodes {
+				// Fields of other types have no name in the fragment.
+				if field.SingleSelect.Name == "" {
+					continue
+				}
+				fieldID, _ := field.SingleSelect.ID.(string)
+				f := ProjectV2Field{ID: fieldID, Name: string(field.SingleSelect.Name)}
+				for _, option := range field.SingleSelect.Options {
+					f.Options = append(f.Options, ProjectV2FieldOption{ID: string(option.ID), Name: string(option.Name)})
+				}
+				project.Fields = append(project.Fields, f)
+			}
+			projects = append(projects, project)
+		}
+		if !connection.PageInfo.HasNextPage {
+			return projects, nil
+		}
+		vars["cursor"] = githubql.NewString(connection.PageInfo.EndCursor)
+	}
+}
+
+type projectV2ItemsConnection struct {
+	Nodes []struct {
+		ID      githubql.ID
+		Project struct {
+			ID githubql.ID
+		}
+		FieldValues struct {
+			Nodes []struct {
+				SingleSelect struct {
+					Name  githubql.String
+					Field struct {
+						SingleSelect struct {
+							Name githubql.String
+						} `graphql:"... on ProjectV2SingleSelectField"`
+					}
+				} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+			}
+		} `graphql:"fieldValues(first: 50)"`
+	}
+}
+
+type issueProjectV2ItemsQuery struct {
+	Repository struct {
+		IssueOrPullRequest struct {
+			Issue struct {
+				ID           githubql.ID
+				ProjectItems projectV2ItemsConnection `graphql:"projectItems(first: 100)"`
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				ID           githubql.ID
+				ProjectItems projectV2ItemsConnection `graphql:"projectItems(first: 100)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"issueOrPullRequest(number: $number)"`
+	} `graphql:"repository(owner: $org, name: $repo)"`
+}
+
+// GetIssueProjectV2Items returns the node ID of an issue or pull request and
+// its items in projects (beta), which is what is needed to add it to a project
+// or to update or remove its item.
+//
+// See https://docs.github.com/en/graphql/reference/objects#projectv2item
+func (c *client) GetIssueProjectV2Items(org, repo string, number int) (string, []ProjectV2ContentItem, error) {
+	durationLogger := c.log("GetIssueProjectV2Items", org, repo, number)
+	defer durationLogger()
+	vars := map[string]interface{}{
+		"org":    githubql.String(org),
+		"repo":   githubql.String(repo),
+		"number": githubql.Int(number),
+	}
+	var q issueProjectV2ItemsQuery
+	if err := c.QueryWithGitHubAppsSupport(context.Background(), &q, vars, org); err != nil {
+		return "", nil, err
+	}
+	content := q.Repository.IssueOrPullRequest.Issue
+	if pr := q.Repository.IssueOrPullRequest.PullRequest; pr.ID != nil {
+		content = pr
+	}
+	id, _ := content.ID.(string)
+	var items []ProjectV2ContentItem
+	for _, node := range content.ProjectItems.Nodes {
+		item := ProjectV2ContentItem{FieldValues: map[string]string{}}
+		item.ID, _ = node.ID.(string)
+		item.ProjectID, _ = node.Project.ID.(string)
+		for _, value := range node.FieldValues.Nodes {
+			if field := value.SingleSelect.Field.SingleSelect.Name; field != "" {
+				item.FieldValues[string(field)] = string(value.SingleSelect.Name)
+			}
+		}
+		items = append(items, item)
+	}
+	return id, items, nil
+}
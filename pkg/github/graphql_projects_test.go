@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetOrgProjectsV2(t *testing.T) {
+	c := getGraphQLReadsClient(t, func(query string, vars map[string]interface{}) (string, int) {
+		if !strings.Contains(query, "projectsV2(first: 50, after: $cursor)") || vars["org"] != "k8s" {
+			t.Errorf("unexpected query %s with %v", query, vars)
+		}
+		if vars["cursor"] == nil {
+			return `{"data": {"organization": {"projectsV2": {
+				"nodes": [
+					{"id": "PVT_1", "number": 1, "title": "Release", "closed": false, "fields": {"nodes": [
+						{},
+						{"id": "PVTSSF_1", "name": "Status", "options": [{"id": "a", "name": "Todo"}, {"id": "b", "name": "Done"}]}
+					]}},
+					{"id": "PVT_2", "number": 2, "title": "Old", "closed": true, "fields": {"nodes": []}}
+				],
+				"pageInfo": {"hasNextPage": true, "endCursor": "c1"}}}}}`, http.StatusOK
+		}
+		return `{"data": {"organization": {"projectsV2": {
+			"nodes": [{"id": "PVT_3", "number": 3, "title": "Triage", "closed": false, "fields": {"nodes": []}}],
+			"pageInfo": {"hasNextPage": false}}}}}`, http.StatusOK
+	}, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected REST request to %s", r.URL.Path)
+	})
+
+	projects, err := c.GetOrgProjectsV2("k8s")
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	expected := []ProjectV2{
+		{ID: "PVT_1", Number: 1, Title: "Release", Fields: []ProjectV2Field{{
+			ID:      "PVTSSF_1",
+			Name:    "Status",
+			Options: []ProjectV2FieldOption{{ID: "a", Name: "Todo"}, {ID: "b", Name: "Done"}},
+		}}},
+		{ID: "PVT_3", Number: 3, Title: "Triage"},
+	}
+	if diff := cmp.Diff(expected, projects); diff != "" {
+		t.Errorf("unexpected projects (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetIssueProjectV2Items(t *testing.T) {
+	c := getGraphQLReadsClient(t, func(query string, vars map[string]interface{}) (string, int) {
+		if !strings.Contains(query, "projectItems(first: 100)") || vars["number"] != float64(5) {
+			t.Errorf("unexpected query %s with %v", query, vars)
+		}
+		return `{"data": {"repository": {"issueOrPullRequest": {"id": "I_5", "projectItems": {"nodes": [
+			{"id": "PVTI_1", "project": {"id": "PVT_1"}, "fieldValues": {"nodes": [{}, {"name": "Todo", "field": {"name": "Status"}}]}}
+		]}}}}}`, http.StatusOK
+	}, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected REST request to %s", r.URL.Path)
+	})
+
+	id, items, err := c.GetIssueProjectV2Items("k8s", "kuber", 5)
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	if id != "I_5" {
+		t.Errorf("expected node ID I_5, got %q", id)
+	}
+	expected := []ProjectV2ContentItem{{ID: "PVTI_1", ProjectID: "PVT_1", FieldValues: map[string]string{"Status": "Todo"}}}
+	if diff := cmp.Diff(expected, items); diff != "" {
+		t.Errorf("unexpected items (-want +got):\n%s", diff)
+	}
+}
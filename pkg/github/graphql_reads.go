@@ -0,0 +1,476 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	githubql "github.com/shurcooL/githubv4"
+)
+
+// The read paths in this file are used instead of their REST counterparts if
+// ClientOptions.GraphQLReads is set. GraphQL requests are accounted against a
+// separate rate limit and return up to 100 items with all the fields we need
+// in a single request, which relieves the REST budget of large installations.
+// Should the GraphQL request fail, e.g. because the GraphQL API is not
+// available on a GitHub Enterprise instance, or should the result not fit
+// into a single page where the REST API returns everything at once, the REST
+// API is used.
+//
+// The files of pull requests are always listed with the REST API: GraphQL
+// doesn't expose their patches, which plugins like golint rely on, and
+// doesn't return more files per request than the REST API.
+
+// readWithGraphQL runs the GraphQL read if it is enabled and reports whether
+// it succeeded. Errors are logged and lead to the REST fallback.
+func (c *client) readWithGraphQL(name string, read func() error) bool {
+	if !c.graphQLReads || c.fake {
+		return false
+	}
+	if err := read(); err != nil {
+		c.logger.WithError(err).WithField("method", name).Info("GraphQL read failed, falling back to REST.")
+		return false
+	}
+	return true
+}
+
+type graphQLPageInfo struct {
+	HasNextPage githubql.Boolean
+	EndCursor   githubql.String
+}
+
+type graphQLActor struct {
+	Login    githubql.String
+	URL      githubql.String `graphql:"url"`
+	Typename githubql.String `graphql:"__typename"`
+	User     struct {
+		DatabaseID githubql.Int `graphql:"databaseId"`
+	} `graphql:"... on User"`
+	Bot struct {
+		DatabaseID githubql.Int `graphql:"databaseId"`
+	} `graphql:"... on Bot"`
+}
+
+func (a graphQLActor) user() User {
+	u := User{Login: string(a.Login), HTMLURL: string(a.URL), Type: string(a.Typename)}
+	switch u.Type {
+	case UserTypeUser:
+		u.ID = int(a.User.DatabaseID)
+	case UserTypeBot:
+		u.ID = int(a.Bot.DatabaseID)
+	}
+	return u
+}
+
+type issueLabelsQuery struct {
+	Repository struct {
+		IssueOrPullRequest struct {
+			Issue struct {
+				Labels issueLabelsConnection `graphql:"labels(first: 100, after: $cursor)"`
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				Labels issueLabelsConnection `graphql:"labels(first: 100, after: $cursor)"`
+			} `graphql:"... on PullRequest"`
+		} `graphql:"issueOrPullRequest(number: $number)"`
+	} `graphql:"repository(owner: $org, name: $repo)"`
+}
+
+type issueLabelsConnection struct {
+	Nodes    []graphQLLabel
+	PageInfo graphQLPageInfo
+}
+
+type graphQLLabel struct {
+	Name        githubql.String
+	Description githubql.String
+	Color       githubql.String
+	URL         githubql.String `graphql:"url"`
+}
+
+func (l graphQLLabel) label() Label {
+	return Label{URL: string(l.URL), Name: string(l.Name), Description: string(l.Description), Color: string(l.Color)}
+}
+
+func (c *client) getIssueLabelsGraphQL(org, repo string, number int) ([]Label, error) {
+	var labels []Label
+	vars := map[string]interface{}{
+		"org":    githubql.String(org),
+		"repo":   githubql.String(repo),
+		"number": githubql.Int(number),
+		"cursor": (*githubql.String)(nil),
+	}
+	for {
+		var q issueLabelsQuery
+		if err := c.QueryWithGitHubAppsSupport(context.Background(), &q, vars, org); err != nil {
+			return nil, err
+		}
+		connection := q.Repository.IssueOrPullRequest.Issue.Labels
+		if len(q.Repository.IssueOrPullRequest.PullRequest.Labels.Nodes) > 0 {
+			connection = q.Repository.IssueOrPullRequest.PullRequest.Labels
+		}
+		for _, node := range connection.Nodes {
+			labels = append(labels, node.label())
+		}
+		if !connection.PageInfo.HasNextPage {
+			return labels, nil
+		}
+		vars["cursor"] = githubql.NewString(connection.PageInfo.EndCursor)
+	}
+}
+
+type pullRequestQuery struct {
+	Repository struct {
+		PullRequest *graphQLPullRequest `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $org, name: $repo)"`
+}
+
+type graphQLPullRequest struct {
+	DatabaseID           githubql.Int `graphql:"databaseId"`
+	ID                   githubql.ID
+	Number               githubql.Int
+	URL                  githubql.String `graphql:"url"`
+	Author               graphQLActor
+	Title                githubql.String
+	Body                 githubql.String
+	State                githubql.String
+	IsDraft              githubql.Boolean
+	Merged               githubql.Boolean
+	CreatedAt            githubql.DateTime
+	UpdatedAt            githubql.DateTime
+	MergeCommit          *graphQLCommit
+	PotentialMergeCommit *graphQLCommit
+	Mergeable            githubql.String
+	Milestone            *struct {
+		Title  githubql.String
+		Number githubql.Int
+		State  githubql.String
+	}
+	Commits struct {
+		TotalCount githubql.Int
+	}
+	AuthorAssociation githubql.String
+	BaseRefName       githubql.String
+	BaseRefOID        githubql.String `graphql:"baseRefOid"`
+	BaseRepository    *graphQLRepository
+	HeadRefName       githubql.String
+	HeadRefOID        githubql.String `graphql:"headRefOid"`
+	HeadRepository    *graphQLRepository
+	Labels            struct {
+		Nodes    []graphQLLabel
+		PageInfo graphQLPageInfo
+	} `graphql:"labels(first: 100)"`
+	Assignees struct {
+		Nodes []struct {
+			Login      githubql.String
+			URL        githubql.String `graphql:"url"`
+			DatabaseID githubql.Int    `graphql:"databaseId"`
+		}
+		PageInfo graphQLPageInfo
+	} `graphql:"assignees(first: 100)"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Typename githubql.String `graphql:"__typename"`
+				User     struct {
+					Login      githubql.String
+					URL        githubql.String `graphql:"url"`
+					DatabaseID githubql.Int    `graphql:"databaseId"`
+				} `graphql:"... on User"`
+				Team struct {
+					Name       githubql.String
+					Slug       githubql.String
+					DatabaseID githubql.Int `graphql:"databaseId"`
+				} `graphql:"... on Team"`
+			}
+		}
+		PageInfo graphQLPageInfo
+	} `graphql:"reviewRequests(first: 100)"`
+}
+
+type graphQLCommit struct {
+	OID githubql.String `graphql:"oid"`
+}
+
+type graphQLRepository struct {
+	ID                 githubql.ID
+	Name               githubql.String
+	NameWithOwner      githubql.String
+	URL                githubql.String `graphql:"url"`
+	Owner              graphQLRepositoryOwner
+	IsFork             githubql.Boolean
+	IsArchived         githubql.Boolean
+	IsPrivate          githubql.Boolean
+	Description        githubql.String
+	HomepageURL        githubql.String `graphql:"homepageUrl"`
+	HasIssuesEnabled   githubql.Boolean
+	HasProjectsEnabled githubql.Boolean
+	HasWikiEnabled     githubql.Boolean
+	DefaultBranchRef   *struct {
+		Name githubql.String
+	}
+	Parent *struct {
+		Name          githubql.String
+		NameWithOwner githubql.String
+		URL           githubql.String `graphql:"url"`
+		Owner         graphQLRepositoryOwner
+	}
+}
+
+type graphQLRepositoryOwner struct {
+	Login    githubql.String
+	URL      githubql.String `graphql:"url"`
+	Typename githubql.String `graphql:"__typename"`
+	User     struct {
+		DatabaseID githubql.Int `graphql:"databaseId"`
+	} `graphql:"... on User"`
+	Organization struct {
+		DatabaseID githubql.Int `graphql:"databaseId"`
+	} `graphql:"... on Organization"`
+}
+
+func (o graphQLRepositoryOwner) user() User {
+	u := User{Login: string(o.Login), HTMLURL: string(o.URL), Type: string(o.Typename)}
+	switch u.Type {
+	case UserTypeUser:
+		u.ID = int(o.User.DatabaseID)
+	case "Organization":
+		u.ID = int(o.Organization.DatabaseID)
+	}
+	return u
+}
+
+// repo returns the repository like the REST API does, an empty one if the
+// repository was deleted.
+func (r *graphQLRepository) repo() Repo {
+	if r == nil {
+		return Repo{}
+	}
+	repo := Repo{
+		Owner:       r.Owner.user(),
+		Name:        string(r.Name),
+		FullName:    string(r.NameWithOwner),
+		HTMLURL:     string(r.URL),
+		Fork:        bool(r.IsFork),
+		Archived:    bool(r.IsArchived),
+		Private:     bool(r.IsPrivate),
+		Description: string(r.Description),
+		Homepage:    string(r.HomepageURL),
+		HasIssues:   bool(r.HasIssuesEnabled),
+		HasProjects: bool(r.HasProjectsEnabled),
+		HasWiki:     bool(r.HasWikiEnabled),
+	}
+	if id, ok := r.ID.(string); ok {
+		repo.NodeID = id
+	}
+	if r.DefaultBranchRef != nil {
+		repo.DefaultBranch = string(r.DefaultBranchRef.Name)
+	}
+	if r.Parent != nil {
+		repo.Parent = ParentRepo{
+			Owner:    r.Parent.Owner.user(),
+			Name:     string(r.Parent.Name),
+			FullName: string(r.Parent.NameWithOwner),
+			HTMLURL:  string(r.Parent.URL),
+		}
+	}
+	return repo
+}
+
+func (c *client) getPullRequestGraphQL(org, repo string, number int) (*PullRequest, error) {
+	vars := map[string]interface{}{
+		"org":    githubql.String(org),
+		"repo":   githubql.String(repo),
+		"number": githubql.Int(number),
+	}
+	var q pullRequestQuery
+	if err := c.QueryWithGitHubAppsSupport(context.Background(), &q, vars, org); err != nil {
+		return nil, err
+	}
+	pr := q.Repository.PullRequest
+	if pr == nil {
+		return nil, fmt.Errorf("pull request %s/%s#%d not found", org, repo, number)
+	}
+	if pr.Labels.PageInfo.HasNextPage || pr.Assignees.PageInfo.HasNextPage || pr.ReviewRequests.PageInfo.HasNextPage {
+		return nil, fmt.Errorf("pull request %s/%s#%d has more than 100 labels, assignees or review requests", org, repo, number)
+	}
+
+	pullRequest := &PullRequest{
+		ID:                int(pr.DatabaseID),
+		Number:            int(pr.Number),
+		HTMLURL:           string(pr.URL),
+		User:              pr.Author.user(),
+		Base:              PullRequestBranch{Ref: string(pr.BaseRefName), SHA: string(pr.BaseRefOID), Repo: pr.BaseRepository.repo()},
+		Head:              PullRequestBranch{Ref: string(pr.HeadRefName), SHA: string(pr.HeadRefOID), Repo: pr.HeadRepository.repo()},
+		Title:             string(pr.Title),
+		Body:              string(pr.Body),
+		State:             "open",
+		Draft:             bool(pr.IsDraft),
+		Merged:            bool(pr.Merged),
+		CreatedAt:         pr.CreatedAt.Time,
+		UpdatedAt:         pr.UpdatedAt.Time,
+		Commits:           int(pr.Commits.TotalCount),
+		AuthorAssociation: string(pr.AuthorAssociation),
+	}
+	if id, ok := pr.ID.(string); ok {
+		pullRequest.NodeID = id
+	}
+	// The REST API knows merged pull requests as closed ones.
+	if pr.State != githubql.String(githubql.PullRequestStateOpen) {
+		pullRequest.State = "closed"
+	}
+	// Like the REST API, the merge SHA is the test merge commit until the
+	// pull request is merged.
+	if mergeCommit := pr.MergeCommit; mergeCommit != nil || pr.PotentialMergeCommit != nil {
+		if mergeCommit == nil {
+			mergeCommit = pr.PotentialMergeCommit
+		}
+		sha := string(mergeCommit.OID)
+		pullRequest.MergeSHA = &sha
+	}
+	// Mergeability that is still being computed is unknown, like null in
+	// the REST API.
+	if state := githubql.MergeableState(pr.Mergeable); state == githubql.MergeableStateMergeable || state == githubql.MergeableStateConflicting {
+		mergeable := state == githubql.MergeableStateMergeable
+		pullRequest.Mergable = &mergeable
+	}
+	if pr.Milestone != nil {
+		pullRequest.Milestone = &Milestone{
+			Title:  string(pr.Milestone.Title),
+			Number: int(pr.Milestone.Number),
+			State:  strings.ToLower(string(pr.Milestone.State)),
+		}
+	}
+	for _, node := range pr.Labels.Nodes {
+		pullRequest.Labels = append(pullRequest.Labels, node.label())
+	}
+	for _, node := range pr.Assignees.Nodes {
+		pullRequest.Assignees = append(pullRequest.Assignees, User{Login: string(node.Login), HTMLURL: string(node.URL), ID: int(node.DatabaseID), Type: UserTypeUser})
+	}
+	for _, node := range pr.ReviewRequests.Nodes {
+		switch reviewer := node.RequestedReviewer; reviewer.Typename {
+		case "User":
+			pullRequest.RequestedReviewers = append(pullRequest.RequestedReviewers, User{Login: string(reviewer.User.Login), HTMLURL: string(reviewer.User.URL), ID: int(reviewer.User.DatabaseID), Type: UserTypeUser})
+		case "Team":
+			pullRequest.RequestedTeams = append(pullRequest.RequestedTeams, Team{ID: int(reviewer.Team.DatabaseID), Name: string(reviewer.Team.Name), Slug: string(reviewer.Team.Slug)})
+		}
+	}
+	return pullRequest, nil
+}
+
+type reviewsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			Reviews struct {
+				Nodes []struct {
+					DatabaseID  githubql.Int `graphql:"databaseId"`
+					ID          githubql.ID
+					Author      graphQLActor
+					Body        githubql.String
+					State       githubql.String
+					URL         githubql.String `graphql:"url"`
+					SubmittedAt *githubql.DateTime
+				}
+				PageInfo graphQLPageInfo
+			} `graphql:"reviews(first: 100, after: $cursor)"`
+		} `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $org, name: $repo)"`
+}
+
+func (c *client) listReviewsGraphQL(org, repo string, number int) ([]Review, error) {
+	var reviews []Review
+	vars := map[string]interface{}{
+		"org":    githubql.String(org),
+		"repo":   githubql.String(repo),
+		"number": githubql.Int(number),
+		"cursor": (*githubql.String)(nil),
+	}
+	for {
+		var q reviewsQuery
+		if err := c.QueryWithGitHubAppsSupport(context.Background(), &q, vars, org); err != nil {
+			return nil, err
+		}
+		connection := q.Repository.PullRequest.Reviews
+		for _, node := range connection.Nodes {
+			review := Review{
+				ID:      int(node.DatabaseID),
+				User:    node.Author.user(),
+				Body:    string(node.Body),
+				State:   ReviewState(node.State),
+				HTMLURL: string(node.URL),
+			}
+			if id, ok := node.ID.(string); ok {
+				review.NodeID = id
+			}
+			if node.SubmittedAt != nil {
+				review.SubmittedAt = node.SubmittedAt.Time
+			}
+			reviews = append(reviews, review)
+		}
+		if !connection.PageInfo.HasNextPage {
+			return reviews, nil
+		}
+		vars["cursor"] = githubql.NewString(connection.PageInfo.EndCursor)
+	}
+}
+
+type teamMembersQuery struct {
+	Organization struct {
+		Team *struct {
+			Members struct {
+				Edges []struct {
+					Role githubql.String
+					Node struct {
+						Login githubql.String
+					}
+				}
+				PageInfo graphQLPageInfo
+			} `graphql:"members(first: 100, after: $cursor)"`
+		} `graphql:"team(slug: $slug)"`
+	} `graphql:"organization(login: $org)"`
+}
+
+func (c *client) listTeamMembersBySlugGraphQL(org, teamSlug, role string) ([]TeamMember, error) {
+	var members []TeamMember
+	vars := map[string]interface{}{
+		"org":    githubql.String(org),
+		"slug":   githubql.String(teamSlug),
+		"cursor": (*githubql.String)(nil),
+	}
+	for {
+		var q teamMembersQuery
+		if err := c.QueryWithGitHubAppsSupport(context.Background(), &q, vars, org); err != nil {
+			return nil, err
+		}
+		if q.Organization.Team == nil {
+			return nil, fmt.Errorf("team %s/%s not found", org, teamSlug)
+		}
+		connection := q.Organization.Team.Members
+		for _, edge := range connection.Edges {
+			// The GraphQL roles are MEMBER and MAINTAINER.
+			if role != "" && role != RoleAll && !strings.EqualFold(string(edge.Role), role) {
+				continue
+			}
+			members = append(members, TeamMember{Login: string(edge.Node.Login)})
+		}
+		if !connection.PageInfo.HasNextPage {
+			return members, nil
+		}
+		vars["cursor"] = githubql.NewString(connection.PageInfo.EndCursor)
+	}
+}
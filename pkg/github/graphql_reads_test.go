@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	githubql "github.com/shurcooL/githubv4"
+)
+
+func getGraphQLReadsClient(t *testing.T, graphql func(query string, vars map[string]interface{}) (string, int), rest http.HandlerFunc) *client {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			rest(w, r)
+			return
+		}
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode GraphQL request: %v", err)
+		}
+		response, code := graphql(body.Query, body.Variables)
+		w.WriteHeader(code)
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(ts.Close)
+	c := getClient(ts.URL)
+	c.graphQLReads = true
+	c.gqlc = &graphQLGitHubAppsAuthClientWrapper{Client: githubql.NewEnterpriseClient(ts.URL+"/graphql", ts.Client())}
+	return c
+}
+
+func TestListReviewsGraphQL(t *testing.T) {
+	c := getGraphQLReadsClient(t, func(query string, vars map[string]interface{}) (string, int) {
+		if !strings.Contains(query, "reviews(first: 100, after: $cursor)") || vars["number"] != float64(15) {
+			t.Errorf("unexpected query %s with %v", query, vars)
+		}
+		if vars["cursor"] == nil {
+			return `{"data": {"repository": {"pullRequest": {"reviews": {
+				"nodes": [{"databaseId": 1, "id": "R_1", "author": {"login": "alice", "__typename": "User", "databaseId": 10}, "state": "APPROVED", "submittedAt": "2024-01-02T03:04:05Z"}],
+				"pageInfo": {"hasNextPage": true, "endCursor": "c1"}}}}}}`, http.StatusOK
+		}
+		return `{"data": {"repository": {"pullRequest": {"reviews": {
+			"nodes": [{"databaseId": 2, "id": "R_2", "author": {"login": "ci[bot]", "__typename": "Bot", "databaseId": 20}, "state": "COMMENTED", "body": "lgtm"}],
+			"pageInfo": {"hasNextPage": false}}}}}}`, http.StatusOK
+	}, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected REST request to %s", r.URL.Path)
+	})
+
+	reviews, err := c.ListReviews("k8s", "kuber", 15)
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	var logins []string
+	var ids []int
+	for _, review := range reviews {
+		logins = append(logins, review.User.Login)
+		ids = append(ids, review.ID, review.User.ID)
+	}
+	if diff := cmp.Diff([]string{"alice", "ci[bot]"}, logins); diff != "" {
+		t.Errorf("unexpected logins (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{1, 10, 2, 20}, ids); diff != "" {
+		t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	}
+	if reviews[0].State != ReviewStateApproved || reviews[0].NodeID != "R_1" || reviews[0].SubmittedAt.IsZero() || reviews[1].User.Type != UserTypeBot {
+		t.Errorf("unexpected reviews: %+v", reviews)
+	}
+}
+
+func TestGetIssueLabelsGraphQLFallback(t *testing.T) {
+	var restCalls int
+	c := getGraphQLReadsClient(t, func(string, map[string]interface{}) (string, int) {
+		return `{"message": "not available"}`, http.StatusBadGateway
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/k8s/kuber/issues/5/labels" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		restCalls++
+		fmt.Fprint(w, `[{"name": "lgtm"}]`)
+	})
+
+	labels, err := c.GetIssueLabels("k8s", "kuber", 5)
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	if restCalls != 1 || len(labels) != 1 || labels[0].Name != "lgtm" {
+		t.Errorf("expected the labels from a single REST call, got %v after %d calls", labels, restCalls)
+	}
+}
+
+func TestListTeamMembersBySlugGraphQL(t *testing.T) {
+	c := getGraphQLReadsClient(t, func(query string, vars map[string]interface{}) (string, int) {
+		if vars["slug"] == "missing" {
+			return `{"data": {"organization": {"team": null}}}`, http.StatusOK
+		}
+		return `{"data": {"organization": {"team": {"members": {
+			"edges": [{"role": "MAINTAINER", "node": {"login": "alice"}}, {"role": "MEMBER", "node": {"login": "bob"}}],
+			"pageInfo": {"hasNextPage": false}}}}}}`, http.StatusOK
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/k8s/teams/missing/members" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		http.NotFound(w, r)
+	})
+
+	for role, expected := range map[string][]TeamMember{
+		RoleAll:        {{Login: "alice"}, {Login: "bob"}},
+		RoleMaintainer: {{Login: "alice"}},
+		RoleMember:     {{Login: "bob"}},
+	} {
+		members, err := c.ListTeamMembersBySlug("k8s", "team", role)
+		if err != nil {
+			t.Fatalf("Didn't expect error: %v", err)
+		}
+		if diff := cmp.Diff(expected, members); diff != "" {
+			t.Errorf("unexpected members for role %s (-want +got):\n%s", role, diff)
+		}
+	}
+
+	c.max404Retries = 0
+	if _, err := c.ListTeamMembersBySlug("k8s", "missing", RoleAll); err == nil {
+		t.Error("expected an error for a missing team")
+	}
+}
+
+func TestGetPullRequestGraphQL(t *testing.T) {
+	c := getGraphQLReadsClient(t, func(query string, vars map[string]interface{}) (string, int) {
+		if !strings.Contains(query, "pullRequest(number: $number)") || vars["number"] != float64(7) {
+			t.Errorf("unexpected query %s with %v", query, vars)
+		}
+		return `{"data": {"repository": {"pullRequest": {
+			"databaseId": 70, "id": "PR_7", "number": 7, "url": "https://github.com/k8s/kuber/pull/7",
+			"author": {"login": "alice", "url": "https://github.com/alice", "__typename": "User", "databaseId": 10},
+			"title": "Fix it", "body": "Fixes #1", "state": "OPEN", "isDraft": true, "merged": false,
+			"createdAt": "2024-01-02T03:04:05Z", "updatedAt": "2024-01-03T03:04:05Z",
+			"mergeCommit": null, "potentialMergeCommit": {"oid": "merge"}, "mergeable": "CONFLICTING",
+			"milestone": {"title": "v1.0", "number": 3, "state": "OPEN"},
+			"commits": {"totalCount": 2}, "authorAssociation": "MEMBER",
+			"baseRefName": "main", "baseRefOid": "base",
+			"baseRepository": {"id": "R_1", "name": "kuber", "nameWithOwner": "k8s/kuber", "url": "https://github.com/k8s/kuber",
+				"owner": {"login": "k8s", "__typename": "Organization", "databaseId": 1},
+				"defaultBranchRef": {"name": "main"}, "hasIssuesEnabled": true},
+			"headRefName": "fix", "headRefOid": "head", "headRepository": null,
+			"labels": {"nodes": [{"name": "lgtm"}], "pageInfo": {"hasNextPage": false}},
+			"assignees": {"nodes": [{"login": "bob", "databaseId": 20}], "pageInfo": {"hasNextPage": false}},
+			"reviewRequests": {"nodes": [
+				{"requestedReviewer": {"__typename": "User", "login": "carol", "databaseId": 30}},
+				{"requestedReviewer": {"__typename": "Team", "name": "Reviewers", "slug": "reviewers", "databaseId": 40}}
+			], "pageInfo": {"hasNextPage": false}}
+		}}}}`, http.StatusOK
+	}, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected REST request to %s", r.URL.Path)
+	})
+
+	pr, err := c.GetPullRequest("k8s", "kuber", 7)
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	mergeSHA, mergeable := "merge", false
+	expected := &PullRequest{
+		ID:      70,
+		NodeID:  "PR_7",
+		Number:  7,
+		HTMLURL: "https://github.com/k8s/kuber/pull/7",
+		User:    User{Login: "alice", HTMLURL: "https://github.com/alice", ID: 10, Type: UserTypeUser},
+		Labels:  []Label{{Name: "lgtm"}},
+		Base: PullRequestBranch{Ref: "main", SHA: "base", Repo: Repo{
+			Owner:         User{Login: "k8s", ID: 1, Type: "Organization"},
+			Name:          "kuber",
+			FullName:      "k8s/kuber",
+			HTMLURL:       "https://github.com/k8s/kuber",
+			DefaultBranch: "main",
+			HasIssues:     true,
+			NodeID:        "R_1",
+		}},
+		Head:               PullRequestBranch{Ref: "fix", SHA: "head"},
+		Title:              "Fix it",
+		Body:               "Fixes #1",
+		RequestedReviewers: []User{{Login: "carol", ID: 30, Type: UserTypeUser}},
+		RequestedTeams:     []Team{{ID: 40, Name: "Reviewers", Slug: "reviewers"}},
+		Assignees:          []User{{Login: "bob", ID: 20, Type: UserTypeUser}},
+		State:              "open",
+		Draft:              true,
+		CreatedAt:          time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		UpdatedAt:          time.Date(2024, 1, 3, 3, 4, 5, 0, time.UTC),
+		MergeSHA:           &mergeSHA,
+		Mergable:           &mergeable,
+		Milestone:          &Milestone{Title: "v1.0", Number: 3, State: "open"},
+		Commits:            2,
+		AuthorAssociation:  "MEMBER",
+	}
+	if diff := cmp.Diff(expected, pr); diff != "" {
+		t.Errorf("unexpected pull request (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetPullRequestGraphQLFallback(t *testing.T) {
+	var restCalls int
+	c := getGraphQLReadsClient(t, func(string, map[string]interface{}) (string, int) {
+		return `{"data": {"repository": {"pullRequest": {"number": 7,
+			"labels": {"nodes": [], "pageInfo": {"hasNextPage": true}},
+			"assignees": {"nodes": [], "pageInfo": {"hasNextPage": false}},
+			"reviewRequests": {"nodes": [], "pageInfo": {"hasNextPage": false}}}}}}`, http.StatusOK
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/k8s/kuber/pulls/7" {
+			t.Errorf("Bad request path: %s", r.URL.Path)
+		}
+		restCalls++
+		fmt.Fprint(w, `{"number": 7, "labels": [{"name": "lgtm"}]}`)
+	})
+
+	pr, err := c.GetPullRequest("k8s", "kuber", 7)
+	if err != nil {
+		t.Fatalf("Didn't expect error: %v", err)
+	}
+	if restCalls != 1 || len(pr.Labels) != 1 {
+		t.Errorf("expected the pull request with all its labels from a single REST call, got %+v after %d calls", pr, restCalls)
+	}
+}
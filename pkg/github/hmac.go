@@ -33,6 +33,15 @@ import (
 type HMACToken struct {
 	Value     string    `json:"value"`
 	CreatedAt time.Time `json:"created_at"`
+	// ExpiresAt is set on tokens that were replaced by a newer token. They
+	// keep being accepted until then, so that webhooks delivered or
+	// redelivered with the old token during the rotation still validate.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired tells whether the token is no longer valid at the given time.
+func (t HMACToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
 }
 
 // HMACsForRepo contains all hmac tokens configured for a repo, org or globally.
@@ -118,11 +127,15 @@ func extractHMACs(orgRepo string, tokenGenerator func() []byte) ([][]byte, error
 	return nil, fmt.Errorf("no hmac is configured for the org/repo %q and no legacy global token is configured", orgRepo)
 }
 
-// extractTokens return tokens for any given level of tree.
+// extractTokens return the tokens that have not expired for any given level of tree.
 func extractTokens(allTokens HMACsForRepo) [][]byte {
-	validTokens := make([][]byte, len(allTokens))
+	now := time.Now()
+	validTokens := make([][]byte, 0, len(allTokens))
 	for i := range allTokens {
-		validTokens[i] = []byte(allTokens[i].Value)
+		if allTokens[i].Expired(now) {
+			continue
+		}
+		validTokens = append(validTokens, []byte(allTokens[i].Value))
 	}
 	return validTokens
 }
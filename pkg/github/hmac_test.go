@@ -17,7 +17,9 @@ limitations under the License.
 package github
 
 import (
+	"fmt"
 	"testing"
+	"time"
 )
 
 var tokens = `
@@ -101,3 +103,30 @@ func TestValidatePayload(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatePayloadExpiredTokens(t *testing.T) {
+	// The signature of the payload {} with the key key.
+	const keySig = "sha1=c9963b837183dc667106461b3de91d0885f539b3"
+	tokensWithExpiry := func(expiresAt time.Time) func() []byte {
+		return func() []byte {
+			return []byte(fmt.Sprintf(`
+'*':
+  - value: abc
+    created_at: 2020-10-02T15:00:00Z
+  - value: key
+    created_at: 2018-10-02T15:00:00Z
+    expires_at: %s
+`, expiresAt.Format(time.RFC3339)))
+		}
+	}
+
+	if !ValidatePayload([]byte("{}"), keySig, tokensWithExpiry(time.Now().Add(time.Hour))) {
+		t.Error("expected a replaced token to be accepted during its grace period")
+	}
+	if ValidatePayload([]byte("{}"), keySig, tokensWithExpiry(time.Now().Add(-time.Hour))) {
+		t.Error("expected an expired token to be rejected")
+	}
+	if sig := PayloadSignature([]byte("{}"), []byte("abc")); !ValidatePayload([]byte("{}"), sig, tokensWithExpiry(time.Now().Add(-time.Hour))) {
+		t.Error("expected the current token to be accepted")
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Paginator knows how to request the pages of a paginated list endpoint.
+type Paginator interface {
+	// Paginate requests the pages of the list at path, e.g.
+	// /repos/org/repo/issues, and passes the body of each page to handlePage
+	// until it returns false or an error. The requests are throttled and
+	// retried like all other requests of the client.
+	Paginate(ctx context.Context, org, path string, values url.Values, handlePage func(page []byte) (bool, error)) error
+}
+
+// Paginate implements Paginator. Unless values set per_page, 100 items are
+// requested per page.
+func (c *client) Paginate(ctx context.Context, org, path string, values url.Values, handlePage func(page []byte) (bool, error)) error {
+	durationLogger := c.log("Paginate", org, path, values)
+	defer durationLogger()
+	if c.fake {
+		return nil
+	}
+	query := url.Values{"per_page": []string{"100"}}
+	for key, value := range values {
+		query[key] = value
+	}
+	return c.forEachPage(ctx, path, query, acceptNone, org, handlePage)
+}
+
+// Iterate returns an iterator over the items of a list endpoint that
+// returns a JSON array of T per page. Pages are requested as the items are
+// consumed, so stopping early saves API tokens. If a request fails or ctx is
+// cancelled, the error is yielded once and the iteration ends.
+//
+// The iterator has the shape of an iter.Seq2, so once the module requires
+// Go 1.23 it can be ranged over:
+//
+//	for issue, err := range github.Iterate[github.Issue](ctx, client, org, path, nil) {
+//
+// Until then, it is called with the loop body:
+//
+//	github.Iterate[github.Issue](ctx, client, org, path, nil)(func(issue github.Issue, err error) bool {
+//		...
+//		return true // continue
+//	})
+func Iterate[T any](ctx context.Context, p Paginator, org, path string, values url.Values) func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		err := p.Paginate(ctx, org, path, values, func(page []byte) (bool, error) {
+			var items []T
+			if err := json.Unmarshal(page, &items); err != nil {
+				return false, err
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return false, nil
+				}
+				if err := ctx.Err(); err != nil {
+					return false, err
+				}
+			}
+			return true, nil
+		})
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIterate(t *testing.T) {
+	var lock sync.Mutex
+	var requested []string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requested = append(requested, r.URL.RequestURI())
+		lock.Unlock()
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/repos/org/repo/labels?page=2>; rel="next"`, r.Host))
+			fmt.Fprint(w, `[{"name": "a"}, {"name": "b"}]`)
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<https://%s/repos/org/repo/labels?page=3>; rel="next"`, r.Host))
+			fmt.Fprint(w, `[{"name": "c"}]`)
+		case "3":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	testCases := []struct {
+		name              string
+		values            url.Values
+		stopAfter         int
+		cancelAfter       int
+		expectedNames     []string
+		expectedErr       string
+		expectedRequested []string
+	}{
+		{
+			name:              "all pages until error",
+			expectedNames:     []string{"a", "b", "c"},
+			expectedErr:       "return code not 2XX: 404 Not Found",
+			expectedRequested: []string{"/repos/org/repo/labels?per_page=100", "/repos/org/repo/labels?page=2", "/repos/org/repo/labels?page=3", "/repos/org/repo/labels?page=3", "/repos/org/repo/labels?page=3"},
+		},
+		{
+			name:              "stopping early doesn't request more pages",
+			values:            url.Values{"per_page": []string{"2"}},
+			stopAfter:         2,
+			expectedNames:     []string{"a", "b"},
+			expectedRequested: []string{"/repos/org/repo/labels?per_page=2"},
+		},
+		{
+			name:              "cancellation ends the iteration",
+			cancelAfter:       1,
+			expectedNames:     []string{"a"},
+			expectedErr:       context.Canceled.Error(),
+			expectedRequested: []string{"/repos/org/repo/labels?per_page=100"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requested = nil
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var names []string
+			var errs []error
+			Iterate[Label](ctx, getClient(ts.URL), "org", "/repos/org/repo/labels", tc.values)(func(label Label, err error) bool {
+				if err != nil {
+					errs = append(errs, err)
+					return true
+				}
+				names = append(names, label.Name)
+				if len(names) == tc.cancelAfter {
+					cancel()
+				}
+				return len(names) != tc.stopAfter
+			})
+			if diff := cmp.Diff(tc.expectedNames, names); diff != "" {
+				t.Errorf("unexpected items (-want +got):\n%s", diff)
+			}
+			var actualErr string
+			if len(errs) > 1 {
+				t.Errorf("expected at most one error, got %v", errs)
+			} else if len(errs) == 1 {
+				actualErr = errs[0].Error()
+				if tc.cancelAfter > 0 && !errors.Is(errs[0], context.Canceled) {
+					t.Errorf("expected context.Canceled, got %v", errs[0])
+				}
+			}
+			if actualErr != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, actualErr)
+			}
+			if diff := cmp.Diff(tc.expectedRequested, requested); diff != "" {
+				t.Errorf("unexpected requests (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
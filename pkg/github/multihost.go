@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// HostOptions configures an additional GitHub host, e.g. a GitHub Enterprise
+// instance, that serves some orgs. Requests for these orgs are sent to the
+// host with its own credentials and are throttled separately.
+type HostOptions struct {
+	// Orgs are the orgs and users that live on the host.
+	Orgs []string
+
+	GetToken      func() []byte
+	AppID         string
+	AppPrivateKey func() *rsa.PrivateKey
+
+	GraphqlEndpoint string
+	Bases           []string
+
+	// ThrottleHourlyTokens and ThrottleAllowBurst configure the throttling
+	// of the host, which is disabled if they are zero.
+	ThrottleHourlyTokens, ThrottleAllowBurst int
+}
+
+// newHostClients creates the clients for the additional hosts. All other
+// options are inherited from the client of the default host.
+func newHostClients(fields logrus.Fields, options ClientOptions) (map[string]*client, map[string]TokenGenerator, error) {
+	clients := map[string]*client{}
+	tokenGenerators := map[string]TokenGenerator{}
+	for i, host := range options.AdditionalHosts {
+		if len(host.Orgs) == 0 || len(host.Bases) == 0 {
+			return nil, nil, fmt.Errorf("additional host %d must have orgs and endpoints", i)
+		}
+		hostOptions := options
+		hostOptions.AdditionalHosts = nil
+		hostOptions.GetToken = host.GetToken
+		hostOptions.AppID = host.AppID
+		hostOptions.AppPrivateKey = host.AppPrivateKey
+		hostOptions.GraphqlEndpoint = host.GraphqlEndpoint
+		hostOptions.Bases = host.Bases
+		tokenGenerator, _, hostClient, err := NewClientFromOptions(fields, hostOptions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to construct client for %s: %w", host.Bases[0], err)
+		}
+		if err := hostClient.Throttle(host.ThrottleHourlyTokens, host.ThrottleAllowBurst); err != nil {
+			return nil, nil, fmt.Errorf("failed to throttle client for %s: %w", host.Bases[0], err)
+		}
+		for _, org := range host.Orgs {
+			org = strings.ToLower(org)
+			if _, exists := clients[org]; exists {
+				return nil, nil, fmt.Errorf("org %s is configured for more than one host", org)
+			}
+			clients[org] = hostClient.(*client)
+			tokenGenerators[org] = tokenGenerator
+		}
+	}
+	return clients, tokenGenerators, nil
+}
+
+// hostFor returns the client of the additional host serving the org, or nil
+// if the org lives on the default host.
+func (c *client) hostFor(org string) *client {
+	if len(c.hosts) == 0 || org == "" {
+		return nil
+	}
+	if host, ok := c.hosts[strings.ToLower(org)]; ok {
+		// Keep the logger and user agent of the client, e.g. the plugin.
+		return &client{
+			logger:     c.logger,
+			identifier: c.identifier,
+			gqlc:       host.gqlc.forUserAgent(c.userAgent()),
+			delegate:   host.delegate,
+		}
+	}
+	return nil
+}
+
+// throttleHosts sets up the throttling of orgs that live on additional hosts
+// and returns the remaining orgs.
+func (c *client) throttleHosts(hourlyTokens, burst int, orgs []string) ([]string, error) {
+	var remaining []string
+	var errs []error
+	for _, org := range orgs {
+		host := c.hostFor(org)
+		if host == nil {
+			remaining = append(remaining, org)
+			continue
+		}
+		errs = append(errs, host.Throttle(hourlyTokens, burst, org))
+	}
+	return remaining, utilerrors.NewAggregate(errs)
+}
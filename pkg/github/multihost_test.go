@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestAdditionalHosts(t *testing.T) {
+	newHost := func(name string, requests *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requests = append(*requests, r.Header.Get("Authorization")+" "+r.URL.Path)
+			if r.URL.Path == "/graphql" {
+				fmt.Fprintf(w, `{"data": {"viewer": {"login": %q}}}`, name)
+				return
+			}
+			fmt.Fprintf(w, `[{"name": %q}]`, name)
+		}))
+	}
+	var defaultRequests, gheRequests []string
+	defaultHost, ghe := newHost("default", &defaultRequests), newHost("ghe", &gheRequests)
+	defer defaultHost.Close()
+	defer ghe.Close()
+
+	tokenGenerator, _, c, err := NewClientFromOptions(logrus.Fields{}, ClientOptions{
+		GetToken:        func() []byte { return []byte("default-token") },
+		Bases:           []string{defaultHost.URL},
+		GraphqlEndpoint: defaultHost.URL + "/graphql",
+		AdditionalHosts: []HostOptions{{
+			Orgs:            []string{"Corp"},
+			GetToken:        func() []byte { return []byte("ghe-token") },
+			Bases:           []string{ghe.URL},
+			GraphqlEndpoint: ghe.URL + "/graphql",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for org, expected := range map[string]string{"kubernetes": "default", "corp": "ghe"} {
+		labels, err := c.GetIssueLabels(org, "repo", 1)
+		if err != nil {
+			t.Fatalf("failed to get labels: %v", err)
+		}
+		if len(labels) != 1 || labels[0].Name != expected {
+			t.Errorf("expected labels of org %s to come from %s, got %v", org, expected, labels)
+		}
+		var q struct {
+			Viewer struct {
+				Login string
+			}
+		}
+		if err := c.QueryWithGitHubAppsSupport(context.Background(), &q, nil, org); err != nil {
+			t.Fatalf("failed to query: %v", err)
+		}
+		if q.Viewer.Login != expected {
+			t.Errorf("expected the query of org %s to go to %s, got %s", org, expected, q.Viewer.Login)
+		}
+		token, err := tokenGenerator(org)
+		if err != nil {
+			t.Fatalf("failed to generate token: %v", err)
+		}
+		if token != expected+"-token" {
+			t.Errorf("unexpected git token %q for org %s", token, org)
+		}
+	}
+	if len(defaultRequests) != 2 || defaultRequests[0] != "Bearer default-token /repos/kubernetes/repo/issues/1/labels" {
+		t.Errorf("unexpected requests to the default host: %v", defaultRequests)
+	}
+	if len(gheRequests) != 2 || gheRequests[0] != "Bearer ghe-token /repos/corp/repo/issues/1/labels" {
+		t.Errorf("unexpected requests to the additional host: %v", gheRequests)
+	}
+}
+
+func TestAdditionalHostsConflict(t *testing.T) {
+	host := HostOptions{Orgs: []string{"corp"}, Bases: []string{"https://ghe.example.com/api/v3"}, GetToken: func() []byte { return nil }}
+	if _, _, _, err := NewClientFromOptions(logrus.Fields{}, ClientOptions{AdditionalHosts: []HostOptions{host, host}}); err == nil {
+		t.Error("expected an error for an org configured for two hosts")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
@@ -35,7 +36,8 @@ import (
 )
 
 const (
-	commentTag = "<!-- test report -->"
+	commentTag       = "<!-- test report -->"
+	stickyCommentTag = "<!-- prow job summary -->"
 )
 
 // GitHubClient provides a client interface to report job status updates
@@ -84,7 +86,7 @@ func reportStatus(ctx context.Context, ghc GitHubClient, pj prowapi.ProwJob) err
 		}
 		if err := ghc.CreateStatusWithContext(ctx, refs.Org, refs.Repo, sha, github.Status{
 			State:       contextState,
-			Description: config.ContextDescriptionWithBaseSha(pj.Status.Description, refs.BaseSHA),
+			Description: config.ContextDescriptionWithBaseSha(statusDescription(pj), refs.BaseSHA),
 			Context:     pj.Spec.Context, // consider truncating this too
 			TargetURL:   pj.Status.URL,
 		}); err != nil {
@@ -94,6 +96,15 @@ func reportStatus(ctx context.Context, ghc GitHubClient, pj prowapi.ProwJob) err
 	return nil
 }
 
+// statusDescription explains why an aborted job was cancelled, when known,
+// instead of its generic description.
+func statusDescription(pj prowapi.ProwJob) string {
+	if pj.Status.State == prowapi.AbortedState && pj.Status.Cancellation != nil {
+		return "Job " + pj.Status.Cancellation.String() + "."
+	}
+	return pj.Status.Description
+}
+
 // TODO(krzyzacy):
 // Move this logic into github/reporter, once we unify all reporting logic to crier
 func ShouldReport(pj prowapi.ProwJob, validTypes []prowapi.ProwJobType) bool {
@@ -293,22 +304,25 @@ func parseIssueComments(pjs []prowapi.ProwJob, isBot func(string) bool, ics []gi
 	return delete, newEntries, latestComment
 }
 
-func createEntry(pj prowapi.ProwJob) string {
-	required := "unknown"
-
+// requiredString tells whether a presubmit is required to merge, or
+// "unknown" if that is not known.
+func requiredString(pj prowapi.ProwJob) string {
 	if pj.Spec.Type == prowapi.PresubmitJob {
 		if label, exist := pj.Labels[kube.IsOptionalLabel]; exist {
 			if optional, err := strconv.ParseBool(label); err == nil {
-				required = strconv.FormatBool(!optional)
+				return strconv.FormatBool(!optional)
 			}
 		}
 	}
+	return "unknown"
+}
 
+func createEntry(pj prowapi.ProwJob) string {
 	return strings.Join([]string{
 		pj.Spec.Context,
 		pj.Spec.Refs.Pulls[0].SHA,
 		fmt.Sprintf("[link](%s)", pj.Status.URL),
-		required,
+		requiredString(pj),
 		fmt.Sprintf("`%s`", pj.Spec.RerunCommand),
 	}, " | ")
 }
@@ -361,3 +375,155 @@ func createComment(reportTemplate *template.Template, pjs []prowapi.ProwJob, ent
 	}...)
 	return strings.Join(lines, "\n"), nil
 }
+
+// ReportStickyComment maintains a single comment on a pull request that
+// summarizes the results of all of its jobs, instead of a comment listing
+// the failed ones. The comment is created on the first report and edited in
+// place after that. All ProwJobs are required to test the same pull request,
+// and the ones that are still running are listed as such.
+func ReportStickyComment(ctx context.Context, ghc GitHubClient, reportTemplate *template.Template, pjs []prowapi.ProwJob, config config.GitHubReporter) error {
+	if ghc == nil {
+		return errors.New("trying to report pj, but found empty github client")
+	}
+
+	var validPjs []prowapi.ProwJob
+	for _, pj := range pjs {
+		if ShouldReport(pj, config.JobTypesToReport) {
+			validPjs = append(validPjs, pj)
+		}
+	}
+	if len(validPjs) == 0 {
+		return nil
+	}
+	refs := validPjs[0].Spec.Refs
+	if refs == nil || len(refs.Pulls) != 1 {
+		return nil
+	}
+
+	comment, err := createStickyComment(reportTemplate, validPjs)
+	if err != nil {
+		return fmt.Errorf("generating comment: %w", err)
+	}
+
+	ics, err := ghc.ListIssueCommentsWithContext(ctx, refs.Org, refs.Repo, refs.Pulls[0].Number)
+	if err != nil {
+		return fmt.Errorf("error listing comments: %w", err)
+	}
+	botNameChecker, err := ghc.BotUserCheckerWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting bot name checker: %w", err)
+	}
+	var existing []github.IssueComment
+	for _, ic := range ics {
+		if botNameChecker(ic.User.Login) && strings.Contains(ic.Body, stickyCommentTag) {
+			existing = append(existing, ic)
+		}
+	}
+	if len(existing) == 0 {
+		if err := ghc.CreateCommentWithContext(ctx, refs.Org, refs.Repo, refs.Pulls[0].Number, comment); err != nil {
+			return fmt.Errorf("error creating comment: %w", err)
+		}
+		return nil
+	}
+	// Keep the oldest comment so that it stays where it was first posted.
+	for _, duplicate := range existing[1:] {
+		if err := ghc.DeleteCommentWithContext(ctx, refs.Org, refs.Repo, duplicate.ID); err != nil {
+			return fmt.Errorf("error deleting comment: %w", err)
+		}
+	}
+	if existing[0].Body == comment {
+		return nil
+	}
+	if err := ghc.EditCommentWithContext(ctx, refs.Org, refs.Repo, existing[0].ID, comment); err != nil {
+		return fmt.Errorf("error updating comment: %w", err)
+	}
+	return nil
+}
+
+// stickyResult returns how the state of a ProwJob is shown in the summary
+// comment and the order the job is listed in: failures first, then the jobs
+// still running, then the rest.
+func stickyResult(pj prowapi.ProwJob) (string, int) {
+	switch pj.Status.State {
+	case prowapi.FailureState:
+		return ":x: failure", 0
+	case prowapi.ErrorState:
+		return ":warning: error", 0
+	case prowapi.TriggeredState, prowapi.PendingState:
+		return ":hourglass_flowing_sand: pending", 1
+	case prowapi.AbortedState:
+		return ":no_entry_sign: aborted", 2
+	case prowapi.SuccessState:
+		return ":white_check_mark: success", 3
+	}
+	return string(pj.Status.State), 2
+}
+
+// createStickyComment returns the summary comment for the given ProwJobs of
+// a pull request. It may fail if template execution fails.
+func createStickyComment(reportTemplate *template.Template, pjs []prowapi.ProwJob) (string, error) {
+	sorted := make([]prowapi.ProwJob, len(pjs))
+	copy(sorted, pjs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		_, oi := stickyResult(sorted[i])
+		_, oj := stickyResult(sorted[j])
+		if oi != oj {
+			return oi < oj
+		}
+		return sorted[i].Spec.Context < sorted[j].Spec.Context
+	})
+
+	var failed, pending int
+	rows := []string{
+		"Test name | Result | Required | Rerun command",
+		"--- | --- | --- | ---",
+	}
+	for _, pj := range sorted {
+		result, order := stickyResult(pj)
+		switch order {
+		case 0:
+			failed++
+		case 1:
+			pending++
+		}
+		if pj.Status.URL != "" {
+			result = fmt.Sprintf("[%s](%s)", result, pj.Status.URL)
+		}
+		rerun := ""
+		if pj.Spec.RerunCommand != "" {
+			rerun = fmt.Sprintf("`%s`", pj.Spec.RerunCommand)
+		}
+		rows = append(rows, strings.Join([]string{pj.Spec.Context, result, requiredString(pj), rerun}, " | "))
+	}
+
+	lines := []string{
+		fmt.Sprintf("**Test results** for %s, updated as jobs finish:", sorted[0].Spec.Refs.Pulls[0].SHA),
+		"",
+	}
+	lines = append(lines, rows...)
+	lines = append(lines, "")
+	switch {
+	case failed > 0:
+		lines = append(lines, fmt.Sprintf("%d of %d tests **failed**, say `/retest` to rerun all failed tests or `/retest-required` to rerun all mandatory failed tests.", failed, len(sorted)))
+	case pending > 0:
+		lines = append(lines, fmt.Sprintf("%d of %d tests are still running.", pending, len(sorted)))
+	default:
+		lines = append(lines, "All tests **passed!**")
+	}
+	if reportTemplate != nil {
+		var b bytes.Buffer
+		if err := reportTemplate.Execute(&b, &sorted[0]); err != nil {
+			return "", err
+		}
+		lines = append(lines, "", b.String())
+	}
+	lines = append(lines, []string{
+		"",
+		"<details>",
+		"",
+		plugins.AboutThisBot,
+		"</details>",
+		stickyCommentTag,
+	}...)
+	return strings.Join(lines, "\n"), nil
+}
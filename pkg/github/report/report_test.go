@@ -226,6 +226,9 @@ func createReportEntry(context string, isRequired bool) string {
 type fakeGhClient struct {
 	status   []github.Status
 	comments []string
+	existing []github.IssueComment
+	edited   map[int]string
+	deleted  []int
 }
 
 func (gh fakeGhClient) BotUserCheckerWithContext(_ context.Context) (func(string) bool, error) {
@@ -245,16 +248,21 @@ func (gh *fakeGhClient) CreateStatusWithContext(_ context.Context, org, repo, re
 
 }
 func (gh fakeGhClient) ListIssueCommentsWithContext(_ context.Context, org, repo string, number int) ([]github.IssueComment, error) {
-	return nil, nil
+	return gh.existing, nil
 }
 func (gh *fakeGhClient) CreateCommentWithContext(_ context.Context, org, repo string, number int, comment string) error {
 	gh.comments = append(gh.comments, comment)
 	return nil
 }
-func (gh fakeGhClient) DeleteCommentWithContext(_ context.Context, org, repo string, ID int) error {
+func (gh *fakeGhClient) DeleteCommentWithContext(_ context.Context, org, repo string, ID int) error {
+	gh.deleted = append(gh.deleted, ID)
 	return nil
 }
-func (gh fakeGhClient) EditCommentWithContext(_ context.Context, org, repo string, ID int, comment string) error {
+func (gh *fakeGhClient) EditCommentWithContext(_ context.Context, org, repo string, ID int, comment string) error {
+	if gh.edited == nil {
+		gh.edited = map[int]string{}
+	}
+	gh.edited[ID] = comment
 	return nil
 }
 
@@ -275,6 +283,7 @@ func TestReportStatus(t *testing.T) {
 		state            prowapi.ProwJobState
 		report           bool
 		desc             string // override default msg
+		cancellation     *prowapi.Cancellation
 		pjType           prowapi.ProwJobType
 		expectedStatuses []string
 		expectedDesc     string
@@ -311,6 +320,16 @@ func TestReportStatus(t *testing.T) {
 			pjType:           prowapi.PresubmitJob,
 			expectedStatuses: []string{"failure"},
 		},
+		{
+			name: "Superseded presubmit job explains the cancellation",
+
+			state:            prowapi.AbortedState,
+			report:           true,
+			pjType:           prowapi.PresubmitJob,
+			cancellation:     &prowapi.Cancellation{Reason: prowapi.CancelledSuperseded, Requester: "bob"},
+			expectedStatuses: []string{"failure"},
+			expectedDesc:     "Job superseded by a newer run (requested by bob).",
+		},
 		{
 			name: "Triggered presubmit job with report true should set pending status",
 
@@ -352,9 +371,10 @@ func TestReportStatus(t *testing.T) {
 			}
 			pj := prowapi.ProwJob{
 				Status: prowapi.ProwJobStatus{
-					State:       tc.state,
-					Description: tc.desc,
-					URL:         "http://mytest.com",
+					State:        tc.state,
+					Description:  tc.desc,
+					URL:          "http://mytest.com",
+					Cancellation: tc.cancellation,
 				},
 				Spec: prowapi.ProwJobSpec{
 					Job:     "job-name",
@@ -724,3 +744,160 @@ func TestReportComment(t *testing.T) {
 		})
 	}
 }
+
+func stickyPJ(context string, state prowapi.ProwJobState, optional bool) prowapi.ProwJob {
+	return prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{kube.IsOptionalLabel: strconv.FormatBool(optional)},
+		},
+		Spec: prowapi.ProwJobSpec{
+			Type:         prowapi.PresubmitJob,
+			Context:      context,
+			Report:       true,
+			RerunCommand: "/test " + context,
+			Refs: &prowapi.Refs{
+				Org:   "org",
+				Repo:  "repo",
+				Pulls: []prowapi.Pull{{Number: 1, SHA: "abc"}},
+			},
+		},
+		Status: prowapi.ProwJobStatus{
+			State: state,
+			URL:   "https://prow/" + context,
+		},
+	}
+}
+
+func TestCreateStickyComment(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pjs      []prowapi.ProwJob
+		expected string
+	}{
+		{
+			name: "failures are listed first",
+			pjs: []prowapi.ProwJob{
+				stickyPJ("b-unit", prowapi.SuccessState, false),
+				stickyPJ("c-lint", prowapi.PendingState, true),
+				stickyPJ("a-e2e", prowapi.FailureState, false),
+			},
+			expected: `**Test results** for abc, updated as jobs finish:
+
+Test name | Result | Required | Rerun command
+--- | --- | --- | ---
+a-e2e | [:x: failure](https://prow/a-e2e) | true | ` + "`/test a-e2e`" + `
+c-lint | [:hourglass_flowing_sand: pending](https://prow/c-lint) | false | ` + "`/test c-lint`" + `
+b-unit | [:white_check_mark: success](https://prow/b-unit) | true | ` + "`/test b-unit`" + `
+
+1 of 3 tests **failed**, say ` + "`/retest`" + ` to rerun all failed tests or ` + "`/retest-required`" + ` to rerun all mandatory failed tests.
+
+<details>
+
+Instructions for interacting with me using PR comments are available [here](https://git.k8s.io/community/contributors/guide/pull-requests.md).  If you have questions or suggestions related to my behavior, please file an issue against the [kubernetes/test-infra](https://github.com/kubernetes/test-infra/issues/new?title=Prow%20issue:) repository. I understand the commands that are listed [here](https://go.k8s.io/bot-commands).
+</details>
+<!-- prow job summary -->`,
+		},
+		{
+			name: "jobs still running",
+			pjs: []prowapi.ProwJob{
+				stickyPJ("a-e2e", prowapi.TriggeredState, false),
+				stickyPJ("b-unit", prowapi.SuccessState, false),
+			},
+			expected: "1 of 2 tests are still running.",
+		},
+		{
+			name: "all passed",
+			pjs: []prowapi.ProwJob{
+				stickyPJ("a-e2e", prowapi.SuccessState, false),
+			},
+			expected: "All tests **passed!**",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			comment, err := createStickyComment(nil, tc.pjs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Contains(tc.expected, stickyCommentTag) {
+				if diff := cmp.Diff(tc.expected, comment); diff != "" {
+					t.Errorf("comment mismatch (-want +got):\n%s", diff)
+				}
+			} else if !strings.Contains(comment, tc.expected) {
+				t.Errorf("expected comment to contain %q, got:\n%s", tc.expected, comment)
+			}
+		})
+	}
+}
+
+func TestReportStickyComment(t *testing.T) {
+	reporterConfig := config.GitHubReporter{
+		JobTypesToReport: []prowapi.ProwJobType{prowapi.PresubmitJob},
+	}
+	pjs := []prowapi.ProwJob{stickyPJ("a-e2e", prowapi.FailureState, false)}
+	current, err := createStickyComment(nil, pjs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bot := github.User{Login: "BotName"}
+
+	testCases := []struct {
+		name            string
+		existing        []github.IssueComment
+		expectedCreated bool
+		expectedEdited  map[int]string
+		expectedDeleted []int
+	}{
+		{
+			name:            "no comment yet",
+			expectedCreated: true,
+		},
+		{
+			name: "comments of others are left alone",
+			existing: []github.IssueComment{
+				{ID: 1, User: github.User{Login: "someone"}, Body: stickyCommentTag},
+				{ID: 2, User: bot, Body: "other" + commentTag},
+			},
+			expectedCreated: true,
+		},
+		{
+			name: "outdated comment is edited",
+			existing: []github.IssueComment{
+				{ID: 1, User: bot, Body: "old" + stickyCommentTag},
+			},
+			expectedEdited: map[int]string{1: current},
+		},
+		{
+			name: "duplicates are deleted",
+			existing: []github.IssueComment{
+				{ID: 1, User: bot, Body: current},
+				{ID: 2, User: bot, Body: "old" + stickyCommentTag},
+				{ID: 3, User: bot, Body: "older" + stickyCommentTag},
+			},
+			expectedDeleted: []int{2, 3},
+		},
+		{
+			name: "up to date comment is not touched",
+			existing: []github.IssueComment{
+				{ID: 1, User: bot, Body: current},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fghc := &fakeGhClient{existing: tc.existing}
+			if err := ReportStickyComment(context.Background(), fghc, nil, pjs, reporterConfig); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if created := len(fghc.comments) == 1; created != tc.expectedCreated {
+				t.Errorf("expected created %t, got %t", tc.expectedCreated, created)
+			}
+			if diff := cmp.Diff(tc.expectedEdited, fghc.edited); diff != "" {
+				t.Errorf("edited comments mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedDeleted, fghc.deleted); diff != "" {
+				t.Errorf("deleted comments mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// secondaryRateLimitBackoff is the initial wait after a secondary rate limit
+// response without Retry-After header, as recommended by GitHub. It doubles
+// for every consecutive secondary rate limit response.
+const secondaryRateLimitBackoff = time.Minute
+
+// secondaryRateLimiter holds back all requests of an installation while it is
+// secondary rate limited. Without it, concurrent goroutines would keep sending
+// requests and extend the limit.
+type secondaryRateLimiter struct {
+	lock         sync.Mutex
+	blockedUntil map[string]time.Time
+	consecutive  map[string]int
+}
+
+// limited records a secondary rate limit response and returns how long the
+// installation is blocked. If GitHub didn't say how long to wait, the wait
+// grows exponentially with the number of consecutive limited responses and is
+// capped at maxWait.
+func (l *secondaryRateLimiter) limited(key string, retryAfter, maxWait time.Duration) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.blockedUntil == nil {
+		l.blockedUntil = map[string]time.Time{}
+		l.consecutive = map[string]int{}
+	}
+	wait := retryAfter
+	if wait == 0 {
+		wait = secondaryRateLimitBackoff << l.consecutive[key]
+		if wait > maxWait || wait <= 0 {
+			wait = maxWait
+		}
+	}
+	l.consecutive[key]++
+	if until := time.Now().Add(wait); until.After(l.blockedUntil[key]) {
+		l.blockedUntil[key] = until
+	}
+	return wait
+}
+
+// succeeded resets the backoff of the installation.
+func (l *secondaryRateLimiter) succeeded(key string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.consecutive, key)
+}
+
+// wait sleeps until the installation is no longer blocked. A random jitter of
+// up to a tenth of the wait is added so that the waiting goroutines don't all
+// send their requests at the same time.
+func (l *secondaryRateLimiter) wait(key string, t timeClient) {
+	l.lock.Lock()
+	until, blocked := l.blockedUntil[key]
+	l.lock.Unlock()
+	if !blocked {
+		return
+	}
+	d := t.Until(until)
+	if d <= 0 {
+		return
+	}
+	t.Sleep(d + time.Duration(rand.Int63n(int64(d)/10+1)))
+}
+
+// secondaryRateLimitKey returns the key that secondary rate limits are
+// tracked by: the installation for GitHub apps, the token otherwise.
+func (c *client) secondaryRateLimitKey(org string) string {
+	if c.usesAppsAuth {
+		return org
+	}
+	return ""
+}
+
+// secondaryRateLimit determines whether the response is a secondary rate
+// limit response and how long GitHub asked us to wait, which is zero if it
+// didn't. The body of the response is preserved.
+func secondaryRateLimit(resp *http.Response) (bool, time.Duration, error) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false, 0, nil
+	}
+	rawTime := resp.Header.Get("Retry-After")
+	if rawTime != "" && rawTime != "0" {
+		t, err := strconv.Atoi(rawTime)
+		if err != nil {
+			return true, 0, fmt.Errorf("failed to parse abuse rate limit wait time %q: %w", rawTime, err)
+		}
+		// Wait an extra second plus how long GitHub wants us to wait.
+		return true, time.Duration(t+1) * time.Second, nil
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true, 0, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false, 0, nil
+	}
+	message := strings.ToLower(string(body))
+	return strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection"), 0, nil
+}
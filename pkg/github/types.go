@@ -231,6 +231,11 @@ type PullRequestEvent struct {
 	// and deserialize later as this is a polymorphic field
 	Changes json.RawMessage `json:"changes"`
 
+	// Before and After are the head SHAs of the pull request before and after
+	// the push of a synchronize event.
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+
 	// GUID is included in the header of the request received by GitHub.
 	GUID string
 }
@@ -369,6 +374,10 @@ type FullRepo struct {
 	AllowRebaseMerge         bool   `json:"allow_rebase_merge,omitempty"`
 	SquashMergeCommitTitle   string `json:"squash_merge_commit_title,omitempty"`
 	SquashMergeCommitMessage string `json:"squash_merge_commit_message,omitempty"`
+	AllowAutoMerge           bool   `json:"allow_auto_merge,omitempty"`
+	DeleteBranchOnMerge      bool   `json:"delete_branch_on_merge,omitempty"`
+
+	Topics []string `json:"topics,omitempty"`
 }
 
 // RepoRequest contains metadata used in requests to create or update a Repo.
@@ -390,6 +399,8 @@ type RepoRequest struct {
 	AllowRebaseMerge         *bool   `json:"allow_rebase_merge,omitempty"`
 	SquashMergeCommitTitle   *string `json:"squash_merge_commit_title,omitempty"`
 	SquashMergeCommitMessage *string `json:"squash_merge_commit_message,omitempty"`
+	AllowAutoMerge           *bool   `json:"allow_auto_merge,omitempty"`
+	DeleteBranchOnMerge      *bool   `json:"delete_branch_on_merge,omitempty"`
 }
 
 type WorkflowRuns struct {
@@ -432,6 +443,8 @@ func (r RepoRequest) ToRepo() *FullRepo {
 	setBool(&repo.AllowRebaseMerge, r.AllowRebaseMerge)
 	setString(&repo.SquashMergeCommitTitle, r.SquashMergeCommitTitle)
 	setString(&repo.SquashMergeCommitMessage, r.SquashMergeCommitMessage)
+	setBool(&repo.AllowAutoMerge, r.AllowAutoMerge)
+	setBool(&repo.DeleteBranchOnMerge, r.DeleteBranchOnMerge)
 
 	return &repo
 }
@@ -440,7 +453,8 @@ func (r RepoRequest) ToRepo() *FullRepo {
 func (r RepoRequest) Defined() bool {
 	return r.Name != nil || r.Description != nil || r.Homepage != nil || r.Private != nil ||
 		r.HasIssues != nil || r.HasProjects != nil || r.HasWiki != nil || r.AllowSquashMerge != nil ||
-		r.AllowMergeCommit != nil || r.AllowRebaseMerge != nil
+		r.AllowMergeCommit != nil || r.AllowRebaseMerge != nil || r.AllowAutoMerge != nil ||
+		r.DeleteBranchOnMerge != nil
 }
 
 // RepoUpdateRequest contains metadata used for updating a repository
@@ -681,6 +695,98 @@ type RestrictionsRequest struct {
 	Teams *[]string `json:"teams,omitempty"`
 }
 
+// Ruleset targets.
+const (
+	RulesetTargetBranch = "branch"
+	RulesetTargetTag    = "tag"
+)
+
+// Ruleset enforcement levels.
+const (
+	RulesetEnforcementActive   = "active"
+	RulesetEnforcementEvaluate = "evaluate"
+	RulesetEnforcementDisabled = "disabled"
+)
+
+// Ruleset rule types.
+const (
+	RulesetRuleCreation              = "creation"
+	RulesetRuleUpdate                = "update"
+	RulesetRuleDeletion              = "deletion"
+	RulesetRuleRequiredLinearHistory = "required_linear_history"
+	RulesetRuleRequiredSignatures    = "required_signatures"
+	RulesetRulePullRequest           = "pull_request"
+	RulesetRuleRequiredStatusChecks  = "required_status_checks"
+	RulesetRuleNonFastForward        = "non_fast_forward"
+)
+
+// Ruleset is a repository or organization ruleset, the successor of
+// branch protection rules.
+// See also: https://docs.github.com/en/rest/repos/rules
+type Ruleset struct {
+	ID           int                  `json:"id,omitempty"`
+	Name         string               `json:"name"`
+	Target       string               `json:"target,omitempty"`
+	SourceType   string               `json:"source_type,omitempty"`
+	Source       string               `json:"source,omitempty"`
+	Enforcement  string               `json:"enforcement"`
+	BypassActors []RulesetBypassActor `json:"bypass_actors,omitempty"`
+	Conditions   *RulesetConditions   `json:"conditions,omitempty"`
+	Rules        []RulesetRule        `json:"rules,omitempty"`
+}
+
+// RulesetBypassActor is an actor that can bypass the rules of a ruleset.
+type RulesetBypassActor struct {
+	// ActorID is unset for actor types that do not need one, e.g. DeployKey.
+	ActorID *int `json:"actor_id,omitempty"`
+	// ActorType is one of Integration, OrganizationAdmin, RepositoryRole, Team or DeployKey.
+	ActorType string `json:"actor_type"`
+	// BypassMode is one of always or pull_request.
+	BypassMode string `json:"bypass_mode"`
+}
+
+// RulesetConditions select the refs, and for organization rulesets the
+// repositories, that a ruleset applies to.
+type RulesetConditions struct {
+	RefName        *RulesetNameCondition `json:"ref_name,omitempty"`
+	RepositoryName *RulesetNameCondition `json:"repository_name,omitempty"`
+}
+
+// RulesetNameCondition includes and excludes names by fnmatch pattern.
+type RulesetNameCondition struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// RulesetRule is a single rule of a ruleset. The shape of Parameters depends
+// on the rule type, see RequiredStatusChecksRuleParameters and
+// PullRequestRuleParameters.
+type RulesetRule struct {
+	Type       string          `json:"type"`
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+// RequiredStatusChecksRuleParameters are the parameters of a required_status_checks rule.
+type RequiredStatusChecksRuleParameters struct {
+	RequiredStatusChecks             []RulesetStatusCheck `json:"required_status_checks"`
+	StrictRequiredStatusChecksPolicy bool                 `json:"strict_required_status_checks_policy"`
+}
+
+// RulesetStatusCheck is a status check context required by a ruleset.
+type RulesetStatusCheck struct {
+	Context       string `json:"context"`
+	IntegrationID *int   `json:"integration_id,omitempty"`
+}
+
+// PullRequestRuleParameters are the parameters of a pull_request rule.
+type PullRequestRuleParameters struct {
+	DismissStaleReviewsOnPush      bool `json:"dismiss_stale_reviews_on_push"`
+	RequireCodeOwnerReview         bool `json:"require_code_owner_review"`
+	RequireLastPushApproval        bool `json:"require_last_push_approval"`
+	RequiredApprovingReviewCount   int  `json:"required_approving_review_count"`
+	RequiredReviewThreadResolution bool `json:"required_review_thread_resolution"`
+}
+
 // HookConfig holds the endpoint and its secret.
 type HookConfig struct {
 	URL         string  `json:"url"`
@@ -697,6 +803,17 @@ type Hook struct {
 	Config HookConfig `json:"config"`
 }
 
+// Autolink references an external resource, such as a JIRA issue, from
+// anything in a repository that starts with its key prefix.
+//
+// See https://docs.github.com/en/rest/repos/autolinks
+type Autolink struct {
+	ID             int    `json:"id,omitempty"`
+	KeyPrefix      string `json:"key_prefix"`
+	URLTemplate    string `json:"url_template"`
+	IsAlphanumeric bool   `json:"is_alphanumeric"`
+}
+
 // HookRequest can create and/or edit a webhook.
 //
 // AddEvents and RemoveEvents are only valid during an edit, and only for a repo
@@ -1417,6 +1534,69 @@ type ProjectCard struct {
 	ContentURL  string `json:"content_url"`
 }
 
+// ProjectV2 is a project (beta) of an org.
+type ProjectV2 struct {
+	// ID is the node ID of the project.
+	ID     string
+	Number int
+	Title  string
+	// Fields are the single-select fields of the project, e.g. Status.
+	Fields []ProjectV2Field
+}
+
+// ProjectV2Field is a single-select field of a project (beta).
+type ProjectV2Field struct {
+	ID      string
+	Name    string
+	Options []ProjectV2FieldOption
+}
+
+// ProjectV2FieldOption is an option of a single-select field.
+type ProjectV2FieldOption struct {
+	ID   string
+	Name string
+}
+
+// ProjectV2ContentItem is the item of an issue or pull request in a project
+// (beta).
+type ProjectV2ContentItem struct {
+	ID        string
+	ProjectID string
+	// FieldValues maps the names of the single-select fields of the item to
+	// the names of the selected options.
+	FieldValues map[string]string
+}
+
+// Check run statuses.
+const (
+	CheckRunStatusQueued     = "queued"
+	CheckRunStatusInProgress = "in_progress"
+	CheckRunStatusCompleted  = "completed"
+)
+
+// Check run conclusions, only set for completed check runs.
+const (
+	CheckRunConclusionSuccess        = "success"
+	CheckRunConclusionFailure        = "failure"
+	CheckRunConclusionNeutral        = "neutral"
+	CheckRunConclusionCancelled      = "cancelled"
+	CheckRunConclusionSkipped        = "skipped"
+	CheckRunConclusionTimedOut       = "timed_out"
+	CheckRunConclusionActionRequired = "action_required"
+)
+
+// Check run annotation levels.
+const (
+	CheckRunAnnotationLevelNotice  = "notice"
+	CheckRunAnnotationLevelWarning = "warning"
+	CheckRunAnnotationLevelFailure = "failure"
+)
+
+// MaxCheckRunAnnotations is the maximum number of annotations that can be
+// sent in a single request creating or updating a check run. More annotations
+// can be added by updating the check run repeatedly.
+const MaxCheckRunAnnotations = 50
+
 type CheckRunList struct {
 	Total     int        `json:"total_count,omitempty"`
 	CheckRuns []CheckRun `json:"check_runs,omitempty"`
@@ -1487,6 +1667,289 @@ type CheckSuite struct {
 	HeadCommit *Commit `json:"head_commit,omitempty"`
 }
 
+type CheckSuiteList struct {
+	Total       int          `json:"total_count,omitempty"`
+	CheckSuites []CheckSuite `json:"check_suites,omitempty"`
+}
+
+// CheckRunEventAction enumerates the triggers for this
+// webhook payload type. See also:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#check_run
+type CheckRunEventAction string
+
+const (
+	// CheckRunActionCreated means a check run was created.
+	CheckRunActionCreated CheckRunEventAction = "created"
+	// CheckRunActionCompleted means a check run was completed.
+	CheckRunActionCompleted CheckRunEventAction = "completed"
+	// CheckRunActionRerequested means someone asked to re-run a check run.
+	CheckRunActionRerequested CheckRunEventAction = "rerequested"
+	// CheckRunActionRequestedAction means someone clicked a button of a check run.
+	CheckRunActionRequestedAction CheckRunEventAction = "requested_action"
+)
+
+// CheckRunEvent holds information about a `check_run` GitHub webhook event.
+type CheckRunEvent struct {
+	Action   CheckRunEventAction `json:"action"`
+	CheckRun CheckRun            `json:"check_run"`
+	// RequestedAction is only set for the requested_action action.
+	RequestedAction *CheckRunRequestedAction `json:"requested_action,omitempty"`
+	Repo            Repo                     `json:"repository"`
+	Sender          User                     `json:"sender"`
+
+	// GUID is included in the header of the request received by GitHub.
+	GUID string
+}
+
+// CheckRunRequestedAction identifies the button of a check run that was
+// clicked.
+type CheckRunRequestedAction struct {
+	Identifier string `json:"identifier"`
+}
+
+// CheckSuiteEventAction enumerates the triggers for this
+// webhook payload type. See also:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#check_suite
+type CheckSuiteEventAction string
+
+const (
+	// CheckSuiteActionCompleted means all check runs of a check suite completed.
+	CheckSuiteActionCompleted CheckSuiteEventAction = "completed"
+	// CheckSuiteActionRequested means a check suite was requested for new code.
+	CheckSuiteActionRequested CheckSuiteEventAction = "requested"
+	// CheckSuiteActionRerequested means someone asked to re-run a check suite.
+	CheckSuiteActionRerequested CheckSuiteEventAction = "rerequested"
+)
+
+// CheckSuiteEvent holds information about a `check_suite` GitHub webhook event.
+type CheckSuiteEvent struct {
+	Action     CheckSuiteEventAction `json:"action"`
+	CheckSuite CheckSuite            `json:"check_suite"`
+	Repo       Repo                  `json:"repository"`
+	Sender     User                  `json:"sender"`
+
+	// GUID is included in the header of the request received by GitHub.
+	GUID string
+}
+
+// ProjectsV2ItemEventAction enumerates the triggers for this
+// webhook payload type. See also:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#projects_v2_item
+type ProjectsV2ItemEventAction string
+
+const (
+	// ProjectsV2ItemActionCreated means an item was added to a project.
+	ProjectsV2ItemActionCreated ProjectsV2ItemEventAction = "created"
+	// ProjectsV2ItemActionEdited means a field value of an item was changed.
+	ProjectsV2ItemActionEdited ProjectsV2ItemEventAction = "edited"
+	// ProjectsV2ItemActionDeleted means an item was removed from a project.
+	ProjectsV2ItemActionDeleted ProjectsV2ItemEventAction = "deleted"
+	// ProjectsV2ItemActionArchived means an item was archived.
+	ProjectsV2ItemActionArchived ProjectsV2ItemEventAction = "archived"
+	// ProjectsV2ItemActionRestored means an archived item was restored.
+	ProjectsV2ItemActionRestored ProjectsV2ItemEventAction = "restored"
+	// ProjectsV2ItemActionConverted means a draft issue was converted to an issue.
+	ProjectsV2ItemActionConverted ProjectsV2ItemEventAction = "converted"
+	// ProjectsV2ItemActionReordered means an item was moved in a project.
+	ProjectsV2ItemActionReordered ProjectsV2ItemEventAction = "reordered"
+)
+
+// ProjectsV2Item is an item of a project (beta), i.e. an issue, pull request
+// or draft issue.
+type ProjectsV2Item struct {
+	ID            int64  `json:"id"`
+	NodeID        string `json:"node_id"`
+	ProjectNodeID string `json:"project_node_id"`
+	ContentNodeID string `json:"content_node_id"`
+	// ContentType is Issue, PullRequest or DraftIssue.
+	ContentType string     `json:"content_type"`
+	Creator     User       `json:"creator"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+}
+
+// ProjectsV2ItemEvent holds information about a `projects_v2_item` GitHub
+// webhook event. Projects (beta) belong to orgs, so the event has no repo.
+type ProjectsV2ItemEvent struct {
+	Action         ProjectsV2ItemEventAction `json:"action"`
+	ProjectsV2Item ProjectsV2Item            `json:"projects_v2_item"`
+	Org            Organization              `json:"organization"`
+	Sender         User                      `json:"sender"`
+
+	// Changes holds raw change data, which we must inspect
+	// and deserialize later as this is a polymorphic field
+	Changes json.RawMessage `json:"changes"`
+
+	// GUID is included in the header of the request received by GitHub.
+	GUID string
+}
+
+// MergeGroupEventAction enumerates the triggers for this
+// webhook payload type. See also:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#merge_group
+type MergeGroupEventAction string
+
+const (
+	// MergeGroupActionChecksRequested means the checks of a merge group must run.
+	MergeGroupActionChecksRequested MergeGroupEventAction = "checks_requested"
+	// MergeGroupActionDestroyed means a merge group was merged or dequeued.
+	MergeGroupActionDestroyed MergeGroupEventAction = "destroyed"
+)
+
+// The reasons why a merge group was destroyed.
+const (
+	MergeGroupDestroyedMerged      = "merged"
+	MergeGroupDestroyedInvalidated = "invalidated"
+	MergeGroupDestroyedDequeued    = "dequeued"
+)
+
+// MergeGroup is the temporary branch the merge queue tests changes on.
+type MergeGroup struct {
+	HeadSHA    string `json:"head_sha"`
+	HeadRef    string `json:"head_ref"`
+	BaseSHA    string `json:"base_sha"`
+	BaseRef    string `json:"base_ref"`
+	HeadCommit Commit `json:"head_commit"`
+}
+
+// MergeGroupEvent holds information about a `merge_group` GitHub webhook
+// event, which is sent by the merge queue.
+type MergeGroupEvent struct {
+	Action     MergeGroupEventAction `json:"action"`
+	MergeGroup MergeGroup            `json:"merge_group"`
+	// Reason is only set for the destroyed action, see the
+	// MergeGroupDestroyed constants.
+	Reason string `json:"reason,omitempty"`
+	Repo   Repo   `json:"repository"`
+	Sender User   `json:"sender"`
+
+	// GUID is included in the header of the request received by GitHub.
+	GUID string
+}
+
+// DiscussionEventAction enumerates the triggers for this
+// webhook payload type. See also:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#discussion
+type DiscussionEventAction string
+
+const (
+	// DiscussionActionCreated means a discussion was created.
+	DiscussionActionCreated DiscussionEventAction = "created"
+	// DiscussionActionEdited means the title or body of a discussion was changed.
+	DiscussionActionEdited DiscussionEventAction = "edited"
+	// DiscussionActionDeleted means a discussion was deleted.
+	DiscussionActionDeleted DiscussionEventAction = "deleted"
+	// DiscussionActionAnswered means a comment was marked as the answer.
+	DiscussionActionAnswered DiscussionEventAction = "answered"
+	// DiscussionActionUnanswered means the answer was unmarked.
+	DiscussionActionUnanswered DiscussionEventAction = "unanswered"
+	// DiscussionActionClosed means a discussion was closed.
+	DiscussionActionClosed DiscussionEventAction = "closed"
+	// DiscussionActionReopened means a discussion was reopened.
+	DiscussionActionReopened DiscussionEventAction = "reopened"
+	// DiscussionActionLabeled means a label was added to a discussion.
+	DiscussionActionLabeled DiscussionEventAction = "labeled"
+	// DiscussionActionUnlabeled means a label was removed from a discussion.
+	DiscussionActionUnlabeled DiscussionEventAction = "unlabeled"
+	// DiscussionActionCategoryChanged means a discussion was moved to another category.
+	DiscussionActionCategoryChanged DiscussionEventAction = "category_changed"
+)
+
+// DiscussionCategory is the category a discussion is filed under.
+type DiscussionCategory struct {
+	ID           int64  `json:"id"`
+	NodeID       string `json:"node_id"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug"`
+	Description  string `json:"description"`
+	IsAnswerable bool   `json:"is_answerable"`
+}
+
+// Discussion is a GitHub Discussion.
+type Discussion struct {
+	ID       int64              `json:"id"`
+	NodeID   string             `json:"node_id"`
+	Number   int                `json:"number"`
+	Title    string             `json:"title"`
+	Body     string             `json:"body"`
+	State    string             `json:"state"`
+	Locked   bool               `json:"locked"`
+	HTMLURL  string             `json:"html_url"`
+	User     User               `json:"user"`
+	Category DiscussionCategory `json:"category"`
+	Labels   []Label            `json:"labels,omitempty"`
+	// AnswerHTMLURL is the URL of the comment that was marked as the answer.
+	AnswerHTMLURL  string     `json:"answer_html_url,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	AnswerChosenAt *time.Time `json:"answer_chosen_at,omitempty"`
+}
+
+// DiscussionComment is a comment on a GitHub Discussion.
+type DiscussionComment struct {
+	ID      int64  `json:"id"`
+	NodeID  string `json:"node_id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    User   `json:"user"`
+	// ParentID is the ID of the comment this comment replies to.
+	ParentID  *int64    `json:"parent_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DiscussionEvent holds information about a `discussion` GitHub webhook
+// event.
+type DiscussionEvent struct {
+	Action     DiscussionEventAction `json:"action"`
+	Discussion Discussion            `json:"discussion"`
+	// Answer is only set for the answered and unanswered actions.
+	Answer *DiscussionComment `json:"answer,omitempty"`
+	// Label is only set for the labeled and unlabeled actions.
+	Label  *Label `json:"label,omitempty"`
+	Repo   Repo   `json:"repository"`
+	Sender User   `json:"sender"`
+
+	// Changes holds raw change data, which we must inspect
+	// and deserialize later as this is a polymorphic field
+	Changes json.RawMessage `json:"changes"`
+
+	// GUID is included in the header of the request received by GitHub.
+	GUID string
+}
+
+// DiscussionCommentEventAction enumerates the triggers for this
+// webhook payload type. See also:
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#discussion_comment
+type DiscussionCommentEventAction string
+
+const (
+	// DiscussionCommentActionCreated means a comment was created.
+	DiscussionCommentActionCreated DiscussionCommentEventAction = "created"
+	// DiscussionCommentActionEdited means a comment was edited.
+	DiscussionCommentActionEdited DiscussionCommentEventAction = "edited"
+	// DiscussionCommentActionDeleted means a comment was deleted.
+	DiscussionCommentActionDeleted DiscussionCommentEventAction = "deleted"
+)
+
+// DiscussionCommentEvent holds information about a `discussion_comment`
+// GitHub webhook event.
+type DiscussionCommentEvent struct {
+	Action     DiscussionCommentEventAction `json:"action"`
+	Comment    DiscussionComment            `json:"comment"`
+	Discussion Discussion                   `json:"discussion"`
+	Repo       Repo                         `json:"repository"`
+	Sender     User                         `json:"sender"`
+
+	// Changes holds raw change data, which we must inspect
+	// and deserialize later as this is a polymorphic field
+	Changes json.RawMessage `json:"changes"`
+
+	// GUID is included in the header of the request received by GitHub.
+	GUID string
+}
+
 type App struct {
 	ID          int64                    `json:"id,omitempty"`
 	Slug        string                   `json:"slug,omitempty"`
@@ -1576,6 +2039,7 @@ type DirectoryContent struct {
 	Type string `json:"type"`
 	Name string `json:"name"`
 	Path string `json:"path"`
+	Size int64  `json:"size"`
 }
 
 // WorkflowRunEvent holds information about an `workflow_run` GitHub webhook event.
@@ -17,6 +17,7 @@ limitations under the License.
 package github
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -107,3 +108,66 @@ func TestUnmarshalClientError(t *testing.T) {
 		}
 	}
 }
+
+func TestUnmarshalCheckRunEvent(t *testing.T) {
+	payload := `{
+		"action": "requested_action",
+		"check_run": {"id": 4, "name": "lint", "head_sha": "abc", "status": "completed", "conclusion": "failure", "check_suite": {"id": 5}},
+		"requested_action": {"identifier": "fix"},
+		"repository": {"name": "repo", "owner": {"login": "org"}},
+		"sender": {"login": "alice"}
+	}`
+	var event CheckRunEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatalf("failed to unmarshal check run event: %v", err)
+	}
+	if event.Action != CheckRunActionRequestedAction || event.CheckRun.ID != 4 || event.CheckRun.CheckSuite.ID != 5 ||
+		event.RequestedAction == nil || event.RequestedAction.Identifier != "fix" || event.Repo.Owner.Login != "org" {
+		t.Errorf("unexpected check run event: %+v", event)
+	}
+}
+
+func TestUnmarshalGraphQLOnlyEvents(t *testing.T) {
+	var projectsV2Item ProjectsV2ItemEvent
+	if err := json.Unmarshal([]byte(`{
+		"action": "edited",
+		"projects_v2_item": {"id": 1, "node_id": "PVTI_1", "project_node_id": "PVT_1", "content_node_id": "I_1", "content_type": "Issue", "created_at": "2024-01-02T03:04:05Z"},
+		"changes": {"field_value": {"field_node_id": "F_1", "field_type": "single_select"}},
+		"organization": {"login": "org"},
+		"sender": {"login": "alice"}
+	}`), &projectsV2Item); err != nil {
+		t.Fatalf("failed to unmarshal projects_v2_item event: %v", err)
+	}
+	if projectsV2Item.Action != ProjectsV2ItemActionEdited || projectsV2Item.ProjectsV2Item.ContentNodeID != "I_1" ||
+		projectsV2Item.ProjectsV2Item.CreatedAt.IsZero() || projectsV2Item.Org.Login != "org" || len(projectsV2Item.Changes) == 0 {
+		t.Errorf("unexpected projects_v2_item event: %+v", projectsV2Item)
+	}
+
+	var mergeGroup MergeGroupEvent
+	if err := json.Unmarshal([]byte(`{
+		"action": "destroyed",
+		"reason": "merged",
+		"merge_group": {"head_sha": "abc", "head_ref": "refs/heads/gh-readonly-queue/main/pr-1-def", "base_sha": "def", "base_ref": "refs/heads/main", "head_commit": {"id": "abc", "message": "Merge"}},
+		"repository": {"name": "repo", "owner": {"login": "org"}}
+	}`), &mergeGroup); err != nil {
+		t.Fatalf("failed to unmarshal merge_group event: %v", err)
+	}
+	if mergeGroup.Action != MergeGroupActionDestroyed || mergeGroup.Reason != MergeGroupDestroyedMerged ||
+		mergeGroup.MergeGroup.HeadCommit.ID != "abc" || mergeGroup.MergeGroup.BaseRef != "refs/heads/main" {
+		t.Errorf("unexpected merge_group event: %+v", mergeGroup)
+	}
+
+	var discussionComment DiscussionCommentEvent
+	if err := json.Unmarshal([]byte(`{
+		"action": "created",
+		"comment": {"id": 2, "node_id": "DC_2", "body": "+1", "parent_id": 1, "user": {"login": "bob"}},
+		"discussion": {"id": 3, "node_id": "D_3", "number": 7, "title": "Q", "category": {"slug": "q-a", "is_answerable": true}},
+		"repository": {"name": "repo", "owner": {"login": "org"}}
+	}`), &discussionComment); err != nil {
+		t.Fatalf("failed to unmarshal discussion_comment event: %v", err)
+	}
+	if discussionComment.Action != DiscussionCommentActionCreated || discussionComment.Comment.ParentID == nil || *discussionComment.Comment.ParentID != 1 ||
+		discussionComment.Discussion.Number != 7 || !discussionComment.Discussion.Category.IsAnswerable {
+		t.Errorf("unexpected discussion_comment event: %+v", discussionComment)
+	}
+}
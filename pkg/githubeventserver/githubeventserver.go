@@ -49,6 +49,10 @@ const (
 	issuesEvent                   = "issues"
 	workflowRunEvent              = "workflow_run"
 	registryPackageEvent          = "registry_package"
+	projectsV2ItemEvent           = "projects_v2_item"
+	mergeGroupEvent               = "merge_group"
+	discussionEvent               = "discussion"
+	discussionCommentEvent        = "discussion_comment"
 )
 
 // GitHubEventServer hold all the information needed for the
@@ -96,6 +100,12 @@ func (g *GitHubEventServer) Shutdown(ctx context.Context) error {
 	return g.httpServer.Shutdown(ctx)
 }
 
+// ServeHTTP serves the webhook endpoint and the other registered handles
+// without running the http server.
+func (g *GitHubEventServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.httpServeMux.ServeHTTP(w, r)
+}
+
 // ReviewCommentEventHandler is a type of function that handles GitHub's review comment events
 type ReviewCommentEventHandler func(*logrus.Entry, github.ReviewCommentEvent)
 
@@ -123,6 +133,18 @@ type WorkflowRunEventHandler func(*logrus.Entry, github.WorkflowRunEvent)
 // RegistryPackageEventHandler is a type of function that handles GitHub's registry package events.
 type RegistryPackageEventHandler func(*logrus.Entry, github.RegistryPackageEvent)
 
+// ProjectsV2ItemEventHandler is a type of function that handles GitHub's projects v2 item events.
+type ProjectsV2ItemEventHandler func(*logrus.Entry, github.ProjectsV2ItemEvent)
+
+// MergeGroupEventHandler is a type of function that handles GitHub's merge group events.
+type MergeGroupEventHandler func(*logrus.Entry, github.MergeGroupEvent)
+
+// DiscussionEventHandler is a type of function that handles GitHub's discussion events.
+type DiscussionEventHandler func(*logrus.Entry, github.DiscussionEvent)
+
+// DiscussionCommentEventHandler is a type of function that handles GitHub's discussion comment events.
+type DiscussionCommentEventHandler func(*logrus.Entry, github.DiscussionCommentEvent)
+
 // RegisterReviewCommentEventHandler registers an ReviewCommentEventHandler function in GitHubEventServerOptions
 func (g *GitHubEventServer) RegisterReviewCommentEventHandler(fn ReviewCommentEventHandler) {
 	g.serveMuxHandler.reviewCommentEventHandlers = append(g.serveMuxHandler.reviewCommentEventHandlers, fn)
@@ -168,6 +190,26 @@ func (g *GitHubEventServer) RegisterRegistryPackageEventHandler(fn RegistryPacka
 	g.serveMuxHandler.registryPackageEventHandlers = append(g.serveMuxHandler.registryPackageEventHandlers, fn)
 }
 
+// RegisterProjectsV2ItemEventHandler registers a ProjectsV2ItemEventHandler function in GitHubEventServerOptions
+func (g *GitHubEventServer) RegisterProjectsV2ItemEventHandler(fn ProjectsV2ItemEventHandler) {
+	g.serveMuxHandler.projectsV2ItemEventHandlers = append(g.serveMuxHandler.projectsV2ItemEventHandlers, fn)
+}
+
+// RegisterMergeGroupEventHandler registers a MergeGroupEventHandler function in GitHubEventServerOptions
+func (g *GitHubEventServer) RegisterMergeGroupEventHandler(fn MergeGroupEventHandler) {
+	g.serveMuxHandler.mergeGroupEventHandlers = append(g.serveMuxHandler.mergeGroupEventHandlers, fn)
+}
+
+// RegisterDiscussionEventHandler registers a DiscussionEventHandler function in GitHubEventServerOptions
+func (g *GitHubEventServer) RegisterDiscussionEventHandler(fn DiscussionEventHandler) {
+	g.serveMuxHandler.discussionEventHandlers = append(g.serveMuxHandler.discussionEventHandlers, fn)
+}
+
+// RegisterDiscussionCommentEventHandler registers a DiscussionCommentEventHandler function in GitHubEventServerOptions
+func (g *GitHubEventServer) RegisterDiscussionCommentEventHandler(fn DiscussionCommentEventHandler) {
+	g.serveMuxHandler.discussionCommentEventHandlers = append(g.serveMuxHandler.discussionCommentEventHandlers, fn)
+}
+
 // RegisterExternalPlugins registers the external plugins in GitHubEventServerOptions
 func (g *GitHubEventServer) RegisterExternalPlugins(p map[string][]plugins.ExternalPlugin) {
 	g.serveMuxHandler.externalPlugins = p
@@ -201,15 +243,19 @@ type serveMuxHandler struct {
 	log *logrus.Entry
 	wg  *sync.WaitGroup
 
-	reviewCommentEventHandlers   []ReviewCommentEventHandler
-	reviewEventHandlers          []ReviewEventHandler
-	pullRequestHandlers          []PullRequestHandler
-	pushEventHandlers            []PushEventHandler
-	issueCommentEventHandlers    []IssueCommentEventHandler
-	issueEventHandlers           []IssueEventHandler
-	statusEventHandlers          []StatusEventHandler
-	workflowRunEventHandler      []WorkflowRunEventHandler
-	registryPackageEventHandlers []RegistryPackageEventHandler
+	reviewCommentEventHandlers     []ReviewCommentEventHandler
+	reviewEventHandlers            []ReviewEventHandler
+	pullRequestHandlers            []PullRequestHandler
+	pushEventHandlers              []PushEventHandler
+	issueCommentEventHandlers      []IssueCommentEventHandler
+	issueEventHandlers             []IssueEventHandler
+	statusEventHandlers            []StatusEventHandler
+	workflowRunEventHandler        []WorkflowRunEventHandler
+	registryPackageEventHandlers   []RegistryPackageEventHandler
+	projectsV2ItemEventHandlers    []ProjectsV2ItemEventHandler
+	mergeGroupEventHandlers        []MergeGroupEventHandler
+	discussionEventHandlers        []DiscussionEventHandler
+	discussionCommentEventHandlers []DiscussionCommentEventHandler
 
 	externalPlugins map[string][]plugins.ExternalPlugin
 
@@ -447,6 +493,99 @@ func (s *serveMuxHandler) handleEvent(eventType, eventGUID string, payload []byt
 			}()
 		}
 
+	case projectsV2ItemEvent:
+		var pie github.ProjectsV2ItemEvent
+		if err := json.Unmarshal(payload, &pie); err != nil {
+			return err
+		}
+		pie.GUID = eventGUID
+		// Projects belong to orgs, so the event has no repo.
+		org = pie.Org.Login
+
+		for _, projectsV2ItemEventHandler := range s.projectsV2ItemEventHandlers {
+			fn := projectsV2ItemEventHandler
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				fn(l.WithFields(logrus.Fields{
+					github.OrgLogField: pie.Org.Login,
+					"project":          pie.ProjectsV2Item.ProjectNodeID,
+					"item":             pie.ProjectsV2Item.NodeID,
+				}), pie)
+			}()
+		}
+
+	case mergeGroupEvent:
+		var mge github.MergeGroupEvent
+		if err := json.Unmarshal(payload, &mge); err != nil {
+			return err
+		}
+		mge.GUID = eventGUID
+		org = mge.Repo.Owner.Login
+		repo = mge.Repo.Name
+
+		for _, mergeGroupEventHandler := range s.mergeGroupEventHandlers {
+			fn := mergeGroupEventHandler
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				fn(l.WithFields(logrus.Fields{
+					github.OrgLogField:  mge.Repo.Owner.Login,
+					github.RepoLogField: mge.Repo.Name,
+					"ref":               mge.MergeGroup.HeadRef,
+					"head":              mge.MergeGroup.HeadSHA,
+				}), mge)
+			}()
+		}
+
+	case discussionEvent:
+		var de github.DiscussionEvent
+		if err := json.Unmarshal(payload, &de); err != nil {
+			return err
+		}
+		de.GUID = eventGUID
+		org = de.Repo.Owner.Login
+		repo = de.Repo.Name
+
+		for _, discussionEventHandler := range s.discussionEventHandlers {
+			fn := discussionEventHandler
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				fn(l.WithFields(logrus.Fields{
+					github.OrgLogField:  de.Repo.Owner.Login,
+					github.RepoLogField: de.Repo.Name,
+					"discussion":        de.Discussion.Number,
+					"author":            de.Discussion.User.Login,
+					"url":               de.Discussion.HTMLURL,
+				}), de)
+			}()
+		}
+
+	case discussionCommentEvent:
+		var dce github.DiscussionCommentEvent
+		if err := json.Unmarshal(payload, &dce); err != nil {
+			return err
+		}
+		dce.GUID = eventGUID
+		org = dce.Repo.Owner.Login
+		repo = dce.Repo.Name
+
+		for _, discussionCommentEventHandler := range s.discussionCommentEventHandlers {
+			fn := discussionCommentEventHandler
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				fn(l.WithFields(logrus.Fields{
+					github.OrgLogField:  dce.Repo.Owner.Login,
+					github.RepoLogField: dce.Repo.Name,
+					"discussion":        dce.Discussion.Number,
+					"author":            dce.Comment.User.Login,
+					"url":               dce.Comment.HTMLURL,
+				}), dce)
+			}()
+		}
+
 	default:
 		l.Debug("Ignoring unhandled event type.")
 	}
@@ -20,11 +20,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/sirupsen/logrus"
 
+	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
 
@@ -262,3 +265,45 @@ func TestGetExternalPluginsForEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGraphQLOnlyEvents(t *testing.T) {
+	var wg sync.WaitGroup
+	g := &GitHubEventServer{serveMuxHandler: &serveMuxHandler{metrics: NewMetrics(), wg: &wg}}
+	var lock sync.Mutex
+	var handled []string
+	record := func(event string) {
+		lock.Lock()
+		defer lock.Unlock()
+		handled = append(handled, event)
+	}
+	g.RegisterProjectsV2ItemEventHandler(func(_ *logrus.Entry, e github.ProjectsV2ItemEvent) {
+		record("projects_v2_item:" + e.GUID + ":" + e.ProjectsV2Item.NodeID)
+	})
+	g.RegisterMergeGroupEventHandler(func(_ *logrus.Entry, e github.MergeGroupEvent) {
+		record("merge_group:" + e.GUID + ":" + e.MergeGroup.HeadSHA)
+	})
+	g.RegisterDiscussionEventHandler(func(_ *logrus.Entry, e github.DiscussionEvent) {
+		record("discussion:" + e.GUID + ":" + e.Discussion.Title)
+	})
+	g.RegisterDiscussionCommentEventHandler(func(_ *logrus.Entry, e github.DiscussionCommentEvent) {
+		record("discussion_comment:" + e.GUID + ":" + e.Comment.Body)
+	})
+
+	for eventType, payload := range map[string]string{
+		"projects_v2_item":   `{"action": "created", "projects_v2_item": {"node_id": "PVTI_1"}, "organization": {"login": "org"}}`,
+		"merge_group":        `{"action": "checks_requested", "merge_group": {"head_sha": "abc"}, "repository": {"name": "repo", "owner": {"login": "org"}}}`,
+		"discussion":         `{"action": "created", "discussion": {"title": "Q"}, "repository": {"name": "repo", "owner": {"login": "org"}}}`,
+		"discussion_comment": `{"action": "created", "comment": {"body": "A"}, "repository": {"name": "repo", "owner": {"login": "org"}}}`,
+	} {
+		if err := g.serveMuxHandler.handleEvent(eventType, "guid", []byte(payload), http.Header{}); err != nil {
+			t.Errorf("failed to handle %s event: %v", eventType, err)
+		}
+	}
+	wg.Wait()
+
+	sort.Strings(handled)
+	expected := []string{"discussion:guid:Q", "discussion_comment:guid:A", "merge_group:guid:abc", "projects_v2_item:guid:PVTI_1"}
+	if !reflect.DeepEqual(expected, handled) {
+		t.Errorf("expected handled events %v, got %v", expected, handled)
+	}
+}
@@ -29,6 +29,9 @@ type Options struct {
 	// github event server operations.
 	Metrics *Metrics
 
+	// EndpointDefault is the default of the --endpoint flag, /hook if unset.
+	EndpointDefault string
+
 	// endpoint is the main url path that the github event server will be served.
 	endpoint string
 	// port will be used to start an http server to listen to.
@@ -50,6 +53,10 @@ func (o *Options) DefaultAndValidate() error {
 
 // Bind binds the flags into the given flagset.
 func (o *Options) Bind(fs *flag.FlagSet) {
-	fs.StringVar(&o.endpoint, "endpoint", "/hook", "The endpoint path where the http server will listen to")
+	endpoint := o.EndpointDefault
+	if endpoint == "" {
+		endpoint = "/hook"
+	}
+	fs.StringVar(&o.endpoint, "endpoint", endpoint, "The endpoint path where the http server will listen to")
 	fs.IntVar(&o.port, "port", 8888, "Port to listen on.")
 }
@@ -21,6 +21,7 @@ package pluginimports
 import (
 	_ "sigs.k8s.io/prow/pkg/plugins/approve" // Import all enabled plugins.
 	_ "sigs.k8s.io/prow/pkg/plugins/assign"
+	_ "sigs.k8s.io/prow/pkg/plugins/backport"
 	_ "sigs.k8s.io/prow/pkg/plugins/blockade"
 	_ "sigs.k8s.io/prow/pkg/plugins/blunderbuss"
 	_ "sigs.k8s.io/prow/pkg/plugins/branchcleaner"
@@ -31,15 +32,20 @@ import (
 	_ "sigs.k8s.io/prow/pkg/plugins/cherrypickunapproved"
 	_ "sigs.k8s.io/prow/pkg/plugins/cla"
 	_ "sigs.k8s.io/prow/pkg/plugins/dco"
+	_ "sigs.k8s.io/prow/pkg/plugins/dedup"
+	_ "sigs.k8s.io/prow/pkg/plugins/dependencyreview"
 	_ "sigs.k8s.io/prow/pkg/plugins/dog"
+	_ "sigs.k8s.io/prow/pkg/plugins/fastforward"
 	_ "sigs.k8s.io/prow/pkg/plugins/golint"
 	_ "sigs.k8s.io/prow/pkg/plugins/goose"
 	_ "sigs.k8s.io/prow/pkg/plugins/heart"
 	_ "sigs.k8s.io/prow/pkg/plugins/help"
 	_ "sigs.k8s.io/prow/pkg/plugins/hold"
 	_ "sigs.k8s.io/prow/pkg/plugins/invalidcommitmsg"
+	_ "sigs.k8s.io/prow/pkg/plugins/issuetemplate"
 	_ "sigs.k8s.io/prow/pkg/plugins/jira"
 	_ "sigs.k8s.io/prow/pkg/plugins/label"
+	_ "sigs.k8s.io/prow/pkg/plugins/largefiles"
 	_ "sigs.k8s.io/prow/pkg/plugins/lgtm"
 	_ "sigs.k8s.io/prow/pkg/plugins/lifecycle"
 	_ "sigs.k8s.io/prow/pkg/plugins/merge-method-comment"
@@ -50,6 +56,7 @@ import (
 	_ "sigs.k8s.io/prow/pkg/plugins/override"
 	_ "sigs.k8s.io/prow/pkg/plugins/owners-label"
 	_ "sigs.k8s.io/prow/pkg/plugins/pony"
+	_ "sigs.k8s.io/prow/pkg/plugins/prdescription"
 	_ "sigs.k8s.io/prow/pkg/plugins/project"
 	_ "sigs.k8s.io/prow/pkg/plugins/projectmanager"
 	_ "sigs.k8s.io/prow/pkg/plugins/releasenote"
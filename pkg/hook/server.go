@@ -18,6 +18,7 @@ package hook
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -28,29 +29,41 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/githubeventserver"
 	_ "sigs.k8s.io/prow/pkg/hook/plugin-imports"
 	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/tracing"
 )
 
 // Server implements http.Handler. It validates incoming GitHub webhooks and
 // then dispatches them to the appropriate plugins.
 type Server struct {
-	ClientAgent    *plugins.ClientAgent
-	Plugins        *plugins.ConfigAgent
-	ConfigAgent    *config.Agent
-	TokenGenerator func() []byte
-	Metrics        *githubeventserver.Metrics
-	RepoEnabled    func(org, repo string) bool
+	ClientAgent *plugins.ClientAgent
+	Plugins     *plugins.ConfigAgent
+	ConfigAgent *config.Agent
+	// CanaryConfigAgent holds the canary config revision, if any. It is used
+	// instead of ConfigAgent for the repos allowlisted in the stable config.
+	CanaryConfigAgent *config.Agent
+	TokenGenerator    func() []byte
+	Metrics           *githubeventserver.Metrics
+	RepoEnabled       func(org, repo string) bool
 
 	// c is an http client used for dispatching events
 	// to external plugin services.
 	c http.Client
 	// Tracks running handlers for graceful shutdown
 	wg sync.WaitGroup
+
+	// canaryJobs holds the stable config with the allowlisted jobs of the
+	// canary config, as built from canaryJobsFrom.
+	canaryJobsLock sync.Mutex
+	canaryJobs     *config.Agent
+	canaryJobsFrom [2]*config.Config
 }
 
 // ServeHTTP validates an incoming webhook and puts it into the event channel.
@@ -75,7 +88,14 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.Header) error {
-	l := logrus.WithFields(
+	// The span is the root of the traces of the ProwJobs the plugins create
+	// for the event, which they reach through the context of the logger.
+	ctx, span := tracing.Tracer().Start(context.Background(), "hook."+eventType,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String(github.EventGUID, eventGUID)),
+	)
+	defer span.End()
+	l := logrus.WithContext(ctx).WithFields(
 		logrus.Fields{
 			eventTypeField:   eventType,
 			github.EventGUID: eventGUID,
@@ -172,6 +192,10 @@ func (s *Server) demuxEvent(eventType, eventGUID string, payload []byte, h http.
 			return err
 		}
 		srcRepo = ge.Repo.FullName
+		if srcRepo == "" {
+			// Org level events like projects_v2_item only have an org.
+			srcRepo = ge.Org.Login
+		}
 		l.Debug("Ignoring unhandled event type. (Might still be handled by external plugins.)")
 	}
 	// Demux events only to external plugins that require this event.
@@ -266,6 +290,37 @@ func (s *Server) GracefulShutdown() {
 	s.wg.Wait() // Handle remaining requests
 }
 
+// configAgentFor returns the config agent to use for events of the given
+// repo. The canary allowlist is always read from the stable config so that a
+// canary revision can't widen its own rollout.
+func (s *Server) configAgentFor(org, repo string) *config.Agent {
+	if s.CanaryConfigAgent == nil {
+		return s.ConfigAgent
+	}
+	stable := s.ConfigAgent.Config()
+	if stable.CanaryApplies(org, repo) {
+		return s.CanaryConfigAgent
+	}
+	if stable.Canary == nil || len(stable.Canary.Jobs) == 0 {
+		return s.ConfigAgent
+	}
+	return s.canaryJobsAgent(stable, s.CanaryConfigAgent.Config())
+}
+
+// canaryJobsAgent returns an agent holding the stable config with the
+// allowlisted jobs of the canary config. It is only rebuilt when either
+// config changed.
+func (s *Server) canaryJobsAgent(stable, canary *config.Config) *config.Agent {
+	s.canaryJobsLock.Lock()
+	defer s.canaryJobsLock.Unlock()
+	if s.canaryJobs == nil || s.canaryJobsFrom != [2]*config.Config{stable, canary} {
+		s.canaryJobs = &config.Agent{}
+		s.canaryJobs.Set(stable.WithCanaryJobs(canary))
+		s.canaryJobsFrom = [2]*config.Config{stable, canary}
+	}
+	return s.canaryJobs
+}
+
 func (s *Server) do(req *http.Request) (*http.Response, error) {
 	var resp *http.Response
 	var err error
@@ -29,6 +29,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/githubeventserver"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
@@ -578,3 +579,48 @@ foo/bar:
 		})
 	}
 }
+
+func TestConfigAgentFor(t *testing.T) {
+	stable := &config.Agent{}
+	stable.Set(&config.Config{ProwConfig: config.ProwConfig{Canary: &config.Canary{Repos: []string{"org/canary"}}}})
+	canary := &config.Agent{}
+	canary.Set(&config.Config{})
+
+	s := &Server{ConfigAgent: stable}
+	if actual := s.configAgentFor("org", "canary"); actual != stable {
+		t.Error("expected the stable agent without a canary agent")
+	}
+	s.CanaryConfigAgent = canary
+	if actual := s.configAgentFor("org", "canary"); actual != canary {
+		t.Error("expected the canary agent for an allowlisted repo")
+	}
+	if actual := s.configAgentFor("org", "other"); actual != stable {
+		t.Error("expected the stable agent for a repo that is not allowlisted")
+	}
+}
+
+func TestConfigAgentForCanaryJobs(t *testing.T) {
+	stable := &config.Agent{}
+	stable.Set(&config.Config{
+		JobConfig:  config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{"org/repo": {{JobBase: config.JobBase{Name: "job", Cluster: "stable"}}}}},
+		ProwConfig: config.ProwConfig{Canary: &config.Canary{Jobs: []string{"job"}}},
+	})
+	canary := &config.Agent{}
+	canary.Set(&config.Config{JobConfig: config.JobConfig{PresubmitsStatic: map[string][]config.Presubmit{"org/repo": {{JobBase: config.JobBase{Name: "job", Cluster: "canary"}}}}}})
+
+	s := &Server{ConfigAgent: stable, CanaryConfigAgent: canary}
+	agent := s.configAgentFor("org", "repo")
+	if agent == stable || agent == canary {
+		t.Fatal("expected an agent merging the allowlisted canary jobs into the stable config")
+	}
+	if cluster := agent.Config().PresubmitsStatic["org/repo"][0].Cluster; cluster != "canary" {
+		t.Errorf("expected the canary definition of the allowlisted job, got the one of cluster %q", cluster)
+	}
+	if s.configAgentFor("org", "repo") != agent {
+		t.Error("expected the merged config to be reused while both configs are unchanged")
+	}
+	canary.Set(&config.Config{})
+	if s.configAgentFor("org", "repo") == agent {
+		t.Error("expected the merged config to be rebuilt after the canary config changed")
+	}
+}
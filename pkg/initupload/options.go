@@ -21,6 +21,7 @@ import (
 	"flag"
 
 	"sigs.k8s.io/prow/pkg/gcsupload"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 )
 
 const (
@@ -43,6 +44,13 @@ type Options struct {
 	// Log is the log file to which clone records are written. If unspecified, no clone records
 	// are uploaded.
 	Log string `json:"log,omitempty"`
+
+	// Caches are restored from blob storage after the upload.
+	Caches []cache.Cache `json:"caches,omitempty"`
+
+	// StartedMetadata are extra key/values added to the metadata of
+	// started.json.
+	StartedMetadata map[string]string `json:"started_metadata,omitempty"`
 }
 
 // ConfigVar exposes the environment variable used to store serialized configuration.
@@ -27,7 +27,10 @@ import (
 	"time"
 
 	"github.com/GoogleCloudPlatform/testgrid/metadata"
+	"github.com/sirupsen/logrus"
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	pkgio "sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 	"sigs.k8s.io/prow/pkg/pod-utils/clone"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
@@ -51,6 +54,7 @@ func (o Options) Run() error {
 	}
 
 	started := downwardapi.SpecToStarted(spec, cloneRecords)
+	addStartedMetadata(&started, o.StartedMetadata)
 
 	startedData, err := json.Marshal(&started)
 	if err != nil {
@@ -68,9 +72,42 @@ func (o Options) Run() error {
 		return errors.New("cloning the appropriate refs failed")
 	}
 
+	// A cache that cannot be restored only makes the test slower, so it
+	// does not fail the job.
+	if err := o.restoreCaches(ctx); err != nil {
+		logrus.WithError(err).Warn("Failed to restore caches.")
+	}
+
 	return nil
 }
 
+// addStartedMetadata adds the extra key/values to the metadata of started,
+// without overriding the values set by Prow.
+func addStartedMetadata(started *metadata.Started, extra map[string]string) {
+	if len(extra) == 0 {
+		return
+	}
+	if started.Metadata == nil {
+		started.Metadata = metadata.Metadata{}
+	}
+	for key, value := range extra {
+		if _, set := started.Metadata[key]; !set {
+			started.Metadata[key] = value
+		}
+	}
+}
+
+func (o Options) restoreCaches(ctx context.Context) error {
+	if len(o.Caches) == 0 || o.DryRun || o.LocalOutputDir != "" {
+		return nil
+	}
+	opener, err := pkgio.NewOpener(ctx, o.StorageClientOptions.GCSCredentialsFile, o.StorageClientOptions.S3CredentialsFile)
+	if err != nil {
+		return fmt.Errorf("new opener: %w", err)
+	}
+	return cache.Restore(ctx, opener, o.Bucket, o.Caches)
+}
+
 // processCloneLog checks if clone operation succeeded or failed for a ref
 // and upload clone logs as build log upon failures.
 // returns: bool - clone status
@@ -171,3 +171,35 @@ func TestSpecToStarted(t *testing.T) {
 		}
 	}
 }
+
+func TestAddStartedMetadata(t *testing.T) {
+	var tests = []struct {
+		name     string
+		metadata metadata.Metadata
+		extra    map[string]string
+		expected metadata.Metadata
+	}{
+		{
+			name: "no extra metadata",
+		},
+		{
+			name:     "extra metadata added",
+			extra:    map[string]string{"tenant": "sig-node", "purpose": "release"},
+			expected: metadata.Metadata{"tenant": "sig-node", "purpose": "release"},
+		},
+		{
+			name:     "extra metadata does not override existing values",
+			metadata: metadata.Metadata{"tenant": "prow"},
+			extra:    map[string]string{"tenant": "sig-node", "purpose": "release"},
+			expected: metadata.Metadata{"tenant": "prow", "purpose": "release"},
+		},
+	}
+
+	for _, test := range tests {
+		started := metadata.Started{Metadata: test.metadata}
+		addStartedMetadata(&started, test.extra)
+		if !reflect.DeepEqual(started.Metadata, test.expected) {
+			t.Errorf("%s: got metadata: %#v, but expected: %#v", test.name, started.Metadata, test.expected)
+		}
+	}
+}
@@ -19,7 +19,10 @@ package fakeopener
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
+	"sort"
+	"strings"
 
 	pkgio "sigs.k8s.io/prow/pkg/io"
 )
@@ -85,3 +88,42 @@ func (fo *FakeOpener) Writer(ctx context.Context, path string, opts ...pkgio.Wri
 
 	return &nopReadWriteCloser{Buffer: fo.Buffer[path]}, nil
 }
+
+// Iterator lists the buffers whose path starts with the prefix. Like for
+// object storage, paths containing the delimiter after the prefix are
+// collapsed into directories.
+func (fo *FakeOpener) Iterator(ctx context.Context, prefix, delimiter string) (pkgio.ObjectIterator, error) {
+	seen := map[string]bool{}
+	var attrs []pkgio.ObjectAttributes
+	for path, buf := range fo.Buffer {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(path, prefix)
+		if i := strings.Index(rest, delimiter); delimiter != "" && i >= 0 {
+			dir := prefix + rest[:i+len(delimiter)]
+			if !seen[dir] {
+				seen[dir] = true
+				attrs = append(attrs, pkgio.ObjectAttributes{Name: dir, IsDir: true})
+			}
+			continue
+		}
+		segments := strings.Split(path, "/")
+		attrs = append(attrs, pkgio.ObjectAttributes{Name: path, ObjName: segments[len(segments)-1], Size: int64(buf.Len())})
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Name < attrs[j].Name })
+	return &fakeIterator{attrs: attrs}, nil
+}
+
+type fakeIterator struct {
+	attrs []pkgio.ObjectAttributes
+}
+
+func (it *fakeIterator) Next(_ context.Context) (pkgio.ObjectAttributes, error) {
+	if len(it.attrs) == 0 {
+		return pkgio.ObjectAttributes{}, io.EOF
+	}
+	attr := it.attrs[0]
+	it.attrs = it.attrs[1:]
+	return attr, nil
+}
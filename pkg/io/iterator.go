@@ -38,6 +38,8 @@ type ObjectAttributes struct {
 	Size int64
 	// Updated is the creation or modification time in case of the object.
 	Updated time.Time
+	// StorageClass is the storage class of the object, only set for GCS.
+	StorageClass string
 }
 
 // ObjectIterator iterates through storage objects
@@ -69,6 +71,7 @@ func (g gcsObjectIterator) Next(_ context.Context) (ObjectAttributes, error) {
 		attr.ObjName = nameSplit[len(nameSplit)-1]
 		attr.Size = oAttrs.Size
 		attr.Updated = oAttrs.Updated
+		attr.StorageClass = oAttrs.StorageClass
 	} else {
 		// directory
 		attr.Name = oAttrs.Prefix
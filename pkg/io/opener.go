@@ -93,6 +93,9 @@ type Opener interface {
 	SignedURL(ctx context.Context, path string, opts SignedURLOptions) (string, error)
 	Iterator(ctx context.Context, prefix, delimiter string) (ObjectIterator, error)
 	UpdateAtributes(context.Context, string, ObjectAttrsToUpdate) (*Attributes, error)
+	Delete(ctx context.Context, path string) error
+	SetStorageClass(ctx context.Context, path, storageClass string) error
+	Compose(ctx context.Context, path string, sources ...string) error
 }
 
 type opener struct {
@@ -391,6 +394,129 @@ func (o *opener) UpdateAtributes(ctx context.Context, path string, attrs ObjectA
 	}, nil
 }
 
+// Delete deletes the object at path, returning an IsNotExist() error when
+// missing.
+func (o *opener) Delete(ctx context.Context, path string) error {
+	if strings.HasPrefix(path, providers.GS+"://") {
+		g, err := o.openGCS(path)
+		if err != nil {
+			return fmt.Errorf("bad gcs path: %w", err)
+		}
+		return g.Delete(ctx)
+	}
+	if strings.HasPrefix(path, "/") {
+		return os.Remove(path)
+	}
+
+	bucket, relativePath, err := o.getBucket(ctx, path)
+	if err != nil {
+		return err
+	}
+	return bucket.Delete(ctx, relativePath)
+}
+
+// SetStorageClass rewrites the object at path with the given storage class.
+// Only GCS is supported.
+func (o *opener) SetStorageClass(ctx context.Context, path, storageClass string) error {
+	if !strings.HasPrefix(path, providers.GS+"://") {
+		return fmt.Errorf("unsupported provider: %q", path)
+	}
+
+	g, err := o.openGCS(path)
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+	copier := g.CopierFrom(g)
+	copier.StorageClass = storageClass
+	if _, err := copier.Run(ctx); err != nil {
+		return fmt.Errorf("rewrite: %w", err)
+	}
+	return nil
+}
+
+// Compose concatenates the sources into the object at path, which keeps the
+// attributes of the first source. Appending to an object is done by passing
+// the object itself as the first source; it is then only replaced if it did
+// not change in the meantime. Only GCS and local files are supported, and GCS
+// allows at most 32 sources in the same bucket.
+func (o *opener) Compose(ctx context.Context, path string, sources ...string) error {
+	if len(sources) == 0 {
+		return errors.New("no sources to compose")
+	}
+	if strings.HasPrefix(path, providers.GS+"://") {
+		g, err := o.openGCS(path)
+		if err != nil {
+			return fmt.Errorf("bad gcs path: %w", err)
+		}
+		handles := make([]*storage.ObjectHandle, 0, len(sources))
+		for _, source := range sources {
+			if !strings.HasPrefix(source, providers.GS+"://") {
+				return fmt.Errorf("cannot compose %q into %q", source, path)
+			}
+			handle, err := o.openGCS(source)
+			if err != nil {
+				return fmt.Errorf("bad gcs path: %w", err)
+			}
+			handles = append(handles, handle)
+		}
+		attrs, err := handles[0].Attrs(ctx)
+		if err != nil {
+			return fmt.Errorf("attributes of %q: %w", sources[0], err)
+		}
+		if sources[0] == path {
+			g = g.If(storage.Conditions{GenerationMatch: attrs.Generation})
+		}
+		composer := g.ComposerFrom(handles...)
+		composer.ContentType = attrs.ContentType
+		composer.ContentEncoding = attrs.ContentEncoding
+		composer.CacheControl = attrs.CacheControl
+		composer.Metadata = attrs.Metadata
+		if _, err := composer.Run(ctx); err != nil {
+			return fmt.Errorf("compose: %w", err)
+		}
+		return nil
+	}
+	if strings.HasPrefix(path, "/") || strings.HasPrefix(path, providers.File+"://") {
+		return composeFiles(path, sources...)
+	}
+	return fmt.Errorf("unsupported provider: %q", path)
+}
+
+// composeFiles concatenates the local source files into a temporary file that
+// then replaces the file at p.
+func composeFiles(p string, sources ...string) error {
+	p = strings.TrimPrefix(p, providers.File+"://")
+	composed, err := os.CreateTemp(path.Dir(p), ".compose-")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(composed.Name())
+	if info, err := os.Stat(strings.TrimPrefix(sources[0], providers.File+"://")); err == nil {
+		if err := composed.Chmod(info.Mode().Perm()); err != nil {
+			composed.Close()
+			return fmt.Errorf("chmod temporary file: %w", err)
+		}
+	}
+	for _, source := range sources {
+		if err := func() error {
+			f, err := os.Open(strings.TrimPrefix(source, providers.File+"://"))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(composed, f)
+			return err
+		}(); err != nil {
+			composed.Close()
+			return fmt.Errorf("copy %q: %w", source, err)
+		}
+	}
+	if err := composed.Close(); err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+	return os.Rename(composed.Name(), p)
+}
+
 const (
 	GSAnonHost   = "storage.googleapis.com"
 	GSCookieHost = "storage.cloud.google.com"
@@ -478,7 +604,7 @@ func (o *opener) Iterator(ctx context.Context, prefix, delimiter string) (Object
 		}
 		if delimiter == "" {
 			// query.SetAttrSelection cannot be used in directory-like mode (when delimiter != "").
-			if err := query.SetAttrSelection([]string{"Name"}); err != nil {
+			if err := query.SetAttrSelection([]string{"Name", "StorageClass"}); err != nil {
 				return nil, err
 			}
 		}
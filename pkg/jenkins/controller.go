@@ -21,10 +21,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/bwmarrin/snowflake"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/clock"
@@ -48,6 +50,7 @@ type jenkinsClient interface {
 	Build(*prowapi.ProwJob, string) error
 	ListBuilds(jobs []BuildQueryParams) (map[string]Build, error)
 	Abort(job string, build *Build) error
+	GetStages(job string, build *Build) ([]Stage, error)
 }
 
 type githubClient interface {
@@ -278,9 +281,9 @@ func (c *Controller) terminateDupes(pjs []prowapi.ProwJob, jbs map[string]Build)
 			dupes[n] = i
 			continue
 		}
-		cancelIndex := i
+		cancelIndex, newerIndex := i, prev
 		if (&pjs[prev].Status.StartTime).Before(&pj.Status.StartTime) {
-			cancelIndex = prev
+			cancelIndex, newerIndex = prev, i
 			dupes[n] = i
 		}
 		toCancel := pjs[cancelIndex]
@@ -304,6 +307,10 @@ func (c *Controller) terminateDupes(pjs []prowapi.ProwJob, jbs map[string]Build)
 		prevState := toCancel.Status.State
 		toCancel.Status.State = prowapi.AbortedState
 		toCancel.Status.Description = "Aborted as the newer version of this job is running."
+		toCancel.Status.Cancellation = &prowapi.Cancellation{
+			Reason:       prowapi.CancelledSuperseded,
+			SupersededBy: pjs[newerIndex].Name,
+		}
 		c.log.WithFields(pjutil.ProwJobFields(&toCancel)).
 			WithField("from", prevState).
 			WithField("to", toCancel.Status.State).Info("Transitioning states.")
@@ -356,6 +363,10 @@ func (c *Controller) syncPendingJob(pj prowapi.ProwJob, reports chan<- prowapi.P
 		pj.Status.URL = c.cfg().StatusErrorLink
 		pj.Status.Description = "Error finding Jenkins job."
 	} else {
+		stages := pj.Status.JenkinsStages
+		if jb.IsPipeline() && !jb.IsEnqueued() {
+			stages = c.pipelineStages(&pj, &jb)
+		}
 		switch {
 		case jb.IsEnqueued():
 			// Still in queue.
@@ -365,10 +376,14 @@ func (c *Controller) syncPendingJob(pj prowapi.ProwJob, reports chan<- prowapi.P
 		case jb.IsRunning():
 			// Build still going.
 			c.incrementNumPendingJobs(pj.Spec.Job)
-			if pj.Status.Description == "Jenkins job running." {
+			description := "Jenkins job running."
+			if running := stagesInState(stages, prowapi.PendingState); len(running) > 0 {
+				description = fmt.Sprintf("Jenkins job running stage %s.", strings.Join(running, ", "))
+			}
+			if pj.Status.Description == description && equality.Semantic.DeepEqual(pj.Status.JenkinsStages, stages) {
 				return nil
 			}
-			pj.Status.Description = "Jenkins job running."
+			pj.Status.Description = description
 
 		case jb.IsSuccess():
 			// Build is complete.
@@ -380,12 +395,16 @@ func (c *Controller) syncPendingJob(pj prowapi.ProwJob, reports chan<- prowapi.P
 			pj.SetComplete()
 			pj.Status.State = prowapi.FailureState
 			pj.Status.Description = "Jenkins job failed."
+			if failed := stagesInState(stages, prowapi.FailureState); len(failed) > 0 {
+				pj.Status.Description = fmt.Sprintf("Jenkins job failed in stage %s.", strings.Join(failed, ", "))
+			}
 
 		case jb.IsAborted():
 			pj.SetComplete()
 			pj.Status.State = prowapi.AbortedState
 			pj.Status.Description = "Jenkins job aborted."
 		}
+		pj.Status.JenkinsStages = stages
 		// Construct the status URL that will be used in reports.
 		pj.Status.PodName = pj.ObjectMeta.Name
 		pj.Status.BuildID = jb.BuildID()
@@ -408,6 +427,49 @@ func (c *Controller) syncPendingJob(pj prowapi.ProwJob, reports chan<- prowapi.P
 	return err
 }
 
+// pipelineStages returns the stages of the Pipeline build of the ProwJob. The
+// stages last recorded in the ProwJob are kept if they cannot be listed.
+func (c *Controller) pipelineStages(pj *prowapi.ProwJob, jb *Build) []prowapi.JenkinsStage {
+	stages, err := c.jc.GetStages(getJobName(&pj.Spec), jb)
+	if err != nil {
+		c.log.WithError(err).WithFields(pjutil.ProwJobFields(pj)).Warn("Cannot get Jenkins pipeline stages")
+		return pj.Status.JenkinsStages
+	}
+	var result []prowapi.JenkinsStage
+	for _, stage := range stages {
+		var state prowapi.ProwJobState
+		switch stage.Status {
+		case stageSuccess:
+			state = prowapi.SuccessState
+		case stageFailed, stageUnstable:
+			state = prowapi.FailureState
+		case stageAborted:
+			state = prowapi.AbortedState
+		case stageInProgress, stagePendingInput:
+			state = prowapi.PendingState
+		case stageNotExecuted:
+			// Skipped, e.g. after an earlier stage failed.
+			continue
+		default:
+			c.log.WithFields(pjutil.ProwJobFields(pj)).Debugf("Ignoring stage %q with unknown status %q", stage.Name, stage.Status)
+			continue
+		}
+		result = append(result, prowapi.JenkinsStage{Name: stage.Name, State: state})
+	}
+	return result
+}
+
+// stagesInState returns the names of the stages in the given state.
+func stagesInState(stages []prowapi.JenkinsStage, state prowapi.ProwJobState) []string {
+	var names []string
+	for _, stage := range stages {
+		if stage.State == state {
+			names = append(names, stage.Name)
+		}
+	}
+	return names
+}
+
 func (c *Controller) syncAbortedJob(pj prowapi.ProwJob, _ chan<- prowapi.ProwJob, jbs map[string]Build) error {
 	if pj.Status.State != prowapi.AbortedState || pj.Complete() {
 		return nil
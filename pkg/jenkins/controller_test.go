@@ -112,6 +112,8 @@ type fjc struct {
 	builds      map[string]Build
 	didAbort    bool
 	abortErrors bool
+	stages      []Stage
+	stagesErr   error
 }
 
 func (f *fjc) Build(pj *prowapi.ProwJob, buildID string) error {
@@ -144,6 +146,12 @@ func (f *fjc) Abort(job string, build *Build) error {
 	return nil
 }
 
+func (f *fjc) GetStages(job string, build *Build) ([]Stage, error) {
+	f.Lock()
+	defer f.Unlock()
+	return f.stages, f.stagesErr
+}
+
 type fghc struct {
 	sync.Mutex
 	changes []github.PullRequestChange
@@ -386,6 +394,11 @@ func TestSyncPendingJobs(t *testing.T) {
 		expectedReport   bool
 		expectedEnqueued bool
 		expectedError    bool
+
+		stages              []Stage
+		stagesErr           error
+		expectedDescription string
+		expectedStages      []prowapi.JenkinsStage
 	}{
 		{
 			name: "enqueued",
@@ -527,6 +540,120 @@ func TestSyncPendingJobs(t *testing.T) {
 			expectedComplete: true,
 			expectedReport:   true,
 		},
+		{
+			name: "pipeline running",
+			pj: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipe",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job: "folder/test-job",
+				},
+				Status: prowapi.ProwJobStatus{
+					State:       prowapi.PendingState,
+					Description: "Jenkins job running.",
+				},
+			},
+			builds: map[string]Build{
+				"pipe": {Class: workflowRunClass, Number: 13},
+			},
+			stages: []Stage{
+				{Name: "Build", Status: stageSuccess},
+				{Name: "Test", Status: stageInProgress},
+				{Name: "Deploy", Status: stageNotExecuted},
+			},
+			expectedURL:         "pipe/pending",
+			expectedState:       prowapi.PendingState,
+			expectedReport:      true,
+			expectedDescription: "Jenkins job running stage Test.",
+			expectedStages: []prowapi.JenkinsStage{
+				{Name: "Build", State: prowapi.SuccessState},
+				{Name: "Test", State: prowapi.PendingState},
+			},
+		},
+		{
+			name: "pipeline running, stages unchanged",
+			pj: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipe",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job: "test-job",
+				},
+				Status: prowapi.ProwJobStatus{
+					State:         prowapi.PendingState,
+					Description:   "Jenkins job running stage Test.",
+					JenkinsStages: []prowapi.JenkinsStage{{Name: "Test", State: prowapi.PendingState}},
+				},
+			},
+			builds: map[string]Build{
+				"pipe": {Class: workflowRunClass, Number: 13},
+			},
+			stages:              []Stage{{Name: "Test", Status: stageInProgress}},
+			expectedState:       prowapi.PendingState,
+			expectedDescription: "Jenkins job running stage Test.",
+			expectedStages:      []prowapi.JenkinsStage{{Name: "Test", State: prowapi.PendingState}},
+		},
+		{
+			name: "pipeline failed in a stage",
+			pj: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipe",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job: "test-job",
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.PendingState,
+				},
+			},
+			builds: map[string]Build{
+				"pipe": {Class: workflowRunClass, Result: pState(failure), Number: 13},
+			},
+			stages: []Stage{
+				{Name: "Build", Status: stageSuccess},
+				{Name: "Test", Status: stageFailed},
+				{Name: "Deploy", Status: stageNotExecuted},
+			},
+			expectedURL:         "pipe/failure",
+			expectedState:       prowapi.FailureState,
+			expectedComplete:    true,
+			expectedReport:      true,
+			expectedDescription: "Jenkins job failed in stage Test.",
+			expectedStages: []prowapi.JenkinsStage{
+				{Name: "Build", State: prowapi.SuccessState},
+				{Name: "Test", State: prowapi.FailureState},
+			},
+		},
+		{
+			name: "pipeline stages cannot be listed",
+			pj: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipe",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job: "test-job",
+				},
+				Status: prowapi.ProwJobStatus{
+					State:         prowapi.PendingState,
+					JenkinsStages: []prowapi.JenkinsStage{{Name: "Build", State: prowapi.PendingState}},
+				},
+			},
+			builds: map[string]Build{
+				"pipe": {Class: workflowRunClass, Result: pState(success), Number: 13},
+			},
+			stagesErr:           errors.New("stage view plugin not installed"),
+			expectedURL:         "pipe/success",
+			expectedState:       prowapi.SuccessState,
+			expectedComplete:    true,
+			expectedReport:      true,
+			expectedDescription: "Jenkins job succeeded.",
+			expectedStages:      []prowapi.JenkinsStage{{Name: "Build", State: prowapi.PendingState}},
+		},
 	}
 	for _, tc := range testcases {
 		t.Logf("scenario %q", tc.name)
@@ -535,7 +662,9 @@ func TestSyncPendingJobs(t *testing.T) {
 		}))
 		defer totServ.Close()
 		fjc := &fjc{
-			err: tc.err,
+			err:       tc.err,
+			stages:    tc.stages,
+			stagesErr: tc.stagesErr,
 		}
 		fakeProwJobClient := fake.NewSimpleClientset(&tc.pj)
 
@@ -595,6 +724,12 @@ func TestSyncPendingJobs(t *testing.T) {
 		if tc.expectedURL != actual.Status.URL {
 			t.Errorf("expected status URL: %s, got: %s", tc.expectedURL, actual.Status.URL)
 		}
+		if tc.expectedDescription != "" && tc.expectedDescription != actual.Status.Description {
+			t.Errorf("expected description %q, got %q", tc.expectedDescription, actual.Status.Description)
+		}
+		if !reflect.DeepEqual(tc.expectedStages, actual.Status.JenkinsStages) {
+			t.Errorf("expected stages %v, got %v", tc.expectedStages, actual.Status.JenkinsStages)
+		}
 	}
 }
 
@@ -58,6 +58,21 @@ const (
 	aborted  = "ABORTED"
 )
 
+// workflowRunClass is the class of the builds of Pipeline (workflow) jobs.
+const workflowRunClass = "org.jenkinsci.plugins.workflow.job.WorkflowRun"
+
+// Statuses of the stages of Pipeline builds, as reported by the Pipeline
+// Stage View plugin.
+const (
+	stageSuccess      = "SUCCESS"
+	stageFailed       = "FAILED"
+	stageUnstable     = "UNSTABLE"
+	stageAborted      = "ABORTED"
+	stageInProgress   = "IN_PROGRESS"
+	stagePendingInput = "PAUSED_PENDING_INPUT"
+	stageNotExecuted  = "NOT_EXECUTED"
+)
+
 // NotFoundError is returned by the Jenkins client when
 // a job does not exist in Jenkins.
 type NotFoundError struct {
@@ -89,6 +104,9 @@ type Parameter struct {
 
 // Build holds information about an instance of a jenkins job.
 type Build struct {
+	// Class is the Java class of the build, which tells Pipeline builds
+	// apart from the builds of other job types.
+	Class   string   `json:"_class"`
 	Actions []Action `json:"actions"`
 	Task    struct {
 		// Used for tracking unscheduled builds for jobs.
@@ -114,6 +132,12 @@ type JobProperty struct {
 	ParameterDefinitions []ParameterDefinition `json:"parameterDefinitions,omitempty"`
 }
 
+// Stage is a stage of a Pipeline build.
+type Stage struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
 // JobInfo holds infofmation about a job from $job/api/json endpoint
 type JobInfo struct {
 	Builds    []Build       `json:"builds"`
@@ -141,6 +165,12 @@ func (jb *Build) IsAborted() bool {
 	return jb.Result != nil && *jb.Result == aborted
 }
 
+// IsPipeline means the build is a run of a Pipeline (workflow) job, which
+// reports the results of its stages.
+func (jb *Build) IsPipeline() bool {
+	return jb.Class == workflowRunClass
+}
+
 // IsEnqueued means the job has created but has not started.
 func (jb *Build) IsEnqueued() bool {
 	return jb.enqueued
@@ -423,18 +453,21 @@ func (c *Client) doRequest(method, path string) (*http.Response, error) {
 
 // getJobName generates the correct job name for this job type
 func getJobName(spec *prowapi.ProwJobSpec) string {
-	jobName := spec.Job
-	if strings.Contains(jobName, "/") {
-		jobParts := strings.Split(strings.Trim(jobName, "/"), "/")
-		jobName = strings.Join(jobParts, "/job/")
+	// Jobs nested in folders are addressed as folder/job/name.
+	jobParts := strings.Split(strings.Trim(spec.Job, "/"), "/")
+	for i, part := range jobParts {
+		jobParts[i] = url.PathEscape(part)
 	}
+	jobName := strings.Join(jobParts, "/job/")
 
 	if spec.JenkinsSpec != nil && spec.JenkinsSpec.GitHubBranchSourceJob && spec.Refs != nil {
 		if len(spec.Refs.Pulls) > 0 {
 			return fmt.Sprintf("%s/view/change-requests/job/PR-%d", jobName, spec.Refs.Pulls[0].Number)
 		}
 
-		return fmt.Sprintf("%s/job/%s", jobName, spec.Refs.BaseRef)
+		// Multibranch jobs encode the slashes of branch names in the names
+		// of their branch jobs, e.g. release%2F1.0.
+		return fmt.Sprintf("%s/job/%s", jobName, url.PathEscape(strings.ReplaceAll(spec.Refs.BaseRef, "/", "%2F")))
 	}
 
 	return jobName
@@ -749,6 +782,24 @@ func (c *Client) GetBuilds(job string) (map[string]Build, error) {
 	return jenkinsBuilds, nil
 }
 
+// GetStages lists the stages of the provided Pipeline build for job. It
+// requires the Pipeline Stage View plugin.
+func (c *Client) GetStages(job string, build *Build) ([]Stage, error) {
+	c.logger.Debugf("GetStages(%v %v)", job, build.Number)
+
+	data, err := c.Get(fmt.Sprintf("/job/%s/%d/wfapi/describe", job, build.Number))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get stages of build %d for job %q: %w", build.Number, job, err)
+	}
+	page := struct {
+		Stages []Stage `json:"stages"`
+	}{}
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal stages of build %d for job %q: %w", build.Number, job, err)
+	}
+	return page.Stages, nil
+}
+
 // Abort aborts the provided Jenkins build for job.
 func (c *Client) Abort(job string, build *Build) error {
 	c.logger.Debugf("Abort(%v %v)", job, build.Number)
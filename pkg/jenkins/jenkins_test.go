@@ -206,6 +206,34 @@ func TestListBuilds(t *testing.T) {
 	}
 }
 
+func TestGetStages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/job/folder/job/pipeline/42/wfapi/describe" {
+			http.Error(w, "404 Not Found", http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id": "42", "status": "IN_PROGRESS", "stages": [{"id": "6", "name": "Build", "status": "SUCCESS"}, {"id": "12", "name": "Test", "status": "IN_PROGRESS"}]}`)
+	}))
+	defer ts.Close()
+
+	jc := Client{
+		logger:  logrus.WithField("client", "jenkins"),
+		client:  ts.Client(),
+		baseURL: ts.URL,
+	}
+	stages, err := jc.GetStages(getJobName(&prowapi.ProwJobSpec{Job: "folder/pipeline"}), &Build{Number: 42})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Stage{{Name: "Build", Status: stageSuccess}, {Name: "Test", Status: stageInProgress}}
+	if !reflect.DeepEqual(expected, stages) {
+		t.Errorf("expected stages %v, got %v", expected, stages)
+	}
+	if _, err := jc.GetStages("missing", &Build{Number: 1}); err == nil {
+		t.Error("expected an error for a missing build")
+	}
+}
+
 func TestBuildCreate(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -439,6 +467,22 @@ func TestGetJobName(t *testing.T) {
 			},
 			output: "folder1/job/folder2/job/my-jenkins-job-name/job/master",
 		},
+		{
+			name: "GitHub Branch Source based branch job with slashes in the branch name",
+			input: &prowapi.ProwJobSpec{
+				Agent: "jenkins",
+				Type:  prowapi.PostsubmitJob,
+				Job:   "folder1/my-jenkins-job-name",
+				JenkinsSpec: &prowapi.JenkinsSpec{
+					GitHubBranchSourceJob: true,
+				},
+				Refs: &prowapi.Refs{
+					BaseRef: "release/1.0",
+					BaseSHA: "deadbeef",
+				},
+			},
+			output: "folder1/job/my-jenkins-job-name/job/release%252F1.0",
+		},
 		{
 			name: "Static Jenkins job",
 			input: &prowapi.ProwJobSpec{
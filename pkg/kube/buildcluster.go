@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	coreapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/version"
+)
+
+// ValidateBuildCluster validates the spec of a BuildCluster.
+func ValidateBuildCluster(bc *prowapi.BuildCluster) error {
+	var errs []error
+	if u, err := url.Parse(bc.Spec.Server); err != nil || u.Scheme != "https" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("server %q must be an https URL", bc.Spec.Server))
+	}
+	auth := bc.Spec.Auth
+	if (auth.Token == nil) == (auth.WorkloadIdentity == nil) {
+		errs = append(errs, errors.New("exactly one of auth.token and auth.workload_identity must be set"))
+	}
+	if token := auth.Token; token != nil {
+		if token.SecretName == "" {
+			errs = append(errs, errors.New("auth.token.secret_name must be set"))
+		}
+		if rotation := token.Rotation; rotation != nil {
+			if rotation.ServiceAccount == "" || rotation.Namespace == "" {
+				errs = append(errs, errors.New("auth.token.rotation.service_account and auth.token.rotation.namespace must be set"))
+			} else {
+				for _, msg := range validation.IsDNS1123Subdomain(rotation.ServiceAccount) {
+					errs = append(errs, fmt.Errorf("auth.token.rotation.service_account: %s", msg))
+				}
+			}
+		}
+	}
+	if wi := auth.WorkloadIdentity; wi != nil && !filepath.IsAbs(wi.TokenFile) {
+		errs = append(errs, fmt.Errorf("auth.workload_identity.token_file %q must be an absolute path", wi.TokenFile))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// BuildClusterConfig returns the rest.Config to connect to the build cluster.
+// The token is the content of the secret key referenced by a BuildCluster
+// authenticating with a token and is ignored otherwise.
+func BuildClusterConfig(bc *prowapi.BuildCluster, token string) (rest.Config, error) {
+	if err := ValidateBuildCluster(bc); err != nil {
+		return rest.Config{}, fmt.Errorf("invalid build cluster %s: %w", bc.Name, err)
+	}
+	cfg := rest.Config{
+		Host:      bc.Spec.Server,
+		UserAgent: version.UserAgent(),
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: bc.Spec.CertificateAuthorityData,
+		},
+	}
+	if bc.Spec.Auth.Token != nil {
+		if token == "" {
+			return rest.Config{}, fmt.Errorf("build cluster %s: key %s of secret %s is empty", bc.Name, bc.Spec.Auth.Token.KeyOrDefault(), bc.Spec.Auth.Token.SecretName)
+		}
+		cfg.BearerToken = token
+	} else {
+		cfg.BearerTokenFile = bc.Spec.Auth.WorkloadIdentity.TokenFile
+	}
+	return cfg, nil
+}
+
+// BuildClusterToken returns the token referenced by a BuildCluster
+// authenticating with a token, or an empty string otherwise.
+func BuildClusterToken(ctx context.Context, client ctrlruntimeclient.Reader, bc *prowapi.BuildCluster) (string, error) {
+	if bc.Spec.Auth.Token == nil {
+		return "", nil
+	}
+	var secret coreapi.Secret
+	if err := client.Get(ctx, types.NamespacedName{Namespace: bc.Namespace, Name: bc.Spec.Auth.Token.SecretName}, &secret); err != nil {
+		return "", fmt.Errorf("failed to get token secret of build cluster %s: %w", bc.Name, err)
+	}
+	return string(secret.Data[bc.Spec.Auth.Token.KeyOrDefault()]), nil
+}
+
+// LoadBuildClusterConfigs loads the rest.Configs of the BuildCluster resources
+// in the namespace, keyed by the names of the resources. Clusters that are
+// disabled, invalid or whose token can not be read are skipped. The returned
+// fingerprint changes whenever the loaded configs change, e.g. because a
+// token was rotated, and is used to detect that components must restart.
+func LoadBuildClusterConfigs(ctx context.Context, client ctrlruntimeclient.Reader, namespace string, disabledClusters sets.Set[string]) (map[string]rest.Config, string, error) {
+	var list prowapi.BuildClusterList
+	if err := client.List(ctx, &list, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+		return nil, "", fmt.Errorf("failed to list build clusters: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+
+	configs := map[string]rest.Config{}
+	hash := sha256.New()
+	for i := range list.Items {
+		bc := &list.Items[i]
+		log := logrus.WithField("build-cluster", bc.Name)
+		if disabledClusters.Has(bc.Name) {
+			log.Info("Ignored build cluster that is disabled")
+			continue
+		}
+		token, err := BuildClusterToken(ctx, client, bc)
+		if err != nil {
+			log.WithError(err).Error("Ignored build cluster")
+			continue
+		}
+		cfg, err := BuildClusterConfig(bc, token)
+		if err != nil {
+			log.WithError(err).Error("Ignored build cluster")
+			continue
+		}
+		spec, err := json.Marshal(bc.Spec)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal spec of build cluster %s: %w", bc.Name, err)
+		}
+		fmt.Fprintf(hash, "%s\x00%s\x00%s\x00", bc.Name, spec, token)
+		configs[bc.Name] = cfg
+		log.Info("Loaded build cluster")
+	}
+	return configs, hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestBuildClusterConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     prowapi.BuildClusterSpec
+		token    string
+		expected rest.Config
+		err      bool
+	}{
+		{
+			name: "token",
+			spec: prowapi.BuildClusterSpec{
+				Server:                   "https://10.0.0.1",
+				CertificateAuthorityData: []byte("ca"),
+				Auth:                     prowapi.BuildClusterAuth{Token: &prowapi.BuildClusterToken{SecretName: "build01"}},
+			},
+			token: "secret",
+			expected: rest.Config{
+				Host:            "https://10.0.0.1",
+				BearerToken:     "secret",
+				TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")},
+			},
+		},
+		{
+			name: "workload identity",
+			spec: prowapi.BuildClusterSpec{
+				Server: "https://10.0.0.1",
+				Auth:   prowapi.BuildClusterAuth{WorkloadIdentity: &prowapi.WorkloadIdentity{TokenFile: "/var/run/secrets/build01/token"}},
+			},
+			expected: rest.Config{
+				Host:            "https://10.0.0.1",
+				BearerTokenFile: "/var/run/secrets/build01/token",
+			},
+		},
+		{
+			name: "empty token",
+			spec: prowapi.BuildClusterSpec{
+				Server: "https://10.0.0.1",
+				Auth:   prowapi.BuildClusterAuth{Token: &prowapi.BuildClusterToken{SecretName: "build01"}},
+			},
+			err: true,
+		},
+		{
+			name: "insecure server",
+			spec: prowapi.BuildClusterSpec{
+				Server: "http://10.0.0.1",
+				Auth:   prowapi.BuildClusterAuth{WorkloadIdentity: &prowapi.WorkloadIdentity{TokenFile: "/token"}},
+			},
+			err: true,
+		},
+		{
+			name: "no auth",
+			spec: prowapi.BuildClusterSpec{Server: "https://10.0.0.1"},
+			err:  true,
+		},
+		{
+			name: "both auths",
+			spec: prowapi.BuildClusterSpec{
+				Server: "https://10.0.0.1",
+				Auth: prowapi.BuildClusterAuth{
+					Token:            &prowapi.BuildClusterToken{SecretName: "build01"},
+					WorkloadIdentity: &prowapi.WorkloadIdentity{TokenFile: "/token"},
+				},
+			},
+			token: "secret",
+			err:   true,
+		},
+		{
+			name: "relative token file",
+			spec: prowapi.BuildClusterSpec{
+				Server: "https://10.0.0.1",
+				Auth:   prowapi.BuildClusterAuth{WorkloadIdentity: &prowapi.WorkloadIdentity{TokenFile: "token"}},
+			},
+			err: true,
+		},
+		{
+			name: "rotation without service account",
+			spec: prowapi.BuildClusterSpec{
+				Server: "https://10.0.0.1",
+				Auth: prowapi.BuildClusterAuth{Token: &prowapi.BuildClusterToken{
+					SecretName: "build01",
+					Rotation:   &prowapi.TokenRotation{Namespace: "prow"},
+				}},
+			},
+			token: "secret",
+			err:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bc := &prowapi.BuildCluster{ObjectMeta: metav1.ObjectMeta{Name: "build01"}, Spec: tc.spec}
+			cfg, err := BuildClusterConfig(bc, tc.token)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %t, got %v", tc.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expected, cfg, cmpopts.IgnoreFields(rest.Config{}, "UserAgent")); diff != "" {
+				t.Errorf("unexpected config (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadBuildClusterConfigs(t *testing.T) {
+	buildCluster := func(name string, secret string) *prowapi.BuildCluster {
+		return &prowapi.BuildCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prow"},
+			Spec: prowapi.BuildClusterSpec{
+				Server: "https://" + name,
+				Auth:   prowapi.BuildClusterAuth{Token: &prowapi.BuildClusterToken{SecretName: secret}},
+			},
+		}
+	}
+	tokenSecret := func(name, token string) *coreapi.Secret {
+		return &coreapi.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prow"},
+			Data:       map[string][]byte{"token": []byte(token)},
+		}
+	}
+	load := func(t *testing.T, disabled sets.Set[string], objs ...ctrlruntimeclient.Object) (map[string]rest.Config, string) {
+		builder := fakectrlruntimeclient.NewClientBuilder()
+		for _, obj := range objs {
+			builder = builder.WithObjects(obj)
+		}
+		configs, fingerprint, err := LoadBuildClusterConfigs(context.Background(), builder.Build(), "prow", disabled)
+		if err != nil {
+			t.Fatalf("failed to load build clusters: %v", err)
+		}
+		return configs, fingerprint
+	}
+
+	configs, fingerprint := load(t, sets.New("build03"),
+		buildCluster("build01", "build01-token"), tokenSecret("build01-token", "one"),
+		buildCluster("build02", "missing"),
+		buildCluster("build03", "build03-token"), tokenSecret("build03-token", "three"),
+	)
+	if diff := cmp.Diff(sets.New("build01"), sets.KeySet(configs)); diff != "" {
+		t.Errorf("unexpected build clusters (-want +got):\n%s", diff)
+	}
+	if token := configs["build01"].BearerToken; token != "one" {
+		t.Errorf("expected token one, got %q", token)
+	}
+
+	_, same := load(t, sets.New("build03"),
+		buildCluster("build01", "build01-token"), tokenSecret("build01-token", "one"),
+		buildCluster("build02", "missing"),
+		buildCluster("build03", "build03-token"), tokenSecret("build03-token", "rotated"),
+	)
+	if same != fingerprint {
+		t.Error("expected fingerprint to ignore disabled and invalid build clusters")
+	}
+	_, rotated := load(t, sets.New("build03"),
+		buildCluster("build01", "build01-token"), tokenSecret("build01-token", "rotated"),
+	)
+	if rotated == fingerprint {
+		t.Error("expected fingerprint to change when a token is rotated")
+	}
+}
@@ -69,6 +69,11 @@ const (
 	// IsOptionalLabel is added in resources created by prow and
 	// carries the Optional from a Presubmit job.
 	IsOptionalLabel = "prow.k8s.io/is-optional"
+	// CanaryLabel is added to jobs loaded from a canary config revision.
+	CanaryLabel = "prow.k8s.io/canary"
+	// TenantIDLabel is added in resources created by prow and
+	// carries the tenant_id of the job, if it has one.
+	TenantIDLabel = "prow.k8s.io/tenant-id"
 
 	// Gerrit related labels that are used by Prow
 
@@ -82,4 +87,10 @@ const (
 	GerritPatchset = "prow.k8s.io/gerrit-patchset"
 	// GerritReportLabel is the gerrit label prow will cast vote on, fallback to CodeReview label if unset
 	GerritReportLabel = "prow.k8s.io/gerrit-report-label"
+	// GerritHashtags are the comma-separated hashtags of the change that
+	// matched the gerrit_hashtags of the job
+	GerritHashtags = "prow.k8s.io/gerrit-hashtags"
+	// GerritTopic is the topic of the change if it matched the gerrit_topics
+	// of the job
+	GerritTopic = "prow.k8s.io/gerrit-topic"
 )
@@ -30,6 +30,7 @@ const (
 	ClaYes                      = "cncf-cla: yes"
 	CpApproved                  = "cherry-pick-approved"
 	CpUnapproved                = "do-not-merge/cherry-pick-not-approved"
+	DependenciesApproved        = "dependencies-approved"
 	DeprecationLabel            = "kind/deprecation"
 	GoodFirstIssue              = "good first issue"
 	Help                        = "help wanted"
@@ -37,11 +38,17 @@ const (
 	InvalidOwners               = "do-not-merge/invalid-owners-file"
 	InvalidBug                  = "bugzilla/invalid-bug"
 	LGTM                        = "lgtm"
+	LargeFiles                  = "do-not-merge/large-files"
+	LargeFilesAllowed           = "large-files-allowed"
 	LifecycleActive             = "lifecycle/active"
 	LifecycleFrozen             = "lifecycle/frozen"
 	LifecycleRotten             = "lifecycle/rotten"
 	LifecycleStale              = "lifecycle/stale"
 	MergeCommits                = "do-not-merge/contains-merge-commits"
+	MissingDescription          = "do-not-merge/missing-description"
+	NeedsBackportApproval       = "do-not-merge/needs-backport-approval"
+	NeedsDependencyReview       = "do-not-merge/needs-dependency-review"
+	NeedsMoreInformation        = "needs-more-information"
 	NeedsOkToTest               = "needs-ok-to-test"
 	NeedsRebase                 = "needs-rebase"
 	OkToTest                    = "ok-to-test"
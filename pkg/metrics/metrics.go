@@ -78,6 +78,14 @@ func ExposeMetrics(component string, pushGateway config.PushGateway, port int) {
 	ExposeMetricsWithRegistry(component, pushGateway, port, nil, nil)
 }
 
+// PushOnce pushes the metrics of the default registry to the push gateway
+// once. It is meant for short lived components that exit before the first
+// push interval elapses. Unlike pushMetrics, the metrics are not grouped by
+// instance, so every run replaces the metrics of the previous one.
+func PushOnce(component, endpoint string) error {
+	return fromGatherer(component, nil, endpoint, prometheus.DefaultGatherer)
+}
+
 // pushMetrics is meant to run in a goroutine and continuously push
 // metrics to the provided endpoint.
 func pushMetrics(component, endpoint string, interval time.Duration) {
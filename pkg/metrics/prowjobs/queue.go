@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prowjobs
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// queueTimeCollector tracks how long ProwJobs wait to be scheduled and to
+// start running. Unlike prow_job_runtime_seconds it is not labeled by job
+// name, which keeps its cardinality low enough to alert on.
+type queueTimeCollector struct {
+	triggered *prometheus.HistogramVec
+	pending   *prometheus.HistogramVec
+}
+
+// NewProwJobQueueTimeCollector creates histograms of the time ProwJobs spend
+// in the triggered and in the pending state, labeled by job type, org and
+// build cluster. Data is collected by hooking itself into the prowjob informer.
+func NewProwJobQueueTimeCollector(informer cache.SharedIndexInformer) prometheus.Collector {
+	c := newQueueTimeCollector()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldJob, newJob interface{}) {
+			c.update(oldJob.(*prowapi.ProwJob), newJob.(*prowapi.ProwJob))
+		},
+	})
+	return c
+}
+
+func newQueueTimeCollector() *queueTimeCollector {
+	labels := []string{
+		// type of the prowjob: presubmit, postsubmit, periodic, batch
+		"type",
+		// the org of the prowjob's repo
+		"org",
+		// the build cluster the prowjob is scheduled on
+		"cluster",
+	}
+	buckets := []float64{
+		(5 * time.Second).Seconds(),
+		(15 * time.Second).Seconds(),
+		(30 * time.Second).Seconds(),
+		(1 * time.Minute).Seconds(),
+		(2 * time.Minute).Seconds(),
+		(5 * time.Minute).Seconds(),
+		(10 * time.Minute).Seconds(),
+		(20 * time.Minute).Seconds(),
+		(30 * time.Minute).Seconds(),
+		(1 * time.Hour).Seconds(),
+		(2 * time.Hour).Seconds(),
+	}
+	return &queueTimeCollector{
+		triggered: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "prow_job_triggered_seconds",
+			Help:    "Time ProwJobs spend in the triggered state before they are scheduled or completed.",
+			Buckets: buckets,
+		}, labels),
+		pending: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "prow_job_pending_seconds",
+			Help:    "Time ProwJobs spend in the pending state before they complete.",
+			Buckets: append(buckets, (4 * time.Hour).Seconds(), (8 * time.Hour).Seconds()),
+		}, labels),
+	}
+}
+
+func (c *queueTimeCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.triggered.Describe(ch)
+	c.pending.Describe(ch)
+}
+
+func (c *queueTimeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.triggered.Collect(ch)
+	c.pending.Collect(ch)
+}
+
+func (c *queueTimeCollector) update(oldJob, newJob *prowapi.ProwJob) {
+	if oldJob == nil || oldJob.Status.State == newJob.Status.State {
+		return
+	}
+	var histogramVec *prometheus.HistogramVec
+	var start, end *metav1.Time
+	switch oldJob.Status.State {
+	case prowapi.TriggeredState:
+		histogramVec = c.triggered
+		start = &newJob.CreationTimestamp
+		// Jobs can go straight from triggered to a final state, e.g. when
+		// they are aborted before being scheduled.
+		end = newJob.Status.PendingTime
+		if end == nil {
+			end = newJob.Status.CompletionTime
+		}
+	case prowapi.PendingState:
+		histogramVec = c.pending
+		start = newJob.Status.PendingTime
+		end = newJob.Status.CompletionTime
+	default:
+		return
+	}
+	if start == nil || end == nil {
+		return
+	}
+	histogram, err := histogramVec.GetMetricWithLabelValues(string(newJob.Spec.Type), jobOrg(newJob), newJob.ClusterAlias())
+	if err != nil {
+		logrus.WithError(err).Error("Failed to get a histogram for a prowjob")
+		return
+	}
+	histogram.Observe(end.Sub(start.Time).Seconds())
+}
+
+func jobOrg(pj *prowapi.ProwJob) string {
+	if pj.Spec.Refs != nil {
+		return pj.Spec.Refs.Org
+	}
+	if len(pj.Spec.ExtraRefs) > 0 {
+		return pj.Spec.ExtraRefs[0].Org
+	}
+	return ""
+}
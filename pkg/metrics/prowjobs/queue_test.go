@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prowjobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestQueueTimeCollectorUpdate(t *testing.T) {
+	created := time.Now()
+	pending := v1.NewTime(created.Add(2 * time.Minute))
+	completed := v1.NewTime(created.Add(12 * time.Minute))
+
+	job := func(state prowapi.ProwJobState, pendingTime, completionTime *v1.Time) *prowapi.ProwJob {
+		return &prowapi.ProwJob{
+			ObjectMeta: v1.ObjectMeta{CreationTimestamp: v1.NewTime(created)},
+			Spec: prowapi.ProwJobSpec{
+				Type:    prowapi.PresubmitJob,
+				Cluster: "build01",
+				Refs:    &prowapi.Refs{Org: "org"},
+			},
+			Status: prowapi.ProwJobStatus{State: state, PendingTime: pendingTime, CompletionTime: completionTime},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		oldJob, newJob *prowapi.ProwJob
+		// expectedTriggered and expectedPending are the observed durations in
+		// seconds, zero if none is observed.
+		expectedTriggered float64
+		expectedPending   float64
+	}{
+		{
+			name:              "triggered to pending is time in triggered",
+			oldJob:            job(prowapi.TriggeredState, nil, nil),
+			newJob:            job(prowapi.PendingState, &pending, nil),
+			expectedTriggered: 120,
+		},
+		{
+			name:              "triggered to aborted is time in triggered",
+			oldJob:            job(prowapi.TriggeredState, nil, nil),
+			newJob:            job(prowapi.AbortedState, nil, &completed),
+			expectedTriggered: 720,
+		},
+		{
+			name:            "pending to success is time in pending",
+			oldJob:          job(prowapi.PendingState, &pending, nil),
+			newJob:          job(prowapi.SuccessState, &pending, &completed),
+			expectedPending: 600,
+		},
+		{
+			name:   "no state change is ignored",
+			oldJob: job(prowapi.PendingState, &pending, nil),
+			newJob: job(prowapi.PendingState, &pending, nil),
+		},
+		{
+			name:   "completed jobs are ignored",
+			oldJob: job(prowapi.SuccessState, &pending, &completed),
+			newJob: job(prowapi.FailureState, &pending, &completed),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := newQueueTimeCollector()
+			c.update(tc.oldJob, tc.newJob)
+			assertObserved(t, c.triggered, tc.expectedTriggered)
+			assertObserved(t, c.pending, tc.expectedPending)
+		})
+	}
+}
+
+func assertObserved(t *testing.T, histogramVec *prometheus.HistogramVec, expected float64) {
+	t.Helper()
+	collected := collect(histogramVec)
+	if expected == 0 {
+		if len(collected) != 0 {
+			t.Errorf("expected no observations, got %d series", len(collected))
+		}
+		return
+	}
+	if len(collected) != 1 {
+		t.Fatalf("expected one series, got %d", len(collected))
+	}
+	expectedLabels := []*dto.LabelPair{
+		toLabelPair("cluster", "build01"),
+		toLabelPair("org", "org"),
+		toLabelPair("type", string(prowapi.PresubmitJob)),
+	}
+	if diff := cmp.Diff(expectedLabels, collected[0].Label, cmpopts.IgnoreUnexported(dto.LabelPair{})); diff != "" {
+		t.Errorf("unexpected labels (-want +got):\n%s", diff)
+	}
+	if count, sum := collected[0].Histogram.GetSampleCount(), collected[0].Histogram.GetSampleSum(); count != 1 || sum != expected {
+		t.Errorf("expected one observation of %v, got %d with sum %v", expected, count, sum)
+	}
+}
@@ -23,21 +23,27 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
 )
 
 const (
 	PathGetInrepoconfig = "inrepoconfig"
 	PathPing            = "ping"
+	PathPrefetch        = "prefetch"
 )
 
 type Moonraker struct {
 	ConfigAgent       *config.Agent
 	InRepoConfigCache *config.InRepoConfigCache
+	// TokenGenerator returns the HMAC secret used to validate the webhooks
+	// received by ServePrefetch.
+	TokenGenerator func() []byte
 }
 
 type configSectionsToWatch struct {
@@ -104,6 +110,47 @@ func (mr *Moonraker) ServeGetInrepoconfig(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// ServePrefetch receives GitHub push events, as an external plugin of hook,
+// and warms up the cache with the ProwYAML of the pushed commit. The next
+// event for that branch is then served without having to go to Git.
+func (mr *Moonraker) ServePrefetch(w http.ResponseWriter, r *http.Request) {
+	eventType, eventGUID, payload, ok, _ := github.ValidateWebhook(w, r, mr.TokenGenerator)
+	if !ok {
+		return
+	}
+	fmt.Fprint(w, "Event received. Have a nice day.")
+
+	if eventType != "push" {
+		return
+	}
+	var pe github.PushEvent
+	if err := json.Unmarshal(payload, &pe); err != nil {
+		logrus.WithError(err).Info("unable to unmarshal push event")
+		return
+	}
+	log := logrus.WithFields(logrus.Fields{
+		github.EventGUID: eventGUID,
+		"repo":           pe.Repo.FullName,
+		"ref":            pe.Ref,
+	})
+	go mr.prefetch(log, pe)
+}
+
+func (mr *Moonraker) prefetch(log *logrus.Entry, pe github.PushEvent) {
+	// Tags and deleted branches are never the base of a change.
+	if pe.Deleted || !strings.HasPrefix(pe.Ref, "refs/heads/") {
+		return
+	}
+	baseSHAGetter := func() (string, error) {
+		return pe.After, nil
+	}
+	if _, err := mr.InRepoConfigCache.GetProwYAMLWithoutDefaults(pe.Repo.FullName, pe.Branch(), baseSHAGetter); err != nil {
+		log.WithError(err).Warn("unable to prefetch inrepoconfig ProwYAML")
+		return
+	}
+	log.Debug("Prefetched inrepoconfig ProwYAML.")
+}
+
 func (mr *Moonraker) RunConfigWatcher(ctx context.Context) error {
 	configEvent := make(chan config.Delta, 2)
 	mr.ConfigAgent.Subscribe(configEvent)
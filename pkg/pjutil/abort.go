@@ -92,15 +92,19 @@ func TerminateOlderJobs(pjc patchClient, log *logrus.Entry, pjs []prowapi.ProwJo
 			dupes[ji] = i
 			continue
 		}
-		cancelIndex := i
+		cancelIndex, newerIndex := i, prev
 		if (&pjs[prev].Status.StartTime).Before(&pj.Status.StartTime) {
-			cancelIndex = prev
+			cancelIndex, newerIndex = prev, i
 			dupes[ji] = i
 		}
 		toCancel := pjs[cancelIndex]
 		prevPJ := toCancel.DeepCopy()
 
 		toCancel.Status.State = prowapi.AbortedState
+		toCancel.Status.Cancellation = &prowapi.Cancellation{
+			Reason:       prowapi.CancelledSuperseded,
+			SupersededBy: pjs[newerIndex].Name,
+		}
 		if toCancel.Status.PrevReportStates == nil {
 			toCancel.Status.PrevReportStates = map[string]prowapi.ProwJobState{}
 		}
@@ -121,7 +125,10 @@ func TerminateOlderJobs(pjc patchClient, log *logrus.Entry, pjs []prowapi.ProwJo
 	return nil
 }
 
+// PatchProwjob patches srcPJ into destPJ, after bringing the conditions of
+// destPJ in line with its state.
 func PatchProwjob(ctx context.Context, pjc prowClient, log *logrus.Entry, srcPJ prowapi.ProwJob, destPJ prowapi.ProwJob) (*prowapi.ProwJob, error) {
+	destPJ.UpdateConditions()
 	srcPJData, err := json.Marshal(srcPJ)
 	if err != nil {
 		return nil, fmt.Errorf("marshal source prow job: %w", err)
@@ -455,6 +455,9 @@ func TestTerminateOlderJobs(t *testing.T) {
 					if job.Complete() {
 						t.Errorf("job %s was set to complete, TerminateOlderJobs must never set prowjobs as completed", job.Name)
 					}
+					if c := job.Status.Cancellation; c == nil || c.Reason != prowv1.CancelledSuperseded || c.SupersededBy == "" || c.SupersededBy == job.Name {
+						t.Errorf("job %s was not marked as superseded by another job: %+v", job.Name, c)
+					}
 					actuallyAbortedJobs.Insert(job.Name)
 				}
 			}
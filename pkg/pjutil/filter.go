@@ -29,12 +29,18 @@ import (
 
 var TestAllRe = regexp.MustCompile(`(?m)^/test all,?($|\s.*)`)
 
+// TestRequiredRe provides the regex for `/test-required`
+var TestRequiredRe = regexp.MustCompile(`(?m)^/test-required\s*$`)
+
 // RetestRe provides the regex for `/retest`
 var RetestRe = regexp.MustCompile(`(?m)^/retest\s*$`)
 
-// RetestRe provides the regex for `/retest-required`
+// RetestRequiredRe provides the regex for `/retest-required`
 var RetestRequiredRe = regexp.MustCompile(`(?m)^/retest-required\s*$`)
 
+// RetestFlakesRe provides the regex for `/retest-flakes`
+var RetestFlakesRe = regexp.MustCompile(`(?m)^/retest-flakes\s*$`)
+
 var OkToTestRe = regexp.MustCompile(`(?m)^/ok-to-test\s*$`)
 
 // AvailablePresubmits returns 3 sets of presubmits:
@@ -149,6 +155,26 @@ func (tf *TestAllFilter) Name() string {
 	return "test-all-filter"
 }
 
+// TestRequiredFilter builds a filter for `/test-required`, which behaves like
+// `/test all` for the presubmits required for merge only. Presubmits that run
+// before merge are forced to run, as Tide would.
+type TestRequiredFilter struct{}
+
+func NewTestRequiredFilter() *TestRequiredFilter {
+	return &TestRequiredFilter{}
+}
+
+func (tf *TestRequiredFilter) ShouldRun(p config.Presubmit) (bool, bool, bool) {
+	if !p.RequiredForMerge() {
+		return false, false, false
+	}
+	return !p.NeedsExplicitTrigger() || p.RunBeforeMerge, p.RunBeforeMerge, false
+}
+
+func (tf *TestRequiredFilter) Name() string {
+	return "test-required-filter"
+}
+
 // AggregateFilter builds a filter that evaluates the child filters in order
 // and returns the first match
 type AggregateFilter struct {
@@ -240,6 +266,9 @@ func (rf *RetestFilter) Name() string {
 
 type RetestRequiredFilter struct {
 	failedContexts, allContexts sets.Set[string]
+	// requiredForMerge makes the filter skip the presubmits Tide does not
+	// require for merge rather than only the optional ones.
+	requiredForMerge bool
 }
 
 func NewRetestRequiredFilter(failedContexts, allContexts sets.Set[string]) *RetestRequiredFilter {
@@ -250,7 +279,7 @@ func NewRetestRequiredFilter(failedContexts, allContexts sets.Set[string]) *Rete
 }
 
 func (rrf *RetestRequiredFilter) ShouldRun(ps config.Presubmit) (bool, bool, bool) {
-	if ps.Optional {
+	if rrf.requiredForMerge && !ps.RequiredForMerge() || !rrf.requiredForMerge && ps.Optional {
 		return false, false, false
 	}
 	return NewRetestFilter(rrf.failedContexts, rrf.allContexts).ShouldRun(ps)
@@ -264,6 +293,20 @@ type contextGetter func() (sets.Set[string], sets.Set[string], error)
 
 // PresubmitFilter creates a filter for presubmits
 func PresubmitFilter(honorOkToTest bool, contextGetter contextGetter, body string, logger logrus.FieldLogger) (Filter, error) {
+	return presubmitFilter(honorOkToTest, false, contextGetter, body, logger)
+}
+
+// RequiredPresubmitFilter creates a filter for presubmits of repos whose policy
+// is to run the presubmits required for merge only: `/retest`, `/test all` and
+// `/ok-to-test` then behave like `/retest-required` and `/test-required`, and
+// `/retest-required` also skips the presubmits that skip reporting and
+// reruns the optional ones that run before merge.
+// Optional presubmits still run when requested by their own trigger.
+func RequiredPresubmitFilter(honorOkToTest bool, contextGetter contextGetter, body string, logger logrus.FieldLogger) (Filter, error) {
+	return presubmitFilter(honorOkToTest, true, contextGetter, body, logger)
+}
+
+func presubmitFilter(honorOkToTest, requiredOnly bool, contextGetter contextGetter, body string, logger logrus.FieldLogger) (Filter, error) {
 	// the filters determine if we should check whether a job should run, whether
 	// it should run regardless of whether its triggering conditions match, and
 	// what the default behavior should be for that check. Multiple filters
@@ -272,7 +315,7 @@ func PresubmitFilter(honorOkToTest bool, contextGetter contextGetter, body strin
 	// match before others. We order filters by amount of specificity.
 	var filters []Filter
 	filters = append(filters, NewCommandFilter(body))
-	if RetestRe.MatchString(body) {
+	if RetestRe.MatchString(body) && !requiredOnly {
 		logger.Info("Using retest filter.")
 		failedContexts, allContexts, err := contextGetter()
 		if err != nil {
@@ -280,17 +323,24 @@ func PresubmitFilter(honorOkToTest bool, contextGetter contextGetter, body strin
 		}
 		filters = append(filters, NewRetestFilter(failedContexts, allContexts))
 	}
-	if RetestRequiredRe.MatchString(body) {
+	if RetestRequiredRe.MatchString(body) || (RetestRe.MatchString(body) && requiredOnly) {
 		logger.Info("Using retest-required filter")
 		failedContexts, allContexts, err := contextGetter()
 		if err != nil {
 			return nil, err
 		}
-		filters = append(filters, NewRetestRequiredFilter(failedContexts, allContexts))
+		filter := NewRetestRequiredFilter(failedContexts, allContexts)
+		filter.requiredForMerge = requiredOnly
+		filters = append(filters, filter)
 	}
-	if (honorOkToTest && OkToTestRe.MatchString(body)) || TestAllRe.MatchString(body) {
+	testAll := (honorOkToTest && OkToTestRe.MatchString(body)) || TestAllRe.MatchString(body)
+	if testAll && !requiredOnly {
 		logger.Debug("Using test-all filter.")
 		filters = append(filters, NewTestAllFilter())
 	}
+	if TestRequiredRe.MatchString(body) || (testAll && requiredOnly) {
+		logger.Debug("Using test-required filter.")
+		filters = append(filters, NewTestRequiredFilter())
+	}
 	return NewAggregateFilter(filters), nil
 }
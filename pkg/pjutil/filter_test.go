@@ -467,6 +467,7 @@ func TestPresubmitFilter(t *testing.T) {
 	var testCases = []struct {
 		name                 string
 		honorOkToTest        bool
+		requiredOnly         bool
 		body, org, repo, ref string
 		presubmits           []config.Presubmit
 		expected             [][]bool
@@ -975,6 +976,125 @@ func TestPresubmitFilter(t *testing.T) {
 				{true, false, false},
 			},
 		},
+		{
+			name: "test required command selects the required tests that don't need an explicit trigger and those that run before merge",
+			body: "/test-required",
+			org:  "org",
+			repo: "repo",
+			ref:  "ref",
+			presubmits: []config.Presubmit{
+				{
+					JobBase:   config.JobBase{Name: "always-runs"},
+					AlwaysRun: true,
+				},
+				{
+					JobBase:   config.JobBase{Name: "optional-always-runs"},
+					AlwaysRun: true,
+					Optional:  true,
+				},
+				{
+					JobBase:  config.JobBase{Name: "skip-report-always-runs"},
+					Reporter: config.Reporter{SkipReport: true},
+				},
+				{
+					JobBase:        config.JobBase{Name: "optional-runs-before-merge"},
+					Optional:       true,
+					RunBeforeMerge: true,
+				},
+				{
+					JobBase: config.JobBase{Name: "runs-if-triggered"},
+				},
+			},
+			expected: [][]bool{{true, false, false}, {false, false, false}, {false, false, false}, {true, true, false}, {false, false, false}},
+		},
+		{
+			name:         "retest command only selects required tests when running required tests only",
+			body:         "/retest",
+			requiredOnly: true,
+			org:          "org",
+			repo:         "repo",
+			ref:          "ref",
+			presubmits: []config.Presubmit{
+				{
+					JobBase:  config.JobBase{Name: "failure-job"},
+					Reporter: config.Reporter{Context: "existing-failure"},
+					Optional: true,
+				},
+				{
+					JobBase:  config.JobBase{Name: "error-job"},
+					Reporter: config.Reporter{Context: "existing-error"},
+				},
+			},
+			expected: [][]bool{{false, false, false}, {true, false, true}},
+		},
+		{
+			name: "retest required command only skips optional tests by default",
+			body: "/retest-required",
+			org:  "org",
+			repo: "repo",
+			ref:  "ref",
+			presubmits: []config.Presubmit{
+				{
+					JobBase:  config.JobBase{Name: "skip-report-job"},
+					Reporter: config.Reporter{Context: "existing-failure", SkipReport: true},
+				},
+				{
+					JobBase:        config.JobBase{Name: "optional-runs-before-merge"},
+					Reporter:       config.Reporter{Context: "existing-error"},
+					Optional:       true,
+					RunBeforeMerge: true,
+				},
+			},
+			expected: [][]bool{{true, false, true}, {false, false, false}},
+		},
+		{
+			name:         "retest required command selects the tests required for merge when running required tests only",
+			body:         "/retest-required",
+			requiredOnly: true,
+			org:          "org",
+			repo:         "repo",
+			ref:          "ref",
+			presubmits: []config.Presubmit{
+				{
+					JobBase:  config.JobBase{Name: "skip-report-job"},
+					Reporter: config.Reporter{Context: "existing-failure", SkipReport: true},
+				},
+				{
+					JobBase:        config.JobBase{Name: "optional-runs-before-merge"},
+					Reporter:       config.Reporter{Context: "existing-error"},
+					Optional:       true,
+					RunBeforeMerge: true,
+				},
+			},
+			expected: [][]bool{{false, false, false}, {true, false, true}},
+		},
+		{
+			name:          "test all and ok-to-test only select required tests when running required tests only, explicit triggers still work",
+			body:          "/ok-to-test\n/test all\n/test trigger",
+			honorOkToTest: true,
+			requiredOnly:  true,
+			org:           "org",
+			repo:          "repo",
+			ref:           "ref",
+			presubmits: []config.Presubmit{
+				{
+					JobBase:   config.JobBase{Name: "always-runs"},
+					AlwaysRun: true,
+				},
+				{
+					JobBase:   config.JobBase{Name: "optional-always-runs"},
+					AlwaysRun: true,
+					Optional:  true,
+				},
+				{
+					JobBase:      config.JobBase{Name: "optional-runs-if-triggered"},
+					Optional:     true,
+					Trigger:      `(?m)^/test (?:.*? )?trigger(?: .*?)?$`,
+					RerunCommand: "/test trigger",
+				},
+			},
+			expected: [][]bool{{true, false, false}, {false, false, false}, {true, true, true}},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1001,7 +1121,11 @@ func TestPresubmitFilter(t *testing.T) {
 				return fsg.getContexts(key)
 			}
 
-			filter, err := PresubmitFilter(testCase.honorOkToTest, fakeContextGetter, testCase.body, logrus.WithField("test-case", testCase.name))
+			presubmitFilter := PresubmitFilter
+			if testCase.requiredOnly {
+				presubmitFilter = RequiredPresubmitFilter
+			}
+			filter, err := presubmitFilter(testCase.honorOkToTest, fakeContextGetter, testCase.body, logrus.WithField("test-case", testCase.name))
 
 			if testCase.expectErr && err == nil {
 				t.Errorf("%s: expected an error creating the filter, but got none", testCase.name)
@@ -242,11 +242,13 @@ func specFromJobBase(jb config.JobBase) prowapi.ProwJobSpec {
 		PipelineRunSpec:       jb.PipelineRunSpec,
 		TektonPipelineRunSpec: jb.TektonPipelineRunSpec,
 
-		ReporterConfig:  jb.ReporterConfig,
-		RerunAuthConfig: jb.RerunAuthConfig,
-		Hidden:          jb.Hidden,
-		ProwJobDefault:  jb.ProwJobDefault,
-		JobQueueName:    jb.JobQueueName,
+		ReporterConfig:    jb.ReporterConfig,
+		RerunAuthConfig:   jb.RerunAuthConfig,
+		Hidden:            jb.Hidden,
+		ProwJobDefault:    jb.ProwJobDefault,
+		JobQueueName:      jb.JobQueueName,
+		ArtifactRetention: jb.ArtifactRetention,
+		Secrets:           jb.Secrets,
 	}
 }
 
@@ -269,6 +271,9 @@ func DecorateRefs(refs prowapi.Refs, jb config.JobBase) *prowapi.Refs {
 	if refs.BloblessFetch == nil {
 		refs.BloblessFetch = dc.BloblessFetch
 	}
+	if refs.TreelessFetch == nil {
+		refs.TreelessFetch = dc.TreelessFetch
+	}
 	return &refs
 }
 
@@ -288,6 +293,9 @@ func CompletePrimaryRefs(refs prowapi.Refs, jb config.JobBase) *prowapi.Refs {
 	if jb.SkipFetchHead {
 		refs.SkipFetchHead = jb.SkipFetchHead
 	}
+	if jb.CloneFilter != "" {
+		refs.Filter = jb.CloneFilter
+	}
 	return DecorateRefs(refs, jb)
 }
 
@@ -56,6 +56,7 @@ func TestPostsubmitSpec(t *testing.T) {
 						SkipSubmodules: true,
 						CloneDepth:     7,
 						SkipFetchHead:  true,
+						CloneFilter:    "blob:none",
 					},
 				},
 			},
@@ -67,6 +68,7 @@ func TestPostsubmitSpec(t *testing.T) {
 					SkipSubmodules: true,
 					CloneDepth:     7,
 					SkipFetchHead:  true,
+					Filter:         "blob:none",
 				},
 				Report: true,
 			},
@@ -1533,6 +1535,36 @@ func TestSpecFromJobBase(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "Verify DecorateExtraRefs treeless default",
+			jobBase: config.JobBase{
+				UtilityConfig: config.UtilityConfig{
+					DecorationConfig: &prowapi.DecorationConfig{
+						TreelessFetch: boolPtr(true),
+					},
+					ExtraRefs: []prowapi.Refs{
+						{
+							Org:           "false-org",
+							TreelessFetch: boolPtr(false),
+						},
+						{
+							Org:           "default-org",
+							TreelessFetch: nil,
+						},
+					},
+				},
+			},
+			verify: func(pj prowapi.ProwJobSpec) error {
+				for _, r := range pj.ExtraRefs {
+					got := r.TreelessFetch
+					want := boolPtr(r.Org != "false-org")
+					if diff := cmp.Diff(want, got); diff != "" {
+						return fmt.Errorf("ExtraRefs TreelessFetch for %s differs (-want +got)\n%s", r.Org, diff)
+					}
+				}
+				return nil
+			},
+		},
 		{
 			name: "Verify DecorateExtraRefs default false",
 			jobBase: config.JobBase{
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pjutil
+
+import (
+	"errors"
+	"fmt"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	gerritsource "sigs.k8s.io/prow/pkg/gerrit/source"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func verifyRefs(refs *prowapi.Refs) error {
+	var errs []error
+	if refs == nil {
+		return errors.New("Refs must be supplied")
+	}
+	if len(refs.Org) == 0 {
+		errs = append(errs, errors.New("org must be supplied"))
+	}
+	if len(refs.Repo) == 0 {
+		errs = append(errs, errors.New("repo must be supplied"))
+	}
+	if len(refs.BaseRef) == 0 {
+		errs = append(errs, errors.New("baseRef must be supplied"))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func refsOrgRepo(refs *prowapi.Refs, labels map[string]string) string {
+	org, repo := refs.Org, refs.Repo
+	orgRepo := org + "/" + repo
+	// Normalize prefix to orgRepo if this is a gerrit job.
+	// (Unfortunately gerrit jobs use the full repo URL as the identifier.)
+	if labels[kube.GerritRevision] != "" && !gerritsource.IsGerritOrg(refs.Org) {
+		orgRepo = gerritsource.CloneURIFromOrgRepo(refs.Org, refs.Repo)
+	}
+	return orgRepo
+}
+
+type preOrPostsubmit interface {
+	GetName() string
+	CouldRun(string) bool
+	GetLabels() map[string]string
+	GetAnnotations() map[string]string
+}
+
+func getPreOrPostSpec[p preOrPostsubmit](jobGetter func(string) []p, creator func(p, prowapi.Refs) prowapi.ProwJobSpec, name string, refs *prowapi.Refs, labels map[string]string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
+	if err := verifyRefs(refs); err != nil {
+		return nil, nil, nil, err
+	}
+	var result *p
+	branch := refs.BaseRef
+	orgRepo := refsOrgRepo(refs, labels)
+	nameFound := false
+	for _, job := range jobGetter(orgRepo) {
+		job := job
+		if job.GetName() != name {
+			continue
+		}
+		nameFound = true
+		if job.CouldRun(branch) { // filter out jobs that are not branch matching
+			if result != nil {
+				return nil, nil, nil, fmt.Errorf("%s matches multiple prow jobs from orgRepo %q", name, orgRepo)
+			}
+			result = &job
+		}
+	}
+	if result == nil {
+		if nameFound {
+			return nil, nil, nil, fmt.Errorf("found job %q, but not allowed to run for orgRepo %q", name, orgRepo)
+		} else {
+			return nil, nil, nil, fmt.Errorf("failed to find job %q for orgRepo %q", name, orgRepo)
+		}
+	}
+
+	prowJobSpec := creator(*result, *refs)
+	return &prowJobSpec, (*result).GetLabels(), (*result).GetAnnotations(), nil
+}
+
+func getPresubmitSpec(cfg config.Getter, name string, refs *prowapi.Refs, labels map[string]string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
+	return getPreOrPostSpec(cfg().GetPresubmitsStatic, PresubmitSpec, name, refs, labels)
+}
+
+func getPostsubmitSpec(cfg config.Getter, name string, refs *prowapi.Refs, labels map[string]string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
+	return getPreOrPostSpec(cfg().GetPostsubmitsStatic, PostsubmitSpec, name, refs, labels)
+}
+
+func getPeriodicSpec(cfg config.Getter, name string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
+	var periodicJob *config.Periodic
+	for _, job := range cfg().AllPeriodics() {
+		if job.Name == name {
+			// Directly followed by break, so this is ok
+			// nolint: exportloopref
+			periodicJob = &job
+			break
+		}
+	}
+	if periodicJob == nil {
+		return nil, nil, nil, fmt.Errorf("failed to find associated periodic job %q", name)
+	}
+	prowJobSpec := PeriodicSpec(*periodicJob)
+	return &prowJobSpec, periodicJob.Labels, periodicJob.Annotations, nil
+}
+
+// ProwJobSpecFromConfig returns the spec of a new ProwJob for the job of the
+// given type and name in the config, along with the labels and annotations of
+// the job. Presubmits and postsubmits are looked up in the config of the org
+// and repo of the refs and must run against their base ref. The labels of the
+// ProwJob being rerun, if any, tell whether the refs are those of a Gerrit
+// change.
+func ProwJobSpecFromConfig(pjType prowapi.ProwJobType, cfg config.Getter, name string, refs *prowapi.Refs, labels map[string]string) (*prowapi.ProwJobSpec, map[string]string, map[string]string, error) {
+	switch pjType {
+	case prowapi.PeriodicJob:
+		return getPeriodicSpec(cfg, name)
+	case prowapi.PresubmitJob:
+		return getPresubmitSpec(cfg, name, refs, labels)
+	case prowapi.PostsubmitJob:
+		return getPostsubmitSpec(cfg, name, refs, labels)
+	default:
+		return nil, nil, nil, fmt.Errorf("Could not create new prowjob: Invalid prowjob type: %q", pjType)
+	}
+}
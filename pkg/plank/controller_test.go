@@ -33,6 +33,7 @@ import (
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	kapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -103,6 +104,12 @@ func newFakeConfigAgent(t *testing.T, maxConcurrency int, queueCapacities map[st
 					PodRunningTimeout:     &metav1.Duration{Duration: podRunningTimeout},
 					PodUnscheduledTimeout: &metav1.Duration{Duration: podUnscheduledTimeout},
 				},
+				ProwJobDefaultEntries: []*config.ProwJobDefaultEntry{
+					{OrgRepo: "restricted", Config: &prowapi.ProwJobDefault{TenantID: "restricted"}},
+				},
+				Tenants: map[string]config.Tenant{
+					"restricted": {AllowedClusters: []string{"trusted"}},
+				},
 			},
 			JobConfig: config.JobConfig{
 				PresubmitsStatic: presubmitMap,
@@ -704,6 +711,29 @@ func TestSyncTriggeredJobs(t *testing.T) {
 			ExpectedBuildID:     "0987654321",
 			ExpectedPodHasName:  true,
 		},
+		{
+			Name: "job violating tenant restrictions is not started",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blabla",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Job:       "boop",
+					Type:      prowapi.PeriodicJob,
+					Cluster:   prowapi.DefaultClusterAlias,
+					ExtraRefs: []prowapi.Refs{{Org: "restricted", Repo: "repo"}},
+					PodSpec:   &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State: prowapi.TriggeredState,
+				},
+			},
+			Pods:             map[string][]v1.Pod{"default": {}},
+			ExpectedState:    prowapi.ErrorState,
+			ExpectedNumPods:  map[string]int{"default": 0},
+			ExpectedComplete: true,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -843,6 +873,10 @@ func TestSyncPendingJob(t *testing.T) {
 		ExpectedPodRunningTimeout     *metav1.Duration
 		ExpectedPodPendingTimeout     *metav1.Duration
 		ExpectedPodUnscheduledTimeout *metav1.Duration
+		ExpectedTestSummary           *prowapi.TestSummary
+		ExpectedFailure               *prowapi.Failure
+		ExpectedDescription           string
+		ExpectedArtifactsUploaded     metav1.ConditionStatus
 	}
 	testcases := []testCase{
 		{
@@ -1068,6 +1102,131 @@ func TestSyncPendingJob(t *testing.T) {
 			ExpectedNumPods:  1,
 			ExpectedURL:      "boop-42/failure",
 		},
+		{
+			Name: "failed pod records the test summary of the sidecar",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Type:    prowapi.PeriodicJob,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodFailed,
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Name:  "test",
+								State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Message: `{"level":"error","msg":"tests failed"}`}},
+							},
+							{
+								Name:  "sidecar",
+								State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Message: `{"test_summary":{"passed":10,"failed":2,"skipped":0,"failed_tests":["TestFoo","TestBar"]},"artifacts_uploaded":true}`}},
+							},
+						},
+					},
+				},
+			},
+			ExpectedComplete:          true,
+			ExpectedState:             prowapi.FailureState,
+			ExpectedNumPods:           1,
+			ExpectedURL:               "boop-42/failure",
+			ExpectedTestSummary:       &prowapi.TestSummary{Passed: 10, Failed: 2, FailedTests: []string{"TestFoo", "TestBar"}},
+			ExpectedDescription:       "Job failed: 2 tests failed: TestFoo, TestBar.",
+			ExpectedArtifactsUploaded: metav1.ConditionTrue,
+		},
+		{
+			Name: "failed pod records the failure classified by the sidecar",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Type:    prowapi.PeriodicJob,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodFailed,
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Name:  "sidecar",
+								State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Message: `{"test_summary":{"passed":10,"failed":2,"skipped":0},"failure":{"category":"infra","message":"cluster setup failed.","owner":"sig-testing"},"artifacts_uploaded":true}`}},
+							},
+						},
+					},
+				},
+			},
+			ExpectedComplete:          true,
+			ExpectedState:             prowapi.FailureState,
+			ExpectedNumPods:           1,
+			ExpectedURL:               "boop-42/failure",
+			ExpectedTestSummary:       &prowapi.TestSummary{Passed: 10, Failed: 2},
+			ExpectedFailure:           &prowapi.Failure{Category: prowapi.FailureCategoryInfra, Message: "cluster setup failed.", Owner: "sig-testing"},
+			ExpectedDescription:       "Job failed: infra failure: cluster setup failed.",
+			ExpectedArtifactsUploaded: metav1.ConditionTrue,
+		},
+		{
+			Name: "failed pod ignores sidecar logs in the termination message",
+			PJ: prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "boop-42",
+					Namespace: "prowjobs",
+				},
+				Spec: prowapi.ProwJobSpec{
+					Type:    prowapi.PeriodicJob,
+					PodSpec: &v1.PodSpec{Containers: []v1.Container{{Name: "test-name", Env: []v1.EnvVar{}}}},
+				},
+				Status: prowapi.ProwJobStatus{
+					State:   prowapi.PendingState,
+					PodName: "boop-42",
+				},
+			},
+			Pods: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "boop-42",
+						Namespace: "pods",
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodFailed,
+						ContainerStatuses: []v1.ContainerStatus{
+							{
+								Name:  "sidecar",
+								State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Message: `{"level":"fatal","msg":"failed to upload"}`}},
+							},
+						},
+					},
+				},
+			},
+			ExpectedComplete: true,
+			ExpectedState:    prowapi.FailureState,
+			ExpectedNumPods:  1,
+			ExpectedURL:      "boop-42/failure",
+		},
 		{
 			Name: "delete evicted pod",
 			PJ: prowapi.ProwJob{
@@ -1687,6 +1846,22 @@ func TestSyncPendingJob(t *testing.T) {
 			if tc.ExpectedBuildID != "" && actual.Status.BuildID != tc.ExpectedBuildID {
 				t.Errorf("expected BuildID %q, got %q", tc.ExpectedBuildID, actual.Status.BuildID)
 			}
+			if tc.ExpectedDescription != "" && actual.Status.Description != tc.ExpectedDescription {
+				t.Errorf("expected description %q, got %q", tc.ExpectedDescription, actual.Status.Description)
+			}
+			if diff := cmp.Diff(tc.ExpectedTestSummary, actual.Status.TestSummary); diff != "" {
+				t.Errorf("unexpected test summary (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.ExpectedFailure, actual.Status.Failure); diff != "" {
+				t.Errorf("unexpected failure (-want +got):\n%s", diff)
+			}
+			var uploaded metav1.ConditionStatus
+			if c := meta.FindStatusCondition(actual.Status.Conditions, prowapi.ProwJobArtifactsUploaded); c != nil {
+				uploaded = c.Status
+			}
+			if uploaded != tc.ExpectedArtifactsUploaded {
+				t.Errorf("expected the %s condition to be %q, got %q", prowapi.ProwJobArtifactsUploaded, tc.ExpectedArtifactsUploaded, uploaded)
+			}
 			if actual.Spec.DecorationConfig != nil && actual.Spec.DecorationConfig.PodRunningTimeout != nil &&
 				tc.ExpectedPodRunningTimeout.Duration != actual.Spec.DecorationConfig.PodRunningTimeout.Duration {
 				t.Errorf("expected PodRunningTimeout %v, got %v",
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
+)
+
+const (
+	// prePullName is the name of the DaemonSet that pulls the images of
+	// jobs on every node of a build cluster.
+	prePullName = "prow-image-prepull"
+	// prePullSpecAnnotation holds a hash of the pod template of the DaemonSet.
+	prePullSpecAnnotation = "prow.k8s.io/prepull-spec"
+	pullTools             = "pull-tools"
+)
+
+// isDecorated tells whether the pod utilities, and with them the entrypoint
+// binary that pulling images relies on, are added to the pods of the job.
+func isDecorated(jb config.JobBase) bool {
+	return jb.Decorate != nil && *jb.Decorate && jb.DecorationConfig != nil && jb.DecorationConfig.UtilityImages != nil && jb.DecorationConfig.UtilityImages.Entrypoint != ""
+}
+
+// pullContainers returns init containers that pull the images on the node
+// of their pod, and the volume they share. Images have no common command
+// that exits, so the entrypoint binary is copied into the volume first and
+// then copies itself again from within every image.
+func pullContainers(decoration *prowv1.DecorationConfig, images []string) ([]corev1.Container, []corev1.Volume) {
+	toolsMount := corev1.VolumeMount{Name: pullTools, MountPath: "/tools"}
+	volumes := []corev1.Volume{{Name: pullTools, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	containers := []corev1.Container{decorate.PlaceEntrypoint(decoration, toolsMount)}
+	seen := map[string]bool{}
+	for _, image := range images {
+		if image == "" || seen[image] {
+			continue
+		}
+		seen[image] = true
+		containers = append(containers, corev1.Container{
+			Name:         fmt.Sprintf("pull-%d", len(seen)),
+			Image:        image,
+			Command:      []string{"/tools/entrypoint"},
+			Args:         []string{"--copy-mode-only", fmt.Sprintf("--copy-destination=/tools/pulled-%d", len(seen))},
+			VolumeMounts: []corev1.VolumeMount{toolsMount},
+		})
+	}
+	return containers, volumes
+}
+
+// prePullImages returns the images of the decorated jobs of the cluster that
+// are used by at least minJobs jobs, most used first, and the decoration
+// config to pull them with. It returns no images if no job is decorated.
+func prePullImages(cfg *config.Config, cluster string, minJobs int) ([]string, *prowv1.DecorationConfig) {
+	var jobs []config.JobBase
+	for _, ps := range cfg.AllStaticPresubmits(nil) {
+		jobs = append(jobs, ps.JobBase)
+	}
+	for _, ps := range cfg.AllStaticPostsubmits(nil) {
+		jobs = append(jobs, ps.JobBase)
+	}
+	for _, p := range cfg.AllPeriodics() {
+		jobs = append(jobs, p.JobBase)
+	}
+
+	counts := map[string]int{}
+	var decoration *prowv1.DecorationConfig
+	for _, jb := range jobs {
+		if jb.Cluster != cluster || jb.Spec == nil || !isDecorated(jb) {
+			continue
+		}
+		if decoration == nil {
+			decoration = jb.DecorationConfig
+		}
+		images := map[string]bool{}
+		for _, c := range append(jb.Spec.InitContainers, jb.Spec.Containers...) {
+			images[c.Image] = true
+		}
+		utilityImages := jb.DecorationConfig.UtilityImages
+		for _, image := range []string{utilityImages.CloneRefs, utilityImages.InitUpload, utilityImages.Sidecar} {
+			images[image] = true
+		}
+		for image := range images {
+			if image != "" {
+				counts[image]++
+			}
+		}
+	}
+
+	var images []string
+	for image, count := range counts {
+		if count >= minJobs {
+			images = append(images, image)
+		}
+	}
+	sort.Slice(images, func(i, j int) bool {
+		if counts[images[i]] != counts[images[j]] {
+			return counts[images[i]] > counts[images[j]]
+		}
+		return images[i] < images[j]
+	})
+	return images, decoration
+}
+
+// prePullDaemonSet returns the DaemonSet that pulls the images on every
+// node it is scheduled to. Its pods keep running so that a change of the
+// images rolls out to all nodes.
+func prePullDaemonSet(settings *config.ImagePrePull, namespace string, images []string, decoration *prowv1.DecorationConfig) *appsv1.DaemonSet {
+	pauseImage := settings.PauseImage
+	if pauseImage == "" {
+		pauseImage = defaultPauseImage
+	}
+	initContainers, volumes := pullContainers(decoration, images)
+	labels := map[string]string{"app": prePullName}
+	automount := false
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: labels},
+		Spec: corev1.PodSpec{
+			InitContainers: initContainers,
+			Containers: []corev1.Container{{
+				Name:  "pause",
+				Image: pauseImage,
+			}},
+			Volumes:                       volumes,
+			NodeSelector:                  settings.NodeSelector,
+			Tolerations:                   settings.Tolerations,
+			AutomountServiceAccountToken:  &automount,
+			TerminationGracePeriodSeconds: new(int64),
+		},
+	}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        prePullName,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: map[string]string{prePullSpecAnnotation: specHash(template.Spec)},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: template,
+		},
+	}
+}
+
+// syncPrePull keeps the pre-pull DaemonSets of the build clusters up to date
+// with the images of their jobs until the context is done.
+func (r *reconciler) syncPrePull(interval time.Duration) func(context.Context) error {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				for cluster, client := range r.buildClients {
+					if err := r.syncPrePullOfCluster(ctx, cluster, client); err != nil {
+						r.log.WithError(err).WithField("cluster", cluster).Error("Failed to sync image pre-pull DaemonSet.")
+					}
+				}
+			}
+		}
+	}
+}
+
+// syncPrePullOfCluster creates, updates or deletes the pre-pull DaemonSet of
+// the cluster. It is deleted if pre-pulling is not enabled for the cluster
+// or if there are no images to pull.
+func (r *reconciler) syncPrePullOfCluster(ctx context.Context, cluster string, client ctrlruntimeclient.Client) error {
+	cfg := r.config()
+	settings := cfg.Plank.ImagePrePull
+	var wanted *appsv1.DaemonSet
+	if settings.EnabledFor(cluster) {
+		if images, decoration := prePullImages(cfg, cluster, settings.MinJobs); len(images) > 0 {
+			wanted = prePullDaemonSet(settings, cfg.PodNamespace, images, decoration)
+		}
+	}
+
+	existing := &appsv1.DaemonSet{}
+	err := client.Get(ctx, types.NamespacedName{Namespace: cfg.PodNamespace, Name: prePullName}, existing)
+	switch {
+	case kerrors.IsNotFound(err):
+		if wanted == nil {
+			return nil
+		}
+		if err := client.Create(ctx, wanted); err != nil {
+			return fmt.Errorf("failed to create DaemonSet: %w", err)
+		}
+		r.log.WithField("cluster", cluster).Info("Created image pre-pull DaemonSet.")
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get DaemonSet: %w", err)
+	}
+
+	if wanted == nil {
+		if err := client.Delete(ctx, existing); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete DaemonSet: %w", err)
+		}
+		r.log.WithField("cluster", cluster).Info("Deleted image pre-pull DaemonSet.")
+		return nil
+	}
+	if existing.Annotations[prePullSpecAnnotation] == wanted.Annotations[prePullSpecAnnotation] {
+		return nil
+	}
+	existing.Labels = wanted.Labels
+	existing.Annotations = wanted.Annotations
+	existing.Spec.Template = wanted.Spec.Template
+	if err := client.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update DaemonSet: %w", err)
+	}
+	r.log.WithField("cluster", cluster).Info("Updated image pre-pull DaemonSet.")
+	return nil
+}
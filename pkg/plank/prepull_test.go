@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func prePullConfig(prePull *config.ImagePrePull) *config.Config {
+	decorate := true
+	utility := config.UtilityConfig{
+		Decorate: &decorate,
+		DecorationConfig: &prowv1.DecorationConfig{
+			UtilityImages: &prowv1.UtilityImages{
+				CloneRefs:  "clonerefs",
+				InitUpload: "initupload",
+				Entrypoint: "entrypoint",
+				Sidecar:    "sidecar",
+			},
+		},
+	}
+	job := func(name, cluster, image string, utility config.UtilityConfig) config.JobBase {
+		return config.JobBase{
+			Name:          name,
+			Agent:         "kubernetes",
+			Cluster:       cluster,
+			Spec:          &corev1.PodSpec{Containers: []corev1.Container{{Image: image}}},
+			UtilityConfig: utility,
+		}
+	}
+	return &config.Config{
+		JobConfig: config.JobConfig{
+			Periodics: []config.Periodic{
+				{JobBase: job("lint", "build01", "golang", utility)},
+				{JobBase: job("unit", "build01", "golang", utility)},
+				{JobBase: job("e2e", "build01", "kind", utility)},
+				{JobBase: job("bazel", "build01", "bazel", config.UtilityConfig{})},
+				{JobBase: job("other", "build02", "node", utility)},
+			},
+		},
+		ProwConfig: config.ProwConfig{
+			PodNamespace: "test-pods",
+			Plank:        config.Plank{ImagePrePull: prePull},
+		},
+	}
+}
+
+func TestPrePullImages(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cluster  string
+		minJobs  int
+		expected []string
+	}{
+		{
+			name:     "images of decorated jobs of the cluster, most used first",
+			cluster:  "build01",
+			expected: []string{"clonerefs", "initupload", "sidecar", "golang", "kind"},
+		},
+		{
+			name:     "images used by too few jobs are not pulled",
+			cluster:  "build01",
+			minJobs:  2,
+			expected: []string{"clonerefs", "initupload", "sidecar", "golang"},
+		},
+		{
+			name:    "cluster without jobs",
+			cluster: "build03",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			images, _ := prePullImages(prePullConfig(nil), tc.cluster, tc.minJobs)
+			if diff := cmp.Diff(tc.expected, images); diff != "" {
+				t.Errorf("images differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncPrePullOfCluster(t *testing.T) {
+	enabled := &config.ImagePrePull{Clusters: []string{"build01"}}
+	current := prePullDaemonSet(enabled, "test-pods", []string{"clonerefs", "initupload", "sidecar", "golang", "kind"}, prePullConfig(nil).Periodics[0].DecorationConfig)
+	outdated := current.DeepCopy()
+	outdated.Annotations[prePullSpecAnnotation] = "outdated"
+	outdated.Spec.Template.Spec.InitContainers = outdated.Spec.Template.Spec.InitContainers[:2]
+
+	testCases := []struct {
+		name     string
+		prePull  *config.ImagePrePull
+		cluster  string
+		existing []ctrlruntimeclient.Object
+		expected *appsv1.DaemonSet
+	}{
+		{
+			name:     "DaemonSet is created",
+			prePull:  enabled,
+			cluster:  "build01",
+			expected: current,
+		},
+		{
+			name:     "outdated DaemonSet is updated",
+			prePull:  enabled,
+			cluster:  "build01",
+			existing: []ctrlruntimeclient.Object{outdated},
+			expected: current,
+		},
+		{
+			name:     "DaemonSet is deleted when pre-pulling is disabled",
+			cluster:  "build01",
+			existing: []ctrlruntimeclient.Object{current.DeepCopy()},
+		},
+		{
+			name:     "DaemonSet is deleted when the cluster has no images",
+			prePull:  &config.ImagePrePull{Clusters: []string{"*"}},
+			cluster:  "build03",
+			existing: []ctrlruntimeclient.Object{current.DeepCopy()},
+		},
+		{
+			name:    "nothing to do",
+			prePull: &config.ImagePrePull{Clusters: []string{"build02"}},
+			cluster: "build01",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := prePullConfig(tc.prePull)
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.existing...).Build()
+			r := &reconciler{config: func() *config.Config { return cfg }, log: logrus.NewEntry(logrus.StandardLogger())}
+			if err := r.syncPrePullOfCluster(context.Background(), tc.cluster, client); err != nil {
+				t.Fatalf("failed to sync: %v", err)
+			}
+
+			actual := &appsv1.DaemonSet{}
+			err := client.Get(context.Background(), types.NamespacedName{Namespace: "test-pods", Name: prePullName}, actual)
+			if tc.expected == nil {
+				if !kerrors.IsNotFound(err) {
+					t.Errorf("expected no DaemonSet, got %v (err: %v)", actual, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get DaemonSet: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected.ObjectMeta.Annotations, actual.Annotations); diff != "" {
+				t.Errorf("annotations differ from expected (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expected.Spec, actual.Spec); diff != "" {
+				t.Errorf("spec differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
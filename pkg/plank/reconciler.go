@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -54,6 +55,8 @@ import (
 	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/pod-utils/decorate"
+	"sigs.k8s.io/prow/pkg/sidecar"
+	"sigs.k8s.io/prow/pkg/tracing"
 	"sigs.k8s.io/prow/pkg/version"
 )
 
@@ -167,6 +170,14 @@ func add(
 		return fmt.Errorf("failed to add cluster status runnable to manager: %w", err)
 	}
 
+	if err := mgr.Add(manager.RunnableFunc(r.syncWarmPools(30 * time.Second))); err != nil {
+		return fmt.Errorf("failed to add warm pool runnable to manager: %w", err)
+	}
+
+	if err := mgr.Add(manager.RunnableFunc(r.syncPrePull(5 * time.Minute))); err != nil {
+		return fmt.Errorf("failed to add image pre-pull runnable to manager: %w", err)
+	}
+
 	return nil
 }
 
@@ -217,6 +228,10 @@ type reconciler struct {
 	*/
 	maxConcurrencySerializationLocks *shardedLock
 	jobQueueSerializationLocks       *shardedLock
+	// warmPoolReservations holds the UIDs of the placeholder pods that are
+	// reserved for pods that are being created, so that every placeholder
+	// pod is only claimed once.
+	warmPoolReservations sync.Map
 }
 
 type shardedLock struct {
@@ -355,6 +370,7 @@ func (r *reconciler) defaultReconcile(ctx context.Context, request reconcile.Req
 			pj.SetComplete()
 			pj.Status.State = prowv1.ErrorState
 			pj.Status.Description = fmt.Sprintf("Terminal error: %v.", err)
+			pj.UpdateConditions()
 			if err := r.pjClient.Patch(ctx, pj, ctrlruntimeclient.MergeFrom(originalPJ)); err != nil {
 				// If we fail to complete and mark the job as errorer we will try again on the next sync loop.
 				log.Errorf("Error marking job with terminal failure as errored: %v.", err)
@@ -524,6 +540,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 
 		case corev1.PodSucceeded:
 			pj.SetComplete()
+			recordSidecarReport(pj, pod)
 			// There were bugs around this in the past so be paranoid and verify each container
 			// https://github.com/kubernetes/kubernetes/issues/58711 is only fixed in 1.18+
 			if didPodSucceed(pod) {
@@ -538,8 +555,9 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 		case corev1.PodFailed:
 			// Pod failed. Update ProwJob, talk to GitHub.
 			pj.SetComplete()
+			recordSidecarReport(pj, pod)
 			pj.Status.State = prowv1.FailureState
-			pj.Status.Description = "Job failed."
+			pj.Status.Description = failedDescription(pj.Status)
 
 		case corev1.PodPending:
 			var requeueAfter time.Duration
@@ -609,6 +627,7 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			pj.SetComplete()
 			pj.Status.State = prowv1.AbortedState
 			pj.Status.Description = "Pod running timeout."
+			pj.Status.Cancellation = &prowv1.Cancellation{Reason: prowv1.CancelledTimeout, Requester: "plank"}
 			if err := r.deletePod(ctx, pj); err != nil {
 				return nil, fmt.Errorf("failed to delete pod %s/%s in cluster %s: %w", pod.Namespace, pod.Name, pj.ClusterAlias(), err)
 			}
@@ -639,9 +658,16 @@ func (r *reconciler) syncPendingJob(ctx context.Context, pj *prowv1.ProwJob) (*r
 			WithField("to", pj.Status.State).Info("Transitioning states.")
 	}
 
+	pj.UpdateConditions()
 	if err := r.pjClient.Patch(ctx, pj.DeepCopy(), ctrlruntimeclient.MergeFrom(prevPJ)); err != nil {
 		return nil, fmt.Errorf("patching prowjob: %w", err)
 	}
+	if pj.Complete() && !prevPJ.Complete() && pj.Status.PendingTime != nil {
+		tracing.RecordProwJobSpan(pj, "plank.pod", pj.Status.PendingTime.Time, pj.Status.CompletionTime.Time,
+			attribute.String("state", string(pj.Status.State)),
+			attribute.String("pod", pj.Status.PodName),
+		)
+	}
 
 	// If the ProwJob state has changed, we must ensure that the update reaches the cache before
 	// processing the key again. Without this we might accidentally replace intentionally deleted pods
@@ -689,16 +715,25 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 		if !canExecuteConcurrently {
 			return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
 		}
-		// We haven't started the pod yet. Do so.
-		id, pn, err = r.startPod(ctx, pj)
-		if err != nil {
-			if !isRequestError(err) {
-				return nil, fmt.Errorf("error starting pod: %w", err)
-			}
+		// Jobs created outside of the config, e.g. through gangway, have not
+		// been validated against the restrictions of their tenant yet.
+		if err := r.config().ValidateTenantRestrictions(pj.Spec); err != nil {
 			pj.Status.State = prowv1.ErrorState
 			pj.SetComplete()
-			pj.Status.Description = fmt.Sprintf("Pod can not be created: %v", err)
-			logrus.WithField("job", pj.Spec.Job).WithError(err).Warning("Unprocessable pod.")
+			pj.Status.Description = fmt.Sprintf("Job violates tenant restrictions: %v", err)
+			r.log.WithFields(pjutil.ProwJobFields(pj)).WithError(err).Warning("Job violates tenant restrictions.")
+		} else {
+			// We haven't started the pod yet. Do so.
+			id, pn, err = r.startPod(ctx, pj)
+			if err != nil {
+				if !isRequestError(err) {
+					return nil, fmt.Errorf("error starting pod: %w", err)
+				}
+				pj.Status.State = prowv1.ErrorState
+				pj.SetComplete()
+				pj.Status.Description = fmt.Sprintf("Pod can not be created: %v", err)
+				logrus.WithField("job", pj.Spec.Job).WithError(err).Warning("Unprocessable pod.")
+			}
 		}
 	}
 
@@ -721,6 +756,7 @@ func (r *reconciler) syncTriggeredJob(ctx context.Context, pj *prowv1.ProwJob) (
 			WithField("from", prevPJ.Status.State).
 			WithField("to", pj.Status.State).Info("Transitioning states.")
 	}
+	pj.UpdateConditions()
 	if err := r.pjClient.Patch(ctx, pj.DeepCopy(), ctrlruntimeclient.MergeFrom(prevPJ)); err != nil {
 		return nil, fmt.Errorf("patch prowjob: %w", err)
 	}
@@ -765,6 +801,7 @@ func (r *reconciler) syncAbortedJob(ctx context.Context, pj *prowv1.ProwJob) err
 
 	originalPJ := pj.DeepCopy()
 	pj.SetComplete()
+	pj.UpdateConditions()
 	return r.pjClient.Patch(ctx, pj, ctrlruntimeclient.MergeFrom(originalPJ))
 }
 
@@ -813,6 +850,8 @@ func (r *reconciler) deletePod(ctx context.Context, pj *prowv1.ProwJob) error {
 }
 
 func (r *reconciler) startPod(ctx context.Context, pj *prowv1.ProwJob) (string, string, error) {
+	ctx, span := tracing.StartProwJobSpan(ctx, pj, "plank.start-pod")
+	defer span.End()
 	buildID, err := r.getBuildID(pj.Spec.Job)
 	if err != nil {
 		return "", "", fmt.Errorf("error getting build ID: %w", err)
@@ -826,12 +865,17 @@ func (r *reconciler) startPod(ctx context.Context, pj *prowv1.ProwJob) (string,
 	pod.Namespace = r.config().PodNamespace
 	// Add prow version as a label for better debugging prowjobs.
 	pod.ObjectMeta.Labels[kube.PlankVersionLabel] = version.Version
+	tracing.SetPodTraceParent(ctx, pod)
 	podName := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
 
 	client, ok := r.buildClients[pj.ClusterAlias()]
 	if !ok {
 		return "", "", TerminalError(fmt.Errorf("unknown cluster alias %q", pj.ClusterAlias()))
 	}
+	if placeholder := r.reserveWarmPod(ctx, client, pj.Spec.Job); placeholder != nil && r.claimWarmPod(ctx, client, placeholder) {
+		r.log.WithFields(pjutil.ProwJobFields(pj)).WithField("node", placeholder.Spec.NodeName).Debug("Claimed placeholder pod of warm pool.")
+		preferNode(pod, placeholder.Spec.NodeName)
+	}
 	err = client.Create(ctx, pod)
 	r.log.WithFields(pjutil.ProwJobFields(pj)).Debug("Create Pod.")
 	if err != nil {
@@ -1057,6 +1101,41 @@ func didPodSucceed(p *corev1.Pod) bool {
 	return true
 }
 
+// recordSidecarReport records the test summary and the outcome of the upload
+// the sidecar of the pod reported, if it did.
+func recordSidecarReport(pj *prowv1.ProwJob, p *corev1.Pod) {
+	for _, container := range p.Status.ContainerStatuses {
+		if container.Name != decorate.SidecarContainerName || container.State.Terminated == nil {
+			continue
+		}
+		report := sidecar.ParseReport(container.State.Terminated.Message)
+		if report == nil {
+			return
+		}
+		pj.Status.TestSummary = report.TestSummary
+		pj.Status.Failure = report.Failure
+		if report.ArtifactsUploaded {
+			pj.SetCondition(prowv1.ProwJobArtifactsUploaded, metav1.ConditionTrue, "Uploaded", "The sidecar uploaded the artifacts.")
+		} else {
+			pj.SetCondition(prowv1.ProwJobArtifactsUploaded, metav1.ConditionFalse, "UploadFailed", "The sidecar failed to upload the artifacts, see its logs.")
+		}
+		return
+	}
+}
+
+// failedDescription describes why the job failed: the failure the sidecar
+// classified when it is not a test failure, else the failed tests.
+func failedDescription(status prowv1.ProwJobStatus) string {
+	summary, failure := status.TestSummary, status.Failure
+	if summary != nil && summary.Failed > 0 && (failure == nil || failure.Category == prowv1.FailureCategoryTest) {
+		return fmt.Sprintf("Job failed: %s.", summary)
+	}
+	if failure != nil {
+		return strings.TrimSuffix(fmt.Sprintf("Job failed: %s", failure), ".") + "."
+	}
+	return "Job failed."
+}
+
 func getPodBuildID(pod *corev1.Pod) string {
 	if buildID, ok := pod.ObjectMeta.Labels[kube.ProwBuildIDLabel]; ok && buildID != "" {
 		return buildID
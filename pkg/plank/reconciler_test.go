@@ -28,6 +28,8 @@ import (
 
 	"github.com/go-test/deep"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -52,6 +54,7 @@ import (
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/tracing"
 )
 
 func TestAdd(t *testing.T) {
@@ -535,6 +538,44 @@ func TestStartPodBlocksUntilItHasThePodInCache(t *testing.T) {
 	}
 }
 
+func TestStartPodContinuesTheTrace(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	defer otel.SetTracerProvider(previous)
+	const traceParent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	r := &reconciler{
+		log:          logrus.NewEntry(logrus.New()),
+		buildClients: map[string]buildClient{"default": {Client: fakectrlruntimeclient.NewClientBuilder().Build()}},
+		config:       func() *config.Config { return &config.Config{} },
+	}
+	pj := &prowv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "name", Annotations: map[string]string{tracing.TraceParentAnnotation: traceParent}},
+		Spec: prowv1.ProwJobSpec{
+			PodSpec: &corev1.PodSpec{Containers: []corev1.Container{{}}},
+			Refs:    &prowv1.Refs{},
+			Type:    prowv1.PeriodicJob,
+		},
+	}
+	if _, _, err := r.startPod(context.Background(), pj); err != nil {
+		t.Fatalf("startPod: %v", err)
+	}
+	pod := &corev1.Pod{}
+	if err := r.buildClients["default"].Get(context.Background(), types.NamespacedName{Name: "name"}, pod); err != nil {
+		t.Fatalf("couldn't get pod: %v", err)
+	}
+	var podTraceParent string
+	for _, env := range pod.Spec.Containers[0].Env {
+		if env.Name == tracing.TraceParentEnv {
+			podTraceParent = env.Value
+		}
+	}
+	parent, _ := tracing.ParseTraceParent(traceParent)
+	sc, ok := tracing.ParseTraceParent(podTraceParent)
+	if !ok || sc.TraceID() != parent.TraceID() || sc.SpanID() == parent.SpanID() {
+		t.Errorf("expected the pod to get a traceparent in the trace of %s, got %q", traceParent, podTraceParent)
+	}
+}
+
 type fakeOpener struct {
 	io.Opener
 	strings.Builder
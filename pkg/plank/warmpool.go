@@ -0,0 +1,263 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+const (
+	// warmPoolLabel marks the placeholder pods of warm pools.
+	warmPoolLabel = "prow.k8s.io/warm-pool"
+	// warmPoolJobAnnotation holds the name of the job of a placeholder pod.
+	// It is not a label as job names may be longer than label values.
+	warmPoolJobAnnotation = "prow.k8s.io/warm-pool-job"
+	// warmPoolSpecAnnotation holds a hash of the spec of a placeholder pod.
+	warmPoolSpecAnnotation = "prow.k8s.io/warm-pool-spec"
+
+	defaultPauseImage = "registry.k8s.io/pause:3.9"
+)
+
+// syncWarmPools keeps the warm pools filled until the context is done.
+func (r *reconciler) syncWarmPools(interval time.Duration) func(context.Context) error {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				for cluster, client := range r.buildClients {
+					if err := r.syncWarmPoolsOfCluster(ctx, cluster, client); err != nil {
+						r.log.WithError(err).WithField("cluster", cluster).Error("Failed to sync warm pools.")
+					}
+				}
+			}
+		}
+	}
+}
+
+// syncWarmPoolsOfCluster creates the missing placeholder pods of the warm
+// pools of the jobs that run in the cluster, and deletes the placeholder pods
+// that are no longer needed or no longer running.
+func (r *reconciler) syncWarmPoolsOfCluster(ctx context.Context, cluster string, client ctrlruntimeclient.Client) error {
+	cfg := r.config()
+	wanted := map[string]*corev1.Pod{}
+	sizes := map[string]int{}
+	for _, pool := range cfg.Plank.WarmPools {
+		jobs := cfg.StaticJobBases(pool.Job)
+		if len(jobs) != 1 || jobs[0].Spec == nil || jobs[0].Cluster != cluster {
+			continue
+		}
+		wanted[pool.Job] = warmPod(pool, jobs[0], cfg.PodNamespace)
+		sizes[pool.Job] = pool.Size
+	}
+
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, ctrlruntimeclient.InNamespace(cfg.PodNamespace), ctrlruntimeclient.MatchingLabels{warmPoolLabel: "true"}); err != nil {
+		return fmt.Errorf("failed to list placeholder pods: %w", err)
+	}
+	// The oldest placeholder pods are kept, as they are the most likely to
+	// be running already.
+	sort.Slice(pods.Items, func(i, j int) bool {
+		if !pods.Items[i].CreationTimestamp.Equal(&pods.Items[j].CreationTimestamp) {
+			return pods.Items[i].CreationTimestamp.Before(&pods.Items[j].CreationTimestamp)
+		}
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	var errs []error
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		job := pod.Annotations[warmPoolJobAnnotation]
+		// Pods that stopped running, or that are left after the pool shrank
+		// or the job changed, are replaced.
+		if sizes[job] > 0 && pod.Status.Phase != corev1.PodFailed && pod.Status.Phase != corev1.PodSucceeded && pod.Annotations[warmPoolSpecAnnotation] == wanted[job].Annotations[warmPoolSpecAnnotation] {
+			sizes[job]--
+			continue
+		}
+		if err := client.Delete(ctx, pod.DeepCopy()); err != nil && !kerrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete placeholder pod %s: %w", pod.Name, err))
+		}
+	}
+
+	for job, missing := range sizes {
+		for i := 0; i < missing; i++ {
+			if err := client.Create(ctx, wanted[job].DeepCopy()); err != nil {
+				errs = append(errs, fmt.Errorf("failed to create placeholder pod of job %s: %w", job, err))
+				break
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// reserveWarmPod returns a running placeholder pod of the job that is not
+// reserved for another pod yet, or nil if the job has none. The reservation
+// ends with claimWarmPod or releaseWarmPod.
+func (r *reconciler) reserveWarmPod(ctx context.Context, client ctrlruntimeclient.Client, job string) *corev1.Pod {
+	hasPool := false
+	for _, pool := range r.config().Plank.WarmPools {
+		hasPool = hasPool || pool.Job == job
+	}
+	if !hasPool {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, ctrlruntimeclient.InNamespace(r.config().PodNamespace), ctrlruntimeclient.MatchingLabels{warmPoolLabel: "true"}); err != nil {
+		r.log.WithError(err).Warn("Failed to list placeholder pods.")
+		return nil
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Annotations[warmPoolJobAnnotation] != job || pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" {
+			continue
+		}
+		if _, reserved := r.warmPoolReservations.LoadOrStore(pod.UID, struct{}{}); reserved {
+			continue
+		}
+		return pod
+	}
+	return nil
+}
+
+// claimWarmPod deletes a reserved placeholder pod before the pod of its job is
+// created, so that the scheduler sees the resources of the placeholder pod as
+// free when it schedules the pod. It returns whether the placeholder pod is
+// gone. If creating the pod fails afterwards, the pool is refilled by the
+// next sync.
+func (r *reconciler) claimWarmPod(ctx context.Context, client ctrlruntimeclient.Client, placeholder *corev1.Pod) bool {
+	defer r.releaseWarmPod(placeholder)
+	if err := client.Delete(ctx, placeholder.DeepCopy(), ctrlruntimeclient.GracePeriodSeconds(0), ctrlruntimeclient.Preconditions{UID: &placeholder.UID}); err != nil && !kerrors.IsNotFound(err) {
+		r.log.WithError(err).WithField("pod", placeholder.Name).Warn("Failed to delete claimed placeholder pod.")
+		return false
+	}
+	return true
+}
+
+// releaseWarmPod ends the reservation of a placeholder pod.
+func (r *reconciler) releaseWarmPod(placeholder *corev1.Pod) {
+	r.warmPoolReservations.Delete(placeholder.UID)
+}
+
+// preferNode makes the scheduler prefer the node for the pod. The pod is
+// not bound to the node, so that it can still run elsewhere if the node is
+// gone or another pod took the resources of the placeholder pod.
+func preferNode(pod *corev1.Pod, node string) {
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.PreferredSchedulingTerm{
+		Weight: 100,
+		Preference: corev1.NodeSelectorTerm{
+			MatchFields: []corev1.NodeSelectorRequirement{{
+				Key:      "metadata.name",
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{node},
+			}},
+		},
+	})
+}
+
+// warmPod returns a placeholder pod for the job. It requests the resources
+// of the job, is placed like the job and, if the job is decorated, pulls the
+// images of the job by running the entrypoint binary in copy mode in them.
+func warmPod(pool config.WarmPool, jb config.JobBase, namespace string) *corev1.Pod {
+	pauseImage := pool.PauseImage
+	if pauseImage == "" {
+		pauseImage = defaultPauseImage
+	}
+	requests := corev1.ResourceList{}
+	for _, container := range jb.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+
+	var images []string
+	for _, container := range jb.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	var initContainers []corev1.Container
+	var volumes []corev1.Volume
+	if isDecorated(jb) {
+		utilityImages := jb.DecorationConfig.UtilityImages
+		images = append(images, utilityImages.CloneRefs, utilityImages.InitUpload, utilityImages.Sidecar)
+		initContainers, volumes = pullContainers(jb.DecorationConfig, images)
+	}
+
+	automount := false
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "warm-pool-",
+			Namespace:    namespace,
+			Labels:       map[string]string{warmPoolLabel: "true"},
+			Annotations:  map[string]string{warmPoolJobAnnotation: jb.Name},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: initContainers,
+			Containers: []corev1.Container{{
+				Name:      "pause",
+				Image:     pauseImage,
+				Resources: corev1.ResourceRequirements{Requests: requests},
+			}},
+			Volumes:                       volumes,
+			NodeSelector:                  jb.Spec.NodeSelector,
+			Tolerations:                   jb.Spec.Tolerations,
+			Affinity:                      jb.Spec.Affinity,
+			RuntimeClassName:              jb.Spec.RuntimeClassName,
+			PriorityClassName:             pool.PriorityClassName,
+			AutomountServiceAccountToken:  &automount,
+			TerminationGracePeriodSeconds: new(int64),
+		},
+	}
+	pod.Annotations[warmPoolSpecAnnotation] = specHash(pod.Spec)
+	return pod
+}
+
+// specHash returns a hash of the spec of a placeholder pod, to tell when the
+// placeholder pods of a job are outdated.
+func specHash(spec corev1.PodSpec) string {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		// Marshalling a pod spec does not fail.
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b))[:16]
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plank
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+func warmPoolConfig(pools ...config.WarmPool) config.Getter {
+	decorate := true
+	return func() *config.Config {
+		return &config.Config{
+			JobConfig: config.JobConfig{
+				Periodics: []config.Periodic{{JobBase: config.JobBase{
+					Name:    "lint",
+					Agent:   "kubernetes",
+					Cluster: "build01",
+					Spec: &corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Image: "golangci/golangci-lint",
+							Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("2"),
+							}},
+						}},
+						NodeSelector: map[string]string{"pool": "ci"},
+					},
+					UtilityConfig: config.UtilityConfig{
+						Decorate: &decorate,
+						DecorationConfig: &prowv1.DecorationConfig{
+							UtilityImages: &prowv1.UtilityImages{
+								CloneRefs:  "clonerefs",
+								InitUpload: "initupload",
+								Entrypoint: "entrypoint",
+								Sidecar:    "sidecar",
+							},
+						},
+					},
+				}}},
+			},
+			ProwConfig: config.ProwConfig{
+				PodNamespace: "test-pods",
+				Plank:        config.Plank{WarmPools: pools},
+			},
+		}
+	}
+}
+
+func placeholder(name, job string, phase corev1.PodPhase, node, spec string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "test-pods",
+			UID:         types.UID(name),
+			Labels:      map[string]string{warmPoolLabel: "true"},
+			Annotations: map[string]string{warmPoolJobAnnotation: job, warmPoolSpecAnnotation: spec},
+		},
+		Spec:   corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestWarmPod(t *testing.T) {
+	cfg := warmPoolConfig()()
+	jb := cfg.Periodics[0].JobBase
+	pod := warmPod(config.WarmPool{Job: "lint", Size: 1, PriorityClassName: "placeholder"}, jb, "test-pods")
+
+	if diff := cmp.Diff(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}, pod.Spec.Containers[0].Resources.Requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+	if pod.Spec.Containers[0].Image != defaultPauseImage {
+		t.Errorf("expected the pause image, got %s", pod.Spec.Containers[0].Image)
+	}
+	if diff := cmp.Diff(jb.Spec.NodeSelector, pod.Spec.NodeSelector); diff != "" {
+		t.Errorf("node selector mismatch (-want +got):\n%s", diff)
+	}
+	if pod.Spec.PriorityClassName != "placeholder" {
+		t.Errorf("expected priority class placeholder, got %q", pod.Spec.PriorityClassName)
+	}
+	var pulled []string
+	for _, c := range pod.Spec.InitContainers[1:] {
+		pulled = append(pulled, c.Image)
+	}
+	if diff := cmp.Diff([]string{"golangci/golangci-lint", "clonerefs", "initupload", "sidecar"}, pulled); diff != "" {
+		t.Errorf("pulled images mismatch (-want +got):\n%s", diff)
+	}
+	if pod.Annotations[warmPoolJobAnnotation] != "lint" || pod.Annotations[warmPoolSpecAnnotation] == "" {
+		t.Errorf("unexpected annotations %v", pod.Annotations)
+	}
+
+	undecorated := jb
+	undecorated.Decorate = nil
+	if pod := warmPod(config.WarmPool{Job: "lint", Size: 1}, undecorated, "test-pods"); len(pod.Spec.InitContainers) != 0 {
+		t.Errorf("expected no images to be pulled for an undecorated job, got %d init containers", len(pod.Spec.InitContainers))
+	}
+}
+
+func TestSyncWarmPoolsOfCluster(t *testing.T) {
+	cfg := warmPoolConfig(config.WarmPool{Job: "lint", Size: 2})
+	spec := warmPod(cfg().Plank.WarmPools[0], cfg().Periodics[0].JobBase, "test-pods").Annotations[warmPoolSpecAnnotation]
+
+	testCases := []struct {
+		name          string
+		cluster       string
+		existing      []ctrlruntimeclient.Object
+		expectedPods  int
+		expectDeleted []string
+	}{
+		{
+			name:         "pool is filled",
+			cluster:      "build01",
+			expectedPods: 2,
+		},
+		{
+			name:         "pools of other clusters are ignored",
+			cluster:      "default",
+			expectedPods: 0,
+		},
+		{
+			name:    "running and pending pods are kept",
+			cluster: "build01",
+			existing: []ctrlruntimeclient.Object{
+				placeholder("a", "lint", corev1.PodRunning, "node-1", spec),
+				placeholder("b", "lint", corev1.PodPending, "", spec),
+			},
+			expectedPods: 2,
+		},
+		{
+			name:    "failed, outdated, extra and orphaned pods are replaced",
+			cluster: "build01",
+			existing: []ctrlruntimeclient.Object{
+				placeholder("a", "lint", corev1.PodRunning, "node-1", spec),
+				placeholder("b", "lint", corev1.PodFailed, "node-1", spec),
+				placeholder("c", "lint", corev1.PodRunning, "node-1", "outdated"),
+				placeholder("d", "lint", corev1.PodRunning, "node-2", spec),
+				placeholder("e", "lint", corev1.PodRunning, "node-2", spec),
+				placeholder("f", "removed", corev1.PodRunning, "node-2", spec),
+			},
+			expectedPods:  2,
+			expectDeleted: []string{"b", "c", "e", "f"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.existing...).Build()
+			r := &reconciler{config: cfg, log: logrus.NewEntry(logrus.StandardLogger())}
+			if err := r.syncWarmPoolsOfCluster(context.Background(), tc.cluster, client); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			pods := &corev1.PodList{}
+			if err := client.List(context.Background(), pods); err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+			if len(pods.Items) != tc.expectedPods {
+				t.Errorf("expected %d pods, got %d", tc.expectedPods, len(pods.Items))
+			}
+			remaining := map[string]bool{}
+			for _, pod := range pods.Items {
+				remaining[pod.Name] = true
+			}
+			for _, name := range tc.expectDeleted {
+				if remaining[name] {
+					t.Errorf("expected pod %s to be deleted", name)
+				}
+			}
+		})
+	}
+}
+
+func TestReserveWarmPod(t *testing.T) {
+	testCases := []struct {
+		name         string
+		pools        []config.WarmPool
+		existing     []ctrlruntimeclient.Object
+		reserved     []types.UID
+		expectedNode string
+	}{
+		{
+			name: "job without warm pool",
+			existing: []ctrlruntimeclient.Object{
+				placeholder("a", "lint", corev1.PodRunning, "node-1", ""),
+			},
+		},
+		{
+			name:  "running placeholder is reserved",
+			pools: []config.WarmPool{{Job: "lint", Size: 2}},
+			existing: []ctrlruntimeclient.Object{
+				placeholder("a", "lint", corev1.PodPending, "", ""),
+				placeholder("b", "other", corev1.PodRunning, "node-2", ""),
+				placeholder("c", "lint", corev1.PodRunning, "node-1", ""),
+			},
+			expectedNode: "node-1",
+		},
+		{
+			name:  "placeholder reserved for another pod is skipped",
+			pools: []config.WarmPool{{Job: "lint", Size: 2}},
+			existing: []ctrlruntimeclient.Object{
+				placeholder("a", "lint", corev1.PodRunning, "node-1", ""),
+				placeholder("b", "lint", corev1.PodRunning, "node-2", ""),
+			},
+			reserved:     []types.UID{"a"},
+			expectedNode: "node-2",
+		},
+		{
+			name:  "no running placeholder",
+			pools: []config.WarmPool{{Job: "lint", Size: 1}},
+			existing: []ctrlruntimeclient.Object{
+				placeholder("a", "lint", corev1.PodPending, "", ""),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(tc.existing...).Build()
+			r := newReconciler(context.Background(), nil, nil, warmPoolConfig(tc.pools...), nil, "")
+			for _, uid := range tc.reserved {
+				r.warmPoolReservations.Store(uid, struct{}{})
+			}
+			var node string
+			if pod := r.reserveWarmPod(context.Background(), client, "lint"); pod != nil {
+				node = pod.Spec.NodeName
+			}
+			if node != tc.expectedNode {
+				t.Errorf("expected node %q, got %q", tc.expectedNode, node)
+			}
+			pods := &corev1.PodList{}
+			if err := client.List(context.Background(), pods); err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+			if len(pods.Items) != len(tc.existing) {
+				t.Errorf("expected reserving to keep all %d pods, got %d", len(tc.existing), len(pods.Items))
+			}
+		})
+	}
+}
+
+func TestClaimWarmPod(t *testing.T) {
+	client := fakectrlruntimeclient.NewClientBuilder().WithObjects(
+		placeholder("a", "lint", corev1.PodRunning, "node-1", ""),
+	).Build()
+	r := newReconciler(context.Background(), nil, nil, warmPoolConfig(config.WarmPool{Job: "lint", Size: 1}), nil, "")
+
+	reserved := r.reserveWarmPod(context.Background(), client, "lint")
+	if reserved == nil {
+		t.Fatal("expected a placeholder pod to be reserved")
+	}
+	if pod := r.reserveWarmPod(context.Background(), client, "lint"); pod != nil {
+		t.Fatalf("expected the placeholder pod to be reserved only once, got %s", pod.Name)
+	}
+	r.releaseWarmPod(reserved)
+	if reserved = r.reserveWarmPod(context.Background(), client, "lint"); reserved == nil {
+		t.Fatal("expected a released placeholder pod to be reserved again")
+	}
+
+	if !r.claimWarmPod(context.Background(), client, reserved) {
+		t.Error("expected the placeholder pod to be claimed")
+	}
+	pods := &corev1.PodList{}
+	if err := client.List(context.Background(), pods); err != nil {
+		t.Fatalf("failed to list pods: %v", err)
+	}
+	if len(pods.Items) != 0 {
+		t.Errorf("expected the claimed placeholder pod to be deleted, got %d pods", len(pods.Items))
+	}
+	if _, reserved := r.warmPoolReservations.Load(types.UID("a")); reserved {
+		t.Error("expected the reservation to end with the claim")
+	}
+}
+
+// recordingClient records the pods that are created and deleted.
+type recordingClient struct {
+	ctrlruntimeclient.Client
+	operations []string
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	c.operations = append(c.operations, "create "+obj.GetName())
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteOption) error {
+	c.operations = append(c.operations, "delete "+obj.GetName())
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func TestStartPodClaimsWarmPodFirst(t *testing.T) {
+	client := &recordingClient{Client: fakectrlruntimeclient.NewClientBuilder().WithObjects(
+		placeholder("warm", "lint", corev1.PodRunning, "node-1", ""),
+	).Build()}
+	r := &reconciler{
+		log:          logrus.NewEntry(logrus.New()),
+		buildClients: map[string]buildClient{"default": {Client: client}},
+		config:       warmPoolConfig(config.WarmPool{Job: "lint", Size: 1}),
+	}
+	pj := &prowv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job"},
+		Spec: prowv1.ProwJobSpec{
+			Job:     "lint",
+			PodSpec: &corev1.PodSpec{Containers: []corev1.Container{{}}},
+			Refs:    &prowv1.Refs{},
+			Type:    prowv1.PeriodicJob,
+		},
+	}
+	if _, _, err := r.startPod(context.Background(), pj); err != nil {
+		t.Fatalf("startPod: %v", err)
+	}
+	if diff := cmp.Diff([]string{"delete warm", "create job"}, client.operations); diff != "" {
+		t.Errorf("expected the placeholder pod to be deleted before the pod is created (-want +got):\n%s", diff)
+	}
+	pod := &corev1.Pod{}
+	if err := client.Get(context.Background(), types.NamespacedName{Namespace: "test-pods", Name: "job"}, pod); err != nil {
+		t.Fatalf("couldn't get pod: %v", err)
+	}
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil || len(pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 1 {
+		t.Errorf("expected the pod to prefer the node of the placeholder pod, got affinity %v", pod.Spec.Affinity)
+	}
+}
+
+func TestPreferNode(t *testing.T) {
+	pod := &corev1.Pod{}
+	preferNode(pod, "node-1")
+	expected := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+			Weight: 100,
+			Preference: corev1.NodeSelectorTerm{
+				MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-1"}}},
+			},
+		}},
+	}}
+	if diff := cmp.Diff(expected, pod.Spec.Affinity); diff != "" {
+		t.Errorf("affinity mismatch (-want +got):\n%s", diff)
+	}
+}
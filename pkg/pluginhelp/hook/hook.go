@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -90,11 +91,77 @@ func (ha *HelpAgent) generateNormalPluginHelp(config *plugins.Configuration, rev
 			continue
 		}
 		help.Events = plugins.EventsForPlugin(name)
+		addCommandAliases(help, config.CommandAliases)
 		pluginHelp[name] = *help
 	}
 	return
 }
 
+var optionalPartRe = regexp.MustCompile(`\[([\w-]*)\]`)
+
+// commandNames returns the names of the commands a command help describes,
+// e.g. `hold`, `unhold`, `remove-hold` and `remove-unhold` for
+// `/[remove-][un]hold [cancel]`.
+func commandNames(command pluginhelp.Command) sets.Set[string] {
+	names := sets.New[string]()
+	var expand func(string)
+	expand = func(name string) {
+		loc := optionalPartRe.FindStringSubmatchIndex(name)
+		if loc == nil {
+			names.Insert(strings.ToLower(name))
+			return
+		}
+		expand(name[:loc[0]] + name[loc[2]:loc[3]] + name[loc[1]:])
+		expand(name[:loc[0]] + name[loc[1]:])
+	}
+	for _, invocation := range append([]string{command.Usage}, command.Examples...) {
+		fields := strings.Fields(invocation)
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+			continue
+		}
+		expand(strings.TrimPrefix(fields[0], "/"))
+	}
+	return names
+}
+
+// addCommandAliases adds the aliases of the commands of a plugin to the
+// configuration help of the orgs and repos defining them.
+func addCommandAliases(help *pluginhelp.PluginHelp, commandAliases map[string]map[string]string) {
+	names := sets.New[string]()
+	for _, command := range help.Commands {
+		names = names.Union(commandNames(command))
+	}
+	config := map[string]string{}
+	for repo, aliases := range commandAliases {
+		var lines []string
+		for _, alias := range sets.List(sets.KeySet(aliases)) {
+			if command := aliases[alias]; names.Has(strings.ToLower(command)) {
+				lines = append(lines, fmt.Sprintf("`/%s` is an alias of `/%s`.", alias, command))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if help.Config[repo] != "" {
+			lines = append([]string{help.Config[repo]}, lines...)
+		}
+		config[repo] = strings.Join(lines, "\n")
+	}
+	if len(config) == 0 {
+		return
+	}
+	// The configuration help may be shared with the plugin, so it is copied
+	// instead of modified.
+	merged := make(map[string]string, len(help.Config)+len(config))
+	for repo, info := range help.Config {
+		merged[repo] = info
+	}
+	for repo, info := range config {
+		merged[repo] = info
+	}
+	help.Config = merged
+}
+
 func (ha *HelpAgent) generateExternalPluginHelp(config *plugins.Configuration, revMap map[string][]prowconfig.OrgRepo) (allPlugins []string, pluginHelp map[string]pluginhelp.PluginHelp) {
 	externals := map[string]plugins.ExternalPlugin{}
 	for _, exts := range config.ExternalPlugins {
@@ -207,3 +207,57 @@ func registerNormalPlugins(t *testing.T, pluginsToEvents map[string][]string, pl
 		}
 	}
 }
+
+func TestAddCommandAliases(t *testing.T) {
+	holdHelp := func() pluginhelp.PluginHelp {
+		return pluginhelp.PluginHelp{
+			Description: "hold",
+			Config:      map[string]string{"org/repo": "repo config"},
+			Commands: []pluginhelp.Command{{
+				Usage:    "/[remove-][un]hold [cancel]",
+				Examples: []string{"/hold", "/hold cancel"},
+			}},
+		}
+	}
+	testCases := []struct {
+		name           string
+		commandAliases map[string]map[string]string
+		expected       map[string]string
+	}{
+		{
+			name:     "no aliases",
+			expected: map[string]string{"org/repo": "repo config"},
+		},
+		{
+			name: "aliases of other commands are ignored",
+			commandAliases: map[string]map[string]string{
+				"org": {"ship-it": "lgtm"},
+			},
+			expected: map[string]string{"org/repo": "repo config"},
+		},
+		{
+			name: "aliases are added to the config of their org or repo",
+			commandAliases: map[string]map[string]string{
+				"org":      {"wait": "hold", "ship-it": "lgtm"},
+				"org/repo": {"go": "unhold", "stop": "Hold"},
+			},
+			expected: map[string]string{
+				"org":      "`/wait` is an alias of `/hold`.",
+				"org/repo": "repo config\n`/go` is an alias of `/unhold`.\n`/stop` is an alias of `/Hold`.",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			help := holdHelp()
+			original := help.Config
+			addCommandAliases(&help, tc.commandAliases)
+			if !reflect.DeepEqual(help.Config, tc.expected) {
+				t.Errorf("expected config %v, got %v", tc.expected, help.Config)
+			}
+			if expected := holdHelp().Config; !reflect.DeepEqual(original, expected) {
+				t.Errorf("expected the original config to be kept as %v, got %v", expected, original)
+			}
+		})
+	}
+}
@@ -111,6 +111,9 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 	for _, repo := range enabledRepos {
 		opts := config.ApproveFor(repo.Org, repo.Repo)
 		approveConfig[repo.String()] = fmt.Sprintf("Pull requests %s require an associated issue.<br>Pull request authors %s implicitly approve their own PRs.<br>The /lgtm [cancel] command(s) %s act as approval.<br>A GitHub approved or changes requested review %s act as approval or cancel respectively.", doNot(opts.IssueRequired), doNot(opts.HasSelfApproval()), willNot(opts.LgtmActsAsApprove), willNot(opts.ConsiderReviewState()))
+		if !opts.HasSelfApproval() && len(opts.SelfApprovalPaths) > 0 {
+			approveConfig[repo.String()] += fmt.Sprintf("<br>Pull request authors implicitly approve their own PRs if they only change files matching %s.", strings.Join(opts.SelfApprovalPaths, ", "))
+		}
 	}
 
 	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
@@ -387,9 +390,15 @@ func handle(log *logrus.Entry, ghc githubClient, repo approvers.Repo, githubConf
 	if err != nil {
 		return fetchErr("PR file changes", err)
 	}
-	var filenames []string
+	// The self-approval policy also considers where files were renamed from,
+	// so that files can't be moved out of paths that need approval.
+	var filenames, selfApprovalFilenames []string
 	for _, change := range changes {
 		filenames = append(filenames, change.Filename)
+		selfApprovalFilenames = append(selfApprovalFilenames, change.Filename)
+		if change.PreviousFilename != "" {
+			selfApprovalFilenames = append(selfApprovalFilenames, change.PreviousFilename)
+		}
 	}
 	issueLabels, err := ghc.GetIssueLabels(pr.org, pr.repo, pr.number)
 	if err != nil {
@@ -439,6 +448,12 @@ func handle(log *logrus.Entry, ghc githubClient, repo approvers.Repo, githubConf
 	// Author implicitly approves their own PR if config allows it
 	if opts.HasSelfApproval() {
 		approversHandler.AddAuthorSelfApprover(pr.author, pr.htmlURL+"#", false)
+	} else if opts.SelfApprovesChanges(selfApprovalFilenames) {
+		var paths []string
+		for _, path := range opts.SelfApprovalPaths {
+			paths = append(paths, "`"+path+"`")
+		}
+		approversHandler.AddPolicySelfApprover(pr.author, pr.htmlURL+"#", "only files matching "+strings.Join(paths, ", ")+" are changed")
 	} else {
 		// Treat the author as an assignee, and suggest them if possible
 		approversHandler.AddAssignees(pr.author)
@@ -182,10 +182,13 @@ func TestHandle(t *testing.T) {
 		hasLabel      bool
 		humanApproved bool
 		files         []string
-		comments      []github.IssueComment
-		reviews       []github.Review
+		// renames maps files to the files they were renamed from.
+		renames  map[string]string
+		comments []github.IssueComment
+		reviews  []github.Review
 
 		selfApprove         bool
+		selfApprovalPaths   []string
 		needsIssue          bool
 		lgtmActsAsApprove   bool
 		reviewActsAsApprove bool
@@ -264,6 +267,77 @@ Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a commen
 </details>
 <!-- META={"approvers":["cjwagner"]} -->`,
 		},
+		{
+			name:                "initial notification (approved by the self-approval policy)",
+			hasLabel:            false,
+			files:               []string{"c/c.go"},
+			comments:            []github.IssueComment{},
+			reviews:             []github.Review{},
+			selfApprove:         false,
+			selfApprovalPaths:   []string{`^c/`, `(^|/)OWNERS$`},
+			needsIssue:          false,
+			lgtmActsAsApprove:   false,
+			reviewActsAsApprove: false,
+			githubLinkURL:       &url.URL{Scheme: "https", Host: "github.com"},
+
+			expectDelete:  false,
+			expectToggle:  true,
+			expectComment: true,
+			expectedComment: `[APPROVALNOTIFIER] This PR is **APPROVED**
+
+This pull-request has been approved by: *<a href="#" title="Author self-approved">cjwagner</a>*
+
+The author approval was granted by the self-approval policy of this repository: only files matching ` + "`^c/`, `(^|/)OWNERS$`" + ` are changed.
+
+The full list of commands accepted by this bot can be found [here](https://go.k8s.io/bot-commands?repo=org%2Frepo).
+
+The pull request process is described [here](https://git.k8s.io/community/contributors/guide/owners.md#the-code-review-process)
+
+<details >
+Needs approval from an approver in each of these files:
+
+- ~~[c/OWNERS](https://github.com/org/repo/blob/master/c/OWNERS)~~ [cjwagner]
+
+Approvers can indicate their approval by writing ` + "`/approve`" + ` in a comment
+Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a comment
+</details>
+<!-- META={"approvers":[]} -->`,
+		},
+		{
+			name:                "initial notification (self-approval policy does not apply to other files)",
+			hasLabel:            false,
+			files:               []string{"c/c.go", "a/a.go"},
+			comments:            []github.IssueComment{},
+			reviews:             []github.Review{},
+			selfApprove:         false,
+			selfApprovalPaths:   []string{`^c/`},
+			needsIssue:          false,
+			lgtmActsAsApprove:   false,
+			reviewActsAsApprove: false,
+			githubLinkURL:       &url.URL{Scheme: "https", Host: "github.com"},
+
+			expectDelete:  false,
+			expectToggle:  false,
+			expectComment: true,
+		},
+		{
+			name:                "initial notification (self-approval policy does not apply to files renamed from other paths)",
+			hasLabel:            false,
+			files:               []string{"c/c.go"},
+			renames:             map[string]string{"c/c.go": "a/a.go"},
+			comments:            []github.IssueComment{},
+			reviews:             []github.Review{},
+			selfApprove:         false,
+			selfApprovalPaths:   []string{`^c/`},
+			needsIssue:          false,
+			lgtmActsAsApprove:   false,
+			reviewActsAsApprove: false,
+			githubLinkURL:       &url.URL{Scheme: "https", Host: "github.com"},
+
+			expectDelete:  false,
+			expectToggle:  false,
+			expectComment: true,
+		},
 		{
 			name:                "no-issue comment",
 			hasLabel:            false,
@@ -1246,6 +1320,9 @@ Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a commen
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			fghc := newFakeGitHubClient(test.hasLabel, test.humanApproved, test.files, test.comments, test.reviews)
+			for i, change := range fghc.PullRequestChanges[prNumber] {
+				fghc.PullRequestChanges[prNumber][i].PreviousFilename = test.renames[change.Filename]
+			}
 			branch := "master"
 			if test.branch != "" {
 				branch = test.branch
@@ -1253,6 +1330,10 @@ Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a commen
 
 			rsa := !test.selfApprove
 			irs := !test.reviewActsAsApprove
+			var selfApprovalRes []*regexp.Regexp
+			for _, path := range test.selfApprovalPaths {
+				selfApprovalRes = append(selfApprovalRes, regexp.MustCompile(path))
+			}
 			if err := handle(
 				logrus.WithField("plugin", "approve"),
 				fghc,
@@ -1263,6 +1344,8 @@ Approvers can cancel approval by writing ` + "`/approve cancel`" + ` in a commen
 				&plugins.Approve{
 					Repos:               []string{"org/repo"},
 					RequireSelfApproval: &rsa,
+					SelfApprovalPaths:   test.selfApprovalPaths,
+					SelfApprovalRes:     selfApprovalRes,
 					IssueRequired:       test.needsIssue,
 					LgtmActsAsApprove:   test.lgtmActsAsApprove,
 					IgnoreReviewState:   &irs,
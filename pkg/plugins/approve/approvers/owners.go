@@ -294,6 +294,9 @@ type Approvers struct {
 	RequireIssue    bool
 
 	ManuallyApproved func() bool
+
+	selfApprover       string
+	selfApprovalPolicy string
 }
 
 // CaseInsensitiveIntersection runs the intersection between to sets.Set[string] in a
@@ -377,6 +380,27 @@ func (ap *Approvers) AddAuthorSelfApprover(login, reference string, noIssue bool
 	}
 }
 
+// AddPolicySelfApprover adds the author self approval granted by a policy of
+// the repository, which is recorded in the notification as long as the author
+// approval stands.
+func (ap *Approvers) AddPolicySelfApprover(login, reference, policy string) {
+	if ap.shouldNotOverrideApproval(login, false) {
+		return
+	}
+	ap.AddAuthorSelfApprover(login, reference, false)
+	ap.selfApprover = strings.ToLower(login)
+	ap.selfApprovalPolicy = policy
+}
+
+// SelfApprovalPolicy returns the policy the author approval was granted by, if
+// any.
+func (ap Approvers) SelfApprovalPolicy() string {
+	if approval, ok := ap.approvers[ap.selfApprover]; !ok || approval.How != "Author self-approved" {
+		return ""
+	}
+	return ap.selfApprovalPolicy
+}
+
 // RemoveApprover removes an approver from the list.
 func (ap *Approvers) RemoveApprover(login string) {
 	delete(ap.approvers, strings.ToLower(login))
@@ -678,6 +702,10 @@ Approval requirements bypassed by manually added approval.
 
 {{end -}}
 This pull-request has been approved by:{{range $index, $approval := .ap.ListApprovals}}{{if $index}}, {{else}} {{end}}{{$approval}}{{end}}
+{{- with .ap.SelfApprovalPolicy}}
+
+The author approval was granted by the self-approval policy of this repository: {{.}}.
+{{- end}}
 
 {{- if (and (not .ap.AreFilesApproved) (not (call .ap.ManuallyApproved))) }}
 {{ if len .ap.SuggestedCCs -}}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backport adds the `do-not-merge/needs-backport-approval` label to
+// PRs against a release branch until they link the PR they backport and are
+// approved by the release team.
+package backport
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	// PluginName defines this plugin's registered name.
+	PluginName = "backport"
+
+	commentMarker = "This PR is a backport to a release branch"
+)
+
+func init() {
+	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
+	plugins.RegisterReviewEventHandler(PluginName, handlePullRequestReview, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		opts := config.BackportFor(repo.Org, repo.Repo)
+		if opts == nil {
+			continue
+		}
+		approvals := "Backports do not need approvals."
+		if opts.ReleaseTeam != "" {
+			approvals = fmt.Sprintf("Backports need %d approving review(s) by members of the %s team.", opts.RequiredApprovals, opts.ReleaseTeam)
+		}
+		configInfo[repo.String()] = fmt.Sprintf("PRs against branches matching `%s` are treated as backports. %s", opts.BranchRegexp, approvals)
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		Backport: []plugins.Backport{
+			{
+				Repos:             []string{"kubernetes/kubernetes"},
+				BranchRegexp:      "^release-.*$",
+				ReleaseTeam:       "release-managers",
+				RequiredApprovals: 1,
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: fmt.Sprintf("The backport plugin labels PRs against a release branch with the `%s` label until their description links the PR they backport, which must be merged into the default branch, and they are approved by the release team.", labels.NeedsBackportApproval),
+		Config:      configInfo,
+		Snippet:     yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+	CreateComment(org, repo string, number int, comment string) error
+	GetPullRequest(org, repo string, number int) (*github.PullRequest, error)
+	ListReviews(org, repo string, number int) ([]github.Review, error)
+	ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error)
+}
+
+type commentPruner interface {
+	PruneComments(shouldPrune func(github.IssueComment) bool)
+}
+
+func handlePullRequest(pc plugins.Agent, pre github.PullRequestEvent) error {
+	switch pre.Action {
+	case github.PullRequestActionOpened, github.PullRequestActionReopened, github.PullRequestActionEdited, github.PullRequestActionSynchronize:
+	default:
+		return nil
+	}
+	cp, err := pc.CommentPruner()
+	if err != nil {
+		return err
+	}
+	return handle(pc.GitHubClient, pc.Logger, cp, pc.PluginConfig.BackportFor(pre.Repo.Owner.Login, pre.Repo.Name), pre.Repo, pre.PullRequest)
+}
+
+func handlePullRequestReview(pc plugins.Agent, re github.ReviewEvent) error {
+	cp, err := pc.CommentPruner()
+	if err != nil {
+		return err
+	}
+	return handle(pc.GitHubClient, pc.Logger, cp, pc.PluginConfig.BackportFor(re.Repo.Owner.Login, re.Repo.Name), re.Repo, re.PullRequest)
+}
+
+func handle(gc githubClient, log *logrus.Entry, cp commentPruner, opts *plugins.Backport, r github.Repo, pr github.PullRequest) error {
+	if opts == nil || opts.BranchRe == nil || !opts.BranchRe.MatchString(pr.Base.Ref) {
+		return nil
+	}
+	if pr.State != github.PullRequestStateOpen {
+		return nil
+	}
+	org, repo, number := r.Owner.Login, r.Name, pr.Number
+
+	satisfied, err := policySatisfied(gc, log, opts, r, pr)
+	if err != nil {
+		return err
+	}
+
+	issueLabels, err := gc.GetIssueLabels(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels of %s/%s#%d: %w", org, repo, number, err)
+	}
+	hasLabel := github.HasLabel(labels.NeedsBackportApproval, issueLabels)
+
+	if satisfied {
+		if hasLabel {
+			if err := gc.RemoveLabel(org, repo, number, labels.NeedsBackportApproval); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.NeedsBackportApproval)
+			}
+		}
+		cp.PruneComments(func(comment github.IssueComment) bool {
+			return strings.Contains(comment.Body, commentMarker)
+		})
+		return nil
+	}
+
+	if hasLabel {
+		return nil
+	}
+	if err := gc.AddLabel(org, repo, number, labels.NeedsBackportApproval); err != nil {
+		log.WithError(err).Errorf("GitHub failed to add the following label: %s", labels.NeedsBackportApproval)
+	}
+	comment := plugins.FormatSimpleResponse(policyComment(opts, r, pr.Base.Ref))
+	if err := gc.CreateComment(org, repo, number, comment); err != nil {
+		log.WithError(err).Errorf("Failed to comment %q", comment)
+	}
+	return nil
+}
+
+func policyComment(opts *plugins.Backport, r github.Repo, branch string) string {
+	approvals := ""
+	if opts.ReleaseTeam != "" {
+		approvals = fmt.Sprintf(" and be approved by %d member(s) of the @%s/%s team with a GitHub review", opts.RequiredApprovals, r.Owner.Login, opts.ReleaseTeam)
+	}
+	return fmt.Sprintf("%s (`%s`). Backports must link the PR they backport, merged into `%s`, in their description%s. Adding the `%s` label until they do.",
+		commentMarker, branch, r.DefaultBranch, approvals, labels.NeedsBackportApproval)
+}
+
+// policySatisfied determines whether the PR links a PR merged into the
+// default branch of the repo and has enough approvals by the release team.
+func policySatisfied(gc githubClient, log *logrus.Entry, opts *plugins.Backport, r github.Repo, pr github.PullRequest) (bool, error) {
+	org, repo := r.Owner.Login, r.Name
+	linked := false
+	for _, number := range referencedPRs(org, repo, pr.Body) {
+		if number == pr.Number {
+			continue
+		}
+		original, err := gc.GetPullRequest(org, repo, number)
+		if err != nil {
+			log.WithError(err).Debugf("Failed to get the referenced PR %s/%s#%d.", org, repo, number)
+			continue
+		}
+		if original.Merged && original.Base.Ref == r.DefaultBranch {
+			linked = true
+			break
+		}
+	}
+	if !linked {
+		return false, nil
+	}
+	if opts.ReleaseTeam == "" {
+		return true, nil
+	}
+
+	members, err := gc.ListTeamMembersBySlug(org, opts.ReleaseTeam, github.RoleAll)
+	if err != nil {
+		return false, fmt.Errorf("failed to list the members of the %s team: %w", opts.ReleaseTeam, err)
+	}
+	releaseTeam := sets.New[string]()
+	for _, member := range members {
+		releaseTeam.Insert(github.NormLogin(member.Login))
+	}
+	reviews, err := gc.ListReviews(org, repo, pr.Number)
+	if err != nil {
+		return false, fmt.Errorf("failed to list the reviews of %s/%s#%d: %w", org, repo, pr.Number, err)
+	}
+	// Only the latest review of a reviewer counts, and comments do not
+	// change the state of a review.
+	latest := map[string]github.ReviewState{}
+	for _, review := range reviews {
+		state := github.ReviewState(strings.ToUpper(string(review.State)))
+		if state == github.ReviewStateCommented {
+			continue
+		}
+		latest[github.NormLogin(review.User.Login)] = state
+	}
+	approvals := 0
+	for login, state := range latest {
+		if state == github.ReviewStateApproved && releaseTeam.Has(login) && login != github.NormLogin(pr.User.Login) {
+			approvals++
+		}
+	}
+	return approvals >= opts.RequiredApprovals, nil
+}
+
+// referencedPRs returns the numbers of the PRs of the repo the body
+// references, either as #123, org/repo#123 or by their URL.
+func referencedPRs(org, repo, body string) []int {
+	re := regexp.MustCompile(fmt.Sprintf(`(?i)(?:https://github\.com/%[1]s/pull/|\b%[1]s#|(?:^|[^\w/#])#)(\d+)\b`, regexp.QuoteMeta(org+"/"+repo)))
+	var numbers []int
+	seen := sets.New[int]()
+	for _, match := range re.FindAllStringSubmatch(body, -1) {
+		number, err := strconv.Atoi(match[1])
+		if err != nil || seen.Has(number) {
+			continue
+		}
+		seen.Insert(number)
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
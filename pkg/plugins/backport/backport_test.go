@@ -0,0 +1,245 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backport
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+type fakePruner struct {
+	pruned bool
+}
+
+func (fp *fakePruner) PruneComments(shouldPrune func(github.IssueComment) bool) {
+	fp.pruned = true
+}
+
+func TestHandle(t *testing.T) {
+	const label = "org/repo#101:" + labels.NeedsBackportApproval
+	approve := func(login string) github.Review {
+		return github.Review{User: github.User{Login: login}, State: github.ReviewStateApproved}
+	}
+	var testcases = []struct {
+		name        string
+		branch      string
+		body        string
+		releaseTeam string
+		reviews     []github.Review
+		hasLabel    bool
+
+		expectAdded   bool
+		expectRemoved bool
+		expectComment bool
+		expectPruned  bool
+	}{
+		{
+			name:   "PR against the default branch is ignored",
+			branch: "master",
+			body:   "Fixes a bug.",
+		},
+		{
+			name:          "backport without a link is labeled",
+			branch:        "release-1.0",
+			body:          "Fixes a bug.",
+			expectAdded:   true,
+			expectComment: true,
+		},
+		{
+			name:          "backport linking an open PR is labeled",
+			branch:        "release-1.0",
+			body:          "Backport of #3.",
+			expectAdded:   true,
+			expectComment: true,
+		},
+		{
+			name:          "backport linking a PR merged into another release branch is labeled",
+			branch:        "release-1.0",
+			body:          "Backport of #2.",
+			expectAdded:   true,
+			expectComment: true,
+		},
+		{
+			name:         "backport linking the merged PR needs no approval without a release team",
+			branch:       "release-1.0",
+			body:         "Backport of #1.",
+			expectPruned: true,
+		},
+		{
+			name:          "backport linking the merged PR by URL without approval is labeled",
+			branch:        "release-1.0",
+			body:          "Backport of https://github.com/org/repo/pull/1.",
+			releaseTeam:   "leads",
+			expectAdded:   true,
+			expectComment: true,
+		},
+		{
+			name:         "backport linking the merged PR approved by the release team",
+			branch:       "release-1.0",
+			body:         "Backport of org/repo#1.",
+			releaseTeam:  "leads",
+			reviews:      []github.Review{approve("sig-lead")},
+			expectPruned: true,
+		},
+		{
+			name:          "approval by someone outside of the release team does not count",
+			branch:        "release-1.0",
+			body:          "Backport of #1.",
+			releaseTeam:   "leads",
+			reviews:       []github.Review{approve("someone")},
+			expectAdded:   true,
+			expectComment: true,
+		},
+		{
+			name:        "approval by the release team that was later changed does not count",
+			branch:      "release-1.0",
+			body:        "Backport of #1.",
+			releaseTeam: "leads",
+			reviews: []github.Review{
+				approve("sig-lead"),
+				{User: github.User{Login: "sig-lead"}, State: github.ReviewStateChangesRequested},
+			},
+			expectAdded:   true,
+			expectComment: true,
+		},
+		{
+			name:        "comments after an approval do not dismiss it",
+			branch:      "release-1.0",
+			body:        "Backport of #1.",
+			releaseTeam: "leads",
+			reviews: []github.Review{
+				approve("sig-lead"),
+				{User: github.User{Login: "sig-lead"}, State: github.ReviewStateCommented},
+			},
+			expectPruned: true,
+		},
+		{
+			name:        "label is removed once the policy is satisfied",
+			branch:      "release-1.0",
+			body:        "Backport of #1.",
+			releaseTeam: "leads",
+			reviews:     []github.Review{approve("sig-lead")},
+			hasLabel:    true,
+
+			expectRemoved: true,
+			expectPruned:  true,
+		},
+		{
+			name:        "labeled backport is not commented on again",
+			branch:      "release-1.0",
+			body:        "Backport of #1.",
+			releaseTeam: "leads",
+			hasLabel:    true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			fc.PullRequests = map[int]*github.PullRequest{
+				1: {Number: 1, Merged: true, Base: github.PullRequestBranch{Ref: "master"}},
+				2: {Number: 2, Merged: true, Base: github.PullRequestBranch{Ref: "release-0.9"}},
+				3: {Number: 3, Base: github.PullRequestBranch{Ref: "master"}},
+			}
+			fc.Reviews = map[int][]github.Review{101: tc.reviews}
+			if tc.hasLabel {
+				fc.IssueLabelsExisting = []string{label}
+			}
+			fp := &fakePruner{}
+			opts := &plugins.Backport{
+				BranchRegexp:      "^release-.*$",
+				BranchRe:          regexp.MustCompile("^release-.*$"),
+				ReleaseTeam:       tc.releaseTeam,
+				RequiredApprovals: 1,
+			}
+			r := github.Repo{Owner: github.User{Login: "org"}, Name: "repo", DefaultBranch: "master"}
+			pr := github.PullRequest{
+				Number: 101,
+				State:  github.PullRequestStateOpen,
+				Body:   tc.body,
+				User:   github.User{Login: "author"},
+				Base:   github.PullRequestBranch{Ref: tc.branch},
+			}
+
+			if err := handle(fc, logrus.WithField("plugin", PluginName), fp, opts, r, pr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if added := len(fc.IssueLabelsAdded) == 1 && fc.IssueLabelsAdded[0] == label; added != tc.expectAdded {
+				t.Errorf("expected the label to be added: %t, got labels added: %v", tc.expectAdded, fc.IssueLabelsAdded)
+			}
+			if removed := len(fc.IssueLabelsRemoved) == 1 && fc.IssueLabelsRemoved[0] == label; removed != tc.expectRemoved {
+				t.Errorf("expected the label to be removed: %t, got labels removed: %v", tc.expectRemoved, fc.IssueLabelsRemoved)
+			}
+			if commented := len(fc.IssueComments[101]) == 1; commented != tc.expectComment {
+				t.Errorf("expected a comment: %t, got comments: %v", tc.expectComment, fc.IssueComments[101])
+			}
+			if fp.pruned != tc.expectPruned {
+				t.Errorf("expected comments to be pruned: %t, got: %t", tc.expectPruned, fp.pruned)
+			}
+		})
+	}
+}
+
+func TestReferencedPRs(t *testing.T) {
+	var testcases = []struct {
+		name     string
+		body     string
+		expected []int
+	}{
+		{
+			name: "no reference",
+			body: "Fixes a bug.",
+		},
+		{
+			name:     "references in all forms",
+			body:     "Backport of #1, org/repo#2 and https://github.com/org/repo/pull/3.",
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "references at the start of lines",
+			body:     "#1\n#2",
+			expected: []int{1, 2},
+		},
+		{
+			name:     "references to other repos are ignored",
+			body:     "See other/repo#1, https://github.com/org/other/pull/2 and ##3.",
+			expected: nil,
+		},
+		{
+			name:     "duplicate references are returned once",
+			body:     "Backport of #1 (https://github.com/org/repo/pull/1).",
+			expected: []int{1},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := referencedPRs("org", "repo", tc.body); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -62,11 +62,20 @@ type Configuration struct {
 	// external plugins.
 	ExternalPlugins map[string][]ExternalPlugin `json:"external_plugins,omitempty"`
 
+	// CommandAliases is a map of organizations (eg "o") or repositories
+	// (eg "o/r") to aliases of plugin commands, without the leading slash.
+	// For example, `ship-it: lgtm` makes `/ship-it` and `/remove-ship-it`
+	// act as `/lgtm` and `/remove-lgtm`. The aliases of a repository take
+	// precedence over the ones of its organization. Aliases are only
+	// expanded for the plugins handling generic comments.
+	CommandAliases map[string]map[string]string `json:"command_aliases,omitempty"`
+
 	// Owners contains configuration related to handling OWNERS files.
 	Owners Owners `json:"owners,omitempty"`
 
 	// Built-in plugins specific configuration.
 	Approve              []Approve                    `json:"approve,omitempty"`
+	Backport             []Backport                   `json:"backport,omitempty"`
 	Blockades            []Blockade                   `json:"blockades,omitempty"`
 	Blunderbuss          Blunderbuss                  `json:"blunderbuss,omitempty"`
 	Bugzilla             Bugzilla                     `json:"bugzilla,omitempty"`
@@ -76,11 +85,17 @@ type Configuration struct {
 	CherryPickUnapproved CherryPickUnapproved         `json:"cherry_pick_unapproved,omitempty"`
 	ConfigUpdater        ConfigUpdater                `json:"config_updater,omitempty"`
 	Dco                  map[string]*Dco              `json:"dco,omitempty"`
+	Dedup                []Dedup                      `json:"dedup,omitempty"`
+	DependencyReview     []DependencyReview           `json:"dependency_review,omitempty"`
+	FastForward          []FastForward                `json:"fast_forward,omitempty"`
 	Golint               Golint                       `json:"golint,omitempty"`
 	Goose                Goose                        `json:"goose,omitempty"`
 	Heart                Heart                        `json:"heart,omitempty"`
+	IssueTemplate        []IssueTemplate              `json:"issue_template,omitempty"`
 	Label                Label                        `json:"label,omitempty"`
+	LargeFiles           []LargeFiles                 `json:"large_files,omitempty"`
 	Lgtm                 []Lgtm                       `json:"lgtm,omitempty"`
+	PRDescription        []PRDescription              `json:"pr_description,omitempty"`
 	Jira                 *Jira                        `json:"jira,omitempty"`
 	MilestoneApplier     map[string]BranchToMilestone `json:"milestone_applier,omitempty"`
 	RepoMilestone        map[string]Milestone         `json:"repo_milestone,omitempty"`
@@ -88,6 +103,7 @@ type Configuration struct {
 	ProjectManager       ProjectManager               `json:"project_manager,omitempty"`
 	RequireMatchingLabel []RequireMatchingLabel       `json:"require_matching_label,omitempty"`
 	Retitle              Retitle                      `json:"retitle,omitempty"`
+	ReviewSLA            []ReviewSLA                  `json:"review_sla,omitempty"`
 	Slack                Slack                        `json:"slack,omitempty"`
 	SigMention           SigMention                   `json:"sigmention,omitempty"`
 	Size                 Size                         `json:"size,omitempty"`
@@ -317,6 +333,14 @@ type Approve struct {
 	// RequireSelfApproval disables automatic approval from PR authors with approval rights.
 	// Otherwise the plugin assumes the author of the PR with approval rights approves the changes in the PR.
 	RequireSelfApproval *bool `json:"require_self_approval,omitempty"`
+	// SelfApprovalPaths are regular expressions of files, e.g. `^docs/` or
+	// `(^|/)OWNERS$`, whose changes PR authors with approval rights implicitly
+	// approve even when RequireSelfApproval is set. They only apply to PRs that
+	// change no other files, and renamed files must match with their old name
+	// too.
+	SelfApprovalPaths []string `json:"self_approval_paths,omitempty"`
+	// SelfApprovalRes are compiled from SelfApprovalPaths during config load.
+	SelfApprovalRes []*regexp.Regexp `json:"-"`
 	// LgtmActsAsApprove indicates that the lgtm command should be used to
 	// indicate approval
 	LgtmActsAsApprove bool `json:"lgtm_acts_as_approve,omitempty"`
@@ -344,6 +368,27 @@ func (a Approve) HasSelfApproval() bool {
 	return true
 }
 
+// SelfApprovesChanges tells whether the author of a PR changing the given
+// files implicitly approves them because they all match SelfApprovalPaths.
+func (a Approve) SelfApprovesChanges(filenames []string) bool {
+	if len(a.SelfApprovalRes) == 0 || len(filenames) == 0 {
+		return false
+	}
+	for _, filename := range filenames {
+		matches := false
+		for _, re := range a.SelfApprovalRes {
+			if re.MatchString(filename) {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
+
 func (a Approve) ConsiderReviewState() bool {
 	if a.IgnoreReviewState != nil {
 		return !*a.IgnoreReviewState
@@ -372,6 +417,13 @@ type Lgtm struct {
 	// StickyLgtmTeam specifies the GitHub team whose members are trusted with sticky LGTM,
 	// which eliminates the need to re-lgtm minor fixes/updates.
 	StickyLgtmTeam string `json:"trusted_team_for_sticky_lgtm,omitempty"`
+	// StickyPaths are regular expressions of files, e.g. `^docs/` for
+	// regenerated documentation, whose changes do not remove LGTM. The label
+	// is kept on pushes that change no other files than the previous head of
+	// the PR.
+	StickyPaths []string `json:"sticky_paths,omitempty"`
+	// StickyPathRes are compiled from StickyPaths during config load.
+	StickyPathRes []*regexp.Regexp `json:"-"`
 }
 
 // Jira holds the config for the jira plugin.
@@ -468,6 +520,19 @@ type Trigger struct {
 	IgnoreOkToTest bool `json:"ignore_ok_to_test,omitempty"`
 	// TriggerGitHubWorkflows enables workflows run by github to be triggered by prow.
 	TriggerGitHubWorkflows bool `json:"trigger_github_workflows,omitempty"`
+	// FlakeTrackerURL is the URL of the flake-tracker API. /retest-flakes
+	// is only available if it is set.
+	FlakeTrackerURL string `json:"flake_tracker_url,omitempty"`
+	// ElideDuplicateRuns makes trigger adopt the pending or running ProwJob of
+	// a presubmit requested by a comment, e.g. /retest, instead of creating a
+	// new one when it tests the same base and head SHAs.
+	ElideDuplicateRuns bool `json:"elide_duplicate_runs,omitempty"`
+	// RunRequiredOnly makes /retest, /test all, /ok-to-test and
+	// /retest-required only run the presubmits Tide requires for merge, like
+	// /test-required does. Without it, /retest-required skips the optional
+	// presubmits only. Optional presubmits can still be run with their own
+	// trigger, e.g. /test <job>.
+	RunRequiredOnly bool `json:"run_required_only,omitempty"`
 }
 
 // Heart contains the configuration for the heart plugin.
@@ -602,7 +667,8 @@ func (cu *ConfigUpdater) resolve() error {
 	return utilerrors.NewAggregate(errs)
 }
 
-// ProjectConfig contains the configuration options for the project plugin
+// ProjectConfig contains the configuration options for the project plugin.
+// Projects are GitHub projects (beta) of the org.
 type ProjectConfig struct {
 	// Org level configs for github projects; key is org name
 	Orgs map[string]ProjectOrgConfig `json:"project_org_configs,omitempty"`
@@ -613,8 +679,8 @@ type ProjectConfig struct {
 type ProjectOrgConfig struct {
 	// ID of the github project maintainer team for a give project or org
 	MaintainerTeamID int `json:"org_maintainers_team_id,omitempty"`
-	// A map of project name to default column; an issue/PR will be added
-	// to the default column if column name is not provided in the command
+	// A map of project title to default status; an issue/PR will be added
+	// with the default status if no status is provided in the command
 	ProjectColumnMap map[string]string `json:"org_default_column_map,omitempty"`
 	// Repo level configs for github projects; key is repo name
 	Repos map[string]ProjectRepoConfig `json:"project_repo_configs,omitempty"`
@@ -624,8 +690,8 @@ type ProjectOrgConfig struct {
 type ProjectRepoConfig struct {
 	// ID of the github project maintainer team for a give project or org
 	MaintainerTeamID int `json:"repo_maintainers_team_id,omitempty"`
-	// A map of project name to default column; an issue/PR will be added
-	// to the default column if column name is not provided in the command
+	// A map of project title to default status; an issue/PR will be added
+	// with the default status if no status is provided in the command
 	ProjectColumnMap map[string]string `json:"repo_default_column_map,omitempty"`
 }
 
@@ -692,6 +758,133 @@ func (w Welcome) getRepos() []string {
 	return w.Repos
 }
 
+// IssueTemplate is config for the issue-template plugin.
+type IssueTemplate struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// MessageTemplate is the template of the comment asking the author of an
+	// issue for the information missing from its description.
+	// For the info struct see prow/plugins/issuetemplate/issuetemplate.go's IssueInfo
+	MessageTemplate string `json:"message_template,omitempty"`
+}
+
+func (it IssueTemplate) getRepos() []string {
+	return it.Repos
+}
+
+// DependencyReview is config for the dependency-review plugin.
+type DependencyReview struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// ReviewTeam is the slug of the GitHub team whose members may add the
+	// `dependencies-approved` label. If unset, anyone who can label PRs may.
+	ReviewTeam string `json:"review_team,omitempty"`
+	// LicenseDatabase is the path to a YAML file mapping Go modules to the
+	// SPDX identifiers of their licenses, e.g. `golang.org/x/mod: BSD-3-Clause`.
+	// Keys of the form module@version take precedence over the module.
+	LicenseDatabase string `json:"license_database,omitempty"`
+}
+
+func (d DependencyReview) getRepos() []string {
+	return d.Repos
+}
+
+// ReviewSLA is config for the review-sla external plugin.
+type ReviewSLA struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// SLA is how long an open PR may await review before its requested
+	// reviewers and assignees are pinged, e.g. `48h`.
+	SLA         string        `json:"sla"`
+	SLADuration time.Duration `json:"-"`
+	// EscalationSLA is how long an open PR may await review before the
+	// approvers of the parent OWNERS files of its changes are pinged as well.
+	// It must be longer than the SLA. If unset, reviews are not escalated.
+	EscalationSLA         string        `json:"escalation_sla,omitempty"`
+	EscalationSLADuration time.Duration `json:"-"`
+}
+
+func (r ReviewSLA) getRepos() []string {
+	return r.Repos
+}
+
+// Dedup is config for the dedup plugin.
+type Dedup struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// LookbackDays is how many days back the issues of the repo are searched
+	// for duplicates of a new issue. Defaults to 90.
+	LookbackDays int `json:"lookback_days,omitempty"`
+	// Threshold is the minimum similarity, between 0 and 1, of an issue to a
+	// new issue for it to be suggested as a duplicate. Defaults to 0.5.
+	Threshold float64 `json:"threshold,omitempty"`
+	// MaxSuggestions is the maximum number of issues suggested as duplicates.
+	// Defaults to 3.
+	MaxSuggestions int `json:"max_suggestions,omitempty"`
+}
+
+func (d Dedup) getRepos() []string {
+	return d.Repos
+}
+
+// FastForward is config for the fast-forward plugin.
+type FastForward struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// ReleaseManagers is the slug of the GitHub team whose members may
+	// fast-forward release branches.
+	ReleaseManagers string `json:"release_managers"`
+	// BranchRegexp is the regular expression for the names of the branches
+	// that may be fast-forwarded. Defaults to `^release-.*$`. Compiles into
+	// BranchRe during config load.
+	BranchRegexp string         `json:"branchregexp,omitempty"`
+	BranchRe     *regexp.Regexp `json:"-"`
+	// RequiredPostsubmits are the names of the postsubmits that must have
+	// passed on a SHA of the default branch before a release branch can be
+	// fast-forwarded to it. Defaults to the postsubmits of the repo that
+	// always run on its default branch.
+	RequiredPostsubmits []string `json:"required_postsubmits,omitempty"`
+}
+
+func (f FastForward) getRepos() []string {
+	return f.Repos
+}
+
+// LargeFiles is config for the large-files plugin.
+type LargeFiles struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// MaxFileSize is the maximum size in bytes of the files PRs add or
+	// change. Defaults to 1MiB.
+	MaxFileSize int64 `json:"max_file_size,omitempty"`
+	// DisallowedExtensions are the extensions of the files PRs must not add
+	// or change, e.g. `.exe` or `.jar`. Extensions match case-insensitively.
+	DisallowedExtensions []string `json:"disallowed_extensions,omitempty"`
+}
+
+func (lf LargeFiles) getRepos() []string {
+	return lf.Repos
+}
+
+// PRDescription is config for the pr-description plugin.
+type PRDescription struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// MinLength is the minimum number of characters of a PR description,
+	// not counting whitespace around it and HTML comments.
+	MinLength int `json:"min_length,omitempty"`
+	// RequireLinkedIssue requires PR descriptions to reference an issue or
+	// PR, e.g. with `Fixes #123`.
+	RequireLinkedIssue bool `json:"require_linked_issue,omitempty"`
+	// RequiredSections are the headings of the sections PR descriptions must
+	// fill in, e.g. `Testing`. Headings match case-insensitively.
+	RequiredSections []string `json:"required_sections,omitempty"`
+}
+
+func (d PRDescription) getRepos() []string {
+	return d.Repos
+}
+
 // Dco is config for the DCO (https://developercertificate.org/) checker plugin.
 type Dco struct {
 	// SkipDCOCheckForMembers is used to skip DCO check for trusted org members
@@ -728,6 +921,24 @@ type CherryPickApproved struct {
 	Approvers []string `json:"approvers,omitempty"`
 }
 
+// Backport specifies the backport policy of a set of repos.
+// The configuration for the backport plugin is defined as a list of these structures.
+type Backport struct {
+	// Repos is either of the form org/repos or just org.
+	Repos []string `json:"repos,omitempty"`
+	// BranchRegexp is the regular expression for branch names such that
+	// the plugin treats only PRs against these branch names as backports.
+	// Defaults to `^release-.*$`. Compiles into BranchRe during config load.
+	BranchRegexp string         `json:"branchregexp,omitempty"`
+	BranchRe     *regexp.Regexp `json:"-"`
+	// ReleaseTeam is the slug of the GitHub team whose members approve
+	// backports. If unset, backports only need to link the PR they backport.
+	ReleaseTeam string `json:"release_team,omitempty"`
+	// RequiredApprovals is the number of approving reviews by members of the
+	// ReleaseTeam a backport needs. Defaults to 1.
+	RequiredApprovals int `json:"required_approvals,omitempty"`
+}
+
 // CherryPickUnapproved is the config for the cherrypick-unapproved plugin.
 type CherryPickUnapproved struct {
 	// BranchRegexp is the regular expression for branch names such that
@@ -902,6 +1113,40 @@ func (c *Configuration) LgtmFor(org, repo string) *Lgtm {
 	return &Lgtm{}
 }
 
+// CommandAliasesFor returns the command aliases of a repo, merging the
+// aliases of the repo into the ones of its org.
+func (c *Configuration) CommandAliasesFor(org, repo string) map[string]string {
+	aliases := map[string]string{}
+	for alias, command := range c.CommandAliases[org] {
+		aliases[alias] = command
+	}
+	for alias, command := range c.CommandAliases[fmt.Sprintf("%s/%s", org, repo)] {
+		aliases[alias] = command
+	}
+	return aliases
+}
+
+// BackportFor finds the Backport for a repo, if one exists.
+// A Backport can be listed for the repo itself or for the
+// owning organization.
+func (c *Configuration) BackportFor(org, repo string) *Backport {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+	for _, backport := range c.Backport {
+		if !sets.New[string](backport.Repos...).Has(fullName) {
+			continue
+		}
+		return &backport
+	}
+	// If you don't find anything, loop again looking for an org config
+	for _, backport := range c.Backport {
+		if !sets.New[string](backport.Repos...).Has(org) {
+			continue
+		}
+		return &backport
+	}
+	return nil
+}
+
 // TriggerFor finds the Trigger for a repo, if one exists
 // a trigger can be listed for the repo itself or for the
 // owning organization
@@ -1094,6 +1339,21 @@ func (c *Configuration) setDefaults() {
 		}
 	}
 
+	for i := range c.Backport {
+		if c.Backport[i].BranchRegexp == "" {
+			c.Backport[i].BranchRegexp = `^release-.*$`
+		}
+		if c.Backport[i].RequiredApprovals == 0 {
+			c.Backport[i].RequiredApprovals = 1
+		}
+	}
+
+	for i := range c.FastForward {
+		if c.FastForward[i].BranchRegexp == "" {
+			c.FastForward[i].BranchRegexp = `^release-.*$`
+		}
+	}
+
 	for i, rml := range c.RequireMatchingLabel {
 		if rml.GracePeriod == "" {
 			c.RequireMatchingLabel[i].GracePeriod = "5s"
@@ -1117,6 +1377,26 @@ func validatePluginsDupes(plugins Plugins) error {
 	return utilerrors.NewAggregate(errors)
 }
 
+var commandNameRe = regexp.MustCompile(`^[\w-]+$`)
+
+func validateCommandAliases(commandAliases map[string]map[string]string) error {
+	var errs []error
+	for repo, aliases := range commandAliases {
+		for alias, command := range aliases {
+			if !commandNameRe.MatchString(alias) {
+				errs = append(errs, fmt.Errorf("command alias %q of %s is not a valid command name", alias, repo))
+			}
+			if !commandNameRe.MatchString(command) {
+				errs = append(errs, fmt.Errorf("command %q aliased by %q of %s is not a valid command name", command, alias, repo))
+			}
+			if strings.EqualFold(alias, command) {
+				errs = append(errs, fmt.Errorf("command alias %q of %s aliases itself", alias, repo))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 // ValidatePluginsUnknown will return an error if there are any unrecognized
 // plugins configured.
 func (c *Configuration) ValidatePluginsUnknown() error {
@@ -1348,6 +1628,22 @@ func compileRegexpsAndDurations(pc *Configuration) error {
 		pc.CherryPickApproved[i].BranchRe = approvedBranchRe
 	}
 
+	for i := range pc.Backport {
+		backportBranchRe, err := regexp.Compile(pc.Backport[i].BranchRegexp)
+		if err != nil {
+			return err
+		}
+		pc.Backport[i].BranchRe = backportBranchRe
+	}
+
+	for i := range pc.FastForward {
+		fastForwardBranchRe, err := regexp.Compile(pc.FastForward[i].BranchRegexp)
+		if err != nil {
+			return err
+		}
+		pc.FastForward[i].BranchRe = fastForwardBranchRe
+	}
+
 	for i := range pc.Blockades {
 		if pc.Blockades[i].BranchRegexp == nil {
 			continue
@@ -1359,12 +1655,51 @@ func compileRegexpsAndDurations(pc *Configuration) error {
 		pc.Blockades[i].BranchRe = branchRe
 	}
 
+	for i := range pc.Approve {
+		pc.Approve[i].SelfApprovalRes = nil
+		for _, path := range pc.Approve[i].SelfApprovalPaths {
+			re, err := regexp.Compile(path)
+			if err != nil {
+				return fmt.Errorf("failed to compile approve self_approval_paths regexp: %q, error: %w", path, err)
+			}
+			pc.Approve[i].SelfApprovalRes = append(pc.Approve[i].SelfApprovalRes, re)
+		}
+	}
+
+	for i := range pc.Lgtm {
+		pc.Lgtm[i].StickyPathRes = nil
+		for _, path := range pc.Lgtm[i].StickyPaths {
+			re, err := regexp.Compile(path)
+			if err != nil {
+				return fmt.Errorf("failed to compile lgtm sticky_paths regexp: %q, error: %w", path, err)
+			}
+			pc.Lgtm[i].StickyPathRes = append(pc.Lgtm[i].StickyPathRes, re)
+		}
+	}
+
 	commentRe, err := regexp.Compile(pc.Heart.CommentRegexp)
 	if err != nil {
 		return err
 	}
 	pc.Heart.CommentRe = commentRe
 
+	for i := range pc.ReviewSLA {
+		sla := &pc.ReviewSLA[i]
+		dur, err := time.ParseDuration(sla.SLA)
+		if err != nil {
+			return fmt.Errorf("failed to parse review_sla sla: %q, error: %w", sla.SLA, err)
+		}
+		sla.SLADuration = dur
+		sla.EscalationSLADuration = 0
+		if sla.EscalationSLA != "" {
+			dur, err := time.ParseDuration(sla.EscalationSLA)
+			if err != nil {
+				return fmt.Errorf("failed to parse review_sla escalation_sla: %q, error: %w", sla.EscalationSLA, err)
+			}
+			sla.EscalationSLADuration = dur
+		}
+	}
+
 	rs := pc.RequireMatchingLabel
 	for i := range rs {
 		re, err := regexp.Compile(rs[i].Regexp)
@@ -1401,6 +1736,9 @@ func (c *Configuration) Validate() error {
 	if err := validateExternalPlugins(c.ExternalPlugins); err != nil {
 		return err
 	}
+	if err := validateCommandAliases(c.CommandAliases); err != nil {
+		return err
+	}
 	if err := validateBlunderbuss(&c.Blunderbuss); err != nil {
 		return err
 	}
@@ -1425,11 +1763,74 @@ func (c *Configuration) Validate() error {
 	if err := validateRepoDupes(c.Welcome); err != nil {
 		return err
 	}
+	if err := validateRepoDupes(c.IssueTemplate); err != nil {
+		return err
+	}
+	if err := validateRepoDupes(c.PRDescription); err != nil {
+		return err
+	}
+	if err := validateRepoDupes(c.DependencyReview); err != nil {
+		return err
+	}
+	if err := validateRepoDupes(c.LargeFiles); err != nil {
+		return err
+	}
+	if err := validateDedup(c.Dedup); err != nil {
+		return err
+	}
+	if err := validateReviewSLA(c.ReviewSLA); err != nil {
+		return err
+	}
+	if err := validateFastForward(c.FastForward); err != nil {
+		return err
+	}
 	validateRepoMilestone(c.RepoMilestone)
 
 	return nil
 }
 
+func validateDedup(configs []Dedup) error {
+	if err := validateRepoDupes(configs); err != nil {
+		return err
+	}
+	for _, config := range configs {
+		if config.Threshold < 0 || config.Threshold > 1 {
+			return fmt.Errorf("the dedup threshold of %v must be between 0 and 1", config.Repos)
+		}
+		if config.LookbackDays < 0 || config.MaxSuggestions < 0 {
+			return fmt.Errorf("the dedup lookback_days and max_suggestions of %v must not be negative", config.Repos)
+		}
+	}
+	return nil
+}
+
+func validateReviewSLA(configs []ReviewSLA) error {
+	if err := validateRepoDupes(configs); err != nil {
+		return err
+	}
+	for _, config := range configs {
+		if config.SLADuration <= 0 {
+			return fmt.Errorf("the review_sla sla of %v must be positive", config.Repos)
+		}
+		if config.EscalationSLA != "" && config.EscalationSLADuration <= config.SLADuration {
+			return fmt.Errorf("the review_sla escalation_sla of %v must be longer than its sla", config.Repos)
+		}
+	}
+	return nil
+}
+
+func validateFastForward(configs []FastForward) error {
+	if err := validateRepoDupes(configs); err != nil {
+		return err
+	}
+	for _, config := range configs {
+		if config.ReleaseManagers == "" {
+			return fmt.Errorf("the fast_forward release_managers of %v must be set", config.Repos)
+		}
+	}
+	return nil
+}
+
 type ListableRepos interface {
 	getRepos() []string
 }
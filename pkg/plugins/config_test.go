@@ -105,6 +105,68 @@ func TestValidateExternalPlugins(t *testing.T) {
 	}
 }
 
+func TestValidateCommandAliases(t *testing.T) {
+	tests := []struct {
+		name           string
+		commandAliases map[string]map[string]string
+		expectedErr    bool
+	}{
+		{
+			name: "valid aliases",
+			commandAliases: map[string]map[string]string{
+				"kubernetes":            {"ship-it": "lgtm"},
+				"kubernetes/test-infra": {"wait": "hold", "ship_it": "approve"},
+			},
+		},
+		{
+			name: "alias with a slash",
+			commandAliases: map[string]map[string]string{
+				"kubernetes": {"/ship-it": "lgtm"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "command with arguments",
+			commandAliases: map[string]map[string]string{
+				"kubernetes": {"ship-it": "lgtm cancel"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "alias of itself",
+			commandAliases: map[string]map[string]string{
+				"kubernetes": {"LGTM": "lgtm"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateCommandAliases(test.commandAliases); (err != nil) != test.expectedErr {
+				t.Errorf("expected an error: %t, got: %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCommandAliasesFor(t *testing.T) {
+	c := &Configuration{
+		CommandAliases: map[string]map[string]string{
+			"org":       {"ship-it": "lgtm", "wait": "hold"},
+			"org/repo":  {"wait": "hold-on"},
+			"org/other": {"go": "unhold"},
+		},
+	}
+	expected := map[string]string{"ship-it": "lgtm", "wait": "hold-on"}
+	if actual := c.CommandAliasesFor("org", "repo"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected aliases %v, got %v", expected, actual)
+	}
+	if actual := c.CommandAliasesFor("other", "repo"); len(actual) != 0 {
+		t.Errorf("expected no aliases, got %v", actual)
+	}
+}
+
 func TestOwnersFilenames(t *testing.T) {
 	cases := []struct {
 		org      string
@@ -2356,3 +2418,107 @@ func TestMergeFrom(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDedup(t *testing.T) {
+	testCases := []struct {
+		name        string
+		configs     []Dedup
+		expectedErr bool
+	}{
+		{
+			name:    "valid config",
+			configs: []Dedup{{Repos: []string{"org"}, Threshold: 0.7}, {Repos: []string{"org/repo"}, LookbackDays: 30}},
+		},
+		{
+			name:        "duplicated repo",
+			configs:     []Dedup{{Repos: []string{"org/repo"}}, {Repos: []string{"org/repo"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "threshold above 1",
+			configs:     []Dedup{{Repos: []string{"org"}, Threshold: 1.5}},
+			expectedErr: true,
+		},
+		{
+			name:        "negative lookback",
+			configs:     []Dedup{{Repos: []string{"org"}, LookbackDays: -1}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateDedup(tc.configs); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateReviewSLA(t *testing.T) {
+	testCases := []struct {
+		name        string
+		configs     []ReviewSLA
+		expectedErr bool
+	}{
+		{
+			name:    "valid config",
+			configs: []ReviewSLA{{Repos: []string{"org"}, SLA: "48h", EscalationSLA: "120h"}, {Repos: []string{"org/repo"}, SLA: "24h"}},
+		},
+		{
+			name:        "missing sla",
+			configs:     []ReviewSLA{{Repos: []string{"org"}, SLA: "0s"}},
+			expectedErr: true,
+		},
+		{
+			name:        "escalation before sla",
+			configs:     []ReviewSLA{{Repos: []string{"org"}, SLA: "48h", EscalationSLA: "24h"}},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicated repo",
+			configs:     []ReviewSLA{{Repos: []string{"org/repo"}, SLA: "48h"}, {Repos: []string{"org/repo"}, SLA: "24h"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Configuration{ReviewSLA: tc.configs}
+			if err := compileRegexpsAndDurations(c); err != nil {
+				t.Fatalf("unexpected error compiling the durations: %v", err)
+			}
+			if err := validateReviewSLA(c.ReviewSLA); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateFastForward(t *testing.T) {
+	testCases := []struct {
+		name        string
+		configs     []FastForward
+		expectedErr bool
+	}{
+		{
+			name:    "valid config",
+			configs: []FastForward{{Repos: []string{"org"}, ReleaseManagers: "release-managers"}, {Repos: []string{"org/repo"}, ReleaseManagers: "repo-release-managers"}},
+		},
+		{
+			name:        "missing release managers",
+			configs:     []FastForward{{Repos: []string{"org"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicated org",
+			configs:     []FastForward{{Repos: []string{"org"}, ReleaseManagers: "a"}, {Repos: []string{"org"}, ReleaseManagers: "b"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateFastForward(tc.configs); (err != nil) != tc.expectedErr {
+				t.Errorf("expected error: %t, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dedup comments on new issues with the recent issues of the
+// repository that look like duplicates of them.
+package dedup
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	// PluginName defines this plugin's registered name.
+	PluginName = "dedup"
+
+	defaultLookbackDays   = 90
+	defaultThreshold      = 0.5
+	defaultMaxSuggestions = 3
+
+	// titleWeight is how many times the words of the title count compared to
+	// the words of the body, as titles are the best summary of an issue.
+	titleWeight = 2
+)
+
+var (
+	commentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+	wordRe    = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+
+	stopWords = sets.New[string](
+		"about", "after", "all", "also", "and", "any", "are", "but", "can", "could", "did", "does", "doesn",
+		"for", "from", "get", "had", "has", "have", "how", "into", "its", "just", "not", "now", "one", "only",
+		"other", "our", "should", "some", "than", "that", "the", "their", "them", "then", "there", "these",
+		"this", "was", "were", "what", "when", "where", "which", "while", "who", "why", "will", "with",
+		"would", "you", "your",
+	)
+)
+
+func init() {
+	plugins.RegisterIssueHandler(PluginName, handleIssue, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		opts := optionsForRepo(config, repo.Org, repo.Repo)
+		configInfo[repo.String()] = fmt.Sprintf("Up to %d issues opened in the last %d days with a similarity of at least %.0f%% are suggested as duplicates.",
+			maxSuggestions(opts), lookbackDays(opts), threshold(opts)*100)
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		Dedup: []plugins.Dedup{
+			{
+				Repos:          []string{"kubernetes/test-infra"},
+				LookbackDays:   30,
+				Threshold:      0.6,
+				MaxSuggestions: 5,
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: "The dedup plugin comments on new issues with the recent issues of the repository whose title and description are similar to theirs, " +
+			"so that triagers can close duplicates with `/close duplicate-of #<number>`.",
+		Config:  configInfo,
+		Snippet: yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	FindIssuesWithOrg(org, query, sort string, asc bool) ([]github.Issue, error)
+	CreateComment(org, repo string, number int, comment string) error
+}
+
+func handleIssue(pc plugins.Agent, ie github.IssueEvent) error {
+	if ie.Action != github.IssueActionOpened || ie.Issue.IsPullRequest() {
+		return nil
+	}
+	return handle(pc.GitHubClient, pc.Logger, optionsForRepo(pc.PluginConfig, ie.Repo.Owner.Login, ie.Repo.Name), ie, time.Now())
+}
+
+// suggestion is an issue that looks like a duplicate of the new issue.
+type suggestion struct {
+	issue      github.Issue
+	similarity float64
+}
+
+func handle(gc githubClient, log *logrus.Entry, opts *plugins.Dedup, ie github.IssueEvent, now time.Time) error {
+	org, repo, number := ie.Repo.Owner.Login, ie.Repo.Name, ie.Issue.Number
+
+	since := now.AddDate(0, 0, -lookbackDays(opts)).Format("2006-01-02")
+	query := fmt.Sprintf("repo:%s/%s is:issue created:>=%s", org, repo, since)
+	issues, err := gc.FindIssuesWithOrg(org, query, "created", false)
+	if err != nil {
+		return fmt.Errorf("failed to search the issues of %s/%s: %w", org, repo, err)
+	}
+	var candidates []github.Issue
+	for _, issue := range issues {
+		if issue.Number == number || issue.IsPullRequest() {
+			continue
+		}
+		candidates = append(candidates, issue)
+	}
+
+	suggestions := findDuplicates(ie.Issue, candidates, threshold(opts), maxSuggestions(opts))
+	if len(suggestions) == 0 {
+		return nil
+	}
+	log.Infof("Suggesting %d possible duplicates of %s/%s#%d.", len(suggestions), org, repo, number)
+
+	var lines []string
+	for _, s := range suggestions {
+		state := ""
+		if s.issue.State == "closed" {
+			state = "closed, "
+		}
+		lines = append(lines, fmt.Sprintf("- #%d: %s (%s%.0f%% similar)", s.issue.Number, s.issue.Title, state, s.similarity*100))
+	}
+	msg := fmt.Sprintf("@%s: This issue looks similar to the following issues:\n\n%s\n\n"+
+		"If it duplicates one of them, a triager can close it with `/close duplicate-of #%d`, using the number of the issue it duplicates.",
+		ie.Issue.User.Login, strings.Join(lines, "\n"), suggestions[0].issue.Number)
+	return gc.CreateComment(org, repo, number, plugins.FormatSimpleResponse(msg))
+}
+
+// findDuplicates ranks the candidates by the cosine similarity of the TF-IDF
+// vectors of their words to the ones of the issue and returns the most
+// similar ones above the threshold.
+func findDuplicates(issue github.Issue, candidates []github.Issue, threshold float64, max int) []suggestion {
+	docs := make([]map[string]float64, 0, len(candidates)+1)
+	docs = append(docs, termFrequencies(issue))
+	for _, candidate := range candidates {
+		docs = append(docs, termFrequencies(candidate))
+	}
+
+	// The inverse document frequencies are computed over the candidates and
+	// the issue, so that the words common in the repository weigh less.
+	df := map[string]int{}
+	for _, doc := range docs {
+		for term := range doc {
+			df[term]++
+		}
+	}
+	idf := map[string]float64{}
+	for term, n := range df {
+		idf[term] = math.Log(float64(len(docs)+1)/float64(n+1)) + 1
+	}
+	vectors := make([]map[string]float64, len(docs))
+	for i, doc := range docs {
+		vectors[i] = map[string]float64{}
+		for term, tf := range doc {
+			vectors[i][term] = tf * idf[term]
+		}
+	}
+
+	var suggestions []suggestion
+	for i, candidate := range candidates {
+		if similarity := cosine(vectors[0], vectors[i+1]); similarity >= threshold {
+			suggestions = append(suggestions, suggestion{issue: candidate, similarity: similarity})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].similarity != suggestions[j].similarity {
+			return suggestions[i].similarity > suggestions[j].similarity
+		}
+		return suggestions[i].issue.Number > suggestions[j].issue.Number
+	})
+	if len(suggestions) > max {
+		suggestions = suggestions[:max]
+	}
+	return suggestions
+}
+
+// termFrequencies counts the words of the title and the body of an issue.
+func termFrequencies(issue github.Issue) map[string]float64 {
+	tf := map[string]float64{}
+	for _, word := range tokenize(issue.Title) {
+		tf[word] += titleWeight
+	}
+	// Comments are the instructions of issue templates.
+	for _, word := range tokenize(commentRe.ReplaceAllString(issue.Body, "")) {
+		tf[word]++
+	}
+	return tf
+}
+
+// tokenize returns the lowercased words of a text, without stop words,
+// numbers and words shorter than three characters.
+func tokenize(text string) []string {
+	var words []string
+	for _, word := range wordRe.FindAllString(strings.ToLower(text), -1) {
+		if len(word) < 3 || stopWords.Has(word) || strings.Trim(word, "0123456789") == "" {
+			continue
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
+func cosine(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for term, x := range a {
+		dot += x * b[term]
+		normA += x * x
+	}
+	for _, y := range b {
+		normB += y * y
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+func lookbackDays(opts *plugins.Dedup) int {
+	if opts.LookbackDays > 0 {
+		return opts.LookbackDays
+	}
+	return defaultLookbackDays
+}
+
+func threshold(opts *plugins.Dedup) float64 {
+	if opts.Threshold > 0 {
+		return opts.Threshold
+	}
+	return defaultThreshold
+}
+
+func maxSuggestions(opts *plugins.Dedup) int {
+	if opts.MaxSuggestions > 0 {
+		return opts.MaxSuggestions
+	}
+	return defaultMaxSuggestions
+}
+
+// optionsForRepo gets the plugins.Dedup struct that is applicable to the
+// indicated repo.
+func optionsForRepo(config *plugins.Configuration, org, repo string) *plugins.Dedup {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+
+	// First search for repo config
+	for _, c := range config.Dedup {
+		if !sets.New[string](c.Repos...).Has(fullName) {
+			continue
+		}
+		return &c
+	}
+
+	// If you don't find anything, loop again looking for an org config
+	for _, c := range config.Dedup {
+		if !sets.New[string](c.Repos...).Has(org) {
+			continue
+		}
+		return &c
+	}
+
+	// Return an empty config, and use the defaults
+	return &plugins.Dedup{}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+var existingIssues = []github.Issue{
+	{
+		Number: 1,
+		State:  "open",
+		Title:  "Tide does not merge PRs with a pending optional context",
+		Body:   "Tide keeps waiting for optional contexts that never report, so the PRs are never merged.",
+	},
+	{
+		Number: 2,
+		State:  "closed",
+		Title:  "Deck crashes when the job history page is empty",
+		Body:   "Opening the job history of a job without builds crashes deck with a nil pointer.",
+	},
+	{
+		Number: 3,
+		State:  "open",
+		Title:  "Support GitHub projects in the project plugin",
+		Body:   "<!-- Please describe the feature -->The project plugin only supports classic projects.",
+	},
+}
+
+func TestHandle(t *testing.T) {
+	testCases := []struct {
+		name             string
+		issue            github.Issue
+		opts             plugins.Dedup
+		expectedNumbers  []string
+		expectedNoMatch  []string
+		expectedComments int
+	}{
+		{
+			name: "similar issue is suggested",
+			issue: github.Issue{
+				Number: 10,
+				Title:  "Tide never merges PRs when an optional context is pending",
+				Body:   "The optional context never reports and tide does not merge.",
+				User:   github.User{Login: "author"},
+			},
+			expectedNumbers:  []string{"#1: Tide does not merge PRs"},
+			expectedNoMatch:  []string{"#2", "#3"},
+			expectedComments: 1,
+		},
+		{
+			name: "closed issues are suggested as well",
+			issue: github.Issue{
+				Number: 10,
+				Title:  "Deck crash on empty job history",
+				Body:   "The job history page crashes deck for a job without builds.",
+				User:   github.User{Login: "author"},
+			},
+			expectedNumbers:  []string{"#2: Deck crashes", "closed, "},
+			expectedNoMatch:  []string{"#1", "#3"},
+			expectedComments: 1,
+		},
+		{
+			name: "unrelated issue gets no comment",
+			issue: github.Issue{
+				Number: 10,
+				Title:  "Add a plugin to greet first-time contributors",
+				Body:   "It would be nice to welcome new people.",
+				User:   github.User{Login: "author"},
+			},
+		},
+		{
+			name: "a high threshold suppresses weak matches",
+			issue: github.Issue{
+				Number: 10,
+				Title:  "Tide and optional contexts",
+				Body:   "Something about deck.",
+				User:   github.User{Login: "author"},
+			},
+			opts: plugins.Dedup{Threshold: 0.95},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			for i := range existingIssues {
+				issue := existingIssues[i]
+				fc.Issues[issue.Number] = &issue
+			}
+			newIssue := tc.issue
+			fc.Issues[newIssue.Number] = &newIssue
+			fc.PullRequests = map[int]*github.PullRequest{11: {Number: 11, Title: tc.issue.Title}}
+
+			ie := github.IssueEvent{
+				Action: github.IssueActionOpened,
+				Issue:  tc.issue,
+				Repo:   github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+			}
+			if err := handle(fc, logrus.WithField("plugin", PluginName), &tc.opts, ie, time.Now()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			comments := fc.IssueComments[tc.issue.Number]
+			if len(comments) != tc.expectedComments {
+				t.Fatalf("expected %d comments, got %v", tc.expectedComments, comments)
+			}
+			if tc.expectedComments == 0 {
+				return
+			}
+			body := comments[0].Body
+			for _, s := range append(tc.expectedNumbers, "@author: ", "/close duplicate-of #") {
+				if !strings.Contains(body, s) {
+					t.Errorf("expected comment to contain %q, got %s", s, body)
+				}
+			}
+			for _, s := range tc.expectedNoMatch {
+				if strings.Contains(body, s+":") {
+					t.Errorf("expected comment not to suggest %s, got %s", s, body)
+				}
+			}
+		})
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	issue := github.Issue{Number: 10, Title: "flaky test in pkg/foo", Body: "TestFoo is flaky"}
+	candidates := []github.Issue{
+		{Number: 1, Title: "flaky test in pkg/foo", Body: "TestFoo is flaky"},
+		{Number: 2, Title: "flaky test in pkg/foo", Body: "TestFoo is flaky"},
+		{Number: 3, Title: "flaky test in pkg/bar", Body: "TestBar is flaky"},
+		{Number: 4, Title: "unrelated", Body: "nothing in common"},
+	}
+	var numbers []int
+	for _, s := range findDuplicates(issue, candidates, 0.3, 2) {
+		numbers = append(numbers, s.issue.Number)
+	}
+	// Identical issues rank first, the most recent one before the others.
+	if diff := cmp.Diff([]int{2, 1}, numbers); diff != "" {
+		t.Errorf("unexpected suggestions (-want +got):\n%s", diff)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := tokenize("The Prow-job `ci-foo` FAILED 3 times with exit code 137 on v1.2!")
+	expected := []string{"prow", "job", "foo", "failed", "times", "exit", "code"}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected words (-want +got):\n%s", diff)
+	}
+}
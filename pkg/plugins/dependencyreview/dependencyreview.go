@@ -0,0 +1,395 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dependencyreview summarizes the changes PRs make to the Go
+// dependencies of a repo and holds them with the
+// `do-not-merge/needs-dependency-review` label until a reviewer adds the
+// `dependencies-approved` label.
+package dependencyreview
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	// PluginName defines this plugin's registered name.
+	PluginName = "dependency-review"
+
+	summaryMarker = "This PR changes the Go dependencies of this repository"
+	unknown       = "unknown"
+)
+
+func init() {
+	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		opts := optionsForRepo(config, repo.Org, repo.Repo)
+		if opts.ReviewTeam == "" {
+			configInfo[repo.String()] = fmt.Sprintf("Anyone who can label PRs may add the `%s` label.", labels.DependenciesApproved)
+		} else {
+			configInfo[repo.String()] = fmt.Sprintf("Only members of the %s team may add the `%s` label.", opts.ReviewTeam, labels.DependenciesApproved)
+		}
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		DependencyReview: []plugins.DependencyReview{
+			{
+				Repos:           []string{"kubernetes/test-infra"},
+				ReviewTeam:      "dep-approvers",
+				LicenseDatabase: "/etc/licenses/licenses.yaml",
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: fmt.Sprintf("The dependency-review plugin comments a summary of the changes PRs make to the requirements of `go.mod` files, "+
+			"including version jumps and license changes, and labels PRs changing `go.mod` or `go.sum` files with the `%s` label until the `%s` label is added. "+
+			"Pushes changing them again remove the `%s` label.",
+			labels.NeedsDependencyReview, labels.DependenciesApproved, labels.DependenciesApproved),
+		Config:  configInfo,
+		Snippet: yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+	CreateComment(org, repo string, number int, comment string) error
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	CompareCommits(org, repo, base, head string) ([]github.CommitFile, error)
+	GetFile(org, repo, filepath, commit string) ([]byte, error)
+	ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error)
+}
+
+type commentPruner interface {
+	PruneComments(shouldPrune func(github.IssueComment) bool)
+}
+
+func handlePullRequest(pc plugins.Agent, pre github.PullRequestEvent) error {
+	if pre.PullRequest.State != github.PullRequestStateOpen {
+		return nil
+	}
+	cp, err := pc.CommentPruner()
+	if err != nil {
+		return err
+	}
+	return handle(pc.GitHubClient, pc.Logger, cp, optionsForRepo(pc.PluginConfig, pre.Repo.Owner.Login, pre.Repo.Name), pre)
+}
+
+func isDependencyFile(filename string) bool {
+	base := path.Base(filename)
+	return base == "go.mod" || base == "go.sum"
+}
+
+func handle(gc githubClient, log *logrus.Entry, cp commentPruner, opts *plugins.DependencyReview, pre github.PullRequestEvent) error {
+	org, repo, number := pre.Repo.Owner.Login, pre.Repo.Name, pre.Number
+
+	switch pre.Action {
+	case github.PullRequestActionOpened, github.PullRequestActionReopened:
+		return review(gc, log, cp, opts, pre, false)
+	case github.PullRequestActionSynchronize:
+		files, err := gc.CompareCommits(org, repo, pre.Before, pre.After)
+		if err != nil {
+			log.WithError(err).Warn("Failed to compare the commits of the push, reviewing the PR.")
+			return review(gc, log, cp, opts, pre, true)
+		}
+		for _, file := range files {
+			if isDependencyFile(file.Filename) || isDependencyFile(file.PreviousFilename) {
+				return review(gc, log, cp, opts, pre, true)
+			}
+		}
+		return nil
+	case github.PullRequestActionLabeled:
+		if pre.Label.Name != labels.DependenciesApproved {
+			return nil
+		}
+		if opts.ReviewTeam != "" {
+			members, err := gc.ListTeamMembersBySlug(org, opts.ReviewTeam, github.RoleAll)
+			if err != nil {
+				return fmt.Errorf("failed to list the members of the %s team: %w", opts.ReviewTeam, err)
+			}
+			allowed := false
+			for _, member := range members {
+				if github.NormLogin(member.Login) == github.NormLogin(pre.Sender.Login) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				if err := gc.RemoveLabel(org, repo, number, labels.DependenciesApproved); err != nil {
+					log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.DependenciesApproved)
+				}
+				msg := fmt.Sprintf("@%s: only members of the @%s/%s team may add the `%s` label.", pre.Sender.Login, org, opts.ReviewTeam, labels.DependenciesApproved)
+				return gc.CreateComment(org, repo, number, plugins.FormatSimpleResponse(msg))
+			}
+		}
+		issueLabels, err := gc.GetIssueLabels(org, repo, number)
+		if err != nil {
+			return fmt.Errorf("failed to get the labels of %s/%s#%d: %w", org, repo, number, err)
+		}
+		if github.HasLabel(labels.NeedsDependencyReview, issueLabels) {
+			if err := gc.RemoveLabel(org, repo, number, labels.NeedsDependencyReview); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.NeedsDependencyReview)
+			}
+		}
+	case github.PullRequestActionUnlabeled:
+		if pre.Label.Name != labels.DependenciesApproved {
+			return nil
+		}
+		changes, err := gc.GetPullRequestChanges(org, repo, number)
+		if err != nil {
+			return fmt.Errorf("failed to get the changes of %s/%s#%d: %w", org, repo, number, err)
+		}
+		for _, change := range changes {
+			if isDependencyFile(change.Filename) {
+				return ensureLabel(gc, org, repo, number, labels.NeedsDependencyReview)
+			}
+		}
+	}
+	return nil
+}
+
+func ensureLabel(gc githubClient, org, repo string, number int, label string) error {
+	issueLabels, err := gc.GetIssueLabels(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels of %s/%s#%d: %w", org, repo, number, err)
+	}
+	if github.HasLabel(label, issueLabels) {
+		return nil
+	}
+	return gc.AddLabel(org, repo, number, label)
+}
+
+// review summarizes the dependency changes of the PR and labels it for
+// review if it changes dependency files. If the dependency changes were
+// pushed to the PR, an earlier approval is revoked.
+func review(gc githubClient, log *logrus.Entry, cp commentPruner, opts *plugins.DependencyReview, pre github.PullRequestEvent, pushed bool) error {
+	org, repo, number := pre.Repo.Owner.Login, pre.Repo.Name, pre.Number
+
+	changes, err := gc.GetPullRequestChanges(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get the changes of %s/%s#%d: %w", org, repo, number, err)
+	}
+	var goMods []github.PullRequestChange
+	changesDependencies := false
+	for _, change := range changes {
+		if !isDependencyFile(change.Filename) {
+			continue
+		}
+		changesDependencies = true
+		if path.Base(change.Filename) == "go.mod" {
+			goMods = append(goMods, change)
+		}
+	}
+
+	issueLabels, err := gc.GetIssueLabels(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels of %s/%s#%d: %w", org, repo, number, err)
+	}
+	needsReview := github.HasLabel(labels.NeedsDependencyReview, issueLabels)
+	approved := github.HasLabel(labels.DependenciesApproved, issueLabels)
+
+	cp.PruneComments(func(comment github.IssueComment) bool {
+		return strings.Contains(comment.Body, summaryMarker)
+	})
+	if !changesDependencies {
+		if needsReview {
+			if err := gc.RemoveLabel(org, repo, number, labels.NeedsDependencyReview); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.NeedsDependencyReview)
+			}
+		}
+		return nil
+	}
+
+	if pushed && approved {
+		if err := gc.RemoveLabel(org, repo, number, labels.DependenciesApproved); err != nil {
+			log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.DependenciesApproved)
+		}
+		approved = false
+	}
+	if !approved && !needsReview {
+		if err := gc.AddLabel(org, repo, number, labels.NeedsDependencyReview); err != nil {
+			log.WithError(err).Errorf("GitHub failed to add the following label: %s", labels.NeedsDependencyReview)
+		}
+	}
+
+	licenses, err := loadLicenseDatabase(opts.LicenseDatabase)
+	if err != nil {
+		log.WithError(err).Warn("Failed to load the license database.")
+	}
+	var summaries []string
+	for _, goMod := range goMods {
+		summary, err := summarize(gc, org, repo, pre.PullRequest, goMod, licenses)
+		if err != nil {
+			log.WithError(err).Warnf("Failed to summarize the changes to %s.", goMod.Filename)
+			summary = fmt.Sprintf("#### `%s`\n\nThe changes could not be summarized: %v", goMod.Filename, err)
+		}
+		summaries = append(summaries, summary)
+	}
+	if len(summaries) == 0 {
+		summaries = append(summaries, "No requirement of a `go.mod` file changed.")
+	}
+	reviewer := "A reviewer"
+	if opts.ReviewTeam != "" {
+		reviewer = fmt.Sprintf("A member of the @%s/%s team", org, opts.ReviewTeam)
+	}
+	msg := fmt.Sprintf("%s:\n\n%s\n\n%s must add the `%s` label before it can merge.",
+		summaryMarker, strings.Join(summaries, "\n\n"), reviewer, labels.DependenciesApproved)
+	return gc.CreateComment(org, repo, number, plugins.FormatSimpleResponse(msg))
+}
+
+func loadLicenseDatabase(path string) (map[string]string, error) {
+	licenses := map[string]string{}
+	if path == "" {
+		return licenses, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return licenses, err
+	}
+	if err := yaml.Unmarshal(raw, &licenses); err != nil {
+		return licenses, err
+	}
+	return licenses, nil
+}
+
+func license(licenses map[string]string, mod, version string) string {
+	if l, ok := licenses[mod+"@"+version]; ok {
+		return l
+	}
+	if l, ok := licenses[mod]; ok {
+		return l
+	}
+	return unknown
+}
+
+// requirements returns the versions of the modules a go.mod file requires.
+func requirements(gc githubClient, org, repo, filename, ref string) (map[string]string, error) {
+	raw, err := gc.GetFile(org, repo, filename, ref)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.ParseLax(filename, raw, nil)
+	if err != nil {
+		return nil, err
+	}
+	versions := map[string]string{}
+	for _, r := range f.Require {
+		versions[r.Mod.Path] = r.Mod.Version
+	}
+	return versions, nil
+}
+
+// summarize returns a markdown table of the changes to the requirements of
+// a go.mod file.
+func summarize(gc githubClient, org, repo string, pr github.PullRequest, goMod github.PullRequestChange, licenses map[string]string) (string, error) {
+	before, after := map[string]string{}, map[string]string{}
+	var err error
+	if goMod.Status != github.PullRequestFileAdded {
+		previous := goMod.Filename
+		if goMod.PreviousFilename != "" {
+			previous = goMod.PreviousFilename
+		}
+		if before, err = requirements(gc, org, repo, previous, pr.Base.SHA); err != nil {
+			return "", err
+		}
+	}
+	if goMod.Status != github.PullRequestFileRemoved {
+		if after, err = requirements(gc, org, repo, goMod.Filename, pr.Head.SHA); err != nil {
+			return "", err
+		}
+	}
+
+	var rows []string
+	for _, mod := range sets.List(sets.KeySet(before).Union(sets.KeySet(after))) {
+		oldVersion, hadMod := before[mod]
+		newVersion, hasMod := after[mod]
+		var change, versions, licenseInfo string
+		switch {
+		case !hadMod:
+			change, versions, licenseInfo = "added", newVersion, license(licenses, mod, newVersion)
+		case !hasMod:
+			change, versions, licenseInfo = "removed", oldVersion, license(licenses, mod, oldVersion)
+		case oldVersion == newVersion:
+			continue
+		default:
+			change = "upgraded"
+			if semver.Compare(newVersion, oldVersion) < 0 {
+				change = "downgraded"
+			}
+			switch {
+			case semver.Major(newVersion) != semver.Major(oldVersion):
+				change += " (major)"
+			case semver.MajorMinor(newVersion) != semver.MajorMinor(oldVersion):
+				change += " (minor)"
+			}
+			versions = fmt.Sprintf("%s → %s", oldVersion, newVersion)
+			licenseInfo = license(licenses, mod, newVersion)
+			if oldLicense := license(licenses, mod, oldVersion); oldLicense != licenseInfo {
+				licenseInfo = fmt.Sprintf("**%s → %s**", oldLicense, licenseInfo)
+			}
+		}
+		rows = append(rows, fmt.Sprintf("| `%s` | %s | %s | %s |", mod, change, versions, licenseInfo))
+	}
+	if len(rows) == 0 {
+		return fmt.Sprintf("#### `%s`\n\nNo requirement changed.", goMod.Filename), nil
+	}
+	return fmt.Sprintf("#### `%s`\n\n| Module | Change | Version | License |\n| --- | --- | --- | --- |\n%s", goMod.Filename, strings.Join(rows, "\n")), nil
+}
+
+// optionsForRepo gets the plugins.DependencyReview struct that is applicable to the indicated repo.
+func optionsForRepo(config *plugins.Configuration, org, repo string) *plugins.DependencyReview {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+
+	// First search for repo config
+	for _, c := range config.DependencyReview {
+		if !sets.New[string](c.Repos...).Has(fullName) {
+			continue
+		}
+		return &c
+	}
+
+	// If you don't find anything, loop again looking for an org config
+	for _, c := range config.DependencyReview {
+		if !sets.New[string](c.Repos...).Has(org) {
+			continue
+		}
+		return &c
+	}
+
+	// Return an empty config, which lets anyone approve dependency changes
+	return &plugins.DependencyReview{}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependencyreview
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	baseGoMod = `module example.com/repo
+
+go 1.21
+
+require (
+	example.com/kept v1.0.0
+	example.com/removed v1.2.0
+	example.com/patched v1.0.0
+	example.com/zero v0.1.0
+)
+`
+	headGoMod = `module example.com/repo
+
+go 1.21
+
+require (
+	example.com/added v0.3.0
+	example.com/kept v1.0.0
+	example.com/patched v1.0.1
+	example.com/zero v0.2.0 // indirect
+)
+`
+)
+
+type fakePruner struct {
+	pruned bool
+}
+
+func (fp *fakePruner) PruneComments(shouldPrune func(github.IssueComment) bool) {
+	fp.pruned = true
+}
+
+func TestHandle(t *testing.T) {
+	label := func(l string) string { return "org/repo#1:" + l }
+	licenseDatabase := filepath.Join(t.TempDir(), "licenses.yaml")
+	if err := os.WriteFile(licenseDatabase, []byte("example.com/patched: Apache-2.0\n"), 0644); err != nil {
+		t.Fatalf("failed to write the license database: %v", err)
+	}
+	goModChange := []github.PullRequestChange{{Filename: "go.mod", Status: "modified"}, {Filename: "main.go"}}
+	var testcases = []struct {
+		name    string
+		action  github.PullRequestEventAction
+		label   string
+		sender  string
+		changes []github.PullRequestChange
+		pushed  []github.CommitFile
+		labels  []string
+
+		expectAdded   []string
+		expectRemoved []string
+		expectComment string
+		expectPruned  bool
+	}{
+		{
+			name:          "opened PR changing go.mod",
+			action:        github.PullRequestActionOpened,
+			changes:       goModChange,
+			expectAdded:   []string{label(labels.NeedsDependencyReview)},
+			expectComment: "| `example.com/patched` | upgraded | v1.0.0 → v1.0.1 | Apache-2.0 |",
+			expectPruned:  true,
+		},
+		{
+			name:          "opened PR only changing go.sum",
+			action:        github.PullRequestActionOpened,
+			changes:       []github.PullRequestChange{{Filename: "hack/tools/go.sum", Status: "modified"}},
+			expectAdded:   []string{label(labels.NeedsDependencyReview)},
+			expectComment: "No requirement of a `go.mod` file changed.",
+			expectPruned:  true,
+		},
+		{
+			name:         "opened PR not changing dependencies",
+			action:       github.PullRequestActionOpened,
+			changes:      []github.PullRequestChange{{Filename: "main.go"}},
+			expectPruned: true,
+		},
+		{
+			name:          "reviewed PR no longer changing dependencies",
+			action:        github.PullRequestActionReopened,
+			changes:       []github.PullRequestChange{{Filename: "main.go"}},
+			labels:        []string{labels.NeedsDependencyReview},
+			expectRemoved: []string{label(labels.NeedsDependencyReview)},
+			expectPruned:  true,
+		},
+		{
+			name:    "push not changing dependencies is ignored",
+			action:  github.PullRequestActionSynchronize,
+			changes: goModChange,
+			pushed:  []github.CommitFile{{Filename: "main.go"}},
+			labels:  []string{labels.DependenciesApproved},
+		},
+		{
+			name:          "push changing dependencies revokes the approval",
+			action:        github.PullRequestActionSynchronize,
+			changes:       goModChange,
+			pushed:        []github.CommitFile{{Filename: "go.mod"}},
+			labels:        []string{labels.DependenciesApproved},
+			expectAdded:   []string{label(labels.NeedsDependencyReview)},
+			expectRemoved: []string{label(labels.DependenciesApproved)},
+			expectComment: "| `example.com/added` | added | v0.3.0 | unknown |",
+			expectPruned:  true,
+		},
+		{
+			name:          "approval by a member of the review team",
+			action:        github.PullRequestActionLabeled,
+			label:         labels.DependenciesApproved,
+			sender:        "sig-lead",
+			labels:        []string{labels.NeedsDependencyReview, labels.DependenciesApproved},
+			expectRemoved: []string{label(labels.NeedsDependencyReview)},
+		},
+		{
+			name:          "approval by someone outside of the review team is reverted",
+			action:        github.PullRequestActionLabeled,
+			label:         labels.DependenciesApproved,
+			sender:        "someone",
+			labels:        []string{labels.NeedsDependencyReview, labels.DependenciesApproved},
+			expectRemoved: []string{label(labels.DependenciesApproved)},
+			expectComment: "@someone: only members of the @org/leads team may add the `dependencies-approved` label.",
+		},
+		{
+			name:        "removal of the approval",
+			action:      github.PullRequestActionUnlabeled,
+			label:       labels.DependenciesApproved,
+			changes:     goModChange,
+			expectAdded: []string{label(labels.NeedsDependencyReview)},
+		},
+		{
+			name:   "other labels are ignored",
+			action: github.PullRequestActionLabeled,
+			label:  labels.LGTM,
+			sender: "someone",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			fc.PullRequestChanges = map[int][]github.PullRequestChange{1: tc.changes}
+			fc.Comparisons = map[string][]github.CommitFile{"before...after": tc.pushed}
+			fc.RemoteFiles = map[string]map[string]string{"go.mod": {"base": baseGoMod, "head": headGoMod}}
+			for _, l := range tc.labels {
+				fc.IssueLabelsExisting = append(fc.IssueLabelsExisting, label(l))
+			}
+			fp := &fakePruner{}
+			pre := github.PullRequestEvent{
+				Action: tc.action,
+				Number: 1,
+				Before: "before",
+				After:  "after",
+				Label:  github.Label{Name: tc.label},
+				Sender: github.User{Login: tc.sender},
+				Repo:   github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				PullRequest: github.PullRequest{
+					Number: 1,
+					State:  github.PullRequestStateOpen,
+					Base:   github.PullRequestBranch{SHA: "base"},
+					Head:   github.PullRequestBranch{SHA: "head"},
+				},
+			}
+			opts := &plugins.DependencyReview{ReviewTeam: "leads", LicenseDatabase: licenseDatabase}
+
+			if err := handle(fc, logrus.WithField("plugin", PluginName), fp, opts, pre); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !sets.New[string](fc.IssueLabelsAdded...).Equal(sets.New[string](tc.expectAdded...)) {
+				t.Errorf("expected labels %v to be added, got %v", tc.expectAdded, fc.IssueLabelsAdded)
+			}
+			if !sets.New[string](fc.IssueLabelsRemoved...).Equal(sets.New[string](tc.expectRemoved...)) {
+				t.Errorf("expected labels %v to be removed, got %v", tc.expectRemoved, fc.IssueLabelsRemoved)
+			}
+			comments := fc.IssueComments[1]
+			if tc.expectComment == "" && len(comments) > 0 {
+				t.Errorf("expected no comment, got %v", comments)
+			}
+			if tc.expectComment != "" && (len(comments) != 1 || !strings.Contains(comments[0].Body, tc.expectComment)) {
+				t.Errorf("expected a comment containing %q, got %v", tc.expectComment, comments)
+			}
+			if fp.pruned != tc.expectPruned {
+				t.Errorf("expected comments to be pruned: %t, got: %t", tc.expectPruned, fp.pruned)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	licenses := map[string]string{
+		"example.com/patched":     "Apache-2.0",
+		"example.com/removed":     "MIT",
+		"example.com/zero":        "MIT",
+		"example.com/zero@v0.2.0": "GPL-3.0",
+	}
+	var testcases = []struct {
+		name     string
+		change   github.PullRequestChange
+		expected string
+	}{
+		{
+			name:   "modified go.mod",
+			change: github.PullRequestChange{Filename: "go.mod", Status: "modified"},
+			expected: "#### `go.mod`\n\n| Module | Change | Version | License |\n| --- | --- | --- | --- |\n" +
+				"| `example.com/added` | added | v0.3.0 | unknown |\n" +
+				"| `example.com/patched` | upgraded | v1.0.0 → v1.0.1 | Apache-2.0 |\n" +
+				"| `example.com/removed` | removed | v1.2.0 | MIT |\n" +
+				"| `example.com/zero` | upgraded (minor) | v0.1.0 → v0.2.0 | **MIT → GPL-3.0** |",
+		},
+		{
+			name:   "removed go.mod",
+			change: github.PullRequestChange{Filename: "go.mod", Status: github.PullRequestFileRemoved},
+			expected: "#### `go.mod`\n\n| Module | Change | Version | License |\n| --- | --- | --- | --- |\n" +
+				"| `example.com/kept` | removed | v1.0.0 | unknown |\n" +
+				"| `example.com/patched` | removed | v1.0.0 | Apache-2.0 |\n" +
+				"| `example.com/removed` | removed | v1.2.0 | MIT |\n" +
+				"| `example.com/zero` | removed | v0.1.0 | MIT |",
+		},
+		{
+			name:     "go.mod without requirement changes",
+			change:   github.PullRequestChange{Filename: "same/go.mod", Status: "modified"},
+			expected: "#### `same/go.mod`\n\nNo requirement changed.",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			fc.RemoteFiles = map[string]map[string]string{
+				"go.mod":      {"base": baseGoMod, "head": headGoMod},
+				"same/go.mod": {"base": baseGoMod, "head": baseGoMod},
+			}
+			pr := github.PullRequest{Base: github.PullRequestBranch{SHA: "base"}, Head: github.PullRequestBranch{SHA: "head"}}
+			actual, err := summarize(fc, "org", "repo", pr, tc.change, licenses)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected summary:\n%s\ngot:\n%s", tc.expected, actual)
+			}
+		})
+	}
+}
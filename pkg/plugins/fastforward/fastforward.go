@@ -0,0 +1,264 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fastforward lets release managers fast-forward a release branch to
+// a commit of the default branch once its required postsubmits passed.
+package fastforward
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+// PluginName defines this plugin's registered name.
+const PluginName = "fast-forward"
+
+var (
+	fastForwardRe = regexp.MustCompile(`(?mi)^/fast-forward(?:[ \t]+(.*?))?\s*$`)
+	shaRe         = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+func init() {
+	plugins.RegisterGenericCommentHandler(PluginName, handleGenericComment, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		opts := optionsForRepo(config, repo.Org, repo.Repo)
+		if opts == nil {
+			configInfo[repo.String()] = "No release managers are configured, so no branch can be fast-forwarded."
+			continue
+		}
+		postsubmits := "the postsubmits that always run on the default branch"
+		if len(opts.RequiredPostsubmits) > 0 {
+			postsubmits = strings.Join(opts.RequiredPostsubmits, ", ")
+		}
+		configInfo[repo.String()] = fmt.Sprintf("Members of the %s team can fast-forward branches matching `%s` once %s passed.", opts.ReleaseManagers, opts.BranchRegexp, postsubmits)
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		FastForward: []plugins.FastForward{
+			{
+				Repos:               []string{"kubernetes/kubernetes"},
+				ReleaseManagers:     "release-managers",
+				BranchRegexp:        "^release-.*$",
+				RequiredPostsubmits: []string{"ci-kubernetes-build", "ci-kubernetes-unit"},
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	pluginHelp := &pluginhelp.PluginHelp{
+		Description: "The fast-forward plugin fast-forwards a release branch to a commit of the default branch, once the required postsubmits passed on that commit.",
+		Config:      configInfo,
+		Snippet:     yamlSnippet,
+	}
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/fast-forward <branch> <sha>",
+		Description: "Fast-forwards the branch to the given commit of the default branch. The full SHA of the commit is required.",
+		Featured:    false,
+		WhoCanUse:   "Members of the release managers GitHub team.",
+		Examples:    []string{"/fast-forward release-1.30 3b18e512dba79e4c8300dd08aeb37f8e728b8dad"},
+	})
+	return pluginHelp, nil
+}
+
+type githubClient interface {
+	BotUserChecker() (func(candidate string) bool, error)
+	CreateComment(org, repo string, number int, comment string) error
+	TeamBySlugHasMember(org string, teamSlug string, memberLogin string) (bool, error)
+	GetRef(org, repo, ref string) (string, error)
+	UpdateRef(org, repo, ref, sha string, force bool) error
+}
+
+type prowJobLister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*prowapi.ProwJobList, error)
+}
+
+func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) error {
+	org, repo := e.Repo.Owner.Login, e.Repo.Name
+	return handle(pc.GitHubClient, pc.ProwJobClient, pc.Logger, optionsForRepo(pc.PluginConfig, org, repo), pc.Config.GetPostsubmitsStatic(org+"/"+repo), &e)
+}
+
+func handle(gc githubClient, pjl prowJobLister, log *logrus.Entry, opts *plugins.FastForward, postsubmits []config.Postsubmit, e *github.GenericCommentEvent) error {
+	if e.Action != github.GenericCommentActionCreated {
+		return nil
+	}
+	matches := fastForwardRe.FindStringSubmatch(e.Body)
+	if matches == nil {
+		return nil
+	}
+	botUserChecker, err := gc.BotUserChecker()
+	if err != nil {
+		return err
+	}
+	if botUserChecker(e.User.Login) {
+		return nil
+	}
+
+	org, repo := e.Repo.Owner.Login, e.Repo.Name
+	respond := func(msg string) error {
+		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
+	}
+
+	args := strings.Fields(matches[1])
+	if len(args) != 2 || !shaRe.MatchString(args[1]) {
+		return respond("Usage: `/fast-forward <branch> <sha>`, with the full SHA of a commit of the default branch.")
+	}
+	branch, sha := args[0], args[1]
+
+	if opts == nil {
+		return respond(fmt.Sprintf("Fast-forwarding is not configured for %s/%s.", org, repo))
+	}
+	isReleaseManager, err := gc.TeamBySlugHasMember(org, opts.ReleaseManagers, e.User.Login)
+	if err != nil {
+		return fmt.Errorf("failed to check whether %s is a member of the %s team: %w", e.User.Login, opts.ReleaseManagers, err)
+	}
+	if !isReleaseManager {
+		return respond(fmt.Sprintf("Only members of the @%s/%s team can fast-forward branches.", org, opts.ReleaseManagers))
+	}
+	if branch == e.Repo.DefaultBranch || opts.BranchRe == nil || !opts.BranchRe.MatchString(branch) {
+		return respond(fmt.Sprintf("The `%s` branch cannot be fast-forwarded: only branches matching `%s` other than the default branch can.", branch, opts.BranchRegexp))
+	}
+
+	ref := "heads/" + branch
+	current, err := gc.GetRef(org, repo, ref)
+	if err != nil {
+		return respond(fmt.Sprintf("Failed to get the head of the `%s` branch: %v", branch, err))
+	}
+	if current == sha {
+		return respond(fmt.Sprintf("The `%s` branch is already at %s.", branch, sha))
+	}
+
+	problems, err := postsubmitProblems(pjl, opts, postsubmits, org, repo, e.Repo.DefaultBranch, sha)
+	if err != nil {
+		return fmt.Errorf("failed to check the postsubmits of %s/%s@%s: %w", org, repo, sha, err)
+	}
+	if len(problems) > 0 {
+		return respond(fmt.Sprintf("The `%s` branch was not fast-forwarded to %s, as the required postsubmits did not all pass on it:\n- %s", branch, sha, strings.Join(problems, "\n- ")))
+	}
+
+	// Not forcing the update makes GitHub reject it unless the commit
+	// descends from the head of the branch.
+	if err := gc.UpdateRef(org, repo, ref, sha, false); err != nil {
+		log.WithError(err).Infof("Failed to fast-forward %s/%s %s to %s.", org, repo, branch, sha)
+		return respond(fmt.Sprintf("Failed to fast-forward the `%s` branch from %s to %s, which must descend from it: %v", branch, current, sha, err))
+	}
+	log.Infof("Fast-forwarded %s/%s %s from %s to %s.", org, repo, branch, current, sha)
+	return respond(fmt.Sprintf("Fast-forwarded the `%s` branch from %s to %s.", branch, current, sha))
+}
+
+// requiredPostsubmits returns the names of the postsubmits that must pass
+// before a branch is fast-forwarded: the configured ones, or else the ones
+// that always run on the default branch.
+func requiredPostsubmits(opts *plugins.FastForward, postsubmits []config.Postsubmit, defaultBranch string) []string {
+	if len(opts.RequiredPostsubmits) > 0 {
+		return opts.RequiredPostsubmits
+	}
+	required := sets.New[string]()
+	for _, ps := range postsubmits {
+		if !ps.CouldRun(defaultBranch) || ps.RunIfChanged != "" || ps.SkipIfOnlyChanged != "" {
+			continue
+		}
+		if ps.AlwaysRun != nil && !*ps.AlwaysRun {
+			continue
+		}
+		required.Insert(ps.Name)
+	}
+	return sets.List(required)
+}
+
+// postsubmitProblems describes the required postsubmits that did not pass on
+// the commit of the default branch, if any.
+func postsubmitProblems(pjl prowJobLister, opts *plugins.FastForward, postsubmits []config.Postsubmit, org, repo, defaultBranch, sha string) ([]string, error) {
+	required := requiredPostsubmits(opts, postsubmits, defaultBranch)
+	if len(required) == 0 {
+		return nil, nil
+	}
+	selector := klabels.SelectorFromSet(klabels.Set{
+		kube.OrgLabel:         org,
+		kube.RepoLabel:        repo,
+		kube.ProwJobTypeLabel: string(prowapi.PostsubmitJob),
+	})
+	jobs, err := pjl.List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	states := map[string]sets.Set[prowapi.ProwJobState]{}
+	for _, job := range jobs.Items {
+		if job.Spec.Refs == nil || job.Spec.Refs.BaseRef != defaultBranch || job.Spec.Refs.BaseSHA != sha {
+			continue
+		}
+		if states[job.Spec.Job] == nil {
+			states[job.Spec.Job] = sets.New[prowapi.ProwJobState]()
+		}
+		states[job.Spec.Job].Insert(job.Status.State)
+	}
+
+	var problems []string
+	for _, name := range required {
+		jobStates := states[name]
+		switch {
+		case jobStates.Has(prowapi.SuccessState):
+		case len(jobStates) == 0:
+			problems = append(problems, fmt.Sprintf("`%s` did not run on %s", name, sha))
+		case jobStates.HasAny(prowapi.SchedulingState, prowapi.TriggeredState, prowapi.PendingState):
+			problems = append(problems, fmt.Sprintf("`%s` is still running on %s", name, sha))
+		default:
+			problems = append(problems, fmt.Sprintf("`%s` failed on %s", name, sha))
+		}
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+func optionsForRepo(config *plugins.Configuration, org, repo string) *plugins.FastForward {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+
+	// First search for repo config
+	for _, c := range config.FastForward {
+		if !sets.New[string](c.Repos...).Has(fullName) {
+			continue
+		}
+		return &c
+	}
+
+	// If you don't find anything, loop again looking for an org config
+	for _, c := range config.FastForward {
+		if !sets.New[string](c.Repos...).Has(org) {
+			continue
+		}
+		return &c
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fastforward
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	sha      = "3b18e512dba79e4c8300dd08aeb37f8e728b8dad"
+	otherSHA = "0123456789012345678901234567890123456789"
+)
+
+func postsubmitJob(name, baseRef, baseSHA string, state prowapi.ProwJobState) runtime.Object {
+	return &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-" + string(state) + "-" + baseSHA[:7],
+			Namespace: "prowjobs",
+			Labels: map[string]string{
+				kube.OrgLabel:         "org",
+				kube.RepoLabel:        "repo",
+				kube.ProwJobTypeLabel: string(prowapi.PostsubmitJob),
+			},
+		},
+		Spec: prowapi.ProwJobSpec{
+			Type: prowapi.PostsubmitJob,
+			Job:  name,
+			Refs: &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: baseRef, BaseSHA: baseSHA},
+		},
+		Status: prowapi.ProwJobStatus{State: state},
+	}
+}
+
+func TestHandle(t *testing.T) {
+	alwaysRun := false
+	postsubmits := []config.Postsubmit{
+		{JobBase: config.JobBase{Name: "build"}},
+		{JobBase: config.JobBase{Name: "unit"}},
+		{JobBase: config.JobBase{Name: "docs"}, RegexpChangeMatcher: config.RegexpChangeMatcher{RunIfChanged: "^docs/"}},
+		{JobBase: config.JobBase{Name: "manual"}, AlwaysRun: &alwaysRun},
+		{JobBase: config.JobBase{Name: "release-only"}, Brancher: config.Brancher{Branches: []string{"^release-"}}},
+	}
+	if err := config.SetPostsubmitRegexes(postsubmits); err != nil {
+		t.Fatalf("failed to compile the postsubmit regexes: %v", err)
+	}
+
+	testCases := []struct {
+		name             string
+		body             string
+		commenter        string
+		unconfigured     bool
+		required         []string
+		jobs             []runtime.Object
+		updateErr        error
+		expectedUpdates  map[string]string
+		expectedComments []string
+	}{
+		{
+			name:      "green postsubmits fast-forward the branch",
+			body:      "/fast-forward release-1.30 " + sha,
+			commenter: "release-manager",
+			jobs: []runtime.Object{
+				postsubmitJob("build", "main", sha, prowapi.FailureState),
+				postsubmitJob("build", "main", sha, prowapi.SuccessState),
+				postsubmitJob("unit", "main", sha, prowapi.SuccessState),
+			},
+			expectedUpdates:  map[string]string{"org/repo/heads/release-1.30": sha},
+			expectedComments: []string{"Fast-forwarded the `release-1.30` branch from abcde to " + sha},
+		},
+		{
+			name:      "missing, running and failed postsubmits block the fast-forward",
+			body:      "/fast-forward release-1.30 " + sha,
+			commenter: "release-manager",
+			required:  []string{"build", "unit", "integration"},
+			jobs: []runtime.Object{
+				postsubmitJob("build", "main", sha, prowapi.FailureState),
+				postsubmitJob("unit", "main", sha, prowapi.PendingState),
+				postsubmitJob("integration", "main", otherSHA, prowapi.SuccessState),
+				postsubmitJob("integration", "release-1.29", sha, prowapi.SuccessState),
+			},
+			expectedComments: []string{
+				"`build` failed on " + sha,
+				"`integration` did not run on " + sha,
+				"`unit` is still running on " + sha,
+			},
+		},
+		{
+			name:             "non-members cannot fast-forward",
+			body:             "/fast-forward release-1.30 " + sha,
+			commenter:        "someone",
+			expectedComments: []string{"Only members of the @org/release-managers team can fast-forward branches."},
+		},
+		{
+			name:             "default branch cannot be fast-forwarded",
+			body:             "/fast-forward main " + sha,
+			commenter:        "release-manager",
+			expectedComments: []string{"The `main` branch cannot be fast-forwarded"},
+		},
+		{
+			name:             "branch not matching the regexp cannot be fast-forwarded",
+			body:             "/fast-forward feature " + sha,
+			commenter:        "release-manager",
+			expectedComments: []string{"The `feature` branch cannot be fast-forwarded"},
+		},
+		{
+			name:             "short SHA is rejected",
+			body:             "/fast-forward release-1.30 3b18e51",
+			commenter:        "release-manager",
+			expectedComments: []string{"Usage: `/fast-forward <branch> <sha>`"},
+		},
+		{
+			name:      "rejected update is reported",
+			body:      "/fast-forward release-1.30 " + sha,
+			commenter: "release-manager",
+			jobs: []runtime.Object{
+				postsubmitJob("build", "main", sha, prowapi.SuccessState),
+				postsubmitJob("unit", "main", sha, prowapi.SuccessState),
+			},
+			updateErr:        errors.New("Update is not a fast forward"),
+			expectedComments: []string{"Failed to fast-forward the `release-1.30` branch from abcde to " + sha + ", which must descend from it: Update is not a fast forward"},
+		},
+		{
+			name:             "unconfigured repo",
+			body:             "/fast-forward release-1.30 " + sha,
+			commenter:        "release-manager",
+			unconfigured:     true,
+			expectedComments: []string{"Fast-forwarding is not configured for org/repo."},
+		},
+		{
+			name:      "other comments are ignored",
+			body:      "Let's /fast-forward release-1.30 " + sha,
+			commenter: "release-manager",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gc := fakegithub.NewFakeClient()
+			gc.Teams = map[string]map[string]fakegithub.TeamWithMembers{
+				"org": {"release-managers": {Members: sets.New[string]("release-manager")}},
+			}
+			opts := &plugins.FastForward{
+				ReleaseManagers:     "release-managers",
+				BranchRegexp:        "^release-.*$",
+				BranchRe:            regexp.MustCompile("^release-.*$"),
+				RequiredPostsubmits: tc.required,
+			}
+			if tc.unconfigured {
+				opts = nil
+			}
+			e := &github.GenericCommentEvent{
+				Action:  github.GenericCommentActionCreated,
+				Body:    tc.body,
+				Number:  1,
+				User:    github.User{Login: tc.commenter},
+				Repo:    github.Repo{Owner: github.User{Login: "org"}, Name: "repo", DefaultBranch: "main"},
+				HTMLURL: "https://github.com/org/repo/issues/1#issuecomment-1",
+			}
+			pjl := fake.NewSimpleClientset(tc.jobs...).ProwV1().ProwJobs("prowjobs")
+			if tc.updateErr != nil {
+				gc.Error = tc.updateErr
+			}
+			if err := handle(gc, pjl, logrus.WithField("plugin", PluginName), opts, postsubmits, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedUpdates, gc.RefsUpdated); diff != "" {
+				t.Errorf("unexpected ref updates (-want +got):\n%s", diff)
+			}
+			comments := gc.IssueComments[1]
+			if len(tc.expectedComments) == 0 {
+				if len(comments) != 0 {
+					t.Errorf("expected no comment, got %v", comments)
+				}
+				return
+			}
+			if len(comments) != 1 {
+				t.Fatalf("expected one comment, got %v", comments)
+			}
+			for _, expected := range tc.expectedComments {
+				if !strings.Contains(comments[0].Body, expected) {
+					t.Errorf("expected comment to contain %q, got %q", expected, comments[0].Body)
+				}
+			}
+		})
+	}
+}
+
+func TestRequiredPostsubmits(t *testing.T) {
+	alwaysRun := true
+	postsubmits := []config.Postsubmit{
+		{JobBase: config.JobBase{Name: "build"}},
+		{JobBase: config.JobBase{Name: "explicit"}, AlwaysRun: &alwaysRun},
+		{JobBase: config.JobBase{Name: "docs"}, RegexpChangeMatcher: config.RegexpChangeMatcher{RunIfChanged: "^docs/"}},
+		{JobBase: config.JobBase{Name: "skip-docs"}, RegexpChangeMatcher: config.RegexpChangeMatcher{SkipIfOnlyChanged: "^docs/"}},
+		{JobBase: config.JobBase{Name: "release-only"}, Brancher: config.Brancher{Branches: []string{"^release-"}}},
+	}
+	if err := config.SetPostsubmitRegexes(postsubmits); err != nil {
+		t.Fatalf("failed to compile the postsubmit regexes: %v", err)
+	}
+	if diff := cmp.Diff([]string{"build", "explicit"}, requiredPostsubmits(&plugins.FastForward{}, postsubmits, "main")); diff != "" {
+		t.Errorf("unexpected required postsubmits (-want +got):\n%s", diff)
+	}
+	configured := []string{"unit"}
+	if diff := cmp.Diff(configured, requiredPostsubmits(&plugins.FastForward{RequiredPostsubmits: configured}, postsubmits, "main")); diff != "" {
+		t.Errorf("unexpected required postsubmits (-want +got):\n%s", diff)
+	}
+}
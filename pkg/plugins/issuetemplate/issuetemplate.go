@@ -0,0 +1,419 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package issuetemplate labels issues whose description does not fill in
+// the sections of the issue form or template of the repo they were created
+// from with the `needs-more-information` label.
+package issuetemplate
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	// PluginName defines this plugin's registered name.
+	PluginName = "issue-template"
+
+	templateDir    = ".github/ISSUE_TEMPLATE"
+	noResponse     = "_No response_"
+	commentMarker  = "<!-- issue-template: missing information -->"
+	defaultMessage = "@{{.AuthorLogin}}: thanks for opening this issue! Its description is missing information the {{.Template}} issue template asks for. " +
+		"Please edit it to fill in the following sections:\n{{range .Missing}}\n- {{.}}{{end}}\n\n" +
+		"The `" + labels.NeedsMoreInformation + "` label will be removed once they are filled in."
+)
+
+var (
+	headingRe = regexp.MustCompile(`^#{1,6}\s+(.*?)[\s#]*$`)
+	commentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// IssueInfo contains the info provided to the message template.
+type IssueInfo struct {
+	Org         string
+	Repo        string
+	AuthorLogin string
+	// Template is the name of the issue form or template the issue was created from.
+	Template string
+	// Missing are the headings of the sections of the description to fill in.
+	Missing []string
+}
+
+func init() {
+	plugins.RegisterIssueHandler(PluginName, handleIssue, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		opts := optionsForRepo(config, repo.Org, repo.Repo)
+		configInfo[repo.String()] = fmt.Sprintf("The issue-template plugin is configured to ask for missing information using the following template: %s.", messageForRepo(opts))
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		IssueTemplate: []plugins.IssueTemplate{
+			{
+				Repos:           []string{"kubernetes/test-infra"},
+				MessageTemplate: "@{{.AuthorLogin}}: please fill in the {{.Template}} issue template.",
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: fmt.Sprintf("The issue-template plugin checks the description of new issues against the issue forms and templates in the `%s` directory of the repo. "+
+			"Issues missing the sections of the form or template they were created from, or leaving required ones empty, are labeled with the `%s` label until their description is edited to fill them in.",
+			templateDir, labels.NeedsMoreInformation),
+		Config:  configInfo,
+		Snippet: yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	CreateComment(org, repo string, number int, comment string) error
+	GetDirectory(org, repo, dirpath, commit string) ([]github.DirectoryContent, error)
+	GetFile(org, repo, filepath, commit string) ([]byte, error)
+}
+
+type commentPruner interface {
+	PruneComments(shouldPrune func(github.IssueComment) bool)
+}
+
+func handleIssue(pc plugins.Agent, ie github.IssueEvent) error {
+	if ie.Issue.IsPullRequest() || ie.Issue.State != "open" {
+		return nil
+	}
+	switch ie.Action {
+	case github.IssueActionOpened, github.IssueActionReopened, github.IssueActionEdited:
+	default:
+		return nil
+	}
+	cp, err := pc.CommentPruner()
+	if err != nil {
+		return err
+	}
+	return handle(pc.GitHubClient, pc.Logger, cp, optionsForRepo(pc.PluginConfig, ie.Repo.Owner.Login, ie.Repo.Name), ie)
+}
+
+func handle(gc githubClient, log *logrus.Entry, cp commentPruner, opts *plugins.IssueTemplate, ie github.IssueEvent) error {
+	org, repo, number := ie.Repo.Owner.Login, ie.Repo.Name, ie.Issue.Number
+
+	templates, err := loadTemplates(gc, org, repo)
+	if err != nil {
+		// Most repos have no issue templates at all, so there is nothing
+		// to check the issue against.
+		log.WithError(err).Debug("Failed to load the issue templates.")
+		return nil
+	}
+	tmpl := matchTemplate(templates, ie.Issue.Body)
+	if tmpl == nil {
+		log.Debug("The issue was not created from an issue template.")
+		return nil
+	}
+	missing := tmpl.missing(ie.Issue.Body)
+	hasLabel := ie.Issue.HasLabel(labels.NeedsMoreInformation)
+
+	if len(missing) == 0 {
+		if hasLabel {
+			if err := gc.RemoveLabel(org, repo, number, labels.NeedsMoreInformation); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.NeedsMoreInformation)
+			}
+		}
+		cp.PruneComments(func(comment github.IssueComment) bool {
+			return strings.Contains(comment.Body, commentMarker)
+		})
+		return nil
+	}
+
+	if hasLabel {
+		return nil
+	}
+	if err := gc.AddLabel(org, repo, number, labels.NeedsMoreInformation); err != nil {
+		log.WithError(err).Errorf("GitHub failed to add the following label: %s", labels.NeedsMoreInformation)
+	}
+	parsedTemplate, err := template.New(PluginName).Parse(messageForRepo(opts))
+	if err != nil {
+		return err
+	}
+	var msgBuffer bytes.Buffer
+	if err := parsedTemplate.Execute(&msgBuffer, IssueInfo{
+		Org:         org,
+		Repo:        repo,
+		AuthorLogin: ie.Issue.User.Login,
+		Template:    tmpl.name,
+		Missing:     missing,
+	}); err != nil {
+		return err
+	}
+	return gc.CreateComment(org, repo, number, plugins.FormatSimpleResponse(msgBuffer.String()+"\n"+commentMarker))
+}
+
+// section is a section of the description of issues created from an issue
+// form or template.
+type section struct {
+	heading  string
+	required bool
+	// placeholder holds the lines of the section that do not count as
+	// filling it in.
+	placeholder sets.Set[string]
+}
+
+// issueTemplate is an issue form or template.
+type issueTemplate struct {
+	name     string
+	sections []section
+}
+
+type issueForm struct {
+	Name string `json:"name"`
+	Body []struct {
+		Type       string `json:"type"`
+		Attributes struct {
+			Label string `json:"label"`
+		} `json:"attributes"`
+		Validations struct {
+			Required bool `json:"required"`
+		} `json:"validations"`
+	} `json:"body"`
+}
+
+func loadTemplates(gc githubClient, org, repo string) ([]issueTemplate, error) {
+	contents, err := gc.GetDirectory(org, repo, templateDir, "")
+	if err != nil {
+		return nil, err
+	}
+	var templates []issueTemplate
+	for _, content := range contents {
+		ext := path.Ext(content.Name)
+		if content.Type != "file" || !sets.New[string](".md", ".yml", ".yaml").Has(ext) || strings.TrimSuffix(content.Name, ext) == "config" {
+			continue
+		}
+		raw, err := gc.GetFile(org, repo, content.Path, "")
+		if err != nil {
+			return nil, err
+		}
+		var tmpl *issueTemplate
+		if ext == ".md" {
+			tmpl, err = parseMarkdownTemplate(content.Name, raw)
+		} else {
+			tmpl, err = parseIssueForm(content.Name, raw)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the issue template %s: %w", content.Path, err)
+		}
+		templates = append(templates, *tmpl)
+	}
+	return templates, nil
+}
+
+// parseIssueForm parses an issue form. Issues created from it have a section
+// for every field, titled by its label, which reads `_No response_` when left
+// empty.
+func parseIssueForm(filename string, raw []byte) (*issueTemplate, error) {
+	var form issueForm
+	if err := yaml.Unmarshal(raw, &form); err != nil {
+		return nil, err
+	}
+	tmpl := &issueTemplate{name: form.Name}
+	if tmpl.name == "" {
+		tmpl.name = filename
+	}
+	for _, field := range form.Body {
+		switch field.Type {
+		case "textarea", "input", "dropdown", "checkboxes":
+		default:
+			continue
+		}
+		tmpl.sections = append(tmpl.sections, section{
+			heading: field.Attributes.Label,
+			// The options of checkboxes are validated one by one, which
+			// the description of the issue does not reflect.
+			required:    field.Validations.Required && field.Type != "checkboxes",
+			placeholder: sets.New[string](noResponse),
+		})
+	}
+	return tmpl, nil
+}
+
+// parseMarkdownTemplate parses a markdown issue template. All of its sections
+// are required, and filling them in means adding lines to the ones of the
+// template.
+func parseMarkdownTemplate(filename string, raw []byte) (*issueTemplate, error) {
+	body := strings.ReplaceAll(string(raw), "\r\n", "\n")
+	tmpl := &issueTemplate{name: filename}
+	if strings.HasPrefix(body, "---\n") {
+		end := strings.Index(body[len("---\n"):], "\n---")
+		if end == -1 {
+			return nil, fmt.Errorf("unterminated front matter")
+		}
+		var frontMatter struct {
+			Name string `json:"name"`
+		}
+		if err := yaml.Unmarshal([]byte(body[len("---\n"):len("---\n")+end]), &frontMatter); err != nil {
+			return nil, err
+		}
+		if frontMatter.Name != "" {
+			tmpl.name = frontMatter.Name
+		}
+		body = body[len("---\n")+end+len("\n---"):]
+	}
+	for _, s := range parseSections(body) {
+		tmpl.sections = append(tmpl.sections, section{
+			heading:     s.heading,
+			required:    true,
+			placeholder: sets.New[string](contentLines(s.content)...),
+		})
+	}
+	return tmpl, nil
+}
+
+type bodySection struct {
+	heading string
+	content string
+}
+
+// parseSections splits a markdown document into its sections, ignoring
+// anything before the first heading and headings in code blocks.
+func parseSections(body string) []bodySection {
+	var sections []bodySection
+	inCodeBlock := false
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+		}
+		if match := headingRe.FindStringSubmatch(line); match != nil && !inCodeBlock {
+			sections = append(sections, bodySection{heading: match[1]})
+			continue
+		}
+		if len(sections) > 0 {
+			sections[len(sections)-1].content += line + "\n"
+		}
+	}
+	return sections
+}
+
+// contentLines returns the non-empty lines of the content of a section
+// outside of comments.
+func contentLines(content string) []string {
+	var lines []string
+	for _, line := range strings.Split(commentRe.ReplaceAllString(content, ""), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func normalizeHeading(heading string) string {
+	return strings.ToLower(strings.TrimSpace(heading))
+}
+
+// matchTemplate returns the template sharing the most sections with the
+// description of the issue, if any.
+func matchTemplate(templates []issueTemplate, body string) *issueTemplate {
+	headings := sets.New[string]()
+	for _, s := range parseSections(body) {
+		headings.Insert(normalizeHeading(s.heading))
+	}
+	var match *issueTemplate
+	best := 0
+	for i := range templates {
+		shared := 0
+		for _, s := range templates[i].sections {
+			if headings.Has(normalizeHeading(s.heading)) {
+				shared++
+			}
+		}
+		if shared > best {
+			match, best = &templates[i], shared
+		}
+	}
+	return match
+}
+
+// missing returns the headings of the required sections of the template that
+// the description of the issue lacks or leaves empty.
+func (t *issueTemplate) missing(body string) []string {
+	contents := map[string][]string{}
+	for _, s := range parseSections(body) {
+		heading := normalizeHeading(s.heading)
+		contents[heading] = append(contents[heading], contentLines(s.content)...)
+	}
+	var missing []string
+	for _, s := range t.sections {
+		if !s.required {
+			continue
+		}
+		filled := false
+		for _, line := range contents[normalizeHeading(s.heading)] {
+			if !s.placeholder.Has(line) {
+				filled = true
+				break
+			}
+		}
+		if !filled {
+			missing = append(missing, s.heading)
+		}
+	}
+	return missing
+}
+
+func messageForRepo(opts *plugins.IssueTemplate) string {
+	if opts.MessageTemplate != "" {
+		return opts.MessageTemplate
+	}
+	return defaultMessage
+}
+
+// optionsForRepo gets the plugins.IssueTemplate struct that is applicable to the indicated repo.
+func optionsForRepo(config *plugins.Configuration, org, repo string) *plugins.IssueTemplate {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+
+	// First search for repo config
+	for _, c := range config.IssueTemplate {
+		if !sets.New[string](c.Repos...).Has(fullName) {
+			continue
+		}
+		return &c
+	}
+
+	// If you don't find anything, loop again looking for an org config
+	for _, c := range config.IssueTemplate {
+		if !sets.New[string](c.Repos...).Has(org) {
+			continue
+		}
+		return &c
+	}
+
+	// Return an empty config, and default to defaultMessage
+	return &plugins.IssueTemplate{}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuetemplate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	bugForm = `name: Bug report
+description: Report a bug
+labels: ["kind/bug"]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for reporting a bug!
+  - type: textarea
+    attributes:
+      label: What happened?
+    validations:
+      required: true
+  - type: input
+    attributes:
+      label: Version
+    validations:
+      required: true
+  - type: textarea
+    attributes:
+      label: Anything else?
+`
+	featureTemplate = `---
+name: Feature request
+about: Suggest a feature
+---
+
+<!-- Please describe the feature. -->
+
+#### What would you like to be added?
+
+#### Why is this needed?
+
+**Version**:
+`
+)
+
+type fakePruner struct {
+	pruned bool
+}
+
+func (fp *fakePruner) PruneComments(shouldPrune func(github.IssueComment) bool) {
+	fp.pruned = true
+}
+
+func TestHandle(t *testing.T) {
+	const label = "org/repo#1:" + labels.NeedsMoreInformation
+	var testcases = []struct {
+		name          string
+		noTemplates   bool
+		body          string
+		hasLabel      bool
+		message       string
+		expectLabel   bool
+		expectUnlabel bool
+		expectComment string
+		expectPruned  bool
+	}{
+		{
+			name:        "repo without issue templates",
+			noTemplates: true,
+			body:        "### What happened?\n\n_No response_",
+		},
+		{
+			name: "blank issue",
+			body: "Something is broken.",
+		},
+		{
+			name:         "complete issue form",
+			body:         "### What happened?\n\nIt broke.\n\n### Version\n\nv1.0.0\n\n### Anything else?\n\n_No response_",
+			expectPruned: true,
+		},
+		{
+			name:          "issue form leaving a required field empty",
+			body:          "### What happened?\n\nIt broke.\n\n### Version\n\n_No response_\n\n### Anything else?\n\n_No response_",
+			expectLabel:   true,
+			expectComment: "@author: thanks for opening this issue! Its description is missing information the Bug report issue template asks for. Please edit it to fill in the following sections:\n\n- Version\n\nThe `needs-more-information` label will be removed once they are filled in.",
+		},
+		{
+			name:          "issue form missing a required section",
+			body:          "### What happened?\n\nIt broke.",
+			expectLabel:   true,
+			message:       "@{{.AuthorLogin}}: please fill in {{range .Missing}}{{.}}{{end}} of the {{.Template}} template.",
+			expectComment: "@author: please fill in Version of the Bug report template.",
+		},
+		{
+			name:     "labeled issue still missing information is not commented on again",
+			body:     "### What happened?\n\nIt broke.",
+			hasLabel: true,
+		},
+		{
+			name:          "labeled issue that was completed",
+			body:          "### What happened?\n\nIt broke.\n\n### Version\n\nv1.0.0",
+			hasLabel:      true,
+			expectUnlabel: true,
+			expectPruned:  true,
+		},
+		{
+			name:          "markdown template left as is",
+			body:          "<!-- Please describe the feature. -->\n\n#### What would you like to be added?\n\n#### Why is this needed?\n\n**Version**:\n",
+			expectLabel:   true,
+			message:       "{{range .Missing}}{{.}};{{end}} {{.Template}}",
+			expectComment: "What would you like to be added?;Why is this needed?; Feature request",
+		},
+		{
+			name:         "filled in markdown template",
+			body:         "#### What would you like to be added?\nA feature.\n#### Why is this needed?\n**Version**: v1.0.0\n",
+			expectPruned: true,
+		},
+		{
+			name:          "headings in code blocks do not count",
+			body:          "#### What would you like to be added?\nA feature:\n```\n#### Why is this needed?\n```\n",
+			expectLabel:   true,
+			message:       "{{range .Missing}}{{.}};{{end}}",
+			expectComment: "Why is this needed?;",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			if !tc.noTemplates {
+				fc.RemoteDirectories = map[string]map[string][]github.DirectoryContent{
+					templateDir: {"master": {
+						{Type: "file", Name: "bug.yml", Path: templateDir + "/bug.yml"},
+						{Type: "file", Name: "config.yml", Path: templateDir + "/config.yml"},
+						{Type: "file", Name: "feature.md", Path: templateDir + "/feature.md"},
+					}},
+				}
+				fc.RemoteFiles = map[string]map[string]string{
+					templateDir + "/bug.yml":    {"master": bugForm},
+					templateDir + "/config.yml": {"master": "blank_issues_enabled: true"},
+					templateDir + "/feature.md": {"master": featureTemplate},
+				}
+			}
+			ie := github.IssueEvent{
+				Action: github.IssueActionOpened,
+				Repo:   github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				Issue: github.Issue{
+					Number: 1,
+					State:  "open",
+					Body:   tc.body,
+					User:   github.User{Login: "author"},
+				},
+			}
+			if tc.hasLabel {
+				ie.Issue.Labels = []github.Label{{Name: labels.NeedsMoreInformation}}
+			}
+			fp := &fakePruner{}
+
+			if err := handle(fc, logrus.WithField("plugin", PluginName), fp, &plugins.IssueTemplate{MessageTemplate: tc.message}, ie); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if labeled := reflect.DeepEqual(fc.IssueLabelsAdded, []string{label}); labeled != tc.expectLabel {
+				t.Errorf("expected the label to be added: %t, got labels added: %v", tc.expectLabel, fc.IssueLabelsAdded)
+			}
+			if unlabeled := reflect.DeepEqual(fc.IssueLabelsRemoved, []string{label}); unlabeled != tc.expectUnlabel {
+				t.Errorf("expected the label to be removed: %t, got labels removed: %v", tc.expectUnlabel, fc.IssueLabelsRemoved)
+			}
+			comments := fc.IssueComments[1]
+			if tc.expectComment == "" && len(comments) > 0 {
+				t.Errorf("expected no comment, got %v", comments)
+			}
+			if tc.expectComment != "" {
+				if len(comments) != 1 {
+					t.Fatalf("expected a comment, got %v", comments)
+				}
+				if !strings.HasPrefix(comments[0].Body, tc.expectComment+"\n"+commentMarker) {
+					t.Errorf("expected a comment starting with %q, got %q", tc.expectComment, comments[0].Body)
+				}
+			}
+			if fp.pruned != tc.expectPruned {
+				t.Errorf("expected comments to be pruned: %t, got: %t", tc.expectPruned, fp.pruned)
+			}
+		})
+	}
+}
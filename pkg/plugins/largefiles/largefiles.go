@@ -0,0 +1,291 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package largefiles labels PRs adding or changing files that are too large
+// or of a disallowed type with the `do-not-merge/large-files` label, unless
+// a top-level approver exempts them with the `large-files-allowed` label.
+package largefiles
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/repoowners"
+)
+
+const (
+	// PluginName defines this plugin's registered name.
+	PluginName = "large-files"
+
+	defaultMaxFileSize = 1 << 20
+	commentMarker      = "This PR adds or changes files that should not be committed to this repository"
+)
+
+func init() {
+	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		opts := optionsForRepo(config, repo.Org, repo.Repo)
+		info := fmt.Sprintf("Files must not be larger than %s.", formatSize(maxFileSize(opts)))
+		if len(opts.DisallowedExtensions) > 0 {
+			info += fmt.Sprintf(" Files with the following extensions are not allowed: %s.", strings.Join(opts.DisallowedExtensions, ", "))
+		}
+		configInfo[repo.String()] = info
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		LargeFiles: []plugins.LargeFiles{
+			{
+				Repos:                []string{"kubernetes/test-infra"},
+				MaxFileSize:          5 << 20,
+				DisallowedExtensions: []string{".exe", ".jar", ".zip"},
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: fmt.Sprintf("The large-files plugin labels PRs adding or changing files that are too large or of a disallowed type with the `%s` label. "+
+			"Top-level approvers of the repository can exempt a PR by adding the `%s` label, which is removed again when further commits are pushed.", labels.LargeFiles, labels.LargeFilesAllowed),
+		Config:  configInfo,
+		Snippet: yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+	CreateComment(org, repo string, number int, comment string) error
+	GetPullRequestChanges(org, repo string, number int) ([]github.PullRequestChange, error)
+	GetDirectory(org, repo, dirpath, commit string) ([]github.DirectoryContent, error)
+}
+
+type ownersClient interface {
+	LoadRepoOwners(org, repo, base string) (repoowners.RepoOwner, error)
+}
+
+type commentPruner interface {
+	PruneComments(shouldPrune func(github.IssueComment) bool)
+}
+
+func handlePullRequest(pc plugins.Agent, pre github.PullRequestEvent) error {
+	if pre.PullRequest.State != github.PullRequestStateOpen {
+		return nil
+	}
+	cp, err := pc.CommentPruner()
+	if err != nil {
+		return err
+	}
+	return handle(pc.GitHubClient, pc.OwnersClient, pc.Logger, cp, optionsForRepo(pc.PluginConfig, pre.Repo.Owner.Login, pre.Repo.Name), pre)
+}
+
+func handle(gc githubClient, oc ownersClient, log *logrus.Entry, cp commentPruner, opts *plugins.LargeFiles, pre github.PullRequestEvent) error {
+	org, repo, number := pre.Repo.Owner.Login, pre.Repo.Name, pre.Number
+
+	switch pre.Action {
+	case github.PullRequestActionOpened, github.PullRequestActionReopened:
+		return check(gc, log, cp, opts, pre, false)
+	case github.PullRequestActionSynchronize:
+		return check(gc, log, cp, opts, pre, true)
+	case github.PullRequestActionLabeled:
+		if pre.Label.Name != labels.LargeFilesAllowed {
+			return nil
+		}
+		owners, err := oc.LoadRepoOwners(org, repo, pre.PullRequest.Base.Ref)
+		if err != nil {
+			return fmt.Errorf("failed to load the OWNERS of %s/%s: %w", org, repo, err)
+		}
+		if !owners.TopLevelApprovers().Has(github.NormLogin(pre.Sender.Login)) {
+			if err := gc.RemoveLabel(org, repo, number, labels.LargeFilesAllowed); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.LargeFilesAllowed)
+			}
+			msg := fmt.Sprintf("@%s: only top-level approvers of this repository may add the `%s` label.", pre.Sender.Login, labels.LargeFilesAllowed)
+			return gc.CreateComment(org, repo, number, plugins.FormatSimpleResponse(msg))
+		}
+		issueLabels, err := gc.GetIssueLabels(org, repo, number)
+		if err != nil {
+			return fmt.Errorf("failed to get the labels of %s/%s#%d: %w", org, repo, number, err)
+		}
+		if github.HasLabel(labels.LargeFiles, issueLabels) {
+			if err := gc.RemoveLabel(org, repo, number, labels.LargeFiles); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.LargeFiles)
+			}
+		}
+	case github.PullRequestActionUnlabeled:
+		if pre.Label.Name != labels.LargeFilesAllowed {
+			return nil
+		}
+		return check(gc, log, cp, opts, pre, false)
+	}
+	return nil
+}
+
+// check labels the PR if it adds or changes disallowed files and is not
+// exempted. If commits were pushed to a PR with disallowed files, an earlier
+// exemption is revoked, as it was granted for different files.
+func check(gc githubClient, log *logrus.Entry, cp commentPruner, opts *plugins.LargeFiles, pre github.PullRequestEvent, pushed bool) error {
+	org, repo, number := pre.Repo.Owner.Login, pre.Repo.Name, pre.Number
+
+	problems, err := findProblems(gc, org, repo, number, pre.PullRequest.Head.SHA, opts)
+	if err != nil {
+		return err
+	}
+	issueLabels, err := gc.GetIssueLabels(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels of %s/%s#%d: %w", org, repo, number, err)
+	}
+	hasLabel := github.HasLabel(labels.LargeFiles, issueLabels)
+	allowed := github.HasLabel(labels.LargeFilesAllowed, issueLabels)
+
+	if pushed && allowed && len(problems) > 0 {
+		if err := gc.RemoveLabel(org, repo, number, labels.LargeFilesAllowed); err != nil {
+			log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.LargeFilesAllowed)
+		}
+		allowed = false
+	}
+	if len(problems) == 0 || allowed {
+		if hasLabel {
+			if err := gc.RemoveLabel(org, repo, number, labels.LargeFiles); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.LargeFiles)
+			}
+		}
+		if len(problems) == 0 {
+			cp.PruneComments(func(comment github.IssueComment) bool {
+				return strings.Contains(comment.Body, commentMarker)
+			})
+		}
+		return nil
+	}
+
+	if hasLabel {
+		return nil
+	}
+	if err := gc.AddLabel(org, repo, number, labels.LargeFiles); err != nil {
+		log.WithError(err).Errorf("GitHub failed to add the following label: %s", labels.LargeFiles)
+	}
+	msg := fmt.Sprintf("%s:\n\n%s\n\nPlease remove them from the PR, e.g. by storing them elsewhere and downloading them when needed. "+
+		"If they are needed, a top-level approver of this repository can add the `%s` label.",
+		commentMarker, strings.Join(problems, "\n"), labels.LargeFilesAllowed)
+	return gc.CreateComment(org, repo, number, plugins.FormatSimpleResponse(msg))
+}
+
+// findProblems returns the disallowed files the PR adds or changes as a
+// markdown list.
+func findProblems(gc githubClient, org, repo string, number int, headSHA string, opts *plugins.LargeFiles) ([]string, error) {
+	changes, err := gc.GetPullRequestChanges(org, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the changes of %s/%s#%d: %w", org, repo, number, err)
+	}
+	disallowed := sets.New[string]()
+	for _, ext := range opts.DisallowedExtensions {
+		disallowed.Insert(strings.ToLower(ext))
+	}
+	maxSize := maxFileSize(opts)
+
+	// The contents API lists the sizes of the files of a directory, so the
+	// files are looked up once per directory. It lists at most 1000 files
+	// though, so a file missing from the listing of a large directory is
+	// reported as its size is unknown.
+	sizes := map[string]int64{}
+	listed := sets.New[string]()
+	var problems []string
+	for _, change := range changes {
+		if change.Status == github.PullRequestFileRemoved {
+			continue
+		}
+		if ext := strings.ToLower(path.Ext(change.Filename)); disallowed.Has(ext) {
+			problems = append(problems, fmt.Sprintf("- `%s` has the disallowed extension `%s`.", change.Filename, ext))
+			continue
+		}
+		if dir := path.Dir(change.Filename); !listed.Has(dir) {
+			listed.Insert(dir)
+			if dir == "." {
+				dir = ""
+			}
+			contents, err := gc.GetDirectory(org, repo, dir, headSHA)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list the files of %s/%s:%s at %s: %w", org, repo, dir, headSHA, err)
+			}
+			for _, content := range contents {
+				sizes[content.Path] = content.Size
+			}
+		}
+		size, ok := sizes[change.Filename]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("- `%s` could not be checked, as its directory has too many files to list.", change.Filename))
+			continue
+		}
+		if size > maxSize {
+			problems = append(problems, fmt.Sprintf("- `%s` is %s, more than the limit of %s.", change.Filename, formatSize(size), formatSize(maxSize)))
+		}
+	}
+	return problems, nil
+}
+
+func maxFileSize(opts *plugins.LargeFiles) int64 {
+	if opts.MaxFileSize > 0 {
+		return opts.MaxFileSize
+	}
+	return defaultMaxFileSize
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1fMiB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1fKiB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%dB", size)
+	}
+}
+
+// optionsForRepo gets the plugins.LargeFiles struct that is applicable to the indicated repo.
+func optionsForRepo(config *plugins.Configuration, org, repo string) *plugins.LargeFiles {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+
+	// First search for repo config
+	for _, c := range config.LargeFiles {
+		if !sets.New[string](c.Repos...).Has(fullName) {
+			continue
+		}
+		return &c
+	}
+
+	// If you don't find anything, loop again looking for an org config
+	for _, c := range config.LargeFiles {
+		if !sets.New[string](c.Repos...).Has(org) {
+			continue
+		}
+		return &c
+	}
+
+	// Return an empty config, and default to defaultMaxFileSize
+	return &plugins.LargeFiles{}
+}
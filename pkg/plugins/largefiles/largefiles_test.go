@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package largefiles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
+	"sigs.k8s.io/prow/pkg/repoowners"
+)
+
+type fakeRepoOwners struct {
+	repoowners.RepoOwner
+	topLevelApprovers sets.Set[string]
+}
+
+func (f *fakeRepoOwners) TopLevelApprovers() sets.Set[string] {
+	return f.topLevelApprovers
+}
+
+type fakeOwnersClient struct{}
+
+func (fakeOwnersClient) LoadRepoOwners(org, repo, base string) (repoowners.RepoOwner, error) {
+	return &fakeRepoOwners{topLevelApprovers: sets.New[string]("approver")}, nil
+}
+
+type fakePruner struct {
+	pruned bool
+}
+
+func (fp *fakePruner) PruneComments(shouldPrune func(github.IssueComment) bool) {
+	fp.pruned = true
+}
+
+func TestHandle(t *testing.T) {
+	label := func(l string) string { return "org/repo#1:" + l }
+	var testcases = []struct {
+		name    string
+		action  github.PullRequestEventAction
+		label   string
+		sender  string
+		changes []github.PullRequestChange
+		labels  []string
+
+		expectAdded   []string
+		expectRemoved []string
+		expectComment []string
+		expectPruned  bool
+	}{
+		{
+			name:         "PR with small files",
+			action:       github.PullRequestActionOpened,
+			changes:      []github.PullRequestChange{{Filename: "main.go", Status: "modified"}, {Filename: "docs/README.md", Status: "added"}},
+			expectPruned: true,
+		},
+		{
+			name:   "PR with large and disallowed files",
+			action: github.PullRequestActionOpened,
+			changes: []github.PullRequestChange{
+				{Filename: "main.go", Status: "modified"},
+				{Filename: "data/dump.sql", Status: "added"},
+				{Filename: "bin/tool.EXE", Status: "added"},
+			},
+			expectAdded: []string{label(labels.LargeFiles)},
+			expectComment: []string{
+				"- `data/dump.sql` is 2.5MiB, more than the limit of 1.0MiB.",
+				"- `bin/tool.EXE` has the disallowed extension `.exe`.",
+			},
+		},
+		{
+			name:    "file missing from the listing of a large directory",
+			action:  github.PullRequestActionOpened,
+			changes: []github.PullRequestChange{{Filename: "vendor/huge.bin", Status: "added"}},
+
+			expectAdded:   []string{label(labels.LargeFiles)},
+			expectComment: []string{"- `vendor/huge.bin` could not be checked, as its directory has too many files to list."},
+		},
+		{
+			name:    "removing a large file is fine",
+			action:  github.PullRequestActionSynchronize,
+			changes: []github.PullRequestChange{{Filename: "data/dump.sql", Status: github.PullRequestFileRemoved}},
+			labels:  []string{labels.LargeFiles},
+
+			expectRemoved: []string{label(labels.LargeFiles)},
+			expectPruned:  true,
+		},
+		{
+			name:    "labeled PR is not commented on again",
+			action:  github.PullRequestActionSynchronize,
+			changes: []github.PullRequestChange{{Filename: "data/dump.sql", Status: "added"}},
+			labels:  []string{labels.LargeFiles},
+		},
+		{
+			name:    "exempted PR is not labeled",
+			action:  github.PullRequestActionReopened,
+			changes: []github.PullRequestChange{{Filename: "data/dump.sql", Status: "added"}},
+			labels:  []string{labels.LargeFilesAllowed},
+		},
+		{
+			name:          "pushing to an exempted PR revokes the exemption",
+			action:        github.PullRequestActionSynchronize,
+			changes:       []github.PullRequestChange{{Filename: "data/dump.sql", Status: "added"}},
+			labels:        []string{labels.LargeFilesAllowed},
+			expectAdded:   []string{label(labels.LargeFiles)},
+			expectRemoved: []string{label(labels.LargeFilesAllowed)},
+			expectComment: []string{"- `data/dump.sql` is 2.5MiB"},
+		},
+		{
+			name:         "pushing to an exempted PR without large files keeps the exemption",
+			action:       github.PullRequestActionSynchronize,
+			changes:      []github.PullRequestChange{{Filename: "main.go", Status: "modified"}},
+			labels:       []string{labels.LargeFilesAllowed},
+			expectPruned: true,
+		},
+		{
+			name:          "exemption by a top-level approver",
+			action:        github.PullRequestActionLabeled,
+			label:         labels.LargeFilesAllowed,
+			sender:        "approver",
+			labels:        []string{labels.LargeFiles, labels.LargeFilesAllowed},
+			expectRemoved: []string{label(labels.LargeFiles)},
+		},
+		{
+			name:          "exemption by someone else is reverted",
+			action:        github.PullRequestActionLabeled,
+			label:         labels.LargeFilesAllowed,
+			sender:        "someone",
+			labels:        []string{labels.LargeFiles, labels.LargeFilesAllowed},
+			expectRemoved: []string{label(labels.LargeFilesAllowed)},
+			expectComment: []string{"@someone: only top-level approvers of this repository may add the `large-files-allowed` label."},
+		},
+		{
+			name:          "removal of the exemption",
+			action:        github.PullRequestActionUnlabeled,
+			label:         labels.LargeFilesAllowed,
+			changes:       []github.PullRequestChange{{Filename: "data/dump.sql", Status: "added"}},
+			expectAdded:   []string{label(labels.LargeFiles)},
+			expectComment: []string{"- `data/dump.sql` is 2.5MiB"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			fc.PullRequestChanges = map[int][]github.PullRequestChange{1: tc.changes}
+			fc.RemoteDirectories = map[string]map[string][]github.DirectoryContent{
+				"":       {"head": {{Type: "file", Name: "main.go", Path: "main.go", Size: 4 << 10}}},
+				"docs":   {"head": {{Type: "file", Name: "README.md", Path: "docs/README.md", Size: 1 << 20}}},
+				"data":   {"head": {{Type: "file", Name: "dump.sql", Path: "data/dump.sql", Size: 5 << 19}}},
+				"vendor": {"head": {{Type: "file", Name: "small.txt", Path: "vendor/small.txt", Size: 1 << 10}}},
+			}
+			for _, l := range tc.labels {
+				fc.IssueLabelsExisting = append(fc.IssueLabelsExisting, label(l))
+			}
+			fp := &fakePruner{}
+			pre := github.PullRequestEvent{
+				Action: tc.action,
+				Number: 1,
+				Label:  github.Label{Name: tc.label},
+				Sender: github.User{Login: tc.sender},
+				Repo:   github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				PullRequest: github.PullRequest{
+					Number: 1,
+					State:  github.PullRequestStateOpen,
+					Head:   github.PullRequestBranch{SHA: "head"},
+				},
+			}
+			opts := &plugins.LargeFiles{DisallowedExtensions: []string{".exe"}}
+
+			if err := handle(fc, fakeOwnersClient{}, logrus.WithField("plugin", PluginName), fp, opts, pre); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !sets.New[string](fc.IssueLabelsAdded...).Equal(sets.New[string](tc.expectAdded...)) {
+				t.Errorf("expected labels %v to be added, got %v", tc.expectAdded, fc.IssueLabelsAdded)
+			}
+			if !sets.New[string](fc.IssueLabelsRemoved...).Equal(sets.New[string](tc.expectRemoved...)) {
+				t.Errorf("expected labels %v to be removed, got %v", tc.expectRemoved, fc.IssueLabelsRemoved)
+			}
+			comments := fc.IssueComments[1]
+			if len(tc.expectComment) == 0 && len(comments) > 0 {
+				t.Errorf("expected no comment, got %v", comments)
+			}
+			if len(tc.expectComment) > 0 {
+				if len(comments) != 1 {
+					t.Fatalf("expected a comment, got %v", comments)
+				}
+				for _, expected := range tc.expectComment {
+					if !strings.Contains(comments[0].Body, expected) {
+						t.Errorf("expected the comment to contain %q, got %q", expected, comments[0].Body)
+					}
+				}
+			}
+			if fp.pruned != tc.expectPruned {
+				t.Errorf("expected comments to be pruned: %t, got: %t", tc.expectPruned, fp.pruned)
+			}
+		})
+	}
+}
@@ -50,12 +50,21 @@ var (
 	// LGTMCancelRe is the regex that matches lgtm cancel comments
 	LGTMCancelRe        = regexp.MustCompile(`(?mi)^/(remove-lgtm|lgtm cancel)\s*$`)
 	removeLGTMLabelNoti = "New changes are detected. LGTM label has been removed."
+	keepLGTMLabelNoti   = "New changes are detected. LGTM label has been kept as they only change files matching %s:\n%s"
 )
 
+// maxComparedFiles is the number of files GitHub lists at most when comparing
+// commits. Comparisons that hit it may omit changed files.
+const maxComparedFiles = 300
+
 func configInfoStickyLgtmTeam(team string) string {
 	return fmt.Sprintf(`Commits from "%s" do not remove LGTM.`, team)
 }
 
+func configInfoStickyPaths(paths []string) string {
+	return fmt.Sprintf(`Commits that only change files matching %s do not remove LGTM.`, strings.Join(paths, ", "))
+}
+
 type commentPruner interface {
 	PruneComments(shouldPrune func(github.IssueComment) bool)
 }
@@ -87,6 +96,10 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 			configInfoStrings = append(configInfoStrings, "<li>"+configInfoStickyLgtmTeam(opts.StickyLgtmTeam)+"</li>")
 			isConfigured = true
 		}
+		if len(opts.StickyPaths) > 0 {
+			configInfoStrings = append(configInfoStrings, "<li>"+configInfoStickyPaths(opts.StickyPaths)+"</li>")
+			isConfigured = true
+		}
 		configInfoStrings = append(configInfoStrings, "</ul>")
 		if isConfigured {
 			configInfo[repo.String()] = strings.Join(configInfoStrings, "\n")
@@ -133,6 +146,7 @@ type githubClient interface {
 	DeleteComment(org, repo string, ID int) error
 	BotUserChecker() (func(candidate string) bool, error)
 	GetSingleCommit(org, repo, SHA string) (github.RepositoryCommit, error)
+	CompareCommits(org, repo, base, head string) ([]github.CommitFile, error)
 	IsMember(org, user string) (bool, error)
 	ListTeams(org string) ([]github.Team, error)
 	ListTeamMembersBySlug(org, teamSlug, role string) ([]github.TeamMember, error)
@@ -468,6 +482,19 @@ func handlePullRequest(log *logrus.Entry, gc githubClient, config *plugins.Confi
 		}
 	}
 
+	if files := stickyChanges(log, gc, opts, org, repo, pe.Before, pe.After); len(files) > 0 {
+		var list strings.Builder
+		for _, file := range files {
+			list.WriteString(fmt.Sprintf("- `%s`\n", file))
+		}
+		var paths []string
+		for _, path := range opts.StickyPaths {
+			paths = append(paths, "`"+path+"`")
+		}
+		log.WithField("files", files).Info("Keeping LGTM label as the new changes only touch sticky paths.")
+		return gc.CreateComment(org, repo, number, fmt.Sprintf(keepLGTMLabelNoti, strings.Join(paths, ", "), list.String()))
+	}
+
 	if err := removeLGTMAndRequestReview(gc, org, repo, number, getLogins(pe.PullRequest.Assignees), opts.StoreTreeHash); err != nil {
 		return fmt.Errorf("failed removing lgtm label: %w", err)
 	}
@@ -478,6 +505,44 @@ func handlePullRequest(log *logrus.Entry, gc githubClient, config *plugins.Confi
 	return gc.CreateComment(org, repo, number, removeLGTMLabelNoti)
 }
 
+// stickyChanges returns the files changed between the before and after heads
+// of a pushed PR if they all match the sticky paths of the repo, which means
+// that the push does not remove LGTM. It returns nothing if the changes cannot
+// be determined.
+func stickyChanges(log *logrus.Entry, gc githubClient, lgtm *plugins.Lgtm, org, repo, before, after string) []string {
+	if len(lgtm.StickyPathRes) == 0 || before == "" || after == "" {
+		return nil
+	}
+	changes, err := gc.CompareCommits(org, repo, before, after)
+	if err != nil {
+		log.WithError(err).Errorf("Failed to compare %s...%s.", before, after)
+		return nil
+	}
+	if len(changes) >= maxComparedFiles {
+		return nil
+	}
+	var files []string
+	for _, change := range changes {
+		for _, filename := range []string{change.Filename, change.PreviousFilename} {
+			if filename == "" {
+				continue
+			}
+			sticky := false
+			for _, re := range lgtm.StickyPathRes {
+				if re.MatchString(filename) {
+					sticky = true
+					break
+				}
+			}
+			if !sticky {
+				return nil
+			}
+		}
+		files = append(files, change.Filename)
+	}
+	return files
+}
+
 func removeLGTMAndRequestReview(gc githubClient, org, repo string, number int, logins []string, storeTreeHash bool) error {
 	if err := gc.RemoveLabel(org, repo, number, LGTMLabel); err != nil {
 		return fmt.Errorf("failed removing lgtm label: %w", err)
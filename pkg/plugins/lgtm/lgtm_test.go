@@ -852,6 +852,8 @@ func TestHandlePullRequest(t *testing.T) {
 		IssueLabelsRemoved []string
 		issueComments      map[int][]github.IssueComment
 		trustedTeam        string
+		stickyPaths        []string
+		comparisons        map[string][]github.CommitFile
 
 		expectNoComments bool
 
@@ -1144,11 +1146,128 @@ func TestHandlePullRequest(t *testing.T) {
 			},
 			expectNoComments: false,
 		},
+		{
+			name: "Sticky LGTM for changes of sticky paths only",
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+					Head: github.PullRequestBranch{
+						SHA: SHA,
+					},
+				},
+				Before: "before",
+				After:  SHA,
+			},
+			stickyPaths: []string{`^docs/`, `\.pb\.go$`},
+			comparisons: map[string][]github.CommitFile{
+				"before..." + SHA: {{Filename: "docs/api.md"}, {Filename: "pkg/api/api.pb.go"}},
+			},
+			issueComments: map[int][]github.IssueComment{
+				101: {
+					{
+						Body: "New changes are detected. LGTM label has been kept as they only change files matching `^docs/`, `\\.pb\\.go$`:\n- `docs/api.md`\n- `pkg/api/api.pb.go`\n",
+						User: github.User{Login: fakegithub.Bot},
+					},
+				},
+			},
+		},
+		{
+			name: "Changes of sticky and other paths remove LGTM",
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+					Head: github.PullRequestBranch{
+						SHA: SHA,
+					},
+					Assignees: []github.User{
+						{
+							Login: "TestReviewer",
+						},
+					},
+				},
+				Before: "before",
+				After:  SHA,
+			},
+			stickyPaths: []string{`^docs/`},
+			comparisons: map[string][]github.CommitFile{
+				"before..." + SHA: {{Filename: "docs/api.md"}, {Filename: "pkg/api/api.go"}},
+			},
+			IssueLabelsRemoved: []string{LGTMLabel},
+			shouldRequest:      true,
+			issueComments: map[int][]github.IssueComment{
+				101: {
+					{
+						Body: removeLGTMLabelNoti,
+						User: github.User{Login: fakegithub.Bot},
+					},
+				},
+			},
+		},
+		{
+			name: "Renames out of sticky paths remove LGTM",
+			event: github.PullRequestEvent{
+				Action: github.PullRequestActionSynchronize,
+				PullRequest: github.PullRequest{
+					Number: 101,
+					Base: github.PullRequestBranch{
+						Repo: github.Repo{
+							Owner: github.User{
+								Login: "kubernetes",
+							},
+							Name: "kubernetes",
+						},
+					},
+					Head: github.PullRequestBranch{
+						SHA: SHA,
+					},
+					Assignees: []github.User{
+						{
+							Login: "TestReviewer",
+						},
+					},
+				},
+				Before: "before",
+				After:  SHA,
+			},
+			stickyPaths: []string{`^docs/`},
+			comparisons: map[string][]github.CommitFile{
+				"before..." + SHA: {{Filename: "docs/main.go", PreviousFilename: "main.go"}},
+			},
+			IssueLabelsRemoved: []string{LGTMLabel},
+			shouldRequest:      true,
+			issueComments: map[int][]github.IssueComment{
+				101: {
+					{
+						Body: removeLGTMLabelNoti,
+						User: github.User{Login: fakegithub.Bot},
+					},
+				},
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			fakeGitHub := fakegithub.NewFakeClient()
 			fakeGitHub.IssueComments = c.issueComments
+			fakeGitHub.Comparisons = c.comparisons
 			fakeGitHub.PullRequests = map[int]*github.PullRequest{
 				101: {
 					Base: github.PullRequestBranch{
@@ -1171,7 +1290,11 @@ func TestHandlePullRequest(t *testing.T) {
 				Repos:          []string{"kubernetes/kubernetes"},
 				StoreTreeHash:  true,
 				StickyLgtmTeam: c.trustedTeam,
+				StickyPaths:    c.stickyPaths,
 			})
+			for _, path := range c.stickyPaths {
+				pc.Lgtm[0].StickyPathRes = append(pc.Lgtm[0].StickyPathRes, regexp.MustCompile(path))
+			}
 			err := handlePullRequest(
 				logrus.WithField("plugin", "approve"),
 				fakeGitHub,
@@ -19,6 +19,7 @@ package lifecycle
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 
 	"github.com/sirupsen/logrus"
 
@@ -29,6 +30,7 @@ import (
 var (
 	closeRe           = regexp.MustCompile(`(?mi)^/close\s*$`)
 	closeNotPlannedRe = regexp.MustCompile(`(?mi)^/close not-planned\s*$`)
+	closeDuplicateRe  = regexp.MustCompile(`(?mi)^/close duplicate-of #(\d+)\s*$`)
 )
 
 type closeClient interface {
@@ -38,6 +40,7 @@ type closeClient interface {
 	CloseIssueAsNotPlanned(org, repo string, number int) error
 	ClosePullRequest(owner, repo string, number int) error
 	GetIssueLabels(owner, repo string, number int) ([]github.Label, error)
+	GetIssue(owner, repo string, number int) (*github.Issue, error)
 }
 
 func isActive(gc closeClient, org, repo string, number int) (bool, error) {
@@ -59,7 +62,8 @@ func handleClose(gc closeClient, log *logrus.Entry, e *github.GenericCommentEven
 		return nil
 	}
 
-	if !closeRe.MatchString(e.Body) && !closeNotPlannedRe.MatchString(e.Body) {
+	duplicateMatch := closeDuplicateRe.FindStringSubmatch(e.Body)
+	if !closeRe.MatchString(e.Body) && !closeNotPlannedRe.MatchString(e.Body) && duplicateMatch == nil {
 		return nil
 	}
 
@@ -100,8 +104,8 @@ func handleClose(gc closeClient, log *logrus.Entry, e *github.GenericCommentEven
 		// "not_planned" state only exists for issues, which
 		// is why allowing PRs to be closed when /close not-planned
 		// is commented feels awkward.
-		if closeNotPlannedRe.MatchString(e.Body) {
-			response := "PRs cannot be closed as Not Planned."
+		if closeNotPlannedRe.MatchString(e.Body) || duplicateMatch != nil {
+			response := "PRs cannot be closed as Not Planned or as duplicates."
 			log.Infof("Commenting \"%s\".", response)
 			return gc.CreateComment(
 				org,
@@ -120,7 +124,22 @@ func handleClose(gc closeClient, log *logrus.Entry, e *github.GenericCommentEven
 
 	log.Info("Closing issue.")
 	var reply string
-	if closeNotPlannedRe.MatchString(e.Body) {
+	if duplicateMatch != nil {
+		// Duplicates are closed as Not Planned, the reply records which
+		// issue this one duplicates.
+		original, _ := strconv.Atoi(duplicateMatch[1])
+		if original == number {
+			return gc.CreateComment(org, repo, number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, commentAuthor, "An issue cannot be a duplicate of itself."))
+		}
+		if _, err := gc.GetIssue(org, repo, original); err != nil {
+			log.WithError(err).Infof("Failed to get issue #%d.", original)
+			return gc.CreateComment(org, repo, number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, commentAuthor, fmt.Sprintf("Could not find #%d in this repository.", original)))
+		}
+		if err := gc.CloseIssueAsNotPlanned(org, repo, number); err != nil {
+			return fmt.Errorf("Error closing issue as a duplicate: %w", err)
+		}
+		reply = fmt.Sprintf("Closing this issue as a duplicate of #%d, marking it as \"Not Planned\".", original)
+	} else if closeNotPlannedRe.MatchString(e.Body) {
 		if err := gc.CloseIssueAsNotPlanned(org, repo, number); err != nil {
 			return fmt.Errorf("Error closing issue as \"Not Planned\": %w", err)
 		}
@@ -73,6 +73,13 @@ func (c *fakeClientClose) GetIssueLabels(owner, repo string, number int) ([]gith
 	return labels, nil
 }
 
+func (c *fakeClientClose) GetIssue(owner, repo string, number int) (*github.Issue, error) {
+	if number == 404 {
+		return nil, errors.New("issue 404 not found")
+	}
+	return &github.Issue{Number: number}, nil
+}
+
 func TestCloseComment(t *testing.T) {
 	var testcases = []struct {
 		name          string
@@ -266,6 +273,62 @@ func TestCloseComment(t *testing.T) {
 			shouldComment: true,
 			isPr:          true,
 		},
+		{
+			name:          "close as duplicate by collaborator",
+			action:        github.GenericCommentActionCreated,
+			state:         "open",
+			stateReason:   "not_planned",
+			body:          "/close duplicate-of #3",
+			commenter:     "collaborator",
+			shouldClose:   true,
+			shouldComment: true,
+		},
+		{
+			name:          "close as duplicate by random person of an active issue",
+			action:        github.GenericCommentActionCreated,
+			state:         "open",
+			body:          "/close duplicate-of #3",
+			commenter:     "random-person",
+			shouldClose:   false,
+			shouldComment: true,
+		},
+		{
+			name:          "cannot close as duplicate of itself",
+			action:        github.GenericCommentActionCreated,
+			state:         "open",
+			body:          "/close duplicate-of #5",
+			commenter:     "collaborator",
+			shouldClose:   false,
+			shouldComment: true,
+		},
+		{
+			name:          "cannot close as duplicate of a missing issue",
+			action:        github.GenericCommentActionCreated,
+			state:         "open",
+			body:          "/close duplicate-of #404",
+			commenter:     "collaborator",
+			shouldClose:   false,
+			shouldComment: true,
+		},
+		{
+			name:          "cannot close PR as duplicate",
+			action:        github.GenericCommentActionCreated,
+			state:         "open",
+			body:          "/close duplicate-of #3",
+			commenter:     "author",
+			shouldClose:   false,
+			shouldComment: true,
+			isPr:          true,
+		},
+		{
+			name:          "duplicate without issue number is not a command",
+			action:        github.GenericCommentActionCreated,
+			state:         "open",
+			body:          "/close duplicate-of",
+			commenter:     "collaborator",
+			shouldClose:   false,
+			shouldComment: false,
+		},
 	}
 	for _, tc := range testcases {
 		fc := &fakeClientClose{labels: tc.labels}
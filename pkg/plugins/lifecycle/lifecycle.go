@@ -43,11 +43,11 @@ func help(config *plugins.Configuration, _ []config.OrgRepo) (*pluginhelp.Plugin
 		Description: "Close, reopen, flag and/or unflag an issue or PR as frozen/stale/rotten",
 	}
 	pluginHelp.AddCommand(pluginhelp.Command{
-		Usage:       "/close [not-planned]",
+		Usage:       "/close [not-planned|duplicate-of #<number>]",
 		Description: "Closes an issue or PR.",
 		Featured:    false,
 		WhoCanUse:   "Authors and collaborators on the repository can trigger this command.",
-		Examples:    []string{"/close", "/close not-planned"},
+		Examples:    []string{"/close", "/close not-planned", "/close duplicate-of #123"},
 	})
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/reopen",
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/audit"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/git/v2"
 	"sigs.k8s.io/prow/pkg/github"
@@ -223,7 +224,7 @@ func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) error
 		prowJobClient: pc.ProwJobClient,
 		ownersClient:  pc.OwnersClient,
 	}
-	return handle(c, pc.Logger, &e, pc.PluginConfig.Override)
+	return handle(c, pc.Logger, pc.Auditor, &e, pc.PluginConfig.Override)
 }
 
 func authorizedUser(gc githubClient, log *logrus.Entry, org, repo, user string) bool {
@@ -321,7 +322,7 @@ func parseOverrideInput(in string) []string {
 	return retval
 }
 
-func handle(oc overrideClient, log *logrus.Entry, e *github.GenericCommentEvent, options plugins.Override) error {
+func handle(oc overrideClient, log *logrus.Entry, auditor *audit.Auditor, e *github.GenericCommentEvent, options plugins.Override) error {
 
 	if !e.IsPR || e.IssueState != "open" || e.Action != github.GenericCommentActionCreated {
 		return nil
@@ -481,6 +482,12 @@ If you are trying to override a checkrun that has a space in it, you must put a
 		}
 		msg := fmt.Sprintf("Overrode contexts on behalf of %s: %s", user, strings.Join(sets.List(done), ", "))
 		log.Info(msg)
+		auditor.Emit(context.TODO(), audit.Event{
+			Action:  audit.ActionOverride,
+			Actor:   user,
+			Target:  fmt.Sprintf("%s/%s#%d", org, repo, number),
+			Details: map[string]string{"contexts": strings.Join(sets.List(done), ","), "sha": sha},
+		})
 		oc.CreateComment(org, repo, number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, user, msg))
 	}()
 
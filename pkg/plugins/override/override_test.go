@@ -1244,7 +1244,7 @@ func TestHandle(t *testing.T) {
 				tc.jobs = sets.Set[string]{}
 			}
 
-			err := handle(&fc, log, &event, tc.options)
+			err := handle(&fc, log, nil, &event, tc.options)
 			switch {
 			case err != nil:
 				if !tc.err {
@@ -24,6 +24,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -39,6 +40,7 @@ import (
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"sigs.k8s.io/yaml"
 
+	"sigs.k8s.io/prow/pkg/audit"
 	"sigs.k8s.io/prow/pkg/bugzilla"
 	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/commentpruner"
@@ -49,6 +51,7 @@ import (
 	"sigs.k8s.io/prow/pkg/pluginhelp"
 	"sigs.k8s.io/prow/pkg/repoowners"
 	"sigs.k8s.io/prow/pkg/slack"
+	"sigs.k8s.io/prow/pkg/tracing"
 	"sigs.k8s.io/prow/pkg/version"
 )
 
@@ -182,6 +185,8 @@ type Agent struct {
 	SlackClient               *slack.Client
 	BugzillaClient            bugzilla.Client
 	JiraClient                jira.Client
+	// Auditor emits audit events of privileged actions, may be nil.
+	Auditor *audit.Auditor
 
 	OwnersClient repoowners.Interface
 
@@ -214,12 +219,13 @@ func NewAgent(configAgent *config.Agent, pluginConfigAgent *ConfigAgent, clientA
 		GitHubClient:              gitHubClient,
 		KubernetesClient:          clientAgent.KubernetesClient,
 		BuildClusterCoreV1Clients: clientAgent.BuildClusterCoreV1Clients,
-		ProwJobClient:             clientAgent.ProwJobClient,
+		ProwJobClient:             tracing.ProwJobClient(logger.Context, clientAgent.ProwJobClient),
 		GitClient:                 clientAgent.GitClient,
 		SlackClient:               clientAgent.SlackClient,
 		OwnersClient:              clientAgent.OwnersClient.WithFields(logger.Data).WithGitHubClient(gitHubClient).ForPlugin(plugin),
 		BugzillaClient:            clientAgent.BugzillaClient.WithFields(logger.Data).ForPlugin(plugin),
 		JiraClient:                jiraClient,
+		Auditor:                   clientAgent.Auditor,
 		Metrics:                   metrics,
 		Config:                    prowConfig,
 		PluginConfig:              pluginConfig,
@@ -265,6 +271,8 @@ type ClientAgent struct {
 	OwnersClient              repoowners.Interface
 	BugzillaClient            bugzilla.Client
 	JiraClient                jira.Client
+	// Auditor emits audit events of privileged actions, may be nil.
+	Auditor *audit.Auditor
 }
 
 // ConfigAgent contains the agent mutex and the Agent configuration.
@@ -397,6 +405,31 @@ func (pa *ConfigAgent) Start(path string, supplementalPluginConfigDirs []string,
 	return nil
 }
 
+var commandLineRe = regexp.MustCompile(`(?m)^/([\w-]+)`)
+
+// ExpandCommandAliases replaces the aliased commands at the start of the
+// lines of a comment with the commands they alias. Aliases match
+// case-insensitively and can be prefixed with `remove-` like the commands.
+func ExpandCommandAliases(body string, aliases map[string]string) string {
+	if len(aliases) == 0 {
+		return body
+	}
+	normalized := make(map[string]string, len(aliases))
+	for alias, command := range aliases {
+		normalized[strings.ToLower(alias)] = command
+	}
+	return commandLineRe.ReplaceAllStringFunc(body, func(match string) string {
+		name := strings.ToLower(strings.TrimPrefix(match, "/"))
+		if command, ok := normalized[name]; ok {
+			return "/" + command
+		}
+		if command, ok := normalized[strings.TrimPrefix(name, "remove-")]; ok && strings.HasPrefix(name, "remove-") {
+			return "/remove-" + command
+		}
+		return match
+	})
+}
+
 // GenericCommentHandlers returns a map of plugin names to handlers for the repo.
 func (pa *ConfigAgent) GenericCommentHandlers(owner, repo string) map[string]GenericCommentHandler {
 	pa.mut.Lock()
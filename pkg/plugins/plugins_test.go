@@ -67,6 +67,60 @@ func TestHasSelfApproval(t *testing.T) {
 	}
 }
 
+func TestSelfApprovesChanges(t *testing.T) {
+	cases := []struct {
+		name      string
+		cfg       string
+		filenames []string
+		expected  bool
+		expectErr bool
+	}{
+		{
+			name:      "no self approval paths",
+			filenames: []string{"docs/README.md"},
+		},
+		{
+			name:      "all files match",
+			cfg:       `{"self_approval_paths": ["^docs/", "(^|/)OWNERS$"]}`,
+			filenames: []string{"docs/README.md", "pkg/OWNERS"},
+			expected:  true,
+		},
+		{
+			name:      "some files do not match",
+			cfg:       `{"self_approval_paths": ["^docs/", "(^|/)OWNERS$"]}`,
+			filenames: []string{"docs/README.md", "pkg/main.go"},
+		},
+		{
+			name: "no files changed",
+			cfg:  `{"self_approval_paths": ["^docs/"]}`,
+		},
+		{
+			name:      "invalid regexp",
+			cfg:       `{"self_approval_paths": ["("]}`,
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var a Approve
+			if err := yaml.Unmarshal([]byte(tc.cfg), &a); err != nil {
+				t.Fatalf("failed to unmarshal cfg: %v", err)
+			}
+			c := &Configuration{Approve: []Approve{a}}
+			err := compileRegexpsAndDurations(c)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if actual := c.Approve[0].SelfApprovesChanges(tc.filenames); actual != tc.expected {
+				t.Errorf("%t != expected %t", actual, tc.expected)
+			}
+		})
+	}
+}
+
 func TestConsiderReviewState(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -101,6 +155,59 @@ func TestConsiderReviewState(t *testing.T) {
 	}
 }
 
+func TestExpandCommandAliases(t *testing.T) {
+	aliases := map[string]string{"ship-it": "lgtm", "Wait": "hold"}
+	testCases := []struct {
+		name     string
+		body     string
+		aliases  map[string]string
+		expected string
+	}{
+		{
+			name:     "no aliases",
+			body:     "/ship-it",
+			expected: "/ship-it",
+		},
+		{
+			name:     "alias is expanded",
+			body:     "/ship-it",
+			aliases:  aliases,
+			expected: "/lgtm",
+		},
+		{
+			name:     "aliases are expanded on every line with their arguments",
+			body:     "Looks good.\n/ship-it\n/wait cancel",
+			aliases:  aliases,
+			expected: "Looks good.\n/lgtm\n/hold cancel",
+		},
+		{
+			name:     "aliases match case-insensitively",
+			body:     "/SHIP-IT\n/wait",
+			aliases:  aliases,
+			expected: "/lgtm\n/hold",
+		},
+		{
+			name:     "removal of an alias is expanded",
+			body:     "/remove-ship-it",
+			aliases:  aliases,
+			expected: "/remove-lgtm",
+		},
+		{
+			name:     "aliases must start the line and match the whole command",
+			body:     "Please /ship-it\n/ship-it-now\n/ship",
+			aliases:  aliases,
+			expected: "Please /ship-it\n/ship-it-now\n/ship",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := ExpandCommandAliases(tc.body, tc.aliases); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
 func TestGetPluginsLegacy(t *testing.T) {
 	var testcases = []struct {
 		name            string
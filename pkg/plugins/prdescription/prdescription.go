@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prdescription labels PRs whose description does not satisfy the
+// description policy of their repo with the `do-not-merge/missing-description`
+// label.
+package prdescription
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/pluginhelp"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+const (
+	// PluginName defines this plugin's registered name.
+	PluginName = "pr-description"
+
+	commentMarker = "The description of this PR does not follow the policy of this repository"
+)
+
+var (
+	commentRe     = regexp.MustCompile(`(?s)<!--.*?-->`)
+	headingRe     = regexp.MustCompile(`^#{1,6}\s+(.*?)[\s#]*$`)
+	linkedIssueRe = regexp.MustCompile(`(?:^|[^\w/#])#\d+\b|\b[\w.-]+/[\w.-]+#\d+\b|https://github\.com/[\w.-]+/[\w.-]+/(?:issues|pull)/\d+`)
+)
+
+func init() {
+	plugins.RegisterPullRequestHandler(PluginName, handlePullRequest, helpProvider)
+}
+
+func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo) (*pluginhelp.PluginHelp, error) {
+	configInfo := map[string]string{}
+	for _, repo := range enabledRepos {
+		opts := optionsForRepo(config, repo.Org, repo.Repo)
+		configInfo[repo.String()] = fmt.Sprintf("PR descriptions must satisfy the following policy:\n%s", strings.Join(policy(opts), "\n"))
+	}
+	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
+		PRDescription: []plugins.PRDescription{
+			{
+				Repos:              []string{"kubernetes/test-infra"},
+				MinLength:          100,
+				RequireLinkedIssue: true,
+				RequiredSections:   []string{"Testing"},
+			},
+		},
+	})
+	if err != nil {
+		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", PluginName)
+	}
+	return &pluginhelp.PluginHelp{
+		Description: fmt.Sprintf("The pr-description plugin labels PRs with the `%s` label until their description satisfies the description policy of their repository, so that Tide only merges PRs with usable descriptions.", labels.MissingDescription),
+		Config:      configInfo,
+		Snippet:     yamlSnippet,
+	}, nil
+}
+
+type githubClient interface {
+	AddLabel(org, repo string, number int, label string) error
+	RemoveLabel(org, repo string, number int, label string) error
+	GetIssueLabels(org, repo string, number int) ([]github.Label, error)
+	CreateComment(org, repo string, number int, comment string) error
+}
+
+type commentPruner interface {
+	PruneComments(shouldPrune func(github.IssueComment) bool)
+}
+
+func handlePullRequest(pc plugins.Agent, pre github.PullRequestEvent) error {
+	switch pre.Action {
+	case github.PullRequestActionOpened, github.PullRequestActionReopened, github.PullRequestActionEdited:
+	default:
+		return nil
+	}
+	if pre.PullRequest.State != github.PullRequestStateOpen {
+		return nil
+	}
+	cp, err := pc.CommentPruner()
+	if err != nil {
+		return err
+	}
+	return handle(pc.GitHubClient, pc.Logger, cp, optionsForRepo(pc.PluginConfig, pre.Repo.Owner.Login, pre.Repo.Name), pre)
+}
+
+func handle(gc githubClient, log *logrus.Entry, cp commentPruner, opts *plugins.PRDescription, pre github.PullRequestEvent) error {
+	org, repo, number := pre.Repo.Owner.Login, pre.Repo.Name, pre.Number
+
+	problems := check(opts, pre.PullRequest.Body)
+
+	issueLabels, err := gc.GetIssueLabels(org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get the labels of %s/%s#%d: %w", org, repo, number, err)
+	}
+	hasLabel := github.HasLabel(labels.MissingDescription, issueLabels)
+
+	if len(problems) == 0 {
+		if hasLabel {
+			if err := gc.RemoveLabel(org, repo, number, labels.MissingDescription); err != nil {
+				log.WithError(err).Errorf("GitHub failed to remove the following label: %s", labels.MissingDescription)
+			}
+		}
+		cp.PruneComments(func(comment github.IssueComment) bool {
+			return strings.Contains(comment.Body, commentMarker)
+		})
+		return nil
+	}
+
+	if hasLabel {
+		return nil
+	}
+	if err := gc.AddLabel(org, repo, number, labels.MissingDescription); err != nil {
+		log.WithError(err).Errorf("GitHub failed to add the following label: %s", labels.MissingDescription)
+	}
+	msg := fmt.Sprintf("%s:\n\n%s\n\nPlease edit the description to fix this. The `%s` label will be removed once it does.",
+		commentMarker, strings.Join(problems, "\n"), labels.MissingDescription)
+	comment := plugins.FormatSimpleResponse(fmt.Sprintf("@%s: %s", pre.PullRequest.User.Login, msg))
+	if err := gc.CreateComment(org, repo, number, comment); err != nil {
+		log.WithError(err).Errorf("Failed to comment %q", comment)
+	}
+	return nil
+}
+
+// policy describes the description policy as a markdown list.
+func policy(opts *plugins.PRDescription) []string {
+	var items []string
+	if opts.MinLength > 0 {
+		items = append(items, fmt.Sprintf("- It must be at least %d characters long.", opts.MinLength))
+	}
+	if opts.RequireLinkedIssue {
+		items = append(items, "- It must reference an issue or PR, e.g. with `Fixes #123`.")
+	}
+	for _, section := range opts.RequiredSections {
+		items = append(items, fmt.Sprintf("- It must fill in a `%s` section.", section))
+	}
+	if len(items) == 0 {
+		items = append(items, "- It must not be empty.")
+	}
+	return items
+}
+
+// check returns the problems of the description as a markdown list.
+func check(opts *plugins.PRDescription, body string) []string {
+	// Comments are the instructions of PR templates, so they do not count
+	// towards the description.
+	body = strings.TrimSpace(commentRe.ReplaceAllString(strings.ReplaceAll(body, "\r\n", "\n"), ""))
+
+	var problems []string
+	if body == "" {
+		problems = append(problems, "- It is empty.")
+	} else if length := len([]rune(body)); length < opts.MinLength {
+		problems = append(problems, fmt.Sprintf("- It is %d characters long instead of at least %d.", length, opts.MinLength))
+	}
+	if opts.RequireLinkedIssue && !linkedIssueRe.MatchString(body) {
+		problems = append(problems, "- It does not reference an issue or PR, e.g. with `Fixes #123`.")
+	}
+	filled := filledSections(body)
+	for _, section := range opts.RequiredSections {
+		if !filled.Has(strings.ToLower(strings.TrimSpace(section))) {
+			problems = append(problems, fmt.Sprintf("- It does not fill in a `%s` section.", section))
+		}
+	}
+	return problems
+}
+
+// filledSections returns the lowercased headings of the sections of the
+// description that have content.
+func filledSections(body string) sets.Set[string] {
+	filled := sets.New[string]()
+	heading := ""
+	for _, line := range strings.Split(body, "\n") {
+		if match := headingRe.FindStringSubmatch(line); match != nil {
+			heading = strings.ToLower(match[1])
+			continue
+		}
+		if heading != "" && strings.TrimSpace(line) != "" {
+			filled.Insert(heading)
+		}
+	}
+	return filled
+}
+
+// optionsForRepo gets the plugins.PRDescription struct that is applicable to the indicated repo.
+func optionsForRepo(config *plugins.Configuration, org, repo string) *plugins.PRDescription {
+	fullName := fmt.Sprintf("%s/%s", org, repo)
+
+	// First search for repo config
+	for _, c := range config.PRDescription {
+		if !sets.New[string](c.Repos...).Has(fullName) {
+			continue
+		}
+		return &c
+	}
+
+	// If you don't find anything, loop again looking for an org config
+	for _, c := range config.PRDescription {
+		if !sets.New[string](c.Repos...).Has(org) {
+			continue
+		}
+		return &c
+	}
+
+	// Return an empty config, which only requires a description
+	return &plugins.PRDescription{}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prdescription
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/github"
+	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/labels"
+	"sigs.k8s.io/prow/pkg/plugins"
+)
+
+type fakePruner struct {
+	pruned bool
+}
+
+func (fp *fakePruner) PruneComments(shouldPrune func(github.IssueComment) bool) {
+	fp.pruned = true
+}
+
+func TestHandle(t *testing.T) {
+	const label = "org/repo#1:" + labels.MissingDescription
+	var testcases = []struct {
+		name     string
+		body     string
+		hasLabel bool
+
+		expectAdded   bool
+		expectRemoved bool
+		expectComment bool
+		expectPruned  bool
+	}{
+		{
+			name:          "non-compliant description is labeled",
+			body:          "Fixes a bug.",
+			expectAdded:   true,
+			expectComment: true,
+		},
+		{
+			name:         "compliant description is not labeled",
+			body:         "Fixes #2 by handling the error.\n\n## Testing\n\nAdded a unit test.",
+			expectPruned: true,
+		},
+		{
+			name:          "label is removed once the description complies",
+			body:          "Fixes #2 by handling the error.\n\n## Testing\n\nAdded a unit test.",
+			hasLabel:      true,
+			expectRemoved: true,
+			expectPruned:  true,
+		},
+		{
+			name:     "labeled PR is not commented on again",
+			body:     "Fixes a bug.",
+			hasLabel: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := fakegithub.NewFakeClient()
+			if tc.hasLabel {
+				fc.IssueLabelsExisting = []string{label}
+			}
+			fp := &fakePruner{}
+			opts := &plugins.PRDescription{MinLength: 20, RequireLinkedIssue: true, RequiredSections: []string{"Testing"}}
+			pre := github.PullRequestEvent{
+				Action: github.PullRequestActionOpened,
+				Number: 1,
+				Repo:   github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+				PullRequest: github.PullRequest{
+					Number: 1,
+					State:  github.PullRequestStateOpen,
+					Body:   tc.body,
+					User:   github.User{Login: "author"},
+				},
+			}
+
+			if err := handle(fc, logrus.WithField("plugin", PluginName), fp, opts, pre); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if added := reflect.DeepEqual(fc.IssueLabelsAdded, []string{label}); added != tc.expectAdded {
+				t.Errorf("expected the label to be added: %t, got labels added: %v", tc.expectAdded, fc.IssueLabelsAdded)
+			}
+			if removed := reflect.DeepEqual(fc.IssueLabelsRemoved, []string{label}); removed != tc.expectRemoved {
+				t.Errorf("expected the label to be removed: %t, got labels removed: %v", tc.expectRemoved, fc.IssueLabelsRemoved)
+			}
+			if commented := len(fc.IssueComments[1]) == 1 && strings.Contains(fc.IssueComments[1][0].Body, commentMarker); commented != tc.expectComment {
+				t.Errorf("expected a comment: %t, got comments: %v", tc.expectComment, fc.IssueComments[1])
+			}
+			if fp.pruned != tc.expectPruned {
+				t.Errorf("expected comments to be pruned: %t, got: %t", tc.expectPruned, fp.pruned)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var testcases = []struct {
+		name     string
+		opts     plugins.PRDescription
+		body     string
+		expected []string
+	}{
+		{
+			name:     "empty description",
+			body:     " \n ",
+			expected: []string{"- It is empty."},
+		},
+		{
+			name:     "description made of template comments is empty",
+			body:     "<!-- Describe your change.\nThanks! -->\n",
+			expected: []string{"- It is empty."},
+		},
+		{
+			name: "any description complies without a policy",
+			body: "Fix.",
+		},
+		{
+			name:     "short description",
+			opts:     plugins.PRDescription{MinLength: 10},
+			body:     "<!-- Describe your change. -->\nFix.",
+			expected: []string{"- It is 4 characters long instead of at least 10."},
+		},
+		{
+			name: "long enough description",
+			opts: plugins.PRDescription{MinLength: 10},
+			body: "Fixes the bug.",
+		},
+		{
+			name:     "description without a linked issue",
+			opts:     plugins.PRDescription{RequireLinkedIssue: true},
+			body:     "Fixes the bug of kubernetes/test-infra.",
+			expected: []string{"- It does not reference an issue or PR, e.g. with `Fixes #123`."},
+		},
+		{
+			name: "description linking an issue",
+			opts: plugins.PRDescription{RequireLinkedIssue: true},
+			body: "Fixes #123.",
+		},
+		{
+			name: "description linking an issue of another repo",
+			opts: plugins.PRDescription{RequireLinkedIssue: true},
+			body: "Part of kubernetes/kubernetes#123.",
+		},
+		{
+			name: "description linking an issue by URL",
+			opts: plugins.PRDescription{RequireLinkedIssue: true},
+			body: "See https://github.com/kubernetes/kubernetes/issues/123.",
+		},
+		{
+			name: "description with required sections",
+			opts: plugins.PRDescription{RequiredSections: []string{"Testing", "Release Note"}},
+			body: "Fix.\n\n### testing\nUnit tests.\n\n## Release note ##\nNone",
+		},
+		{
+			name: "description with empty or missing required sections",
+			opts: plugins.PRDescription{RequiredSections: []string{"Testing", "Release Note"}},
+			body: "Fix.\n\n### Testing\n<!-- How was this tested? -->\n\n## Notes\nNone",
+			expected: []string{
+				"- It does not fill in a `Testing` section.",
+				"- It does not fill in a `Release Note` section.",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := check(&tc.opts, tc.body); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected problems %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
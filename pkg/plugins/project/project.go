@@ -34,38 +34,36 @@ import (
 
 const (
 	pluginName = "project"
+	// statusField is the single-select field set by `/project <project> <status>`.
+	statusField = "Status"
 )
 
 var (
 	projectRegex              = regexp.MustCompile(`(?m)^/project\s(.*?)$`)
 	notTeamConfigMsg          = "There is no maintainer team for this repo or org."
-	notATeamMemberMsg         = "You must be a member of the [%s/%s](https://github.com/orgs/%s/teams/%s/members) github team to set the project and column."
-	invalidProject            = "The provided project is not valid for this organization. Projects in Kubernetes orgs and repositories: [%s]."
-	invalidColumn             = "A column is not provided or it's not valid for the project %s. Please provide one of the following columns in the command:\n%v"
-	invalidNumArgs            = "Please provide 1 or more arguments. Example usage: /project 0.5.0, /project 0.5.0 To do, /project clear 0.4.0"
+	notATeamMemberMsg         = "You must be a member of the [%s/%s](https://github.com/orgs/%s/teams/%s/members) github team to set the project and its fields."
+	invalidProject            = "The provided project is not valid for this organization. Open projects of the %s organization: [%s]."
+	invalidField              = "The project %s has no single-select field %s. Please provide one of the following fields in the command:\n%v"
+	invalidOption             = "A %s is not provided or it's not valid for the project %s. Please provide one of the following options in the command:\n%v"
+	invalidNumArgs            = "Please provide 1 or more arguments. Example usage: /project 0.5.0, /project 0.5.0 Todo, /project 0.5.0 Priority=P1, /project clear 0.4.0"
 	projectTeamMsg            = "The project maintainers team is the github team with ID: %d."
-	columnsMsg                = "An issue/PR with unspecified column will be added to one of the following columns: %v."
-	successMovingCardMsg      = "You have successfully moved the project card for this issue to column %s (ID %d)."
-	successCreatingCardMsg    = "You have successfully created a project card for this issue. It's been added to project %s column %s (ID %D)."
+	statusesMsg               = "An issue/PR with unspecified status will be added with one of the following statuses: %v."
+	successSettingFieldMsg    = "You have successfully set the %s of this issue/PR in project %s to %s."
+	successAddingItemMsg      = "You have successfully added this issue/PR to project %s."
 	successClearingProjectMsg = "You have successfully removed this issue/PR from project %s."
 	failedClearingProjectMsg  = "The project %q is not valid for the issue/PR %v. Please provide a valid project to which this issue belongs."
 	clearKeyword              = "clear"
-	projectNameToIDMap        = make(map[string]int)
 )
 
 type githubClient interface {
 	BotUserChecker() (func(candidate string) bool, error)
 	CreateComment(owner, repo string, number int, comment string) error
-	ListTeamMembers(org string, id int, role string) ([]github.TeamMember, error)
-	GetRepos(org string, isUser bool) ([]github.Repo, error)
-	GetRepoProjects(owner, repo string) ([]github.Project, error)
-	GetOrgProjects(org string) ([]github.Project, error)
-	GetProjectColumns(org string, projectID int) ([]github.ProjectColumn, error)
-	CreateProjectCard(org string, columnID int, projectCard github.ProjectCard) (*github.ProjectCard, error)
-	GetColumnProjectCard(org string, columnID int, contentURL string) (*github.ProjectCard, error)
-	MoveProjectCard(org string, projectCardID int, newColumnID int) error
-	DeleteProjectCard(org string, projectCardID int) error
 	TeamHasMember(org string, teamID int, memberLogin string) (bool, error)
+	GetOrgProjectsV2(org string) ([]github.ProjectV2, error)
+	GetIssueProjectV2Items(org, repo string, number int) (string, []github.ProjectV2ContentItem, error)
+	AddProjectV2Item(org, projectNodeID, contentNodeID string) (string, error)
+	DeleteProjectV2Item(org, projectNodeID, itemNodeID string) error
+	SetProjectV2ItemSingleSelectValue(org, projectNodeID, itemNodeID, fieldNodeID, optionID string) error
 }
 
 func init() {
@@ -83,7 +81,7 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		}
 
 		if columnMap := projectConfig.GetColumnMap(repo.Org, repo.Repo); len(columnMap) != 0 {
-			configInfo[repo.String()] = fmt.Sprintf(columnsMsg, columnMap)
+			configInfo[repo.String()] = fmt.Sprintf(statusesMsg, columnMap)
 		}
 	}
 	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
@@ -92,14 +90,14 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 				"org": {
 					MaintainerTeamID: 123456,
 					ProjectColumnMap: map[string]string{
-						"project1": "To do",
+						"project1": "Todo",
 						"project2": "Backlog",
 					},
 					Repos: map[string]plugins.ProjectRepoConfig{
 						"repo": {
 							MaintainerTeamID: 123456,
 							ProjectColumnMap: map[string]string{
-								"project3": "To do",
+								"project3": "Todo",
 								"project4": "Backlog",
 							},
 						},
@@ -112,16 +110,16 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		logrus.WithError(err).Warnf("cannot generate comments for %s plugin", pluginName)
 	}
 	pluginHelp := &pluginhelp.PluginHelp{
-		Description: "The project plugin allows members of a GitHub team to set the project and column on an issue or pull request.",
+		Description: "The project plugin allows members of a GitHub team to add an issue or pull request to a GitHub project of the org and to set its status or other single-select fields.",
 		Config:      configInfo,
 		Snippet:     yamlSnippet,
 	}
 	pluginHelp.AddCommand(pluginhelp.Command{
-		Usage:       "/project <board>, /project <board> <column>, or /project clear <board>",
-		Description: "Add an issue or PR to a project board and column",
+		Usage:       "/project <project>, /project <project> <status>, /project <project> <field>=<option>, or /project clear <project>",
+		Description: "Add an issue or PR to a project and set its status or another single-select field, or remove it from the project",
 		Featured:    false,
 		WhoCanUse:   "Members of the project maintainer GitHub team can use the '/project' command.",
-		Examples:    []string{"/project 0.5.0", "/project 0.5.0 To do", "/project clear 0.4.0"},
+		Examples:    []string{"/project 0.5.0", "/project 0.5.0 'In Progress'", "/project 0.5.0 Priority=P1", "/project clear 0.4.0"},
 	})
 	return pluginHelp, nil
 }
@@ -130,14 +128,8 @@ func handleGenericComment(pc plugins.Agent, e github.GenericCommentEvent) error
 	return handle(pc.GitHubClient, pc.Logger, &e, pc.PluginConfig.Project)
 }
 
-func updateProjectNameToIDMap(projects []github.Project) {
-	for _, project := range projects {
-		projectNameToIDMap[project.Name] = project.ID
-	}
-}
-
 // processCommand processes the user command regex matches and returns the proposed project name,
-// proposed column name, whether the command is to remove issue/PR from project,
+// proposed status or field assignment, whether the command is to remove issue/PR from project,
 // and the error message
 func processCommand(match string) (string, string, bool, string) {
 	proposedProject := ""
@@ -203,7 +195,7 @@ func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, p
 
 	org := e.Repo.Owner.Login
 	repo := e.Repo.Name
-	proposedProject, proposedColumnName, shouldClear, msg := processCommand(matches[1])
+	proposedProject, proposedValue, shouldClear, msg := processCommand(matches[1])
 	if proposedProject == "" {
 		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
 	}
@@ -222,169 +214,125 @@ func handle(gc githubClient, log *logrus.Entry, e *github.GenericCommentEvent, p
 		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
 	}
 
-	var projects []github.Project
-
-	// see if the project in the same repo as the issue/pr
-	repoProjects, err := gc.GetRepoProjects(org, repo)
-	if err == nil {
-		projects = append(projects, repoProjects...)
+	projects, err := gc.GetOrgProjectsV2(org)
+	if err != nil {
+		return err
 	}
-	updateProjectNameToIDMap(projects)
-
-	var ok bool
-	// Only fetch the other repos in the org if we did not find the project in the same repo as the issue/pr
-	if _, ok = projectNameToIDMap[proposedProject]; !ok {
-		repos, err := gc.GetRepos(org, false)
-		if err != nil {
-			return err
-		}
-		// Get all projects for all repos
-		for _, repo := range repos {
-			repoProjects, err := gc.GetRepoProjects(org, repo.Name)
-			if err != nil {
-				return err
-			}
-			projects = append(projects, repoProjects...)
+	var project *github.ProjectV2
+	var titles []string
+	for i := range projects {
+		if projects[i].Title == proposedProject {
+			project = &projects[i]
 		}
+		titles = append(titles, fmt.Sprintf("`%s`", projects[i].Title))
+	}
+	if project == nil {
+		sort.Strings(titles)
+		msg = fmt.Sprintf(invalidProject, org, strings.Join(titles, ", "))
+		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
 	}
-	// Only fetch org projects if we can't find the proposed project / project to clear in the repo projects
-	updateProjectNameToIDMap(projects)
-
-	var projectID int
-	if projectID, ok = projectNameToIDMap[proposedProject]; !ok {
-		// Get all projects for this org
-		orgProjects, err := gc.GetOrgProjects(org)
-		if err != nil {
-			return err
-		}
-		projects = append(projects, orgProjects...)
-
-		// If still can't find proposed project / project to clear in the list of projects, abort and create a comment
-		updateProjectNameToIDMap(projects)
-		if projectID, ok = projectNameToIDMap[proposedProject]; !ok {
-			slice := make([]string, 0, len(projectNameToIDMap))
-			for k := range projectNameToIDMap {
-				slice = append(slice, fmt.Sprintf("`%s`", k))
-			}
-			sort.Strings(slice)
 
-			msg = fmt.Sprintf(invalidProject, strings.Join(slice, ", "))
+	var field *github.ProjectV2Field
+	var option *github.ProjectV2FieldOption
+	if !shouldClear {
+		field, option, msg = resolveFieldValue(*project, proposedValue, projectConfig, org, repo)
+		if msg != "" {
 			return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
 		}
 	}
 
-	// Get all columns for proposedProject
-	projectColumns, err := gc.GetProjectColumns(org, projectID)
+	contentID, items, err := gc.GetIssueProjectV2Items(org, repo, e.Number)
 	if err != nil {
 		return err
 	}
-
-	// If proposedColumnName is not found (or not provided), add to one of the default
-	// columns. If none of the default columns exists, an error will be shown to the user
-	columnFound := false
-	proposedColumnID := 0
-	for _, c := range projectColumns {
-		if c.Name == proposedColumnName {
-			columnFound = true
-			proposedColumnID = c.ID
+	var existingItem *github.ProjectV2ContentItem
+	for i := range items {
+		if items[i].ProjectID == project.ID {
+			existingItem = &items[i]
 			break
 		}
 	}
-	if !columnFound && !shouldClear {
-		// If user does not provide a column name, look for the columns
-		// specified in the project config and see if any of them exists on the
-		// proposed project
-		if proposedColumnName == "" {
-			defaultColumn, exists := projectConfig.GetColumnMap(org, repo)[proposedProject]
-			if !exists {
-				// Try to find the proposedProject in the org config in case the
-				// project is on the org level
-				defaultColumn, exists = projectConfig.GetOrgColumnMap(org)[proposedProject]
-			}
-			if exists {
-				// See if the default column exists in the actual list of project columns
-				for _, pc := range projectColumns {
-					if pc.Name == defaultColumn {
-						proposedColumnID = pc.ID
-						proposedColumnName = pc.Name
-						columnFound = true
-						break
-					}
-				}
-			}
-		}
-		// In this case, user does not provide the column name in the command,
-		// or the provided column name cannot be found, and none of the default
-		// columns are available in the proposed project. An error will be
-		// shown to the user
-		if !columnFound {
-			projectColumnNames := []string{}
-			for _, c := range projectColumns {
-				projectColumnNames = append(projectColumnNames, c.Name)
-			}
-			msg = fmt.Sprintf(invalidColumn, proposedProject, projectColumnNames)
+
+	// Remove the issue/PR from the project if the command is to clear
+	if shouldClear {
+		if existingItem == nil {
+			msg = fmt.Sprintf(failedClearingProjectMsg, proposedProject, e.Number)
 			return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
 		}
-	}
-
-	// Move this issue/PR to the new column if there's already a project card for
-	// this issue/PR in this project
-	var existingProjectCard *github.ProjectCard
-	var foundColumnID int
-	for _, colID := range projectColumns {
-		// make issue URL in the form of card content URL
-		issueURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%v", org, repo, e.Number)
-		existingProjectCard, err = gc.GetColumnProjectCard(org, colID.ID, issueURL)
-		if err != nil {
+		if err := gc.DeleteProjectV2Item(org, project.ID, existingItem.ID); err != nil {
 			return err
 		}
-
-		if existingProjectCard != nil {
-			foundColumnID = colID.ID
-			break
-		}
+		msg = fmt.Sprintf(successClearingProjectMsg, proposedProject)
+		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
 	}
 
-	// no need to move the card if it is in the same column
-	if (existingProjectCard != nil) && (proposedColumnID == foundColumnID) {
+	// Nothing to do if the issue/PR is already in the project with the
+	// requested value
+	if existingItem != nil && (field == nil || existingItem.FieldValues[field.Name] == option.Name) {
 		return nil
 	}
 
-	// Clear issue/PR from project if command is to clear
-	if shouldClear {
-		if existingProjectCard != nil {
-			if err := gc.DeleteProjectCard(org, existingProjectCard.ID); err != nil {
-				return err
-			}
-			msg = fmt.Sprintf(successClearingProjectMsg, proposedProject)
-			return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
+	itemID := ""
+	if existingItem != nil {
+		itemID = existingItem.ID
+	} else {
+		log.Infof("Adding issue %d to project %s.", e.Number, project.Title)
+		if itemID, err = gc.AddProjectV2Item(org, project.ID, contentID); err != nil {
+			return err
 		}
-		msg = fmt.Sprintf(failedClearingProjectMsg, proposedProject, e.Number)
-		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
+		msg = fmt.Sprintf(successAddingItemMsg, proposedProject)
 	}
-
-	// Move this issue/PR to the new column if there's already a project card for this issue/PR in this project
-	if existingProjectCard != nil {
-		log.Infof("Move card to column proposedColumnID: %v with issue: %v ", proposedColumnID, e.Number)
-		if err := gc.MoveProjectCard(org, existingProjectCard.ID, proposedColumnID); err != nil {
+	if field != nil {
+		log.Infof("Setting %s of issue %d in project %s to %s.", field.Name, e.Number, project.Title, option.Name)
+		if err := gc.SetProjectV2ItemSingleSelectValue(org, project.ID, itemID, field.ID, option.ID); err != nil {
 			return err
 		}
-		msg = fmt.Sprintf(successMovingCardMsg, proposedColumnName, proposedColumnID)
-		return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
+		msg = fmt.Sprintf(successSettingFieldMsg, field.Name, proposedProject, option.Name)
+		if existingItem == nil {
+			msg = fmt.Sprintf(successAddingItemMsg, proposedProject) + " " + msg
+		}
 	}
+	return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
+}
 
-	projectCard := github.ProjectCard{}
-	projectCard.ContentID = e.ID
-	if e.IsPR {
-		projectCard.ContentType = "PullRequest"
-	} else {
-		projectCard.ContentType = "Issue"
+// resolveFieldValue returns the single-select field and option of the project
+// to set, or an error message for the user. The proposed value is either
+// `<field>=<option>` or the name of a status. If it is empty, the default
+// status of the project from the config is used, if any.
+func resolveFieldValue(project github.ProjectV2, proposedValue string, projectConfig plugins.ProjectConfig, org, repo string) (*github.ProjectV2Field, *github.ProjectV2FieldOption, string) {
+	fieldName, optionName := statusField, proposedValue
+	if name, value, ok := strings.Cut(proposedValue, "="); ok {
+		fieldName, optionName = strings.TrimSpace(name), strings.TrimSpace(value)
 	}
-
-	if _, err := gc.CreateProjectCard(org, proposedColumnID, projectCard); err != nil {
-		return err
+	if optionName == "" && fieldName == statusField {
+		defaultStatus, exists := projectConfig.GetColumnMap(org, repo)[project.Title]
+		if !exists {
+			// Try to find the project in the org config in case the project
+			// is configured on the org level
+			defaultStatus, exists = projectConfig.GetOrgColumnMap(org)[project.Title]
+		}
+		if !exists {
+			// The issue/PR is only added to the project
+			return nil, nil, ""
+		}
+		optionName = defaultStatus
 	}
 
-	msg = fmt.Sprintf(successCreatingCardMsg, proposedProject, proposedColumnName, proposedColumnID)
-	return gc.CreateComment(org, repo, e.Number, plugins.FormatResponseRaw(e.Body, e.HTMLURL, e.User.Login, msg))
+	var fieldNames []string
+	for i := range project.Fields {
+		field := &project.Fields[i]
+		fieldNames = append(fieldNames, field.Name)
+		if !strings.EqualFold(field.Name, fieldName) {
+			continue
+		}
+		var optionNames []string
+		for j := range field.Options {
+			if strings.EqualFold(field.Options[j].Name, optionName) {
+				return field, &field.Options[j], ""
+			}
+			optionNames = append(optionNames, field.Options[j].Name)
+		}
+		return nil, nil, fmt.Sprintf(invalidOption, strings.ToLower(field.Name), project.Title, optionNames)
+	}
+	return nil, nil, fmt.Sprintf(invalidField, project.Title, fieldName, fieldNames)
 }
@@ -23,58 +23,35 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
+
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
 	"sigs.k8s.io/prow/pkg/plugins"
 )
 
 func TestProjectCommand(t *testing.T) {
-	projectColumnsMap := map[string][]github.ProjectColumn{
-		"0.0.0": {
-			github.ProjectColumn{
-				Name: "To do",
-				ID:   00000,
-			},
-			github.ProjectColumn{
-				Name: "Backlog",
-				ID:   00001,
-			},
-		},
-		"0.1.0": {
-			github.ProjectColumn{
-				Name: "To do",
-				ID:   00002,
-			},
-			github.ProjectColumn{
-				Name: "Backlog",
-				ID:   00003,
-			},
+	statusField := github.ProjectV2Field{
+		ID:   "status",
+		Name: "Status",
+		Options: []github.ProjectV2FieldOption{
+			{ID: "todo", Name: "To do"},
+			{ID: "backlog", Name: "Backlog"},
 		},
 	}
-	repoProjects := map[string][]github.Project{
-		"kubernetes/*": {
-			github.Project{
-				Name: "0.0.0",
-				ID:   000,
-			},
-		},
-		"kubernetes/kubernetes": {
-			github.Project{
-				Name: "0.1.0",
-				ID:   010,
-			},
+	priorityField := github.ProjectV2Field{
+		ID:   "priority",
+		Name: "Priority",
+		Options: []github.ProjectV2FieldOption{
+			{ID: "p1", Name: "P1"},
+			{ID: "p2", Name: "P2"},
 		},
 	}
-	// Maps github project name to maps of column IDs to column string
-	columnIDMap := map[string]map[int]string{
-		"0.0.0": {
-			00000: "To do",
-			00001: "Backlog",
-		},
-		"0.1.0": {
-			00002: "To do",
-			00003: "Backlog",
+	orgProjects := map[string][]github.ProjectV2{
+		"kubernetes": {
+			{ID: "PVT_0", Title: "0.0.0", Fields: []github.ProjectV2Field{statusField, priorityField}},
+			{ID: "PVT_1", Title: "0.1.0", Fields: []github.ProjectV2Field{statusField}},
 		},
 	}
 
@@ -96,9 +73,12 @@ func TestProjectCommand(t *testing.T) {
 					"community": {
 						MaintainerTeamID: 0,
 						ProjectColumnMap: map[string]string{
-							"0.1.0": "does not exist column",
+							"0.1.0": "does not exist status",
 						},
 					},
+					"test-infra": {
+						MaintainerTeamID: 0,
+					},
 				},
 			},
 		},
@@ -107,242 +87,219 @@ func TestProjectCommand(t *testing.T) {
 	type testCase struct {
 		name            string
 		action          github.GenericCommentEventAction
-		noAction        bool
 		body            string
 		repo            string
-		org             string
 		commenter       string
-		previousProject string
-		previousColumn  string
-		expectedProject string
-		expectedColumn  string
+		previousItems   []github.ProjectV2ContentItem
+		expectedItems   []github.ProjectV2ContentItem
 		expectedComment string
 	}
 
 	testcases := []testCase{
 		{
-			name:            "Setting project and column with valid values, but commenter does not belong to the project maintainer team",
+			name:            "Setting project and status with valid values, but commenter does not belong to the project maintainer team",
 			action:          github.GenericCommentActionCreated,
 			body:            "/project 0.0.0 To do",
 			repo:            "kubernetes",
-			org:             "kubernetes",
 			commenter:       "random-user",
-			previousProject: "",
-			previousColumn:  "",
-			expectedProject: "",
-			expectedColumn:  "",
 			expectedComment: "@random-user: " + fmt.Sprintf(notATeamMemberMsg, "kubernetes", "kubernetes", "kubernetes", "kubernetes"),
 		},
 		{
-			name:            "Setting project and column with valid values; project card does not currently exist for this issue/PR in the project",
-			action:          github.GenericCommentActionCreated,
-			body:            "/project 0.0.0 To do",
-			repo:            "kubernetes",
-			org:             "kubernetes",
-			commenter:       "sig-lead",
-			previousProject: "",
-			previousColumn:  "",
-			expectedProject: "0.0.0",
-			expectedColumn:  "To do",
+			name:      "Setting project and status with valid values; the issue/PR is not in the project yet",
+			action:    github.GenericCommentActionCreated,
+			body:      "/project 0.0.0 To do",
+			repo:      "kubernetes",
+			commenter: "sig-lead",
+			expectedItems: []github.ProjectV2ContentItem{
+				{ID: "PVT_0:kubernetes/kubernetes#1", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "To do"}},
+			},
+			expectedComment: fmt.Sprintf(successAddingItemMsg, "0.0.0") + " " + fmt.Sprintf(successSettingFieldMsg, "Status", "0.0.0", "To do"),
 		},
 		{
-			name:            "Setting project and column with valid values; project card already exist for this issue/PR in the project, but the project card is under a different column",
-			action:          github.GenericCommentActionCreated,
-			body:            "/project 0.0.0 To do",
-			repo:            "kubernetes",
-			org:             "kubernetes",
-			commenter:       "sig-lead",
-			previousProject: "",
-			previousColumn:  "",
-			expectedProject: "0.0.0",
-			expectedColumn:  "To do",
+			name:      "Setting project and status with valid values; the issue/PR is already in the project with a different status",
+			action:    github.GenericCommentActionCreated,
+			body:      "/project 0.0.0 to do",
+			repo:      "kubernetes",
+			commenter: "sig-lead",
+			previousItems: []github.ProjectV2ContentItem{
+				{ID: "item", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "Backlog", "Priority": "P1"}},
+			},
+			expectedItems: []github.ProjectV2ContentItem{
+				{ID: "item", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "To do", "Priority": "P1"}},
+			},
+			expectedComment: fmt.Sprintf(successSettingFieldMsg, "Status", "0.0.0", "To do"),
 		},
 		{
-			name:            "Setting project without column value; the project specified exists on the repo level; the default column is set on the project and it exists on the project",
-			action:          github.GenericCommentActionCreated,
-			body:            "/project 0.1.0",
-			repo:            "kubernetes",
-			org:             "kubernetes",
-			commenter:       "sig-lead",
-			previousProject: "0.0.0",
-			previousColumn:  "Backlog",
-			expectedProject: "0.1.0",
-			expectedColumn:  "To do",
+			name:      "Setting project and status; the issue/PR already has the status",
+			action:    github.GenericCommentActionCreated,
+			body:      "/project 0.0.0 Backlog",
+			repo:      "kubernetes",
+			commenter: "sig-lead",
+			previousItems: []github.ProjectV2ContentItem{
+				{ID: "item", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "Backlog"}},
+			},
+			expectedItems: []github.ProjectV2ContentItem{
+				{ID: "item", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "Backlog"}},
+			},
 		},
 		{
-			name:            "Setting project without column value; the project specified exists on the org level; the default column is set on the project and it exists on the project",
+			name:      "Setting another single-select field",
+			action:    github.GenericCommentActionCreated,
+			body:      "/project 0.0.0 Priority=P2",
+			repo:      "kubernetes",
+			commenter: "sig-lead",
+			previousItems: []github.ProjectV2ContentItem{
+				{ID: "item", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "Backlog"}},
+			},
+			expectedItems: []github.ProjectV2ContentItem{
+				{ID: "item", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "Backlog", "Priority": "P2"}},
+			},
+			expectedComment: fmt.Sprintf(successSettingFieldMsg, "Priority", "0.0.0", "P2"),
+		},
+		{
+			name:            "Setting a field the project does not have",
 			action:          github.GenericCommentActionCreated,
-			body:            "/project 0.0.0",
+			body:            "/project 0.1.0 Priority=P2",
 			repo:            "kubernetes",
-			org:             "kubernetes",
 			commenter:       "sig-lead",
-			previousProject: "",
-			previousColumn:  "",
-			expectedProject: "0.0.0",
-			expectedColumn:  "Backlog",
+			expectedComment: fmt.Sprintf(invalidField, "0.1.0", "Priority", []string{"Status"}),
 		},
 		{
-			name:            "Setting project without column value; the default column is set on the project but it does not exist on the project",
-			action:          github.GenericCommentActionCreated,
-			body:            "/project 0.1.0",
-			repo:            "community",
-			org:             "kubernetes",
-			commenter:       "default-sig-lead",
-			previousProject: "0.0.0",
-			previousColumn:  "Backlog",
-			expectedProject: "0.0.0",
-			expectedColumn:  "Backlog",
-			expectedComment: "@default-sig-lead: " + fmt.Sprintf(invalidColumn, "0.1.0", []string{"To do", "Backlog"}),
+			name:      "Setting project without status; the default status is set on the repo level",
+			action:    github.GenericCommentActionCreated,
+			body:      "/project 0.1.0",
+			repo:      "kubernetes",
+			commenter: "sig-lead",
+			previousItems: []github.ProjectV2ContentItem{
+				{ID: "item", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "Backlog"}},
+			},
+			expectedItems: []github.ProjectV2ContentItem{
+				{ID: "item", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "Backlog"}},
+				{ID: "PVT_1:kubernetes/kubernetes#1", ProjectID: "PVT_1", FieldValues: map[string]string{"Status": "To do"}},
+			},
+			expectedComment: fmt.Sprintf(successAddingItemMsg, "0.1.0") + " " + fmt.Sprintf(successSettingFieldMsg, "Status", "0.1.0", "To do"),
 		},
 		{
-			name:            "Setting project with invalid column value; an error will be returned",
-			action:          github.GenericCommentActionCreated,
-			body:            "/project 0.1.0 Random 2",
-			repo:            "kubernetes",
-			org:             "kubernetes",
-			commenter:       "sig-lead",
-			previousProject: "",
-			previousColumn:  "",
-			expectedProject: "",
-			expectedColumn:  "",
-			expectedComment: "@sig-lead: " + fmt.Sprintf(invalidColumn, "0.1.0", []string{"To do", "Backlog"}),
+			name:      "Setting project without status; the default status is set on the org level",
+			action:    github.GenericCommentActionCreated,
+			body:      "/project 0.0.0",
+			repo:      "kubernetes",
+			commenter: "sig-lead",
+			expectedItems: []github.ProjectV2ContentItem{
+				{ID: "PVT_0:kubernetes/kubernetes#1", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "Backlog"}},
+			},
+			expectedComment: fmt.Sprintf(successAddingItemMsg, "0.0.0") + " " + fmt.Sprintf(successSettingFieldMsg, "Status", "0.0.0", "Backlog"),
 		},
 		{
-			name:            "Clearing project for a issue/PR; the project name provided is valid",
-			action:          github.GenericCommentActionCreated,
-			body:            "/project clear 0.0.0",
-			repo:            "kubernetes",
-			org:             "kubernetes",
-			commenter:       "sig-lead",
-			previousProject: "0.0.0",
-			previousColumn:  "Backlog",
-			expectedProject: "0.0.0",
-			expectedColumn:  "Backlog",
+			name:      "Setting project without status; no default status is set",
+			action:    github.GenericCommentActionCreated,
+			body:      "/project 0.1.0",
+			repo:      "test-infra",
+			commenter: "default-sig-lead",
+			expectedItems: []github.ProjectV2ContentItem{
+				{ID: "PVT_1:kubernetes/test-infra#1", ProjectID: "PVT_1", FieldValues: map[string]string{}},
+			},
+			expectedComment: fmt.Sprintf(successAddingItemMsg, "0.1.0"),
 		},
 		{
-			name:            "Setting project with invalid project name",
+			name:            "Setting project without status; the default status does not exist on the project",
 			action:          github.GenericCommentActionCreated,
-			body:            "/project invalidprojectname",
+			body:            "/project 0.1.0",
 			repo:            "community",
-			org:             "kubernetes",
 			commenter:       "default-sig-lead",
-			previousProject: "",
-			previousColumn:  "",
-			expectedProject: "",
-			expectedColumn:  "",
-			expectedComment: "@default-sig-lead: " + fmt.Sprintf(invalidProject, "`0.0.0`, `0.1.0`"),
+			expectedComment: "@default-sig-lead: " + fmt.Sprintf(invalidOption, "status", "0.1.0", []string{"To do", "Backlog"}),
 		},
 		{
-			name:            "Clearing project for a issue/PR; the project name provided is invalid",
+			name:            "Setting project with invalid status; an error will be returned",
 			action:          github.GenericCommentActionCreated,
-			body:            "/project clear invalidprojectname",
+			body:            "/project 0.1.0 Random 2",
 			repo:            "kubernetes",
-			org:             "kubernetes",
 			commenter:       "sig-lead",
-			previousProject: "0.1.0",
-			previousColumn:  "To do",
-			expectedProject: "0.1.0",
-			expectedColumn:  "To do",
-			expectedComment: "@sig-lead: " + fmt.Sprintf(invalidProject, "`0.0.0`, `0.1.0`"),
+			expectedComment: fmt.Sprintf(invalidOption, "status", "0.1.0", []string{"To do", "Backlog"}),
 		},
 		{
-			name:            "Clearing project for a issue/PR; the project does not contain the card",
+			name:            "Setting an invalid project; an error will be returned",
 			action:          github.GenericCommentActionCreated,
-			body:            "/project clear 0.1.0",
+			body:            "/project 0.0.0found",
 			repo:            "kubernetes",
-			org:             "kubernetes",
 			commenter:       "sig-lead",
-			previousProject: "0.1.0",
-			previousColumn:  "To do",
-			expectedProject: "0.1.0",
-			expectedColumn:  "To do",
-			expectedComment: "@sig-lead: " + fmt.Sprintf(failedClearingProjectMsg, "0.1.0", "1"),
+			expectedComment: fmt.Sprintf(invalidProject, "kubernetes", "`0.0.0`, `0.1.0`"),
 		},
 		{
-			name:            "No action on events that are not new comments",
-			action:          github.GenericCommentActionEdited,
-			body:            "/project 0.0.0 To do",
-			repo:            "kubernetes",
-			org:             "kubernetes",
-			commenter:       "sig-lead",
-			previousProject: "",
-			previousColumn:  "",
-			expectedProject: "",
-			expectedColumn:  "",
-			noAction:        true,
+			name:      "Clearing project for an issue/PR",
+			action:    github.GenericCommentActionCreated,
+			body:      "/project clear 0.0.0",
+			repo:      "kubernetes",
+			commenter: "sig-lead",
+			previousItems: []github.ProjectV2ContentItem{
+				{ID: "item", ProjectID: "PVT_0", FieldValues: map[string]string{"Status": "Backlog"}},
+			},
+			expectedItems:   []github.ProjectV2ContentItem{},
+			expectedComment: fmt.Sprintf(successClearingProjectMsg, "0.0.0"),
 		},
 		{
-			name:            "No action on bot comments",
+			name:            "Clearing project for an issue/PR that is not in the project",
 			action:          github.GenericCommentActionCreated,
-			body:            "/project 0.0.0 To do",
+			body:            "/project clear 0.1.0",
 			repo:            "kubernetes",
-			org:             "kubernetes",
-			commenter:       fakegithub.Bot,
-			previousProject: "",
-			previousColumn:  "",
-			expectedProject: "",
-			expectedColumn:  "",
-			noAction:        true,
+			commenter:       "sig-lead",
+			expectedComment: fmt.Sprintf(failedClearingProjectMsg, "0.1.0", 1),
 		},
 		{
-			name:            "No action on non-matching comments",
+			name:            "No arguments",
 			action:          github.GenericCommentActionCreated,
-			body:            "random comment",
+			body:            "/project clear",
 			repo:            "kubernetes",
-			org:             "kubernetes",
 			commenter:       "sig-lead",
-			previousProject: "",
-			previousColumn:  "",
-			expectedProject: "",
-			expectedColumn:  "",
-			noAction:        true,
+			expectedComment: invalidNumArgs,
+		},
+		{
+			name:      "Edited comments are ignored",
+			action:    github.GenericCommentActionEdited,
+			body:      "/project 0.0.0 To do",
+			repo:      "kubernetes",
+			commenter: "sig-lead",
 		},
 	}
 
-	fakeClient := fakegithub.NewFakeClient()
-	fakeClient.RepoProjects = repoProjects
-	fakeClient.ProjectColumnsMap = projectColumnsMap
-	fakeClient.ColumnIDMap = columnIDMap
-
-	prevCommentCount := 0
 	for _, tc := range testcases {
-		tc := tc
-		fakeClient.Project = tc.previousProject
-		fakeClient.Column = tc.previousColumn
-		fakeClient.ColumnCardsMap = map[int][]github.ProjectCard{}
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakegithub.NewFakeClient()
+			fakeClient.OrgProjectsV2 = orgProjects
+			key := fmt.Sprintf("kubernetes/%s#1", tc.repo)
+			if tc.previousItems != nil {
+				fakeClient.IssueProjectV2Items[key] = tc.previousItems
+			}
 
-		e := &github.GenericCommentEvent{
-			Action:       tc.action,
-			Body:         tc.body,
-			Number:       1,
-			IssueHTMLURL: "1",
-			Repo:         github.Repo{Owner: github.User{Login: tc.org}, Name: tc.repo},
-			User:         github.User{Login: tc.commenter},
-		}
-		if err := handle(fakeClient, logrus.WithField("plugin", pluginName), e, projectConfig); err != nil {
-			t.Errorf("(%s): Unexpected error from handle: %v.", tc.name, err)
-			continue
-		}
-		if fakeClient.Project != tc.expectedProject {
-			t.Errorf("(%s): Unexpected project %s but got %s", tc.name, tc.expectedProject, fakeClient.Project)
-		}
-		if fakeClient.Column != tc.expectedColumn {
-			t.Errorf("(%s): Unexpected column %s but got %s", tc.name, tc.expectedColumn, fakeClient.Column)
-		}
-		issueComments := fakeClient.IssueComments[e.Number]
-		if tc.expectedComment != "" {
-			actualComment := issueComments[len(issueComments)-1].Body
-			// Only check for substring because the actual comment contains a lot of extra stuff
-			if !strings.Contains(actualComment, tc.expectedComment) {
-				t.Errorf("(%s): Unexpected comment\n%s\nbut got\n%s", tc.name, tc.expectedComment, actualComment)
+			e := &github.GenericCommentEvent{
+				Action:       tc.action,
+				Body:         tc.body,
+				Number:       1,
+				IssueHTMLURL: "1",
+				Repo:         github.Repo{Owner: github.User{Login: "kubernetes"}, Name: tc.repo},
+				User:         github.User{Login: tc.commenter},
 			}
-		}
-		if tc.noAction {
-			if len(issueComments) != prevCommentCount {
-				t.Errorf("(%s): No new comment should be created", tc.name)
+			if err := handle(fakeClient, logrus.WithField("plugin", pluginName), e, projectConfig); err != nil {
+				t.Fatalf("Unexpected error from handle: %v.", err)
 			}
-		}
-		prevCommentCount = len(issueComments)
+			if diff := cmp.Diff(tc.expectedItems, fakeClient.IssueProjectV2Items[key]); diff != "" {
+				t.Errorf("Unexpected project items (-want +got):\n%s", diff)
+			}
+			issueComments := fakeClient.IssueComments[e.Number]
+			if tc.expectedComment == "" {
+				if len(issueComments) != 0 {
+					t.Errorf("No comment should be created, got %v", issueComments)
+				}
+				return
+			}
+			if len(issueComments) != 1 {
+				t.Fatalf("Expected one comment, got %v", issueComments)
+			}
+			// Only check for substring because the actual comment contains a lot of extra stuff
+			if !strings.Contains(issueComments[0].Body, tc.expectedComment) {
+				t.Errorf("Unexpected comment\n%s\nbut got\n%s", tc.expectedComment, issueComments[0].Body)
+			}
+		})
 	}
 }
 
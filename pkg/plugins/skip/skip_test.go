@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/plugins"
 )
 
 func TestSkipStatus(t *testing.T) {
@@ -323,7 +324,7 @@ func TestSkipStatus(t *testing.T) {
 			},
 		}
 
-		if err := handle(fghc, l, test.event, c, nil, true); err != nil {
+		if err := handle(fghc, l, test.event, c, nil, plugins.Trigger{}); err != nil {
 			t.Errorf("%s: unexpected error: %v", test.name, err)
 			continue
 		}
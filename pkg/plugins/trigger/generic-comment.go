@@ -17,13 +17,17 @@ limitations under the License.
 package trigger
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
 	"sigs.k8s.io/prow/pkg/kube"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/flaketracker"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/labels"
 	"sigs.k8s.io/prow/pkg/pjutil"
@@ -59,8 +63,10 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 	// Skip comments not germane to this plugin
 	if !pjutil.RetestRe.MatchString(gc.Body) &&
 		!pjutil.RetestRequiredRe.MatchString(gc.Body) &&
+		!pjutil.RetestFlakesRe.MatchString(gc.Body) &&
 		!pjutil.OkToTestRe.MatchString(gc.Body) &&
 		!pjutil.TestAllRe.MatchString(gc.Body) &&
+		!pjutil.TestRequiredRe.MatchString(gc.Body) &&
 		!pjutil.MayNeedHelpComment(gc.Body) {
 		matched := false
 		for _, presubmit := range presubmits {
@@ -126,16 +132,37 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 		return err
 	}
 
-	toTest, err := FilterPresubmits(HonorOkToTest(trigger), c.GitHubClient, gc.Body, pr, presubmits, c.Logger)
+	toTest, err := FilterPresubmits(trigger, c.GitHubClient, gc.Body, pr, presubmits, c.Logger)
 	if err != nil {
 		return err
 	}
 	if needsHelp, note := pjutil.ShouldRespondWithHelp(gc.Body, len(toTest)); needsHelp {
 		return addHelpComment(c.GitHubClient, gc.Body, org, repo, pr.Base.Ref, pr.Number, presubmits, gc.HTMLURL, commentAuthor, note, c.Logger)
 	}
+	if pjutil.RetestFlakesRe.MatchString(gc.Body) {
+		flaky, resp, err := flakyPresubmits(trigger, org, repo, pr, presubmits)
+		if err != nil {
+			return err
+		}
+		if resp != "" {
+			c.Logger.Infof("Commenting \"%s\".", resp)
+			if err := c.GitHubClient.CreateComment(org, repo, number, plugins.FormatResponseRaw(gc.Body, gc.HTMLURL, gc.User.Login, resp)); err != nil {
+				return err
+			}
+		}
+		requested := sets.New[string]()
+		for _, presubmit := range toTest {
+			requested.Insert(presubmit.Name)
+		}
+		for _, presubmit := range flaky {
+			if !requested.Has(presubmit.Name) {
+				toTest = append(toTest, presubmit)
+			}
+		}
+	}
 	// we want to be able to track re-tests separately from the general body of tests
 	additionalLabels := map[string]string{}
-	if pjutil.RetestRe.MatchString(gc.Body) || pjutil.RetestRequiredRe.MatchString(gc.Body) {
+	if pjutil.RetestRe.MatchString(gc.Body) || pjutil.RetestRequiredRe.MatchString(gc.Body) || pjutil.RetestFlakesRe.MatchString(gc.Body) {
 		additionalLabels[kube.RetestLabel] = "true"
 	}
 	// run failed github actions
@@ -167,9 +194,81 @@ func handleGenericComment(c Client, trigger plugins.Trigger, gc github.GenericCo
 			}
 		}
 	}
+	if trigger.ElideDuplicateRuns {
+		toTest = elideInFlight(c, pr, baseSHA, toTest)
+	}
 	return RunRequestedWithLabels(c, pr, baseSHA, toTest, gc.GUID, additionalLabels)
 }
 
+// elideInFlight drops the presubmits that already have a pending or running
+// ProwJob testing the same base and head SHAs of the pull request. Failing to
+// list the ProwJobs is not fatal: the presubmits are then all run.
+func elideInFlight(c Client, pr *github.PullRequest, baseSHA string, presubmits []config.Presubmit) []config.Presubmit {
+	if len(presubmits) == 0 {
+		return presubmits
+	}
+	selector, err := labelSelectorForPR(pr)
+	if err != nil {
+		c.Logger.WithError(err).Warn("Failed to construct label selector, not eliding duplicate runs.")
+		return presubmits
+	}
+	jobs, err := c.ProwJobClient.List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		c.Logger.WithError(err).Warn("Failed to list prowjobs for pr, not eliding duplicate runs.")
+		return presubmits
+	}
+
+	inFlight := map[string]string{}
+	for _, job := range jobs.Items {
+		switch job.Status.State {
+		case prowapi.SchedulingState, prowapi.TriggeredState, prowapi.PendingState:
+		default:
+			continue
+		}
+		if job.Spec.Refs == nil || job.Spec.Refs.BaseSHA != baseSHA {
+			continue
+		}
+		if len(job.Spec.Refs.Pulls) != 1 || job.Spec.Refs.Pulls[0].SHA != pr.Head.SHA {
+			continue
+		}
+		inFlight[job.Spec.Job] = job.Name
+	}
+
+	var toRun []config.Presubmit
+	for _, presubmit := range presubmits {
+		if name, ok := inFlight[presubmit.Name]; ok {
+			c.Logger.WithFields(logrus.Fields{"job": presubmit.Name, "prowjob": name}).Info("Adopting the in-flight run of the same SHAs instead of creating a new prowjob.")
+			continue
+		}
+		toRun = append(toRun, presubmit)
+	}
+	return toRun
+}
+
+// flakyPresubmits returns the presubmits that failed on the head of the pull
+// request because of known flakes only, as reported by the flake-tracker, or
+// a response explaining why there are none.
+func flakyPresubmits(trigger plugins.Trigger, org, repo string, pr *github.PullRequest, presubmits []config.Presubmit) ([]config.Presubmit, string, error) {
+	if trigger.FlakeTrackerURL == "" {
+		return nil, "`/retest-flakes` is not available because no flake-tracker is configured for this repository.", nil
+	}
+	report, err := flaketracker.NewClient(trigger.FlakeTrackerURL).PullReport(org, repo, pr.Number, pr.Head.SHA)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get the flake report of %s/%s#%d: %w", org, repo, pr.Number, err)
+	}
+	flakyJobs := sets.New[string](report.FlakyJobs()...)
+	var flaky []config.Presubmit
+	for _, presubmit := range presubmits {
+		if flakyJobs.Has(presubmit.Name) {
+			flaky = append(flaky, presubmit)
+		}
+	}
+	if len(flaky) == 0 {
+		return nil, "No job failed because of known flakes only. Use `/retest` to rerun all failed jobs.", nil
+	}
+	return flaky, "", nil
+}
+
 func HonorOkToTest(trigger plugins.Trigger) bool {
 	return !trigger.IgnoreOkToTest
 }
@@ -192,10 +291,15 @@ type GitHubClient interface {
 //     that doesn't explicitly require a human trigger comment; jobs will
 //     default to not run unless we can determine that they should
 //
+// The /retest-required variant skips optional jobs, and /test-required only
+// considers the jobs Tide requires for merge. When the trigger config runs
+// required jobs only, so do /retest, /retest-required, /test all and
+// /ok-to-test.
+//
 // If a comment that we get matches more than one of the above patterns, we
 // consider the set of matching presubmits the union of the results from the
 // matching cases.
-func FilterPresubmits(honorOkToTest bool, gitHubClient GitHubClient, body string, pr *github.PullRequest, presubmits []config.Presubmit, logger *logrus.Entry) ([]config.Presubmit, error) {
+func FilterPresubmits(trigger plugins.Trigger, gitHubClient GitHubClient, body string, pr *github.PullRequest, presubmits []config.Presubmit, logger *logrus.Entry) ([]config.Presubmit, error) {
 	org, repo, sha := pr.Base.Repo.Owner.Login, pr.Base.Repo.Name, pr.Head.SHA
 
 	contextGetter := func() (sets.Set[string], sets.Set[string], error) {
@@ -207,7 +311,11 @@ func FilterPresubmits(honorOkToTest bool, gitHubClient GitHubClient, body string
 		return failedContexts, allContexts, nil
 	}
 
-	filter, err := pjutil.PresubmitFilter(honorOkToTest, contextGetter, body, logger)
+	presubmitFilter := pjutil.PresubmitFilter
+	if trigger.RunRequiredOnly {
+		presubmitFilter = pjutil.RequiredPresubmitFilter
+	}
+	filter, err := presubmitFilter(HonorOkToTest(trigger), contextGetter, body, logger)
 	if err != nil {
 		return nil, err
 	}
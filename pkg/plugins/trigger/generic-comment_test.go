@@ -19,19 +19,25 @@ package trigger
 import (
 	"fmt"
 	"log"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clienttesting "k8s.io/client-go/testing"
 
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
 	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/flaketracker"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/github/fakegithub"
+	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/labels"
 	"sigs.k8s.io/prow/pkg/pjutil"
 	"sigs.k8s.io/prow/pkg/plugins"
@@ -62,6 +68,12 @@ type testcase struct {
 	IssueLabels    []string
 	IgnoreOkToTest bool
 	AddedComment   string
+	// RunRequiredOnly configures trigger to run the required jobs only on
+	// /retest, /test all and /ok-to-test.
+	RunRequiredOnly bool
+	// FlakyJobs are the jobs the flake-tracker reports to have failed
+	// because of known flakes only. No flake-tracker is configured if nil.
+	FlakyJobs []string
 }
 
 func TestHandleGenericComment(t *testing.T) {
@@ -564,6 +576,65 @@ func TestHandleGenericComment(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "Retest doesn't trigger failed job that is optional when running required jobs only",
+			Author: "trusted-member",
+			Body:   "/retest",
+			State:  "open",
+			IsPR:   true,
+			Presubmits: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase: config.JobBase{
+							Name: "jib",
+						},
+						Reporter: config.Reporter{
+							Context: "pull-jib",
+						},
+						Trigger:      `(?m)^/test (?:.*? )?jib(?: .*?)?$`,
+						RerunCommand: `/test jib`,
+						Optional:     true,
+					},
+				},
+			},
+			RunRequiredOnly: true,
+		},
+		{
+			name:   "Test-Required triggers required job",
+			Author: "trusted-member",
+			Body:   "/test-required",
+			State:  "open",
+			IsPR:   true,
+			Presubmits: map[string][]config.Presubmit{
+				"org/repo": {
+					{
+						JobBase: config.JobBase{
+							Name: "job",
+						},
+						AlwaysRun: true,
+						Reporter: config.Reporter{
+							Context: "pull-job",
+						},
+						Trigger:      `(?m)^/test (?:.*? )?job(?: .*?)?$`,
+						RerunCommand: `/test job`,
+					},
+					{
+						JobBase: config.JobBase{
+							Name: "jib",
+						},
+						AlwaysRun: true,
+						Reporter: config.Reporter{
+							Context: "pull-jib",
+						},
+						Trigger:      `(?m)^/test (?:.*? )?jib(?: .*?)?$`,
+						RerunCommand: `/test jib`,
+						Optional:     true,
+					},
+				},
+			},
+			ShouldBuild:   true,
+			StartsExactly: "pull-job",
+		},
 		{
 			name:   "Retest of run_if_changed job that failed. Changes do not require the job",
 			Author: "trusted-member",
@@ -1246,6 +1317,45 @@ func TestHandleGenericComment(t *testing.T) {
 				"The following commands are available to trigger optional jobs:\n* `/test jub`\n\n" +
 				"Use `/test all` to run all jobs.",
 		},
+		{
+			name:          "/retest-flakes reruns the jobs that failed because of known flakes only",
+			Author:        "trusted-member",
+			Body:          "/retest-flakes",
+			State:         "open",
+			IsPR:          true,
+			FlakyJobs:     []string{"jib"},
+			ShouldBuild:   true,
+			StartsExactly: "pull-jib",
+		},
+		{
+			name:         "/retest-flakes without flaky jobs explains why nothing is rerun",
+			Author:       "trusted-member",
+			Body:         "/retest-flakes",
+			State:        "open",
+			IsPR:         true,
+			FlakyJobs:    []string{},
+			ShouldBuild:  false,
+			AddedComment: "No job failed because of known flakes only.",
+		},
+		{
+			name:         "/retest-flakes without flake-tracker",
+			Author:       "trusted-member",
+			Body:         "/retest-flakes",
+			State:        "open",
+			IsPR:         true,
+			ShouldBuild:  false,
+			AddedComment: "no flake-tracker is configured",
+		},
+		{
+			name:        "/retest-flakes from non-trusted member",
+			Author:      "untrusted-member",
+			PRAuthor:    "untrusted-member",
+			Body:        "/retest-flakes",
+			State:       "open",
+			IsPR:        true,
+			FlakyJobs:   []string{"jib"},
+			ShouldBuild: false,
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1341,7 +1451,13 @@ func TestHandleGenericComment(t *testing.T) {
 			}
 
 			trigger := plugins.Trigger{
-				IgnoreOkToTest: tc.IgnoreOkToTest,
+				IgnoreOkToTest:  tc.IgnoreOkToTest,
+				RunRequiredOnly: tc.RunRequiredOnly,
+			}
+			if tc.FlakyJobs != nil {
+				server := httptest.NewServer(flaketracker.NewServer(flakeStore(tc.FlakyJobs), flaketracker.Thresholds{MinFlakes: 1}))
+				defer server.Close()
+				trigger.FlakeTrackerURL = server.URL
 			}
 			trigger.SetDefaults()
 
@@ -1361,6 +1477,20 @@ func TestHandleGenericComment(t *testing.T) {
 	}
 }
 
+// flakeStore records a known flake of each job and a failure of the job on
+// the head of the pull request of TestHandleGenericComment because of it.
+func flakeStore(jobs []string) *flaketracker.Store {
+	store := flaketracker.NewStore(time.Hour)
+	now := time.Now()
+	for i, job := range jobs {
+		failure := map[string]string{"TestFlaky": "timeout"}
+		store.Add(flaketracker.Run{Job: job, BuildID: fmt.Sprintf("%d-1", i), Repo: "org/repo", SHA: "base", Finished: now, HasResults: true, Failures: failure})
+		store.Add(flaketracker.Run{Job: job, BuildID: fmt.Sprintf("%d-2", i), Repo: "org/repo", SHA: "base", Finished: now, HasResults: true, Passed: true})
+		store.Add(flaketracker.Run{Job: job, BuildID: fmt.Sprintf("%d-3", i), Repo: "org/repo", SHA: "cafe", Finished: now, HasResults: true, Failures: failure})
+	}
+	return store
+}
+
 func validate(t *testing.T, actions []clienttesting.Action, g *fakegithub.FakeClient, tc testcase) {
 	startedContexts := sets.New[string]()
 	for _, action := range actions {
@@ -1481,3 +1611,106 @@ func TestRetestFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestElideInFlight(t *testing.T) {
+	pr := &github.PullRequest{
+		Number: 5,
+		Base: github.PullRequestBranch{
+			Repo: github.Repo{Owner: github.User{Login: "org"}, Name: "repo"},
+			Ref:  "master",
+		},
+		Head: github.PullRequestBranch{SHA: "head"},
+	}
+	presubmits := []config.Presubmit{
+		{JobBase: config.JobBase{Name: "unit"}},
+		{JobBase: config.JobBase{Name: "e2e"}},
+	}
+	prowJob := func(name, job, baseSHA, headSHA string, state prowapi.ProwJobState) *prowapi.ProwJob {
+		return &prowapi.ProwJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "prowjobs",
+				Labels: map[string]string{
+					kube.OrgLabel:         "org",
+					kube.RepoLabel:        "repo",
+					kube.PullLabel:        "5",
+					kube.ProwJobTypeLabel: string(prowapi.PresubmitJob),
+				},
+			},
+			Spec: prowapi.ProwJobSpec{
+				Type: prowapi.PresubmitJob,
+				Job:  job,
+				Refs: &prowapi.Refs{
+					Org:     "org",
+					Repo:    "repo",
+					BaseSHA: baseSHA,
+					Pulls:   []prowapi.Pull{{Number: 5, SHA: headSHA}},
+				},
+			},
+			Status: prowapi.ProwJobStatus{State: state},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		existing []runtime.Object
+		expected []string
+	}{
+		{
+			name:     "no prowjobs, all run",
+			expected: []string{"unit", "e2e"},
+		},
+		{
+			name: "pending run of the same SHAs is adopted",
+			existing: []runtime.Object{
+				prowJob("a", "unit", "base", "head", prowapi.PendingState),
+			},
+			expected: []string{"e2e"},
+		},
+		{
+			name: "triggered runs of the same SHAs are adopted",
+			existing: []runtime.Object{
+				prowJob("a", "unit", "base", "head", prowapi.TriggeredState),
+				prowJob("b", "e2e", "base", "head", prowapi.TriggeredState),
+			},
+		},
+		{
+			name: "completed run is not adopted",
+			existing: []runtime.Object{
+				prowJob("a", "unit", "base", "head", prowapi.FailureState),
+			},
+			expected: []string{"unit", "e2e"},
+		},
+		{
+			name: "run of another head SHA is not adopted",
+			existing: []runtime.Object{
+				prowJob("a", "unit", "base", "old-head", prowapi.PendingState),
+			},
+			expected: []string{"unit", "e2e"},
+		},
+		{
+			name: "run of another base SHA is not adopted",
+			existing: []runtime.Object{
+				prowJob("a", "unit", "old-base", "head", prowapi.PendingState),
+			},
+			expected: []string{"unit", "e2e"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := Client{
+				ProwJobClient: fake.NewSimpleClientset(tc.existing...).ProwV1().ProwJobs("prowjobs"),
+				Logger:        logrus.WithField("testcase", tc.name),
+			}
+			var actual []string
+			for _, presubmit := range elideInFlight(c, pr, "base", presubmits) {
+				actual = append(actual, presubmit.Name)
+			}
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Errorf("expected presubmits %v to run, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
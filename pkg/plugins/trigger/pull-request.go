@@ -120,7 +120,7 @@ func handlePR(c Client, trigger plugins.Trigger, pr github.PullRequestEvent) err
 		}
 	case github.PullRequestActionSynchronize:
 		var errs []error
-		if err := abortAllJobs(c, &pr.PullRequest); err != nil {
+		if err := abortAllJobs(c, &pr.PullRequest, prowapi.Cancellation{Reason: prowapi.CancelledSuperseded, Requester: pr.Sender.Login}); err != nil {
 			errs = append(errs, fmt.Errorf("failed to abort jobs: %w", err))
 		}
 		return utilerrors.NewAggregate(append(errs, buildAllIfTrusted(c, trigger, pr, baseSHA, presubmits)))
@@ -150,14 +150,14 @@ func handlePR(c Client, trigger plugins.Trigger, pr github.PullRequestEvent) err
 			return buildAllButDrafts(c, &pr.PullRequest, pr.GUID, baseSHA, presubmits)
 		}
 	case github.PullRequestActionClosed:
-		if err := abortAllJobs(c, &pr.PullRequest); err != nil {
+		if err := abortAllJobs(c, &pr.PullRequest, prowapi.Cancellation{Reason: prowapi.CancelledAbandoned, Requester: pr.Sender.Login}); err != nil {
 			c.Logger.WithError(err).Error("Failed to abort jobs for closed pull request")
 			return err
 		}
 	case github.PullRequestActionReadyForReview:
 		return buildAllIfTrusted(c, trigger, pr, baseSHA, presubmits)
 	case github.PullRequestActionConvertedToDraft:
-		if err := abortAllJobs(c, &pr.PullRequest); err != nil {
+		if err := abortAllJobs(c, &pr.PullRequest, prowapi.Cancellation{Reason: prowapi.CancelledAbandoned, Requester: pr.Sender.Login}); err != nil {
 			c.Logger.WithError(err).Error("Failed to abort jobs for pull request converted to draft")
 			return err
 		}
@@ -166,7 +166,7 @@ func handlePR(c Client, trigger plugins.Trigger, pr github.PullRequestEvent) err
 	return nil
 }
 
-func abortAllJobs(c Client, pr *github.PullRequest) error {
+func abortAllJobs(c Client, pr *github.PullRequest, cancellation prowapi.Cancellation) error {
 	selector, err := labelSelectorForPR(pr)
 	if err != nil {
 		return fmt.Errorf("failed to construct label selector: %w", err)
@@ -185,6 +185,7 @@ func abortAllJobs(c Client, pr *github.PullRequest) error {
 		}
 		job.Status.State = prowapi.AbortedState
 		job.Status.Description = abortedDescription
+		job.Status.Cancellation = cancellation.DeepCopy()
 		// We use Update and not Patch here, because we are not the authority of the .Status.State field
 		// and must not overwrite changes made to it in the interim by the responsible agent.
 		// The accepted trade-off for now is that this leads to failure if unrelated fields where changed
@@ -616,7 +616,8 @@ func TestAbortAllJobs(t *testing.T) {
 				Number: number,
 			}
 
-			if err := abortAllJobs(client, pr); err != nil {
+			cancellation := prowapi.Cancellation{Reason: prowapi.CancelledSuperseded, Requester: "author"}
+			if err := abortAllJobs(client, pr, cancellation); err != nil {
 				t.Fatalf("error caling abortAllJobs: %v", err)
 			}
 
@@ -628,6 +629,11 @@ func TestAbortAllJobs(t *testing.T) {
 			if isAborted := (pj.Status.State == prowapi.AbortedState && pj.Status.Description == abortedDescription); isAborted != tc.expectedAbortedProwJob {
 				t.Errorf("IsAborted: %t, but expected aborted: %t", isAborted, tc.expectedAbortedProwJob)
 			}
+			if tc.expectedAbortedProwJob {
+				if diff := cmp.Diff(&cancellation, pj.Status.Cancellation); diff != "" {
+					t.Errorf("unexpected cancellation (-want +got):\n%s", diff)
+				}
+			}
 		})
 	}
 }
@@ -86,6 +86,9 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 			org = trigger.TrustedOrg
 		}
 		configInfo[repo.String()] = fmt.Sprintf("The trusted GitHub organization for this repository is %q.", org)
+		if trigger.RunRequiredOnly {
+			configInfo[repo.String()] += " Only the jobs required for merge are run by '/retest', '/retest-required', '/test all' and '/ok-to-test'."
+		}
 	}
 	yamlSnippet, err := plugins.CommentMap.GenYaml(&plugins.Configuration{
 		Triggers: []plugins.Trigger{
@@ -135,6 +138,27 @@ func helpProvider(config *plugins.Configuration, enabledRepos []config.OrgRepo)
 		WhoCanUse:   "Anyone can trigger this command on a trusted PR.",
 		Examples:    []string{"/retest"},
 	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/test-required",
+		Description: "Manually starts the automatically triggered test jobs that are required for merge, skipping optional ones.",
+		Featured:    false,
+		WhoCanUse:   "Anyone can trigger this command on a trusted PR.",
+		Examples:    []string{"/test-required"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/retest-required",
+		Description: "Rerun the test jobs that have failed, skipping optional ones.",
+		Featured:    false,
+		WhoCanUse:   "Anyone can trigger this command on a trusted PR.",
+		Examples:    []string{"/retest-required"},
+	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       "/retest-flakes",
+		Description: "Rerun test jobs whose failures are all known flakes, as reported by the flake-tracker.",
+		Featured:    false,
+		WhoCanUse:   "Anyone can trigger this command on a trusted PR.",
+		Examples:    []string{"/retest-flakes"},
+	})
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       "/test ?",
 		Description: "List available test job(s) for a trusted PR.",
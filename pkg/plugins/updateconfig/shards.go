@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updateconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// shardOfLabel is set on the additional ConfigMaps of a sharded ConfigMap and
+// holds the name of the primary ConfigMap.
+const shardOfLabel = "prow.k8s.io/shard-of"
+
+// maxShardDataSize is the amount of data put into a single ConfigMap. It
+// leaves room for the object metadata below the 1MiB limit of ConfigMaps.
+var maxShardDataSize = 1000 * 1024
+
+func shardName(name string, i int) string {
+	return fmt.Sprintf("%s-shard-%d", name, i)
+}
+
+func listShards(kc corev1.ConfigMapInterface, name string) ([]coreapi.ConfigMap, error) {
+	shards, err := kc.List(context.TODO(), metav1.ListOptions{LabelSelector: labels.Set{shardOfLabel: name}.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shards of configmap %s: %w", name, err)
+	}
+	return shards.Items, nil
+}
+
+// reassembleShards merges the data of the shards of cm back into cm.
+func reassembleShards(cm *coreapi.ConfigMap, shards []coreapi.ConfigMap) {
+	delete(cm.Data, config.ShardManifestFileName)
+	for _, shard := range shards {
+		for key, value := range shard.Data {
+			cm.Data[key] = value
+		}
+		for key, value := range shard.BinaryData {
+			cm.BinaryData[key] = value
+		}
+	}
+}
+
+func dataSize(cm *coreapi.ConfigMap) int {
+	var size int
+	for _, data := range cm.Data {
+		size += len(data)
+	}
+	for _, data := range cm.BinaryData {
+		size += len(data)
+	}
+	return size
+}
+
+// shard splits cm into shards if its data doesn't fit into a single
+// ConfigMap. The version file and the manifest stay in cm, the other keys are
+// distributed in alphabetical order. A single key larger than the limit
+// can't be sharded and is an error.
+func shard(cm *coreapi.ConfigMap) ([]*coreapi.ConfigMap, error) {
+	if dataSize(cm) <= maxShardDataSize {
+		return nil, nil
+	}
+	manifest := config.ShardManifest{Files: map[string]string{}}
+	contents := map[string][]byte{}
+	for key, value := range cm.Data {
+		contents[key] = []byte(value)
+	}
+	for key, value := range cm.BinaryData {
+		contents[key] = value
+	}
+	for key, value := range contents {
+		if len(value) > maxShardDataSize {
+			return nil, fmt.Errorf("key %s has %d bytes and does not fit into a ConfigMap even when sharded, consider gzipping it", key, len(value))
+		}
+		manifest.Files[key] = config.ShardHash(value)
+	}
+
+	var keys []string
+	for key := range contents {
+		if key != config.ConfigVersionFileName {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	primaryKeys := sets.New[string]()
+	var shards []*coreapi.ConfigMap
+	// The manifest lists the shards, so its size is only known at the end.
+	// Reserve a generous amount of room for it in the primary.
+	used := len(contents[config.ConfigVersionFileName]) + 200*len(contents)
+	var current *coreapi.ConfigMap
+	for _, key := range keys {
+		size := len(contents[key])
+		if current == nil && used+size <= maxShardDataSize {
+			primaryKeys.Insert(key)
+			used += size
+			continue
+		}
+		if current == nil || dataSize(current)+size > maxShardDataSize {
+			current = &coreapi.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      shardName(cm.Name, len(shards)+1),
+					Namespace: cm.Namespace,
+					Labels:    map[string]string{shardOfLabel: cm.Name},
+				},
+				Data:       map[string]string{},
+				BinaryData: map[string][]byte{},
+			}
+			for k, v := range cm.Labels {
+				current.Labels[k] = v
+			}
+			shards = append(shards, current)
+		}
+		if value, ok := cm.Data[key]; ok {
+			current.Data[key] = value
+		} else {
+			current.BinaryData[key] = cm.BinaryData[key]
+		}
+	}
+	for key := range contents {
+		if key != config.ConfigVersionFileName && !primaryKeys.Has(key) {
+			delete(cm.Data, key)
+			delete(cm.BinaryData, key)
+		}
+	}
+
+	for _, s := range shards {
+		manifest.Shards = append(manifest.Shards, s.Name)
+	}
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal shard manifest: %w", err)
+	}
+	cm.Data[config.ShardManifestFileName] = string(raw)
+	return shards, nil
+}
+
+// writeShards creates or updates the shards and deletes the shards that are
+// no longer needed.
+func writeShards(kc corev1.ConfigMapInterface, existing []coreapi.ConfigMap, shards []*coreapi.ConfigMap) error {
+	existingNames := sets.New[string]()
+	for _, s := range existing {
+		existingNames.Insert(s.Name)
+	}
+	for _, s := range shards {
+		var err error
+		if existingNames.Has(s.Name) {
+			_, err = kc.Update(context.TODO(), s, metav1.UpdateOptions{})
+		} else {
+			_, err = kc.Create(context.TODO(), s, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write shard %s: %w", s.Name, err)
+		}
+		existingNames.Delete(s.Name)
+	}
+	for _, name := range sets.List(existingNames) {
+		if err := kc.Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("failed to delete stale shard %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package updateconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+// TestUpdateShards is not parallel because it changes maxShardDataSize.
+func TestUpdateShards(t *testing.T) {
+	defer func(size int) { maxShardDataSize = size }(maxShardDataSize)
+	maxShardDataSize = 1000
+
+	ns, name, commit := "ns", "jobs", "da28634f10160f8c746c387cd33b488909036e1f"
+	log := logrus.NewEntry(logrus.New())
+	fs := MapFS{
+		"a": {Data: []byte(strings.Repeat("a", 400))},
+		"b": {Data: []byte(strings.Repeat("b", 400))},
+		"c": {Data: []byte(strings.Repeat("c", 400))},
+		"d": {Data: []byte(strings.Repeat("d", 400))},
+		"e": {Data: []byte(strings.Repeat("e", 400))},
+	}
+	client, err := GetConfigMapClient(fake.NewSimpleClientset().CoreV1(), ns, nil, kube.DefaultClusterAlias)
+	if err != nil {
+		t.Fatalf("failed to create fake client: %v", err)
+	}
+	update := func(bootstrap bool, keys ...string) {
+		var updates []ConfigMapUpdate
+		for _, key := range keys {
+			updates = append(updates, ConfigMapUpdate{Key: key, Filename: key})
+		}
+		metrics := prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{name, ns})
+		if err := Update(fs, client, name, ns, updates, bootstrap, metrics, log, commit); err != nil {
+			t.Fatalf("unexpected error updating: %v", err)
+		}
+	}
+	// assertShards checks the names of the shards and that the ConfigMaps
+	// mounted into a single directory hold the expected keys and pass
+	// config.VerifyShards.
+	assertShards := func(expectedShards, expectedKeys []string) {
+		t.Helper()
+		shards, err := listShards(client, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, shard := range shards {
+			names = append(names, shard.Name)
+		}
+		if diff := cmp.Diff(expectedShards, names); diff != "" {
+			t.Errorf("shards differ from expected: %s", diff)
+		}
+		primary, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		dir := t.TempDir()
+		var keys []string
+		for _, cm := range append(shards, *primary) {
+			if cm.Name != name && dataSize(&cm) > maxShardDataSize {
+				t.Errorf("shard %s exceeds the size limit", cm.Name)
+			}
+			for key, value := range cm.Data {
+				if key != config.ShardManifestFileName && key != config.ConfigVersionFileName {
+					keys = append(keys, key)
+				}
+				if err := os.WriteFile(filepath.Join(dir, key), []byte(value), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		if diff := cmp.Diff(expectedKeys, keys, cmpSorted); diff != "" {
+			t.Errorf("keys differ from expected: %s", diff)
+		}
+		if err := config.VerifyShards(dir); err != nil {
+			t.Errorf("failed to verify shards: %v", err)
+		}
+	}
+
+	update(true, "a", "b", "c", "d", "e")
+	assertShards([]string{"jobs-shard-1", "jobs-shard-2", "jobs-shard-3"}, []string{"a", "b", "c", "d", "e"})
+
+	fs["e"].Data = []byte("e")
+	update(false, "e")
+	assertShards([]string{"jobs-shard-1", "jobs-shard-2"}, []string{"a", "b", "c", "d", "e"})
+
+	update(true, "a")
+	assertShards(nil, []string{"a"})
+}
+
+var cmpSorted = cmp.Transformer("sorted", func(in []string) []string {
+	out := append([]string(nil), in...)
+	sort.Strings(out)
+	return out
+})
+
+func TestShardKeyTooLarge(t *testing.T) {
+	defer func(size int) { maxShardDataSize = size }(maxShardDataSize)
+	maxShardDataSize = 10
+
+	cm := &coreapi.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "jobs"}, Data: map[string]string{"a": strings.Repeat("a", 20)}}
+	if _, err := shard(cm); err == nil {
+		t.Error("expected an error for a key exceeding the size limit")
+	}
+}
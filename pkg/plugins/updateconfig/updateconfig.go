@@ -107,6 +107,20 @@ func Update(fg FileGetter, kc corev1.ConfigMapInterface, name, namespace string,
 	if getErr != nil && !isNotFound {
 		return fmt.Errorf("failed to fetch current state of configmap: %w", getErr)
 	}
+	// ConfigMaps exceeding the size limit are sharded, see shard().
+	existingShards, err := listShards(kc, name)
+	if err != nil {
+		return err
+	}
+	if cm != nil && !isNotFound {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		if cm.BinaryData == nil {
+			cm.BinaryData = map[string][]byte{}
+		}
+		reassembleShards(cm, existingShards)
+	}
 
 	labels := map[string]string{
 		"app.kubernetes.io/name":      "prow",
@@ -198,6 +212,12 @@ func Update(fg FileGetter, kc corev1.ConfigMapInterface, name, namespace string,
 		}
 	}
 
+	size := float64(dataSize(cm))
+	shards, err := shard(cm)
+	if err != nil {
+		return err
+	}
+
 	var updateErr error
 	var verb string
 	if getErr != nil && isNotFound {
@@ -210,14 +230,10 @@ func Update(fg FileGetter, kc corev1.ConfigMapInterface, name, namespace string,
 	if updateErr != nil {
 		return fmt.Errorf("%s config map err: %w", verb, updateErr)
 	}
+	if err := writeShards(kc, existingShards, shards); err != nil {
+		return err
+	}
 	if metrics != nil {
-		var size float64
-		for _, data := range cm.Data {
-			size += float64(len(data))
-		}
-		for _, data := range cm.BinaryData {
-			size += float64(len(data))
-		}
 		// in a strict sense this can race to update the value with other goroutines
 		// handling other events, but as events are serialized due to the fact that
 		// merges are serial in repositories, this is effectively not an issue here
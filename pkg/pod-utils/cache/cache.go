@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	pkgio "sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/io/providers"
+)
+
+// Cache is a cache of a job as the pod utilities see it.
+type Cache struct {
+	// Key is the rendered key of the cache.
+	Key string `json:"key"`
+	// Fallback is the key of the cache that is restored if there is none
+	// with Key yet.
+	Fallback string `json:"fallback,omitempty"`
+	// Dirs are the directories of the cache in the container of the
+	// utility, in the order of the paths of the cache.
+	Dirs []string `json:"dirs"`
+}
+
+// Path returns where the cache with the given key is stored in the bucket.
+func Path(bucket, key string) (string, error) {
+	parsedBucket, err := url.Parse(bucket)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse bucket name %s: %w", bucket, err)
+	}
+	if parsedBucket.Scheme == "" {
+		parsedBucket.Scheme = providers.GS
+	}
+	return fmt.Sprintf("%s/caches/%s.tar.gz", strings.TrimSuffix(parsedBucket.String(), "/"), key), nil
+}
+
+// Restore extracts the caches from the bucket into their directories. A
+// cache that is not in the bucket yet is skipped.
+func Restore(ctx context.Context, opener pkgio.Opener, bucket string, caches []Cache) error {
+	var errs []error
+	for _, cache := range caches {
+		if err := restore(ctx, opener, bucket, cache); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore cache %s: %w", cache.Key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func restore(ctx context.Context, opener pkgio.Opener, bucket string, cache Cache) error {
+	key := cache.Key
+	src, err := Path(bucket, key)
+	if err != nil {
+		return err
+	}
+	r, err := opener.Reader(ctx, src)
+	if pkgio.IsNotExist(err) && cache.Fallback != "" {
+		key = cache.Fallback
+		if src, err = Path(bucket, key); err != nil {
+			return err
+		}
+		r, err = opener.Reader(ctx, src)
+	}
+	if pkgio.IsNotExist(err) {
+		logrus.WithField("key", cache.Key).Info("Cache does not exist yet.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	if err := extract(tar.NewReader(gz), cache.Dirs); err != nil {
+		return err
+	}
+	logrus.WithField("key", key).Info("Restored cache.")
+	return nil
+}
+
+// extract writes the entries of the archive into the directories. Entries
+// are named after the index of their directory, followed by their path in
+// it. Symbolic links are created last, so that no entry is written through
+// one of them.
+func extract(tr *tar.Reader, dirs []string) error {
+	links := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		index, rel, _ := strings.Cut(path.Clean(hdr.Name), "/")
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(dirs) {
+			return fmt.Errorf("entry %s does not belong to a directory of the cache", hdr.Name)
+		}
+		if rel == "" || rel == ".." || strings.HasPrefix(rel, "../") {
+			return fmt.Errorf("entry %s is outside of its directory", hdr.Name)
+		}
+		dest := filepath.Join(dirs[i], filepath.FromSlash(rel))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dest, hdr.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			links[dest] = hdr.Linkname
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for dest, target := range links {
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.Symlink(target, dest); err != nil && !os.IsExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Save archives the directories of the caches into the bucket, replacing
+// what the bucket held for their keys.
+func Save(ctx context.Context, opener pkgio.Opener, bucket string, caches []Cache) error {
+	var errs []error
+	for _, cache := range caches {
+		if err := save(ctx, opener, bucket, cache); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save cache %s: %w", cache.Key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func save(ctx context.Context, opener pkgio.Opener, bucket string, cache Cache) error {
+	dest, err := Path(bucket, cache.Key)
+	if err != nil {
+		return err
+	}
+	w, err := opener.Writer(ctx, dest)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	err = archive(tw, cache.Dirs)
+	for _, c := range []io.Closer{tw, gz, w} {
+		if closeErr := c.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	logrus.WithField("key", cache.Key).Info("Saved cache.")
+	return nil
+}
+
+// archive writes the contents of the directories into the archive.
+func archive(tw *tar.Writer, dirs []string) error {
+	for i, dir := range dirs {
+		err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil || rel == "." {
+				return err
+			}
+			var link string
+			switch {
+			case info.Mode()&os.ModeSymlink != 0:
+				if link, err = os.Readlink(p); err != nil {
+					return err
+				}
+			case !info.Mode().IsRegular() && !info.IsDir():
+				return nil
+			}
+			hdr, err := tar.FileInfoHeader(info, link)
+			if err != nil {
+				return err
+			}
+			hdr.Name = path.Join(strconv.Itoa(i), filepath.ToSlash(rel))
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
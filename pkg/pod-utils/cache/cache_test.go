@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/io/fakeopener"
+)
+
+func TestPath(t *testing.T) {
+	testCases := []struct {
+		name     string
+		bucket   string
+		expected string
+	}{
+		{
+			name:     "bucket without scheme is in GCS",
+			bucket:   "kubernetes-ci-logs",
+			expected: "gs://kubernetes-ci-logs/caches/test-infra-bazel.tar.gz",
+		},
+		{
+			name:     "bucket with scheme",
+			bucket:   "s3://prow-logs",
+			expected: "s3://prow-logs/caches/test-infra-bazel.tar.gz",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Path(tc.bucket, "test-infra-bazel")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected path %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// contents returns the files and symbolic links under the directory.
+func contents(t *testing.T, dir string) map[string]string {
+	found := map[string]string{}
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || p == dir {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(p)
+			found[rel] = "-> " + target
+			return err
+		case info.IsDir():
+			found[rel] = "/"
+		default:
+			data, err := os.ReadFile(p)
+			found[rel] = string(data)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk %s: %v", dir, err)
+	}
+	return found
+}
+
+func TestSaveAndRestore(t *testing.T) {
+	src := []string{t.TempDir(), t.TempDir()}
+	files := map[string]string{
+		filepath.Join(src[0], "cache", "a"): "first",
+		filepath.Join(src[0], "b"):          "second",
+		filepath.Join(src[1], "c"):          "third",
+	}
+	for p, content := range files {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("b", filepath.Join(src[0], "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	opener := &fakeopener.FakeOpener{}
+	ctx := context.Background()
+	if err := Save(ctx, opener, "bucket", []Cache{{Key: "key", Dirs: src}}); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if _, ok := opener.Buffer["gs://bucket/caches/key.tar.gz"]; !ok {
+		t.Fatalf("cache was not written, got %v", opener.Buffer)
+	}
+
+	dest := []string{t.TempDir(), t.TempDir()}
+	if err := Restore(ctx, opener, "bucket", []Cache{{Key: "key", Dirs: dest}, {Key: "missing", Dirs: []string{t.TempDir()}}}); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+	for i := range src {
+		if diff := cmp.Diff(contents(t, src[i]), contents(t, dest[i])); diff != "" {
+			t.Errorf("directory %d differs after restoring (-saved +restored):\n%s", i, diff)
+		}
+	}
+}
+
+func TestRestoreFallback(t *testing.T) {
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "file"), []byte("trusted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opener := &fakeopener.FakeOpener{}
+	ctx := context.Background()
+	if err := Save(ctx, opener, "bucket", []Cache{{Key: "postsubmit/key", Dirs: []string{src}}}); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	dest := t.TempDir()
+	if err := Restore(ctx, opener, "bucket", []Cache{{Key: "presubmit/key", Fallback: "postsubmit/key", Dirs: []string{dest}}}); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+	if diff := cmp.Diff(contents(t, src), contents(t, dest)); diff != "" {
+		t.Errorf("fallback was not restored (-saved +restored):\n%s", diff)
+	}
+}
+
+func TestRestoreRejectsEntriesOutsideOfDirectories(t *testing.T) {
+	testCases := []string{"2/file", "0/../../file", "file", "0"}
+	for _, name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644}); err != nil {
+				t.Fatal(err)
+			}
+			tw.Close()
+			gz.Close()
+			opener := &fakeopener.FakeOpener{Buffer: map[string]*bytes.Buffer{"gs://bucket/caches/key.tar.gz": &buf}}
+			if err := Restore(context.Background(), opener, "bucket", []Cache{{Key: "key", Dirs: []string{t.TempDir(), t.TempDir()}}}); err == nil {
+				t.Error("expected an error, got none")
+			}
+		})
+	}
+}
@@ -43,7 +43,7 @@ type runnable interface {
 // configures the git username and email in the repository as well.
 func Run(refs prowapi.Refs, dir, gitUserName, gitUserEmail, cookiePath string, env []string, userGenerator github.UserGenerator, tokenGenerator github.TokenGenerator) Record {
 	startTime := time.Now()
-	record := Record{Refs: refs}
+	record := Record{Refs: refs, FetchStrategy: fetchStrategyForRefs(refs)}
 
 	var (
 		user  string
@@ -243,15 +243,12 @@ func (g *gitCtx) commandsForBaseRef(refs prowapi.Refs, gitUserName, gitUserEmail
 	if d := refs.CloneDepth; d > 0 {
 		depthArgs = append(depthArgs, "--depth", strconv.Itoa(d))
 	}
-	var filterArgs []string
-	if refs.BloblessFetch != nil && *refs.BloblessFetch {
-		filterArgs = append(filterArgs, "--filter=blob:none")
-	}
+	partialArgs := filterArgs(refs)
 
 	if !refs.SkipFetchHead {
 		var fetchArgs []string
 		fetchArgs = append(fetchArgs, depthArgs...)
-		fetchArgs = append(fetchArgs, filterArgs...)
+		fetchArgs = append(fetchArgs, partialArgs...)
 		fetchArgs = append(fetchArgs, g.repositoryURI, "--tags", "--prune")
 		commands = append(commands, g.gitFetch(fetchArgs...))
 	}
@@ -269,7 +266,7 @@ func (g *gitCtx) commandsForBaseRef(refs prowapi.Refs, gitUserName, gitUserEmail
 	{
 		var fetchArgs []string
 		fetchArgs = append(fetchArgs, depthArgs...)
-		fetchArgs = append(fetchArgs, filterArgs...)
+		fetchArgs = append(fetchArgs, partialArgs...)
 		fetchArgs = append(fetchArgs, g.repositoryURI, fetchRef)
 		commands = append(commands, g.gitFetch(fetchArgs...))
 	}
@@ -325,6 +322,40 @@ func (g *gitCtx) gitRevParse() (string, error) {
 	return strings.TrimSpace(commit), nil
 }
 
+// filterArgs returns the arguments that make fetches of the refs partial.
+func filterArgs(refs prowapi.Refs) []string {
+	if filter := effectiveFilter(refs); filter != "" {
+		return []string{"--filter=" + filter}
+	}
+	return nil
+}
+
+// effectiveFilter returns the partial clone filter that the refs are fetched
+// with, if any.
+func effectiveFilter(refs prowapi.Refs) string {
+	switch {
+	case refs.Filter != "":
+		return refs.Filter
+	case refs.TreelessFetch != nil && *refs.TreelessFetch:
+		return "tree:0"
+	case refs.BloblessFetch != nil && *refs.BloblessFetch:
+		return "blob:none"
+	}
+	return ""
+}
+
+// fetchStrategyForRefs describes how the refs are fetched.
+func fetchStrategyForRefs(refs prowapi.Refs) *FetchStrategy {
+	strategy := &FetchStrategy{
+		Filter:        effectiveFilter(refs),
+		SkipFetchHead: refs.SkipFetchHead,
+	}
+	if refs.CloneDepth > 0 {
+		strategy.Depth = refs.CloneDepth
+	}
+	return strategy
+}
+
 // commandsForPullRefs returns the list of commands needed to fetch and
 // merge any pull refs as well as submodules. These commands should be run only
 // after the commands provided by commandsForBaseRef have been run
@@ -336,10 +367,7 @@ func (g *gitCtx) gitRevParse() (string, error) {
 func (g *gitCtx) commandsForPullRefs(refs prowapi.Refs, fakeTimestamp int) []runnable {
 	var commands []runnable
 	for _, prRef := range refs.Pulls {
-		var fetchArgs []string
-		if refs.BloblessFetch != nil && *refs.BloblessFetch {
-			fetchArgs = append(fetchArgs, "--filter=blob:none")
-		}
+		fetchArgs := filterArgs(refs)
 		ref := fmt.Sprintf("pull/%d/head", prRef.Number)
 		if prRef.SHA != "" {
 			ref = prRef.SHA
@@ -610,6 +610,73 @@ func TestCommandsForRefs(t *testing.T) {
 				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"submodule", "update", "--init", "--recursive"}},
 			},
 		},
+		{
+			name: "treeless refs take precedence over blobless refs",
+			refs: prowapi.Refs{
+				Org:     "org",
+				Repo:    "repo",
+				BaseRef: "master",
+				Pulls: []prowapi.Pull{
+					{Number: 1, Ref: "pull-me"},
+				},
+				BloblessFetch: boolPtr(true),
+				TreelessFetch: boolPtr(true),
+			},
+			dir: "/go",
+			expectedBase: []runnable{
+				cloneCommand{dir: "/", command: "mkdir", args: []string{"-p", "/go/src/github.com/org/repo"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"init"}},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"fetch", "--filter=tree:0", "https://github.com/org/repo.git", "--tags", "--prune"}},
+					fetchRetries,
+				},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"fetch", "--filter=tree:0", "https://github.com/org/repo.git", "master"}},
+					fetchRetries,
+				},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"checkout", "FETCH_HEAD"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"branch", "--force", "master", "FETCH_HEAD"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"checkout", "master"}},
+			},
+			expectedPull: []runnable{
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"fetch", "--filter=tree:0", "https://github.com/org/repo.git", "pull-me"}},
+					fetchRetries,
+				},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"merge", "--no-ff", "FETCH_HEAD"}, env: gitTimestampEnvs(fakeTimestamp + 1)},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"submodule", "update", "--init", "--recursive"}},
+			},
+		},
+		{
+			name: "shallow refs with a filter that takes precedence over treeless refs",
+			refs: prowapi.Refs{
+				Org:           "org",
+				Repo:          "repo",
+				BaseRef:       "master",
+				CloneDepth:    1,
+				TreelessFetch: boolPtr(true),
+				Filter:        "blob:limit=1m",
+			},
+			dir: "/go",
+			expectedBase: []runnable{
+				cloneCommand{dir: "/", command: "mkdir", args: []string{"-p", "/go/src/github.com/org/repo"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"init"}},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"fetch", "--depth", "1", "--filter=blob:limit=1m", "https://github.com/org/repo.git", "--tags", "--prune"}},
+					fetchRetries,
+				},
+				retryCommand{
+					cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"fetch", "--depth", "1", "--filter=blob:limit=1m", "https://github.com/org/repo.git", "master"}},
+					fetchRetries,
+				},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"checkout", "FETCH_HEAD"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"branch", "--force", "master", "FETCH_HEAD"}},
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"checkout", "master"}},
+			},
+			expectedPull: []runnable{
+				cloneCommand{dir: "/go/src/github.com/org/repo", command: "git", args: []string{"submodule", "update", "--init", "--recursive"}},
+			},
+		},
 		{
 			name: "refs with pr ref with specific sha",
 			refs: prowapi.Refs{
@@ -787,6 +854,43 @@ func TestCommandsForRefs(t *testing.T) {
 	}
 }
 
+func TestFetchStrategyForRefs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		refs     prowapi.Refs
+		expected *FetchStrategy
+	}{
+		{
+			name:     "full clone",
+			refs:     prowapi.Refs{Org: "org", Repo: "repo"},
+			expected: &FetchStrategy{},
+		},
+		{
+			name:     "shallow blobless clone",
+			refs:     prowapi.Refs{Org: "org", Repo: "repo", CloneDepth: 3, BloblessFetch: boolPtr(true)},
+			expected: &FetchStrategy{Depth: 3, Filter: "blob:none"},
+		},
+		{
+			name:     "treeless clone without fetching head",
+			refs:     prowapi.Refs{Org: "org", Repo: "repo", TreelessFetch: boolPtr(true), SkipFetchHead: true},
+			expected: &FetchStrategy{Filter: "tree:0", SkipFetchHead: true},
+		},
+		{
+			name:     "filter takes precedence",
+			refs:     prowapi.Refs{Org: "org", Repo: "repo", BloblessFetch: boolPtr(true), Filter: "object:type=commit"},
+			expected: &FetchStrategy{Filter: "object:type=commit"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, fetchStrategyForRefs(tc.refs)); diff != "" {
+				t.Errorf("unexpected fetch strategy (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGitHeadTimestamp(t *testing.T) {
 	fakeTimestamp := 987654321
 	fakeGitDir, err := makeFakeGitRepo(t, fakeTimestamp)
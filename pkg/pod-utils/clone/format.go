@@ -19,6 +19,7 @@ package clone
 import (
 	"bytes"
 	"fmt"
+	"strings"
 )
 
 // FormatRecord describes the record in a human-readable
@@ -47,6 +48,18 @@ func FormatRecord(record Record) string {
 			fmt.Fprint(&output, "\n")
 		}
 	}
+	if strategy := record.FetchStrategy; strategy != nil {
+		var options []string
+		if strategy.Depth > 0 {
+			options = append(options, fmt.Sprintf("--depth=%d", strategy.Depth))
+		}
+		if strategy.Filter != "" {
+			options = append(options, "--filter="+strategy.Filter)
+		}
+		if len(options) > 0 {
+			fmt.Fprintf(&output, "# Fetch strategy: %s\n", strings.Join(options, " "))
+		}
+	}
 	for _, command := range record.Commands {
 		runtime := ""
 		if command.Duration != 0 {
@@ -126,6 +126,20 @@ func TestFormatRecord(t *testing.T) {
 			},
 			require: []string{"12s", "23s"},
 		},
+		{
+			name: "include partial fetch strategy",
+			r: Record{
+				FetchStrategy: &FetchStrategy{Depth: 1, Filter: "blob:none"},
+			},
+			require: []string{"# Fetch strategy: --depth=1 --filter=blob:none"},
+		},
+		{
+			name: "omit full fetch strategy",
+			r: Record{
+				FetchStrategy: &FetchStrategy{SkipFetchHead: true},
+			},
+			deny: []string{"Fetch strategy"},
+		},
 	}
 
 	for _, tc := range cases {
@@ -37,6 +37,20 @@ type Record struct {
 
 	// Duration is the total runtime for the clone.
 	Duration time.Duration `json:"duration,omitempty"`
+
+	// FetchStrategy is how the refs were fetched.
+	FetchStrategy *FetchStrategy `json:"fetch_strategy,omitempty"`
+}
+
+// FetchStrategy describes how the refs were fetched.
+type FetchStrategy struct {
+	// Depth is the depth the base ref was fetched with, zero if its full
+	// history was fetched. Pulls are always fetched with their full history.
+	Depth int `json:"depth,omitempty"`
+	// Filter is the partial clone filter of all fetches, e.g. blob:none.
+	Filter string `json:"filter,omitempty"`
+	// SkipFetchHead is set if the fetch of all branches and tags was skipped.
+	SkipFetchHead bool `json:"skip_fetch_head,omitempty"`
 }
 
 // Command is a trace of a command executed
@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/initupload"
 	"sigs.k8s.io/prow/pkg/kube"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 	"sigs.k8s.io/prow/pkg/pod-utils/clone"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
@@ -60,6 +62,8 @@ const (
 	s3CredentialsMountPath  = "/secrets/s3-storage"
 	outputMountName         = "output"
 	outputMountPath         = "/output"
+	cacheMountName          = "cache"
+	cacheMountPath          = "/caches"
 )
 
 // Labels returns a string slice with label consts from kube.
@@ -80,6 +84,13 @@ func VolumeMounts(dc *prowapi.DecorationConfig) sets.Set[string] {
 	for _, sshKeySecret := range dc.SSHKeySecrets {
 		ret.Insert(sshKeySecret)
 	}
+	var cacheVolumes int
+	for _, c := range dc.Caches {
+		for range c.Paths {
+			ret.Insert(fmt.Sprintf("%s-%d", cacheMountName, cacheVolumes))
+			cacheVolumes++
+		}
+	}
 	return ret
 }
 
@@ -95,7 +106,21 @@ func VolumeMountPathsOnTestContainer() sets.Set[string] {
 
 // PodUtilsContainerNames returns a string set with pod utility container name consts in it.
 func PodUtilsContainerNames() sets.Set[string] {
-	return sets.New[string](cloneRefsName, initUploadName, entrypointName, sidecarName)
+	return sets.New[string](cloneRefsName, initUploadName, entrypointName, SidecarContainerName)
+}
+
+// PodUtilsContainerImages maps the names of the pod utility containers to the
+// images they run with the given utility images.
+func PodUtilsContainerImages(images *prowapi.UtilityImages) map[string]string {
+	if images == nil {
+		return nil
+	}
+	return map[string]string{
+		cloneRefsName:        images.CloneRefs,
+		initUploadName:       images.InitUpload,
+		entrypointName:       images.Entrypoint,
+		SidecarContainerName: images.Sidecar,
+	}
 }
 
 // LabelsAndAnnotationsForSpec returns a minimal set of labels to add to prowjobs or its owned resources.
@@ -143,6 +168,9 @@ func LabelsAndAnnotationsForSpec(spec prowapi.ProwJobSpec, extraLabels, extraAnn
 			labels[kube.PullLabel] = strconv.Itoa(refs.Pulls[0].Number)
 		}
 	}
+	if spec.ProwJobDefault != nil && spec.ProwJobDefault.TenantID != "" {
+		labels[kube.TenantIDLabel] = spec.ProwJobDefault.TenantID
+	}
 
 	for k, v := range extraLabels {
 		labels[k] = v
@@ -268,10 +296,12 @@ func CloneLogPath(logMount coreapi.VolumeMount) string {
 const (
 	entrypointName = "place-entrypoint"
 	initUploadName = "initupload"
-	sidecarName    = "sidecar"
 	cloneRefsName  = "clonerefs"
 )
 
+// SidecarContainerName is the name of the sidecar container of decorated pods.
+const SidecarContainerName = "sidecar"
+
 // cloneEnv encodes clonerefs Options into json and puts it into an environment variable
 func cloneEnv(opt clonerefs.Options) ([]coreapi.EnvVar, error) {
 	// TODO(fejta): use flags
@@ -530,6 +560,14 @@ func metadataFile(log coreapi.VolumeMount, prefix string) string {
 	return filepath.Join(ad, fmt.Sprintf("%s-metadata.json", prefix))
 }
 
+func failureFile(log coreapi.VolumeMount, prefix string) string {
+	ad := artifactsDir(log)
+	if prefix == "" {
+		return filepath.Join(ad, prowapi.FailureFile)
+	}
+	return filepath.Join(ad, fmt.Sprintf("%s-%s", prefix, prowapi.FailureFile))
+}
+
 func artifactsDir(log coreapi.VolumeMount) string {
 	return filepath.Join(log.MountPath, "artifacts")
 }
@@ -546,6 +584,7 @@ func InjectEntrypoint(c *coreapi.Container, timeout, gracePeriod time.Duration,
 		ProcessLog:    processLog(log, prefix),
 		MarkerFile:    markerFile(log, prefix),
 		MetadataFile:  metadataFile(log, prefix),
+		FailureFile:   failureFile(log, prefix),
 	}
 	// TODO(fejta): use flags
 	entrypointConfigEnv, err := entrypoint.Encode(entrypoint.Options{
@@ -630,10 +669,12 @@ func BlobStorageOptions(dc prowapi.DecorationConfig, localMode bool) ([]coreapi.
 	return volumes, mounts, opt
 }
 
-func InitUpload(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blobStorageMounts []coreapi.VolumeMount, cloneLogMount *coreapi.VolumeMount, outputMount *coreapi.VolumeMount, encodedJobSpec string) (*coreapi.Container, error) {
+func InitUpload(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blobStorageMounts []coreapi.VolumeMount, cloneLogMount *coreapi.VolumeMount, outputMount *coreapi.VolumeMount, encodedJobSpec string, caches []cache.Cache, startedMetadata map[string]string) (*coreapi.Container, error) {
 	// TODO(fejta): remove encodedJobSpec
 	initUploadOptions := initupload.Options{
-		Options: &gcsOptions,
+		Options:         &gcsOptions,
+		Caches:          caches,
+		StartedMetadata: startedMetadata,
 	}
 	var mounts []coreapi.VolumeMount
 	if cloneLogMount != nil {
@@ -664,6 +705,110 @@ func InitUpload(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options,
 	return container, nil
 }
 
+// startedMetadata returns the extra metadata of the started.json of the
+// job: the started_metadata of its decoration config, overridden by its
+// annotations prefixed with StartedMetadataAnnotationPrefix.
+func startedMetadata(pj prowapi.ProwJob) map[string]string {
+	metadata := map[string]string{}
+	for key, value := range pj.Spec.DecorationConfig.StartedMetadata {
+		metadata[key] = value
+	}
+	for key, value := range pj.Annotations {
+		if name := strings.TrimPrefix(key, prowapi.StartedMetadataAnnotationPrefix); name != key && name != "" {
+			metadata[name] = value
+		}
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// cacheKeyData is what the keys of caches are rendered with.
+type cacheKeyData struct {
+	Job     string
+	Org     string
+	Repo    string
+	BaseRef string
+}
+
+// untrustedCacheJobTypes run code that is not reviewed yet, so they do not
+// save caches unless asked to, and restore the ones of postsubmits.
+var untrustedCacheJobTypes = sets.New[prowapi.ProwJobType](prowapi.PresubmitJob, prowapi.BatchJob)
+
+// noRepoCacheScope scopes the caches of jobs without refs. It is no valid
+// org name, so these caches can't collide with the ones of a repo.
+const noRepoCacheScope = "_"
+
+// cacheVolumes returns the volumes that hold the directories of the caches
+// of the job, their mounts in the test containers and in the pod utilities,
+// and the caches as the pod utilities see them. The keys of the caches are
+// prefixed with the type of the job and the org/repo of its refs, or of its
+// first extra refs if it has none, so that jobs of other repos can't read or
+// write them.
+func cacheVolumes(pj prowapi.ProwJob) ([]coreapi.Volume, []coreapi.VolumeMount, []coreapi.VolumeMount, []cache.Cache, error) {
+	data := cacheKeyData{Job: pj.Spec.Job}
+	if pj.Spec.Refs != nil {
+		data.Org, data.Repo, data.BaseRef = pj.Spec.Refs.Org, pj.Spec.Refs.Repo, pj.Spec.Refs.BaseRef
+	}
+	scope := noRepoCacheScope
+	if refs := pj.Spec.Refs; refs != nil {
+		scope = path.Join(refs.Org, refs.Repo)
+	} else if len(pj.Spec.ExtraRefs) > 0 {
+		scope = path.Join(pj.Spec.ExtraRefs[0].Org, pj.Spec.ExtraRefs[0].Repo)
+	}
+	if strings.Contains(scope, "..") {
+		return nil, nil, nil, nil, fmt.Errorf("org/repo %q of caches must not contain \"..\"", scope)
+	}
+	var volumes []coreapi.Volume
+	var testMounts, utilityMounts []coreapi.VolumeMount
+	var caches []cache.Cache
+	for _, c := range pj.Spec.DecorationConfig.Caches {
+		tmpl, err := template.New("key").Option("missingkey=error").Parse(c.Key)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("parse key of cache: %w", err)
+		}
+		var key strings.Builder
+		if err := tmpl.Execute(&key, data); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("render key of cache: %w", err)
+		}
+		if strings.Contains(key.String(), "..") {
+			return nil, nil, nil, nil, fmt.Errorf("rendered key %q of cache must not contain \"..\"", key.String())
+		}
+		rendered := cache.Cache{Key: path.Join(string(pj.Spec.Type), scope, key.String())}
+		if untrustedCacheJobTypes.Has(pj.Spec.Type) {
+			rendered.Fallback = path.Join(string(prowapi.PostsubmitJob), scope, key.String())
+		}
+		for _, p := range c.Paths {
+			name := fmt.Sprintf("%s-%d", cacheMountName, len(volumes))
+			volumes = append(volumes, coreapi.Volume{
+				Name:         name,
+				VolumeSource: coreapi.VolumeSource{EmptyDir: &coreapi.EmptyDirVolumeSource{}},
+			})
+			testMounts = append(testMounts, coreapi.VolumeMount{Name: name, MountPath: p})
+			utilityMount := coreapi.VolumeMount{Name: name, MountPath: path.Join(cacheMountPath, strconv.Itoa(len(utilityMounts)))}
+			utilityMounts = append(utilityMounts, utilityMount)
+			rendered.Dirs = append(rendered.Dirs, utilityMount.MountPath)
+		}
+		caches = append(caches, rendered)
+	}
+	return volumes, testMounts, utilityMounts, caches, nil
+}
+
+// savedCaches returns the caches that sidecar saves after the test passed.
+func savedCaches(pj prowapi.ProwJob, caches []cache.Cache) []cache.Cache {
+	if !untrustedCacheJobTypes.Has(pj.Spec.Type) {
+		return caches
+	}
+	var saved []cache.Cache
+	for i, c := range pj.Spec.DecorationConfig.Caches {
+		if c.SaveFromPresubmits {
+			saved = append(saved, caches[i])
+		}
+	}
+	return saved
+}
+
 // LogMountAndVolume returns the canonical volume and mount used to persist container logs.
 func LogMountAndVolume() (coreapi.VolumeMount, coreapi.Volume) {
 	return coreapi.VolumeMount{
@@ -744,11 +889,17 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 		cloneLogMount = &logMount
 	}
 
+	cacheVolumes, cacheTestMounts, cacheUtilityMounts, caches, err := cacheVolumes(*pj)
+	if err != nil {
+		return fmt.Errorf("create cache volumes: %w", err)
+	}
+
 	encodedJobSpec := rawEnv[downwardapi.JobSpecEnv]
-	initUpload, err := InitUpload(pj.Spec.DecorationConfig, blobStorageOptions, blobStorageMounts, cloneLogMount, outputMount, encodedJobSpec)
+	initUpload, err := InitUpload(pj.Spec.DecorationConfig, blobStorageOptions, blobStorageMounts, cloneLogMount, outputMount, encodedJobSpec, caches, startedMetadata(*pj))
 	if err != nil {
 		return fmt.Errorf("create initupload container: %w", err)
 	}
+	initUpload.VolumeMounts = append(initUpload.VolumeMounts, cacheUtilityMounts...)
 	spec.InitContainers = append(
 		spec.InitContainers,
 		*initUpload,
@@ -798,13 +949,18 @@ func decorate(spec *coreapi.PodSpec, pj *prowapi.ProwJob, rawEnv map[string]stri
 
 	ignoreInterrupts := pj.Spec.DecorationConfig.UploadIgnoresInterrupts != nil && *pj.Spec.DecorationConfig.UploadIgnoresInterrupts
 
-	sidecar, err := Sidecar(pj.Spec.DecorationConfig, blobStorageOptions, blobStorageMounts, logMount, outputMount, encodedJobSpec, !RequirePassingEntries, ignoreInterrupts, secretVolumeMounts, wrappers...)
+	sidecar, err := Sidecar(pj.Spec.DecorationConfig, blobStorageOptions, blobStorageMounts, logMount, outputMount, encodedJobSpec, !RequirePassingEntries, ignoreInterrupts, secretVolumeMounts, savedCaches(*pj, caches), wrappers...)
 	if err != nil {
 		return fmt.Errorf("create sidecar: %w", err)
 	}
+	sidecar.VolumeMounts = append(sidecar.VolumeMounts, cacheUtilityMounts...)
 
 	spec.Volumes = append(spec.Volumes, logVolume, toolsVolume)
 	spec.Volumes = append(spec.Volumes, blobStorageVolumes...)
+	spec.Volumes = append(spec.Volumes, cacheVolumes...)
+	for i, container := range spec.Containers {
+		spec.Containers[i].VolumeMounts = append(container.VolumeMounts, cacheTestMounts...)
+	}
 	if outputVolume != nil {
 		spec.Volumes = append(spec.Volumes, *outputVolume)
 	}
@@ -894,7 +1050,7 @@ const (
 	RequirePassingEntries = true
 )
 
-func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blobStorageMounts []coreapi.VolumeMount, logMount coreapi.VolumeMount, outputMount *coreapi.VolumeMount, encodedJobSpec string, requirePassingEntries, ignoreInterrupts bool, secretVolumeMounts []coreapi.VolumeMount, wrappers ...wrapper.Options) (*coreapi.Container, error) {
+func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blobStorageMounts []coreapi.VolumeMount, logMount coreapi.VolumeMount, outputMount *coreapi.VolumeMount, encodedJobSpec string, requirePassingEntries, ignoreInterrupts bool, secretVolumeMounts []coreapi.VolumeMount, caches []cache.Cache, wrappers ...wrapper.Options) (*coreapi.Container, error) {
 	var secretVolumePaths []string
 	for _, volumeMount := range secretVolumeMounts {
 		secretVolumePaths = append(secretVolumePaths, volumeMount.MountPath)
@@ -915,6 +1071,9 @@ func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blo
 		EntryError:       requirePassingEntries,
 		IgnoreInterrupts: ignoreInterrupts,
 		CensoringOptions: censoringOptions,
+		TestSummaryPath:  coreapi.TerminationMessagePathDefault,
+		UploadInterval:   config.UploadInterval.Get(),
+		Caches:           caches,
 	})
 
 	if err != nil {
@@ -928,7 +1087,7 @@ func Sidecar(config *prowapi.DecorationConfig, gcsOptions gcsupload.Options, blo
 	}
 
 	container := &coreapi.Container{
-		Name:  sidecarName,
+		Name:  SidecarContainerName,
 		Image: config.UtilityImages.Sidecar,
 		Env: KubeEnv(map[string]string{
 			sidecar.JSONConfigEnvVar: sidecarConfigEnv,
@@ -39,6 +39,7 @@ import (
 	"sigs.k8s.io/prow/pkg/gcsupload"
 	"sigs.k8s.io/prow/pkg/github"
 	"sigs.k8s.io/prow/pkg/initupload"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
 	"sigs.k8s.io/prow/pkg/sidecar"
 	"sigs.k8s.io/prow/pkg/testutil"
@@ -1329,6 +1330,22 @@ func TestSidecar(t *testing.T) {
 			},
 			wrappers: []wrapper.Options{{Args: []string{"yes"}}},
 		},
+		{
+			name: "with upload interval",
+			config: &prowapi.DecorationConfig{
+				UtilityImages:  &prowapi.UtilityImages{Sidecar: "sidecar-image"},
+				UploadInterval: &prowapi.Duration{Duration: 30 * time.Second},
+			},
+			gcsOptions: gcsupload.Options{
+				Items:            []string{"first", "second"},
+				GCSConfiguration: &prowapi.GCSConfiguration{Bucket: "bucket"},
+			},
+			blobStorageMounts:     []coreapi.VolumeMount{{Name: "blob", MountPath: "/blob"}},
+			logMount:              coreapi.VolumeMount{Name: "logs", MountPath: "/logs"},
+			encodedJobSpec:        "spec",
+			requirePassingEntries: true,
+			wrappers:              []wrapper.Options{{Args: []string{"yes"}}},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1338,7 +1355,7 @@ func TestSidecar(t *testing.T) {
 				testCase.blobStorageMounts, testCase.logMount, testCase.outputMount,
 				testCase.encodedJobSpec,
 				testCase.requirePassingEntries, testCase.ignoreInterrupts,
-				testCase.secretVolumeMounts, testCase.wrappers...,
+				testCase.secretVolumeMounts, nil, testCase.wrappers...,
 			)
 			if err != nil {
 				t.Fatalf("%s: got an error from Sidecar(): %v", testCase.name, err)
@@ -1622,6 +1639,58 @@ func TestDecorate(t *testing.T) {
 			},
 			rawEnv: map[string]string{"custom": "env"},
 		},
+		{
+			name: "caches",
+			spec: &coreapi.PodSpec{
+				Volumes: []coreapi.Volume{
+					{Name: "secret", VolumeSource: coreapi.VolumeSource{Secret: &coreapi.SecretVolumeSource{SecretName: "secretname"}}},
+				},
+				Containers: []coreapi.Container{
+					{Name: "test", Command: []string{"/bin/ls"}, Args: []string{"-l", "-a"}, VolumeMounts: []coreapi.VolumeMount{{Name: "secret", MountPath: "/secret"}}},
+				},
+				ServiceAccountName: "tester",
+			},
+			pj: &prowapi.ProwJob{
+				Spec: prowapi.ProwJobSpec{
+					Type: prowapi.PresubmitJob,
+					Job:  "pull-repo-unit",
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: time.Minute},
+						GracePeriod: &prowapi.Duration{Duration: time.Hour},
+						Caches: []prowapi.Cache{
+							{Key: "{{.Org}}-{{.Repo}}-{{.BaseRef}}-gomod", Paths: []string{"/go/pkg/mod", "/root/.cache/go-build"}},
+							{Key: "{{.Job}}-bazel", Paths: []string{"/root/.cache/bazel"}, SaveFromPresubmits: true},
+						},
+						UtilityImages: &prowapi.UtilityImages{
+							CloneRefs:  "cloneimage",
+							InitUpload: "initimage",
+							Entrypoint: "entrypointimage",
+							Sidecar:    "sidecarimage",
+						},
+						Resources: &prowapi.Resources{
+							CloneRefs:       &coreapi.ResourceRequirements{Limits: coreapi.ResourceList{"cpu": resource.Quantity{}}, Requests: coreapi.ResourceList{"memory": resource.Quantity{}}},
+							InitUpload:      &coreapi.ResourceRequirements{Limits: coreapi.ResourceList{"cpu": resource.Quantity{}}, Requests: coreapi.ResourceList{"memory": resource.Quantity{}}},
+							PlaceEntrypoint: &coreapi.ResourceRequirements{Limits: coreapi.ResourceList{"cpu": resource.Quantity{}}, Requests: coreapi.ResourceList{"memory": resource.Quantity{}}},
+							Sidecar:         &coreapi.ResourceRequirements{Limits: coreapi.ResourceList{"cpu": resource.Quantity{}}, Requests: coreapi.ResourceList{"memory": resource.Quantity{}}},
+						},
+						GCSConfiguration: &prowapi.GCSConfiguration{
+							Bucket:       "bucket",
+							PathStrategy: "single",
+							DefaultOrg:   "org",
+							DefaultRepo:  "repo",
+						},
+						GCSCredentialsSecret:      &gCSCredentialsSecret,
+						DefaultServiceAccountName: &defaultServiceAccountName,
+					},
+					Refs: &prowapi.Refs{
+						Org: "org", Repo: "repo", BaseRef: "main", BaseSHA: "abcd1234",
+						Pulls: []prowapi.Pull{{Number: 1, SHA: "aksdjhfkds"}},
+					},
+					ExtraRefs: []prowapi.Refs{{Org: "other", Repo: "something", BaseRef: "release", BaseSHA: "sldijfsd"}},
+				},
+			},
+			rawEnv: map[string]string{"custom": "env"},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1633,3 +1702,152 @@ func TestDecorate(t *testing.T) {
 		})
 	}
 }
+
+func TestStartedMetadata(t *testing.T) {
+	testCases := []struct {
+		name        string
+		metadata    map[string]string
+		annotations map[string]string
+		expected    map[string]string
+	}{
+		{
+			name:        "no started metadata",
+			annotations: map[string]string{"prow.k8s.io/job": "job-name"},
+		},
+		{
+			name:     "started metadata of the decoration config",
+			metadata: map[string]string{"tenant": "sig-node"},
+			expected: map[string]string{"tenant": "sig-node"},
+		},
+		{
+			name:     "annotations override the decoration config",
+			metadata: map[string]string{"tenant": "sig-node", "cost-center": "ci"},
+			annotations: map[string]string{
+				"prow.k8s.io/job":                     "job-name",
+				"prow.k8s.io/started-metadata.":       "ignored",
+				"prow.k8s.io/started-metadata.tenant": "sig-release",
+			},
+			expected: map[string]string{"tenant": "sig-release", "cost-center": "ci"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pj := prowapi.ProwJob{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       prowapi.ProwJobSpec{DecorationConfig: &prowapi.DecorationConfig{StartedMetadata: tc.metadata}},
+			}
+			if actual := startedMetadata(pj); !equality.Semantic.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected started metadata %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCacheKeys(t *testing.T) {
+	testCases := []struct {
+		name          string
+		jobType       prowapi.ProwJobType
+		refs          *prowapi.Refs
+		extraRefs     []prowapi.Refs
+		caches        []prowapi.Cache
+		expected      []cache.Cache
+		expectedSaved []cache.Cache
+		expectedErr   bool
+	}{
+		{
+			name:    "postsubmit saves its caches",
+			jobType: prowapi.PostsubmitJob,
+			caches:  []prowapi.Cache{{Key: "{{.Repo}}-go", Paths: []string{"/go"}}},
+			expected: []cache.Cache{
+				{Key: "postsubmit/org/repo/repo-go", Dirs: []string{"/caches/0"}},
+			},
+			expectedSaved: []cache.Cache{
+				{Key: "postsubmit/org/repo/repo-go", Dirs: []string{"/caches/0"}},
+			},
+		},
+		{
+			name:    "presubmit restores the caches of postsubmits and only saves if asked to",
+			jobType: prowapi.PresubmitJob,
+			caches: []prowapi.Cache{
+				{Key: "{{.Repo}}-go", Paths: []string{"/go"}},
+				{Key: "{{.Repo}}-bazel", Paths: []string{"/bazel"}, SaveFromPresubmits: true},
+			},
+			expected: []cache.Cache{
+				{Key: "presubmit/org/repo/repo-go", Fallback: "postsubmit/org/repo/repo-go", Dirs: []string{"/caches/0"}},
+				{Key: "presubmit/org/repo/repo-bazel", Fallback: "postsubmit/org/repo/repo-bazel", Dirs: []string{"/caches/1"}},
+			},
+			expectedSaved: []cache.Cache{
+				{Key: "presubmit/org/repo/repo-bazel", Fallback: "postsubmit/org/repo/repo-bazel", Dirs: []string{"/caches/1"}},
+			},
+		},
+		{
+			name:    "jobs of other repos with the same key use other caches",
+			jobType: prowapi.PostsubmitJob,
+			refs:    &prowapi.Refs{Org: "other", Repo: "repo", BaseRef: "main"},
+			caches:  []prowapi.Cache{{Key: "{{.Repo}}-go", Paths: []string{"/go"}}},
+			expected: []cache.Cache{
+				{Key: "postsubmit/other/repo/repo-go", Dirs: []string{"/caches/0"}},
+			},
+			expectedSaved: []cache.Cache{
+				{Key: "postsubmit/other/repo/repo-go", Dirs: []string{"/caches/0"}},
+			},
+		},
+		{
+			name:      "periodic uses the repo of its first extra refs",
+			jobType:   prowapi.PeriodicJob,
+			extraRefs: []prowapi.Refs{{Org: "org", Repo: "tools"}, {Org: "org", Repo: "repo"}},
+			caches:    []prowapi.Cache{{Key: "{{.Job}}", Paths: []string{"/go"}}},
+			expected: []cache.Cache{
+				{Key: "periodic/org/tools/job", Dirs: []string{"/caches/0"}},
+			},
+			expectedSaved: []cache.Cache{
+				{Key: "periodic/org/tools/job", Dirs: []string{"/caches/0"}},
+			},
+		},
+		{
+			name:      "periodic without refs uses caches of no repo",
+			jobType:   prowapi.PeriodicJob,
+			extraRefs: []prowapi.Refs{},
+			caches:    []prowapi.Cache{{Key: "org/repo/{{.Job}}", Paths: []string{"/go"}}},
+			expected: []cache.Cache{
+				{Key: "periodic/_/org/repo/job", Dirs: []string{"/caches/0"}},
+			},
+			expectedSaved: []cache.Cache{
+				{Key: "periodic/_/org/repo/job", Dirs: []string{"/caches/0"}},
+			},
+		},
+		{
+			name:        "rendered key must not escape the caches",
+			jobType:     prowapi.PeriodicJob,
+			caches:      []prowapi.Cache{{Key: "../{{.Job}}", Paths: []string{"/go"}}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			refs := tc.refs
+			if refs == nil && tc.extraRefs == nil {
+				refs = &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main"}
+			}
+			pj := prowapi.ProwJob{Spec: prowapi.ProwJobSpec{
+				Type:             tc.jobType,
+				Job:              "job",
+				Refs:             refs,
+				ExtraRefs:        tc.extraRefs,
+				DecorationConfig: &prowapi.DecorationConfig{Caches: tc.caches},
+			}}
+			_, _, _, caches, err := cacheVolumes(pj)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if !equality.Semantic.DeepEqual(caches, tc.expected) {
+				t.Errorf("expected caches %v, got %v", tc.expected, caches)
+			}
+			if saved := savedCaches(pj, caches); !equality.Semantic.DeepEqual(saved, tc.expectedSaved) {
+				t.Errorf("expected saved caches %v, got %v", tc.expectedSaved, saved)
+			}
+		})
+	}
+}
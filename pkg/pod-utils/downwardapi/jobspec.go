@@ -46,6 +46,8 @@ type JobSpec struct {
 
 	DecorationConfig *prowapi.DecorationConfig `json:"decoration_config,omitempty"`
 
+	ArtifactRetention *prowapi.JobArtifactRetention `json:"artifact_retention,omitempty"`
+
 	// we need to keep track of the agent until we
 	// migrate everyone away from using the $BUILD_NUMBER
 	// environment variable
@@ -55,14 +57,15 @@ type JobSpec struct {
 // NewJobSpec converts a prowapi.ProwJobSpec invocation into a JobSpec
 func NewJobSpec(spec prowapi.ProwJobSpec, buildID, prowJobID string) JobSpec {
 	return JobSpec{
-		Type:             spec.Type,
-		Job:              spec.Job,
-		BuildID:          buildID,
-		ProwJobID:        prowJobID,
-		Refs:             spec.Refs,
-		ExtraRefs:        spec.ExtraRefs,
-		DecorationConfig: spec.DecorationConfig,
-		agent:            spec.Agent,
+		Type:              spec.Type,
+		Job:               spec.Job,
+		BuildID:           buildID,
+		ProwJobID:         prowJobID,
+		Refs:              spec.Refs,
+		ExtraRefs:         spec.ExtraRefs,
+		DecorationConfig:  spec.DecorationConfig,
+		ArtifactRetention: spec.ArtifactRetention,
+		agent:             spec.Agent,
 	}
 }
 
@@ -19,7 +19,10 @@ package gcs
 import (
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"mime"
@@ -93,6 +96,67 @@ func LocalExport(ctx context.Context, exportDir string, uploadTargets map[string
 	return upload(dtw, uploadTargets)
 }
 
+// chunkSuffix is appended to the name of an object for the chunk that is
+// appended to it.
+const chunkSuffix = ".chunk"
+
+// Append appends the data in the appendTargets map to the existing objects in
+// blob storage. The map is keyed on blob storage path under the bucket. The
+// data is uploaded next to the object and composed onto it, so that the object
+// itself is not uploaded again. Only GCS supports composing objects.
+func Append(ctx context.Context, bucket, gcsCredentialsFile, s3CredentialsFile string, appendTargets map[string]UploadFunc) error {
+	parsedBucket, err := url.Parse(bucket)
+	if err != nil {
+		return fmt.Errorf("cannot parse bucket name %s: %w", bucket, err)
+	}
+	if parsedBucket.Scheme == "" {
+		parsedBucket.Scheme = providers.GS
+	}
+
+	opener, err := pkgio.NewOpener(ctx, gcsCredentialsFile, s3CredentialsFile)
+	if err != nil {
+		return fmt.Errorf("new opener: %w", err)
+	}
+	return appendTo(ctx, opener, parsedBucket.String(), appendTargets)
+}
+
+// LocalAppend appends the data in the appendTargets map to the existing local
+// files. The map is keyed on file path under the exportDir.
+func LocalAppend(ctx context.Context, exportDir string, appendTargets map[string]UploadFunc) error {
+	opener, err := pkgio.NewOpener(ctx, "", "")
+	if err != nil {
+		return fmt.Errorf("new opener: %w", err)
+	}
+	return appendTo(ctx, opener, exportDir, appendTargets)
+}
+
+// appendTo uploads the data of the appendTargets as chunks next to their
+// objects, composes the chunks onto the objects and deletes them again.
+func appendTo(ctx context.Context, opener pkgio.Opener, bucket string, appendTargets map[string]UploadFunc) error {
+	dtw := func(dest string) dataWriter {
+		return &openerObjectWriter{Opener: opener, Context: ctx, Bucket: bucket, Dest: dest}
+	}
+	chunkTargets := make(map[string]UploadFunc, len(appendTargets))
+	for dest, upload := range appendTargets {
+		chunkTargets[dest+chunkSuffix] = upload
+	}
+	if err := upload(dtw, chunkTargets); err != nil {
+		return err
+	}
+
+	var errs []error
+	for dest := range appendTargets {
+		object, chunk := dtw(dest).fullUploadPath(), dtw(dest+chunkSuffix).fullUploadPath()
+		if err := opener.Compose(ctx, object, object, chunk); err != nil {
+			errs = append(errs, fmt.Errorf("append to %s: %w", object, err))
+		}
+		if err := opener.Delete(ctx, chunk); err != nil {
+			logrus.WithError(err).WithField("chunk", chunk).Warn("Failed to delete the appended chunk.")
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 func upload(dtw destToWriter, uploadTargets map[string]UploadFunc) error {
 	errCh := make(chan error, len(uploadTargets))
 	group := &sync.WaitGroup{}
@@ -226,6 +290,33 @@ func DataUploadWithOptions(newReader ReaderFunc, attrs pkgio.WriterOptions) Uplo
 	}
 }
 
+// ChecksumUpload returns an UploadFunc which uploads like upload and
+// reports the size and hex encoded SHA256 checksum of the data once the
+// upload succeeded. The data is checksummed before it is compressed.
+func ChecksumUpload(upload UploadFunc, report func(size int64, checksum string)) UploadFunc {
+	return func(writer dataWriter) error {
+		hashing := &hashingWriter{dataWriter: writer, hash: sha256.New()}
+		if err := upload(hashing); err != nil {
+			return err
+		}
+		report(hashing.size, hex.EncodeToString(hashing.hash.Sum(nil)))
+		return nil
+	}
+}
+
+type hashingWriter struct {
+	dataWriter
+	hash hash.Hash
+	size int64
+}
+
+func (w *hashingWriter) Write(p []byte) (int, error) {
+	n, err := w.dataWriter.Write(p)
+	w.hash.Write(p[:n])
+	w.size += int64(n)
+	return n, err
+}
+
 type dataWriter interface {
 	io.WriteCloser
 	fullUploadPath() string
@@ -462,3 +462,119 @@ func Test_openerObjectWriter_fullUploadPath(t *testing.T) {
 		})
 	}
 }
+
+func TestChecksumUpload(t *testing.T) {
+	dir := t.TempDir()
+	var size int64
+	var checksum string
+	upload := ChecksumUpload(DataUpload(func() (stdio.ReadCloser, error) {
+		return stdio.NopCloser(bytes.NewReader([]byte("hello"))), nil
+	}), func(s int64, c string) {
+		size, checksum = s, c
+	})
+	if err := LocalExport(context.Background(), dir, map[string]UploadFunc{"greeting.txt": upload}); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+
+	if size != 5 {
+		t.Errorf("expected size 5, got %d", size)
+	}
+	if expected := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"; checksum != expected {
+		t.Errorf("expected checksum %s, got %s", expected, checksum)
+	}
+	content, err := os.ReadFile(path.Join(dir, "greeting.txt"))
+	if err != nil {
+		t.Fatalf("failed to read uploaded file: %v", err)
+	}
+	if string(content) != "hello" {
+		t.Errorf("expected uploaded content %q, got %q", "hello", string(content))
+	}
+}
+
+func stringUpload(content string) UploadFunc {
+	return DataUpload(func() (stdio.ReadCloser, error) {
+		return stdio.NopCloser(bytes.NewReader([]byte(content))), nil
+	})
+}
+
+// composingOpener records the chunks that are written, composed and deleted.
+type composingOpener struct {
+	io.Opener
+	lock     sync.Mutex
+	written  map[string]string
+	composed [][]string
+	deleted  []string
+}
+
+type recordingWriter struct {
+	bytes.Buffer
+	close func(content string)
+}
+
+func (w *recordingWriter) Close() error {
+	w.close(w.String())
+	return nil
+}
+
+func (o *composingOpener) Writer(_ context.Context, path string, _ ...io.WriterOptions) (io.WriteCloser, error) {
+	return &recordingWriter{close: func(content string) {
+		o.lock.Lock()
+		defer o.lock.Unlock()
+		o.written[path] = content
+	}}, nil
+}
+
+func (o *composingOpener) Compose(_ context.Context, path string, sources ...string) error {
+	o.composed = append(o.composed, append([]string{path}, sources...))
+	return nil
+}
+
+func (o *composingOpener) Delete(_ context.Context, path string) error {
+	o.deleted = append(o.deleted, path)
+	return nil
+}
+
+func TestAppend(t *testing.T) {
+	opener := &composingOpener{written: map[string]string{}}
+	if err := appendTo(context.Background(), opener, "gs://bucket", map[string]UploadFunc{"job/build-log.txt": stringUpload("second\n")}); err != nil {
+		t.Fatalf("failed to append: %v", err)
+	}
+
+	chunk := "gs://bucket/job/build-log.txt" + chunkSuffix
+	if expected := map[string]string{chunk: "second\n"}; !reflect.DeepEqual(expected, opener.written) {
+		t.Errorf("expected chunks %v to be written, got %v", expected, opener.written)
+	}
+	if expected := [][]string{{"gs://bucket/job/build-log.txt", "gs://bucket/job/build-log.txt", chunk}}; !reflect.DeepEqual(expected, opener.composed) {
+		t.Errorf("expected compositions %v, got %v", expected, opener.composed)
+	}
+	if expected := []string{chunk}; !reflect.DeepEqual(expected, opener.deleted) {
+		t.Errorf("expected %v to be deleted, got %v", expected, opener.deleted)
+	}
+}
+
+func TestLocalAppend(t *testing.T) {
+	dir := t.TempDir()
+	if err := LocalExport(context.Background(), dir, map[string]UploadFunc{"build-log.txt": stringUpload("first\n")}); err != nil {
+		t.Fatalf("failed to upload: %v", err)
+	}
+	for _, chunk := range []string{"second\n", "third\n"} {
+		if err := LocalAppend(context.Background(), dir, map[string]UploadFunc{"build-log.txt": stringUpload(chunk)}); err != nil {
+			t.Fatalf("failed to append: %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(path.Join(dir, "build-log.txt"))
+	if err != nil {
+		t.Fatalf("failed to read appended file: %v", err)
+	}
+	if expected := "first\nsecond\nthird\n"; string(content) != expected {
+		t.Errorf("expected appended content %q, got %q", expected, string(content))
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to list the export dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the appended file to be left, got %v", entries)
+	}
+}
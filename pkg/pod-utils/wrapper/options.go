@@ -57,6 +57,12 @@ type Options struct {
 	// Prow will parse the file and merge it into
 	// the `metadata` field in finished.json
 	MetadataFile string `json:"metadata_file"`
+
+	// FailureFile is a file the job can write a Failure
+	// to, to classify why it failed. The entrypoint
+	// writes it when the process times out or cannot
+	// run, unless the job already wrote it.
+	FailureFile string `json:"failure_file,omitempty"`
 }
 
 type MarkerResult struct {
@@ -70,6 +76,7 @@ func (o *Options) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&o.ProcessLog, "process-log", "", "path to the log where stdout and stderr are streamed for the process we execute")
 	fs.StringVar(&o.MarkerFile, "marker-file", "", "file we write the return code of the process we execute once it has finished running")
 	fs.StringVar(&o.MetadataFile, "metadata-file", "", "path to the metadata file generated from the job")
+	fs.StringVar(&o.FailureFile, "failure-file", "", "path to the file classifying the failure of the job")
 }
 
 // Validate ensures that the set of options are
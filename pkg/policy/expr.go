@@ -0,0 +1,874 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy evaluates the expressions used by job policies.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// This file implements the small expression language of policies. Its syntax
+// follows the Common Expression Language (CEL), but it is not CEL: expressions
+// are not type checked and are evaluated dynamically against JSON values, so
+// all numbers are doubles and type errors only surface during evaluation. It
+// supports literals, field selection and indexing, the logical, relational and
+// arithmetic operators, the conditional operator, `in`, the has(), size(),
+// startsWith(), endsWith(), contains() and matches() functions and the all(),
+// exists(), exists_one(), filter() and map() macros.
+
+type activation map[string]interface{}
+
+type node interface {
+	eval(activation) (interface{}, error)
+}
+
+// Expression is a compiled policy expression.
+type Expression struct {
+	source string
+	root   node
+}
+
+// Compile parses a policy expression.
+func Compile(source string) (*Expression, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at offset %d", t.text, t.pos)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// EvalBool evaluates the expression with the given variables, which must be
+// JSON values, and requires the result to be a bool.
+func (e *Expression) EvalBool(vars map[string]interface{}) (bool, error) {
+	v, err := e.root.eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluated to %s, not bool", typeName(v))
+	}
+	return b, nil
+}
+
+// Lexer
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "%", "?", ":", ".", ",", "(", ")", "[", "]", "{", "}"}
+
+func lex(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '_' || unicode.IsLetter(c):
+			start := i
+			for i < len(s) && (s[i] == '_' || unicode.IsLetter(rune(s[i])) || unicode.IsDigit(rune(s[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: s[start:i], pos: start})
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(s) && (unicode.IsDigit(rune(s[i])) || s[i] == '.' || s[i] == 'e' || s[i] == 'E') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: s[start:i], pos: start})
+		case c == '"' || c == '\'':
+			start := i
+			i++
+			var sb strings.Builder
+			for ; i < len(s) && rune(s[i]) != c; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+					switch s[i] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 't':
+						sb.WriteByte('\t')
+					default:
+						sb.WriteByte(s[i])
+					}
+					continue
+				}
+				sb.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, fmt.Errorf("unterminated string starting at offset %d", start)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: start})
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(s[i:], op) {
+					tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(s)}), nil
+}
+
+// Parser
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) accept(op string) bool {
+	if t := p.peek(); t.kind == tokOp && t.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		t := p.peek()
+		return fmt.Errorf("expected %q at offset %d, got %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *parser) parseExpr() (node, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("?") {
+		return cond, nil
+	}
+	ifTrue, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	ifFalse, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return &conditional{cond: cond, ifTrue: ifTrue, ifFalse: ifFalse}, nil
+}
+
+// binaryPrecedence lists the binary operators from lowest to highest precedence.
+var binaryPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">=", "in"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		op := ""
+		for _, candidate := range binaryPrecedence[level] {
+			if (t.kind == tokOp || t.kind == tokIdent) && t.text == candidate {
+				op = candidate
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &not{operand: operand}, nil
+	}
+	if p.accept("-") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &binary{op: "-", left: &literal{value: float64(0)}, right: operand}, nil
+	}
+	return p.parseMember()
+}
+
+func (p *parser) parseMember() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.accept("."):
+			t := p.next()
+			if t.kind != tokIdent {
+				return nil, fmt.Errorf("expected field name at offset %d", t.pos)
+			}
+			if !p.accept("(") {
+				n = &selection{operand: n, field: t.text}
+				continue
+			}
+			if macro, ok := macros[t.text]; ok {
+				if n, err = p.parseMacro(t.text, macro, n); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			args, err := p.parseArgs(")")
+			if err != nil {
+				return nil, err
+			}
+			n = &call{function: t.text, args: append([]node{n}, args...)}
+		case p.accept("["):
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			n = &indexing{operand: n, index: index}
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (p *parser) parseMacro(name string, kind macroKind, target node) (node, error) {
+	t := p.next()
+	if t.kind != tokIdent {
+		return nil, fmt.Errorf("%s() expects a variable name as first argument at offset %d", name, t.pos)
+	}
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	body, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return &comprehension{kind: kind, name: name, target: target, variable: t.text, body: body}, nil
+}
+
+func (p *parser) parseArgs(closing string) ([]node, error) {
+	var args []node
+	if p.accept(closing) {
+		return args, nil
+	}
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.accept(closing) {
+			return args, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		return &literal{value: t.text}, nil
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at offset %d", t.text, t.pos)
+		}
+		return &literal{value: f}, nil
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return &literal{value: true}, nil
+		case "false":
+			return &literal{value: false}, nil
+		case "null":
+			return &literal{value: nil}, nil
+		}
+		if !p.accept("(") {
+			return &identifier{name: t.text}, nil
+		}
+		if t.text == "has" {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			sel, ok := arg.(*selection)
+			if !ok {
+				return nil, fmt.Errorf("has() expects a field selection at offset %d", t.pos)
+			}
+			return &presence{selection: sel}, nil
+		}
+		args, err := p.parseArgs(")")
+		if err != nil {
+			return nil, err
+		}
+		return &call{function: t.text, args: args}, nil
+	case tokOp:
+		switch t.text {
+		case "(":
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expect(")")
+		case "[":
+			elements, err := p.parseArgs("]")
+			if err != nil {
+				return nil, err
+			}
+			return &list{elements: elements}, nil
+		}
+	}
+	if t.kind == tokEOF {
+		return nil, errors.New("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at offset %d", t.text, t.pos)
+}
+
+// AST
+
+type literal struct{ value interface{} }
+
+func (n *literal) eval(activation) (interface{}, error) { return n.value, nil }
+
+type identifier struct{ name string }
+
+func (n *identifier) eval(a activation) (interface{}, error) {
+	v, ok := a[n.name]
+	if !ok {
+		return nil, fmt.Errorf("undeclared reference to %q", n.name)
+	}
+	return v, nil
+}
+
+type list struct{ elements []node }
+
+func (n *list) eval(a activation) (interface{}, error) {
+	values := make([]interface{}, 0, len(n.elements))
+	for _, e := range n.elements {
+		v, err := e.eval(a)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+type selection struct {
+	operand node
+	field   string
+}
+
+func (n *selection) eval(a activation) (interface{}, error) {
+	v, err := n.operand.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot select field %q from %s", n.field, typeName(v))
+	}
+	field, ok := m[n.field]
+	if !ok {
+		return nil, fmt.Errorf("no such key: %s", n.field)
+	}
+	return field, nil
+}
+
+type presence struct{ selection *selection }
+
+func (n *presence) eval(a activation) (interface{}, error) {
+	v, err := n.selection.operand.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("has() cannot test field %q on %s", n.selection.field, typeName(v))
+	}
+	field, ok := m[n.selection.field]
+	return ok && field != nil, nil
+}
+
+type indexing struct {
+	operand node
+	index   node
+}
+
+func (n *indexing) eval(a activation) (interface{}, error) {
+	v, err := n.operand.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	i, err := n.index.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	switch container := v.(type) {
+	case map[string]interface{}:
+		key, ok := i.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot index map with %s", typeName(i))
+		}
+		value, ok := container[key]
+		if !ok {
+			return nil, fmt.Errorf("no such key: %s", key)
+		}
+		return value, nil
+	case []interface{}:
+		f, ok := i.(float64)
+		if !ok || f != float64(int(f)) {
+			return nil, fmt.Errorf("cannot index list with %v", i)
+		}
+		if int(f) < 0 || int(f) >= len(container) {
+			return nil, fmt.Errorf("index %d out of range", int(f))
+		}
+		return container[int(f)], nil
+	}
+	return nil, fmt.Errorf("cannot index %s", typeName(v))
+}
+
+type not struct{ operand node }
+
+func (n *not) eval(a activation) (interface{}, error) {
+	v, err := n.operand.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("no such overload: !%s", typeName(v))
+	}
+	return !b, nil
+}
+
+type conditional struct{ cond, ifTrue, ifFalse node }
+
+func (n *conditional) eval(a activation) (interface{}, error) {
+	v, err := n.cond.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("conditional requires a bool, got %s", typeName(v))
+	}
+	if b {
+		return n.ifTrue.eval(a)
+	}
+	return n.ifFalse.eval(a)
+}
+
+type binary struct {
+	op          string
+	left, right node
+}
+
+func (n *binary) eval(a activation) (interface{}, error) {
+	if n.op == "&&" || n.op == "||" {
+		return n.evalLogical(a)
+	}
+	left, err := n.left.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "in":
+		switch container := right.(type) {
+		case []interface{}:
+			for _, e := range container {
+				if equal(left, e) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]interface{}:
+			key, ok := left.(string)
+			if !ok {
+				return false, nil
+			}
+			_, found := container[key]
+			return found, nil
+		}
+		return nil, fmt.Errorf("no such overload: %s in %s", typeName(left), typeName(right))
+	case "+":
+		switch l := left.(type) {
+		case string:
+			if r, ok := right.(string); ok {
+				return l + r, nil
+			}
+		case []interface{}:
+			if r, ok := right.([]interface{}); ok {
+				return append(append([]interface{}{}, l...), r...), nil
+			}
+		}
+	case "<", "<=", ">", ">=":
+		cmp, err := compare(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+	}
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("no such overload: %s %s %s", typeName(left), n.op, typeName(right))
+	}
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return l / r, nil
+	case "%":
+		// Operands are truncated to integers, so a fractional divisor such as
+		// 0.5 is a modulus by zero too.
+		if int64(r) == 0 {
+			return nil, errors.New("modulus by zero")
+		}
+		return float64(int64(l) % int64(r)), nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+// evalLogical implements commutative logical operators like CEL: an error on one
+// side is absorbed if the other side alone determines the result.
+func (n *binary) evalLogical(a activation) (interface{}, error) {
+	short := n.op == "||"
+	left, leftErr := evalBool(n.left, a)
+	if leftErr == nil && left == short {
+		return short, nil
+	}
+	right, rightErr := evalBool(n.right, a)
+	if rightErr == nil && right == short {
+		return short, nil
+	}
+	if leftErr != nil {
+		return nil, leftErr
+	}
+	if rightErr != nil {
+		return nil, rightErr
+	}
+	return !short, nil
+}
+
+func evalBool(n node, a activation) (bool, error) {
+	v, err := n.eval(a)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected bool, got %s", typeName(v))
+	}
+	return b, nil
+}
+
+type call struct {
+	function string
+	args     []node
+}
+
+func (n *call) eval(a activation) (interface{}, error) {
+	args := make([]interface{}, 0, len(n.args))
+	for _, arg := range n.args {
+		v, err := arg.eval(a)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	switch n.function {
+	case "size":
+		if len(args) != 1 {
+			break
+		}
+		switch v := args[0].(type) {
+		case string:
+			return float64(len([]rune(v))), nil
+		case []interface{}:
+			return float64(len(v)), nil
+		case map[string]interface{}:
+			return float64(len(v)), nil
+		}
+	case "startsWith", "endsWith", "contains", "matches":
+		if len(args) != 2 {
+			break
+		}
+		s, sok := args[0].(string)
+		arg, aok := args[1].(string)
+		if !sok || !aok {
+			break
+		}
+		switch n.function {
+		case "startsWith":
+			return strings.HasPrefix(s, arg), nil
+		case "endsWith":
+			return strings.HasSuffix(s, arg), nil
+		case "contains":
+			return strings.Contains(s, arg), nil
+		default:
+			re, err := regexp.Compile(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %w", arg, err)
+			}
+			return re.MatchString(s), nil
+		}
+	}
+	var types []string
+	for _, arg := range args {
+		types = append(types, typeName(arg))
+	}
+	return nil, fmt.Errorf("no such overload: %s(%s)", n.function, strings.Join(types, ", "))
+}
+
+type macroKind int
+
+const (
+	macroAll macroKind = iota
+	macroExists
+	macroExistsOne
+	macroFilter
+	macroMap
+)
+
+var macros = map[string]macroKind{
+	"all":        macroAll,
+	"exists":     macroExists,
+	"exists_one": macroExistsOne,
+	"filter":     macroFilter,
+	"map":        macroMap,
+}
+
+type comprehension struct {
+	kind     macroKind
+	name     string
+	target   node
+	variable string
+	body     node
+}
+
+func (n *comprehension) eval(a activation) (interface{}, error) {
+	v, err := n.target.eval(a)
+	if err != nil {
+		return nil, err
+	}
+	var elements []interface{}
+	switch target := v.(type) {
+	case []interface{}:
+		elements = target
+	case map[string]interface{}:
+		for key := range target {
+			elements = append(elements, key)
+		}
+	case nil:
+		// Absent lists are serialized as null, treat them like empty ones.
+	default:
+		return nil, fmt.Errorf("%s() cannot iterate over %s", n.name, typeName(v))
+	}
+
+	scope := activation{}
+	for k, v := range a {
+		scope[k] = v
+	}
+	matches := 0
+	var results []interface{}
+	for _, e := range elements {
+		scope[n.variable] = e
+		if n.kind == macroMap {
+			r, err := n.body.eval(scope)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, r)
+			continue
+		}
+		b, err := evalBool(n.body, scope)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case n.kind == macroAll && !b:
+			return false, nil
+		case n.kind == macroExists && b:
+			return true, nil
+		case b:
+			matches++
+			results = append(results, e)
+		}
+	}
+	switch n.kind {
+	case macroAll:
+		return true, nil
+	case macroExists:
+		return false, nil
+	case macroExistsOne:
+		return matches == 1, nil
+	}
+	if results == nil {
+		results = []interface{}{}
+	}
+	return results, nil
+}
+
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func compare(a, b interface{}) (int, error) {
+	switch l := a.(type) {
+	case float64:
+		if r, ok := b.(float64); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if r, ok := b.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	}
+	return 0, fmt.Errorf("no such overload: cannot compare %s and %s", typeName(a), typeName(b))
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case float64:
+		return "double"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEvalBool(t *testing.T) {
+	var job map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+		"type": "presubmit",
+		"job": "pull-test-infra-unit",
+		"max_concurrency": 2,
+		"refs": {"org": "kubernetes", "repo": "test-infra"},
+		"decoration_config": {"timeout": "2h"},
+		"pod_spec": {"containers": [{"image": "golang:1.22"}], "volumes": [{"name": "creds", "secret": {"secretName": "admin-token"}}]}
+	}`), &job); err != nil {
+		t.Fatalf("failed to unmarshal job: %v", err)
+	}
+	vars := map[string]interface{}{
+		"job":    job,
+		"org":    "kubernetes",
+		"labels": map[string]interface{}{"preset-dind": "true"},
+	}
+
+	testCases := []struct {
+		expression  string
+		expected    bool
+		expectedErr string
+	}{
+		{expression: `true`, expected: true},
+		{expression: `job.type == "presubmit"`, expected: true},
+		{expression: `job.refs.org == 'kubernetes' && job.refs.repo != "test-infra"`, expected: false},
+		{expression: `has(job.decoration_config)`, expected: true},
+		{expression: `has(job.cluster)`, expected: false},
+		{expression: `org != "kubernetes" || has(job.decoration_config)`, expected: true},
+		{expression: `job.max_concurrency >= 2 && job.max_concurrency < 2.5`, expected: true},
+		{expression: `job.max_concurrency * 2 + 1 == 5`, expected: true},
+		{expression: `-job.max_concurrency == -2`, expected: true},
+		{expression: `job.type in ["presubmit", "postsubmit"]`, expected: true},
+		{expression: `"preset-dind" in labels`, expected: true},
+		{expression: `labels["preset-dind"] == "true"`, expected: true},
+		{expression: `job.pod_spec.containers[0].image.startsWith("golang:")`, expected: true},
+		{expression: `job.job.endsWith("-unit") && job.job.contains("test-infra")`, expected: true},
+		{expression: `job.job.matches("^pull-.*-unit$")`, expected: true},
+		{expression: `size(job.pod_spec.containers) == 1 && job.job.size() == 20`, expected: true},
+		{expression: `job.pod_spec.volumes.exists(v, has(v.secret) && v.secret.secretName == "admin-token")`, expected: true},
+		{expression: `job.pod_spec.volumes.all(v, !has(v.secret) || v.secret.secretName != "admin-token")`, expected: false},
+		{expression: `job.pod_spec.containers.exists_one(c, c.image.startsWith("golang"))`, expected: true},
+		{expression: `job.pod_spec.containers.map(c, c.image) == ["golang:1.22"]`, expected: true},
+		{expression: `size(job.pod_spec.volumes.filter(v, v.name == "nope")) == 0`, expected: true},
+		{expression: `job.type == "periodic" ? false : true`, expected: true},
+		{expression: `!(job.type == "periodic")`, expected: true},
+		{expression: `job.cluster == "default" || true`, expected: true},
+		{expression: `job.cluster == "default" && false`, expected: false},
+		{expression: `job.cluster == "default"`, expectedErr: "no such key: cluster"},
+		{expression: `job.type`, expectedErr: "expression evaluated to string, not bool"},
+		{expression: `unknown == 1`, expectedErr: `undeclared reference to "unknown"`},
+		{expression: `job.type + 1 == 2`, expectedErr: "no such overload: string + double"},
+		{expression: `job.max_concurrency % 2 == 0`, expected: true},
+		{expression: `job.max_concurrency % 0.5 == 0`, expectedErr: "modulus by zero"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			e, err := Compile(tc.expression)
+			if err != nil {
+				t.Fatalf("failed to compile: %v", err)
+			}
+			actual, err := e.EvalBool(vars)
+			var errMsg string
+			if err != nil {
+				errMsg = err.Error()
+			}
+			if errMsg != tc.expectedErr {
+				t.Fatalf("expected error %q, got %q", tc.expectedErr, errMsg)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	testCases := []struct {
+		expression  string
+		expectedErr string
+	}{
+		{expression: ``, expectedErr: "unexpected end of expression"},
+		{expression: `job.type ==`, expectedErr: "unexpected end of expression"},
+		{expression: `"unterminated`, expectedErr: "unterminated string starting at offset 0"},
+		{expression: `job.type == "a" "b"`, expectedErr: `unexpected "b" at offset 16`},
+		{expression: `(true`, expectedErr: `expected ")" at offset 5, got ""`},
+		{expression: `has(job)`, expectedErr: "has() expects a field selection at offset 0"},
+		{expression: `job.all(1, true)`, expectedErr: "all() expects a variable name as first argument at offset 8"},
+		{expression: `job.type = "a"`, expectedErr: `unexpected character '=' at offset 9`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			_, err := Compile(tc.expression)
+			if err == nil {
+				t.Fatalf("expected error %q, got none", tc.expectedErr)
+			}
+			if err.Error() != tc.expectedErr {
+				t.Errorf("expected error %q, got %q", tc.expectedErr, err.Error())
+			}
+		})
+	}
+}
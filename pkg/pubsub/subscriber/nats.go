@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriber
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+// natsClientInterface interfaces with NATS for testing reason
+type natsClientInterface interface {
+	new(ctx context.Context, cfg config.NATSTrigger) (natsClientInterface, error)
+	subscription(subject string, maxOutstandingMessages int) subscriptionInterface
+}
+
+type natsClient struct {
+	url        string
+	queueGroup string
+	options    []nats.Option
+}
+
+// new reads the credentials of the NATS servers. The connections themselves
+// are opened by the subscriptions, so that they are closed along with them
+// when the config changes.
+func (c *natsClient) new(ctx context.Context, cfg config.NATSTrigger) (natsClientInterface, error) {
+	options := []nats.Option{nats.Name("prow-sub"), nats.MaxReconnects(-1)}
+	if cfg.CredentialsFile != "" {
+		if _, err := os.Stat(cfg.CredentialsFile); err != nil {
+			return nil, fmt.Errorf("nats credentials: %w", err)
+		}
+		options = append(options, nats.UserCredentials(cfg.CredentialsFile))
+	}
+	if cfg.TokenFile != "" {
+		token, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("nats token: %w", err)
+		}
+		options = append(options, nats.Token(strings.TrimSpace(string(token))))
+	}
+	return &natsClient{url: cfg.URL, queueGroup: cfg.QueueGroup, options: options}, nil
+}
+
+func (c *natsClient) subscription(subject string, maxOutstandingMessages int) subscriptionInterface {
+	return &natsSubscription{client: c, subject: subject, maxOutstandingMessages: maxOutstandingMessages}
+}
+
+type natsSubscription struct {
+	client                 *natsClient
+	subject                string
+	maxOutstandingMessages int
+}
+
+func (s *natsSubscription) string() string {
+	return s.subject
+}
+
+// receive joins the queue group of the subject and handles up to
+// maxOutstandingMessages messages concurrently until the context is cancelled.
+func (s *natsSubscription) receive(ctx context.Context, f func(context.Context, messageInterface)) error {
+	conn, err := nats.Connect(s.client.url, s.client.options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	messages := make(chan *nats.Msg, s.maxOutstandingMessages)
+	sub, err := conn.ChanQueueSubscribe(s.subject, s.client.queueGroup, messages)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	outstanding := make(chan struct{}, max(s.maxOutstandingMessages, 1))
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case message := <-messages:
+			outstanding <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-outstanding }()
+				f(ctx, &natsMessage{Msg: message})
+			}()
+		}
+	}
+}
+
+type natsMessage struct {
+	*nats.Msg
+}
+
+// getAttributes returns the headers of the message, which carry the
+// attributes Pub/Sub messages have.
+func (m *natsMessage) getAttributes() map[string]string {
+	attributes := make(map[string]string, len(m.Header))
+	for name := range m.Header {
+		attributes[name] = m.Header.Get(name)
+	}
+	return attributes
+}
+
+func (m *natsMessage) getPayload() []byte {
+	return m.Data
+}
+
+func (m *natsMessage) getID() string {
+	return m.Header.Get(nats.MsgIdHdr)
+}
+
+// isJetStream determines whether the message was delivered by a JetStream
+// consumer, which expects it to be acknowledged. Core NATS messages need no
+// acknowledgement.
+func (m *natsMessage) isJetStream() bool {
+	return strings.HasPrefix(m.Reply, "$JS.ACK.")
+}
+
+func (m *natsMessage) ack() {
+	if !m.isJetStream() {
+		return
+	}
+	if err := m.Msg.Ack(); err != nil {
+		logrus.WithError(err).WithField("nats-subject", m.Subject).Warn("Failed to acknowledge NATS message.")
+	}
+}
+
+func (m *natsMessage) nack() {
+	if !m.isJetStream() {
+		return
+	}
+	if err := m.Msg.Nak(); err != nil {
+		logrus.WithError(err).WithField("nats-subject", m.Subject).Warn("Failed to negatively acknowledge NATS message.")
+	}
+}
@@ -35,10 +35,13 @@ type configToWatch struct {
 	config.PubsubSubscriptions
 }
 
-// PullServer listen to Pull Pub/Sub subscriptions and handle them.
+// PullServer listen to Pull Pub/Sub subscriptions, SQS queues and NATS
+// subjects and handle them.
 type PullServer struct {
 	Subscriber *Subscriber
 	Client     pubsubClientInterface
+	SQSClient  sqsClientInterface
+	NATSClient natsClientInterface
 }
 
 // NewPullServer creates a new PullServer
@@ -46,6 +49,8 @@ func NewPullServer(s *Subscriber) *PullServer {
 	return &PullServer{
 		Subscriber: s,
 		Client:     &pubSubClient{},
+		SQSClient:  &sqsClient{},
+		NATSClient: &natsClient{},
 	}
 }
 
@@ -106,27 +111,58 @@ func (c *pubSubClient) subscription(id string, maxOutstandingMessages int) subsc
 	}
 }
 
+// subscriptions creates the subscriptions of a trigger for its source.
+func (s *PullServer) subscriptions(ctx context.Context, trigger config.PubSubTrigger) ([]subscriptionInterface, logrus.Fields, error) {
+	var subscriptions []subscriptionInterface
+	switch {
+	case trigger.SQS != nil:
+		client, err := s.SQSClient.new(ctx, *trigger.SQS)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, queueURL := range trigger.Topics {
+			subscriptions = append(subscriptions, client.subscription(queueURL, trigger.MaxOutstandingMessages))
+		}
+		return subscriptions, logrus.Fields{"source": "sqs", "region": trigger.SQS.Region}, nil
+	case trigger.NATS != nil:
+		client, err := s.NATSClient.new(ctx, *trigger.NATS)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, subject := range trigger.Topics {
+			subscriptions = append(subscriptions, client.subscription(subject, trigger.MaxOutstandingMessages))
+		}
+		return subscriptions, logrus.Fields{"source": "nats", "queue-group": trigger.NATS.QueueGroup}, nil
+	default:
+		client, err := s.Client.new(ctx, trigger.Project)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, subName := range trigger.Topics {
+			subscriptions = append(subscriptions, client.subscription(subName, trigger.MaxOutstandingMessages))
+		}
+		return subscriptions, logrus.Fields{"project": trigger.Project}, nil
+	}
+}
+
 // handlePulls pull for Pub/Sub subscriptions and handle them.
 func (s *PullServer) handlePulls(ctx context.Context, projectSubscriptions config.PubSubTriggers) (*errgroup.Group, context.Context, error) {
 	// Since config might change we need be able to cancel the current run
 	errGroup, derivedCtx := errgroup.WithContext(ctx)
 	for _, topics := range projectSubscriptions {
-		project, subscriptions, allowedClusters := topics.Project, topics.Topics, topics.AllowedClusters
-		client, err := s.Client.new(ctx, project)
+		allowedClusters, restrictOverrides := topics.AllowedClusters, !topics.AllowUnrestrictedOverrides
+		subscriptions, fields, err := s.subscriptions(ctx, topics)
 		if err != nil {
 			return errGroup, derivedCtx, err
 		}
-		for _, subName := range subscriptions {
-			sub := client.subscription(subName, topics.MaxOutstandingMessages)
-			logger := logrus.WithFields(logrus.Fields{
-				"subscription": sub.string(),
-				"project":      project,
-			})
+		for _, sub := range subscriptions {
+			sub := sub
+			logger := logrus.WithFields(fields).WithField("subscription", sub.string())
 			errGroup.Go(func() error {
 				logger.Info("Listening for subscription")
 				defer logger.Warn("Stopped Listening for subscription")
 				err := sub.receive(derivedCtx, func(ctx context.Context, msg messageInterface) {
-					if err = s.Subscriber.handleMessage(msg, sub.string(), allowedClusters); err != nil {
+					if err = s.Subscriber.handleMessage(msg, sub.string(), allowedClusters, restrictOverrides); err != nil {
 						s.Subscriber.Metrics.ACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
 					} else {
 						s.Subscriber.Metrics.NACKMessageCounter.With(prometheus.Labels{subscriptionLabel: sub.string()}).Inc()
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriber
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+)
+
+const (
+	// sqsMaxMessages is the maximum number of messages SQS returns at once.
+	sqsMaxMessages = 10
+	// sqsWaitTimeSeconds enables long polling, which is capped at 20 seconds.
+	sqsWaitTimeSeconds = 20
+)
+
+// sqsClientInterface interfaces with the AWS SQS client for testing reason
+type sqsClientInterface interface {
+	new(ctx context.Context, cfg config.SQSTrigger) (sqsClientInterface, error)
+	subscription(queueURL string, maxOutstandingMessages int) subscriptionInterface
+}
+
+type sqsClient struct {
+	client sqsiface.SQSAPI
+}
+
+// new creates a new SQS client authenticated as configured.
+func (c *sqsClient) new(ctx context.Context, cfg config.SQSTrigger) (sqsClientInterface, error) {
+	awsConfig := aws.NewConfig().WithRegion(cfg.Region)
+	if cfg.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(cfg.Endpoint)
+	}
+	if cfg.CredentialsFile != "" {
+		awsConfig = awsConfig.WithCredentials(credentials.NewSharedCredentials(cfg.CredentialsFile, cfg.Profile))
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *awsConfig,
+		Profile:           cfg.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RoleARN != "" {
+		return &sqsClient{client: sqs.New(sess, aws.NewConfig().WithCredentials(stscreds.NewCredentials(sess, cfg.RoleARN)))}, nil
+	}
+	return &sqsClient{client: sqs.New(sess)}, nil
+}
+
+func (c *sqsClient) subscription(queueURL string, maxOutstandingMessages int) subscriptionInterface {
+	maxMessages := maxOutstandingMessages
+	if maxMessages < 1 || maxMessages > sqsMaxMessages {
+		maxMessages = sqsMaxMessages
+	}
+	return &sqsSubscription{client: c.client, queueURL: queueURL, maxMessages: maxMessages}
+}
+
+type sqsSubscription struct {
+	client      sqsiface.SQSAPI
+	queueURL    string
+	maxMessages int
+}
+
+func (s *sqsSubscription) string() string {
+	return s.queueURL
+}
+
+// receive long polls the queue and handles every batch of messages
+// concurrently until the context is cancelled.
+func (s *sqsSubscription) receive(ctx context.Context, f func(context.Context, messageInterface)) error {
+	for {
+		out, err := s.client.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              aws.String(s.queueURL),
+			MaxNumberOfMessages:   aws.Int64(int64(s.maxMessages)),
+			WaitTimeSeconds:       aws.Int64(sqsWaitTimeSeconds),
+			MessageAttributeNames: aws.StringSlice([]string{"All"}),
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		var wg sync.WaitGroup
+		for _, message := range out.Messages {
+			wg.Add(1)
+			go func(message *sqs.Message) {
+				defer wg.Done()
+				f(ctx, newSQSMessage(ctx, s, message))
+			}(message)
+		}
+		wg.Wait()
+	}
+}
+
+type sqsMessage struct {
+	ctx          context.Context
+	subscription *sqsSubscription
+	id           string
+	receipt      *string
+	attributes   map[string]string
+	payload      []byte
+}
+
+// snsNotification is the envelope SNS wraps messages in when delivering them
+// to an SQS queue without raw message delivery.
+type snsNotification struct {
+	Type              string `json:"Type"`
+	MessageID         string `json:"MessageId"`
+	TopicArn          string `json:"TopicArn"`
+	Message           string `json:"Message"`
+	MessageAttributes map[string]struct {
+		Type  string `json:"Type"`
+		Value string `json:"Value"`
+	} `json:"MessageAttributes"`
+}
+
+func newSQSMessage(ctx context.Context, s *sqsSubscription, message *sqs.Message) *sqsMessage {
+	m := &sqsMessage{
+		ctx:          ctx,
+		subscription: s,
+		id:           aws.StringValue(message.MessageId),
+		receipt:      message.ReceiptHandle,
+		attributes:   map[string]string{},
+		payload:      []byte(aws.StringValue(message.Body)),
+	}
+	var notification snsNotification
+	if err := json.Unmarshal(m.payload, &notification); err == nil && notification.Type == "Notification" && notification.TopicArn != "" {
+		m.id = notification.MessageID
+		m.payload = []byte(notification.Message)
+		for name, attribute := range notification.MessageAttributes {
+			if attribute.Type == "String" {
+				m.attributes[name] = attribute.Value
+			}
+		}
+		return m
+	}
+	for name, attribute := range message.MessageAttributes {
+		if attribute.StringValue != nil {
+			m.attributes[name] = *attribute.StringValue
+		}
+	}
+	return m
+}
+
+func (m *sqsMessage) getAttributes() map[string]string {
+	return m.attributes
+}
+
+func (m *sqsMessage) getPayload() []byte {
+	return m.payload
+}
+
+func (m *sqsMessage) getID() string {
+	return m.id
+}
+
+// ack deletes the message from the queue.
+func (m *sqsMessage) ack() {
+	if _, err := m.subscription.client.DeleteMessageWithContext(m.ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(m.subscription.queueURL),
+		ReceiptHandle: m.receipt,
+	}); err != nil {
+		logrus.WithError(err).WithField("sqs-id", m.id).Warn("Failed to delete SQS message.")
+	}
+}
+
+// nack makes the message visible again, so that it is redelivered.
+func (m *sqsMessage) nack() {
+	if _, err := m.subscription.client.ChangeMessageVisibilityWithContext(m.ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(m.subscription.queueURL),
+		ReceiptHandle:     m.receipt,
+		VisibilityTimeout: aws.Int64(0),
+	}); err != nil {
+		logrus.WithError(err).WithField("sqs-id", m.id).Warn("Failed to release SQS message.")
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriber
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeSQS struct {
+	sqsiface.SQSAPI
+	lock     sync.Mutex
+	messages []*sqs.Message
+	deleted  []string
+	released []string
+}
+
+func (f *fakeSQS) ReceiveMessageWithContext(ctx aws.Context, in *sqs.ReceiveMessageInput, _ ...request.Option) (*sqs.ReceiveMessageOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if len(f.messages) == 0 {
+		// Block like long polling on an empty queue does.
+		f.lock.Unlock()
+		<-ctx.Done()
+		f.lock.Lock()
+		return nil, ctx.Err()
+	}
+	n := int(aws.Int64Value(in.MaxNumberOfMessages))
+	if n > len(f.messages) {
+		n = len(f.messages)
+	}
+	out := &sqs.ReceiveMessageOutput{Messages: f.messages[:n]}
+	f.messages = f.messages[n:]
+	return out, nil
+}
+
+func (f *fakeSQS) DeleteMessageWithContext(_ aws.Context, in *sqs.DeleteMessageInput, _ ...request.Option) (*sqs.DeleteMessageOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.deleted = append(f.deleted, aws.StringValue(in.ReceiptHandle))
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func (f *fakeSQS) ChangeMessageVisibilityWithContext(_ aws.Context, in *sqs.ChangeMessageVisibilityInput, _ ...request.Option) (*sqs.ChangeMessageVisibilityOutput, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.released = append(f.released, aws.StringValue(in.ReceiptHandle))
+	return &sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
+func TestSQSSubscriptionReceive(t *testing.T) {
+	fake := &fakeSQS{messages: []*sqs.Message{
+		{
+			MessageId:     aws.String("sqs-1"),
+			ReceiptHandle: aws.String("receipt-1"),
+			Body:          aws.String(`{"name":"periodic-job"}`),
+			MessageAttributes: map[string]*sqs.MessageAttributeValue{
+				ProwEventType: {DataType: aws.String("String"), StringValue: aws.String(PeriodicProwJobEvent)},
+			},
+		},
+		{
+			MessageId:     aws.String("sqs-2"),
+			ReceiptHandle: aws.String("receipt-2"),
+			Body: aws.String(`{
+  "Type": "Notification",
+  "MessageId": "sns-1",
+  "TopicArn": "arn:aws:sns:us-east-1:123456789012:prow",
+  "Message": "{\"name\":\"postsubmit-job\"}",
+  "MessageAttributes": {
+    "prow.k8s.io/pubsub.EventType": {"Type": "String", "Value": "prow.k8s.io/pubsub.PostsubmitProwJobEvent"},
+    "binary": {"Type": "Binary", "Value": "AAE="}
+  }
+}`),
+		},
+		{
+			MessageId:     aws.String("sqs-3"),
+			ReceiptHandle: aws.String("receipt-3"),
+			Body:          aws.String(`{"name":"unhandled"}`),
+		},
+	}}
+
+	type received struct {
+		ID         string
+		Attributes map[string]string
+		Payload    string
+	}
+	var lock sync.Mutex
+	got := map[string]received{}
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := (&sqsClient{client: fake}).subscription("https://sqs.us-east-1.amazonaws.com/123456789012/prow", 0)
+	err := sub.receive(ctx, func(_ context.Context, msg messageInterface) {
+		lock.Lock()
+		defer lock.Unlock()
+		got[msg.getID()] = received{ID: msg.getID(), Attributes: msg.getAttributes(), Payload: string(msg.getPayload())}
+		if msg.getID() == "sqs-3" {
+			msg.nack()
+		} else {
+			msg.ack()
+		}
+		if len(got) == 3 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("expected the receive to stop with the context, got %v", err)
+	}
+
+	expected := map[string]received{
+		"sqs-1": {ID: "sqs-1", Attributes: map[string]string{ProwEventType: PeriodicProwJobEvent}, Payload: `{"name":"periodic-job"}`},
+		"sns-1": {ID: "sns-1", Attributes: map[string]string{ProwEventType: PostsubmitProwJobEvent}, Payload: `{"name":"postsubmit-job"}`},
+		"sqs-3": {ID: "sqs-3", Attributes: map[string]string{}, Payload: `{"name":"unhandled"}`},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected messages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"receipt-3"}, fake.released); diff != "" {
+		t.Errorf("unexpected released messages (-want +got):\n%s", diff)
+	}
+	if len(fake.deleted) != 2 {
+		t.Errorf("expected two deleted messages, got %v", fake.deleted)
+	}
+}
@@ -148,7 +148,7 @@ func (s *Subscriber) getReporterFunc(l *logrus.Entry) gangway.ReporterFunc {
 	}
 }
 
-func (s *Subscriber) handleMessage(msg messageInterface, subscription string, allowedClusters []string) error {
+func (s *Subscriber) handleMessage(msg messageInterface, subscription string, allowedClusters []string, restrictOverrides bool) error {
 
 	msgID := msg.getID()
 	l := logrus.WithFields(logrus.Fields{
@@ -167,7 +167,7 @@ func (s *Subscriber) handleMessage(msg messageInterface, subscription string, al
 	var requireTenantID bool = false
 
 	cfgAdapter := gangway.ProwCfgAdapter{Config: s.ConfigAgent.Config()}
-	if _, err = gangway.HandleProwJob(l, s.getReporterFunc(l), cjer, s.ProwJobClient, &cfgAdapter, s.InRepoConfigGetter, allowedApiClient, requireTenantID, allowedClusters); err != nil {
+	if _, err = gangway.HandleProwJob(l, s.getReporterFunc(l), cjer, s.ProwJobClient, &cfgAdapter, s.InRepoConfigGetter, allowedApiClient, requireTenantID, allowedClusters, restrictOverrides); err != nil {
 		l.WithError(err).Info("failed to create Prow Job")
 		s.Metrics.ErrorCounter.With(prometheus.Labels{
 			subscriptionLabel: subscription,
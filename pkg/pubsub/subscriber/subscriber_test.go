@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/pubsub"
+	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime"
 	clienttesting "k8s.io/client-go/testing"
@@ -354,7 +355,7 @@ func TestHandleMessage(t *testing.T) {
 				m.ID = "id"
 				tc.msg = &pubSubMessage{*m}
 			}
-			if err := s.handleMessage(tc.msg, "", []string{"*"}); err != nil {
+			if err := s.handleMessage(tc.msg, "", []string{"*"}, false); err != nil {
 				if err.Error() != tc.err {
 					t1.Errorf("Expected error '%v' got '%v'", tc.err, err.Error())
 				} else if tc.err == "" {
@@ -419,9 +420,13 @@ func TestHandlePeriodicJob(t *testing.T) {
 		s               string
 		config          *config.Config
 		allowedClusters []string
-		err             string
-		reported        bool
-		clientFails     bool
+		// allowUnrestrictedOverrides accepts any overrides of jobs without
+		// trigger_overrides.
+		allowUnrestrictedOverrides bool
+		err                        string
+		reported                   bool
+		clientFails                bool
+		expectedExtraRefs          []prowapi.Refs
 	}{
 		{
 			name: "PeriodicJobNoPubsub",
@@ -479,8 +484,9 @@ func TestHandlePeriodicJob(t *testing.T) {
 					},
 				},
 			},
-			allowedClusters: []string{"*"},
-			reported:        true,
+			allowedClusters:            []string{"*"},
+			allowUnrestrictedOverrides: true,
+			reported:                   true,
 		},
 		{
 			name: "ClusterNotAllowed",
@@ -547,6 +553,143 @@ func TestHandlePeriodicJob(t *testing.T) {
 			clientFails:     true,
 			reported:        true,
 		},
+		{
+			name: "OverrideNotAllowed",
+			pe: &ProwJobEvent{
+				Name: "test",
+				Envs: map[string]string{
+					"env1": "env1",
+					"env2": "env2",
+				},
+			},
+			config: &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{
+						{
+							JobBase: config.JobBase{
+								Name:             "test",
+								TriggerOverrides: &config.TriggerOverrides{Envs: []string{"env1"}},
+							},
+						},
+					},
+				},
+			},
+			allowedClusters: []string{"*"},
+			err:             "the job does not allow overriding envs env2",
+		},
+		{
+			name: "RestrictedOverridesWithoutTriggerOverrides",
+			pe: &ProwJobEvent{
+				Name: "test",
+				Labels: map[string]string{
+					"label1": "label1",
+				},
+			},
+			config: &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{
+						{
+							JobBase: config.JobBase{
+								Name: "test",
+							},
+						},
+					},
+				},
+				ProwConfig: config.ProwConfig{
+					OverrideRestrictions: config.OverrideRestrictionsEnforce,
+				},
+			},
+			allowedClusters: []string{"*"},
+			err:             "the job does not allow overriding labels label1",
+		},
+		{
+			name: "RestrictedOverridesWithoutTriggerOverridesOnlyWarn",
+			pe: &ProwJobEvent{
+				Name: "test",
+				Labels: map[string]string{
+					"label1": "label1",
+				},
+			},
+			config: &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{
+						{
+							JobBase: config.JobBase{
+								Name: "test",
+							},
+						},
+					},
+				},
+			},
+			allowedClusters: []string{"*"},
+		},
+		{
+			name: "RefsOverride",
+			pe: &ProwJobEvent{
+				Name: "test",
+				Refs: &prowapi.Refs{
+					Org:     "org",
+					Repo:    "repo",
+					BaseRef: "release-1.0",
+					BaseSHA: "abcdef",
+				},
+				Envs: map[string]string{
+					"VERSION": "1.0",
+				},
+			},
+			config: &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{
+						{
+							JobBase: config.JobBase{
+								Name: "test",
+								TriggerOverrides: &config.TriggerOverrides{
+									Envs: []string{"*"},
+									Refs: true,
+								},
+								UtilityConfig: config.UtilityConfig{
+									ExtraRefs: []prowapi.Refs{
+										{Org: "org", Repo: "other", BaseRef: "main"},
+										{Org: "org", Repo: "repo", BaseRef: "main", PathAlias: "example.com/repo"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			allowedClusters: []string{"*"},
+			expectedExtraRefs: []prowapi.Refs{
+				{Org: "org", Repo: "other", BaseRef: "main"},
+				{Org: "org", Repo: "repo", BaseRef: "release-1.0", BaseSHA: "abcdef", PathAlias: "example.com/repo"},
+			},
+		},
+		{
+			name: "RefsOverrideNotAllowed",
+			pe: &ProwJobEvent{
+				Name: "test",
+				Refs: &prowapi.Refs{
+					Org:     "org",
+					Repo:    "repo",
+					BaseRef: "release-1.0",
+					BaseSHA: "abcdef",
+				},
+			},
+			config: &config.Config{
+				JobConfig: config.JobConfig{
+					Periodics: []config.Periodic{
+						{
+							JobBase: config.JobBase{
+								Name:             "test",
+								TriggerOverrides: &config.TriggerOverrides{},
+							},
+						},
+					},
+				},
+			},
+			allowedClusters: []string{"*"},
+			err:             "the job does not allow overriding refs",
+		},
 		{
 			name: "JobNotFound",
 			pe: &ProwJobEvent{
@@ -600,7 +743,7 @@ func TestHandlePeriodicJob(t *testing.T) {
 			}
 
 			cfgAdapter := gangway.ProwCfgAdapter{Config: s.ConfigAgent.Config()}
-			_, err = gangway.HandleProwJob(l, s.getReporterFunc(l), cjer, s.ProwJobClient, &cfgAdapter, s.InRepoConfigGetter, nil, false, tc.allowedClusters)
+			_, err = gangway.HandleProwJob(l, s.getReporterFunc(l), cjer, s.ProwJobClient, &cfgAdapter, s.InRepoConfigGetter, nil, false, tc.allowedClusters, !tc.allowUnrestrictedOverrides)
 			if err != nil {
 				if err.Error() != tc.err {
 					t1.Errorf("Expected error '%v' got '%v'", tc.err, err.Error())
@@ -615,6 +758,11 @@ func TestHandlePeriodicJob(t *testing.T) {
 							if err := CheckProwJob(tc.pe, prowjob); err != nil {
 								t.Error(err)
 							}
+							if tc.expectedExtraRefs != nil {
+								if diff := cmp.Diff(tc.expectedExtraRefs, prowjob.Spec.ExtraRefs); diff != "" {
+									t.Errorf("unexpected extra refs (-want +got):\n%s", diff)
+								}
+							}
 						}
 					}
 				}
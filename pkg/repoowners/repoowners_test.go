@@ -452,6 +452,14 @@ func TestLoadRepoOwnersV2(t *testing.T) {
 	testLoadRepoOwners(localgit.NewV2, t)
 }
 
+func TestLoadRepoOwnersWorktrees(t *testing.T) {
+	testLoadRepoOwners(localgit.NewV2WithWorktrees, t)
+}
+
+func TestLoadRepoOwnersBlobless(t *testing.T) {
+	testLoadRepoOwners(localgit.NewV2Blobless, t)
+}
+
 func testLoadRepoOwners(clients localgit.Clients, t *testing.T) {
 	t.Parallel()
 	tests := []struct {
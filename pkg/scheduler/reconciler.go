@@ -96,6 +96,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	scheduled := pj.DeepCopy()
 	scheduled.Spec.Cluster = result.Cluster
 	scheduled.Status.State = prowv1.TriggeredState
+	scheduled.UpdateConditions()
 
 	if err := r.pjClient.Patch(ctx, scheduled, client.MergeFrom(pj.DeepCopy())); err != nil {
 		return reconcile.Result{}, fmt.Errorf("patch prowjob: %w", err)
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -37,6 +38,21 @@ import (
 	"sigs.k8s.io/prow/pkg/scheduler/strategy"
 )
 
+// ignoreTransitionTimes ignores when the conditions of the jobs changed.
+var ignoreTransitionTimes = cmpopts.IgnoreFields(v1.Condition{}, "LastTransitionTime")
+
+// triggeredStatus is the status of a job that got assigned a cluster.
+func triggeredStatus() prowv1.ProwJobStatus {
+	return prowv1.ProwJobStatus{
+		State: prowv1.TriggeredState,
+		Conditions: []v1.Condition{
+			{Type: prowv1.ProwJobScheduled, Status: v1.ConditionTrue, Reason: "Scheduled", Message: "The job was scheduled."},
+			{Type: prowv1.ProwJobPodCreated, Status: v1.ConditionFalse, Reason: "Waiting", Message: "The job did not start yet."},
+			{Type: prowv1.ProwJobCompleted, Status: v1.ConditionFalse, Reason: "Running", Message: "The job did not complete yet."},
+		},
+	}
+}
+
 type fakeStrategy struct {
 	cluster string
 	err     error
@@ -89,7 +105,7 @@ func TestReconcile(t *testing.T) {
 			wantPJ: &prowv1.ProwJob{
 				ObjectMeta: v1.ObjectMeta{Name: "pj", Namespace: "ns", ResourceVersion: "2"},
 				Spec:       prowv1.ProwJobSpec{Cluster: "foo", Agent: prowv1.KubernetesAgent},
-				Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
+				Status:     triggeredStatus(),
 			},
 		},
 		{
@@ -103,7 +119,7 @@ func TestReconcile(t *testing.T) {
 			wantPJ: &prowv1.ProwJob{
 				ObjectMeta: v1.ObjectMeta{Name: "pj", Namespace: "ns", ResourceVersion: "2"},
 				Spec:       prowv1.ProwJobSpec{Cluster: "foo", Agent: prowv1.TektonAgent},
-				Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
+				Status:     triggeredStatus(),
 			},
 		},
 		{
@@ -127,7 +143,7 @@ func TestReconcile(t *testing.T) {
 			wantPJ: &prowv1.ProwJob{
 				ObjectMeta: v1.ObjectMeta{Name: "pj", Namespace: "ns", ResourceVersion: "2"},
 				Spec:       prowv1.ProwJobSpec{Cluster: "foo"},
-				Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
+				Status:     triggeredStatus(),
 			},
 			clientErrors: map[string]error{"UPDATE": errors.New("expected")},
 			wantError:    errors.New("patch prowjob: expected"),
@@ -153,7 +169,7 @@ func TestReconcile(t *testing.T) {
 			wantPJ: &prowv1.ProwJob{
 				ObjectMeta: v1.ObjectMeta{Name: "pj", Namespace: "ns", ResourceVersion: "2"},
 				Spec:       prowv1.ProwJobSpec{Cluster: "untouched"},
-				Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
+				Status:     triggeredStatus(),
 			},
 		},
 	} {
@@ -202,7 +218,7 @@ func TestReconcile(t *testing.T) {
 					t.Errorf("Expected 1 ProwJob but got %d", len(pjs.Items))
 					return
 				}
-				if diff := cmp.Diff(tc.wantPJ, &pjs.Items[0]); diff != "" {
+				if diff := cmp.Diff(tc.wantPJ, &pjs.Items[0], ignoreTransitionTimes); diff != "" {
 					t.Errorf("Unexpected ProwJob: %s", diff)
 				}
 			}
@@ -241,12 +257,12 @@ func TestConfigHotReload(t *testing.T) {
 				{
 					ObjectMeta: v1.ObjectMeta{Name: "job1", Namespace: "", ResourceVersion: "2"},
 					Spec:       prowv1.ProwJobSpec{Agent: prowv1.KubernetesAgent, Cluster: "foo"},
-					Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
+					Status:     triggeredStatus(),
 				},
 				{
 					ObjectMeta: v1.ObjectMeta{Name: "job2", Namespace: "", ResourceVersion: "2"},
 					Spec:       prowv1.ProwJobSpec{Agent: prowv1.KubernetesAgent, Cluster: "bar"},
-					Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
+					Status:     triggeredStatus(),
 				},
 			},
 		},
@@ -281,12 +297,12 @@ func TestConfigHotReload(t *testing.T) {
 				{
 					ObjectMeta: v1.ObjectMeta{Name: "job1", Namespace: "", ResourceVersion: "2"},
 					Spec:       prowv1.ProwJobSpec{Agent: prowv1.KubernetesAgent, Cluster: "bar"},
-					Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
+					Status:     triggeredStatus(),
 				},
 				{
 					ObjectMeta: v1.ObjectMeta{Name: "job2", Namespace: "", ResourceVersion: "2"},
 					Spec:       prowv1.ProwJobSpec{Agent: prowv1.KubernetesAgent, Cluster: "duper"},
-					Status:     prowv1.ProwJobStatus{State: prowv1.TriggeredState},
+					Status:     triggeredStatus(),
 				},
 			},
 		},
@@ -313,7 +329,7 @@ func TestConfigHotReload(t *testing.T) {
 				t.Fatalf("Couldn't get PJs from the fake client: %s", err)
 			}
 
-			if diff := cmp.Diff(tc.wantPJs, pjs.Items); diff != "" {
+			if diff := cmp.Diff(tc.wantPJs, pjs.Items, ignoreTransitionTimes); diff != "" {
 				t.Errorf("Unexpected ProwJob: %s", diff)
 			}
 		})
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/pjutil"
+)
+
+type clusterClient struct {
+	prowJobs prowv1.ProwJobInterface
+	config   config.Getter
+	log      *logrus.Entry
+}
+
+// NewClusterClient returns a Client managing the ProwJobs of the namespace of
+// the given ProwJob client. Jobs to create or rerun with their latest config
+// are looked up in the given config.
+func NewClusterClient(prowJobs prowv1.ProwJobInterface, cfg config.Getter) Client {
+	return &clusterClient{prowJobs: prowJobs, config: cfg, log: logrus.WithField("client", "prow-sdk")}
+}
+
+func (c *clusterClient) Create(ctx context.Context, opts CreateOptions) (*prowapi.ProwJob, error) {
+	spec, jobLabels, jobAnnotations, err := pjutil.ProwJobSpecFromConfig(opts.Type, c.config, opts.Job, opts.Refs, nil)
+	if err != nil {
+		return nil, err
+	}
+	pj := pjutil.NewProwJob(*spec, merge(jobLabels, opts.Labels), merge(jobAnnotations, opts.Annotations), pjutil.RequireScheduling(c.config().Scheduler.Enabled))
+	return c.prowJobs.Create(ctx, &pj, metav1.CreateOptions{})
+}
+
+func (c *clusterClient) Rerun(ctx context.Context, name string, opts RerunOptions) (*prowapi.ProwJob, error) {
+	pj, err := c.prowJobs.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	spec, jobLabels, jobAnnotations := pj.Spec, pj.Labels, pj.Annotations
+	if opts.Latest {
+		latest, labels, annotations, err := pjutil.ProwJobSpecFromConfig(pj.Spec.Type, c.config, pj.Spec.Job, pj.Spec.Refs, pj.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the latest config of job %q: %w", pj.Spec.Job, err)
+		}
+		spec, jobLabels, jobAnnotations = *latest, labels, annotations
+	}
+	rerun := pjutil.NewProwJob(spec, jobLabels, jobAnnotations, pjutil.RequireScheduling(c.config().Scheduler.Enabled))
+	return c.prowJobs.Create(ctx, &rerun, metav1.CreateOptions{})
+}
+
+func (c *clusterClient) Abort(ctx context.Context, name string, opts AbortOptions) error {
+	pj, err := c.prowJobs.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if pj.Status.State != prowapi.TriggeredState && pj.Status.State != prowapi.PendingState {
+		return fmt.Errorf("cannot abort job with state %q", pj.Status.State)
+	}
+	aborted := pj.DeepCopy()
+	aborted.Status.State = prowapi.AbortedState
+	aborted.Status.Cancellation = &prowapi.Cancellation{Reason: prowapi.CancelledByUser, Requester: opts.Requester}
+	aborted.Status.Description = fmt.Sprintf("Job %s.", aborted.Status.Cancellation)
+	_, err = pjutil.PatchProwjob(ctx, c.prowJobs, c.log, *pj, *aborted)
+	return err
+}
+
+func (c *clusterClient) Get(ctx context.Context, name string) (*prowapi.ProwJob, error) {
+	return c.prowJobs.Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c *clusterClient) List(ctx context.Context, opts ListOptions) ([]prowapi.ProwJob, error) {
+	pjs, err := c.prowJobs.List(ctx, metav1.ListOptions{LabelSelector: labels.SelectorFromSet(opts.labelSelector()).String()})
+	if err != nil {
+		return nil, err
+	}
+	return filter(pjs.Items, opts), nil
+}
+
+// merge returns the union of the given maps, the latter taking precedence.
+func merge(base, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
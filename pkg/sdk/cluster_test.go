@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+func testConfig() config.Getter {
+	presubmits := []config.Presubmit{{
+		JobBase:  config.JobBase{Name: "pull-unit", Labels: map[string]string{"team": "a"}, Agent: string(prowapi.KubernetesAgent)},
+		Brancher: config.Brancher{Branches: []string{"main"}},
+	}}
+	config.SetPresubmitRegexes(presubmits)
+	cfg := &config.Config{
+		JobConfig: config.JobConfig{
+			PresubmitsStatic: map[string][]config.Presubmit{"org/repo": presubmits},
+			Periodics:        []config.Periodic{{JobBase: config.JobBase{Name: "ci-periodic", Agent: string(prowapi.KubernetesAgent)}}},
+		},
+	}
+	return func() *config.Config { return cfg }
+}
+
+func prowJob(name, job string, pjType prowapi.ProwJobType, state prowapi.ProwJobState, refs *prowapi.Refs) *prowapi.ProwJob {
+	pj := &prowapi.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prowjobs", Labels: map[string]string{kube.ProwJobTypeLabel: string(pjType)}},
+		Spec:       prowapi.ProwJobSpec{Job: job, Type: pjType, Refs: refs},
+		Status:     prowapi.ProwJobStatus{State: state},
+	}
+	if refs != nil {
+		pj.Labels[kube.OrgLabel] = refs.Org
+		pj.Labels[kube.RepoLabel] = refs.Repo
+		pj.Labels[kube.PullLabel] = strconv.Itoa(refs.Pulls[0].Number)
+	}
+	return pj
+}
+
+func TestClusterClientCreate(t *testing.T) {
+	refs := &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", Pulls: []prowapi.Pull{{Number: 1}}}
+	testCases := []struct {
+		name           string
+		opts           CreateOptions
+		expectedLabels map[string]string
+		expectedErr    bool
+	}{
+		{
+			name:           "periodic",
+			opts:           CreateOptions{Job: "ci-periodic", Type: prowapi.PeriodicJob},
+			expectedLabels: map[string]string{kube.ProwJobTypeLabel: "periodic"},
+		},
+		{
+			name:           "presubmit with extra labels",
+			opts:           CreateOptions{Job: "pull-unit", Type: prowapi.PresubmitJob, Refs: refs, Labels: map[string]string{"tool": "mine"}},
+			expectedLabels: map[string]string{"team": "a", "tool": "mine", kube.ProwJobTypeLabel: "presubmit", kube.OrgLabel: "org", kube.RepoLabel: "repo", kube.PullLabel: "1"},
+		},
+		{
+			name:        "presubmit without refs",
+			opts:        CreateOptions{Job: "pull-unit", Type: prowapi.PresubmitJob},
+			expectedErr: true,
+		},
+		{
+			name:        "presubmit not running against the branch",
+			opts:        CreateOptions{Job: "pull-unit", Type: prowapi.PresubmitJob, Refs: &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "release-1.0"}},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown job",
+			opts:        CreateOptions{Job: "ci-unknown", Type: prowapi.PeriodicJob},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewClusterClient(fake.NewSimpleClientset().ProwV1().ProwJobs("prowjobs"), testConfig())
+			pj, err := c.Create(context.Background(), tc.opts)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if pj.Spec.Job != tc.opts.Job || pj.Status.State != prowapi.TriggeredState {
+				t.Errorf("expected a triggered %s ProwJob, got %s in state %s", tc.opts.Job, pj.Spec.Job, pj.Status.State)
+			}
+			for k, v := range tc.expectedLabels {
+				if pj.Labels[k] != v {
+					t.Errorf("expected label %s=%s, got labels %v", k, v, pj.Labels)
+				}
+			}
+		})
+	}
+}
+
+func TestClusterClientRerunAndAbort(t *testing.T) {
+	refs := &prowapi.Refs{Org: "org", Repo: "repo", BaseRef: "main", Pulls: []prowapi.Pull{{Number: 1}}}
+	outdated := prowJob("outdated", "pull-unit", prowapi.PresubmitJob, prowapi.FailureState, refs)
+	outdated.Spec.Agent = "outdated-agent"
+	client := fake.NewSimpleClientset(outdated, prowJob("running", "ci-periodic", prowapi.PeriodicJob, prowapi.PendingState, nil))
+	c := NewClusterClient(client.ProwV1().ProwJobs("prowjobs"), testConfig())
+	ctx := context.Background()
+
+	rerun, err := c.Rerun(ctx, "outdated", RerunOptions{})
+	if err != nil {
+		t.Fatalf("failed to rerun: %v", err)
+	}
+	if rerun.Name == "outdated" || rerun.Spec.Agent != "outdated-agent" || rerun.Status.State != prowapi.TriggeredState {
+		t.Errorf("expected a new triggered ProwJob with the original spec, got %+v", rerun)
+	}
+	latest, err := c.Rerun(ctx, "outdated", RerunOptions{Latest: true})
+	if err != nil {
+		t.Fatalf("failed to rerun with the latest config: %v", err)
+	}
+	if latest.Spec.Agent != prowapi.KubernetesAgent {
+		t.Errorf("expected the latest spec, got agent %q", latest.Spec.Agent)
+	}
+
+	if err := c.Abort(ctx, "running", AbortOptions{Requester: "alice"}); err != nil {
+		t.Fatalf("failed to abort: %v", err)
+	}
+	aborted, err := c.Get(ctx, "running")
+	if err != nil {
+		t.Fatalf("failed to get aborted job: %v", err)
+	}
+	expected := &prowapi.Cancellation{Reason: prowapi.CancelledByUser, Requester: "alice"}
+	if aborted.Status.State != prowapi.AbortedState || !cmp.Equal(aborted.Status.Cancellation, expected) {
+		t.Errorf("expected the job to be aborted by alice, got state %s and cancellation %v", aborted.Status.State, aborted.Status.Cancellation)
+	}
+	if err := c.Abort(ctx, "outdated", AbortOptions{}); err == nil {
+		t.Error("expected an error aborting a finished job")
+	}
+}
+
+func TestClusterClientList(t *testing.T) {
+	refs := func(org, repo string, pull int) *prowapi.Refs {
+		return &prowapi.Refs{Org: org, Repo: repo, BaseRef: "main", Pulls: []prowapi.Pull{{Number: pull}}}
+	}
+	objects := []runtime.Object{
+		prowJob("a", "pull-unit", prowapi.PresubmitJob, prowapi.SuccessState, refs("org", "repo", 1)),
+		prowJob("b", "pull-unit", prowapi.PresubmitJob, prowapi.PendingState, refs("org", "repo", 2)),
+		prowJob("c", "pull-e2e", prowapi.PresubmitJob, prowapi.PendingState, refs("org", "other", 1)),
+		prowJob("d", "ci-periodic", prowapi.PeriodicJob, prowapi.PendingState, nil),
+	}
+	testCases := []struct {
+		name     string
+		opts     ListOptions
+		expected []string
+	}{
+		{
+			name:     "everything",
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:     "by job",
+			opts:     ListOptions{Job: "pull-unit"},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "by type and state",
+			opts:     ListOptions{Type: prowapi.PresubmitJob, State: prowapi.PendingState},
+			expected: []string{"b", "c"},
+		},
+		{
+			name:     "by pull",
+			opts:     ListOptions{Org: "org", Repo: "repo", Pull: 1},
+			expected: []string{"a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewClusterClient(fake.NewSimpleClientset(objects...).ProwV1().ProwJobs("prowjobs"), testConfig())
+			pjs, err := c.List(context.Background(), tc.opts)
+			if err != nil {
+				t.Fatalf("failed to list: %v", err)
+			}
+			var names []string
+			for _, pj := range pjs {
+				names = append(names, pj.Name)
+			}
+			sort.Strings(names)
+			if diff := cmp.Diff(tc.expected, names); diff != "" {
+				t.Errorf("listed ProwJobs differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
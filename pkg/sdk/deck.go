@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// csrfTokenRe matches the CSRF token Deck renders into its pages as a
+// JavaScript string.
+var csrfTokenRe = regexp.MustCompile(`var csrfToken = ("(?:[^"\\]|\\.)*");`)
+
+type deckClient struct {
+	url     string
+	client  *http.Client
+	idToken func() (string, error)
+
+	lock      sync.Mutex
+	csrfToken *string
+}
+
+// DeckOption configures a Deck client.
+type DeckOption func(*deckClient)
+
+// WithHTTPClient makes the Deck client send its requests with the given HTTP
+// client. A cookie jar is added to a copy of it if it has none, as Deck's CSRF
+// protection relies on a cookie.
+func WithHTTPClient(client *http.Client) DeckOption {
+	return func(c *deckClient) {
+		c.client = client
+	}
+}
+
+// WithIDToken authenticates reruns and aborts with the OIDC ID token returned
+// by the given function, which Deck authorizes by its groups, see the
+// --oidc-* flags of Deck. The function is called for every request, so that
+// it can refresh expired tokens.
+func WithIDToken(idToken func() (string, error)) DeckOption {
+	return func(c *deckClient) {
+		c.idToken = idToken
+	}
+}
+
+// NewDeckClient returns a Client using the API of the Deck served at the
+// given URL. Deck cannot create jobs, so Create returns ErrUnsupported, and
+// Rerun requires Deck to run with --rerun-creates-job.
+func NewDeckClient(deckURL string, opts ...DeckOption) (Client, error) {
+	if _, err := url.ParseRequestURI(deckURL); err != nil {
+		return nil, fmt.Errorf("invalid Deck URL %q: %w", deckURL, err)
+	}
+	c := &deckClient{url: strings.TrimSuffix(deckURL, "/"), client: &http.Client{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.client.Jar == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, err
+		}
+		client := *c.client
+		client.Jar = jar
+		c.client = &client
+	}
+	return c, nil
+}
+
+func (c *deckClient) Create(context.Context, CreateOptions) (*prowapi.ProwJob, error) {
+	return nil, ErrUnsupported
+}
+
+func (c *deckClient) Rerun(ctx context.Context, name string, opts RerunOptions) (*prowapi.ProwJob, error) {
+	query := url.Values{"prowjob": []string{name}}
+	if opts.Latest {
+		query.Set("mode", "latest")
+	}
+	resp, body, err := c.post(ctx, "/rerun", query)
+	if err != nil {
+		return nil, err
+	}
+	// Deck answers unauthorized reruns with a 200 but no new job.
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || location.Query().Get("prowjob") == "" {
+		return nil, fmt.Errorf("rerun of %s was not created: %s", name, strings.TrimSpace(string(body)))
+	}
+	return c.Get(ctx, location.Query().Get("prowjob"))
+}
+
+func (c *deckClient) Abort(ctx context.Context, name string, _ AbortOptions) error {
+	_, _, err := c.post(ctx, "/abort", url.Values{"prowjob": []string{name}})
+	return err
+}
+
+func (c *deckClient) Get(ctx context.Context, name string) (*prowapi.ProwJob, error) {
+	body, err := c.get(ctx, "/prowjob?"+url.Values{"prowjob": []string{name}}.Encode())
+	if err != nil {
+		return nil, err
+	}
+	var pj prowapi.ProwJob
+	if err := yaml.Unmarshal(body, &pj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ProwJob %s: %w", name, err)
+	}
+	return &pj, nil
+}
+
+func (c *deckClient) List(ctx context.Context, opts ListOptions) ([]prowapi.ProwJob, error) {
+	body, err := c.get(ctx, "/prowjobs.js?omit=pod_spec,decoration_config")
+	if err != nil {
+		return nil, err
+	}
+	var pjs struct {
+		Items []prowapi.ProwJob `json:"items"`
+	}
+	if err := json.Unmarshal(body, &pjs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ProwJobs: %w", err)
+	}
+	return filter(pjs.Items, opts), nil
+}
+
+func (c *deckClient) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	_, body, err := c.do(req)
+	return body, err
+}
+
+func (c *deckClient) post(ctx context.Context, path string, query url.Values) (*http.Response, []byte, error) {
+	csrfToken, err := c.getCSRFToken(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if csrfToken != "" {
+		req.Header.Set("X-CSRF-Token", csrfToken)
+	}
+	if c.idToken != nil {
+		token, err := c.idToken()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get ID token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return c.do(req)
+}
+
+func (c *deckClient) do(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response of %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp, body, nil
+}
+
+// getCSRFToken returns the CSRF token Deck expects along with its cookie on
+// POST requests, or an empty token if Deck is not protected against CSRF.
+func (c *deckClient) getCSRFToken(ctx context.Context) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.csrfToken != nil {
+		return *c.csrfToken, nil
+	}
+	page, err := c.get(ctx, "/command-help")
+	if err != nil {
+		return "", fmt.Errorf("failed to get CSRF token: %w", err)
+	}
+	var token string
+	if match := csrfTokenRe.FindSubmatch(page); match != nil {
+		if err := json.Unmarshal(match[1], &token); err != nil {
+			return "", fmt.Errorf("failed to parse CSRF token %s: %w", match[1], err)
+		}
+	}
+	c.csrfToken = &token
+	return token, nil
+}
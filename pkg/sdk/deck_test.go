@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/csrf"
+	"sigs.k8s.io/yaml"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+var fakeDeckPage = template.Must(template.New("page").Parse(`<script>var csrfToken = {{.}};</script>`))
+
+// newFakeDeck serves the API of Deck for the given ProwJobs behind its CSRF
+// protection, only allowing reruns and aborts authenticated with the ID token.
+func newFakeDeck(t *testing.T, pjs map[string]*prowapi.ProwJob) *httptest.Server {
+	authorized := func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Method != http.MethodPost {
+			http.Error(w, "bad verb", http.StatusMethodNotAllowed)
+			return false
+		}
+		if r.Header.Get("Authorization") != "Bearer id-token" {
+			http.Error(w, "Could not verify if allowed to rerun.", http.StatusUnauthorized)
+			return false
+		}
+		return true
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/command-help", func(w http.ResponseWriter, r *http.Request) {
+		fakeDeckPage.Execute(w, csrf.Token(r))
+	})
+	mux.HandleFunc("/prowjob", func(w http.ResponseWriter, r *http.Request) {
+		pj, ok := pjs[r.URL.Query().Get("prowjob")]
+		if !ok {
+			http.Error(w, "ProwJob not found", http.StatusNotFound)
+			return
+		}
+		b, _ := yaml.Marshal(pj)
+		w.Write(b)
+	})
+	mux.HandleFunc("/prowjobs.js", func(w http.ResponseWriter, r *http.Request) {
+		var items []prowapi.ProwJob
+		for _, pj := range pjs {
+			items = append(items, *pj)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	})
+	mux.HandleFunc("/rerun", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		pj := pjs[r.URL.Query().Get("prowjob")].DeepCopy()
+		pj.Name = "rerun"
+		pj.Status.State = prowapi.TriggeredState
+		pjs[pj.Name] = pj
+		w.Header().Set("Location", "/prowjob?prowjob="+pj.Name)
+		w.Write([]byte("Job successfully triggered."))
+	})
+	mux.HandleFunc("/abort", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		pjs[r.URL.Query().Get("prowjob")].Status.State = prowapi.AbortedState
+		w.Write([]byte("Job successfully aborted."))
+	})
+	server := httptest.NewServer(csrf.Protect([]byte("0123456789abcdef0123456789abcdef"), csrf.Path("/"), csrf.Secure(false))(mux))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDeckClient(t *testing.T) {
+	pjs := map[string]*prowapi.ProwJob{
+		"failed":  prowJob("failed", "ci-periodic", prowapi.PeriodicJob, prowapi.FailureState, nil),
+		"pending": prowJob("pending", "pull-unit", prowapi.PresubmitJob, prowapi.PendingState, &prowapi.Refs{Org: "org", Repo: "repo", Pulls: []prowapi.Pull{{Number: 1}}}),
+	}
+	deck := newFakeDeck(t, pjs)
+	ctx := context.Background()
+
+	anonymous, err := NewDeckClient(deck.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := anonymous.Create(ctx, CreateOptions{Job: "ci-periodic", Type: prowapi.PeriodicJob}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected creating through Deck to be unsupported, got %v", err)
+	}
+	if _, err := anonymous.Rerun(ctx, "failed", RerunOptions{}); err == nil {
+		t.Error("expected an unauthenticated rerun to fail")
+	}
+	listed, err := anonymous.List(ctx, ListOptions{Org: "org", Pull: 1})
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Name != "pending" {
+		t.Errorf("expected to list the pending job, got %v", listed)
+	}
+
+	c, err := NewDeckClient(deck.URL, WithIDToken(func() (string, error) { return "id-token", nil }))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	rerun, err := c.Rerun(ctx, "failed", RerunOptions{})
+	if err != nil {
+		t.Fatalf("failed to rerun: %v", err)
+	}
+	if rerun.Name != "rerun" || rerun.Spec.Job != "ci-periodic" || rerun.Status.State != prowapi.TriggeredState {
+		t.Errorf("expected the triggered rerun, got %+v", rerun)
+	}
+	if err := c.Abort(ctx, "pending", AbortOptions{}); err != nil {
+		t.Fatalf("failed to abort: %v", err)
+	}
+	aborted, err := c.Get(ctx, "pending")
+	if err != nil {
+		t.Fatalf("failed to get the aborted job: %v", err)
+	}
+	if aborted.Status.State != prowapi.AbortedState {
+		t.Errorf("expected the job to be aborted, got state %s", aborted.Status.State)
+	}
+	if _, err := c.Get(ctx, "missing"); err == nil {
+		t.Error("expected an error getting a missing job")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowclientset "sigs.k8s.io/prow/pkg/client/clientset/versioned"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/sdk"
+)
+
+// Create a periodic from the Prow config with access to the cluster.
+func ExampleNewClusterClient() {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", os.Getenv("KUBECONFIG"))
+	if err != nil {
+		panic(err)
+	}
+	prowJobs, err := prowclientset.NewForConfig(restConfig)
+	if err != nil {
+		panic(err)
+	}
+	cfg, err := config.Load("config/prow/config.yaml", "config/jobs", nil, "")
+	if err != nil {
+		panic(err)
+	}
+
+	c := sdk.NewClusterClient(prowJobs.ProwV1().ProwJobs("prow"), func() *config.Config { return cfg })
+	pj, err := c.Create(context.Background(), sdk.CreateOptions{Job: "ci-prow-canary", Type: prowapi.PeriodicJob})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("Created", pj.Name)
+}
+
+// Rerun the failed presubmits of a pull request through Deck, authenticated
+// with an OIDC ID token.
+func ExampleNewDeckClient() {
+	c, err := sdk.NewDeckClient("https://prow.example.com", sdk.WithIDToken(func() (string, error) {
+		return os.Getenv("ID_TOKEN"), nil
+	}))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx := context.Background()
+	failed, err := c.List(ctx, sdk.ListOptions{Org: "org", Repo: "repo", Pull: 1234, State: prowapi.FailureState})
+	if err != nil {
+		panic(err)
+	}
+	for _, pj := range failed {
+		rerun, err := c.Rerun(ctx, pj.Name, sdk.RerunOptions{Latest: true})
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println("Reran", pj.Spec.Job, "as", rerun.Name)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sdk is the supported Go API for submitting and managing ProwJobs
+// from tools outside of Prow. Tools with access to the cluster ProwJobs live
+// in use NewClusterClient, tools that only reach Deck use NewDeckClient. Both
+// return a Client, so tools can switch between them without changes.
+package sdk
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+// ErrUnsupported is returned by the clients for operations their backend does
+// not offer, e.g. creating a job through Deck.
+var ErrUnsupported = errors.New("operation not supported by this client")
+
+// Client creates, reruns, aborts and lists ProwJobs.
+type Client interface {
+	// Create creates a ProwJob for a job of the Prow config.
+	Create(ctx context.Context, opts CreateOptions) (*prowapi.ProwJob, error)
+	// Rerun creates a new ProwJob running the job of the given ProwJob again.
+	Rerun(ctx context.Context, name string, opts RerunOptions) (*prowapi.ProwJob, error)
+	// Abort aborts the given ProwJob if it is still triggered or pending.
+	Abort(ctx context.Context, name string, opts AbortOptions) error
+	// Get returns the given ProwJob.
+	Get(ctx context.Context, name string) (*prowapi.ProwJob, error)
+	// List returns the ProwJobs matching the options.
+	List(ctx context.Context, opts ListOptions) ([]prowapi.ProwJob, error)
+}
+
+// CreateOptions select the job to create a ProwJob for.
+type CreateOptions struct {
+	// Job is the name of the job in the Prow config.
+	Job string
+	// Type is the type of the job.
+	Type prowapi.ProwJobType
+	// Refs are the refs to test. They are required for presubmits and
+	// postsubmits, whose job is looked up in the config of Refs.Org and
+	// Refs.Repo and must run against Refs.BaseRef. Presubmits need a pull.
+	Refs *prowapi.Refs
+	// Labels and Annotations are added to the ProwJob.
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// RerunOptions configure a rerun.
+type RerunOptions struct {
+	// Latest reruns the job with its current config instead of the spec of
+	// the ProwJob being rerun.
+	Latest bool
+}
+
+// AbortOptions configure an abort.
+type AbortOptions struct {
+	// Requester is recorded as who aborted the job. Deck records the
+	// authenticated user instead.
+	Requester string
+}
+
+// ListOptions filter the listed ProwJobs. Unset fields match any ProwJob.
+type ListOptions struct {
+	Job   string
+	Type  prowapi.ProwJobType
+	State prowapi.ProwJobState
+	Org   string
+	Repo  string
+	// Pull matches the ProwJobs testing the given pull request.
+	Pull int
+}
+
+// labelSelector returns the labels ProwJobs matching the options carry.
+func (o ListOptions) labelSelector() map[string]string {
+	selector := map[string]string{}
+	if o.Type != "" {
+		selector[kube.ProwJobTypeLabel] = string(o.Type)
+	}
+	if o.Org != "" {
+		selector[kube.OrgLabel] = o.Org
+	}
+	if o.Repo != "" {
+		selector[kube.RepoLabel] = o.Repo
+	}
+	if o.Pull != 0 {
+		selector[kube.PullLabel] = strconv.Itoa(o.Pull)
+	}
+	return selector
+}
+
+// matches tells whether the ProwJob matches the options.
+func (o ListOptions) matches(pj *prowapi.ProwJob) bool {
+	if o.Job != "" && pj.Spec.Job != o.Job {
+		return false
+	}
+	if o.Type != "" && pj.Spec.Type != o.Type {
+		return false
+	}
+	if o.State != "" && pj.Status.State != o.State {
+		return false
+	}
+	if o.Org != "" || o.Repo != "" || o.Pull != 0 {
+		refs := pj.Spec.Refs
+		if refs == nil {
+			return false
+		}
+		if (o.Org != "" && refs.Org != o.Org) || (o.Repo != "" && refs.Repo != o.Repo) {
+			return false
+		}
+		if o.Pull != 0 && (len(refs.Pulls) == 0 || refs.Pulls[0].Number != o.Pull) {
+			return false
+		}
+	}
+	return true
+}
+
+func filter(pjs []prowapi.ProwJob, opts ListOptions) []prowapi.ProwJob {
+	var filtered []prowapi.ProwJob
+	for i := range pjs {
+		if opts.matches(&pjs[i]) {
+			filtered = append(filtered, pjs[i])
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/entrypoint"
+	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
+)
+
+// classifyFailure classifies why the test processes failed. The failure
+// file of the first process that failed wins, and a process that failed
+// without classifying its failure failed its tests. It returns nil when no
+// process failed on its own.
+func classifyFailure(entries []wrapper.Options) *prowapi.Failure {
+	for _, opt := range entries {
+		code, err := readExitCode(opt.MarkerFile)
+		if err != nil {
+			return &prowapi.Failure{
+				Category: prowapi.FailureCategoryInfra,
+				Message:  truncateFailureMessage(fmt.Sprintf("Could not read the exit code of the %s: %v", processName(entries, opt), err)),
+			}
+		}
+		if code == 0 || code == entrypoint.AbortedErrorCode || code == entrypoint.PreviousErrorCode {
+			continue
+		}
+		if failure := readFailure(opt.FailureFile); failure != nil {
+			failure.Message = truncateFailureMessage(failure.Message)
+			return failure
+		}
+		return &prowapi.Failure{
+			Category: prowapi.FailureCategoryTest,
+			Message:  fmt.Sprintf("The %s exited with code %d.", processName(entries, opt), code),
+		}
+	}
+	return nil
+}
+
+func readExitCode(markerFile string) (int, error) {
+	raw, err := os.ReadFile(markerFile)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(raw)))
+}
+
+// readFailure reads the failure the process classified, if it did.
+func readFailure(failureFile string) *prowapi.Failure {
+	if failureFile == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(failureFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.WithError(err).Warnf("Failed to read %s", failureFile)
+		}
+		return nil
+	}
+	var failure prowapi.Failure
+	if err := json.Unmarshal(raw, &failure); err != nil {
+		logrus.WithError(err).Warnf("Failed to unmarshal %s", failureFile)
+		return nil
+	}
+	if err := failure.Validate(); err != nil {
+		logrus.WithError(err).Warnf("Ignoring invalid %s", failureFile)
+		return nil
+	}
+	return &failure
+}
+
+func processName(entries []wrapper.Options, opt wrapper.Options) string {
+	if len(entries) > 1 {
+		return fmt.Sprintf("%s container", opt.ContainerName)
+	}
+	return "test"
+}
+
+// truncateFailureMessage keeps the message short enough for the report
+// the sidecar writes to its termination message.
+func truncateFailureMessage(message string) string {
+	if len(message) <= prowapi.MaxFailureMessageLength {
+		return message
+	}
+	message = message[:prowapi.MaxFailureMessageLength-len("…")]
+	// Do not cut a multi-byte rune in half.
+	for !utf8.ValidString(message) {
+		message = message[:len(message)-1]
+	}
+	return message + "…"
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	type process struct {
+		name    string
+		marker  string
+		failure string
+	}
+	var testCases = []struct {
+		name      string
+		processes []process
+		expected  *prowapi.Failure
+	}{
+		{
+			name:      "test passed",
+			processes: []process{{name: "test", marker: "0"}},
+		},
+		{
+			name:      "test failed without classifying its failure",
+			processes: []process{{name: "test", marker: "1"}},
+			expected:  &prowapi.Failure{Category: prowapi.FailureCategoryTest, Message: "The test exited with code 1."},
+		},
+		{
+			name:      "test classified its failure",
+			processes: []process{{name: "test", marker: "1", failure: `{"category":"infra","message":"cluster setup failed","owner":"sig-testing"}`}},
+			expected:  &prowapi.Failure{Category: prowapi.FailureCategoryInfra, Message: "cluster setup failed", Owner: "sig-testing"},
+		},
+		{
+			name:      "invalid classification is ignored",
+			processes: []process{{name: "test", marker: "1", failure: `{"category":"flake"}`}},
+			expected:  &prowapi.Failure{Category: prowapi.FailureCategoryTest, Message: "The test exited with code 1."},
+		},
+		{
+			name:      "missing marker",
+			processes: []process{{name: "test"}},
+			expected:  &prowapi.Failure{Category: prowapi.FailureCategoryInfra, Message: "Could not read the exit code of the test: open marker-test.txt: no such file or directory"},
+		},
+		{
+			name: "first failed container wins",
+			processes: []process{
+				{name: "setup", marker: "0"},
+				{name: "e2e", marker: "2", failure: `{"category":"timeout","message":"The process did not finish before the 2h0m0s timeout."}`},
+				{name: "cleanup", marker: "1"},
+			},
+			expected: &prowapi.Failure{Category: prowapi.FailureCategoryTimeout, Message: "The process did not finish before the 2h0m0s timeout."},
+		},
+		{
+			name: "skipped and aborted containers are ignored",
+			processes: []process{
+				{name: "setup", marker: "130"},
+				{name: "e2e", marker: "1130"},
+				{name: "cleanup", marker: "3"},
+			},
+			expected: &prowapi.Failure{Category: prowapi.FailureCategoryTest, Message: "The cleanup container exited with code 3."},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			var entries []wrapper.Options
+			for _, p := range tc.processes {
+				opt := wrapper.Options{
+					ContainerName: p.name,
+					MarkerFile:    filepath.Join(dir, "marker-"+p.name+".txt"),
+					FailureFile:   filepath.Join(dir, p.name+"-failure.json"),
+				}
+				if p.marker != "" {
+					if err := os.WriteFile(opt.MarkerFile, []byte(p.marker), 0644); err != nil {
+						t.Fatalf("failed to write marker: %v", err)
+					}
+				}
+				if p.failure != "" {
+					if err := os.WriteFile(opt.FailureFile, []byte(p.failure), 0644); err != nil {
+						t.Fatalf("failed to write failure: %v", err)
+					}
+				}
+				entries = append(entries, opt)
+			}
+			actual := classifyFailure(entries)
+			if actual != nil {
+				actual.Message = strings.ReplaceAll(actual.Message, dir+"/", "")
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected failure (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTruncateFailureMessage(t *testing.T) {
+	short := "node went away"
+	if actual := truncateFailureMessage(short); actual != short {
+		t.Errorf("expected short message to be kept, got %q", actual)
+	}
+	long := strings.Repeat("é", prowapi.MaxFailureMessageLength)
+	actual := truncateFailureMessage(long)
+	if len(actual) > prowapi.MaxFailureMessageLength {
+		t.Errorf("expected at most %d bytes, got %d", prowapi.MaxFailureMessageLength, len(actual))
+	}
+	if !utf8.ValidString(actual) || !strings.HasSuffix(actual, "…") {
+		t.Errorf("expected a valid truncated message, got %q", actual)
+	}
+}
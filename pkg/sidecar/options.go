@@ -21,8 +21,10 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/prow/pkg/gcsupload"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
 )
 
@@ -80,9 +82,25 @@ type Options struct {
 	// load the data into time series and plot it for analysis.
 	WriteMemoryProfile bool `json:"write_memory_profile,omitempty"`
 
+	// TestSummaryPath is where to write the Report of the upload, which holds
+	// the summary of the junit results found in the uploaded items, if any.
+	// Decorated pods use the termination message path, so that plank can
+	// record it in the ProwJob.
+	TestSummaryPath string `json:"test_summary_path,omitempty"`
+
+	// UploadInterval is how often the build logs are uploaded while the test
+	// is still running, so that the logs of long running jobs can be followed
+	// before they finish. Every upload appends the lines written since the
+	// previous one, censored like the final upload. If unset, logs are only
+	// uploaded once the test finished.
+	UploadInterval time.Duration `json:"upload_interval,omitempty"`
+
 	// CensoringOptions are options that pertain to censoring output before upload.
 	CensoringOptions *CensoringOptions `json:"censoring_options,omitempty"`
 
+	// Caches are saved to blob storage after the upload if all entries passed.
+	Caches []cache.Cache `json:"caches,omitempty"`
+
 	// SecretDirectories is deprecated, use censoring_options.secret_directories instead.
 	SecretDirectories []string `json:"secret_directories,omitempty"`
 	// CensoringConcurrency is deprecated, use censoring_options.censoring_concurrency instead.
@@ -150,6 +168,10 @@ func (o *Options) Validate() error {
 		o.CensoringOptions = &opts
 	}
 
+	if o.UploadInterval < 0 {
+		return errors.New("upload_interval must not be negative")
+	}
+
 	ents := o.entries()
 	if len(ents) == 0 {
 		return errors.New("no wrapper.Option entries")
@@ -184,6 +206,7 @@ func (o *Options) LoadConfig(config string) error {
 // AddFlags binds flags to options
 func (o *Options) AddFlags(flags *flag.FlagSet) {
 	o.GcsOptions.AddFlags(flags)
+	flags.DurationVar(&o.UploadInterval, "upload-interval", 0, "How often to upload the build logs while the test is running. If unset, logs are only uploaded once the test finished.")
 	// DeprecatedWrapperOptions flags should be unused, remove immediately
 }
 
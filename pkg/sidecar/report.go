@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// Report is what the sidecar tells plank about the upload through the
+// termination message of its container.
+type Report struct {
+	// TestSummary summarizes the junit results among the uploaded items,
+	// if there were any.
+	TestSummary *prowapi.TestSummary `json:"test_summary,omitempty"`
+	// Failure classifies why the test failed, if it did.
+	Failure *prowapi.Failure `json:"failure,omitempty"`
+	// ArtifactsUploaded is whether the artifacts were uploaded.
+	ArtifactsUploaded bool `json:"artifacts_uploaded"`
+}
+
+// writeReport writes the report to TestSummaryPath, if set.
+func (o Options) writeReport(report Report) {
+	if o.TestSummaryPath == "" {
+		return
+	}
+	raw, err := json.Marshal(report)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to marshal the report")
+		return
+	}
+	if err := os.WriteFile(o.TestSummaryPath, raw, 0644); err != nil {
+		logrus.WithError(err).Warnf("Failed to write the report to %s", o.TestSummaryPath)
+	}
+}
+
+// ParseReport parses the termination message of a sidecar container. It
+// returns nil when the message is not a report, e.g. when the sidecar failed
+// before it wrote one and kubernetes fell back to the tail of its logs.
+func ParseReport(message string) *Report {
+	if message == "" {
+		return nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(message))
+	decoder.DisallowUnknownFields()
+	var report Report
+	if err := decoder.Decode(&report); err != nil || decoder.More() {
+		return nil
+	}
+	return &report
+}
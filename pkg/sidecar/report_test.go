@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestWriteReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "termination-log")
+	report := Report{
+		TestSummary:       &prowapi.TestSummary{Passed: 3, Failed: 1, FailedTests: []string{"TestFoo"}},
+		Failure:           &prowapi.Failure{Category: prowapi.FailureCategoryTest, Message: "The test exited with code 1."},
+		ArtifactsUploaded: true,
+	}
+	Options{TestSummaryPath: path}.writeReport(report)
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the report: %v", err)
+	}
+	if diff := cmp.Diff(&report, ParseReport(string(raw))); diff != "" {
+		t.Errorf("report did not round trip (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseReport(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		message  string
+		expected *Report
+	}{
+		{
+			name: "no message",
+		},
+		{
+			name:     "report without tests",
+			message:  `{"artifacts_uploaded":false}`,
+			expected: &Report{},
+		},
+		{
+			name:    "tail of the logs",
+			message: "{\"component\":\"sidecar\",\"msg\":\"Failed to upload\"}\n",
+		},
+		{
+			name:    "report followed by logs",
+			message: `{"artifacts_uploaded":true} panic: oops`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, ParseReport(tc.message)); diff != "" {
+				t.Errorf("unexpected report (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -37,6 +37,8 @@ import (
 
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/entrypoint"
+	pkgio "sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/pod-utils/cache"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
 	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
@@ -126,7 +128,7 @@ func (o Options) Run(ctx context.Context, logFile *os.File) (int, error) {
 				metadata := combineMetadata(entries)
 
 				//Peform best-effort upload
-				err := o.doUpload(ctx, spec, false, true, metadata, buildLogs, logFile, &once)
+				err := o.doUpload(ctx, spec, false, true, metadata, nil, buildLogs, logFile, &once)
 				if err != nil {
 					logrus.WithError(err).Error("Failed to perform best-effort upload")
 				} else {
@@ -137,8 +139,19 @@ func (o Options) Run(ctx context.Context, logFile *os.File) (int, error) {
 		}
 	}()
 
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	streamDone := make(chan struct{})
+	go func() {
+		defer close(streamDone)
+		o.streamLogs(streamCtx, spec, entries)
+	}()
+
 	passed, aborted, failures := wait(ctx, entries)
 
+	// Stop streaming logs before the final upload, so that it is not
+	// overwritten with a partial log.
+	cancelStream()
+	<-streamDone
 	cancel()
 	// If we are being asked to terminate by the kubelet but we have
 	// seen the test process exit cleanly, we need a chance to upload
@@ -148,10 +161,35 @@ func (o Options) Run(ctx context.Context, logFile *os.File) (int, error) {
 	signal.Ignore(os.Interrupt, syscall.SIGTERM)
 
 	o.preUpload()
+	summary := o.testSummary()
+	var failure *prowv1.Failure
+	if !passed && !aborted {
+		failure = classifyFailure(entries)
+	}
 
 	buildLogs := logReadersFuncs(entries)
 	metadata := combineMetadata(entries)
-	return failures, o.doUpload(context.Background(), spec, passed, aborted, metadata, buildLogs, logFile, &once)
+	err = o.doUpload(context.Background(), spec, passed, aborted, metadata, failure, buildLogs, logFile, &once)
+	o.writeReport(Report{TestSummary: summary, Failure: failure, ArtifactsUploaded: err == nil})
+	// Only the caches of passing tests are saved, so that a broken test does
+	// not replace a good cache with a broken one.
+	if passed && !aborted {
+		if err := o.saveCaches(context.Background()); err != nil {
+			logrus.WithError(err).Warn("Failed to save caches.")
+		}
+	}
+	return failures, err
+}
+
+func (o Options) saveCaches(ctx context.Context) error {
+	if len(o.Caches) == 0 || o.GcsOptions.DryRun || o.GcsOptions.LocalOutputDir != "" {
+		return nil
+	}
+	opener, err := pkgio.NewOpener(ctx, o.GcsOptions.StorageClientOptions.GCSCredentialsFile, o.GcsOptions.StorageClientOptions.S3CredentialsFile)
+	if err != nil {
+		return fmt.Errorf("new opener: %w", err)
+	}
+	return cache.Save(ctx, opener, o.GcsOptions.Bucket, o.Caches)
 }
 
 const errorKey = "sidecar-errors"
@@ -170,15 +208,19 @@ func logReadersFuncs(entries []wrapper.Options) map[string]gcs.ReaderFunc {
 				return log, nil
 			}
 		}
-		buildLog := "build-log.txt"
-		if len(entries) > 1 {
-			buildLog = fmt.Sprintf("%s-build-log.txt", opt.ContainerName)
-		}
-		readerFuncs[buildLog] = f
+		readerFuncs[buildLogName(entries, opt)] = f
 	}
 	return readerFuncs
 }
 
+// buildLogName is the name that the log of the entry is uploaded as.
+func buildLogName(entries []wrapper.Options, opt wrapper.Options) string {
+	if len(entries) > 1 {
+		return fmt.Sprintf("%s-build-log.txt", opt.ContainerName)
+	}
+	return "build-log.txt"
+}
+
 func combineMetadata(entries []wrapper.Options) map[string]interface{} {
 	errors := map[string]error{}
 	metadata := map[string]interface{}{}
@@ -230,7 +272,7 @@ func (o Options) preUpload() {
 	}
 }
 
-func (o Options) doUpload(ctx context.Context, spec *downwardapi.JobSpec, passed, aborted bool, metadata map[string]interface{}, logReadersFuncs map[string]gcs.ReaderFunc, logFile *os.File, once *sync.Once) error {
+func (o Options) doUpload(ctx context.Context, spec *downwardapi.JobSpec, passed, aborted bool, metadata map[string]interface{}, failure *prowv1.Failure, logReadersFuncs map[string]gcs.ReaderFunc, logFile *os.File, once *sync.Once) error {
 	startTime := time.Now()
 	logrus.Info("Starting to upload")
 	uploadTargets := make(map[string]gcs.UploadFunc)
@@ -275,6 +317,10 @@ func (o Options) doUpload(ctx context.Context, spec *downwardapi.JobSpec, passed
 		result = "FAILURE"
 	}
 
+	if spec.ArtifactRetention != nil {
+		metadata[prowv1.ArtifactRetentionMetadataKey] = spec.ArtifactRetention
+	}
+
 	now := time.Now().Unix()
 	finished := testgridmetadata.Finished{
 		Timestamp: &now,
@@ -297,6 +343,17 @@ func (o Options) doUpload(ctx context.Context, spec *downwardapi.JobSpec, passed
 		uploadTargets[prowv1.FinishedStatusFile] = gcs.DataUpload(newReader)
 	}
 
+	if failure != nil {
+		failureData, err := json.Marshal(failure)
+		if err != nil {
+			logrus.WithError(err).Warn("Could not marshal failure data")
+		} else {
+			uploadTargets[prowv1.FailureFile] = gcs.DataUpload(func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(failureData)), nil
+			})
+		}
+	}
+
 	if err := o.GcsOptions.Run(ctx, spec, uploadTargets); err != nil {
 		return fmt.Errorf("failed to upload to GCS: %w", err)
 	}
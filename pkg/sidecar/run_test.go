@@ -18,6 +18,7 @@ package sidecar
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -545,14 +546,15 @@ func TestSideCarLogsUpload(t *testing.T) {
 				},
 			},
 		},
-		BuildID: "build",
+		BuildID:           "build",
+		ArtifactRetention: &prowapi.JobArtifactRetention{KeepLast: 3},
 	}
 
 	entries := options.entries()
 	metadata := combineMetadata(entries)
 	buildLogs := logReadersFuncs(entries)
 
-	options.doUpload(context.Background(), spec, true, false, metadata, buildLogs, logFile, &once)
+	options.doUpload(context.Background(), spec, true, false, metadata, nil, buildLogs, logFile, &once)
 
 	files, err := os.ReadDir(localOutputDir)
 	if err != nil {
@@ -573,4 +575,19 @@ func TestSideCarLogsUpload(t *testing.T) {
 		t.Fatal("Log file not correctly capturing logs")
 	}
 
+	s, err = os.ReadFile(filepath.Join(localOutputDir, prowapi.FinishedStatusFile))
+	if err != nil {
+		t.Fatalf("Unable to read finished.json: %v", err)
+	}
+	var finished struct {
+		Metadata struct {
+			ArtifactRetention *prowapi.JobArtifactRetention `json:"artifact-retention"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(s, &finished); err != nil {
+		t.Fatalf("Unable to parse finished.json: %v", err)
+	}
+	if !equality.Semantic.DeepEqual(spec.ArtifactRetention, finished.Metadata.ArtifactRetention) {
+		t.Errorf("finished.json does not carry the artifact retention:\n%s", diff.ObjectReflectDiff(spec.ArtifactRetention, finished.Metadata.ArtifactRetention))
+	}
 }
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+	"sigs.k8s.io/prow/pkg/pod-utils/gcs"
+	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
+	"sigs.k8s.io/prow/pkg/secretutil"
+)
+
+// tailSize is how much of the end of a log is searched for its last complete
+// line. Logs without a line break in their tail are uploaded as they are.
+const tailSize = 64 * 1024
+
+// streamLogs uploads the build logs of the entries every UploadInterval until
+// the context is cancelled. Where the storage supports composing objects, only
+// the part of a log written since the last upload is uploaded and appended to
+// it; otherwise every upload replaces the previous one with the log written so
+// far. Logs that did not grow since the last upload are skipped.
+func (o Options) streamLogs(ctx context.Context, spec *downwardapi.JobSpec, entries []wrapper.Options) {
+	if o.UploadInterval <= 0 {
+		return
+	}
+	var censorer secretutil.Censorer
+	var bufferSize int
+	if o.CensoringOptions != nil {
+		var err error
+		if censorer, bufferSize, err = o.newCensorer(); err != nil {
+			// Never upload logs that may not have been censored.
+			logrus.WithError(err).Warn("Failed to load secrets to censor, not streaming logs.")
+			return
+		}
+	}
+
+	// Chunks can only be composed onto a log that is not compressed, the
+	// final upload compresses it as configured.
+	gcsOptions := *o.GcsOptions
+	if gcsOptions.GCSConfiguration != nil {
+		gcsConfiguration := *gcsOptions.GCSConfiguration
+		gcsConfiguration.CompressFileTypes = nil
+		gcsOptions.GCSConfiguration = &gcsConfiguration
+	}
+	appendable := gcsOptions.CanAppend()
+
+	uploaded := map[string]int64{}
+	ticker := time.NewTicker(o.UploadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, opt := range entries {
+			name := buildLogName(entries, opt)
+			size, err := completeLines(opt.ProcessLog)
+			if err != nil {
+				logrus.WithError(err).Debugf("Failed to determine the size of %s.", opt.ProcessLog)
+				continue
+			}
+			if size <= uploaded[name] {
+				continue
+			}
+			var offset int64
+			if appendable {
+				offset = uploaded[name]
+			}
+			files := map[string]gcs.UploadFunc{name: gcs.DataUpload(logChunk(opt.ProcessLog, offset, size, censorer, bufferSize))}
+			if offset > 0 {
+				err = gcsOptions.AppendFiles(ctx, spec, files)
+			} else {
+				err = gcsOptions.UploadFiles(ctx, spec, files)
+			}
+			if err != nil {
+				logrus.WithError(err).Warnf("Failed to upload the %s written so far.", name)
+				continue
+			}
+			uploaded[name] = size
+		}
+	}
+}
+
+// completeLines returns the size of the log up to and including its last
+// line break.
+func completeLines(path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	start := size - tailSize
+	if start < 0 {
+		start = 0
+	}
+	tail := make([]byte, size-start)
+	n, err := f.ReadAt(tail, start)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if i := bytes.LastIndexByte(tail[:n], '\n'); i >= 0 {
+		return start + int64(i) + 1, nil
+	}
+	return size, nil
+}
+
+// logChunk reads the log from offset up to size, censored if a censorer is
+// given. Up to half a censoring buffer of the log around the chunk is censored
+// along with it, so that secrets crossing the bounds of chunks are censored
+// as well.
+func logChunk(path string, offset, size int64, censorer secretutil.Censorer, bufferSize int) gcs.ReaderFunc {
+	return func() (io.ReadCloser, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		if censorer == nil {
+			return readCloser{io.NewSectionReader(f, offset, size-offset), f}, nil
+		}
+		margin := int64(bufferSize / 2)
+		start := offset - margin
+		if start < 0 {
+			start = 0
+		}
+		// Censoring never changes the size of the log, so the censored
+		// chunk is at the same offset as the original one.
+		window := readCloser{io.NewSectionReader(f, start, size+margin-start), f}
+		reader, writer := io.Pipe()
+		go func() {
+			writer.CloseWithError(censor(window, openPipeWriter{writer}, censorer, bufferSize))
+		}()
+		if _, err := io.CopyN(io.Discard, reader, offset-start); err != nil {
+			reader.Close()
+			return nil, fmt.Errorf("failed to censor %s: %w", path, err)
+		}
+		return readCloser{io.LimitReader(reader, size-offset), reader}, nil
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// openPipeWriter leaves closing the pipe to the caller of censor, so that
+// censoring errors reach the reader.
+type openPipeWriter struct {
+	io.Writer
+}
+
+func (openPipeWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/gcsupload"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+	"sigs.k8s.io/prow/pkg/pod-utils/wrapper"
+	"sigs.k8s.io/prow/pkg/secretutil"
+)
+
+func TestCompleteLines(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected int64
+	}{
+		{
+			name:     "empty log",
+			content:  "",
+			expected: 0,
+		},
+		{
+			name:     "log ending in a line break is complete",
+			content:  "first\nsecond\n",
+			expected: 13,
+		},
+		{
+			name:     "partial last line is left out",
+			content:  "first\nsecond\nthi",
+			expected: 13,
+		},
+		{
+			name:     "log without line break is uploaded as it is",
+			content:  "no line break yet",
+			expected: 17,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "process-log.txt")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("failed to write log: %v", err)
+			}
+			actual, err := completeLines(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLogChunk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "process-log.txt")
+	if err := os.WriteFile(path, []byte("token: hunter2\nstill running"), 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	censorer := secretutil.NewCensorer()
+	censorer.RefreshBytes([]byte("hunter2"))
+
+	for _, tc := range []struct {
+		name         string
+		offset, size int64
+		censorer     secretutil.Censorer
+		expected     string
+	}{
+		{
+			name:     "snapshot without censoring",
+			size:     15,
+			expected: "token: hunter2\n",
+		},
+		{
+			name:     "snapshot with censoring",
+			size:     15,
+			censorer: censorer,
+			expected: "token: XXXXXXX\n",
+		},
+		{
+			name:     "chunk without censoring",
+			offset:   7,
+			size:     15,
+			expected: "hunter2\n",
+		},
+		{
+			name:     "secret starting before the chunk is censored",
+			offset:   10,
+			size:     15,
+			censorer: censorer,
+			expected: "XXXX\n",
+		},
+		{
+			name:     "secret ending after the chunk is censored",
+			offset:   2,
+			size:     10,
+			censorer: censorer,
+			expected: "ken: XXX",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reader, err := logChunk(path, tc.offset, tc.size, tc.censorer, 16)()
+			if err != nil {
+				t.Fatalf("failed to open chunk: %v", err)
+			}
+			defer reader.Close()
+			actual, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("failed to read chunk: %v", err)
+			}
+			if string(actual) != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, string(actual))
+			}
+		})
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	dir := t.TempDir()
+	processLog := filepath.Join(dir, "process-log.txt")
+	if err := os.WriteFile(processLog, []byte("started\nhalf a li"), 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	outputDir := filepath.Join(dir, "output")
+	options := Options{
+		GcsOptions:     gcsupload.NewOptions(),
+		UploadInterval: 10 * time.Millisecond,
+	}
+	options.GcsOptions.LocalOutputDir = outputDir
+	entries := []wrapper.Options{{ProcessLog: processLog}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		options.streamLogs(ctx, &downwardapi.JobSpec{Type: prowapi.PeriodicJob, Job: "job", BuildID: "1"}, entries)
+	}()
+
+	uploaded := filepath.Join(outputDir, "build-log.txt")
+	waitFor := func(expected string) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		var content []byte
+		for time.Now().Before(deadline) {
+			if content, _ = os.ReadFile(uploaded); string(content) == expected {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Errorf("expected the streamed log to be %q, got %q", expected, string(content))
+	}
+	waitFor("started\n")
+
+	f, err := os.OpenFile(processLog, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	if _, err := f.WriteString("ne\nfinished\n"); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	f.Close()
+	waitFor("started\nhalf a line\nfinished\n")
+
+	cancel()
+	<-done
+}
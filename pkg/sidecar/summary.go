@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/testgrid/metadata/junit"
+	"github.com/sirupsen/logrus"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// maxTestNameLength keeps the summary within the 4096 bytes kubernetes keeps
+// of termination messages.
+const maxTestNameLength = 256
+
+var junitRe = regexp.MustCompile(`^junit.*\.xml$`)
+
+// testSummary summarizes the junit results among the uploaded items, if any.
+func (o Options) testSummary() *prowapi.TestSummary {
+	if o.GcsOptions == nil {
+		return nil
+	}
+	summary, err := summarizeTests(o.GcsOptions.Items)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to summarize test results")
+		return nil
+	}
+	return summary
+}
+
+// summarizeTests counts the results in the junit files found in the given
+// files and directories. It returns nil when there are no junit files.
+func summarizeTests(items []string) (*prowapi.TestSummary, error) {
+	var summary *prowapi.TestSummary
+	for _, item := range items {
+		err := filepath.WalkDir(item, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || !junitRe.MatchString(d.Name()) {
+				return nil
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			suites, err := junit.Parse(content)
+			if err != nil {
+				logrus.WithError(err).WithField("path", path).Debug("Ignoring invalid junit file.")
+				return nil
+			}
+			if summary == nil {
+				summary = &prowapi.TestSummary{}
+			}
+			for _, suite := range suites.Suites {
+				addSuite(summary, suite)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return summary, nil
+}
+
+func addSuite(summary *prowapi.TestSummary, suite junit.Suite) {
+	for _, result := range suite.Results {
+		switch {
+		case result.Failure != nil, result.Errored != nil:
+			summary.Failed++
+			if len(summary.FailedTests) < prowapi.MaxSummaryFailedTests {
+				name := result.Name
+				if len(name) > maxTestNameLength {
+					name = name[:maxTestNameLength]
+				}
+				summary.FailedTests = append(summary.FailedTests, name)
+			}
+		case result.Skipped != nil:
+			summary.Skipped++
+		default:
+			summary.Passed++
+		}
+	}
+	for _, s := range suite.Suites {
+		addSuite(summary, s)
+	}
+}
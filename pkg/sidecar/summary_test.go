@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+func TestSummarizeTests(t *testing.T) {
+	const goJunit = `<testsuites>
+  <testsuite name="pkg/foo" tests="4" failures="1">
+    <testcase classname="pkg/foo" name="TestPass"></testcase>
+    <testcase classname="pkg/foo" name="TestFail"><failure message="boom"></failure></testcase>
+    <testcase classname="pkg/foo" name="TestSkip"><skipped message="short"></skipped></testcase>
+    <testsuite name="nested">
+      <testcase classname="pkg/foo" name="TestError"><error message="panic"></error></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+	const singleSuite = `<testsuite name="bar" tests="1"><testcase name="TestBar"></testcase></testsuite>`
+
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		items    []string
+		expected *prowapi.TestSummary
+	}{
+		{
+			name:  "no junit files",
+			files: map[string]string{"artifacts/build-log.txt": "hello", "artifacts/results.xml": goJunit},
+			items: []string{"artifacts"},
+		},
+		{
+			name:  "missing items are ignored",
+			items: []string{"artifacts"},
+		},
+		{
+			name: "results of all junit files are counted",
+			files: map[string]string{
+				"artifacts/junit_01.xml":      goJunit,
+				"artifacts/sub/junit_bar.xml": singleSuite,
+				"artifacts/junit_broken.xml":  "<testsuites",
+			},
+			items: []string{"artifacts"},
+			expected: &prowapi.TestSummary{
+				Passed:      2,
+				Failed:      2,
+				Skipped:     1,
+				FailedTests: []string{"TestFail", "TestError"},
+			},
+		},
+		{
+			name:  "junit files can be items",
+			files: map[string]string{"junit.xml": singleSuite},
+			items: []string{"junit.xml"},
+			expected: &prowapi.TestSummary{
+				Passed: 1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("failed to create dir: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+			var items []string
+			for _, item := range tc.items {
+				items = append(items, filepath.Join(dir, item))
+			}
+			actual, err := summarizeTests(items)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected summary (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSummarizeTestsLimitsFailedTests(t *testing.T) {
+	dir := t.TempDir()
+	junit := `<testsuite name="many">`
+	for i := 0; i < prowapi.MaxSummaryFailedTests+5; i++ {
+		junit += `<testcase name="TestFail"><failure></failure></testcase>`
+	}
+	junit += `</testsuite>`
+	if err := os.WriteFile(filepath.Join(dir, "junit.xml"), []byte(junit), 0644); err != nil {
+		t.Fatalf("failed to write junit: %v", err)
+	}
+	summary, err := summarizeTests([]string{dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Failed != prowapi.MaxSummaryFailedTests+5 {
+		t.Errorf("expected %d failures, got %d", prowapi.MaxSummaryFailedTests+5, summary.Failed)
+	}
+	if len(summary.FailedTests) != prowapi.MaxSummaryFailedTests {
+		t.Errorf("expected %d failed test names, got %d", prowapi.MaxSummaryFailedTests, len(summary.FailedTests))
+	}
+}
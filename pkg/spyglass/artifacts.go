@@ -24,6 +24,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/config"
 	"sigs.k8s.io/prow/pkg/io/providers"
 	"sigs.k8s.io/prow/pkg/spyglass/api"
@@ -89,6 +90,18 @@ func (s *Spyglass) ListArtifacts(ctx context.Context, src string) ([]string, err
 		}
 	}
 
+	if job.Spec.Agent != prowapi.KubernetesAgent {
+		// External agents serve the build log through deck if they are configured to.
+		if agentToTmpl := externalAgentLog(s.config(), &job); agentToTmpl != nil && agentToTmpl.URLTemplateString != "" {
+			artifactNamesSet.Insert(singleLogName)
+		}
+		externalNames, err := s.ExternalArtifactFetcher.artifactNames(ctx, &job)
+		if err != nil && err != context.Canceled {
+			logrus.WithError(err).WithField("prowjob", job.Name).Warn("error retrieving artifact names from external agent")
+		}
+		artifactNamesSet.Insert(externalNames...)
+	}
+
 	return sets.List(artifactNamesSet), nil
 }
 
@@ -99,8 +112,33 @@ func (s *Spyglass) prowToGCS(prowKey string) (string, string, error) {
 
 // FetchArtifacts constructs and returns Artifact objects for each artifact name in the list.
 // This includes getting any handles needed for read write operations, direct artifact links, etc.
+// Artifacts that are found neither in storage nor in the pod logs are looked
+// for among the artifacts that the job's external agent keeps, if any.
 func (s *Spyglass) FetchArtifacts(ctx context.Context, src string, podName string, sizeLimit int64, artifactNames []string) ([]api.Artifact, error) {
-	return common.FetchArtifacts(ctx, s.JobAgent, s.config, s.StorageArtifactFetcher, s.PodLogArtifactFetcher, src, podName, sizeLimit, artifactNames)
+	arts, err := common.FetchArtifacts(ctx, s.JobAgent, s.config, s.StorageArtifactFetcher, s.PodLogArtifactFetcher, src, podName, sizeLimit, artifactNames)
+	if err != nil {
+		return arts, err
+	}
+	found := sets.New[string]()
+	for _, art := range arts {
+		found.Insert(art.JobPath())
+	}
+	for _, name := range artifactNames {
+		if found.Has(name) {
+			continue
+		}
+		art, err := s.ExternalArtifactFetcher.Artifact(ctx, src, name, sizeLimit)
+		if err == nil {
+			// As for storage, make sure the artifact actually exists.
+			_, err = art.Size()
+		}
+		if err != nil {
+			logrus.WithError(err).WithField("artifact", name).Debug("Failed to fetch artifact from external agent")
+			continue
+		}
+		arts = append(arts, art)
+	}
+	return arts, nil
 }
 
 func splitSrc(src string) (keyType, key string, err error) {
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+// ExternalArtifact holds data for reading an artifact that an external agent,
+// e.g. Jenkins, serves over HTTP
+type ExternalArtifact struct {
+	ctx       context.Context
+	client    *http.Client
+	name      string
+	link      string
+	sizeLimit int64
+
+	once      sync.Once
+	contents  []byte
+	truncated bool
+	err       error
+}
+
+// NewExternalArtifact creates a new ExternalArtifact read from the given URL
+func NewExternalArtifact(ctx context.Context, client *http.Client, artifactName, link string, sizeLimit int64) (*ExternalArtifact, error) {
+	if artifactName == "" || link == "" {
+		return nil, errInsufficientJobInfo
+	}
+	if sizeLimit < 0 {
+		return nil, errInvalidSizeLimit
+	}
+	return &ExternalArtifact{
+		ctx:       ctx,
+		client:    client,
+		name:      artifactName,
+		link:      link,
+		sizeLimit: sizeLimit,
+	}, nil
+}
+
+// read fetches the artifact once, keeping at most one byte over the size limit
+// so that artifacts that are too large can be told apart.
+func (a *ExternalArtifact) read() ([]byte, error) {
+	a.once.Do(func() {
+		req, err := http.NewRequestWithContext(a.ctx, http.MethodGet, a.link, nil)
+		if err != nil {
+			a.err = fmt.Errorf("error creating request: %w", err)
+			return
+		}
+		resp, err := a.client.Do(req)
+		if err != nil {
+			a.err = fmt.Errorf("error fetching artifact: %w", err)
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			a.err = fmt.Errorf("error fetching artifact: %s", resp.Status)
+			return
+		}
+		contents, err := io.ReadAll(io.LimitReader(resp.Body, a.sizeLimit+1))
+		if err != nil {
+			a.err = fmt.Errorf("error reading artifact: %w", err)
+			return
+		}
+		a.truncated = int64(len(contents)) > a.sizeLimit
+		a.contents = contents
+	})
+	return a.contents, a.err
+}
+
+// CanonicalLink returns the URL the artifact is read from
+func (a *ExternalArtifact) CanonicalLink() string {
+	return a.link
+}
+
+// JobPath gets the path of the artifact within the build
+func (a *ExternalArtifact) JobPath() string {
+	return a.name
+}
+
+// ReadAt reads len(p) bytes of the artifact at offset off
+func (a *ExternalArtifact) ReadAt(p []byte, off int64) (n int, err error) {
+	if int64(len(p)) > a.sizeLimit {
+		return 0, lenses.ErrRequestSizeTooLarge
+	}
+	contents, err := a.read()
+	if err != nil {
+		return 0, err
+	}
+	if a.truncated && off+int64(len(p)) > a.sizeLimit {
+		return 0, lenses.ErrFileTooLarge
+	}
+	return bytes.NewReader(contents).ReadAt(p, off)
+}
+
+// ReadAll reads the whole artifact, failing if it is too large
+func (a *ExternalArtifact) ReadAll() ([]byte, error) {
+	contents, err := a.read()
+	if err != nil {
+		return nil, err
+	}
+	if a.truncated {
+		return nil, lenses.ErrFileTooLarge
+	}
+	return contents, nil
+}
+
+// ReadAtMost reads at most n bytes from the beginning of the artifact
+func (a *ExternalArtifact) ReadAtMost(n int64) ([]byte, error) {
+	if n > a.sizeLimit {
+		return nil, lenses.ErrRequestSizeTooLarge
+	}
+	contents, err := a.read()
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(contents)) <= n {
+		return contents, io.EOF
+	}
+	return contents[:n], nil
+}
+
+// ReadTail reads the last n bytes of the artifact
+func (a *ExternalArtifact) ReadTail(n int64) ([]byte, error) {
+	if n > a.sizeLimit {
+		return nil, lenses.ErrRequestSizeTooLarge
+	}
+	contents, err := a.read()
+	if err != nil {
+		return nil, err
+	}
+	if a.truncated {
+		return nil, lenses.ErrFileTooLarge
+	}
+	if int64(len(contents)) <= n {
+		return contents, nil
+	}
+	return contents[int64(len(contents))-n:], nil
+}
+
+// Size gets the size of the artifact. Note: this function reads the entire
+// artifact, up to the size limit; larger artifacts are reported as one byte
+// over the limit.
+func (a *ExternalArtifact) Size() (int64, error) {
+	contents, err := a.read()
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(contents)), nil
+}
+
+func (a *ExternalArtifact) Metadata() (map[string]string, error) {
+	return nil, nil
+}
+
+func (a *ExternalArtifact) UpdateMetadata(meta map[string]string) error {
+	return errors.New("not implemented")
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/common"
+)
+
+// ArtifactSource knows how to find the artifacts that an external agent keeps
+// for its builds, e.g. the archived artifacts of a Jenkins build. The base URL
+// passed to it is generated from the artifacts_url_template configured for the
+// agent in deck.external_agent_logs.
+type ArtifactSource interface {
+	// Artifacts lists the names of the artifacts of the ProwJob's build.
+	Artifacts(ctx context.Context, client *http.Client, baseURL string, pj *prowapi.ProwJob) ([]string, error)
+	// ArtifactURL returns the URL from which the named artifact can be read.
+	ArtifactURL(ctx context.Context, client *http.Client, baseURL string, pj *prowapi.ProwJob, name string) (string, error)
+}
+
+var artifactSources = map[prowapi.ProwJobAgent]ArtifactSource{
+	prowapi.JenkinsAgent: jenkinsArtifactSource{},
+	prowapi.TektonAgent:  tektonArtifactSource{},
+}
+
+// RegisterArtifactSource makes Spyglass read the artifacts of builds run by the
+// given agent from the given source, replacing any source registered for it
+// before. It is not safe to call once Spyglass is serving requests.
+func RegisterArtifactSource(agent prowapi.ProwJobAgent, source ArtifactSource) {
+	artifactSources[agent] = source
+}
+
+// ExternalArtifactFetcher is used to fetch the artifacts that external agents
+// keep for their builds
+type ExternalArtifactFetcher struct {
+	ja     jobAgent
+	config config.Getter
+	client *http.Client
+}
+
+// NewExternalArtifactFetcher returns an ExternalArtifactFetcher that finds the
+// agents of builds using the given job agent
+func NewExternalArtifactFetcher(ja jobAgent, cfg config.Getter) *ExternalArtifactFetcher {
+	return &ExternalArtifactFetcher{
+		ja:     ja,
+		config: cfg,
+		client: &http.Client{Timeout: time.Minute},
+	}
+}
+
+// externalAgentLog returns the deck configuration of the ProwJob's agent, if any.
+func externalAgentLog(cfg *config.Config, pj *prowapi.ProwJob) *config.ExternalAgentLog {
+	for i, agentToTmpl := range cfg.Deck.ExternalAgentLogs {
+		if agentToTmpl.Agent != string(pj.Spec.Agent) {
+			continue
+		}
+		if agentToTmpl.Selector != nil && !agentToTmpl.Selector.Matches(labels.Set(pj.ObjectMeta.Labels)) {
+			continue
+		}
+		return &cfg.Deck.ExternalAgentLogs[i]
+	}
+	return nil
+}
+
+// source returns the artifact source of the ProwJob's agent along with the
+// URL of the build's artifacts. It returns a nil source for ProwJobs whose
+// agent has no artifacts configured.
+func (af *ExternalArtifactFetcher) source(pj *prowapi.ProwJob) (ArtifactSource, string, error) {
+	if pj.Spec.Agent == prowapi.KubernetesAgent {
+		return nil, "", nil
+	}
+	agentToTmpl := externalAgentLog(af.config(), pj)
+	if agentToTmpl == nil || agentToTmpl.ArtifactsURLTemplate == nil {
+		return nil, "", nil
+	}
+	source, ok := artifactSources[pj.Spec.Agent]
+	if !ok {
+		return nil, "", fmt.Errorf("no artifact source is registered for agent %q", pj.Spec.Agent)
+	}
+	var b bytes.Buffer
+	if err := agentToTmpl.ArtifactsURLTemplate.Execute(&b, pj); err != nil {
+		return nil, "", fmt.Errorf("cannot execute artifacts URL template for prowjob %q with agent %q: %w", pj.Name, pj.Spec.Agent, err)
+	}
+	return source, strings.TrimSuffix(b.String(), "/"), nil
+}
+
+// artifactNames lists the names of the artifacts the agent of the ProwJob
+// keeps for its build.
+func (af *ExternalArtifactFetcher) artifactNames(ctx context.Context, pj *prowapi.ProwJob) ([]string, error) {
+	source, baseURL, err := af.source(pj)
+	if err != nil || source == nil {
+		return nil, err
+	}
+	return source.Artifacts(ctx, af.client, baseURL, pj)
+}
+
+// Artifact constructs an artifact handle for the given job build
+func (af *ExternalArtifactFetcher) Artifact(ctx context.Context, key, artifactName string, sizeLimit int64) (api.Artifact, error) {
+	jobName, buildID, err := common.KeyToJob(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive job: %w", err)
+	}
+	pj, err := af.ja.GetProwJob(jobName, buildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prow job: %w", err)
+	}
+	source, baseURL, err := af.source(&pj)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("agent %q of prowjob %q keeps no artifacts", pj.Spec.Agent, pj.Name)
+	}
+	link, err := source.ArtifactURL(ctx, af.client, baseURL, &pj, artifactName)
+	if err != nil {
+		return nil, fmt.Errorf("could not find artifact %q: %w", artifactName, err)
+	}
+	return NewExternalArtifact(ctx, af.client, artifactName, link, sizeLimit)
+}
+
+// getJSON decodes the JSON document served at the given URL into v.
+func getJSON(ctx context.Context, client *http.Client, link string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", link, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// escapePath escapes each element of a slash-separated path, refusing paths
+// that could escape the directory they are relative to.
+func escapePath(p string) (string, error) {
+	if p == "" || path.IsAbs(p) || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("invalid path %q", p)
+	}
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/"), nil
+}
+
+// jenkinsArtifactSource reads the artifacts archived by a Jenkins build. The
+// base URL is that of the build, either on Jenkins or as served by
+// jenkins-operator, e.g. http://jenkins-operator/job/<job>/<build>.
+type jenkinsArtifactSource struct{}
+
+func (jenkinsArtifactSource) Artifacts(ctx context.Context, client *http.Client, baseURL string, _ *prowapi.ProwJob) ([]string, error) {
+	var build struct {
+		Artifacts []struct {
+			RelativePath string `json:"relativePath"`
+		} `json:"artifacts"`
+	}
+	if err := getJSON(ctx, client, baseURL+"/api/json?tree=artifacts%5BrelativePath%5D", &build); err != nil {
+		return nil, fmt.Errorf("error listing Jenkins artifacts: %w", err)
+	}
+	var names []string
+	for _, artifact := range build.Artifacts {
+		names = append(names, artifact.RelativePath)
+	}
+	return names, nil
+}
+
+func (jenkinsArtifactSource) ArtifactURL(_ context.Context, _ *http.Client, baseURL string, _ *prowapi.ProwJob, name string) (string, error) {
+	escaped, err := escapePath(name)
+	if err != nil {
+		return "", err
+	}
+	return baseURL + "/artifact/" + escaped, nil
+}
+
+const (
+	tektonLogSuffix         = ".log"
+	tektonPipelineRunLabel  = "tekton.dev/pipelineRun"
+	tektonTaskRunsAPIFormat = "%s/apis/tekton.dev/v1beta1/namespaces/%s/taskruns"
+)
+
+// tektonTaskRun holds the parts of a Tekton TaskRun that tell where its steps ran.
+type tektonTaskRun struct {
+	Metadata struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Status struct {
+		PodName string `json:"podName"`
+		Steps   []struct {
+			Name      string `json:"name"`
+			Container string `json:"container"`
+		} `json:"steps"`
+	} `json:"status"`
+}
+
+// tektonArtifactSource reads the logs of the steps of the TaskRuns that make
+// up the PipelineRun of a ProwJob, named <taskrun>/<step>.log. The base URL is
+// that of a proxy to the Kubernetes API of the build cluster, e.g. the Tekton
+// Dashboard, which is expected to handle authentication.
+type tektonArtifactSource struct{}
+
+func (tektonArtifactSource) Artifacts(ctx context.Context, client *http.Client, baseURL string, pj *prowapi.ProwJob) ([]string, error) {
+	var taskRuns struct {
+		Items []tektonTaskRun `json:"items"`
+	}
+	selector := url.Values{"labelSelector": []string{fmt.Sprintf("%s=%s", tektonPipelineRunLabel, pj.Name)}}
+	link := fmt.Sprintf(tektonTaskRunsAPIFormat, baseURL, url.PathEscape(pj.Spec.Namespace)) + "?" + selector.Encode()
+	if err := getJSON(ctx, client, link, &taskRuns); err != nil {
+		return nil, fmt.Errorf("error listing Tekton TaskRuns: %w", err)
+	}
+	var names []string
+	for _, taskRun := range taskRuns.Items {
+		for _, step := range taskRun.Status.Steps {
+			names = append(names, taskRun.Metadata.Name+"/"+step.Name+tektonLogSuffix)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (tektonArtifactSource) ArtifactURL(ctx context.Context, client *http.Client, baseURL string, pj *prowapi.ProwJob, name string) (string, error) {
+	taskRunName, stepName, ok := strings.Cut(strings.TrimSuffix(name, tektonLogSuffix), "/")
+	if !ok || !strings.HasSuffix(name, tektonLogSuffix) || strings.Contains(stepName, "/") {
+		return "", errors.New("not the log of a TaskRun step")
+	}
+	var taskRun tektonTaskRun
+	link := fmt.Sprintf(tektonTaskRunsAPIFormat, baseURL, url.PathEscape(pj.Spec.Namespace)) + "/" + url.PathEscape(taskRunName)
+	if err := getJSON(ctx, client, link, &taskRun); err != nil {
+		return "", fmt.Errorf("error getting Tekton TaskRun: %w", err)
+	}
+	// Only serve the TaskRuns of the ProwJob's own PipelineRun.
+	if taskRun.Metadata.Labels[tektonPipelineRunLabel] != pj.Name {
+		return "", fmt.Errorf("TaskRun %q is not part of PipelineRun %q", taskRunName, pj.Name)
+	}
+	for _, step := range taskRun.Status.Steps {
+		if step.Name != stepName {
+			continue
+		}
+		query := url.Values{"container": []string{step.Container}}
+		return fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s/log?%s", baseURL, url.PathEscape(pj.Spec.Namespace), url.PathEscape(taskRun.Status.PodName), query.Encode()), nil
+	}
+	return "", fmt.Errorf("TaskRun %q has no step %q", taskRunName, stepName)
+}
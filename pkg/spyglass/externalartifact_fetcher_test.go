@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"text/template"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/common"
+)
+
+// fakeExternalJAgent serves ProwJobs run by external agents
+type fakeExternalJAgent struct {
+	jobs map[string]prowapi.ProwJob
+}
+
+func (j *fakeExternalJAgent) GetProwJob(job, id string) (prowapi.ProwJob, error) {
+	pj, ok := j.jobs[job+"/"+id]
+	if !ok {
+		return prowapi.ProwJob{}, fmt.Errorf("could not find job %s, id %s", job, id)
+	}
+	return pj, nil
+}
+
+func (j *fakeExternalJAgent) GetJobLog(job, id, container string) ([]byte, error) {
+	return nil, fmt.Errorf("could not find job %s, id %s, container %s", job, id, container)
+}
+
+// fakeAgentServer serves the artifacts of a Jenkins build through the paths
+// jenkins-operator exposes and the TaskRuns of a Tekton PipelineRun through
+// the Kubernetes API.
+func fakeAgentServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/job/jenkins-job/12/api/json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"artifacts": [{"relativePath": "artifacts/junit.xml"}, {"relativePath": "out/report 1.txt"}]}`)
+	})
+	mux.HandleFunc("/job/jenkins-job/12/artifact/artifacts/junit.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<testsuites/>")
+	})
+	mux.HandleFunc("/apis/tekton.dev/v1beta1/namespaces/test-pods/taskruns", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("labelSelector") != "tekton.dev/pipelineRun=tekton-pj" {
+			http.Error(w, "unexpected selector", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"items": [
+			{"metadata": {"name": "tekton-pj-unit"}, "status": {"podName": "tekton-pj-unit-pod", "steps": [{"name": "test", "container": "step-test"}]}},
+			{"metadata": {"name": "tekton-pj-build"}, "status": {"podName": "tekton-pj-build-pod", "steps": [{"name": "compile", "container": "step-compile"}, {"name": "push", "container": "step-push"}]}}
+		]}`)
+	})
+	mux.HandleFunc("/apis/tekton.dev/v1beta1/namespaces/test-pods/taskruns/tekton-pj-unit", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"metadata": {"name": "tekton-pj-unit", "labels": {"tekton.dev/pipelineRun": "tekton-pj"}}, "status": {"podName": "tekton-pj-unit-pod", "steps": [{"name": "test", "container": "step-test"}]}}`)
+	})
+	mux.HandleFunc("/apis/tekton.dev/v1beta1/namespaces/test-pods/taskruns/other-unit", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"metadata": {"name": "other-unit", "labels": {"tekton.dev/pipelineRun": "other"}}, "status": {"podName": "other-unit-pod", "steps": [{"name": "test", "container": "step-test"}]}}`)
+	})
+	mux.HandleFunc("/api/v1/namespaces/test-pods/pods/tekton-pj-unit-pod/log", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("container") != "step-test" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "PASS")
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestExternalArtifactFetcher(serverURL string) *ExternalArtifactFetcher {
+	agentLog := func(agent, artifactsTemplate string) config.ExternalAgentLog {
+		return config.ExternalAgentLog{
+			Agent:                agent,
+			Selector:             labels.Everything(),
+			URLTemplate:          template.Must(template.New(agent).Parse("unused")),
+			ArtifactsURLTemplate: template.Must(template.New(agent).Parse(artifactsTemplate)),
+		}
+	}
+	cfg := &config.Config{ProwConfig: config.ProwConfig{Deck: config.Deck{ExternalAgentLogs: []config.ExternalAgentLog{
+		agentLog(string(prowapi.JenkinsAgent), serverURL+"/job/{{.Spec.Job}}/{{.Status.BuildID}}/"),
+		agentLog(string(prowapi.TektonAgent), serverURL),
+	}}}}
+	ja := &fakeExternalJAgent{jobs: map[string]prowapi.ProwJob{
+		"jenkins-job/12": {
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins-pj"},
+			Spec:       prowapi.ProwJobSpec{Agent: prowapi.JenkinsAgent, Job: "jenkins-job"},
+			Status:     prowapi.ProwJobStatus{BuildID: "12"},
+		},
+		"tekton-job/7": {
+			ObjectMeta: metav1.ObjectMeta{Name: "tekton-pj"},
+			Spec:       prowapi.ProwJobSpec{Agent: prowapi.TektonAgent, Job: "tekton-job", Namespace: "test-pods"},
+			Status:     prowapi.ProwJobStatus{BuildID: "7"},
+		},
+		"pod-job/3": {
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-pj"},
+			Spec:       prowapi.ProwJobSpec{Agent: prowapi.KubernetesAgent, Job: "pod-job"},
+			Status:     prowapi.ProwJobStatus{BuildID: "3"},
+		},
+	}}
+	return NewExternalArtifactFetcher(ja, func() *config.Config { return cfg })
+}
+
+func TestExternalArtifactNames(t *testing.T) {
+	server := fakeAgentServer()
+	defer server.Close()
+	fetcher := newTestExternalArtifactFetcher(server.URL)
+
+	testCases := []struct {
+		name     string
+		key      string
+		expected []string
+	}{
+		{
+			name:     "Jenkins build",
+			key:      "jenkins-job/12",
+			expected: []string{"artifacts/junit.xml", "out/report 1.txt"},
+		},
+		{
+			name:     "Tekton PipelineRun",
+			key:      "tekton-job/7",
+			expected: []string{"tekton-pj-build/compile.log", "tekton-pj-build/push.log", "tekton-pj-unit/test.log"},
+		},
+		{
+			name: "Kubernetes pod",
+			key:  "pod-job/3",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobName, buildID, err := common.KeyToJob(tc.key)
+			if err != nil {
+				t.Fatalf("failed to parse key: %v", err)
+			}
+			pj, err := fetcher.ja.GetProwJob(jobName, buildID)
+			if err != nil {
+				t.Fatalf("failed to get prowjob: %v", err)
+			}
+			names, err := fetcher.artifactNames(context.Background(), &pj)
+			if err != nil {
+				t.Fatalf("failed to list artifacts: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, names); diff != "" {
+				t.Errorf("artifact names differ from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFetchArtifacts_External(t *testing.T) {
+	server := fakeAgentServer()
+	defer server.Close()
+	fetcher := newTestExternalArtifactFetcher(server.URL)
+
+	testCases := []struct {
+		name         string
+		key          string
+		artifact     string
+		expectedLink string
+		expected     string
+		expectErr    bool
+	}{
+		{
+			name:         "Jenkins artifact",
+			key:          "jenkins-job/12",
+			artifact:     "artifacts/junit.xml",
+			expectedLink: server.URL + "/job/jenkins-job/12/artifact/artifacts/junit.xml",
+			expected:     "<testsuites/>",
+		},
+		{
+			name:      "Jenkins artifact outside of the build",
+			key:       "jenkins-job/12",
+			artifact:  "../11/artifact/secret.txt",
+			expectErr: true,
+		},
+		{
+			name:         "Tekton step log",
+			key:          "tekton-job/7",
+			artifact:     "tekton-pj-unit/test.log",
+			expectedLink: server.URL + "/api/v1/namespaces/test-pods/pods/tekton-pj-unit-pod/log?container=step-test",
+			expected:     "PASS",
+		},
+		{
+			name:      "Tekton step log of another PipelineRun",
+			key:       "tekton-job/7",
+			artifact:  "other-unit/test.log",
+			expectErr: true,
+		},
+		{
+			name:      "Tekton log of unknown step",
+			key:       "tekton-job/7",
+			artifact:  "tekton-pj-unit/lint.log",
+			expectErr: true,
+		},
+		{
+			name:      "Kubernetes pod",
+			key:       "pod-job/3",
+			artifact:  "artifacts/junit.xml",
+			expectErr: true,
+		},
+		{
+			name:      "unknown job",
+			key:       "missing-job/1",
+			artifact:  "artifacts/junit.xml",
+			expectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			artifact, err := fetcher.Artifact(context.Background(), tc.key, tc.artifact, 500e6)
+			if err != nil {
+				if !tc.expectErr {
+					t.Fatalf("failed unexpectedly: %v", err)
+				}
+				return
+			}
+			if tc.expectErr {
+				t.Fatalf("expected an error, got artifact %s", artifact.CanonicalLink())
+			}
+			if link := artifact.CanonicalLink(); link != tc.expectedLink {
+				t.Errorf("expected link %q, got %q", tc.expectedLink, link)
+			}
+			contents, err := artifact.ReadAll()
+			if err != nil {
+				t.Fatalf("failed to read artifact: %v", err)
+			}
+			if string(contents) != tc.expected {
+				t.Errorf("expected contents %q, got %q", tc.expected, string(contents))
+			}
+		})
+	}
+}
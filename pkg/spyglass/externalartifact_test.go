@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+func TestExternalArtifact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/build-log.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "0123456789")
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name      string
+		path      string
+		sizeLimit int64
+		read      func(*ExternalArtifact) ([]byte, error)
+		expected  string
+		expectErr error
+	}{
+		{
+			name:      "read all",
+			path:      "/build-log.txt",
+			sizeLimit: 10,
+			read:      (*ExternalArtifact).ReadAll,
+			expected:  "0123456789",
+		},
+		{
+			name:      "read all over the size limit",
+			path:      "/build-log.txt",
+			sizeLimit: 5,
+			read:      (*ExternalArtifact).ReadAll,
+			expectErr: lenses.ErrFileTooLarge,
+		},
+		{
+			name:      "read tail",
+			path:      "/build-log.txt",
+			sizeLimit: 10,
+			read:      func(a *ExternalArtifact) ([]byte, error) { return a.ReadTail(3) },
+			expected:  "789",
+		},
+		{
+			name:      "read at most",
+			path:      "/build-log.txt",
+			sizeLimit: 5,
+			read:      func(a *ExternalArtifact) ([]byte, error) { return a.ReadAtMost(4) },
+			expected:  "0123",
+		},
+		{
+			name:      "read at most over the size limit",
+			path:      "/build-log.txt",
+			sizeLimit: 5,
+			read:      func(a *ExternalArtifact) ([]byte, error) { return a.ReadAtMost(6) },
+			expectErr: lenses.ErrRequestSizeTooLarge,
+		},
+		{
+			name:      "read at",
+			path:      "/build-log.txt",
+			sizeLimit: 10,
+			read: func(a *ExternalArtifact) ([]byte, error) {
+				p := make([]byte, 3)
+				n, err := a.ReadAt(p, 2)
+				return p[:n], err
+			},
+			expected: "234",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			artifact, err := NewExternalArtifact(context.Background(), server.Client(), "build-log.txt", server.URL+tc.path, tc.sizeLimit)
+			if err != nil {
+				t.Fatalf("failed to create artifact: %v", err)
+			}
+			contents, err := tc.read(artifact)
+			if tc.expectErr != nil {
+				if err != tc.expectErr {
+					t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+				}
+				return
+			}
+			if err != nil && err != io.EOF {
+				t.Fatalf("failed to read artifact: %v", err)
+			}
+			if string(contents) != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, string(contents))
+			}
+		})
+	}
+}
+
+func TestExternalArtifactNotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	artifact, err := NewExternalArtifact(context.Background(), server.Client(), "missing.txt", server.URL+"/missing.txt", 100)
+	if err != nil {
+		t.Fatalf("failed to create artifact: %v", err)
+	}
+	if _, err := artifact.Size(); err == nil {
+		t.Error("expected an error getting the size of a missing artifact")
+	}
+}
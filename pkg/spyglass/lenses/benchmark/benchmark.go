@@ -0,0 +1,347 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package benchmark provides a viewer of benchmark results and their trend
+// over previous runs of a job for Spyglass
+package benchmark
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+const (
+	name     = "benchmark"
+	title    = "Benchmarks"
+	priority = 15
+
+	// resultsRequest is the callback request for the parsed results of
+	// the artifacts, which the frontend makes for previous runs.
+	resultsRequest = "results"
+
+	defaultThresholdPercent = 10
+)
+
+func init() {
+	lenses.RegisterLens(Lens{})
+}
+
+type ownConfig struct {
+	// RegressionThresholdPercent is how much worse than the median of the
+	// previous runs a metric may get before it is flagged as a regression.
+	// Defaults to 10.
+	RegressionThresholdPercent float64 `json:"regression_threshold_percent,omitempty"`
+}
+
+// Lens is the implementation of a benchmark-rendering Spyglass lens.
+type Lens struct{}
+
+// Config returns the lens's configuration.
+func (lens Lens) Config() lenses.LensConfig {
+	return lenses.LensConfig{
+		Name:     name,
+		Title:    title,
+		Priority: priority,
+	}
+}
+
+// Header renders the content of <head> from template.html.
+func (lens Lens) Header(artifacts []api.Artifact, resourceDir string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	t, err := loadTemplate(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		return fmt.Sprintf("<!-- FAILED LOADING HEADER: %v -->", err)
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "header", nil); err != nil {
+		return fmt.Sprintf("<!-- FAILED EXECUTING HEADER TEMPLATE: %v -->", err)
+	}
+	return buf.String()
+}
+
+// Callback returns the parsed results of the artifacts as JSON.
+func (lens Lens) Callback(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	if data != resultsRequest {
+		return ""
+	}
+	raw, err := json.Marshal(parseArtifacts(artifacts))
+	if err != nil {
+		logrus.WithError(err).Error("Failed to marshal benchmark results.")
+		return ""
+	}
+	return string(raw)
+}
+
+// Body renders the <body>. The frontend renders it again with the results
+// of the previous runs of the job as data once it has fetched them.
+func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string, rawConfig json.RawMessage, spyglassConfig config.Spyglass) string {
+	conf := ownConfig{RegressionThresholdPercent: defaultThresholdPercent}
+	if len(rawConfig) > 0 {
+		if err := json.Unmarshal(rawConfig, &conf); err != nil {
+			logrus.WithError(err).Error("Failed to decode benchmark config")
+		}
+	}
+
+	var history []run
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &history); err != nil {
+			logrus.WithError(err).Info("Failed to decode benchmark history.")
+		}
+	}
+
+	benchTemplate, err := loadTemplate(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		logrus.WithError(err).Error("Error loading template.")
+		return fmt.Sprintf("Failed to load template file: %v", err)
+	}
+	t := struct {
+		HistoryLoaded    bool
+		Runs             int
+		ThresholdPercent float64
+		Trends           []trend
+	}{
+		HistoryLoaded:    data != "",
+		Runs:             len(history),
+		ThresholdPercent: conf.RegressionThresholdPercent,
+		Trends:           trends(parseArtifacts(artifacts), history, conf.RegressionThresholdPercent),
+	}
+	var buf bytes.Buffer
+	if err := benchTemplate.ExecuteTemplate(&buf, "body", t); err != nil {
+		logrus.WithError(err).Error("Error executing template.")
+	}
+	return buf.String()
+}
+
+// result is a metric of a benchmark.
+type result struct {
+	Name  string  `json:"name"`
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+	// HigherIsBetter is set for metrics like throughput. Metrics like
+	// durations, where lower is better, are the default.
+	HigherIsBetter bool `json:"higher_is_better,omitempty"`
+}
+
+func (r result) key() string {
+	return r.Name + " " + r.Unit
+}
+
+// jsonResults is the JSON schema of benchmark results.
+type jsonResults struct {
+	Benchmarks []result `json:"benchmarks"`
+}
+
+// run holds the results of a previous run of the job.
+type run struct {
+	ID      string   `json:"id"`
+	Link    string   `json:"link"`
+	Results []result `json:"results"`
+}
+
+var (
+	goBenchmarkRe = regexp.MustCompile(`^Benchmark\S+\s+\d+\s+`)
+	// goMaxProcsRe matches the GOMAXPROCS suffix of benchmark names, which
+	// differs between machines.
+	goMaxProcsRe = regexp.MustCompile(`-\d+$`)
+)
+
+func parseArtifacts(artifacts []api.Artifact) []result {
+	var results []result
+	for _, artifact := range artifacts {
+		content, err := artifact.ReadAll()
+		if err != nil {
+			logrus.WithError(err).WithField("artifact", artifact.JobPath()).Warn("Couldn't read benchmark results.")
+			continue
+		}
+		if strings.HasSuffix(artifact.JobPath(), ".json") {
+			var parsed jsonResults
+			if err := json.Unmarshal(content, &parsed); err != nil {
+				logrus.WithError(err).WithField("artifact", artifact.JobPath()).Info("Couldn't parse benchmark results.")
+				continue
+			}
+			results = append(results, parsed.Benchmarks...)
+			continue
+		}
+		results = append(results, parseGoBenchmarks(content)...)
+	}
+	return average(results)
+}
+
+// parseGoBenchmarks parses the output of `go test -bench`, e.g.
+//
+// BenchmarkParse-8   	  500000	      2345 ns/op	     512 B/op	       7 allocs/op
+func parseGoBenchmarks(content []byte) []result {
+	var results []result
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !goBenchmarkRe.MatchString(line) {
+			continue
+		}
+		fields := strings.Fields(line)
+		name := goMaxProcsRe.ReplaceAllString(fields[0], "")
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				break
+			}
+			unit := fields[i+1]
+			results = append(results, result{Name: name, Unit: unit, Value: value, HigherIsBetter: unit == "MB/s"})
+		}
+	}
+	return results
+}
+
+// average merges the results of benchmarks that ran several times, e.g.
+// with `go test -count`, into their mean.
+func average(results []result) []result {
+	var merged []result
+	counts := map[string]int{}
+	index := map[string]int{}
+	for _, r := range results {
+		i, ok := index[r.key()]
+		if !ok {
+			index[r.key()] = len(merged)
+			counts[r.key()] = 1
+			merged = append(merged, r)
+			continue
+		}
+		counts[r.key()]++
+		merged[i].Value += (r.Value - merged[i].Value) / float64(counts[r.key()])
+	}
+	return merged
+}
+
+// trend is a metric of a benchmark over the previous runs of the job.
+type trend struct {
+	Name  string
+	Unit  string
+	Value float64
+	// Baseline is the median of the metric in previous runs, which is 0
+	// when there were none.
+	Baseline float64
+	// ChangePercent is how much the metric changed from the baseline.
+	ChangePercent float64
+	Regression    bool
+	Improvement   bool
+	Points        []point
+}
+
+// point is a run of the job in the trend, the last one being the current
+// run.
+type point struct {
+	ID    string
+	Link  string
+	Value float64
+}
+
+func trends(current []result, history []run, thresholdPercent float64) []trend {
+	var result []trend
+	for _, r := range current {
+		t := trend{Name: r.Name, Unit: r.Unit, Value: r.Value}
+		var previous []float64
+		// Runs are listed newest first.
+		for i := len(history) - 1; i >= 0; i-- {
+			for _, h := range history[i].Results {
+				if h.key() == r.key() {
+					previous = append(previous, h.Value)
+					t.Points = append(t.Points, point{ID: history[i].ID, Link: history[i].Link, Value: h.Value})
+					break
+				}
+			}
+		}
+		t.Points = append(t.Points, point{Value: r.Value})
+		if len(previous) > 0 {
+			t.Baseline = median(previous)
+			if t.Baseline != 0 {
+				t.ChangePercent = 100 * (r.Value - t.Baseline) / t.Baseline
+			}
+			worse, better := t.ChangePercent > thresholdPercent, t.ChangePercent < -thresholdPercent
+			if r.HigherIsBetter {
+				worse, better = better, worse
+			}
+			t.Regression, t.Improvement = worse, better
+		}
+		result = append(result, t)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Regression && !result[j].Regression })
+	return result
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+const (
+	sparklineWidth  = 200
+	sparklineHeight = 30
+)
+
+// sparkline returns the SVG polyline points plotting the values of a trend.
+func sparkline(points []point) string {
+	if len(points) < 2 {
+		return ""
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, p := range points {
+		lo, hi = math.Min(lo, p.Value), math.Max(hi, p.Value)
+	}
+	var coords []string
+	for i, p := range points {
+		x := float64(sparklineWidth) * float64(i) / float64(len(points)-1)
+		y := float64(sparklineHeight) / 2
+		if hi > lo {
+			y = float64(sparklineHeight) * (1 - (p.Value-lo)/(hi-lo))
+		}
+		coords = append(coords, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	return strings.Join(coords, " ")
+}
+
+func loadTemplate(path string) (*template.Template, error) {
+	return template.New("template.html").Funcs(template.FuncMap{
+		"sparkline": sparkline,
+		"number": func(f float64) string {
+			if math.Abs(f) >= 1000 {
+				return strconv.FormatFloat(f, 'f', 0, 64)
+			}
+			return strconv.FormatFloat(f, 'g', 4, 64)
+		},
+		"percent": func(f float64) string {
+			return fmt.Sprintf("%+.1f%%", f)
+		},
+	}).ParseFiles(path)
+}
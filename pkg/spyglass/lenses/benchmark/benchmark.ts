@@ -0,0 +1,75 @@
+// The number of previous runs of the job compared with.
+const maxRuns = 20;
+
+interface Build {
+  ID: string;
+  SpyglassLink: string;
+}
+
+interface Run {
+  id: string;
+  link: string;
+  results: unknown[];
+}
+
+// jobHistoryPath returns the path of the job history of the build at src,
+// like Spyglass.JobPath does for storage sources.
+function jobHistoryPath(src: string): string | undefined {
+  const parts = src.split('/');
+  if (parts.length < 5) {
+    return undefined;
+  }
+  const [provider, bucket, logType] = parts;
+  switch (logType) {
+  case 'logs':
+    return parts.slice(0, -1).join('/');
+  case 'pr-logs':
+    return [provider, bucket, 'pr-logs', 'directory', parts[parts.length - 2]].join('/');
+  default:
+    return undefined;
+  }
+}
+
+async function loadHistory(): Promise<void> {
+  const container = document.getElementById('benchmark');
+  if (!container || container.dataset.historyLoaded === 'true' || !document.querySelector('table.benchmarks')) {
+    return;
+  }
+  const status = document.getElementById('history-status')!;
+  const req = new URLSearchParams(location.search).get('req');
+  if (!req) {
+    return;
+  }
+  const src: string = JSON.parse(req).src;
+  const historyPath = jobHistoryPath(src);
+  const buildID = src.split('/').pop();
+  if (!historyPath) {
+    status.textContent = 'The previous runs of this job cannot be found.';
+    return;
+  }
+
+  let builds: Build[];
+  try {
+    const response = await fetch(`/job-history/${historyPath}?buildId=${buildID}&format=json`);
+    builds = await response.json();
+  } catch (e) {
+    status.textContent = `Failed to load the previous runs of this job: ${e}`;
+    return;
+  }
+
+  const previous = builds.filter((b) => b.ID !== buildID && b.SpyglassLink).slice(0, maxRuns);
+  const runs = await Promise.all(previous.map(async (b): Promise<Run | undefined> => {
+    try {
+      const results = await spyglass.requestForBuild(b.SpyglassLink.replace(/^\/view\//, ''), 'results');
+      return {id: b.ID, link: b.SpyglassLink, results: JSON.parse(results)};
+    } catch (e) {
+      // Runs without results, e.g. because they failed early, are left out.
+      return undefined;
+    }
+  }));
+  await spyglass.updatePage(JSON.stringify(runs.filter((r) => r !== undefined && r.results)));
+}
+
+window.addEventListener('load', () => {
+  loadHistory();
+});
@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/fake"
+)
+
+const goBenchmarks = `goos: linux
+goarch: amd64
+pkg: example.com/parser
+BenchmarkParse-8   	  500000	      2000 ns/op	     512 B/op	       7 allocs/op
+BenchmarkParse-8   	  500000	      3000 ns/op	     512 B/op	       7 allocs/op
+BenchmarkRead-8    	    1000	    100000 ns/op	  200.00 MB/s
+PASS
+ok  	example.com/parser	3.2s
+`
+
+func TestParseArtifacts(t *testing.T) {
+	artifacts := []api.Artifact{
+		&fake.Artifact{Path: "artifacts/bench.txt", Content: []byte(goBenchmarks)},
+		&fake.Artifact{Path: "artifacts/load.json", Content: []byte(`{"benchmarks": [{"name": "p99 latency", "unit": "ms", "value": 12.5}, {"name": "throughput", "unit": "req/s", "value": 900, "higher_is_better": true}]}`)},
+		&fake.Artifact{Path: "artifacts/broken.json", Content: []byte(`{`)},
+	}
+	expected := []result{
+		{Name: "BenchmarkParse", Unit: "ns/op", Value: 2500},
+		{Name: "BenchmarkParse", Unit: "B/op", Value: 512},
+		{Name: "BenchmarkParse", Unit: "allocs/op", Value: 7},
+		{Name: "BenchmarkRead", Unit: "ns/op", Value: 100000},
+		{Name: "BenchmarkRead", Unit: "MB/s", Value: 200, HigherIsBetter: true},
+		{Name: "p99 latency", Unit: "ms", Value: 12.5},
+		{Name: "throughput", Unit: "req/s", Value: 900, HigherIsBetter: true},
+	}
+	if diff := cmp.Diff(expected, parseArtifacts(artifacts)); diff != "" {
+		t.Errorf("parsed results differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestTrends(t *testing.T) {
+	current := []result{
+		{Name: "BenchmarkParse", Unit: "ns/op", Value: 1300},
+		{Name: "BenchmarkRead", Unit: "MB/s", Value: 150, HigherIsBetter: true},
+		{Name: "BenchmarkStable", Unit: "ns/op", Value: 105},
+		{Name: "BenchmarkNew", Unit: "ns/op", Value: 50},
+	}
+	// Newest run first, like the job history.
+	history := []run{
+		{ID: "3", Link: "/view/gs/bucket/logs/job/3", Results: []result{
+			{Name: "BenchmarkParse", Unit: "ns/op", Value: 1000},
+			{Name: "BenchmarkRead", Unit: "MB/s", Value: 200, HigherIsBetter: true},
+			{Name: "BenchmarkStable", Unit: "ns/op", Value: 100},
+		}},
+		{ID: "2", Link: "/view/gs/bucket/logs/job/2", Results: []result{
+			{Name: "BenchmarkParse", Unit: "ns/op", Value: 2000},
+		}},
+		{ID: "1", Link: "/view/gs/bucket/logs/job/1", Results: []result{
+			{Name: "BenchmarkParse", Unit: "ns/op", Value: 900},
+		}},
+	}
+	expected := []trend{
+		{
+			Name: "BenchmarkParse", Unit: "ns/op", Value: 1300,
+			Baseline: 1000, ChangePercent: 30, Regression: true,
+			Points: []point{
+				{ID: "1", Link: "/view/gs/bucket/logs/job/1", Value: 900},
+				{ID: "2", Link: "/view/gs/bucket/logs/job/2", Value: 2000},
+				{ID: "3", Link: "/view/gs/bucket/logs/job/3", Value: 1000},
+				{Value: 1300},
+			},
+		},
+		{
+			Name: "BenchmarkRead", Unit: "MB/s", Value: 150,
+			Baseline: 200, ChangePercent: -25, Regression: true,
+			Points: []point{
+				{ID: "3", Link: "/view/gs/bucket/logs/job/3", Value: 200},
+				{Value: 150},
+			},
+		},
+		{
+			Name: "BenchmarkStable", Unit: "ns/op", Value: 105,
+			Baseline: 100, ChangePercent: 5,
+			Points: []point{
+				{ID: "3", Link: "/view/gs/bucket/logs/job/3", Value: 100},
+				{Value: 105},
+			},
+		},
+		{
+			Name: "BenchmarkNew", Unit: "ns/op", Value: 50,
+			Points: []point{{Value: 50}},
+		},
+	}
+	if diff := cmp.Diff(expected, trends(current, history, 10)); diff != "" {
+		t.Errorf("trends differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if line := sparkline([]point{{Value: 1}}); line != "" {
+		t.Errorf("expected no sparkline for a single point, got %q", line)
+	}
+	if line, expected := sparkline([]point{{Value: 1}, {Value: 3}, {Value: 2}}), "0.0,30.0 100.0,0.0 200.0,15.0"; line != expected {
+		t.Errorf("expected sparkline %q, got %q", expected, line)
+	}
+	if line, expected := sparkline([]point{{Value: 2}, {Value: 2}}), "0.0,15.0 200.0,15.0"; line != expected {
+		t.Errorf("expected flat sparkline %q, got %q", expected, line)
+	}
+}
+
+func TestBody(t *testing.T) {
+	artifacts := []api.Artifact{&fake.Artifact{Path: "artifacts/bench.txt", Content: []byte(goBenchmarks)}}
+	history, err := json.Marshal([]run{{ID: "1", Results: []result{{Name: "BenchmarkParse", Unit: "ns/op", Value: 2000}}}})
+	if err != nil {
+		t.Fatalf("failed to marshal history: %v", err)
+	}
+
+	loading := Lens{}.Body(artifacts, ".", "", nil, config.Spyglass{})
+	for _, expected := range []string{`data-history-loaded="false"`, "Loading the results of previous runs", "BenchmarkParse", "2500 ns/op"} {
+		if !strings.Contains(loading, expected) {
+			t.Errorf("expected body to contain %q, got:\n%s", expected, loading)
+		}
+	}
+
+	loaded := Lens{}.Body(artifacts, ".", string(history), json.RawMessage(`{"regression_threshold_percent": 20}`), config.Spyglass{})
+	for _, expected := range []string{`data-history-loaded="true"`, "median of 1 previous runs", "over 20%", `class="regression"`, "25.0% regression", "<polyline"} {
+		if !strings.Contains(loaded, expected) {
+			t.Errorf("expected body to contain %q, got:\n%s", expected, loaded)
+		}
+	}
+}
+
+func TestCallback(t *testing.T) {
+	artifacts := []api.Artifact{&fake.Artifact{Path: "artifacts/bench.txt", Content: []byte("BenchmarkParse-8 10 5 ns/op\n")}}
+	if got, expected := (Lens{}).Callback(artifacts, ".", "results", nil, config.Spyglass{}), `[{"name":"BenchmarkParse","unit":"ns/op","value":5}]`; got != expected {
+		t.Errorf("expected callback to return %s, got %s", expected, got)
+	}
+	if got := (Lens{}).Callback(artifacts, ".", "unknown", nil, config.Spyglass{}); got != "" {
+		t.Errorf("expected no response to an unknown request, got %s", got)
+	}
+}
@@ -0,0 +1,388 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterdump provides a viewer of cluster dumps for Spyglass
+package clusterdump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+const (
+	name     = "clusterdump"
+	title    = "Cluster Dump"
+	priority = 25
+
+	nodesFile  = "nodes.json"
+	podsFile   = "pods.json"
+	eventsFile = "events.json"
+	logsFile   = "logs.txt"
+
+	// maxWarnings is the number of warning events shown per namespace.
+	maxWarnings = 50
+)
+
+func init() {
+	lenses.RegisterLens(Lens{})
+}
+
+// Lens is the implementation of a cluster dump-rendering Spyglass lens.
+type Lens struct{}
+
+// Config returns the lens's configuration.
+func (lens Lens) Config() lenses.LensConfig {
+	return lenses.LensConfig{
+		Name:     name,
+		Title:    title,
+		Priority: priority,
+	}
+}
+
+// Header renders the content of <head> from template.html.
+func (lens Lens) Header(artifacts []api.Artifact, resourceDir string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	t, err := loadTemplate(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		return fmt.Sprintf("<!-- FAILED LOADING HEADER: %v -->", err)
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "header", nil); err != nil {
+		return fmt.Sprintf("<!-- FAILED EXECUTING HEADER TEMPLATE: %v -->", err)
+	}
+	return buf.String()
+}
+
+// Callback does nothing.
+func (lens Lens) Callback(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	return ""
+}
+
+// Body renders the <body>
+func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string, rawConfig json.RawMessage, spyglassConfig config.Spyglass) string {
+	dumpTemplate, err := loadTemplate(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		logrus.WithError(err).Error("Error loading template.")
+		return fmt.Sprintf("Failed to load template file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := dumpTemplate.ExecuteTemplate(&buf, "body", parseDumps(artifacts)); err != nil {
+		logrus.WithError(err).Error("Error executing template.")
+	}
+	return buf.String()
+}
+
+// status is how healthy a resource of the cluster is.
+type status string
+
+const (
+	statusOK      status = "ok"
+	statusWarning status = "warning"
+	statusError   status = "error"
+)
+
+func worse(a, b status) status {
+	rank := map[status]int{statusOK: 0, statusWarning: 1, statusError: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// dump is a cluster dump in the layout of `kubectl cluster-info dump
+// --output-directory`:
+//
+// <dir>/nodes.json
+// <dir>/<namespace>/pods.json
+// <dir>/<namespace>/events.json
+// <dir>/<namespace>/<pod>/logs.txt
+type dump struct {
+	// Dir is the directory of the dump within the job's artifacts.
+	Dir        string
+	Nodes      []node
+	Namespaces []*namespace
+	// Errors are the files of the dump that could not be read.
+	Errors []string
+}
+
+type node struct {
+	Name    string
+	Status  status
+	Message string
+}
+
+type namespace struct {
+	Name   string
+	Status status
+	Pods   []pod
+	// Unhealthy is the number of pods that are not ok.
+	Unhealthy int
+	// Warnings are the latest warning events of the namespace.
+	Warnings      []event
+	TotalWarnings int
+	EventsLink    string
+}
+
+type pod struct {
+	Name       string
+	Phase      v1.PodPhase
+	Status     status
+	Ready      string
+	Restarts   int32
+	Containers []container
+	LogLink    string
+}
+
+type container struct {
+	Name     string
+	Status   status
+	State    string
+	Restarts int32
+}
+
+type event struct {
+	Object  string
+	Reason  string
+	Message string
+	Count   int32
+	Last    time.Time
+}
+
+// parseDumps finds the cluster dumps among the artifacts, of which a
+// job may have several, e.g. one per cluster of a multi-cluster test.
+func parseDumps(artifacts []api.Artifact) []*dump {
+	dumps := map[string]*dump{}
+	namespaces := map[string]*namespace{}
+	getNamespace := func(nsDir string) (*dump, *namespace) {
+		dir := path.Dir(nsDir)
+		d, ok := dumps[dir]
+		if !ok {
+			d = &dump{Dir: dir}
+			dumps[dir] = d
+		}
+		ns, ok := namespaces[nsDir]
+		if !ok {
+			ns = &namespace{Name: path.Base(nsDir), Status: statusOK}
+			namespaces[nsDir] = ns
+			d.Namespaces = append(d.Namespaces, ns)
+		}
+		return d, ns
+	}
+
+	// Logs are linked to pods once all pods are known.
+	logLinks := map[string]string{}
+	for _, artifact := range artifacts {
+		p := artifact.JobPath()
+		dir := path.Dir(p)
+		switch path.Base(p) {
+		case nodesFile:
+			d, ok := dumps[dir]
+			if !ok {
+				d = &dump{Dir: dir}
+				dumps[dir] = d
+			}
+			var nodes v1.NodeList
+			if err := readJSON(artifact, &nodes); err != nil {
+				d.Errors = append(d.Errors, err.Error())
+				continue
+			}
+			d.Nodes = parseNodes(nodes)
+		case podsFile:
+			d, ns := getNamespace(dir)
+			var pods v1.PodList
+			if err := readJSON(artifact, &pods); err != nil {
+				d.Errors = append(d.Errors, err.Error())
+				continue
+			}
+			ns.Pods = parsePods(pods)
+		case eventsFile:
+			d, ns := getNamespace(dir)
+			ns.EventsLink = artifact.CanonicalLink()
+			var events v1.EventList
+			if err := readJSON(artifact, &events); err != nil {
+				d.Errors = append(d.Errors, err.Error())
+				continue
+			}
+			ns.Warnings, ns.TotalWarnings = parseWarnings(events)
+		case logsFile:
+			logLinks[dir] = artifact.CanonicalLink()
+		}
+	}
+
+	var result []*dump
+	for _, d := range dumps {
+		for nsDir, ns := range namespaces {
+			if path.Dir(nsDir) != d.Dir {
+				continue
+			}
+			for i := range ns.Pods {
+				ns.Pods[i].LogLink = logLinks[path.Join(nsDir, ns.Pods[i].Name)]
+				if ns.Pods[i].Status != statusOK {
+					ns.Unhealthy++
+				}
+				ns.Status = worse(ns.Status, ns.Pods[i].Status)
+			}
+			if ns.TotalWarnings > 0 {
+				ns.Status = worse(ns.Status, statusWarning)
+			}
+		}
+		sort.Slice(d.Namespaces, func(i, j int) bool { return d.Namespaces[i].Name < d.Namespaces[j].Name })
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Dir < result[j].Dir })
+	return result
+}
+
+func readJSON(artifact api.Artifact, into interface{}) error {
+	content, err := artifact.ReadAll()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", artifact.JobPath(), err)
+	}
+	if err := json.Unmarshal(content, into); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", artifact.JobPath(), err)
+	}
+	return nil
+}
+
+func parseNodes(nodes v1.NodeList) []node {
+	var result []node
+	for _, n := range nodes.Items {
+		parsed := node{Name: n.Name, Status: statusWarning, Message: "no Ready condition"}
+		for _, condition := range n.Status.Conditions {
+			if condition.Type != v1.NodeReady {
+				continue
+			}
+			parsed.Message = condition.Message
+			if condition.Status == v1.ConditionTrue {
+				parsed.Status = statusOK
+			} else {
+				parsed.Status = statusError
+			}
+		}
+		result = append(result, parsed)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func parsePods(pods v1.PodList) []pod {
+	var result []pod
+	for _, p := range pods.Items {
+		parsed := pod{Name: p.Name, Phase: p.Status.Phase, Status: statusOK}
+		switch p.Status.Phase {
+		case v1.PodFailed:
+			parsed.Status = statusError
+		case v1.PodPending, v1.PodUnknown:
+			parsed.Status = statusWarning
+		}
+		var ready int
+		statuses := append(append([]v1.ContainerStatus{}, p.Status.InitContainerStatuses...), p.Status.ContainerStatuses...)
+		for _, s := range statuses {
+			c := parseContainer(s, p.Status.Phase)
+			parsed.Containers = append(parsed.Containers, c)
+			parsed.Restarts += s.RestartCount
+			parsed.Status = worse(parsed.Status, c.Status)
+		}
+		for _, s := range p.Status.ContainerStatuses {
+			if s.Ready {
+				ready++
+			}
+		}
+		parsed.Ready = fmt.Sprintf("%d/%d", ready, len(p.Status.ContainerStatuses))
+		result = append(result, parsed)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func parseContainer(s v1.ContainerStatus, phase v1.PodPhase) container {
+	c := container{Name: s.Name, Restarts: s.RestartCount, Status: statusOK}
+	switch {
+	case s.State.Waiting != nil:
+		c.State = "Waiting: " + s.State.Waiting.Reason
+		c.Status = statusError
+		if reason := s.State.Waiting.Reason; reason == "ContainerCreating" || reason == "PodInitializing" {
+			c.Status = statusWarning
+		}
+	case s.State.Terminated != nil:
+		c.State = fmt.Sprintf("Terminated: %s (exit code %d)", s.State.Terminated.Reason, s.State.Terminated.ExitCode)
+		if s.State.Terminated.ExitCode != 0 {
+			c.Status = statusError
+		}
+	case s.State.Running != nil:
+		c.State = "Running"
+		if !s.Ready && phase == v1.PodRunning {
+			c.Status = statusWarning
+			c.State = "Running, not ready"
+		}
+	}
+	if c.Restarts > 0 {
+		c.Status = worse(c.Status, statusWarning)
+	}
+	return c
+}
+
+// parseWarnings returns the latest warning events and the number of them.
+func parseWarnings(events v1.EventList) ([]event, int) {
+	var warnings []event
+	for _, e := range events.Items {
+		if e.Type != v1.EventTypeWarning {
+			continue
+		}
+		last := e.LastTimestamp.Time
+		if last.IsZero() {
+			last = e.EventTime.Time
+		}
+		warnings = append(warnings, event{
+			Object:  fmt.Sprintf("%s/%s", e.InvolvedObject.Kind, e.InvolvedObject.Name),
+			Reason:  e.Reason,
+			Message: e.Message,
+			Count:   e.Count,
+			Last:    last,
+		})
+	}
+	sort.SliceStable(warnings, func(i, j int) bool { return warnings[i].Last.After(warnings[j].Last) })
+	total := len(warnings)
+	if total > maxWarnings {
+		warnings = warnings[:maxWarnings]
+	}
+	return warnings, total
+}
+
+func loadTemplate(path string) (*template.Template, error) {
+	return template.New("template.html").Funcs(template.FuncMap{
+		"isOK": func(s status) bool { return s == statusOK },
+		"timestamp": func(t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			return t.UTC().Format("15:04:05")
+		},
+	}).ParseFiles(path)
+}
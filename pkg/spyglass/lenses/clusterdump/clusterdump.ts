@@ -0,0 +1,5 @@
+window.addEventListener('load', () => {
+  document.querySelectorAll<HTMLDetailsElement>('details').forEach((e) => {
+    e.addEventListener('toggle', () => spyglass.contentUpdated());
+  });
+});
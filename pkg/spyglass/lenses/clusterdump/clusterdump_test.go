@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdump
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/fake"
+)
+
+func jsonArtifact(t *testing.T, path string, o interface{}) *fake.Artifact {
+	content, err := json.Marshal(o)
+	if err != nil {
+		t.Fatalf("failed to marshal %s: %v", path, err)
+	}
+	link := "https://storage.example.com/" + path
+	return &fake.Artifact{Path: path, Content: content, Link: &link}
+}
+
+func testArtifacts(t *testing.T) []api.Artifact {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	logLink := "https://storage.example.com/artifacts/cluster-info/kube-system/coredns-1/logs.txt"
+	return []api.Artifact{
+		jsonArtifact(t, "artifacts/cluster-info/nodes.json", v1.NodeList{Items: []v1.Node{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "kind-worker"},
+				Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+					{Type: v1.NodeReady, Status: v1.ConditionFalse, Message: "container runtime network not ready"},
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "kind-control-plane"},
+				Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+					{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+					{Type: v1.NodeReady, Status: v1.ConditionTrue, Message: "kubelet is posting ready status"},
+				}},
+			},
+		}}),
+		jsonArtifact(t, "artifacts/cluster-info/kube-system/pods.json", v1.PodList{Items: []v1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+				Status: v1.PodStatus{
+					Phase: v1.PodRunning,
+					ContainerStatuses: []v1.ContainerStatus{
+						{Name: "etcd", Ready: true, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "coredns-1"},
+				Status: v1.PodStatus{
+					Phase: v1.PodRunning,
+					ContainerStatuses: []v1.ContainerStatus{
+						{Name: "coredns", RestartCount: 5, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+					},
+				},
+			},
+		}}),
+		&fake.Artifact{Path: "artifacts/cluster-info/kube-system/coredns-1/logs.txt", Content: []byte("panic: boom\n"), Link: &logLink},
+		jsonArtifact(t, "artifacts/cluster-info/kube-system/events.json", v1.EventList{Items: []v1.Event{
+			{Type: v1.EventTypeNormal, Reason: "Pulled", InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "etcd"}},
+			{Type: v1.EventTypeWarning, Reason: "BackOff", Message: "Back-off restarting failed container", Count: 5, InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "coredns-1"}, LastTimestamp: metav1.NewTime(now)},
+			{Type: v1.EventTypeWarning, Reason: "Unhealthy", Message: "Readiness probe failed", Count: 1, InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: "coredns-1"}, LastTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+		}}),
+		jsonArtifact(t, "artifacts/cluster-info/default/pods.json", v1.PodList{Items: []v1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "job-1"},
+				Status: v1.PodStatus{
+					Phase: v1.PodSucceeded,
+					ContainerStatuses: []v1.ContainerStatus{
+						{Name: "main", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed"}}},
+					},
+				},
+			},
+		}}),
+		&fake.Artifact{Path: "artifacts/cluster-info/broken/pods.json", Content: []byte("{")},
+	}
+}
+
+func TestParseDumps(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	expected := []*dump{
+		{
+			Dir: "artifacts/cluster-info",
+			Nodes: []node{
+				{Name: "kind-control-plane", Status: statusOK, Message: "kubelet is posting ready status"},
+				{Name: "kind-worker", Status: statusError, Message: "container runtime network not ready"},
+			},
+			Namespaces: []*namespace{
+				{Name: "broken", Status: statusOK},
+				{
+					Name:   "default",
+					Status: statusOK,
+					Pods: []pod{
+						{
+							Name:   "job-1",
+							Phase:  v1.PodSucceeded,
+							Status: statusOK,
+							Ready:  "0/1",
+							Containers: []container{
+								{Name: "main", Status: statusOK, State: "Terminated: Completed (exit code 0)"},
+							},
+						},
+					},
+				},
+				{
+					Name:      "kube-system",
+					Status:    statusError,
+					Unhealthy: 1,
+					Pods: []pod{
+						{
+							Name:     "coredns-1",
+							Phase:    v1.PodRunning,
+							Status:   statusError,
+							Ready:    "0/1",
+							Restarts: 5,
+							Containers: []container{
+								{Name: "coredns", Status: statusError, State: "Waiting: CrashLoopBackOff", Restarts: 5},
+							},
+							LogLink: "https://storage.example.com/artifacts/cluster-info/kube-system/coredns-1/logs.txt",
+						},
+						{
+							Name:   "etcd",
+							Phase:  v1.PodRunning,
+							Status: statusOK,
+							Ready:  "1/1",
+							Containers: []container{
+								{Name: "etcd", Status: statusOK, State: "Running"},
+							},
+						},
+					},
+					Warnings: []event{
+						{Object: "Pod/coredns-1", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 5, Last: now},
+						{Object: "Pod/coredns-1", Reason: "Unhealthy", Message: "Readiness probe failed", Count: 1, Last: now.Add(-time.Minute)},
+					},
+					TotalWarnings: 2,
+					EventsLink:    "https://storage.example.com/artifacts/cluster-info/kube-system/events.json",
+				},
+			},
+			Errors: []string{"failed to parse artifacts/cluster-info/broken/pods.json: unexpected end of JSON input"},
+		},
+	}
+	if diff := cmp.Diff(expected, parseDumps(testArtifacts(t))); diff != "" {
+		t.Errorf("parsed dumps differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestBody(t *testing.T) {
+	body := Lens{}.Body(testArtifacts(t), ".", "", nil, config.Spyglass{})
+	for _, expected := range []string{"kind-worker", "coredns-1", "Waiting: CrashLoopBackOff", "Back-off restarting failed container", "logs.txt"} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected body to contain %q, got:\n%s", expected, body)
+		}
+	}
+
+	empty := Lens{}.Body(nil, ".", "", nil, config.Spyglass{})
+	if !strings.Contains(empty, "No cluster dump found") {
+		t.Errorf("expected body without artifacts to explain the missing dump, got:\n%s", empty)
+	}
+}
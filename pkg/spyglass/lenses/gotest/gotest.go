@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gotest provides a viewer of `go test -json` output for Spyglass
+package gotest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses"
+)
+
+const (
+	name     = "gotest"
+	title    = "Go Test Results"
+	priority = 6
+)
+
+func init() {
+	lenses.RegisterLens(Lens{})
+}
+
+// Lens is the implementation of a `go test -json`-rendering Spyglass lens.
+type Lens struct{}
+
+// Config returns the lens's configuration.
+func (lens Lens) Config() lenses.LensConfig {
+	return lenses.LensConfig{
+		Name:     name,
+		Title:    title,
+		Priority: priority,
+	}
+}
+
+// Header renders the content of <head> from template.html.
+func (lens Lens) Header(artifacts []api.Artifact, resourceDir string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	t, err := loadTemplate(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		return fmt.Sprintf("<!-- FAILED LOADING HEADER: %v -->", err)
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "header", nil); err != nil {
+		return fmt.Sprintf("<!-- FAILED EXECUTING HEADER TEMPLATE: %v -->", err)
+	}
+	return buf.String()
+}
+
+// Callback does nothing.
+func (lens Lens) Callback(artifacts []api.Artifact, resourceDir string, data string, config json.RawMessage, spyglassConfig config.Spyglass) string {
+	return ""
+}
+
+// Body renders the <body>
+func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string, rawConfig json.RawMessage, spyglassConfig config.Spyglass) string {
+	if len(artifacts) == 0 {
+		logrus.Error("gotest Body() called with no artifacts, which should never happen.")
+		return "Why am I here? There is no go test output."
+	}
+
+	var results []artifactResult
+	for _, artifact := range artifacts {
+		content, err := artifact.ReadAll()
+		if err != nil {
+			logrus.WithError(err).WithField("artifact", artifact.JobPath()).Warn("Couldn't read go test output.")
+			results = append(results, artifactResult{Path: artifact.JobPath(), Error: fmt.Sprintf("Failed to read the go test output: %v", err)})
+			continue
+		}
+		results = append(results, artifactResult{Path: artifact.JobPath(), Link: artifact.CanonicalLink(), Packages: parse(content)})
+	}
+
+	testTemplate, err := loadTemplate(filepath.Join(resourceDir, "template.html"))
+	if err != nil {
+		logrus.WithError(err).Error("Error loading template.")
+		return fmt.Sprintf("Failed to load template file: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := testTemplate.ExecuteTemplate(&buf, "body", results); err != nil {
+		logrus.WithError(err).Error("Error executing template.")
+	}
+	return buf.String()
+}
+
+// event is a line of `go test -json` output, see `go doc test2json`.
+type event struct {
+	Time    time.Time
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// Statuses of packages and tests. Tests that were still running when the
+// output ended, e.g. because the test binary timed out or crashed, are
+// incomplete.
+const (
+	statusPass       = "pass"
+	statusFail       = "fail"
+	statusSkip       = "skip"
+	statusIncomplete = "incomplete"
+)
+
+type artifactResult struct {
+	Path     string
+	Link     string
+	Error    string
+	Packages []*packageResult
+}
+
+type packageResult struct {
+	Name    string
+	Status  string
+	Elapsed time.Duration
+	// Output is the output of the package that does not belong to a test.
+	Output   string
+	Panicked bool
+	Tests    []*testResult
+	Passed   int
+	Failed   int
+	Skipped  int
+	// Slowest is the slowest top-level test of the package.
+	Slowest *testResult
+}
+
+type testResult struct {
+	Name string
+	// Depth is the number of parents of a subtest.
+	Depth    int
+	Status   string
+	Elapsed  time.Duration
+	Output   string
+	Panicked bool
+}
+
+func parse(content []byte) []*packageResult {
+	var packages []*packageResult
+	byName := map[string]*packageResult{}
+	tests := map[string]*testResult{}
+	outputs := map[*testResult]*strings.Builder{}
+	packageOutputs := map[*packageResult]*strings.Builder{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		var e event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Action == "" {
+			// The output of `go test -json` may be mixed with other output,
+			// e.g. of the build.
+			continue
+		}
+		if e.Package == "" {
+			continue
+		}
+		p, ok := byName[e.Package]
+		if !ok {
+			p = &packageResult{Name: e.Package, Status: statusIncomplete}
+			byName[e.Package] = p
+			packages = append(packages, p)
+			packageOutputs[p] = &strings.Builder{}
+		}
+
+		if e.Test == "" {
+			switch e.Action {
+			case "output":
+				packageOutputs[p].WriteString(e.Output)
+				if strings.HasPrefix(e.Output, "panic:") {
+					p.Panicked = true
+				}
+			case statusPass, statusFail, statusSkip:
+				p.Status = e.Action
+				p.Elapsed = seconds(e.Elapsed)
+			}
+			continue
+		}
+
+		key := e.Package + " " + e.Test
+		t, ok := tests[key]
+		if !ok {
+			t = &testResult{Name: e.Test, Depth: strings.Count(e.Test, "/"), Status: statusIncomplete}
+			tests[key] = t
+			outputs[t] = &strings.Builder{}
+			p.Tests = append(p.Tests, t)
+		}
+		switch e.Action {
+		case "output":
+			outputs[t].WriteString(e.Output)
+			if strings.HasPrefix(strings.TrimSpace(e.Output), "panic:") {
+				t.Panicked = true
+				p.Panicked = true
+			}
+		case statusPass, statusFail, statusSkip:
+			t.Status = e.Action
+			t.Elapsed = seconds(e.Elapsed)
+		}
+	}
+
+	for _, p := range packages {
+		p.Output = packageOutputs[p].String()
+		for _, t := range p.Tests {
+			t.Output = outputs[t].String()
+			// Subtests are not counted, their parents fail with them.
+			if t.Depth > 0 {
+				continue
+			}
+			switch t.Status {
+			case statusPass:
+				p.Passed++
+			case statusSkip:
+				p.Skipped++
+			default:
+				p.Failed++
+			}
+			if t.Status != statusSkip && (p.Slowest == nil || t.Elapsed > p.Slowest.Elapsed) {
+				p.Slowest = t
+			}
+		}
+	}
+	sort.SliceStable(packages, func(i, j int) bool {
+		return failing(packages[i].Status) && !failing(packages[j].Status)
+	})
+	return packages
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Millisecond)
+}
+
+func failing(status string) bool {
+	return status == statusFail || status == statusIncomplete
+}
+
+// outputLine is a line of output, which is highlighted if it is the start of
+// a panic or a failure.
+type outputLine struct {
+	Text      string
+	Highlight bool
+}
+
+func outputLines(output string) []outputLine {
+	var lines []outputLine
+	for _, line := range strings.SplitAfter(output, "\n") {
+		if line == "" {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		lines = append(lines, outputLine{
+			Text:      line,
+			Highlight: strings.HasPrefix(trimmed, "panic:") || strings.HasPrefix(trimmed, "--- FAIL") || strings.HasPrefix(trimmed, "FAIL"),
+		})
+	}
+	return lines
+}
+
+func loadTemplate(path string) (*template.Template, error) {
+	return template.New("template.html").Funcs(template.FuncMap{
+		"failing":     failing,
+		"outputLines": outputLines,
+		"indent": func(depth int) string {
+			return fmt.Sprintf("%dpx", 20*depth)
+		},
+	}).ParseFiles(path)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gotest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/fake"
+)
+
+const testOutput = `go: downloading example.com/dep v1.0.0
+{"Action":"start","Package":"example.com/good"}
+{"Action":"run","Package":"example.com/good","Test":"TestFast"}
+{"Action":"output","Package":"example.com/good","Test":"TestFast","Output":"=== RUN   TestFast\n"}
+{"Action":"output","Package":"example.com/good","Test":"TestFast","Output":"--- PASS: TestFast (0.01s)\n"}
+{"Action":"pass","Package":"example.com/good","Test":"TestFast","Elapsed":0.01}
+{"Action":"run","Package":"example.com/good","Test":"TestSlow"}
+{"Action":"pass","Package":"example.com/good","Test":"TestSlow","Elapsed":2.5}
+{"Action":"run","Package":"example.com/good","Test":"TestSkipped"}
+{"Action":"skip","Package":"example.com/good","Test":"TestSkipped","Elapsed":0}
+{"Action":"output","Package":"example.com/good","Output":"ok  \texample.com/good\t2.6s\n"}
+{"Action":"pass","Package":"example.com/good","Elapsed":2.6}
+{"Action":"start","Package":"example.com/bad"}
+{"Action":"run","Package":"example.com/bad","Test":"TestTable"}
+{"Action":"run","Package":"example.com/bad","Test":"TestTable/case_1"}
+{"Action":"output","Package":"example.com/bad","Test":"TestTable/case_1","Output":"    table_test.go:12: expected 1, got 2\n"}
+{"Action":"output","Package":"example.com/bad","Test":"TestTable/case_1","Output":"    --- FAIL: TestTable/case_1 (0.00s)\n"}
+{"Action":"fail","Package":"example.com/bad","Test":"TestTable/case_1","Elapsed":0}
+{"Action":"fail","Package":"example.com/bad","Test":"TestTable","Elapsed":0.1}
+{"Action":"run","Package":"example.com/bad","Test":"TestCrash"}
+{"Action":"output","Package":"example.com/bad","Test":"TestCrash","Output":"panic: runtime error: invalid memory address or nil pointer dereference\n"}
+{"Action":"output","Package":"example.com/bad","Output":"FAIL\texample.com/bad\t0.2s\n"}
+{"Action":"fail","Package":"example.com/bad","Elapsed":0.2}
+`
+
+func TestParse(t *testing.T) {
+	fast := &testResult{Name: "TestFast", Status: statusPass, Elapsed: 10 * time.Millisecond, Output: "=== RUN   TestFast\n--- PASS: TestFast (0.01s)\n"}
+	slow := &testResult{Name: "TestSlow", Status: statusPass, Elapsed: 2500 * time.Millisecond}
+	table := &testResult{Name: "TestTable", Status: statusFail, Elapsed: 100 * time.Millisecond}
+	crash := &testResult{Name: "TestCrash", Status: statusIncomplete, Panicked: true, Output: "panic: runtime error: invalid memory address or nil pointer dereference\n"}
+	expected := []*packageResult{
+		{
+			Name:     "example.com/bad",
+			Status:   statusFail,
+			Elapsed:  200 * time.Millisecond,
+			Output:   "FAIL\texample.com/bad\t0.2s\n",
+			Panicked: true,
+			Tests: []*testResult{
+				table,
+				{Name: "TestTable/case_1", Depth: 1, Status: statusFail, Output: "    table_test.go:12: expected 1, got 2\n    --- FAIL: TestTable/case_1 (0.00s)\n"},
+				crash,
+			},
+			Failed:  2,
+			Slowest: table,
+		},
+		{
+			Name:    "example.com/good",
+			Status:  statusPass,
+			Elapsed: 2600 * time.Millisecond,
+			Output:  "ok  \texample.com/good\t2.6s\n",
+			Tests: []*testResult{
+				fast,
+				slow,
+				{Name: "TestSkipped", Status: statusSkip},
+			},
+			Passed:  2,
+			Skipped: 1,
+			Slowest: slow,
+		},
+	}
+	if diff := cmp.Diff(expected, parse([]byte(testOutput))); diff != "" {
+		t.Errorf("parsed results differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestOutputLines(t *testing.T) {
+	expected := []outputLine{
+		{Text: "=== RUN   TestFoo\n"},
+		{Text: "    --- FAIL: TestFoo/bar (0.00s)\n", Highlight: true},
+		{Text: "panic: boom\n", Highlight: true},
+		{Text: "goroutine 1 [running]:"},
+	}
+	if diff := cmp.Diff(expected, outputLines("=== RUN   TestFoo\n    --- FAIL: TestFoo/bar (0.00s)\npanic: boom\ngoroutine 1 [running]:")); diff != "" {
+		t.Errorf("output lines differ from expected (-want +got):\n%s", diff)
+	}
+}
+
+func TestBody(t *testing.T) {
+	artifacts := []api.Artifact{&fake.Artifact{Path: "artifacts/go-test.json", Content: []byte(testOutput)}}
+	body := Lens{}.Body(artifacts, ".", "", nil, config.Spyglass{})
+	for _, expected := range []string{"example.com/bad", "TestTable/case_1", "expected 1, got 2", `<span class="panic">panic</span>`, "slowest TestSlow (2.5s)"} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected body to contain %q, got:\n%s", expected, body)
+		}
+	}
+}
@@ -92,6 +92,7 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 		Errored      bool
 		Elapsed      time.Duration
 		Hint         string
+		Failure      *prowv1.Failure
 		Metadata     map[string]interface{}
 	}
 	metadataViewData := MetadataViewData{}
@@ -129,6 +130,8 @@ func (lens Lens) Body(artifacts []api.Artifact, resourceDir string, data string,
 			} else {
 				logrus.Debug("Empty finished.json")
 			}
+		case prowv1.FailureFile:
+			metadataViewData.Failure = failureFromFile(read)
 		case "podinfo.json":
 			metadataViewData.Hint = hintFromPodInfo(read)
 		case prowv1.ProwJobFile:
@@ -265,6 +268,20 @@ func hintFromPodInfo(buf []byte) string {
 	return strings.Join(msgs, "\n")
 }
 
+// failureFromFile parses the failure the pod utilities classified.
+func failureFromFile(buf []byte) *prowv1.Failure {
+	var failure prowv1.Failure
+	if err := json.Unmarshal(buf, &failure); err != nil {
+		logrus.WithError(err).Infof("Failed to decode %s", prowv1.FailureFile)
+		return nil
+	}
+	if err := failure.Validate(); err != nil {
+		logrus.WithError(err).Infof("Ignoring invalid %s", prowv1.FailureFile)
+		return nil
+	}
+	return &failure
+}
+
 func hintFromProwJob(buf []byte) (string, bool) {
 	var pj prowv1.ProwJob
 	if err := json.Unmarshal(buf, &pj); err != nil {
@@ -679,3 +679,33 @@ func TestHintFromProwJob(t *testing.T) {
 		})
 	}
 }
+
+func TestFailureFromFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *prowv1.Failure
+	}{
+		{
+			name:     "classified failure",
+			content:  `{"category":"infra","message":"cluster setup failed","owner":"sig-testing"}`,
+			expected: &prowv1.Failure{Category: prowv1.FailureCategoryInfra, Message: "cluster setup failed", Owner: "sig-testing"},
+		},
+		{
+			name:    "unknown category",
+			content: `{"category":"flake"}`,
+		},
+		{
+			name:    "invalid file",
+			content: `not json`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, failureFromFile([]byte(tc.content))); diff != "" {
+				t.Errorf("unexpected failure (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
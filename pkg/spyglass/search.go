@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+)
+
+// SearchLimits bound the work of a search over the artifacts of a build.
+type SearchLimits struct {
+	// ArtifactBytes is the size of the largest artifact that is searched.
+	ArtifactBytes int64
+	// TotalBytes is the number of bytes after which the search stops.
+	TotalBytes int64
+	// Matches is the number of matches after which the search stops.
+	Matches int
+	// LineLength is the length at which matching lines are truncated.
+	LineLength int
+}
+
+// DefaultSearchLimits are the limits of searches from Deck.
+var DefaultSearchLimits = SearchLimits{
+	ArtifactBytes: 50 * 1024 * 1024,
+	TotalBytes:    200 * 1024 * 1024,
+	Matches:       1000,
+	LineLength:    500,
+}
+
+// SearchMatch is a line of an artifact that contains the searched text.
+type SearchMatch struct {
+	// Artifact is the path of the artifact within the build.
+	Artifact string `json:"artifact"`
+	// Line is the 1-based number of the matching line.
+	Line int    `json:"line"`
+	Text string `json:"text"`
+	// Link is the canonical link to the artifact in storage.
+	Link string `json:"link"`
+}
+
+// SearchResult holds the matches of a search over the artifacts of a build.
+type SearchResult struct {
+	Query   string        `json:"query"`
+	Matches []SearchMatch `json:"matches"`
+	// Truncated is set when the search stopped at a limit before searching
+	// every artifact.
+	Truncated bool `json:"truncated,omitempty"`
+	// Skipped are the artifacts that were not searched because they are
+	// too large or not text.
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// SearchArtifacts searches the text artifacts of the build at src for the
+// lines containing query, ignoring case.
+func (s *Spyglass) SearchArtifacts(ctx context.Context, src, query string, limits SearchLimits) (*SearchResult, error) {
+	names, err := s.ListArtifacts(ctx, src)
+	if err != nil {
+		return nil, fmt.Errorf("error listing artifacts: %w", err)
+	}
+	artifacts, err := s.FetchArtifacts(ctx, src, "", limits.ArtifactBytes, names)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching artifacts: %w", err)
+	}
+	return searchArtifacts(ctx, artifacts, query, limits), nil
+}
+
+func searchArtifacts(ctx context.Context, artifacts []api.Artifact, query string, limits SearchLimits) *SearchResult {
+	result := &SearchResult{Query: query, Matches: []SearchMatch{}}
+	needle := []byte(strings.ToLower(query))
+	var searched int64
+	for _, artifact := range artifacts {
+		if ctx.Err() != nil || len(result.Matches) >= limits.Matches {
+			result.Truncated = true
+			break
+		}
+		size, err := artifact.Size()
+		if err != nil || size > limits.ArtifactBytes {
+			result.Skipped = append(result.Skipped, artifact.JobPath())
+			continue
+		}
+		if searched+size > limits.TotalBytes {
+			result.Truncated = true
+			break
+		}
+		content, err := artifact.ReadAll()
+		if err != nil || !isText(content) {
+			result.Skipped = append(result.Skipped, artifact.JobPath())
+			continue
+		}
+		searched += int64(len(content))
+
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		scanner.Buffer(nil, len(content)+1)
+		for line := 1; scanner.Scan(); line++ {
+			text := scanner.Bytes()
+			if !bytes.Contains(bytes.ToLower(text), needle) {
+				continue
+			}
+			if len(text) > limits.LineLength {
+				text = text[:limits.LineLength]
+			}
+			result.Matches = append(result.Matches, SearchMatch{
+				Artifact: artifact.JobPath(),
+				Line:     line,
+				Text:     string(text),
+				Link:     artifact.CanonicalLink(),
+			})
+			if len(result.Matches) >= limits.Matches {
+				result.Truncated = true
+				break
+			}
+		}
+	}
+	return result
+}
+
+// isText guesses whether content is text the same way git does, by looking
+// for a NUL byte at its start.
+func isText(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) == -1
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spyglass
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/prow/pkg/spyglass/api"
+	"sigs.k8s.io/prow/pkg/spyglass/lenses/fake"
+)
+
+func TestSearchArtifacts(t *testing.T) {
+	link := "https://storage.example.com/build-log.txt"
+	buildLog := &fake.Artifact{
+		Path:    "build-log.txt",
+		Content: []byte("Running tests\nFAIL: TestFoo\nconnection refused\nok\nError: Connection Refused by peer\n"),
+		Link:    &link,
+	}
+	junit := &fake.Artifact{
+		Path:    "artifacts/junit.xml",
+		Content: []byte("<testsuite>\n<failure>dial tcp: connection refused</failure>\n</testsuite>\n"),
+	}
+	binary := &fake.Artifact{
+		Path:    "artifacts/core.dump",
+		Content: []byte("connection refused\x00\x01\x02"),
+	}
+	large := &fake.Artifact{
+		Path:    "artifacts/huge.log",
+		Content: []byte(strings.Repeat("connection refused\n", 10)),
+	}
+	limits := SearchLimits{ArtifactBytes: 150, TotalBytes: 1000, Matches: 10, LineLength: 20}
+
+	testCases := []struct {
+		name      string
+		artifacts []api.Artifact
+		query     string
+		limits    SearchLimits
+		expected  *SearchResult
+	}{
+		{
+			name:      "matches lines ignoring case",
+			artifacts: []api.Artifact{buildLog, junit},
+			query:     "connection refused",
+			limits:    limits,
+			expected: &SearchResult{
+				Query: "connection refused",
+				Matches: []SearchMatch{
+					{Artifact: "build-log.txt", Line: 3, Text: "connection refused", Link: link},
+					{Artifact: "build-log.txt", Line: 5, Text: "Error: Connection Re", Link: link},
+					{Artifact: "artifacts/junit.xml", Line: 2, Text: "<failure>dial tcp: c", Link: fake.NotFound},
+				},
+			},
+		},
+		{
+			name:      "no matches",
+			artifacts: []api.Artifact{buildLog},
+			query:     "panic:",
+			limits:    limits,
+			expected:  &SearchResult{Query: "panic:", Matches: []SearchMatch{}},
+		},
+		{
+			name:      "binary and large artifacts are skipped",
+			artifacts: []api.Artifact{binary, large, junit},
+			query:     "connection refused",
+			limits:    limits,
+			expected: &SearchResult{
+				Query: "connection refused",
+				Matches: []SearchMatch{
+					{Artifact: "artifacts/junit.xml", Line: 2, Text: "<failure>dial tcp: c", Link: fake.NotFound},
+				},
+				Skipped: []string{"artifacts/core.dump", "artifacts/huge.log"},
+			},
+		},
+		{
+			name:      "stops at the match limit",
+			artifacts: []api.Artifact{buildLog, junit},
+			query:     "connection refused",
+			limits:    SearchLimits{ArtifactBytes: 150, TotalBytes: 1000, Matches: 1, LineLength: 20},
+			expected: &SearchResult{
+				Query: "connection refused",
+				Matches: []SearchMatch{
+					{Artifact: "build-log.txt", Line: 3, Text: "connection refused", Link: link},
+				},
+				Truncated: true,
+			},
+		},
+		{
+			name:      "stops at the total size limit",
+			artifacts: []api.Artifact{buildLog, junit},
+			query:     "connection refused",
+			limits:    SearchLimits{ArtifactBytes: 150, TotalBytes: 100, Matches: 10, LineLength: 20},
+			expected: &SearchResult{
+				Query: "connection refused",
+				Matches: []SearchMatch{
+					{Artifact: "build-log.txt", Line: 3, Text: "connection refused", Link: link},
+					{Artifact: "build-log.txt", Line: 5, Text: "Error: Connection Re", Link: link},
+				},
+				Truncated: true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := searchArtifacts(context.Background(), tc.artifacts, tc.query, tc.limits)
+			if diff := cmp.Diff(tc.expected, result); diff != "" {
+				t.Errorf("search result differs from expected (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
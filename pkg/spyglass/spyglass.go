@@ -63,6 +63,7 @@ type Spyglass struct {
 
 	*StorageArtifactFetcher
 	*PodLogArtifactFetcher
+	*ExternalArtifactFetcher
 }
 
 // LensRequest holds data sent by a view
@@ -82,10 +83,11 @@ type ExtraLink struct {
 // New constructs a Spyglass object from a JobAgent, a config.Agent, and a storage Client.
 func New(ctx context.Context, ja *jobs.JobAgent, cfg config.Getter, opener pkgio.Opener, useCookieAuth bool) *Spyglass {
 	return &Spyglass{
-		JobAgent:               ja,
-		config:                 cfg,
-		PodLogArtifactFetcher:  NewPodLogArtifactFetcher(ja),
-		StorageArtifactFetcher: NewStorageArtifactFetcher(opener, cfg, useCookieAuth),
+		JobAgent:                ja,
+		config:                  cfg,
+		PodLogArtifactFetcher:   NewPodLogArtifactFetcher(ja),
+		StorageArtifactFetcher:  NewStorageArtifactFetcher(opener, cfg, useCookieAuth),
+		ExternalArtifactFetcher: NewExternalArtifactFetcher(ja, cfg),
 		testgrid: &TestGrid{
 			conf:   cfg,
 			opener: opener,
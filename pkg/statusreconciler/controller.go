@@ -37,8 +37,10 @@ import (
 	"sigs.k8s.io/prow/pkg/statusreconciler/migrator"
 )
 
-// NewController constructs a new controller to reconcile stauses on config change
-func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitDenylistAll sets.Set[string], opener io.Opener, configOpts configflagutil.ConfigOptions, statusURI string, prowJobClient prowv1.ProwJobInterface, githubClient github.Client, pluginAgent *plugins.ConfigAgent) *Controller {
+// NewController constructs a new controller to reconcile stauses on config change.
+// When planURI is set, the planned changes are written there for every config
+// change, and with planOnly they are not executed.
+func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitDenylistAll sets.Set[string], opener io.Opener, configOpts configflagutil.ConfigOptions, statusURI, planURI string, planOnly bool, prowJobClient prowv1.ProwJobInterface, githubClient github.Client, pluginAgent *plugins.ConfigAgent) *Controller {
 	sc := &statusController{
 		logger:     logrus.WithField("client", "statusController"),
 		opener:     opener,
@@ -66,6 +68,9 @@ func NewController(continueOnError bool, addedPresubmitDenylist, addedPresubmitD
 			pluginAgent:  pluginAgent,
 		},
 		statusClient: sc,
+		opener:       opener,
+		planURI:      planURI,
+		planOnly:     planOnly,
 	}
 }
 
@@ -154,6 +159,10 @@ type Controller struct {
 	statusMigrator            statusMigrator
 	trustedChecker            trustedChecker
 	statusClient              statusClient
+
+	opener   io.Opener
+	planURI  string
+	planOnly bool
 }
 
 // Run monitors the incoming configuration changes to determine when statuses need to be
@@ -165,11 +174,29 @@ func (c *Controller) Run(ctx context.Context) {
 		return
 	}
 
+	// The state is not saved when only planning, so plans cover all changes
+	// since the saved state, which are what is reconciled once planning stops.
+	var plannedSince *config.Config
 	for {
 		select {
 		case change := <-changes:
+			if c.planOnly {
+				if plannedSince == nil {
+					plannedSince = &change.Before
+				}
+				change.Before = *plannedSince
+			}
 			start := time.Now()
 			log := logrus.WithField("old_config_revision", change.Before.ConfigVersionSHA).WithField("config_revision", change.After.ConfigVersionSHA)
+			if c.planURI != "" {
+				if err := c.writePlans(ctx, change, log); err != nil {
+					log.WithError(err).Error("Error writing status reconciliation plans.")
+				}
+			}
+			if c.planOnly {
+				log.Info("Only planning, not reconciling statuses.")
+				continue
+			}
 			if err := c.reconcile(change, log); err != nil {
 				log.WithError(err).Error("Error reconciling statuses.")
 			}
@@ -182,23 +209,42 @@ func (c *Controller) Run(ctx context.Context) {
 	}
 }
 
+// contextChanges are the changes to presubmit contexts between two config
+// revisions, keyed by org/repo.
+type contextChanges struct {
+	added    map[string][]config.Presubmit
+	removed  map[string][]config.Presubmit
+	migrated map[string][]presubmitMigration
+}
+
+func changedContexts(delta config.Delta, log *logrus.Entry) contextChanges {
+	var changes contextChanges
+	changes.added, _ = addedBlockingPresubmits(delta.Before.PresubmitsStatic, delta.After.PresubmitsStatic, log)
+	changes.removed, _ = removedPresubmits(delta.Before.PresubmitsStatic, delta.After.PresubmitsStatic, log)
+	changes.migrated, _ = migratedBlockingPresubmits(delta.Before.PresubmitsStatic, delta.After.PresubmitsStatic, log)
+	applyContextMigrations(delta.After.StatusReconciler, delta.After.PresubmitsStatic, &changes, log)
+	return changes
+}
+
 func (c *Controller) reconcile(delta config.Delta, log *logrus.Entry) error {
+	changes := changedContexts(delta, log)
+
 	var errors []error
-	if err := c.triggerNewPresubmits(addedBlockingPresubmits(delta.Before.PresubmitsStatic, delta.After.PresubmitsStatic, log)); err != nil {
+	if err := c.triggerNewPresubmits(changes.added, log); err != nil {
 		errors = append(errors, err)
 		if !c.continueOnError {
 			return utilerrors.NewAggregate(errors)
 		}
 	}
 
-	if err := c.retireRemovedContexts(removedPresubmits(delta.Before.PresubmitsStatic, delta.After.PresubmitsStatic, log)); err != nil {
+	if err := c.retireRemovedContexts(changes.removed, log); err != nil {
 		errors = append(errors, err)
 		if !c.continueOnError {
 			return utilerrors.NewAggregate(errors)
 		}
 	}
 
-	if err := c.updateMigratedContexts(migratedBlockingPresubmits(delta.Before.PresubmitsStatic, delta.After.PresubmitsStatic, log)); err != nil {
+	if err := c.updateMigratedContexts(changes.migrated, log); err != nil {
 		errors = append(errors, err)
 		if !c.continueOnError {
 			return utilerrors.NewAggregate(errors)
@@ -471,3 +517,61 @@ func migratedBlockingPresubmits(old, new map[string][]config.Presubmit, log *log
 	log.Infof("Identified %d migrated blocking presubmits.", numMigrated)
 	return migrated, log
 }
+
+// applyContextMigrations turns removed presubmits whose context migrates to
+// the context of a presubmit in the new config into migrations, so that the
+// statuses are moved instead of retired and retriggered.
+func applyContextMigrations(cfg *config.StatusReconciler, new map[string][]config.Presubmit, changes *contextChanges, log *logrus.Entry) {
+	if cfg == nil || len(cfg.ContextMigrations) == 0 {
+		return
+	}
+	var numMigrated int
+	for orgrepo, removed := range changes.removed {
+		org, repo, ok := strings.Cut(orgrepo, "/")
+		if !ok {
+			continue
+		}
+		var remaining []config.Presubmit
+		for _, oldPresubmit := range removed {
+			to, ok := cfg.MigrateContext(org, repo, oldPresubmit.Context)
+			if !ok {
+				remaining = append(remaining, oldPresubmit)
+				continue
+			}
+			var newPresubmit *config.Presubmit
+			for i := range new[orgrepo] {
+				if new[orgrepo][i].Context == to {
+					newPresubmit = &new[orgrepo][i]
+					break
+				}
+			}
+			if newPresubmit == nil {
+				log.WithFields(logrus.Fields{
+					"repo": orgrepo,
+					"from": oldPresubmit.Context,
+					"to":   to,
+				}).Warn("No presubmit has the context the removed presubmit migrates to, retiring it instead.")
+				remaining = append(remaining, oldPresubmit)
+				continue
+			}
+			changes.migrated[orgrepo] = append(changes.migrated[orgrepo], presubmitMigration{from: oldPresubmit, to: *newPresubmit})
+			var added []config.Presubmit
+			for _, presubmit := range changes.added[orgrepo] {
+				if presubmit.Name != newPresubmit.Name {
+					added = append(added, presubmit)
+				}
+			}
+			if _, ok := changes.added[orgrepo]; ok {
+				changes.added[orgrepo] = append([]config.Presubmit{}, added...)
+			}
+			numMigrated++
+			log.WithFields(logrus.Fields{
+				"repo": orgrepo,
+				"from": oldPresubmit.Context,
+				"to":   to,
+			}).Debug("Identified a presubmit migrated by a context migration.")
+		}
+		changes.removed[orgrepo] = append([]config.Presubmit{}, remaining...)
+	}
+	log.Infof("Identified %d presubmits migrated by context migrations.", numMigrated)
+}
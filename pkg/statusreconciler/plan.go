@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusreconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+// RepoPlan lists the status changes that status-reconciler makes on the open
+// pull requests of a repo for a config change.
+type RepoPlan struct {
+	Org  string `json:"org"`
+	Repo string `json:"repo"`
+	// Trigger are the added blocking presubmits, which are triggered on the
+	// open pull requests they run for.
+	Trigger []PlannedContext `json:"trigger,omitempty"`
+	// Retire are the contexts of removed presubmits, which are retired.
+	Retire []PlannedContext `json:"retire,omitempty"`
+	// Migrate are the contexts that are moved to a new context.
+	Migrate []PlannedMigration `json:"migrate,omitempty"`
+}
+
+// PlannedContext is the context of a presubmit.
+type PlannedContext struct {
+	Job     string `json:"job"`
+	Context string `json:"context"`
+}
+
+// PlannedMigration moves the statuses of one context to another.
+type PlannedMigration struct {
+	Job  string `json:"job"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// PlanIndex summarizes the plans written for a config change. Plans of repos
+// that are not listed are stale.
+type PlanIndex struct {
+	OldConfigRevision string `json:"old_config_revision,omitempty"`
+	ConfigRevision    string `json:"config_revision,omitempty"`
+	// Repos lists the org/repos with planned changes.
+	Repos []string `json:"repos"`
+}
+
+// plans computes the per repo plans for the config change, honoring the
+// deny lists the same way reconciling does.
+func (c *Controller) plans(delta config.Delta, log *logrus.Entry) []RepoPlan {
+	changes := changedContexts(delta, log)
+	byRepo := map[string]*RepoPlan{}
+	plan := func(orgrepo string) *RepoPlan {
+		if p, ok := byRepo[orgrepo]; ok {
+			return p
+		}
+		org, repo, _ := strings.Cut(orgrepo, "/")
+		byRepo[orgrepo] = &RepoPlan{Org: org, Repo: repo}
+		return byRepo[orgrepo]
+	}
+	denied := func(denylist sets.Set[string], orgrepo string) bool {
+		org, _, _ := strings.Cut(orgrepo, "/")
+		return denylist.Has(org) || denylist.Has(orgrepo)
+	}
+
+	for orgrepo, presubmits := range changes.added {
+		if denied(c.addedPresubmitDenylist, orgrepo) || denied(c.addedPresubmitDenylistAll, orgrepo) {
+			continue
+		}
+		for _, presubmit := range presubmits {
+			p := plan(orgrepo)
+			p.Trigger = append(p.Trigger, PlannedContext{Job: presubmit.Name, Context: presubmit.Context})
+		}
+	}
+	for orgrepo, presubmits := range changes.removed {
+		if denied(c.addedPresubmitDenylistAll, orgrepo) {
+			continue
+		}
+		for _, presubmit := range presubmits {
+			p := plan(orgrepo)
+			p.Retire = append(p.Retire, PlannedContext{Job: presubmit.Name, Context: presubmit.Context})
+		}
+	}
+	for orgrepo, migrations := range changes.migrated {
+		if denied(c.addedPresubmitDenylistAll, orgrepo) {
+			continue
+		}
+		for _, migration := range migrations {
+			p := plan(orgrepo)
+			p.Migrate = append(p.Migrate, PlannedMigration{Job: migration.to.Name, From: migration.from.Context, To: migration.to.Context})
+		}
+	}
+
+	plans := make([]RepoPlan, 0, len(byRepo))
+	for _, p := range byRepo {
+		plans = append(plans, *p)
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		if plans[i].Org != plans[j].Org {
+			return plans[i].Org < plans[j].Org
+		}
+		return plans[i].Repo < plans[j].Repo
+	})
+	return plans
+}
+
+// writePlans writes the plan of every repo with changes to
+// <plan-path>/<org>/<repo>.json and an index of them to <plan-path>/index.json.
+func (c *Controller) writePlans(ctx context.Context, delta config.Delta, log *logrus.Entry) error {
+	plans := c.plans(delta, log)
+	index := PlanIndex{
+		OldConfigRevision: delta.Before.ConfigVersionSHA,
+		ConfigRevision:    delta.After.ConfigVersionSHA,
+		Repos:             []string{},
+	}
+	base := strings.TrimSuffix(c.planURI, "/")
+	var errs []error
+	for _, plan := range plans {
+		index.Repos = append(index.Repos, plan.Org+"/"+plan.Repo)
+		if err := c.writeJSON(ctx, fmt.Sprintf("%s/%s/%s.json", base, plan.Org, plan.Repo), plan, log); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// The index is written last, so that it only lists complete plans.
+	if err := c.writeJSON(ctx, base+"/index.json", index, log); err != nil {
+		errs = append(errs, err)
+	}
+	log.WithField("path", c.planURI).Infof("Wrote status reconciliation plans for %d repos.", len(plans))
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *Controller) writeJSON(ctx context.Context, path string, content interface{}, log *logrus.Entry) error {
+	raw, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", path, err)
+	}
+	contentType := "application/json"
+	if err := io.WriteContent(ctx, log, c.opener, path, raw, io.WriterOptions{ContentType: &contentType}); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
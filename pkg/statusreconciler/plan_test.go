@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusreconciler
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config"
+	"sigs.k8s.io/prow/pkg/io"
+)
+
+func TestWritePlans(t *testing.T) {
+	var oldPresubmits, newPresubmits map[string][]config.Presubmit
+	if err := yaml.Unmarshal([]byte(`"org/repo":
+- name: pull-kubernetes-unit
+  context: pull-kubernetes-unit
+  always_run: true
+- name: pull-kubernetes-e2e
+  context: pull-kubernetes-e2e
+  always_run: true
+- name: lint
+  context: lint
+  always_run: true
+"org/denied":
+- name: pull-kubernetes-unit
+  context: pull-kubernetes-unit
+  always_run: true`), &oldPresubmits); err != nil {
+		t.Fatalf("could not unmarshal old presubmits: %v", err)
+	}
+	if err := yaml.Unmarshal([]byte(`"org/repo":
+- name: pull-k8s-unit
+  context: pull-k8s-unit
+  always_run: true
+- name: lint
+  context: lint
+  always_run: true
+- name: verify
+  context: verify
+  always_run: true
+"org/denied":
+- name: pull-k8s-unit
+  context: pull-k8s-unit
+  always_run: true`), &newPresubmits); err != nil {
+		t.Fatalf("could not unmarshal new presubmits: %v", err)
+	}
+	before := config.Config{JobConfig: config.JobConfig{PresubmitsStatic: oldPresubmits}, ProwConfig: config.ProwConfig{ConfigVersionSHA: "old"}}
+	after := config.Config{JobConfig: config.JobConfig{PresubmitsStatic: newPresubmits}, ProwConfig: config.ProwConfig{
+		ConfigVersionSHA: "new",
+		StatusReconciler: &config.StatusReconciler{ContextMigrations: []config.ContextMigration{{From: "pull-kubernetes-*", To: "pull-k8s-*"}}},
+	}}
+
+	ctx := context.Background()
+	opener, err := io.NewOpener(ctx, "", "")
+	if err != nil {
+		t.Fatalf("could not create opener: %v", err)
+	}
+	dir := t.TempDir()
+	c := Controller{
+		addedPresubmitDenylistAll: sets.New[string]("org/denied"),
+		opener:                    opener,
+		planURI:                   dir,
+	}
+	if err := c.writePlans(ctx, config.Delta{Before: before, After: after}, logrusEntry()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var index PlanIndex
+	readJSON(t, filepath.Join(dir, "index.json"), &index)
+	if diff := cmp.Diff(PlanIndex{OldConfigRevision: "old", ConfigRevision: "new", Repos: []string{"org/repo"}}, index); diff != "" {
+		t.Errorf("unexpected index (-want +got):\n%s", diff)
+	}
+	var plan RepoPlan
+	readJSON(t, filepath.Join(dir, "org", "repo.json"), &plan)
+	expected := RepoPlan{
+		Org:     "org",
+		Repo:    "repo",
+		Trigger: []PlannedContext{{Job: "verify", Context: "verify"}},
+		Retire:  []PlannedContext{{Job: "pull-kubernetes-e2e", Context: "pull-kubernetes-e2e"}},
+		Migrate: []PlannedMigration{{Job: "pull-k8s-unit", From: "pull-kubernetes-unit", To: "pull-k8s-unit"}},
+	}
+	if diff := cmp.Diff(expected, plan); diff != "" {
+		t.Errorf("unexpected plan (-want +got):\n%s", diff)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "org", "denied.json")); !os.IsNotExist(err) {
+		t.Errorf("expected no plan for a denied repo, got %v", err)
+	}
+}
+
+func readJSON(t *testing.T, path string, into interface{}) {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(raw, into); err != nil {
+		t.Fatalf("could not unmarshal %s: %v", path, err)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2/google"
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/option"
+)
+
+// GoogleGroupsConfig configures a Google Workspace directory.
+type GoogleGroupsConfig struct {
+	// CredentialsFile is the path to the key of a service account with
+	// domain-wide delegation of the read only group member and user scopes.
+	CredentialsFile string `json:"credentials_file"`
+	// Subject is the directory admin the service account impersonates.
+	Subject string `json:"subject"`
+	// LoginField is the custom user schema field holding the GitHub login,
+	// written as <schema>.<field>, e.g. GitHub.login.
+	LoginField string `json:"login_field"`
+}
+
+type googleGroupsSource struct {
+	service *admin.Service
+	schema  string
+	field   string
+	logins  map[string]string
+}
+
+// NewGoogleGroupsSource creates a Source listing the members of Google
+// Groups, identified by their email. Nested groups are expanded.
+func NewGoogleGroupsSource(ctx context.Context, cfg GoogleGroupsConfig) (Source, error) {
+	key, err := os.ReadFile(cfg.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("read credentials: %w", err)
+	}
+	jwt, err := google.JWTConfigFromJSON(key, admin.AdminDirectoryGroupMemberReadonlyScope, admin.AdminDirectoryUserReadonlyScope)
+	if err != nil {
+		return nil, fmt.Errorf("parse credentials: %w", err)
+	}
+	jwt.Subject = cfg.Subject
+	service, err := admin.NewService(ctx, option.WithHTTPClient(jwt.Client(ctx)))
+	if err != nil {
+		return nil, fmt.Errorf("create directory client: %w", err)
+	}
+	schema, field, _ := strings.Cut(cfg.LoginField, ".")
+	return &googleGroupsSource{service: service, schema: schema, field: field, logins: map[string]string{}}, nil
+}
+
+func (s *googleGroupsSource) Members(ctx context.Context, group string) ([]string, error) {
+	var logins []string
+	err := s.service.Members.List(group).IncludeDerivedMembership(true).Pages(ctx, func(members *admin.Members) error {
+		for _, member := range members.Members {
+			if member.Type != "USER" || member.Status != "ACTIVE" {
+				continue
+			}
+			login, err := s.login(ctx, member.Id)
+			if err != nil {
+				return err
+			}
+			if login != "" {
+				logins = append(logins, login)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logins, nil
+}
+
+// login returns the GitHub login from the custom schema of the user, which is
+// empty if unset.
+func (s *googleGroupsSource) login(ctx context.Context, id string) (string, error) {
+	if login, ok := s.logins[id]; ok {
+		return login, nil
+	}
+	user, err := s.service.Users.Get(id).Projection("custom").CustomFieldMask(s.schema).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("get user %s: %w", id, err)
+	}
+	var login string
+	if raw, ok := user.CustomSchemas[s.schema]; ok {
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return "", fmt.Errorf("parse %s schema of user %s: %w", s.schema, id, err)
+		}
+		login, _ = fields[s.field].(string)
+	}
+	s.logins[id] = login
+	return login, nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultSCIMLoginAttribute = "userName"
+
+// SCIMConfig configures a SCIM 2.0 directory, which is what most identity
+// providers (and LDAP gateways) expose.
+type SCIMConfig struct {
+	// Endpoint is the base URL of the SCIM API, e.g.
+	// https://example.okta.com/scim/v2.
+	Endpoint string `json:"endpoint"`
+	// TokenPath is the path to a bearer token for the API.
+	TokenPath string `json:"token_path,omitempty"`
+	// LoginAttribute is the user attribute holding the GitHub login, either
+	// a core attribute such as userName (the default) or an extension
+	// attribute such as urn:example:params:scim:schemas:extension:GitHub:2.0:User:login.
+	LoginAttribute string `json:"login_attribute,omitempty"`
+}
+
+type scimSource struct {
+	client         *http.Client
+	endpoint       string
+	token          string
+	loginAttribute string
+	// logins caches the logins of users by their ID.
+	logins map[string]string
+}
+
+// NewSCIMSource creates a Source listing the members of SCIM groups, looking
+// groups up by their display name. Nested groups are expanded.
+func NewSCIMSource(cfg SCIMConfig) (Source, error) {
+	s := &scimSource{
+		client:         &http.Client{Timeout: time.Minute},
+		endpoint:       strings.TrimSuffix(cfg.Endpoint, "/"),
+		loginAttribute: cfg.LoginAttribute,
+		logins:         map[string]string{},
+	}
+	if s.loginAttribute == "" {
+		s.loginAttribute = defaultSCIMLoginAttribute
+	}
+	if cfg.TokenPath != "" {
+		token, err := os.ReadFile(cfg.TokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("read scim token: %w", err)
+		}
+		s.token = strings.TrimSpace(string(token))
+	}
+	return s, nil
+}
+
+type scimGroup struct {
+	ID      string `json:"id"`
+	Members []struct {
+		Value string `json:"value"`
+		Type  string `json:"type"`
+	} `json:"members"`
+}
+
+func (s *scimSource) Members(ctx context.Context, group string) ([]string, error) {
+	var groups struct {
+		Resources []scimGroup `json:"Resources"`
+	}
+	query := url.Values{"filter": []string{fmt.Sprintf("displayName eq %q", group)}}
+	if err := s.get(ctx, "/Groups?"+query.Encode(), &groups); err != nil {
+		return nil, err
+	}
+	if len(groups.Resources) != 1 {
+		return nil, fmt.Errorf("found %d groups named %q", len(groups.Resources), group)
+	}
+
+	var logins []string
+	seen := map[string]bool{groups.Resources[0].ID: true}
+	queue := []scimGroup{groups.Resources[0]}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, member := range current.Members {
+			if seen[member.Value] {
+				continue
+			}
+			seen[member.Value] = true
+			if member.Type == "Group" {
+				var nested scimGroup
+				if err := s.get(ctx, "/Groups/"+url.PathEscape(member.Value), &nested); err != nil {
+					return nil, err
+				}
+				queue = append(queue, nested)
+				continue
+			}
+			login, err := s.login(ctx, member.Value)
+			if err != nil {
+				return nil, err
+			}
+			if login != "" {
+				logins = append(logins, login)
+			}
+		}
+	}
+	return logins, nil
+}
+
+// login returns the GitHub login of the user, which is empty if the user has
+// none or is inactive.
+func (s *scimSource) login(ctx context.Context, id string) (string, error) {
+	if login, ok := s.logins[id]; ok {
+		return login, nil
+	}
+	var user map[string]interface{}
+	if err := s.get(ctx, "/Users/"+url.PathEscape(id), &user); err != nil {
+		return "", err
+	}
+	var login string
+	if active, ok := user["active"].(bool); !ok || active {
+		attributes := user
+		name := s.loginAttribute
+		if i := strings.LastIndex(name, ":"); i >= 0 {
+			attributes, _ = user[name[:i]].(map[string]interface{})
+			name = name[i+1:]
+		}
+		login, _ = attributes[name].(string)
+	}
+	s.logins[id] = login
+	return login, nil
+}
+
+func (s *scimSource) get(ctx context.Context, path string, into interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/scim+json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d: %s", path, resp.StatusCode, body)
+	}
+	return json.Unmarshal(body, into)
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package teamsync fills the members of peribolos teams from the groups of
+// an external identity provider, such as a SCIM directory or Google Groups.
+package teamsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/prow/pkg/config/org"
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// Config configures the identity providers teams are synced from.
+type Config struct {
+	// Sources maps the names referenced by the sync stanza of teams to
+	// identity providers.
+	Sources map[string]SourceConfig `json:"sources"`
+	// AddOrgMembers adds synced users that are neither admins nor members of
+	// the org to its members. Otherwise peribolos rejects the resulting
+	// config, as team members must be org members.
+	AddOrgMembers bool `json:"add_org_members,omitempty"`
+}
+
+// SourceConfig configures a single identity provider. Exactly one of the
+// fields must be set.
+type SourceConfig struct {
+	SCIM         *SCIMConfig         `json:"scim,omitempty"`
+	GoogleGroups *GoogleGroupsConfig `json:"google_groups,omitempty"`
+}
+
+// LoadConfig reads and validates the team sync config at path.
+func LoadConfig(path string) (*Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read team sync config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.UnmarshalStrict(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("parse team sync config: %w", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) validate() error {
+	var errs []error
+	for name, source := range c.Sources {
+		switch {
+		case source.SCIM != nil && source.GoogleGroups != nil:
+			errs = append(errs, fmt.Errorf("source %s: only one of scim and google_groups can be set", name))
+		case source.SCIM != nil:
+			if source.SCIM.Endpoint == "" {
+				errs = append(errs, fmt.Errorf("source %s: scim endpoint must be set", name))
+			}
+		case source.GoogleGroups != nil:
+			if source.GoogleGroups.LoginField == "" || !strings.Contains(source.GoogleGroups.LoginField, ".") {
+				errs = append(errs, fmt.Errorf("source %s: google_groups login_field must be set as <schema>.<field>", name))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("source %s: one of scim and google_groups must be set", name))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// Source lists the GitHub logins of the members of a group.
+type Source interface {
+	Members(ctx context.Context, group string) ([]string, error)
+}
+
+// NewSources creates a Source for every identity provider in the config.
+func NewSources(ctx context.Context, cfg Config) (map[string]Source, error) {
+	sources := make(map[string]Source, len(cfg.Sources))
+	for name, source := range cfg.Sources {
+		var err error
+		switch {
+		case source.SCIM != nil:
+			sources[name], err = NewSCIMSource(*source.SCIM)
+		case source.GoogleGroups != nil:
+			sources[name], err = NewGoogleGroupsSource(ctx, *source.GoogleGroups)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("source %s: %w", name, err)
+		}
+	}
+	return sources, nil
+}
+
+// Sync returns a copy of orgs in which the members and maintainers of every
+// team with a sync stanza are replaced by the members of its groups, along
+// with a unified diff of the changes.
+func Sync(ctx context.Context, cfg Config, sources map[string]Source, orgs org.FullConfig) (*org.FullConfig, string, error) {
+	before, err := yaml.Marshal(orgs)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshal org config: %w", err)
+	}
+	// Round trip the config to work on a deep copy.
+	var synced org.FullConfig
+	if err := yaml.Unmarshal(before, &synced); err != nil {
+		return nil, "", fmt.Errorf("copy org config: %w", err)
+	}
+
+	s := syncer{sources: sources, groups: map[string][]string{}}
+	var errs []error
+	for orgName, orgConfig := range synced.Orgs {
+		users := sets.New[string]()
+		for teamName, team := range orgConfig.Teams {
+			synced, err := s.syncTeam(ctx, team, users)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("org %s: team %s: %w", orgName, teamName, err))
+				continue
+			}
+			orgConfig.Teams[teamName] = synced
+		}
+		if cfg.AddOrgMembers {
+			orgConfig.Members = addMembers(orgConfig, users)
+		}
+		synced.Orgs[orgName] = orgConfig
+	}
+	if len(errs) > 0 {
+		return nil, "", utilerrors.NewAggregate(errs)
+	}
+
+	after, err := yaml.Marshal(synced)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshal synced org config: %w", err)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: "current",
+		ToFile:   "synced",
+		Context:  3,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("diff org config: %w", err)
+	}
+	return &synced, diff, nil
+}
+
+type syncer struct {
+	sources map[string]Source
+	// groups caches the members of groups by source and group name, as the
+	// same group is often used for several teams.
+	groups map[string][]string
+}
+
+func (s *syncer) members(ctx context.Context, source, group string) ([]string, error) {
+	key := source + "/" + group
+	if members, ok := s.groups[key]; ok {
+		return members, nil
+	}
+	src, ok := s.sources[source]
+	if !ok {
+		return nil, fmt.Errorf("unknown source %q", source)
+	}
+	members, err := src.Members(ctx, group)
+	if err != nil {
+		return nil, fmt.Errorf("list members of %s group %s: %w", source, group, err)
+	}
+	s.groups[key] = members
+	return members, nil
+}
+
+// logins returns the normalized logins of the members of a group of the team.
+func (s *syncer) logins(ctx context.Context, sync *org.TeamSync, group string) (sets.Set[string], error) {
+	members, err := s.members(ctx, sync.Source, group)
+	if err != nil {
+		return nil, err
+	}
+	logins := normalized(members)
+	if logins.Len() == 0 && !sync.AllowEmpty {
+		return nil, fmt.Errorf("%s group %s has no members with a GitHub login, set allow_empty if that is expected", sync.Source, group)
+	}
+	return logins, nil
+}
+
+// syncTeam syncs the team and its children, collecting all synced users.
+func (s *syncer) syncTeam(ctx context.Context, team org.Team, users sets.Set[string]) (org.Team, error) {
+	var errs []error
+	if team.Sync != nil {
+		if team.Sync.MembersGroup == "" && team.Sync.MaintainersGroup == "" {
+			errs = append(errs, errors.New("sync requires members_group or maintainers_group"))
+		}
+		maintainers := normalized(team.Maintainers)
+		if team.Sync.MaintainersGroup != "" {
+			logins, err := s.logins(ctx, team.Sync, team.Sync.MaintainersGroup)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				maintainers = logins
+				team.Maintainers = sets.List(maintainers)
+				users.Insert(team.Maintainers...)
+			}
+		}
+		if team.Sync.MembersGroup != "" {
+			logins, err := s.logins(ctx, team.Sync, team.Sync.MembersGroup)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				// Maintainers are not listed as members, as peribolos
+				// rejects users that are both.
+				team.Members = sets.List(logins.Difference(maintainers))
+				users.Insert(team.Members...)
+			}
+		}
+	}
+	for childName, child := range team.Children {
+		synced, err := s.syncTeam(ctx, child, users)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("team %s: %w", childName, err))
+			continue
+		}
+		team.Children[childName] = synced
+	}
+	return team, utilerrors.NewAggregate(errs)
+}
+
+// addMembers returns the members of the org extended with the users that are
+// neither admins nor members yet.
+func addMembers(orgConfig org.Config, users sets.Set[string]) []string {
+	existing := normalized(append(append([]string{}, orgConfig.Admins...), orgConfig.Members...))
+	missing := users.Difference(existing)
+	if missing.Len() == 0 {
+		return orgConfig.Members
+	}
+	members := append(append([]string{}, orgConfig.Members...), sets.List(missing)...)
+	sort.Strings(members)
+	return members
+}
+
+func normalized(logins []string) sets.Set[string] {
+	out := sets.New[string]()
+	for _, login := range logins {
+		if login = github.NormLogin(login); login != "" {
+			out.Insert(login)
+		}
+	}
+	return out
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teamsync
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/config/org"
+)
+
+type fakeSource map[string][]string
+
+func (f fakeSource) Members(_ context.Context, group string) ([]string, error) {
+	members, ok := f[group]
+	if !ok {
+		return nil, fmt.Errorf("group %s not found", group)
+	}
+	return members, nil
+}
+
+func TestSync(t *testing.T) {
+	const orgs = `
+orgs:
+  org:
+    admins:
+    - admin
+    members:
+    - alice
+    teams:
+      manual:
+        members:
+        - alice
+      synced:
+        maintainers:
+        - alice
+        members:
+        - gone
+        sync:
+          source: corp
+          members_group: eng
+        teams:
+          child:
+            sync:
+              source: corp
+              members_group: eng
+              maintainers_group: leads
+`
+	source := fakeSource{
+		"eng":   {"Alice", "bob", "carol", "admin"},
+		"leads": {"carol"},
+	}
+
+	cases := []struct {
+		name     string
+		cfg      Config
+		sources  map[string]Source
+		expected string
+		err      bool
+	}{
+		{
+			name:    "teams are synced",
+			sources: map[string]Source{"corp": source},
+			expected: `
+orgs:
+  org:
+    admins:
+    - admin
+    members:
+    - alice
+    teams:
+      manual:
+        members:
+        - alice
+      synced:
+        maintainers:
+        - alice
+        members:
+        - admin
+        - bob
+        - carol
+        sync:
+          source: corp
+          members_group: eng
+        teams:
+          child:
+            maintainers:
+            - carol
+            members:
+            - admin
+            - alice
+            - bob
+            sync:
+              source: corp
+              members_group: eng
+              maintainers_group: leads
+`,
+		},
+		{
+			name:    "missing users are added to the org",
+			cfg:     Config{AddOrgMembers: true},
+			sources: map[string]Source{"corp": source},
+			expected: `
+orgs:
+  org:
+    admins:
+    - admin
+    members:
+    - alice
+    - bob
+    - carol
+    teams:
+      manual:
+        members:
+        - alice
+      synced:
+        maintainers:
+        - alice
+        members:
+        - admin
+        - bob
+        - carol
+        sync:
+          source: corp
+          members_group: eng
+        teams:
+          child:
+            maintainers:
+            - carol
+            members:
+            - admin
+            - alice
+            - bob
+            sync:
+              source: corp
+              members_group: eng
+              maintainers_group: leads
+`,
+		},
+		{
+			name:    "unknown source is an error",
+			sources: map[string]Source{},
+			err:     true,
+		},
+		{
+			name:    "unknown group is an error",
+			sources: map[string]Source{"corp": fakeSource{"eng": {"bob"}}},
+			err:     true,
+		},
+		{
+			name:    "empty group is an error",
+			sources: map[string]Source{"corp": fakeSource{"eng": {"Alice", "bob"}, "leads": {}}},
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var current org.FullConfig
+			if err := yaml.Unmarshal([]byte(orgs), &current); err != nil {
+				t.Fatalf("failed to parse org config: %v", err)
+			}
+			synced, diff, err := Sync(context.Background(), tc.cfg, tc.sources, current)
+			if err != nil {
+				if !tc.err {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if tc.err {
+				t.Fatal("expected an error, got none")
+			}
+			var expected org.FullConfig
+			if err := yaml.Unmarshal([]byte(tc.expected), &expected); err != nil {
+				t.Fatalf("failed to parse expected org config: %v", err)
+			}
+			if d := cmp.Diff(&expected, synced); d != "" {
+				t.Errorf("synced config differs from expected (-want +got):\n%s", d)
+			}
+			if !strings.Contains(diff, "-        - gone\n") || !strings.Contains(diff, "+        - bob\n") {
+				t.Errorf("diff does not show the synced members:\n%s", diff)
+			}
+			if d := cmp.Diff([]string{"gone"}, current.Orgs["org"].Teams["synced"].Members); d != "" {
+				t.Errorf("Sync modified the current config (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestSyncAllowEmpty(t *testing.T) {
+	var current org.FullConfig
+	if err := yaml.Unmarshal([]byte(`
+orgs:
+  org:
+    teams:
+      synced:
+        members:
+        - gone
+        sync:
+          source: corp
+          members_group: eng
+          allow_empty: true
+`), &current); err != nil {
+		t.Fatalf("failed to parse org config: %v", err)
+	}
+	synced, _, err := Sync(context.Background(), Config{}, map[string]Source{"corp": fakeSource{"eng": {""}}}, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if members := synced.Orgs["org"].Teams["synced"].Members; len(members) != 0 {
+		t.Errorf("expected the team to be emptied, got members %v", members)
+	}
+}
+
+func TestSCIMSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected authorization header %q", got)
+		}
+		switch r.URL.Path {
+		case "/scim/v2/Groups":
+			if got := r.URL.Query().Get("filter"); got != `displayName eq "eng"` {
+				fmt.Fprint(w, `{"Resources":[]}`)
+				return
+			}
+			fmt.Fprint(w, `{"Resources":[{"id":"g1","members":[{"value":"u1","type":"User"},{"value":"g2","type":"Group"}]}]}`)
+		case "/scim/v2/Groups/g2":
+			fmt.Fprint(w, `{"id":"g2","members":[{"value":"u1","type":"User"},{"value":"u2","type":"User"},{"value":"u3","type":"User"},{"value":"u4","type":"User"}]}`)
+		case "/scim/v2/Users/u1":
+			fmt.Fprint(w, `{"id":"u1","userName":"alice@example.com","active":true,"urn:example:GitHub:User":{"login":"alice"}}`)
+		case "/scim/v2/Users/u2":
+			fmt.Fprint(w, `{"id":"u2","userName":"bob@example.com","urn:example:GitHub:User":{"login":"bob"}}`)
+		case "/scim/v2/Users/u3":
+			fmt.Fprint(w, `{"id":"u3","userName":"carol@example.com","active":false,"urn:example:GitHub:User":{"login":"carol"}}`)
+		case "/scim/v2/Users/u4":
+			fmt.Fprint(w, `{"id":"u4","userName":"dan@example.com"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	tokenPath := t.TempDir() + "/token"
+	if err := os.WriteFile(tokenPath, []byte("secret\n"), 0600); err != nil {
+		t.Fatalf("failed to write token: %v", err)
+	}
+	source, err := NewSCIMSource(SCIMConfig{
+		Endpoint:       ts.URL + "/scim/v2/",
+		TokenPath:      tokenPath,
+		LoginAttribute: "urn:example:GitHub:User:login",
+	})
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+	members, err := source.Members(context.Background(), "eng")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff([]string{"alice", "bob"}, members); d != "" {
+		t.Errorf("members differ from expected (-want +got):\n%s", d)
+	}
+
+	if _, err := source.Members(context.Background(), "missing"); err == nil {
+		t.Error("expected an error for a missing group, got none")
+	}
+}
@@ -419,7 +419,7 @@ func (gi *GitHubProvider) labelsAndAnnotations(instance string, jobLabels, jobAn
 }
 
 func (gi *GitHubProvider) jobIsRequiredByTide(ps *config.Presubmit, pr *CodeReviewCommon) bool {
-	return ps.ContextRequired() || ps.RunBeforeMerge
+	return ps.RequiredForMerge()
 }
 
 // dateToken generates a GitHub search query token for the specified date range.
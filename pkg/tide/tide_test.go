@@ -1009,6 +1009,14 @@ func TestPickBatchV2(t *testing.T) {
 	testPickBatch(localgit.NewV2, t)
 }
 
+func TestPickBatchWorktrees(t *testing.T) {
+	testPickBatch(localgit.NewV2WithWorktrees, t)
+}
+
+func TestPickBatchBlobless(t *testing.T) {
+	testPickBatch(localgit.NewV2Blobless, t)
+}
+
 func testPickBatch(clients localgit.Clients, t *testing.T) {
 	lg, gc, err := clients()
 	if err != nil {
@@ -1411,6 +1419,10 @@ func TestTakeActionV2(t *testing.T) {
 	testTakeAction(localgit.NewV2, t)
 }
 
+func TestTakeActionWorktrees(t *testing.T) {
+	testTakeAction(localgit.NewV2WithWorktrees, t)
+}
+
 func testTakeAction(clients localgit.Clients, t *testing.T) {
 	sleep = func(time.Duration) {}
 	defer func() { sleep = time.Sleep }()
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenbroker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+// expiryBuffer is how long before their expiry tokens are refreshed, so that
+// they don't expire while in use.
+const expiryBuffer = time.Minute
+
+type tokenCache struct {
+	brokerURL string
+	getSecret func() []byte
+	client    *http.Client
+	now       func() time.Time
+
+	lock   sync.Mutex
+	tokens map[string]TokenResponse
+}
+
+// ValidateURL checks that the broker is reached over TLS, with an https://
+// URL, or on a unix socket, with a unix:// URL holding the path of the socket.
+// Tokens must not be sent over plain HTTP.
+func ValidateURL(brokerURL string) error {
+	u, err := url.Parse(brokerURL)
+	if err != nil {
+		return fmt.Errorf("invalid token broker URL %q: %w", brokerURL, err)
+	}
+	switch {
+	case u.Scheme == "https" && u.Host != "":
+	case u.Scheme == "unix" && u.Path != "":
+	default:
+		return fmt.Errorf("token broker URL %q must be an https:// URL or a unix:// socket path", brokerURL)
+	}
+	return nil
+}
+
+// NewAppTokenSource returns a github.AppTokenSource that gets installation
+// tokens from the broker at brokerURL, authenticating with the secret
+// returned by getSecret. Tokens are cached until shortly before they expire.
+// See ValidateURL for the URLs of brokers.
+func NewAppTokenSource(brokerURL string, getSecret func() []byte) (github.AppTokenSource, error) {
+	if err := ValidateURL(brokerURL); err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	if socket, ok := strings.CutPrefix(brokerURL, "unix://"); ok {
+		client.Transport = &http.Transport{DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}}
+		// The host is ignored when dialing the socket.
+		brokerURL = "http://token-broker"
+	}
+	return &tokenCache{
+		brokerURL: strings.TrimSuffix(brokerURL, "/"),
+		getSecret: getSecret,
+		client:    client,
+		now:       time.Now,
+		tokens:    map[string]TokenResponse{},
+	}, nil
+}
+
+// NewTokenGenerator returns a github.TokenGenerator for git that gets its
+// tokens from the broker, see NewAppTokenSource.
+func NewTokenGenerator(brokerURL string, getSecret func() []byte) (github.TokenGenerator, error) {
+	source, err := NewAppTokenSource(brokerURL, getSecret)
+	if err != nil {
+		return nil, err
+	}
+	return func(org string) (string, error) {
+		token, _, err := source.InstallationToken(org)
+		return token, err
+	}, nil
+}
+
+// InstallationToken implements github.InstallationTokenSource.
+func (c *tokenCache) InstallationToken(org string) (string, time.Time, error) {
+	key := strings.ToLower(org)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if cached, ok := c.tokens[key]; ok && c.now().Add(expiryBuffer).Before(cached.ExpiresAt) {
+		return cached.Token, cached.ExpiresAt, nil
+	}
+	var token TokenResponse
+	if err := c.get(TokenPath+"?"+url.Values{"org": []string{org}}.Encode(), &token); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get a token for org %s: %w", org, err)
+	}
+	c.tokens[key] = token
+	return token.Token, token.ExpiresAt, nil
+}
+
+// GetApp implements github.AppTokenSource.
+func (c *tokenCache) GetApp() (*github.App, error) {
+	var app AppResponse
+	if err := c.get(AppPath, &app); err != nil {
+		return nil, fmt.Errorf("failed to get the app: %w", err)
+	}
+	return &github.App{Slug: app.Slug, Name: app.Name}, nil
+}
+
+func (c *tokenCache) get(path string, into interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.brokerURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+string(c.getSecret()))
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token broker responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, into); err != nil {
+		return fmt.Errorf("failed to unmarshal the response: %w", err)
+	}
+	return nil
+}
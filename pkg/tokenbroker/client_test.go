@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenbroker
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenGenerator(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	source := &fakeTokenSource{expiresAt: now.Add(10 * time.Minute)}
+	server := NewServer(source, []Client{{Name: "clonerefs", TokenPath: "token", Orgs: []string{"org"}}}, func(string) []byte { return []byte("secret") })
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	cache := &tokenCache{
+		brokerURL: ts.URL,
+		getSecret: func() []byte { return []byte("secret") },
+		client:    ts.Client(),
+		now:       func() time.Time { return now },
+		tokens:    map[string]TokenResponse{},
+	}
+
+	for i := 0; i < 2; i++ {
+		token, _, err := cache.InstallationToken("org")
+		if err != nil {
+			t.Fatalf("failed to get token: %v", err)
+		}
+		if token != "token-for-org" {
+			t.Errorf("expected token %q, got %q", "token-for-org", token)
+		}
+	}
+	if source.requests != 1 {
+		t.Errorf("expected the token to be requested once, got %d requests", source.requests)
+	}
+
+	// Tokens are refreshed shortly before they expire.
+	now = now.Add(9*time.Minute + time.Second)
+	if _, _, err := cache.InstallationToken("org"); err != nil {
+		t.Fatalf("failed to get token: %v", err)
+	}
+	if source.requests != 2 {
+		t.Errorf("expected the token to be refreshed, got %d requests", source.requests)
+	}
+
+	if _, _, err := cache.InstallationToken("other"); err == nil || !strings.Contains(err.Error(), "403 Forbidden") {
+		t.Errorf("expected a 403 error, got %v", err)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	testCases := []struct {
+		url         string
+		expectedErr bool
+	}{
+		{url: "https://token-broker"},
+		{url: "https://token-broker.prow.svc:8443/"},
+		{url: "unix:///var/run/token-broker/broker.sock"},
+		{url: "http://token-broker", expectedErr: true},
+		{url: "token-broker", expectedErr: true},
+		{url: "unix://", expectedErr: true},
+		{url: "https://", expectedErr: true},
+	}
+	for _, tc := range testCases {
+		if err := ValidateURL(tc.url); (err != nil) != tc.expectedErr {
+			t.Errorf("%s: expected error: %t, got: %v", tc.url, tc.expectedErr, err)
+		}
+	}
+}
+
+func TestAppTokenSourceOnUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "broker.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", socket, err)
+	}
+	server := NewServer(&fakeTokenSource{expiresAt: time.Now().Add(time.Hour)}, []Client{{Name: "hook", TokenPath: "token"}}, func(string) []byte { return []byte("secret") })
+	ts := &httptest.Server{Listener: listener, Config: &http.Server{Handler: server}}
+	ts.Start()
+	defer ts.Close()
+
+	source, err := NewAppTokenSource("unix://"+socket, func() []byte { return []byte("secret") })
+	if err != nil {
+		t.Fatalf("failed to create the token source: %v", err)
+	}
+	token, _, err := source.InstallationToken("org")
+	if err != nil {
+		t.Fatalf("failed to get token: %v", err)
+	}
+	if token != "token-for-org" {
+		t.Errorf("expected token %q, got %q", "token-for-org", token)
+	}
+	app, err := source.GetApp()
+	if err != nil {
+		t.Fatalf("failed to get the app: %v", err)
+	}
+	if app.Slug != "prow-bot" {
+		t.Errorf("expected app prow-bot, got %q", app.Slug)
+	}
+}
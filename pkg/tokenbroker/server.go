@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tokenbroker serves GitHub App installation tokens to components
+// and jobs, so that the private key of the app only needs to be available to
+// the broker.
+package tokenbroker
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+const (
+	// TokenPath is the path at which the broker serves tokens.
+	TokenPath = "/token"
+	// AppPath is the path at which the broker serves the GitHub App the
+	// tokens are minted for.
+	AppPath = "/app"
+)
+
+var tokenRequestsCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "token_broker_requests",
+		Help: "How many installation tokens were requested, by client and response code.",
+	},
+	[]string{"client", "code"},
+)
+
+func init() {
+	prometheus.MustRegister(tokenRequestsCounter)
+}
+
+// Client is a consumer of installation tokens.
+type Client struct {
+	// Name identifies the client in logs and metrics.
+	Name string `json:"name"`
+	// TokenPath is the path of the file holding the secret that the client
+	// authenticates with.
+	TokenPath string `json:"token_path"`
+	// Orgs are the orgs the client may get tokens for. Leave empty to allow
+	// all orgs the app is installed in.
+	Orgs []string `json:"orgs,omitempty"`
+}
+
+// Config is the configuration of the broker.
+type Config struct {
+	Clients []Client `json:"clients"`
+}
+
+// LoadConfig loads and validates the configuration at path.
+func LoadConfig(path string) (*Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(raw, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	return &config, config.Validate()
+}
+
+// Validate checks that every client has a unique name and a secret.
+func (c *Config) Validate() error {
+	names := sets.New[string]()
+	for i, client := range c.Clients {
+		if client.Name == "" {
+			return fmt.Errorf("client %d has no name", i)
+		}
+		if names.Has(client.Name) {
+			return fmt.Errorf("client %q is configured more than once", client.Name)
+		}
+		names.Insert(client.Name)
+		if client.TokenPath == "" {
+			return fmt.Errorf("client %q has no token_path", client.Name)
+		}
+	}
+	return nil
+}
+
+// TokenResponse is the body of a successful token request.
+type TokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AppResponse is the body of a successful app request.
+type AppResponse struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// Server is a http.Handler that serves installation tokens to the configured
+// clients. Clients send their secret as bearer token and name the org in the
+// org query parameter, e.g. GET /token?org=kubernetes. GET /app returns the
+// slug and name of the app, which clients need to identify themselves.
+type Server struct {
+	tokens    github.AppTokenSource
+	clients   []Client
+	getSecret func(path string) []byte
+}
+
+// NewServer returns a Server that mints tokens with the token source.
+// getSecret returns the current content of a client's token_path.
+func NewServer(tokens github.AppTokenSource, clients []Client, getSecret func(path string) []byte) *Server {
+	return &Server{tokens: tokens, clients: clients, getSecret: getSecret}
+}
+
+// authenticate returns the client whose secret the request carries.
+func (s *Server) authenticate(r *http.Request) (*Client, error) {
+	secret, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || secret == "" {
+		return nil, errors.New("no bearer token")
+	}
+	for i := range s.clients {
+		expected := s.getSecret(s.clients[i].TokenPath)
+		if len(expected) > 0 && subtle.ConstantTimeCompare(expected, []byte(secret)) == 1 {
+			return &s.clients[i], nil
+		}
+	}
+	return nil, errors.New("unknown bearer token")
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	clientName := "unknown"
+	respond := func(code int, body interface{}) {
+		tokenRequestsCounter.WithLabelValues(clientName, fmt.Sprint(code)).Inc()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			logrus.WithError(err).Warn("Failed to write response.")
+		}
+	}
+	fail := func(code int, message string) {
+		respond(code, map[string]string{"error": message})
+	}
+
+	if r.Method != http.MethodGet {
+		fail(http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+	client, err := s.authenticate(r)
+	if err != nil {
+		logrus.WithError(err).WithField("remote", r.RemoteAddr).Info("Rejected unauthenticated token request.")
+		fail(http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	clientName = client.Name
+	if r.URL.Path == AppPath {
+		app, err := s.tokens.GetApp()
+		if err != nil {
+			logrus.WithError(err).WithField("client", client.Name).Warn("Failed to get the app.")
+			fail(http.StatusBadGateway, "failed to get the app")
+			return
+		}
+		respond(http.StatusOK, AppResponse{Slug: app.Slug, Name: app.Name})
+		return
+	}
+	org := r.URL.Query().Get("org")
+	log := logrus.WithFields(logrus.Fields{"client": client.Name, "org": org})
+	if org == "" {
+		fail(http.StatusBadRequest, "the org query parameter is required")
+		return
+	}
+	if len(client.Orgs) > 0 && !containsFold(client.Orgs, org) {
+		log.Info("Rejected token request for an org the client may not access.")
+		fail(http.StatusForbidden, fmt.Sprintf("client %s may not get tokens for org %s", client.Name, org))
+		return
+	}
+	token, expiresAt, err := s.tokens.InstallationToken(org)
+	if err != nil {
+		log.WithError(err).Warn("Failed to get installation token.")
+		fail(http.StatusBadGateway, "failed to get an installation token")
+		return
+	}
+	log.WithField("expires-at", expiresAt).Debug("Served installation token.")
+	respond(http.StatusOK, TokenResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+func containsFold(orgs []string, org string) bool {
+	for _, candidate := range orgs {
+		if strings.EqualFold(candidate, org) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenbroker
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/prow/pkg/github"
+)
+
+type fakeTokenSource struct {
+	expiresAt time.Time
+	requests  int
+}
+
+func (f *fakeTokenSource) InstallationToken(org string) (string, time.Time, error) {
+	f.requests++
+	if org == "broken" {
+		return "", time.Time{}, errors.New("no installation")
+	}
+	return "token-for-" + org, f.expiresAt, nil
+}
+
+func (f *fakeTokenSource) GetApp() (*github.App, error) {
+	return &github.App{ID: 1, Slug: "prow-bot", Name: "Prow Bot"}, nil
+}
+
+func TestServeHTTP(t *testing.T) {
+	expiresAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	secrets := map[string][]byte{
+		"/secrets/deck":      []byte("deck-secret"),
+		"/secrets/clonerefs": []byte("clonerefs-secret"),
+		"/secrets/unset":     nil,
+	}
+	clients := []Client{
+		{Name: "deck", TokenPath: "/secrets/deck", Orgs: []string{"Kubernetes"}},
+		{Name: "clonerefs", TokenPath: "/secrets/clonerefs"},
+		{Name: "unset", TokenPath: "/secrets/unset"},
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		authorization string
+		org           string
+		expectedCode  int
+		expectedToken string
+	}{
+		{
+			name:          "client without allowlist gets a token",
+			authorization: "Bearer clonerefs-secret",
+			org:           "other",
+			expectedCode:  http.StatusOK,
+			expectedToken: "token-for-other",
+		},
+		{
+			name:          "allowlist is case insensitive",
+			authorization: "Bearer deck-secret",
+			org:           "kubernetes",
+			expectedCode:  http.StatusOK,
+			expectedToken: "token-for-kubernetes",
+		},
+		{
+			name:          "org outside of allowlist is forbidden",
+			authorization: "Bearer deck-secret",
+			org:           "other",
+			expectedCode:  http.StatusForbidden,
+		},
+		{
+			name:         "missing authorization is rejected",
+			org:          "other",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "unknown secret is rejected",
+			authorization: "Bearer guess",
+			org:           "other",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "empty secret doesn't match a client without secret",
+			authorization: "Bearer ",
+			org:           "other",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "org is required",
+			authorization: "Bearer clonerefs-secret",
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "only GET is allowed",
+			method:        http.MethodPost,
+			authorization: "Bearer clonerefs-secret",
+			org:           "other",
+			expectedCode:  http.StatusMethodNotAllowed,
+		},
+		{
+			name:          "token source errors are passed on",
+			authorization: "Bearer clonerefs-secret",
+			org:           "broken",
+			expectedCode:  http.StatusBadGateway,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := NewServer(&fakeTokenSource{expiresAt: expiresAt}, clients, func(path string) []byte { return secrets[path] })
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, TokenPath+"?org="+tc.org, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rr := httptest.NewRecorder()
+			server.ServeHTTP(rr, req)
+
+			if rr.Code != tc.expectedCode {
+				t.Fatalf("expected code %d, got %d: %s", tc.expectedCode, rr.Code, rr.Body.String())
+			}
+			if cacheControl := rr.Header().Get("Cache-Control"); cacheControl != "no-store" {
+				t.Errorf("expected Cache-Control no-store, got %q", cacheControl)
+			}
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+			var resp TokenResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if resp.Token != tc.expectedToken {
+				t.Errorf("expected token %q, got %q", tc.expectedToken, resp.Token)
+			}
+			if !resp.ExpiresAt.Equal(expiresAt) {
+				t.Errorf("expected expiry %v, got %v", expiresAt, resp.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestServeApp(t *testing.T) {
+	server := NewServer(&fakeTokenSource{}, []Client{{Name: "clonerefs", TokenPath: "token"}}, func(string) []byte { return []byte("secret") })
+	for _, authorization := range []string{"Bearer secret", "Bearer guess"} {
+		req := httptest.NewRequest(http.MethodGet, AppPath, nil)
+		req.Header.Set("Authorization", authorization)
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		if authorization != "Bearer secret" {
+			if rr.Code != http.StatusUnauthorized {
+				t.Errorf("expected an unknown secret to be rejected, got code %d", rr.Code)
+			}
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		var resp AppResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if expected := (AppResponse{Slug: "prow-bot", Name: "Prow Bot"}); resp != expected {
+			t.Errorf("expected app %+v, got %+v", expected, resp)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      string
+		expectedErr string
+	}{
+		{
+			name: "valid config",
+			config: `clients:
+- name: deck
+  token_path: /etc/deck/token
+  orgs:
+  - kubernetes
+- name: clonerefs
+  token_path: /etc/clonerefs/token
+`,
+		},
+		{
+			name: "duplicate client",
+			config: `clients:
+- name: deck
+  token_path: /etc/deck/token
+- name: deck
+  token_path: /etc/other/token
+`,
+			expectedErr: `client "deck" is configured more than once`,
+		},
+		{
+			name: "missing token path",
+			config: `clients:
+- name: deck
+`,
+			expectedErr: `client "deck" has no token_path`,
+		},
+		{
+			name: "unknown field",
+			config: `clients:
+- name: deck
+  token_path: /etc/deck/token
+  token: plaintext
+`,
+			expectedErr: `unknown field "token"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tc.config), 0600); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			_, err := LoadConfig(path)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected error containing %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing follows a ProwJob from the webhook that triggered it to the
+// report of its result. The trace context is carried in the W3C traceparent
+// format: in an annotation of the ProwJob and in the environment of its pod.
+// Spans are exported with OTLP over gRPC, e.g. to an OpenTelemetry Collector.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowv1 "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/interrupts"
+)
+
+const (
+	// TraceParentAnnotation is the annotation of a ProwJob holding the
+	// traceparent of the span that created it.
+	TraceParentAnnotation = "prow.k8s.io/traceparent"
+	// TraceParentEnv is the environment variable holding the traceparent
+	// in the containers of the pod of a ProwJob.
+	TraceParentEnv = "TRACEPARENT"
+
+	traceParentHeader = "traceparent"
+)
+
+// Init exports the spans of the component to the tracing endpoint, if any.
+// Without an endpoint the global tracer provider is left alone, so all spans
+// are no-ops and ProwJobs do not get a traceparent.
+func Init(component string, opts flagutil.InstrumentationOptions) error {
+	if opts.TracingEndpoint == "" {
+		return nil
+	}
+	exp, err := otlptracegrpc.New(context.Background(),
+		otlptracegrpc.WithInsecure(),
+		otlptracegrpc.WithEndpoint(opts.TracingEndpoint),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create the trace exporter: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(component))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.TracingSampleRate))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	interrupts.OnInterrupt(func() {
+		if err := provider.Shutdown(context.Background()); err != nil {
+			otel.Handle(err)
+		}
+	})
+	return nil
+}
+
+// Tracer returns the tracer Prow components start their spans with.
+func Tracer() trace.Tracer {
+	return otel.Tracer("sigs.k8s.io/prow")
+}
+
+// FormatTraceParent formats a span context as a traceparent. It is empty if
+// the span context is not valid.
+func FormatTraceParent(sc trace.SpanContext) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+	return carrier.Get(traceParentHeader)
+}
+
+// ParseTraceParent parses a traceparent into a span context.
+func ParseTraceParent(traceParent string) (trace.SpanContext, bool) {
+	// Versions after 00 may append fields, 00 has exactly four.
+	if strings.HasPrefix(traceParent, "00-") && strings.Count(traceParent, "-") != 3 {
+		return trace.SpanContext{}, false
+	}
+	carrier := propagation.MapCarrier{traceParentHeader: traceParent}
+	sc := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), carrier))
+	return sc, sc.IsValid()
+}
+
+// StartProwJobSpan starts a span in the trace of the ProwJob. The span is a
+// no-op if the ProwJob has no trace, e.g. because it was not created in
+// response to a webhook or tracing was off when it was.
+func StartProwJobSpan(ctx context.Context, pj *prowapi.ProwJob, name string) (context.Context, trace.Span) {
+	parent, ok := ParseTraceParent(pj.Annotations[TraceParentAnnotation])
+	if !ok {
+		return trace.NewNoopTracerProvider().Tracer("").Start(ctx, name)
+	}
+	return Tracer().Start(trace.ContextWithRemoteSpanContext(ctx, parent), name, trace.WithAttributes(
+		attribute.String("prowjob", pj.Name),
+		attribute.String("job", pj.Spec.Job),
+	))
+}
+
+// SetTraceParent records the span of the context as the parent of the spans
+// of the ProwJob. Nothing is recorded if the context has no valid span, e.g.
+// because tracing is off.
+func SetTraceParent(ctx context.Context, pj *prowapi.ProwJob) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	if pj.Annotations == nil {
+		pj.Annotations = map[string]string{}
+	}
+	pj.Annotations[TraceParentAnnotation] = FormatTraceParent(sc)
+}
+
+// SetPodTraceParent exposes the span of the context to the containers of the
+// pod in the TRACEPARENT environment variable, which OpenTelemetry SDKs read.
+func SetPodTraceParent(ctx context.Context, pod *corev1.Pod) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || !sc.IsSampled() {
+		return
+	}
+	env := corev1.EnvVar{Name: TraceParentEnv, Value: FormatTraceParent(sc)}
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			containers[i].Env = append(containers[i].Env, env)
+		}
+	}
+}
+
+// RecordProwJobSpan records a span of the trace of the ProwJob that already
+// ended, e.g. the lifetime of its pod. Nothing is recorded if the ProwJob has
+// no sampled trace.
+func RecordProwJobSpan(pj *prowapi.ProwJob, name string, start, end time.Time, attributes ...attribute.KeyValue) {
+	parent, ok := ParseTraceParent(pj.Annotations[TraceParentAnnotation])
+	if !ok || !parent.IsSampled() {
+		return
+	}
+	attributes = append(attributes, attribute.String("prowjob", pj.Name), attribute.String("job", pj.Spec.Job))
+	_, span := Tracer().Start(trace.ContextWithRemoteSpanContext(context.Background(), parent), name,
+		trace.WithTimestamp(start),
+		trace.WithAttributes(attributes...),
+	)
+	span.End(trace.WithTimestamp(end))
+}
+
+// ProwJobClient returns a client that creates ProwJobs in the trace of the
+// context, e.g. of the webhook the ProwJobs are created for. The client is
+// returned as is if the context has no valid span, e.g. because tracing is
+// off.
+func ProwJobClient(ctx context.Context, client prowv1.ProwJobInterface) prowv1.ProwJobInterface {
+	if client == nil || ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return client
+	}
+	return &prowJobClient{ProwJobInterface: client, ctx: ctx}
+}
+
+type prowJobClient struct {
+	prowv1.ProwJobInterface
+	ctx context.Context
+}
+
+func (c *prowJobClient) Create(ctx context.Context, pj *prowapi.ProwJob, opts metav1.CreateOptions) (*prowapi.ProwJob, error) {
+	spanCtx, span := Tracer().Start(c.ctx, "create-prowjob", trace.WithAttributes(attribute.String("job", pj.Spec.Job)))
+	defer span.End()
+	pj = pj.DeepCopy()
+	SetTraceParent(spanCtx, pj)
+	created, err := c.ProwJobInterface.Create(ctx, pj, opts)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return created, err
+}
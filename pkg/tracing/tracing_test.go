@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/client/clientset/versioned/fake"
+)
+
+const (
+	sampled   = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	unsampled = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		traceParent string
+		expectedOK  bool
+	}{
+		{name: "sampled", traceParent: sampled, expectedOK: true},
+		{name: "unsampled", traceParent: unsampled, expectedOK: true},
+		{name: "future version with more fields", traceParent: "01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra", expectedOK: true},
+		{name: "version 00 with more fields", traceParent: sampled + "-extra"},
+		{name: "invalid version", traceParent: "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+		{name: "short trace ID", traceParent: "00-0af7651916cd43dd-b7ad6b7169203331-01"},
+		{name: "zero span ID", traceParent: "00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01"},
+		{name: "not hex", traceParent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b716920333z-01"},
+		{name: "empty"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			sc, ok := ParseTraceParent(tc.traceParent)
+			if ok != tc.expectedOK {
+				t.Fatalf("expected ok to be %t, got %t", tc.expectedOK, ok)
+			}
+			if ok && tc.traceParent[:2] == "00" {
+				if formatted := FormatTraceParent(sc); formatted != tc.traceParent {
+					t.Errorf("expected %q to round-trip, got %q", tc.traceParent, formatted)
+				}
+			}
+		})
+	}
+}
+
+func TestStartProwJobSpan(t *testing.T) {
+	var testCases = []struct {
+		name            string
+		annotations     map[string]string
+		expectedSampled bool
+		expectedTraceID string
+	}{
+		{
+			name:            "sampled trace is continued",
+			annotations:     map[string]string{TraceParentAnnotation: sampled},
+			expectedSampled: true,
+			expectedTraceID: "0af7651916cd43dd8448eb211c80319c",
+		},
+		{
+			name:            "unsampled trace is continued",
+			annotations:     map[string]string{TraceParentAnnotation: unsampled},
+			expectedTraceID: "0af7651916cd43dd8448eb211c80319c",
+		},
+		{
+			name: "ProwJob without trace is not traced",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pj := &prowapi.ProwJob{ObjectMeta: metav1.ObjectMeta{Name: "pj", Annotations: tc.annotations}}
+			ctx, span := StartProwJobSpan(context.Background(), pj, "span")
+			defer span.End()
+			sc := span.SpanContext()
+			if sc.IsSampled() != tc.expectedSampled {
+				t.Errorf("expected sampled to be %t, got %t", tc.expectedSampled, sc.IsSampled())
+			}
+			if tc.expectedTraceID != "" && sc.TraceID().String() != tc.expectedTraceID {
+				t.Errorf("expected trace ID %s, got %s", tc.expectedTraceID, sc.TraceID())
+			}
+
+			pod := &corev1.Pod{Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers:     []corev1.Container{{Name: "test"}, {Name: "sidecar"}},
+			}}
+			SetPodTraceParent(ctx, pod)
+			for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				var traceParent string
+				for _, env := range container.Env {
+					if env.Name == TraceParentEnv {
+						traceParent = env.Value
+					}
+				}
+				if expected := map[bool]string{true: FormatTraceParent(sc)}[tc.expectedSampled]; traceParent != expected {
+					t.Errorf("expected container %s to get traceparent %q, got %q", container.Name, expected, traceParent)
+				}
+			}
+		})
+	}
+}
+
+// withRecorder records the spans of the test instead of dropping them.
+func withRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestProwJobClient(t *testing.T) {
+	recorder := withRecorder(t)
+	ctx, span := Tracer().Start(context.Background(), "webhook")
+	defer span.End()
+	clientset := fake.NewSimpleClientset()
+	client := ProwJobClient(ctx, clientset.ProwV1().ProwJobs("prowjobs"))
+
+	pj := &prowapi.ProwJob{ObjectMeta: metav1.ObjectMeta{Name: "pj"}, Spec: prowapi.ProwJobSpec{Job: "job"}}
+	created, err := client.Create(context.Background(), pj, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to create the ProwJob: %v", err)
+	}
+	if pj.Annotations != nil {
+		t.Errorf("expected the ProwJob passed to Create to be left alone, got annotations %v", pj.Annotations)
+	}
+	parent, ok := ParseTraceParent(created.Annotations[TraceParentAnnotation])
+	if !ok {
+		t.Fatalf("expected the created ProwJob to have a traceparent, got annotations %v", created.Annotations)
+	}
+	if parent.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("expected the ProwJob to be in trace %s, got %s", span.SpanContext().TraceID(), parent.TraceID())
+	}
+	if parent.SpanID() == span.SpanContext().SpanID() {
+		t.Error("expected the ProwJob to be created in a child span of the webhook")
+	}
+	if ended := recorder.Ended(); len(ended) != 1 || ended[0].Name() != "create-prowjob" {
+		t.Errorf("expected the create-prowjob span to end, got %v", ended)
+	}
+
+	if _, ok := ProwJobClient(context.Background(), clientset.ProwV1().ProwJobs("prowjobs")).(*prowJobClient); ok {
+		t.Error("expected a context without span not to wrap the client")
+	}
+}
+
+func TestProwJobClientWithoutTracing(t *testing.T) {
+	ctx, span := trace.NewNoopTracerProvider().Tracer("").Start(context.Background(), "webhook")
+	defer span.End()
+	clientset := fake.NewSimpleClientset()
+	client := ProwJobClient(ctx, clientset.ProwV1().ProwJobs("prowjobs"))
+	if _, ok := client.(*prowJobClient); ok {
+		t.Error("expected the client not to be wrapped when tracing is off")
+	}
+	pj := &prowapi.ProwJob{ObjectMeta: metav1.ObjectMeta{Name: "pj"}, Spec: prowapi.ProwJobSpec{Job: "job"}}
+	created, err := client.Create(context.Background(), pj, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to create the ProwJob: %v", err)
+	}
+	if _, ok := created.Annotations[TraceParentAnnotation]; ok {
+		t.Errorf("expected no traceparent when tracing is off, got annotations %v", created.Annotations)
+	}
+}
+
+func TestRecordProwJobSpan(t *testing.T) {
+	recorder := withRecorder(t)
+
+	start, end := time.Unix(100, 0), time.Unix(200, 0)
+	for _, traceParent := range []string{sampled, unsampled, ""} {
+		pj := &prowapi.ProwJob{ObjectMeta: metav1.ObjectMeta{Name: "pj", Annotations: map[string]string{TraceParentAnnotation: traceParent}}}
+		RecordProwJobSpan(pj, "pod", start, end, attribute.String("state", "success"))
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected only the sampled trace to get a span, got %d spans", len(spans))
+	}
+	span := spans[0]
+	parent, _ := ParseTraceParent(sampled)
+	if span.SpanContext().TraceID() != parent.TraceID() || span.Parent().SpanID() != parent.SpanID() || span.SpanContext().SpanID() == parent.SpanID() {
+		t.Errorf("expected a child span of %s, got span %s with parent %s", sampled, FormatTraceParent(span.SpanContext()), span.Parent().SpanID())
+	}
+	if !span.StartTime().Equal(start) || !span.EndTime().Equal(end) {
+		t.Errorf("expected the span to last from %v to %v, got %v to %v", start, end, span.StartTime(), span.EndTime())
+	}
+	attributes := map[attribute.Key]string{}
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value.Emit()
+	}
+	if attributes["state"] != "success" || attributes["prowjob"] != "pj" {
+		t.Errorf("unexpected attributes %v", attributes)
+	}
+}